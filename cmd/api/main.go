@@ -19,10 +19,15 @@ import (
 
 	"github.com/google/uuid"
 
+	"erpgo/internal/application/jobs"
 	emailsvc "erpgo/internal/application/services/email"
 	"erpgo/internal/application/services/inventory"
+	"erpgo/internal/application/services/order"
 	"erpgo/internal/application/services/product"
+	"erpgo/internal/application/services/purchasing"
+	"erpgo/internal/application/services/transfer"
 	"erpgo/internal/application/services/user"
+	orderEntities "erpgo/internal/domain/orders/entities"
 	"erpgo/internal/domain/users/entities"
 	"erpgo/internal/domain/users/repositories"
 	infrarepos "erpgo/internal/infrastructure/repositories"
@@ -111,19 +116,41 @@ func main() {
 	variantAttrRepo := infrarepos.NewPostgresVariantAttributeRepository(db)
 	variantImageRepo := infrarepos.NewPostgresVariantImageRepository(db)
 
-	// TODO: Uncomment when order services are implemented
 	// Initialize order repositories
-	// orderRepo := infrarepos.NewPostgresOrderRepository(db)
-	// orderItemRepo := infrarepos.NewPostgresOrderItemRepository(db)
-	// customerRepo := infrarepos.NewPostgresCustomerRepository(db)
-	// addressRepo := infrarepos.NewPostgresOrderAddressRepository(db)
-	// companyRepo := infrarepos.NewPostgresCompanyRepository(db)
-	// orderAnalyticsRepo := infrarepos.NewPostgresOrderAnalyticsRepository(db)
+	orderRepo := infrarepos.NewPostgresOrderRepository(db)
+	orderItemRepo := infrarepos.NewPostgresOrderItemRepository(db)
+	orderHistoryRepo := infrarepos.NewPostgresOrderStatusHistoryRepository(db)
+	shipmentRepo := infrarepos.NewPostgresShipmentRepository(db)
+	backorderRepo := infrarepos.NewPostgresBackorderRepository(db)
+	paymentRepo := infrarepos.NewPostgresPaymentRepository(db)
+	invoiceRepo := infrarepos.NewPostgresInvoiceRepository(db)
+	taxZoneRepo := infrarepos.NewPostgresTaxZoneRepository(db)
+	taxExemptionRepo := infrarepos.NewPostgresTaxExemptionRepository(db)
+	promotionRepo := infrarepos.NewPostgresPromotionRepository(db)
+	approvalRepo := infrarepos.NewPostgresApprovalRepository(db)
+	shippingZoneRepo := infrarepos.NewPostgresShippingZoneRepository(db)
+	exchangeRateRepo := infrarepos.NewPostgresExchangeRateRepository(db)
+	quotationRepo := infrarepos.NewPostgresQuotationRepository(db)
+	recurringOrderRepo := infrarepos.NewPostgresRecurringOrderRepository(db)
+	orderImportRepo := infrarepos.NewPostgresOrderImportRepository(db)
+	returnRepo := infrarepos.NewPostgresReturnAuthorizationRepository(db)
+	pickWaveRepo := infrarepos.NewPostgresPickWaveRepository(db)
+	sourcingRepo := infrarepos.NewPostgresSourcingRepository(db)
+	revisionRepo := infrarepos.NewPostgresOrderRevisionRepository(db)
+	archiveRepo := infrarepos.NewPostgresOrderArchiveRepository(db)
+	customerRepo := infrarepos.NewPostgresCustomerRepository(db)
+	addressRepo := infrarepos.NewPostgresOrderAddressRepository(db)
 
 	// Initialize inventory repositories
 	inventoryRepo := infrarepos.NewPostgresInventoryRepository(db)
 	warehouseRepo := infrarepos.NewPostgresWarehouseRepository(db)
 	transactionRepo := infrarepos.NewPostgresInventoryTransactionRepository(db)
+	transferOrderRepo := infrarepos.NewPostgresTransferOrderRepository(db)
+
+	// Initialize purchasing repositories
+	supplierRepo := infrarepos.NewPostgresSupplierRepository(db)
+	purchaseOrderRepo := infrarepos.NewPostgresPurchaseOrderRepository(db)
+	goodsReceiptRepo := infrarepos.NewPostgresGoodsReceiptRepository(db)
 
 	// Create default roles if they don't exist
 	// TODO: Implement proper role repository and uncomment
@@ -167,34 +194,155 @@ func main() {
 	// Initialize product service
 	productService := product.NewService(productRepo, categoryRepo, variantRepo, variantAttrRepo, variantImageRepo)
 
+	// Initialize backorder service; it allocates stock added by inventory adjustments and goods receipts
+	backorderNotifier := order.NewEmailBackorderNotifier(smtpSvc)
+	backorderService := order.NewBackorderService(backorderRepo, orderRepo, orderItemRepo, customerRepo, sourcingRepo, inventoryRepo, backorderNotifier, txManager, log)
+
 	// Initialize inventory service
-	inventoryService := inventory.NewService(inventoryRepo, warehouseRepo, transactionRepo, txManager, log)
-
-	// Initialize order service (with some dependencies still nil)
-	// TODO: Implement notification, payment, tax, and shipping services
-	// TODO: Fix order service compilation issues
-	/*
-		orderService := order.NewService(
-			orderRepo,
-			orderItemRepo,
-			customerRepo,
-			addressRepo,
-			companyRepo,
-			orderAnalyticsRepo,
-			nil, // productService - will need adapter or direct repo access
-			inventoryService, // inventoryService - now available
-			nil, // userService - will need adapter or direct repo access
-			nil, // notificationService
-			nil, // paymentService
-			nil, // taxCalculator
-			nil, // shippingCalculator
-		)
-	*/
+	inventoryService := inventory.NewService(inventoryRepo, warehouseRepo, transactionRepo, transferOrderRepo, backorderService, txManager, log)
+
+	// Initialize order service; orders are taxed and their shipping priced by
+	// the zones of their shipping address, and booked into the base currency
+	taxCalculator := order.NewRuleTaxCalculator(taxZoneRepo, taxExemptionRepo)
+	shippingRates := order.NewZoneShippingRateCalculator(shippingZoneRepo)
+	currencies := order.NewTableCurrencyConverter(exchangeRateRepo, cfg.BaseCurrency)
+	orderService := order.NewService(
+		orderRepo,
+		orderItemRepo,
+		orderHistoryRepo,
+		shipmentRepo,
+		backorderRepo,
+		paymentRepo,
+		invoiceRepo,
+		promotionRepo,
+		approvalRepo,
+		orderImportRepo,
+		sourcingRepo,
+		revisionRepo,
+		archiveRepo,
+		customerRepo,
+		addressRepo,
+		productRepo,
+		warehouseRepo,
+		inventoryRepo,
+		transactionRepo,
+		purchaseOrderRepo,
+		supplierRepo,
+		backorderNotifier,
+		roleRepo,
+		taxCalculator,
+		shippingRates,
+		currencies,
+		txManager,
+		log,
+	)
+
+	// Initialize quotation service
+	quotationService := order.NewQuotationService(quotationRepo, customerRepo, addressRepo, productRepo, orderService, cfg.BaseCurrency, txManager, log)
+
+	// Initialize recurring order service
+	recurringOrderNotifier := order.NewEmailRecurringOrderNotifier(smtpSvc)
+	recurringOrderService := order.NewRecurringOrderService(recurringOrderRepo, customerRepo, addressRepo, productRepo, orderService, recurringOrderNotifier, log)
+
+	// Initialize order import profile service
+	orderImportProfileService := order.NewOrderImportProfileService(orderImportRepo, log)
+
+	// Initialize return service
+	returnService := order.NewReturnService(returnRepo, productRepo, warehouseRepo, inventoryRepo, transactionRepo, orderService, txManager, log)
+
+	// Initialize sourcing rule service
+	sourcingRuleService := order.NewSourcingRuleService(sourcingRepo, log)
+
+	// Initialize pick wave service
+	pickWaveService := order.NewPickWaveService(pickWaveRepo, orderRepo, orderItemRepo, backorderRepo, sourcingRepo, productRepo, warehouseRepo, inventoryRepo, transactionRepo, orderService, txManager, log)
+
+	// Initialize invoice service
+	invoiceService := order.NewInvoiceService(invoiceRepo, shipmentRepo, customerRepo, orderService, taxCalculator, txManager, log)
+
+	// Initialize tax service
+	taxService := order.NewTaxService(taxZoneRepo, taxExemptionRepo, customerRepo, log)
+
+	// Initialize shipping service
+	shippingService := order.NewShippingService(shippingZoneRepo, log)
+
+	// Initialize exchange rate service
+	exchangeRateService := order.NewExchangeRateService(exchangeRateRepo, currencies, log)
+
+	// Initialize promotion service
+	promotionService := order.NewPromotionService(promotionRepo, log)
+
+	// Initialize approval policy service
+	approvalPolicyService := order.NewApprovalPolicyService(approvalRepo, log)
+
+	// Initialize purchasing service
+	purchasingService := purchasing.NewService(
+		supplierRepo,
+		purchaseOrderRepo,
+		goodsReceiptRepo,
+		productRepo,
+		warehouseRepo,
+		inventoryRepo,
+		transactionRepo,
+		backorderService,
+		txManager,
+		log,
+	)
+
+	// Initialize transfer order service
+	transferService := transfer.NewService(
+		transferOrderRepo,
+		productRepo,
+		warehouseRepo,
+		inventoryRepo,
+		transactionRepo,
+		backorderService,
+		txManager,
+		log,
+	)
+
+	// Initialize background jobs
+	jobScheduler := jobs.NewScheduler(log)
+	if err := jobScheduler.Register(jobs.NewQuotationExpiryJob(quotationService, time.Hour)); err != nil {
+		log.Fatal().Err(err).Msg("Failed to register quotation expiry job")
+	}
+	if err := jobScheduler.Register(jobs.NewOverdueOrdersJob(orderService, time.Hour)); err != nil {
+		log.Fatal().Err(err).Msg("Failed to register overdue orders job")
+	}
+	if err := jobScheduler.Register(jobs.NewRecurringOrdersJob(recurringOrderService, time.Hour)); err != nil {
+		log.Fatal().Err(err).Msg("Failed to register recurring orders job")
+	}
+	if cfg.OrderArchiveAfterMonths > 0 {
+		archivePolicy := orderEntities.OrderArchivePolicy{
+			AfterMonths: cfg.OrderArchiveAfterMonths,
+			BatchSize:   cfg.OrderArchiveBatchSize,
+		}
+		if err := jobScheduler.Register(jobs.NewOrderArchiveJob(orderService, archivePolicy, 24*time.Hour)); err != nil {
+			log.Fatal().Err(err).Msg("Failed to register order archive job")
+		}
+	}
 
 	// Initialize handlers
 	authHandler := handlers.NewAuthHandler(userService, *log)
 	productHandler := handlers.NewProductHandler(productService, *log)
-	// orderHandler := handlers.NewOrderHandler(orderService, *log) // TODO: Fix order service
+	orderHandler := handlers.NewOrderHandler(orderService, *log)
+	quotationHandler := handlers.NewQuotationHandler(quotationService, *log)
+	recurringOrderHandler := handlers.NewRecurringOrderHandler(recurringOrderService, *log)
+	orderImportHandler := handlers.NewOrderImportHandler(orderService, orderImportProfileService, *log)
+	returnHandler := handlers.NewReturnHandler(returnService, *log)
+	pickWaveHandler := handlers.NewPickWaveHandler(pickWaveService, *log)
+	sourcingRuleHandler := handlers.NewSourcingRuleHandler(sourcingRuleService, *log)
+	backorderHandler := handlers.NewBackorderHandler(backorderService, *log)
+	paymentHandler := handlers.NewPaymentHandler(orderService, *log)
+	invoiceHandler := handlers.NewInvoiceHandler(invoiceService, *log)
+	taxHandler := handlers.NewTaxHandler(taxService, *log)
+	promotionHandler := handlers.NewPromotionHandler(promotionService, *log)
+	approvalPolicyHandler := handlers.NewApprovalPolicyHandler(approvalPolicyService, *log)
+	shippingHandler := handlers.NewShippingHandler(shippingService, *log)
+	exchangeRateHandler := handlers.NewExchangeRateHandler(exchangeRateService, *log)
+	supplierHandler := handlers.NewSupplierHandler(purchasingService, *log)
+	purchaseOrderHandler := handlers.NewPurchaseOrderHandler(purchasingService, *log)
+	transferOrderHandler := handlers.NewTransferOrderHandler(transferService, *log)
+	dropShipHandler := handlers.NewDropShipHandler(orderService, *log)
 	inventoryHandler := handlers.NewInventoryHandler(inventoryService, *log)
 	warehouseHandler := handlers.NewWarehouseHandler(nil, *log)              // TODO: Create warehouseService
 	transactionHandler := handlers.NewInventoryTransactionHandler(nil, *log) // TODO: Create transactionService
@@ -221,7 +369,7 @@ func main() {
 	router.Use(securityMiddleware)
 
 	// Setup routes
-	routes.SetupRoutes(router, authHandler, productHandler, inventoryHandler, warehouseHandler, transactionHandler, cfg, *log)
+	routes.SetupRoutes(router, authHandler, productHandler, inventoryHandler, warehouseHandler, transactionHandler, orderHandler, quotationHandler, recurringOrderHandler, orderImportHandler, returnHandler, pickWaveHandler, sourcingRuleHandler, backorderHandler, paymentHandler, invoiceHandler, taxHandler, promotionHandler, approvalPolicyHandler, shippingHandler, exchangeRateHandler, supplierHandler, purchaseOrderHandler, transferOrderHandler, dropShipHandler, roleRepo, jwtSvc, cfg, *log)
 
 	// Setup Swagger documentation routes with configuration
 	// Configure Swagger UI with authentication support
@@ -355,6 +503,15 @@ func main() {
 		log.Fatal().Err(err).Msg("Failed to register security coordinator shutdown hook")
 	}
 
+	// Priority 1: Stop background jobs before their connections are closed
+	jobsHook := shutdown.NewGenericHook("background-jobs", 1, jobScheduler.Stop, log)
+	if err := shutdownMgr.RegisterHook(jobsHook); err != nil {
+		log.Fatal().Err(err).Msg("Failed to register background jobs shutdown hook")
+	}
+
+	// Start background jobs
+	jobScheduler.Start(context.Background())
+
 	// Start server in a goroutine
 	go func() {
 		log.Info().Int("port", cfg.ServerPort).Msg("Starting HTTP server")
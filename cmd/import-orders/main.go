@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"erpgo/internal/interfaces/http/dto"
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	// Parse command line flags
+	var (
+		apiURL    = flag.String("url", "", "API base URL (uses API_URL env if empty)")
+		token     = flag.String("token", "", "Access token (uses API_TOKEN env if empty)")
+		profileID = flag.String("profile", "", "Order import profile ID")
+		filePath  = flag.String("file", "", "CSV or JSON order file")
+		dryRun    = flag.Bool("dry-run", false, "Validate the file without creating orders")
+		jsonOut   = flag.Bool("json", false, "Output the import report as JSON")
+		help      = flag.Bool("help", false, "Show help")
+	)
+	flag.Parse()
+
+	if *help {
+		fmt.Println("Order Importer for ERPGo")
+		fmt.Println("")
+		fmt.Println("Imports a partner's CSV or JSON order file through an order import profile.")
+		fmt.Println("Nothing is created when any row has errors; the errors are listed per row.")
+		fmt.Println("External references imported before are skipped.")
+		fmt.Println("")
+		fmt.Println("Usage:")
+		fmt.Println("  go run ./cmd/import-orders -profile <id> -file <path> [flags]")
+		fmt.Println("")
+		fmt.Println("Flags:")
+		flag.PrintDefaults()
+		fmt.Println("")
+		fmt.Println("Examples:")
+		fmt.Println("  go run ./cmd/import-orders -profile 550e8400-e29b-41d4-a716-446655440000 -file orders.csv -dry-run")
+		fmt.Println("  go run ./cmd/import-orders -url https://erp.example.com -token $TOKEN -profile 550e8400-e29b-41d4-a716-446655440000 -file orders.json -json")
+		os.Exit(0)
+	}
+
+	// Load environment variables
+	if err := godotenv.Load(); err != nil {
+		log.Println("Warning: Could not load .env file")
+	}
+
+	if *apiURL == "" {
+		*apiURL = os.Getenv("API_URL")
+		if *apiURL == "" {
+			*apiURL = "http://localhost:8080"
+		}
+	}
+	if *token == "" {
+		*token = os.Getenv("API_TOKEN")
+	}
+
+	if *profileID == "" || *filePath == "" {
+		log.Fatalf("Both -profile and -file are required (see -help)")
+	}
+	if *token == "" {
+		log.Fatalf("An access token is required: pass -token or set API_TOKEN")
+	}
+
+	// Build the multipart upload
+	file, err := os.Open(*filePath)
+	if err != nil {
+		log.Fatalf("Failed to open order file: %v", err)
+	}
+	defer file.Close()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if err := writer.WriteField("profile_id", *profileID); err != nil {
+		log.Fatalf("Failed to build request: %v", err)
+	}
+	if err := writer.WriteField("dry_run", strconv.FormatBool(*dryRun)); err != nil {
+		log.Fatalf("Failed to build request: %v", err)
+	}
+	part, err := writer.CreateFormFile("file", filepath.Base(*filePath))
+	if err != nil {
+		log.Fatalf("Failed to build request: %v", err)
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		log.Fatalf("Failed to read order file: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		log.Fatalf("Failed to build request: %v", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, strings.TrimRight(*apiURL, "/")+"/api/v1/order-imports", &body)
+	if err != nil {
+		log.Fatalf("Failed to build request: %v", err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+*token)
+
+	client := &http.Client{Timeout: 5 * time.Minute}
+	resp, err := client.Do(req)
+	if err != nil {
+		log.Fatalf("Failed to import orders: %v", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		log.Fatalf("Failed to read response: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		var apiErr dto.ErrorResponse
+		if err := json.Unmarshal(respBody, &apiErr); err == nil && apiErr.Error != "" {
+			log.Fatalf("Import failed (%d): %s: %s", resp.StatusCode, apiErr.Error, apiErr.Details)
+		}
+		log.Fatalf("Import failed (%d): %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	var report dto.ImportOrdersResponse
+	if err := json.Unmarshal(respBody, &report); err != nil {
+		log.Fatalf("Failed to parse import report: %v", err)
+	}
+
+	if *jsonOut {
+		jsonData, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			log.Fatalf("Failed to marshal JSON: %v", err)
+		}
+		fmt.Println(string(jsonData))
+	} else {
+		printReport(&report)
+	}
+
+	if len(report.Errors) > 0 {
+		os.Exit(1)
+	}
+}
+
+// printReport prints a human-readable import report
+func printReport(report *dto.ImportOrdersResponse) {
+	fmt.Println("=== ORDER IMPORT ===")
+	fmt.Printf("Profile: %s (%s)\n", report.ProfileID, report.Source)
+	fmt.Printf("Rows: %d\n", report.TotalRows)
+	fmt.Printf("Orders: %d\n", report.TotalOrders)
+	switch {
+	case report.DryRun:
+		fmt.Println("Mode: dry run, no orders created")
+	case report.Committed:
+		fmt.Println("Mode: committed")
+	default:
+		fmt.Println("Mode: not committed")
+	}
+	fmt.Println("")
+
+	if len(report.Created) > 0 {
+		if report.Committed {
+			fmt.Printf("CREATED (%d):\n", len(report.Created))
+		} else {
+			fmt.Printf("TO CREATE (%d):\n", len(report.Created))
+		}
+		for _, imported := range report.Created {
+			fmt.Printf("  %s", imported.ExternalReference)
+			if imported.OrderNumber != "" {
+				fmt.Printf(" -> %s", imported.OrderNumber)
+			}
+			if imported.TotalAmount != nil {
+				fmt.Printf(" %s %s", imported.TotalAmount.StringFixed(2), imported.Currency)
+			}
+			fmt.Printf(" (rows %s)\n", joinRows(imported.Rows))
+		}
+		fmt.Println("")
+	}
+
+	if len(report.Skipped) > 0 {
+		fmt.Printf("SKIPPED, ALREADY IMPORTED (%d):\n", len(report.Skipped))
+		for _, imported := range report.Skipped {
+			fmt.Printf("  %s", imported.ExternalReference)
+			if imported.OrderNumber != "" {
+				fmt.Printf(" -> %s", imported.OrderNumber)
+			}
+			fmt.Println("")
+		}
+		fmt.Println("")
+	}
+
+	if len(report.Errors) > 0 {
+		fmt.Printf("ERRORS (%d):\n", len(report.Errors))
+		for _, importErr := range report.Errors {
+			location := fmt.Sprintf("row %d", importErr.Row)
+			if importErr.Item > 0 {
+				location += fmt.Sprintf(" item %d", importErr.Item)
+			}
+			if importErr.ExternalReference != "" {
+				location += " [" + importErr.ExternalReference + "]"
+			}
+			if importErr.Field != "" {
+				location += " " + importErr.Field
+			}
+			fmt.Printf("  %s: %s\n", location, importErr.Message)
+		}
+	}
+}
+
+func joinRows(rows []int) string {
+	values := make([]string, len(rows))
+	for i, row := range rows {
+		values[i] = strconv.Itoa(row)
+	}
+	return strings.Join(values, ", ")
+}
@@ -0,0 +1,24 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"erpgo/internal/application/services/order"
+	"erpgo/internal/domain/orders/entities"
+)
+
+// OrderArchiveJobName identifies the closed order archiving sweep
+const OrderArchiveJobName = "order-archive"
+
+// NewOrderArchiveJob returns a job that moves closed orders older than the policy allows into the archive
+func NewOrderArchiveJob(orderService order.Service, policy entities.OrderArchivePolicy, interval time.Duration) Job {
+	return Job{
+		Name:     OrderArchiveJobName,
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			_, err := orderService.ArchiveClosedOrders(ctx, policy, time.Now().UTC())
+			return err
+		},
+	}
+}
@@ -0,0 +1,24 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"erpgo/internal/application/services/order"
+)
+
+// OverdueOrdersJobName identifies the overdue receivables sweep
+const OverdueOrdersJobName = "overdue-orders"
+
+// NewOverdueOrdersJob returns a job that flags orders unpaid past the due date of their payment terms
+func NewOverdueOrdersJob(orderService order.Service, interval time.Duration) Job {
+	return Job{
+		Name:       OverdueOrdersJobName,
+		Interval:   interval,
+		RunOnStart: true,
+		Run: func(ctx context.Context) error {
+			_, err := orderService.MarkOverdueOrders(ctx, time.Now().UTC())
+			return err
+		},
+	}
+}
@@ -0,0 +1,24 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"erpgo/internal/application/services/order"
+)
+
+// QuotationExpiryJobName identifies the quotation expiry sweep
+const QuotationExpiryJobName = "quotation-expiry"
+
+// NewQuotationExpiryJob returns a job that closes open quotations past their validity date
+func NewQuotationExpiryJob(quotationService order.QuotationService, interval time.Duration) Job {
+	return Job{
+		Name:       QuotationExpiryJobName,
+		Interval:   interval,
+		RunOnStart: true,
+		Run: func(ctx context.Context) error {
+			_, err := quotationService.ExpireQuotations(ctx, time.Now().UTC())
+			return err
+		},
+	}
+}
@@ -0,0 +1,24 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"erpgo/internal/application/services/order"
+)
+
+// RecurringOrdersJobName identifies the recurring order generation sweep
+const RecurringOrdersJobName = "recurring-orders"
+
+// NewRecurringOrdersJob returns a job that generates the orders of recurring orders that are due
+func NewRecurringOrdersJob(recurringOrderService order.RecurringOrderService, interval time.Duration) Job {
+	return Job{
+		Name:       RecurringOrdersJobName,
+		Interval:   interval,
+		RunOnStart: true,
+		Run: func(ctx context.Context) error {
+			_, err := recurringOrderService.GenerateDueOrders(ctx, time.Now().UTC())
+			return err
+		},
+	}
+}
@@ -0,0 +1,151 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Job is a unit of background work run on a fixed interval
+type Job struct {
+	Name     string
+	Interval time.Duration
+	// RunOnStart runs the job once immediately when the scheduler starts
+	RunOnStart bool
+	Run        func(ctx context.Context) error
+}
+
+// Scheduler runs registered jobs on their intervals until stopped.
+// A job never overlaps with itself; a run that outlasts its interval
+// delays the next run instead.
+type Scheduler struct {
+	jobs   []Job
+	logger *zerolog.Logger
+
+	mu      sync.Mutex
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	running bool
+}
+
+// NewScheduler creates a new job scheduler
+func NewScheduler(logger *zerolog.Logger) *Scheduler {
+	return &Scheduler{
+		logger: logger,
+	}
+}
+
+// Register adds a job to the scheduler. Jobs must be registered before Start.
+func (s *Scheduler) Register(job Job) error {
+	if job.Name == "" {
+		return fmt.Errorf("job name cannot be empty")
+	}
+	if job.Interval <= 0 {
+		return fmt.Errorf("job %s: interval must be positive", job.Name)
+	}
+	if job.Run == nil {
+		return fmt.Errorf("job %s: run function is required", job.Name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running {
+		return fmt.Errorf("job %s: scheduler is already running", job.Name)
+	}
+	for _, existing := range s.jobs {
+		if existing.Name == job.Name {
+			return fmt.Errorf("job %s is already registered", job.Name)
+		}
+	}
+
+	s.jobs = append(s.jobs, job)
+	return nil
+}
+
+// Start runs every registered job in its own goroutine
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running {
+		return
+	}
+
+	ctx, s.cancel = context.WithCancel(ctx)
+	s.running = true
+
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, job)
+	}
+
+	s.logger.Info().Int("jobs", len(s.jobs)).Msg("Background job scheduler started")
+}
+
+// Stop cancels all jobs and waits for running jobs to finish or for ctx to end
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return nil
+	}
+	s.cancel()
+	s.running = false
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.logger.Info().Msg("Background job scheduler stopped")
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("timed out waiting for background jobs: %w", ctx.Err())
+	}
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	if job.RunOnStart {
+		s.run(ctx, job)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.run(ctx, job)
+		}
+	}
+}
+
+// run executes one run of a job, logging failures and recovering panics so a
+// failing job does not stop the scheduler
+func (s *Scheduler) run(ctx context.Context, job Job) {
+	start := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.Error().Str("job", job.Name).Interface("panic", r).Msg("Background job panicked")
+		}
+	}()
+
+	if err := job.Run(ctx); err != nil {
+		s.logger.Error().Err(err).Str("job", job.Name).Dur("duration", time.Since(start)).Msg("Background job failed")
+		return
+	}
+
+	s.logger.Debug().Str("job", job.Name).Dur("duration", time.Since(start)).Msg("Background job completed")
+}
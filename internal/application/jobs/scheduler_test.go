@@ -0,0 +1,64 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduler_Register(t *testing.T) {
+	logger := zerolog.Nop()
+	scheduler := NewScheduler(&logger)
+	noop := func(ctx context.Context) error { return nil }
+
+	require.NoError(t, scheduler.Register(Job{Name: "sweep", Interval: time.Minute, Run: noop}))
+	assert.Error(t, scheduler.Register(Job{Name: "sweep", Interval: time.Minute, Run: noop}))
+	assert.Error(t, scheduler.Register(Job{Name: "", Interval: time.Minute, Run: noop}))
+	assert.Error(t, scheduler.Register(Job{Name: "zero", Run: noop}))
+	assert.Error(t, scheduler.Register(Job{Name: "no-run", Interval: time.Minute}))
+}
+
+func TestScheduler_RunsJobsUntilStopped(t *testing.T) {
+	logger := zerolog.Nop()
+	scheduler := NewScheduler(&logger)
+
+	var runs, failing atomic.Int32
+	require.NoError(t, scheduler.Register(Job{
+		Name:       "counter",
+		Interval:   5 * time.Millisecond,
+		RunOnStart: true,
+		Run: func(ctx context.Context) error {
+			runs.Add(1)
+			return nil
+		},
+	}))
+	require.NoError(t, scheduler.Register(Job{
+		Name:     "failing",
+		Interval: 5 * time.Millisecond,
+		Run: func(ctx context.Context) error {
+			if failing.Add(1) == 1 {
+				panic("boom")
+			}
+			return errors.New("always fails")
+		},
+	}))
+
+	scheduler.Start(context.Background())
+	assert.Eventually(t, func() bool {
+		return runs.Load() >= 3 && failing.Load() >= 2
+	}, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, scheduler.Stop(ctx))
+
+	stopped := runs.Load()
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, stopped, runs.Load())
+}
@@ -55,20 +55,30 @@ type Service interface {
 	BulkInventoryAdjustment(ctx *gin.Context, req *dto.BulkInventoryAdjustmentRequest) (*dto.BulkInventoryOperationResponse, error)
 }
 
+// BackorderAllocator allocates stock added by adjustments to waiting backorders
+type BackorderAllocator interface {
+	AllocateBackorders(ctx context.Context, productID, warehouseID uuid.UUID) error
+}
+
 // ServiceImpl implements the inventory service interface
 type ServiceImpl struct {
 	inventoryRepo   repositories.InventoryRepository
 	warehouseRepo   repositories.WarehouseRepository
 	transactionRepo repositories.InventoryTransactionRepository
+	transferRepo    repositories.TransferOrderRepository
+	allocator       BackorderAllocator
 	txManager       database.TransactionManagerInterface
 	logger          *zerolog.Logger
 }
 
-// NewService creates a new inventory service instance
+// NewService creates a new inventory service instance. Stock reports include
+// the quantities in transit on transfer orders when transferRepo is set.
 func NewService(
 	inventoryRepo repositories.InventoryRepository,
 	warehouseRepo repositories.WarehouseRepository,
 	transactionRepo repositories.InventoryTransactionRepository,
+	transferRepo repositories.TransferOrderRepository,
+	allocator BackorderAllocator,
 	txManager database.TransactionManagerInterface,
 	logger *zerolog.Logger,
 ) Service {
@@ -76,6 +86,8 @@ func NewService(
 		inventoryRepo:   inventoryRepo,
 		warehouseRepo:   warehouseRepo,
 		transactionRepo: transactionRepo,
+		transferRepo:    transferRepo,
+		allocator:       allocator,
 		txManager:       txManager,
 		logger:          logger,
 	}
@@ -103,6 +115,8 @@ func (s *ServiceImpl) AdjustInventory(c *gin.Context, req *dto.AdjustInventoryRe
 
 	// Execute transaction creation and stock adjustment within a database transaction
 	err := s.txManager.WithRetryTransaction(ctx, func(tx pgx.Tx) error {
+		ctx := database.ContextWithTx(ctx, tx)
+
 		// Save transaction
 		if err := s.transactionRepo.Create(ctx, transaction); err != nil {
 			return fmt.Errorf("failed to create transaction: %w", err)
@@ -120,6 +134,15 @@ func (s *ServiceImpl) AdjustInventory(c *gin.Context, req *dto.AdjustInventoryRe
 		return nil, err
 	}
 
+	// Offer added stock to waiting backorders
+	if req.Adjustment > 0 && s.allocator != nil {
+		if err := s.allocator.AllocateBackorders(ctx, req.ProductID, req.WarehouseID); err != nil {
+			s.logger.Error().Err(err).
+				Str("product_id", req.ProductID.String()).
+				Msg("Failed to allocate adjusted stock to backorders")
+		}
+	}
+
 	// Return response
 	return &dto.InventoryTransactionResponse{
 		ID:              transaction.ID,
@@ -224,6 +247,8 @@ func (s *ServiceImpl) TransferInventory(c *gin.Context, req *dto.TransferInvento
 	// Execute all operations within a transaction
 	var response *dto.InventoryTransactionResponse
 	err = s.txManager.WithRetryTransaction(ctx, func(tx pgx.Tx) error {
+		ctx := database.ContextWithTx(ctx, tx)
+
 		// Save outbound transaction
 		if err := s.transactionRepo.Create(ctx, outboundTransaction); err != nil {
 			return fmt.Errorf("failed to create outbound transaction: %w", err)
@@ -321,6 +346,17 @@ func (s *ServiceImpl) ListInventory(c *gin.Context, req *dto.ListInventoryReques
 		inventoryDTOs[i] = s.inventoryToDTO(inv)
 	}
 
+	inTransitFilter := repositories.InTransitFilter{}
+	if len(filter.WarehouseIDs) == 1 {
+		inTransitFilter.ToWarehouseID = &filter.WarehouseIDs[0]
+	}
+	if len(filter.ProductIDs) == 1 {
+		inTransitFilter.ProductID = &filter.ProductIDs[0]
+	}
+	if err := s.applyInTransit(ctx, inventoryDTOs, inTransitFilter); err != nil {
+		return nil, err
+	}
+
 	totalPages := (total + req.Limit - 1) / req.Limit
 	return &dto.InventoryListResponse{
 		Inventory: inventoryDTOs,
@@ -344,7 +380,13 @@ func (s *ServiceImpl) GetInventoryByProductAndWarehouse(c *gin.Context, productI
 		return nil, fmt.Errorf("failed to get inventory: %w", err)
 	}
 
-	return s.inventoryToDTO(inventory), nil
+	response := s.inventoryToDTO(inventory)
+	inTransitFilter := repositories.InTransitFilter{ProductID: &productID, ToWarehouseID: &warehouseID}
+	if err := s.applyInTransit(ctx, []*dto.InventoryResponse{response}, inTransitFilter); err != nil {
+		return nil, err
+	}
+
+	return response, nil
 }
 
 // GetInventoryStats gets inventory statistics
@@ -395,6 +437,18 @@ func (s *ServiceImpl) GetInventoryStats(c *gin.Context) (*dto.InventoryStatsResp
 
 	stats.TotalWarehouses = len(warehouseSet)
 
+	if s.transferRepo != nil {
+		inTransit, err := s.transferRepo.GetInTransitStock(ctx, repositories.InTransitFilter{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get in-transit stock: %w", err)
+		}
+		stats.TotalInTransitValue = decimal.Zero
+		for _, line := range inTransit {
+			stats.TotalInTransitQuantity += line.Quantity
+			stats.TotalInTransitValue = stats.TotalInTransitValue.Add(decimal.NewFromFloat(line.TotalValue))
+		}
+	}
+
 	return stats, nil
 }
 
@@ -421,6 +475,10 @@ func (s *ServiceImpl) GetLowStockItems(c *gin.Context, warehouseID *uuid.UUID) (
 		inventoryDTOs[i] = s.inventoryToDTO(inv)
 	}
 
+	if err := s.applyInTransit(ctx, inventoryDTOs, repositories.InTransitFilter{ToWarehouseID: warehouseID}); err != nil {
+		return nil, err
+	}
+
 	return &dto.InventoryListResponse{
 		Inventory: inventoryDTOs,
 		Pagination: &dto.PaginationInfo{
@@ -556,6 +614,30 @@ func (s *ServiceImpl) validateTransferInventoryRequest(ctx context.Context, req
 	return nil
 }
 
+// applyInTransit fills in the quantity shipped to each inventory record's
+// warehouse on transfer orders and not yet received
+func (s *ServiceImpl) applyInTransit(ctx context.Context, inventories []*dto.InventoryResponse, filter repositories.InTransitFilter) error {
+	if s.transferRepo == nil || len(inventories) == 0 {
+		return nil
+	}
+
+	inTransit, err := s.transferRepo.GetInTransitStock(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to get in-transit stock: %w", err)
+	}
+
+	type stockKey struct{ productID, warehouseID uuid.UUID }
+	inbound := make(map[stockKey]int, len(inTransit))
+	for _, line := range inTransit {
+		inbound[stockKey{line.ProductID, line.ToWarehouseID}] += line.Quantity
+	}
+	for _, inventory := range inventories {
+		inventory.InTransitQuantity = inbound[stockKey{inventory.ProductID, inventory.WarehouseID}]
+	}
+
+	return nil
+}
+
 func (s *ServiceImpl) inventoryToDTO(inventory *entities.Inventory) *dto.InventoryResponse {
 	availableStock := inventory.QuantityOnHand - inventory.QuantityReserved
 
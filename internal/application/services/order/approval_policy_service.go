@@ -0,0 +1,241 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
+)
+
+// ApprovalPolicyService defines the interface for approval policy management.
+// Orders are approved and rejected through the order service.
+type ApprovalPolicyService interface {
+	CreateApprovalPolicy(ctx context.Context, req *CreateApprovalPolicyRequest) (*entities.ApprovalPolicy, error)
+	GetApprovalPolicy(ctx context.Context, id string) (*entities.ApprovalPolicy, error)
+	UpdateApprovalPolicy(ctx context.Context, id string, req *UpdateApprovalPolicyRequest) (*entities.ApprovalPolicy, error)
+	ListApprovalPolicies(ctx context.Context, req *ListApprovalPoliciesRequest) (*ListApprovalPoliciesResponse, error)
+}
+
+// CreateApprovalPolicyRequest represents a request to create an approval policy
+type CreateApprovalPolicyRequest struct {
+	Name               string           `json:"name" validate:"required"`
+	Description        *string          `json:"description,omitempty"`
+	MinAmount          *decimal.Decimal `json:"min_amount,omitempty"`
+	CustomerTypes      []string         `json:"customer_types,omitempty"`
+	MinDiscountPercent *decimal.Decimal `json:"min_discount_percent,omitempty"`
+	MaxMarginPercent   *decimal.Decimal `json:"max_margin_percent,omitempty"`
+	ApproverRoles      []string         `json:"approver_roles" validate:"required,min=1"`
+	Priority           int              `json:"priority"`
+	CreatedBy          string           `json:"created_by" validate:"required,uuid"`
+}
+
+// UpdateApprovalPolicyRequest represents a request to update an approval
+// policy. Customer types and approver roles are replaced when set.
+type UpdateApprovalPolicyRequest struct {
+	Name               *string          `json:"name,omitempty"`
+	Description        *string          `json:"description,omitempty"`
+	MinAmount          *decimal.Decimal `json:"min_amount,omitempty"`
+	CustomerTypes      []string         `json:"customer_types,omitempty"`
+	MinDiscountPercent *decimal.Decimal `json:"min_discount_percent,omitempty"`
+	MaxMarginPercent   *decimal.Decimal `json:"max_margin_percent,omitempty"`
+	ApproverRoles      []string         `json:"approver_roles,omitempty"`
+	Priority           *int             `json:"priority,omitempty"`
+	IsActive           *bool            `json:"is_active,omitempty"`
+}
+
+// ListApprovalPoliciesRequest represents a request to list approval policies
+type ListApprovalPoliciesRequest struct {
+	Search   string `json:"search,omitempty"`
+	IsActive *bool  `json:"is_active,omitempty"`
+	Page     int    `json:"page"`
+	Limit    int    `json:"limit"`
+}
+
+// ListApprovalPoliciesResponse represents a paginated list of approval policies
+type ListApprovalPoliciesResponse struct {
+	Policies   []*entities.ApprovalPolicy `json:"policies"`
+	Pagination *Pagination                `json:"pagination"`
+}
+
+// Approval errors
+var (
+	ErrApprovalPolicyNotFound   = errors.New("approval policy not found")
+	ErrOrderNotAwaitingApproval = errors.New("order is not awaiting approval")
+	ErrApproverNotAuthorized    = errors.New("approver does not hold the required role")
+)
+
+// ApprovalPolicyServiceImpl implements the ApprovalPolicyService interface
+type ApprovalPolicyServiceImpl struct {
+	approvalRepo repositories.ApprovalRepository
+	logger       *zerolog.Logger
+}
+
+// NewApprovalPolicyService creates a new approval policy service
+func NewApprovalPolicyService(
+	approvalRepo repositories.ApprovalRepository,
+	logger *zerolog.Logger,
+) ApprovalPolicyService {
+	return &ApprovalPolicyServiceImpl{
+		approvalRepo: approvalRepo,
+		logger:       logger,
+	}
+}
+
+// CreateApprovalPolicy creates an active approval policy. Pending orders it
+// matches require its approvals from their next change or approval.
+func (s *ApprovalPolicyServiceImpl) CreateApprovalPolicy(ctx context.Context, req *CreateApprovalPolicyRequest) (*entities.ApprovalPolicy, error) {
+	createdBy, err := uuid.Parse(req.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid created by user ID: %w", err)
+	}
+
+	now := time.Now().UTC()
+	policy := &entities.ApprovalPolicy{
+		ID:                 uuid.New(),
+		Name:               strings.TrimSpace(req.Name),
+		Description:        trimmedOrNil(req.Description),
+		MinAmount:          req.MinAmount,
+		CustomerTypes:      customerTypes(req.CustomerTypes),
+		MinDiscountPercent: req.MinDiscountPercent,
+		MaxMarginPercent:   req.MaxMarginPercent,
+		ApproverRoles:      approverRoles(req.ApproverRoles),
+		Priority:           req.Priority,
+		IsActive:           true,
+		CreatedBy:          createdBy,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid approval policy data: %w", err)
+	}
+
+	if err := s.approvalRepo.CreatePolicy(ctx, policy); err != nil {
+		return nil, fmt.Errorf("failed to create approval policy: %w", err)
+	}
+
+	s.logger.Info().
+		Str("policy_id", policy.ID.String()).
+		Str("name", policy.Name).
+		Strs("approver_roles", policy.ApproverRoles).
+		Msg("Approval policy created")
+
+	return policy, nil
+}
+
+// GetApprovalPolicy retrieves an approval policy by ID
+func (s *ApprovalPolicyServiceImpl) GetApprovalPolicy(ctx context.Context, id string) (*entities.ApprovalPolicy, error) {
+	return s.loadPolicy(ctx, id)
+}
+
+// UpdateApprovalPolicy updates the conditions, approvers or status of an
+// approval policy. Approvals already requested are not affected until the
+// order changes.
+func (s *ApprovalPolicyServiceImpl) UpdateApprovalPolicy(ctx context.Context, id string, req *UpdateApprovalPolicyRequest) (*entities.ApprovalPolicy, error) {
+	policy, err := s.loadPolicy(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		policy.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		policy.Description = trimmedOrNil(req.Description)
+	}
+	if req.MinAmount != nil {
+		policy.MinAmount = req.MinAmount
+	}
+	if req.CustomerTypes != nil {
+		policy.CustomerTypes = customerTypes(req.CustomerTypes)
+	}
+	if req.MinDiscountPercent != nil {
+		policy.MinDiscountPercent = req.MinDiscountPercent
+	}
+	if req.MaxMarginPercent != nil {
+		policy.MaxMarginPercent = req.MaxMarginPercent
+	}
+	if req.ApproverRoles != nil {
+		policy.ApproverRoles = approverRoles(req.ApproverRoles)
+	}
+	if req.Priority != nil {
+		policy.Priority = *req.Priority
+	}
+	if req.IsActive != nil {
+		policy.IsActive = *req.IsActive
+	}
+	policy.UpdatedAt = time.Now().UTC()
+
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid approval policy data: %w", err)
+	}
+
+	if err := s.approvalRepo.UpdatePolicy(ctx, policy); err != nil {
+		return nil, fmt.Errorf("failed to update approval policy: %w", err)
+	}
+
+	return policy, nil
+}
+
+// ListApprovalPolicies lists approval policies, highest priority first
+func (s *ApprovalPolicyServiceImpl) ListApprovalPolicies(ctx context.Context, req *ListApprovalPoliciesRequest) (*ListApprovalPoliciesResponse, error) {
+	page, limit := normalizePage(req.Page, req.Limit)
+
+	filter := repositories.ApprovalPolicyFilter{
+		Search:   req.Search,
+		IsActive: req.IsActive,
+		Page:     page,
+		Limit:    limit,
+		Offset:   (page - 1) * limit,
+	}
+
+	policies, err := s.approvalRepo.ListPolicies(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list approval policies: %w", err)
+	}
+
+	total, err := s.approvalRepo.CountPolicies(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count approval policies: %w", err)
+	}
+
+	return &ListApprovalPoliciesResponse{
+		Policies:   policies,
+		Pagination: newPagination(page, limit, total),
+	}, nil
+}
+
+// loadPolicy parses the ID and loads the policy, mapping missing rows to ErrApprovalPolicyNotFound
+func (s *ApprovalPolicyServiceImpl) loadPolicy(ctx context.Context, id string) (*entities.ApprovalPolicy, error) {
+	policyID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid approval policy ID: %w", err)
+	}
+
+	policy, err := s.approvalRepo.GetPolicyByID(ctx, policyID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrApprovalPolicyNotFound
+		}
+		return nil, fmt.Errorf("failed to get approval policy: %w", err)
+	}
+
+	return policy, nil
+}
+
+// approverRoles normalizes the role names approving under a policy, keeping their sequence
+func approverRoles(values []string) []string {
+	roles := make([]string, 0, len(values))
+	for _, value := range values {
+		roles = append(roles, strings.ToLower(strings.TrimSpace(value)))
+	}
+	return roles
+}
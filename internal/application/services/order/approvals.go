@@ -0,0 +1,399 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
+	userEntities "erpgo/internal/domain/users/entities"
+	"erpgo/pkg/database"
+)
+
+// ApproverRoles looks up the roles of the users approving orders
+type ApproverRoles interface {
+	GetUserRoles(ctx context.Context, userID uuid.UUID) ([]*userEntities.Role, error)
+}
+
+// RejectOrderRequest represents an approver's rejection of a pending order
+type RejectOrderRequest struct {
+	Reason     string `json:"reason" validate:"required"`
+	RejectedBy string `json:"rejected_by" validate:"required,uuid"`
+}
+
+// GetPendingApprovalsRequest represents a request for an approver's inbox
+type GetPendingApprovalsRequest struct {
+	ApproverID string `json:"approver_id" validate:"required,uuid"`
+	Page       int    `json:"page"`
+	Limit      int    `json:"limit"`
+}
+
+// GetPendingApprovalsResponse lists the approval steps awaiting an approver, with their orders
+type GetPendingApprovalsResponse struct {
+	Approvals  []*entities.OrderApproval `json:"approvals"`
+	Pagination *Pagination               `json:"pagination"`
+}
+
+// RejectOrder records an approver's rejection of the next step of a pending
+// order's approval chain and cancels the order. The order stays locked from
+// the rejection through its cancellation, so it cannot be approved meanwhile.
+func (s *ServiceImpl) RejectOrder(ctx context.Context, id string, req *RejectOrderRequest) (*entities.Order, error) {
+	approverID, err := uuid.Parse(req.RejectedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid approver ID: %w", err)
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, fmt.Errorf("%w: a rejection reason is required", ErrInvalidStatusTransition)
+	}
+
+	ctx = withActorID(ctx, req.RejectedBy)
+
+	var order *entities.Order
+	err = database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		if order, err = s.lockOrder(ctx, id); err != nil {
+			return err
+		}
+		if order.Status != entities.OrderStatusPending || order.ApprovedAt != nil {
+			return ErrOrderNotAwaitingApproval
+		}
+
+		chain, err := s.requestApprovals(ctx, order)
+		if err != nil {
+			return err
+		}
+		next := chain.Next()
+		if next == nil {
+			return ErrOrderNotAwaitingApproval
+		}
+		if err := s.checkApprover(ctx, approverID, next); err != nil {
+			return err
+		}
+
+		if err := next.Decide(entities.ApprovalStatusRejected, approverID, reason, time.Now().UTC()); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidStatusTransition, err)
+		}
+
+		// The steps after the rejected one are never decided
+		for _, approval := range chain {
+			if approval.Sequence < next.Sequence {
+				continue
+			}
+			if approval.Status == entities.ApprovalStatusPending {
+				approval.Status = entities.ApprovalStatusSkipped
+			}
+			if err := s.approvalRepo.UpdateApproval(ctx, approval); err != nil {
+				return err
+			}
+		}
+
+		s.logger.Info().
+			Str("order_number", order.OrderNumber).
+			Str("role", next.Role).
+			Str("rejected_by", approverID.String()).
+			Str("reason", reason).
+			Msg("Order approval rejected")
+
+		order, err = s.CancelOrder(ctx, id, &CancelOrderRequest{
+			Reason:      fmt.Sprintf("approval rejected by %s: %s", next.Role, reason),
+			CancelledBy: req.RejectedBy,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// GetOrderApprovals retrieves the approval steps of an order with the
+// approvals and rejections recorded against them, including superseded ones
+func (s *ServiceImpl) GetOrderApprovals(ctx context.Context, id string) ([]*entities.OrderApproval, error) {
+	order, err := s.loadOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.approvalRepo == nil {
+		return []*entities.OrderApproval{}, nil
+	}
+
+	approvals, err := s.approvalRepo.GetApprovalsByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order approvals: %w", err)
+	}
+
+	return approvals, nil
+}
+
+// GetPendingApprovals lists the approval steps awaiting a decision by one of
+// the approver's roles, oldest first, with their orders
+func (s *ServiceImpl) GetPendingApprovals(ctx context.Context, req *GetPendingApprovalsRequest) (*GetPendingApprovalsResponse, error) {
+	approverID, err := uuid.Parse(req.ApproverID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid approver ID: %w", err)
+	}
+
+	page, limit := normalizePage(req.Page, req.Limit)
+	response := &GetPendingApprovalsResponse{
+		Approvals:  []*entities.OrderApproval{},
+		Pagination: newPagination(page, limit, 0),
+	}
+	if s.approvalRepo == nil {
+		return response, nil
+	}
+
+	roles, err := s.userRoles(ctx, approverID)
+	if err != nil {
+		return nil, err
+	}
+	if len(roles) == 0 {
+		return response, nil
+	}
+
+	filter := repositories.PendingApprovalFilter{
+		Roles:  roles,
+		Page:   page,
+		Limit:  limit,
+		Offset: (page - 1) * limit,
+	}
+
+	approvals, err := s.approvalRepo.ListPendingApprovals(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending approvals: %w", err)
+	}
+
+	total, err := s.approvalRepo.CountPendingApprovals(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count pending approvals: %w", err)
+	}
+
+	for _, approval := range approvals {
+		if approval.Order, err = s.orderRepo.GetByID(ctx, approval.OrderID); err != nil {
+			return nil, fmt.Errorf("failed to get order: %w", err)
+		}
+	}
+
+	response.Approvals = append(response.Approvals, approvals...)
+	response.Pagination = newPagination(page, limit, total)
+	return response, nil
+}
+
+// approveNext records the approver's approval of the next step of the
+// order's approval chain and reports whether the chain is complete, so the
+// order can be confirmed. Orders without approval policies are complete.
+// The caller holds the order's lock, so concurrent approvers decide the steps
+// one after the other. An approver who approved a step of the chain cannot
+// approve another, even when they hold the roles of both.
+func (s *ServiceImpl) approveNext(ctx context.Context, order *entities.Order, approverID uuid.UUID) (bool, error) {
+	chain, err := s.requestApprovals(ctx, order)
+	if err != nil {
+		return false, err
+	}
+
+	next := chain.Next()
+	if next == nil {
+		return true, nil
+	}
+	if err := s.checkApprover(ctx, approverID, next); err != nil {
+		return false, err
+	}
+	if approved := chain.ApprovedBy(approverID); approved != nil {
+		return false, fmt.Errorf("%w: the approver already approved step %d of the approval", ErrApproverNotAuthorized, approved.Sequence)
+	}
+
+	if err := next.Decide(entities.ApprovalStatusApproved, approverID, "", time.Now().UTC()); err != nil {
+		return false, fmt.Errorf("%w: %v", ErrInvalidStatusTransition, err)
+	}
+	if err := s.approvalRepo.UpdateApproval(ctx, next); err != nil {
+		return false, err
+	}
+
+	s.logger.Info().
+		Str("order_number", order.OrderNumber).
+		Int("sequence", next.Sequence).
+		Str("role", next.Role).
+		Str("approved_by", approverID.String()).
+		Msg("Order approval step approved")
+
+	return chain.Next() == nil, nil
+}
+
+// requestApprovals returns the current approval chain of an order. The chain
+// of a pending order is replaced when the order changed in a way that
+// requires other approvals, or a different amount to be approved; earlier
+// decisions are kept as superseded steps.
+func (s *ServiceImpl) requestApprovals(ctx context.Context, order *entities.Order) (entities.ApprovalChain, error) {
+	if s.approvalRepo == nil {
+		return nil, nil
+	}
+
+	approvals, err := s.approvalRepo.GetApprovalsByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order approvals: %w", err)
+	}
+	chain := entities.CurrentApprovalChain(approvals)
+	if order.Status != entities.OrderStatusPending || order.ApprovedAt != nil {
+		return chain, nil
+	}
+
+	steps, metrics, err := s.requiredApprovals(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+	if chain.Covers(steps, metrics.Amount) {
+		return chain, nil
+	}
+
+	now := time.Now().UTC()
+	requested := make(entities.ApprovalChain, len(steps))
+	for i, step := range steps {
+		requested[i] = &entities.OrderApproval{
+			ID:         uuid.New(),
+			OrderID:    order.ID,
+			PolicyID:   step.PolicyID,
+			PolicyName: step.PolicyName,
+			Sequence:   i + 1,
+			Role:       step.Role,
+			Status:     entities.ApprovalStatusPending,
+			Amount:     metrics.Amount,
+			CreatedAt:  now,
+		}
+	}
+
+	err = database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		for _, approval := range chain {
+			approval.SupersededAt = &now
+			if approval.Status == entities.ApprovalStatusPending {
+				approval.Status = entities.ApprovalStatusSkipped
+			}
+			if err := s.approvalRepo.UpdateApproval(ctx, approval); err != nil {
+				return err
+			}
+		}
+		return s.approvalRepo.CreateApprovals(ctx, requested)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info().
+		Str("order_number", order.OrderNumber).
+		Str("amount", metrics.Amount.String()).
+		Strs("roles", requested.Roles()).
+		Int("superseded", len(chain)).
+		Msg("Order approval requested")
+
+	return requested, nil
+}
+
+// awaitedApprovers returns the roles still to approve an order that is not
+// yet approved, in sequence, without requesting the approvals
+func (s *ServiceImpl) awaitedApprovers(ctx context.Context, order *entities.Order) ([]string, error) {
+	if s.approvalRepo == nil || order.ApprovedAt != nil {
+		return nil, nil
+	}
+
+	steps, metrics, err := s.requiredApprovals(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+
+	approvals, err := s.approvalRepo.GetApprovalsByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order approvals: %w", err)
+	}
+	if chain := entities.CurrentApprovalChain(approvals); chain.Covers(steps, metrics.Amount) {
+		return chain.Roles(), nil
+	}
+
+	roles := make([]string, len(steps))
+	for i, step := range steps {
+		roles[i] = step.Role
+	}
+	return roles, nil
+}
+
+// requiredApprovals works out the approval steps the active policies require
+// of an order and the metrics they were matched against
+func (s *ServiceImpl) requiredApprovals(ctx context.Context, order *entities.Order) ([]entities.ApprovalStep, entities.ApprovalMetrics, error) {
+	active := true
+	policies, err := s.approvalRepo.ListPolicies(ctx, repositories.ApprovalPolicyFilter{IsActive: &active})
+	if err != nil {
+		return nil, entities.ApprovalMetrics{}, fmt.Errorf("failed to list approval policies: %w", err)
+	}
+
+	costs := make(map[uuid.UUID]decimal.Decimal)
+	for _, policy := range policies {
+		if policy.NeedsMargin() {
+			if costs, err = s.productCosts(ctx, order); err != nil {
+				return nil, entities.ApprovalMetrics{}, err
+			}
+			break
+		}
+	}
+
+	metrics := entities.NewApprovalMetrics(order, costs)
+	return entities.BuildApprovalChain(policies, metrics), metrics, nil
+}
+
+// productCosts maps the products of an order's lines to their unit cost.
+// Products without a cost are left out, leaving the order's margin unknown.
+func (s *ServiceImpl) productCosts(ctx context.Context, order *entities.Order) (map[uuid.UUID]decimal.Decimal, error) {
+	costs := make(map[uuid.UUID]decimal.Decimal)
+	for _, item := range order.Items {
+		if _, ok := costs[item.ProductID]; ok {
+			continue
+		}
+		product, err := s.productRepo.GetByID(ctx, item.ProductID)
+		if err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get product: %w", err)
+		}
+		if product.Cost.IsPositive() {
+			costs[item.ProductID] = product.Cost
+		}
+	}
+	return costs, nil
+}
+
+// checkApprover checks that the user holds the role of an approval step
+func (s *ServiceImpl) checkApprover(ctx context.Context, userID uuid.UUID, approval *entities.OrderApproval) error {
+	roles, err := s.userRoles(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, role := range roles {
+		if role == approval.Role {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: step %d of the approval requires the %s role", ErrApproverNotAuthorized, approval.Sequence, approval.Role)
+}
+
+// userRoles returns the role names of a user
+func (s *ServiceImpl) userRoles(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	if s.approverRoles == nil {
+		return nil, nil
+	}
+
+	roles, err := s.approverRoles.GetUserRoles(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approver roles: %w", err)
+	}
+
+	names := make([]string, 0, len(roles))
+	for _, role := range roles {
+		names = append(names, strings.ToLower(role.Name))
+	}
+	return names, nil
+}
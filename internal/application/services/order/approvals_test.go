@@ -0,0 +1,154 @@
+package order
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"erpgo/internal/domain/orders/entities"
+)
+
+func TestServiceImpl_ApproveOrder(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("orders without approval policies are confirmed in one step", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		order := fixture.createOrder(t, service, 5)
+		store.resetWrites()
+
+		approved, err := service.ApproveOrder(ctx, order.ID.String(), fixture.user.String())
+		require.NoError(t, err)
+
+		assert.Equal(t, entities.OrderStatusConfirmed, approved.Status)
+		assert.NotNil(t, approved.ApprovedAt)
+		assert.Equal(t, 5, store.reserved(fixture.product.ID, fixture.warehouse.ID))
+		assert.Equal(t, map[uuid.UUID]int{fixture.warehouse.ID: 5}, store.allocated(order.ID))
+		assert.Empty(t, store.untransacted())
+	})
+
+	t.Run("approval chain is approved step by step", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		minAmount := decimal.NewFromInt(100)
+		store.policies = append(store.policies, &entities.ApprovalPolicy{
+			ID:            uuid.New(),
+			Name:          "Large orders",
+			MinAmount:     &minAmount,
+			ApproverRoles: []string{"sales_manager", "finance"},
+			IsActive:      true,
+		})
+		salesManager, finance := uuid.New(), uuid.New()
+		store.roles[salesManager] = []string{"sales_manager"}
+		store.roles[finance] = []string{"finance"}
+
+		order := fixture.createOrder(t, service, 5)
+		require.Len(t, store.approvals, 2, "creating the order requests its approvals")
+
+		// Finance approves after the sales manager
+		_, err := service.ApproveOrder(ctx, order.ID.String(), finance.String())
+		assert.ErrorIs(t, err, ErrApproverNotAuthorized)
+
+		pending, err := service.ApproveOrder(ctx, order.ID.String(), salesManager.String())
+		require.NoError(t, err)
+		assert.Equal(t, entities.OrderStatusPending, pending.Status)
+		assert.Nil(t, pending.ApprovedAt)
+		assert.Zero(t, store.reserved(fixture.product.ID, fixture.warehouse.ID))
+
+		confirmed, err := service.ApproveOrder(ctx, order.ID.String(), finance.String())
+		require.NoError(t, err)
+		assert.Equal(t, entities.OrderStatusConfirmed, confirmed.Status)
+		assert.Equal(t, finance, *confirmed.ApprovedBy)
+		assert.Equal(t, 5, store.reserved(fixture.product.ID, fixture.warehouse.ID))
+
+		for _, approval := range store.approvals {
+			assert.Equal(t, entities.ApprovalStatusApproved, approval.Status, approval.Role)
+		}
+	})
+
+	t.Run("one approver approves one step of the chain", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		minAmount := decimal.NewFromInt(100)
+		store.policies = append(store.policies, &entities.ApprovalPolicy{
+			ID:            uuid.New(),
+			Name:          "Large orders",
+			MinAmount:     &minAmount,
+			ApproverRoles: []string{"sales_manager", "finance"},
+			IsActive:      true,
+		})
+		director := uuid.New()
+		store.roles[director] = []string{"sales_manager", "finance"}
+
+		order := fixture.createOrder(t, service, 5)
+
+		_, err := service.ApproveOrder(ctx, order.ID.String(), director.String())
+		require.NoError(t, err)
+		store.resetWrites()
+
+		_, err = service.ApproveOrder(ctx, order.ID.String(), director.String())
+		assert.ErrorIs(t, err, ErrApproverNotAuthorized)
+		assert.Empty(t, store.ops())
+		assert.Equal(t, entities.OrderStatusPending, store.orders[order.ID].Status)
+		assert.Nil(t, store.orders[order.ID].ApprovedAt)
+	})
+
+	t.Run("approved order cannot be approved again", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		order := fixture.confirmOrder(t, service, 1)
+
+		_, err := service.ApproveOrder(ctx, order.ID.String(), fixture.user.String())
+		assert.ErrorIs(t, err, ErrInvalidStatusTransition)
+	})
+
+	t.Run("shortage of stock fails the approval", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		order := fixture.createOrder(t, service, 150)
+
+		_, err := service.ApproveOrder(ctx, order.ID.String(), fixture.user.String())
+		assert.ErrorIs(t, err, ErrInsufficientInventory)
+		assert.Zero(t, store.reserved(fixture.product.ID, fixture.warehouse.ID))
+		assert.Equal(t, entities.OrderStatusPending, store.orders[order.ID].Status)
+	})
+}
+
+func TestServiceImpl_RejectOrder(t *testing.T) {
+	ctx := context.Background()
+
+	service, store := newTestService(t)
+	fixture := newOrderFixture(store, decimal.Zero)
+	minAmount := decimal.NewFromInt(100)
+	store.policies = append(store.policies, &entities.ApprovalPolicy{
+		ID:            uuid.New(),
+		Name:          "Large orders",
+		MinAmount:     &minAmount,
+		ApproverRoles: []string{"sales_manager"},
+		IsActive:      true,
+	})
+	salesManager := uuid.New()
+	store.roles[salesManager] = []string{"sales_manager"}
+
+	order := fixture.createOrder(t, service, 5)
+	store.resetWrites()
+
+	rejected, err := service.RejectOrder(ctx, order.ID.String(), &RejectOrderRequest{
+		Reason:     "price too low",
+		RejectedBy: salesManager.String(),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, entities.OrderStatusCancelled, rejected.Status)
+	assert.Contains(t, store.locked, order.ID)
+	assert.Empty(t, store.untransacted())
+	require.Len(t, store.approvals, 1)
+	assert.Equal(t, entities.ApprovalStatusRejected, store.approvals[0].Status)
+
+	_, err = service.ApproveOrder(ctx, order.ID.String(), salesManager.String())
+	assert.Error(t, err, "rejected orders cannot be approved")
+}
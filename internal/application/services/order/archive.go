@@ -0,0 +1,237 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
+)
+
+// ListArchivedOrdersRequest represents a request to list archived orders
+type ListArchivedOrdersRequest struct {
+	Search     string  `json:"search,omitempty"`
+	CustomerID *string `json:"customer_id,omitempty"`
+	// Year is the year of the order date
+	Year  *int `json:"year,omitempty"`
+	Page  int  `json:"page"`
+	Limit int  `json:"limit"`
+}
+
+// Archive errors
+var (
+	ErrOrderNotArchivable = errors.New("order cannot be archived")
+)
+
+// ArchiveOrder moves a closed, settled order without open returns into the
+// archive with its items, addresses and status history
+func (s *ServiceImpl) ArchiveOrder(ctx context.Context, id string) error {
+	order, err := s.loadOrder(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := order.CanBeArchived(); err != nil {
+		return fmt.Errorf("%w: %v", ErrOrderNotArchivable, err)
+	}
+	openReturns, err := s.archiveRepo.HasOpenReturns(ctx, order.ID)
+	if err != nil {
+		return err
+	}
+	if openReturns {
+		return fmt.Errorf("%w: order has an open return authorization", ErrOrderNotArchivable)
+	}
+
+	if err := s.archiveRepo.Archive(ctx, order.ID, time.Now().UTC()); err != nil {
+		return fmt.Errorf("failed to archive order: %w", err)
+	}
+
+	s.logger.Info().
+		Str("order_id", order.ID.String()).
+		Str("order_number", order.OrderNumber).
+		Msg("Order archived")
+
+	return nil
+}
+
+// RestoreOrder moves an archived order back into the live order tables
+func (s *ServiceImpl) RestoreOrder(ctx context.Context, id string) error {
+	orderID, err := uuid.Parse(id)
+	if err != nil {
+		return fmt.Errorf("invalid order ID: %w", err)
+	}
+
+	if err := s.archiveRepo.Restore(ctx, orderID); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			if _, err := s.orderRepo.GetByID(ctx, orderID); err == nil {
+				return ErrOrderNotArchived
+			}
+			return ErrOrderNotFound
+		}
+		return fmt.Errorf("failed to restore order: %w", err)
+	}
+
+	s.logger.Info().
+		Str("order_id", orderID.String()).
+		Msg("Order restored from archive")
+
+	return nil
+}
+
+// ArchiveClosedOrders archives up to a batch of the closed, settled orders
+// left unchanged for the policy's age and returns how many were archived.
+// An order failing to archive is logged and left for the next run.
+func (s *ServiceImpl) ArchiveClosedOrders(ctx context.Context, policy entities.OrderArchivePolicy, asOf time.Time) (int, error) {
+	if err := policy.Validate(); err != nil {
+		return 0, fmt.Errorf("invalid archive policy: %w", err)
+	}
+
+	orderIDs, err := s.archiveRepo.FindArchivable(ctx, policy.Cutoff(asOf), policy.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	archived := 0
+	for _, orderID := range orderIDs {
+		if err := s.archiveRepo.Archive(ctx, orderID, asOf); err != nil {
+			s.logger.Warn().Err(err).Str("order_id", orderID.String()).Msg("Failed to archive order")
+			continue
+		}
+		archived++
+	}
+
+	if archived > 0 {
+		s.logger.Info().Int("count", archived).Msg("Archived closed orders")
+	}
+
+	return archived, nil
+}
+
+// ListArchivedOrders lists archived orders, most recent order date first
+func (s *ServiceImpl) ListArchivedOrders(ctx context.Context, req *ListArchivedOrdersRequest) (*ListOrdersResponse, error) {
+	page, limit := normalizePage(req.Page, req.Limit)
+
+	filter := repositories.ArchivedOrderFilter{
+		Search: strings.TrimSpace(req.Search),
+		Year:   req.Year,
+		Page:   page,
+		Limit:  limit,
+		Offset: (page - 1) * limit,
+	}
+	if req.CustomerID != nil {
+		customerID, err := uuid.Parse(*req.CustomerID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid customer ID: %w", err)
+		}
+		filter.CustomerID = &customerID
+	}
+
+	orders, err := s.archiveRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list archived orders: %w", err)
+	}
+
+	total, err := s.archiveRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count archived orders: %w", err)
+	}
+
+	return &ListOrdersResponse{
+		Orders:     orders,
+		Pagination: newPagination(page, limit, total),
+	}, nil
+}
+
+// missingOrder explains why an order is not in the live tables: an archived
+// order has to be restored before it can change
+func (s *ServiceImpl) missingOrder(ctx context.Context, orderID uuid.UUID) error {
+	if _, err := s.archiveRepo.GetByID(ctx, orderID); err == nil {
+		return fmt.Errorf("%w: restore it before changing it", ErrOrderAlreadyArchived)
+	}
+	return ErrOrderNotFound
+}
+
+// loadArchivedOrder parses the ID and loads the archived order with its details
+func (s *ServiceImpl) loadArchivedOrder(ctx context.Context, id string) (*entities.Order, error) {
+	orderID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order ID: %w", err)
+	}
+
+	order, err := s.archiveRepo.GetByID(ctx, orderID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to get archived order: %w", err)
+	}
+
+	if err := s.loadArchivedOrderDetails(ctx, order); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// loadArchivedOrderByNumber loads the archived order with an order number and its details
+func (s *ServiceImpl) loadArchivedOrderByNumber(ctx context.Context, orderNumber string) (*entities.Order, error) {
+	order, err := s.archiveRepo.GetByOrderNumber(ctx, orderNumber)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to get archived order by number: %w", err)
+	}
+
+	if err := s.loadArchivedOrderDetails(ctx, order); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// loadArchivedOrderDetails attaches the archived items and addresses, the
+// customer and the applied coupon codes to an archived order
+func (s *ServiceImpl) loadArchivedOrderDetails(ctx context.Context, order *entities.Order) error {
+	items, err := s.archiveRepo.GetItems(ctx, order.ID)
+	if err != nil {
+		return err
+	}
+	order.Items = make([]entities.OrderItem, len(items))
+	for i, item := range items {
+		order.Items[i] = *item
+	}
+
+	addresses, err := s.archiveRepo.GetAddresses(ctx, order.ID)
+	if err != nil {
+		return err
+	}
+	for _, address := range addresses {
+		if address.ID == order.ShippingAddressID {
+			order.ShippingAddress = address
+		}
+		if address.ID == order.BillingAddressID {
+			order.BillingAddress = address
+		}
+	}
+
+	if customer, err := s.customerRepo.GetByID(ctx, order.CustomerID); err == nil {
+		order.Customer = customer
+	}
+
+	redemptions, err := s.promotionRepo.GetActiveRedemptionsByOrderID(ctx, order.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get order promotions: %w", err)
+	}
+	order.Promotions = make([]entities.PromotionRedemption, len(redemptions))
+	for i, redemption := range redemptions {
+		order.Promotions[i] = *redemption
+	}
+
+	return nil
+}
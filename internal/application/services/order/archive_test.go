@@ -0,0 +1,98 @@
+package order
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"erpgo/internal/domain/orders/entities"
+)
+
+// deliveredOrder confirms an order and settles it as delivered and paid in full
+func deliveredOrder(t *testing.T, service *ServiceImpl, store *memoryStore) (*orderFixture, *entities.Order) {
+	t.Helper()
+	fixture := newOrderFixture(store, decimal.Zero)
+	order := fixture.confirmOrder(t, service, 2)
+
+	stored := store.orders[order.ID]
+	stored.Status = entities.OrderStatusDelivered
+	stored.PaidAmount = stored.TotalAmount
+	stored.PaymentStatus = entities.PaymentStatusPaid
+	return fixture, order
+}
+
+func TestServiceImpl_ArchiveOrder(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("archived order is read from the archive", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture, order := deliveredOrder(t, service, store)
+		history := len(store.historyOf(order.ID))
+		require.NotZero(t, history)
+
+		require.NoError(t, service.ArchiveOrder(ctx, order.ID.String()))
+		assert.NotContains(t, store.orders, order.ID)
+		assert.Empty(t, store.historyOf(order.ID))
+
+		archived, err := service.GetOrder(ctx, order.ID.String())
+		require.NoError(t, err)
+		assert.True(t, archived.IsArchived())
+		assert.Equal(t, order.OrderNumber, archived.OrderNumber)
+		assert.Len(t, archived.Items, 1)
+
+		timeline, err := service.GetOrderTimeline(ctx, order.ID.String())
+		require.NoError(t, err)
+		statusEvents := 0
+		for _, event := range timeline {
+			if event.Type == entities.TimelineEventStatus {
+				statusEvents++
+			}
+		}
+		assert.Equal(t, history, statusEvents)
+
+		_, err = service.CancelOrder(ctx, order.ID.String(), &CancelOrderRequest{
+			Reason:      "customer request",
+			CancelledBy: fixture.user.String(),
+		})
+		assert.ErrorIs(t, err, ErrOrderAlreadyArchived)
+	})
+
+	t.Run("restored order is live again", func(t *testing.T) {
+		service, store := newTestService(t)
+		_, order := deliveredOrder(t, service, store)
+		require.NoError(t, service.ArchiveOrder(ctx, order.ID.String()))
+
+		require.NoError(t, service.RestoreOrder(ctx, order.ID.String()))
+		assert.NotContains(t, store.archive, order.ID)
+
+		restored, err := service.GetOrder(ctx, order.ID.String())
+		require.NoError(t, err)
+		assert.False(t, restored.IsArchived())
+		assert.Equal(t, entities.OrderStatusDelivered, restored.Status)
+		assert.NotEmpty(t, store.historyOf(order.ID))
+
+		assert.ErrorIs(t, service.RestoreOrder(ctx, order.ID.String()), ErrOrderNotArchived)
+	})
+
+	t.Run("open orders are not archived", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		order := fixture.confirmOrder(t, service, 2)
+		store.resetWrites()
+
+		assert.ErrorIs(t, service.ArchiveOrder(ctx, order.ID.String()), ErrOrderNotArchivable)
+		assert.Empty(t, store.ops())
+	})
+
+	t.Run("unpaid delivered orders are not archived", func(t *testing.T) {
+		service, store := newTestService(t)
+		_, order := deliveredOrder(t, service, store)
+		store.orders[order.ID].PaidAmount = decimal.Zero
+
+		assert.ErrorIs(t, service.ArchiveOrder(ctx, order.ID.String()), ErrOrderNotArchivable)
+		assert.Contains(t, store.orders, order.ID)
+	})
+}
@@ -0,0 +1,87 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"erpgo/internal/domain/orders/entities"
+	userEntities "erpgo/internal/domain/users/entities"
+)
+
+// BackorderNotifier tells customers about order lines waiting for stock.
+// Orders are passed with their customer loaded.
+type BackorderNotifier interface {
+	// BackordersCreated is called when approving an order left lines short of stock
+	BackordersCreated(ctx context.Context, order *entities.Order, backorders []*entities.Backorder) error
+	// BackordersAllocated is called when arriving stock was allocated to an order's backorders
+	BackordersAllocated(ctx context.Context, order *entities.Order, backorders []*entities.Backorder, allocated map[uuid.UUID]int) error
+}
+
+// EmailSender sends a rendered email
+type EmailSender interface {
+	SendEmail(content *userEntities.EmailContent) error
+}
+
+type emailBackorderNotifier struct {
+	sender EmailSender
+}
+
+// NewEmailBackorderNotifier creates a notifier that emails the order's customer
+func NewEmailBackorderNotifier(sender EmailSender) BackorderNotifier {
+	return &emailBackorderNotifier{sender: sender}
+}
+
+func (n *emailBackorderNotifier) BackordersCreated(ctx context.Context, order *entities.Order, backorders []*entities.Backorder) error {
+	lines := make([]string, len(backorders))
+	for i, backorder := range backorders {
+		lines[i] = fmt.Sprintf("%s: %d on backorder", backorder.ProductSKU, backorder.Remaining())
+	}
+
+	return emailCustomer(n.sender, order,
+		fmt.Sprintf("Items on backorder for order %s", order.OrderNumber),
+		fmt.Sprintf("Some items of your order %s are temporarily out of stock. We will ship them as soon as they arrive.", order.OrderNumber),
+		lines,
+	)
+}
+
+func (n *emailBackorderNotifier) BackordersAllocated(ctx context.Context, order *entities.Order, backorders []*entities.Backorder, allocated map[uuid.UUID]int) error {
+	lines := make([]string, 0, len(backorders))
+	for _, backorder := range backorders {
+		line := fmt.Sprintf("%s: %d now in stock", backorder.ProductSKU, allocated[backorder.ID])
+		if remaining := backorder.Remaining(); remaining > 0 {
+			line += fmt.Sprintf(", %d still on backorder", remaining)
+		}
+		lines = append(lines, line)
+	}
+
+	return emailCustomer(n.sender, order,
+		fmt.Sprintf("Backordered items available for order %s", order.OrderNumber),
+		fmt.Sprintf("Good news: backordered items of your order %s are back in stock and reserved for you.", order.OrderNumber),
+		lines,
+	)
+}
+
+// emailCustomer emails the customer of an order an introduction followed by a
+// list of lines. Customers without an email address are not notified.
+func emailCustomer(sender EmailSender, order *entities.Order, subject, intro string, lines []string) error {
+	if order.Customer == nil || strings.TrimSpace(order.Customer.Email) == "" {
+		return nil
+	}
+
+	var htmlLines strings.Builder
+	for _, line := range lines {
+		htmlLines.WriteString("<li>" + html.EscapeString(line) + "</li>")
+	}
+
+	name := order.Customer.GetFullName()
+	return sender.SendEmail(&userEntities.EmailContent{
+		ToEmail:  order.Customer.Email,
+		Subject:  subject,
+		TextBody: fmt.Sprintf("Hello %s,\n\n%s\n\n%s\n", name, intro, strings.Join(lines, "\n")),
+		HTMLBody: fmt.Sprintf("<p>Hello %s,</p><p>%s</p><ul>%s</ul>", html.EscapeString(name), html.EscapeString(intro), htmlLines.String()),
+	})
+}
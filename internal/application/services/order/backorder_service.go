@@ -0,0 +1,326 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	invRepositories "erpgo/internal/domain/inventory/repositories"
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
+	"erpgo/pkg/database"
+)
+
+// BackorderService defines the interface for backorder queue management
+type BackorderService interface {
+	GetBackorder(ctx context.Context, id string) (*entities.Backorder, error)
+	GetOrderBackorders(ctx context.Context, orderID string) ([]*entities.Backorder, error)
+	ListBackorders(ctx context.Context, req *ListBackordersRequest) (*ListBackordersResponse, error)
+
+	// GetBackorderQueue returns the open backorders of a product in allocation order
+	GetBackorderQueue(ctx context.Context, productID string) ([]*entities.Backorder, error)
+	// AllocateStock allocates the available stock of a warehouse to the
+	// product's backorder queue and returns the backorders that received stock
+	AllocateStock(ctx context.Context, req *AllocateBackordersRequest) ([]*entities.Backorder, error)
+	// AllocateBackorders is called when stock of a product arrives in a warehouse
+	AllocateBackorders(ctx context.Context, productID, warehouseID uuid.UUID) error
+}
+
+// ListBackordersRequest represents a request to list backorders
+type ListBackordersRequest struct {
+	Status    []entities.BackorderStatus `json:"status,omitempty"`
+	ProductID *string                    `json:"product_id,omitempty"`
+	OrderID   *string                    `json:"order_id,omitempty"`
+	Page      int                        `json:"page"`
+	Limit     int                        `json:"limit"`
+}
+
+// ListBackordersResponse represents a paginated list of backorders
+type ListBackordersResponse struct {
+	Backorders []*entities.Backorder `json:"backorders"`
+	Pagination *Pagination           `json:"pagination"`
+}
+
+// AllocateBackordersRequest represents a request to allocate warehouse stock to backorders
+type AllocateBackordersRequest struct {
+	ProductID   string `json:"product_id" validate:"required,uuid"`
+	WarehouseID string `json:"warehouse_id" validate:"required,uuid"`
+}
+
+// Backorder errors
+var (
+	ErrBackorderNotFound = errors.New("backorder not found")
+)
+
+// BackorderServiceImpl implements the BackorderService interface
+type BackorderServiceImpl struct {
+	backorderRepo repositories.BackorderRepository
+	orderRepo     repositories.OrderRepository
+	orderItemRepo repositories.OrderItemRepository
+	customerRepo  repositories.CustomerRepository
+	sourcingRepo  repositories.SourcingRepository
+	inventoryRepo invRepositories.InventoryRepository
+	notifier      BackorderNotifier
+	txManager     database.TransactionManagerInterface
+	logger        *zerolog.Logger
+}
+
+// NewBackorderService creates a new backorder service. The notifier may be nil.
+func NewBackorderService(
+	backorderRepo repositories.BackorderRepository,
+	orderRepo repositories.OrderRepository,
+	orderItemRepo repositories.OrderItemRepository,
+	customerRepo repositories.CustomerRepository,
+	sourcingRepo repositories.SourcingRepository,
+	inventoryRepo invRepositories.InventoryRepository,
+	notifier BackorderNotifier,
+	txManager database.TransactionManagerInterface,
+	logger *zerolog.Logger,
+) BackorderService {
+	return &BackorderServiceImpl{
+		backorderRepo: backorderRepo,
+		orderRepo:     orderRepo,
+		orderItemRepo: orderItemRepo,
+		customerRepo:  customerRepo,
+		sourcingRepo:  sourcingRepo,
+		inventoryRepo: inventoryRepo,
+		notifier:      notifier,
+		txManager:     txManager,
+		logger:        logger,
+	}
+}
+
+// GetBackorder retrieves a backorder by ID
+func (s *BackorderServiceImpl) GetBackorder(ctx context.Context, id string) (*entities.Backorder, error) {
+	backorderID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid backorder ID: %w", err)
+	}
+
+	backorder, err := s.backorderRepo.GetByID(ctx, backorderID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrBackorderNotFound
+		}
+		return nil, fmt.Errorf("failed to get backorder: %w", err)
+	}
+
+	return backorder, nil
+}
+
+// GetOrderBackorders retrieves the backorders of an order
+func (s *BackorderServiceImpl) GetOrderBackorders(ctx context.Context, orderID string) ([]*entities.Backorder, error) {
+	id, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order ID: %w", err)
+	}
+
+	backorders, err := s.backorderRepo.GetByOrderID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order backorders: %w", err)
+	}
+
+	return backorders, nil
+}
+
+// ListBackorders lists backorders
+func (s *BackorderServiceImpl) ListBackorders(ctx context.Context, req *ListBackordersRequest) (*ListBackordersResponse, error) {
+	page, limit := normalizePage(req.Page, req.Limit)
+
+	filter := repositories.BackorderFilter{
+		Status: req.Status,
+		Page:   page,
+		Limit:  limit,
+		Offset: (page - 1) * limit,
+	}
+	if req.ProductID != nil {
+		productID, err := uuid.Parse(*req.ProductID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid product ID: %w", err)
+		}
+		filter.ProductID = &productID
+	}
+	if req.OrderID != nil {
+		orderID, err := uuid.Parse(*req.OrderID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid order ID: %w", err)
+		}
+		filter.OrderID = &orderID
+	}
+
+	backorders, err := s.backorderRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backorders: %w", err)
+	}
+
+	total, err := s.backorderRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count backorders: %w", err)
+	}
+
+	return &ListBackordersResponse{
+		Backorders: backorders,
+		Pagination: newPagination(page, limit, total),
+	}, nil
+}
+
+// GetBackorderQueue returns the open backorders of a product in allocation order
+func (s *BackorderServiceImpl) GetBackorderQueue(ctx context.Context, productID string) ([]*entities.Backorder, error) {
+	id, err := uuid.Parse(productID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	return s.queue(ctx, id)
+}
+
+// AllocateStock allocates the available stock of a warehouse to the product's backorder queue
+func (s *BackorderServiceImpl) AllocateStock(ctx context.Context, req *AllocateBackordersRequest) ([]*entities.Backorder, error) {
+	productID, err := uuid.Parse(req.ProductID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+	warehouseID, err := uuid.Parse(req.WarehouseID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid warehouse ID: %w", err)
+	}
+
+	return s.allocate(ctx, productID, warehouseID)
+}
+
+// AllocateBackorders is called when stock of a product arrives in a warehouse
+func (s *BackorderServiceImpl) AllocateBackorders(ctx context.Context, productID, warehouseID uuid.UUID) error {
+	_, err := s.allocate(ctx, productID, warehouseID)
+	return err
+}
+
+// allocate reserves the warehouse's available stock for the product's queue,
+// moving the allocated quantities off the backordered part of the order lines,
+// and notifies the customers once committed
+func (s *BackorderServiceImpl) allocate(ctx context.Context, productID, warehouseID uuid.UUID) ([]*entities.Backorder, error) {
+	allocated := make(map[uuid.UUID]int)
+	var changed []*entities.Backorder
+
+	err := database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		// Reload the queue on every attempt so a retry starts from stored state
+		clear(allocated)
+		changed = changed[:0]
+
+		queue, err := s.queue(ctx, productID)
+		if err != nil || len(queue) == 0 {
+			return err
+		}
+
+		inventory, err := s.inventoryRepo.GetByProductAndWarehouse(ctx, productID, warehouseID)
+		if err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				return nil
+			}
+			return fmt.Errorf("failed to get inventory: %w", err)
+		}
+
+		available := inventory.GetAvailableQuantity()
+		for _, backorder := range queue {
+			if available <= 0 {
+				break
+			}
+			take := min(available, backorder.Remaining())
+
+			if err := s.inventoryRepo.ReserveStock(ctx, productID, warehouseID, take); err != nil {
+				return fmt.Errorf("%w: %v", ErrInventoryReservationFailed, err)
+			}
+			if err := s.sourcingRepo.AddAllocation(ctx, newStockAllocation(backorder.OrderID, backorder.OrderItemID, productID, warehouseID, take)); err != nil {
+				return fmt.Errorf("failed to record order allocation: %w", err)
+			}
+			if err := backorder.Allocate(take); err != nil {
+				return err
+			}
+			if err := s.backorderRepo.Update(ctx, backorder); err != nil {
+				return fmt.Errorf("failed to update backorder: %w", err)
+			}
+
+			item, err := s.orderItemRepo.GetByID(ctx, backorder.OrderItemID)
+			if err != nil {
+				return fmt.Errorf("failed to get order item: %w", err)
+			}
+			item.QuantityBackordered = max(item.QuantityBackordered-take, 0)
+			item.UpdatedAt = time.Now().UTC()
+			if err := s.orderItemRepo.Update(ctx, item); err != nil {
+				return fmt.Errorf("failed to update order item: %w", err)
+			}
+
+			allocated[backorder.ID] = take
+			changed = append(changed, backorder)
+			available -= take
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(changed) > 0 {
+		s.logger.Info().
+			Str("product_id", productID.String()).
+			Str("warehouse_id", warehouseID.String()).
+			Int("backorders", len(changed)).
+			Msg("Allocated stock to backorders")
+		s.notifyAllocated(ctx, changed, allocated)
+	}
+
+	return changed, nil
+}
+
+// queue loads the open backorders of a product in allocation order
+func (s *BackorderServiceImpl) queue(ctx context.Context, productID uuid.UUID) ([]*entities.Backorder, error) {
+	backorders, err := s.backorderRepo.GetOpenByProduct(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get backorder queue: %w", err)
+	}
+
+	entities.SortBackorderQueue(backorders)
+	return backorders, nil
+}
+
+// notifyAllocated sends one notification per order. Failures are logged; the
+// allocation has already been committed.
+func (s *BackorderServiceImpl) notifyAllocated(ctx context.Context, backorders []*entities.Backorder, allocated map[uuid.UUID]int) {
+	if s.notifier == nil {
+		return
+	}
+
+	var orderIDs []uuid.UUID
+	byOrder := make(map[uuid.UUID][]*entities.Backorder)
+	for _, backorder := range backorders {
+		if _, ok := byOrder[backorder.OrderID]; !ok {
+			orderIDs = append(orderIDs, backorder.OrderID)
+		}
+		byOrder[backorder.OrderID] = append(byOrder[backorder.OrderID], backorder)
+	}
+
+	for _, orderID := range orderIDs {
+		order, err := s.orderRepo.GetByID(ctx, orderID)
+		if err != nil {
+			s.logger.Error().Err(err).Str("order_id", orderID.String()).Msg("Failed to load order for backorder notification")
+			continue
+		}
+		if order.Customer == nil {
+			customer, err := s.customerRepo.GetByID(ctx, order.CustomerID)
+			if err != nil {
+				s.logger.Error().Err(err).Str("order_id", orderID.String()).Msg("Failed to load customer for backorder notification")
+				continue
+			}
+			order.Customer = customer
+		}
+
+		if err := s.notifier.BackordersAllocated(ctx, order, byOrder[orderID], allocated); err != nil {
+			s.logger.Error().Err(err).Str("order_id", orderID.String()).Msg("Failed to send backorder notification")
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/pkg/database"
+)
+
+// OverrideCreditHoldRequest represents a credit manager's decision to confirm
+// an order held for exceeding the customer's credit limit
+type OverrideCreditHoldRequest struct {
+	Reason       string `json:"reason" validate:"required"`
+	OverriddenBy string `json:"overridden_by" validate:"required,uuid"`
+}
+
+// OverrideCreditHold confirms an order on credit hold despite the customer's
+// credit limit. The override and its reason are recorded in the order history.
+// The order stays locked from the check of its hold through its confirmation.
+func (s *ServiceImpl) OverrideCreditHold(ctx context.Context, id string, req *OverrideCreditHoldRequest) (*entities.Order, error) {
+	managerID, err := uuid.Parse(req.OverriddenBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid credit manager ID: %w", err)
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, fmt.Errorf("%w: an override reason is required", ErrInvalidStatusTransition)
+	}
+
+	ctx = withActorID(ctx, req.OverriddenBy)
+
+	var order *entities.Order
+	err = database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		if order, err = s.lockOrder(ctx, id); err != nil {
+			return err
+		}
+
+		if !order.CreditHold || order.Status != entities.OrderStatusOnHold {
+			return ErrOrderNotOnCreditHold
+		}
+
+		order.CreditHold = false
+		appendInternalNote(order, "Credit hold overridden: "+reason)
+
+		return s.confirmOrder(ctx, order, managerID, "credit hold overridden: "+reason)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Warn().
+		Str("order_number", order.OrderNumber).
+		Str("customer_id", order.CustomerID.String()).
+		Str("overridden_by", managerID.String()).
+		Str("amount", order.CreditExposure().String()).
+		Str("reason", reason).
+		Msg("Credit hold overridden")
+
+	return order, nil
+}
+
+// checkCredit returns why confirming the order would take its customer over
+// their credit limit, or an empty string when the customer has the credit.
+// Customers without a credit limit are not checked.
+func (s *ServiceImpl) checkCredit(ctx context.Context, order *entities.Order) (string, error) {
+	shortfall, err := s.creditShortfall(ctx, order.CustomerID, order.CreditExposure(), "order of")
+	if shortfall == "" || err != nil {
+		return "", err
+	}
+	return "credit hold: " + shortfall, nil
+}
+
+// creditShortfall returns why taking amount more credit would take the
+// customer over their credit limit, or an empty string when they have it.
+// The amount is in the base currency. The customer stays locked until the
+// transaction of the context ends, so the credit taken by orders approved
+// at the same time is checked one order after the other.
+func (s *ServiceImpl) creditShortfall(ctx context.Context, customerID uuid.UUID, amount decimal.Decimal, what string) (string, error) {
+	if !amount.IsPositive() {
+		return "", nil
+	}
+
+	if err := s.customerRepo.Lock(ctx, customerID); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return "", ErrCustomerNotFound
+		}
+		return "", err
+	}
+	customer, err := s.customerRepo.GetByID(ctx, customerID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return "", ErrCustomerNotFound
+		}
+		return "", fmt.Errorf("failed to get customer: %w", err)
+	}
+	if !customer.CreditLimit.IsPositive() {
+		return "", nil
+	}
+
+	limit, err := s.creditLimit(ctx, customer)
+	if err != nil {
+		return "", err
+	}
+
+	// Used credit is the customer's open receivables plus their unshipped orders
+	exposure, err := s.orderRepo.GetCustomerCreditExposure(ctx, customer.ID)
+	if err != nil {
+		return "", err
+	}
+
+	available := limit.Sub(exposure)
+	if available.GreaterThanOrEqual(amount) {
+		return "", nil
+	}
+
+	return fmt.Sprintf("%s %s %s exceeds available credit of %s %s (limit %s, used %s)",
+		what, amount.StringFixed(2), s.defaultCurrency, decimal.Max(available, decimal.Zero).StringFixed(2),
+		s.defaultCurrency, limit.StringFixed(2), exposure.StringFixed(2)), nil
+}
+
+// creditLimit returns the customer's credit limit in the base currency.
+// Limits are set in the customer's preferred currency and converted at
+// today's rate, as their exposure is kept in the base currency.
+func (s *ServiceImpl) creditLimit(ctx context.Context, customer *entities.Customer) (decimal.Decimal, error) {
+	currency := customer.PreferredCurrency
+	if currency == "" || currency == s.defaultCurrency {
+		return customer.CreditLimit, nil
+	}
+
+	rate, err := s.bookExchangeRate(ctx, currency, time.Now().UTC())
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return customer.CreditLimit.Mul(rate).Round(2), nil
+}
+
+// holdForCredit puts an order that would exceed its customer's credit limit on hold
+func (s *ServiceImpl) holdForCredit(ctx context.Context, order *entities.Order, reason string) error {
+	if order.Status == entities.OrderStatusOnHold {
+		return fmt.Errorf("%w: %s", ErrCreditLimitExceeded, reason)
+	}
+
+	order.CreditHold = true
+	appendInternalNote(order, "On hold: "+reason)
+
+	if err := s.transitionOrder(ctx, order, entities.OrderStatusOnHold, reason); err != nil {
+		return err
+	}
+
+	s.logger.Info().
+		Str("order_number", order.OrderNumber).
+		Str("customer_id", order.CustomerID.String()).
+		Str("reason", reason).
+		Msg("Order placed on credit hold")
+
+	return nil
+}
+
+// confirmOrder approves an order, reserves its inventory and confirms it
+func (s *ServiceImpl) confirmOrder(ctx context.Context, order *entities.Order, approverID uuid.UUID, reason string) error {
+	now := time.Now().UTC()
+	order.ApprovedBy = &approverID
+	order.ApprovedAt = &now
+
+	var backorders []*entities.Backorder
+	err := database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		if backorders, err = s.reserveItems(ctx, order, approverID); err != nil {
+			return err
+		}
+		if err := s.placeDropShipOrders(ctx, order, approverID); err != nil {
+			return err
+		}
+		return s.transitionOrder(ctx, order, entities.OrderStatusConfirmed, reason)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.notifyBackorders(ctx, order, backorders)
+	return nil
+}
+
+// syncCustomerCredit sets the credit used by a customer to their open
+// receivables plus unshipped orders, booking approved orders against the
+// credit limit and releasing paid, cancelled and returned ones
+func (s *ServiceImpl) syncCustomerCredit(ctx context.Context, customerID uuid.UUID) error {
+	exposure, err := s.orderRepo.GetCustomerCreditExposure(ctx, customerID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.customerRepo.UpdateCreditUsed(ctx, customerID, exposure); err != nil {
+		return fmt.Errorf("failed to update customer credit: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"erpgo/internal/domain/orders/entities"
+)
+
+// creditedLine is the part of a refund paid back for an order line
+type creditedLine struct {
+	item     *entities.OrderItem
+	quantity int
+	amount   decimal.Decimal
+}
+
+// issueCreditNote credits a refund against the latest issued invoice of the
+// order. Refunds of orders that were never invoiced have nothing to credit.
+// Refunds of order lines are credited per line at the line's tax rate; other
+// refunds are credited as one amount at the invoice's effective tax rate.
+func (s *ServiceImpl) issueCreditNote(ctx context.Context, order *entities.Order, amount decimal.Decimal, lines []creditedLine, reason string) error {
+	invoices, err := s.invoiceRepo.GetByOrderID(ctx, order.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get order invoices: %w", err)
+	}
+
+	var credited *entities.Invoice
+	for _, invoice := range invoices {
+		if invoice.Type == entities.InvoiceTypeInvoice && invoice.Status == entities.InvoiceStatusIssued {
+			credited = invoice
+		}
+	}
+	if credited == nil {
+		s.logger.Debug().Str("order_number", order.OrderNumber).Msg("Refund of uninvoiced order, no credit note issued")
+		return nil
+	}
+
+	now := time.Now().UTC()
+	reason = strings.TrimSpace(reason)
+	creditNote := &entities.Invoice{
+		ID:                uuid.New(),
+		Type:              entities.InvoiceTypeCreditNote,
+		Status:            entities.InvoiceStatusDraft,
+		OrderID:           order.ID,
+		CreditedInvoiceID: &credited.ID,
+		CustomerID:        order.CustomerID,
+		BillingAddressID:  credited.BillingAddressID,
+		Currency:          credited.Currency,
+		PaymentTerms:      credited.PaymentTerms,
+		Reason:            &reason,
+		CreatedBy:         ledgerActor(ctx, order.CreatedBy),
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+
+	for _, credit := range lines {
+		line := entities.NewCreditLine(credit.item.ProductName, credit.quantity, credit.amount, credit.item.TaxRate)
+		itemID, productID := credit.item.ID, credit.item.ProductID
+		line.InvoiceID = creditNote.ID
+		line.OrderItemID = &itemID
+		line.ProductID = &productID
+		line.ProductSKU = credit.item.ProductSKU
+		creditNote.Lines = append(creditNote.Lines, line)
+	}
+	if len(lines) == 0 {
+		line := entities.NewCreditLine("Refund: "+reason, 1, amount, effectiveTaxRate(credited))
+		line.InvoiceID = creditNote.ID
+		creditNote.Lines = append(creditNote.Lines, line)
+	}
+
+	if err := creditNote.CalculateTotals(decimal.Zero); err != nil {
+		return fmt.Errorf("failed to calculate credit note totals: %w", err)
+	}
+	if err := creditNote.Issue(creditNote.CreatedBy, now); err != nil {
+		return fmt.Errorf("%w: %v", ErrRefundFailed, err)
+	}
+
+	if err := s.invoiceRepo.Create(ctx, creditNote); err != nil {
+		return fmt.Errorf("failed to create credit note: %w", err)
+	}
+
+	s.logger.Info().
+		Str("order_number", order.OrderNumber).
+		Str("credit_note_number", creditNote.InvoiceNumber).
+		Str("credited_invoice", credited.InvoiceNumber).
+		Str("amount", creditNote.TotalAmount.String()).
+		Msg("Credit note issued")
+
+	return nil
+}
+
+// effectiveTaxRate returns the overall tax rate of an invoice in percent
+func effectiveTaxRate(invoice *entities.Invoice) decimal.Decimal {
+	net := invoice.TotalAmount.Sub(invoice.TaxAmount)
+	if !net.IsPositive() {
+		return decimal.Zero
+	}
+	return invoice.TaxAmount.Div(net).Mul(decimal.NewFromInt(100)).Round(2)
+}
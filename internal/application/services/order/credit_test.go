@@ -0,0 +1,151 @@
+package order
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"erpgo/internal/domain/orders/entities"
+)
+
+// holdOrder creates an order of 5 units, 250.00, for a customer with a
+// credit limit of 200.00 and approves it onto credit hold
+func holdOrder(t *testing.T, service *ServiceImpl, store *memoryStore) (*orderFixture, *entities.Order) {
+	t.Helper()
+	fixture := newOrderFixture(store, decimal.NewFromInt(200))
+	order := fixture.createOrder(t, service, 5)
+
+	held, err := service.ApproveOrder(context.Background(), order.ID.String(), fixture.user.String())
+	require.NoError(t, err)
+	return fixture, held
+}
+
+func TestServiceImpl_CreditHold(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("orders over the credit limit are held", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture, held := holdOrder(t, service, store)
+
+		assert.Equal(t, entities.OrderStatusOnHold, held.Status)
+		assert.True(t, held.CreditHold)
+		assert.Nil(t, held.ApprovedAt)
+		assert.Zero(t, store.reserved(fixture.product.ID, fixture.warehouse.ID))
+		assert.True(t, store.customers[fixture.customer.ID].CreditUsed.IsZero())
+		assert.Equal(t, []uuid.UUID{fixture.customer.ID}, store.lockedCustomers, "the customer is locked while their credit is checked")
+
+		history := store.historyOf(held.ID)
+		assert.True(t, strings.HasPrefix(history[len(history)-1].Reason, "credit hold"), history[len(history)-1].Reason)
+	})
+
+	t.Run("orders within the credit limit are confirmed", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.NewFromInt(300))
+		order := fixture.confirmOrder(t, service, 5)
+
+		assert.False(t, order.CreditHold)
+		assert.True(t, decimal.NewFromInt(250).Equal(store.customers[fixture.customer.ID].CreditUsed))
+	})
+
+	t.Run("credit limits are converted from the customer's currency", func(t *testing.T) {
+		service, store := newTestService(t)
+		service.currencies = fakeCurrencyConverter{rates: map[string]decimal.Decimal{
+			"EUR": decimal.RequireFromString("1.5"),
+			"GBP": decimal.RequireFromString("0.8"),
+		}}
+
+		// 200.00 EUR is 300.00 USD, enough for the 250.00 USD order
+		fixture := newOrderFixture(store, decimal.NewFromInt(200))
+		fixture.customer.PreferredCurrency = "EUR"
+		order := fixture.confirmOrder(t, service, 5)
+		assert.False(t, order.CreditHold)
+
+		// 300.00 GBP is 240.00 USD, short of it
+		fixture = newOrderFixture(store, decimal.NewFromInt(300))
+		fixture.customer.PreferredCurrency = "GBP"
+		order = fixture.createOrder(t, service, 5)
+		held, err := service.ApproveOrder(ctx, order.ID.String(), fixture.user.String())
+		require.NoError(t, err)
+		assert.True(t, held.CreditHold)
+		history := store.historyOf(held.ID)
+		assert.Contains(t, history[len(history)-1].Reason, "limit 240.00")
+	})
+
+	t.Run("releasing the hold returns the order for approval", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture, held := holdOrder(t, service, store)
+
+		released, err := service.UnholdOrder(ctx, held.ID.String())
+		require.NoError(t, err)
+		assert.Equal(t, entities.OrderStatusPending, released.Status)
+		assert.False(t, released.CreditHold)
+
+		// The customer still lacks the credit, so approval holds it again
+		again, err := service.ApproveOrder(ctx, held.ID.String(), fixture.user.String())
+		require.NoError(t, err)
+		assert.Equal(t, entities.OrderStatusOnHold, again.Status)
+		assert.True(t, again.CreditHold)
+	})
+
+	t.Run("credit manager overrides the hold", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture, held := holdOrder(t, service, store)
+		store.resetWrites()
+
+		confirmed, err := service.OverrideCreditHold(ctx, held.ID.String(), &OverrideCreditHoldRequest{
+			Reason:       "long-standing customer",
+			OverriddenBy: fixture.user.String(),
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, entities.OrderStatusConfirmed, confirmed.Status)
+		assert.False(t, confirmed.CreditHold)
+		assert.Equal(t, fixture.user, *confirmed.ApprovedBy)
+		require.NotNil(t, confirmed.InternalNotes)
+		assert.Contains(t, *confirmed.InternalNotes, "Credit hold overridden: long-standing customer")
+		assert.Equal(t, 5, store.reserved(fixture.product.ID, fixture.warehouse.ID))
+		assert.True(t, decimal.NewFromInt(250).Equal(store.customers[fixture.customer.ID].CreditUsed))
+		assert.Equal(t, []uuid.UUID{held.ID}, store.locked)
+		assert.Empty(t, store.untransacted())
+
+		history := store.historyOf(held.ID)
+		assert.Equal(t, "credit hold overridden: long-standing customer", history[len(history)-1].Reason)
+	})
+
+	t.Run("override needs a reason and a held order", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture, held := holdOrder(t, service, store)
+
+		_, err := service.OverrideCreditHold(ctx, held.ID.String(), &OverrideCreditHoldRequest{
+			Reason:       " ",
+			OverriddenBy: fixture.user.String(),
+		})
+		assert.ErrorIs(t, err, ErrInvalidStatusTransition)
+
+		_, err = service.UnholdOrder(ctx, held.ID.String())
+		require.NoError(t, err)
+		_, err = service.OverrideCreditHold(ctx, held.ID.String(), &OverrideCreditHoldRequest{
+			Reason:       "long-standing customer",
+			OverriddenBy: fixture.user.String(),
+		})
+		assert.ErrorIs(t, err, ErrOrderNotOnCreditHold)
+	})
+
+	t.Run("cancelling a confirmed order releases its credit", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.NewFromInt(300))
+		order := fixture.confirmOrder(t, service, 5)
+
+		_, err := service.CancelOrder(ctx, order.ID.String(), &CancelOrderRequest{
+			Reason:      "customer request",
+			CancelledBy: fixture.user.String(),
+		})
+		require.NoError(t, err)
+		assert.True(t, store.customers[fixture.customer.ID].CreditUsed.IsZero())
+	})
+}
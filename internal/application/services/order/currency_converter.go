@@ -0,0 +1,67 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
+)
+
+// CurrencyConverter converts between currencies and the company base currency
+// that reports are kept in
+type CurrencyConverter interface {
+	BaseCurrency() string
+	// ExchangeRate returns the rate converting one unit of from into to on the date
+	ExchangeRate(ctx context.Context, from, to string, at time.Time) (decimal.Decimal, error)
+}
+
+type tableCurrencyConverter struct {
+	rateRepo     repositories.ExchangeRateRepository
+	baseCurrency string
+}
+
+// NewTableCurrencyConverter creates a converter that looks rates up in the
+// exchange rate table, crossing pairs without a direct rate
+func NewTableCurrencyConverter(rateRepo repositories.ExchangeRateRepository, baseCurrency string) CurrencyConverter {
+	return &tableCurrencyConverter{rateRepo: rateRepo, baseCurrency: baseCurrency}
+}
+
+func (c *tableCurrencyConverter) BaseCurrency() string {
+	return c.baseCurrency
+}
+
+func (c *tableCurrencyConverter) ExchangeRate(ctx context.Context, from, to string, at time.Time) (decimal.Decimal, error) {
+	if from == to {
+		return decimal.NewFromInt(1), nil
+	}
+
+	rates, err := c.rateRepo.GetEffective(ctx, at)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("failed to get exchange rates: %w", err)
+	}
+
+	rate, ok := entities.ResolveExchangeRate(from, to, rates)
+	if !ok {
+		return decimal.Zero, fmt.Errorf("%w: %s to %s on %s", ErrExchangeRateUnavailable, from, to, at.Format("2006-01-02"))
+	}
+	return rate, nil
+}
+
+// bookExchangeRate returns the rate an order in the currency is booked at.
+// Orders cannot be booked in a currency without a rate into the base
+// currency, as their revenue could not be reported.
+func (s *ServiceImpl) bookExchangeRate(ctx context.Context, currency string, at time.Time) (decimal.Decimal, error) {
+	if s.currencies == nil {
+		return decimal.NewFromInt(1), nil
+	}
+
+	rate, err := s.currencies.ExchangeRate(ctx, currency, s.currencies.BaseCurrency(), at)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return rate, nil
+}
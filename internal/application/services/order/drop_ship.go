@@ -0,0 +1,659 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"erpgo/internal/domain/orders/entities"
+	purchasingEntities "erpgo/internal/domain/purchasing/entities"
+	"erpgo/pkg/database"
+)
+
+// ConfirmDropShipmentRequest represents a supplier's confirmation that it
+// shipped lines of a drop-ship purchase order to the customer
+type ConfirmDropShipmentRequest struct {
+	// Items lists the shipped purchase order lines; without items every
+	// outstanding line has shipped
+	Items          []DropShipmentItemRequest `json:"items,omitempty" validate:"omitempty,dive"`
+	TrackingNumber string                    `json:"tracking_number,omitempty"`
+	Carrier        string                    `json:"carrier,omitempty"`
+	ShippingDate   *time.Time                `json:"shipping_date,omitempty"`
+	ConfirmedBy    string                    `json:"confirmed_by" validate:"required,uuid"`
+}
+
+// DropShipmentItemRequest represents the quantity shipped of a drop-ship purchase order line
+type DropShipmentItemRequest struct {
+	PurchaseOrderItemID string `json:"purchase_order_item_id" validate:"required,uuid"`
+	Quantity            int    `json:"quantity" validate:"required,min=1"`
+}
+
+// DropShipment is a supplier shipment booked against a drop-ship purchase
+// order and the sales order it ships
+type DropShipment struct {
+	PurchaseOrder *purchasingEntities.PurchaseOrder `json:"purchase_order"`
+	Order         *entities.Order                   `json:"order"`
+	Shipment      *entities.Shipment                `json:"shipment"`
+}
+
+// ImportDropShipConfirmationsRequest represents a request to import a
+// supplier's shipment confirmation file
+type ImportDropShipConfirmationsRequest struct {
+	File       io.Reader                  `json:"-"`
+	Format     entities.OrderImportFormat `json:"format" validate:"required,oneof=CSV JSON"`
+	ImportedBy string                     `json:"imported_by" validate:"required,uuid"`
+}
+
+// ImportedDropShipment is a supplier shipment of a confirmation file
+type ImportedDropShipment struct {
+	PONumber       string `json:"po_number"`
+	OrderNumber    string `json:"order_number"`
+	TrackingNumber string `json:"tracking_number,omitempty"`
+	ShipmentNumber string `json:"shipment_number"`
+	Rows           []int  `json:"rows"`
+}
+
+// ImportDropShipConfirmationsResponse reports the outcome of a confirmation
+// import. Shipments are booked only when no row of the file has errors.
+type ImportDropShipConfirmationsResponse struct {
+	Committed bool                                 `json:"committed"`
+	TotalRows int                                  `json:"total_rows"`
+	Shipments []ImportedDropShipment               `json:"shipments"`
+	Errors    []entities.DropShipConfirmationError `json:"errors"`
+}
+
+// Drop-ship errors
+var (
+	ErrInvalidDropShip       = errors.New("invalid drop-ship")
+	ErrDropShipOrderNotFound = errors.New("drop-ship purchase order not found")
+	ErrInvalidDropShipment   = errors.New("invalid drop-ship shipment")
+)
+
+// ConfirmDropShipment books a supplier's shipment of a drop-ship purchase
+// order: the purchase order lines are marked shipped and the sales order
+// lines they fulfil ship in one shipment, without moving warehouse stock.
+// A confirmed sales order moves into processing first.
+func (s *ServiceImpl) ConfirmDropShipment(ctx context.Context, purchaseOrderID string, req *ConfirmDropShipmentRequest) (*DropShipment, error) {
+	id, err := uuid.Parse(purchaseOrderID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid purchase order ID: %w", err)
+	}
+	if _, err := uuid.Parse(req.ConfirmedBy); err != nil {
+		return nil, fmt.Errorf("invalid confirmed by user ID: %w", err)
+	}
+
+	po, err := s.poRepo.GetByID(ctx, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrDropShipOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to get purchase order: %w", err)
+	}
+	if !po.IsDropShip() {
+		return nil, ErrDropShipOrderNotFound
+	}
+
+	quantities := make(map[uuid.UUID]int)
+	for _, item := range req.Items {
+		itemID, err := uuid.Parse(item.PurchaseOrderItemID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid purchase order item ID: %w", err)
+		}
+		if item.Quantity <= 0 {
+			return nil, ErrInvalidQuantity
+		}
+		quantities[itemID] += item.Quantity
+	}
+	if len(req.Items) == 0 {
+		for _, item := range po.Items {
+			if outstanding := item.OutstandingQuantity(); outstanding > 0 {
+				quantities[item.ID] = outstanding
+			}
+		}
+	}
+
+	details := shipmentDetails{
+		trackingNumber: req.TrackingNumber,
+		carrier:        req.Carrier,
+		shippingDate:   req.ShippingDate,
+		shippedBy:      req.ConfirmedBy,
+	}
+
+	var shipment *DropShipment
+	err = database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		shipment, err = s.confirmDropShipment(ctx, po, quantities, details)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return shipment, nil
+}
+
+// ImportDropShipConfirmations books the supplier shipments of a confirmation
+// file. Rows are grouped into one shipment per purchase order and tracking
+// number and matched to the purchase order lines by SKU. Nothing is booked
+// when any row has errors.
+func (s *ServiceImpl) ImportDropShipConfirmations(ctx context.Context, req *ImportDropShipConfirmationsRequest) (*ImportDropShipConfirmationsResponse, error) {
+	if _, err := uuid.Parse(req.ImportedBy); err != nil {
+		return nil, fmt.Errorf("invalid imported by user ID: %w", err)
+	}
+
+	rows, rowErrors, err := entities.ParseDropShipConfirmations(req.File, req.Format)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+	}
+	total := len(rows) + len(rowErrors)
+	if total == 0 {
+		return nil, fmt.Errorf("%w: file has no rows", ErrInvalidImportFile)
+	}
+	if total > maxImportRows {
+		return nil, fmt.Errorf("%w: file has %d rows, at most %d are imported at once", ErrInvalidImportFile, total, maxImportRows)
+	}
+
+	response := &ImportDropShipConfirmationsResponse{
+		TotalRows: total,
+		Shipments: []ImportedDropShipment{},
+		Errors:    rowErrors,
+	}
+	if response.Errors == nil {
+		response.Errors = []entities.DropShipConfirmationError{}
+	}
+
+	groups := entities.GroupDropShipConfirmations(rows)
+	purchaseOrders := make(map[string]*purchasingEntities.PurchaseOrder)
+	orders := make(map[uuid.UUID]*entities.Order)
+	// pending is the quantity of each purchase order line and sales order
+	// line shipped by earlier rows of the file
+	pending := make(map[uuid.UUID]int)
+	quantities := make([]map[uuid.UUID]int, len(groups))
+
+	for i, group := range groups {
+		po, err := s.importedDropShipOrder(ctx, purchaseOrders, group.PONumber)
+		if err != nil {
+			if !errors.Is(err, ErrDropShipOrderNotFound) && !errors.Is(err, ErrInvalidDropShipment) {
+				return nil, err
+			}
+			for _, row := range group.Rows {
+				response.Errors = append(response.Errors, row.Error(entities.DropShipConfirmationFieldPONumber, "%s", err.Error()))
+			}
+			continue
+		}
+
+		quantities[i] = make(map[uuid.UUID]int)
+		for _, row := range group.Rows {
+			lines, err := dropShipLineQuantities(po, row, pending)
+			if err != nil {
+				response.Errors = append(response.Errors, row.Error(entities.DropShipConfirmationFieldQuantity, "%s", err.Error()))
+				continue
+			}
+			for itemID, quantity := range lines {
+				quantities[i][itemID] += quantity
+				pending[itemID] += quantity
+			}
+		}
+
+		if err := s.checkImportedDropShipment(ctx, orders, po, quantities[i], pending); err != nil {
+			if !errors.Is(err, ErrOrderNotFound) && !errors.Is(err, ErrInvalidDropShipment) {
+				return nil, err
+			}
+			for _, row := range group.Rows {
+				response.Errors = append(response.Errors, row.Error("", "%s", err.Error()))
+			}
+		}
+	}
+
+	if len(response.Errors) > 0 {
+		return response, nil
+	}
+
+	err = database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		for i, group := range groups {
+			details := shipmentDetails{
+				trackingNumber: group.TrackingNumber,
+				carrier:        group.Carrier,
+				shippingDate:   group.ShippedAt,
+				shippedBy:      req.ImportedBy,
+			}
+			shipment, err := s.confirmDropShipment(ctx, purchaseOrders[group.PONumber], quantities[i], details)
+			if err != nil {
+				return fmt.Errorf("purchase order %s: %w", group.PONumber, err)
+			}
+
+			imported := ImportedDropShipment{
+				PONumber:       group.PONumber,
+				OrderNumber:    shipment.Order.OrderNumber,
+				TrackingNumber: group.TrackingNumber,
+				ShipmentNumber: shipment.Shipment.ShipmentNumber,
+			}
+			for _, row := range group.Rows {
+				imported.Rows = append(imported.Rows, row.Number)
+			}
+			response.Shipments = append(response.Shipments, imported)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	response.Committed = true
+
+	s.logger.Info().
+		Int("rows", response.TotalRows).
+		Int("shipments", len(response.Shipments)).
+		Msg("Drop-ship confirmations imported")
+
+	return response, nil
+}
+
+// confirmDropShipment books the shipped quantities of the purchase order
+// lines against the purchase order and ships the sales order lines they
+// fulfil. It runs in the caller's transaction, which it locks the sales order in.
+func (s *ServiceImpl) confirmDropShipment(ctx context.Context, po *purchasingEntities.PurchaseOrder, quantities map[uuid.UUID]int, details shipmentDetails) (*DropShipment, error) {
+	order, err := s.lockOrder(ctx, po.SalesOrderID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := po.ConfirmShipment(quantities); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDropShipment, err)
+	}
+
+	shipped := make(map[uuid.UUID]int, len(quantities))
+	for itemID, quantity := range quantities {
+		shipped[*po.FindItem(itemID).SalesOrderItemID] += quantity
+	}
+
+	ctx = withActorID(ctx, details.shippedBy)
+	if order.Status == entities.OrderStatusConfirmed {
+		if err := s.transitionOrder(ctx, order, entities.OrderStatusProcessing, "drop-ship purchase order "+po.PONumber+" shipped"); err != nil {
+			return nil, err
+		}
+	}
+
+	details.purchaseOrder = po
+	shipment, err := s.shipItems(ctx, order, shipped, details)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info().
+		Str("order_number", order.OrderNumber).
+		Str("po_number", po.PONumber).
+		Str("shipment_number", shipment.ShipmentNumber).
+		Msg("Drop-ship shipment confirmed")
+
+	return &DropShipment{PurchaseOrder: po, Order: order, Shipment: shipment}, nil
+}
+
+// importedDropShipOrder loads the open drop-ship purchase order of a PO
+// number, once per import
+func (s *ServiceImpl) importedDropShipOrder(ctx context.Context, loaded map[string]*purchasingEntities.PurchaseOrder, poNumber string) (*purchasingEntities.PurchaseOrder, error) {
+	if po, ok := loaded[poNumber]; ok {
+		return po, nil
+	}
+
+	po, err := s.poRepo.GetByNumber(ctx, poNumber)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, fmt.Errorf("%w: %s", ErrDropShipOrderNotFound, poNumber)
+		}
+		return nil, fmt.Errorf("failed to get purchase order: %w", err)
+	}
+	if !po.IsDropShip() {
+		return nil, fmt.Errorf("%w: %s is not a drop-ship purchase order", ErrInvalidDropShipment, poNumber)
+	}
+	if !po.CanReceive() {
+		return nil, fmt.Errorf("%w: purchase order %s is %s", ErrInvalidDropShipment, poNumber, po.Status)
+	}
+
+	loaded[poNumber] = po
+	return po, nil
+}
+
+// checkImportedDropShipment checks that the sales order of a drop-ship
+// purchase order can ship the lines of an imported shipment, counting the
+// quantities shipped by earlier shipments of the file as pending
+func (s *ServiceImpl) checkImportedDropShipment(ctx context.Context, orders map[uuid.UUID]*entities.Order, po *purchasingEntities.PurchaseOrder, quantities map[uuid.UUID]int, pending map[uuid.UUID]int) error {
+	order, ok := orders[*po.SalesOrderID]
+	if !ok {
+		var err error
+		if order, err = s.loadOrder(ctx, po.SalesOrderID.String()); err != nil {
+			return err
+		}
+		orders[order.ID] = order
+	}
+
+	switch order.Status {
+	case entities.OrderStatusConfirmed, entities.OrderStatusProcessing, entities.OrderStatusPartiallyShipped:
+	default:
+		return fmt.Errorf("%w: sales order %s is %s", ErrInvalidDropShipment, order.OrderNumber, order.Status)
+	}
+
+	for itemID, quantity := range quantities {
+		line := po.FindItem(itemID)
+		item, err := findOrderItem(order, line.SalesOrderItemID.String())
+		if err != nil {
+			return fmt.Errorf("%w: %s is no longer on sales order %s", ErrInvalidDropShipment, line.ProductSKU, order.OrderNumber)
+		}
+		pending[item.ID] += quantity
+		if !item.IsDropShip || pending[item.ID] > item.ShippableQuantity() {
+			return fmt.Errorf("%w: sales order %s cannot ship %d of %s", ErrInvalidDropShipment, order.OrderNumber, pending[item.ID], item.ProductSKU)
+		}
+	}
+
+	return nil
+}
+
+// dropShipLineQuantities spreads the quantity of a confirmation row over the
+// outstanding lines of the purchase order carrying its SKU, in line order
+func dropShipLineQuantities(po *purchasingEntities.PurchaseOrder, row entities.DropShipConfirmationRow, pending map[uuid.UUID]int) (map[uuid.UUID]int, error) {
+	lines := make(map[uuid.UUID]int)
+	remaining := row.Quantity
+	outstanding := 0
+	found := false
+
+	for _, item := range po.Items {
+		if !strings.EqualFold(item.ProductSKU, row.SKU) {
+			continue
+		}
+		found = true
+		available := item.OutstandingQuantity() - pending[item.ID]
+		if available <= 0 {
+			continue
+		}
+		outstanding += available
+		take := min(remaining, available)
+		if take > 0 {
+			lines[item.ID] = take
+			remaining -= take
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("%s is not on purchase order %s", row.SKU, po.PONumber)
+	}
+	if remaining > 0 {
+		return nil, fmt.Errorf("cannot ship %d of %s: only %d outstanding on purchase order %s", row.Quantity, row.SKU, outstanding, po.PONumber)
+	}
+	return lines, nil
+}
+
+// applyDropShip overrides whether the supplier ships a new line. Lines of
+// products only sold drop-ship cannot be shipped from a warehouse, and only
+// products with a supplier can be drop-shipped.
+func (s *ServiceImpl) applyDropShip(ctx context.Context, item *entities.OrderItem, dropShip *bool) error {
+	if dropShip == nil || *dropShip == item.IsDropShip {
+		return nil
+	}
+	if !*dropShip {
+		return fmt.Errorf("%w: %s is only shipped by its supplier", ErrInvalidDropShip, item.ProductSKU)
+	}
+
+	product, err := s.productRepo.GetByID(ctx, item.ProductID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return ErrProductNotFound
+		}
+		return fmt.Errorf("failed to get product: %w", err)
+	}
+	if product.IsDigital {
+		return fmt.Errorf("%w: %s is digital", ErrInvalidDropShip, product.SKU)
+	}
+	if product.SupplierID == nil {
+		return fmt.Errorf("%w: %s has no supplier to ship it", ErrInvalidDropShip, product.SKU)
+	}
+
+	item.IsDropShip = true
+	return nil
+}
+
+// placeDropShipOrders orders the drop-ship lines of a confirmed order from
+// the suppliers of their products: one ORDERED purchase order per supplier,
+// shipped to the order's shipping address. Quantities already on drop-ship
+// purchase orders of the order that were not cancelled are not ordered again.
+func (s *ServiceImpl) placeDropShipOrders(ctx context.Context, order *entities.Order, orderedBy uuid.UUID) error {
+	var lines []*entities.OrderItem
+	for i := range order.Items {
+		if order.Items[i].IsDropShip {
+			lines = append(lines, &order.Items[i])
+		}
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+
+	existing, err := s.poRepo.GetBySalesOrderID(ctx, order.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get drop-ship purchase orders: %w", err)
+	}
+	ordered := dropShipOrderedQuantities(existing)
+
+	var shipTo *purchasingEntities.DropShipAddress
+	now := time.Now().UTC()
+	bySupplier := make(map[uuid.UUID]*purchasingEntities.PurchaseOrder)
+	suppliers := make(map[uuid.UUID]string)
+	var placed []*purchasingEntities.PurchaseOrder
+
+	for _, item := range lines {
+		quantity := item.Quantity - ordered[item.ID]
+		if quantity <= 0 {
+			continue
+		}
+
+		product, err := s.productRepo.GetByID(ctx, item.ProductID)
+		if err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				return ErrProductNotFound
+			}
+			return fmt.Errorf("failed to get product: %w", err)
+		}
+		if product.SupplierID == nil {
+			return fmt.Errorf("%w: %s has no supplier to ship it", ErrInvalidDropShip, product.SKU)
+		}
+
+		po, ok := bySupplier[*product.SupplierID]
+		if !ok {
+			supplier, err := s.supplierRepo.GetByID(ctx, *product.SupplierID)
+			if err != nil {
+				if strings.Contains(err.Error(), "not found") {
+					return fmt.Errorf("%w: supplier of %s not found", ErrInvalidDropShip, product.SKU)
+				}
+				return fmt.Errorf("failed to get supplier: %w", err)
+			}
+			if !supplier.IsActive {
+				return fmt.Errorf("%w: supplier %s of %s is inactive", ErrInvalidDropShip, supplier.SupplierCode, product.SKU)
+			}
+			if shipTo == nil {
+				if shipTo, err = s.dropShipAddress(ctx, order); err != nil {
+					return err
+				}
+			}
+
+			salesOrderID := order.ID
+			note := "Drop-ship for sales order " + order.OrderNumber
+			po = &purchasingEntities.PurchaseOrder{
+				ID:           uuid.New(),
+				SupplierID:   supplier.ID,
+				SalesOrderID: &salesOrderID,
+				ShipTo:       shipTo,
+				Status:       purchasingEntities.PurchaseOrderStatusDraft,
+				OrderDate:    now,
+				Currency:     supplier.Currency,
+				PaymentTerms: supplier.PaymentTerms,
+				Notes:        &note,
+				CreatedBy:    orderedBy,
+				CreatedAt:    now,
+				UpdatedAt:    now,
+			}
+			if supplier.LeadTimeDays > 0 {
+				expected := supplier.ExpectedDeliveryDate(now)
+				po.ExpectedDate = &expected
+			}
+			bySupplier[supplier.ID] = po
+			suppliers[supplier.ID] = supplier.Name
+			placed = append(placed, po)
+		}
+
+		itemID := item.ID
+		po.Items = append(po.Items, purchasingEntities.PurchaseOrderItem{
+			ID:               uuid.New(),
+			PurchaseOrderID:  po.ID,
+			ProductID:        product.ID,
+			ProductSKU:       product.SKU,
+			ProductName:      product.Name,
+			QuantityOrdered:  quantity,
+			UnitCost:         product.Cost,
+			ExpectedDate:     po.ExpectedDate,
+			SalesOrderItemID: &itemID,
+			CreatedAt:        now,
+			UpdatedAt:        now,
+		})
+	}
+
+	for _, po := range placed {
+		po.CalculateTotals()
+		if err := po.Validate(); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidDropShip, err)
+		}
+		if err := po.ChangeStatus(purchasingEntities.PurchaseOrderStatusOrdered); err != nil {
+			return err
+		}
+		if err := s.poRepo.Create(ctx, po); err != nil {
+			return fmt.Errorf("failed to create drop-ship purchase order: %w", err)
+		}
+
+		appendInternalNote(order, fmt.Sprintf("Drop-ship purchase order %s placed with %s", po.PONumber, suppliers[po.SupplierID]))
+		s.logger.Info().
+			Str("order_number", order.OrderNumber).
+			Str("po_number", po.PONumber).
+			Str("supplier_id", po.SupplierID.String()).
+			Int("lines", len(po.Items)).
+			Msg("Drop-ship purchase order placed")
+	}
+
+	return nil
+}
+
+// withdrawDropShipOrders cancels the drop-ship purchase orders of a
+// cancelled order. Purchase orders the supplier has shipped part of are
+// closed short instead.
+func (s *ServiceImpl) withdrawDropShipOrders(ctx context.Context, order *entities.Order, reason string) error {
+	purchaseOrders, err := s.poRepo.GetBySalesOrderID(ctx, order.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get drop-ship purchase orders: %w", err)
+	}
+
+	note := "Sales order " + order.OrderNumber + " cancelled"
+	if reason = strings.TrimSpace(reason); reason != "" {
+		note += ": " + reason
+	}
+
+	for _, po := range purchaseOrders {
+		switch po.Status {
+		case purchasingEntities.PurchaseOrderStatusDraft, purchasingEntities.PurchaseOrderStatusOrdered:
+			po.CancellationReason = &note
+			if err := po.ChangeStatus(purchasingEntities.PurchaseOrderStatusCancelled); err != nil {
+				return err
+			}
+		case purchasingEntities.PurchaseOrderStatusPartiallyReceived:
+			if err := po.ChangeStatus(purchasingEntities.PurchaseOrderStatusClosed); err != nil {
+				return err
+			}
+		default:
+			continue
+		}
+
+		if err := s.poRepo.Update(ctx, po); err != nil {
+			return fmt.Errorf("failed to update drop-ship purchase order: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// checkDropShipAmendment refuses amendments the drop-ship purchase orders
+// already placed for the order cannot follow: removing or reducing a line
+// below the quantity ordered from its supplier, or changing the shipping
+// address while a supplier still has lines to ship
+func (s *ServiceImpl) checkDropShipAmendment(ctx context.Context, order, amended *entities.Order) error {
+	purchaseOrders, err := s.poRepo.GetBySalesOrderID(ctx, order.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get drop-ship purchase orders: %w", err)
+	}
+	ordered := dropShipOrderedQuantities(purchaseOrders)
+
+	for _, item := range order.Items {
+		quantity := ordered[item.ID]
+		if quantity == 0 {
+			continue
+		}
+		line, err := findOrderItem(amended, item.ID.String())
+		if err != nil {
+			return fmt.Errorf("%w: %d of %s are on order from its supplier", ErrInvalidAmendment, quantity, item.ProductSKU)
+		}
+		if line.Quantity < quantity {
+			return fmt.Errorf("%w: %d of %s are on order from its supplier", ErrInvalidAmendment, quantity, line.ProductSKU)
+		}
+	}
+
+	if amended.ShippingAddressID != order.ShippingAddressID {
+		for _, po := range purchaseOrders {
+			if po.CanReceive() {
+				return fmt.Errorf("%w: drop-ship purchase order %s ships to the current address", ErrInvalidAmendment, po.PONumber)
+			}
+		}
+	}
+
+	return nil
+}
+
+// dropShipAddress copies the order's shipping address for its suppliers
+func (s *ServiceImpl) dropShipAddress(ctx context.Context, order *entities.Order) (*purchasingEntities.DropShipAddress, error) {
+	address := order.ShippingAddress
+	if address == nil || address.ID != order.ShippingAddressID {
+		var err error
+		if address, err = s.addressRepo.GetByID(ctx, order.ShippingAddressID); err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				return nil, fmt.Errorf("%w: shipping address not found", ErrInvalidAddress)
+			}
+			return nil, fmt.Errorf("failed to get shipping address: %w", err)
+		}
+	}
+
+	return &purchasingEntities.DropShipAddress{
+		Name:         strings.TrimSpace(address.FirstName + " " + address.LastName),
+		Company:      address.Company,
+		AddressLine1: address.AddressLine1,
+		AddressLine2: address.AddressLine2,
+		City:         address.City,
+		State:        address.State,
+		PostalCode:   address.PostalCode,
+		Country:      address.Country,
+		Phone:        address.Phone,
+	}, nil
+}
+
+// dropShipOrderedQuantities sums the quantities of each sales order line on
+// drop-ship purchase orders that were not cancelled
+func dropShipOrderedQuantities(purchaseOrders []*purchasingEntities.PurchaseOrder) map[uuid.UUID]int {
+	ordered := make(map[uuid.UUID]int)
+	for _, po := range purchaseOrders {
+		if po.Status == purchasingEntities.PurchaseOrderStatusCancelled {
+			continue
+		}
+		for _, item := range po.Items {
+			if item.SalesOrderItemID != nil {
+				ordered[*item.SalesOrderItemID] += item.QuantityOrdered
+			}
+		}
+	}
+	return ordered
+}
@@ -0,0 +1,364 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
+)
+
+// ExchangeRateService defines the interface for exchange rate management
+type ExchangeRateService interface {
+	CreateExchangeRate(ctx context.Context, req *CreateExchangeRateRequest) (*entities.ExchangeRate, error)
+	GetExchangeRate(ctx context.Context, id string) (*entities.ExchangeRate, error)
+	UpdateExchangeRate(ctx context.Context, id string, req *UpdateExchangeRateRequest) (*entities.ExchangeRate, error)
+	DeleteExchangeRate(ctx context.Context, id string) error
+	ListExchangeRates(ctx context.Context, req *ListExchangeRatesRequest) (*ListExchangeRatesResponse, error)
+
+	// ImportExchangeRates imports a CSV or European Central Bank XML file
+	ImportExchangeRates(ctx context.Context, req *ImportExchangeRatesRequest) (*ImportExchangeRatesResponse, error)
+	// ConvertCurrency converts an amount at the rate effective on a date,
+	// into the base currency unless another currency is asked for
+	ConvertCurrency(ctx context.Context, req *ConvertCurrencyRequest) (*CurrencyConversion, error)
+}
+
+// Exchange rate import formats
+const (
+	ExchangeRateFormatCSV = "CSV"
+	ExchangeRateFormatECB = "ECB"
+)
+
+// CreateExchangeRateRequest represents a request to enter an exchange rate by hand
+type CreateExchangeRateRequest struct {
+	FromCurrency  string          `json:"from_currency" validate:"required,len=3"`
+	ToCurrency    string          `json:"to_currency" validate:"required,len=3"`
+	Rate          decimal.Decimal `json:"rate" validate:"required"`
+	EffectiveDate time.Time       `json:"effective_date" validate:"required"`
+	CreatedBy     string          `json:"created_by" validate:"required"`
+}
+
+// UpdateExchangeRateRequest represents a request to correct an exchange rate.
+// Orders keep the rate they were booked at.
+type UpdateExchangeRateRequest struct {
+	Rate          *decimal.Decimal `json:"rate,omitempty"`
+	EffectiveDate *time.Time       `json:"effective_date,omitempty"`
+}
+
+// ListExchangeRatesRequest represents a request to list exchange rates
+type ListExchangeRatesRequest struct {
+	Currency  string     `json:"currency,omitempty"`
+	Source    string     `json:"source,omitempty"`
+	StartDate *time.Time `json:"start_date,omitempty"`
+	EndDate   *time.Time `json:"end_date,omitempty"`
+	Page      int        `json:"page"`
+	Limit     int        `json:"limit"`
+}
+
+// ListExchangeRatesResponse represents a paginated list of exchange rates
+type ListExchangeRatesResponse struct {
+	Rates      []*entities.ExchangeRate `json:"rates"`
+	Pagination *Pagination              `json:"pagination"`
+}
+
+// ImportExchangeRatesRequest represents an exchange rate file to import.
+// Rates already entered for a pair and date are replaced.
+type ImportExchangeRatesRequest struct {
+	Format     string    `json:"format" validate:"required,oneof=CSV ECB"`
+	File       io.Reader `json:"-"`
+	ImportedBy string    `json:"imported_by" validate:"required"`
+}
+
+// ImportExchangeRatesResponse summarizes an exchange rate import
+type ImportExchangeRatesResponse struct {
+	Imported   int                      `json:"imported"`
+	Currencies []string                 `json:"currencies"`
+	Rates      []*entities.ExchangeRate `json:"rates"`
+}
+
+// ConvertCurrencyRequest represents a request to convert an amount
+type ConvertCurrencyRequest struct {
+	Amount       decimal.Decimal `json:"amount"`
+	FromCurrency string          `json:"from_currency" validate:"required,len=3"`
+	ToCurrency   string          `json:"to_currency,omitempty"`
+	Date         *time.Time      `json:"date,omitempty"`
+}
+
+// CurrencyConversion is an amount converted at an exchange rate
+type CurrencyConversion struct {
+	FromCurrency    string          `json:"from_currency"`
+	ToCurrency      string          `json:"to_currency"`
+	Rate            decimal.Decimal `json:"rate"`
+	Date            time.Time       `json:"date"`
+	Amount          decimal.Decimal `json:"amount"`
+	ConvertedAmount decimal.Decimal `json:"converted_amount"`
+}
+
+// Exchange rate errors
+var (
+	ErrExchangeRateNotFound     = errors.New("exchange rate not found")
+	ErrExchangeRateExists       = errors.New("exchange rate already exists for the date")
+	ErrExchangeRateUnavailable  = errors.New("no exchange rate available")
+	ErrInvalidExchangeRateFile  = errors.New("invalid exchange rate file")
+	ErrUnsupportedRateFormat    = errors.New("unsupported exchange rate format")
+	ErrExchangeRateUnconfigured = errors.New("exchange rates are not configured")
+)
+
+// ExchangeRateServiceImpl implements the ExchangeRateService interface
+type ExchangeRateServiceImpl struct {
+	rateRepo   repositories.ExchangeRateRepository
+	currencies CurrencyConverter
+	logger     *zerolog.Logger
+}
+
+// NewExchangeRateService creates a new exchange rate service. The rates it
+// manages are read by the currency converter when orders are booked.
+func NewExchangeRateService(rateRepo repositories.ExchangeRateRepository, currencies CurrencyConverter, logger *zerolog.Logger) ExchangeRateService {
+	return &ExchangeRateServiceImpl{
+		rateRepo:   rateRepo,
+		currencies: currencies,
+		logger:     logger,
+	}
+}
+
+// CreateExchangeRate enters an exchange rate by hand
+func (s *ExchangeRateServiceImpl) CreateExchangeRate(ctx context.Context, req *CreateExchangeRateRequest) (*entities.ExchangeRate, error) {
+	createdBy, err := uuid.Parse(req.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid created by user ID: %w", err)
+	}
+
+	now := time.Now().UTC()
+	rate := &entities.ExchangeRate{
+		ID:            uuid.New(),
+		FromCurrency:  strings.ToUpper(strings.TrimSpace(req.FromCurrency)),
+		ToCurrency:    strings.ToUpper(strings.TrimSpace(req.ToCurrency)),
+		Rate:          req.Rate.Round(entities.ExchangeRatePrecision),
+		EffectiveDate: truncateToDate(req.EffectiveDate),
+		Source:        entities.ExchangeRateSourceManual,
+		CreatedBy:     &createdBy,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := rate.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid exchange rate data: %w", err)
+	}
+
+	if err := s.rateRepo.Create(ctx, rate); err != nil {
+		if strings.Contains(err.Error(), "already exists") {
+			return nil, fmt.Errorf("%w: %v", ErrExchangeRateExists, err)
+		}
+		return nil, fmt.Errorf("failed to create exchange rate: %w", err)
+	}
+
+	s.logger.Info().
+		Str("exchange_rate_id", rate.ID.String()).
+		Str("pair", rate.FromCurrency+"/"+rate.ToCurrency).
+		Str("rate", rate.Rate.String()).
+		Msg("Exchange rate created")
+
+	return rate, nil
+}
+
+// GetExchangeRate retrieves an exchange rate
+func (s *ExchangeRateServiceImpl) GetExchangeRate(ctx context.Context, id string) (*entities.ExchangeRate, error) {
+	return s.loadRate(ctx, id)
+}
+
+// UpdateExchangeRate corrects the rate or effective date of an exchange rate
+func (s *ExchangeRateServiceImpl) UpdateExchangeRate(ctx context.Context, id string, req *UpdateExchangeRateRequest) (*entities.ExchangeRate, error) {
+	rate, err := s.loadRate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Rate != nil {
+		rate.Rate = req.Rate.Round(entities.ExchangeRatePrecision)
+	}
+	if req.EffectiveDate != nil {
+		rate.EffectiveDate = truncateToDate(*req.EffectiveDate)
+	}
+	// A corrected rate is no longer the one the file published
+	rate.Source = entities.ExchangeRateSourceManual
+	rate.UpdatedAt = time.Now().UTC()
+
+	if err := rate.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid exchange rate data: %w", err)
+	}
+
+	if err := s.rateRepo.Update(ctx, rate); err != nil {
+		if strings.Contains(err.Error(), "already exists") {
+			return nil, fmt.Errorf("%w: %v", ErrExchangeRateExists, err)
+		}
+		return nil, fmt.Errorf("failed to update exchange rate: %w", err)
+	}
+
+	return rate, nil
+}
+
+// DeleteExchangeRate deletes an exchange rate
+func (s *ExchangeRateServiceImpl) DeleteExchangeRate(ctx context.Context, id string) error {
+	rate, err := s.loadRate(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.rateRepo.Delete(ctx, rate.ID); err != nil {
+		return fmt.Errorf("failed to delete exchange rate: %w", err)
+	}
+
+	return nil
+}
+
+// ListExchangeRates lists exchange rates, latest first
+func (s *ExchangeRateServiceImpl) ListExchangeRates(ctx context.Context, req *ListExchangeRatesRequest) (*ListExchangeRatesResponse, error) {
+	page, limit := normalizePage(req.Page, req.Limit)
+
+	filter := repositories.ExchangeRateFilter{
+		Currency:  strings.ToUpper(strings.TrimSpace(req.Currency)),
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+		Page:      page,
+		Limit:     limit,
+		Offset:    (page - 1) * limit,
+	}
+	if req.Source != "" {
+		source := entities.ExchangeRateSource(strings.ToUpper(req.Source))
+		filter.Source = &source
+	}
+
+	rates, err := s.rateRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list exchange rates: %w", err)
+	}
+
+	total, err := s.rateRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count exchange rates: %w", err)
+	}
+
+	return &ListExchangeRatesResponse{
+		Rates:      rates,
+		Pagination: newPagination(page, limit, total),
+	}, nil
+}
+
+// ImportExchangeRates parses an exchange rate file and stores all its rates,
+// or none of them when a line is invalid
+func (s *ExchangeRateServiceImpl) ImportExchangeRates(ctx context.Context, req *ImportExchangeRatesRequest) (*ImportExchangeRatesResponse, error) {
+	importedBy, err := uuid.Parse(req.ImportedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid imported by user ID: %w", err)
+	}
+
+	var rates []*entities.ExchangeRate
+	switch strings.ToUpper(strings.TrimSpace(req.Format)) {
+	case ExchangeRateFormatCSV:
+		rates, err = entities.ParseExchangeRatesCSV(req.File)
+	case ExchangeRateFormatECB:
+		rates, err = entities.ParseECBExchangeRates(req.File)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedRateFormat, req.Format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidExchangeRateFile, err)
+	}
+
+	seen := make(map[string]bool)
+	var currencies []string
+	for _, rate := range rates {
+		rate.CreatedBy = &importedBy
+		for _, currency := range []string{rate.FromCurrency, rate.ToCurrency} {
+			if !seen[currency] {
+				seen[currency] = true
+				currencies = append(currencies, currency)
+			}
+		}
+	}
+
+	if err := s.rateRepo.Upsert(ctx, rates); err != nil {
+		return nil, fmt.Errorf("failed to import exchange rates: %w", err)
+	}
+
+	s.logger.Info().
+		Str("format", req.Format).
+		Int("rates", len(rates)).
+		Strs("currencies", currencies).
+		Msg("Exchange rates imported")
+
+	return &ImportExchangeRatesResponse{
+		Imported:   len(rates),
+		Currencies: currencies,
+		Rates:      rates,
+	}, nil
+}
+
+// ConvertCurrency converts an amount at the rate effective on the date, today by default
+func (s *ExchangeRateServiceImpl) ConvertCurrency(ctx context.Context, req *ConvertCurrencyRequest) (*CurrencyConversion, error) {
+	if s.currencies == nil {
+		return nil, ErrExchangeRateUnconfigured
+	}
+
+	from, err := entities.NormalizeCurrency(req.FromCurrency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCurrency, err)
+	}
+	to := s.currencies.BaseCurrency()
+	if strings.TrimSpace(req.ToCurrency) != "" {
+		if to, err = entities.NormalizeCurrency(req.ToCurrency); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCurrency, err)
+		}
+	}
+
+	date := time.Now().UTC()
+	if req.Date != nil {
+		date = *req.Date
+	}
+	date = truncateToDate(date)
+
+	rate, err := s.currencies.ExchangeRate(ctx, from, to, date)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CurrencyConversion{
+		FromCurrency:    from,
+		ToCurrency:      to,
+		Rate:            rate,
+		Date:            date,
+		Amount:          req.Amount,
+		ConvertedAmount: req.Amount.Mul(rate).Round(2),
+	}, nil
+}
+
+// loadRate parses the ID and loads the rate, mapping missing rows to ErrExchangeRateNotFound
+func (s *ExchangeRateServiceImpl) loadRate(ctx context.Context, id string) (*entities.ExchangeRate, error) {
+	rateID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exchange rate ID: %w", err)
+	}
+
+	rate, err := s.rateRepo.GetByID(ctx, rateID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrExchangeRateNotFound
+		}
+		return nil, fmt.Errorf("failed to get exchange rate: %w", err)
+	}
+
+	return rate, nil
+}
+
+// truncateToDate drops the time of day; rates are effective for whole days
+func truncateToDate(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,1043 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+
+	invEntities "erpgo/internal/domain/inventory/entities"
+	invRepositories "erpgo/internal/domain/inventory/repositories"
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
+	productEntities "erpgo/internal/domain/products/entities"
+	productRepositories "erpgo/internal/domain/products/repositories"
+	purchasingEntities "erpgo/internal/domain/purchasing/entities"
+	purchasingRepositories "erpgo/internal/domain/purchasing/repositories"
+	userEntities "erpgo/internal/domain/users/entities"
+	"erpgo/pkg/database"
+)
+
+// The fakes below keep the rows of the order workflows in memory. Each fake
+// embeds its repository interface and implements only the methods the
+// workflows under test call; any other call panics on the nil interface.
+
+// fakeTx is a transaction the fakes only check for
+type fakeTx struct {
+	pgx.Tx
+}
+
+// fakeTxManager runs functions in a fake transaction
+type fakeTxManager struct {
+	calls int
+}
+
+func (m *fakeTxManager) WithTransaction(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	return m.WithTransactionOptions(ctx, database.DefaultTransactionConfig(), fn)
+}
+
+func (m *fakeTxManager) WithRetryTransaction(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	return m.WithTransactionOptions(ctx, database.DefaultTransactionConfig(), fn)
+}
+
+func (m *fakeTxManager) WithTransactionOptions(ctx context.Context, opts database.TransactionConfig, fn func(tx pgx.Tx) error) error {
+	m.calls++
+	return fn(&fakeTx{})
+}
+
+// write is a change made through a fake repository
+type write struct {
+	op   string
+	inTx bool
+}
+
+// archivedOrder is an order moved into the fake archive with its rows
+type archivedOrder struct {
+	order   *entities.Order
+	items   []*entities.OrderItem
+	history []*entities.OrderStatusHistory
+}
+
+// memoryStore holds the rows behind the fake repositories
+type memoryStore struct {
+	orders      map[uuid.UUID]*entities.Order
+	items       map[uuid.UUID][]*entities.OrderItem
+	customers   map[uuid.UUID]*entities.Customer
+	addresses   map[uuid.UUID]*entities.OrderAddress
+	products    map[uuid.UUID]*productEntities.Product
+	warehouses  map[uuid.UUID]*invEntities.WarehouseExtended
+	stock       []*invEntities.Inventory
+	payments    map[uuid.UUID]*entities.Payment
+	shipments   []*entities.Shipment
+	history     []*entities.OrderStatusHistory
+	allocations map[uuid.UUID][]*entities.OrderAllocation
+	revisions   []*entities.OrderRevision
+	backorders  []*entities.Backorder
+	policies    []*entities.ApprovalPolicy
+	approvals   []*entities.OrderApproval
+	archive     map[uuid.UUID]*archivedOrder
+	roles       map[uuid.UUID][]string
+	quotations  map[uuid.UUID]*entities.Quotation
+	promotions  map[string]*entities.Promotion
+	redemptions []*entities.PromotionRedemption
+
+	// locked lists the rows locked, in locking order
+	locked []uuid.UUID
+	// lockedCustomers lists the customers locked for credit checks
+	lockedCustomers []uuid.UUID
+	// writes lists the changes made, in order
+	writes   []write
+	sequence int
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		orders:      make(map[uuid.UUID]*entities.Order),
+		items:       make(map[uuid.UUID][]*entities.OrderItem),
+		customers:   make(map[uuid.UUID]*entities.Customer),
+		addresses:   make(map[uuid.UUID]*entities.OrderAddress),
+		products:    make(map[uuid.UUID]*productEntities.Product),
+		warehouses:  make(map[uuid.UUID]*invEntities.WarehouseExtended),
+		payments:    make(map[uuid.UUID]*entities.Payment),
+		allocations: make(map[uuid.UUID][]*entities.OrderAllocation),
+		archive:     make(map[uuid.UUID]*archivedOrder),
+		roles:       make(map[uuid.UUID][]string),
+		quotations:  make(map[uuid.UUID]*entities.Quotation),
+		promotions:  make(map[string]*entities.Promotion),
+	}
+}
+
+// newTestService returns an order service over a fresh in-memory store
+func newTestService(t *testing.T) (*ServiceImpl, *memoryStore) {
+	t.Helper()
+
+	store := newMemoryStore()
+	logger := zerolog.Nop()
+
+	service := &ServiceImpl{
+		orderRepo:       &fakeOrderRepository{store: store},
+		orderItemRepo:   &fakeOrderItemRepository{store: store},
+		historyRepo:     &fakeHistoryRepository{store: store},
+		shipmentRepo:    &fakeShipmentRepository{store: store},
+		backorderRepo:   &fakeBackorderRepository{store: store},
+		paymentRepo:     &fakePaymentRepository{store: store},
+		promotionRepo:   &fakePromotionRepository{store: store},
+		invoiceRepo:     &fakeInvoiceRepository{},
+		approvalRepo:    &fakeApprovalRepository{store: store},
+		sourcingRepo:    &fakeSourcingRepository{store: store},
+		revisionRepo:    &fakeRevisionRepository{store: store},
+		archiveRepo:     &fakeArchiveRepository{store: store},
+		customerRepo:    &fakeCustomerRepository{store: store},
+		addressRepo:     &fakeAddressRepository{store: store},
+		productRepo:     &fakeProductRepository{store: store},
+		warehouseRepo:   &fakeWarehouseRepository{store: store},
+		inventoryRepo:   &fakeInventoryRepository{store: store},
+		transactionRepo: &fakeInventoryTransactionRepository{store: store},
+		poRepo:          &fakePurchaseOrderRepository{},
+		approverRoles:   fakeApproverRoles{store: store},
+		txManager:       &fakeTxManager{},
+		logger:          &logger,
+		defaultCurrency: "USD",
+	}
+
+	return service, store
+}
+
+func (s *memoryStore) record(ctx context.Context, op string) {
+	_, inTx := database.TxFromContext(ctx)
+	s.writes = append(s.writes, write{op: op, inTx: inTx})
+}
+
+// ops returns the names of the changes made, in order
+func (s *memoryStore) ops() []string {
+	ops := make([]string, len(s.writes))
+	for i, w := range s.writes {
+		ops[i] = w.op
+	}
+	return ops
+}
+
+// untransacted returns the changes made outside of a transaction
+func (s *memoryStore) untransacted() []string {
+	var ops []string
+	for _, w := range s.writes {
+		if !w.inTx {
+			ops = append(ops, w.op)
+		}
+	}
+	return ops
+}
+
+// resetWrites forgets the changes and locks made so far
+func (s *memoryStore) resetWrites() {
+	s.writes = nil
+	s.locked = nil
+	s.lockedCustomers = nil
+}
+
+func (s *memoryStore) addCustomer(creditLimit decimal.Decimal) *entities.Customer {
+	customer := &entities.Customer{
+		ID:           uuid.New(),
+		CustomerCode: fmt.Sprintf("C%05d", len(s.customers)+1),
+		Type:         "BUSINESS",
+		FirstName:    "Ada",
+		LastName:     "Lovelace",
+		CreditLimit:  creditLimit,
+		CreditUsed:   decimal.Zero,
+		IsActive:     true,
+	}
+	s.customers[customer.ID] = customer
+	return customer
+}
+
+func (s *memoryStore) addAddress(customerID uuid.UUID, country, state, postalCode string) *entities.OrderAddress {
+	address := &entities.OrderAddress{
+		ID:           uuid.New(),
+		CustomerID:   &customerID,
+		Type:         "BOTH",
+		FirstName:    "Ada",
+		LastName:     "Lovelace",
+		AddressLine1: "1 Main Street",
+		City:         "Springfield",
+		State:        state,
+		PostalCode:   postalCode,
+		Country:      country,
+		IsActive:     true,
+	}
+	s.addresses[address.ID] = address
+	return address
+}
+
+func (s *memoryStore) addProduct(price decimal.Decimal) *productEntities.Product {
+	product := &productEntities.Product{
+		ID:             uuid.New(),
+		SKU:            fmt.Sprintf("SKU-%03d", len(s.products)+1),
+		Name:           "Widget",
+		Price:          price,
+		TrackInventory: true,
+		IsActive:       true,
+	}
+	s.products[product.ID] = product
+	return product
+}
+
+func (s *memoryStore) addWarehouse(code, country, state, postalCode string) *invEntities.WarehouseExtended {
+	warehouse := &invEntities.WarehouseExtended{
+		Warehouse: invEntities.Warehouse{
+			ID:         uuid.New(),
+			Name:       code,
+			Code:       code,
+			State:      state,
+			Country:    country,
+			PostalCode: postalCode,
+			IsActive:   true,
+		},
+		Type: invEntities.WarehouseTypeDistribution,
+	}
+	s.warehouses[warehouse.ID] = warehouse
+	return warehouse
+}
+
+func (s *memoryStore) addStock(productID, warehouseID uuid.UUID, onHand int) {
+	s.stock = append(s.stock, &invEntities.Inventory{
+		ID:             uuid.New(),
+		ProductID:      productID,
+		WarehouseID:    warehouseID,
+		QuantityOnHand: onHand,
+	})
+}
+
+// level returns the stock of a product in a warehouse
+func (s *memoryStore) level(productID, warehouseID uuid.UUID) *invEntities.Inventory {
+	for _, level := range s.stock {
+		if level.ProductID == productID && level.WarehouseID == warehouseID {
+			return level
+		}
+	}
+	return nil
+}
+
+// reserved returns the stock of a product reserved in a warehouse
+func (s *memoryStore) reserved(productID, warehouseID uuid.UUID) int {
+	if level := s.level(productID, warehouseID); level != nil {
+		return level.QuantityReserved
+	}
+	return 0
+}
+
+// allocated sums the allocations of an order by warehouse
+func (s *memoryStore) allocated(orderID uuid.UUID) map[uuid.UUID]int {
+	quantities := make(map[uuid.UUID]int)
+	for _, allocation := range s.allocations[orderID] {
+		quantities[allocation.WarehouseID] += allocation.Quantity
+	}
+	return quantities
+}
+
+// historyOf returns the status history recorded for an order
+func (s *memoryStore) historyOf(orderID uuid.UUID) []*entities.OrderStatusHistory {
+	var entries []*entities.OrderStatusHistory
+	for _, entry := range s.history {
+		if entry.OrderID == orderID {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+func copyOrder(order *entities.Order) *entities.Order {
+	stored := *order
+	stored.Items = nil
+	stored.Promotions = nil
+	return &stored
+}
+
+func copyItems(items []*entities.OrderItem) []*entities.OrderItem {
+	copied := make([]*entities.OrderItem, len(items))
+	for i, item := range items {
+		stored := *item
+		copied[i] = &stored
+	}
+	return copied
+}
+
+func copyPayment(payment *entities.Payment) *entities.Payment {
+	stored := *payment
+	stored.Allocations = append([]entities.PaymentAllocation(nil), payment.Allocations...)
+	return &stored
+}
+
+func copyAllocations(allocations []*entities.OrderAllocation) []*entities.OrderAllocation {
+	copied := make([]*entities.OrderAllocation, len(allocations))
+	for i, allocation := range allocations {
+		stored := *allocation
+		copied[i] = &stored
+	}
+	return copied
+}
+
+// Orders
+
+type fakeOrderRepository struct {
+	repositories.OrderRepository
+	store *memoryStore
+}
+
+func (r *fakeOrderRepository) Create(ctx context.Context, order *entities.Order) error {
+	r.store.record(ctx, "orders.create")
+	if order.OrderNumber == "" {
+		r.store.sequence++
+		order.OrderNumber = fmt.Sprintf("SO-%06d", r.store.sequence)
+	}
+	r.store.orders[order.ID] = copyOrder(order)
+	return nil
+}
+
+func (r *fakeOrderRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Order, error) {
+	order, ok := r.store.orders[id]
+	if !ok {
+		return nil, fmt.Errorf("order with id %s not found", id)
+	}
+	return copyOrder(order), nil
+}
+
+func (r *fakeOrderRepository) Update(ctx context.Context, order *entities.Order) error {
+	r.store.record(ctx, "orders.update")
+	if _, ok := r.store.orders[order.ID]; !ok {
+		return fmt.Errorf("order with id %s not found", order.ID)
+	}
+	r.store.orders[order.ID] = copyOrder(order)
+	return nil
+}
+
+func (r *fakeOrderRepository) Lock(ctx context.Context, id uuid.UUID) error {
+	if _, ok := r.store.orders[id]; !ok {
+		return fmt.Errorf("order with id %s not found", id)
+	}
+	if _, inTx := database.TxFromContext(ctx); !inTx {
+		return fmt.Errorf("order %s locked outside of a transaction", id)
+	}
+	r.store.locked = append(r.store.locked, id)
+	return nil
+}
+
+func (r *fakeOrderRepository) List(ctx context.Context, filter repositories.OrderFilter) ([]*entities.Order, error) {
+	orders := r.matching(filter)
+	if filter.Offset >= len(orders) {
+		return []*entities.Order{}, nil
+	}
+	end := min(filter.Offset+filter.Limit, len(orders))
+	return orders[filter.Offset:end], nil
+}
+
+func (r *fakeOrderRepository) Count(ctx context.Context, filter repositories.OrderFilter) (int, error) {
+	return len(r.matching(filter)), nil
+}
+
+// matching returns copies of the orders of the filter's customer and
+// statuses, oldest number first
+func (r *fakeOrderRepository) matching(filter repositories.OrderFilter) []*entities.Order {
+	var orders []*entities.Order
+	for _, order := range r.store.orders {
+		if filter.CustomerID != nil && order.CustomerID != *filter.CustomerID {
+			continue
+		}
+		if len(filter.Status) > 0 && !containsStatus(filter.Status, order.Status) {
+			continue
+		}
+		orders = append(orders, copyOrder(order))
+	}
+	sort.Slice(orders, func(i, j int) bool { return orders[i].OrderNumber < orders[j].OrderNumber })
+	return orders
+}
+
+func containsStatus(statuses []entities.OrderStatus, status entities.OrderStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// GetCustomerCreditExposure sums the outstanding balances of the customer's
+// approved orders that are still open
+func (r *fakeOrderRepository) GetCustomerCreditExposure(ctx context.Context, customerID uuid.UUID) (decimal.Decimal, error) {
+	exposure := decimal.Zero
+	for _, order := range r.store.orders {
+		if order.CustomerID != customerID || order.ApprovedAt == nil {
+			continue
+		}
+		switch order.Status {
+		case entities.OrderStatusCancelled, entities.OrderStatusReturned, entities.OrderStatusRefunded:
+			continue
+		}
+		exposure = exposure.Add(order.CreditExposure())
+	}
+	return exposure, nil
+}
+
+type fakeOrderItemRepository struct {
+	repositories.OrderItemRepository
+	store *memoryStore
+}
+
+func (r *fakeOrderItemRepository) Create(ctx context.Context, item *entities.OrderItem) error {
+	r.store.record(ctx, "order_items.create")
+	r.store.items[item.OrderID] = append(r.store.items[item.OrderID], copyItems([]*entities.OrderItem{item})...)
+	return nil
+}
+
+func (r *fakeOrderItemRepository) BulkCreate(ctx context.Context, items []*entities.OrderItem) error {
+	r.store.record(ctx, "order_items.bulk_create")
+	for _, item := range copyItems(items) {
+		r.store.items[item.OrderID] = append(r.store.items[item.OrderID], item)
+	}
+	return nil
+}
+
+func (r *fakeOrderItemRepository) BulkUpdate(ctx context.Context, items []*entities.OrderItem) error {
+	r.store.record(ctx, "order_items.bulk_update")
+	for _, item := range copyItems(items) {
+		stored := r.store.items[item.OrderID]
+		for i := range stored {
+			if stored[i].ID == item.ID {
+				stored[i] = item
+			}
+		}
+	}
+	return nil
+}
+
+func (r *fakeOrderItemRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.store.record(ctx, "order_items.delete")
+	for orderID, items := range r.store.items {
+		for i, item := range items {
+			if item.ID == id {
+				r.store.items[orderID] = append(items[:i:i], items[i+1:]...)
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("order item with id %s not found", id)
+}
+
+func (r *fakeOrderItemRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.OrderItem, error) {
+	return copyItems(r.store.items[orderID]), nil
+}
+
+type fakeHistoryRepository struct {
+	store *memoryStore
+}
+
+func (r *fakeHistoryRepository) Create(ctx context.Context, entry *entities.OrderStatusHistory) error {
+	r.store.record(ctx, "order_status_history.create")
+	if entry.ID == uuid.Nil {
+		entry.ID = uuid.New()
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now().UTC()
+	}
+	stored := *entry
+	r.store.history = append(r.store.history, &stored)
+	return nil
+}
+
+func (r *fakeHistoryRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.OrderStatusHistory, error) {
+	return r.store.historyOf(orderID), nil
+}
+
+type fakeShipmentRepository struct {
+	repositories.ShipmentRepository
+	store *memoryStore
+}
+
+func (r *fakeShipmentRepository) Create(ctx context.Context, shipment *entities.Shipment) error {
+	r.store.record(ctx, "shipments.create")
+	stored := *shipment
+	r.store.shipments = append(r.store.shipments, &stored)
+	return nil
+}
+
+func (r *fakeShipmentRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.Shipment, error) {
+	var shipments []*entities.Shipment
+	for _, stored := range r.store.shipments {
+		if stored.OrderID == orderID {
+			shipment := *stored
+			shipments = append(shipments, &shipment)
+		}
+	}
+	return shipments, nil
+}
+
+type fakeBackorderRepository struct {
+	repositories.BackorderRepository
+	store *memoryStore
+}
+
+func (r *fakeBackorderRepository) Create(ctx context.Context, backorder *entities.Backorder) error {
+	r.store.record(ctx, "backorders.create")
+	stored := *backorder
+	r.store.backorders = append(r.store.backorders, &stored)
+	return nil
+}
+
+func (r *fakeBackorderRepository) Update(ctx context.Context, backorder *entities.Backorder) error {
+	r.store.record(ctx, "backorders.update")
+	for i, stored := range r.store.backorders {
+		if stored.ID == backorder.ID {
+			updated := *backorder
+			r.store.backorders[i] = &updated
+		}
+	}
+	return nil
+}
+
+func (r *fakeBackorderRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.Backorder, error) {
+	var backorders []*entities.Backorder
+	for _, stored := range r.store.backorders {
+		if stored.OrderID == orderID {
+			backorder := *stored
+			backorders = append(backorders, &backorder)
+		}
+	}
+	return backorders, nil
+}
+
+type fakePaymentRepository struct {
+	repositories.PaymentRepository
+	store *memoryStore
+}
+
+func (r *fakePaymentRepository) Create(ctx context.Context, payment *entities.Payment) error {
+	r.store.record(ctx, "payments.create")
+	r.store.sequence++
+	payment.PaymentNumber = fmt.Sprintf("PAY-%06d", r.store.sequence)
+	r.store.payments[payment.ID] = copyPayment(payment)
+	return nil
+}
+
+func (r *fakePaymentRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Payment, error) {
+	payment, ok := r.store.payments[id]
+	if !ok {
+		return nil, fmt.Errorf("payment with id %s not found", id)
+	}
+	return copyPayment(payment), nil
+}
+
+func (r *fakePaymentRepository) Lock(ctx context.Context, id uuid.UUID) error {
+	if _, ok := r.store.payments[id]; !ok {
+		return fmt.Errorf("payment with id %s not found", id)
+	}
+	if _, inTx := database.TxFromContext(ctx); !inTx {
+		return fmt.Errorf("payment %s locked outside of a transaction", id)
+	}
+	r.store.locked = append(r.store.locked, id)
+	return nil
+}
+
+func (r *fakePaymentRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.Payment, error) {
+	var payments []*entities.Payment
+	for _, payment := range r.store.payments {
+		if payment.AllocatedTo(orderID).IsPositive() {
+			payments = append(payments, copyPayment(payment))
+		}
+	}
+	sort.Slice(payments, func(i, j int) bool { return payments[i].ReceivedAt.Before(payments[j].ReceivedAt) })
+	return payments, nil
+}
+
+func (r *fakePaymentRepository) Update(ctx context.Context, payment *entities.Payment) error {
+	r.store.record(ctx, "payments.update")
+	r.store.payments[payment.ID] = copyPayment(payment)
+	return nil
+}
+
+type fakePromotionRepository struct {
+	repositories.PromotionRepository
+	store *memoryStore
+}
+
+func (r *fakePromotionRepository) GetByCode(ctx context.Context, code string) (*entities.Promotion, error) {
+	promotion, ok := r.store.promotions[code]
+	if !ok {
+		return nil, fmt.Errorf("promotion with code %s not found", code)
+	}
+	return promotion, nil
+}
+
+func (r *fakePromotionRepository) Redeem(ctx context.Context, redemption *entities.PromotionRedemption) error {
+	r.store.record(ctx, "promotion_redemptions.create")
+	redemption.Promotion = r.store.promotions[redemption.PromotionCode]
+	stored := *redemption
+	r.store.redemptions = append(r.store.redemptions, &stored)
+	return nil
+}
+
+func (r *fakePromotionRepository) GetActiveRedemptionsByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.PromotionRedemption, error) {
+	var redemptions []*entities.PromotionRedemption
+	for _, stored := range r.store.redemptions {
+		if stored.OrderID == orderID && !stored.IsReleased() {
+			redemption := *stored
+			redemption.Promotion = r.store.promotions[redemption.PromotionCode]
+			redemptions = append(redemptions, &redemption)
+		}
+	}
+	return redemptions, nil
+}
+
+// fakeInvoiceRepository holds no invoices, so refunds issue no credit notes
+type fakeInvoiceRepository struct {
+	repositories.InvoiceRepository
+}
+
+func (r *fakeInvoiceRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.Invoice, error) {
+	return nil, nil
+}
+
+type fakeApprovalRepository struct {
+	repositories.ApprovalRepository
+	store *memoryStore
+}
+
+func (r *fakeApprovalRepository) ListPolicies(ctx context.Context, filter repositories.ApprovalPolicyFilter) ([]*entities.ApprovalPolicy, error) {
+	var policies []*entities.ApprovalPolicy
+	for _, policy := range r.store.policies {
+		if filter.IsActive == nil || policy.IsActive == *filter.IsActive {
+			policies = append(policies, policy)
+		}
+	}
+	return policies, nil
+}
+
+func (r *fakeApprovalRepository) CreateApprovals(ctx context.Context, approvals []*entities.OrderApproval) error {
+	r.store.record(ctx, "order_approvals.create")
+	for _, approval := range approvals {
+		stored := *approval
+		r.store.approvals = append(r.store.approvals, &stored)
+	}
+	return nil
+}
+
+func (r *fakeApprovalRepository) UpdateApproval(ctx context.Context, approval *entities.OrderApproval) error {
+	r.store.record(ctx, "order_approvals.update")
+	for i, stored := range r.store.approvals {
+		if stored.ID == approval.ID {
+			updated := *approval
+			r.store.approvals[i] = &updated
+		}
+	}
+	return nil
+}
+
+func (r *fakeApprovalRepository) GetApprovalsByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.OrderApproval, error) {
+	var approvals []*entities.OrderApproval
+	for _, stored := range r.store.approvals {
+		if stored.OrderID == orderID {
+			approval := *stored
+			approvals = append(approvals, &approval)
+		}
+	}
+	return approvals, nil
+}
+
+// fakeApproverRoles looks up the roles users were given in the store
+type fakeApproverRoles struct {
+	store *memoryStore
+}
+
+func (r fakeApproverRoles) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]*userEntities.Role, error) {
+	var roles []*userEntities.Role
+	for _, name := range r.store.roles[userID] {
+		roles = append(roles, &userEntities.Role{ID: uuid.New(), Name: name})
+	}
+	return roles, nil
+}
+
+// fakeCurrencyConverter converts into a USD base currency at fixed rates
+type fakeCurrencyConverter struct {
+	rates map[string]decimal.Decimal
+}
+
+func (c fakeCurrencyConverter) BaseCurrency() string {
+	return "USD"
+}
+
+func (c fakeCurrencyConverter) ExchangeRate(ctx context.Context, from, to string, at time.Time) (decimal.Decimal, error) {
+	if from == to {
+		return decimal.NewFromInt(1), nil
+	}
+	rate, ok := c.rates[from]
+	if !ok || to != "USD" {
+		return decimal.Zero, fmt.Errorf("%w: %s to %s", ErrExchangeRateUnavailable, from, to)
+	}
+	return rate, nil
+}
+
+type fakeSourcingRepository struct {
+	repositories.SourcingRepository
+	store *memoryStore
+}
+
+func (r *fakeSourcingRepository) ListRules(ctx context.Context, filter repositories.SourcingRuleFilter) ([]*entities.SourcingRule, error) {
+	return nil, nil
+}
+
+func (r *fakeSourcingRepository) GetAllocationsByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.OrderAllocation, error) {
+	return copyAllocations(r.store.allocations[orderID]), nil
+}
+
+func (r *fakeSourcingRepository) ReplaceAllocations(ctx context.Context, orderID uuid.UUID, allocations []*entities.OrderAllocation) error {
+	r.store.record(ctx, "order_allocations.replace")
+	r.store.allocations[orderID] = copyAllocations(allocations)
+	return nil
+}
+
+func (r *fakeSourcingRepository) AddAllocation(ctx context.Context, allocation *entities.OrderAllocation) error {
+	r.store.record(ctx, "order_allocations.add")
+	r.store.allocations[allocation.OrderID] = append(r.store.allocations[allocation.OrderID], copyAllocations([]*entities.OrderAllocation{allocation})...)
+	return nil
+}
+
+type fakeRevisionRepository struct {
+	repositories.OrderRevisionRepository
+	store *memoryStore
+}
+
+func (r *fakeRevisionRepository) Create(ctx context.Context, revision *entities.OrderRevision) error {
+	r.store.record(ctx, "order_revisions.create")
+	stored := *revision
+	r.store.revisions = append(r.store.revisions, &stored)
+	return nil
+}
+
+func (r *fakeRevisionRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.OrderRevision, error) {
+	var revisions []*entities.OrderRevision
+	for _, stored := range r.store.revisions {
+		if stored.OrderID == orderID {
+			revision := *stored
+			revisions = append(revisions, &revision)
+		}
+	}
+	return revisions, nil
+}
+
+// fakeArchiveRepository moves orders with their items and status history
+// between the live rows of the store and its archive
+type fakeArchiveRepository struct {
+	repositories.OrderArchiveRepository
+	store *memoryStore
+}
+
+func (r *fakeArchiveRepository) Archive(ctx context.Context, orderID uuid.UUID, archivedAt time.Time) error {
+	r.store.record(ctx, "orders.archive")
+	order, ok := r.store.orders[orderID]
+	if !ok {
+		return fmt.Errorf("order with id %s not found", orderID)
+	}
+
+	archived := &archivedOrder{order: order, items: r.store.items[orderID]}
+	archived.order.ArchivedAt = &archivedAt
+	live := r.store.history[:0:0]
+	for _, entry := range r.store.history {
+		if entry.OrderID == orderID {
+			archived.history = append(archived.history, entry)
+		} else {
+			live = append(live, entry)
+		}
+	}
+
+	r.store.archive[orderID] = archived
+	r.store.history = live
+	delete(r.store.orders, orderID)
+	delete(r.store.items, orderID)
+	return nil
+}
+
+func (r *fakeArchiveRepository) Restore(ctx context.Context, orderID uuid.UUID) error {
+	r.store.record(ctx, "orders.restore")
+	archived, ok := r.store.archive[orderID]
+	if !ok {
+		return fmt.Errorf("archived order with id %s not found", orderID)
+	}
+
+	archived.order.ArchivedAt = nil
+	r.store.orders[orderID] = archived.order
+	r.store.items[orderID] = archived.items
+	r.store.history = append(r.store.history, archived.history...)
+	delete(r.store.archive, orderID)
+	return nil
+}
+
+func (r *fakeArchiveRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Order, error) {
+	archived, ok := r.store.archive[id]
+	if !ok {
+		return nil, fmt.Errorf("archived order with id %s not found", id)
+	}
+	return copyOrder(archived.order), nil
+}
+
+func (r *fakeArchiveRepository) GetItems(ctx context.Context, orderID uuid.UUID) ([]*entities.OrderItem, error) {
+	if archived, ok := r.store.archive[orderID]; ok {
+		return copyItems(archived.items), nil
+	}
+	return nil, nil
+}
+
+func (r *fakeArchiveRepository) GetAddresses(ctx context.Context, orderID uuid.UUID) ([]*entities.OrderAddress, error) {
+	archived, ok := r.store.archive[orderID]
+	if !ok {
+		return nil, nil
+	}
+	var addresses []*entities.OrderAddress
+	for _, id := range []uuid.UUID{archived.order.ShippingAddressID, archived.order.BillingAddressID} {
+		if address, ok := r.store.addresses[id]; ok {
+			addresses = append(addresses, address)
+		}
+	}
+	return addresses, nil
+}
+
+func (r *fakeArchiveRepository) GetStatusHistory(ctx context.Context, orderID uuid.UUID) ([]*entities.OrderStatusHistory, error) {
+	if archived, ok := r.store.archive[orderID]; ok {
+		return archived.history, nil
+	}
+	return nil, nil
+}
+
+func (r *fakeArchiveRepository) HasOpenReturns(ctx context.Context, orderID uuid.UUID) (bool, error) {
+	return false, nil
+}
+
+// Customers and addresses
+
+type fakeQuotationRepository struct {
+	repositories.QuotationRepository
+	store *memoryStore
+}
+
+func (r *fakeQuotationRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Quotation, error) {
+	quotation, ok := r.store.quotations[id]
+	if !ok {
+		return nil, fmt.Errorf("quotation with id %s not found", id)
+	}
+	stored := *quotation
+	return &stored, nil
+}
+
+func (r *fakeQuotationRepository) Update(ctx context.Context, quotation *entities.Quotation) error {
+	r.store.record(ctx, "quotations.update")
+	stored := *quotation
+	r.store.quotations[quotation.ID] = &stored
+	return nil
+}
+
+func (r *fakeQuotationRepository) Lock(ctx context.Context, id uuid.UUID) error {
+	if _, ok := r.store.quotations[id]; !ok {
+		return fmt.Errorf("quotation with id %s not found", id)
+	}
+	if _, inTx := database.TxFromContext(ctx); !inTx {
+		return fmt.Errorf("quotation %s locked outside of a transaction", id)
+	}
+	r.store.locked = append(r.store.locked, id)
+	return nil
+}
+
+type fakeCustomerRepository struct {
+	repositories.CustomerRepository
+	store *memoryStore
+}
+
+func (r *fakeCustomerRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Customer, error) {
+	customer, ok := r.store.customers[id]
+	if !ok {
+		return nil, fmt.Errorf("customer with id %s not found", id)
+	}
+	stored := *customer
+	return &stored, nil
+}
+
+func (r *fakeCustomerRepository) Lock(ctx context.Context, id uuid.UUID) error {
+	if _, ok := r.store.customers[id]; !ok {
+		return fmt.Errorf("customer with id %s not found", id)
+	}
+	if _, inTx := database.TxFromContext(ctx); !inTx {
+		return fmt.Errorf("customer %s locked outside of a transaction", id)
+	}
+	r.store.lockedCustomers = append(r.store.lockedCustomers, id)
+	return nil
+}
+
+func (r *fakeCustomerRepository) UpdateCreditUsed(ctx context.Context, customerID uuid.UUID, amount decimal.Decimal) error {
+	r.store.record(ctx, "customers.update_credit_used")
+	customer, ok := r.store.customers[customerID]
+	if !ok {
+		return fmt.Errorf("customer with id %s not found", customerID)
+	}
+	customer.CreditUsed = amount
+	return nil
+}
+
+type fakeAddressRepository struct {
+	repositories.OrderAddressRepository
+	store *memoryStore
+}
+
+func (r *fakeAddressRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.OrderAddress, error) {
+	address, ok := r.store.addresses[id]
+	if !ok {
+		return nil, fmt.Errorf("address with id %s not found", id)
+	}
+	stored := *address
+	return &stored, nil
+}
+
+// Products, warehouses and stock
+
+type fakeProductRepository struct {
+	productRepositories.ProductRepository
+	store *memoryStore
+}
+
+func (r *fakeProductRepository) GetByID(ctx context.Context, id uuid.UUID) (*productEntities.Product, error) {
+	product, ok := r.store.products[id]
+	if !ok {
+		return nil, fmt.Errorf("product with id %s not found", id)
+	}
+	stored := *product
+	return &stored, nil
+}
+
+type fakeWarehouseRepository struct {
+	invRepositories.WarehouseRepository
+	store *memoryStore
+}
+
+func (r *fakeWarehouseRepository) GetExtendedByID(ctx context.Context, id uuid.UUID) (*invEntities.WarehouseExtended, error) {
+	warehouse, ok := r.store.warehouses[id]
+	if !ok {
+		return nil, fmt.Errorf("warehouse with id %s not found", id)
+	}
+	stored := *warehouse
+	return &stored, nil
+}
+
+// fakeInventoryRepository reserves and releases stock like the database:
+// a bulk reservation fails as a whole when any warehouse is short
+type fakeInventoryRepository struct {
+	invRepositories.InventoryRepository
+	store *memoryStore
+}
+
+func (r *fakeInventoryRepository) GetProductInventory(ctx context.Context, productID uuid.UUID) ([]*invEntities.Inventory, error) {
+	var levels []*invEntities.Inventory
+	for _, level := range r.store.stock {
+		if level.ProductID == productID {
+			stored := *level
+			levels = append(levels, &stored)
+		}
+	}
+	return levels, nil
+}
+
+func (r *fakeInventoryRepository) BulkReserveStock(ctx context.Context, reservations []invRepositories.StockReservation) error {
+	r.store.record(ctx, "inventory.reserve")
+	needed := make(map[stockKey]int)
+	for _, reservation := range reservations {
+		needed[stockKey{reservation.ProductID, reservation.WarehouseID}] += reservation.Quantity
+	}
+	for key, quantity := range needed {
+		level := r.store.level(key.productID, key.warehouseID)
+		if level == nil || level.GetAvailableQuantity() < quantity {
+			return fmt.Errorf("insufficient stock of product %s in warehouse %s", key.productID, key.warehouseID)
+		}
+	}
+	for key, quantity := range needed {
+		r.store.level(key.productID, key.warehouseID).QuantityReserved += quantity
+	}
+	return nil
+}
+
+func (r *fakeInventoryRepository) ReleaseStock(ctx context.Context, productID, warehouseID uuid.UUID, quantity int) error {
+	r.store.record(ctx, "inventory.release")
+	level := r.store.level(productID, warehouseID)
+	if level == nil || level.QuantityReserved < quantity {
+		return fmt.Errorf("cannot release %d units of product %s in warehouse %s", quantity, productID, warehouseID)
+	}
+	level.QuantityReserved -= quantity
+	return nil
+}
+
+func (r *fakeInventoryRepository) AdjustStock(ctx context.Context, productID, warehouseID uuid.UUID, adjustment int) error {
+	r.store.record(ctx, "inventory.adjust")
+	level := r.store.level(productID, warehouseID)
+	if level == nil || level.QuantityOnHand+adjustment < 0 {
+		return fmt.Errorf("cannot adjust product %s in warehouse %s by %d", productID, warehouseID, adjustment)
+	}
+	level.QuantityOnHand += adjustment
+	return nil
+}
+
+type fakeInventoryTransactionRepository struct {
+	invRepositories.InventoryTransactionRepository
+	store *memoryStore
+}
+
+func (r *fakeInventoryTransactionRepository) Create(ctx context.Context, transaction *invEntities.InventoryTransaction) error {
+	r.store.record(ctx, "inventory_transactions.create")
+	return nil
+}
+
+type fakePurchaseOrderRepository struct {
+	purchasingRepositories.PurchaseOrderRepository
+}
+
+func (r *fakePurchaseOrderRepository) GetBySalesOrderID(ctx context.Context, salesOrderID uuid.UUID) ([]*purchasingEntities.PurchaseOrder, error) {
+	return nil, nil
+}
@@ -0,0 +1,453 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
+	"erpgo/pkg/database"
+)
+
+// InvoiceService defines the interface for invoice and credit note management
+type InvoiceService interface {
+	// GenerateInvoice bills the part of an order not invoiced yet, or the
+	// lines of one of its shipments
+	GenerateInvoice(ctx context.Context, req *GenerateInvoiceRequest) (*entities.Invoice, error)
+	// IssueInvoice numbers and locks a draft invoice
+	IssueInvoice(ctx context.Context, id string, req *IssueInvoiceRequest) (*entities.Invoice, error)
+	// CancelInvoice discards a draft invoice
+	CancelInvoice(ctx context.Context, id string, req *CancelInvoiceRequest) (*entities.Invoice, error)
+
+	GetInvoice(ctx context.Context, id string) (*entities.Invoice, error)
+	GetInvoiceByNumber(ctx context.Context, invoiceNumber string) (*entities.Invoice, error)
+	GetOrderInvoices(ctx context.Context, orderID string) ([]*entities.Invoice, error)
+	ListInvoices(ctx context.Context, req *ListInvoicesRequest) (*ListInvoicesResponse, error)
+}
+
+// GenerateInvoiceRequest represents a request to invoice an order
+type GenerateInvoiceRequest struct {
+	OrderID string `json:"order_id" validate:"required,uuid"`
+	// ShipmentID limits the invoice to the lines of one shipment
+	ShipmentID *string `json:"shipment_id,omitempty" validate:"omitempty,uuid"`
+	Notes      *string `json:"notes,omitempty"`
+	// Issue issues the invoice right away instead of leaving a draft
+	Issue     bool   `json:"issue"`
+	CreatedBy string `json:"created_by" validate:"required,uuid"`
+}
+
+// IssueInvoiceRequest represents a request to issue a draft invoice
+type IssueInvoiceRequest struct {
+	IssueDate *time.Time `json:"issue_date,omitempty"`
+	IssuedBy  string     `json:"issued_by" validate:"required,uuid"`
+}
+
+// CancelInvoiceRequest represents a request to cancel a draft invoice
+type CancelInvoiceRequest struct {
+	Reason      string `json:"reason" validate:"required"`
+	CancelledBy string `json:"cancelled_by" validate:"required,uuid"`
+}
+
+// ListInvoicesRequest represents a request to list invoices
+type ListInvoicesRequest struct {
+	Search     string                   `json:"search,omitempty"`
+	Type       *entities.InvoiceType    `json:"type,omitempty"`
+	Status     []entities.InvoiceStatus `json:"status,omitempty"`
+	CustomerID *string                  `json:"customer_id,omitempty"`
+	OrderID    *string                  `json:"order_id,omitempty"`
+	IssuedFrom *time.Time               `json:"issued_from,omitempty"`
+	IssuedTo   *time.Time               `json:"issued_to,omitempty"`
+	Page       int                      `json:"page"`
+	Limit      int                      `json:"limit"`
+}
+
+// ListInvoicesResponse represents a paginated list of invoices
+type ListInvoicesResponse struct {
+	Invoices   []*entities.Invoice `json:"invoices"`
+	Pagination *Pagination         `json:"pagination"`
+}
+
+// Invoice errors
+var (
+	ErrInvoiceNotFound  = errors.New("invoice not found")
+	ErrInvoiceLocked    = errors.New("invoice is locked")
+	ErrNothingToInvoice = errors.New("nothing to invoice")
+)
+
+// invoiceableStatuses are the order statuses that can be invoiced
+var invoiceableStatuses = map[entities.OrderStatus]bool{
+	entities.OrderStatusConfirmed:        true,
+	entities.OrderStatusProcessing:       true,
+	entities.OrderStatusPartiallyShipped: true,
+	entities.OrderStatusShipped:          true,
+	entities.OrderStatusDelivered:        true,
+}
+
+// InvoiceServiceImpl implements the InvoiceService interface
+type InvoiceServiceImpl struct {
+	invoiceRepo   repositories.InvoiceRepository
+	shipmentRepo  repositories.ShipmentRepository
+	customerRepo  repositories.CustomerRepository
+	orderService  Service
+	taxCalculator TaxCalculator
+	txManager     database.TransactionManagerInterface
+	logger        *zerolog.Logger
+}
+
+// NewInvoiceService creates a new invoice service. Orders are read through the
+// order service; credit notes are generated by the order service's refunds.
+// The tax calculator, when set, itemises the invoice tax by component.
+func NewInvoiceService(
+	invoiceRepo repositories.InvoiceRepository,
+	shipmentRepo repositories.ShipmentRepository,
+	customerRepo repositories.CustomerRepository,
+	orderService Service,
+	taxCalculator TaxCalculator,
+	txManager database.TransactionManagerInterface,
+	logger *zerolog.Logger,
+) InvoiceService {
+	return &InvoiceServiceImpl{
+		invoiceRepo:   invoiceRepo,
+		shipmentRepo:  shipmentRepo,
+		customerRepo:  customerRepo,
+		orderService:  orderService,
+		taxCalculator: taxCalculator,
+		txManager:     txManager,
+		logger:        logger,
+	}
+}
+
+// GenerateInvoice bills the part of an order not invoiced yet, or the lines of
+// one of its shipments. Shipping and the order-level discount are billed on
+// the first invoice of the order.
+func (s *InvoiceServiceImpl) GenerateInvoice(ctx context.Context, req *GenerateInvoiceRequest) (*entities.Invoice, error) {
+	createdBy, err := uuid.Parse(req.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid created by user ID: %w", err)
+	}
+
+	order, err := s.orderService.GetOrder(ctx, req.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if !invoiceableStatuses[order.Status] {
+		return nil, fmt.Errorf("%w: only confirmed or shipped orders can be invoiced, order is %s", ErrInvalidOrderStatus, order.Status)
+	}
+
+	customer := order.Customer
+	if customer == nil {
+		if customer, err = s.customerRepo.GetByID(ctx, order.CustomerID); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrCustomerNotFound, err)
+		}
+	}
+
+	existing, err := s.invoiceRepo.GetByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order invoices: %w", err)
+	}
+
+	quantities, shipmentID, err := s.invoiceQuantities(ctx, order, req.ShipmentID, existing)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	invoice := &entities.Invoice{
+		ID:               uuid.New(),
+		Type:             entities.InvoiceTypeInvoice,
+		Status:           entities.InvoiceStatusDraft,
+		OrderID:          order.ID,
+		ShipmentID:       shipmentID,
+		CustomerID:       order.CustomerID,
+		BillingAddressID: order.BillingAddressID,
+		Currency:         order.Currency,
+		PaymentTerms:     customer.Terms,
+		Notes:            req.Notes,
+		CreatedBy:        createdBy,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	for i := range order.Items {
+		item := &order.Items[i]
+		if quantity := quantities[item.ID]; quantity > 0 {
+			line := entities.NewInvoiceLine(item, quantity)
+			line.InvoiceID = invoice.ID
+			invoice.Lines = append(invoice.Lines, line)
+		}
+	}
+
+	invoiceDiscount := decimal.Zero
+	if !hasInvoice(existing) {
+		invoice.ShippingAmount = order.ShippingAmount
+		invoiceDiscount = orderLevelDiscount(order)
+	}
+	if err := invoice.CalculateTotals(invoiceDiscount); err != nil {
+		return nil, fmt.Errorf("failed to calculate invoice totals: %w", err)
+	}
+	if s.taxCalculator != nil {
+		taxes, err := s.taxCalculator.CalculateTax(ctx, order)
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate tax: %w", err)
+		}
+		invoice.ApplyTaxBreakdown(taxes.Portion(order.Items, quantities, !hasInvoice(existing)))
+	}
+
+	if req.Issue {
+		if err := invoice.Issue(createdBy, now); err != nil {
+			return nil, fmt.Errorf("invalid invoice: %v", err)
+		}
+	} else if err := invoice.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid invoice: %v", err)
+	}
+
+	err = database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		invoice.InvoiceNumber = ""
+		if err := s.invoiceRepo.Create(ctx, invoice); err != nil {
+			return fmt.Errorf("failed to create invoice: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info().
+		Str("order_number", order.OrderNumber).
+		Str("invoice_number", invoice.InvoiceNumber).
+		Str("status", string(invoice.Status)).
+		Str("total", invoice.TotalAmount.String()).
+		Msg("Invoice generated")
+
+	return invoice, nil
+}
+
+// IssueInvoice numbers and locks a draft invoice
+func (s *InvoiceServiceImpl) IssueInvoice(ctx context.Context, id string, req *IssueInvoiceRequest) (*entities.Invoice, error) {
+	issuedBy, err := uuid.Parse(req.IssuedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid issued by user ID: %w", err)
+	}
+
+	invoice, err := s.loadInvoice(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if invoice.IsLocked() {
+		return nil, fmt.Errorf("%w: invoice is %s", ErrInvoiceLocked, strings.ToLower(string(invoice.Status)))
+	}
+
+	issueDate := time.Now().UTC()
+	if req.IssueDate != nil {
+		issueDate = *req.IssueDate
+	}
+	if err := invoice.Issue(issuedBy, issueDate); err != nil {
+		return nil, fmt.Errorf("invalid invoice: %v", err)
+	}
+
+	err = database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		invoice.InvoiceNumber = ""
+		if err := s.invoiceRepo.Update(ctx, invoice); err != nil {
+			return fmt.Errorf("failed to issue invoice: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info().
+		Str("invoice_number", invoice.InvoiceNumber).
+		Time("due_date", *invoice.DueDate).
+		Msg("Invoice issued")
+
+	return invoice, nil
+}
+
+// CancelInvoice discards a draft invoice. Issued invoices are corrected with credit notes.
+func (s *InvoiceServiceImpl) CancelInvoice(ctx context.Context, id string, req *CancelInvoiceRequest) (*entities.Invoice, error) {
+	invoice, err := s.loadInvoice(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if invoice.IsLocked() {
+		return nil, fmt.Errorf("%w: invoice is %s", ErrInvoiceLocked, strings.ToLower(string(invoice.Status)))
+	}
+
+	if err := invoice.Cancel(req.Reason); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvoiceLocked, err)
+	}
+
+	if err := s.invoiceRepo.Update(ctx, invoice); err != nil {
+		return nil, fmt.Errorf("failed to cancel invoice: %w", err)
+	}
+
+	return invoice, nil
+}
+
+// GetInvoice retrieves an invoice or credit note by ID
+func (s *InvoiceServiceImpl) GetInvoice(ctx context.Context, id string) (*entities.Invoice, error) {
+	return s.loadInvoice(ctx, id)
+}
+
+// GetInvoiceByNumber retrieves an issued invoice or credit note by its number
+func (s *InvoiceServiceImpl) GetInvoiceByNumber(ctx context.Context, invoiceNumber string) (*entities.Invoice, error) {
+	invoice, err := s.invoiceRepo.GetByNumber(ctx, invoiceNumber)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrInvoiceNotFound
+		}
+		return nil, fmt.Errorf("failed to get invoice: %w", err)
+	}
+
+	return invoice, nil
+}
+
+// GetOrderInvoices retrieves the invoices and credit notes of an order, oldest first
+func (s *InvoiceServiceImpl) GetOrderInvoices(ctx context.Context, orderID string) ([]*entities.Invoice, error) {
+	id, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order ID: %w", err)
+	}
+
+	invoices, err := s.invoiceRepo.GetByOrderID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order invoices: %w", err)
+	}
+
+	return invoices, nil
+}
+
+// ListInvoices lists invoices and credit notes
+func (s *InvoiceServiceImpl) ListInvoices(ctx context.Context, req *ListInvoicesRequest) (*ListInvoicesResponse, error) {
+	page, limit := normalizePage(req.Page, req.Limit)
+
+	filter := repositories.InvoiceFilter{
+		Search:     req.Search,
+		Type:       req.Type,
+		Status:     req.Status,
+		IssuedFrom: req.IssuedFrom,
+		IssuedTo:   req.IssuedTo,
+		Page:       page,
+		Limit:      limit,
+		Offset:     (page - 1) * limit,
+	}
+	if req.CustomerID != nil {
+		customerID, err := uuid.Parse(*req.CustomerID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid customer ID: %w", err)
+		}
+		filter.CustomerID = &customerID
+	}
+	if req.OrderID != nil {
+		orderID, err := uuid.Parse(*req.OrderID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid order ID: %w", err)
+		}
+		filter.OrderID = &orderID
+	}
+
+	invoices, err := s.invoiceRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invoices: %w", err)
+	}
+
+	total, err := s.invoiceRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count invoices: %w", err)
+	}
+
+	return &ListInvoicesResponse{
+		Invoices:   invoices,
+		Pagination: newPagination(page, limit, total),
+	}, nil
+}
+
+// invoiceQuantities returns the quantity to bill per order line: the shipped
+// quantities of the shipment, or everything not invoiced yet. Lines never bill
+// more than their ordered quantity across all invoices.
+func (s *InvoiceServiceImpl) invoiceQuantities(ctx context.Context, order *entities.Order, shipmentIDParam *string, existing []*entities.Invoice) (map[uuid.UUID]int, *uuid.UUID, error) {
+	invoiced := entities.InvoicedQuantities(existing)
+	quantities := make(map[uuid.UUID]int)
+
+	if shipmentIDParam == nil {
+		for _, item := range order.Items {
+			if remaining := item.Quantity - invoiced[item.ID]; remaining > 0 {
+				quantities[item.ID] = remaining
+			}
+		}
+		if len(quantities) == 0 {
+			return nil, nil, fmt.Errorf("%w: order %s is fully invoiced", ErrNothingToInvoice, order.OrderNumber)
+		}
+		return quantities, nil, nil
+	}
+
+	shipmentID, err := uuid.Parse(*shipmentIDParam)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid shipment ID: %w", err)
+	}
+	shipment, err := s.shipmentRepo.GetByID(ctx, shipmentID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, nil, ErrShipmentNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to get shipment: %w", err)
+	}
+	if shipment.OrderID != order.ID {
+		return nil, nil, fmt.Errorf("%w: shipment %s does not belong to order %s", ErrShipmentNotFound, shipment.ShipmentNumber, order.OrderNumber)
+	}
+	for _, invoice := range existing {
+		if invoice.ShipmentID != nil && *invoice.ShipmentID == shipmentID && invoice.Status != entities.InvoiceStatusCancelled {
+			return nil, nil, fmt.Errorf("%w: shipment %s is already invoiced", ErrNothingToInvoice, shipment.ShipmentNumber)
+		}
+	}
+
+	ordered := make(map[uuid.UUID]int, len(order.Items))
+	for _, item := range order.Items {
+		ordered[item.ID] = item.Quantity
+	}
+	for _, shipped := range shipment.Items {
+		remaining := ordered[shipped.OrderItemID] - invoiced[shipped.OrderItemID] - quantities[shipped.OrderItemID]
+		if quantity := min(shipped.Quantity, remaining); quantity > 0 {
+			quantities[shipped.OrderItemID] += quantity
+		}
+	}
+	if len(quantities) == 0 {
+		return nil, nil, fmt.Errorf("%w: the lines of shipment %s are already invoiced", ErrNothingToInvoice, shipment.ShipmentNumber)
+	}
+
+	return quantities, &shipmentID, nil
+}
+
+func (s *InvoiceServiceImpl) loadInvoice(ctx context.Context, id string) (*entities.Invoice, error) {
+	invoiceID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid invoice ID: %w", err)
+	}
+
+	invoice, err := s.invoiceRepo.GetByID(ctx, invoiceID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrInvoiceNotFound
+		}
+		return nil, fmt.Errorf("failed to get invoice: %w", err)
+	}
+
+	return invoice, nil
+}
+
+// hasInvoice reports whether any invoice of the order is not cancelled
+func hasInvoice(invoices []*entities.Invoice) bool {
+	for _, invoice := range invoices {
+		if invoice.Type == entities.InvoiceTypeInvoice && invoice.Status != entities.InvoiceStatusCancelled {
+			return true
+		}
+	}
+	return false
+}
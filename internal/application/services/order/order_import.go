@@ -0,0 +1,567 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
+	productEntities "erpgo/internal/domain/products/entities"
+	"erpgo/pkg/database"
+)
+
+// maxImportRows caps the line items of one import file
+const maxImportRows = 5000
+
+// ImportOrdersRequest represents a request to import orders from a partner file
+type ImportOrdersRequest struct {
+	ProfileID string    `json:"profile_id" validate:"required,uuid"`
+	File      io.Reader `json:"-"`
+	// DryRun validates the file and reports the orders it would create
+	// without creating them
+	DryRun     bool   `json:"dry_run"`
+	ImportedBy string `json:"imported_by" validate:"required,uuid"`
+}
+
+// ImportedOrder is the order of an external reference in an import report
+type ImportedOrder struct {
+	ExternalReference string `json:"external_reference"`
+	// OrderID and OrderNumber are empty for orders of a dry run
+	OrderID     *uuid.UUID       `json:"order_id,omitempty"`
+	OrderNumber string           `json:"order_number,omitempty"`
+	Rows        []int            `json:"rows"`
+	TotalAmount *decimal.Decimal `json:"total_amount,omitempty"`
+	Currency    string           `json:"currency,omitempty"`
+}
+
+// ImportOrdersResponse reports the outcome of an order import. Orders are
+// created only when no row of the file has errors; references already
+// imported from the same source are skipped.
+type ImportOrdersResponse struct {
+	ProfileID   uuid.UUID                   `json:"profile_id"`
+	Source      string                      `json:"source"`
+	DryRun      bool                        `json:"dry_run"`
+	Committed   bool                        `json:"committed"`
+	TotalRows   int                         `json:"total_rows"`
+	TotalOrders int                         `json:"total_orders"`
+	Created     []ImportedOrder             `json:"created"`
+	Skipped     []ImportedOrder             `json:"skipped"`
+	Errors      []entities.OrderImportError `json:"errors"`
+}
+
+// Order import errors
+var (
+	ErrInvalidImportFile   = errors.New("invalid import file")
+	ErrOrderImportConflict = errors.New("external references were imported concurrently")
+)
+
+// ImportOrders imports the orders of a partner file through an import
+// profile. Rows are grouped into orders by external reference, priced from the
+// catalogue unless they carry a unit price, and checked with ValidateOrder.
+// Nothing is created when any row has errors.
+func (s *ServiceImpl) ImportOrders(ctx context.Context, req *ImportOrdersRequest) (*ImportOrdersResponse, error) {
+	importedBy, err := uuid.Parse(req.ImportedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid imported by user ID: %w", err)
+	}
+	ctx = withActorID(ctx, req.ImportedBy)
+
+	profile, err := loadImportProfile(ctx, s.importRepo, req.ProfileID)
+	if err != nil {
+		return nil, err
+	}
+	if !profile.IsActive {
+		return nil, ErrOrderImportProfileInactive
+	}
+
+	rows, err := profile.Parse(req.File)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: file has no rows", ErrInvalidImportFile)
+	}
+	if len(rows) > maxImportRows {
+		return nil, fmt.Errorf("%w: file has %d rows, at most %d are imported at once", ErrInvalidImportFile, len(rows), maxImportRows)
+	}
+
+	groups, importErrors := entities.GroupOrderImportRows(rows)
+	response := &ImportOrdersResponse{
+		ProfileID:   profile.ID,
+		Source:      profile.Source,
+		DryRun:      req.DryRun,
+		TotalRows:   len(rows),
+		TotalOrders: len(groups),
+		Created:     []ImportedOrder{},
+		Skipped:     []ImportedOrder{},
+		Errors:      importErrors,
+	}
+
+	references := make([]string, len(groups))
+	for i, group := range groups {
+		references[i] = group.ExternalReference
+	}
+	existing, err := s.importRepo.GetExternalReferences(ctx, profile.Source, references)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get external references: %w", err)
+	}
+	imported := make(map[string]*entities.OrderExternalReference, len(existing))
+	for _, reference := range existing {
+		imported[reference.ExternalReference] = reference
+	}
+
+	lookups := newImportLookups(s)
+	var orders []*entities.Order
+	var orderGroups []entities.OrderImportGroup
+	for _, group := range groups {
+		if reference, exists := imported[group.ExternalReference]; exists {
+			orderID := reference.OrderID
+			response.Skipped = append(response.Skipped, ImportedOrder{
+				ExternalReference: group.ExternalReference,
+				OrderID:           &orderID,
+				OrderNumber:       reference.OrderNumber,
+				Rows:              rowNumbers(group),
+			})
+			continue
+		}
+
+		order, groupErrors := s.buildImportedOrder(ctx, profile, group, req.ImportedBy, lookups)
+		if len(groupErrors) > 0 {
+			response.Errors = append(response.Errors, groupErrors...)
+			continue
+		}
+		orders = append(orders, order)
+		orderGroups = append(orderGroups, group)
+	}
+
+	if len(response.Errors) > 0 {
+		return response, nil
+	}
+	if req.DryRun || len(orders) == 0 {
+		for i, order := range orders {
+			response.Created = append(response.Created, importedOrder(orderGroups[i], order, false))
+		}
+		return response, nil
+	}
+
+	now := time.Now().UTC()
+	claims := make([]*entities.OrderExternalReference, len(orders))
+	for i, order := range orders {
+		profileID := profile.ID
+		claims[i] = &entities.OrderExternalReference{
+			Source:            profile.Source,
+			ExternalReference: orderGroups[i].ExternalReference,
+			OrderID:           order.ID,
+			ProfileID:         &profileID,
+			CreatedBy:         importedBy,
+			CreatedAt:         now,
+		}
+	}
+
+	// The references, orders and items commit together. References are
+	// claimed first, so a file imported twice at once waits for the first
+	// import and then conflicts instead of creating its orders again.
+	err = database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		if err := s.importRepo.ClaimExternalReferences(ctx, claims); err != nil {
+			if strings.Contains(err.Error(), "already exists") {
+				return fmt.Errorf("%w: %v", ErrOrderImportConflict, err)
+			}
+			return fmt.Errorf("failed to record external references: %w", err)
+		}
+
+		if err := s.orderRepo.BulkCreate(ctx, orders); err != nil {
+			return fmt.Errorf("failed to create orders: %w", err)
+		}
+
+		var items []*entities.OrderItem
+		for _, order := range orders {
+			for i := range order.Items {
+				items = append(items, &order.Items[i])
+			}
+		}
+		if err := s.orderItemRepo.BulkCreate(ctx, items); err != nil {
+			return fmt.Errorf("failed to create order items: %w", err)
+		}
+
+		for _, order := range orders {
+			if err := s.recordStatusChange(ctx, order, nil, "imported from "+profile.Source); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Approval chains do not undo the import; orders whose chain could not
+	// be requested get it on their next change
+	for _, order := range orders {
+		if _, err := s.requestApprovals(ctx, order); err != nil {
+			s.logger.Error().Err(err).Str("order_id", order.ID.String()).Msg("Failed to request approvals for imported order")
+		}
+	}
+
+	for i, order := range orders {
+		response.Created = append(response.Created, importedOrder(orderGroups[i], order, true))
+	}
+	response.Committed = true
+
+	s.logger.Info().
+		Str("profile_id", profile.ID.String()).
+		Str("source", profile.Source).
+		Int("orders", len(orders)).
+		Int("skipped", len(response.Skipped)).
+		Msg("Orders imported")
+
+	return response, nil
+}
+
+// buildImportedOrder builds and validates the order of an external
+// reference. Header values are taken from the first row of the group.
+func (s *ServiceImpl) buildImportedOrder(ctx context.Context, profile *entities.OrderImportProfile, group entities.OrderImportGroup, importedBy string, lookups *importLookups) (*entities.Order, []entities.OrderImportError) {
+	first := group.Rows[0]
+	var errs []entities.OrderImportError
+
+	req := &CreateOrderRequest{
+		Type:           entities.OrderTypeSales,
+		Priority:       entities.OrderPriority(strings.ToUpper(first.Value(entities.OrderImportFieldPriority))),
+		ShippingMethod: entities.ShippingMethod(strings.ToUpper(first.Value(entities.OrderImportFieldShippingMethod))),
+		Currency:       first.Value(entities.OrderImportFieldCurrency),
+		Notes:          optionalValue(first, entities.OrderImportFieldNotes),
+		CustomerNotes:  optionalValue(first, entities.OrderImportFieldCustomerNotes),
+		CreatedBy:      importedBy,
+	}
+	if req.ShippingMethod == "" {
+		req.ShippingMethod = entities.ShippingMethodStandard
+	}
+
+	customer, err := lookups.customer(ctx, first)
+	if err != nil {
+		errs = append(errs, *err)
+	} else {
+		req.CustomerID = customer.ID.String()
+		req.ShippingAddressID, err = lookups.address(ctx, first, entities.OrderImportFieldShippingAddressID, customer.ID, "SHIPPING")
+		if err != nil {
+			errs = append(errs, *err)
+		}
+		req.BillingAddressID, err = lookups.address(ctx, first, entities.OrderImportFieldBillingAddressID, customer.ID, "BILLING")
+		if err != nil {
+			errs = append(errs, *err)
+		}
+	}
+
+	if value := first.Value(entities.OrderImportFieldRequiredDate); value != "" {
+		requiredDate, parseErr := parseImportDate(value)
+		if parseErr != nil {
+			errs = append(errs, first.Error(entities.OrderImportFieldRequiredDate, "required date %q must be a date such as 2006-01-02", value))
+		} else {
+			req.RequiredDate = &requiredDate
+		}
+	}
+	if amount, err := decimalValue(first, entities.OrderImportFieldShippingAmount); err != nil {
+		errs = append(errs, *err)
+	} else {
+		req.ShippingAmount = amount
+	}
+
+	for _, row := range group.Rows {
+		item, rowErrs := lookups.item(ctx, row)
+		if len(rowErrs) > 0 {
+			errs = append(errs, rowErrs...)
+			continue
+		}
+		req.Items = append(req.Items, *item)
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	order, prepareErr := s.prepareOrder(ctx, req)
+	if prepareErr != nil {
+		return nil, []entities.OrderImportError{first.Error("", "%s", prepareErr.Error())}
+	}
+	internalNotes := fmt.Sprintf("Imported from %s order %s", profile.Source, group.ExternalReference)
+	order.InternalNotes = &internalNotes
+
+	validation := entities.ValidateOrder(order)
+	for _, message := range validation.Errors {
+		errs = append(errs, first.Error("", "%s", message))
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	return order, nil
+}
+
+// importLookups resolves the customers, addresses and products of an import,
+// loading each once
+type importLookups struct {
+	service   *ServiceImpl
+	customers map[string]*entities.Customer
+	products  map[string]*productEntities.Product
+	defaults  map[string]string
+}
+
+func newImportLookups(service *ServiceImpl) *importLookups {
+	return &importLookups{
+		service:   service,
+		customers: make(map[string]*entities.Customer),
+		products:  make(map[string]*productEntities.Product),
+		defaults:  make(map[string]string),
+	}
+}
+
+// customer resolves the customer of a row by ID, code or email, in that order
+func (l *importLookups) customer(ctx context.Context, row entities.OrderImportRow) (*entities.Customer, *entities.OrderImportError) {
+	var field entities.OrderImportField
+	for _, candidate := range []entities.OrderImportField{
+		entities.OrderImportFieldCustomerID,
+		entities.OrderImportFieldCustomerCode,
+		entities.OrderImportFieldCustomerEmail,
+	} {
+		if row.Value(candidate) != "" {
+			field = candidate
+			break
+		}
+	}
+	if field == "" {
+		err := row.Error(entities.OrderImportFieldCustomerID, "customer ID, code or email is required")
+		return nil, &err
+	}
+
+	value := row.Value(field)
+	key := string(field) + ":" + strings.ToLower(value)
+	if customer, exists := l.customers[key]; exists {
+		return customer, nil
+	}
+
+	var customer *entities.Customer
+	var err error
+	switch field {
+	case entities.OrderImportFieldCustomerID:
+		customer, err = findCustomer(ctx, l.service.customerRepo, value)
+	case entities.OrderImportFieldCustomerCode:
+		customer, err = l.service.customerRepo.GetByCustomerCode(ctx, value)
+	default:
+		customer, err = l.service.customerRepo.GetByEmail(ctx, value)
+	}
+	if err != nil {
+		if errors.Is(err, ErrCustomerNotFound) || strings.Contains(err.Error(), "not found") {
+			importErr := row.Error(field, "customer %s not found", value)
+			return nil, &importErr
+		}
+		importErr := row.Error(field, "%s", err.Error())
+		return nil, &importErr
+	}
+	if !customer.IsActive {
+		importErr := row.Error(field, "customer %s is inactive", value)
+		return nil, &importErr
+	}
+
+	l.customers[key] = customer
+	return customer, nil
+}
+
+// address returns the address of a row, or the customer's default address of the type
+func (l *importLookups) address(ctx context.Context, row entities.OrderImportRow, field entities.OrderImportField, customerID uuid.UUID, addressType string) (string, *entities.OrderImportError) {
+	if value := row.Value(field); value != "" {
+		if _, err := uuid.Parse(value); err != nil {
+			importErr := row.Error(field, "address ID %q is not a valid UUID", value)
+			return "", &importErr
+		}
+		return value, nil
+	}
+
+	key := customerID.String() + ":" + addressType
+	if id, exists := l.defaults[key]; exists {
+		return id, nil
+	}
+	address, err := l.service.addressRepo.GetDefaultAddress(ctx, customerID, addressType)
+	if err != nil {
+		importErr := row.Error(field, "%s is required, the customer has no default %s address", field, strings.ToLower(addressType))
+		return "", &importErr
+	}
+
+	l.defaults[key] = address.ID.String()
+	return l.defaults[key], nil
+}
+
+// item builds the order line of a row, resolving its product by ID or SKU
+func (l *importLookups) item(ctx context.Context, row entities.OrderImportRow) (*CreateOrderItemRequest, []entities.OrderImportError) {
+	var errs []entities.OrderImportError
+
+	product, err := l.product(ctx, row)
+	if err != nil {
+		errs = append(errs, *err)
+	}
+
+	quantity, parseErr := strconv.Atoi(row.Value(entities.OrderImportFieldQuantity))
+	if parseErr != nil || quantity <= 0 {
+		errs = append(errs, row.Error(entities.OrderImportFieldQuantity, "quantity %q must be a positive whole number", row.Value(entities.OrderImportFieldQuantity)))
+	}
+
+	unitPrice, err := decimalValue(row, entities.OrderImportFieldUnitPrice)
+	if err != nil {
+		errs = append(errs, *err)
+	}
+	discount, err := decimalValue(row, entities.OrderImportFieldDiscountAmount)
+	if err != nil {
+		errs = append(errs, *err)
+	}
+	taxRate, err := decimalValue(row, entities.OrderImportFieldTaxRate)
+	if err != nil {
+		errs = append(errs, *err)
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	return &CreateOrderItemRequest{
+		ProductID:      product.ID.String(),
+		Quantity:       quantity,
+		UnitPrice:      unitPrice,
+		DiscountAmount: discount,
+		TaxRate:        taxRate,
+		Notes:          optionalValue(row, entities.OrderImportFieldItemNotes),
+	}, nil
+}
+
+// product resolves the active product of a row by ID or SKU
+func (l *importLookups) product(ctx context.Context, row entities.OrderImportRow) (*productEntities.Product, *entities.OrderImportError) {
+	field := entities.OrderImportFieldProductID
+	value := row.Value(field)
+	if value == "" {
+		field = entities.OrderImportFieldSKU
+		value = row.Value(field)
+	}
+	if value == "" {
+		err := row.Error(entities.OrderImportFieldSKU, "product ID or SKU is required")
+		return nil, &err
+	}
+
+	key := string(field) + ":" + value
+	if product, exists := l.products[key]; exists {
+		return product, nil
+	}
+
+	var product *productEntities.Product
+	var err error
+	if field == entities.OrderImportFieldProductID {
+		productID, parseErr := uuid.Parse(value)
+		if parseErr != nil {
+			importErr := row.Error(field, "product ID %q is not a valid UUID", value)
+			return nil, &importErr
+		}
+		product, err = l.service.productRepo.GetByID(ctx, productID)
+	} else {
+		product, err = l.service.productRepo.GetBySKU(ctx, value)
+	}
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			importErr := row.Error(field, "product %s not found", value)
+			return nil, &importErr
+		}
+		importErr := row.Error(field, "%s", err.Error())
+		return nil, &importErr
+	}
+	if !product.IsActive {
+		importErr := row.Error(field, "product %s is inactive", value)
+		return nil, &importErr
+	}
+
+	l.products[key] = product
+	return product, nil
+}
+
+// loadImportProfile parses the ID and loads the import profile, mapping
+// missing rows to ErrOrderImportProfileNotFound
+func loadImportProfile(ctx context.Context, importRepo repositories.OrderImportRepository, id string) (*entities.OrderImportProfile, error) {
+	profileID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order import profile ID: %w", err)
+	}
+
+	profile, err := importRepo.GetProfileByID(ctx, profileID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrOrderImportProfileNotFound
+		}
+		return nil, fmt.Errorf("failed to get order import profile: %w", err)
+	}
+
+	return profile, nil
+}
+
+// importedOrder reports the order of an import group
+func importedOrder(group entities.OrderImportGroup, order *entities.Order, committed bool) ImportedOrder {
+	total := order.TotalAmount
+	imported := ImportedOrder{
+		ExternalReference: group.ExternalReference,
+		Rows:              rowNumbers(group),
+		TotalAmount:       &total,
+		Currency:          order.Currency,
+	}
+	if committed {
+		orderID := order.ID
+		imported.OrderID = &orderID
+		imported.OrderNumber = order.OrderNumber
+	}
+	return imported
+}
+
+// rowNumbers lists the distinct file rows of an import group
+func rowNumbers(group entities.OrderImportGroup) []int {
+	numbers := make([]int, 0, len(group.Rows))
+	for _, row := range group.Rows {
+		if len(numbers) == 0 || numbers[len(numbers)-1] != row.Number {
+			numbers = append(numbers, row.Number)
+		}
+	}
+	return numbers
+}
+
+// optionalValue returns the value of a field, nil when the row has none
+func optionalValue(row entities.OrderImportRow, field entities.OrderImportField) *string {
+	if value := row.Value(field); value != "" {
+		return &value
+	}
+	return nil
+}
+
+// decimalValue parses an optional amount of a row, zero when it has none
+func decimalValue(row entities.OrderImportRow, field entities.OrderImportField) (decimal.Decimal, *entities.OrderImportError) {
+	value := row.Value(field)
+	if value == "" {
+		return decimal.Zero, nil
+	}
+	amount, err := decimal.NewFromString(value)
+	if err != nil || amount.IsNegative() {
+		importErr := row.Error(field, "%s %q must be a non-negative number", field, value)
+		return decimal.Zero, &importErr
+	}
+	return amount, nil
+}
+
+// parseImportDate parses a date or an RFC 3339 timestamp
+func parseImportDate(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.UTC(), nil
+}
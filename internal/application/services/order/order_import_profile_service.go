@@ -0,0 +1,211 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
+)
+
+// OrderImportProfileService defines the interface for order import profile
+// management. Orders are imported through the order service.
+type OrderImportProfileService interface {
+	CreateOrderImportProfile(ctx context.Context, req *CreateOrderImportProfileRequest) (*entities.OrderImportProfile, error)
+	GetOrderImportProfile(ctx context.Context, id string) (*entities.OrderImportProfile, error)
+	UpdateOrderImportProfile(ctx context.Context, id string, req *UpdateOrderImportProfileRequest) (*entities.OrderImportProfile, error)
+	ListOrderImportProfiles(ctx context.Context, req *ListOrderImportProfilesRequest) (*ListOrderImportProfilesResponse, error)
+}
+
+// CreateOrderImportProfileRequest represents a request to create an order import profile
+type CreateOrderImportProfileRequest struct {
+	Name        string                               `json:"name" validate:"required"`
+	Description *string                              `json:"description,omitempty"`
+	Source      string                               `json:"source" validate:"required"`
+	Format      entities.OrderImportFormat           `json:"format" validate:"required"`
+	Delimiter   string                               `json:"delimiter,omitempty"`
+	Columns     map[entities.OrderImportField]string `json:"columns,omitempty"`
+	Defaults    map[entities.OrderImportField]string `json:"defaults,omitempty"`
+	CreatedBy   string                               `json:"created_by" validate:"required,uuid"`
+}
+
+// UpdateOrderImportProfileRequest represents a request to update an order
+// import profile. Columns and defaults are replaced when set.
+type UpdateOrderImportProfileRequest struct {
+	Name        *string                              `json:"name,omitempty"`
+	Description *string                              `json:"description,omitempty"`
+	Source      *string                              `json:"source,omitempty"`
+	Format      *entities.OrderImportFormat          `json:"format,omitempty"`
+	Delimiter   *string                              `json:"delimiter,omitempty"`
+	Columns     map[entities.OrderImportField]string `json:"columns,omitempty"`
+	Defaults    map[entities.OrderImportField]string `json:"defaults,omitempty"`
+	IsActive    *bool                                `json:"is_active,omitempty"`
+}
+
+// ListOrderImportProfilesRequest represents a request to list order import profiles
+type ListOrderImportProfilesRequest struct {
+	Search   string `json:"search,omitempty"`
+	Source   string `json:"source,omitempty"`
+	IsActive *bool  `json:"is_active,omitempty"`
+	Page     int    `json:"page"`
+	Limit    int    `json:"limit"`
+}
+
+// ListOrderImportProfilesResponse represents a paginated list of order import profiles
+type ListOrderImportProfilesResponse struct {
+	Profiles   []*entities.OrderImportProfile `json:"profiles"`
+	Pagination *Pagination                    `json:"pagination"`
+}
+
+// Order import profile errors
+var (
+	ErrOrderImportProfileNotFound = errors.New("order import profile not found")
+	ErrOrderImportProfileInactive = errors.New("order import profile is inactive")
+)
+
+// OrderImportProfileServiceImpl implements the OrderImportProfileService interface
+type OrderImportProfileServiceImpl struct {
+	importRepo repositories.OrderImportRepository
+	logger     *zerolog.Logger
+}
+
+// NewOrderImportProfileService creates a new order import profile service
+func NewOrderImportProfileService(
+	importRepo repositories.OrderImportRepository,
+	logger *zerolog.Logger,
+) OrderImportProfileService {
+	return &OrderImportProfileServiceImpl{
+		importRepo: importRepo,
+		logger:     logger,
+	}
+}
+
+// CreateOrderImportProfile creates an active order import profile. CSV
+// profiles are comma separated unless given another delimiter.
+func (s *OrderImportProfileServiceImpl) CreateOrderImportProfile(ctx context.Context, req *CreateOrderImportProfileRequest) (*entities.OrderImportProfile, error) {
+	createdBy, err := uuid.Parse(req.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid created by user ID: %w", err)
+	}
+
+	now := time.Now().UTC()
+	profile := &entities.OrderImportProfile{
+		ID:          uuid.New(),
+		Name:        strings.TrimSpace(req.Name),
+		Description: trimmedOrNil(req.Description),
+		Source:      strings.TrimSpace(req.Source),
+		Format:      entities.OrderImportFormat(strings.ToUpper(string(req.Format))),
+		Delimiter:   req.Delimiter,
+		Columns:     req.Columns,
+		Defaults:    req.Defaults,
+		IsActive:    true,
+		CreatedBy:   createdBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if profile.Delimiter == "" {
+		profile.Delimiter = ","
+	}
+
+	if err := profile.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid order import profile data: %w", err)
+	}
+
+	if err := s.importRepo.CreateProfile(ctx, profile); err != nil {
+		return nil, fmt.Errorf("failed to create order import profile: %w", err)
+	}
+
+	s.logger.Info().
+		Str("profile_id", profile.ID.String()).
+		Str("name", profile.Name).
+		Str("source", profile.Source).
+		Msg("Order import profile created")
+
+	return profile, nil
+}
+
+// GetOrderImportProfile retrieves an order import profile by ID
+func (s *OrderImportProfileServiceImpl) GetOrderImportProfile(ctx context.Context, id string) (*entities.OrderImportProfile, error) {
+	return loadImportProfile(ctx, s.importRepo, id)
+}
+
+// UpdateOrderImportProfile updates the mapping or status of an order import
+// profile. References already imported stay with the source they were
+// imported from.
+func (s *OrderImportProfileServiceImpl) UpdateOrderImportProfile(ctx context.Context, id string, req *UpdateOrderImportProfileRequest) (*entities.OrderImportProfile, error) {
+	profile, err := loadImportProfile(ctx, s.importRepo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		profile.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		profile.Description = trimmedOrNil(req.Description)
+	}
+	if req.Source != nil {
+		profile.Source = strings.TrimSpace(*req.Source)
+	}
+	if req.Format != nil {
+		profile.Format = entities.OrderImportFormat(strings.ToUpper(string(*req.Format)))
+	}
+	if req.Delimiter != nil {
+		profile.Delimiter = *req.Delimiter
+	}
+	if req.Columns != nil {
+		profile.Columns = req.Columns
+	}
+	if req.Defaults != nil {
+		profile.Defaults = req.Defaults
+	}
+	if req.IsActive != nil {
+		profile.IsActive = *req.IsActive
+	}
+	profile.UpdatedAt = time.Now().UTC()
+
+	if err := profile.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid order import profile data: %w", err)
+	}
+
+	if err := s.importRepo.UpdateProfile(ctx, profile); err != nil {
+		return nil, fmt.Errorf("failed to update order import profile: %w", err)
+	}
+
+	return profile, nil
+}
+
+// ListOrderImportProfiles lists order import profiles by name
+func (s *OrderImportProfileServiceImpl) ListOrderImportProfiles(ctx context.Context, req *ListOrderImportProfilesRequest) (*ListOrderImportProfilesResponse, error) {
+	page, limit := normalizePage(req.Page, req.Limit)
+
+	filter := repositories.OrderImportProfileFilter{
+		Search:   req.Search,
+		Source:   req.Source,
+		IsActive: req.IsActive,
+		Page:     page,
+		Limit:    limit,
+		Offset:   (page - 1) * limit,
+	}
+
+	profiles, err := s.importRepo.ListProfiles(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list order import profiles: %w", err)
+	}
+
+	total, err := s.importRepo.CountProfiles(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count order import profiles: %w", err)
+	}
+
+	return &ListOrderImportProfilesResponse{
+		Profiles:   profiles,
+		Pagination: newPagination(page, limit, total),
+	}, nil
+}
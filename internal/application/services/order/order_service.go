@@ -3,12 +3,23 @@ package order
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
 	"github.com/shopspring/decimal"
 
+	invEntities "erpgo/internal/domain/inventory/entities"
+	invRepositories "erpgo/internal/domain/inventory/repositories"
 	"erpgo/internal/domain/orders/entities"
 	"erpgo/internal/domain/orders/repositories"
+	productEntities "erpgo/internal/domain/products/entities"
+	productRepositories "erpgo/internal/domain/products/repositories"
+	purchasingRepositories "erpgo/internal/domain/purchasing/repositories"
+	"erpgo/pkg/database"
 )
 
 // Service defines the business logic interface for order management
@@ -28,34 +39,69 @@ type Service interface {
 	ApproveOrder(ctx context.Context, id string, approvedBy string) (*entities.Order, error)
 	HoldOrder(ctx context.Context, id string, reason string) (*entities.Order, error)
 	UnholdOrder(ctx context.Context, id string) (*entities.Order, error)
+	OverrideCreditHold(ctx context.Context, id string, req *OverrideCreditHoldRequest) (*entities.Order, error)
+	RejectOrder(ctx context.Context, id string, req *RejectOrderRequest) (*entities.Order, error)
+	GetOrderApprovals(ctx context.Context, id string) ([]*entities.OrderApproval, error)
+	GetPendingApprovals(ctx context.Context, req *GetPendingApprovalsRequest) (*GetPendingApprovalsResponse, error)
+	GetOrderStatusHistory(ctx context.Context, id string) ([]*entities.OrderStatusHistory, error)
+	GetOrderTimeline(ctx context.Context, id string) ([]entities.OrderTimelineEvent, error)
 
 	// Order fulfillment
 	ProcessOrder(ctx context.Context, id string) (*entities.Order, error)
 	ShipOrder(ctx context.Context, id string, req *ShipOrderRequest) (*entities.Order, error)
 	DeliverOrder(ctx context.Context, id string, req *DeliverOrderRequest) (*entities.Order, error)
 	PartialShipOrder(ctx context.Context, id string, req *PartialShipOrderRequest) (*entities.Order, error)
+	GetOrderShipments(ctx context.Context, id string) ([]*entities.Shipment, error)
 	ReturnOrderItems(ctx context.Context, id string, req *ReturnItemsRequest) (*entities.Order, error)
+	// Drop-ship lines ship when their supplier confirms shipping the
+	// purchase order placed for them, through the API or a confirmation file
+	ConfirmDropShipment(ctx context.Context, purchaseOrderID string, req *ConfirmDropShipmentRequest) (*DropShipment, error)
+	ImportDropShipConfirmations(ctx context.Context, req *ImportDropShipConfirmationsRequest) (*ImportDropShipConfirmationsResponse, error)
 
 	// Payment processing
 	ProcessPayment(ctx context.Context, id string, req *ProcessPaymentRequest) (*entities.Order, error)
 	RefundOrder(ctx context.Context, id string, req *RefundOrderRequest) (*entities.Order, error)
 	PartialRefundOrder(ctx context.Context, id string, req *PartialRefundOrderRequest) (*entities.Order, error)
+	RecordPayment(ctx context.Context, req *RecordPaymentRequest) (*entities.Payment, error)
+	AllocatePayment(ctx context.Context, id string, req *AllocatePaymentRequest) (*entities.Payment, error)
+	ReversePayment(ctx context.Context, id string, req *ReversePaymentRequest) (*entities.Payment, error)
+	GetPayment(ctx context.Context, id string) (*entities.Payment, error)
+	GetOrderPayments(ctx context.Context, id string) ([]*entities.Payment, error)
+	ListPayments(ctx context.Context, req *ListPaymentsRequest) (*ListPaymentsResponse, error)
+
+	// Receivables, aged in the base currency
+	MarkOverdueOrders(ctx context.Context, asOf time.Time) (int, error)
+	GetARAgingReport(ctx context.Context, req *GetARAgingReportRequest) (*entities.ARAgingReport, error)
 
 	// Order item management
 	AddOrderItem(ctx context.Context, orderID string, req *AddOrderItemRequest) (*entities.Order, error)
 	UpdateOrderItem(ctx context.Context, orderID, itemID string, req *UpdateOrderItemRequest) (*entities.Order, error)
 	RemoveOrderItem(ctx context.Context, orderID, itemID string) (*entities.Order, error)
 
+	// Amendments of confirmed orders, kept as numbered revisions
+	AmendOrder(ctx context.Context, id string, req *AmendOrderRequest) (*entities.OrderRevision, error)
+	GetOrderRevisions(ctx context.Context, id string) ([]*entities.OrderRevision, error)
+	GetOrderRevision(ctx context.Context, id string, number int) (*entities.OrderRevision, error)
+	ApproveOrderRevision(ctx context.Context, id string, number int, req *ApproveOrderRevisionRequest) (*entities.OrderRevision, error)
+	RejectOrderRevision(ctx context.Context, id string, number int, req *RejectOrderRevisionRequest) (*entities.OrderRevision, error)
+
 	// Order validation and calculation
 	ValidateOrder(ctx context.Context, id string) (*entities.OrderValidation, error)
 	CalculateOrderTotals(ctx context.Context, id string) (*entities.OrderCalculation, error)
 	RecalculateOrder(ctx context.Context, id string) (*entities.Order, error)
+	// QuoteShipping prices the shipping methods available for an order
+	QuoteShipping(ctx context.Context, id string) ([]entities.ShippingQuote, error)
+
+	// Coupons
+	ApplyCoupon(ctx context.Context, id string, req *ApplyCouponRequest) (*entities.Order, error)
+	RemoveCoupon(ctx context.Context, id, code string) (*entities.Order, error)
 
 	// Customer order management
 	GetCustomerOrders(ctx context.Context, customerID string, req *GetCustomerOrdersRequest) (*GetCustomerOrdersResponse, error)
 	GetCustomerOrderHistory(ctx context.Context, customerID string, limit int) ([]*entities.Order, error)
 
-	// Order analytics and reporting
+	// Order analytics and reporting, with amounts in the base currency
+	BaseCurrency() string
 	GetOrderStats(ctx context.Context, req *GetOrderStatsRequest) (*repositories.OrderStats, error)
 	GetRevenueByPeriod(ctx context.Context, req *GetRevenueByPeriodRequest) ([]*repositories.RevenueByPeriod, error)
 	GetTopCustomers(ctx context.Context, req *GetTopCustomersRequest) ([]*repositories.CustomerOrderStats, error)
@@ -67,16 +113,27 @@ type Service interface {
 	ReserveInventory(ctx context.Context, orderID string) error
 	ReleaseInventoryReservation(ctx context.Context, orderID string) error
 	ConsumeInventory(ctx context.Context, orderID string) error
+	// SourceOrder plans again which warehouses fulfil the order's reserved
+	// lines and moves its reservations to match
+	SourceOrder(ctx context.Context, orderID string) ([]*entities.OrderAllocation, error)
+	GetOrderAllocations(ctx context.Context, orderID string) ([]*entities.OrderAllocation, error)
 
 	// Bulk operations
 	BulkUpdateStatus(ctx context.Context, req *BulkUpdateStatusRequest) (*BulkUpdateStatusResponse, error)
 	BulkCancelOrders(ctx context.Context, req *BulkCancelOrdersRequest) (*BulkCancelOrdersResponse, error)
+	// ImportOrders creates the orders of a partner file through an import profile
+	ImportOrders(ctx context.Context, req *ImportOrdersRequest) (*ImportOrdersResponse, error)
 
 	// Order management utilities
 	GenerateOrderNumber(ctx context.Context) (string, error)
 	CloneOrder(ctx context.Context, id string, req *CloneOrderRequest) (*entities.Order, error)
+
+	// Archive of closed orders; archived orders are still read by ID or
+	// number but left out of order lists
 	ArchiveOrder(ctx context.Context, id string) error
 	RestoreOrder(ctx context.Context, id string) error
+	ArchiveClosedOrders(ctx context.Context, policy entities.OrderArchivePolicy, asOf time.Time) (int, error)
+	ListArchivedOrders(ctx context.Context, req *ListArchivedOrdersRequest) (*ListOrdersResponse, error)
 }
 
 // Request/Response DTOs
@@ -94,8 +151,10 @@ type CreateOrderRequest struct {
 	Notes             *string                  `json:"notes,omitempty"`
 	CustomerNotes     *string                  `json:"customer_notes,omitempty"`
 	Items             []CreateOrderItemRequest `json:"items" validate:"required,min=1"`
+	ShippingAmount    decimal.Decimal          `json:"shipping_amount,omitempty"`
 	DiscountCode      *string                  `json:"discount_code,omitempty"`
 	PaymentMethod     *string                  `json:"payment_method,omitempty"`
+	CreatedBy         string                   `json:"created_by" validate:"required,uuid"`
 }
 
 // CreateOrderItemRequest represents a request to add an item to an order
@@ -106,6 +165,9 @@ type CreateOrderItemRequest struct {
 	DiscountAmount decimal.Decimal `json:"discount_amount,omitempty"`
 	TaxRate        decimal.Decimal `json:"tax_rate,omitempty"`
 	Notes          *string         `json:"notes,omitempty"`
+	// DropShip overrides whether the product's supplier ships the line;
+	// lines follow the product when it is not set
+	DropShip *bool `json:"drop_ship,omitempty"`
 }
 
 // UpdateOrderRequest represents a request to update an order
@@ -188,6 +250,11 @@ type ShipOrderRequest struct {
 	Notify         bool              `json:"notify"`
 	ShippedBy      string            `json:"shipped_by" validate:"required,uuid"`
 	Items          []ShipItemRequest `json:"items,omitempty"`
+	// Packages describes the parcels of the shipment
+	Packages []ShipmentPackageRequest `json:"packages,omitempty"`
+	// WarehouseID is the warehouse the goods leave from; its reserved stock
+	// is drawn first
+	WarehouseID string `json:"warehouse_id,omitempty"`
 }
 
 // ShipItemRequest represents shipping information for an order item
@@ -197,8 +264,23 @@ type ShipItemRequest struct {
 	TrackingNumber string `json:"tracking_number,omitempty"`
 }
 
-// DeliverOrderRequest represents a request to mark an order as delivered
+// ShipmentPackageRequest represents a parcel of a shipment
+type ShipmentPackageRequest struct {
+	TrackingNumber string          `json:"tracking_number,omitempty"`
+	Weight         decimal.Decimal `json:"weight"`
+	WeightUnit     string          `json:"weight_unit,omitempty"`
+	Length         decimal.Decimal `json:"length"`
+	Width          decimal.Decimal `json:"width"`
+	Height         decimal.Decimal `json:"height"`
+	DimensionUnit  string          `json:"dimension_unit,omitempty"`
+}
+
+// DeliverOrderRequest represents a request to mark an order, or one of its
+// shipments, as delivered
 type DeliverOrderRequest struct {
+	// ShipmentID limits the delivery to one shipment; all shipments still in
+	// transit are delivered when empty
+	ShipmentID   *string    `json:"shipment_id,omitempty" validate:"omitempty,uuid"`
 	DeliveryDate *time.Time `json:"delivery_date,omitempty"`
 	Proof        *string    `json:"proof,omitempty"` // Delivery proof URL or reference
 	Notes        *string    `json:"notes,omitempty"`
@@ -214,6 +296,8 @@ type PartialShipOrderRequest struct {
 	ShippingDate   *time.Time        `json:"shipping_date,omitempty"`
 	Notify         bool              `json:"notify"`
 	ShippedBy      string            `json:"shipped_by" validate:"required,uuid"`
+	// Packages describes the parcels of the shipment
+	Packages []ShipmentPackageRequest `json:"packages,omitempty"`
 }
 
 // ReturnItemsRequest represents a request to return order items
@@ -239,6 +323,7 @@ type ProcessPaymentRequest struct {
 	PaymentMethod string          `json:"payment_method" validate:"required"`
 	TransactionID string          `json:"transaction_id,omitempty"`
 	Notes         *string         `json:"notes,omitempty"`
+	PaymentDate   *time.Time      `json:"payment_date,omitempty"`
 	PaymentBy     string          `json:"payment_by" validate:"required,uuid"`
 }
 
@@ -278,6 +363,9 @@ type AddOrderItemRequest struct {
 	DiscountAmount decimal.Decimal `json:"discount_amount,omitempty"`
 	TaxRate        decimal.Decimal `json:"tax_rate,omitempty"`
 	Notes          *string         `json:"notes,omitempty"`
+	// DropShip overrides whether the product's supplier ships the line;
+	// lines follow the product when it is not set
+	DropShip *bool `json:"drop_ship,omitempty"`
 }
 
 // UpdateOrderItemRequest represents a request to update an order item
@@ -402,6 +490,8 @@ type CheckInventoryItemResponse struct {
 	ProductName      string               `json:"product_name"`
 	RequestedQty     int                  `json:"requested_qty"`
 	AvailableQty     int                  `json:"available_qty"`
+	AllocatedQty     int                  `json:"allocated_qty"`
+	BackorderQty     int                  `json:"backorder_qty"`
 	CanFulfill       bool                 `json:"can_fulfill"`
 	BackorderAllowed bool                 `json:"backorder_allowed"`
 	UnitPrice        decimal.Decimal      `json:"unit_price"`
@@ -478,6 +568,7 @@ type CloneOrderRequest struct {
 	CopyDiscounts bool    `json:"copy_discounts"`
 	NewCustomerID *string `json:"new_customer_id,omitempty"`
 	Notes         *string `json:"notes,omitempty"`
+	ClonedBy      string  `json:"cloned_by,omitempty"`
 }
 
 // Pagination represents pagination information
@@ -517,4 +608,2856 @@ var (
 	ErrInvalidShippingMethod      = errors.New("invalid shipping method")
 	ErrOrderAlreadyArchived       = errors.New("order is already archived")
 	ErrOrderNotArchived           = errors.New("order is not archived")
+	ErrOrderItemNotFound          = errors.New("order item not found")
+	ErrOrderCannotBeModified      = errors.New("order cannot be modified")
+	ErrOrderCannotBeDelivered     = errors.New("order cannot be delivered")
+	ErrShipmentNotFound           = errors.New("shipment not found")
+	ErrPaymentNotFound            = errors.New("payment not found")
+	ErrInvalidPayment             = errors.New("invalid payment")
+	ErrPaymentAlreadyReversed     = errors.New("payment is already reversed")
+	ErrPaymentCannotBeReversed    = errors.New("payment cannot be reversed")
+	ErrCreditLimitExceeded        = errors.New("credit limit exceeded")
+	ErrOrderNotOnCreditHold       = errors.New("order is not on credit hold")
+	ErrOrderNotSourceable         = errors.New("order holds no inventory reservation to source")
 )
+
+// ServiceImpl implements the order service interface
+type ServiceImpl struct {
+	orderRepo       repositories.OrderRepository
+	orderItemRepo   repositories.OrderItemRepository
+	historyRepo     repositories.OrderStatusHistoryRepository
+	shipmentRepo    repositories.ShipmentRepository
+	backorderRepo   repositories.BackorderRepository
+	paymentRepo     repositories.PaymentRepository
+	invoiceRepo     repositories.InvoiceRepository
+	promotionRepo   repositories.PromotionRepository
+	approvalRepo    repositories.ApprovalRepository
+	importRepo      repositories.OrderImportRepository
+	sourcingRepo    repositories.SourcingRepository
+	revisionRepo    repositories.OrderRevisionRepository
+	archiveRepo     repositories.OrderArchiveRepository
+	customerRepo    repositories.CustomerRepository
+	addressRepo     repositories.OrderAddressRepository
+	productRepo     productRepositories.ProductRepository
+	warehouseRepo   invRepositories.WarehouseRepository
+	inventoryRepo   invRepositories.InventoryRepository
+	transactionRepo invRepositories.InventoryTransactionRepository
+	poRepo          purchasingRepositories.PurchaseOrderRepository
+	supplierRepo    purchasingRepositories.SupplierRepository
+	notifier        BackorderNotifier
+	approverRoles   ApproverRoles
+	taxCalculator   TaxCalculator
+	shippingRates   ShippingRateCalculator
+	currencies      CurrencyConverter
+	txManager       database.TransactionManagerInterface
+	logger          *zerolog.Logger
+	defaultCurrency string
+}
+
+// NewService creates a new order service instance. Without an approval
+// repository orders are approved in one step; without a tax calculator
+// order lines are taxed at their own rates; without a shipping rate
+// calculator shipping is charged at the amount the caller supplies; without a
+// currency converter orders are booked at par with a USD base currency.
+func NewService(
+	orderRepo repositories.OrderRepository,
+	orderItemRepo repositories.OrderItemRepository,
+	historyRepo repositories.OrderStatusHistoryRepository,
+	shipmentRepo repositories.ShipmentRepository,
+	backorderRepo repositories.BackorderRepository,
+	paymentRepo repositories.PaymentRepository,
+	invoiceRepo repositories.InvoiceRepository,
+	promotionRepo repositories.PromotionRepository,
+	approvalRepo repositories.ApprovalRepository,
+	importRepo repositories.OrderImportRepository,
+	sourcingRepo repositories.SourcingRepository,
+	revisionRepo repositories.OrderRevisionRepository,
+	archiveRepo repositories.OrderArchiveRepository,
+	customerRepo repositories.CustomerRepository,
+	addressRepo repositories.OrderAddressRepository,
+	productRepo productRepositories.ProductRepository,
+	warehouseRepo invRepositories.WarehouseRepository,
+	inventoryRepo invRepositories.InventoryRepository,
+	transactionRepo invRepositories.InventoryTransactionRepository,
+	poRepo purchasingRepositories.PurchaseOrderRepository,
+	supplierRepo purchasingRepositories.SupplierRepository,
+	notifier BackorderNotifier,
+	approverRoles ApproverRoles,
+	taxCalculator TaxCalculator,
+	shippingRates ShippingRateCalculator,
+	currencies CurrencyConverter,
+	txManager database.TransactionManagerInterface,
+	logger *zerolog.Logger,
+) Service {
+	defaultCurrency := "USD"
+	if currencies != nil {
+		defaultCurrency = currencies.BaseCurrency()
+	}
+
+	return &ServiceImpl{
+		orderRepo:       orderRepo,
+		orderItemRepo:   orderItemRepo,
+		historyRepo:     historyRepo,
+		shipmentRepo:    shipmentRepo,
+		backorderRepo:   backorderRepo,
+		paymentRepo:     paymentRepo,
+		invoiceRepo:     invoiceRepo,
+		promotionRepo:   promotionRepo,
+		approvalRepo:    approvalRepo,
+		importRepo:      importRepo,
+		sourcingRepo:    sourcingRepo,
+		revisionRepo:    revisionRepo,
+		archiveRepo:     archiveRepo,
+		customerRepo:    customerRepo,
+		addressRepo:     addressRepo,
+		productRepo:     productRepo,
+		warehouseRepo:   warehouseRepo,
+		inventoryRepo:   inventoryRepo,
+		transactionRepo: transactionRepo,
+		poRepo:          poRepo,
+		supplierRepo:    supplierRepo,
+		notifier:        notifier,
+		approverRoles:   approverRoles,
+		taxCalculator:   taxCalculator,
+		shippingRates:   shippingRates,
+		currencies:      currencies,
+		txManager:       txManager,
+		logger:          logger,
+		defaultCurrency: defaultCurrency,
+	}
+}
+
+// Order Management Methods
+
+// CreateOrder creates a new order with priced items, numbered from the sequence of its type
+func (s *ServiceImpl) CreateOrder(ctx context.Context, req *CreateOrderRequest) (*entities.Order, error) {
+	ctx = withActorID(ctx, req.CreatedBy)
+
+	order, err := s.prepareOrder(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := order.ValidateUnnumbered(); err != nil {
+		return nil, fmt.Errorf("invalid order data: %w", err)
+	}
+
+	// A refused coupon code fails the request before the order is created
+	discountCode := ""
+	if req.DiscountCode != nil {
+		discountCode = strings.TrimSpace(*req.DiscountCode)
+	}
+	if discountCode != "" {
+		if _, err := s.checkCoupon(ctx, order, discountCode, order.OrderDate); err != nil {
+			return nil, err
+		}
+	}
+
+	err = database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		if err := s.orderRepo.Create(ctx, order); err != nil {
+			return fmt.Errorf("failed to create order: %w", err)
+		}
+
+		items := make([]*entities.OrderItem, len(order.Items))
+		for i := range order.Items {
+			items[i] = &order.Items[i]
+		}
+		if err := s.orderItemRepo.BulkCreate(ctx, items); err != nil {
+			return fmt.Errorf("failed to create order items: %w", err)
+		}
+
+		if err := s.recordStatusChange(ctx, order, nil, "created"); err != nil {
+			return err
+		}
+		if _, err := s.requestApprovals(ctx, order); err != nil {
+			return err
+		}
+
+		// The code is redeemed in the transaction creating the order, so a
+		// refused redemption, as when the usage limit was reached meanwhile,
+		// rolls back the order and gives its number back to the sequence
+		if discountCode != "" {
+			applied, err := s.ApplyCoupon(ctx, order.ID.String(), &ApplyCouponRequest{Code: discountCode, AppliedBy: req.CreatedBy})
+			if err != nil {
+				return err
+			}
+			order = applied
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// prepareOrder builds a priced pending order from a request without
+// persisting it. The order is numbered when it is inserted.
+func (s *ServiceImpl) prepareOrder(ctx context.Context, req *CreateOrderRequest) (*entities.Order, error) {
+	if len(req.Items) == 0 {
+		return nil, fmt.Errorf("%w: order must have at least one item", ErrInvalidQuantity)
+	}
+
+	createdBy, err := uuid.Parse(req.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid created by user ID: %w", err)
+	}
+
+	customer, err := s.getCustomer(ctx, req.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+	if !customer.IsActive {
+		return nil, fmt.Errorf("%w: customer is inactive", ErrCustomerNotFound)
+	}
+
+	shippingAddress, err := s.getAddress(ctx, req.ShippingAddressID)
+	if err != nil {
+		return nil, err
+	}
+	billingAddress, err := s.getAddress(ctx, req.BillingAddressID)
+	if err != nil {
+		return nil, err
+	}
+
+	currency := strings.ToUpper(strings.TrimSpace(req.Currency))
+	if currency == "" {
+		currency = customer.PreferredCurrency
+	}
+	if currency == "" {
+		currency = s.defaultCurrency
+	}
+
+	// The order date is the booking date of the exchange rate
+	now := time.Now().UTC()
+	exchangeRate, err := s.bookExchangeRate(ctx, currency, now)
+	if err != nil {
+		return nil, err
+	}
+
+	priority := req.Priority
+	if priority == "" {
+		priority = entities.OrderPriorityNormal
+	}
+
+	if req.ShippingAmount.LessThan(decimal.Zero) {
+		return nil, fmt.Errorf("shipping amount cannot be negative")
+	}
+
+	// The order number is allocated from the document sequence of the order
+	// type when the order is inserted.
+	order := &entities.Order{
+		ID:                uuid.New(),
+		CustomerID:        customer.ID,
+		Customer:          customer,
+		Status:            entities.OrderStatusPending,
+		Priority:          priority,
+		Type:              req.Type,
+		PaymentStatus:     entities.PaymentStatusPending,
+		ShippingMethod:    req.ShippingMethod,
+		Subtotal:          decimal.Zero,
+		TaxAmount:         decimal.Zero,
+		ShippingAmount:    req.ShippingAmount,
+		DiscountAmount:    decimal.Zero,
+		TotalAmount:       decimal.Zero,
+		PaidAmount:        decimal.Zero,
+		RefundedAmount:    decimal.Zero,
+		Currency:          currency,
+		ExchangeRate:      exchangeRate,
+		OrderDate:         now,
+		RequiredDate:      req.RequiredDate,
+		ShippingAddressID: shippingAddress.ID,
+		BillingAddressID:  billingAddress.ID,
+		ShippingAddress:   shippingAddress,
+		BillingAddress:    billingAddress,
+		Notes:             req.Notes,
+		CustomerNotes:     req.CustomerNotes,
+		CreatedBy:         createdBy,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+
+	for _, itemReq := range req.Items {
+		item, err := s.buildOrderItem(ctx, order.ID, itemReq.ProductID, itemReq.Quantity, itemReq.UnitPrice, itemReq.DiscountAmount, itemReq.TaxRate, itemReq.Notes)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.applyDropShip(ctx, item, itemReq.DropShip); err != nil {
+			return nil, err
+		}
+		order.Items = append(order.Items, *item)
+	}
+
+	if _, err := s.applyTotals(ctx, order); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// GetOrder retrieves an order by ID including items, addresses and customer.
+// Archived orders are read from the archive.
+func (s *ServiceImpl) GetOrder(ctx context.Context, id string) (*entities.Order, error) {
+	order, err := s.loadOrder(ctx, id)
+	if errors.Is(err, ErrOrderAlreadyArchived) {
+		return s.loadArchivedOrder(ctx, id)
+	}
+	return order, err
+}
+
+// GetOrderByNumber retrieves an order by its order number
+func (s *ServiceImpl) GetOrderByNumber(ctx context.Context, orderNumber string) (*entities.Order, error) {
+	orderNumber = strings.TrimSpace(orderNumber)
+	if orderNumber == "" {
+		return nil, ErrInvalidOrderNumber
+	}
+
+	order, err := s.orderRepo.GetByOrderNumber(ctx, orderNumber)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return s.loadArchivedOrderByNumber(ctx, orderNumber)
+		}
+		return nil, fmt.Errorf("failed to get order by number: %w", err)
+	}
+
+	if err := s.loadOrderDetails(ctx, order); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// UpdateOrder updates the header fields of an order that is still being
+// edited. Confirmed orders change through amendments instead. The order is
+// locked while it changes, and its totals are saved with the approval chain
+// they require.
+func (s *ServiceImpl) UpdateOrder(ctx context.Context, id string, req *UpdateOrderRequest) (*entities.Order, error) {
+	var order *entities.Order
+	err := database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		if order, err = s.lockOrder(ctx, id); err != nil {
+			return err
+		}
+
+		if !isEditable(order) {
+			if hasInventoryReservation(order) {
+				return fmt.Errorf("%w: amend the confirmed order to change it", ErrOrderCannotBeModified)
+			}
+			return ErrOrderCannotBeModified
+		}
+
+		if req.ShippingMethod != nil {
+			order.ShippingMethod = *req.ShippingMethod
+		}
+		if req.RequiredDate != nil {
+			order.RequiredDate = req.RequiredDate
+		}
+		if req.Notes != nil {
+			order.Notes = req.Notes
+		}
+		if req.InternalNotes != nil {
+			order.InternalNotes = req.InternalNotes
+		}
+		if req.CustomerNotes != nil {
+			order.CustomerNotes = req.CustomerNotes
+		}
+		if req.Priority != nil {
+			if err := order.SetPriority(*req.Priority); err != nil {
+				return err
+			}
+		}
+		if req.ShippingAddressID != nil {
+			address, err := s.getAddress(ctx, *req.ShippingAddressID)
+			if err != nil {
+				return err
+			}
+			order.ShippingAddressID = address.ID
+			order.ShippingAddress = address
+		}
+		if req.BillingAddressID != nil {
+			address, err := s.getAddress(ctx, *req.BillingAddressID)
+			if err != nil {
+				return err
+			}
+			order.BillingAddressID = address.ID
+			order.BillingAddress = address
+		}
+		if req.ShippingAmount != nil {
+			if req.ShippingAmount.LessThan(decimal.Zero) {
+				return fmt.Errorf("shipping amount cannot be negative")
+			}
+			order.ShippingAmount = *req.ShippingAmount
+		}
+		if req.DiscountAmount != nil {
+			if req.DiscountAmount.LessThan(decimal.Zero) {
+				return ErrInvalidDiscount
+			}
+			// The requested discount replaces the manual one; coupon discounts stay
+			order.DiscountAmount = itemDiscountTotal(order).Add(*req.DiscountAmount).Add(order.PromotionDiscountAmount)
+		}
+
+		if _, err := s.applyTotals(ctx, order); err != nil {
+			return err
+		}
+
+		// An explicit tax override wins over the item-derived tax
+		if req.TaxAmount != nil {
+			if req.TaxAmount.LessThan(decimal.Zero) {
+				return ErrInvalidTaxRate
+			}
+			order.TaxAmount = *req.TaxAmount
+			order.TotalAmount = order.Subtotal.Add(order.TaxAmount).Add(order.ShippingAmount).Sub(order.DiscountAmount)
+		}
+
+		order.UpdatedAt = time.Now().UTC()
+		if err := order.Validate(); err != nil {
+			return fmt.Errorf("invalid order data: %w", err)
+		}
+
+		if err := s.orderRepo.Update(ctx, order); err != nil {
+			return fmt.Errorf("failed to update order: %w", err)
+		}
+
+		_, err = s.requestApprovals(ctx, order)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// DeleteOrder withdraws an order that has not entered fulfillment. Orders
+// are numbered from gapless sequences and their history, payments and
+// invoices reference them, so a deleted order is cancelled and kept rather
+// than removed; a cancelled order is left as it is.
+func (s *ServiceImpl) DeleteOrder(ctx context.Context, id string) error {
+	order, err := s.loadOrder(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	switch order.Status {
+	case entities.OrderStatusCancelled:
+		return nil
+	case entities.OrderStatusDraft, entities.OrderStatusPending:
+	default:
+		return fmt.Errorf("%w: only draft, pending or cancelled orders can be deleted", ErrInvalidOrderStatus)
+	}
+
+	_, err = s.CancelOrder(ctx, id, &CancelOrderRequest{
+		Reason:      "order deleted",
+		CancelledBy: ledgerActor(ctx, order.CreatedBy).String(),
+	})
+	return err
+}
+
+// ListOrders retrieves a paginated list of orders
+func (s *ServiceImpl) ListOrders(ctx context.Context, req *ListOrdersRequest) (*ListOrdersResponse, error) {
+	filter := repositories.OrderFilter{
+		Search:         req.Search,
+		Status:         req.Status,
+		PaymentStatus:  req.PaymentStatus,
+		Priority:       req.Priority,
+		Type:           req.Type,
+		ShippingMethod: req.ShippingMethod,
+		CustomerType:   req.CustomerType,
+		StartDate:      req.StartDate,
+		EndDate:        req.EndDate,
+		MinTotalAmount: req.MinTotalAmount,
+		MaxTotalAmount: req.MaxTotalAmount,
+		Currency:       req.Currency,
+		SortBy:         req.SortBy,
+		SortOrder:      req.SortOrder,
+	}
+
+	var err error
+	if filter.CustomerID, err = parseOptionalUUID(req.CustomerID, "customer ID"); err != nil {
+		return nil, err
+	}
+	if filter.CompanyID, err = parseOptionalUUID(req.CompanyID, "company ID"); err != nil {
+		return nil, err
+	}
+	if filter.CreatedBy, err = parseOptionalUUID(req.CreatedBy, "created by user ID"); err != nil {
+		return nil, err
+	}
+
+	page, limit := normalizePage(req.Page, req.Limit)
+	filter.Page = page
+	filter.Limit = limit
+	filter.Offset = (page - 1) * limit
+
+	orders, err := s.orderRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list orders: %w", err)
+	}
+
+	total, err := s.orderRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count orders: %w", err)
+	}
+
+	return &ListOrdersResponse{
+		Orders:     orders,
+		Pagination: newPagination(page, limit, total),
+	}, nil
+}
+
+// SearchOrders performs a free-text search across orders
+func (s *ServiceImpl) SearchOrders(ctx context.Context, req *SearchOrdersRequest) (*SearchOrdersResponse, error) {
+	query := strings.TrimSpace(req.Query)
+	if query == "" {
+		return nil, fmt.Errorf("search query is required")
+	}
+
+	limit := req.Limit
+	if limit <= 0 || limit > 50 {
+		limit = 20
+	}
+
+	orders, err := s.orderRepo.SearchOrders(ctx, query, repositories.OrderFilter{Page: 1, Limit: limit})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search orders: %w", err)
+	}
+
+	return &SearchOrdersResponse{
+		Orders: orders,
+		Total:  len(orders),
+	}, nil
+}
+
+// Order Status Management Methods
+
+// UpdateOrderStatus moves an order to a new status, routing statuses with
+// side effects through their dedicated workflows. Unapproved orders are
+// confirmed through the approval chain; refunds and returns go through the
+// payment ledger and return authorizations only.
+func (s *ServiceImpl) UpdateOrderStatus(ctx context.Context, id string, req *UpdateOrderStatusRequest) (*entities.Order, error) {
+	ctx = withActorID(ctx, req.UpdatedBy)
+
+	switch req.Status {
+	case entities.OrderStatusRefunded:
+		return nil, fmt.Errorf("%w: orders are refunded by refunding their payments", ErrInvalidStatusTransition)
+	case entities.OrderStatusReturned:
+		return nil, fmt.Errorf("%w: orders are returned by returning their items", ErrInvalidStatusTransition)
+	case entities.OrderStatusCancelled:
+		return s.CancelOrder(ctx, id, &CancelOrderRequest{
+			Reason:      req.Reason,
+			Notify:      req.Notify,
+			CancelledBy: req.UpdatedBy,
+		})
+	case entities.OrderStatusOnHold:
+		return s.HoldOrder(ctx, id, req.Reason)
+	case entities.OrderStatusShipped:
+		return s.ShipOrder(ctx, id, &ShipOrderRequest{
+			Notify:    req.Notify,
+			ShippedBy: req.UpdatedBy,
+		})
+	case entities.OrderStatusDelivered:
+		return s.DeliverOrder(ctx, id, &DeliverOrderRequest{
+			Notify:      req.Notify,
+			DeliveredBy: req.UpdatedBy,
+		})
+	case entities.OrderStatusPartiallyShipped:
+		return nil, fmt.Errorf("%w: partial shipment is derived from the order's shipments", ErrInvalidStatusTransition)
+	}
+
+	order, err := s.loadOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Status == entities.OrderStatusProcessing && order.Status == entities.OrderStatusPartiallyShipped {
+		return nil, fmt.Errorf("%w: order already has shipments", ErrInvalidStatusTransition)
+	}
+
+	if req.Status == entities.OrderStatusConfirmed && order.ApprovedAt == nil {
+		return s.ApproveOrder(ctx, id, req.UpdatedBy)
+	}
+	if entities.RequiresApproval(req.Status) && order.ApprovedAt == nil {
+		return nil, fmt.Errorf("%w: order must be approved first", ErrInvalidStatusTransition)
+	}
+
+	if err := s.transitionOrder(ctx, order, req.Status, req.Reason); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// CancelOrder cancels an order and releases any inventory it holds
+func (s *ServiceImpl) CancelOrder(ctx context.Context, id string, req *CancelOrderRequest) (*entities.Order, error) {
+	ctx = withActorID(ctx, req.CancelledBy)
+
+	var order *entities.Order
+	err := database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		if order, err = s.lockOrder(ctx, id); err != nil {
+			return err
+		}
+		if !order.CanBeCancelled() {
+			return ErrOrderCannotBeCancelled
+		}
+
+		if reason := strings.TrimSpace(req.Reason); reason != "" {
+			appendInternalNote(order, "Cancelled: "+reason)
+		}
+
+		if hasInventoryReservation(order) {
+			if err := s.releaseReservations(ctx, order); err != nil {
+				return err
+			}
+		}
+		if err := s.cancelBackorders(ctx, order); err != nil {
+			return err
+		}
+		if err := s.withdrawDropShipOrders(ctx, order, req.Reason); err != nil {
+			return err
+		}
+		if err := s.releasePromotions(ctx, order, "order cancelled"); err != nil {
+			return err
+		}
+
+		if req.Refund && order.PaidAmount.Sub(order.RefundedAmount).GreaterThan(decimal.Zero) {
+			// AddRefund moves fully refunded orders to REFUNDED; a cancellation keeps its own status
+			status, paymentStatus := order.Status, order.PaymentStatus
+			amount := order.PaidAmount.Sub(order.RefundedAmount)
+			if err := order.AddRefund(amount); err != nil {
+				return fmt.Errorf("%w: %v", ErrRefundFailed, err)
+			}
+			order.Status = status
+			if err := s.recordRefund(ctx, order, paymentStatus, amount, ledgerEntry{}, "refunded on cancellation"); err != nil {
+				return err
+			}
+		}
+
+		return s.transitionOrder(ctx, order, entities.OrderStatusCancelled, req.Reason)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// ApproveOrder records the approver's approval of the next step of the
+// order's approval chain; once every step the approval policies require is
+// approved it confirms the order and reserves its inventory. Orders that would
+// take the customer over their credit limit are put on credit hold instead.
+func (s *ServiceImpl) ApproveOrder(ctx context.Context, id string, approvedBy string) (*entities.Order, error) {
+	approverID, err := uuid.Parse(approvedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid approver ID: %w", err)
+	}
+
+	ctx = withActorID(ctx, approvedBy)
+
+	// The order stays locked from the approval through its confirmation, so
+	// concurrent approvals queue and find it approved
+	var order *entities.Order
+	err = database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		if order, err = s.lockOrder(ctx, id); err != nil {
+			return err
+		}
+		if order.ApprovedAt != nil {
+			return fmt.Errorf("%w: order is already approved", ErrInvalidStatusTransition)
+		}
+
+		// Orders stay pending until the last approver of their chain approves
+		approved, err := s.approveNext(ctx, order, approverID)
+		if err != nil || !approved {
+			return err
+		}
+
+		// Orders taking the customer over their credit limit wait for a credit manager
+		creditHold, err := s.checkCredit(ctx, order)
+		if err != nil {
+			return err
+		}
+		if creditHold != "" {
+			return s.holdForCredit(ctx, order, creditHold)
+		}
+
+		order.CreditHold = false
+		return s.confirmOrder(ctx, order, approverID, "approved")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// HoldOrder places an order on hold
+func (s *ServiceImpl) HoldOrder(ctx context.Context, id string, reason string) (*entities.Order, error) {
+	order, err := s.loadOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if reason = strings.TrimSpace(reason); reason != "" {
+		appendInternalNote(order, "On hold: "+reason)
+	}
+
+	if err := s.transitionOrder(ctx, order, entities.OrderStatusOnHold, reason); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// UnholdOrder releases an order from hold back to the status it was held from
+func (s *ServiceImpl) UnholdOrder(ctx context.Context, id string) (*entities.Order, error) {
+	order, err := s.loadOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.Status != entities.OrderStatusOnHold {
+		return nil, fmt.Errorf("%w: order is not on hold", ErrInvalidStatusTransition)
+	}
+
+	// Orders released from credit hold are checked again when they are approved
+	order.CreditHold = false
+
+	target := entities.OrderStatusPending
+	if order.ApprovedAt != nil {
+		target = entities.OrderStatusConfirmed
+	}
+	if order.PreviousStatus != nil && entities.IsValidStatusTransition(order.Status, *order.PreviousStatus) {
+		target = *order.PreviousStatus
+	}
+
+	if err := s.transitionOrder(ctx, order, target, "released from hold"); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// Order Fulfillment Methods
+
+// ProcessOrder moves a confirmed order into processing
+func (s *ServiceImpl) ProcessOrder(ctx context.Context, id string) (*entities.Order, error) {
+	order, err := s.loadOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.transitionOrder(ctx, order, entities.OrderStatusProcessing, ""); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// ShipOrder ships all remaining quantities, or the listed items when provided
+func (s *ServiceImpl) ShipOrder(ctx context.Context, id string, req *ShipOrderRequest) (*entities.Order, error) {
+	warehouseID, err := parseOptionalUUID(&req.WarehouseID, "warehouse ID")
+	if err != nil {
+		return nil, err
+	}
+
+	details := shipmentDetails{
+		trackingNumber: req.TrackingNumber,
+		carrier:        req.Carrier,
+		shippingDate:   req.ShippingDate,
+		packages:       req.Packages,
+		shippedBy:      req.ShippedBy,
+		warehouseID:    warehouseID,
+	}
+
+	var order *entities.Order
+	err = database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		if order, err = s.lockOrder(ctx, id); err != nil {
+			return err
+		}
+
+		quantities := make(map[uuid.UUID]int)
+		if len(req.Items) > 0 {
+			if quantities, err = shipQuantities(req.Items); err != nil {
+				return err
+			}
+		} else {
+			// Suppliers ship drop-ship lines; their confirmations ship them here
+			for _, item := range order.Items {
+				if remaining := item.ShippableQuantity(); remaining > 0 && !item.IsDropShip {
+					quantities[item.ID] = remaining
+				}
+			}
+		}
+
+		_, err = s.shipItems(ctx, order, quantities, details)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// DeliverOrder marks the order's shipments as delivered, or only the given
+// one, and derives the order status from them. The order becomes DELIVERED
+// once every line has shipped and every shipment has arrived.
+func (s *ServiceImpl) DeliverOrder(ctx context.Context, id string, req *DeliverOrderRequest) (*entities.Order, error) {
+	ctx = withActorID(ctx, req.DeliveredBy)
+
+	delivererID, err := uuid.Parse(req.DeliveredBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid delivered by user ID: %w", err)
+	}
+	shipmentID, err := parseOptionalUUID(req.ShipmentID, "shipment ID")
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := s.loadOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch order.Status {
+	case entities.OrderStatusShipped, entities.OrderStatusPartiallyShipped:
+	default:
+		return nil, fmt.Errorf("%w: order must be shipped to deliver", ErrOrderCannotBeDelivered)
+	}
+
+	shipments, err := s.shipmentRepo.GetByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order shipments: %w", err)
+	}
+
+	deliveredAt := time.Now().UTC()
+	if req.DeliveryDate != nil {
+		deliveredAt = req.DeliveryDate.UTC()
+	}
+
+	var delivered []*entities.Shipment
+	for _, shipment := range shipments {
+		if shipmentID != nil && shipment.ID != *shipmentID {
+			continue
+		}
+		if shipment.IsDelivered() && shipmentID == nil {
+			continue
+		}
+		if err := shipment.Deliver(deliveredAt, delivererID, req.Proof); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrOrderCannotBeDelivered, err)
+		}
+		delivered = append(delivered, shipment)
+	}
+	if shipmentID != nil && len(delivered) == 0 {
+		return nil, ErrShipmentNotFound
+	}
+
+	// Orders shipped before shipments were recorded have none to deliver and
+	// are delivered as a whole once fully shipped
+	target := entities.FulfillmentStatus(order.Items, shipments)
+	if len(delivered) == 0 && target != entities.OrderStatusDelivered {
+		return nil, fmt.Errorf("%w: no shipment is awaiting delivery", ErrOrderCannotBeDelivered)
+	}
+
+	if req.Notes != nil && strings.TrimSpace(*req.Notes) != "" {
+		appendInternalNote(order, "Delivery: "+strings.TrimSpace(*req.Notes))
+	}
+	if req.Proof != nil && strings.TrimSpace(*req.Proof) != "" {
+		appendInternalNote(order, "Delivery proof: "+strings.TrimSpace(*req.Proof))
+	}
+
+	deliveredQuantities := entities.DeliveredQuantities(shipments)
+	for i := range order.Items {
+		item := &order.Items[i]
+		if item.Status == "SHIPPED" && (target == entities.OrderStatusDelivered || deliveredQuantities[item.ID] >= item.Quantity) {
+			item.Status = "DELIVERED"
+			item.UpdatedAt = time.Now().UTC()
+		}
+	}
+
+	previousStatus := order.Status
+	if target != order.Status {
+		if err := order.ChangeStatus(target, ""); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidStatusTransition, err)
+		}
+		if target == entities.OrderStatusDelivered {
+			order.DeliveryDate = &deliveredAt
+		}
+	}
+	order.UpdatedAt = time.Now().UTC()
+
+	err = database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		for _, shipment := range delivered {
+			if err := s.shipmentRepo.Update(ctx, shipment); err != nil {
+				return fmt.Errorf("failed to update shipment: %w", err)
+			}
+		}
+		if err := s.orderItemRepo.BulkUpdate(ctx, itemPointers(order)); err != nil {
+			return fmt.Errorf("failed to update order items: %w", err)
+		}
+		if err := s.orderRepo.Update(ctx, order); err != nil {
+			return fmt.Errorf("failed to update order: %w", err)
+		}
+		return s.recordStatusChange(ctx, order, &previousStatus, "delivered")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// PartialShipOrder ships a subset of the order's items
+func (s *ServiceImpl) PartialShipOrder(ctx context.Context, id string, req *PartialShipOrderRequest) (*entities.Order, error) {
+	quantities, err := shipQuantities(req.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	details := shipmentDetails{
+		trackingNumber: req.TrackingNumber,
+		carrier:        req.Carrier,
+		shippingDate:   req.ShippingDate,
+		packages:       req.Packages,
+		shippedBy:      req.ShippedBy,
+	}
+
+	var order *entities.Order
+	err = database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		if order, err = s.lockOrder(ctx, id); err != nil {
+			return err
+		}
+		_, err = s.shipItems(ctx, order, quantities, details)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// ReturnOrderItems records returned quantities and optionally refunds them.
+// The order is locked while its lines and balance change.
+func (s *ServiceImpl) ReturnOrderItems(ctx context.Context, id string, req *ReturnItemsRequest) (*entities.Order, error) {
+	ctx = withActorID(ctx, req.ReturnedBy)
+
+	var order *entities.Order
+	err := database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		if order, err = s.lockOrder(ctx, id); err != nil {
+			return err
+		}
+		previousStatus, previousPaymentStatus := order.Status, order.PaymentStatus
+
+		switch order.Status {
+		case entities.OrderStatusShipped, entities.OrderStatusPartiallyShipped, entities.OrderStatusDelivered:
+		default:
+			return ErrOrderCannotBeReturned
+		}
+
+		refundAmount := decimal.Zero
+		returned := make([]creditedLine, 0, len(req.Items))
+		for _, returnReq := range req.Items {
+			item, err := findOrderItem(order, returnReq.ItemID)
+			if err != nil {
+				return err
+			}
+			if err := item.ReturnItem(returnReq.Quantity); err != nil {
+				return fmt.Errorf("%w: %v", ErrInvalidQuantity, err)
+			}
+			returned = append(returned, creditedLine{item: item, quantity: returnReq.Quantity})
+
+			if returnReq.RefundAmount.GreaterThan(decimal.Zero) {
+				refundAmount = refundAmount.Add(returnReq.RefundAmount)
+			} else {
+				refundAmount = refundAmount.Add(refundableAmount(item, returnReq.Quantity))
+			}
+		}
+
+		appendInternalNote(order, "Returned items: "+req.Reason)
+
+		fullyReturned := true
+		for _, item := range order.Items {
+			if item.QuantityReturned < item.Quantity {
+				fullyReturned = false
+				break
+			}
+		}
+
+		if fullyReturned && entities.IsValidStatusTransition(order.Status, entities.OrderStatusReturned) {
+			if err := order.ChangeStatus(entities.OrderStatusReturned, req.Reason); err != nil {
+				return fmt.Errorf("%w: %v", ErrInvalidStatusTransition, err)
+			}
+		}
+
+		refunded := false
+		if req.Refund {
+			refundable := order.PaidAmount.Sub(order.RefundedAmount)
+			if refundAmount.GreaterThan(refundable) {
+				refundAmount = refundable
+			}
+			if refundAmount.GreaterThan(decimal.Zero) {
+				for _, line := range returned {
+					if err := line.item.RefundItem(line.quantity); err != nil {
+						return fmt.Errorf("%w: %v", ErrInvalidQuantity, err)
+					}
+				}
+				if err := order.AddRefund(refundAmount); err != nil {
+					return fmt.Errorf("%w: %v", ErrRefundFailed, err)
+				}
+				refunded = true
+			}
+		}
+
+		if err := s.orderItemRepo.BulkUpdate(ctx, itemPointers(order)); err != nil {
+			return fmt.Errorf("failed to update order items: %w", err)
+		}
+		if err := s.orderRepo.Update(ctx, order); err != nil {
+			return fmt.Errorf("failed to update order: %w", err)
+		}
+		if err := s.recordStatusChange(ctx, order, &previousStatus, req.Reason); err != nil {
+			return err
+		}
+		if refunded {
+			return s.recordRefund(ctx, order, previousPaymentStatus, refundAmount, ledgerEntry{}, "refunded on return")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// Payment Processing Methods
+
+// ProcessPayment records a payment against an order. The order is locked
+// while its balance is checked and the payment recorded.
+func (s *ServiceImpl) ProcessPayment(ctx context.Context, id string, req *ProcessPaymentRequest) (*entities.Order, error) {
+	ctx = withActorID(ctx, req.PaymentBy)
+
+	if req.Amount.LessThanOrEqual(decimal.Zero) {
+		return nil, ErrInvalidPaymentAmount
+	}
+
+	method, err := entities.ParsePaymentMethod(req.PaymentMethod)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayment, err)
+	}
+
+	var order *entities.Order
+	err = database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		if order, err = s.lockOrder(ctx, id); err != nil {
+			return err
+		}
+
+		if order.Status == entities.OrderStatusCancelled || order.Status == entities.OrderStatusDraft {
+			return fmt.Errorf("%w: payments cannot be taken for %s orders", ErrInvalidOrderStatus, order.Status)
+		}
+		if order.IsFullyPaid() && order.TotalAmount.GreaterThan(decimal.Zero) {
+			return ErrOrderAlreadyPaid
+		}
+
+		if req.Amount.GreaterThan(order.GetOutstandingBalance()) {
+			return fmt.Errorf("%w: payment amount %s exceeds outstanding balance %s", ErrInvalidPaymentAmount, req.Amount, order.GetOutstandingBalance())
+		}
+
+		now := time.Now().UTC()
+		payment := &entities.Payment{
+			ID:         uuid.New(),
+			Type:       entities.PaymentTypePayment,
+			CustomerID: order.CustomerID,
+			Method:     method,
+			Reference:  optionalString(req.TransactionID),
+			Amount:     req.Amount,
+			Currency:   order.Currency,
+			ReceivedAt: now,
+			Status:     entities.PaymentEntryStatusCompleted,
+			Notes:      req.Notes,
+			CreatedBy:  ledgerActor(ctx, order.CreatedBy),
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+		if req.PaymentDate != nil {
+			payment.ReceivedAt = req.PaymentDate.UTC()
+		}
+		if err := payment.Allocate(order.ID, req.Amount); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidPaymentAmount, err)
+		}
+
+		previousPaymentStatus := order.PaymentStatus
+		if err := s.paymentRepo.Create(ctx, payment); err != nil {
+			return fmt.Errorf("failed to create payment: %w", err)
+		}
+		return s.syncPayments(ctx, order, previousPaymentStatus, req.Amount, "payment received")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// RefundOrder refunds an amount previously paid on an order. The order is
+// locked while the refundable amount is checked and the refund recorded.
+func (s *ServiceImpl) RefundOrder(ctx context.Context, id string, req *RefundOrderRequest) (*entities.Order, error) {
+	ctx = withActorID(ctx, req.RefundedBy)
+
+	if req.Amount.LessThanOrEqual(decimal.Zero) {
+		return nil, ErrInvalidPaymentAmount
+	}
+
+	var order *entities.Order
+	err := database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		if order, err = s.lockOrder(ctx, id); err != nil {
+			return err
+		}
+
+		if order.PaidAmount.LessThanOrEqual(decimal.Zero) {
+			return ErrOrderNotPaid
+		}
+
+		previousStatus, previousPaymentStatus := order.Status, order.PaymentStatus
+		if err := order.AddRefund(req.Amount); err != nil {
+			return fmt.Errorf("%w: %v", ErrRefundFailed, err)
+		}
+		appendInternalNote(order, fmt.Sprintf("Refunded %s: %s", req.Amount.StringFixed(2), req.Reason))
+
+		entry := ledgerEntry{method: req.RefundMethod, reference: req.TransactionID, notes: req.Notes}
+		return s.saveRefund(ctx, order, previousStatus, previousPaymentStatus, req.Amount, entry, nil, req.Reason)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// PartialRefundOrder refunds individual order lines. Each line is refunded
+// at most once per unit, and the order is locked while the lines and the
+// refundable amount are checked and the refund recorded.
+func (s *ServiceImpl) PartialRefundOrder(ctx context.Context, id string, req *PartialRefundOrderRequest) (*entities.Order, error) {
+	ctx = withActorID(ctx, req.RefundedBy)
+
+	var order *entities.Order
+	err := database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		if order, err = s.lockOrder(ctx, id); err != nil {
+			return err
+		}
+
+		if order.PaidAmount.LessThanOrEqual(decimal.Zero) {
+			return ErrOrderNotPaid
+		}
+
+		amount := decimal.Zero
+		credited := make([]creditedLine, 0, len(req.Items))
+		for _, refundReq := range req.Items {
+			item, err := findOrderItem(order, refundReq.ItemID)
+			if err != nil {
+				return err
+			}
+			if err := item.RefundItem(refundReq.Quantity); err != nil {
+				return fmt.Errorf("%w: %v", ErrInvalidQuantity, err)
+			}
+
+			lineAmount := refundReq.RefundAmount
+			if !lineAmount.GreaterThan(decimal.Zero) {
+				lineAmount = refundableAmount(item, refundReq.Quantity)
+			}
+			amount = amount.Add(lineAmount)
+			credited = append(credited, creditedLine{item: item, quantity: refundReq.Quantity, amount: lineAmount})
+		}
+
+		previousStatus, previousPaymentStatus := order.Status, order.PaymentStatus
+		if err := order.AddRefund(amount); err != nil {
+			return fmt.Errorf("%w: %v", ErrRefundFailed, err)
+		}
+		appendInternalNote(order, fmt.Sprintf("Partially refunded %s: %s", amount.StringFixed(2), req.Reason))
+
+		if err := s.orderItemRepo.BulkUpdate(ctx, itemPointers(order)); err != nil {
+			return fmt.Errorf("failed to update order items: %w", err)
+		}
+		entry := ledgerEntry{method: req.RefundMethod, reference: req.TransactionID, notes: req.Notes}
+		return s.saveRefund(ctx, order, previousStatus, previousPaymentStatus, amount, entry, credited, req.Reason)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// Order Item Management Methods
+
+// AddOrderItem adds a line to an editable order
+func (s *ServiceImpl) AddOrderItem(ctx context.Context, orderID string, req *AddOrderItemRequest) (*entities.Order, error) {
+	var order *entities.Order
+	err := database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		if order, err = s.lockOrder(ctx, orderID); err != nil {
+			return err
+		}
+
+		if !isEditable(order) {
+			return lineEditError(order)
+		}
+
+		item, err := s.buildOrderItem(ctx, order.ID, req.ProductID, req.Quantity, req.UnitPrice, req.DiscountAmount, req.TaxRate, req.Notes)
+		if err != nil {
+			return err
+		}
+		if err := s.applyDropShip(ctx, item, req.DropShip); err != nil {
+			return err
+		}
+		order.Items = append(order.Items, *item)
+
+		if _, err := s.applyTotals(ctx, order); err != nil {
+			return err
+		}
+
+		if err := s.orderItemRepo.Create(ctx, item); err != nil {
+			return fmt.Errorf("failed to create order item: %w", err)
+		}
+		// Tax on the other lines can change with the order, so all of them are saved
+		if err := s.orderItemRepo.BulkUpdate(ctx, itemPointers(order)); err != nil {
+			return fmt.Errorf("failed to update order items: %w", err)
+		}
+		if err := s.orderRepo.Update(ctx, order); err != nil {
+			return fmt.Errorf("failed to update order: %w", err)
+		}
+
+		_, err = s.requestApprovals(ctx, order)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// UpdateOrderItem updates quantity or pricing of a line on an editable order
+func (s *ServiceImpl) UpdateOrderItem(ctx context.Context, orderID, itemID string, req *UpdateOrderItemRequest) (*entities.Order, error) {
+	var order *entities.Order
+	err := database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		if order, err = s.lockOrder(ctx, orderID); err != nil {
+			return err
+		}
+
+		if !isEditable(order) {
+			return lineEditError(order)
+		}
+
+		item, err := findOrderItem(order, itemID)
+		if err != nil {
+			return err
+		}
+
+		if req.Quantity != nil {
+			if *req.Quantity <= 0 {
+				return ErrInvalidQuantity
+			}
+			item.Quantity = *req.Quantity
+		}
+		if req.UnitPrice != nil {
+			item.UnitPrice = *req.UnitPrice
+		}
+		if req.DiscountAmount != nil {
+			item.DiscountAmount = *req.DiscountAmount
+		}
+		if req.TaxRate != nil {
+			item.TaxRate = *req.TaxRate
+		}
+		if req.Notes != nil {
+			item.Notes = req.Notes
+		}
+
+		item.CalculateTotals()
+		if err := item.Validate(); err != nil {
+			return fmt.Errorf("invalid order item: %w", err)
+		}
+
+		if _, err := s.applyTotals(ctx, order); err != nil {
+			return err
+		}
+
+		if err := s.orderItemRepo.BulkUpdate(ctx, itemPointers(order)); err != nil {
+			return fmt.Errorf("failed to update order items: %w", err)
+		}
+		if err := s.orderRepo.Update(ctx, order); err != nil {
+			return fmt.Errorf("failed to update order: %w", err)
+		}
+
+		_, err = s.requestApprovals(ctx, order)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// RemoveOrderItem removes a line from an editable order
+func (s *ServiceImpl) RemoveOrderItem(ctx context.Context, orderID, itemID string) (*entities.Order, error) {
+	var order *entities.Order
+	err := database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		if order, err = s.lockOrder(ctx, orderID); err != nil {
+			return err
+		}
+
+		if !isEditable(order) {
+			return lineEditError(order)
+		}
+
+		item, err := findOrderItem(order, itemID)
+		if err != nil {
+			return err
+		}
+		if len(order.Items) == 1 {
+			return fmt.Errorf("%w: order must keep at least one item", ErrInvalidQuantity)
+		}
+
+		removedID := item.ID
+		remaining := make([]entities.OrderItem, 0, len(order.Items)-1)
+		for _, existing := range order.Items {
+			if existing.ID != removedID {
+				remaining = append(remaining, existing)
+			}
+		}
+		order.Items = remaining
+
+		if _, err := s.applyTotals(ctx, order); err != nil {
+			return err
+		}
+
+		if err := s.orderItemRepo.Delete(ctx, removedID); err != nil {
+			return fmt.Errorf("failed to delete order item: %w", err)
+		}
+		if err := s.orderItemRepo.BulkUpdate(ctx, itemPointers(order)); err != nil {
+			return fmt.Errorf("failed to update order items: %w", err)
+		}
+		if err := s.orderRepo.Update(ctx, order); err != nil {
+			return fmt.Errorf("failed to update order: %w", err)
+		}
+
+		_, err = s.requestApprovals(ctx, order)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// Order Validation and Calculation Methods
+
+// ValidateOrder runs entity validation plus customer, approval and stock checks
+func (s *ServiceImpl) ValidateOrder(ctx context.Context, id string) (*entities.OrderValidation, error) {
+	order, err := s.loadOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	validation := entities.ValidateOrder(order)
+
+	if order.Customer == nil {
+		validation.IsValid = false
+		validation.Errors = append(validation.Errors, "Customer not found")
+	} else if !order.Customer.IsActive {
+		validation.IsValid = false
+		validation.Errors = append(validation.Errors, "Customer is inactive")
+	}
+
+	if order.ShippingAddress == nil {
+		validation.IsValid = false
+		validation.Errors = append(validation.Errors, "Shipping address not found")
+	}
+	if order.BillingAddress == nil {
+		validation.IsValid = false
+		validation.Errors = append(validation.Errors, "Billing address not found")
+	}
+
+	if !entities.IsTerminalStatus(order.Status) {
+		approvers, err := s.awaitedApprovers(ctx, order)
+		if err != nil {
+			return nil, err
+		}
+		if len(approvers) > 0 {
+			validation.Warnings = append(validation.Warnings, fmt.Sprintf("Order awaits approval by %s", strings.Join(approvers, ", then ")))
+		}
+	}
+
+	if !hasInventoryReservation(order) && !entities.IsTerminalStatus(order.Status) {
+		check, err := s.CheckInventoryAvailability(ctx, checkRequestForOrder(order))
+		if err == nil {
+			for _, item := range check.Items {
+				if !item.CanFulfill {
+					validation.Warnings = append(validation.Warnings, fmt.Sprintf("Insufficient stock for %s: requested %d, available %d", item.ProductName, item.RequestedQty, item.AvailableQty))
+				}
+			}
+		}
+	}
+
+	return validation, nil
+}
+
+// CalculateOrderTotals returns the calculation breakdown without persisting it
+func (s *ServiceImpl) CalculateOrderTotals(ctx context.Context, id string) (*entities.OrderCalculation, error) {
+	order, err := s.loadOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.applyTotals(ctx, order)
+}
+
+// RecalculateOrder recalculates and persists order totals
+func (s *ServiceImpl) RecalculateOrder(ctx context.Context, id string) (*entities.Order, error) {
+	order, err := s.loadOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if entities.IsTerminalStatus(order.Status) {
+		return nil, ErrOrderCannotBeModified
+	}
+
+	for i := range order.Items {
+		order.Items[i].CalculateTotals()
+	}
+
+	if _, err := s.applyTotals(ctx, order); err != nil {
+		return nil, err
+	}
+
+	err = database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		if err := s.orderItemRepo.BulkUpdate(ctx, itemPointers(order)); err != nil {
+			return fmt.Errorf("failed to update order items: %w", err)
+		}
+		if err := s.orderRepo.Update(ctx, order); err != nil {
+			return fmt.Errorf("failed to update order: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.requestApprovals(ctx, order); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// Customer Order Methods
+
+// GetCustomerOrders retrieves a customer's orders with an order summary
+func (s *ServiceImpl) GetCustomerOrders(ctx context.Context, customerID string, req *GetCustomerOrdersRequest) (*GetCustomerOrdersResponse, error) {
+	customer, err := s.getCustomer(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	page, limit := normalizePage(req.Page, req.Limit)
+	filter := repositories.OrderFilter{
+		Status:     req.Status,
+		CustomerID: &customer.ID,
+		StartDate:  req.StartDate,
+		EndDate:    req.EndDate,
+		Page:       page,
+		Limit:      limit,
+		Offset:     (page - 1) * limit,
+		SortBy:     req.SortBy,
+		SortOrder:  req.SortOrder,
+	}
+
+	orders, err := s.orderRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list customer orders: %w", err)
+	}
+
+	total, err := s.orderRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count customer orders: %w", err)
+	}
+
+	response := &GetCustomerOrdersResponse{
+		Orders:     orders,
+		Pagination: newPagination(page, limit, total),
+	}
+
+	summary, err := s.customerRepo.GetCustomerOrdersSummary(ctx, customer.ID)
+	if err != nil {
+		s.logger.Warn().Err(err).Str("customer_id", customer.ID.String()).Msg("Failed to load customer order summary")
+		return response, nil
+	}
+
+	breakdown := make(map[string]int, len(summary.StatusCounts))
+	for status, count := range summary.StatusCounts {
+		breakdown[status] = int(count)
+	}
+	response.Summary = &CustomerSummary{
+		TotalOrders:       int(summary.TotalOrders),
+		TotalAmount:       summary.TotalRevenue,
+		AverageOrderValue: summary.AverageOrderValue,
+		FirstOrderDate:    summary.FirstOrderDate,
+		LastOrderDate:     summary.LastOrderDate,
+		StatusBreakdown:   breakdown,
+	}
+
+	return response, nil
+}
+
+// GetCustomerOrderHistory retrieves a customer's most recent orders
+func (s *ServiceImpl) GetCustomerOrderHistory(ctx context.Context, customerID string, limit int) ([]*entities.Order, error) {
+	customerUUID, err := uuid.Parse(customerID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid customer ID: %w", err)
+	}
+
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+
+	orders, err := s.orderRepo.GetCustomerOrderHistory(ctx, customerUUID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get customer order history: %w", err)
+	}
+
+	return orders, nil
+}
+
+// Analytics Methods
+
+// BaseCurrency returns the currency orders are reported in
+func (s *ServiceImpl) BaseCurrency() string {
+	return s.defaultCurrency
+}
+
+// GetOrderStats returns aggregate order statistics in the base currency
+func (s *ServiceImpl) GetOrderStats(ctx context.Context, req *GetOrderStatsRequest) (*repositories.OrderStats, error) {
+	filter := repositories.OrderStatsFilter{
+		EndDate: time.Now().UTC(),
+		Status:  req.Status,
+	}
+	if req.StartDate != nil {
+		filter.StartDate = *req.StartDate
+	}
+	if req.EndDate != nil {
+		filter.EndDate = *req.EndDate
+	}
+
+	var err error
+	if filter.CustomerID, err = parseOptionalUUID(req.CustomerID, "customer ID"); err != nil {
+		return nil, err
+	}
+	if filter.CompanyID, err = parseOptionalUUID(req.CompanyID, "company ID"); err != nil {
+		return nil, err
+	}
+
+	stats, err := s.orderRepo.GetOrderStats(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order stats: %w", err)
+	}
+	stats.Currency = s.defaultCurrency
+
+	return stats, nil
+}
+
+// GetRevenueByPeriod returns revenue in the base currency grouped by period
+func (s *ServiceImpl) GetRevenueByPeriod(ctx context.Context, req *GetRevenueByPeriodRequest) ([]*repositories.RevenueByPeriod, error) {
+	if req.EndDate.Before(req.StartDate) {
+		return nil, fmt.Errorf("end date cannot be before start date")
+	}
+
+	revenue, err := s.orderRepo.GetRevenueByPeriod(ctx, req.StartDate, req.EndDate, req.GroupBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revenue by period: %w", err)
+	}
+	for _, period := range revenue {
+		period.Currency = s.defaultCurrency
+	}
+
+	return revenue, nil
+}
+
+// GetTopCustomers returns the customers with the highest revenue in the base currency
+func (s *ServiceImpl) GetTopCustomers(ctx context.Context, req *GetTopCustomersRequest) ([]*repositories.CustomerOrderStats, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+
+	customers, err := s.orderRepo.GetTopCustomers(ctx, req.StartDate, req.EndDate, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get top customers: %w", err)
+	}
+	for _, customer := range customers {
+		customer.Currency = s.defaultCurrency
+	}
+
+	return customers, nil
+}
+
+// GetSalesByProduct returns sales aggregated per product
+func (s *ServiceImpl) GetSalesByProduct(ctx context.Context, req *GetSalesByProductRequest) ([]*repositories.ProductSalesStats, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+
+	sales, err := s.orderRepo.GetSalesByProduct(ctx, req.StartDate, req.EndDate, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sales by product: %w", err)
+	}
+
+	return sales, nil
+}
+
+// GetOrderAnalytics combines stats, revenue, top customers and top products
+func (s *ServiceImpl) GetOrderAnalytics(ctx context.Context, req *GetOrderAnalyticsRequest) (*OrderAnalyticsResponse, error) {
+	groupBy := req.GroupBy
+	if groupBy == "" {
+		groupBy = "day"
+	}
+
+	stats, err := s.GetOrderStats(ctx, &GetOrderStatsRequest{
+		StartDate:  &req.StartDate,
+		EndDate:    &req.EndDate,
+		CustomerID: req.CustomerID,
+		CompanyID:  req.CompanyID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	revenue, err := s.GetRevenueByPeriod(ctx, &GetRevenueByPeriodRequest{StartDate: req.StartDate, EndDate: req.EndDate, GroupBy: groupBy})
+	if err != nil {
+		return nil, err
+	}
+
+	topCustomers, err := s.GetTopCustomers(ctx, &GetTopCustomersRequest{StartDate: req.StartDate, EndDate: req.EndDate, Limit: 10})
+	if err != nil {
+		return nil, err
+	}
+
+	topProducts, err := s.GetSalesByProduct(ctx, &GetSalesByProductRequest{StartDate: req.StartDate, EndDate: req.EndDate, Limit: 10})
+	if err != nil {
+		return nil, err
+	}
+
+	trends := &OrderTrends{
+		GrowthRate:        decimal.Zero,
+		AverageOrderValue: stats.AverageOrderValue,
+	}
+	if len(revenue) > 1 {
+		first := revenue[0].Revenue
+		last := revenue[len(revenue)-1].Revenue
+		if first.GreaterThan(decimal.Zero) {
+			trends.GrowthRate = last.Sub(first).Div(first).Mul(decimal.NewFromInt(100)).Round(2)
+		}
+	}
+	for _, product := range topProducts {
+		trends.PopularProducts = append(trends.PopularProducts, product.ProductName)
+	}
+
+	return &OrderAnalyticsResponse{
+		OrderStats:      stats,
+		RevenueByPeriod: revenue,
+		TopCustomers:    topCustomers,
+		TopProducts:     topProducts,
+		Trends:          trends,
+	}, nil
+}
+
+// Inventory Integration Methods
+
+// CheckInventoryAvailability checks stock for a set of products
+func (s *ServiceImpl) CheckInventoryAvailability(ctx context.Context, req *CheckInventoryRequest) (*CheckInventoryResponse, error) {
+	response := &CheckInventoryResponse{
+		Available:  true,
+		Items:      make([]CheckInventoryItemResponse, 0, len(req.Items)),
+		TotalValue: decimal.Zero,
+	}
+
+	for _, itemReq := range req.Items {
+		productID, err := uuid.Parse(itemReq.ProductID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid product ID: %w", err)
+		}
+
+		product, err := s.productRepo.GetByID(ctx, productID)
+		if err != nil {
+			return nil, ErrProductNotFound
+		}
+
+		available, err := s.availableStock(ctx, productID, itemReq.WarehouseID)
+		if err != nil {
+			return nil, err
+		}
+
+		item := CheckInventoryItemResponse{
+			ProductID:        product.ID.String(),
+			ProductName:      product.Name,
+			RequestedQty:     itemReq.Quantity,
+			AvailableQty:     available,
+			AllocatedQty:     itemReq.Quantity,
+			CanFulfill:       !product.TrackInventory || available >= itemReq.Quantity,
+			BackorderAllowed: product.AllowBackorder,
+			UnitPrice:        product.Price,
+			TotalValue:       product.Price.Mul(decimal.NewFromInt(int64(itemReq.Quantity))),
+		}
+		if !item.CanFulfill {
+			item.AllocatedQty = max(available, 0)
+			item.Reason = fmt.Sprintf("only %d available", available)
+			if product.AllowBackorder {
+				item.BackorderQty = itemReq.Quantity - item.AllocatedQty
+				item.Reason += fmt.Sprintf(", %d will be backordered", item.BackorderQty)
+			}
+			response.Available = false
+		}
+
+		response.TotalValue = response.TotalValue.Add(item.TotalValue)
+		response.Items = append(response.Items, item)
+	}
+
+	return response, nil
+}
+
+// ReserveInventory reserves stock for all unshipped quantities of an order
+func (s *ServiceImpl) ReserveInventory(ctx context.Context, orderID string) error {
+	order, err := s.loadOrder(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	backorders, err := s.reserveItems(ctx, order, order.CreatedBy)
+	if err != nil {
+		return err
+	}
+
+	s.notifyBackorders(ctx, order, backorders)
+	return nil
+}
+
+// ReleaseInventoryReservation releases stock reserved for an order
+func (s *ServiceImpl) ReleaseInventoryReservation(ctx context.Context, orderID string) error {
+	order, err := s.loadOrder(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	return s.releaseReservations(ctx, order)
+}
+
+// ConsumeInventory deducts reserved stock for all unshipped quantities of an order
+func (s *ServiceImpl) ConsumeInventory(ctx context.Context, orderID string) error {
+	order, err := s.loadOrder(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	quantities := make(map[uuid.UUID]int)
+	for _, item := range order.Items {
+		if remaining := item.Quantity - item.QuantityShipped; remaining > 0 {
+			quantities[item.ID] = remaining
+		}
+	}
+
+	return database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		return s.consumeItems(ctx, order, quantities, order.CreatedBy, nil)
+	})
+}
+
+// Bulk Operation Methods
+
+// BulkUpdateStatus updates the status of many orders, reporting per-order results
+func (s *ServiceImpl) BulkUpdateStatus(ctx context.Context, req *BulkUpdateStatusRequest) (*BulkUpdateStatusResponse, error) {
+	response := &BulkUpdateStatusResponse{
+		Results: make([]BulkUpdateResult, 0, len(req.OrderIDs)),
+	}
+	ctx = WithStatusChangeActor(ctx, req.UpdatedBy, entities.StatusChangeSourceBulk)
+
+	for _, orderID := range req.OrderIDs {
+		_, err := s.UpdateOrderStatus(ctx, orderID, &UpdateOrderStatusRequest{
+			Status:    req.Status,
+			Reason:    req.Reason,
+			Notify:    req.Notify,
+			UpdatedBy: req.UpdatedBy,
+		})
+
+		result := BulkUpdateResult{OrderID: orderID, Success: err == nil}
+		if err != nil {
+			result.Error = err.Error()
+			response.FailedCount++
+		} else {
+			response.UpdatedCount++
+		}
+		response.Results = append(response.Results, result)
+	}
+
+	return response, nil
+}
+
+// BulkCancelOrders cancels many orders, reporting per-order results
+func (s *ServiceImpl) BulkCancelOrders(ctx context.Context, req *BulkCancelOrdersRequest) (*BulkCancelOrdersResponse, error) {
+	response := &BulkCancelOrdersResponse{
+		Results: make([]BulkUpdateResult, 0, len(req.OrderIDs)),
+	}
+	ctx = WithStatusChangeActor(ctx, req.CancelledBy, entities.StatusChangeSourceBulk)
+
+	for _, orderID := range req.OrderIDs {
+		_, err := s.CancelOrder(ctx, orderID, &CancelOrderRequest{
+			Reason:      req.Reason,
+			Refund:      req.Refund,
+			Notify:      req.Notify,
+			CancelledBy: req.CancelledBy,
+		})
+
+		result := BulkUpdateResult{OrderID: orderID, Success: err == nil}
+		if err != nil {
+			result.Error = err.Error()
+			response.FailedCount++
+		} else {
+			response.CancelledCount++
+		}
+		response.Results = append(response.Results, result)
+	}
+
+	return response, nil
+}
+
+// Utility Methods
+
+// GenerateOrderNumber generates a unique order number
+func (s *ServiceImpl) GenerateOrderNumber(ctx context.Context) (string, error) {
+	orderNumber, err := s.orderRepo.GenerateUniqueOrderNumber(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate order number: %w", err)
+	}
+	return orderNumber, nil
+}
+
+// CloneOrder creates a new pending order from an existing one
+func (s *ServiceImpl) CloneOrder(ctx context.Context, id string, req *CloneOrderRequest) (*entities.Order, error) {
+	source, err := s.loadOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !req.CopyItems {
+		return nil, fmt.Errorf("%w: a cloned order must copy its items", ErrInvalidQuantity)
+	}
+
+	customerID := source.CustomerID.String()
+	if req.NewCustomerID != nil {
+		customerID = *req.NewCustomerID
+	}
+
+	shippingAddressID := source.ShippingAddressID
+	billingAddressID := source.BillingAddressID
+	if !req.CopyAddresses || req.NewCustomerID != nil {
+		customerUUID, err := uuid.Parse(customerID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid customer ID: %w", err)
+		}
+		if address, err := s.addressRepo.GetDefaultAddress(ctx, customerUUID, "SHIPPING"); err == nil {
+			shippingAddressID = address.ID
+		}
+		if address, err := s.addressRepo.GetDefaultAddress(ctx, customerUUID, "BILLING"); err == nil {
+			billingAddressID = address.ID
+		}
+	}
+
+	createReq := &CreateOrderRequest{
+		CustomerID:        customerID,
+		Type:              source.Type,
+		Priority:          source.Priority,
+		ShippingMethod:    source.ShippingMethod,
+		ShippingAddressID: shippingAddressID.String(),
+		BillingAddressID:  billingAddressID.String(),
+		Currency:          source.Currency,
+		Notes:             req.Notes,
+		CreatedBy:         source.CreatedBy.String(),
+	}
+	if req.ClonedBy != "" {
+		createReq.CreatedBy = req.ClonedBy
+	}
+	if req.CopyNotes {
+		if createReq.Notes == nil {
+			createReq.Notes = source.Notes
+		}
+		createReq.CustomerNotes = source.CustomerNotes
+	}
+
+	for _, item := range source.Items {
+		itemReq := CreateOrderItemRequest{
+			ProductID: item.ProductID.String(),
+			Quantity:  item.Quantity,
+			UnitPrice: item.UnitPrice,
+			TaxRate:   item.TaxRate,
+			Notes:     item.Notes,
+		}
+		if req.CopyDiscounts {
+			itemReq.DiscountAmount = item.DiscountAmount
+		}
+		if item.IsDropShip {
+			itemReq.DropShip = &item.IsDropShip
+		}
+		createReq.Items = append(createReq.Items, itemReq)
+	}
+
+	return s.CreateOrder(ctx, createReq)
+}
+
+// Helper Methods
+
+// loadOrder parses the ID and loads the order with its details
+func (s *ServiceImpl) loadOrder(ctx context.Context, id string) (*entities.Order, error) {
+	orderID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order ID: %w", err)
+	}
+
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, s.missingOrder(ctx, orderID)
+		}
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	if err := s.loadOrderDetails(ctx, order); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// lockOrder locks an order for the rest of the context's transaction and
+// loads it, so checks of its state hold until the transaction ends
+func (s *ServiceImpl) lockOrder(ctx context.Context, id string) (*entities.Order, error) {
+	orderID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order ID: %w", err)
+	}
+	if err := s.lockOrders(ctx, orderID); err != nil {
+		return nil, err
+	}
+	return s.loadOrder(ctx, id)
+}
+
+// lockOrders locks the rows of orders until the transaction of the context
+// ends. Rows are locked in ID order, so workflows locking the same orders
+// queue behind each other rather than deadlock.
+func (s *ServiceImpl) lockOrders(ctx context.Context, ids ...uuid.UUID) error {
+	sorted := append([]uuid.UUID(nil), ids...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].String() < sorted[j].String() })
+
+	for i, id := range sorted {
+		if i > 0 && id == sorted[i-1] {
+			continue
+		}
+		if err := s.orderRepo.Lock(ctx, id); err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				return s.missingOrder(ctx, id)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// loadOrderDetails attaches items, addresses, the customer and the applied
+// coupon codes to an order
+func (s *ServiceImpl) loadOrderDetails(ctx context.Context, order *entities.Order) error {
+	items, err := s.orderItemRepo.GetByOrderID(ctx, order.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get order items: %w", err)
+	}
+
+	order.Items = make([]entities.OrderItem, len(items))
+	for i, item := range items {
+		order.Items[i] = *item
+	}
+
+	// Addresses and customer are informational; a missing one should not hide the order
+	if address, err := s.addressRepo.GetByID(ctx, order.ShippingAddressID); err == nil {
+		order.ShippingAddress = address
+	}
+	if address, err := s.addressRepo.GetByID(ctx, order.BillingAddressID); err == nil {
+		order.BillingAddress = address
+	}
+	if customer, err := s.customerRepo.GetByID(ctx, order.CustomerID); err == nil {
+		order.Customer = customer
+	}
+
+	redemptions, err := s.promotionRepo.GetActiveRedemptionsByOrderID(ctx, order.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get order promotions: %w", err)
+	}
+	order.Promotions = make([]entities.PromotionRedemption, len(redemptions))
+	for i, redemption := range redemptions {
+		order.Promotions[i] = *redemption
+	}
+
+	return nil
+}
+
+// getCustomer parses the ID and loads the customer
+func (s *ServiceImpl) getCustomer(ctx context.Context, id string) (*entities.Customer, error) {
+	return findCustomer(ctx, s.customerRepo, id)
+}
+
+// getAddress parses the ID and loads the address
+func (s *ServiceImpl) getAddress(ctx context.Context, id string) (*entities.OrderAddress, error) {
+	return findAddress(ctx, s.addressRepo, id)
+}
+
+// buildOrderItem creates a priced order line from the product catalogue
+func (s *ServiceImpl) buildOrderItem(ctx context.Context, orderID uuid.UUID, productID string, quantity int, unitPrice, discount, taxRate decimal.Decimal, notes *string) (*entities.OrderItem, error) {
+	return newPricedItem(ctx, s.productRepo, orderID, productID, quantity, unitPrice, discount, taxRate, notes)
+}
+
+// findCustomer parses the ID and loads the customer
+func findCustomer(ctx context.Context, customerRepo repositories.CustomerRepository, id string) (*entities.Customer, error) {
+	customerID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid customer ID: %w", err)
+	}
+
+	customer, err := customerRepo.GetByID(ctx, customerID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrCustomerNotFound
+		}
+		return nil, fmt.Errorf("failed to get customer: %w", err)
+	}
+
+	return customer, nil
+}
+
+// findAddress parses the ID and loads the address
+func findAddress(ctx context.Context, addressRepo repositories.OrderAddressRepository, id string) (*entities.OrderAddress, error) {
+	addressID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAddress, err)
+	}
+
+	address, err := addressRepo.GetByID(ctx, addressID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrInvalidAddress
+		}
+		return nil, fmt.Errorf("failed to get address: %w", err)
+	}
+
+	return address, nil
+}
+
+// newPricedItem creates a line priced from the product catalogue. It prices
+// both order lines and quotation lines, so quotes and orders always agree.
+func newPricedItem(ctx context.Context, productRepo productRepositories.ProductRepository, orderID uuid.UUID, productID string, quantity int, unitPrice, discount, taxRate decimal.Decimal, notes *string) (*entities.OrderItem, error) {
+	if quantity <= 0 {
+		return nil, ErrInvalidQuantity
+	}
+
+	productUUID, err := uuid.Parse(productID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	product, err := productRepo.GetByID(ctx, productUUID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	if !product.IsActive {
+		return nil, fmt.Errorf("%w: product %s is inactive", ErrProductNotFound, product.SKU)
+	}
+
+	if unitPrice.LessThanOrEqual(decimal.Zero) {
+		unitPrice = product.Price
+	}
+	if taxRate.IsZero() && product.Taxable {
+		taxRate = product.TaxRate
+	}
+	taxClass := entities.TaxClassExempt
+	if product.Taxable {
+		if taxClass, err = entities.NormalizeTaxClass(product.TaxClass); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now().UTC()
+	item := &entities.OrderItem{
+		ID:             uuid.New(),
+		OrderID:        orderID,
+		ProductID:      product.ID,
+		ProductSKU:     product.SKU,
+		ProductName:    product.Name,
+		Quantity:       quantity,
+		UnitPrice:      unitPrice,
+		DiscountAmount: discount,
+		TaxRate:        taxRate,
+		TaxClass:       taxClass,
+		Weight:         product.Weight,
+		Dimensions:     product.Dimensions,
+		Notes:          notes,
+		IsDropShip:     product.IsDropShip,
+		Status:         "ORDERED",
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if product.Barcode != "" {
+		barcode := product.Barcode
+		item.Barcode = &barcode
+	}
+	// Products measured field by field still count towards dimensional weight
+	if item.Dimensions == "" && product.Length > 0 && product.Width > 0 && product.Height > 0 {
+		item.Dimensions = fmt.Sprintf("%g x %g x %g", product.Length, product.Width, product.Height)
+	}
+
+	item.CalculateTotals()
+	if err := item.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid order item: %w", err)
+	}
+
+	return item, nil
+}
+
+// applyTotals recalculates order totals from its items and returns the
+// breakdown. Shipping is priced first so it can be taxed and discounted. The
+// tax calculator sets the tax rate of each line and replaces the per-rate tax
+// breakdown with named components, including tax on shipping.
+func (s *ServiceImpl) applyTotals(ctx context.Context, order *entities.Order) (*entities.OrderCalculation, error) {
+	// DiscountAmount holds item discounts, any manual order-level discount and
+	// the coupon discounts; only the manual part is fed back in so the others
+	// are not counted twice
+	order.DiscountAmount = decimal.Max(orderLevelDiscount(order).Sub(order.PromotionDiscountAmount), decimal.Zero)
+
+	if err := s.priceShipping(ctx, order); err != nil {
+		return nil, err
+	}
+
+	var taxes *entities.TaxCalculation
+	if s.taxCalculator != nil && len(order.Items) > 0 {
+		var err error
+		taxes, err = s.taxCalculator.CalculateTax(ctx, order)
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate tax: %w", err)
+		}
+		taxes.ApplyToItems(order)
+	}
+
+	calculation, err := entities.CalculateOrderTotals(order, decimal.Zero, order.ShippingAmount)
+	if err != nil {
+		return nil, err
+	}
+	if taxes != nil {
+		taxes.ApplyTo(calculation)
+	}
+
+	promotions, err := s.calculatePromotions(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+	promotions.ApplyTo(calculation)
+
+	order.Subtotal = calculation.Subtotal.Round(2)
+	order.TaxAmount = calculation.TaxAmount.Round(2)
+	order.ShippingAmount = calculation.ShippingAmount.Round(2)
+	order.DiscountAmount = calculation.DiscountAmount.Round(2)
+	order.PromotionDiscountAmount = promotions.DiscountAmount.Round(2)
+	order.TotalAmount = order.Subtotal.Add(order.TaxAmount).Add(order.ShippingAmount).Sub(order.DiscountAmount)
+	order.UpdatedAt = time.Now().UTC()
+
+	return calculation, nil
+}
+
+// transitionOrder validates and persists a status change and records it in the history
+func (s *ServiceImpl) transitionOrder(ctx context.Context, order *entities.Order, newStatus entities.OrderStatus, reason string) error {
+	// Only approval confirms an order, reserving its inventory on the way
+	if entities.RequiresApproval(newStatus) && order.ApprovedAt == nil {
+		return fmt.Errorf("%w: order must be approved first", ErrInvalidStatusTransition)
+	}
+
+	previousStatus := order.Status
+	if err := order.ChangeStatus(newStatus, reason); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidStatusTransition, err)
+	}
+
+	if err := s.orderRepo.Update(ctx, order); err != nil {
+		return fmt.Errorf("failed to update order: %w", err)
+	}
+
+	// Approval books the order against the customer's credit, closing it releases the credit
+	switch newStatus {
+	case entities.OrderStatusConfirmed, entities.OrderStatusCancelled, entities.OrderStatusReturned, entities.OrderStatusRefunded:
+		if order.ApprovedAt != nil {
+			if err := s.syncCustomerCredit(ctx, order.CustomerID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return s.recordStatusChange(ctx, order, &previousStatus, reason)
+}
+
+// saveRefund pays the refund out through the ledger, credits it against the
+// order's invoice and records it, plus the move to REFUNDED when the refund
+// settled the order
+func (s *ServiceImpl) saveRefund(ctx context.Context, order *entities.Order, previousStatus entities.OrderStatus, previousPaymentStatus entities.PaymentStatus, amount decimal.Decimal, entry ledgerEntry, credited []creditedLine, reason string) error {
+	return database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		if err := s.recordRefund(ctx, order, previousPaymentStatus, amount, entry, reason); err != nil {
+			return err
+		}
+		if err := s.issueCreditNote(ctx, order, amount, credited, reason); err != nil {
+			return err
+		}
+		return s.recordStatusChange(ctx, order, &previousStatus, reason)
+	})
+}
+
+// shipItems ships the given quantities as a new shipment, consumes their stock
+// and derives the order status from the order's shipments. Drop-ship lines
+// ship only with the drop-ship purchase order their supplier shipped. The
+// caller locks the order in the context's transaction, so shipments of the
+// same order are numbered one after another.
+func (s *ServiceImpl) shipItems(ctx context.Context, order *entities.Order, quantities map[uuid.UUID]int, details shipmentDetails) (*entities.Shipment, error) {
+	switch order.Status {
+	case entities.OrderStatusProcessing, entities.OrderStatusPartiallyShipped:
+	default:
+		return nil, fmt.Errorf("%w: order must be processing to ship", ErrOrderCannotBeShipped)
+	}
+
+	if len(quantities) == 0 {
+		return nil, fmt.Errorf("%w: nothing left to ship", ErrOrderCannotBeShipped)
+	}
+
+	shipperID, err := uuid.Parse(details.shippedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid shipped by user ID: %w", err)
+	}
+	ctx = withActorID(ctx, details.shippedBy)
+
+	for itemID, quantity := range quantities {
+		item, err := findOrderItem(order, itemID.String())
+		if err != nil {
+			return nil, err
+		}
+		if item.QuantityBackordered > 0 && quantity > item.ShippableQuantity() {
+			return nil, fmt.Errorf("%w: %s has %d units on backorder", ErrInsufficientInventory, item.ProductSKU, item.QuantityBackordered)
+		}
+		if item.IsDropShip != (details.purchaseOrder != nil) {
+			if item.IsDropShip {
+				return nil, fmt.Errorf("%w: %s is shipped by its supplier against a drop-ship purchase order", ErrOrderCannotBeShipped, item.ProductSKU)
+			}
+			return nil, fmt.Errorf("%w: %s is not a drop-ship line", ErrOrderCannotBeShipped, item.ProductSKU)
+		}
+		if err := item.ShipItem(quantity); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidQuantity, err)
+		}
+	}
+
+	if err := order.UpdateTracking(details.trackingNumber, details.carrier); err != nil {
+		return nil, err
+	}
+
+	shipments, err := s.shipmentRepo.GetByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order shipments: %w", err)
+	}
+
+	shipment, err := newShipment(order, quantities, details, shipperID, len(shipments)+1)
+	if err != nil {
+		return nil, err
+	}
+	if details.purchaseOrder != nil {
+		shipment.Notes = optionalString("Shipped by the supplier on drop-ship purchase order " + details.purchaseOrder.PONumber)
+	}
+	target := entities.FulfillmentStatus(order.Items, append(shipments, shipment))
+
+	order.ShippedBy = &shipperID
+	order.ShippedAt = &shipment.ShippedAt
+
+	err = database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		if err := s.consumeItems(ctx, order, quantities, shipperID, details.warehouseID); err != nil {
+			return err
+		}
+
+		if err := s.orderItemRepo.BulkUpdate(ctx, itemPointers(order)); err != nil {
+			return fmt.Errorf("failed to update order items: %w", err)
+		}
+
+		if err := s.shipmentRepo.Create(ctx, shipment); err != nil {
+			return fmt.Errorf("failed to create shipment: %w", err)
+		}
+
+		if details.purchaseOrder != nil {
+			if err := s.poRepo.Update(ctx, details.purchaseOrder); err != nil {
+				return fmt.Errorf("failed to update drop-ship purchase order: %w", err)
+			}
+		}
+
+		if order.Status == target {
+			if err := s.orderRepo.Update(ctx, order); err != nil {
+				return fmt.Errorf("failed to update order: %w", err)
+			}
+			return nil
+		}
+
+		previousStatus := order.Status
+		if err := order.ChangeStatus(target, ""); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidStatusTransition, err)
+		}
+		if details.shippingDate != nil {
+			date := details.shippingDate.UTC()
+			order.ShippingDate = &date
+		}
+		if err := s.orderRepo.Update(ctx, order); err != nil {
+			return fmt.Errorf("failed to update order: %w", err)
+		}
+		return s.recordStatusChange(ctx, order, &previousStatus, "")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return shipment, nil
+}
+
+// reserveItems reserves stock for every unshipped quantity on the order
+func (s *ServiceImpl) reserveItems(ctx context.Context, order *entities.Order, reservedBy uuid.UUID) ([]*entities.Backorder, error) {
+	quantities := make(map[uuid.UUID]int, len(order.Items))
+	for _, item := range order.Items {
+		quantities[item.ID] = item.ShippableQuantity()
+	}
+	return s.reserveQuantities(ctx, order, quantities, reservedBy)
+}
+
+// reserveQuantities reserves stock for the quantities of the order's lines
+// in the warehouses chosen by the sourcing rules, recording the allocation
+// of each line. Shortfalls on products that allow backorders are split off
+// the line into backorders instead of failing the reservation; the created
+// backorders are returned.
+func (s *ServiceImpl) reserveQuantities(ctx context.Context, order *entities.Order, quantities map[uuid.UUID]int, reservedBy uuid.UUID) ([]*entities.Backorder, error) {
+	var lines []entities.SourcingLine
+	products := make(map[uuid.UUID]*productEntities.Product)
+
+	for i := range order.Items {
+		item := &order.Items[i]
+		quantity := quantities[item.ID]
+		// Drop-ship lines are ordered from the supplier, not reserved
+		if quantity <= 0 || item.IsDropShip {
+			continue
+		}
+
+		product, err := s.productRepo.GetByID(ctx, item.ProductID)
+		if err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				return nil, ErrProductNotFound
+			}
+			return nil, fmt.Errorf("failed to get product: %w", err)
+		}
+		if !product.TrackInventory || product.IsDigital {
+			continue
+		}
+
+		products[item.ID] = product
+		lines = append(lines, entities.SourcingLine{OrderItemID: item.ID, ProductID: item.ProductID, Quantity: quantity})
+	}
+	if len(lines) == 0 {
+		return nil, nil
+	}
+
+	plan, ruleID, err := s.planSourcing(ctx, order, lines, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var backorders []*entities.Backorder
+	var backorderedItems []*entities.OrderItem
+	for _, line := range lines {
+		remaining := plan.Unallocated[line.OrderItemID]
+		if remaining == 0 {
+			continue
+		}
+
+		item, err := findOrderItem(order, line.OrderItemID.String())
+		if err != nil {
+			return nil, err
+		}
+		if !products[item.ID].AllowBackorder {
+			return nil, fmt.Errorf("%w: %s needs %d more", ErrInsufficientInventory, item.ProductSKU, remaining)
+		}
+
+		backorder, err := entities.NewBackorder(order, item, remaining)
+		if err != nil {
+			return nil, err
+		}
+		item.QuantityBackordered += remaining
+		item.UpdatedAt = time.Now().UTC()
+		backorders = append(backorders, backorder)
+		backorderedItems = append(backorderedItems, item)
+	}
+
+	reservations := make([]invRepositories.StockReservation, 0, len(plan.Allocations))
+	for _, allocation := range plan.Allocations {
+		reservations = append(reservations, invRepositories.StockReservation{
+			ProductID:   allocation.ProductID,
+			WarehouseID: allocation.WarehouseID,
+			Quantity:    allocation.Quantity,
+			ReservedBy:  reservedBy,
+		})
+	}
+	if err := s.inventoryRepo.BulkReserveStock(ctx, reservations); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInventoryReservationFailed, err)
+	}
+
+	for _, allocation := range newOrderAllocations(order, plan, ruleID) {
+		if err := s.sourcingRepo.AddAllocation(ctx, allocation); err != nil {
+			return nil, fmt.Errorf("failed to record order allocation: %w", err)
+		}
+	}
+
+	if len(backorders) == 0 {
+		return nil, nil
+	}
+
+	if err := s.orderItemRepo.BulkUpdate(ctx, backorderedItems); err != nil {
+		return nil, fmt.Errorf("failed to update order items: %w", err)
+	}
+	for _, backorder := range backorders {
+		if err := s.backorderRepo.Create(ctx, backorder); err != nil {
+			return nil, fmt.Errorf("failed to create backorder: %w", err)
+		}
+	}
+
+	return backorders, nil
+}
+
+// cancelBackorders withdraws the order's open backorders from their queues
+func (s *ServiceImpl) cancelBackorders(ctx context.Context, order *entities.Order) error {
+	backorders, err := s.backorderRepo.GetByOrderID(ctx, order.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get order backorders: %w", err)
+	}
+
+	for _, backorder := range backorders {
+		if !backorder.IsOpen() {
+			continue
+		}
+		if err := backorder.Cancel(); err != nil {
+			return err
+		}
+		if err := s.backorderRepo.Update(ctx, backorder); err != nil {
+			return fmt.Errorf("failed to cancel backorder: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// notifyBackorders tells the customer which lines were backordered. Failures
+// are logged; the order itself has already been committed.
+func (s *ServiceImpl) notifyBackorders(ctx context.Context, order *entities.Order, backorders []*entities.Backorder) {
+	if s.notifier == nil || len(backorders) == 0 {
+		return
+	}
+
+	if order.Customer == nil {
+		customer, err := s.customerRepo.GetByID(ctx, order.CustomerID)
+		if err != nil {
+			s.logger.Error().Err(err).Str("order_id", order.ID.String()).Msg("Failed to load customer for backorder notification")
+			return
+		}
+		order.Customer = customer
+	}
+
+	if err := s.notifier.BackordersCreated(ctx, order, backorders); err != nil {
+		s.logger.Error().Err(err).Str("order_id", order.ID.String()).Msg("Failed to send backorder notification")
+	}
+}
+
+// releaseReservations releases the stock reserved for unshipped quantities,
+// from the warehouses the lines are allocated to first, and clears the
+// order's allocations
+func (s *ServiceImpl) releaseReservations(ctx context.Context, order *entities.Order) error {
+	allocations, err := s.sourcingRepo.GetAllocationsByOrderID(ctx, order.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get order allocations: %w", err)
+	}
+
+	for _, item := range order.Items {
+		quantity := item.ShippableQuantity()
+		if quantity <= 0 {
+			continue
+		}
+
+		if _, err := s.drawReservedStockFrom(ctx, item.ProductID, quantity, allocatedWarehouses(allocations, item.ID), func(warehouseID uuid.UUID, take int) error {
+			return s.inventoryRepo.ReleaseStock(ctx, item.ProductID, warehouseID, take)
+		}); err != nil {
+			return fmt.Errorf("failed to release inventory: %w", err)
+		}
+	}
+
+	if len(allocations) == 0 {
+		return nil
+	}
+	if err := s.sourcingRepo.ReplaceAllocations(ctx, order.ID, nil); err != nil {
+		return fmt.Errorf("failed to clear order allocations: %w", err)
+	}
+
+	return nil
+}
+
+// consumeItems removes shipped quantities from stock and records SALE
+// transactions. Stock reserved in the preferred warehouse is drawn first,
+// then the warehouses the lines are allocated to; the allocations are drawn
+// down by the shipped quantities.
+func (s *ServiceImpl) consumeItems(ctx context.Context, order *entities.Order, quantities map[uuid.UUID]int, consumedBy uuid.UUID, preferred *uuid.UUID) error {
+	allocations, err := s.sourcingRepo.GetAllocationsByOrderID(ctx, order.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get order allocations: %w", err)
+	}
+	allocated := len(allocations) > 0
+
+	for itemID, quantity := range quantities {
+		item, err := findOrderItem(order, itemID.String())
+		if err != nil {
+			return err
+		}
+		if item.IsDropShip {
+			continue
+		}
+
+		tracked, err := s.tracksInventory(ctx, item.ProductID)
+		if err != nil {
+			return err
+		}
+		if !tracked {
+			continue
+		}
+
+		warehouses := allocatedWarehouses(allocations, item.ID)
+		if preferred != nil {
+			warehouses = append([]uuid.UUID{*preferred}, warehouses...)
+		}
+
+		_, err = s.drawReservedStockFrom(ctx, item.ProductID, quantity, warehouses, func(warehouseID uuid.UUID, take int) error {
+			if err := s.inventoryRepo.ReleaseStock(ctx, item.ProductID, warehouseID, take); err != nil {
+				return err
+			}
+			if err := s.inventoryRepo.AdjustStock(ctx, item.ProductID, warehouseID, -take); err != nil {
+				return err
+			}
+			allocations = entities.ReleaseAllocations(allocations, item.ID, warehouseID, take)
+
+			reference := order.ID
+			transaction := &invEntities.InventoryTransaction{
+				ID:              uuid.New(),
+				ProductID:       item.ProductID,
+				WarehouseID:     warehouseID,
+				TransactionType: invEntities.TransactionTypeSale,
+				Quantity:        -take,
+				ReferenceType:   "ORDER",
+				ReferenceID:     &reference,
+				Reason:          fmt.Sprintf("Shipped on order %s", order.OrderNumber),
+				CreatedAt:       time.Now().UTC(),
+				CreatedBy:       consumedBy,
+			}
+			return s.transactionRepo.Create(ctx, transaction)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to consume inventory: %w", err)
+		}
+	}
+
+	if !allocated {
+		return nil
+	}
+	if err := s.sourcingRepo.ReplaceAllocations(ctx, order.ID, allocations); err != nil {
+		return fmt.Errorf("failed to update order allocations: %w", err)
+	}
+
+	return nil
+}
+
+// drawReservedStockFrom walks the warehouses holding reservations for a
+// product, starting with the preferred warehouses in the order given, and
+// applies fn until quantity has been covered
+func (s *ServiceImpl) drawReservedStockFrom(ctx context.Context, productID uuid.UUID, quantity int, preferred []uuid.UUID, fn func(warehouseID uuid.UUID, take int) error) (int, error) {
+	levels, err := s.inventoryRepo.GetProductInventory(ctx, productID)
+	if err != nil {
+		return 0, err
+	}
+
+	rank := func(warehouseID uuid.UUID) int {
+		for i, id := range preferred {
+			if id == warehouseID {
+				return i
+			}
+		}
+		return len(preferred)
+	}
+	sort.Slice(levels, func(i, j int) bool {
+		if ri, rj := rank(levels[i].WarehouseID), rank(levels[j].WarehouseID); ri != rj {
+			return ri < rj
+		}
+		return levels[i].QuantityReserved > levels[j].QuantityReserved
+	})
+
+	remaining := quantity
+	for _, level := range levels {
+		if remaining == 0 {
+			break
+		}
+		if level.QuantityReserved <= 0 {
+			continue
+		}
+		take := min(level.QuantityReserved, remaining)
+		if err := fn(level.WarehouseID, take); err != nil {
+			return quantity - remaining, err
+		}
+		remaining -= take
+	}
+
+	if remaining > 0 {
+		return quantity - remaining, fmt.Errorf("%w: %d units of product %s are not reserved", ErrInsufficientInventory, remaining, productID)
+	}
+
+	return quantity, nil
+}
+
+// tracksInventory reports whether stock is managed for a product
+func (s *ServiceImpl) tracksInventory(ctx context.Context, productID uuid.UUID) (bool, error) {
+	product, err := s.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return false, ErrProductNotFound
+		}
+		return false, fmt.Errorf("failed to get product: %w", err)
+	}
+	return product.TrackInventory && !product.IsDigital, nil
+}
+
+// availableStock returns available stock for a product in one or all warehouses
+func (s *ServiceImpl) availableStock(ctx context.Context, productID uuid.UUID, warehouseID *string) (int, error) {
+	if warehouseID != nil && *warehouseID != "" {
+		warehouseUUID, err := uuid.Parse(*warehouseID)
+		if err != nil {
+			return 0, fmt.Errorf("invalid warehouse ID: %w", err)
+		}
+		available, err := s.inventoryRepo.GetAvailableStock(ctx, productID, warehouseUUID)
+		if err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				return 0, nil
+			}
+			return 0, fmt.Errorf("failed to get available stock: %w", err)
+		}
+		return available, nil
+	}
+
+	levels, err := s.inventoryRepo.GetProductInventory(ctx, productID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get inventory levels: %w", err)
+	}
+
+	available := 0
+	for _, level := range levels {
+		if qty := level.GetAvailableQuantity(); qty > 0 {
+			available += qty
+		}
+	}
+	return available, nil
+}
+
+// hasInventoryReservation reports whether stock is currently held for the order
+func hasInventoryReservation(order *entities.Order) bool {
+	if order.ApprovedAt == nil {
+		return false
+	}
+	switch order.Status {
+	case entities.OrderStatusConfirmed, entities.OrderStatusProcessing,
+		entities.OrderStatusPartiallyShipped, entities.OrderStatusOnHold:
+		return true
+	}
+	return false
+}
+
+// lineEditError explains why the lines of an order cannot be edited
+func lineEditError(order *entities.Order) error {
+	if hasInventoryReservation(order) {
+		return fmt.Errorf("%w: amend the confirmed order to change its lines", ErrOrderCannotBeModified)
+	}
+	return ErrOrderCannotBeModified
+}
+
+// isEditable reports whether order lines may still be changed
+func isEditable(order *entities.Order) bool {
+	switch order.Status {
+	case entities.OrderStatusDraft, entities.OrderStatusPending:
+		return true
+	case entities.OrderStatusOnHold:
+		return order.ApprovedAt == nil
+	}
+	return false
+}
+
+// itemDiscountTotal sums the line-level discounts on an order
+func itemDiscountTotal(order *entities.Order) decimal.Decimal {
+	total := decimal.Zero
+	for _, item := range order.Items {
+		total = total.Add(item.LineDiscount())
+	}
+	return total
+}
+
+// orderLevelDiscount returns the part of DiscountAmount not attributable to lines
+func orderLevelDiscount(order *entities.Order) decimal.Decimal {
+	discount := order.DiscountAmount.Sub(itemDiscountTotal(order))
+	if discount.LessThan(decimal.Zero) {
+		return decimal.Zero
+	}
+	return discount
+}
+
+// unitRefundAmount returns what the customer paid per unit of an order line,
+// after the line discount and including tax
+func unitRefundAmount(item *entities.OrderItem) decimal.Decimal {
+	net := item.UnitPrice.Sub(item.DiscountAmount)
+	if item.PriceIncludesTax {
+		return net.Round(2)
+	}
+	tax := net.Mul(item.TaxRate).Div(decimal.NewFromInt(100))
+	return net.Add(tax).Round(2)
+}
+
+// refundableAmount returns what the customer paid for a quantity of an order
+// line, the amount refunded when it is returned
+func refundableAmount(item *entities.OrderItem, quantity int) decimal.Decimal {
+	return unitRefundAmount(item).Mul(decimal.NewFromInt(int64(quantity)))
+}
+
+// findOrderItem returns a pointer to the order line with the given ID
+func findOrderItem(order *entities.Order, itemID string) (*entities.OrderItem, error) {
+	id, err := uuid.Parse(itemID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order item ID: %w", err)
+	}
+
+	for i := range order.Items {
+		if order.Items[i].ID == id {
+			return &order.Items[i], nil
+		}
+	}
+
+	return nil, ErrOrderItemNotFound
+}
+
+// itemPointers returns pointers to the order's items for bulk repository calls
+func itemPointers(order *entities.Order) []*entities.OrderItem {
+	items := make([]*entities.OrderItem, len(order.Items))
+	for i := range order.Items {
+		items[i] = &order.Items[i]
+	}
+	return items
+}
+
+// shipQuantities converts ship item requests into a quantity per item ID
+func shipQuantities(items []ShipItemRequest) (map[uuid.UUID]int, error) {
+	quantities := make(map[uuid.UUID]int, len(items))
+	for _, item := range items {
+		itemID, err := uuid.Parse(item.ItemID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid order item ID: %w", err)
+		}
+		if item.Quantity <= 0 {
+			return nil, ErrInvalidQuantity
+		}
+		quantities[itemID] += item.Quantity
+	}
+	return quantities, nil
+}
+
+// checkRequestForOrder builds an inventory check for an order's unshipped quantities
+func checkRequestForOrder(order *entities.Order) *CheckInventoryRequest {
+	req := &CheckInventoryRequest{}
+	for _, item := range order.Items {
+		if remaining := item.Quantity - item.QuantityShipped; remaining > 0 {
+			req.Items = append(req.Items, CheckInventoryItemRequest{
+				ProductID: item.ProductID.String(),
+				Quantity:  remaining,
+			})
+		}
+	}
+	return req
+}
+
+// appendInternalNote appends a line to the order's internal notes
+func appendInternalNote(order *entities.Order, note string) {
+	if order.InternalNotes == nil || strings.TrimSpace(*order.InternalNotes) == "" {
+		order.InternalNotes = &note
+		return
+	}
+	combined := *order.InternalNotes + "\n" + note
+	order.InternalNotes = &combined
+}
+
+// parseOptionalUUID parses an optional UUID string
+func parseOptionalUUID(value *string, field string) (*uuid.UUID, error) {
+	if value == nil || *value == "" {
+		return nil, nil
+	}
+	parsed, err := uuid.Parse(*value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", field, err)
+	}
+	return &parsed, nil
+}
+
+// normalizePage applies default paging values
+func normalizePage(page, limit int) (int, int) {
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	return page, limit
+}
+
+// newPagination builds pagination metadata
+func newPagination(page, limit, total int) *Pagination {
+	totalPages := 0
+	if limit > 0 {
+		totalPages = (total + limit - 1) / limit
+	}
+	return &Pagination{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+		HasPrev:    page > 1,
+	}
+}
@@ -12,36 +12,36 @@ import (
 	"erpgo/internal/domain/orders/entities"
 )
 
-// MockOrderRepository is a simplified mock for testing
-type MockOrderRepository struct {
+// simpleOrderRepository is a simplified mock for testing
+type simpleOrderRepository struct {
 	mock.Mock
 }
 
-// NewMockOrderRepository creates a new mock order repository
-func NewMockOrderRepository() *MockOrderRepository {
-	return &MockOrderRepository{mock.Mock{}}
+// newSimpleOrderRepository creates a new mock order repository
+func newSimpleOrderRepository() *simpleOrderRepository {
+	return &simpleOrderRepository{mock.Mock{}}
 }
 
 // Create mocks the Create method
-func (m *MockOrderRepository) Create(ctx context.Context, order *entities.Order) error {
+func (m *simpleOrderRepository) Create(ctx context.Context, order *entities.Order) error {
 	args := m.Called(ctx, order)
 	return args.Error(0)
 }
 
 // GetByID mocks the GetByID method
-func (m *MockOrderRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Order, error) {
+func (m *simpleOrderRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Order, error) {
 	args := m.Called(ctx, id)
 	return args.Get(0).(*entities.Order), args.Error(1)
 }
 
 // Update mocks the Update method
-func (m *MockOrderRepository) Update(ctx context.Context, order *entities.Order) error {
+func (m *simpleOrderRepository) Update(ctx context.Context, order *entities.Order) error {
 	args := m.Called(ctx, order)
 	return args.Error(0)
 }
 
 // List mocks the List method
-func (m *MockOrderRepository) List(ctx context.Context, filter interface{}) ([]*entities.Order, error) {
+func (m *simpleOrderRepository) List(ctx context.Context, filter interface{}) ([]*entities.Order, error) {
 	args := m.Called(ctx, filter)
 	return args.Get(0).([]*entities.Order), args.Error(1)
 }
@@ -49,7 +49,7 @@ func (m *MockOrderRepository) List(ctx context.Context, filter interface{}) ([]*
 // TestCreateOrder tests the CreateOrder method
 func TestCreateOrder(t *testing.T) {
 	// Setup
-	mockRepo := NewMockOrderRepository()
+	mockRepo := newSimpleOrderRepository()
 
 	ctx := context.Background()
 
@@ -77,7 +77,7 @@ func TestCreateOrder(t *testing.T) {
 // TestGetOrderByID tests the GetByID method
 func TestGetOrderByID(t *testing.T) {
 	// Setup
-	mockRepo := NewMockOrderRepository()
+	mockRepo := newSimpleOrderRepository()
 
 	ctx := context.Background()
 	orderID := uuid.New()
@@ -107,7 +107,7 @@ func TestGetOrderByID(t *testing.T) {
 // TestUpdateOrder tests the UpdateOrder method
 func TestUpdateOrder(t *testing.T) {
 	// Setup
-	mockRepo := NewMockOrderRepository()
+	mockRepo := newSimpleOrderRepository()
 
 	ctx := context.Background()
 	orderID := uuid.New()
@@ -134,7 +134,7 @@ func TestUpdateOrder(t *testing.T) {
 // TestListOrders tests the List method
 func TestListOrders(t *testing.T) {
 	// Setup
-	mockRepo := NewMockOrderRepository()
+	mockRepo := newSimpleOrderRepository()
 
 	ctx := context.Background()
 
@@ -159,7 +159,7 @@ func TestListOrders(t *testing.T) {
 	}
 
 	// Mock expectations
-	mockRepo.On("List", ctx, mock.AnythingOfType("interface{}")).Return(expectedOrders, nil)
+	mockRepo.On("List", ctx, mock.Anything).Return(expectedOrders, nil)
 
 	// Execute
 	orders, err := mockRepo.List(ctx, nil)
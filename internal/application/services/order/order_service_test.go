@@ -7,743 +7,497 @@ import (
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	invEntities "erpgo/internal/domain/inventory/entities"
 	"erpgo/internal/domain/orders/entities"
+	productEntities "erpgo/internal/domain/products/entities"
 )
 
-func TestServiceImpl_CreateOrder(t *testing.T) {
-	// Setup
-	ctx := context.Background()
-	mockOrderRepo := &MockOrderRepository{}
-	mockOrderItemRepo := &MockOrderItemRepository{}
-	mockCustomerRepo := &MockCustomerRepository{}
-	mockAddressRepo := &MockOrderAddressRepository{}
-	mockCompanyRepo := &MockCompanyRepository{}
-	mockAnalyticsRepo := &MockOrderAnalyticsRepository{}
-	mockProductService := &MockProductService{}
-	mockInventoryService := &MockInventoryService{}
-	mockUserService := &MockUserService{}
-	mockNotificationService := &MockNotificationService{}
-	mockPaymentService := &MockPaymentService{}
-	mockTaxCalculator := &MockTaxCalculator{}
-	mockShippingCalculator := &MockShippingCalculator{}
-
-	service := &ServiceImpl{
-		orderRepo:           mockOrderRepo,
-		orderItemRepo:       mockOrderItemRepo,
-		customerRepo:        mockCustomerRepo,
-		addressRepo:         mockAddressRepo,
-		companyRepo:         mockCompanyRepo,
-		analyticsRepo:       mockAnalyticsRepo,
-		productService:      mockProductService,
-		inventoryService:    mockInventoryService,
-		userService:         mockUserService,
-		notificationService: mockNotificationService,
-		paymentService:      mockPaymentService,
-		taxCalculator:       mockTaxCalculator,
-		shippingCalculator:  mockShippingCalculator,
-		defaultCurrency:     "USD",
-	}
-
-	// Test data
-	customerID := uuid.New()
-	shippingAddressID := uuid.New()
-	billingAddressID := uuid.New()
-	productID := uuid.New()
+// orderFixture is a customer ordering a stocked product, shipped to an
+// address on the west coast from a warehouse on the east coast
+type orderFixture struct {
+	customer  *entities.Customer
+	address   *entities.OrderAddress
+	product   *productEntities.Product
+	warehouse *invEntities.WarehouseExtended
+	user      uuid.UUID
+}
 
-	customer := CreateTestCustomer(customerID)
-	shippingAddress := CreateTestOrderAddress(shippingAddressID, customerID, "SHIPPING")
-	billingAddress := CreateTestOrderAddress(billingAddressID, customerID, "BILLING")
-	product := CreateTestProduct(productID)
+// newOrderFixture adds a customer with the credit limit, a product at 50.00
+// and 100 units of it in stock. A zero credit limit leaves credit unchecked.
+func newOrderFixture(store *memoryStore, creditLimit decimal.Decimal) *orderFixture {
+	customer := store.addCustomer(creditLimit)
+	fixture := &orderFixture{
+		customer:  customer,
+		address:   store.addAddress(customer.ID, "US", "CA", "94105"),
+		product:   store.addProduct(decimal.NewFromInt(50)),
+		warehouse: store.addWarehouse("EAST", "US", "NY", "10001"),
+		user:      uuid.New(),
+	}
+	store.addStock(fixture.product.ID, fixture.warehouse.ID, 100)
+	return fixture
+}
 
-	req := &CreateOrderRequest{
-		CustomerID:        customerID.String(),
+func (f *orderFixture) createRequest(quantity int) *CreateOrderRequest {
+	return &CreateOrderRequest{
+		CustomerID:        f.customer.ID.String(),
 		Type:              entities.OrderTypeSales,
-		Priority:          entities.OrderPriorityNormal,
 		ShippingMethod:    entities.ShippingMethodStandard,
-		ShippingAddressID: shippingAddressID.String(),
-		BillingAddressID:  billingAddressID.String(),
+		ShippingAddressID: f.address.ID.String(),
+		BillingAddressID:  f.address.ID.String(),
 		Currency:          "USD",
 		Items: []CreateOrderItemRequest{
-			{
-				ProductID: productID.String(),
-				Quantity:  2,
-			},
+			{ProductID: f.product.ID.String(), Quantity: quantity},
 		},
+		CreatedBy: f.user.String(),
 	}
+}
+
+// createOrder creates a pending order for a quantity of the product
+func (f *orderFixture) createOrder(t *testing.T, service *ServiceImpl, quantity int) *entities.Order {
+	t.Helper()
+	order, err := service.CreateOrder(context.Background(), f.createRequest(quantity))
+	require.NoError(t, err)
+	return order
+}
+
+// confirmOrder creates an order for a quantity of the product and approves it
+func (f *orderFixture) confirmOrder(t *testing.T, service *ServiceImpl, quantity int) *entities.Order {
+	t.Helper()
+	order := f.createOrder(t, service, quantity)
+	confirmed, err := service.ApproveOrder(context.Background(), order.ID.String(), f.user.String())
+	require.NoError(t, err)
+	require.Equal(t, entities.OrderStatusConfirmed, confirmed.Status)
+	return confirmed
+}
+
+func TestServiceImpl_CreateOrder(t *testing.T) {
+	ctx := context.Background()
 
 	t.Run("successful order creation", func(t *testing.T) {
-		// Setup mocks
-		mockCustomerRepo.On("GetByID", ctx, customerID).Return(customer, nil)
-		mockAddressRepo.On("GetByID", ctx, shippingAddressID).Return(shippingAddress, nil)
-		mockAddressRepo.On("GetByID", ctx, billingAddressID).Return(billingAddress, nil)
-		mockOrderRepo.On("GenerateUniqueOrderNumber", ctx).Return("2024-001234", nil)
-		mockProductService.On("GetProduct", ctx, productID.String()).Return(product, nil)
-		mockInventoryService.On("CheckAvailability", ctx, mock.AnythingOfType("*order.CheckInventoryRequest")).Return(&CheckInventoryResponse{
-			Available: true,
-			Items: []CheckInventoryItemResponse{
-				{
-					ProductID:    productID.String(),
-					RequestedQty: 2,
-					AvailableQty: 100,
-					CanFulfill:   true,
-					UnitPrice:    decimal.NewFromFloat(50.00),
-					TotalValue:   decimal.NewFromFloat(100.00),
-				},
-			},
-		}, nil)
-		mockTaxCalculator.On("CalculateTax", ctx, mock.AnythingOfType("*order.TaxCalculationRequest")).Return(&TaxCalculationResponse{
-			TaxAmount: decimal.NewFromFloat(8.00),
-		}, nil)
-		mockShippingCalculator.On("CalculateShipping", ctx, mock.AnythingOfType("*order.ShippingCalculationRequest")).Return(&ShippingCalculationResponse{
-			Cost: decimal.NewFromFloat(10.00),
-		}, nil)
-
-		// Mock transaction
-		mockTx := &struct{}{}
-		mockOrderRepo.On("BeginTransaction", ctx).Return(mockTx, nil)
-		mockOrderRepo.On("Create", ctx, mock.AnythingOfType("*entities.Order")).Return(nil)
-		mockOrderItemRepo.On("Create", ctx, mock.AnythingOfType("*entities.OrderItem")).Return(nil)
-		mockInventoryService.On("ReserveInventory", ctx, mock.AnythingOfType("string"), mock.AnythingOfType("[]order.ReserveItemRequest")).Return(nil)
-		mockOrderRepo.On("UpdateCreditUsed", ctx, customerID, decimal.NewFromFloat(118.00)).Return(nil)
-		mockOrderRepo.On("Commit", ctx).Return(nil)
-
-		// Execute
-		order, err := service.CreateOrder(ctx, req)
-
-		// Assert
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+
+		order, err := service.CreateOrder(ctx, fixture.createRequest(2))
 		require.NoError(t, err)
-		assert.NotNil(t, order)
-		assert.Equal(t, entities.OrderStatusDraft, order.Status)
-		assert.Equal(t, decimal.NewFromFloat(118.00), order.TotalAmount)
-		assert.Equal(t, customerID, order.CustomerID)
-		assert.Equal(t, "2024-001234", order.OrderNumber)
-
-		// Verify all mocks were called
-		mockCustomerRepo.AssertExpectations(t)
-		mockAddressRepo.AssertExpectations(t)
-		mockOrderRepo.AssertExpectations(t)
-		mockOrderItemRepo.AssertExpectations(t)
-		mockProductService.AssertExpectations(t)
-		mockInventoryService.AssertExpectations(t)
-		mockTaxCalculator.AssertExpectations(t)
-		mockShippingCalculator.AssertExpectations(t)
+
+		assert.Equal(t, entities.OrderStatusPending, order.Status)
+		assert.Equal(t, entities.PaymentStatusPending, order.PaymentStatus)
+		assert.NotEmpty(t, order.OrderNumber)
+		assert.True(t, decimal.NewFromInt(100).Equal(order.TotalAmount), "total is %s", order.TotalAmount)
+
+		require.Contains(t, store.orders, order.ID)
+		require.Len(t, store.items[order.ID], 1)
+		assert.Equal(t, 2, store.items[order.ID][0].Quantity)
+		assert.Empty(t, store.untransacted())
+
+		history := store.historyOf(order.ID)
+		require.Len(t, history, 1)
+		assert.Equal(t, string(entities.OrderStatusPending), history[0].ToStatus)
+		assert.Equal(t, fixture.user, *history[0].ChangedBy)
+
+		// Creating an order reserves nothing until it is approved
+		assert.Zero(t, store.reserved(fixture.product.ID, fixture.warehouse.ID))
 	})
 
 	t.Run("customer not found", func(t *testing.T) {
-		// Setup mocks
-		mockCustomerRepo.On("GetByID", ctx, customerID).Return(nil, ErrCustomerNotFound)
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
 
-		// Execute
-		order, err := service.CreateOrder(ctx, req)
+		req := fixture.createRequest(1)
+		req.CustomerID = uuid.New().String()
 
-		// Assert
-		require.Error(t, err)
-		assert.Nil(t, order)
-		assert.Contains(t, err.Error(), "customer not found")
-
-		mockCustomerRepo.AssertExpectations(t)
+		_, err := service.CreateOrder(ctx, req)
+		assert.ErrorIs(t, err, ErrCustomerNotFound)
+		assert.Empty(t, store.orders)
 	})
 
 	t.Run("invalid customer ID", func(t *testing.T) {
-		// Setup
-		invalidReq := *req
-		invalidReq.CustomerID = "invalid-uuid"
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
 
-		// Execute
-		order, err := service.CreateOrder(ctx, &invalidReq)
+		req := fixture.createRequest(1)
+		req.CustomerID = "invalid-uuid"
 
-		// Assert
-		require.Error(t, err)
-		assert.Nil(t, order)
-		assert.Contains(t, err.Error(), "invalid customer ID")
+		_, err := service.CreateOrder(ctx, req)
+		assert.ErrorContains(t, err, "invalid customer ID")
+		assert.Empty(t, store.orders)
 	})
 
-	t.Run("insufficient inventory", func(t *testing.T) {
-		// Setup mocks
-		mockCustomerRepo.On("GetByID", ctx, customerID).Return(customer, nil)
-		mockAddressRepo.On("GetByID", ctx, shippingAddressID).Return(shippingAddress, nil)
-		mockAddressRepo.On("GetByID", ctx, billingAddressID).Return(billingAddress, nil)
-		mockOrderRepo.On("GenerateUniqueOrderNumber", ctx).Return("2024-001234", nil)
-		mockProductService.On("GetProduct", ctx, productID.String()).Return(product, nil)
-		mockInventoryService.On("CheckAvailability", ctx, mock.AnythingOfType("*order.CheckInventoryRequest")).Return(&CheckInventoryResponse{
-			Available: false,
-		}, nil)
-
-		// Execute
-		order, err := service.CreateOrder(ctx, req)
-
-		// Assert
-		require.Error(t, err)
-		assert.Nil(t, order)
-		assert.Contains(t, err.Error(), "inventory check failed")
-
-		mockCustomerRepo.AssertExpectations(t)
-		mockAddressRepo.AssertExpectations(t)
-		mockProductService.AssertExpectations(t)
-		mockInventoryService.AssertExpectations(t)
-	})
+	t.Run("order without items", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+
+		req := fixture.createRequest(1)
+		req.Items = nil
 
-	t.Run("customer credit limit exceeded", func(t *testing.T) {
-		// Setup customer with low credit limit
-		lowCreditCustomer := CreateTestCustomer(customerID)
-		lowCreditCustomer.CreditLimit = decimal.NewFromFloat(50.00)
-
-		// Setup mocks
-		mockCustomerRepo.On("GetByID", ctx, customerID).Return(lowCreditCustomer, nil)
-		mockAddressRepo.On("GetByID", ctx, shippingAddressID).Return(shippingAddress, nil)
-		mockAddressRepo.On("GetByID", ctx, billingAddressID).Return(billingAddress, nil)
-		mockOrderRepo.On("GenerateUniqueOrderNumber", ctx).Return("2024-001234", nil)
-		mockProductService.On("GetProduct", ctx, productID.String()).Return(product, nil)
-		mockInventoryService.On("CheckAvailability", ctx, mock.AnythingOfType("*order.CheckInventoryRequest")).Return(&CheckInventoryResponse{
-			Available: true,
-		}, nil)
-		mockTaxCalculator.On("CalculateTax", ctx, mock.AnythingOfType("*order.TaxCalculationRequest")).Return(&TaxCalculationResponse{
-			TaxAmount: decimal.NewFromFloat(8.00),
-		}, nil)
-		mockShippingCalculator.On("CalculateShipping", ctx, mock.AnythingOfType("*order.ShippingCalculationRequest")).Return(&ShippingCalculationResponse{
-			Cost: decimal.NewFromFloat(10.00),
-		}, nil)
-
-		// Execute
-		order, err := service.CreateOrder(ctx, req)
-
-		// Assert
-		require.Error(t, err)
-		assert.Nil(t, order)
-		assert.Contains(t, err.Error(), "insufficient customer credit")
-
-		mockCustomerRepo.AssertExpectations(t)
+		_, err := service.CreateOrder(ctx, req)
+		assert.ErrorIs(t, err, ErrInvalidQuantity)
+		assert.Empty(t, store.orders)
 	})
 }
 
 func TestServiceImpl_GetOrder(t *testing.T) {
 	ctx := context.Background()
-	mockOrderRepo := &MockOrderRepository{}
-	mockOrderItemRepo := &MockOrderItemRepository{}
-	mockCustomerRepo := &MockCustomerRepository{}
-	mockAddressRepo := &MockOrderAddressRepository{}
-
-	service := &ServiceImpl{
-		orderRepo:     mockOrderRepo,
-		orderItemRepo: mockOrderItemRepo,
-		customerRepo:  mockCustomerRepo,
-		addressRepo:   mockAddressRepo,
-	}
-
-	orderID := uuid.New()
-	customerID := uuid.New()
-	shippingAddressID := uuid.New()
-	productID := uuid.New()
-
-	order := CreateTestOrder(orderID)
-	order.CustomerID = customerID
-	order.ShippingAddressID = shippingAddressID
-
-	customer := CreateTestCustomer(customerID)
-	shippingAddress := CreateTestOrderAddress(shippingAddressID, customerID, "SHIPPING")
-	orderItem := CreateTestOrderItem(uuid.New(), orderID, productID)
+	service, store := newTestService(t)
+	fixture := newOrderFixture(store, decimal.Zero)
+	created := fixture.createOrder(t, service, 3)
 
-	t.Run("successful order retrieval", func(t *testing.T) {
-		// Setup mocks
-		mockOrderRepo.On("GetByID", ctx, orderID).Return(order, nil)
-		mockOrderItemRepo.On("GetByOrderID", ctx, orderID).Return([]*entities.OrderItem{orderItem}, nil)
-		mockCustomerRepo.On("GetByID", ctx, customerID).Return(customer, nil)
-		mockAddressRepo.On("GetByID", ctx, shippingAddressID).Return(shippingAddress, nil)
-
-		// Execute
-		result, err := service.GetOrder(ctx, orderID.String())
-
-		// Assert
+	t.Run("successful retrieval", func(t *testing.T) {
+		order, err := service.GetOrder(ctx, created.ID.String())
 		require.NoError(t, err)
-		assert.NotNil(t, result)
-		assert.Equal(t, orderID, result.ID)
-		assert.Equal(t, customerID, result.Customer.ID)
-		assert.Len(t, result.Items, 1)
-		assert.Equal(t, shippingAddressID, result.ShippingAddress.ID)
-
-		mockOrderRepo.AssertExpectations(t)
-		mockOrderItemRepo.AssertExpectations(t)
-		mockCustomerRepo.AssertExpectations(t)
-		mockAddressRepo.AssertExpectations(t)
+
+		assert.Equal(t, created.OrderNumber, order.OrderNumber)
+		require.Len(t, order.Items, 1)
+		assert.Equal(t, 3, order.Items[0].Quantity)
+		require.NotNil(t, order.Customer)
+		assert.Equal(t, fixture.customer.ID, order.Customer.ID)
+		require.NotNil(t, order.ShippingAddress)
+		assert.Equal(t, fixture.address.ID, order.ShippingAddress.ID)
 	})
 
 	t.Run("order not found", func(t *testing.T) {
-		// Setup mocks
-		mockOrderRepo.On("GetByID", ctx, orderID).Return(nil, ErrOrderNotFound)
-
-		// Execute
-		result, err := service.GetOrder(ctx, orderID.String())
-
-		// Assert
-		require.Error(t, err)
-		assert.Nil(t, result)
-		assert.Equal(t, ErrOrderNotFound, err)
-
-		mockOrderRepo.AssertExpectations(t)
+		_, err := service.GetOrder(ctx, uuid.New().String())
+		assert.ErrorIs(t, err, ErrOrderNotFound)
 	})
 
 	t.Run("invalid order ID", func(t *testing.T) {
-		// Execute
-		result, err := service.GetOrder(ctx, "invalid-uuid")
-
-		// Assert
-		require.Error(t, err)
-		assert.Nil(t, result)
-		assert.Contains(t, err.Error(), "invalid order ID")
+		_, err := service.GetOrder(ctx, "invalid-uuid")
+		assert.ErrorContains(t, err, "invalid order ID")
 	})
 }
 
 func TestServiceImpl_UpdateOrderStatus(t *testing.T) {
 	ctx := context.Background()
-	mockOrderRepo := &MockOrderRepository{}
-	mockOrderItemRepo := &MockOrderItemRepository{}
-	mockCustomerRepo := &MockCustomerRepository{}
-	mockAddressRepo := &MockOrderAddressRepository{}
-	mockInventoryService := &MockInventoryService{}
-	mockNotificationService := &MockNotificationService{}
-
-	service := &ServiceImpl{
-		orderRepo:           mockOrderRepo,
-		orderItemRepo:       mockOrderItemRepo,
-		customerRepo:        mockCustomerRepo,
-		addressRepo:         mockAddressRepo,
-		inventoryService:    mockInventoryService,
-		notificationService: mockNotificationService,
-	}
 
-	orderID := uuid.New()
-	customerID := uuid.New()
-	updatedByID := uuid.New()
+	t.Run("refunded and returned are derived, not set", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		order := fixture.confirmOrder(t, service, 1)
+		store.resetWrites()
+
+		for _, status := range []entities.OrderStatus{entities.OrderStatusRefunded, entities.OrderStatusReturned} {
+			_, err := service.UpdateOrderStatus(ctx, order.ID.String(), &UpdateOrderStatusRequest{
+				Status:    status,
+				UpdatedBy: fixture.user.String(),
+			})
+			assert.ErrorIs(t, err, ErrInvalidStatusTransition, status)
+		}
+		assert.Empty(t, store.writes)
+	})
 
-	order := CreateTestOrder(orderID)
-	order.Status = entities.OrderStatusPending
-	order.CustomerID = customerID
+	t.Run("unapproved order cannot skip approval", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		order := fixture.createOrder(t, service, 1)
+		store.resetWrites()
+
+		_, err := service.UpdateOrderStatus(ctx, order.ID.String(), &UpdateOrderStatusRequest{
+			Status:    entities.OrderStatusProcessing,
+			UpdatedBy: fixture.user.String(),
+		})
+		assert.ErrorIs(t, err, ErrInvalidStatusTransition)
+		assert.Empty(t, store.writes)
+		assert.Equal(t, entities.OrderStatusPending, store.orders[order.ID].Status)
+	})
 
-	customer := CreateTestCustomer(customerID)
+	t.Run("confirming an unapproved order approves it", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		order := fixture.createOrder(t, service, 4)
 
-	req := &UpdateOrderStatusRequest{
-		Status:    entities.OrderStatusConfirmed,
-		Reason:    "Order approved",
-		Notify:    true,
-		UpdatedBy: updatedByID.String(),
-	}
+		confirmed, err := service.UpdateOrderStatus(ctx, order.ID.String(), &UpdateOrderStatusRequest{
+			Status:    entities.OrderStatusConfirmed,
+			UpdatedBy: fixture.user.String(),
+		})
+		require.NoError(t, err)
 
-	t.Run("successful status update", func(t *testing.T) {
-		// Setup mocks
-		mockOrderRepo.On("GetByID", ctx, orderID).Return(order, nil)
-		mockOrderItemRepo.On("GetByOrderID", ctx, orderID).Return([]*entities.OrderItem{}, nil)
-		mockCustomerRepo.On("GetByID", ctx, customerID).Return(customer, nil)
-		mockAddressRepo.On("GetByID", ctx, mock.AnythingOfType("uuid.UUID")).Return(&entities.OrderAddress{}, nil).Maybe()
-		mockOrderRepo.On("Update", ctx, mock.AnythingOfType("*entities.Order")).Return(nil)
-		mockNotificationService.On("SendOrderNotification", ctx, mock.AnythingOfType("*order.OrderNotificationRequest")).Return(nil)
+		assert.Equal(t, entities.OrderStatusConfirmed, confirmed.Status)
+		require.NotNil(t, confirmed.ApprovedBy)
+		assert.Equal(t, fixture.user, *confirmed.ApprovedBy)
+		assert.Equal(t, 4, store.reserved(fixture.product.ID, fixture.warehouse.ID))
+		assert.Equal(t, map[uuid.UUID]int{fixture.warehouse.ID: 4}, store.allocated(order.ID))
+		assert.Equal(t, []uuid.UUID{order.ID}, store.locked, "the order is locked while it is approved")
+	})
 
-		// Execute
-		result, err := service.UpdateOrderStatus(ctx, orderID.String(), req)
+	t.Run("approved order moves on", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		order := fixture.confirmOrder(t, service, 1)
 
-		// Assert
+		processing, err := service.UpdateOrderStatus(ctx, order.ID.String(), &UpdateOrderStatusRequest{
+			Status:    entities.OrderStatusProcessing,
+			Reason:    "picking",
+			UpdatedBy: fixture.user.String(),
+		})
 		require.NoError(t, err)
-		assert.NotNil(t, result)
-		assert.Equal(t, entities.OrderStatusConfirmed, result.Status)
 
-		mockOrderRepo.AssertExpectations(t)
-		mockCustomerRepo.AssertExpectations(t)
-		mockNotificationService.AssertExpectations(t)
+		assert.Equal(t, entities.OrderStatusProcessing, processing.Status)
+		history := store.historyOf(order.ID)
+		last := history[len(history)-1]
+		assert.Equal(t, string(entities.OrderStatusConfirmed), *last.FromStatus)
+		assert.Equal(t, string(entities.OrderStatusProcessing), last.ToStatus)
+		assert.Equal(t, "picking", last.Reason)
 	})
 
 	t.Run("invalid status transition", func(t *testing.T) {
-		// Setup
-		invalidReq := *req
-		invalidReq.Status = entities.OrderStatusDelivered // Can't go from Pending to Delivered
-
-		// Setup mocks
-		mockOrderRepo.On("GetByID", ctx, orderID).Return(order, nil)
-		mockOrderItemRepo.On("GetByOrderID", ctx, orderID).Return([]*entities.OrderItem{}, nil)
-		mockCustomerRepo.On("GetByID", ctx, customerID).Return(customer, nil)
-		mockAddressRepo.On("GetByID", ctx, mock.AnythingOfType("uuid.UUID")).Return(&entities.OrderAddress{}, nil).Maybe()
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		order := fixture.createOrder(t, service, 1)
+
+		_, err := service.UpdateOrderStatus(ctx, order.ID.String(), &UpdateOrderStatusRequest{
+			Status:    entities.OrderStatusDraft,
+			UpdatedBy: fixture.user.String(),
+		})
+		assert.ErrorIs(t, err, ErrInvalidStatusTransition)
+	})
+}
 
-		// Execute
-		result, err := service.UpdateOrderStatus(ctx, orderID.String(), &invalidReq)
+func TestServiceImpl_CancelOrder(t *testing.T) {
+	ctx := context.Background()
 
-		// Assert
-		require.Error(t, err)
-		assert.Nil(t, result)
-		assert.Contains(t, err.Error(), "invalid status transition")
+	t.Run("cancelling releases the reservations", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		order := fixture.confirmOrder(t, service, 5)
+		require.Equal(t, 5, store.reserved(fixture.product.ID, fixture.warehouse.ID))
+		store.resetWrites()
+
+		cancelled, err := service.CancelOrder(ctx, order.ID.String(), &CancelOrderRequest{
+			Reason:      "customer request",
+			CancelledBy: fixture.user.String(),
+		})
+		require.NoError(t, err)
 
-		mockOrderRepo.AssertExpectations(t)
+		assert.Equal(t, entities.OrderStatusCancelled, cancelled.Status)
+		assert.Zero(t, store.reserved(fixture.product.ID, fixture.warehouse.ID))
+		assert.Empty(t, store.allocations[order.ID])
+		assert.Equal(t, []uuid.UUID{order.ID}, store.locked)
+		assert.Empty(t, store.untransacted())
 	})
 
-	t.Run("order shipped - release inventory", func(t *testing.T) {
-		// Setup
-		shipReq := *req
-		shipReq.Status = entities.OrderStatusShipped
-
-		// Setup mocks
-		mockOrderRepo.On("GetByID", ctx, orderID).Return(order, nil)
-		mockOrderItemRepo.On("GetByOrderID", ctx, orderID).Return([]*entities.OrderItem{}, nil)
-		mockCustomerRepo.On("GetByID", ctx, customerID).Return(customer, nil)
-		mockAddressRepo.On("GetByID", ctx, mock.AnythingOfType("uuid.UUID")).Return(&entities.OrderAddress{}, nil).Maybe()
-		mockOrderRepo.On("Update", ctx, mock.AnythingOfType("*entities.Order")).Return(nil)
-		mockInventoryService.On("ReleaseReservation", ctx, orderID.String()).Return(nil)
-		mockNotificationService.On("SendOrderNotification", ctx, mock.AnythingOfType("*order.OrderNotificationRequest")).Return(nil)
-
-		// Execute
-		result, err := service.UpdateOrderStatus(ctx, orderID.String(), &shipReq)
+	t.Run("cancelled order cannot be cancelled again", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		order := fixture.createOrder(t, service, 1)
 
-		// Assert
+		_, err := service.CancelOrder(ctx, order.ID.String(), &CancelOrderRequest{CancelledBy: fixture.user.String()})
 		require.NoError(t, err)
-		assert.NotNil(t, result)
-		assert.Equal(t, entities.OrderStatusShipped, result.Status)
 
-		mockOrderRepo.AssertExpectations(t)
-		mockInventoryService.AssertExpectations(t)
-		mockNotificationService.AssertExpectations(t)
+		_, err = service.CancelOrder(ctx, order.ID.String(), &CancelOrderRequest{CancelledBy: fixture.user.String()})
+		assert.ErrorIs(t, err, ErrOrderCannotBeCancelled)
 	})
 }
 
-func TestServiceImpl_CancelOrder(t *testing.T) {
+func TestServiceImpl_UpdateOrder(t *testing.T) {
 	ctx := context.Background()
-	mockOrderRepo := &MockOrderRepository{}
-	mockOrderItemRepo := &MockOrderItemRepository{}
-	mockCustomerRepo := &MockCustomerRepository{}
-	mockAddressRepo := &MockOrderAddressRepository{}
-	mockInventoryService := &MockInventoryService{}
-	mockPaymentService := &MockPaymentService{}
-	mockNotificationService := &MockNotificationService{}
-
-	service := &ServiceImpl{
-		orderRepo:           mockOrderRepo,
-		orderItemRepo:       mockOrderItemRepo,
-		customerRepo:        mockCustomerRepo,
-		addressRepo:         mockAddressRepo,
-		inventoryService:    mockInventoryService,
-		paymentService:      mockPaymentService,
-		notificationService: mockNotificationService,
-	}
-
-	orderID := uuid.New()
-	customerID := uuid.New()
-	cancelledByID := uuid.New()
-
-	order := CreateTestOrder(orderID)
-	order.Status = entities.OrderStatusPending
-	order.CustomerID = customerID
 
-	customer := CreateTestCustomer(customerID)
+	t.Run("order is locked and saved in one transaction", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		order := fixture.createOrder(t, service, 2)
+		store.resetWrites()
 
-	req := &CancelOrderRequest{
-		Reason:      "Customer requested cancellation",
-		Refund:      true,
-		Notify:      true,
-		CancelledBy: cancelledByID.String(),
-	}
-
-	t.Run("successful order cancellation", func(t *testing.T) {
-		// Setup mocks
-		mockOrderRepo.On("GetByID", ctx, orderID).Return(order, nil)
-		mockOrderItemRepo.On("GetByOrderID", ctx, orderID).Return([]*entities.OrderItem{}, nil)
-		mockCustomerRepo.On("GetByID", ctx, customerID).Return(customer, nil)
-		mockAddressRepo.On("GetByID", ctx, mock.AnythingOfType("uuid.UUID")).Return(&entities.OrderAddress{}, nil).Maybe()
-		mockPaymentService.On("ProcessRefund", ctx, mock.AnythingOfType("*order.RefundProcessRequest")).Return(&RefundResponse{
-			Success:       true,
-			TransactionID: "refund-123",
-			Amount:        decimal.Zero,
-			Status:        "completed",
-		}, nil)
-		mockInventoryService.On("ReleaseReservation", ctx, orderID.String()).Return(nil)
-		mockCustomerRepo.On("UpdateCreditUsed", ctx, customerID, decimal.NewFromFloat(-118.00)).Return(nil)
-		mockOrderRepo.On("Update", ctx, mock.AnythingOfType("*entities.Order")).Return(nil)
-		mockNotificationService.On("SendOrderNotification", ctx, mock.AnythingOfType("*order.OrderNotificationRequest")).Return(nil)
-
-		// Execute
-		result, err := service.CancelOrder(ctx, orderID.String(), req)
-
-		// Assert
+		shipping := decimal.NewFromInt(15)
+		updated, err := service.UpdateOrder(ctx, order.ID.String(), &UpdateOrderRequest{ShippingAmount: &shipping})
 		require.NoError(t, err)
-		assert.NotNil(t, result)
-		assert.Equal(t, entities.OrderStatusCancelled, result.Status)
-
-		mockOrderRepo.AssertExpectations(t)
-		mockPaymentService.AssertExpectations(t)
-		mockInventoryService.AssertExpectations(t)
-		mockCustomerRepo.AssertExpectations(t)
-		mockNotificationService.AssertExpectations(t)
-	})
 
-	t.Run("order cannot be cancelled", func(t *testing.T) {
-		// Setup order in terminal status
-		completedOrder := CreateTestOrder(orderID)
-		completedOrder.Status = entities.OrderStatusDelivered
-
-		// Setup mocks
-		mockOrderRepo.On("GetByID", ctx, orderID).Return(completedOrder, nil)
-
-		// Execute
-		result, err := service.CancelOrder(ctx, orderID.String(), req)
-
-		// Assert
-		require.Error(t, err)
-		assert.Nil(t, result)
-		assert.Equal(t, ErrOrderCannotBeCancelled, err)
-
-		mockOrderRepo.AssertExpectations(t)
+		assert.True(t, decimal.NewFromInt(115).Equal(updated.TotalAmount), "total is %s", updated.TotalAmount)
+		assert.True(t, decimal.NewFromInt(115).Equal(store.orders[order.ID].TotalAmount))
+		assert.Equal(t, []uuid.UUID{order.ID}, store.locked)
+		assert.NotEmpty(t, store.ops())
+		assert.Empty(t, store.untransacted())
 	})
 }
 
-func TestServiceImpl_ValidateOrder(t *testing.T) {
+func TestServiceImpl_ShipOrder(t *testing.T) {
 	ctx := context.Background()
-	mockOrderRepo := &MockOrderRepository{}
-	mockOrderItemRepo := &MockOrderItemRepository{}
-	mockCustomerRepo := &MockCustomerRepository{}
-	mockAddressRepo := &MockOrderAddressRepository{}
-	mockInventoryService := &MockInventoryService{}
-
-	service := &ServiceImpl{
-		orderRepo:        mockOrderRepo,
-		orderItemRepo:    mockOrderItemRepo,
-		customerRepo:     mockCustomerRepo,
-		addressRepo:      mockAddressRepo,
-		inventoryService: mockInventoryService,
-	}
-
-	orderID := uuid.New()
-	customerID := uuid.New()
-	productID := uuid.New()
 
-	order := CreateTestOrder(orderID)
-	order.CustomerID = customerID
-
-	customer := CreateTestCustomer(customerID)
-	customer.CreditLimit = decimal.NewFromFloat(200.00) // Higher than order total
+	t.Run("shipments of an order are numbered in turn", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		order := fixture.confirmOrder(t, service, 5)
+		_, err := service.ProcessOrder(ctx, order.ID.String())
+		require.NoError(t, err)
+		store.resetWrites()
 
-	orderItem := CreateTestOrderItem(uuid.New(), orderID, productID)
+		partial, err := service.PartialShipOrder(ctx, order.ID.String(), &PartialShipOrderRequest{
+			Items:     []ShipItemRequest{{ItemID: order.Items[0].ID.String(), Quantity: 2}},
+			ShippedBy: fixture.user.String(),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, entities.OrderStatusPartiallyShipped, partial.Status)
 
-	t.Run("valid order", func(t *testing.T) {
-		// Setup mocks
-		mockOrderRepo.On("GetByID", ctx, orderID).Return(order, nil)
-		mockOrderItemRepo.On("GetByOrderID", ctx, orderID).Return([]*entities.OrderItem{orderItem}, nil)
-		mockCustomerRepo.On("GetByID", ctx, customerID).Return(customer, nil)
-		mockInventoryService.On("CheckAvailability", ctx, mock.AnythingOfType("*order.CheckInventoryRequest")).Return(&CheckInventoryResponse{
-			Available: true,
-		}, nil)
+		shipped, err := service.ShipOrder(ctx, order.ID.String(), &ShipOrderRequest{ShippedBy: fixture.user.String()})
+		require.NoError(t, err)
+		assert.Equal(t, entities.OrderStatusShipped, shipped.Status)
 
-		// Execute
-		result, err := service.ValidateOrder(ctx, orderID.String())
+		require.Len(t, store.shipments, 2)
+		assert.Equal(t, order.OrderNumber+"-S1", store.shipments[0].ShipmentNumber)
+		assert.Equal(t, order.OrderNumber+"-S2", store.shipments[1].ShipmentNumber)
+		assert.Equal(t, []uuid.UUID{order.ID, order.ID}, store.locked)
+		assert.Empty(t, store.untransacted())
 
-		// Assert
-		require.NoError(t, err)
-		assert.NotNil(t, result)
-		assert.True(t, result.IsValid)
-		assert.Empty(t, result.Errors)
-
-		mockOrderRepo.AssertExpectations(t)
-		mockOrderItemRepo.AssertExpectations(t)
-		mockCustomerRepo.AssertExpectations(t)
-		mockInventoryService.AssertExpectations(t)
+		assert.Zero(t, store.reserved(fixture.product.ID, fixture.warehouse.ID))
+		assert.Equal(t, 95, store.level(fixture.product.ID, fixture.warehouse.ID).QuantityOnHand)
 	})
 
-	t.Run("insufficient customer credit", func(t *testing.T) {
-		// Setup customer with low credit limit
-		lowCreditCustomer := CreateTestCustomer(customerID)
-		lowCreditCustomer.CreditLimit = decimal.NewFromFloat(50.00)
+	t.Run("orders ship only once they are processing", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		order := fixture.confirmOrder(t, service, 5)
+		store.resetWrites()
 
-		// Setup mocks
-		mockOrderRepo.On("GetByID", ctx, orderID).Return(order, nil)
-		mockOrderItemRepo.On("GetByOrderID", ctx, orderID).Return([]*entities.OrderItem{orderItem}, nil)
-		mockCustomerRepo.On("GetByID", ctx, customerID).Return(lowCreditCustomer, nil)
-		mockInventoryService.On("CheckAvailability", ctx, mock.AnythingOfType("*order.CheckInventoryRequest")).Return(&CheckInventoryResponse{
-			Available: true,
-		}, nil)
+		_, err := service.ShipOrder(ctx, order.ID.String(), &ShipOrderRequest{ShippedBy: fixture.user.String()})
+		assert.ErrorIs(t, err, ErrOrderCannotBeShipped)
+		assert.Empty(t, store.ops())
+	})
+}
 
-		// Execute
-		result, err := service.ValidateOrder(ctx, orderID.String())
+func TestServiceImpl_ReturnOrderItems(t *testing.T) {
+	ctx := context.Background()
 
-		// Assert
+	t.Run("returned units are refunded at what was paid for them", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		order := fixture.confirmOrder(t, service, 5)
+		_, err := service.ProcessOrder(ctx, order.ID.String())
+		require.NoError(t, err)
+		_, err = service.ShipOrder(ctx, order.ID.String(), &ShipOrderRequest{ShippedBy: fixture.user.String()})
+		require.NoError(t, err)
+		_, err = service.RecordPayment(ctx, fixture.paymentRequest(250, allocation(order, 250)))
+		require.NoError(t, err)
+		store.items[order.ID][0].DiscountAmount = decimal.NewFromInt(5)
+		store.resetWrites()
+
+		returned, err := service.ReturnOrderItems(ctx, order.ID.String(), &ReturnItemsRequest{
+			Items:      []ReturnItemRequest{{ItemID: order.Items[0].ID.String(), Quantity: 2}},
+			Reason:     "damaged in transit",
+			Refund:     true,
+			ReturnedBy: fixture.user.String(),
+		})
 		require.NoError(t, err)
-		assert.NotNil(t, result)
-		assert.False(t, result.IsValid)
-		assert.NotEmpty(t, result.Errors)
-		assert.Contains(t, result.Errors[0], "insufficient customer credit")
 
-		mockOrderRepo.AssertExpectations(t)
-		mockCustomerRepo.AssertExpectations(t)
+		assert.Equal(t, 2, returned.Items[0].QuantityReturned)
+		assert.Equal(t, 2, returned.Items[0].QuantityRefunded)
+		assert.True(t, decimal.NewFromInt(90).Equal(store.orders[order.ID].RefundedAmount), "refunded %s", store.orders[order.ID].RefundedAmount)
+		assert.Equal(t, []uuid.UUID{order.ID}, store.locked)
+		assert.Empty(t, store.untransacted())
 	})
 
-	t.Run("inventory unavailable", func(t *testing.T) {
-		// Setup mocks
-		mockOrderRepo.On("GetByID", ctx, orderID).Return(order, nil)
-		mockOrderItemRepo.On("GetByOrderID", ctx, orderID).Return([]*entities.OrderItem{orderItem}, nil)
-		mockCustomerRepo.On("GetByID", ctx, customerID).Return(customer, nil)
-		mockInventoryService.On("CheckAvailability", ctx, mock.AnythingOfType("*order.CheckInventoryRequest")).Return(&CheckInventoryResponse{
-			Available: false,
-		}, nil)
+	t.Run("unshipped orders are not returned", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		order := fixture.confirmOrder(t, service, 5)
+		store.resetWrites()
+
+		_, err := service.ReturnOrderItems(ctx, order.ID.String(), &ReturnItemsRequest{
+			Items:      []ReturnItemRequest{{ItemID: order.Items[0].ID.String(), Quantity: 1}},
+			Reason:     "changed mind",
+			ReturnedBy: fixture.user.String(),
+		})
+		assert.ErrorIs(t, err, ErrOrderCannotBeReturned)
+		assert.Empty(t, store.ops())
+	})
+}
 
-		// Execute
-		result, err := service.ValidateOrder(ctx, orderID.String())
+func TestServiceImpl_ProcessPayment(t *testing.T) {
+	ctx := context.Background()
 
-		// Assert
+	t.Run("payments are checked against the locked order", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		order := fixture.confirmOrder(t, service, 5)
+		store.resetWrites()
+
+		paid, err := service.ProcessPayment(ctx, order.ID.String(), &ProcessPaymentRequest{
+			Amount:        decimal.NewFromInt(200),
+			PaymentMethod: string(entities.PaymentMethodBankTransfer),
+			PaymentBy:     fixture.user.String(),
+		})
 		require.NoError(t, err)
-		assert.NotNil(t, result)
-		assert.False(t, result.IsValid)
-		assert.Contains(t, result.Errors, "Insufficient inventory for some items")
-
-		mockOrderRepo.AssertExpectations(t)
-		mockInventoryService.AssertExpectations(t)
+		assert.True(t, decimal.NewFromInt(50).Equal(paid.GetOutstandingBalance()))
+		assert.Equal(t, []uuid.UUID{order.ID}, store.locked)
+		assert.Empty(t, store.untransacted())
+		store.resetWrites()
+
+		_, err = service.ProcessPayment(ctx, order.ID.String(), &ProcessPaymentRequest{
+			Amount:        decimal.NewFromInt(100),
+			PaymentMethod: string(entities.PaymentMethodBankTransfer),
+			PaymentBy:     fixture.user.String(),
+		})
+		assert.ErrorIs(t, err, ErrInvalidPaymentAmount)
+		assert.Empty(t, store.ops())
+		assert.True(t, decimal.NewFromInt(200).Equal(store.orders[order.ID].PaidAmount))
 	})
 }
 
-func TestServiceImpl_ListOrders(t *testing.T) {
+func TestServiceImpl_PartialRefundOrder(t *testing.T) {
 	ctx := context.Background()
-	mockOrderRepo := &MockOrderRepository{}
-	mockOrderItemRepo := &MockOrderItemRepository{}
-	mockCustomerRepo := &MockCustomerRepository{}
-
-	service := &ServiceImpl{
-		orderRepo:     mockOrderRepo,
-		orderItemRepo: mockOrderItemRepo,
-		customerRepo:  mockCustomerRepo,
-	}
 
-	req := &ListOrdersRequest{
-		Page:  1,
-		Limit: 20,
-	}
-
-	orders := []*entities.Order{
-		CreateTestOrder(uuid.New()),
-		CreateTestOrder(uuid.New()),
+	refundLine := func(order *entities.Order, quantity int) *PartialRefundOrderRequest {
+		return &PartialRefundOrderRequest{
+			Items:      []RefundItemRequest{{ItemID: order.Items[0].ID.String(), Quantity: quantity}},
+			Reason:     "damaged",
+			RefundedBy: uuid.NewString(),
+		}
 	}
 
-	t.Run("successful order listing", func(t *testing.T) {
-		// Setup mocks
-		mockOrderRepo.On("List", ctx, mock.AnythingOfType("repositories.OrderFilter")).Return(orders, nil)
-		mockOrderRepo.On("Count", ctx, mock.AnythingOfType("repositories.OrderFilter")).Return(2, nil)
-		mockOrderItemRepo.On("GetByOrderID", ctx, mock.AnythingOfType("uuid.UUID")).Return([]*entities.OrderItem{}, nil)
-		mockCustomerRepo.On("GetByID", ctx, mock.AnythingOfType("uuid.UUID")).Return(&entities.Customer{}, nil)
-
-		// Execute
-		result, err := service.ListOrders(ctx, req)
+	t.Run("units of a line are refunded only once", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		order := fixture.confirmOrder(t, service, 5)
+		_, err := service.RecordPayment(ctx, fixture.paymentRequest(250, allocation(order, 250)))
+		require.NoError(t, err)
+		store.resetWrites()
 
-		// Assert
+		refunded, err := service.PartialRefundOrder(ctx, order.ID.String(), refundLine(order, 3))
 		require.NoError(t, err)
-		assert.NotNil(t, result)
-		assert.Len(t, result.Orders, 2)
-		assert.NotNil(t, result.Pagination)
-		assert.Equal(t, 1, result.Pagination.Page)
-		assert.Equal(t, 20, result.Pagination.Limit)
-		assert.Equal(t, 2, result.Pagination.Total)
-
-		mockOrderRepo.AssertExpectations(t)
+		assert.True(t, decimal.NewFromInt(150).Equal(refunded.RefundedAmount), "refunded %s", refunded.RefundedAmount)
+		assert.Equal(t, 3, store.items[order.ID][0].QuantityRefunded)
+		assert.Equal(t, []uuid.UUID{order.ID}, store.locked)
+		assert.Empty(t, store.untransacted())
+		store.resetWrites()
+
+		_, err = service.PartialRefundOrder(ctx, order.ID.String(), refundLine(order, 3))
+		assert.ErrorIs(t, err, ErrInvalidQuantity)
+		assert.Empty(t, store.ops())
+		assert.Equal(t, 3, store.items[order.ID][0].QuantityRefunded)
+		assert.True(t, decimal.NewFromInt(150).Equal(store.orders[order.ID].RefundedAmount))
 	})
 
-	t.Run("empty order list", func(t *testing.T) {
-		// Setup mocks
-		mockOrderRepo.On("List", ctx, mock.AnythingOfType("repositories.OrderFilter")).Return([]*entities.Order{}, nil)
-		mockOrderRepo.On("Count", ctx, mock.AnythingOfType("repositories.OrderFilter")).Return(0, nil)
-
-		// Execute
-		result, err := service.ListOrders(ctx, req)
+	t.Run("unpaid orders are not refunded", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		order := fixture.confirmOrder(t, service, 5)
+		store.resetWrites()
 
-		// Assert
-		require.NoError(t, err)
-		assert.NotNil(t, result)
-		assert.Empty(t, result.Orders)
-		assert.Equal(t, 0, result.Pagination.Total)
-
-		mockOrderRepo.AssertExpectations(t)
+		_, err := service.PartialRefundOrder(ctx, order.ID.String(), refundLine(order, 1))
+		assert.ErrorIs(t, err, ErrOrderNotPaid)
+		assert.Empty(t, store.ops())
 	})
 }
 
-// Benchmark tests
-func BenchmarkServiceImpl_CreateOrder(b *testing.B) {
+func TestServiceImpl_ListOrders(t *testing.T) {
 	ctx := context.Background()
-	mockOrderRepo := &MockOrderRepository{}
-	mockOrderItemRepo := &MockOrderItemRepository{}
-	mockCustomerRepo := &MockCustomerRepository{}
-	mockAddressRepo := &MockOrderAddressRepository{}
-	mockCompanyRepo := &MockCompanyRepository{}
-	mockAnalyticsRepo := &MockOrderAnalyticsRepository{}
-	mockProductService := &MockProductService{}
-	mockInventoryService := &MockInventoryService{}
-	mockUserService := &MockUserService{}
-	mockNotificationService := &MockNotificationService{}
-	mockPaymentService := &MockPaymentService{}
-	mockTaxCalculator := &MockTaxCalculator{}
-	mockShippingCalculator := &MockShippingCalculator{}
-
-	service := &ServiceImpl{
-		orderRepo:           mockOrderRepo,
-		orderItemRepo:       mockOrderItemRepo,
-		customerRepo:        mockCustomerRepo,
-		addressRepo:         mockAddressRepo,
-		companyRepo:         mockCompanyRepo,
-		analyticsRepo:       mockAnalyticsRepo,
-		productService:      mockProductService,
-		inventoryService:    mockInventoryService,
-		userService:         mockUserService,
-		notificationService: mockNotificationService,
-		paymentService:      mockPaymentService,
-		taxCalculator:       mockTaxCalculator,
-		shippingCalculator:  mockShippingCalculator,
-		defaultCurrency:     "USD",
+	service, store := newTestService(t)
+	fixture := newOrderFixture(store, decimal.Zero)
+	for i := 0; i < 3; i++ {
+		fixture.createOrder(t, service, 1)
 	}
-
-	// Setup test data
-	customerID := uuid.New()
-	shippingAddressID := uuid.New()
-	billingAddressID := uuid.New()
-	productID := uuid.New()
-
-	customer := CreateTestCustomer(customerID)
-	shippingAddress := CreateTestOrderAddress(shippingAddressID, customerID, "SHIPPING")
-	billingAddress := CreateTestOrderAddress(billingAddressID, customerID, "BILLING")
-	product := CreateTestProduct(productID)
-
-	req := &CreateOrderRequest{
-		CustomerID:        customerID.String(),
-		Type:              entities.OrderTypeSales,
-		Priority:          entities.OrderPriorityNormal,
-		ShippingMethod:    entities.ShippingMethodStandard,
-		ShippingAddressID: shippingAddressID.String(),
-		BillingAddressID:  billingAddressID.String(),
-		Currency:          "USD",
-		Items: []CreateOrderItemRequest{
-			{
-				ProductID: productID.String(),
-				Quantity:  2,
-			},
-		},
-	}
-
-	// Setup mocks
-	mockCustomerRepo.On("GetByID", ctx, customerID).Return(customer, nil)
-	mockAddressRepo.On("GetByID", ctx, shippingAddressID).Return(shippingAddress, nil)
-	mockAddressRepo.On("GetByID", ctx, billingAddressID).Return(billingAddress, nil)
-	mockOrderRepo.On("GenerateUniqueOrderNumber", ctx).Return("2024-001234", nil)
-	mockProductService.On("GetProduct", ctx, productID.String()).Return(product, nil)
-	mockInventoryService.On("CheckAvailability", ctx, mock.AnythingOfType("*order.CheckInventoryRequest")).Return(&CheckInventoryResponse{
-		Available: true,
-	}, nil)
-	mockTaxCalculator.On("CalculateTax", ctx, mock.AnythingOfType("*order.TaxCalculationRequest")).Return(&TaxCalculationResponse{
-		TaxAmount: decimal.NewFromFloat(8.00),
-	}, nil)
-	mockShippingCalculator.On("CalculateShipping", ctx, mock.AnythingOfType("*order.ShippingCalculationRequest")).Return(&ShippingCalculationResponse{
-		Cost: decimal.NewFromFloat(10.00),
-	}, nil)
-	mockOrderRepo.On("BeginTransaction", ctx).Return(&struct{}{}, nil)
-	mockOrderRepo.On("Create", ctx, mock.AnythingOfType("*entities.Order")).Return(nil)
-	mockOrderItemRepo.On("Create", ctx, mock.AnythingOfType("*entities.OrderItem")).Return(nil)
-	mockInventoryService.On("ReserveInventory", ctx, mock.AnythingOfType("string"), mock.AnythingOfType("[]order.ReserveItemRequest")).Return(nil)
-	mockOrderRepo.On("UpdateCreditUsed", ctx, customerID, decimal.NewFromFloat(118.00)).Return(nil)
-	mockOrderRepo.On("Commit", ctx).Return(nil)
-
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		_, err := service.CreateOrder(ctx, req)
-		if err != nil {
-			b.Fatalf("CreateOrder failed: %v", err)
-		}
+	other := newOrderFixture(store, decimal.Zero)
+	other.createOrder(t, service, 1)
+
+	customerID := fixture.customer.ID.String()
+	response, err := service.ListOrders(ctx, &ListOrdersRequest{CustomerID: &customerID, Page: 1, Limit: 2})
+	require.NoError(t, err)
+
+	assert.Len(t, response.Orders, 2)
+	assert.Equal(t, 3, response.Pagination.Total)
+	assert.Equal(t, 2, response.Pagination.TotalPages)
+	assert.True(t, response.Pagination.HasNext)
+	for _, order := range response.Orders {
+		assert.Equal(t, fixture.customer.ID, order.CustomerID)
 	}
 }
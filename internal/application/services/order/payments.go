@@ -0,0 +1,518 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
+	"erpgo/pkg/database"
+)
+
+// RecordPaymentRequest represents money received from a customer. The payment
+// may be allocated across several of the customer's orders now and the rest
+// later with AllocatePayment.
+type RecordPaymentRequest struct {
+	CustomerID  string                     `json:"customer_id" validate:"required,uuid"`
+	Method      string                     `json:"method" validate:"required"`
+	Reference   string                     `json:"reference,omitempty"`
+	Amount      decimal.Decimal            `json:"amount" validate:"required,gt=0"`
+	Currency    string                     `json:"currency" validate:"required,len=3"`
+	ReceivedAt  *time.Time                 `json:"received_at,omitempty"`
+	Notes       *string                    `json:"notes,omitempty"`
+	Allocations []PaymentAllocationRequest `json:"allocations,omitempty"`
+	ReceivedBy  string                     `json:"received_by" validate:"required,uuid"`
+}
+
+// PaymentAllocationRequest represents the part of a payment applied to an order
+type PaymentAllocationRequest struct {
+	OrderID string          `json:"order_id" validate:"required,uuid"`
+	Amount  decimal.Decimal `json:"amount" validate:"required,gt=0"`
+}
+
+// AllocatePaymentRequest represents a request to apply the unallocated part of a payment to orders
+type AllocatePaymentRequest struct {
+	Allocations []PaymentAllocationRequest `json:"allocations" validate:"required,min=1"`
+	AllocatedBy string                     `json:"allocated_by" validate:"required,uuid"`
+}
+
+// ReversePaymentRequest represents a request to reverse a ledger entry
+type ReversePaymentRequest struct {
+	Reason     string `json:"reason" validate:"required"`
+	ReversedBy string `json:"reversed_by" validate:"required,uuid"`
+}
+
+// ListPaymentsRequest represents a request to list ledger entries
+type ListPaymentsRequest struct {
+	Search       string                        `json:"search,omitempty"`
+	Type         *entities.PaymentType         `json:"type,omitempty"`
+	Method       []entities.PaymentMethod      `json:"method,omitempty"`
+	Status       []entities.PaymentEntryStatus `json:"status,omitempty"`
+	CustomerID   *string                       `json:"customer_id,omitempty"`
+	OrderID      *string                       `json:"order_id,omitempty"`
+	ReceivedFrom *time.Time                    `json:"received_from,omitempty"`
+	ReceivedTo   *time.Time                    `json:"received_to,omitempty"`
+	Unallocated  bool                          `json:"unallocated,omitempty"`
+	Page         int                           `json:"page"`
+	Limit        int                           `json:"limit"`
+}
+
+// ListPaymentsResponse represents a paginated list of ledger entries
+type ListPaymentsResponse struct {
+	Payments   []*entities.Payment `json:"payments"`
+	Pagination *Pagination         `json:"pagination"`
+}
+
+// ledgerEntry carries the method and reference of a refund paid out through the ledger
+type ledgerEntry struct {
+	method    string
+	reference string
+	notes     *string
+}
+
+// RecordPayment records money received from a customer and applies it to the given orders
+func (s *ServiceImpl) RecordPayment(ctx context.Context, req *RecordPaymentRequest) (*entities.Payment, error) {
+	ctx = withActorID(ctx, req.ReceivedBy)
+
+	customerID, err := uuid.Parse(req.CustomerID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid customer ID: %w", err)
+	}
+	receivedBy, err := uuid.Parse(req.ReceivedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid receiver ID: %w", err)
+	}
+	if _, err := s.customerRepo.GetByID(ctx, customerID); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrCustomerNotFound
+		}
+		return nil, fmt.Errorf("failed to get customer: %w", err)
+	}
+
+	method, err := entities.ParsePaymentMethod(req.Method)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayment, err)
+	}
+
+	now := time.Now().UTC()
+	payment := &entities.Payment{
+		ID:         uuid.New(),
+		Type:       entities.PaymentTypePayment,
+		CustomerID: customerID,
+		Method:     method,
+		Reference:  optionalString(req.Reference),
+		Amount:     req.Amount,
+		Currency:   strings.ToUpper(req.Currency),
+		ReceivedAt: now,
+		Status:     entities.PaymentEntryStatusCompleted,
+		Notes:      req.Notes,
+		CreatedBy:  receivedBy,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if req.ReceivedAt != nil {
+		payment.ReceivedAt = req.ReceivedAt.UTC()
+	}
+
+	var orders []*entities.Order
+	err = database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		if orders, err = s.allocatePayment(ctx, payment, req.Allocations); err != nil {
+			return err
+		}
+		if err := payment.Validate(); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidPayment, err)
+		}
+
+		if err := s.paymentRepo.Create(ctx, payment); err != nil {
+			return fmt.Errorf("failed to create payment: %w", err)
+		}
+		return s.syncOrderPayments(ctx, orders, payment, "payment received")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info().
+		Str("payment_number", payment.PaymentNumber).
+		Str("customer_id", customerID.String()).
+		Str("amount", payment.Amount.String()).
+		Int("orders", len(orders)).
+		Msg("Payment recorded")
+
+	return payment, nil
+}
+
+// AllocatePayment applies the unallocated part of a payment to orders
+func (s *ServiceImpl) AllocatePayment(ctx context.Context, id string, req *AllocatePaymentRequest) (*entities.Payment, error) {
+	ctx = withActorID(ctx, req.AllocatedBy)
+
+	var payment *entities.Payment
+	err := database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		if payment, err = s.lockPayment(ctx, id); err != nil {
+			return err
+		}
+		if payment.Type != entities.PaymentTypePayment {
+			return fmt.Errorf("%w: refunds cannot be reallocated", ErrInvalidPayment)
+		}
+
+		orders, err := s.allocatePayment(ctx, payment, req.Allocations)
+		if err != nil {
+			return err
+		}
+
+		if err := s.paymentRepo.Update(ctx, payment); err != nil {
+			return fmt.Errorf("failed to update payment: %w", err)
+		}
+		return s.syncOrderPayments(ctx, orders, payment, "payment allocated")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// ReversePayment reverses a payment or refund, e.g. for a bounced cheque, and
+// derives the payment state of every order it was allocated to again
+func (s *ServiceImpl) ReversePayment(ctx context.Context, id string, req *ReversePaymentRequest) (*entities.Payment, error) {
+	ctx = withActorID(ctx, req.ReversedBy)
+
+	reversedBy, err := uuid.Parse(req.ReversedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reverser ID: %w", err)
+	}
+
+	var payment *entities.Payment
+	err = database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		if payment, err = s.lockPayment(ctx, id); err != nil {
+			return err
+		}
+		if payment.IsReversed() {
+			return ErrPaymentAlreadyReversed
+		}
+		if err := payment.Reverse(reversedBy, req.Reason); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidPayment, err)
+		}
+
+		orderIDs := make([]uuid.UUID, len(payment.Allocations))
+		for i, allocation := range payment.Allocations {
+			orderIDs[i] = allocation.OrderID
+		}
+		if err := s.lockOrders(ctx, orderIDs...); err != nil {
+			return err
+		}
+
+		var orders []*entities.Order
+		seen := make(map[uuid.UUID]bool)
+		for _, orderID := range orderIDs {
+			if seen[orderID] {
+				continue
+			}
+			seen[orderID] = true
+
+			order, err := s.loadOrder(ctx, orderID.String())
+			if err != nil {
+				return err
+			}
+			orders = append(orders, order)
+		}
+
+		// Nothing is written for a reversal that would leave an order refunded
+		// beyond what it was paid
+		if err := s.checkReversal(ctx, orders, payment); err != nil {
+			return err
+		}
+
+		reason := fmt.Sprintf("%s %s reversed: %s", strings.ToLower(string(payment.Type)), payment.PaymentNumber, strings.TrimSpace(req.Reason))
+		if err := s.paymentRepo.Update(ctx, payment); err != nil {
+			return fmt.Errorf("failed to update payment: %w", err)
+		}
+		return s.syncOrderPayments(ctx, orders, payment, reason)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info().
+		Str("payment_number", payment.PaymentNumber).
+		Str("reason", req.Reason).
+		Msg("Payment reversed")
+
+	return payment, nil
+}
+
+// GetPayment retrieves a ledger entry by ID
+func (s *ServiceImpl) GetPayment(ctx context.Context, id string) (*entities.Payment, error) {
+	return s.loadPayment(ctx, id)
+}
+
+// GetOrderPayments returns the ledger entries allocated to an order, oldest first
+func (s *ServiceImpl) GetOrderPayments(ctx context.Context, id string) ([]*entities.Payment, error) {
+	order, err := s.loadOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	payments, err := s.paymentRepo.GetByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order payments: %w", err)
+	}
+
+	return payments, nil
+}
+
+// ListPayments lists ledger entries, e.g. to reconcile a bank statement by reference and date
+func (s *ServiceImpl) ListPayments(ctx context.Context, req *ListPaymentsRequest) (*ListPaymentsResponse, error) {
+	page, limit := normalizePage(req.Page, req.Limit)
+
+	filter := repositories.PaymentFilter{
+		Search:       req.Search,
+		Type:         req.Type,
+		Method:       req.Method,
+		Status:       req.Status,
+		ReceivedFrom: req.ReceivedFrom,
+		ReceivedTo:   req.ReceivedTo,
+		Unallocated:  req.Unallocated,
+		Page:         page,
+		Limit:        limit,
+		Offset:       (page - 1) * limit,
+	}
+	if req.CustomerID != nil {
+		customerID, err := uuid.Parse(*req.CustomerID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid customer ID: %w", err)
+		}
+		filter.CustomerID = &customerID
+	}
+	if req.OrderID != nil {
+		orderID, err := uuid.Parse(*req.OrderID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid order ID: %w", err)
+		}
+		filter.OrderID = &orderID
+	}
+
+	payments, err := s.paymentRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list payments: %w", err)
+	}
+
+	total, err := s.paymentRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count payments: %w", err)
+	}
+
+	return &ListPaymentsResponse{
+		Payments:   payments,
+		Pagination: newPagination(page, limit, total),
+	}, nil
+}
+
+// allocatePayment applies parts of a payment to orders of its customer and
+// returns the orders. Each order takes at most its outstanding balance; the
+// orders stay locked for the rest of the transaction, so concurrent
+// allocations read the balance left by each other.
+func (s *ServiceImpl) allocatePayment(ctx context.Context, payment *entities.Payment, allocations []PaymentAllocationRequest) ([]*entities.Order, error) {
+	orderIDs := make([]uuid.UUID, len(allocations))
+	for i, allocationReq := range allocations {
+		orderID, err := uuid.Parse(allocationReq.OrderID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid order ID: %w", err)
+		}
+		orderIDs[i] = orderID
+	}
+	if err := s.lockOrders(ctx, orderIDs...); err != nil {
+		return nil, err
+	}
+
+	var orders []*entities.Order
+	pending := make(map[uuid.UUID]decimal.Decimal)
+
+	for _, allocationReq := range allocations {
+		order, err := s.loadOrder(ctx, allocationReq.OrderID)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case order.CustomerID != payment.CustomerID:
+			return nil, fmt.Errorf("%w: order %s belongs to another customer", ErrInvalidPayment, order.OrderNumber)
+		case order.Currency != payment.Currency:
+			return nil, fmt.Errorf("%w: order %s is in %s, payment is in %s", ErrInvalidCurrency, order.OrderNumber, order.Currency, payment.Currency)
+		case order.Status == entities.OrderStatusCancelled || order.Status == entities.OrderStatusDraft:
+			return nil, fmt.Errorf("%w: payments cannot be taken for %s orders", ErrInvalidOrderStatus, order.Status)
+		}
+
+		if _, ok := pending[order.ID]; !ok {
+			orders = append(orders, order)
+		}
+		pending[order.ID] = pending[order.ID].Add(allocationReq.Amount)
+		if outstanding := order.GetOutstandingBalance(); pending[order.ID].GreaterThan(outstanding) {
+			return nil, fmt.Errorf("%w: allocation exceeds outstanding balance %s of order %s", ErrInvalidPaymentAmount, outstanding, order.OrderNumber)
+		}
+
+		if err := payment.Allocate(order.ID, allocationReq.Amount); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPaymentAmount, err)
+		}
+	}
+
+	return orders, nil
+}
+
+// syncOrderPayments derives the payment state of orders touched by a ledger entry
+func (s *ServiceImpl) syncOrderPayments(ctx context.Context, orders []*entities.Order, payment *entities.Payment, reason string) error {
+	for _, order := range orders {
+		if err := s.syncPayments(ctx, order, order.PaymentStatus, payment.AllocatedTo(order.ID), reason); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncPayments derives the paid and refunded amounts and the payment status
+// of an order from the ledger, persists the order, releases or rebooks the
+// customer credit it takes and records the payment event
+func (s *ServiceImpl) syncPayments(ctx context.Context, order *entities.Order, previousPaymentStatus entities.PaymentStatus, amount decimal.Decimal, reason string) error {
+	payments, err := s.paymentRepo.GetByOrderID(ctx, order.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get order payments: %w", err)
+	}
+
+	order.ApplyPaymentLedger(payments)
+	if order.RefundedAmount.GreaterThan(order.PaidAmount) {
+		return fmt.Errorf("%w: order %s would have refunds of %s against payments of %s",
+			ErrPaymentCannotBeReversed, order.OrderNumber, order.RefundedAmount, order.PaidAmount)
+	}
+
+	if err := s.orderRepo.Update(ctx, order); err != nil {
+		return fmt.Errorf("failed to update order: %w", err)
+	}
+	if order.ApprovedAt != nil {
+		if err := s.syncCustomerCredit(ctx, order.CustomerID); err != nil {
+			return err
+		}
+	}
+	return s.recordPaymentEvent(ctx, order, previousPaymentStatus, amount, reason)
+}
+
+// recordRefund pays a refund out through the ledger and derives the order's
+// payment state. Refunds without a method go back the way the order was last paid.
+func (s *ServiceImpl) recordRefund(ctx context.Context, order *entities.Order, previousPaymentStatus entities.PaymentStatus, amount decimal.Decimal, entry ledgerEntry, reason string) error {
+	method := entities.PaymentMethodOther
+	if strings.TrimSpace(entry.method) != "" {
+		parsed, err := entities.ParsePaymentMethod(entry.method)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidPayment, err)
+		}
+		method = parsed
+	} else {
+		payments, err := s.paymentRepo.GetByOrderID(ctx, order.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get order payments: %w", err)
+		}
+		for _, payment := range payments {
+			if payment.Type == entities.PaymentTypePayment && !payment.IsReversed() {
+				method = payment.Method
+			}
+		}
+	}
+
+	now := time.Now().UTC()
+	refund := &entities.Payment{
+		ID:         uuid.New(),
+		Type:       entities.PaymentTypeRefund,
+		CustomerID: order.CustomerID,
+		Method:     method,
+		Reference:  optionalString(entry.reference),
+		Amount:     amount,
+		Currency:   order.Currency,
+		ReceivedAt: now,
+		Status:     entities.PaymentEntryStatusCompleted,
+		Notes:      entry.notes,
+		CreatedBy:  ledgerActor(ctx, order.CreatedBy),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := refund.Allocate(order.ID, amount); err != nil {
+		return fmt.Errorf("%w: %v", ErrRefundFailed, err)
+	}
+	if err := refund.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrRefundFailed, err)
+	}
+
+	if err := s.paymentRepo.Create(ctx, refund); err != nil {
+		return fmt.Errorf("failed to create refund: %w", err)
+	}
+	return s.syncPayments(ctx, order, previousPaymentStatus, amount, reason)
+}
+
+// checkReversal checks that reversing a ledger entry leaves none of the
+// orders it was allocated to with refunds beyond their payments
+func (s *ServiceImpl) checkReversal(ctx context.Context, orders []*entities.Order, reversed *entities.Payment) error {
+	for _, order := range orders {
+		payments, err := s.paymentRepo.GetByOrderID(ctx, order.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get order payments: %w", err)
+		}
+		for i := range payments {
+			if payments[i].ID == reversed.ID {
+				payments[i] = reversed
+			}
+		}
+
+		paid, refunded := entities.PaymentTotals(order.ID, payments)
+		if refunded.GreaterThan(paid) {
+			return fmt.Errorf("%w: order %s would have refunds of %s against payments of %s",
+				ErrPaymentCannotBeReversed, order.OrderNumber, refunded, paid)
+		}
+	}
+	return nil
+}
+
+// lockPayment locks a ledger entry for the rest of the context's transaction and loads it
+func (s *ServiceImpl) lockPayment(ctx context.Context, id string) (*entities.Payment, error) {
+	paymentID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payment ID: %w", err)
+	}
+	if err := s.paymentRepo.Lock(ctx, paymentID); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrPaymentNotFound
+		}
+		return nil, fmt.Errorf("failed to lock payment: %w", err)
+	}
+	return s.loadPayment(ctx, id)
+}
+
+func (s *ServiceImpl) loadPayment(ctx context.Context, id string) (*entities.Payment, error) {
+	paymentID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payment ID: %w", err)
+	}
+
+	payment, err := s.paymentRepo.GetByID(ctx, paymentID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrPaymentNotFound
+		}
+		return nil, fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	return payment, nil
+}
+
+// ledgerActor returns the acting user recorded on ledger entries
+func ledgerActor(ctx context.Context, fallback uuid.UUID) uuid.UUID {
+	if userID, err := uuid.Parse(actorFromContext(ctx).userID); err == nil {
+		return userID
+	}
+	return fallback
+}
@@ -0,0 +1,150 @@
+package order
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"erpgo/internal/domain/orders/entities"
+)
+
+func (f *orderFixture) paymentRequest(amount int64, allocations ...PaymentAllocationRequest) *RecordPaymentRequest {
+	return &RecordPaymentRequest{
+		CustomerID:  f.customer.ID.String(),
+		Method:      string(entities.PaymentMethodBankTransfer),
+		Reference:   "BANK-REF-1",
+		Amount:      decimal.NewFromInt(amount),
+		Currency:    "USD",
+		Allocations: allocations,
+		ReceivedBy:  f.user.String(),
+	}
+}
+
+func allocation(order *entities.Order, amount int64) PaymentAllocationRequest {
+	return PaymentAllocationRequest{OrderID: order.ID.String(), Amount: decimal.NewFromInt(amount)}
+}
+
+func TestServiceImpl_RecordPayment(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("payment is applied to several orders", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		first := fixture.confirmOrder(t, service, 2)
+		second := fixture.confirmOrder(t, service, 4)
+		store.resetWrites()
+
+		payment, err := service.RecordPayment(ctx, fixture.paymentRequest(250, allocation(first, 100), allocation(second, 150)))
+		require.NoError(t, err)
+
+		assert.True(t, payment.UnallocatedAmount().IsZero())
+		assert.ElementsMatch(t, []uuid.UUID{first.ID, second.ID}, store.locked)
+		assert.Empty(t, store.untransacted())
+
+		assert.Equal(t, entities.PaymentStatusPaid, store.orders[first.ID].PaymentStatus)
+		assert.True(t, decimal.NewFromInt(100).Equal(store.orders[first.ID].PaidAmount))
+		assert.Equal(t, entities.PaymentStatusPartiallyPaid, store.orders[second.ID].PaymentStatus)
+		assert.True(t, decimal.NewFromInt(50).Equal(store.orders[second.ID].GetOutstandingBalance()))
+	})
+
+	t.Run("allocations cannot exceed the outstanding balance", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		order := fixture.confirmOrder(t, service, 2)
+		store.resetWrites()
+
+		_, err := service.RecordPayment(ctx, fixture.paymentRequest(150, allocation(order, 60), allocation(order, 60)))
+		assert.ErrorIs(t, err, ErrInvalidPaymentAmount)
+		assert.Empty(t, store.ops())
+		assert.True(t, store.orders[order.ID].PaidAmount.IsZero())
+	})
+
+	t.Run("unallocated payment is allocated later", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		order := fixture.confirmOrder(t, service, 2)
+
+		payment, err := service.RecordPayment(ctx, fixture.paymentRequest(100))
+		require.NoError(t, err)
+		assert.True(t, decimal.NewFromInt(100).Equal(payment.UnallocatedAmount()))
+		store.resetWrites()
+
+		allocated, err := service.AllocatePayment(ctx, payment.ID.String(), &AllocatePaymentRequest{
+			Allocations: []PaymentAllocationRequest{allocation(order, 100)},
+			AllocatedBy: fixture.user.String(),
+		})
+		require.NoError(t, err)
+
+		assert.True(t, allocated.UnallocatedAmount().IsZero())
+		assert.Equal(t, []uuid.UUID{payment.ID, order.ID}, store.locked)
+		assert.Empty(t, store.untransacted())
+		assert.Equal(t, entities.PaymentStatusPaid, store.orders[order.ID].PaymentStatus)
+	})
+}
+
+func TestServiceImpl_ReversePayment(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reversal reopens the order balance", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		order := fixture.confirmOrder(t, service, 2)
+		payment, err := service.RecordPayment(ctx, fixture.paymentRequest(100, allocation(order, 100)))
+		require.NoError(t, err)
+		store.resetWrites()
+
+		reversed, err := service.ReversePayment(ctx, payment.ID.String(), &ReversePaymentRequest{
+			Reason:     "cheque bounced",
+			ReversedBy: fixture.user.String(),
+		})
+		require.NoError(t, err)
+
+		assert.True(t, reversed.IsReversed())
+		assert.Empty(t, store.untransacted())
+		assert.Equal(t, entities.PaymentStatusPending, store.orders[order.ID].PaymentStatus)
+		assert.True(t, store.orders[order.ID].PaidAmount.IsZero())
+
+		_, err = service.ReversePayment(ctx, payment.ID.String(), &ReversePaymentRequest{
+			Reason:     "cheque bounced",
+			ReversedBy: fixture.user.String(),
+		})
+		assert.ErrorIs(t, err, ErrPaymentAlreadyReversed)
+	})
+
+	t.Run("payments that were refunded cannot be reversed", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		order := fixture.confirmOrder(t, service, 2)
+		payment, err := service.RecordPayment(ctx, fixture.paymentRequest(100, allocation(order, 100)))
+		require.NoError(t, err)
+
+		refund := &entities.Payment{
+			ID:            uuid.New(),
+			PaymentNumber: "REF-000001",
+			Type:          entities.PaymentTypeRefund,
+			CustomerID:    fixture.customer.ID,
+			Method:        entities.PaymentMethodBankTransfer,
+			Amount:        decimal.NewFromInt(40),
+			Currency:      "USD",
+			ReceivedAt:    time.Now().UTC().Add(time.Minute),
+			Status:        entities.PaymentEntryStatusCompleted,
+			CreatedBy:     fixture.user,
+		}
+		require.NoError(t, refund.Allocate(order.ID, refund.Amount))
+		store.payments[refund.ID] = refund
+		store.resetWrites()
+
+		_, err = service.ReversePayment(ctx, payment.ID.String(), &ReversePaymentRequest{
+			Reason:     "cheque bounced",
+			ReversedBy: fixture.user.String(),
+		})
+		assert.ErrorIs(t, err, ErrPaymentCannotBeReversed)
+		assert.Empty(t, store.ops())
+		assert.False(t, store.payments[payment.ID].IsReversed())
+	})
+}
@@ -0,0 +1,866 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+
+	invEntities "erpgo/internal/domain/inventory/entities"
+	invRepositories "erpgo/internal/domain/inventory/repositories"
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
+	productEntities "erpgo/internal/domain/products/entities"
+	productRepositories "erpgo/internal/domain/products/repositories"
+	"erpgo/pkg/database"
+)
+
+// PickWaveService defines the interface for warehouse picking and packing
+type PickWaveService interface {
+	// CreatePickWave releases a wave of the warehouse's most urgent orders
+	// that are ready to ship, limited to the requested grouping
+	CreatePickWave(ctx context.Context, req *CreatePickWaveRequest) (*entities.PickWave, error)
+	GetPickWave(ctx context.Context, id string) (*entities.PickWave, error)
+	ListPickWaves(ctx context.Context, req *ListPickWavesRequest) (*ListPickWavesResponse, error)
+	// GetPickList returns the lines of a wave still to pick, in location order
+	GetPickList(ctx context.Context, id string) ([]entities.PickLine, error)
+
+	// ConfirmPicks records picked quantities. Short picked units are written
+	// off the wave's warehouse and reserved elsewhere or backordered.
+	ConfirmPicks(ctx context.Context, id string, req *ConfirmPicksRequest) (*entities.PickWave, error)
+	// PackOrder ships the picked quantities of an order from the wave's warehouse
+	PackOrder(ctx context.Context, id, orderID string, req *PackOrderRequest) (*entities.PickWave, error)
+	CancelPickWave(ctx context.Context, id string) (*entities.PickWave, error)
+
+	SetPickLocation(ctx context.Context, req *SetPickLocationRequest) (*entities.PickLocation, error)
+	GetPickLocations(ctx context.Context, warehouseID string) ([]*entities.PickLocation, error)
+}
+
+// CreatePickWaveRequest represents a request to release a pick wave. Only
+// orders at or above MinPriority, shipping with ShippingMethod and placed by
+// CutoffAt are included when those are set.
+type CreatePickWaveRequest struct {
+	WarehouseID    string                   `json:"warehouse_id" validate:"required,uuid"`
+	ShippingMethod *entities.ShippingMethod `json:"shipping_method,omitempty"`
+	MinPriority    *entities.OrderPriority  `json:"min_priority,omitempty"`
+	Carrier        *string                  `json:"carrier,omitempty"`
+	CutoffAt       *time.Time               `json:"cutoff_at,omitempty"`
+	MaxOrders      int                      `json:"max_orders,omitempty"`
+	Notes          *string                  `json:"notes,omitempty"`
+	CreatedBy      string                   `json:"created_by" validate:"required,uuid"`
+}
+
+// ConfirmPicksRequest represents the picked quantities of wave lines
+type ConfirmPicksRequest struct {
+	Picks    []ConfirmPickRequest `json:"picks" validate:"required,min=1"`
+	PickedBy string               `json:"picked_by" validate:"required,uuid"`
+}
+
+// ConfirmPickRequest represents the quantity picked for a line; anything
+// less than the line quantity is short
+type ConfirmPickRequest struct {
+	LineID   string `json:"line_id" validate:"required,uuid"`
+	Quantity int    `json:"quantity" validate:"min=0"`
+}
+
+// PackOrderRequest represents the packing of a picked order. The carrier
+// defaults to the carrier of the wave.
+type PackOrderRequest struct {
+	TrackingNumber string                   `json:"tracking_number" validate:"required"`
+	Carrier        string                   `json:"carrier,omitempty"`
+	Packages       []ShipmentPackageRequest `json:"packages,omitempty"`
+	Notify         bool                     `json:"notify"`
+	PackedBy       string                   `json:"packed_by" validate:"required,uuid"`
+}
+
+// SetPickLocationRequest represents the shelf location of a product in a warehouse
+type SetPickLocationRequest struct {
+	WarehouseID string `json:"warehouse_id" validate:"required,uuid"`
+	ProductID   string `json:"product_id" validate:"required,uuid"`
+	Location    string `json:"location" validate:"required,max=50"`
+	UpdatedBy   string `json:"updated_by" validate:"required,uuid"`
+}
+
+// ListPickWavesRequest represents a request to list pick waves
+type ListPickWavesRequest struct {
+	Search      string                    `json:"search,omitempty"`
+	Status      []entities.PickWaveStatus `json:"status,omitempty"`
+	WarehouseID *string                   `json:"warehouse_id,omitempty"`
+	OrderID     *string                   `json:"order_id,omitempty"`
+	Page        int                       `json:"page"`
+	Limit       int                       `json:"limit"`
+}
+
+// ListPickWavesResponse represents a paginated list of pick waves
+type ListPickWavesResponse struct {
+	Waves      []*entities.PickWave `json:"waves"`
+	Pagination *Pagination          `json:"pagination"`
+}
+
+// Pick wave errors
+var (
+	ErrPickWaveNotFound    = errors.New("pick wave not found")
+	ErrInvalidPickWaveData = errors.New("invalid pick wave data")
+	ErrNoOrdersToPick      = errors.New("no orders to pick")
+)
+
+const (
+	// defaultWaveOrders and maxWaveOrders bound the number of orders in a wave
+	defaultWaveOrders = 50
+	maxWaveOrders     = 500
+	// waveCandidateLimit bounds the ready orders considered for a wave
+	waveCandidateLimit = 1000
+)
+
+// wavePriorities lists order priorities from most to least urgent
+var wavePriorities = []entities.OrderPriority{
+	entities.OrderPriorityCritical,
+	entities.OrderPriorityUrgent,
+	entities.OrderPriorityHigh,
+	entities.OrderPriorityNormal,
+	entities.OrderPriorityLow,
+}
+
+// PickWaveServiceImpl implements the PickWaveService interface
+type PickWaveServiceImpl struct {
+	waveRepo        repositories.PickWaveRepository
+	orderRepo       repositories.OrderRepository
+	orderItemRepo   repositories.OrderItemRepository
+	backorderRepo   repositories.BackorderRepository
+	sourcingRepo    repositories.SourcingRepository
+	productRepo     productRepositories.ProductRepository
+	warehouseRepo   invRepositories.WarehouseRepository
+	inventoryRepo   invRepositories.InventoryRepository
+	transactionRepo invRepositories.InventoryTransactionRepository
+	orderService    Service
+	txManager       database.TransactionManagerInterface
+	logger          *zerolog.Logger
+}
+
+// NewPickWaveService creates a new pick wave service. Packed orders are
+// shipped through the order service so that shipments, stock and order
+// status follow the same rules as any other shipment.
+func NewPickWaveService(
+	waveRepo repositories.PickWaveRepository,
+	orderRepo repositories.OrderRepository,
+	orderItemRepo repositories.OrderItemRepository,
+	backorderRepo repositories.BackorderRepository,
+	sourcingRepo repositories.SourcingRepository,
+	productRepo productRepositories.ProductRepository,
+	warehouseRepo invRepositories.WarehouseRepository,
+	inventoryRepo invRepositories.InventoryRepository,
+	transactionRepo invRepositories.InventoryTransactionRepository,
+	orderService Service,
+	txManager database.TransactionManagerInterface,
+	logger *zerolog.Logger,
+) PickWaveService {
+	return &PickWaveServiceImpl{
+		waveRepo:        waveRepo,
+		orderRepo:       orderRepo,
+		orderItemRepo:   orderItemRepo,
+		backorderRepo:   backorderRepo,
+		sourcingRepo:    sourcingRepo,
+		productRepo:     productRepo,
+		warehouseRepo:   warehouseRepo,
+		inventoryRepo:   inventoryRepo,
+		transactionRepo: transactionRepo,
+		orderService:    orderService,
+		txManager:       txManager,
+		logger:          logger,
+	}
+}
+
+// CreatePickWave releases a pick wave. Orders are taken most urgent first,
+// then by required and order date. Each order line gets the quantity that is
+// not already held by an open wave and, for stocked products, that is
+// reserved in the warehouse and not yet on another wave's pick list.
+func (s *PickWaveServiceImpl) CreatePickWave(ctx context.Context, req *CreatePickWaveRequest) (*entities.PickWave, error) {
+	createdBy, err := uuid.Parse(req.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid created by user ID: %w", err)
+	}
+
+	warehouse, err := s.loadWarehouse(ctx, req.WarehouseID)
+	if err != nil {
+		return nil, err
+	}
+	if !warehouse.IsActive {
+		return nil, fmt.Errorf("%w: warehouse %s is inactive", ErrInvalidPickWaveData, warehouse.Code)
+	}
+
+	maxOrders := req.MaxOrders
+	if maxOrders <= 0 {
+		maxOrders = defaultWaveOrders
+	}
+	if maxOrders > maxWaveOrders {
+		return nil, fmt.Errorf("%w: a wave cannot have more than %d orders", ErrInvalidPickWaveData, maxWaveOrders)
+	}
+
+	now := time.Now().UTC()
+	wave := &entities.PickWave{
+		ID:             uuid.New(),
+		WarehouseID:    warehouse.ID,
+		Status:         entities.PickWaveStatusReleased,
+		ShippingMethod: req.ShippingMethod,
+		MinPriority:    req.MinPriority,
+		Carrier:        req.Carrier,
+		CutoffAt:       req.CutoffAt,
+		Notes:          req.Notes,
+		CreatedBy:      createdBy,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	held, heldInWarehouse, err := s.heldQuantities(ctx, warehouse.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := s.waveCandidates(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	locations, err := s.waveRepo.GetPickLocations(ctx, warehouse.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pick locations: %w", err)
+	}
+	locationOf := make(map[uuid.UUID]string, len(locations))
+	for _, location := range locations {
+		locationOf[location.ProductID] = location.Location
+	}
+
+	products := make(map[uuid.UUID]*productEntities.Product)
+	// free is the stock reserved in the warehouse and not yet on a pick list
+	free := make(map[uuid.UUID]int)
+
+	for _, order := range orders {
+		if len(wave.Orders) == maxOrders {
+			break
+		}
+
+		items, err := s.orderItemRepo.GetByOrderID(ctx, order.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get order items: %w", err)
+		}
+
+		var lines []entities.PickLine
+		for _, item := range items {
+			remaining := item.ShippableQuantity() - held[item.ID]
+			if remaining <= 0 || item.IsDropShip {
+				continue
+			}
+
+			product, ok := products[item.ProductID]
+			if !ok {
+				if product, err = s.loadProduct(ctx, item.ProductID); err != nil {
+					return nil, err
+				}
+				products[item.ProductID] = product
+				if product.TrackInventory {
+					if free[item.ProductID], err = s.reservedIn(ctx, item.ProductID, warehouse.ID); err != nil {
+						return nil, err
+					}
+					free[item.ProductID] -= heldInWarehouse[item.ProductID]
+				}
+			}
+			if product.IsDigital {
+				continue
+			}
+
+			quantity := remaining
+			if product.TrackInventory {
+				quantity = min(remaining, free[item.ProductID])
+				if quantity <= 0 {
+					continue
+				}
+				free[item.ProductID] -= quantity
+			}
+
+			line := entities.PickLine{
+				ID:          uuid.New(),
+				WaveID:      wave.ID,
+				OrderID:     order.ID,
+				OrderItemID: item.ID,
+				ProductID:   item.ProductID,
+				ProductSKU:  item.ProductSKU,
+				ProductName: item.ProductName,
+				Quantity:    quantity,
+				Status:      entities.PickLineStatusPending,
+				CreatedAt:   now,
+				UpdatedAt:   now,
+			}
+			if location, ok := locationOf[item.ProductID]; ok {
+				line.Location = &location
+			}
+			lines = append(lines, line)
+		}
+
+		if len(lines) == 0 {
+			continue
+		}
+
+		wave.Orders = append(wave.Orders, entities.PickWaveOrder{
+			ID:          uuid.New(),
+			WaveID:      wave.ID,
+			OrderID:     order.ID,
+			OrderNumber: order.OrderNumber,
+			Priority:    order.Priority,
+			Sequence:    len(wave.Orders) + 1,
+			Status:      entities.PickWaveOrderStatusPicking,
+		})
+		wave.Lines = append(wave.Lines, lines...)
+	}
+
+	if len(wave.Orders) == 0 {
+		return nil, ErrNoOrdersToPick
+	}
+
+	if err := wave.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPickWaveData, err)
+	}
+
+	err = database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		if err := s.waveRepo.Create(ctx, wave); err != nil {
+			return fmt.Errorf("failed to create pick wave: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info().
+		Str("wave_number", wave.WaveNumber).
+		Str("warehouse_code", warehouse.Code).
+		Int("orders", len(wave.Orders)).
+		Int("lines", len(wave.Lines)).
+		Msg("Pick wave released")
+
+	return wave, nil
+}
+
+// GetPickWave retrieves a pick wave by ID
+func (s *PickWaveServiceImpl) GetPickWave(ctx context.Context, id string) (*entities.PickWave, error) {
+	return s.loadWave(ctx, id)
+}
+
+// ListPickWaves lists pick waves
+func (s *PickWaveServiceImpl) ListPickWaves(ctx context.Context, req *ListPickWavesRequest) (*ListPickWavesResponse, error) {
+	page, limit := normalizePage(req.Page, req.Limit)
+
+	filter := repositories.PickWaveFilter{
+		Search: req.Search,
+		Status: req.Status,
+		Page:   page,
+		Limit:  limit,
+		Offset: (page - 1) * limit,
+	}
+	if req.WarehouseID != nil {
+		warehouseID, err := uuid.Parse(*req.WarehouseID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid warehouse ID: %w", err)
+		}
+		filter.WarehouseID = &warehouseID
+	}
+	if req.OrderID != nil {
+		orderID, err := uuid.Parse(*req.OrderID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid order ID: %w", err)
+		}
+		filter.OrderID = &orderID
+	}
+
+	waves, err := s.waveRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pick waves: %w", err)
+	}
+
+	total, err := s.waveRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count pick waves: %w", err)
+	}
+
+	return &ListPickWavesResponse{
+		Waves:      waves,
+		Pagination: newPagination(page, limit, total),
+	}, nil
+}
+
+// GetPickList returns the lines of a wave still to pick, in location order
+func (s *PickWaveServiceImpl) GetPickList(ctx context.Context, id string) ([]entities.PickLine, error) {
+	wave, err := s.loadWave(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return wave.PickList(), nil
+}
+
+// ConfirmPicks records picked quantities. The units short picked are not on
+// the shelf: they are written off the wave's warehouse with an adjustment,
+// reserved again in the warehouses that have them available and backordered
+// when the product allows it. Anything left stays unreserved on the order.
+func (s *PickWaveServiceImpl) ConfirmPicks(ctx context.Context, id string, req *ConfirmPicksRequest) (*entities.PickWave, error) {
+	pickedBy, err := uuid.Parse(req.PickedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid picked by user ID: %w", err)
+	}
+	if len(req.Picks) == 0 {
+		return nil, fmt.Errorf("%w: no picks to confirm", ErrInvalidQuantity)
+	}
+
+	wave, err := s.loadWave(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if wave.IsClosed() {
+		return nil, fmt.Errorf("%w: pick wave is %s", ErrInvalidStatusTransition, strings.ToLower(string(wave.Status)))
+	}
+
+	now := time.Now().UTC()
+	var shorts []*entities.PickLine
+	for _, pick := range req.Picks {
+		lineID, err := uuid.Parse(pick.LineID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pick line ID: %w", err)
+		}
+		line, err := wave.ConfirmPick(lineID, pick.Quantity, pickedBy, now)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidQuantity, err)
+		}
+		if line.QuantityShort > 0 {
+			shorts = append(shorts, line)
+		}
+	}
+
+	err = database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		for _, line := range shorts {
+			if err := s.coverShortPick(ctx, wave, line, pickedBy, now); err != nil {
+				return err
+			}
+		}
+
+		if err := s.waveRepo.Update(ctx, wave); err != nil {
+			return fmt.Errorf("failed to update pick wave: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, line := range shorts {
+		s.logger.Warn().
+			Str("wave_number", wave.WaveNumber).
+			Str("product_sku", line.ProductSKU).
+			Int("short", line.QuantityShort).
+			Int("reallocated", line.QuantityReallocated).
+			Int("backordered", line.QuantityBackordered).
+			Msg("Short pick")
+	}
+
+	return wave, nil
+}
+
+// PackOrder ships the picked quantities of an order from the wave's
+// warehouse and links the shipment to the wave
+func (s *PickWaveServiceImpl) PackOrder(ctx context.Context, id, orderID string, req *PackOrderRequest) (*entities.PickWave, error) {
+	packedBy, err := uuid.Parse(req.PackedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid packed by user ID: %w", err)
+	}
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order ID: %w", err)
+	}
+
+	wave, err := s.loadWave(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	waveOrder := wave.FindOrder(orderUUID)
+	if waveOrder == nil {
+		return nil, ErrOrderNotFound
+	}
+	if waveOrder.Status != entities.PickWaveOrderStatusPicked {
+		return nil, fmt.Errorf("%w: order %s must be picked to pack, status is %s",
+			ErrInvalidStatusTransition, waveOrder.OrderNumber, waveOrder.Status)
+	}
+
+	carrier := req.Carrier
+	if carrier == "" && wave.Carrier != nil {
+		carrier = *wave.Carrier
+	}
+	if carrier == "" {
+		return nil, fmt.Errorf("%w: carrier is required", ErrInvalidPickWaveData)
+	}
+
+	shipReq := &ShipOrderRequest{
+		TrackingNumber: req.TrackingNumber,
+		Carrier:        carrier,
+		Notify:         req.Notify,
+		ShippedBy:      req.PackedBy,
+		Packages:       req.Packages,
+		WarehouseID:    wave.WarehouseID.String(),
+	}
+	for itemID, quantity := range wave.PickedQuantities(orderUUID) {
+		shipReq.Items = append(shipReq.Items, ShipItemRequest{ItemID: itemID.String(), Quantity: quantity})
+	}
+
+	if _, err := s.orderService.ShipOrder(ctx, orderID, shipReq); err != nil {
+		return nil, err
+	}
+
+	shipments, err := s.orderService.GetOrderShipments(ctx, orderID)
+	if err != nil || len(shipments) == 0 {
+		s.logger.Error().Err(err).
+			Str("wave_number", wave.WaveNumber).
+			Str("order_number", waveOrder.OrderNumber).
+			Msg("Order shipped but its shipment could not be linked to the pick wave")
+		return nil, fmt.Errorf("order shipped but pick wave not updated: %w", err)
+	}
+
+	if err := wave.Pack(orderUUID, shipments[len(shipments)-1].ID, packedBy, time.Now().UTC()); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStatusTransition, err)
+	}
+
+	if err := s.waveRepo.Update(ctx, wave); err != nil {
+		s.logger.Error().Err(err).
+			Str("wave_number", wave.WaveNumber).
+			Str("order_number", waveOrder.OrderNumber).
+			Msg("Order shipped but pick wave was not updated")
+		return nil, fmt.Errorf("failed to update pick wave: %w", err)
+	}
+
+	s.logger.Info().
+		Str("wave_number", wave.WaveNumber).
+		Str("order_number", waveOrder.OrderNumber).
+		Msg("Order packed")
+
+	return wave, nil
+}
+
+// CancelPickWave withdraws the unpacked orders from a wave. Their
+// reservations are kept so the orders can be picked in a later wave.
+func (s *PickWaveServiceImpl) CancelPickWave(ctx context.Context, id string) (*entities.PickWave, error) {
+	wave, err := s.loadWave(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := wave.Cancel(time.Now().UTC()); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStatusTransition, err)
+	}
+
+	if err := s.waveRepo.Update(ctx, wave); err != nil {
+		return nil, fmt.Errorf("failed to update pick wave: %w", err)
+	}
+
+	s.logger.Info().
+		Str("wave_number", wave.WaveNumber).
+		Msg("Pick wave cancelled")
+
+	return wave, nil
+}
+
+// SetPickLocation sets the shelf location a product is picked from. Waves
+// released afterwards use the new location.
+func (s *PickWaveServiceImpl) SetPickLocation(ctx context.Context, req *SetPickLocationRequest) (*entities.PickLocation, error) {
+	updatedBy, err := uuid.Parse(req.UpdatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid updated by user ID: %w", err)
+	}
+	productID, err := uuid.Parse(req.ProductID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	warehouse, err := s.loadWarehouse(ctx, req.WarehouseID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.loadProduct(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	location := &entities.PickLocation{
+		WarehouseID: warehouse.ID,
+		ProductID:   productID,
+		Location:    strings.TrimSpace(req.Location),
+		UpdatedBy:   updatedBy,
+		UpdatedAt:   time.Now().UTC(),
+	}
+	if err := location.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPickWaveData, err)
+	}
+
+	if err := s.waveRepo.SetPickLocation(ctx, location); err != nil {
+		return nil, fmt.Errorf("failed to set pick location: %w", err)
+	}
+
+	return location, nil
+}
+
+// GetPickLocations retrieves the pick locations of a warehouse
+func (s *PickWaveServiceImpl) GetPickLocations(ctx context.Context, warehouseID string) ([]*entities.PickLocation, error) {
+	id, err := uuid.Parse(warehouseID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid warehouse ID: %w", err)
+	}
+
+	locations, err := s.waveRepo.GetPickLocations(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pick locations: %w", err)
+	}
+
+	return locations, nil
+}
+
+// waveCandidates lists the orders ready to ship that match the wave's
+// grouping, most urgent first
+func (s *PickWaveServiceImpl) waveCandidates(ctx context.Context, req *CreatePickWaveRequest) ([]*entities.Order, error) {
+	filter := repositories.OrderFilter{
+		Status:    []entities.OrderStatus{entities.OrderStatusProcessing, entities.OrderStatusPartiallyShipped},
+		EndDate:   req.CutoffAt,
+		Limit:     waveCandidateLimit,
+		SortBy:    "order_date",
+		SortOrder: "ASC",
+	}
+	if req.ShippingMethod != nil {
+		filter.ShippingMethod = []entities.ShippingMethod{*req.ShippingMethod}
+	}
+	if req.MinPriority != nil {
+		rank := entities.PriorityRank(*req.MinPriority)
+		for _, priority := range wavePriorities {
+			if entities.PriorityRank(priority) <= rank {
+				filter.Priority = append(filter.Priority, priority)
+			}
+		}
+	}
+
+	orders, err := s.orderRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list orders: %w", err)
+	}
+
+	sort.SliceStable(orders, func(i, j int) bool {
+		a, b := orders[i], orders[j]
+		if ra, rb := entities.PriorityRank(a.Priority), entities.PriorityRank(b.Priority); ra != rb {
+			return ra < rb
+		}
+		switch {
+		case a.RequiredDate != nil && b.RequiredDate == nil:
+			return true
+		case a.RequiredDate == nil && b.RequiredDate != nil:
+			return false
+		case a.RequiredDate != nil && !a.RequiredDate.Equal(*b.RequiredDate):
+			return a.RequiredDate.Before(*b.RequiredDate)
+		}
+		return a.OrderDate.Before(b.OrderDate)
+	})
+
+	return orders, nil
+}
+
+// heldQuantities returns the quantities held by open waves, by order item,
+// and the quantities held by the open waves of a warehouse, by product
+func (s *PickWaveServiceImpl) heldQuantities(ctx context.Context, warehouseID uuid.UUID) (map[uuid.UUID]int, map[uuid.UUID]int, error) {
+	waves, err := s.waveRepo.GetOpen(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get open pick waves: %w", err)
+	}
+
+	byItem := make(map[uuid.UUID]int)
+	byProduct := make(map[uuid.UUID]int)
+	for _, wave := range waves {
+		for _, line := range wave.OpenLines() {
+			byItem[line.OrderItemID] += line.Outstanding()
+			if wave.WarehouseID == warehouseID {
+				byProduct[line.ProductID] += line.Outstanding()
+			}
+		}
+	}
+
+	return byItem, byProduct, nil
+}
+
+// reservedIn returns the stock of a product reserved in a warehouse
+func (s *PickWaveServiceImpl) reservedIn(ctx context.Context, productID, warehouseID uuid.UUID) (int, error) {
+	levels, err := s.inventoryRepo.GetProductInventory(ctx, productID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get inventory levels: %w", err)
+	}
+	for _, level := range levels {
+		if level.WarehouseID == warehouseID {
+			return level.QuantityReserved, nil
+		}
+	}
+	return 0, nil
+}
+
+// coverShortPick writes the short units of a line off the wave's warehouse
+// and covers them from the other warehouses or with a backorder. It records
+// the outcome on the line.
+func (s *PickWaveServiceImpl) coverShortPick(ctx context.Context, wave *entities.PickWave, line *entities.PickLine, pickedBy uuid.UUID, at time.Time) error {
+	product, err := s.loadProduct(ctx, line.ProductID)
+	if err != nil {
+		return err
+	}
+
+	line.QuantityReallocated, line.QuantityBackordered = 0, 0
+	if !product.TrackInventory || product.IsDigital {
+		return nil
+	}
+
+	short := line.QuantityShort
+	if err := s.inventoryRepo.ReleaseStock(ctx, line.ProductID, wave.WarehouseID, short); err != nil {
+		return fmt.Errorf("failed to release short picked stock: %w", err)
+	}
+	if err := s.inventoryRepo.AdjustStock(ctx, line.ProductID, wave.WarehouseID, -short); err != nil {
+		return fmt.Errorf("failed to write off short picked stock: %w", err)
+	}
+
+	reference := wave.ID
+	transaction := &invEntities.InventoryTransaction{
+		ID:              uuid.New(),
+		ProductID:       line.ProductID,
+		WarehouseID:     wave.WarehouseID,
+		TransactionType: invEntities.TransactionTypeAdjustment,
+		Quantity:        -short,
+		ReferenceType:   "PICK_WAVE",
+		ReferenceID:     &reference,
+		Reason:          fmt.Sprintf("Short picked on wave %s", wave.WaveNumber),
+		UnitCost:        product.Cost.InexactFloat64(),
+		TotalCost:       product.Cost.Mul(decimal.NewFromInt(int64(short))).Round(2).InexactFloat64(),
+		CreatedAt:       at,
+		CreatedBy:       pickedBy,
+	}
+	if err := transaction.Validate(); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidPickWaveData, line.ProductSKU, err)
+	}
+	if err := s.transactionRepo.Create(ctx, transaction); err != nil {
+		return fmt.Errorf("failed to record inventory transaction: %w", err)
+	}
+
+	allocations, err := s.sourcingRepo.GetAllocationsByOrderID(ctx, line.OrderID)
+	if err != nil {
+		return fmt.Errorf("failed to get order allocations: %w", err)
+	}
+	if len(allocations) > 0 {
+		allocations = entities.ReleaseAllocations(allocations, line.OrderItemID, wave.WarehouseID, short)
+		if err := s.sourcingRepo.ReplaceAllocations(ctx, line.OrderID, allocations); err != nil {
+			return fmt.Errorf("failed to update order allocations: %w", err)
+		}
+	}
+
+	levels, err := s.inventoryRepo.GetProductInventory(ctx, line.ProductID)
+	if err != nil {
+		return fmt.Errorf("failed to get inventory levels: %w", err)
+	}
+	sort.Slice(levels, func(i, j int) bool {
+		return levels[i].GetAvailableQuantity() > levels[j].GetAvailableQuantity()
+	})
+
+	remaining := short
+	for _, level := range levels {
+		if remaining == 0 {
+			break
+		}
+		available := level.GetAvailableQuantity()
+		if level.WarehouseID == wave.WarehouseID || available <= 0 {
+			continue
+		}
+		take := min(available, remaining)
+		if err := s.inventoryRepo.ReserveStock(ctx, line.ProductID, level.WarehouseID, take); err != nil {
+			return fmt.Errorf("%w: %v", ErrInventoryReservationFailed, err)
+		}
+		if err := s.sourcingRepo.AddAllocation(ctx, newStockAllocation(line.OrderID, line.OrderItemID, line.ProductID, level.WarehouseID, take)); err != nil {
+			return fmt.Errorf("failed to record order allocation: %w", err)
+		}
+		line.QuantityReallocated += take
+		remaining -= take
+	}
+
+	if remaining == 0 || !product.AllowBackorder {
+		return nil
+	}
+
+	order, err := s.orderRepo.GetByID(ctx, line.OrderID)
+	if err != nil {
+		return fmt.Errorf("failed to get order: %w", err)
+	}
+	item, err := s.orderItemRepo.GetByID(ctx, line.OrderItemID)
+	if err != nil {
+		return fmt.Errorf("failed to get order item: %w", err)
+	}
+
+	backorder, err := entities.NewBackorder(order, item, remaining)
+	if err != nil {
+		return err
+	}
+	item.QuantityBackordered += remaining
+	item.UpdatedAt = at
+	if err := s.orderItemRepo.BulkUpdate(ctx, []*entities.OrderItem{item}); err != nil {
+		return fmt.Errorf("failed to update order item: %w", err)
+	}
+	if err := s.backorderRepo.Create(ctx, backorder); err != nil {
+		return fmt.Errorf("failed to create backorder: %w", err)
+	}
+	line.QuantityBackordered = remaining
+
+	return nil
+}
+
+// loadProduct loads a product, mapping missing rows to ErrProductNotFound
+func (s *PickWaveServiceImpl) loadProduct(ctx context.Context, id uuid.UUID) (*productEntities.Product, error) {
+	product, err := s.productRepo.GetByID(ctx, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	return product, nil
+}
+
+// loadWarehouse parses the ID and loads the warehouse
+func (s *PickWaveServiceImpl) loadWarehouse(ctx context.Context, id string) (*invEntities.Warehouse, error) {
+	warehouseID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid warehouse ID: %w", err)
+	}
+
+	warehouse, err := s.warehouseRepo.GetByID(ctx, warehouseID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrWarehouseNotFound
+		}
+		return nil, fmt.Errorf("failed to get warehouse: %w", err)
+	}
+
+	return warehouse, nil
+}
+
+// loadWave parses the ID and loads the wave, mapping missing rows to ErrPickWaveNotFound
+func (s *PickWaveServiceImpl) loadWave(ctx context.Context, id string) (*entities.PickWave, error) {
+	waveID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pick wave ID: %w", err)
+	}
+
+	wave, err := s.waveRepo.GetByID(ctx, waveID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrPickWaveNotFound
+		}
+		return nil, fmt.Errorf("failed to get pick wave: %w", err)
+	}
+
+	return wave, nil
+}
@@ -0,0 +1,363 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
+)
+
+// PromotionService defines the interface for promotion management. Coupon
+// codes are applied to orders through the order service.
+type PromotionService interface {
+	CreatePromotion(ctx context.Context, req *CreatePromotionRequest) (*entities.Promotion, error)
+	GetPromotion(ctx context.Context, id string) (*entities.Promotion, error)
+	GetPromotionByCode(ctx context.Context, code string) (*entities.Promotion, error)
+	UpdatePromotion(ctx context.Context, id string, req *UpdatePromotionRequest) (*entities.Promotion, error)
+	ListPromotions(ctx context.Context, req *ListPromotionsRequest) (*ListPromotionsResponse, error)
+}
+
+// CreatePromotionRequest represents a request to create a promotion
+type CreatePromotionRequest struct {
+	Code                  string                       `json:"code" validate:"required,max=50"`
+	Name                  string                       `json:"name" validate:"required"`
+	Description           *string                      `json:"description,omitempty"`
+	ActionType            entities.PromotionActionType `json:"action_type" validate:"required"`
+	Value                 decimal.Decimal              `json:"value"`
+	MaxDiscount           *decimal.Decimal             `json:"max_discount,omitempty"`
+	BuyQuantity           int                          `json:"buy_quantity,omitempty"`
+	GetQuantity           int                          `json:"get_quantity,omitempty"`
+	MinSubtotal           decimal.Decimal              `json:"min_subtotal"`
+	ProductIDs            []string                     `json:"product_ids,omitempty"`
+	CategoryIDs           []string                     `json:"category_ids,omitempty"`
+	CustomerTypes         []string                     `json:"customer_types,omitempty"`
+	StartsAt              *time.Time                   `json:"starts_at,omitempty"`
+	EndsAt                *time.Time                   `json:"ends_at,omitempty"`
+	UsageLimit            *int                         `json:"usage_limit,omitempty"`
+	UsageLimitPerCustomer *int                         `json:"usage_limit_per_customer,omitempty"`
+	Stackable             bool                         `json:"stackable"`
+	Priority              int                          `json:"priority"`
+	CreatedBy             string                       `json:"created_by" validate:"required,uuid"`
+}
+
+// UpdatePromotionRequest represents a request to update a promotion. The code
+// and action type are fixed once created; scopes are replaced when set.
+type UpdatePromotionRequest struct {
+	Name                  *string          `json:"name,omitempty"`
+	Description           *string          `json:"description,omitempty"`
+	Value                 *decimal.Decimal `json:"value,omitempty"`
+	MaxDiscount           *decimal.Decimal `json:"max_discount,omitempty"`
+	BuyQuantity           *int             `json:"buy_quantity,omitempty"`
+	GetQuantity           *int             `json:"get_quantity,omitempty"`
+	MinSubtotal           *decimal.Decimal `json:"min_subtotal,omitempty"`
+	ProductIDs            []string         `json:"product_ids,omitempty"`
+	CategoryIDs           []string         `json:"category_ids,omitempty"`
+	CustomerTypes         []string         `json:"customer_types,omitempty"`
+	StartsAt              *time.Time       `json:"starts_at,omitempty"`
+	EndsAt                *time.Time       `json:"ends_at,omitempty"`
+	UsageLimit            *int             `json:"usage_limit,omitempty"`
+	UsageLimitPerCustomer *int             `json:"usage_limit_per_customer,omitempty"`
+	Stackable             *bool            `json:"stackable,omitempty"`
+	Priority              *int             `json:"priority,omitempty"`
+	IsActive              *bool            `json:"is_active,omitempty"`
+}
+
+// ListPromotionsRequest represents a request to list promotions
+type ListPromotionsRequest struct {
+	Search     string                        `json:"search,omitempty"`
+	ActionType *entities.PromotionActionType `json:"action_type,omitempty"`
+	IsActive   *bool                         `json:"is_active,omitempty"`
+	// Running lists only the promotions that can be redeemed now
+	Running bool `json:"running,omitempty"`
+	Page    int  `json:"page"`
+	Limit   int  `json:"limit"`
+}
+
+// ListPromotionsResponse represents a paginated list of promotions
+type ListPromotionsResponse struct {
+	Promotions []*entities.Promotion `json:"promotions"`
+	Pagination *Pagination           `json:"pagination"`
+}
+
+// Promotion errors
+var (
+	ErrPromotionNotFound          = errors.New("promotion not found")
+	ErrPromotionExists            = errors.New("promotion code already exists")
+	ErrPromotionNotApplicable     = errors.New("order does not qualify for promotion")
+	ErrPromotionNotStackable      = errors.New("promotion cannot be combined")
+	ErrPromotionUsageLimitReached = errors.New("promotion usage limit reached")
+	ErrPromotionNotApplied        = errors.New("promotion is not applied to order")
+)
+
+// PromotionServiceImpl implements the PromotionService interface
+type PromotionServiceImpl struct {
+	promotionRepo repositories.PromotionRepository
+	logger        *zerolog.Logger
+}
+
+// NewPromotionService creates a new promotion service
+func NewPromotionService(
+	promotionRepo repositories.PromotionRepository,
+	logger *zerolog.Logger,
+) PromotionService {
+	return &PromotionServiceImpl{
+		promotionRepo: promotionRepo,
+		logger:        logger,
+	}
+}
+
+// CreatePromotion creates an active promotion; without a start date it starts now
+func (s *PromotionServiceImpl) CreatePromotion(ctx context.Context, req *CreatePromotionRequest) (*entities.Promotion, error) {
+	createdBy, err := uuid.Parse(req.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid created by user ID: %w", err)
+	}
+
+	code := entities.NormalizePromotionCode(req.Code)
+	if _, err := s.promotionRepo.GetByCode(ctx, code); err == nil {
+		return nil, fmt.Errorf("%w: %s", ErrPromotionExists, code)
+	} else if !strings.Contains(err.Error(), "not found") {
+		return nil, fmt.Errorf("failed to check promotion code: %w", err)
+	}
+
+	productIDs, err := parseIDs(req.ProductIDs, "product")
+	if err != nil {
+		return nil, err
+	}
+	categoryIDs, err := parseIDs(req.CategoryIDs, "category")
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	promotion := &entities.Promotion{
+		ID:                    uuid.New(),
+		Code:                  code,
+		Name:                  strings.TrimSpace(req.Name),
+		Description:           trimmedOrNil(req.Description),
+		ActionType:            entities.PromotionActionType(strings.ToUpper(string(req.ActionType))),
+		Value:                 req.Value,
+		MaxDiscount:           req.MaxDiscount,
+		BuyQuantity:           req.BuyQuantity,
+		GetQuantity:           req.GetQuantity,
+		MinSubtotal:           req.MinSubtotal,
+		ProductIDs:            productIDs,
+		CategoryIDs:           categoryIDs,
+		CustomerTypes:         customerTypes(req.CustomerTypes),
+		StartsAt:              now,
+		EndsAt:                req.EndsAt,
+		UsageLimit:            req.UsageLimit,
+		UsageLimitPerCustomer: req.UsageLimitPerCustomer,
+		Stackable:             req.Stackable,
+		Priority:              req.Priority,
+		IsActive:              true,
+		CreatedBy:             createdBy,
+		CreatedAt:             now,
+		UpdatedAt:             now,
+	}
+	if req.StartsAt != nil {
+		promotion.StartsAt = req.StartsAt.UTC()
+	}
+
+	if err := promotion.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid promotion data: %w", err)
+	}
+
+	if err := s.promotionRepo.Create(ctx, promotion); err != nil {
+		return nil, fmt.Errorf("failed to create promotion: %w", err)
+	}
+
+	s.logger.Info().
+		Str("promotion_id", promotion.ID.String()).
+		Str("code", promotion.Code).
+		Str("action_type", string(promotion.ActionType)).
+		Msg("Promotion created")
+
+	return promotion, nil
+}
+
+// GetPromotion retrieves a promotion by ID
+func (s *PromotionServiceImpl) GetPromotion(ctx context.Context, id string) (*entities.Promotion, error) {
+	return s.loadPromotion(ctx, id)
+}
+
+// GetPromotionByCode retrieves a promotion by its coupon code
+func (s *PromotionServiceImpl) GetPromotionByCode(ctx context.Context, code string) (*entities.Promotion, error) {
+	return findPromotionByCode(ctx, s.promotionRepo, code)
+}
+
+// UpdatePromotion updates the conditions, discount or limits of a promotion.
+// Orders it was applied to pick up the change when they are recalculated.
+func (s *PromotionServiceImpl) UpdatePromotion(ctx context.Context, id string, req *UpdatePromotionRequest) (*entities.Promotion, error) {
+	promotion, err := s.loadPromotion(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		promotion.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		promotion.Description = trimmedOrNil(req.Description)
+	}
+	if req.Value != nil {
+		promotion.Value = *req.Value
+	}
+	if req.MaxDiscount != nil {
+		promotion.MaxDiscount = req.MaxDiscount
+	}
+	if req.BuyQuantity != nil {
+		promotion.BuyQuantity = *req.BuyQuantity
+	}
+	if req.GetQuantity != nil {
+		promotion.GetQuantity = *req.GetQuantity
+	}
+	if req.MinSubtotal != nil {
+		promotion.MinSubtotal = *req.MinSubtotal
+	}
+	if req.ProductIDs != nil {
+		if promotion.ProductIDs, err = parseIDs(req.ProductIDs, "product"); err != nil {
+			return nil, err
+		}
+	}
+	if req.CategoryIDs != nil {
+		if promotion.CategoryIDs, err = parseIDs(req.CategoryIDs, "category"); err != nil {
+			return nil, err
+		}
+	}
+	if req.CustomerTypes != nil {
+		promotion.CustomerTypes = customerTypes(req.CustomerTypes)
+	}
+	if req.StartsAt != nil {
+		promotion.StartsAt = req.StartsAt.UTC()
+	}
+	if req.EndsAt != nil {
+		endsAt := req.EndsAt.UTC()
+		promotion.EndsAt = &endsAt
+	}
+	if req.UsageLimit != nil {
+		promotion.UsageLimit = req.UsageLimit
+	}
+	if req.UsageLimitPerCustomer != nil {
+		promotion.UsageLimitPerCustomer = req.UsageLimitPerCustomer
+	}
+	if req.Stackable != nil {
+		promotion.Stackable = *req.Stackable
+	}
+	if req.Priority != nil {
+		promotion.Priority = *req.Priority
+	}
+	if req.IsActive != nil {
+		promotion.IsActive = *req.IsActive
+	}
+	promotion.UpdatedAt = time.Now().UTC()
+
+	if err := promotion.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid promotion data: %w", err)
+	}
+
+	if err := s.promotionRepo.Update(ctx, promotion); err != nil {
+		return nil, fmt.Errorf("failed to update promotion: %w", err)
+	}
+
+	return promotion, nil
+}
+
+// ListPromotions lists promotions, most recently started first
+func (s *PromotionServiceImpl) ListPromotions(ctx context.Context, req *ListPromotionsRequest) (*ListPromotionsResponse, error) {
+	page, limit := normalizePage(req.Page, req.Limit)
+
+	filter := repositories.PromotionFilter{
+		Search:     req.Search,
+		ActionType: req.ActionType,
+		IsActive:   req.IsActive,
+		Page:       page,
+		Limit:      limit,
+		Offset:     (page - 1) * limit,
+	}
+	if req.Running {
+		now := time.Now().UTC()
+		filter.RunningAt = &now
+	}
+
+	promotions, err := s.promotionRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list promotions: %w", err)
+	}
+
+	total, err := s.promotionRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count promotions: %w", err)
+	}
+
+	return &ListPromotionsResponse{
+		Promotions: promotions,
+		Pagination: newPagination(page, limit, total),
+	}, nil
+}
+
+// loadPromotion parses the ID and loads the promotion, mapping missing rows to ErrPromotionNotFound
+func (s *PromotionServiceImpl) loadPromotion(ctx context.Context, id string) (*entities.Promotion, error) {
+	promotionID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid promotion ID: %w", err)
+	}
+
+	promotion, err := s.promotionRepo.GetByID(ctx, promotionID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrPromotionNotFound
+		}
+		return nil, fmt.Errorf("failed to get promotion: %w", err)
+	}
+
+	return promotion, nil
+}
+
+// findPromotionByCode normalizes a coupon code and loads its promotion
+func findPromotionByCode(ctx context.Context, promotionRepo repositories.PromotionRepository, code string) (*entities.Promotion, error) {
+	code = entities.NormalizePromotionCode(code)
+	if code == "" {
+		return nil, ErrPromotionNotFound
+	}
+
+	promotion, err := promotionRepo.GetByCode(ctx, code)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrPromotionNotFound
+		}
+		return nil, fmt.Errorf("failed to get promotion: %w", err)
+	}
+
+	return promotion, nil
+}
+
+// parseIDs parses a list of IDs of the named kind
+func parseIDs(values []string, kind string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(values))
+	for _, value := range values {
+		id, err := uuid.Parse(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s ID: %w", kind, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// customerTypes upper-cases the customer types a promotion is limited to
+func customerTypes(values []string) []string {
+	types := make([]string, 0, len(values))
+	for _, value := range values {
+		types = append(types, strings.ToUpper(strings.TrimSpace(value)))
+	}
+	return types
+}
@@ -0,0 +1,236 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/pkg/database"
+)
+
+// ApplyCouponRequest represents a coupon code entered on an order
+type ApplyCouponRequest struct {
+	Code      string `json:"code" validate:"required"`
+	AppliedBy string `json:"applied_by" validate:"required,uuid"`
+}
+
+// ApplyCoupon redeems a coupon code on an editable order and recalculates its
+// totals. The order must qualify for the promotion now, the promotion must
+// combine with the codes already applied, and the redemption must fit within
+// the usage limits, which the repository checks with the promotion locked.
+// The order stays locked from these checks until its new totals are saved, so
+// codes applied at the same time are checked against each other.
+func (s *ServiceImpl) ApplyCoupon(ctx context.Context, orderID string, req *ApplyCouponRequest) (*entities.Order, error) {
+	appliedBy, err := uuid.Parse(req.AppliedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid applied by user ID: %w", err)
+	}
+	ctx = withActorID(ctx, req.AppliedBy)
+
+	var order *entities.Order
+	// The redemption commits with the order's new totals or not at all
+	err = database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		if order, err = s.lockOrder(ctx, orderID); err != nil {
+			return err
+		}
+
+		if !isEditable(order) {
+			return ErrOrderCannotBeModified
+		}
+
+		now := time.Now().UTC()
+		promotion, err := s.checkCoupon(ctx, order, req.Code, now)
+		if err != nil {
+			return err
+		}
+
+		redemption := &entities.PromotionRedemption{
+			ID:            uuid.New(),
+			PromotionID:   promotion.ID,
+			PromotionCode: promotion.Code,
+			OrderID:       order.ID,
+			CustomerID:    order.CustomerID,
+			RedeemedBy:    appliedBy,
+			RedeemedAt:    now,
+		}
+		if err := s.promotionRepo.Redeem(ctx, redemption); err != nil {
+			switch {
+			case strings.Contains(err.Error(), "usage limit"):
+				return fmt.Errorf("%w: %v", ErrPromotionUsageLimitReached, err)
+			case strings.Contains(err.Error(), "already applied"):
+				return fmt.Errorf("%w: %v", ErrPromotionNotStackable, err)
+			case strings.Contains(err.Error(), "not found"):
+				return ErrPromotionNotFound
+			}
+			return fmt.Errorf("failed to redeem promotion: %w", err)
+		}
+		order.Promotions = append(order.Promotions, *redemption)
+
+		return s.saveTotals(ctx, order)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// RemoveCoupon releases a coupon code applied to an editable order and
+// recalculates its totals
+func (s *ServiceImpl) RemoveCoupon(ctx context.Context, orderID, code string) (*entities.Order, error) {
+	var order *entities.Order
+	err := database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		if order, err = s.lockOrder(ctx, orderID); err != nil {
+			return err
+		}
+
+		if !isEditable(order) {
+			return ErrOrderCannotBeModified
+		}
+
+		code = entities.NormalizePromotionCode(code)
+		index := -1
+		for i := range order.Promotions {
+			if order.Promotions[i].PromotionCode == code {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return ErrPromotionNotApplied
+		}
+
+		if err := s.releaseRedemption(ctx, &order.Promotions[index], "removed from order"); err != nil {
+			return err
+		}
+		order.Promotions = append(order.Promotions[:index], order.Promotions[index+1:]...)
+
+		return s.saveTotals(ctx, order)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// checkCoupon finds the promotion of a coupon code and checks that the order
+// qualifies for it and that it combines with the codes already applied
+func (s *ServiceImpl) checkCoupon(ctx context.Context, order *entities.Order, code string, at time.Time) (*entities.Promotion, error) {
+	promotion, err := findPromotionByCode(ctx, s.promotionRepo, code)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := appliedPromotions(order)
+	if err := promotion.CanStackWith(applied); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrPromotionNotStackable, err)
+	}
+
+	categories, err := s.productCategories(ctx, order, append(applied, promotion))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := promotion.CheckEligibility(order, categories, at); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrPromotionNotApplicable, err)
+	}
+
+	return promotion, nil
+}
+
+// saveTotals recalculates an order, persists the new totals and requests the
+// approvals they require
+func (s *ServiceImpl) saveTotals(ctx context.Context, order *entities.Order) error {
+	if _, err := s.applyTotals(ctx, order); err != nil {
+		return err
+	}
+
+	return database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		if err := s.orderRepo.Update(ctx, order); err != nil {
+			return fmt.Errorf("failed to update order: %w", err)
+		}
+		_, err := s.requestApprovals(ctx, order)
+		return err
+	})
+}
+
+// calculatePromotions works out the discounts of the codes applied to an order
+func (s *ServiceImpl) calculatePromotions(ctx context.Context, order *entities.Order) (*entities.PromotionCalculation, error) {
+	categories, err := s.productCategories(ctx, order, appliedPromotions(order))
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.CalculatePromotions(order, categories), nil
+}
+
+// releasePromotions gives the codes applied to an order back to their usage limits
+func (s *ServiceImpl) releasePromotions(ctx context.Context, order *entities.Order, reason string) error {
+	for i := range order.Promotions {
+		if order.Promotions[i].IsReleased() {
+			continue
+		}
+		if err := s.releaseRedemption(ctx, &order.Promotions[i], reason); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *ServiceImpl) releaseRedemption(ctx context.Context, redemption *entities.PromotionRedemption, reason string) error {
+	if err := redemption.Release(reason); err != nil {
+		return err
+	}
+	if err := s.promotionRepo.UpdateRedemption(ctx, redemption); err != nil {
+		return fmt.Errorf("failed to release promotion redemption: %w", err)
+	}
+	return nil
+}
+
+// productCategories maps the products of an order's lines to their categories
+// when one of the promotions is scoped to categories
+func (s *ServiceImpl) productCategories(ctx context.Context, order *entities.Order, promotions []*entities.Promotion) (map[uuid.UUID]uuid.UUID, error) {
+	categories := make(map[uuid.UUID]uuid.UUID)
+
+	scoped := false
+	for _, promotion := range promotions {
+		scoped = scoped || len(promotion.CategoryIDs) > 0
+	}
+	if !scoped {
+		return categories, nil
+	}
+
+	for _, item := range order.Items {
+		if _, ok := categories[item.ProductID]; ok {
+			continue
+		}
+		product, err := s.productRepo.GetByID(ctx, item.ProductID)
+		if err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get product: %w", err)
+		}
+		categories[item.ProductID] = product.CategoryID
+	}
+
+	return categories, nil
+}
+
+// appliedPromotions returns the promotions of the codes applied to an order
+func appliedPromotions(order *entities.Order) []*entities.Promotion {
+	var promotions []*entities.Promotion
+	for _, redemption := range order.Promotions {
+		if redemption.Promotion != nil && !redemption.IsReleased() {
+			promotions = append(promotions, redemption.Promotion)
+		}
+	}
+	return promotions
+}
@@ -0,0 +1,67 @@
+package order
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"erpgo/internal/domain/orders/entities"
+)
+
+// addPromotion stores an active percentage promotion under its code
+func (s *memoryStore) addPromotion(code string, percent int64, stackable bool) *entities.Promotion {
+	promotion := &entities.Promotion{
+		ID:         uuid.New(),
+		Code:       code,
+		Name:       code,
+		ActionType: entities.PromotionActionPercentage,
+		Value:      decimal.NewFromInt(percent),
+		StartsAt:   time.Now().UTC().Add(-time.Hour),
+		Stackable:  stackable,
+		IsActive:   true,
+	}
+	s.promotions[code] = promotion
+	return promotion
+}
+
+func TestServiceImpl_ApplyCoupon(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("coupon is redeemed on the locked order", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		order := fixture.createOrder(t, service, 2)
+		store.addPromotion("TENOFF", 10, false)
+		store.resetWrites()
+
+		applied, err := service.ApplyCoupon(ctx, order.ID.String(), &ApplyCouponRequest{Code: "TENOFF", AppliedBy: fixture.user.String()})
+		require.NoError(t, err)
+
+		assert.True(t, decimal.NewFromInt(90).Equal(applied.TotalAmount), "total is %s", applied.TotalAmount)
+		require.Len(t, store.redemptions, 1)
+		assert.Equal(t, []uuid.UUID{order.ID}, store.locked)
+		assert.Empty(t, store.untransacted())
+	})
+
+	t.Run("codes that do not combine with an applied code are refused", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		order := fixture.createOrder(t, service, 2)
+		store.addPromotion("TENOFF", 10, false)
+		store.addPromotion("FIVEOFF", 5, true)
+
+		_, err := service.ApplyCoupon(ctx, order.ID.String(), &ApplyCouponRequest{Code: "TENOFF", AppliedBy: fixture.user.String()})
+		require.NoError(t, err)
+		store.resetWrites()
+
+		_, err = service.ApplyCoupon(ctx, order.ID.String(), &ApplyCouponRequest{Code: "FIVEOFF", AppliedBy: fixture.user.String()})
+		assert.ErrorIs(t, err, ErrPromotionNotStackable)
+		assert.Empty(t, store.ops())
+		assert.Len(t, store.redemptions, 1)
+	})
+}
@@ -0,0 +1,623 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
+	productRepositories "erpgo/internal/domain/products/repositories"
+	"erpgo/pkg/database"
+)
+
+// QuotationService defines the interface for sales quotation management
+type QuotationService interface {
+	CreateQuotation(ctx context.Context, req *CreateQuotationRequest) (*entities.Quotation, error)
+	GetQuotation(ctx context.Context, id string) (*entities.Quotation, error)
+	GetQuotationByOrder(ctx context.Context, orderID string) (*entities.Quotation, error)
+	UpdateQuotation(ctx context.Context, id string, req *UpdateQuotationRequest) (*entities.Quotation, error)
+	ListQuotations(ctx context.Context, req *ListQuotationsRequest) (*ListQuotationsResponse, error)
+	GetQuotationRevisions(ctx context.Context, id string) ([]*entities.Quotation, error)
+
+	SendQuotation(ctx context.Context, id string) (*entities.Quotation, error)
+	AcceptQuotation(ctx context.Context, id string) (*entities.Quotation, error)
+	RejectQuotation(ctx context.Context, id string, reason string) (*entities.Quotation, error)
+	ReviseQuotation(ctx context.Context, id string, req *ReviseQuotationRequest) (*entities.Quotation, error)
+
+	// ConvertToOrder turns an accepted quotation into a PENDING sales order
+	ConvertToOrder(ctx context.Context, id string, req *ConvertQuotationRequest) (*entities.Order, error)
+
+	// ExpireQuotations closes open quotations whose validity ended before the given time
+	ExpireQuotations(ctx context.Context, at time.Time) (int, error)
+}
+
+// CreateQuotationRequest represents a request to create a quotation
+type CreateQuotationRequest struct {
+	CustomerID        string                   `json:"customer_id" validate:"required,uuid"`
+	ShippingMethod    entities.ShippingMethod  `json:"shipping_method" validate:"required"`
+	ShippingAddressID string                   `json:"shipping_address_id" validate:"required,uuid"`
+	BillingAddressID  string                   `json:"billing_address_id" validate:"required,uuid"`
+	Currency          string                   `json:"currency" validate:"omitempty,len=3"`
+	ValidUntil        time.Time                `json:"valid_until" validate:"required"`
+	ShippingAmount    decimal.Decimal          `json:"shipping_amount,omitempty"`
+	Notes             *string                  `json:"notes,omitempty"`
+	Items             []CreateOrderItemRequest `json:"items" validate:"required,min=1"`
+	CreatedBy         string                   `json:"created_by" validate:"required,uuid"`
+}
+
+// UpdateQuotationRequest represents a request to update a draft quotation.
+// When Items is set the quotation lines are replaced.
+type UpdateQuotationRequest struct {
+	ShippingMethod    *entities.ShippingMethod `json:"shipping_method,omitempty"`
+	ShippingAddressID *string                  `json:"shipping_address_id,omitempty"`
+	BillingAddressID  *string                  `json:"billing_address_id,omitempty"`
+	ValidUntil        *time.Time               `json:"valid_until,omitempty"`
+	ShippingAmount    *decimal.Decimal         `json:"shipping_amount,omitempty"`
+	Notes             *string                  `json:"notes,omitempty"`
+	Items             []CreateOrderItemRequest `json:"items,omitempty"`
+}
+
+// ReviseQuotationRequest represents a request to create a new quotation revision
+type ReviseQuotationRequest struct {
+	ValidUntil *time.Time `json:"valid_until,omitempty"`
+	RevisedBy  string     `json:"revised_by" validate:"required,uuid"`
+}
+
+// ConvertQuotationRequest represents a request to convert a quotation into an order
+type ConvertQuotationRequest struct {
+	Priority      entities.OrderPriority `json:"priority"`
+	RequiredDate  *time.Time             `json:"required_date,omitempty"`
+	CustomerNotes *string                `json:"customer_notes,omitempty"`
+	PaymentMethod *string                `json:"payment_method,omitempty"`
+	ConvertedBy   string                 `json:"converted_by" validate:"required,uuid"`
+}
+
+// ListQuotationsRequest represents a request to list quotations
+type ListQuotationsRequest struct {
+	Search       string                     `json:"search,omitempty"`
+	Status       []entities.QuotationStatus `json:"status,omitempty"`
+	CustomerID   *string                    `json:"customer_id,omitempty"`
+	AllRevisions bool                       `json:"all_revisions,omitempty"`
+	Page         int                        `json:"page"`
+	Limit        int                        `json:"limit"`
+}
+
+// ListQuotationsResponse represents a paginated list of quotations
+type ListQuotationsResponse struct {
+	Quotations []*entities.Quotation `json:"quotations"`
+	Pagination *Pagination           `json:"pagination"`
+}
+
+// Quotation errors
+var (
+	ErrQuotationNotFound        = errors.New("quotation not found")
+	ErrQuotationNotEditable     = errors.New("quotation cannot be modified")
+	ErrQuotationNotAccepted     = errors.New("quotation is not accepted")
+	ErrQuotationExpired         = errors.New("quotation has expired")
+	ErrQuotationNotLatest       = errors.New("quotation has a newer revision")
+	ErrInvalidQuotationValidity = errors.New("invalid quotation validity date")
+)
+
+// QuotationServiceImpl implements the QuotationService interface
+type QuotationServiceImpl struct {
+	quotationRepo repositories.QuotationRepository
+	customerRepo  repositories.CustomerRepository
+	addressRepo   repositories.OrderAddressRepository
+	productRepo   productRepositories.ProductRepository
+	orderService  Service
+	txManager     database.TransactionManagerInterface
+	logger        *zerolog.Logger
+
+	defaultCurrency string
+}
+
+// NewQuotationService creates a new quotation service. Accepted quotations are
+// converted through the order service so converted orders follow the same rules
+// as orders created directly. Quotations without a currency of their own or of
+// the customer are priced in the base currency.
+func NewQuotationService(
+	quotationRepo repositories.QuotationRepository,
+	customerRepo repositories.CustomerRepository,
+	addressRepo repositories.OrderAddressRepository,
+	productRepo productRepositories.ProductRepository,
+	orderService Service,
+	baseCurrency string,
+	txManager database.TransactionManagerInterface,
+	logger *zerolog.Logger,
+) QuotationService {
+	return &QuotationServiceImpl{
+		quotationRepo:   quotationRepo,
+		customerRepo:    customerRepo,
+		addressRepo:     addressRepo,
+		productRepo:     productRepo,
+		orderService:    orderService,
+		txManager:       txManager,
+		logger:          logger,
+		defaultCurrency: baseCurrency,
+	}
+}
+
+// CreateQuotation creates a draft quotation priced from the product catalogue
+func (s *QuotationServiceImpl) CreateQuotation(ctx context.Context, req *CreateQuotationRequest) (*entities.Quotation, error) {
+	if len(req.Items) == 0 {
+		return nil, fmt.Errorf("%w: quotation must have at least one item", ErrInvalidQuantity)
+	}
+
+	createdBy, err := uuid.Parse(req.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid created by user ID: %w", err)
+	}
+
+	now := time.Now().UTC()
+	if !req.ValidUntil.After(now) {
+		return nil, fmt.Errorf("%w: valid until must be in the future", ErrInvalidQuotationValidity)
+	}
+	if req.ShippingAmount.LessThan(decimal.Zero) {
+		return nil, fmt.Errorf("shipping amount cannot be negative")
+	}
+
+	customer, err := findCustomer(ctx, s.customerRepo, req.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+	if !customer.IsActive {
+		return nil, fmt.Errorf("%w: customer is inactive", ErrCustomerNotFound)
+	}
+
+	shippingAddress, err := findAddress(ctx, s.addressRepo, req.ShippingAddressID)
+	if err != nil {
+		return nil, err
+	}
+	billingAddress, err := findAddress(ctx, s.addressRepo, req.BillingAddressID)
+	if err != nil {
+		return nil, err
+	}
+
+	currency := strings.ToUpper(strings.TrimSpace(req.Currency))
+	if currency == "" {
+		currency = customer.PreferredCurrency
+	}
+	if currency == "" {
+		currency = s.defaultCurrency
+	}
+
+	// The quotation number is allocated from the QUOTATION document sequence
+	// when the quotation is inserted.
+	quotation := &entities.Quotation{
+		ID:                uuid.New(),
+		Revision:          1,
+		CustomerID:        customer.ID,
+		Status:            entities.QuotationStatusDraft,
+		ValidUntil:        req.ValidUntil.UTC(),
+		ShippingMethod:    req.ShippingMethod,
+		ShippingAddressID: shippingAddress.ID,
+		BillingAddressID:  billingAddress.ID,
+		ShippingAmount:    req.ShippingAmount,
+		Currency:          currency,
+		Notes:             req.Notes,
+		CreatedBy:         createdBy,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+
+	if err := s.priceItems(ctx, quotation, req.Items); err != nil {
+		return nil, err
+	}
+
+	if err := quotation.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid quotation data: %w", err)
+	}
+
+	if err := s.quotationRepo.Create(ctx, quotation); err != nil {
+		return nil, fmt.Errorf("failed to create quotation: %w", err)
+	}
+
+	s.logger.Info().
+		Str("quotation_id", quotation.ID.String()).
+		Str("quotation_number", quotation.QuotationNumber).
+		Msg("Quotation created")
+
+	return quotation, nil
+}
+
+// GetQuotation retrieves a quotation by ID
+func (s *QuotationServiceImpl) GetQuotation(ctx context.Context, id string) (*entities.Quotation, error) {
+	return s.loadQuotation(ctx, id)
+}
+
+// GetQuotationByOrder retrieves the quotation an order was converted from
+func (s *QuotationServiceImpl) GetQuotationByOrder(ctx context.Context, orderID string) (*entities.Quotation, error) {
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order ID: %w", err)
+	}
+
+	quotation, err := s.quotationRepo.GetByOrderID(ctx, orderUUID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrQuotationNotFound
+		}
+		return nil, fmt.Errorf("failed to get quotation: %w", err)
+	}
+
+	return quotation, nil
+}
+
+// UpdateQuotation updates the terms and lines of a draft quotation
+func (s *QuotationServiceImpl) UpdateQuotation(ctx context.Context, id string, req *UpdateQuotationRequest) (*entities.Quotation, error) {
+	quotation, err := s.loadQuotation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !quotation.IsEditable() {
+		return nil, fmt.Errorf("%w: quotation is %s", ErrQuotationNotEditable, quotation.Status)
+	}
+
+	if req.ShippingMethod != nil {
+		quotation.ShippingMethod = *req.ShippingMethod
+	}
+	if req.ShippingAddressID != nil {
+		address, err := findAddress(ctx, s.addressRepo, *req.ShippingAddressID)
+		if err != nil {
+			return nil, err
+		}
+		quotation.ShippingAddressID = address.ID
+	}
+	if req.BillingAddressID != nil {
+		address, err := findAddress(ctx, s.addressRepo, *req.BillingAddressID)
+		if err != nil {
+			return nil, err
+		}
+		quotation.BillingAddressID = address.ID
+	}
+	if req.ValidUntil != nil {
+		if !req.ValidUntil.After(time.Now().UTC()) {
+			return nil, fmt.Errorf("%w: valid until must be in the future", ErrInvalidQuotationValidity)
+		}
+		quotation.ValidUntil = req.ValidUntil.UTC()
+	}
+	if req.ShippingAmount != nil {
+		if req.ShippingAmount.LessThan(decimal.Zero) {
+			return nil, fmt.Errorf("shipping amount cannot be negative")
+		}
+		quotation.ShippingAmount = *req.ShippingAmount
+	}
+	if req.Notes != nil {
+		quotation.Notes = req.Notes
+	}
+
+	if req.Items != nil {
+		if err := s.priceItems(ctx, quotation, req.Items); err != nil {
+			return nil, err
+		}
+	} else if _, err := quotation.CalculateTotals(); err != nil {
+		return nil, fmt.Errorf("failed to calculate quotation totals: %w", err)
+	}
+
+	if err := quotation.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid quotation data: %w", err)
+	}
+
+	if err := s.quotationRepo.Update(ctx, quotation); err != nil {
+		return nil, fmt.Errorf("failed to update quotation: %w", err)
+	}
+
+	return quotation, nil
+}
+
+// ListQuotations lists quotations, by default only the latest revision of each
+func (s *QuotationServiceImpl) ListQuotations(ctx context.Context, req *ListQuotationsRequest) (*ListQuotationsResponse, error) {
+	page, limit := normalizePage(req.Page, req.Limit)
+
+	filter := repositories.QuotationFilter{
+		Search:     req.Search,
+		Status:     req.Status,
+		LatestOnly: !req.AllRevisions,
+		Page:       page,
+		Limit:      limit,
+		Offset:     (page - 1) * limit,
+	}
+	if req.CustomerID != nil {
+		customerID, err := uuid.Parse(*req.CustomerID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid customer ID: %w", err)
+		}
+		filter.CustomerID = &customerID
+	}
+
+	quotations, err := s.quotationRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list quotations: %w", err)
+	}
+
+	total, err := s.quotationRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count quotations: %w", err)
+	}
+
+	return &ListQuotationsResponse{
+		Quotations: quotations,
+		Pagination: newPagination(page, limit, total),
+	}, nil
+}
+
+// GetQuotationRevisions returns all revisions of a quotation, oldest first
+func (s *QuotationServiceImpl) GetQuotationRevisions(ctx context.Context, id string) ([]*entities.Quotation, error) {
+	quotation, err := s.loadQuotation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.quotationRepo.GetRevisions(ctx, quotation.QuotationNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quotation revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// SendQuotation marks a draft quotation as sent to the customer
+func (s *QuotationServiceImpl) SendQuotation(ctx context.Context, id string) (*entities.Quotation, error) {
+	return s.transition(ctx, id, entities.QuotationStatusSent, nil)
+}
+
+// AcceptQuotation records the customer's acceptance of a quotation
+func (s *QuotationServiceImpl) AcceptQuotation(ctx context.Context, id string) (*entities.Quotation, error) {
+	return s.transition(ctx, id, entities.QuotationStatusAccepted, nil)
+}
+
+// RejectQuotation records the customer's rejection of a quotation
+func (s *QuotationServiceImpl) RejectQuotation(ctx context.Context, id string, reason string) (*entities.Quotation, error) {
+	return s.transition(ctx, id, entities.QuotationStatusRejected, func(q *entities.Quotation) {
+		if reason = strings.TrimSpace(reason); reason != "" {
+			q.RejectionReason = &reason
+		}
+	})
+}
+
+// ReviseQuotation creates a new draft revision of the latest revision of a
+// quotation. An open revision is superseded by the new one; rejected and
+// expired revisions keep their status.
+func (s *QuotationServiceImpl) ReviseQuotation(ctx context.Context, id string, req *ReviseQuotationRequest) (*entities.Quotation, error) {
+	revisedBy, err := uuid.Parse(req.RevisedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid revised by user ID: %w", err)
+	}
+
+	current, err := s.loadQuotation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch current.Status {
+	case entities.QuotationStatusAccepted, entities.QuotationStatusConverted, entities.QuotationStatusSuperseded:
+		return nil, fmt.Errorf("%w: quotation is %s", ErrQuotationNotEditable, current.Status)
+	}
+
+	revisions, err := s.quotationRepo.GetRevisions(ctx, current.QuotationNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quotation revisions: %w", err)
+	}
+	for _, revision := range revisions {
+		if revision.Revision > current.Revision {
+			return nil, fmt.Errorf("%w: revision %d exists", ErrQuotationNotLatest, revision.Revision)
+		}
+	}
+
+	validUntil := current.ValidUntil
+	if req.ValidUntil != nil {
+		validUntil = req.ValidUntil.UTC()
+	}
+	if !validUntil.After(time.Now().UTC()) {
+		return nil, fmt.Errorf("%w: valid until must be in the future", ErrInvalidQuotationValidity)
+	}
+
+	revision := current.NewRevision(validUntil, revisedBy)
+	if _, err := revision.CalculateTotals(); err != nil {
+		return nil, fmt.Errorf("failed to calculate quotation totals: %w", err)
+	}
+	if err := revision.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid quotation data: %w", err)
+	}
+
+	if !current.IsClosed() {
+		if err := current.ChangeStatus(entities.QuotationStatusSuperseded); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidStatusTransition, err)
+		}
+		if err := s.quotationRepo.Update(ctx, current); err != nil {
+			return nil, fmt.Errorf("failed to supersede quotation: %w", err)
+		}
+	}
+
+	if err := s.quotationRepo.Create(ctx, revision); err != nil {
+		return nil, fmt.Errorf("failed to create quotation revision: %w", err)
+	}
+
+	s.logger.Info().
+		Str("quotation_number", revision.QuotationNumber).
+		Int("revision", revision.Revision).
+		Msg("Quotation revised")
+
+	return revision, nil
+}
+
+// ConvertToOrder creates a PENDING sales order from an accepted quotation at
+// the quoted prices and links the quotation to it. The quotation is locked
+// while it converts, so it is converted into one order only.
+func (s *QuotationServiceImpl) ConvertToOrder(ctx context.Context, id string, req *ConvertQuotationRequest) (*entities.Order, error) {
+	var quotation *entities.Quotation
+	var order *entities.Order
+	err := database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		if quotation, err = s.lockQuotation(ctx, id); err != nil {
+			return err
+		}
+		if quotation.Status != entities.QuotationStatusAccepted {
+			return fmt.Errorf("%w: quotation is %s", ErrQuotationNotAccepted, quotation.Status)
+		}
+
+		notes := fmt.Sprintf("Converted from quotation %s revision %d", quotation.QuotationNumber, quotation.Revision)
+		if quotation.Notes != nil && *quotation.Notes != "" {
+			notes = notes + "\n" + *quotation.Notes
+		}
+
+		orderReq := &CreateOrderRequest{
+			CustomerID:        quotation.CustomerID.String(),
+			Type:              entities.OrderTypeSales,
+			Priority:          req.Priority,
+			ShippingMethod:    quotation.ShippingMethod,
+			ShippingAddressID: quotation.ShippingAddressID.String(),
+			BillingAddressID:  quotation.BillingAddressID.String(),
+			Currency:          quotation.Currency,
+			RequiredDate:      req.RequiredDate,
+			Notes:             &notes,
+			CustomerNotes:     req.CustomerNotes,
+			ShippingAmount:    quotation.ShippingAmount,
+			PaymentMethod:     req.PaymentMethod,
+			CreatedBy:         req.ConvertedBy,
+			Items:             make([]CreateOrderItemRequest, len(quotation.Items)),
+		}
+		for i, item := range quotation.Items {
+			orderReq.Items[i] = CreateOrderItemRequest{
+				ProductID:      item.ProductID.String(),
+				Quantity:       item.Quantity,
+				UnitPrice:      item.UnitPrice,
+				DiscountAmount: item.DiscountAmount,
+				TaxRate:        item.TaxRate,
+				Notes:          item.Notes,
+			}
+		}
+
+		if order, err = s.orderService.CreateOrder(ctx, orderReq); err != nil {
+			return err
+		}
+
+		if err := quotation.ChangeStatus(entities.QuotationStatusConverted); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidStatusTransition, err)
+		}
+		quotation.ConvertedOrderID = &order.ID
+
+		if err := s.quotationRepo.Update(ctx, quotation); err != nil {
+			s.logger.Error().Err(err).
+				Str("quotation_id", quotation.ID.String()).
+				Str("order_id", order.ID.String()).
+				Msg("Failed to link converted order to quotation")
+			return fmt.Errorf("failed to update quotation: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info().
+		Str("quotation_number", quotation.QuotationNumber).
+		Str("order_number", order.OrderNumber).
+		Msg("Quotation converted to order")
+
+	return order, nil
+}
+
+// ExpireQuotations closes open quotations whose validity ended before the given time
+func (s *QuotationServiceImpl) ExpireQuotations(ctx context.Context, at time.Time) (int, error) {
+	expired, err := s.quotationRepo.ExpireBefore(ctx, at)
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire quotations: %w", err)
+	}
+
+	if expired > 0 {
+		s.logger.Info().Int("count", expired).Msg("Expired quotations")
+	}
+
+	return expired, nil
+}
+
+// transition moves a quotation to a new status and persists it
+func (s *QuotationServiceImpl) transition(ctx context.Context, id string, status entities.QuotationStatus, apply func(*entities.Quotation)) (*entities.Quotation, error) {
+	quotation, err := s.loadQuotation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if quotation.IsExpiredAt(time.Now().UTC()) && status != entities.QuotationStatusRejected {
+		return nil, fmt.Errorf("%w: valid until %s", ErrQuotationExpired, quotation.ValidUntil.Format("2006-01-02"))
+	}
+
+	if err := quotation.ChangeStatus(status); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStatusTransition, err)
+	}
+	if apply != nil {
+		apply(quotation)
+	}
+
+	if err := s.quotationRepo.Update(ctx, quotation); err != nil {
+		return nil, fmt.Errorf("failed to update quotation: %w", err)
+	}
+
+	return quotation, nil
+}
+
+// priceItems replaces the quotation lines with lines priced like order lines
+func (s *QuotationServiceImpl) priceItems(ctx context.Context, quotation *entities.Quotation, items []CreateOrderItemRequest) error {
+	if len(items) == 0 {
+		return fmt.Errorf("%w: quotation must have at least one item", ErrInvalidQuantity)
+	}
+
+	quotation.Items = make([]entities.OrderItem, 0, len(items))
+	for _, itemReq := range items {
+		item, err := newPricedItem(ctx, s.productRepo, quotation.ID, itemReq.ProductID, itemReq.Quantity, itemReq.UnitPrice, itemReq.DiscountAmount, itemReq.TaxRate, itemReq.Notes)
+		if err != nil {
+			return err
+		}
+		quotation.Items = append(quotation.Items, *item)
+	}
+
+	if _, err := quotation.CalculateTotals(); err != nil {
+		return fmt.Errorf("failed to calculate quotation totals: %w", err)
+	}
+
+	return nil
+}
+
+// loadQuotation parses the ID and loads the quotation, mapping missing rows to ErrQuotationNotFound
+// lockQuotation locks a quotation for the rest of the context's transaction
+// and loads it
+func (s *QuotationServiceImpl) lockQuotation(ctx context.Context, id string) (*entities.Quotation, error) {
+	quotationID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid quotation ID: %w", err)
+	}
+
+	if err := s.quotationRepo.Lock(ctx, quotationID); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrQuotationNotFound
+		}
+		return nil, fmt.Errorf("failed to lock quotation: %w", err)
+	}
+
+	return s.loadQuotation(ctx, id)
+}
+
+func (s *QuotationServiceImpl) loadQuotation(ctx context.Context, id string) (*entities.Quotation, error) {
+	quotationID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid quotation ID: %w", err)
+	}
+
+	quotation, err := s.quotationRepo.GetByID(ctx, quotationID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrQuotationNotFound
+		}
+		return nil, fmt.Errorf("failed to get quotation: %w", err)
+	}
+
+	return quotation, nil
+}
@@ -0,0 +1,101 @@
+package order
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"erpgo/internal/domain/orders/entities"
+)
+
+// newTestQuotationService returns a quotation service converting through the
+// order service of the store
+func newTestQuotationService(t *testing.T) (*QuotationServiceImpl, *memoryStore) {
+	t.Helper()
+
+	orders, store := newTestService(t)
+	service := &QuotationServiceImpl{
+		quotationRepo:   &fakeQuotationRepository{store: store},
+		customerRepo:    orders.customerRepo,
+		addressRepo:     orders.addressRepo,
+		productRepo:     orders.productRepo,
+		orderService:    orders,
+		txManager:       orders.txManager,
+		logger:          orders.logger,
+		defaultCurrency: "USD",
+	}
+	return service, store
+}
+
+// acceptedQuotation stores an accepted quotation for a quantity of the
+// fixture's product at a quoted price
+func (f *orderFixture) acceptedQuotation(store *memoryStore, quantity int, unitPrice int64) *entities.Quotation {
+	now := time.Now().UTC()
+	quotation := &entities.Quotation{
+		ID:                uuid.New(),
+		QuotationNumber:   "QT-000001",
+		Revision:          1,
+		CustomerID:        f.customer.ID,
+		Status:            entities.QuotationStatusAccepted,
+		ValidUntil:        now.AddDate(0, 0, 30),
+		ShippingMethod:    entities.ShippingMethodStandard,
+		ShippingAddressID: f.address.ID,
+		BillingAddressID:  f.address.ID,
+		Currency:          "USD",
+		Items: []entities.OrderItem{{
+			ID:        uuid.New(),
+			ProductID: f.product.ID,
+			Quantity:  quantity,
+			UnitPrice: decimal.NewFromInt(unitPrice),
+		}},
+		CreatedBy:  f.user,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		AcceptedAt: &now,
+	}
+	store.quotations[quotation.ID] = quotation
+	return quotation
+}
+
+func TestQuotationServiceImpl_ConvertToOrder(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("accepted quotation becomes an order once", func(t *testing.T) {
+		service, store := newTestQuotationService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		quotation := fixture.acceptedQuotation(store, 2, 45)
+		req := &ConvertQuotationRequest{ConvertedBy: fixture.user.String()}
+
+		order, err := service.ConvertToOrder(ctx, quotation.ID.String(), req)
+		require.NoError(t, err)
+
+		assert.Equal(t, entities.OrderStatusPending, order.Status)
+		assert.True(t, decimal.NewFromInt(90).Equal(order.TotalAmount), "total is %s", order.TotalAmount)
+		assert.Equal(t, quotation.ID, store.locked[0])
+		assert.Empty(t, store.untransacted())
+
+		converted := store.quotations[quotation.ID]
+		assert.Equal(t, entities.QuotationStatusConverted, converted.Status)
+		require.NotNil(t, converted.ConvertedOrderID)
+		assert.Equal(t, order.ID, *converted.ConvertedOrderID)
+		store.resetWrites()
+
+		_, err = service.ConvertToOrder(ctx, quotation.ID.String(), req)
+		assert.ErrorIs(t, err, ErrQuotationNotAccepted)
+		assert.Empty(t, store.ops())
+		assert.Len(t, store.orders, 1)
+	})
+
+	t.Run("unknown quotations are not converted", func(t *testing.T) {
+		service, store := newTestQuotationService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+
+		_, err := service.ConvertToOrder(ctx, uuid.NewString(), &ConvertQuotationRequest{ConvertedBy: fixture.user.String()})
+		assert.ErrorIs(t, err, ErrQuotationNotFound)
+	})
+}
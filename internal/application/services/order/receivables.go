@@ -0,0 +1,91 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"erpgo/internal/domain/orders/entities"
+)
+
+// GetARAgingReportRequest represents a request for the accounts receivable aging
+type GetARAgingReportRequest struct {
+	AsOf       *time.Time `json:"as_of,omitempty"`
+	CustomerID *string    `json:"customer_id,omitempty"`
+}
+
+// MarkOverdueOrders flags shipped orders whose balance is unpaid past the due
+// date of their customer's payment terms as OVERDUE and returns how many were
+// flagged
+func (s *ServiceImpl) MarkOverdueOrders(ctx context.Context, asOf time.Time) (int, error) {
+	orders, err := s.orderRepo.GetOpenReceivables(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	marked := 0
+	for _, order := range orders {
+		switch order.PaymentStatus {
+		case entities.PaymentStatusPending, entities.PaymentStatusPartiallyPaid:
+		default:
+			continue
+		}
+
+		terms := order.Customer.Terms
+		if !order.IsPaymentOverdue(terms, asOf) {
+			continue
+		}
+
+		due := order.PaymentDueDate(terms)
+		if err := s.markOverdue(ctx, order, fmt.Sprintf("payment due %s under %s terms", due.Format("2006-01-02"), terms)); err != nil {
+			return marked, err
+		}
+		marked++
+	}
+
+	if marked > 0 {
+		s.logger.Info().Int("count", marked).Msg("Marked orders overdue")
+	}
+
+	return marked, nil
+}
+
+// GetARAgingReport ages the open receivables of every customer, or of one
+// customer, in the base currency
+func (s *ServiceImpl) GetARAgingReport(ctx context.Context, req *GetARAgingReportRequest) (*entities.ARAgingReport, error) {
+	asOf := time.Now().UTC()
+	if req.AsOf != nil {
+		asOf = req.AsOf.UTC()
+	}
+
+	var customerID *uuid.UUID
+	if req.CustomerID != nil {
+		id, err := uuid.Parse(*req.CustomerID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid customer ID: %w", err)
+		}
+		customerID = &id
+	}
+
+	orders, err := s.orderRepo.GetOpenReceivables(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.BuildARAgingReport(orders, asOf, s.defaultCurrency), nil
+}
+
+// markOverdue flips the payment status of an order to OVERDUE and records the
+// change with the outstanding balance in the order history
+func (s *ServiceImpl) markOverdue(ctx context.Context, order *entities.Order, reason string) error {
+	previousPaymentStatus := order.PaymentStatus
+	order.PaymentStatus = entities.PaymentStatusOverdue
+
+	if err := s.orderRepo.UpdatePaymentStatus(ctx, order.ID, order.PaymentStatus, order.PaidAmount); err != nil {
+		return err
+	}
+
+	return s.recordPaymentEvent(ctx, order, previousPaymentStatus, order.GetOutstandingBalance(), reason)
+}
@@ -0,0 +1,42 @@
+package order
+
+import (
+	"context"
+	"fmt"
+
+	"erpgo/internal/domain/orders/entities"
+)
+
+// RecurringOrderNotifier tells customers about orders generated by their
+// recurring orders. Orders are passed with their customer loaded.
+type RecurringOrderNotifier interface {
+	RecurringOrderGenerated(ctx context.Context, recurringOrder *entities.RecurringOrder, order *entities.Order) error
+}
+
+type emailRecurringOrderNotifier struct {
+	sender EmailSender
+}
+
+// NewEmailRecurringOrderNotifier creates a notifier that emails the order's customer
+func NewEmailRecurringOrderNotifier(sender EmailSender) RecurringOrderNotifier {
+	return &emailRecurringOrderNotifier{sender: sender}
+}
+
+func (n *emailRecurringOrderNotifier) RecurringOrderGenerated(ctx context.Context, recurringOrder *entities.RecurringOrder, order *entities.Order) error {
+	lines := make([]string, 0, len(order.Items)+1)
+	for _, item := range order.Items {
+		lines = append(lines, fmt.Sprintf("%s: %d x %s %s", item.ProductName, item.Quantity, item.UnitPrice.StringFixed(2), order.Currency))
+	}
+	lines = append(lines, fmt.Sprintf("Total: %s %s", order.TotalAmount.StringFixed(2), order.Currency))
+
+	intro := fmt.Sprintf("Your recurring order %s has been placed as order %s at current prices.", recurringOrder.Name, order.OrderNumber)
+	if recurringOrder.NextRunAt != nil {
+		intro += fmt.Sprintf(" The next order will be placed on %s.", recurringOrder.NextRunAt.Format("2006-01-02"))
+	}
+
+	return emailCustomer(n.sender, order,
+		fmt.Sprintf("Order %s placed from your recurring order", order.OrderNumber),
+		intro,
+		lines,
+	)
+}
@@ -0,0 +1,698 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
+	productRepositories "erpgo/internal/domain/products/repositories"
+)
+
+// RecurringOrderService defines the interface for recurring order management
+type RecurringOrderService interface {
+	CreateRecurringOrder(ctx context.Context, req *CreateRecurringOrderRequest) (*entities.RecurringOrder, error)
+	GetRecurringOrder(ctx context.Context, id string) (*entities.RecurringOrder, error)
+	UpdateRecurringOrder(ctx context.Context, id string, req *UpdateRecurringOrderRequest) (*entities.RecurringOrder, error)
+	ListRecurringOrders(ctx context.Context, req *ListRecurringOrdersRequest) (*ListRecurringOrdersResponse, error)
+	GetRecurringOrderRuns(ctx context.Context, id string) ([]*entities.RecurringOrderRun, error)
+
+	PauseRecurringOrder(ctx context.Context, id string) (*entities.RecurringOrder, error)
+	ResumeRecurringOrder(ctx context.Context, id string) (*entities.RecurringOrder, error)
+	SkipNextOccurrence(ctx context.Context, id string, req *SkipRecurringOrderRequest) (*entities.RecurringOrder, error)
+	CancelRecurringOrder(ctx context.Context, id string) (*entities.RecurringOrder, error)
+
+	// GenerateDueOrders generates the orders of active recurring orders due at
+	// the given time and returns the number of orders generated
+	GenerateDueOrders(ctx context.Context, at time.Time) (int, error)
+}
+
+// CreateRecurringOrderRequest represents a request to create a recurring
+// order. With a source order, the customer, addresses, shipping method,
+// currency and lines not given are copied from that order.
+type CreateRecurringOrderRequest struct {
+	Name              string                      `json:"name" validate:"required"`
+	SourceOrderID     *string                     `json:"source_order_id,omitempty" validate:"omitempty,uuid"`
+	CustomerID        string                      `json:"customer_id" validate:"omitempty,uuid"`
+	Priority          entities.OrderPriority      `json:"priority"`
+	ShippingMethod    entities.ShippingMethod     `json:"shipping_method"`
+	ShippingAddressID string                      `json:"shipping_address_id" validate:"omitempty,uuid"`
+	BillingAddressID  string                      `json:"billing_address_id" validate:"omitempty,uuid"`
+	Currency          string                      `json:"currency" validate:"omitempty,len=3"`
+	Notes             *string                     `json:"notes,omitempty"`
+	Items             []RecurringOrderItemRequest `json:"items,omitempty"`
+
+	Frequency  entities.RecurringOrderFrequency `json:"frequency" validate:"required"`
+	Interval   int                              `json:"interval"`
+	DayOfMonth *int                             `json:"day_of_month,omitempty"`
+	StartDate  time.Time                        `json:"start_date" validate:"required"`
+	EndDate    *time.Time                       `json:"end_date,omitempty"`
+
+	CreatedBy string `json:"created_by" validate:"required,uuid"`
+}
+
+// RecurringOrderItemRequest represents a template line of a recurring order
+type RecurringOrderItemRequest struct {
+	ProductID string  `json:"product_id" validate:"required,uuid"`
+	Quantity  int     `json:"quantity" validate:"required,min=1"`
+	Notes     *string `json:"notes,omitempty"`
+}
+
+// UpdateRecurringOrderRequest represents a request to update an open
+// recurring order. When Items is set the template lines are replaced; a
+// changed schedule is recalculated from the time of the update.
+type UpdateRecurringOrderRequest struct {
+	Name              *string                     `json:"name,omitempty"`
+	Priority          *entities.OrderPriority     `json:"priority,omitempty"`
+	ShippingMethod    *entities.ShippingMethod    `json:"shipping_method,omitempty"`
+	ShippingAddressID *string                     `json:"shipping_address_id,omitempty"`
+	BillingAddressID  *string                     `json:"billing_address_id,omitempty"`
+	Currency          *string                     `json:"currency,omitempty"`
+	Notes             *string                     `json:"notes,omitempty"`
+	Items             []RecurringOrderItemRequest `json:"items,omitempty"`
+
+	Frequency  *entities.RecurringOrderFrequency `json:"frequency,omitempty"`
+	Interval   *int                              `json:"interval,omitempty"`
+	DayOfMonth *int                              `json:"day_of_month,omitempty"`
+	StartDate  *time.Time                        `json:"start_date,omitempty"`
+	EndDate    *time.Time                        `json:"end_date,omitempty"`
+}
+
+// SkipRecurringOrderRequest represents a request to skip the next occurrence of a recurring order
+type SkipRecurringOrderRequest struct {
+	Reason    *string `json:"reason,omitempty"`
+	SkippedBy string  `json:"skipped_by" validate:"required,uuid"`
+}
+
+// ListRecurringOrdersRequest represents a request to list recurring orders
+type ListRecurringOrdersRequest struct {
+	Search     string                          `json:"search,omitempty"`
+	Status     []entities.RecurringOrderStatus `json:"status,omitempty"`
+	CustomerID *string                         `json:"customer_id,omitempty"`
+	Page       int                             `json:"page"`
+	Limit      int                             `json:"limit"`
+}
+
+// ListRecurringOrdersResponse represents a paginated list of recurring orders
+type ListRecurringOrdersResponse struct {
+	RecurringOrders []*entities.RecurringOrder `json:"recurring_orders"`
+	Pagination      *Pagination                `json:"pagination"`
+}
+
+// Recurring order errors
+var (
+	ErrRecurringOrderNotFound = errors.New("recurring order not found")
+	ErrRecurringOrderClosed   = errors.New("recurring order is closed")
+)
+
+// dueRecurringOrderBatch is the number of due recurring orders generated per query
+const dueRecurringOrderBatch = 100
+
+// RecurringOrderServiceImpl implements the RecurringOrderService interface
+type RecurringOrderServiceImpl struct {
+	recurringOrderRepo repositories.RecurringOrderRepository
+	customerRepo       repositories.CustomerRepository
+	addressRepo        repositories.OrderAddressRepository
+	productRepo        productRepositories.ProductRepository
+	orderService       Service
+	notifier           RecurringOrderNotifier
+	logger             *zerolog.Logger
+}
+
+// NewRecurringOrderService creates a new recurring order service. Orders are
+// generated through the order service, so generated orders are priced, taxed
+// and approved like orders created directly.
+func NewRecurringOrderService(
+	recurringOrderRepo repositories.RecurringOrderRepository,
+	customerRepo repositories.CustomerRepository,
+	addressRepo repositories.OrderAddressRepository,
+	productRepo productRepositories.ProductRepository,
+	orderService Service,
+	notifier RecurringOrderNotifier,
+	logger *zerolog.Logger,
+) RecurringOrderService {
+	return &RecurringOrderServiceImpl{
+		recurringOrderRepo: recurringOrderRepo,
+		customerRepo:       customerRepo,
+		addressRepo:        addressRepo,
+		productRepo:        productRepo,
+		orderService:       orderService,
+		notifier:           notifier,
+		logger:             logger,
+	}
+}
+
+// CreateRecurringOrder creates an active recurring order whose first
+// occurrence is the first one on or after both its start date and now
+func (s *RecurringOrderServiceImpl) CreateRecurringOrder(ctx context.Context, req *CreateRecurringOrderRequest) (*entities.RecurringOrder, error) {
+	createdBy, err := uuid.Parse(req.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid created by user ID: %w", err)
+	}
+
+	customerID := req.CustomerID
+	shippingAddressID := req.ShippingAddressID
+	billingAddressID := req.BillingAddressID
+	shippingMethod := req.ShippingMethod
+	currency := req.Currency
+	items := req.Items
+
+	if req.SourceOrderID != nil {
+		source, err := s.orderService.GetOrder(ctx, *req.SourceOrderID)
+		if err != nil {
+			return nil, err
+		}
+		if customerID == "" {
+			customerID = source.CustomerID.String()
+		}
+		if shippingAddressID == "" {
+			shippingAddressID = source.ShippingAddressID.String()
+		}
+		if billingAddressID == "" {
+			billingAddressID = source.BillingAddressID.String()
+		}
+		if shippingMethod == "" {
+			shippingMethod = source.ShippingMethod
+		}
+		if currency == "" {
+			currency = source.Currency
+		}
+		if len(items) == 0 {
+			for _, item := range source.Items {
+				items = append(items, RecurringOrderItemRequest{
+					ProductID: item.ProductID.String(),
+					Quantity:  item.Quantity,
+					Notes:     item.Notes,
+				})
+			}
+		}
+	}
+
+	if len(items) == 0 {
+		return nil, fmt.Errorf("%w: recurring order must have at least one item", ErrInvalidQuantity)
+	}
+	if shippingMethod == "" {
+		shippingMethod = entities.ShippingMethodStandard
+	}
+
+	customer, err := findCustomer(ctx, s.customerRepo, customerID)
+	if err != nil {
+		return nil, err
+	}
+	if !customer.IsActive {
+		return nil, fmt.Errorf("%w: customer is inactive", ErrCustomerNotFound)
+	}
+
+	shippingAddress, err := findAddress(ctx, s.addressRepo, shippingAddressID)
+	if err != nil {
+		return nil, err
+	}
+	billingAddress, err := findAddress(ctx, s.addressRepo, billingAddressID)
+	if err != nil {
+		return nil, err
+	}
+
+	priority := req.Priority
+	if priority == "" {
+		priority = entities.OrderPriorityNormal
+	}
+	interval := req.Interval
+	if interval == 0 {
+		interval = 1
+	}
+
+	now := time.Now().UTC()
+	recurringOrder := &entities.RecurringOrder{
+		ID:                uuid.New(),
+		Name:              strings.TrimSpace(req.Name),
+		CustomerID:        customer.ID,
+		Status:            entities.RecurringOrderStatusActive,
+		Priority:          priority,
+		ShippingMethod:    shippingMethod,
+		ShippingAddressID: shippingAddress.ID,
+		BillingAddressID:  billingAddress.ID,
+		Currency:          strings.ToUpper(strings.TrimSpace(currency)),
+		Notes:             trimmedOrNil(req.Notes),
+		Frequency:         entities.RecurringOrderFrequency(strings.ToUpper(string(req.Frequency))),
+		Interval:          interval,
+		DayOfMonth:        req.DayOfMonth,
+		StartDate:         req.StartDate.UTC().Truncate(time.Second),
+		EndDate:           utcOrNil(req.EndDate),
+		CreatedBy:         createdBy,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+
+	if recurringOrder.Items, err = s.templateItems(ctx, recurringOrder.ID, items, now); err != nil {
+		return nil, err
+	}
+
+	if err := recurringOrder.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid recurring order data: %w", err)
+	}
+	recurringOrder.Reschedule(now)
+
+	if err := s.recurringOrderRepo.Create(ctx, recurringOrder); err != nil {
+		return nil, fmt.Errorf("failed to create recurring order: %w", err)
+	}
+
+	logEvent := s.logger.Info().
+		Str("recurring_order_id", recurringOrder.ID.String()).
+		Str("customer_id", recurringOrder.CustomerID.String()).
+		Str("frequency", string(recurringOrder.Frequency)).
+		Int("interval", recurringOrder.Interval)
+	if recurringOrder.NextRunAt != nil {
+		logEvent = logEvent.Time("next_run_at", *recurringOrder.NextRunAt)
+	}
+	logEvent.Msg("Recurring order created")
+
+	return recurringOrder, nil
+}
+
+// GetRecurringOrder retrieves a recurring order with its template lines
+func (s *RecurringOrderServiceImpl) GetRecurringOrder(ctx context.Context, id string) (*entities.RecurringOrder, error) {
+	return s.loadRecurringOrder(ctx, id)
+}
+
+// UpdateRecurringOrder updates the template or schedule of an active or paused recurring order
+func (s *RecurringOrderServiceImpl) UpdateRecurringOrder(ctx context.Context, id string, req *UpdateRecurringOrderRequest) (*entities.RecurringOrder, error) {
+	recurringOrder, err := s.loadRecurringOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if recurringOrder.IsClosed() {
+		return nil, fmt.Errorf("%w: recurring order is %s", ErrRecurringOrderClosed, recurringOrder.Status)
+	}
+
+	now := time.Now().UTC()
+
+	if req.Name != nil {
+		recurringOrder.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Priority != nil {
+		recurringOrder.Priority = *req.Priority
+	}
+	if req.ShippingMethod != nil {
+		recurringOrder.ShippingMethod = *req.ShippingMethod
+	}
+	if req.ShippingAddressID != nil {
+		address, err := findAddress(ctx, s.addressRepo, *req.ShippingAddressID)
+		if err != nil {
+			return nil, err
+		}
+		recurringOrder.ShippingAddressID = address.ID
+	}
+	if req.BillingAddressID != nil {
+		address, err := findAddress(ctx, s.addressRepo, *req.BillingAddressID)
+		if err != nil {
+			return nil, err
+		}
+		recurringOrder.BillingAddressID = address.ID
+	}
+	if req.Currency != nil {
+		recurringOrder.Currency = strings.ToUpper(strings.TrimSpace(*req.Currency))
+	}
+	if req.Notes != nil {
+		recurringOrder.Notes = trimmedOrNil(req.Notes)
+	}
+	if req.Items != nil {
+		if len(req.Items) == 0 {
+			return nil, fmt.Errorf("%w: recurring order must have at least one item", ErrInvalidQuantity)
+		}
+		if recurringOrder.Items, err = s.templateItems(ctx, recurringOrder.ID, req.Items, now); err != nil {
+			return nil, err
+		}
+	}
+
+	rescheduled := false
+	if req.Frequency != nil {
+		recurringOrder.Frequency = entities.RecurringOrderFrequency(strings.ToUpper(string(*req.Frequency)))
+		rescheduled = true
+	}
+	if req.Interval != nil {
+		recurringOrder.Interval = *req.Interval
+		rescheduled = true
+	}
+	if req.DayOfMonth != nil {
+		recurringOrder.DayOfMonth = req.DayOfMonth
+		rescheduled = true
+	}
+	if req.StartDate != nil {
+		recurringOrder.StartDate = req.StartDate.UTC().Truncate(time.Second)
+		rescheduled = true
+	}
+	if req.EndDate != nil {
+		recurringOrder.EndDate = utcOrNil(req.EndDate)
+		rescheduled = true
+	}
+	recurringOrder.UpdatedAt = now
+
+	if err := recurringOrder.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid recurring order data: %w", err)
+	}
+	if rescheduled {
+		recurringOrder.Reschedule(now)
+	}
+
+	if err := s.recurringOrderRepo.Update(ctx, recurringOrder); err != nil {
+		return nil, fmt.Errorf("failed to update recurring order: %w", err)
+	}
+
+	return recurringOrder, nil
+}
+
+// ListRecurringOrders lists recurring orders, next occurrence first
+func (s *RecurringOrderServiceImpl) ListRecurringOrders(ctx context.Context, req *ListRecurringOrdersRequest) (*ListRecurringOrdersResponse, error) {
+	page, limit := normalizePage(req.Page, req.Limit)
+
+	filter := repositories.RecurringOrderFilter{
+		Search: req.Search,
+		Status: req.Status,
+		Page:   page,
+		Limit:  limit,
+		Offset: (page - 1) * limit,
+	}
+	if req.CustomerID != nil {
+		customerID, err := uuid.Parse(*req.CustomerID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid customer ID: %w", err)
+		}
+		filter.CustomerID = &customerID
+	}
+
+	recurringOrders, err := s.recurringOrderRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recurring orders: %w", err)
+	}
+
+	total, err := s.recurringOrderRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count recurring orders: %w", err)
+	}
+
+	return &ListRecurringOrdersResponse{
+		RecurringOrders: recurringOrders,
+		Pagination:      newPagination(page, limit, total),
+	}, nil
+}
+
+// GetRecurringOrderRuns returns the occurrences of a recurring order with the
+// orders generated for them, latest first
+func (s *RecurringOrderServiceImpl) GetRecurringOrderRuns(ctx context.Context, id string) ([]*entities.RecurringOrderRun, error) {
+	recurringOrder, err := s.loadRecurringOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	runs, err := s.recurringOrderRepo.GetRuns(ctx, recurringOrder.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recurring order runs: %w", err)
+	}
+
+	return runs, nil
+}
+
+// PauseRecurringOrder stops an active recurring order from generating orders
+func (s *RecurringOrderServiceImpl) PauseRecurringOrder(ctx context.Context, id string) (*entities.RecurringOrder, error) {
+	return s.transition(ctx, id, (*entities.RecurringOrder).Pause)
+}
+
+// ResumeRecurringOrder reactivates a paused recurring order. Occurrences that
+// fell while it was paused are not generated.
+func (s *RecurringOrderServiceImpl) ResumeRecurringOrder(ctx context.Context, id string) (*entities.RecurringOrder, error) {
+	return s.transition(ctx, id, (*entities.RecurringOrder).Resume)
+}
+
+// CancelRecurringOrder closes a recurring order; orders already generated are kept
+func (s *RecurringOrderServiceImpl) CancelRecurringOrder(ctx context.Context, id string) (*entities.RecurringOrder, error) {
+	return s.transition(ctx, id, (*entities.RecurringOrder).Cancel)
+}
+
+// SkipNextOccurrence moves a recurring order past its next occurrence without
+// generating an order, recording the skipped occurrence
+func (s *RecurringOrderServiceImpl) SkipNextOccurrence(ctx context.Context, id string, req *SkipRecurringOrderRequest) (*entities.RecurringOrder, error) {
+	skippedBy, err := uuid.Parse(req.SkippedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid skipped by user ID: %w", err)
+	}
+
+	recurringOrder, err := s.loadRecurringOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	skipped, err := recurringOrder.Skip(now)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRecurringOrderClosed, err)
+	}
+
+	if err := s.recurringOrderRepo.Update(ctx, recurringOrder); err != nil {
+		return nil, fmt.Errorf("failed to update recurring order: %w", err)
+	}
+
+	run := &entities.RecurringOrderRun{
+		ID:               uuid.New(),
+		RecurringOrderID: recurringOrder.ID,
+		ScheduledFor:     skipped,
+		Status:           entities.RecurringOrderRunSkipped,
+		Reason:           trimmedOrNil(req.Reason),
+		CreatedBy:        &skippedBy,
+		CreatedAt:        now,
+	}
+	if err := s.recurringOrderRepo.CreateRun(ctx, run); err != nil {
+		return nil, fmt.Errorf("failed to record skipped occurrence: %w", err)
+	}
+
+	s.logger.Info().
+		Str("recurring_order_id", recurringOrder.ID.String()).
+		Time("skipped", skipped).
+		Str("skipped_by", skippedBy.String()).
+		Msg("Recurring order occurrence skipped")
+
+	return recurringOrder, nil
+}
+
+// GenerateDueOrders generates an order for each active recurring order due at
+// the given time. Each occurrence is claimed before its order is generated,
+// so concurrent runs never generate it twice. An order that cannot be
+// generated, as when a product was discontinued, is recorded as a failed run
+// and the schedule moves on.
+func (s *RecurringOrderServiceImpl) GenerateDueOrders(ctx context.Context, at time.Time) (int, error) {
+	generated := 0
+	for {
+		due, err := s.recurringOrderRepo.ListDue(ctx, at, dueRecurringOrderBatch)
+		if err != nil {
+			return generated, fmt.Errorf("failed to list due recurring orders: %w", err)
+		}
+
+		for _, recurringOrder := range due {
+			ok, err := s.generate(ctx, recurringOrder, at)
+			if err != nil {
+				return generated, err
+			}
+			if ok {
+				generated++
+			}
+		}
+
+		if len(due) < dueRecurringOrderBatch {
+			break
+		}
+	}
+
+	if generated > 0 {
+		s.logger.Info().Int("count", generated).Msg("Recurring orders generated")
+	}
+
+	return generated, nil
+}
+
+// generate claims the due occurrence of a recurring order, generates its
+// order and records the run. It reports whether an order was generated.
+func (s *RecurringOrderServiceImpl) generate(ctx context.Context, recurringOrder *entities.RecurringOrder, at time.Time) (bool, error) {
+	if !recurringOrder.IsDueAt(at) {
+		return false, nil
+	}
+
+	dueAt := *recurringOrder.NextRunAt
+	recurringOrder.Advance(at)
+
+	claimed, err := s.recurringOrderRepo.ClaimOccurrence(ctx, recurringOrder, dueAt)
+	if err != nil {
+		return false, err
+	}
+	if !claimed {
+		return false, nil
+	}
+
+	run := &entities.RecurringOrderRun{
+		ID:               uuid.New(),
+		RecurringOrderID: recurringOrder.ID,
+		ScheduledFor:     dueAt,
+		Status:           entities.RecurringOrderRunGenerated,
+		CreatedAt:        time.Now().UTC(),
+	}
+
+	order, err := s.orderService.CreateOrder(ctx, s.orderRequest(recurringOrder, dueAt))
+	if err != nil {
+		reason := err.Error()
+		run.Status = entities.RecurringOrderRunFailed
+		run.Reason = &reason
+
+		s.logger.Error().Err(err).
+			Str("recurring_order_id", recurringOrder.ID.String()).
+			Time("scheduled_for", dueAt).
+			Msg("Failed to generate recurring order")
+	} else {
+		run.OrderID = &order.ID
+		run.OrderNumber = &order.OrderNumber
+	}
+
+	if err := s.recurringOrderRepo.CreateRun(ctx, run); err != nil {
+		s.logger.Error().Err(err).
+			Str("recurring_order_id", recurringOrder.ID.String()).
+			Time("scheduled_for", dueAt).
+			Msg("Failed to record recurring order run")
+	}
+
+	if order == nil {
+		return false, nil
+	}
+
+	s.logger.Info().
+		Str("recurring_order_id", recurringOrder.ID.String()).
+		Str("order_number", order.OrderNumber).
+		Time("scheduled_for", dueAt).
+		Msg("Recurring order generated")
+
+	if s.notifier != nil {
+		if err := s.notifier.RecurringOrderGenerated(ctx, recurringOrder, order); err != nil {
+			s.logger.Error().Err(err).Str("order_number", order.OrderNumber).Msg("Failed to notify customer of recurring order")
+		}
+	}
+
+	return true, nil
+}
+
+// orderRequest builds the order of an occurrence. Lines carry no prices so
+// the order is priced from the catalogue.
+func (s *RecurringOrderServiceImpl) orderRequest(recurringOrder *entities.RecurringOrder, dueAt time.Time) *CreateOrderRequest {
+	notes := fmt.Sprintf("Generated by recurring order %s for %s", recurringOrder.Name, dueAt.Format("2006-01-02"))
+	if recurringOrder.Notes != nil && *recurringOrder.Notes != "" {
+		notes = notes + "\n" + *recurringOrder.Notes
+	}
+
+	req := &CreateOrderRequest{
+		CustomerID:        recurringOrder.CustomerID.String(),
+		Type:              entities.OrderTypeSales,
+		Priority:          recurringOrder.Priority,
+		ShippingMethod:    recurringOrder.ShippingMethod,
+		ShippingAddressID: recurringOrder.ShippingAddressID.String(),
+		BillingAddressID:  recurringOrder.BillingAddressID.String(),
+		Currency:          recurringOrder.Currency,
+		Notes:             &notes,
+		CreatedBy:         recurringOrder.CreatedBy.String(),
+		Items:             make([]CreateOrderItemRequest, len(recurringOrder.Items)),
+	}
+	for i, item := range recurringOrder.Items {
+		req.Items[i] = CreateOrderItemRequest{
+			ProductID: item.ProductID.String(),
+			Quantity:  item.Quantity,
+			Notes:     item.Notes,
+		}
+	}
+
+	return req
+}
+
+// templateItems builds the template lines of a recurring order, checking that their products can be ordered
+func (s *RecurringOrderServiceImpl) templateItems(ctx context.Context, recurringOrderID uuid.UUID, reqs []RecurringOrderItemRequest, now time.Time) ([]entities.RecurringOrderItem, error) {
+	items := make([]entities.RecurringOrderItem, len(reqs))
+	for i, req := range reqs {
+		if req.Quantity <= 0 {
+			return nil, ErrInvalidQuantity
+		}
+
+		productID, err := uuid.Parse(req.ProductID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid product ID: %w", err)
+		}
+		product, err := s.productRepo.GetByID(ctx, productID)
+		if err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				return nil, ErrProductNotFound
+			}
+			return nil, fmt.Errorf("failed to get product: %w", err)
+		}
+		if !product.IsActive {
+			return nil, fmt.Errorf("%w: product %s is inactive", ErrProductNotFound, product.SKU)
+		}
+
+		items[i] = entities.RecurringOrderItem{
+			ID:               uuid.New(),
+			RecurringOrderID: recurringOrderID,
+			ProductID:        product.ID,
+			Quantity:         req.Quantity,
+			Notes:            trimmedOrNil(req.Notes),
+			CreatedAt:        now,
+		}
+	}
+	return items, nil
+}
+
+// transition applies a status change to a recurring order and persists it
+func (s *RecurringOrderServiceImpl) transition(ctx context.Context, id string, change func(*entities.RecurringOrder, time.Time) error) (*entities.RecurringOrder, error) {
+	recurringOrder, err := s.loadRecurringOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := change(recurringOrder, time.Now().UTC()); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStatusTransition, err)
+	}
+
+	if err := s.recurringOrderRepo.Update(ctx, recurringOrder); err != nil {
+		return nil, fmt.Errorf("failed to update recurring order: %w", err)
+	}
+
+	s.logger.Info().
+		Str("recurring_order_id", recurringOrder.ID.String()).
+		Str("status", string(recurringOrder.Status)).
+		Msg("Recurring order status changed")
+
+	return recurringOrder, nil
+}
+
+// loadRecurringOrder parses the ID and loads the recurring order, mapping missing rows to ErrRecurringOrderNotFound
+func (s *RecurringOrderServiceImpl) loadRecurringOrder(ctx context.Context, id string) (*entities.RecurringOrder, error) {
+	recurringOrderID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recurring order ID: %w", err)
+	}
+
+	recurringOrder, err := s.recurringOrderRepo.GetByID(ctx, recurringOrderID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrRecurringOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to get recurring order: %w", err)
+	}
+
+	return recurringOrder, nil
+}
+
+// utcOrNil returns the time in UTC, truncated to the second, or nil
+func utcOrNil(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC().Truncate(time.Second)
+	return &utc
+}
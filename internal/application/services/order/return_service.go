@@ -0,0 +1,845 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+
+	invEntities "erpgo/internal/domain/inventory/entities"
+	invRepositories "erpgo/internal/domain/inventory/repositories"
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
+	productEntities "erpgo/internal/domain/products/entities"
+	productRepositories "erpgo/internal/domain/products/repositories"
+	"erpgo/pkg/database"
+)
+
+// ReturnService defines the interface for return merchandise authorization (RMA) management
+type ReturnService interface {
+	CreateReturn(ctx context.Context, req *CreateReturnRequest) (*entities.ReturnAuthorization, error)
+	GetReturn(ctx context.Context, id string) (*entities.ReturnAuthorization, error)
+	GetReturnsByOrder(ctx context.Context, orderID string) ([]*entities.ReturnAuthorization, error)
+	ListReturns(ctx context.Context, req *ListReturnsRequest) (*ListReturnsResponse, error)
+
+	ApproveReturn(ctx context.Context, id string, approvedBy string) (*entities.ReturnAuthorization, error)
+	RejectReturn(ctx context.Context, id string, reason string) (*entities.ReturnAuthorization, error)
+	CancelReturn(ctx context.Context, id string) (*entities.ReturnAuthorization, error)
+
+	// ReceiveReturn records the goods arriving in a RETURN warehouse and marks
+	// the order lines as returned
+	ReceiveReturn(ctx context.Context, id string, req *ReceiveReturnRequest) (*entities.ReturnAuthorization, error)
+	// InspectReturn records the disposition of every received line, moves the
+	// stock accordingly and resolves the return
+	InspectReturn(ctx context.Context, id string, req *InspectReturnRequest) (*entities.ReturnAuthorization, error)
+	// ResolveReturn refunds the customer or creates the replacement order for an
+	// inspected return. It is only needed when automatic resolution failed.
+	ResolveReturn(ctx context.Context, id string, resolvedBy string) (*entities.ReturnAuthorization, error)
+}
+
+// CreateReturnRequest represents a request to authorize the return of order lines
+type CreateReturnRequest struct {
+	OrderID       string                    `json:"order_id" validate:"required,uuid"`
+	Resolution    entities.ReturnResolution `json:"resolution" validate:"required"`
+	Reason        entities.ReturnReason     `json:"reason" validate:"required"`
+	CustomerNotes *string                   `json:"customer_notes,omitempty"`
+	Items         []CreateReturnItemRequest `json:"items" validate:"required,min=1"`
+	RequestedBy   string                    `json:"requested_by" validate:"required,uuid"`
+}
+
+// CreateReturnItemRequest represents an order line to be returned. Lines
+// without a reason take the reason of the return.
+type CreateReturnItemRequest struct {
+	OrderItemID string                `json:"order_item_id" validate:"required,uuid"`
+	Quantity    int                   `json:"quantity" validate:"required,min=1"`
+	Reason      entities.ReturnReason `json:"reason,omitempty"`
+}
+
+// ReceiveReturnRequest represents the receipt of returned goods
+type ReceiveReturnRequest struct {
+	WarehouseID string                     `json:"warehouse_id" validate:"required,uuid"`
+	Items       []ReceiveReturnItemRequest `json:"items" validate:"required,min=1"`
+	ReceivedBy  string                     `json:"received_by" validate:"required,uuid"`
+}
+
+// ReceiveReturnItemRequest represents the received quantity of a return line
+type ReceiveReturnItemRequest struct {
+	ItemID   string `json:"item_id" validate:"required,uuid"`
+	Quantity int    `json:"quantity" validate:"min=0"`
+}
+
+// InspectReturnRequest represents the inspection outcome of a received return.
+// RestockWarehouseID is required when any line is restocked.
+type InspectReturnRequest struct {
+	Items              []InspectReturnItemRequest `json:"items" validate:"required,min=1"`
+	RestockWarehouseID *string                    `json:"restock_warehouse_id,omitempty"`
+	InspectedBy        string                     `json:"inspected_by" validate:"required,uuid"`
+}
+
+// InspectReturnItemRequest represents the inspection outcome of a return line
+type InspectReturnItemRequest struct {
+	ItemID      string                     `json:"item_id" validate:"required,uuid"`
+	Disposition entities.ReturnDisposition `json:"disposition" validate:"required"`
+	Notes       *string                    `json:"notes,omitempty"`
+}
+
+// ListReturnsRequest represents a request to list return authorizations
+type ListReturnsRequest struct {
+	Search     string                  `json:"search,omitempty"`
+	Status     []entities.ReturnStatus `json:"status,omitempty"`
+	OrderID    *string                 `json:"order_id,omitempty"`
+	CustomerID *string                 `json:"customer_id,omitempty"`
+	Page       int                     `json:"page"`
+	Limit      int                     `json:"limit"`
+}
+
+// ListReturnsResponse represents a paginated list of return authorizations
+type ListReturnsResponse struct {
+	Returns    []*entities.ReturnAuthorization `json:"returns"`
+	Pagination *Pagination                     `json:"pagination"`
+}
+
+// Return errors
+var (
+	ErrReturnNotFound         = errors.New("return authorization not found")
+	ErrInvalidReturnData      = errors.New("invalid return authorization data")
+	ErrInvalidReturnWarehouse = errors.New("invalid return warehouse")
+	ErrWarehouseNotFound      = errors.New("warehouse not found")
+)
+
+// ReturnServiceImpl implements the ReturnService interface
+type ReturnServiceImpl struct {
+	returnRepo      repositories.ReturnAuthorizationRepository
+	productRepo     productRepositories.ProductRepository
+	warehouseRepo   invRepositories.WarehouseRepository
+	inventoryRepo   invRepositories.InventoryRepository
+	transactionRepo invRepositories.InventoryTransactionRepository
+	orderService    Service
+	txManager       database.TransactionManagerInterface
+	logger          *zerolog.Logger
+}
+
+// NewReturnService creates a new return service. Order lines are marked as
+// returned, refunded and replaced through the order service so returns follow
+// the same rules as the order operations they trigger.
+func NewReturnService(
+	returnRepo repositories.ReturnAuthorizationRepository,
+	productRepo productRepositories.ProductRepository,
+	warehouseRepo invRepositories.WarehouseRepository,
+	inventoryRepo invRepositories.InventoryRepository,
+	transactionRepo invRepositories.InventoryTransactionRepository,
+	orderService Service,
+	txManager database.TransactionManagerInterface,
+	logger *zerolog.Logger,
+) ReturnService {
+	return &ReturnServiceImpl{
+		returnRepo:      returnRepo,
+		productRepo:     productRepo,
+		warehouseRepo:   warehouseRepo,
+		inventoryRepo:   inventoryRepo,
+		transactionRepo: transactionRepo,
+		orderService:    orderService,
+		txManager:       txManager,
+		logger:          logger,
+	}
+}
+
+// CreateReturn authorizes the return of shipped order lines. Each line may
+// return at most the shipped quantity not yet returned or held by another
+// open return. Refunds are priced at what the customer paid for the line.
+func (s *ReturnServiceImpl) CreateReturn(ctx context.Context, req *CreateReturnRequest) (*entities.ReturnAuthorization, error) {
+	if len(req.Items) == 0 {
+		return nil, fmt.Errorf("%w: return must have at least one item", ErrInvalidQuantity)
+	}
+
+	requestedBy, err := uuid.Parse(req.RequestedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid requested by user ID: %w", err)
+	}
+
+	order, err := s.orderService.GetOrder(ctx, req.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch order.Status {
+	case entities.OrderStatusShipped, entities.OrderStatusPartiallyShipped, entities.OrderStatusDelivered:
+	default:
+		return nil, fmt.Errorf("%w: order is %s", ErrOrderCannotBeReturned, order.Status)
+	}
+
+	pending, err := s.pendingQuantities(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	// The RMA number is allocated from the RETURN document sequence when the
+	// return is inserted.
+	rma := &entities.ReturnAuthorization{
+		ID:            uuid.New(),
+		OrderID:       order.ID,
+		CustomerID:    order.CustomerID,
+		Status:        entities.ReturnStatusRequested,
+		Resolution:    req.Resolution,
+		Reason:        req.Reason,
+		CustomerNotes: req.CustomerNotes,
+		RefundAmount:  decimal.Zero,
+		RequestedBy:   requestedBy,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	for _, itemReq := range req.Items {
+		orderItem, err := findOrderItem(order, itemReq.OrderItemID)
+		if err != nil {
+			return nil, err
+		}
+
+		returnable := orderItem.QuantityShipped - orderItem.QuantityReturned - pending[orderItem.ID]
+		if itemReq.Quantity <= 0 || itemReq.Quantity > returnable {
+			return nil, fmt.Errorf("%w: only %d of %s can be returned", ErrInvalidQuantity, max(returnable, 0), orderItem.ProductSKU)
+		}
+		pending[orderItem.ID] += itemReq.Quantity
+
+		reason := itemReq.Reason
+		if reason == "" {
+			reason = req.Reason
+		}
+
+		rma.Items = append(rma.Items, entities.ReturnAuthorizationItem{
+			ID:                    uuid.New(),
+			ReturnAuthorizationID: rma.ID,
+			OrderItemID:           orderItem.ID,
+			ProductID:             orderItem.ProductID,
+			ProductSKU:            orderItem.ProductSKU,
+			ProductName:           orderItem.ProductName,
+			Quantity:              itemReq.Quantity,
+			UnitRefundAmount:      unitRefundAmount(orderItem),
+			Reason:                reason,
+			CreatedAt:             now,
+			UpdatedAt:             now,
+		})
+	}
+
+	if err := rma.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidReturnData, err)
+	}
+
+	if err := s.returnRepo.Create(ctx, rma); err != nil {
+		return nil, fmt.Errorf("failed to create return authorization: %w", err)
+	}
+
+	s.logger.Info().
+		Str("rma_number", rma.RMANumber).
+		Str("order_number", order.OrderNumber).
+		Msg("Return authorization requested")
+
+	return rma, nil
+}
+
+// GetReturn retrieves a return authorization by ID
+func (s *ReturnServiceImpl) GetReturn(ctx context.Context, id string) (*entities.ReturnAuthorization, error) {
+	return s.loadReturn(ctx, id)
+}
+
+// GetReturnsByOrder retrieves the return authorizations raised against an order
+func (s *ReturnServiceImpl) GetReturnsByOrder(ctx context.Context, orderID string) ([]*entities.ReturnAuthorization, error) {
+	id, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order ID: %w", err)
+	}
+
+	rmas, err := s.returnRepo.GetByOrderID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get return authorizations: %w", err)
+	}
+
+	return rmas, nil
+}
+
+// ListReturns lists return authorizations
+func (s *ReturnServiceImpl) ListReturns(ctx context.Context, req *ListReturnsRequest) (*ListReturnsResponse, error) {
+	page, limit := normalizePage(req.Page, req.Limit)
+
+	filter := repositories.ReturnAuthorizationFilter{
+		Search: req.Search,
+		Status: req.Status,
+		Page:   page,
+		Limit:  limit,
+		Offset: (page - 1) * limit,
+	}
+	if req.OrderID != nil {
+		orderID, err := uuid.Parse(*req.OrderID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid order ID: %w", err)
+		}
+		filter.OrderID = &orderID
+	}
+	if req.CustomerID != nil {
+		customerID, err := uuid.Parse(*req.CustomerID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid customer ID: %w", err)
+		}
+		filter.CustomerID = &customerID
+	}
+
+	rmas, err := s.returnRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list return authorizations: %w", err)
+	}
+
+	total, err := s.returnRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count return authorizations: %w", err)
+	}
+
+	return &ListReturnsResponse{
+		Returns:    rmas,
+		Pagination: newPagination(page, limit, total),
+	}, nil
+}
+
+// ApproveReturn authorizes the customer to send the goods back
+func (s *ReturnServiceImpl) ApproveReturn(ctx context.Context, id string, approvedBy string) (*entities.ReturnAuthorization, error) {
+	approverID, err := uuid.Parse(approvedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid approved by user ID: %w", err)
+	}
+
+	return s.transition(ctx, id, entities.ReturnStatusApproved, func(rma *entities.ReturnAuthorization) {
+		rma.ApprovedBy = &approverID
+	})
+}
+
+// RejectReturn declines a requested return
+func (s *ReturnServiceImpl) RejectReturn(ctx context.Context, id string, reason string) (*entities.ReturnAuthorization, error) {
+	if strings.TrimSpace(reason) == "" {
+		return nil, fmt.Errorf("%w: rejection reason is required", ErrInvalidReturnData)
+	}
+
+	return s.transition(ctx, id, entities.ReturnStatusRejected, func(rma *entities.ReturnAuthorization) {
+		rma.RejectionReason = &reason
+	})
+}
+
+// CancelReturn withdraws a return before the goods are received
+func (s *ReturnServiceImpl) CancelReturn(ctx context.Context, id string) (*entities.ReturnAuthorization, error) {
+	return s.transition(ctx, id, entities.ReturnStatusCancelled, nil)
+}
+
+// ReceiveReturn records the received quantities, books the goods into the
+// RETURN warehouse and marks the order lines as returned. The order is updated
+// first so stock is never received against lines that cannot be returned, and
+// the return stays locked until the order, the stock and the return are all
+// saved in one transaction.
+func (s *ReturnServiceImpl) ReceiveReturn(ctx context.Context, id string, req *ReceiveReturnRequest) (*entities.ReturnAuthorization, error) {
+	receivedBy, err := uuid.Parse(req.ReceivedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid received by user ID: %w", err)
+	}
+
+	warehouse, err := s.loadWarehouse(ctx, req.WarehouseID)
+	if err != nil {
+		return nil, err
+	}
+	if warehouse.Type != invEntities.WarehouseTypeReturn {
+		return nil, fmt.Errorf("%w: warehouse %s is a %s warehouse", ErrInvalidReturnWarehouse, warehouse.Code, warehouse.Type)
+	}
+	if !warehouse.IsActive {
+		return nil, fmt.Errorf("%w: warehouse %s is inactive", ErrInvalidReturnWarehouse, warehouse.Code)
+	}
+
+	quantities := make(map[uuid.UUID]int, len(req.Items))
+	for _, itemReq := range req.Items {
+		itemID, err := uuid.Parse(itemReq.ItemID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid return item ID: %w", err)
+		}
+		quantities[itemID] += itemReq.Quantity
+	}
+
+	var rma *entities.ReturnAuthorization
+	err = database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		if rma, err = s.lockReturn(ctx, id); err != nil {
+			return err
+		}
+
+		if err := rma.Receive(quantities); err != nil {
+			if rma.Status != entities.ReturnStatusApproved {
+				return fmt.Errorf("%w: %v", ErrInvalidStatusTransition, err)
+			}
+			return fmt.Errorf("%w: %v", ErrInvalidQuantity, err)
+		}
+		rma.WarehouseID = &warehouse.ID
+		rma.ReceivedBy = &receivedBy
+
+		now := time.Now().UTC()
+		var transactions []*invEntities.InventoryTransaction
+		returnReq := &ReturnItemsRequest{
+			Reason:     fmt.Sprintf("Received on return %s", rma.RMANumber),
+			ReturnedBy: req.ReceivedBy,
+		}
+		for _, item := range rma.Items {
+			if item.QuantityReceived == 0 {
+				continue
+			}
+			returnReq.Items = append(returnReq.Items, ReturnItemRequest{
+				ItemID:   item.OrderItemID.String(),
+				Quantity: item.QuantityReceived,
+				Reason:   string(item.Reason),
+			})
+
+			transaction, err := s.stockTransaction(ctx, rma, item, invEntities.TransactionTypeReturn, warehouse.ID, item.QuantityReceived, returnReq.Reason, receivedBy, now)
+			if err != nil {
+				return err
+			}
+			if transaction == nil {
+				continue
+			}
+			if err := transaction.Validate(); err != nil {
+				return fmt.Errorf("%w: %s: %v", ErrInvalidReturnData, item.ProductSKU, err)
+			}
+			transactions = append(transactions, transaction)
+		}
+
+		if _, err := s.orderService.ReturnOrderItems(ctx, rma.OrderID.String(), returnReq); err != nil {
+			return err
+		}
+
+		for _, transaction := range transactions {
+			if _, err := s.inventoryRepo.ReceiveStock(ctx, transaction.ProductID, transaction.WarehouseID, transaction.Quantity, transaction.UnitCost, receivedBy); err != nil {
+				return fmt.Errorf("failed to receive returned stock: %w", err)
+			}
+			if err := s.transactionRepo.Create(ctx, transaction); err != nil {
+				return fmt.Errorf("failed to record inventory transaction: %w", err)
+			}
+		}
+
+		if err := s.returnRepo.Update(ctx, rma); err != nil {
+			return fmt.Errorf("failed to update return authorization: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info().
+		Str("rma_number", rma.RMANumber).
+		Str("warehouse_code", warehouse.Code).
+		Msg("Return received")
+
+	return rma, nil
+}
+
+// InspectReturn records the inspection outcome of every received line and
+// moves the stock out of the RETURN warehouse: restocked goods are transferred
+// to the restock warehouse, scrapped goods are written off as DAMAGE and goods
+// returned to the vendor are adjusted out. The return is then resolved.
+func (s *ReturnServiceImpl) InspectReturn(ctx context.Context, id string, req *InspectReturnRequest) (*entities.ReturnAuthorization, error) {
+	inspectedBy, err := uuid.Parse(req.InspectedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid inspected by user ID: %w", err)
+	}
+
+	rma, err := s.loadReturn(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	outcomes := make(map[uuid.UUID]entities.ReturnInspection, len(req.Items))
+	restocking := false
+	for _, itemReq := range req.Items {
+		itemID, err := uuid.Parse(itemReq.ItemID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid return item ID: %w", err)
+		}
+		outcomes[itemID] = entities.ReturnInspection{Disposition: itemReq.Disposition, Notes: itemReq.Notes}
+		restocking = restocking || itemReq.Disposition == entities.ReturnDispositionRestock
+	}
+
+	if err := rma.Inspect(outcomes); err != nil {
+		if rma.Status != entities.ReturnStatusReceived {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidStatusTransition, err)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidReturnData, err)
+	}
+	rma.InspectedBy = &inspectedBy
+
+	var restockWarehouse *invEntities.WarehouseExtended
+	if restocking {
+		if req.RestockWarehouseID == nil {
+			return nil, fmt.Errorf("%w: restock warehouse is required to restock returned goods", ErrInvalidReturnData)
+		}
+		restockWarehouse, err = s.loadWarehouse(ctx, *req.RestockWarehouseID)
+		if err != nil {
+			return nil, err
+		}
+		if restockWarehouse.Type == invEntities.WarehouseTypeReturn || !restockWarehouse.IsActive {
+			return nil, fmt.Errorf("%w: goods cannot be restocked into warehouse %s", ErrInvalidReturnWarehouse, restockWarehouse.Code)
+		}
+	}
+
+	type stockMove struct {
+		transaction *invEntities.InventoryTransaction
+		receive     bool
+	}
+
+	now := time.Now().UTC()
+	var moves []stockMove
+	for _, item := range rma.Items {
+		if item.QuantityReceived == 0 || rma.WarehouseID == nil {
+			continue
+		}
+
+		var outbound, inbound *invEntities.InventoryTransaction
+		switch *item.Disposition {
+		case entities.ReturnDispositionRestock:
+			reason := fmt.Sprintf("Restocked from return %s", rma.RMANumber)
+			outbound, err = s.stockTransaction(ctx, rma, item, invEntities.TransactionTypeTransferOut, *rma.WarehouseID, -item.QuantityReceived, reason, inspectedBy, now)
+			if err != nil || outbound == nil {
+				break
+			}
+			inbound, err = s.stockTransaction(ctx, rma, item, invEntities.TransactionTypeTransferIn, restockWarehouse.ID, item.QuantityReceived, reason, inspectedBy, now)
+			if err != nil {
+				break
+			}
+			for _, transfer := range []*invEntities.InventoryTransaction{outbound, inbound} {
+				transfer.FromWarehouseID = rma.WarehouseID
+				transfer.ToWarehouseID = &restockWarehouse.ID
+			}
+		case entities.ReturnDispositionScrap:
+			outbound, err = s.stockTransaction(ctx, rma, item, invEntities.TransactionTypeDamage, *rma.WarehouseID, -item.QuantityReceived,
+				fmt.Sprintf("Scrapped on return %s", rma.RMANumber), inspectedBy, now)
+		case entities.ReturnDispositionReturnToVendor:
+			outbound, err = s.stockTransaction(ctx, rma, item, invEntities.TransactionTypeAdjustment, *rma.WarehouseID, -item.QuantityReceived,
+				fmt.Sprintf("Returned to vendor on return %s", rma.RMANumber), inspectedBy, now)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, transaction := range []*invEntities.InventoryTransaction{outbound, inbound} {
+			if transaction == nil {
+				continue
+			}
+			if err := transaction.Validate(); err != nil {
+				return nil, fmt.Errorf("%w: %s: %v", ErrInvalidReturnData, item.ProductSKU, err)
+			}
+			moves = append(moves, stockMove{transaction: transaction, receive: transaction == inbound})
+		}
+	}
+
+	err = database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		for _, move := range moves {
+			transaction := move.transaction
+			if move.receive {
+				if _, err := s.inventoryRepo.ReceiveStock(ctx, transaction.ProductID, transaction.WarehouseID, transaction.Quantity, transaction.UnitCost, inspectedBy); err != nil {
+					return fmt.Errorf("failed to restock returned goods: %w", err)
+				}
+			} else if err := s.inventoryRepo.AdjustStock(ctx, transaction.ProductID, transaction.WarehouseID, transaction.Quantity); err != nil {
+				return fmt.Errorf("failed to remove returned goods from stock: %w", err)
+			}
+			if err := s.transactionRepo.Create(ctx, transaction); err != nil {
+				return fmt.Errorf("failed to record inventory transaction: %w", err)
+			}
+		}
+
+		if err := s.returnRepo.Update(ctx, rma); err != nil {
+			return fmt.Errorf("failed to update return authorization: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info().
+		Str("rma_number", rma.RMANumber).
+		Msg("Return inspected")
+
+	if err := s.resolve(ctx, rma, req.InspectedBy); err != nil {
+		return nil, fmt.Errorf("return inspected but not resolved: %w", err)
+	}
+
+	return rma, nil
+}
+
+// ResolveReturn resolves an inspected return
+func (s *ReturnServiceImpl) ResolveReturn(ctx context.Context, id string, resolvedBy string) (*entities.ReturnAuthorization, error) {
+	if _, err := uuid.Parse(resolvedBy); err != nil {
+		return nil, fmt.Errorf("invalid resolved by user ID: %w", err)
+	}
+
+	rma, err := s.loadReturn(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.resolve(ctx, rma, resolvedBy); err != nil {
+		return nil, err
+	}
+
+	return rma, nil
+}
+
+// resolve refunds the received lines on the original order or creates a free
+// replacement order for them, then completes the return. Refunds are capped at
+// the amount still refundable on the order; unpaid orders get no refund.
+func (s *ReturnServiceImpl) resolve(ctx context.Context, rma *entities.ReturnAuthorization, resolvedBy string) error {
+	if rma.Status != entities.ReturnStatusInspected {
+		return fmt.Errorf("%w: return is %s", ErrInvalidStatusTransition, rma.Status)
+	}
+
+	order, err := s.orderService.GetOrder(ctx, rma.OrderID.String())
+	if err != nil {
+		return err
+	}
+
+	switch rma.Resolution {
+	case entities.ReturnResolutionRefund:
+		refundReq := &PartialRefundOrderRequest{
+			Reason:     fmt.Sprintf("Refund for return %s", rma.RMANumber),
+			RefundedBy: resolvedBy,
+		}
+
+		remaining := order.PaidAmount.Sub(order.RefundedAmount)
+		refunded := decimal.Zero
+		for _, item := range rma.Items {
+			amount := decimal.Min(item.UnitRefundAmount.Mul(decimal.NewFromInt(int64(item.QuantityReceived))).Round(2), remaining)
+			if amount.LessThanOrEqual(decimal.Zero) {
+				continue
+			}
+			refundReq.Items = append(refundReq.Items, RefundItemRequest{
+				ItemID:       item.OrderItemID.String(),
+				Quantity:     item.QuantityReceived,
+				RefundAmount: amount,
+			})
+			remaining = remaining.Sub(amount)
+			refunded = refunded.Add(amount)
+		}
+
+		if len(refundReq.Items) > 0 {
+			if _, err := s.orderService.PartialRefundOrder(ctx, order.ID.String(), refundReq); err != nil {
+				return err
+			}
+		} else {
+			s.logger.Warn().
+				Str("rma_number", rma.RMANumber).
+				Str("order_number", order.OrderNumber).
+				Msg("Nothing left to refund on order for return")
+		}
+		rma.RefundAmount = refunded
+
+	case entities.ReturnResolutionReplacement:
+		notes := fmt.Sprintf("Replacement for return %s of order %s", rma.RMANumber, order.OrderNumber)
+		orderReq := &CreateOrderRequest{
+			CustomerID:        order.CustomerID.String(),
+			Type:              entities.OrderTypeExchange,
+			Priority:          order.Priority,
+			ShippingMethod:    order.ShippingMethod,
+			ShippingAddressID: order.ShippingAddressID.String(),
+			BillingAddressID:  order.BillingAddressID.String(),
+			Currency:          order.Currency,
+			Notes:             &notes,
+			CreatedBy:         resolvedBy,
+		}
+		for _, item := range rma.Items {
+			if item.QuantityReceived == 0 {
+				continue
+			}
+			orderItem, err := findOrderItem(order, item.OrderItemID.String())
+			if err != nil {
+				return err
+			}
+			// Replacements are free of charge: each unit is discounted by its full price
+			orderReq.Items = append(orderReq.Items, CreateOrderItemRequest{
+				ProductID:      item.ProductID.String(),
+				Quantity:       item.QuantityReceived,
+				UnitPrice:      orderItem.UnitPrice,
+				DiscountAmount: orderItem.UnitPrice,
+				TaxRate:        orderItem.TaxRate,
+			})
+		}
+
+		replacement, err := s.orderService.CreateOrder(ctx, orderReq)
+		if err != nil {
+			return err
+		}
+		rma.ReplacementOrderID = &replacement.ID
+	}
+
+	if err := rma.ChangeStatus(entities.ReturnStatusCompleted); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidStatusTransition, err)
+	}
+
+	if err := s.returnRepo.Update(ctx, rma); err != nil {
+		s.logger.Error().Err(err).
+			Str("rma_number", rma.RMANumber).
+			Msg("Failed to complete resolved return")
+		return fmt.Errorf("failed to update return authorization: %w", err)
+	}
+
+	s.logger.Info().
+		Str("rma_number", rma.RMANumber).
+		Str("resolution", string(rma.Resolution)).
+		Str("refund_amount", rma.RefundAmount.StringFixed(2)).
+		Msg("Return completed")
+
+	return nil
+}
+
+// transition moves a return authorization to a new status and persists it
+func (s *ReturnServiceImpl) transition(ctx context.Context, id string, status entities.ReturnStatus, apply func(*entities.ReturnAuthorization)) (*entities.ReturnAuthorization, error) {
+	rma, err := s.loadReturn(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rma.ChangeStatus(status); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStatusTransition, err)
+	}
+	if apply != nil {
+		apply(rma)
+	}
+
+	if err := s.returnRepo.Update(ctx, rma); err != nil {
+		return nil, fmt.Errorf("failed to update return authorization: %w", err)
+	}
+
+	return rma, nil
+}
+
+// pendingQuantities sums the quantities per order line held by returns that
+// are authorized but not yet received
+func (s *ReturnServiceImpl) pendingQuantities(ctx context.Context, orderID uuid.UUID) (map[uuid.UUID]int, error) {
+	rmas, err := s.returnRepo.GetByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get return authorizations: %w", err)
+	}
+
+	pending := make(map[uuid.UUID]int)
+	for _, rma := range rmas {
+		if !rma.IsOpen() {
+			continue
+		}
+		for _, item := range rma.Items {
+			pending[item.OrderItemID] += item.Quantity
+		}
+	}
+
+	return pending, nil
+}
+
+// stockTransaction builds the inventory transaction for a movement of
+// returned goods at the product cost. Products that do not track inventory
+// get no transaction. Callers validate the transaction once it is complete.
+func (s *ReturnServiceImpl) stockTransaction(
+	ctx context.Context,
+	rma *entities.ReturnAuthorization,
+	item entities.ReturnAuthorizationItem,
+	transactionType invEntities.TransactionType,
+	warehouseID uuid.UUID,
+	quantity int,
+	reason string,
+	createdBy uuid.UUID,
+	at time.Time,
+) (*invEntities.InventoryTransaction, error) {
+	product, err := s.loadProduct(ctx, item.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if !product.TrackInventory || product.IsDigital {
+		return nil, nil
+	}
+
+	reference := rma.ID
+	transaction := &invEntities.InventoryTransaction{
+		ID:              uuid.New(),
+		ProductID:       item.ProductID,
+		WarehouseID:     warehouseID,
+		TransactionType: transactionType,
+		Quantity:        quantity,
+		ReferenceType:   "RETURN_AUTHORIZATION",
+		ReferenceID:     &reference,
+		Reason:          reason,
+		UnitCost:        product.Cost.InexactFloat64(),
+		TotalCost:       product.Cost.Mul(decimal.NewFromInt(int64(max(quantity, -quantity)))).Round(2).InexactFloat64(),
+		CreatedAt:       at,
+		CreatedBy:       createdBy,
+	}
+
+	return transaction, nil
+}
+
+// loadProduct loads a product, mapping missing rows to ErrProductNotFound
+func (s *ReturnServiceImpl) loadProduct(ctx context.Context, id uuid.UUID) (*productEntities.Product, error) {
+	product, err := s.productRepo.GetByID(ctx, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	return product, nil
+}
+
+// loadWarehouse parses the ID and loads the warehouse with its type
+func (s *ReturnServiceImpl) loadWarehouse(ctx context.Context, id string) (*invEntities.WarehouseExtended, error) {
+	warehouseID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid warehouse ID: %w", err)
+	}
+
+	warehouse, err := s.warehouseRepo.GetExtendedByID(ctx, warehouseID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrWarehouseNotFound
+		}
+		return nil, fmt.Errorf("failed to get warehouse: %w", err)
+	}
+
+	return warehouse, nil
+}
+
+// lockReturn locks a return for the rest of the context's transaction and loads it
+func (s *ReturnServiceImpl) lockReturn(ctx context.Context, id string) (*entities.ReturnAuthorization, error) {
+	rmaID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid return authorization ID: %w", err)
+	}
+
+	if err := s.returnRepo.Lock(ctx, rmaID); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrReturnNotFound
+		}
+		return nil, fmt.Errorf("failed to lock return authorization: %w", err)
+	}
+
+	return s.loadReturn(ctx, id)
+}
+
+// loadReturn parses the ID and loads the return, mapping missing rows to ErrReturnNotFound
+func (s *ReturnServiceImpl) loadReturn(ctx context.Context, id string) (*entities.ReturnAuthorization, error) {
+	rmaID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid return authorization ID: %w", err)
+	}
+
+	rma, err := s.returnRepo.GetByID(ctx, rmaID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrReturnNotFound
+		}
+		return nil, fmt.Errorf("failed to get return authorization: %w", err)
+	}
+
+	return rma, nil
+}
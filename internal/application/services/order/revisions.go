@@ -0,0 +1,718 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/pkg/database"
+)
+
+// AmendOrderRequest represents a change to the lines, prices or addresses of
+// a confirmed order
+type AmendOrderRequest struct {
+	Lines             []AmendOrderLineRequest `json:"lines,omitempty" validate:"omitempty,dive"`
+	ShippingAddressID *string                 `json:"shipping_address_id,omitempty" validate:"omitempty,uuid"`
+	BillingAddressID  *string                 `json:"billing_address_id,omitempty" validate:"omitempty,uuid"`
+	Reason            *string                 `json:"reason,omitempty"`
+	AmendedBy         string                  `json:"amended_by" validate:"required,uuid"`
+}
+
+// AmendOrderLineRequest changes, removes or, without an order item ID, adds
+// a line. A new line without a unit price is priced from the catalogue.
+type AmendOrderLineRequest struct {
+	OrderItemID    *string          `json:"order_item_id,omitempty" validate:"omitempty,uuid"`
+	ProductID      *string          `json:"product_id,omitempty" validate:"omitempty,uuid"`
+	Quantity       *int             `json:"quantity,omitempty" validate:"omitempty,min=1"`
+	UnitPrice      *decimal.Decimal `json:"unit_price,omitempty"`
+	DiscountAmount *decimal.Decimal `json:"discount_amount,omitempty"`
+	Remove         bool             `json:"remove,omitempty"`
+}
+
+// ApproveOrderRevisionRequest represents an approver's approval of a pending revision
+type ApproveOrderRevisionRequest struct {
+	ApprovedBy string `json:"approved_by" validate:"required,uuid"`
+}
+
+// RejectOrderRevisionRequest represents an approver's rejection of a pending revision
+type RejectOrderRevisionRequest struct {
+	Reason     string `json:"reason" validate:"required"`
+	RejectedBy string `json:"rejected_by" validate:"required,uuid"`
+}
+
+// Order revision errors
+var (
+	ErrOrderRevisionNotFound = errors.New("order revision not found")
+	ErrOrderNotAmendable     = errors.New("only confirmed orders that have not fully shipped can be amended")
+	ErrInvalidAmendment      = errors.New("invalid amendment")
+	ErrRevisionPending       = errors.New("order has an amendment awaiting approval")
+	ErrRevisionNotPending    = errors.New("order revision is not awaiting approval")
+)
+
+// AmendOrder changes the lines, prices or addresses of a confirmed order as
+// a new revision. The reservations of the order move with its quantities:
+// reduced quantities come off its backorders first and then release stock,
+// while added quantities are sourced and reserved, backordering shortfalls.
+// An amendment taking the customer over their credit limit is refused, and
+// one the approval policies require approval for at its new amount waits
+// as a pending revision, leaving the order unchanged until it is approved.
+func (s *ServiceImpl) AmendOrder(ctx context.Context, id string, req *AmendOrderRequest) (*entities.OrderRevision, error) {
+	amendedBy, err := uuid.Parse(req.AmendedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amended by user ID: %w", err)
+	}
+
+	amendment, err := parseAmendment(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := amendment.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAmendment, err)
+	}
+
+	ctx = withActorID(ctx, req.AmendedBy)
+
+	// The order stays locked from the checks through the amendment, so
+	// amendments made at the same time see each other
+	var order, amended *entities.Order
+	var revision *entities.OrderRevision
+	var changes []entities.OrderRevisionChange
+	var backorders []*entities.Backorder
+	err = database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		if order, err = s.lockOrder(ctx, id); err != nil {
+			return err
+		}
+		if !hasInventoryReservation(order) {
+			return ErrOrderNotAmendable
+		}
+
+		revisions, err := s.revisionRepo.GetByOrderID(ctx, order.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get order revisions: %w", err)
+		}
+		for _, revision := range revisions {
+			if revision.IsPending() {
+				return fmt.Errorf("%w: revision %d", ErrRevisionPending, revision.RevisionNumber)
+			}
+		}
+
+		if amended, changes, err = s.amendOrder(ctx, order, amendment); err != nil {
+			return err
+		}
+
+		roles, approvalAmount, err := s.amendmentApprovers(ctx, order, amended, revisions)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		number := 2
+		if len(revisions) == 0 {
+			if err := s.revisionRepo.Create(ctx, baselineRevision(order)); err != nil {
+				return err
+			}
+		} else {
+			number = revisions[len(revisions)-1].RevisionNumber + 1
+		}
+
+		revision = &entities.OrderRevision{
+			ID:             uuid.New(),
+			OrderID:        order.ID,
+			RevisionNumber: number,
+			Status:         entities.OrderRevisionStatusPendingApproval,
+			Reason:         trimmedOrNil(req.Reason),
+			Amendment:      amendment,
+			Snapshot:       entities.NewOrderSnapshot(amended),
+			Changes:        changes,
+			ApproverRoles:  roles,
+			ApprovalAmount: approvalAmount,
+			CreatedBy:      amendedBy,
+			CreatedAt:      now,
+		}
+		if len(roles) == 0 {
+			revision.MarkApplied(now)
+		}
+		if err := revision.Validate(); err != nil {
+			return fmt.Errorf("invalid order revision: %w", err)
+		}
+
+		if revision.IsPending() {
+			return s.revisionRepo.Create(ctx, revision)
+		}
+
+		if backorders, err = s.applyAmendment(ctx, order, amended, revision.RevisionNumber); err != nil {
+			return err
+		}
+		return s.revisionRepo.Create(ctx, revision)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if revision.IsPending() {
+		s.logger.Info().
+			Str("order_number", order.OrderNumber).
+			Int("revision", revision.RevisionNumber).
+			Strs("roles", revision.ApproverRoles).
+			Msg("Order amendment awaiting approval")
+		return revision, nil
+	}
+
+	s.notifyBackorders(ctx, amended, backorders)
+
+	s.logger.Info().
+		Str("order_number", order.OrderNumber).
+		Int("revision", revision.RevisionNumber).
+		Int("changes", len(changes)).
+		Str("total_amount", amended.TotalAmount.String()).
+		Msg("Order amended")
+
+	return revision, nil
+}
+
+// GetOrderRevisions returns the revisions of an order, oldest first
+func (s *ServiceImpl) GetOrderRevisions(ctx context.Context, id string) ([]*entities.OrderRevision, error) {
+	order, err := s.loadOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.revisionRepo.GetByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// GetOrderRevision returns a revision of an order by its number
+func (s *ServiceImpl) GetOrderRevision(ctx context.Context, id string, number int) (*entities.OrderRevision, error) {
+	order, err := s.loadOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.loadRevision(ctx, order, number)
+}
+
+// ApproveOrderRevision records the approval of a pending revision by the
+// next of its approver roles. Once every role has approved, the amendment
+// is applied to the order as it now stands.
+func (s *ServiceImpl) ApproveOrderRevision(ctx context.Context, id string, number int, req *ApproveOrderRevisionRequest) (*entities.OrderRevision, error) {
+	approverID, err := uuid.Parse(req.ApprovedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid approver ID: %w", err)
+	}
+
+	ctx = withActorID(ctx, req.ApprovedBy)
+
+	var order, amended *entities.Order
+	var revision *entities.OrderRevision
+	var backorders []*entities.Backorder
+	complete := false
+	err = database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		if order, revision, err = s.loadPendingRevision(ctx, id, number, approverID); err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		if complete, err = revision.Approve(approverID, now); err != nil {
+			return fmt.Errorf("%w: %v", ErrRevisionNotPending, err)
+		}
+		if !complete {
+			return s.revisionRepo.Update(ctx, revision)
+		}
+
+		// The order may have shipped since the amendment was requested
+		if !hasInventoryReservation(order) {
+			return ErrOrderNotAmendable
+		}
+		var changes []entities.OrderRevisionChange
+		if amended, changes, err = s.amendOrder(ctx, order, revision.Amendment); err != nil {
+			return err
+		}
+		revision.Snapshot = entities.NewOrderSnapshot(amended)
+		revision.Changes = changes
+		revision.MarkApplied(now)
+
+		if backorders, err = s.applyAmendment(ctx, order, amended, revision.RevisionNumber); err != nil {
+			return err
+		}
+		return s.revisionRepo.Update(ctx, revision)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !complete {
+		s.logger.Info().
+			Str("order_number", order.OrderNumber).
+			Int("revision", revision.RevisionNumber).
+			Str("approved_by", approverID.String()).
+			Msg("Order amendment approval step approved")
+
+		return revision, nil
+	}
+
+	s.notifyBackorders(ctx, amended, backorders)
+
+	s.logger.Info().
+		Str("order_number", order.OrderNumber).
+		Int("revision", revision.RevisionNumber).
+		Str("approved_by", approverID.String()).
+		Str("total_amount", amended.TotalAmount.String()).
+		Msg("Order amendment approved and applied")
+
+	return revision, nil
+}
+
+// RejectOrderRevision records an approver's rejection of a pending
+// revision; the order stays as it is
+func (s *ServiceImpl) RejectOrderRevision(ctx context.Context, id string, number int, req *RejectOrderRevisionRequest) (*entities.OrderRevision, error) {
+	approverID, err := uuid.Parse(req.RejectedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid approver ID: %w", err)
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, fmt.Errorf("%w: a rejection reason is required", ErrInvalidAmendment)
+	}
+
+	ctx = withActorID(ctx, req.RejectedBy)
+
+	var order *entities.Order
+	var revision *entities.OrderRevision
+	err = database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		if order, revision, err = s.loadPendingRevision(ctx, id, number, approverID); err != nil {
+			return err
+		}
+
+		if err := revision.Reject(approverID, reason, time.Now().UTC()); err != nil {
+			return fmt.Errorf("%w: %v", ErrRevisionNotPending, err)
+		}
+		return s.revisionRepo.Update(ctx, revision)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info().
+		Str("order_number", order.OrderNumber).
+		Int("revision", revision.RevisionNumber).
+		Str("rejected_by", approverID.String()).
+		Str("reason", reason).
+		Msg("Order amendment rejected")
+
+	return revision, nil
+}
+
+// loadRevision loads a revision of the order, mapping missing rows to ErrOrderRevisionNotFound
+func (s *ServiceImpl) loadRevision(ctx context.Context, order *entities.Order, number int) (*entities.OrderRevision, error) {
+	revision, err := s.revisionRepo.GetByNumber(ctx, order.ID, number)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrOrderRevisionNotFound
+		}
+		return nil, fmt.Errorf("failed to get order revision: %w", err)
+	}
+
+	return revision, nil
+}
+
+// loadPendingRevision locks the order and loads it with its pending revision,
+// checking that the approver holds the role the revision awaits
+func (s *ServiceImpl) loadPendingRevision(ctx context.Context, id string, number int, approverID uuid.UUID) (*entities.Order, *entities.OrderRevision, error) {
+	order, err := s.lockOrder(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	revision, err := s.loadRevision(ctx, order, number)
+	if err != nil {
+		return nil, nil, err
+	}
+	role := revision.NextApproverRole()
+	if role == "" {
+		return nil, nil, ErrRevisionNotPending
+	}
+
+	roles, err := s.userRoles(ctx, approverID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, held := range roles {
+		if held == role {
+			return order, revision, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("%w: revision %d requires the %s role", ErrApproverNotAuthorized, revision.RevisionNumber, role)
+}
+
+// amendOrder returns a copy of the order with the amendment made to its
+// lines, prices and addresses and its totals recalculated, along with the
+// changes from the order. Shipped quantities cannot be amended away.
+func (s *ServiceImpl) amendOrder(ctx context.Context, order *entities.Order, amendment *entities.OrderAmendment) (*entities.Order, []entities.OrderRevisionChange, error) {
+	amended := *order
+	amended.Items = append([]entities.OrderItem(nil), order.Items...)
+
+	for _, line := range amendment.Lines {
+		if line.OrderItemID == nil {
+			unitPrice, discount := decimal.Zero, decimal.Zero
+			if line.UnitPrice != nil {
+				unitPrice = *line.UnitPrice
+			}
+			if line.DiscountAmount != nil {
+				discount = *line.DiscountAmount
+			}
+			item, err := s.buildOrderItem(ctx, order.ID, line.ProductID.String(), *line.Quantity, unitPrice, discount, decimal.Zero, nil)
+			if err != nil {
+				return nil, nil, err
+			}
+			amended.Items = append(amended.Items, *item)
+			continue
+		}
+
+		item, err := findOrderItem(&amended, line.OrderItemID.String())
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if line.Remove {
+			if item.QuantityShipped > 0 {
+				return nil, nil, fmt.Errorf("%w: %s has shipped and cannot be removed", ErrInvalidAmendment, item.ProductSKU)
+			}
+			removedID := item.ID
+			remaining := make([]entities.OrderItem, 0, len(amended.Items)-1)
+			for _, existing := range amended.Items {
+				if existing.ID != removedID {
+					remaining = append(remaining, existing)
+				}
+			}
+			amended.Items = remaining
+			continue
+		}
+
+		if line.Quantity != nil {
+			if *line.Quantity < item.QuantityShipped {
+				return nil, nil, fmt.Errorf("%w: %s has %d units shipped", ErrInvalidQuantity, item.ProductSKU, item.QuantityShipped)
+			}
+			item.Quantity = *line.Quantity
+		}
+		if line.UnitPrice != nil {
+			item.UnitPrice = *line.UnitPrice
+		}
+		if line.DiscountAmount != nil {
+			item.DiscountAmount = *line.DiscountAmount
+		}
+		item.UpdatedAt = time.Now().UTC()
+
+		item.CalculateTotals()
+		if err := item.Validate(); err != nil {
+			return nil, nil, fmt.Errorf("invalid order item: %w", err)
+		}
+	}
+	if len(amended.Items) == 0 {
+		return nil, nil, fmt.Errorf("%w: order must keep at least one item", ErrInvalidQuantity)
+	}
+
+	if amendment.ShippingAddressID != nil {
+		address, err := s.getAddress(ctx, amendment.ShippingAddressID.String())
+		if err != nil {
+			return nil, nil, err
+		}
+		amended.ShippingAddressID = address.ID
+		amended.ShippingAddress = address
+	}
+	if amendment.BillingAddressID != nil {
+		address, err := s.getAddress(ctx, amendment.BillingAddressID.String())
+		if err != nil {
+			return nil, nil, err
+		}
+		amended.BillingAddressID = address.ID
+		amended.BillingAddress = address
+	}
+
+	if _, err := s.applyTotals(ctx, &amended); err != nil {
+		return nil, nil, err
+	}
+	if amended.PaidAmount.IsPositive() {
+		if amended.IsFullyPaid() {
+			amended.PaymentStatus = entities.PaymentStatusPaid
+		} else {
+			amended.PaymentStatus = entities.PaymentStatusPartiallyPaid
+		}
+	}
+	if err := amended.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidAmendment, err)
+	}
+
+	changes := entities.DiffOrderSnapshots(entities.NewOrderSnapshot(order), entities.NewOrderSnapshot(&amended))
+	if len(changes) == 0 {
+		return nil, nil, fmt.Errorf("%w: the amendment changes nothing", ErrInvalidAmendment)
+	}
+	if err := s.checkDropShipAmendment(ctx, order, &amended); err != nil {
+		return nil, nil, err
+	}
+
+	// Only the credit the amendment adds is checked; the order already holds the rest
+	shortfall, err := s.creditShortfall(ctx, order.CustomerID, amended.CreditExposure().Sub(order.CreditExposure()), "amendment adding")
+	if err != nil {
+		return nil, nil, err
+	}
+	if shortfall != "" {
+		return nil, nil, fmt.Errorf("%w: %s", ErrCreditLimitExceeded, shortfall)
+	}
+
+	return &amended, changes, nil
+}
+
+// amendmentApprovers returns the roles that must approve an amendment, in
+// sequence, and the amount they approve. None are needed when every role
+// the approval policies require of the amended order approved the order,
+// or one of its revisions, at an amount at least as large.
+func (s *ServiceImpl) amendmentApprovers(ctx context.Context, order, amended *entities.Order, revisions []*entities.OrderRevision) ([]string, decimal.Decimal, error) {
+	if s.approvalRepo == nil {
+		return nil, decimal.Zero, nil
+	}
+
+	steps, metrics, err := s.requiredApprovals(ctx, amended)
+	if err != nil {
+		return nil, decimal.Zero, err
+	}
+	if len(steps) == 0 {
+		return nil, decimal.Zero, nil
+	}
+
+	approvals, err := s.approvalRepo.GetApprovalsByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, decimal.Zero, fmt.Errorf("failed to get order approvals: %w", err)
+	}
+	if entities.CurrentApprovalChain(approvals).ApprovesFor(steps, metrics.Amount) {
+		return nil, decimal.Zero, nil
+	}
+
+	roles := make([]string, len(steps))
+	for i, step := range steps {
+		roles[i] = step.Role
+	}
+	for _, revision := range revisions {
+		if revision.ApprovesFor(roles, metrics.Amount) {
+			return nil, decimal.Zero, nil
+		}
+	}
+
+	return roles, metrics.Amount, nil
+}
+
+// applyAmendment moves the order's reservations from its lines to the
+// amended lines and saves the amended order. Reductions come off the
+// line's backorders first, then release reserved stock from the warehouses
+// the line is allocated to; increases and added lines are reserved like a
+// newly confirmed order. Backorders created for shortfalls are returned.
+func (s *ServiceImpl) applyAmendment(ctx context.Context, order, amended *entities.Order, revisionNumber int) ([]*entities.Backorder, error) {
+	allocations, err := s.sourcingRepo.GetAllocationsByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order allocations: %w", err)
+	}
+	backorders, err := s.backorderRepo.GetByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order backorders: %w", err)
+	}
+
+	existing := make(map[uuid.UUID]bool, len(order.Items))
+	for _, item := range order.Items {
+		existing[item.ID] = true
+	}
+
+	increases := make(map[uuid.UUID]int)
+	var added []*entities.OrderItem
+	for i := range amended.Items {
+		item := &amended.Items[i]
+		if !existing[item.ID] {
+			added = append(added, item)
+			increases[item.ID] = item.Quantity
+		}
+	}
+
+	var removed []uuid.UUID
+	for _, old := range order.Items {
+		item, _ := findOrderItem(amended, old.ID.String())
+		quantity := 0
+		if item != nil {
+			quantity = item.Quantity
+		} else {
+			removed = append(removed, old.ID)
+		}
+
+		if quantity > old.Quantity {
+			increases[old.ID] = quantity - old.Quantity
+			continue
+		}
+		reduction := old.Quantity - quantity
+		if reduction == 0 {
+			continue
+		}
+
+		backordered := min(reduction, old.QuantityBackordered)
+		if backordered > 0 {
+			if err := s.reduceBackorders(ctx, backorders, old.ID, backordered); err != nil {
+				return nil, err
+			}
+			if item != nil {
+				item.QuantityBackordered -= backordered
+			}
+		}
+
+		release := reduction - backordered
+		if release <= 0 || old.IsDropShip {
+			continue
+		}
+		tracked, err := s.tracksInventory(ctx, old.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		if !tracked {
+			continue
+		}
+		if _, err := s.drawReservedStockFrom(ctx, old.ProductID, release, allocatedWarehouses(allocations, old.ID), func(warehouseID uuid.UUID, take int) error {
+			if err := s.inventoryRepo.ReleaseStock(ctx, old.ProductID, warehouseID, take); err != nil {
+				return err
+			}
+			allocations = entities.ReleaseAllocations(allocations, old.ID, warehouseID, take)
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("failed to release inventory: %w", err)
+		}
+	}
+
+	if len(allocations) > 0 || len(removed) > 0 {
+		kept := make([]*entities.OrderAllocation, 0, len(allocations))
+		for _, allocation := range allocations {
+			if _, err := findOrderItem(amended, allocation.OrderItemID.String()); err == nil {
+				kept = append(kept, allocation)
+			}
+		}
+		if err := s.sourcingRepo.ReplaceAllocations(ctx, order.ID, kept); err != nil {
+			return nil, fmt.Errorf("failed to update order allocations: %w", err)
+		}
+	}
+
+	for _, itemID := range removed {
+		if err := s.orderItemRepo.Delete(ctx, itemID); err != nil {
+			return nil, fmt.Errorf("failed to delete order item: %w", err)
+		}
+	}
+	for _, item := range added {
+		if err := s.orderItemRepo.Create(ctx, item); err != nil {
+			return nil, fmt.Errorf("failed to create order item: %w", err)
+		}
+	}
+	if err := s.orderItemRepo.BulkUpdate(ctx, itemPointers(amended)); err != nil {
+		return nil, fmt.Errorf("failed to update order items: %w", err)
+	}
+
+	created, err := s.reserveQuantities(ctx, amended, increases, ledgerActor(ctx, order.CreatedBy))
+	if err != nil {
+		return nil, err
+	}
+	// Drop-ship lines added or increased are ordered from their suppliers
+	if err := s.placeDropShipOrders(ctx, amended, ledgerActor(ctx, order.CreatedBy)); err != nil {
+		return nil, err
+	}
+
+	appendInternalNote(amended, fmt.Sprintf("Amended to revision %d", revisionNumber))
+	if err := s.orderRepo.Update(ctx, amended); err != nil {
+		return nil, fmt.Errorf("failed to update order: %w", err)
+	}
+	if err := s.syncCustomerCredit(ctx, order.CustomerID); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// reduceBackorders takes quantity off the open backorders of an order line
+func (s *ServiceImpl) reduceBackorders(ctx context.Context, backorders []*entities.Backorder, orderItemID uuid.UUID, quantity int) error {
+	for _, backorder := range backorders {
+		if quantity == 0 {
+			break
+		}
+		if backorder.OrderItemID != orderItemID || !backorder.IsOpen() {
+			continue
+		}
+
+		take := min(quantity, backorder.Remaining())
+		if err := backorder.Reduce(take); err != nil {
+			return err
+		}
+		if err := s.backorderRepo.Update(ctx, backorder); err != nil {
+			return fmt.Errorf("failed to update backorder: %w", err)
+		}
+		quantity -= take
+	}
+	return nil
+}
+
+// baselineRevision records an order as it stood before its first amendment
+func baselineRevision(order *entities.Order) *entities.OrderRevision {
+	createdBy, createdAt := order.CreatedBy, order.CreatedAt
+	if order.ApprovedBy != nil {
+		createdBy = *order.ApprovedBy
+	}
+	if order.ApprovedAt != nil {
+		createdAt = *order.ApprovedAt
+	}
+
+	revision := &entities.OrderRevision{
+		ID:             uuid.New(),
+		OrderID:        order.ID,
+		RevisionNumber: 1,
+		Snapshot:       entities.NewOrderSnapshot(order),
+		CreatedBy:      createdBy,
+		CreatedAt:      createdAt,
+	}
+	revision.MarkApplied(createdAt)
+	return revision
+}
+
+// parseAmendment turns an amendment request into the amendment recorded with the revision
+func parseAmendment(req *AmendOrderRequest) (*entities.OrderAmendment, error) {
+	amendment := &entities.OrderAmendment{
+		Lines: make([]entities.OrderAmendmentLine, len(req.Lines)),
+	}
+
+	var err error
+	if amendment.ShippingAddressID, err = parseOptionalUUID(req.ShippingAddressID, "shipping address ID"); err != nil {
+		return nil, err
+	}
+	if amendment.BillingAddressID, err = parseOptionalUUID(req.BillingAddressID, "billing address ID"); err != nil {
+		return nil, err
+	}
+
+	for i, line := range req.Lines {
+		amended := entities.OrderAmendmentLine{
+			Quantity:       line.Quantity,
+			UnitPrice:      line.UnitPrice,
+			DiscountAmount: line.DiscountAmount,
+			Remove:         line.Remove,
+		}
+		if amended.OrderItemID, err = parseOptionalUUID(line.OrderItemID, "order item ID"); err != nil {
+			return nil, err
+		}
+		if amended.ProductID, err = parseOptionalUUID(line.ProductID, "product ID"); err != nil {
+			return nil, err
+		}
+		amendment.Lines[i] = amended
+	}
+
+	return amendment, nil
+}
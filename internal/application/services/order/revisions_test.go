@@ -0,0 +1,95 @@
+package order
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"erpgo/internal/domain/orders/entities"
+)
+
+// amendQuantity builds an amendment changing the quantity of the order's first line
+func amendQuantity(fixture *orderFixture, order *entities.Order, quantity int) *AmendOrderRequest {
+	itemID := order.Items[0].ID.String()
+	return &AmendOrderRequest{
+		Lines:     []AmendOrderLineRequest{{OrderItemID: &itemID, Quantity: &quantity}},
+		AmendedBy: fixture.user.String(),
+	}
+}
+
+func TestServiceImpl_AmendOrder(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reduced quantity releases stock", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		order := fixture.confirmOrder(t, service, 5)
+		store.resetWrites()
+
+		revision, err := service.AmendOrder(ctx, order.ID.String(), amendQuantity(fixture, order, 3))
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, revision.RevisionNumber)
+		assert.False(t, revision.IsPending())
+		require.Len(t, store.revisions, 2)
+		assert.Equal(t, 1, store.revisions[0].RevisionNumber, "the order as confirmed is kept as the first revision")
+
+		assert.Equal(t, 3, store.items[order.ID][0].Quantity)
+		assert.True(t, decimal.NewFromInt(150).Equal(store.orders[order.ID].Subtotal))
+		assert.Equal(t, 3, store.reserved(fixture.product.ID, fixture.warehouse.ID))
+		assert.Equal(t, map[uuid.UUID]int{fixture.warehouse.ID: 3}, store.allocated(order.ID))
+		assert.Equal(t, []uuid.UUID{order.ID}, store.locked)
+		assert.Empty(t, store.untransacted())
+	})
+
+	t.Run("increased quantity reserves stock", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		order := fixture.confirmOrder(t, service, 5)
+
+		_, err := service.AmendOrder(ctx, order.ID.String(), amendQuantity(fixture, order, 8))
+		require.NoError(t, err)
+
+		assert.Equal(t, 8, store.items[order.ID][0].Quantity)
+		assert.Equal(t, 8, store.reserved(fixture.product.ID, fixture.warehouse.ID))
+		assert.Equal(t, map[uuid.UUID]int{fixture.warehouse.ID: 8}, store.allocated(order.ID))
+	})
+
+	t.Run("amendment over the credit limit is refused", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.NewFromInt(300))
+		order := fixture.confirmOrder(t, service, 5)
+		store.resetWrites()
+
+		_, err := service.AmendOrder(ctx, order.ID.String(), amendQuantity(fixture, order, 7))
+		assert.ErrorIs(t, err, ErrCreditLimitExceeded)
+		assert.Empty(t, store.ops())
+		assert.Equal(t, 5, store.items[order.ID][0].Quantity)
+		assert.Equal(t, 5, store.reserved(fixture.product.ID, fixture.warehouse.ID))
+	})
+
+	t.Run("pending orders are updated, not amended", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		order := fixture.createOrder(t, service, 5)
+
+		_, err := service.AmendOrder(ctx, order.ID.String(), amendQuantity(fixture, order, 3))
+		assert.ErrorIs(t, err, ErrOrderNotAmendable)
+	})
+
+	t.Run("confirmed orders are amended, not updated", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		order := fixture.confirmOrder(t, service, 5)
+		store.resetWrites()
+
+		notes := "deliver to the back door"
+		_, err := service.UpdateOrder(ctx, order.ID.String(), &UpdateOrderRequest{Notes: &notes})
+		assert.ErrorIs(t, err, ErrOrderCannotBeModified)
+		assert.Empty(t, store.ops())
+	})
+}
@@ -0,0 +1,120 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"erpgo/internal/domain/orders/entities"
+	purchasingEntities "erpgo/internal/domain/purchasing/entities"
+)
+
+const (
+	defaultWeightUnit    = "kg"
+	defaultDimensionUnit = "cm"
+)
+
+// shipmentDetails carries the carrier, tracking and packing of a shipment wave
+type shipmentDetails struct {
+	trackingNumber string
+	carrier        string
+	shippingDate   *time.Time
+	packages       []ShipmentPackageRequest
+	shippedBy      string
+	// warehouseID is the warehouse the shipment leaves from, when known
+	warehouseID *uuid.UUID
+	// purchaseOrder is the drop-ship purchase order the supplier shipped
+	// against, saved with the shipment; nil for warehouse shipments
+	purchaseOrder *purchasingEntities.PurchaseOrder
+}
+
+// GetOrderShipments returns the shipments of an order, oldest first
+func (s *ServiceImpl) GetOrderShipments(ctx context.Context, id string) ([]*entities.Shipment, error) {
+	order, err := s.loadOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	shipments, err := s.shipmentRepo.GetByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order shipments: %w", err)
+	}
+
+	return shipments, nil
+}
+
+// newShipment builds the sequence-th shipment of an order carrying the given
+// quantities. Lines follow the order's item order.
+func newShipment(order *entities.Order, quantities map[uuid.UUID]int, details shipmentDetails, shippedBy uuid.UUID, sequence int) (*entities.Shipment, error) {
+	now := time.Now().UTC()
+	shippedAt := now
+	if details.shippingDate != nil {
+		shippedAt = details.shippingDate.UTC()
+	}
+
+	shipment := &entities.Shipment{
+		ID:             uuid.New(),
+		OrderID:        order.ID,
+		ShipmentNumber: fmt.Sprintf("%s-S%d", order.OrderNumber, sequence),
+		Status:         entities.ShipmentStatusShipped,
+		Carrier:        optionalString(details.carrier),
+		TrackingNumber: optionalString(details.trackingNumber),
+		ShippedBy:      shippedBy,
+		ShippedAt:      shippedAt,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	for _, item := range order.Items {
+		quantity, ok := quantities[item.ID]
+		if !ok {
+			continue
+		}
+		shipment.Items = append(shipment.Items, entities.ShipmentItem{
+			ID:          uuid.New(),
+			ShipmentID:  shipment.ID,
+			OrderItemID: item.ID,
+			ProductID:   item.ProductID,
+			Quantity:    quantity,
+		})
+	}
+
+	for _, pkg := range details.packages {
+		weightUnit, dimensionUnit := pkg.WeightUnit, pkg.DimensionUnit
+		if weightUnit == "" {
+			weightUnit = defaultWeightUnit
+		}
+		if dimensionUnit == "" {
+			dimensionUnit = defaultDimensionUnit
+		}
+		shipment.Packages = append(shipment.Packages, entities.ShipmentPackage{
+			ID:             uuid.New(),
+			ShipmentID:     shipment.ID,
+			TrackingNumber: optionalString(pkg.TrackingNumber),
+			Weight:         pkg.Weight,
+			WeightUnit:     weightUnit,
+			Length:         pkg.Length,
+			Width:          pkg.Width,
+			Height:         pkg.Height,
+			DimensionUnit:  dimensionUnit,
+		})
+	}
+
+	if err := shipment.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid shipment: %w", err)
+	}
+
+	return shipment, nil
+}
+
+// optionalString returns nil for blank strings and a pointer to the trimmed value otherwise
+func optionalString(value string) *string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,40 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
+)
+
+// ShippingRateCalculator quotes the shipping methods available for an order.
+// Orders are passed with their items and shipping address loaded.
+type ShippingRateCalculator interface {
+	QuoteShipping(ctx context.Context, order *entities.Order) ([]entities.ShippingQuote, error)
+}
+
+type zoneShippingRateCalculator struct {
+	zoneRepo repositories.ShippingZoneRepository
+}
+
+// NewZoneShippingRateCalculator creates a calculator that prices shipping by
+// the shipping zone rates of the order's shipping address
+func NewZoneShippingRateCalculator(zoneRepo repositories.ShippingZoneRepository) ShippingRateCalculator {
+	return &zoneShippingRateCalculator{zoneRepo: zoneRepo}
+}
+
+func (c *zoneShippingRateCalculator) QuoteShipping(ctx context.Context, order *entities.Order) ([]entities.ShippingQuote, error) {
+	if order.ShippingAddress == nil {
+		return []entities.ShippingQuote{}, nil
+	}
+
+	zones, err := c.zoneRepo.GetActiveByCountry(ctx, order.ShippingAddress.Country)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shipping zones: %w", err)
+	}
+
+	// Delivery is estimated from now: the order has not shipped yet
+	return entities.QuoteShipping(order, zones, time.Now().UTC()), nil
+}
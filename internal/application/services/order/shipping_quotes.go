@@ -0,0 +1,50 @@
+package order
+
+import (
+	"context"
+	"fmt"
+
+	"erpgo/internal/domain/orders/entities"
+)
+
+// QuoteShipping prices the shipping methods available at an order's shipping
+// address, cheapest first, with their delivery estimates
+func (s *ServiceImpl) QuoteShipping(ctx context.Context, id string) ([]entities.ShippingQuote, error) {
+	order, err := s.loadOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.shippingRates == nil {
+		return []entities.ShippingQuote{}, nil
+	}
+
+	quotes, err := s.shippingRates.QuoteShipping(ctx, order)
+	if err != nil {
+		return nil, fmt.Errorf("failed to quote shipping: %w", err)
+	}
+
+	return quotes, nil
+}
+
+// priceShipping quotes the shipping methods of an editable order and charges
+// the quote of the order's method. An order whose method has no rate keeps
+// the amount it was given, and an approved order keeps the shipping it was
+// sold with.
+func (s *ServiceImpl) priceShipping(ctx context.Context, order *entities.Order) error {
+	if s.shippingRates == nil || !isEditable(order) {
+		return nil
+	}
+
+	quotes, err := s.shippingRates.QuoteShipping(ctx, order)
+	if err != nil {
+		return fmt.Errorf("failed to quote shipping: %w", err)
+	}
+
+	order.ShippingQuotes = quotes
+	if quote, ok := entities.ShippingQuoteFor(quotes, order.ShippingMethod); ok {
+		order.ShippingAmount = quote.Amount
+	}
+
+	return nil
+}
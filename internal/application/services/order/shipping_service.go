@@ -0,0 +1,377 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
+)
+
+// ShippingService defines the interface for shipping zone and shipping rate management
+type ShippingService interface {
+	CreateShippingZone(ctx context.Context, req *CreateShippingZoneRequest) (*entities.ShippingZone, error)
+	GetShippingZone(ctx context.Context, id string) (*entities.ShippingZone, error)
+	UpdateShippingZone(ctx context.Context, id string, req *UpdateShippingZoneRequest) (*entities.ShippingZone, error)
+	ListShippingZones(ctx context.Context, req *ListShippingZonesRequest) (*ListShippingZonesResponse, error)
+
+	// AddShippingRate adds a rate to a zone and returns the zone with its rates
+	AddShippingRate(ctx context.Context, zoneID string, req *ShippingRateRequest) (*entities.ShippingZone, error)
+	UpdateShippingRate(ctx context.Context, zoneID, rateID string, req *UpdateShippingRateRequest) (*entities.ShippingZone, error)
+}
+
+// CreateShippingZoneRequest represents a request to create a shipping zone
+type CreateShippingZoneRequest struct {
+	Code               string                `json:"code" validate:"required,max=50"`
+	Name               string                `json:"name" validate:"required"`
+	Country            string                `json:"country" validate:"required,len=2"`
+	State              *string               `json:"state,omitempty"`
+	PostalCodePrefixes []string              `json:"postal_code_prefixes,omitempty"`
+	Rates              []ShippingRateRequest `json:"rates,omitempty"`
+}
+
+// UpdateShippingZoneRequest represents a request to update a shipping zone.
+// When PostalCodePrefixes is set the prefixes are replaced.
+type UpdateShippingZoneRequest struct {
+	Name               *string  `json:"name,omitempty"`
+	State              *string  `json:"state,omitempty"`
+	PostalCodePrefixes []string `json:"postal_code_prefixes,omitempty"`
+	IsActive           *bool    `json:"is_active,omitempty"`
+}
+
+// ShippingRateRequest represents a shipping rate of a zone
+type ShippingRateRequest struct {
+	Method                string           `json:"method" validate:"required"`
+	Name                  string           `json:"name" validate:"required"`
+	MinWeight             float64          `json:"min_weight"`
+	MaxWeight             *float64         `json:"max_weight,omitempty"`
+	BaseRate              decimal.Decimal  `json:"base_rate"`
+	PerKgRate             decimal.Decimal  `json:"per_kg_rate"`
+	HandlingFee           decimal.Decimal  `json:"handling_fee"`
+	FreeShippingThreshold *decimal.Decimal `json:"free_shipping_threshold,omitempty"`
+	DimensionalDivisor    float64          `json:"dimensional_divisor"`
+	MinDeliveryDays       int              `json:"min_delivery_days"`
+	MaxDeliveryDays       int              `json:"max_delivery_days"`
+}
+
+// UpdateShippingRateRequest represents a request to update a shipping rate.
+// ClearMaxWeight opens the bracket up and ClearFreeShippingThreshold stops
+// waiving the charge.
+type UpdateShippingRateRequest struct {
+	Name                       *string          `json:"name,omitempty"`
+	MinWeight                  *float64         `json:"min_weight,omitempty"`
+	MaxWeight                  *float64         `json:"max_weight,omitempty"`
+	ClearMaxWeight             bool             `json:"clear_max_weight,omitempty"`
+	BaseRate                   *decimal.Decimal `json:"base_rate,omitempty"`
+	PerKgRate                  *decimal.Decimal `json:"per_kg_rate,omitempty"`
+	HandlingFee                *decimal.Decimal `json:"handling_fee,omitempty"`
+	FreeShippingThreshold      *decimal.Decimal `json:"free_shipping_threshold,omitempty"`
+	ClearFreeShippingThreshold bool             `json:"clear_free_shipping_threshold,omitempty"`
+	DimensionalDivisor         *float64         `json:"dimensional_divisor,omitempty"`
+	MinDeliveryDays            *int             `json:"min_delivery_days,omitempty"`
+	MaxDeliveryDays            *int             `json:"max_delivery_days,omitempty"`
+	IsActive                   *bool            `json:"is_active,omitempty"`
+}
+
+// ListShippingZonesRequest represents a request to list shipping zones
+type ListShippingZonesRequest struct {
+	Search   string  `json:"search,omitempty"`
+	Country  string  `json:"country,omitempty"`
+	State    *string `json:"state,omitempty"`
+	IsActive *bool   `json:"is_active,omitempty"`
+	Page     int     `json:"page"`
+	Limit    int     `json:"limit"`
+}
+
+// ListShippingZonesResponse represents a paginated list of shipping zones
+type ListShippingZonesResponse struct {
+	Zones      []*entities.ShippingZone `json:"zones"`
+	Pagination *Pagination              `json:"pagination"`
+}
+
+// Shipping errors
+var (
+	ErrShippingZoneNotFound = errors.New("shipping zone not found")
+	ErrShippingZoneExists   = errors.New("shipping zone code already exists")
+	ErrShippingRateNotFound = errors.New("shipping rate not found")
+)
+
+// ShippingServiceImpl implements the ShippingService interface
+type ShippingServiceImpl struct {
+	zoneRepo repositories.ShippingZoneRepository
+	logger   *zerolog.Logger
+}
+
+// NewShippingService creates a new shipping service. The zones and rates it
+// manages are read by the zone shipping rate calculator when orders are priced.
+func NewShippingService(zoneRepo repositories.ShippingZoneRepository, logger *zerolog.Logger) ShippingService {
+	return &ShippingServiceImpl{
+		zoneRepo: zoneRepo,
+		logger:   logger,
+	}
+}
+
+// CreateShippingZone creates a shipping zone with its rates
+func (s *ShippingServiceImpl) CreateShippingZone(ctx context.Context, req *CreateShippingZoneRequest) (*entities.ShippingZone, error) {
+	code := strings.ToUpper(strings.TrimSpace(req.Code))
+	if _, err := s.zoneRepo.GetByCode(ctx, code); err == nil {
+		return nil, fmt.Errorf("%w: %s", ErrShippingZoneExists, code)
+	} else if !strings.Contains(err.Error(), "not found") {
+		return nil, fmt.Errorf("failed to check shipping zone code: %w", err)
+	}
+
+	now := time.Now().UTC()
+	zone := &entities.ShippingZone{
+		ID:                 uuid.New(),
+		Code:               code,
+		Name:               strings.TrimSpace(req.Name),
+		Country:            strings.ToUpper(strings.TrimSpace(req.Country)),
+		State:              trimmedOrNil(req.State),
+		PostalCodePrefixes: req.PostalCodePrefixes,
+		IsActive:           true,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+	for i := range req.Rates {
+		rate, err := newShippingRate(zone.ID, &req.Rates[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid shipping rate %d: %w", i+1, err)
+		}
+		zone.Rates = append(zone.Rates, *rate)
+	}
+
+	if err := zone.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid shipping zone data: %w", err)
+	}
+
+	if err := s.zoneRepo.Create(ctx, zone); err != nil {
+		return nil, fmt.Errorf("failed to create shipping zone: %w", err)
+	}
+
+	s.logger.Info().
+		Str("shipping_zone_id", zone.ID.String()).
+		Str("code", zone.Code).
+		Int("rates", len(zone.Rates)).
+		Msg("Shipping zone created")
+
+	return zone, nil
+}
+
+// GetShippingZone retrieves a shipping zone with its rates
+func (s *ShippingServiceImpl) GetShippingZone(ctx context.Context, id string) (*entities.ShippingZone, error) {
+	return s.loadZone(ctx, id)
+}
+
+// UpdateShippingZone updates the area covered by a shipping zone
+func (s *ShippingServiceImpl) UpdateShippingZone(ctx context.Context, id string, req *UpdateShippingZoneRequest) (*entities.ShippingZone, error) {
+	zone, err := s.loadZone(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		zone.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.State != nil {
+		// An empty state widens the zone to the whole country
+		zone.State = trimmedOrNil(req.State)
+	}
+	if req.PostalCodePrefixes != nil {
+		zone.PostalCodePrefixes = req.PostalCodePrefixes
+	}
+	if req.IsActive != nil {
+		zone.IsActive = *req.IsActive
+	}
+	zone.UpdatedAt = time.Now().UTC()
+
+	if err := zone.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid shipping zone data: %w", err)
+	}
+
+	if err := s.zoneRepo.Update(ctx, zone); err != nil {
+		return nil, fmt.Errorf("failed to update shipping zone: %w", err)
+	}
+
+	return zone, nil
+}
+
+// ListShippingZones lists shipping zones with their rates
+func (s *ShippingServiceImpl) ListShippingZones(ctx context.Context, req *ListShippingZonesRequest) (*ListShippingZonesResponse, error) {
+	page, limit := normalizePage(req.Page, req.Limit)
+
+	filter := repositories.ShippingZoneFilter{
+		Search:   req.Search,
+		Country:  req.Country,
+		State:    req.State,
+		IsActive: req.IsActive,
+		Page:     page,
+		Limit:    limit,
+		Offset:   (page - 1) * limit,
+	}
+
+	zones, err := s.zoneRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shipping zones: %w", err)
+	}
+
+	total, err := s.zoneRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count shipping zones: %w", err)
+	}
+
+	return &ListShippingZonesResponse{
+		Zones:      zones,
+		Pagination: newPagination(page, limit, total),
+	}, nil
+}
+
+// AddShippingRate adds a rate to a zone. Its weight bracket cannot overlap an
+// active rate of the same method.
+func (s *ShippingServiceImpl) AddShippingRate(ctx context.Context, zoneID string, req *ShippingRateRequest) (*entities.ShippingZone, error) {
+	zone, err := s.loadZone(ctx, zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	rate, err := newShippingRate(zone.ID, req)
+	if err != nil {
+		return nil, fmt.Errorf("invalid shipping rate: %w", err)
+	}
+	zone.Rates = append(zone.Rates, *rate)
+	if err := zone.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid shipping rate: %w", err)
+	}
+
+	if err := s.zoneRepo.CreateRate(ctx, rate); err != nil {
+		return nil, fmt.Errorf("failed to create shipping rate: %w", err)
+	}
+
+	return zone, nil
+}
+
+// UpdateShippingRate updates the bracket, charges or delivery window of a
+// rate, or deactivates it. Its method cannot change; a new rate is added instead.
+func (s *ShippingServiceImpl) UpdateShippingRate(ctx context.Context, zoneID, rateID string, req *UpdateShippingRateRequest) (*entities.ShippingZone, error) {
+	zone, err := s.loadZone(ctx, zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	rateUUID, err := uuid.Parse(rateID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid shipping rate ID: %w", err)
+	}
+
+	var rate *entities.ShippingRate
+	for i := range zone.Rates {
+		if zone.Rates[i].ID == rateUUID {
+			rate = &zone.Rates[i]
+			break
+		}
+	}
+	if rate == nil {
+		return nil, ErrShippingRateNotFound
+	}
+
+	if req.Name != nil {
+		rate.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.MinWeight != nil {
+		rate.MinWeight = *req.MinWeight
+	}
+	if req.ClearMaxWeight {
+		rate.MaxWeight = nil
+	} else if req.MaxWeight != nil {
+		rate.MaxWeight = req.MaxWeight
+	}
+	if req.BaseRate != nil {
+		rate.BaseRate = *req.BaseRate
+	}
+	if req.PerKgRate != nil {
+		rate.PerKgRate = *req.PerKgRate
+	}
+	if req.HandlingFee != nil {
+		rate.HandlingFee = *req.HandlingFee
+	}
+	if req.ClearFreeShippingThreshold {
+		rate.FreeShippingThreshold = nil
+	} else if req.FreeShippingThreshold != nil {
+		rate.FreeShippingThreshold = req.FreeShippingThreshold
+	}
+	if req.DimensionalDivisor != nil {
+		rate.DimensionalDivisor = *req.DimensionalDivisor
+	}
+	if req.MinDeliveryDays != nil {
+		rate.MinDeliveryDays = *req.MinDeliveryDays
+	}
+	if req.MaxDeliveryDays != nil {
+		rate.MaxDeliveryDays = *req.MaxDeliveryDays
+	}
+	if req.IsActive != nil {
+		rate.IsActive = *req.IsActive
+	}
+	rate.UpdatedAt = time.Now().UTC()
+
+	if err := zone.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid shipping rate: %w", err)
+	}
+
+	if err := s.zoneRepo.UpdateRate(ctx, rate); err != nil {
+		return nil, fmt.Errorf("failed to update shipping rate: %w", err)
+	}
+
+	return zone, nil
+}
+
+// loadZone parses the ID and loads the zone, mapping missing rows to ErrShippingZoneNotFound
+func (s *ShippingServiceImpl) loadZone(ctx context.Context, id string) (*entities.ShippingZone, error) {
+	zoneID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid shipping zone ID: %w", err)
+	}
+
+	zone, err := s.zoneRepo.GetByID(ctx, zoneID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrShippingZoneNotFound
+		}
+		return nil, fmt.Errorf("failed to get shipping zone: %w", err)
+	}
+
+	return zone, nil
+}
+
+// newShippingRate builds an active rate of a zone
+func newShippingRate(zoneID uuid.UUID, req *ShippingRateRequest) (*entities.ShippingRate, error) {
+	method, err := entities.ParseShippingMethod(req.Method)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	return &entities.ShippingRate{
+		ID:                    uuid.New(),
+		ZoneID:                zoneID,
+		Method:                method,
+		Name:                  strings.TrimSpace(req.Name),
+		MinWeight:             req.MinWeight,
+		MaxWeight:             req.MaxWeight,
+		BaseRate:              req.BaseRate,
+		PerKgRate:             req.PerKgRate,
+		HandlingFee:           req.HandlingFee,
+		FreeShippingThreshold: req.FreeShippingThreshold,
+		DimensionalDivisor:    req.DimensionalDivisor,
+		MinDeliveryDays:       req.MinDeliveryDays,
+		MaxDeliveryDays:       req.MaxDeliveryDays,
+		IsActive:              true,
+		CreatedAt:             now,
+		UpdatedAt:             now,
+	}, nil
+}
@@ -0,0 +1,316 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	invEntities "erpgo/internal/domain/inventory/entities"
+	invRepositories "erpgo/internal/domain/inventory/repositories"
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
+	"erpgo/pkg/database"
+)
+
+// stockKey identifies the stock of a product in a warehouse
+type stockKey struct {
+	productID   uuid.UUID
+	warehouseID uuid.UUID
+}
+
+// GetOrderAllocations returns the warehouses the order's lines are reserved from
+func (s *ServiceImpl) GetOrderAllocations(ctx context.Context, id string) ([]*entities.OrderAllocation, error) {
+	order, err := s.loadOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	allocations, err := s.sourcingRepo.GetAllocationsByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order allocations: %w", err)
+	}
+
+	return allocations, nil
+}
+
+// SourceOrder plans the warehouses of a reserved order again, counting the
+// stock it already holds as available to it, and moves its reservations to
+// match. Only quantities the order's allocations place in a warehouse are
+// sourced: stock reserved before its lines were allocated cannot be told
+// apart from other orders' reservations and stays where it is. The plan is
+// complete before anything is written; added quantities are then reserved
+// in one atomic bulk reservation before the quantities no longer needed are
+// released, all in the order's transaction.
+func (s *ServiceImpl) SourceOrder(ctx context.Context, id string) ([]*entities.OrderAllocation, error) {
+	orderID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order ID: %w", err)
+	}
+
+	var order *entities.Order
+	var allocations []*entities.OrderAllocation
+	err = database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		if err := s.lockOrders(ctx, orderID); err != nil {
+			return err
+		}
+		var err error
+		if order, err = s.loadOrder(ctx, id); err != nil {
+			return err
+		}
+		if !hasInventoryReservation(order) {
+			return ErrOrderNotSourceable
+		}
+		reservedBy := ledgerActor(ctx, order.CreatedBy)
+
+		current, err := s.sourcingRepo.GetAllocationsByOrderID(ctx, order.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get order allocations: %w", err)
+		}
+
+		lines, err := s.sourcingLines(ctx, order)
+		if err != nil {
+			return err
+		}
+		var allocated []entities.SourcingLine
+		for _, line := range lines {
+			line.Quantity = min(line.Quantity, allocatedQuantity(current, line.OrderItemID))
+			if line.Quantity > 0 {
+				allocated = append(allocated, line)
+			}
+		}
+		if len(allocated) == 0 {
+			return fmt.Errorf("%w: its reservations are not allocated to warehouses", ErrOrderNotSourceable)
+		}
+
+		plan, ruleID, err := s.planSourcing(ctx, order, allocated, current)
+		if err != nil {
+			return err
+		}
+		for itemID, remaining := range plan.Unallocated {
+			item, err := findOrderItem(order, itemID.String())
+			if err != nil {
+				return err
+			}
+			return fmt.Errorf("%w: %s needs %d more", ErrInsufficientInventory, item.ProductSKU, remaining)
+		}
+
+		deltas := make(map[stockKey]int)
+		for _, allocation := range plan.Allocations {
+			deltas[stockKey{allocation.ProductID, allocation.WarehouseID}] += allocation.Quantity
+		}
+		for _, allocation := range current {
+			deltas[stockKey{allocation.ProductID, allocation.WarehouseID}] -= allocation.Quantity
+		}
+
+		var reservations []invRepositories.StockReservation
+		for key, delta := range deltas {
+			if delta > 0 {
+				reservations = append(reservations, invRepositories.StockReservation{
+					ProductID:   key.productID,
+					WarehouseID: key.warehouseID,
+					Quantity:    delta,
+					ReservedBy:  reservedBy,
+				})
+			}
+		}
+		if len(reservations) > 0 {
+			if err := s.inventoryRepo.BulkReserveStock(ctx, reservations); err != nil {
+				return fmt.Errorf("%w: %v", ErrInventoryReservationFailed, err)
+			}
+		}
+		for key, delta := range deltas {
+			if delta < 0 {
+				if err := s.inventoryRepo.ReleaseStock(ctx, key.productID, key.warehouseID, -delta); err != nil {
+					return fmt.Errorf("failed to release inventory: %w", err)
+				}
+			}
+		}
+
+		allocations = newOrderAllocations(order, plan, ruleID)
+		if err := s.sourcingRepo.ReplaceAllocations(ctx, order.ID, allocations); err != nil {
+			return fmt.Errorf("failed to replace order allocations: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info().
+		Str("order_id", order.ID.String()).
+		Int("allocations", len(allocations)).
+		Msg("Order sourced")
+
+	return allocations, nil
+}
+
+// sourcingLines returns the unshipped quantities of the order's stocked lines
+func (s *ServiceImpl) sourcingLines(ctx context.Context, order *entities.Order) ([]entities.SourcingLine, error) {
+	var lines []entities.SourcingLine
+	for _, item := range order.Items {
+		quantity := item.ShippableQuantity()
+		if quantity <= 0 || item.IsDropShip {
+			continue
+		}
+
+		tracked, err := s.tracksInventory(ctx, item.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		if tracked {
+			lines = append(lines, entities.SourcingLine{OrderItemID: item.ID, ProductID: item.ProductID, Quantity: quantity})
+		}
+	}
+	return lines, nil
+}
+
+// planSourcing allocates lines of an order to the active warehouses holding
+// their products, using the strategies of the sourcing rule matching the
+// order or the default strategies. Stock the order already holds through
+// its allocations counts as available to it. The ID of the rule applied is
+// returned along with the plan.
+func (s *ServiceImpl) planSourcing(ctx context.Context, order *entities.Order, lines []entities.SourcingLine, held []*entities.OrderAllocation) (*entities.SourcingPlan, *uuid.UUID, error) {
+	if order.ShippingAddress == nil {
+		if address, err := s.addressRepo.GetByID(ctx, order.ShippingAddressID); err == nil {
+			order.ShippingAddress = address
+		}
+	}
+	var destination entities.SourcingAddress
+	if address := order.ShippingAddress; address != nil {
+		destination = entities.SourcingAddress{Country: address.Country, State: address.State, PostalCode: address.PostalCode}
+	}
+
+	active := true
+	rules, err := s.sourcingRepo.ListRules(ctx, repositories.SourcingRuleFilter{IsActive: &active})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get sourcing rules: %w", err)
+	}
+	strategies := entities.DefaultSourcingStrategies
+	var ruleID *uuid.UUID
+	if rule := entities.SelectSourcingRule(rules, order.ShippingMethod, destination.Country); rule != nil {
+		strategies = rule.Strategies
+		ruleID = &rule.ID
+	}
+
+	holding := make(map[stockKey]int)
+	for _, allocation := range held {
+		holding[stockKey{allocation.ProductID, allocation.WarehouseID}] += allocation.Quantity
+	}
+
+	var warehouses []entities.SourcingWarehouse
+	index := make(map[uuid.UUID]int)
+	skipped := make(map[uuid.UUID]bool)
+	levelsLoaded := make(map[uuid.UUID]bool)
+	for _, line := range lines {
+		if levelsLoaded[line.ProductID] {
+			continue
+		}
+		levelsLoaded[line.ProductID] = true
+
+		levels, err := s.inventoryRepo.GetProductInventory(ctx, line.ProductID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get inventory levels: %w", err)
+		}
+
+		for _, level := range levels {
+			if skipped[level.WarehouseID] {
+				continue
+			}
+			i, ok := index[level.WarehouseID]
+			if !ok {
+				warehouse, err := s.warehouseRepo.GetExtendedByID(ctx, level.WarehouseID)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to get warehouse: %w", err)
+				}
+				if !warehouse.IsActive {
+					skipped[level.WarehouseID] = true
+					continue
+				}
+				i = len(warehouses)
+				index[level.WarehouseID] = i
+				warehouses = append(warehouses, entities.SourcingWarehouse{
+					ID:          warehouse.ID,
+					Code:        warehouse.Code,
+					Fulfillment: warehouse.Type == invEntities.WarehouseTypeFulfillment,
+					Address: entities.SourcingAddress{
+						Country:    warehouse.Country,
+						State:      warehouse.State,
+						PostalCode: warehouse.PostalCode,
+					},
+					Available: make(map[uuid.UUID]int),
+				})
+			}
+
+			available := level.GetAvailableQuantity() + holding[stockKey{line.ProductID, level.WarehouseID}]
+			if available > 0 {
+				warehouses[i].Available[line.ProductID] = available
+			}
+		}
+	}
+
+	return entities.PlanSourcing(lines, warehouses, strategies, destination), ruleID, nil
+}
+
+// newOrderAllocations turns the allocations of a plan into records of the order
+func newOrderAllocations(order *entities.Order, plan *entities.SourcingPlan, ruleID *uuid.UUID) []*entities.OrderAllocation {
+	now := time.Now().UTC()
+	allocations := make([]*entities.OrderAllocation, 0, len(plan.Allocations))
+	for _, planned := range plan.Allocations {
+		allocation := planned
+		allocation.ID = uuid.New()
+		allocation.OrderID = order.ID
+		allocation.RuleID = ruleID
+		allocation.CreatedAt = now
+		allocation.UpdatedAt = now
+		allocations = append(allocations, &allocation)
+	}
+	return allocations
+}
+
+// newStockAllocation builds an allocation of stock reserved for an order
+// line outside of sourcing, such as stock allocated to a backorder
+func newStockAllocation(orderID, orderItemID, productID, warehouseID uuid.UUID, quantity int) *entities.OrderAllocation {
+	now := time.Now().UTC()
+	return &entities.OrderAllocation{
+		ID:          uuid.New(),
+		OrderID:     orderID,
+		OrderItemID: orderItemID,
+		ProductID:   productID,
+		WarehouseID: warehouseID,
+		Quantity:    quantity,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// allocatedWarehouses returns the warehouses an order item is allocated to,
+// largest allocation first
+func allocatedWarehouses(allocations []*entities.OrderAllocation, orderItemID uuid.UUID) []uuid.UUID {
+	var matching []*entities.OrderAllocation
+	for _, allocation := range allocations {
+		if allocation.OrderItemID == orderItemID {
+			matching = append(matching, allocation)
+		}
+	}
+	sort.SliceStable(matching, func(i, j int) bool { return matching[i].Quantity > matching[j].Quantity })
+
+	warehouses := make([]uuid.UUID, len(matching))
+	for i, allocation := range matching {
+		warehouses[i] = allocation.WarehouseID
+	}
+	return warehouses
+}
+
+// allocatedQuantity returns the quantity of an order item allocated across warehouses
+func allocatedQuantity(allocations []*entities.OrderAllocation, orderItemID uuid.UUID) int {
+	total := 0
+	for _, allocation := range allocations {
+		if allocation.OrderItemID == orderItemID {
+			total += allocation.Quantity
+		}
+	}
+	return total
+}
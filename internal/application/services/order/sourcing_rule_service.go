@@ -0,0 +1,243 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
+)
+
+// SourcingRuleService defines the interface for sourcing rule management.
+// Orders are sourced through the order service.
+type SourcingRuleService interface {
+	CreateSourcingRule(ctx context.Context, req *CreateSourcingRuleRequest) (*entities.SourcingRule, error)
+	GetSourcingRule(ctx context.Context, id string) (*entities.SourcingRule, error)
+	UpdateSourcingRule(ctx context.Context, id string, req *UpdateSourcingRuleRequest) (*entities.SourcingRule, error)
+	ListSourcingRules(ctx context.Context, req *ListSourcingRulesRequest) (*ListSourcingRulesResponse, error)
+}
+
+// CreateSourcingRuleRequest represents a request to create a sourcing rule
+type CreateSourcingRuleRequest struct {
+	Name            string   `json:"name" validate:"required"`
+	Description     *string  `json:"description,omitempty"`
+	Countries       []string `json:"countries,omitempty"`
+	ShippingMethods []string `json:"shipping_methods,omitempty"`
+	Strategies      []string `json:"strategies" validate:"required,min=1"`
+	Priority        int      `json:"priority"`
+	CreatedBy       string   `json:"created_by" validate:"required,uuid"`
+}
+
+// UpdateSourcingRuleRequest represents a request to update a sourcing rule.
+// Countries, shipping methods and strategies are replaced when set.
+type UpdateSourcingRuleRequest struct {
+	Name            *string  `json:"name,omitempty"`
+	Description     *string  `json:"description,omitempty"`
+	Countries       []string `json:"countries,omitempty"`
+	ShippingMethods []string `json:"shipping_methods,omitempty"`
+	Strategies      []string `json:"strategies,omitempty"`
+	Priority        *int     `json:"priority,omitempty"`
+	IsActive        *bool    `json:"is_active,omitempty"`
+}
+
+// ListSourcingRulesRequest represents a request to list sourcing rules
+type ListSourcingRulesRequest struct {
+	Search   string `json:"search,omitempty"`
+	IsActive *bool  `json:"is_active,omitempty"`
+	Page     int    `json:"page"`
+	Limit    int    `json:"limit"`
+}
+
+// ListSourcingRulesResponse represents a paginated list of sourcing rules
+type ListSourcingRulesResponse struct {
+	Rules      []*entities.SourcingRule `json:"rules"`
+	Pagination *Pagination              `json:"pagination"`
+}
+
+// Sourcing errors
+var (
+	ErrSourcingRuleNotFound = errors.New("sourcing rule not found")
+)
+
+// SourcingRuleServiceImpl implements the SourcingRuleService interface
+type SourcingRuleServiceImpl struct {
+	sourcingRepo repositories.SourcingRepository
+	logger       *zerolog.Logger
+}
+
+// NewSourcingRuleService creates a new sourcing rule service
+func NewSourcingRuleService(
+	sourcingRepo repositories.SourcingRepository,
+	logger *zerolog.Logger,
+) SourcingRuleService {
+	return &SourcingRuleServiceImpl{
+		sourcingRepo: sourcingRepo,
+		logger:       logger,
+	}
+}
+
+// CreateSourcingRule creates an active sourcing rule. Orders already
+// reserved keep their warehouses until they are sourced again.
+func (s *SourcingRuleServiceImpl) CreateSourcingRule(ctx context.Context, req *CreateSourcingRuleRequest) (*entities.SourcingRule, error) {
+	createdBy, err := uuid.Parse(req.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid created by user ID: %w", err)
+	}
+
+	now := time.Now().UTC()
+	rule := &entities.SourcingRule{
+		ID:              uuid.New(),
+		Name:            strings.TrimSpace(req.Name),
+		Description:     trimmedOrNil(req.Description),
+		Countries:       sourcingCountries(req.Countries),
+		ShippingMethods: sourcingShippingMethods(req.ShippingMethods),
+		Strategies:      sourcingStrategies(req.Strategies),
+		Priority:        req.Priority,
+		IsActive:        true,
+		CreatedBy:       createdBy,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	if err := rule.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid sourcing rule data: %w", err)
+	}
+
+	if err := s.sourcingRepo.CreateRule(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to create sourcing rule: %w", err)
+	}
+
+	s.logger.Info().
+		Str("rule_id", rule.ID.String()).
+		Str("name", rule.Name).
+		Int("priority", rule.Priority).
+		Msg("Sourcing rule created")
+
+	return rule, nil
+}
+
+// GetSourcingRule retrieves a sourcing rule by ID
+func (s *SourcingRuleServiceImpl) GetSourcingRule(ctx context.Context, id string) (*entities.SourcingRule, error) {
+	return s.loadRule(ctx, id)
+}
+
+// UpdateSourcingRule updates the conditions, strategies or status of a
+// sourcing rule
+func (s *SourcingRuleServiceImpl) UpdateSourcingRule(ctx context.Context, id string, req *UpdateSourcingRuleRequest) (*entities.SourcingRule, error) {
+	rule, err := s.loadRule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		rule.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		rule.Description = trimmedOrNil(req.Description)
+	}
+	if req.Countries != nil {
+		rule.Countries = sourcingCountries(req.Countries)
+	}
+	if req.ShippingMethods != nil {
+		rule.ShippingMethods = sourcingShippingMethods(req.ShippingMethods)
+	}
+	if req.Strategies != nil {
+		rule.Strategies = sourcingStrategies(req.Strategies)
+	}
+	if req.Priority != nil {
+		rule.Priority = *req.Priority
+	}
+	if req.IsActive != nil {
+		rule.IsActive = *req.IsActive
+	}
+	rule.UpdatedAt = time.Now().UTC()
+
+	if err := rule.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid sourcing rule data: %w", err)
+	}
+
+	if err := s.sourcingRepo.UpdateRule(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to update sourcing rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+// ListSourcingRules lists sourcing rules, highest priority first
+func (s *SourcingRuleServiceImpl) ListSourcingRules(ctx context.Context, req *ListSourcingRulesRequest) (*ListSourcingRulesResponse, error) {
+	page, limit := normalizePage(req.Page, req.Limit)
+
+	filter := repositories.SourcingRuleFilter{
+		Search:   req.Search,
+		IsActive: req.IsActive,
+		Page:     page,
+		Limit:    limit,
+		Offset:   (page - 1) * limit,
+	}
+
+	rules, err := s.sourcingRepo.ListRules(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sourcing rules: %w", err)
+	}
+
+	total, err := s.sourcingRepo.CountRules(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count sourcing rules: %w", err)
+	}
+
+	return &ListSourcingRulesResponse{
+		Rules:      rules,
+		Pagination: newPagination(page, limit, total),
+	}, nil
+}
+
+// loadRule parses the ID and loads the rule, mapping missing rows to ErrSourcingRuleNotFound
+func (s *SourcingRuleServiceImpl) loadRule(ctx context.Context, id string) (*entities.SourcingRule, error) {
+	ruleID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sourcing rule ID: %w", err)
+	}
+
+	rule, err := s.sourcingRepo.GetRuleByID(ctx, ruleID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrSourcingRuleNotFound
+		}
+		return nil, fmt.Errorf("failed to get sourcing rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+// sourcingCountries normalizes the destination countries of a rule
+func sourcingCountries(values []string) []string {
+	countries := make([]string, 0, len(values))
+	for _, value := range values {
+		countries = append(countries, strings.ToUpper(strings.TrimSpace(value)))
+	}
+	return countries
+}
+
+// sourcingShippingMethods normalizes the shipping methods of a rule
+func sourcingShippingMethods(values []string) []entities.ShippingMethod {
+	methods := make([]entities.ShippingMethod, 0, len(values))
+	for _, value := range values {
+		methods = append(methods, entities.ShippingMethod(strings.ToUpper(strings.TrimSpace(value))))
+	}
+	return methods
+}
+
+// sourcingStrategies normalizes the strategies of a rule, keeping their precedence
+func sourcingStrategies(values []string) []entities.SourcingStrategy {
+	strategies := make([]entities.SourcingStrategy, 0, len(values))
+	for _, value := range values {
+		strategies = append(strategies, entities.SourcingStrategy(strings.ToUpper(strings.TrimSpace(value))))
+	}
+	return strategies
+}
@@ -0,0 +1,93 @@
+package order
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceImpl_SourceOrder(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reservations move to the closer warehouse", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		order := fixture.confirmOrder(t, service, 5)
+
+		west := store.addWarehouse("WEST", "US", "CA", "94105")
+		store.addStock(fixture.product.ID, west.ID, 100)
+		store.resetWrites()
+
+		allocations, err := service.SourceOrder(ctx, order.ID.String())
+		require.NoError(t, err)
+
+		require.Len(t, allocations, 1)
+		assert.Equal(t, west.ID, allocations[0].WarehouseID)
+		assert.Equal(t, 5, store.reserved(fixture.product.ID, west.ID))
+		assert.Zero(t, store.reserved(fixture.product.ID, fixture.warehouse.ID))
+		assert.Equal(t, map[uuid.UUID]int{west.ID: 5}, store.allocated(order.ID))
+		assert.Equal(t, []uuid.UUID{order.ID}, store.locked)
+		assert.Empty(t, store.untransacted())
+	})
+
+	t.Run("stock reserved outside of the allocations stays where it is", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		order := fixture.confirmOrder(t, service, 5)
+		store.allocations[order.ID][0].Quantity = 3
+
+		west := store.addWarehouse("WEST", "US", "CA", "94105")
+		store.addStock(fixture.product.ID, west.ID, 100)
+
+		_, err := service.SourceOrder(ctx, order.ID.String())
+		require.NoError(t, err)
+
+		assert.Equal(t, 3, store.reserved(fixture.product.ID, west.ID))
+		assert.Equal(t, 2, store.reserved(fixture.product.ID, fixture.warehouse.ID))
+		assert.Equal(t, map[uuid.UUID]int{west.ID: 3}, store.allocated(order.ID))
+	})
+
+	t.Run("orders without allocations are not sourced", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		order := fixture.confirmOrder(t, service, 5)
+		delete(store.allocations, order.ID)
+		store.resetWrites()
+
+		_, err := service.SourceOrder(ctx, order.ID.String())
+		assert.ErrorIs(t, err, ErrOrderNotSourceable)
+		assert.Empty(t, store.ops())
+		assert.Equal(t, 5, store.reserved(fixture.product.ID, fixture.warehouse.ID))
+	})
+
+	t.Run("orders without reservations are not sourced", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		order := fixture.createOrder(t, service, 5)
+
+		_, err := service.SourceOrder(ctx, order.ID.String())
+		assert.ErrorIs(t, err, ErrOrderNotSourceable)
+	})
+
+	t.Run("plan short of stock changes nothing", func(t *testing.T) {
+		service, store := newTestService(t)
+		fixture := newOrderFixture(store, decimal.Zero)
+		order := fixture.confirmOrder(t, service, 5)
+
+		west := store.addWarehouse("WEST", "US", "CA", "94105")
+		store.addStock(fixture.product.ID, west.ID, 2)
+		store.warehouses[fixture.warehouse.ID].IsActive = false
+		store.resetWrites()
+
+		_, err := service.SourceOrder(ctx, order.ID.String())
+		assert.ErrorIs(t, err, ErrInsufficientInventory)
+		assert.Empty(t, store.ops())
+		assert.Equal(t, 5, store.reserved(fixture.product.ID, fixture.warehouse.ID))
+		assert.Zero(t, store.reserved(fixture.product.ID, west.ID))
+		assert.Equal(t, map[uuid.UUID]int{fixture.warehouse.ID: 5}, store.allocated(order.ID))
+	})
+}
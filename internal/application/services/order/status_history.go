@@ -0,0 +1,156 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"erpgo/internal/domain/orders/entities"
+)
+
+type statusChangeActorKey struct{}
+
+// statusChangeActor attributes status changes recorded in the order history
+type statusChangeActor struct {
+	userID string
+	source entities.StatusChangeSource
+}
+
+// WithStatusChangeActor returns a context that attributes order status changes
+// made with it to the given user and source. Changes made without an actor are
+// recorded as SYSTEM changes.
+func WithStatusChangeActor(ctx context.Context, userID string, source entities.StatusChangeSource) context.Context {
+	return context.WithValue(ctx, statusChangeActorKey{}, statusChangeActor{userID: userID, source: source})
+}
+
+// withActorID overrides the acting user while keeping the source of the context
+func withActorID(ctx context.Context, userID string) context.Context {
+	if userID == "" {
+		return ctx
+	}
+	actor := actorFromContext(ctx)
+	actor.userID = userID
+	return context.WithValue(ctx, statusChangeActorKey{}, actor)
+}
+
+func actorFromContext(ctx context.Context) statusChangeActor {
+	if actor, ok := ctx.Value(statusChangeActorKey{}).(statusChangeActor); ok {
+		if actor.source == "" {
+			actor.source = entities.StatusChangeSourceSystem
+		}
+		return actor
+	}
+	return statusChangeActor{source: entities.StatusChangeSourceSystem}
+}
+
+// GetOrderStatusHistory returns the recorded status changes of an order,
+// reading the history of archived orders from the archive
+func (s *ServiceImpl) GetOrderStatusHistory(ctx context.Context, id string) ([]*entities.OrderStatusHistory, error) {
+	order, err := s.loadOrder(ctx, id)
+	if errors.Is(err, ErrOrderAlreadyArchived) {
+		archived, err := s.loadArchivedOrder(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return s.archiveRepo.GetStatusHistory(ctx, archived.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.historyRepo.GetByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order status history: %w", err)
+	}
+
+	return history, nil
+}
+
+// GetOrderTimeline returns status, payment and shipment events of an order in
+// chronological order. The shipments of an archived order stay live, while
+// its status history is read from the archive.
+func (s *ServiceImpl) GetOrderTimeline(ctx context.Context, id string) ([]entities.OrderTimelineEvent, error) {
+	order, err := s.loadOrder(ctx, id)
+	archived := errors.Is(err, ErrOrderAlreadyArchived)
+	if archived {
+		order, err = s.loadArchivedOrder(ctx, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var history []*entities.OrderStatusHistory
+	if archived {
+		history, err = s.archiveRepo.GetStatusHistory(ctx, order.ID)
+	} else {
+		history, err = s.historyRepo.GetByOrderID(ctx, order.ID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order status history: %w", err)
+	}
+
+	shipments, err := s.shipmentRepo.GetByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order shipments: %w", err)
+	}
+
+	return entities.BuildOrderTimeline(order, history, shipments), nil
+}
+
+// recordStatusChange appends an order status change to the history when the status moved
+func (s *ServiceImpl) recordStatusChange(ctx context.Context, order *entities.Order, from *entities.OrderStatus, reason string) error {
+	if from != nil && *from == order.Status {
+		return nil
+	}
+
+	var fromStatus *string
+	if from != nil {
+		status := string(*from)
+		fromStatus = &status
+	}
+
+	return s.appendHistory(ctx, &entities.OrderStatusHistory{
+		OrderID:    order.ID,
+		Type:       entities.StatusHistoryTypeOrder,
+		FromStatus: fromStatus,
+		ToStatus:   string(order.Status),
+		Reason:     reason,
+	})
+}
+
+// recordPaymentEvent appends a payment or refund to the history. Payment events
+// are always recorded, even when the payment status does not change.
+func (s *ServiceImpl) recordPaymentEvent(ctx context.Context, order *entities.Order, from entities.PaymentStatus, amount decimal.Decimal, reason string) error {
+	fromStatus := string(from)
+	return s.appendHistory(ctx, &entities.OrderStatusHistory{
+		OrderID:    order.ID,
+		Type:       entities.StatusHistoryTypePayment,
+		FromStatus: &fromStatus,
+		ToStatus:   string(order.PaymentStatus),
+		Amount:     &amount,
+		Reason:     reason,
+	})
+}
+
+func (s *ServiceImpl) appendHistory(ctx context.Context, entry *entities.OrderStatusHistory) error {
+	actor := actorFromContext(ctx)
+	entry.Source = actor.source
+	if actor.userID != "" {
+		if userID, err := uuid.Parse(actor.userID); err == nil {
+			entry.ChangedBy = &userID
+		}
+	}
+
+	if err := entry.Validate(); err != nil {
+		return fmt.Errorf("invalid status history entry: %w", err)
+	}
+
+	if err := s.historyRepo.Create(ctx, entry); err != nil {
+		return fmt.Errorf("failed to record status history: %w", err)
+	}
+
+	return nil
+}
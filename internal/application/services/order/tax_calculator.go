@@ -0,0 +1,52 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
+)
+
+// TaxCalculator works out the tax of an order. Orders are passed with their
+// items, shipping address and customer loaded.
+type TaxCalculator interface {
+	CalculateTax(ctx context.Context, order *entities.Order) (*entities.TaxCalculation, error)
+}
+
+type ruleTaxCalculator struct {
+	zoneRepo      repositories.TaxZoneRepository
+	exemptionRepo repositories.TaxExemptionRepository
+}
+
+// NewRuleTaxCalculator creates a calculator that taxes orders by the tax zone
+// rules of their shipping address and the customer's exemption certificates
+func NewRuleTaxCalculator(zoneRepo repositories.TaxZoneRepository, exemptionRepo repositories.TaxExemptionRepository) TaxCalculator {
+	return &ruleTaxCalculator{zoneRepo: zoneRepo, exemptionRepo: exemptionRepo}
+}
+
+func (c *ruleTaxCalculator) CalculateTax(ctx context.Context, order *entities.Order) (*entities.TaxCalculation, error) {
+	var zones []*entities.TaxZone
+	if order.ShippingAddress != nil {
+		var err error
+		zones, err = c.zoneRepo.GetActiveByCountry(ctx, order.ShippingAddress.Country)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tax zones: %w", err)
+		}
+	}
+
+	exemptions, err := c.exemptionRepo.GetByCustomerID(ctx, order.CustomerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tax exemptions: %w", err)
+	}
+
+	// Exemptions are judged on the order date so recalculating an old order
+	// does not pick up certificates issued since
+	at := order.OrderDate
+	if at.IsZero() {
+		at = time.Now().UTC()
+	}
+
+	return entities.CalculateTax(order, zones, exemptions, at), nil
+}
@@ -0,0 +1,506 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
+)
+
+// TaxService defines the interface for tax zone, tax rule and tax exemption management
+type TaxService interface {
+	CreateTaxZone(ctx context.Context, req *CreateTaxZoneRequest) (*entities.TaxZone, error)
+	GetTaxZone(ctx context.Context, id string) (*entities.TaxZone, error)
+	UpdateTaxZone(ctx context.Context, id string, req *UpdateTaxZoneRequest) (*entities.TaxZone, error)
+	ListTaxZones(ctx context.Context, req *ListTaxZonesRequest) (*ListTaxZonesResponse, error)
+
+	// AddTaxRule adds a rule to a zone and returns the zone with its rules
+	AddTaxRule(ctx context.Context, zoneID string, req *TaxRuleRequest) (*entities.TaxZone, error)
+	UpdateTaxRule(ctx context.Context, zoneID, ruleID string, req *UpdateTaxRuleRequest) (*entities.TaxZone, error)
+
+	CreateTaxExemption(ctx context.Context, req *CreateTaxExemptionRequest) (*entities.TaxExemption, error)
+	GetCustomerTaxExemptions(ctx context.Context, customerID string) ([]*entities.TaxExemption, error)
+	RevokeTaxExemption(ctx context.Context, id string, req *RevokeTaxExemptionRequest) (*entities.TaxExemption, error)
+}
+
+// CreateTaxZoneRequest represents a request to create a tax zone
+type CreateTaxZoneRequest struct {
+	Code               string           `json:"code" validate:"required,max=50"`
+	Name               string           `json:"name" validate:"required"`
+	Country            string           `json:"country" validate:"required,len=2"`
+	State              *string          `json:"state,omitempty"`
+	PostalCodePrefixes []string         `json:"postal_code_prefixes,omitempty"`
+	PricesIncludeTax   bool             `json:"prices_include_tax"`
+	Rules              []TaxRuleRequest `json:"rules,omitempty"`
+}
+
+// UpdateTaxZoneRequest represents a request to update a tax zone.
+// When PostalCodePrefixes is set the prefixes are replaced.
+type UpdateTaxZoneRequest struct {
+	Name               *string  `json:"name,omitempty"`
+	State              *string  `json:"state,omitempty"`
+	PostalCodePrefixes []string `json:"postal_code_prefixes,omitempty"`
+	PricesIncludeTax   *bool    `json:"prices_include_tax,omitempty"`
+	IsActive           *bool    `json:"is_active,omitempty"`
+}
+
+// TaxRuleRequest represents a tax rule of a zone
+type TaxRuleRequest struct {
+	Name     string          `json:"name" validate:"required"`
+	Level    string          `json:"level" validate:"required"`
+	TaxClass string          `json:"tax_class,omitempty"`
+	Rate     decimal.Decimal `json:"rate" validate:"required"`
+}
+
+// UpdateTaxRuleRequest represents a request to update a tax rule
+type UpdateTaxRuleRequest struct {
+	Name     *string          `json:"name,omitempty"`
+	Level    *string          `json:"level,omitempty"`
+	TaxClass *string          `json:"tax_class,omitempty"`
+	Rate     *decimal.Decimal `json:"rate,omitempty"`
+	IsActive *bool            `json:"is_active,omitempty"`
+}
+
+// ListTaxZonesRequest represents a request to list tax zones
+type ListTaxZonesRequest struct {
+	Search   string  `json:"search,omitempty"`
+	Country  string  `json:"country,omitempty"`
+	State    *string `json:"state,omitempty"`
+	IsActive *bool   `json:"is_active,omitempty"`
+	Page     int     `json:"page"`
+	Limit    int     `json:"limit"`
+}
+
+// ListTaxZonesResponse represents a paginated list of tax zones
+type ListTaxZonesResponse struct {
+	Zones      []*entities.TaxZone `json:"zones"`
+	Pagination *Pagination         `json:"pagination"`
+}
+
+// CreateTaxExemptionRequest represents a request to record a customer's exemption certificate
+type CreateTaxExemptionRequest struct {
+	CustomerID        string     `json:"customer_id" validate:"required,uuid"`
+	CertificateNumber string     `json:"certificate_number" validate:"required"`
+	Country           string     `json:"country" validate:"required,len=2"`
+	State             *string    `json:"state,omitempty"`
+	ValidFrom         *time.Time `json:"valid_from,omitempty"`
+	ValidUntil        *time.Time `json:"valid_until,omitempty"`
+	Reason            *string    `json:"reason,omitempty"`
+	CreatedBy         string     `json:"created_by" validate:"required,uuid"`
+}
+
+// RevokeTaxExemptionRequest represents a request to revoke an exemption certificate
+type RevokeTaxExemptionRequest struct {
+	Reason    string `json:"reason" validate:"required"`
+	RevokedBy string `json:"revoked_by" validate:"required,uuid"`
+}
+
+// Tax errors
+var (
+	ErrTaxZoneNotFound      = errors.New("tax zone not found")
+	ErrTaxZoneExists        = errors.New("tax zone code already exists")
+	ErrTaxRuleNotFound      = errors.New("tax rule not found")
+	ErrTaxExemptionNotFound = errors.New("tax exemption not found")
+	ErrTaxExemptionExists   = errors.New("tax exemption certificate already recorded for customer")
+	ErrTaxExemptionRevoked  = errors.New("tax exemption is already revoked")
+)
+
+// TaxServiceImpl implements the TaxService interface
+type TaxServiceImpl struct {
+	zoneRepo      repositories.TaxZoneRepository
+	exemptionRepo repositories.TaxExemptionRepository
+	customerRepo  repositories.CustomerRepository
+	logger        *zerolog.Logger
+}
+
+// NewTaxService creates a new tax service. The zones and exemptions it manages
+// are read by the rule tax calculator when orders are priced.
+func NewTaxService(
+	zoneRepo repositories.TaxZoneRepository,
+	exemptionRepo repositories.TaxExemptionRepository,
+	customerRepo repositories.CustomerRepository,
+	logger *zerolog.Logger,
+) TaxService {
+	return &TaxServiceImpl{
+		zoneRepo:      zoneRepo,
+		exemptionRepo: exemptionRepo,
+		customerRepo:  customerRepo,
+		logger:        logger,
+	}
+}
+
+// CreateTaxZone creates a tax zone with its rules
+func (s *TaxServiceImpl) CreateTaxZone(ctx context.Context, req *CreateTaxZoneRequest) (*entities.TaxZone, error) {
+	code := strings.ToUpper(strings.TrimSpace(req.Code))
+	if _, err := s.zoneRepo.GetByCode(ctx, code); err == nil {
+		return nil, fmt.Errorf("%w: %s", ErrTaxZoneExists, code)
+	} else if !strings.Contains(err.Error(), "not found") {
+		return nil, fmt.Errorf("failed to check tax zone code: %w", err)
+	}
+
+	now := time.Now().UTC()
+	zone := &entities.TaxZone{
+		ID:                 uuid.New(),
+		Code:               code,
+		Name:               strings.TrimSpace(req.Name),
+		Country:            strings.ToUpper(strings.TrimSpace(req.Country)),
+		State:              trimmedOrNil(req.State),
+		PostalCodePrefixes: req.PostalCodePrefixes,
+		PricesIncludeTax:   req.PricesIncludeTax,
+		IsActive:           true,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+	for i := range req.Rules {
+		rule, err := newTaxRule(zone.ID, &req.Rules[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid tax rule %d: %w", i+1, err)
+		}
+		zone.Rules = append(zone.Rules, *rule)
+	}
+
+	if err := zone.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid tax zone data: %w", err)
+	}
+
+	if err := s.zoneRepo.Create(ctx, zone); err != nil {
+		return nil, fmt.Errorf("failed to create tax zone: %w", err)
+	}
+
+	s.logger.Info().
+		Str("tax_zone_id", zone.ID.String()).
+		Str("code", zone.Code).
+		Int("rules", len(zone.Rules)).
+		Msg("Tax zone created")
+
+	return zone, nil
+}
+
+// GetTaxZone retrieves a tax zone with its rules
+func (s *TaxServiceImpl) GetTaxZone(ctx context.Context, id string) (*entities.TaxZone, error) {
+	return s.loadZone(ctx, id)
+}
+
+// UpdateTaxZone updates the jurisdiction and pricing of a tax zone
+func (s *TaxServiceImpl) UpdateTaxZone(ctx context.Context, id string, req *UpdateTaxZoneRequest) (*entities.TaxZone, error) {
+	zone, err := s.loadZone(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		zone.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.State != nil {
+		// An empty state widens the zone to the whole country
+		zone.State = trimmedOrNil(req.State)
+	}
+	if req.PostalCodePrefixes != nil {
+		zone.PostalCodePrefixes = req.PostalCodePrefixes
+	}
+	if req.PricesIncludeTax != nil {
+		zone.PricesIncludeTax = *req.PricesIncludeTax
+	}
+	if req.IsActive != nil {
+		zone.IsActive = *req.IsActive
+	}
+	zone.UpdatedAt = time.Now().UTC()
+
+	if err := zone.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid tax zone data: %w", err)
+	}
+
+	if err := s.zoneRepo.Update(ctx, zone); err != nil {
+		return nil, fmt.Errorf("failed to update tax zone: %w", err)
+	}
+
+	return zone, nil
+}
+
+// ListTaxZones lists tax zones with their rules
+func (s *TaxServiceImpl) ListTaxZones(ctx context.Context, req *ListTaxZonesRequest) (*ListTaxZonesResponse, error) {
+	page, limit := normalizePage(req.Page, req.Limit)
+
+	filter := repositories.TaxZoneFilter{
+		Search:   req.Search,
+		Country:  req.Country,
+		State:    req.State,
+		IsActive: req.IsActive,
+		Page:     page,
+		Limit:    limit,
+		Offset:   (page - 1) * limit,
+	}
+
+	zones, err := s.zoneRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tax zones: %w", err)
+	}
+
+	total, err := s.zoneRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count tax zones: %w", err)
+	}
+
+	return &ListTaxZonesResponse{
+		Zones:      zones,
+		Pagination: newPagination(page, limit, total),
+	}, nil
+}
+
+// AddTaxRule adds a rule to a zone
+func (s *TaxServiceImpl) AddTaxRule(ctx context.Context, zoneID string, req *TaxRuleRequest) (*entities.TaxZone, error) {
+	zone, err := s.loadZone(ctx, zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := newTaxRule(zone.ID, req)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tax rule: %w", err)
+	}
+	if err := rule.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid tax rule: %w", err)
+	}
+
+	if err := s.zoneRepo.CreateRule(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to create tax rule: %w", err)
+	}
+	zone.Rules = append(zone.Rules, *rule)
+
+	return zone, nil
+}
+
+// UpdateTaxRule updates the rate, class or name of a rule, or deactivates it
+func (s *TaxServiceImpl) UpdateTaxRule(ctx context.Context, zoneID, ruleID string, req *UpdateTaxRuleRequest) (*entities.TaxZone, error) {
+	zone, err := s.loadZone(ctx, zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleUUID, err := uuid.Parse(ruleID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tax rule ID: %w", err)
+	}
+
+	var rule *entities.TaxRule
+	for i := range zone.Rules {
+		if zone.Rules[i].ID == ruleUUID {
+			rule = &zone.Rules[i]
+			break
+		}
+	}
+	if rule == nil {
+		return nil, ErrTaxRuleNotFound
+	}
+
+	if req.Name != nil {
+		rule.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Level != nil {
+		level, err := entities.ParseTaxLevel(*req.Level)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tax rule: %w", err)
+		}
+		rule.Level = level
+	}
+	if req.TaxClass != nil {
+		taxClass, err := entities.NormalizeTaxClass(*req.TaxClass)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tax rule: %w", err)
+		}
+		rule.TaxClass = taxClass
+	}
+	if req.Rate != nil {
+		rule.Rate = *req.Rate
+	}
+	if req.IsActive != nil {
+		rule.IsActive = *req.IsActive
+	}
+	rule.UpdatedAt = time.Now().UTC()
+
+	if err := rule.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid tax rule: %w", err)
+	}
+
+	if err := s.zoneRepo.UpdateRule(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to update tax rule: %w", err)
+	}
+
+	return zone, nil
+}
+
+// CreateTaxExemption records a customer's exemption certificate
+func (s *TaxServiceImpl) CreateTaxExemption(ctx context.Context, req *CreateTaxExemptionRequest) (*entities.TaxExemption, error) {
+	customerID, err := uuid.Parse(req.CustomerID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid customer ID: %w", err)
+	}
+	createdBy, err := uuid.Parse(req.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid created by user ID: %w", err)
+	}
+
+	if _, err := s.customerRepo.GetByID(ctx, customerID); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrCustomerNotFound
+		}
+		return nil, fmt.Errorf("failed to get customer: %w", err)
+	}
+
+	existing, err := s.exemptionRepo.GetByCustomerID(ctx, customerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tax exemptions: %w", err)
+	}
+	certificateNumber := strings.TrimSpace(req.CertificateNumber)
+	for _, exemption := range existing {
+		if strings.EqualFold(exemption.CertificateNumber, certificateNumber) {
+			return nil, fmt.Errorf("%w: %s", ErrTaxExemptionExists, certificateNumber)
+		}
+	}
+
+	now := time.Now().UTC()
+	exemption := &entities.TaxExemption{
+		ID:                uuid.New(),
+		CustomerID:        customerID,
+		CertificateNumber: certificateNumber,
+		Country:           strings.ToUpper(strings.TrimSpace(req.Country)),
+		State:             trimmedOrNil(req.State),
+		ValidFrom:         now,
+		ValidUntil:        req.ValidUntil,
+		Reason:            req.Reason,
+		CreatedBy:         createdBy,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	if req.ValidFrom != nil {
+		exemption.ValidFrom = req.ValidFrom.UTC()
+	}
+
+	if err := exemption.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid tax exemption data: %w", err)
+	}
+
+	if err := s.exemptionRepo.Create(ctx, exemption); err != nil {
+		return nil, fmt.Errorf("failed to create tax exemption: %w", err)
+	}
+
+	s.logger.Info().
+		Str("tax_exemption_id", exemption.ID.String()).
+		Str("customer_id", customerID.String()).
+		Str("certificate_number", exemption.CertificateNumber).
+		Msg("Tax exemption recorded")
+
+	return exemption, nil
+}
+
+// GetCustomerTaxExemptions lists the exemption certificates of a customer, revoked ones included
+func (s *TaxServiceImpl) GetCustomerTaxExemptions(ctx context.Context, customerID string) ([]*entities.TaxExemption, error) {
+	customerUUID, err := uuid.Parse(customerID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid customer ID: %w", err)
+	}
+
+	exemptions, err := s.exemptionRepo.GetByCustomerID(ctx, customerUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tax exemptions: %w", err)
+	}
+
+	return exemptions, nil
+}
+
+// RevokeTaxExemption withdraws an exemption certificate. Orders priced while
+// it was valid keep their tax until they are recalculated.
+func (s *TaxServiceImpl) RevokeTaxExemption(ctx context.Context, id string, req *RevokeTaxExemptionRequest) (*entities.TaxExemption, error) {
+	exemptionID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tax exemption ID: %w", err)
+	}
+	revokedBy, err := uuid.Parse(req.RevokedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid revoked by user ID: %w", err)
+	}
+
+	exemption, err := s.exemptionRepo.GetByID(ctx, exemptionID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrTaxExemptionNotFound
+		}
+		return nil, fmt.Errorf("failed to get tax exemption: %w", err)
+	}
+
+	if exemption.IsRevoked() {
+		return nil, ErrTaxExemptionRevoked
+	}
+	if err := exemption.Revoke(revokedBy, req.Reason); err != nil {
+		return nil, fmt.Errorf("invalid tax exemption revocation: %v", err)
+	}
+
+	if err := s.exemptionRepo.Update(ctx, exemption); err != nil {
+		return nil, fmt.Errorf("failed to update tax exemption: %w", err)
+	}
+
+	return exemption, nil
+}
+
+// loadZone parses the ID and loads the zone, mapping missing rows to ErrTaxZoneNotFound
+func (s *TaxServiceImpl) loadZone(ctx context.Context, id string) (*entities.TaxZone, error) {
+	zoneID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tax zone ID: %w", err)
+	}
+
+	zone, err := s.zoneRepo.GetByID(ctx, zoneID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrTaxZoneNotFound
+		}
+		return nil, fmt.Errorf("failed to get tax zone: %w", err)
+	}
+
+	return zone, nil
+}
+
+// newTaxRule builds an active rule of a zone; an empty tax class is the standard class
+func newTaxRule(zoneID uuid.UUID, req *TaxRuleRequest) (*entities.TaxRule, error) {
+	level, err := entities.ParseTaxLevel(req.Level)
+	if err != nil {
+		return nil, err
+	}
+	taxClass, err := entities.NormalizeTaxClass(req.TaxClass)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	return &entities.TaxRule{
+		ID:        uuid.New(),
+		ZoneID:    zoneID,
+		Name:      strings.TrimSpace(req.Name),
+		Level:     level,
+		TaxClass:  taxClass,
+		Rate:      req.Rate,
+		IsActive:  true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// trimmedOrNil trims an optional string, treating a blank one as unset
+func trimmedOrNil(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
@@ -84,8 +84,11 @@ type CreateProductRequest struct {
 	RequiresShipping bool            `json:"requires_shipping"`
 	Taxable          bool            `json:"taxable"`
 	TaxRate          decimal.Decimal `json:"tax_rate,omitempty" validate:"gte=0,lte=100"`
+	TaxClass         string          `json:"tax_class,omitempty" validate:"omitempty,max=50"`
 	IsFeatured       bool            `json:"is_featured"`
 	IsDigital        bool            `json:"is_digital"`
+	IsDropShip       bool            `json:"is_drop_ship"`
+	SupplierID       string          `json:"supplier_id,omitempty" validate:"omitempty,uuid"`
 	DownloadURL      string          `json:"download_url,omitempty" validate:"omitempty,url,max=1000"`
 	MaxDownloads     int             `json:"max_downloads,omitempty" validate:"gte=0,max=9999"`
 	ExpiryDays       int             `json:"expiry_days,omitempty" validate:"gte=0,max=3650"`
@@ -110,8 +113,11 @@ type UpdateProductRequest struct {
 	RequiresShipping *bool            `json:"requires_shipping,omitempty"`
 	Taxable          *bool            `json:"taxable,omitempty"`
 	TaxRate          *decimal.Decimal `json:"tax_rate,omitempty" validate:"omitempty,gte=0,lte=100"`
+	TaxClass         *string          `json:"tax_class,omitempty" validate:"omitempty,max=50"`
 	IsFeatured       *bool            `json:"is_featured,omitempty"`
 	IsDigital        *bool            `json:"is_digital,omitempty"`
+	IsDropShip       *bool            `json:"is_drop_ship,omitempty"`
+	SupplierID       *string          `json:"supplier_id,omitempty" validate:"omitempty,uuid"`
 	DownloadURL      *string          `json:"download_url,omitempty" validate:"omitempty,url,max=1000"`
 	MaxDownloads     *int             `json:"max_downloads,omitempty" validate:"omitempty,gte=0,max=9999"`
 	ExpiryDays       *int             `json:"expiry_days,omitempty" validate:"omitempty,gte=0,max=3650"`
@@ -397,6 +403,15 @@ func (s *ServiceImpl) CreateProduct(ctx context.Context, req *CreateProductReque
 		return nil, ErrCategoryNotFound
 	}
 
+	var supplierID *uuid.UUID
+	if req.SupplierID != "" {
+		id, err := uuid.Parse(req.SupplierID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid supplier ID: %w", err)
+		}
+		supplierID = &id
+	}
+
 	// Create product entity
 	product := &entities.Product{
 		ID:               uuid.New(),
@@ -421,9 +436,12 @@ func (s *ServiceImpl) CreateProduct(ctx context.Context, req *CreateProductReque
 		RequiresShipping: req.RequiresShipping,
 		Taxable:          req.Taxable,
 		TaxRate:          req.TaxRate,
+		TaxClass:         strings.ToUpper(strings.TrimSpace(req.TaxClass)),
 		IsActive:         true, // Always create active products
 		IsFeatured:       req.IsFeatured,
 		IsDigital:        req.IsDigital,
+		IsDropShip:       req.IsDropShip,
+		SupplierID:       supplierID,
 		DownloadURL:      strings.TrimSpace(req.DownloadURL),
 		MaxDownloads:     req.MaxDownloads,
 		ExpiryDays:       req.ExpiryDays,
@@ -560,12 +578,25 @@ func (s *ServiceImpl) UpdateProduct(ctx context.Context, id string, req *UpdateP
 	if req.TaxRate != nil {
 		product.TaxRate = *req.TaxRate
 	}
+	if req.TaxClass != nil {
+		product.TaxClass = strings.ToUpper(strings.TrimSpace(*req.TaxClass))
+	}
 	if req.IsFeatured != nil {
 		product.IsFeatured = *req.IsFeatured
 	}
 	if req.IsDigital != nil {
 		product.IsDigital = *req.IsDigital
 	}
+	if req.IsDropShip != nil {
+		product.IsDropShip = *req.IsDropShip
+	}
+	if req.SupplierID != nil {
+		supplierID, err := uuid.Parse(*req.SupplierID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid supplier ID: %w", err)
+		}
+		product.SupplierID = &supplierID
+	}
 	if req.DownloadURL != nil {
 		product.DownloadURL = strings.TrimSpace(*req.DownloadURL)
 	}
@@ -0,0 +1,1009 @@
+package purchasing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+
+	invEntities "erpgo/internal/domain/inventory/entities"
+	invRepositories "erpgo/internal/domain/inventory/repositories"
+	productRepositories "erpgo/internal/domain/products/repositories"
+	"erpgo/internal/domain/purchasing/entities"
+	"erpgo/internal/domain/purchasing/repositories"
+	"erpgo/pkg/database"
+)
+
+// Service defines the business logic interface for suppliers, purchase orders and goods receipts
+type Service interface {
+	// Supplier management
+	CreateSupplier(ctx context.Context, req *SupplierRequest) (*entities.Supplier, error)
+	GetSupplier(ctx context.Context, id string) (*entities.Supplier, error)
+	UpdateSupplier(ctx context.Context, id string, req *SupplierRequest) (*entities.Supplier, error)
+	DeleteSupplier(ctx context.Context, id string) error
+	ListSuppliers(ctx context.Context, req *ListSuppliersRequest) (*ListSuppliersResponse, error)
+
+	// Purchase order management
+	CreatePurchaseOrder(ctx context.Context, req *CreatePurchaseOrderRequest) (*entities.PurchaseOrder, error)
+	GetPurchaseOrder(ctx context.Context, id string) (*entities.PurchaseOrder, error)
+	UpdatePurchaseOrder(ctx context.Context, id string, req *UpdatePurchaseOrderRequest) (*entities.PurchaseOrder, error)
+	ListPurchaseOrders(ctx context.Context, req *ListPurchaseOrdersRequest) (*ListPurchaseOrdersResponse, error)
+	// SubmitPurchaseOrder places a draft purchase order with the supplier
+	SubmitPurchaseOrder(ctx context.Context, id string) (*entities.PurchaseOrder, error)
+	CancelPurchaseOrder(ctx context.Context, id string, reason string) (*entities.PurchaseOrder, error)
+
+	// ReceiveGoods books received stock against a purchase order, adding it to
+	// the purchase order's warehouse at the received unit cost
+	ReceiveGoods(ctx context.Context, id string, req *ReceiveGoodsRequest) (*ReceiveGoodsResponse, error)
+	GetGoodsReceipts(ctx context.Context, id string) ([]*entities.GoodsReceipt, error)
+}
+
+// SupplierContactRequest represents a supplier contact in a supplier request
+type SupplierContactRequest struct {
+	Name      string  `json:"name" validate:"required"`
+	Role      *string `json:"role,omitempty"`
+	Email     *string `json:"email,omitempty" validate:"omitempty,email"`
+	Phone     *string `json:"phone,omitempty"`
+	IsPrimary bool    `json:"is_primary"`
+}
+
+// SupplierRequest represents a request to create or replace a supplier
+type SupplierRequest struct {
+	SupplierCode string                   `json:"supplier_code" validate:"required"`
+	Name         string                   `json:"name" validate:"required"`
+	LegalName    *string                  `json:"legal_name,omitempty"`
+	TaxID        *string                  `json:"tax_id,omitempty"`
+	Email        string                   `json:"email,omitempty" validate:"omitempty,email"`
+	Phone        string                   `json:"phone,omitempty"`
+	Website      *string                  `json:"website,omitempty"`
+	AddressLine1 *string                  `json:"address_line1,omitempty"`
+	AddressLine2 *string                  `json:"address_line2,omitempty"`
+	City         *string                  `json:"city,omitempty"`
+	State        *string                  `json:"state,omitempty"`
+	PostalCode   *string                  `json:"postal_code,omitempty"`
+	Country      *string                  `json:"country,omitempty"`
+	Currency     string                   `json:"currency,omitempty" validate:"omitempty,len=3"`
+	PaymentTerms string                   `json:"payment_terms,omitempty"`
+	LeadTimeDays int                      `json:"lead_time_days"`
+	IsActive     *bool                    `json:"is_active,omitempty"`
+	Notes        *string                  `json:"notes,omitempty"`
+	Contacts     []SupplierContactRequest `json:"contacts,omitempty"`
+}
+
+// ListSuppliersRequest represents a request to list suppliers
+type ListSuppliersRequest struct {
+	Search   string `json:"search,omitempty"`
+	IsActive *bool  `json:"is_active,omitempty"`
+	Currency string `json:"currency,omitempty"`
+	Page     int    `json:"page"`
+	Limit    int    `json:"limit"`
+}
+
+// ListSuppliersResponse represents a paginated list of suppliers
+type ListSuppliersResponse struct {
+	Suppliers  []*entities.Supplier `json:"suppliers"`
+	Pagination *Pagination          `json:"pagination"`
+}
+
+// PurchaseOrderItemRequest represents a purchase order line in a request
+type PurchaseOrderItemRequest struct {
+	ProductID    string          `json:"product_id" validate:"required,uuid"`
+	Quantity     int             `json:"quantity" validate:"required,min=1"`
+	UnitCost     decimal.Decimal `json:"unit_cost"`
+	TaxRate      decimal.Decimal `json:"tax_rate,omitempty"`
+	ExpectedDate *time.Time      `json:"expected_date,omitempty"`
+	Notes        *string         `json:"notes,omitempty"`
+}
+
+// CreatePurchaseOrderRequest represents a request to create a draft purchase order.
+// Currency and payment terms default to the supplier's, and the expected date to
+// the supplier's lead time.
+type CreatePurchaseOrderRequest struct {
+	SupplierID     string                     `json:"supplier_id" validate:"required,uuid"`
+	WarehouseID    string                     `json:"warehouse_id" validate:"required,uuid"`
+	ExpectedDate   *time.Time                 `json:"expected_date,omitempty"`
+	Currency       string                     `json:"currency,omitempty" validate:"omitempty,len=3"`
+	PaymentTerms   string                     `json:"payment_terms,omitempty"`
+	ShippingAmount decimal.Decimal            `json:"shipping_amount,omitempty"`
+	Notes          *string                    `json:"notes,omitempty"`
+	Items          []PurchaseOrderItemRequest `json:"items" validate:"required,min=1"`
+	CreatedBy      string                     `json:"created_by" validate:"required,uuid"`
+}
+
+// UpdatePurchaseOrderRequest represents a request to update a draft purchase order.
+// When Items is set the purchase order lines are replaced.
+type UpdatePurchaseOrderRequest struct {
+	WarehouseID    *string                    `json:"warehouse_id,omitempty"`
+	ExpectedDate   *time.Time                 `json:"expected_date,omitempty"`
+	PaymentTerms   *string                    `json:"payment_terms,omitempty"`
+	ShippingAmount *decimal.Decimal           `json:"shipping_amount,omitempty"`
+	Notes          *string                    `json:"notes,omitempty"`
+	Items          []PurchaseOrderItemRequest `json:"items,omitempty"`
+}
+
+// ListPurchaseOrdersRequest represents a request to list purchase orders
+type ListPurchaseOrdersRequest struct {
+	Search      string                         `json:"search,omitempty"`
+	Status      []entities.PurchaseOrderStatus `json:"status,omitempty"`
+	SupplierID  *string                        `json:"supplier_id,omitempty"`
+	WarehouseID *string                        `json:"warehouse_id,omitempty"`
+	// SalesOrderID restricts results to the drop-ship purchase orders of a sales order
+	SalesOrderID *string `json:"sales_order_id,omitempty"`
+	// Overdue restricts results to open purchase orders past their expected date
+	Overdue bool `json:"overdue,omitempty"`
+	Page    int  `json:"page"`
+	Limit   int  `json:"limit"`
+}
+
+// ListPurchaseOrdersResponse represents a paginated list of purchase orders
+type ListPurchaseOrdersResponse struct {
+	PurchaseOrders []*entities.PurchaseOrder `json:"purchase_orders"`
+	Pagination     *Pagination               `json:"pagination"`
+}
+
+// ReceiveGoodsItemRequest represents the quantity received for one purchase order line.
+// UnitCost defaults to the purchase order line's unit cost.
+type ReceiveGoodsItemRequest struct {
+	PurchaseOrderItemID string           `json:"purchase_order_item_id" validate:"required,uuid"`
+	Quantity            int              `json:"quantity" validate:"required,min=1"`
+	UnitCost            *decimal.Decimal `json:"unit_cost,omitempty"`
+	BatchNumber         *string          `json:"batch_number,omitempty"`
+	ExpiryDate          *time.Time       `json:"expiry_date,omitempty"`
+}
+
+// ReceiveGoodsRequest represents a goods receipt against a purchase order
+type ReceiveGoodsRequest struct {
+	SupplierReference *string                   `json:"supplier_reference,omitempty"`
+	Notes             *string                   `json:"notes,omitempty"`
+	Items             []ReceiveGoodsItemRequest `json:"items" validate:"required,min=1"`
+	ReceivedBy        string                    `json:"received_by" validate:"required,uuid"`
+}
+
+// ReceiveGoodsResponse represents the recorded receipt and the updated purchase order
+type ReceiveGoodsResponse struct {
+	Receipt       *entities.GoodsReceipt  `json:"receipt"`
+	PurchaseOrder *entities.PurchaseOrder `json:"purchase_order"`
+}
+
+// Pagination represents pagination metadata
+type Pagination struct {
+	Page       int  `json:"page"`
+	Limit      int  `json:"limit"`
+	Total      int  `json:"total"`
+	TotalPages int  `json:"total_pages"`
+	HasNext    bool `json:"has_next"`
+	HasPrev    bool `json:"has_prev"`
+}
+
+// Errors
+var (
+	ErrSupplierNotFound          = errors.New("supplier not found")
+	ErrSupplierAlreadyExists     = errors.New("supplier already exists")
+	ErrSupplierInactive          = errors.New("supplier is inactive")
+	ErrSupplierInUse             = errors.New("supplier has open purchase orders")
+	ErrPurchaseOrderNotFound     = errors.New("purchase order not found")
+	ErrPurchaseOrderNotEditable  = errors.New("purchase order cannot be modified")
+	ErrInvalidStatusTransition   = errors.New("invalid status transition")
+	ErrInvalidReceipt            = errors.New("invalid goods receipt")
+	ErrProductNotFound           = errors.New("product not found")
+	ErrWarehouseNotFound         = errors.New("warehouse not found")
+	ErrInvalidPurchaseOrderData  = errors.New("invalid purchase order data")
+	ErrInvalidSupplierData       = errors.New("invalid supplier data")
+	ErrCancellationReasonMissing = errors.New("cancellation reason is required")
+)
+
+// BackorderAllocator allocates newly received stock to waiting backorders
+type BackorderAllocator interface {
+	AllocateBackorders(ctx context.Context, productID, warehouseID uuid.UUID) error
+}
+
+// ServiceImpl implements the Service interface
+type ServiceImpl struct {
+	supplierRepo    repositories.SupplierRepository
+	poRepo          repositories.PurchaseOrderRepository
+	receiptRepo     repositories.GoodsReceiptRepository
+	productRepo     productRepositories.ProductRepository
+	warehouseRepo   invRepositories.WarehouseRepository
+	inventoryRepo   invRepositories.InventoryRepository
+	transactionRepo invRepositories.InventoryTransactionRepository
+	allocator       BackorderAllocator
+	txManager       database.TransactionManagerInterface
+	logger          *zerolog.Logger
+
+	defaultCurrency     string
+	defaultPaymentTerms string
+}
+
+// NewService creates a new purchasing service. Received stock is offered to
+// the allocator, which may be nil.
+func NewService(
+	supplierRepo repositories.SupplierRepository,
+	poRepo repositories.PurchaseOrderRepository,
+	receiptRepo repositories.GoodsReceiptRepository,
+	productRepo productRepositories.ProductRepository,
+	warehouseRepo invRepositories.WarehouseRepository,
+	inventoryRepo invRepositories.InventoryRepository,
+	transactionRepo invRepositories.InventoryTransactionRepository,
+	allocator BackorderAllocator,
+	txManager database.TransactionManagerInterface,
+	logger *zerolog.Logger,
+) Service {
+	return &ServiceImpl{
+		supplierRepo:        supplierRepo,
+		poRepo:              poRepo,
+		receiptRepo:         receiptRepo,
+		productRepo:         productRepo,
+		warehouseRepo:       warehouseRepo,
+		inventoryRepo:       inventoryRepo,
+		transactionRepo:     transactionRepo,
+		allocator:           allocator,
+		txManager:           txManager,
+		logger:              logger,
+		defaultCurrency:     "USD",
+		defaultPaymentTerms: "NET30",
+	}
+}
+
+// CreateSupplier creates a new supplier
+func (s *ServiceImpl) CreateSupplier(ctx context.Context, req *SupplierRequest) (*entities.Supplier, error) {
+	exists, err := s.supplierRepo.ExistsByCode(ctx, req.SupplierCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check supplier code: %w", err)
+	}
+	if exists {
+		return nil, ErrSupplierAlreadyExists
+	}
+
+	now := time.Now().UTC()
+	supplier := &entities.Supplier{
+		ID:        uuid.New(),
+		IsActive:  true,
+		CreatedAt: now,
+	}
+	s.applySupplierRequest(supplier, req, now)
+
+	if err := supplier.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSupplierData, err)
+	}
+
+	if err := s.supplierRepo.Create(ctx, supplier); err != nil {
+		if strings.Contains(err.Error(), "already exists") {
+			return nil, ErrSupplierAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to create supplier: %w", err)
+	}
+
+	s.logger.Info().
+		Str("supplier_id", supplier.ID.String()).
+		Str("supplier_code", supplier.SupplierCode).
+		Msg("Supplier created")
+
+	return supplier, nil
+}
+
+// GetSupplier retrieves a supplier with its contacts
+func (s *ServiceImpl) GetSupplier(ctx context.Context, id string) (*entities.Supplier, error) {
+	return s.loadSupplier(ctx, id)
+}
+
+// UpdateSupplier replaces a supplier's master data and contacts
+func (s *ServiceImpl) UpdateSupplier(ctx context.Context, id string, req *SupplierRequest) (*entities.Supplier, error) {
+	supplier, err := s.loadSupplier(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.SupplierCode != supplier.SupplierCode {
+		exists, err := s.supplierRepo.ExistsByCode(ctx, req.SupplierCode)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check supplier code: %w", err)
+		}
+		if exists {
+			return nil, ErrSupplierAlreadyExists
+		}
+	}
+
+	s.applySupplierRequest(supplier, req, time.Now().UTC())
+
+	if err := supplier.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSupplierData, err)
+	}
+
+	if err := s.supplierRepo.Update(ctx, supplier); err != nil {
+		if strings.Contains(err.Error(), "already exists") {
+			return nil, ErrSupplierAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to update supplier: %w", err)
+	}
+
+	return supplier, nil
+}
+
+// DeleteSupplier deletes a supplier without open purchase orders
+func (s *ServiceImpl) DeleteSupplier(ctx context.Context, id string) error {
+	supplier, err := s.loadSupplier(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	open, err := s.poRepo.HasOpenOrdersForSupplier(ctx, supplier.ID)
+	if err != nil {
+		return err
+	}
+	if open {
+		return ErrSupplierInUse
+	}
+
+	if err := s.supplierRepo.Delete(ctx, supplier.ID); err != nil {
+		if strings.Contains(err.Error(), "referenced by") {
+			return ErrSupplierInUse
+		}
+		return fmt.Errorf("failed to delete supplier: %w", err)
+	}
+
+	s.logger.Info().Str("supplier_id", supplier.ID.String()).Msg("Supplier deleted")
+	return nil
+}
+
+// ListSuppliers lists suppliers with filtering and pagination
+func (s *ServiceImpl) ListSuppliers(ctx context.Context, req *ListSuppliersRequest) (*ListSuppliersResponse, error) {
+	page, limit := normalizePage(req.Page, req.Limit)
+
+	filter := repositories.SupplierFilter{
+		Search:   req.Search,
+		IsActive: req.IsActive,
+		Currency: strings.ToUpper(req.Currency),
+		Page:     page,
+		Limit:    limit,
+	}
+
+	suppliers, err := s.supplierRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list suppliers: %w", err)
+	}
+
+	total, err := s.supplierRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count suppliers: %w", err)
+	}
+
+	return &ListSuppliersResponse{
+		Suppliers:  suppliers,
+		Pagination: newPagination(page, limit, total),
+	}, nil
+}
+
+// CreatePurchaseOrder creates a draft purchase order with an active supplier
+func (s *ServiceImpl) CreatePurchaseOrder(ctx context.Context, req *CreatePurchaseOrderRequest) (*entities.PurchaseOrder, error) {
+	supplier, err := s.loadSupplier(ctx, req.SupplierID)
+	if err != nil {
+		return nil, err
+	}
+	if !supplier.IsActive {
+		return nil, ErrSupplierInactive
+	}
+
+	warehouseID, err := s.checkWarehouse(ctx, req.WarehouseID)
+	if err != nil {
+		return nil, err
+	}
+
+	createdBy, err := uuid.Parse(req.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid created by ID: %w", err)
+	}
+
+	now := time.Now().UTC()
+	po := &entities.PurchaseOrder{
+		ID:             uuid.New(),
+		SupplierID:     supplier.ID,
+		WarehouseID:    &warehouseID,
+		Status:         entities.PurchaseOrderStatusDraft,
+		OrderDate:      now,
+		ExpectedDate:   req.ExpectedDate,
+		Currency:       supplier.Currency,
+		PaymentTerms:   supplier.PaymentTerms,
+		ShippingAmount: req.ShippingAmount,
+		Notes:          req.Notes,
+		CreatedBy:      createdBy,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if req.Currency != "" {
+		po.Currency = strings.ToUpper(req.Currency)
+	}
+	if req.PaymentTerms != "" {
+		po.PaymentTerms = strings.ToUpper(req.PaymentTerms)
+	}
+	if po.ExpectedDate == nil && supplier.LeadTimeDays > 0 {
+		expected := supplier.ExpectedDeliveryDate(now)
+		po.ExpectedDate = &expected
+	}
+
+	po.Items, err = s.buildItems(ctx, po.ID, req.Items)
+	if err != nil {
+		return nil, err
+	}
+	po.CalculateTotals()
+
+	if err := po.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPurchaseOrderData, err)
+	}
+
+	if err := s.poRepo.Create(ctx, po); err != nil {
+		return nil, fmt.Errorf("failed to create purchase order: %w", err)
+	}
+
+	s.logger.Info().
+		Str("purchase_order_id", po.ID.String()).
+		Str("po_number", po.PONumber).
+		Str("supplier_id", supplier.ID.String()).
+		Msg("Purchase order created")
+
+	return po, nil
+}
+
+// GetPurchaseOrder retrieves a purchase order with its lines
+func (s *ServiceImpl) GetPurchaseOrder(ctx context.Context, id string) (*entities.PurchaseOrder, error) {
+	return s.loadPurchaseOrder(ctx, id)
+}
+
+// UpdatePurchaseOrder updates a draft purchase order
+func (s *ServiceImpl) UpdatePurchaseOrder(ctx context.Context, id string, req *UpdatePurchaseOrderRequest) (*entities.PurchaseOrder, error) {
+	po, err := s.loadPurchaseOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !po.IsEditable() {
+		return nil, ErrPurchaseOrderNotEditable
+	}
+
+	if req.WarehouseID != nil {
+		warehouseID, err := s.checkWarehouse(ctx, *req.WarehouseID)
+		if err != nil {
+			return nil, err
+		}
+		po.WarehouseID = &warehouseID
+	}
+	if req.ExpectedDate != nil {
+		po.ExpectedDate = req.ExpectedDate
+	}
+	if req.PaymentTerms != nil {
+		po.PaymentTerms = strings.ToUpper(*req.PaymentTerms)
+	}
+	if req.ShippingAmount != nil {
+		po.ShippingAmount = *req.ShippingAmount
+	}
+	if req.Notes != nil {
+		po.Notes = req.Notes
+	}
+	if req.Items != nil {
+		po.Items, err = s.buildItems(ctx, po.ID, req.Items)
+		if err != nil {
+			return nil, err
+		}
+	}
+	po.CalculateTotals()
+
+	if err := po.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPurchaseOrderData, err)
+	}
+
+	if err := s.poRepo.Update(ctx, po); err != nil {
+		return nil, fmt.Errorf("failed to update purchase order: %w", err)
+	}
+
+	return po, nil
+}
+
+// ListPurchaseOrders lists purchase orders with filtering and pagination
+func (s *ServiceImpl) ListPurchaseOrders(ctx context.Context, req *ListPurchaseOrdersRequest) (*ListPurchaseOrdersResponse, error) {
+	page, limit := normalizePage(req.Page, req.Limit)
+
+	filter := repositories.PurchaseOrderFilter{
+		Search: req.Search,
+		Status: req.Status,
+		Page:   page,
+		Limit:  limit,
+	}
+	if req.SupplierID != nil {
+		supplierID, err := uuid.Parse(*req.SupplierID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid supplier ID: %w", err)
+		}
+		filter.SupplierID = &supplierID
+	}
+	if req.WarehouseID != nil {
+		warehouseID, err := uuid.Parse(*req.WarehouseID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid warehouse ID: %w", err)
+		}
+		filter.WarehouseID = &warehouseID
+	}
+	if req.SalesOrderID != nil {
+		salesOrderID, err := uuid.Parse(*req.SalesOrderID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sales order ID: %w", err)
+		}
+		filter.SalesOrderID = &salesOrderID
+	}
+	if req.Overdue {
+		now := time.Now().UTC()
+		filter.ExpectedBefore = &now
+	}
+
+	orders, err := s.poRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list purchase orders: %w", err)
+	}
+
+	total, err := s.poRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count purchase orders: %w", err)
+	}
+
+	return &ListPurchaseOrdersResponse{
+		PurchaseOrders: orders,
+		Pagination:     newPagination(page, limit, total),
+	}, nil
+}
+
+// SubmitPurchaseOrder places a draft purchase order with the supplier
+func (s *ServiceImpl) SubmitPurchaseOrder(ctx context.Context, id string) (*entities.PurchaseOrder, error) {
+	po, err := s.loadPurchaseOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	supplier, err := s.loadSupplier(ctx, po.SupplierID.String())
+	if err != nil {
+		return nil, err
+	}
+	if !supplier.IsActive {
+		return nil, ErrSupplierInactive
+	}
+
+	if err := po.ChangeStatus(entities.PurchaseOrderStatusOrdered); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStatusTransition, err)
+	}
+	po.OrderDate = *po.OrderedAt
+
+	if err := s.poRepo.Update(ctx, po); err != nil {
+		return nil, fmt.Errorf("failed to update purchase order: %w", err)
+	}
+
+	s.logger.Info().
+		Str("purchase_order_id", po.ID.String()).
+		Str("po_number", po.PONumber).
+		Msg("Purchase order submitted")
+
+	return po, nil
+}
+
+// CancelPurchaseOrder cancels a purchase order that has not received any goods
+func (s *ServiceImpl) CancelPurchaseOrder(ctx context.Context, id string, reason string) (*entities.PurchaseOrder, error) {
+	if strings.TrimSpace(reason) == "" {
+		return nil, ErrCancellationReasonMissing
+	}
+
+	po, err := s.loadPurchaseOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := po.ChangeStatus(entities.PurchaseOrderStatusCancelled); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStatusTransition, err)
+	}
+	po.CancellationReason = &reason
+
+	if err := s.poRepo.Update(ctx, po); err != nil {
+		return nil, fmt.Errorf("failed to update purchase order: %w", err)
+	}
+
+	s.logger.Info().
+		Str("purchase_order_id", po.ID.String()).
+		Str("reason", reason).
+		Msg("Purchase order cancelled")
+
+	return po, nil
+}
+
+// ReceiveGoods books a (partial) goods receipt against a purchase order. Each
+// received line raises stock in the purchase order's warehouse, updates its
+// average cost and is recorded as a PURCHASE inventory transaction referencing
+// the purchase order. The purchase order closes once every line is received.
+func (s *ServiceImpl) ReceiveGoods(ctx context.Context, id string, req *ReceiveGoodsRequest) (*ReceiveGoodsResponse, error) {
+	receivedBy, err := uuid.Parse(req.ReceivedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid received by ID: %w", err)
+	}
+	if len(req.Items) == 0 {
+		return nil, fmt.Errorf("%w: at least one line must be received", ErrInvalidReceipt)
+	}
+
+	var po *entities.PurchaseOrder
+	var receipt *entities.GoodsReceipt
+
+	err = database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		po, err = s.lockPurchaseOrder(ctx, id)
+		if err != nil {
+			return err
+		}
+		if po.IsDropShip() {
+			return fmt.Errorf("%w: drop-ship purchase orders are shipped by the supplier, not received", ErrInvalidReceipt)
+		}
+
+		now := time.Now().UTC()
+		receipt = &entities.GoodsReceipt{
+			ID:                uuid.New(),
+			PurchaseOrderID:   po.ID,
+			WarehouseID:       *po.WarehouseID,
+			SupplierReference: req.SupplierReference,
+			Notes:             req.Notes,
+			ReceivedBy:        receivedBy,
+			ReceivedAt:        now,
+			CreatedAt:         now,
+		}
+		for _, line := range req.Items {
+			item, err := receiptLine(po, receipt.ID, line)
+			if err != nil {
+				return err
+			}
+			receipt.Items = append(receipt.Items, item)
+		}
+
+		if err := po.ApplyReceipt(receipt); err != nil {
+			if !po.CanReceive() {
+				return fmt.Errorf("%w: %v", ErrInvalidStatusTransition, err)
+			}
+			return fmt.Errorf("%w: %v", ErrInvalidReceipt, err)
+		}
+
+		transactions, err := s.receiptTransactions(ctx, po, receipt)
+		if err != nil {
+			return err
+		}
+
+		for i := range receipt.Items {
+			transaction := transactions[i]
+			if transaction == nil {
+				continue
+			}
+			line := receipt.Items[i]
+			if _, err := s.inventoryRepo.ReceiveStock(ctx, line.ProductID, receipt.WarehouseID, line.Quantity, transaction.UnitCost, receivedBy); err != nil {
+				return fmt.Errorf("failed to receive stock: %w", err)
+			}
+			if err := s.transactionRepo.Create(ctx, transaction); err != nil {
+				return fmt.Errorf("failed to record inventory transaction: %w", err)
+			}
+			receipt.Items[i].InventoryTransactionID = &transaction.ID
+		}
+
+		if err := s.poRepo.Update(ctx, po); err != nil {
+			return fmt.Errorf("failed to update purchase order: %w", err)
+		}
+
+		if err := s.receiptRepo.Create(ctx, receipt); err != nil {
+			return fmt.Errorf("failed to create goods receipt: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info().
+		Str("purchase_order_id", po.ID.String()).
+		Str("receipt_number", receipt.ReceiptNumber).
+		Str("status", string(po.Status)).
+		Msg("Goods received")
+
+	if s.allocator != nil {
+		for _, line := range receipt.Items {
+			if line.InventoryTransactionID == nil {
+				continue
+			}
+			if err := s.allocator.AllocateBackorders(ctx, line.ProductID, receipt.WarehouseID); err != nil {
+				s.logger.Error().Err(err).
+					Str("product_id", line.ProductID.String()).
+					Msg("Failed to allocate received stock to backorders")
+			}
+		}
+	}
+
+	return &ReceiveGoodsResponse{
+		Receipt:       receipt,
+		PurchaseOrder: po,
+	}, nil
+}
+
+// GetGoodsReceipts retrieves the goods receipts of a purchase order
+func (s *ServiceImpl) GetGoodsReceipts(ctx context.Context, id string) ([]*entities.GoodsReceipt, error) {
+	po, err := s.loadPurchaseOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	receipts, err := s.receiptRepo.GetByPurchaseOrderID(ctx, po.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get goods receipts: %w", err)
+	}
+
+	return receipts, nil
+}
+
+// receiptTransactions builds and validates the PURCHASE inventory transactions
+// for a receipt before any stock is moved. Lines for products that do not
+// track inventory get no transaction.
+func (s *ServiceImpl) receiptTransactions(ctx context.Context, po *entities.PurchaseOrder, receipt *entities.GoodsReceipt) ([]*invEntities.InventoryTransaction, error) {
+	transactions := make([]*invEntities.InventoryTransaction, len(receipt.Items))
+	reference := po.ID
+
+	for i, line := range receipt.Items {
+		product, err := s.productRepo.GetByID(ctx, line.ProductID)
+		if err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				return nil, ErrProductNotFound
+			}
+			return nil, fmt.Errorf("failed to get product: %w", err)
+		}
+		if !product.TrackInventory || product.IsDigital {
+			continue
+		}
+
+		unitCost := line.UnitCost.InexactFloat64()
+		transaction := &invEntities.InventoryTransaction{
+			ID:              uuid.New(),
+			ProductID:       line.ProductID,
+			WarehouseID:     receipt.WarehouseID,
+			TransactionType: invEntities.TransactionTypePurchase,
+			Quantity:        line.Quantity,
+			ReferenceType:   "PURCHASE_ORDER",
+			ReferenceID:     &reference,
+			Reason:          fmt.Sprintf("Received on purchase order %s", po.PONumber),
+			UnitCost:        unitCost,
+			TotalCost:       line.UnitCost.Mul(decimal.NewFromInt(int64(line.Quantity))).Round(2).InexactFloat64(),
+			ExpiryDate:      line.ExpiryDate,
+			CreatedAt:       receipt.ReceivedAt,
+			CreatedBy:       receipt.ReceivedBy,
+		}
+		if line.BatchNumber != nil {
+			transaction.BatchNumber = *line.BatchNumber
+		}
+		if err := transaction.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidReceipt, i+1, err)
+		}
+		transactions[i] = transaction
+	}
+
+	return transactions, nil
+}
+
+// receiptLine builds a goods receipt line for a purchase order line
+func receiptLine(po *entities.PurchaseOrder, receiptID uuid.UUID, req ReceiveGoodsItemRequest) (entities.GoodsReceiptItem, error) {
+	itemID, err := uuid.Parse(req.PurchaseOrderItemID)
+	if err != nil {
+		return entities.GoodsReceiptItem{}, fmt.Errorf("%w: invalid purchase order item ID: %v", ErrInvalidReceipt, err)
+	}
+
+	item := po.FindItem(itemID)
+	if item == nil {
+		return entities.GoodsReceiptItem{}, fmt.Errorf("%w: purchase order item %s not found", ErrInvalidReceipt, itemID)
+	}
+
+	unitCost := item.UnitCost
+	if req.UnitCost != nil {
+		unitCost = *req.UnitCost
+	}
+
+	return entities.GoodsReceiptItem{
+		ID:                  uuid.New(),
+		GoodsReceiptID:      receiptID,
+		PurchaseOrderItemID: item.ID,
+		ProductID:           item.ProductID,
+		Quantity:            req.Quantity,
+		UnitCost:            unitCost,
+		BatchNumber:         req.BatchNumber,
+		ExpiryDate:          req.ExpiryDate,
+	}, nil
+}
+
+// buildItems builds purchase order lines, copying product details from the catalogue
+func (s *ServiceImpl) buildItems(ctx context.Context, poID uuid.UUID, reqs []PurchaseOrderItemRequest) ([]entities.PurchaseOrderItem, error) {
+	now := time.Now().UTC()
+	items := make([]entities.PurchaseOrderItem, 0, len(reqs))
+
+	for _, req := range reqs {
+		productID, err := uuid.Parse(req.ProductID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid product ID: %w", err)
+		}
+
+		product, err := s.productRepo.GetByID(ctx, productID)
+		if err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				return nil, ErrProductNotFound
+			}
+			return nil, fmt.Errorf("failed to get product: %w", err)
+		}
+
+		unitCost := req.UnitCost
+		if unitCost.IsZero() {
+			unitCost = product.Cost
+		}
+
+		items = append(items, entities.PurchaseOrderItem{
+			ID:              uuid.New(),
+			PurchaseOrderID: poID,
+			ProductID:       product.ID,
+			ProductSKU:      product.SKU,
+			ProductName:     product.Name,
+			QuantityOrdered: req.Quantity,
+			UnitCost:        unitCost,
+			TaxRate:         req.TaxRate,
+			ExpectedDate:    req.ExpectedDate,
+			Notes:           req.Notes,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		})
+	}
+
+	return items, nil
+}
+
+// applySupplierRequest copies a supplier request onto a supplier, defaulting
+// currency and payment terms
+func (s *ServiceImpl) applySupplierRequest(supplier *entities.Supplier, req *SupplierRequest, now time.Time) {
+	supplier.SupplierCode = strings.ToUpper(strings.TrimSpace(req.SupplierCode))
+	supplier.Name = strings.TrimSpace(req.Name)
+	supplier.LegalName = req.LegalName
+	supplier.TaxID = req.TaxID
+	supplier.Email = req.Email
+	supplier.Phone = req.Phone
+	supplier.Website = req.Website
+	supplier.AddressLine1 = req.AddressLine1
+	supplier.AddressLine2 = req.AddressLine2
+	supplier.City = req.City
+	supplier.State = req.State
+	supplier.PostalCode = req.PostalCode
+	supplier.Country = req.Country
+	supplier.Currency = strings.ToUpper(req.Currency)
+	if supplier.Currency == "" {
+		supplier.Currency = s.defaultCurrency
+	}
+	supplier.PaymentTerms = strings.ToUpper(req.PaymentTerms)
+	if supplier.PaymentTerms == "" {
+		supplier.PaymentTerms = s.defaultPaymentTerms
+	}
+	supplier.LeadTimeDays = req.LeadTimeDays
+	if req.IsActive != nil {
+		supplier.IsActive = *req.IsActive
+	}
+	supplier.Notes = req.Notes
+	supplier.UpdatedAt = now
+
+	supplier.Contacts = make([]entities.SupplierContact, len(req.Contacts))
+	for i, contact := range req.Contacts {
+		supplier.Contacts[i] = entities.SupplierContact{
+			ID:         uuid.New(),
+			SupplierID: supplier.ID,
+			Name:       strings.TrimSpace(contact.Name),
+			Role:       contact.Role,
+			Email:      contact.Email,
+			Phone:      contact.Phone,
+			IsPrimary:  contact.IsPrimary,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+	}
+}
+
+// loadSupplier parses an ID and loads the supplier
+func (s *ServiceImpl) loadSupplier(ctx context.Context, id string) (*entities.Supplier, error) {
+	supplierID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid supplier ID: %w", err)
+	}
+
+	supplier, err := s.supplierRepo.GetByID(ctx, supplierID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrSupplierNotFound
+		}
+		return nil, fmt.Errorf("failed to get supplier: %w", err)
+	}
+
+	return supplier, nil
+}
+
+// loadPurchaseOrder parses an ID and loads the purchase order with its lines
+func (s *ServiceImpl) loadPurchaseOrder(ctx context.Context, id string) (*entities.PurchaseOrder, error) {
+	poID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid purchase order ID: %w", err)
+	}
+
+	po, err := s.poRepo.GetByID(ctx, poID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrPurchaseOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to get purchase order: %w", err)
+	}
+
+	return po, nil
+}
+
+// lockPurchaseOrder locks a purchase order for the rest of the context's
+// transaction and loads it, so its status and received quantities cannot
+// change before the transaction ends
+func (s *ServiceImpl) lockPurchaseOrder(ctx context.Context, id string) (*entities.PurchaseOrder, error) {
+	poID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid purchase order ID: %w", err)
+	}
+
+	if err := s.poRepo.Lock(ctx, poID); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrPurchaseOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to lock purchase order: %w", err)
+	}
+
+	return s.loadPurchaseOrder(ctx, id)
+}
+
+// checkWarehouse parses a warehouse ID and checks that the warehouse exists
+func (s *ServiceImpl) checkWarehouse(ctx context.Context, id string) (uuid.UUID, error) {
+	warehouseID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid warehouse ID: %w", err)
+	}
+
+	exists, err := s.warehouseRepo.ExistsByID(ctx, warehouseID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to check warehouse: %w", err)
+	}
+	if !exists {
+		return uuid.Nil, ErrWarehouseNotFound
+	}
+
+	return warehouseID, nil
+}
+
+// normalizePage applies default and maximum page sizes
+func normalizePage(page, limit int) (int, int) {
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	return page, limit
+}
+
+// newPagination builds pagination metadata
+func newPagination(page, limit, total int) *Pagination {
+	totalPages := 0
+	if limit > 0 {
+		totalPages = (total + limit - 1) / limit
+	}
+	return &Pagination{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+		HasPrev:    page > 1,
+	}
+}
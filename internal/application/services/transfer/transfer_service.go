@@ -0,0 +1,794 @@
+package transfer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"erpgo/internal/domain/inventory/entities"
+	"erpgo/internal/domain/inventory/repositories"
+	productRepositories "erpgo/internal/domain/products/repositories"
+	"erpgo/pkg/database"
+)
+
+// Service defines the business logic interface for inter-warehouse transfer orders
+type Service interface {
+	// CreateTransferOrder creates a draft transfer order, reserving the
+	// requested stock in the source warehouse
+	CreateTransferOrder(ctx context.Context, req *CreateTransferOrderRequest) (*entities.TransferOrder, error)
+	GetTransferOrder(ctx context.Context, id string) (*entities.TransferOrder, error)
+	ListTransferOrders(ctx context.Context, req *ListTransferOrdersRequest) (*ListTransferOrdersResponse, error)
+	// ShipTransferOrder takes the stock out of the source warehouse and puts it in transit
+	ShipTransferOrder(ctx context.Context, id string, req *ShipTransferOrderRequest) (*entities.TransferOrder, error)
+	// ReceiveTransferOrder books stock arriving at the destination warehouse,
+	// recording damaged and short quantities
+	ReceiveTransferOrder(ctx context.Context, id string, req *ReceiveTransferOrderRequest) (*ReceiveTransferOrderResponse, error)
+	// CancelTransferOrder cancels a draft transfer order, releasing its reservations
+	CancelTransferOrder(ctx context.Context, id string, reason string) (*entities.TransferOrder, error)
+	GetTransferReceipts(ctx context.Context, id string) ([]*entities.TransferReceipt, error)
+
+	// GetInTransitStock reports the stock shipped and not yet received
+	GetInTransitStock(ctx context.Context, req *InTransitStockRequest) ([]*repositories.InTransitStock, error)
+}
+
+// TransferOrderItemRequest represents a transfer order line in a request
+type TransferOrderItemRequest struct {
+	ProductID string  `json:"product_id" validate:"required,uuid"`
+	Quantity  int     `json:"quantity" validate:"required,min=1"`
+	Notes     *string `json:"notes,omitempty"`
+}
+
+// CreateTransferOrderRequest represents a request to create a draft transfer order
+type CreateTransferOrderRequest struct {
+	FromWarehouseID string                     `json:"from_warehouse_id" validate:"required,uuid"`
+	ToWarehouseID   string                     `json:"to_warehouse_id" validate:"required,uuid"`
+	ExpectedDate    *time.Time                 `json:"expected_date,omitempty"`
+	Notes           *string                    `json:"notes,omitempty"`
+	Items           []TransferOrderItemRequest `json:"items" validate:"required,min=1"`
+	CreatedBy       string                     `json:"created_by" validate:"required,uuid"`
+}
+
+// ListTransferOrdersRequest represents a request to list transfer orders
+type ListTransferOrdersRequest struct {
+	Search          string                         `json:"search,omitempty"`
+	Status          []entities.TransferOrderStatus `json:"status,omitempty"`
+	FromWarehouseID *string                        `json:"from_warehouse_id,omitempty"`
+	ToWarehouseID   *string                        `json:"to_warehouse_id,omitempty"`
+	Page            int                            `json:"page"`
+	Limit           int                            `json:"limit"`
+}
+
+// ListTransferOrdersResponse represents a paginated list of transfer orders
+type ListTransferOrdersResponse struct {
+	TransferOrders []*entities.TransferOrder `json:"transfer_orders"`
+	Pagination     *Pagination               `json:"pagination"`
+}
+
+// ShipTransferOrderRequest represents a request to ship a transfer order
+type ShipTransferOrderRequest struct {
+	Carrier        *string    `json:"carrier,omitempty"`
+	TrackingNumber *string    `json:"tracking_number,omitempty"`
+	ExpectedDate   *time.Time `json:"expected_date,omitempty"`
+	ShippedBy      string     `json:"shipped_by" validate:"required,uuid"`
+}
+
+// ReceiveTransferItemRequest represents what arrived for one transfer order line.
+// Damaged and short quantities require a discrepancy reason.
+type ReceiveTransferItemRequest struct {
+	TransferOrderItemID string  `json:"transfer_order_item_id" validate:"required,uuid"`
+	QuantityReceived    int     `json:"quantity_received" validate:"min=0"`
+	QuantityDamaged     int     `json:"quantity_damaged" validate:"min=0"`
+	QuantityShort       int     `json:"quantity_short" validate:"min=0"`
+	DiscrepancyReason   *string `json:"discrepancy_reason,omitempty"`
+}
+
+// ReceiveTransferOrderRequest represents a receipt against a transfer order.
+// CloseShort records whatever is still in transit after the receipt as short,
+// with ShortReason, so the transfer order is closed.
+type ReceiveTransferOrderRequest struct {
+	Notes       *string                      `json:"notes,omitempty"`
+	Items       []ReceiveTransferItemRequest `json:"items,omitempty"`
+	CloseShort  bool                         `json:"close_short"`
+	ShortReason string                       `json:"short_reason,omitempty"`
+	ReceivedBy  string                       `json:"received_by" validate:"required,uuid"`
+}
+
+// ReceiveTransferOrderResponse represents the recorded receipt and the updated transfer order
+type ReceiveTransferOrderResponse struct {
+	Receipt       *entities.TransferReceipt `json:"receipt"`
+	TransferOrder *entities.TransferOrder   `json:"transfer_order"`
+}
+
+// InTransitStockRequest represents a request for the in-transit stock report
+type InTransitStockRequest struct {
+	ProductID       *string `json:"product_id,omitempty"`
+	FromWarehouseID *string `json:"from_warehouse_id,omitempty"`
+	ToWarehouseID   *string `json:"to_warehouse_id,omitempty"`
+}
+
+// Pagination represents pagination metadata
+type Pagination struct {
+	Page       int  `json:"page"`
+	Limit      int  `json:"limit"`
+	Total      int  `json:"total"`
+	TotalPages int  `json:"total_pages"`
+	HasNext    bool `json:"has_next"`
+	HasPrev    bool `json:"has_prev"`
+}
+
+// Errors
+var (
+	ErrTransferOrderNotFound     = errors.New("transfer order not found")
+	ErrInvalidTransferOrderData  = errors.New("invalid transfer order data")
+	ErrInvalidStatusTransition   = errors.New("invalid status transition")
+	ErrInvalidReceipt            = errors.New("invalid transfer receipt")
+	ErrInsufficientStock         = errors.New("insufficient stock")
+	ErrProductNotFound           = errors.New("product not found")
+	ErrProductNotStocked         = errors.New("product does not track inventory")
+	ErrWarehouseNotFound         = errors.New("warehouse not found")
+	ErrWarehouseInactive         = errors.New("warehouse is inactive")
+	ErrCancellationReasonMissing = errors.New("cancellation reason is required")
+)
+
+// referenceType is the reference type of inventory transactions recorded for transfer orders
+const referenceType = "TRANSFER_ORDER"
+
+// BackorderAllocator allocates newly received stock to waiting backorders
+type BackorderAllocator interface {
+	AllocateBackorders(ctx context.Context, productID, warehouseID uuid.UUID) error
+}
+
+// ServiceImpl implements the Service interface
+type ServiceImpl struct {
+	transferRepo    repositories.TransferOrderRepository
+	productRepo     productRepositories.ProductRepository
+	warehouseRepo   repositories.WarehouseRepository
+	inventoryRepo   repositories.InventoryRepository
+	transactionRepo repositories.InventoryTransactionRepository
+	allocator       BackorderAllocator
+	txManager       database.TransactionManagerInterface
+	logger          *zerolog.Logger
+}
+
+// NewService creates a new transfer order service. Received stock is offered
+// to the allocator, which may be nil.
+func NewService(
+	transferRepo repositories.TransferOrderRepository,
+	productRepo productRepositories.ProductRepository,
+	warehouseRepo repositories.WarehouseRepository,
+	inventoryRepo repositories.InventoryRepository,
+	transactionRepo repositories.InventoryTransactionRepository,
+	allocator BackorderAllocator,
+	txManager database.TransactionManagerInterface,
+	logger *zerolog.Logger,
+) Service {
+	return &ServiceImpl{
+		transferRepo:    transferRepo,
+		productRepo:     productRepo,
+		warehouseRepo:   warehouseRepo,
+		inventoryRepo:   inventoryRepo,
+		transactionRepo: transactionRepo,
+		allocator:       allocator,
+		txManager:       txManager,
+		logger:          logger,
+	}
+}
+
+// CreateTransferOrder creates a draft transfer order between two active
+// warehouses. The requested quantities are reserved in the source warehouse
+// so they cannot be sold before the transfer ships.
+func (s *ServiceImpl) CreateTransferOrder(ctx context.Context, req *CreateTransferOrderRequest) (*entities.TransferOrder, error) {
+	fromWarehouseID, err := s.checkWarehouse(ctx, req.FromWarehouseID)
+	if err != nil {
+		return nil, err
+	}
+	toWarehouseID, err := s.checkWarehouse(ctx, req.ToWarehouseID)
+	if err != nil {
+		return nil, err
+	}
+
+	createdBy, err := uuid.Parse(req.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid created by ID: %w", err)
+	}
+
+	now := time.Now().UTC()
+	transfer := &entities.TransferOrder{
+		ID:              uuid.New(),
+		FromWarehouseID: fromWarehouseID,
+		ToWarehouseID:   toWarehouseID,
+		Status:          entities.TransferOrderStatusDraft,
+		ExpectedDate:    req.ExpectedDate,
+		Notes:           req.Notes,
+		CreatedBy:       createdBy,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	transfer.Items, err = s.buildItems(ctx, transfer.ID, req.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := transfer.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTransferOrderData, err)
+	}
+
+	err = database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		for _, item := range transfer.Items {
+			if err := s.inventoryRepo.ReserveStock(ctx, item.ProductID, transfer.FromWarehouseID, item.QuantityRequested); err != nil {
+				if strings.Contains(err.Error(), "insufficient") || strings.Contains(err.Error(), "not found") {
+					return fmt.Errorf("%w: %s in the source warehouse", ErrInsufficientStock, item.ProductSKU)
+				}
+				return fmt.Errorf("failed to reserve stock: %w", err)
+			}
+		}
+
+		if err := s.transferRepo.Create(ctx, transfer); err != nil {
+			return fmt.Errorf("failed to create transfer order: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info().
+		Str("transfer_order_id", transfer.ID.String()).
+		Str("transfer_number", transfer.TransferNumber).
+		Str("from_warehouse_id", transfer.FromWarehouseID.String()).
+		Str("to_warehouse_id", transfer.ToWarehouseID.String()).
+		Msg("Transfer order created")
+
+	return transfer, nil
+}
+
+// GetTransferOrder retrieves a transfer order with its lines
+func (s *ServiceImpl) GetTransferOrder(ctx context.Context, id string) (*entities.TransferOrder, error) {
+	return s.loadTransferOrder(ctx, id)
+}
+
+// ListTransferOrders lists transfer orders with filtering and pagination
+func (s *ServiceImpl) ListTransferOrders(ctx context.Context, req *ListTransferOrdersRequest) (*ListTransferOrdersResponse, error) {
+	page, limit := normalizePage(req.Page, req.Limit)
+
+	filter := repositories.TransferOrderFilter{
+		Search: strings.TrimSpace(req.Search),
+		Status: req.Status,
+		Page:   page,
+		Limit:  limit,
+	}
+	if req.FromWarehouseID != nil {
+		warehouseID, err := uuid.Parse(*req.FromWarehouseID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid from warehouse ID: %w", err)
+		}
+		filter.FromWarehouseID = &warehouseID
+	}
+	if req.ToWarehouseID != nil {
+		warehouseID, err := uuid.Parse(*req.ToWarehouseID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid to warehouse ID: %w", err)
+		}
+		filter.ToWarehouseID = &warehouseID
+	}
+
+	transfers, err := s.transferRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transfer orders: %w", err)
+	}
+
+	total, err := s.transferRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count transfer orders: %w", err)
+	}
+
+	return &ListTransferOrdersResponse{
+		TransferOrders: transfers,
+		Pagination:     newPagination(page, limit, total),
+	}, nil
+}
+
+// ShipTransferOrder ships every line of a draft transfer order in full. The
+// reserved stock leaves the source warehouse at its average cost and is
+// recorded as a TRANSFER_OUT inventory transaction referencing the transfer
+// order; from then on it is in transit until received.
+func (s *ServiceImpl) ShipTransferOrder(ctx context.Context, id string, req *ShipTransferOrderRequest) (*entities.TransferOrder, error) {
+	shippedBy, err := uuid.Parse(req.ShippedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid shipped by ID: %w", err)
+	}
+
+	var transfer *entities.TransferOrder
+
+	err = database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		transfer, err = s.lockTransferOrder(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := transfer.Ship(shippedBy); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidStatusTransition, err)
+		}
+		if req.Carrier != nil {
+			transfer.Carrier = req.Carrier
+		}
+		if req.TrackingNumber != nil {
+			transfer.TrackingNumber = req.TrackingNumber
+		}
+		if req.ExpectedDate != nil {
+			transfer.ExpectedDate = req.ExpectedDate
+		}
+
+		for i := range transfer.Items {
+			item := &transfer.Items[i]
+
+			inventory, err := s.inventoryRepo.GetByProductAndWarehouse(ctx, item.ProductID, transfer.FromWarehouseID)
+			if err != nil {
+				return fmt.Errorf("failed to get source inventory: %w", err)
+			}
+			item.UnitCost = math.Round(inventory.AverageCost*100) / 100
+
+			transaction := s.transferTransaction(transfer, item.ProductID, entities.TransactionTypeTransferOut,
+				-item.QuantityShipped, item.UnitCost, shippedBy, *transfer.ShippedAt)
+			transaction.Reason = fmt.Sprintf("Shipped on transfer order %s", transfer.TransferNumber)
+			if err := transaction.Validate(); err != nil {
+				return fmt.Errorf("%w: %s: %v", ErrInvalidTransferOrderData, item.ProductSKU, err)
+			}
+
+			if err := s.inventoryRepo.ReleaseStock(ctx, item.ProductID, transfer.FromWarehouseID, item.QuantityShipped); err != nil {
+				return fmt.Errorf("failed to release reserved stock: %w", err)
+			}
+			if err := s.inventoryRepo.AdjustStock(ctx, item.ProductID, transfer.FromWarehouseID, -item.QuantityShipped); err != nil {
+				return fmt.Errorf("failed to remove shipped stock: %w", err)
+			}
+			if err := s.transactionRepo.Create(ctx, transaction); err != nil {
+				return fmt.Errorf("failed to record inventory transaction: %w", err)
+			}
+		}
+
+		if err := s.transferRepo.Update(ctx, transfer); err != nil {
+			return fmt.Errorf("failed to update transfer order: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info().
+		Str("transfer_order_id", transfer.ID.String()).
+		Str("transfer_number", transfer.TransferNumber).
+		Int("quantity", transfer.InTransitQuantity()).
+		Msg("Transfer order shipped")
+
+	return transfer, nil
+}
+
+// ReceiveTransferOrder books a (partial) receipt against a transfer order in
+// transit. Received and damaged quantities arrived at the destination: they
+// are added to its stock as a TRANSFER_IN inventory transaction at the shipped
+// unit cost, and damaged quantities are then written off as DAMAGE. Short
+// quantities never arrived and move no stock; they are only recorded on the
+// receipt. The transfer order is received once nothing is left in transit.
+func (s *ServiceImpl) ReceiveTransferOrder(ctx context.Context, id string, req *ReceiveTransferOrderRequest) (*ReceiveTransferOrderResponse, error) {
+	receivedBy, err := uuid.Parse(req.ReceivedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid received by ID: %w", err)
+	}
+	if len(req.Items) == 0 && !req.CloseShort {
+		return nil, fmt.Errorf("%w: at least one line must be received", ErrInvalidReceipt)
+	}
+
+	var transfer *entities.TransferOrder
+	var receipt *entities.TransferReceipt
+
+	err = database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		transfer, err = s.lockTransferOrder(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		receipt = &entities.TransferReceipt{
+			ID:              uuid.New(),
+			TransferOrderID: transfer.ID,
+			WarehouseID:     transfer.ToWarehouseID,
+			Notes:           req.Notes,
+			ReceivedBy:      receivedBy,
+			ReceivedAt:      now,
+			CreatedAt:       now,
+		}
+		for _, line := range req.Items {
+			itemID, err := uuid.Parse(line.TransferOrderItemID)
+			if err != nil {
+				return fmt.Errorf("%w: invalid transfer order item ID: %v", ErrInvalidReceipt, err)
+			}
+			item := transfer.FindItem(itemID)
+			if item == nil {
+				return fmt.Errorf("%w: transfer order item %s not found", ErrInvalidReceipt, itemID)
+			}
+			receipt.Items = append(receipt.Items, entities.TransferReceiptItem{
+				ID:                  uuid.New(),
+				TransferReceiptID:   receipt.ID,
+				TransferOrderItemID: item.ID,
+				ProductID:           item.ProductID,
+				QuantityReceived:    line.QuantityReceived,
+				QuantityDamaged:     line.QuantityDamaged,
+				QuantityShort:       line.QuantityShort,
+				DiscrepancyReason:   line.DiscrepancyReason,
+			})
+		}
+
+		if err := transfer.ApplyReceipt(receipt, req.CloseShort, strings.TrimSpace(req.ShortReason)); err != nil {
+			if !transfer.CanReceive() {
+				return fmt.Errorf("%w: %v", ErrInvalidStatusTransition, err)
+			}
+			return fmt.Errorf("%w: %v", ErrInvalidReceipt, err)
+		}
+
+		for i := range receipt.Items {
+			line := &receipt.Items[i]
+			arrived := line.QuantityReceived + line.QuantityDamaged
+			if arrived == 0 {
+				continue
+			}
+			item := transfer.FindItem(line.TransferOrderItemID)
+
+			transaction := s.transferTransaction(transfer, line.ProductID, entities.TransactionTypeTransferIn,
+				arrived, item.UnitCost, receivedBy, receipt.ReceivedAt)
+			transaction.Reason = fmt.Sprintf("Received on transfer order %s", transfer.TransferNumber)
+			if err := transaction.Validate(); err != nil {
+				return fmt.Errorf("%w: %s: %v", ErrInvalidReceipt, item.ProductSKU, err)
+			}
+
+			if _, err := s.inventoryRepo.ReceiveStock(ctx, line.ProductID, transfer.ToWarehouseID, arrived, item.UnitCost, receivedBy); err != nil {
+				return fmt.Errorf("failed to receive stock: %w", err)
+			}
+			if err := s.transactionRepo.Create(ctx, transaction); err != nil {
+				return fmt.Errorf("failed to record inventory transaction: %w", err)
+			}
+			line.InventoryTransactionID = &transaction.ID
+
+			if line.QuantityDamaged > 0 {
+				if err := s.writeOffDamaged(ctx, transfer, item, line, receipt); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := s.transferRepo.Update(ctx, transfer); err != nil {
+			return fmt.Errorf("failed to update transfer order: %w", err)
+		}
+
+		if err := s.transferRepo.CreateReceipt(ctx, receipt); err != nil {
+			return fmt.Errorf("failed to create transfer receipt: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	event := s.logger.Info()
+	if transfer.HasDiscrepancies() {
+		event = s.logger.Warn().Bool("discrepancies", true)
+	}
+	event.
+		Str("transfer_order_id", transfer.ID.String()).
+		Str("transfer_number", transfer.TransferNumber).
+		Str("status", string(transfer.Status)).
+		Msg("Transfer order received")
+
+	if s.allocator != nil {
+		for _, line := range receipt.Items {
+			if line.QuantityReceived == 0 {
+				continue
+			}
+			if err := s.allocator.AllocateBackorders(ctx, line.ProductID, transfer.ToWarehouseID); err != nil {
+				s.logger.Error().Err(err).
+					Str("product_id", line.ProductID.String()).
+					Msg("Failed to allocate transferred stock to backorders")
+			}
+		}
+	}
+
+	return &ReceiveTransferOrderResponse{
+		Receipt:       receipt,
+		TransferOrder: transfer,
+	}, nil
+}
+
+// CancelTransferOrder cancels a draft transfer order and releases the stock
+// it reserved in the source warehouse. Shipped transfers cannot be cancelled;
+// stock that never arrives is closed short on receipt instead.
+func (s *ServiceImpl) CancelTransferOrder(ctx context.Context, id string, reason string) (*entities.TransferOrder, error) {
+	if strings.TrimSpace(reason) == "" {
+		return nil, ErrCancellationReasonMissing
+	}
+
+	var transfer *entities.TransferOrder
+
+	err := database.InTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		transfer, err = s.lockTransferOrder(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := transfer.ChangeStatus(entities.TransferOrderStatusCancelled); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidStatusTransition, err)
+		}
+		transfer.CancellationReason = &reason
+
+		for _, item := range transfer.Items {
+			if err := s.inventoryRepo.ReleaseStock(ctx, item.ProductID, transfer.FromWarehouseID, item.QuantityRequested); err != nil {
+				return fmt.Errorf("failed to release reserved stock: %w", err)
+			}
+		}
+
+		if err := s.transferRepo.Update(ctx, transfer); err != nil {
+			return fmt.Errorf("failed to update transfer order: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info().
+		Str("transfer_order_id", transfer.ID.String()).
+		Str("reason", reason).
+		Msg("Transfer order cancelled")
+
+	return transfer, nil
+}
+
+// GetTransferReceipts retrieves the receipts of a transfer order
+func (s *ServiceImpl) GetTransferReceipts(ctx context.Context, id string) ([]*entities.TransferReceipt, error) {
+	transfer, err := s.loadTransferOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	receipts, err := s.transferRepo.GetReceipts(ctx, transfer.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transfer receipts: %w", err)
+	}
+
+	return receipts, nil
+}
+
+// GetInTransitStock reports the stock shipped on open transfer orders and not
+// yet received, per product and route
+func (s *ServiceImpl) GetInTransitStock(ctx context.Context, req *InTransitStockRequest) ([]*repositories.InTransitStock, error) {
+	var filter repositories.InTransitFilter
+	if req.ProductID != nil {
+		productID, err := uuid.Parse(*req.ProductID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid product ID: %w", err)
+		}
+		filter.ProductID = &productID
+	}
+	if req.FromWarehouseID != nil {
+		warehouseID, err := uuid.Parse(*req.FromWarehouseID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid from warehouse ID: %w", err)
+		}
+		filter.FromWarehouseID = &warehouseID
+	}
+	if req.ToWarehouseID != nil {
+		warehouseID, err := uuid.Parse(*req.ToWarehouseID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid to warehouse ID: %w", err)
+		}
+		filter.ToWarehouseID = &warehouseID
+	}
+
+	stock, err := s.transferRepo.GetInTransitStock(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get in-transit stock: %w", err)
+	}
+
+	return stock, nil
+}
+
+// writeOffDamaged removes damaged stock received on a transfer from the
+// destination warehouse, recording it as a DAMAGE inventory transaction
+func (s *ServiceImpl) writeOffDamaged(ctx context.Context, transfer *entities.TransferOrder, item *entities.TransferOrderItem, line *entities.TransferReceiptItem, receipt *entities.TransferReceipt) error {
+	reference := transfer.ID
+	transaction := &entities.InventoryTransaction{
+		ID:              uuid.New(),
+		ProductID:       line.ProductID,
+		WarehouseID:     transfer.ToWarehouseID,
+		TransactionType: entities.TransactionTypeDamage,
+		Quantity:        -line.QuantityDamaged,
+		ReferenceType:   referenceType,
+		ReferenceID:     &reference,
+		Reason:          fmt.Sprintf("Damaged on transfer order %s: %s", transfer.TransferNumber, *line.DiscrepancyReason),
+		UnitCost:        item.UnitCost,
+		TotalCost:       math.Round(item.UnitCost*float64(line.QuantityDamaged)*100) / 100,
+		CreatedAt:       receipt.ReceivedAt,
+		CreatedBy:       receipt.ReceivedBy,
+	}
+	if err := transaction.Validate(); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidReceipt, item.ProductSKU, err)
+	}
+
+	if err := s.inventoryRepo.AdjustStock(ctx, line.ProductID, transfer.ToWarehouseID, -line.QuantityDamaged); err != nil {
+		return fmt.Errorf("failed to write off damaged stock: %w", err)
+	}
+	if err := s.transactionRepo.Create(ctx, transaction); err != nil {
+		return fmt.Errorf("failed to record inventory transaction: %w", err)
+	}
+
+	return nil
+}
+
+// transferTransaction builds a TRANSFER_OUT or TRANSFER_IN inventory
+// transaction of a transfer order. The transaction is booked in the source
+// warehouse when shipping and in the destination warehouse when receiving.
+func (s *ServiceImpl) transferTransaction(transfer *entities.TransferOrder, productID uuid.UUID, transactionType entities.TransactionType, quantity int, unitCost float64, by uuid.UUID, at time.Time) *entities.InventoryTransaction {
+	warehouseID := transfer.ToWarehouseID
+	if transactionType == entities.TransactionTypeTransferOut {
+		warehouseID = transfer.FromWarehouseID
+	}
+	reference := transfer.ID
+	fromWarehouseID := transfer.FromWarehouseID
+	toWarehouseID := transfer.ToWarehouseID
+
+	return &entities.InventoryTransaction{
+		ID:              uuid.New(),
+		ProductID:       productID,
+		WarehouseID:     warehouseID,
+		TransactionType: transactionType,
+		Quantity:        quantity,
+		ReferenceType:   referenceType,
+		ReferenceID:     &reference,
+		UnitCost:        unitCost,
+		TotalCost:       math.Round(unitCost*math.Abs(float64(quantity))*100) / 100,
+		FromWarehouseID: &fromWarehouseID,
+		ToWarehouseID:   &toWarehouseID,
+		CreatedAt:       at,
+		CreatedBy:       by,
+	}
+}
+
+// buildItems builds transfer order lines, copying product details from the
+// catalogue. Only products that track physical stock can be transferred.
+func (s *ServiceImpl) buildItems(ctx context.Context, transferID uuid.UUID, reqs []TransferOrderItemRequest) ([]entities.TransferOrderItem, error) {
+	now := time.Now().UTC()
+	items := make([]entities.TransferOrderItem, 0, len(reqs))
+
+	for _, req := range reqs {
+		productID, err := uuid.Parse(req.ProductID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid product ID: %w", err)
+		}
+
+		product, err := s.productRepo.GetByID(ctx, productID)
+		if err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				return nil, ErrProductNotFound
+			}
+			return nil, fmt.Errorf("failed to get product: %w", err)
+		}
+		if !product.TrackInventory || product.IsDigital {
+			return nil, fmt.Errorf("%w: %s", ErrProductNotStocked, product.SKU)
+		}
+
+		items = append(items, entities.TransferOrderItem{
+			ID:                uuid.New(),
+			TransferOrderID:   transferID,
+			ProductID:         product.ID,
+			ProductSKU:        product.SKU,
+			ProductName:       product.Name,
+			QuantityRequested: req.Quantity,
+			Notes:             req.Notes,
+			CreatedAt:         now,
+			UpdatedAt:         now,
+		})
+	}
+
+	return items, nil
+}
+
+// loadTransferOrder parses an ID and loads the transfer order with its lines
+func (s *ServiceImpl) loadTransferOrder(ctx context.Context, id string) (*entities.TransferOrder, error) {
+	transferID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transfer order ID: %w", err)
+	}
+
+	transfer, err := s.transferRepo.GetByID(ctx, transferID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrTransferOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to get transfer order: %w", err)
+	}
+
+	return transfer, nil
+}
+
+// lockTransferOrder locks a transfer order for the rest of the context's
+// transaction and loads it, so its status and quantities cannot change
+// before the transaction ends
+func (s *ServiceImpl) lockTransferOrder(ctx context.Context, id string) (*entities.TransferOrder, error) {
+	transferID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transfer order ID: %w", err)
+	}
+
+	if err := s.transferRepo.Lock(ctx, transferID); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrTransferOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to lock transfer order: %w", err)
+	}
+
+	return s.loadTransferOrder(ctx, id)
+}
+
+// checkWarehouse parses a warehouse ID and checks that the warehouse exists and is active
+func (s *ServiceImpl) checkWarehouse(ctx context.Context, id string) (uuid.UUID, error) {
+	warehouseID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid warehouse ID: %w", err)
+	}
+
+	warehouse, err := s.warehouseRepo.GetByID(ctx, warehouseID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return uuid.Nil, ErrWarehouseNotFound
+		}
+		return uuid.Nil, fmt.Errorf("failed to get warehouse: %w", err)
+	}
+	if !warehouse.IsActive {
+		return uuid.Nil, fmt.Errorf("%w: %s", ErrWarehouseInactive, warehouse.Code)
+	}
+
+	return warehouseID, nil
+}
+
+// normalizePage applies default and maximum page sizes
+func normalizePage(page, limit int) (int, int) {
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	return page, limit
+}
+
+// newPagination builds pagination metadata
+func newPagination(page, limit, total int) *Pagination {
+	totalPages := 0
+	if limit > 0 {
+		totalPages = (total + limit - 1) / limit
+	}
+	return &Pagination{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+		HasPrev:    page > 1,
+	}
+}
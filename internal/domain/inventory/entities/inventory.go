@@ -3,6 +3,7 @@ package entities
 import (
 	"errors"
 	"fmt"
+	"math"
 	"time"
 
 	apperrors "erpgo/pkg/errors"
@@ -437,6 +438,27 @@ func (i *Inventory) UpdateAverageCost(newCost float64) error {
 	return nil
 }
 
+// ReceiveStock adds received stock and moves the average cost to the weighted
+// average of the stock on hand and the received quantity at its unit cost
+func (i *Inventory) ReceiveStock(quantity int, unitCost float64) error {
+	if quantity <= 0 {
+		return errors.New("received quantity must be positive")
+	}
+	if unitCost < 0 {
+		return errors.New("unit cost cannot be negative")
+	}
+
+	// Negative on-hand stock carries no cost, so it does not dilute the average
+	onHand := max(i.QuantityOnHand, 0)
+	averageCost := (float64(onHand)*i.AverageCost + float64(quantity)*unitCost) / float64(onHand+quantity)
+	averageCost = math.Round(averageCost*10000) / 10000
+
+	if err := i.AddStock(quantity); err != nil {
+		return err
+	}
+	return i.UpdateAverageCost(averageCost)
+}
+
 // UpdateStockLevels updates all stock levels at once
 func (i *Inventory) UpdateStockLevels(minStock, maxStock, reorderLevel *int) error {
 	// Validate min stock
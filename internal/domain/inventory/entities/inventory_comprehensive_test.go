@@ -932,4 +932,43 @@ func TestInventory_ComprehensiveCoverage(t *testing.T) {
 			t.Error("Expected error for negative quantity")
 		}
 	})
+
+	t.Run("ReceiveStock", func(t *testing.T) {
+		inventory := &Inventory{
+			ID:             inventoryID,
+			ProductID:      productID,
+			WarehouseID:    warehouseID,
+			QuantityOnHand: 10,
+			AverageCost:    4.00,
+			UpdatedAt:      time.Now().UTC(),
+			UpdatedBy:      updatedBy,
+		}
+
+		// 10 @ 4.00 + 30 @ 6.00 averages to 5.50
+		if err := inventory.ReceiveStock(30, 6.00); err != nil {
+			t.Fatalf("Expected no error receiving stock, got: %v", err)
+		}
+		if inventory.QuantityOnHand != 40 {
+			t.Errorf("Expected quantity on hand 40, got %d", inventory.QuantityOnHand)
+		}
+		if inventory.AverageCost != 5.50 {
+			t.Errorf("Expected average cost 5.50, got %.4f", inventory.AverageCost)
+		}
+
+		if err := inventory.ReceiveStock(0, 6.00); err == nil {
+			t.Error("Expected error for zero quantity")
+		}
+		if err := inventory.ReceiveStock(5, -1); err == nil {
+			t.Error("Expected error for negative unit cost")
+		}
+
+		// Negative stock on hand does not dilute the cost of received stock
+		inventory.QuantityOnHand = -5
+		if err := inventory.ReceiveStock(10, 8.00); err != nil {
+			t.Fatalf("Expected no error receiving stock, got: %v", err)
+		}
+		if inventory.AverageCost != 8.00 {
+			t.Errorf("Expected average cost 8.00, got %.4f", inventory.AverageCost)
+		}
+	})
 }
@@ -0,0 +1,364 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TransferOrderStatus represents the status of a transfer order
+type TransferOrderStatus string
+
+const (
+	TransferOrderStatusDraft             TransferOrderStatus = "DRAFT"
+	TransferOrderStatusInTransit         TransferOrderStatus = "IN_TRANSIT"
+	TransferOrderStatusPartiallyReceived TransferOrderStatus = "PARTIALLY_RECEIVED"
+	TransferOrderStatusReceived          TransferOrderStatus = "RECEIVED"
+	TransferOrderStatusCancelled         TransferOrderStatus = "CANCELLED"
+)
+
+// TransferOrderStatusTransitions defines valid transfer order status transitions
+var TransferOrderStatusTransitions = map[TransferOrderStatus][]TransferOrderStatus{
+	TransferOrderStatusDraft:             {TransferOrderStatusInTransit, TransferOrderStatusCancelled},
+	TransferOrderStatusInTransit:         {TransferOrderStatusPartiallyReceived, TransferOrderStatusReceived},
+	TransferOrderStatusPartiallyReceived: {TransferOrderStatusReceived},
+	TransferOrderStatusReceived:          {},
+	TransferOrderStatusCancelled:         {},
+}
+
+// TransferOrder represents stock moving from one warehouse to another. Stock
+// leaves the source warehouse when the order ships and is held in transit
+// until the destination warehouse receives it.
+type TransferOrder struct {
+	ID              uuid.UUID           `json:"id" db:"id"`
+	TransferNumber  string              `json:"transfer_number" db:"transfer_number"`
+	FromWarehouseID uuid.UUID           `json:"from_warehouse_id" db:"from_warehouse_id"`
+	ToWarehouseID   uuid.UUID           `json:"to_warehouse_id" db:"to_warehouse_id"`
+	Status          TransferOrderStatus `json:"status" db:"status"`
+
+	ExpectedDate   *time.Time `json:"expected_date,omitempty" db:"expected_date"`
+	Carrier        *string    `json:"carrier,omitempty" db:"carrier"`
+	TrackingNumber *string    `json:"tracking_number,omitempty" db:"tracking_number"`
+
+	Notes              *string `json:"notes,omitempty" db:"notes"`
+	CancellationReason *string `json:"cancellation_reason,omitempty" db:"cancellation_reason"`
+
+	Items []TransferOrderItem `json:"items,omitempty" db:"-"`
+
+	CreatedBy   uuid.UUID  `json:"created_by" db:"created_by"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+	ShippedBy   *uuid.UUID `json:"shipped_by,omitempty" db:"shipped_by"`
+	ShippedAt   *time.Time `json:"shipped_at,omitempty" db:"shipped_at"`
+	ReceivedAt  *time.Time `json:"received_at,omitempty" db:"received_at"`
+	CancelledAt *time.Time `json:"cancelled_at,omitempty" db:"cancelled_at"`
+}
+
+// TransferOrderItem represents a product line of a transfer order. Shipped
+// quantity is in transit until it is received, reported damaged or written
+// off as short.
+type TransferOrderItem struct {
+	ID                uuid.UUID `json:"id" db:"id"`
+	TransferOrderID   uuid.UUID `json:"transfer_order_id" db:"transfer_order_id"`
+	ProductID         uuid.UUID `json:"product_id" db:"product_id"`
+	ProductSKU        string    `json:"product_sku" db:"product_sku"`
+	ProductName       string    `json:"product_name" db:"product_name"`
+	QuantityRequested int       `json:"quantity_requested" db:"quantity_requested"`
+	QuantityShipped   int       `json:"quantity_shipped" db:"quantity_shipped"`
+	QuantityReceived  int       `json:"quantity_received" db:"quantity_received"`
+	QuantityDamaged   int       `json:"quantity_damaged" db:"quantity_damaged"`
+	QuantityShort     int       `json:"quantity_short" db:"quantity_short"`
+	// UnitCost is the source warehouse's average cost when the line shipped
+	UnitCost  float64   `json:"unit_cost" db:"unit_cost"`
+	Notes     *string   `json:"notes,omitempty" db:"notes"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// TransferReceipt records stock arriving at the destination warehouse of a transfer order
+type TransferReceipt struct {
+	ID              uuid.UUID             `json:"id" db:"id"`
+	TransferOrderID uuid.UUID             `json:"transfer_order_id" db:"transfer_order_id"`
+	WarehouseID     uuid.UUID             `json:"warehouse_id" db:"warehouse_id"`
+	Notes           *string               `json:"notes,omitempty" db:"notes"`
+	Items           []TransferReceiptItem `json:"items" db:"-"`
+	ReceivedBy      uuid.UUID             `json:"received_by" db:"received_by"`
+	ReceivedAt      time.Time             `json:"received_at" db:"received_at"`
+	CreatedAt       time.Time             `json:"created_at" db:"created_at"`
+}
+
+// TransferReceiptItem records, for one transfer order line, the quantity
+// received in good condition and any damaged or short quantity
+type TransferReceiptItem struct {
+	ID                     uuid.UUID  `json:"id" db:"id"`
+	TransferReceiptID      uuid.UUID  `json:"transfer_receipt_id" db:"transfer_receipt_id"`
+	TransferOrderItemID    uuid.UUID  `json:"transfer_order_item_id" db:"transfer_order_item_id"`
+	ProductID              uuid.UUID  `json:"product_id" db:"product_id"`
+	QuantityReceived       int        `json:"quantity_received" db:"quantity_received"`
+	QuantityDamaged        int        `json:"quantity_damaged" db:"quantity_damaged"`
+	QuantityShort          int        `json:"quantity_short" db:"quantity_short"`
+	DiscrepancyReason      *string    `json:"discrepancy_reason,omitempty" db:"discrepancy_reason"`
+	InventoryTransactionID *uuid.UUID `json:"inventory_transaction_id,omitempty" db:"inventory_transaction_id"`
+}
+
+// Validate validates the transfer order entity. A transfer order without a
+// number is accepted, since the number is allocated when it is first persisted.
+func (t *TransferOrder) Validate() error {
+	var errs []error
+
+	if t.ID == uuid.Nil {
+		errs = append(errs, errors.New("transfer order ID cannot be empty"))
+	}
+
+	if t.FromWarehouseID == uuid.Nil {
+		errs = append(errs, errors.New("source warehouse ID cannot be empty"))
+	}
+
+	if t.ToWarehouseID == uuid.Nil {
+		errs = append(errs, errors.New("destination warehouse ID cannot be empty"))
+	}
+
+	if t.FromWarehouseID != uuid.Nil && t.FromWarehouseID == t.ToWarehouseID {
+		errs = append(errs, errors.New("source and destination warehouses cannot be the same"))
+	}
+
+	if _, ok := TransferOrderStatusTransitions[t.Status]; !ok {
+		errs = append(errs, fmt.Errorf("invalid status: %s", t.Status))
+	}
+
+	if t.CreatedBy == uuid.Nil {
+		errs = append(errs, errors.New("created by user ID cannot be empty"))
+	}
+
+	if len(t.Items) == 0 {
+		errs = append(errs, errors.New("transfer order must have at least one item"))
+	}
+	products := make(map[uuid.UUID]bool, len(t.Items))
+	for i := range t.Items {
+		if err := t.Items[i].Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("invalid item %d: %w", i+1, err))
+		}
+		if products[t.Items[i].ProductID] {
+			errs = append(errs, fmt.Errorf("invalid item %d: product is already on the transfer order", i+1))
+		}
+		products[t.Items[i].ProductID] = true
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("validation failed: %v", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// Validate validates the transfer order item entity
+func (item *TransferOrderItem) Validate() error {
+	var errs []error
+
+	if item.ProductID == uuid.Nil {
+		errs = append(errs, errors.New("product ID cannot be empty"))
+	}
+
+	if item.QuantityRequested <= 0 {
+		errs = append(errs, errors.New("requested quantity must be positive"))
+	}
+
+	if item.QuantityShipped < 0 || item.QuantityShipped > item.QuantityRequested {
+		errs = append(errs, errors.New("shipped quantity must be between 0 and the requested quantity"))
+	}
+
+	if item.QuantityReceived < 0 || item.QuantityDamaged < 0 || item.QuantityShort < 0 {
+		errs = append(errs, errors.New("received, damaged and short quantities cannot be negative"))
+	}
+
+	if item.QuantityReceived+item.QuantityDamaged+item.QuantityShort > item.QuantityShipped {
+		errs = append(errs, errors.New("received, damaged and short quantities cannot exceed the shipped quantity"))
+	}
+
+	if item.UnitCost < 0 {
+		errs = append(errs, errors.New("unit cost cannot be negative"))
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	return nil
+}
+
+// InTransitQuantity returns the shipped quantity not yet accounted for at the destination
+func (item *TransferOrderItem) InTransitQuantity() int {
+	return item.QuantityShipped - item.QuantityReceived - item.QuantityDamaged - item.QuantityShort
+}
+
+// IsOpen reports whether the transfer order still has stock reserved or in transit
+func (t *TransferOrder) IsOpen() bool {
+	return t.Status == TransferOrderStatusDraft || t.CanReceive()
+}
+
+// CanReceive reports whether stock may be received against the transfer order
+func (t *TransferOrder) CanReceive() bool {
+	return t.Status == TransferOrderStatusInTransit || t.Status == TransferOrderStatusPartiallyReceived
+}
+
+// InTransitQuantity returns the total quantity shipped and not yet accounted for
+func (t *TransferOrder) InTransitQuantity() int {
+	total := 0
+	for i := range t.Items {
+		total += t.Items[i].InTransitQuantity()
+	}
+	return total
+}
+
+// HasDiscrepancies reports whether any line was received damaged or short
+func (t *TransferOrder) HasDiscrepancies() bool {
+	for i := range t.Items {
+		if t.Items[i].QuantityDamaged > 0 || t.Items[i].QuantityShort > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ChangeStatus moves the transfer order to a new status, stamping the matching date
+func (t *TransferOrder) ChangeStatus(newStatus TransferOrderStatus) error {
+	valid := false
+	for _, status := range TransferOrderStatusTransitions[t.Status] {
+		if status == newStatus {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return fmt.Errorf("invalid transfer order status transition from %s to %s", t.Status, newStatus)
+	}
+
+	now := time.Now().UTC()
+	switch newStatus {
+	case TransferOrderStatusInTransit:
+		t.ShippedAt = &now
+	case TransferOrderStatusReceived:
+		t.ReceivedAt = &now
+	case TransferOrderStatusCancelled:
+		t.CancelledAt = &now
+	}
+
+	t.Status = newStatus
+	t.UpdatedAt = now
+	return nil
+}
+
+// Ship ships every line in full and puts the transfer order in transit
+func (t *TransferOrder) Ship(shippedBy uuid.UUID) error {
+	if err := t.ChangeStatus(TransferOrderStatusInTransit); err != nil {
+		return err
+	}
+
+	t.ShippedBy = &shippedBy
+	for i := range t.Items {
+		t.Items[i].QuantityShipped = t.Items[i].QuantityRequested
+		t.Items[i].UpdatedAt = t.UpdatedAt
+	}
+	return nil
+}
+
+// ApplyReceipt books the receipt lines against the transfer order lines. When
+// closeShort is set, whatever remains in transit afterwards is added to the
+// receipt as short with the given reason, so the transfer can be closed. The
+// transfer order moves to PARTIALLY_RECEIVED, or RECEIVED once nothing is left
+// in transit. Accounting for more than is in transit is rejected.
+func (t *TransferOrder) ApplyReceipt(receipt *TransferReceipt, closeShort bool, shortReason string) error {
+	if !t.CanReceive() {
+		return fmt.Errorf("cannot receive stock against a %s transfer order", t.Status)
+	}
+	if len(receipt.Items) == 0 && !closeShort {
+		return errors.New("transfer receipt must have at least one item")
+	}
+	if closeShort && shortReason == "" {
+		return errors.New("a reason is required to close a transfer short")
+	}
+
+	type counts struct{ received, damaged, short int }
+	booked := make(map[uuid.UUID]counts, len(receipt.Items))
+	for _, line := range receipt.Items {
+		if line.QuantityReceived < 0 || line.QuantityDamaged < 0 || line.QuantityShort < 0 {
+			return errors.New("receipt quantities cannot be negative")
+		}
+		if line.QuantityReceived+line.QuantityDamaged+line.QuantityShort == 0 {
+			return errors.New("receipt line must account for a positive quantity")
+		}
+		if (line.QuantityDamaged > 0 || line.QuantityShort > 0) && (line.DiscrepancyReason == nil || *line.DiscrepancyReason == "") {
+			return errors.New("a reason is required for damaged or short quantities")
+		}
+		c := booked[line.TransferOrderItemID]
+		c.received += line.QuantityReceived
+		c.damaged += line.QuantityDamaged
+		c.short += line.QuantityShort
+		booked[line.TransferOrderItemID] = c
+	}
+
+	for itemID, c := range booked {
+		item := t.FindItem(itemID)
+		if item == nil {
+			return fmt.Errorf("transfer order item %s not found", itemID)
+		}
+		if total := c.received + c.damaged + c.short; total > item.InTransitQuantity() {
+			return fmt.Errorf("cannot account for %d of %s: only %d in transit", total, item.ProductSKU, item.InTransitQuantity())
+		}
+	}
+
+	now := time.Now().UTC()
+	for itemID, c := range booked {
+		item := t.FindItem(itemID)
+		item.QuantityReceived += c.received
+		item.QuantityDamaged += c.damaged
+		item.QuantityShort += c.short
+		item.UpdatedAt = now
+	}
+
+	if closeShort {
+		for i := range t.Items {
+			item := &t.Items[i]
+			remaining := item.InTransitQuantity()
+			if remaining == 0 {
+				continue
+			}
+			reason := shortReason
+			receipt.Items = append(receipt.Items, TransferReceiptItem{
+				ID:                  uuid.New(),
+				TransferReceiptID:   receipt.ID,
+				TransferOrderItemID: item.ID,
+				ProductID:           item.ProductID,
+				QuantityShort:       remaining,
+				DiscrepancyReason:   &reason,
+			})
+			item.QuantityShort += remaining
+			item.UpdatedAt = now
+		}
+		if len(receipt.Items) == 0 {
+			return errors.New("transfer receipt must have at least one item")
+		}
+	}
+
+	target := TransferOrderStatusPartiallyReceived
+	if t.InTransitQuantity() == 0 {
+		target = TransferOrderStatusReceived
+	}
+	if t.Status == target {
+		t.UpdatedAt = now
+		return nil
+	}
+	return t.ChangeStatus(target)
+}
+
+// FindItem returns the transfer order line with the given ID
+func (t *TransferOrder) FindItem(itemID uuid.UUID) *TransferOrderItem {
+	for i := range t.Items {
+		if t.Items[i].ID == itemID {
+			return &t.Items[i]
+		}
+	}
+	return nil
+}
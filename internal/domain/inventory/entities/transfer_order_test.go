@@ -0,0 +1,111 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTransferOrder() *TransferOrder {
+	now := time.Now().UTC()
+	orderID := uuid.New()
+	return &TransferOrder{
+		ID:              orderID,
+		FromWarehouseID: uuid.New(),
+		ToWarehouseID:   uuid.New(),
+		Status:          TransferOrderStatusDraft,
+		CreatedBy:       uuid.New(),
+		CreatedAt:       now,
+		UpdatedAt:       now,
+		Items: []TransferOrderItem{
+			{ID: uuid.New(), TransferOrderID: orderID, ProductID: uuid.New(), ProductSKU: "SKU-1", QuantityRequested: 10},
+			{ID: uuid.New(), TransferOrderID: orderID, ProductID: uuid.New(), ProductSKU: "SKU-2", QuantityRequested: 4},
+		},
+	}
+}
+
+func TestTransferOrderValidate(t *testing.T) {
+	order := newTestTransferOrder()
+	assert.NoError(t, order.Validate())
+
+	order.ToWarehouseID = order.FromWarehouseID
+	assert.ErrorContains(t, order.Validate(), "cannot be the same")
+
+	order = newTestTransferOrder()
+	order.Items[1].ProductID = order.Items[0].ProductID
+	assert.ErrorContains(t, order.Validate(), "already on the transfer order")
+
+	order = newTestTransferOrder()
+	order.Items[0].QuantityRequested = 0
+	assert.ErrorContains(t, order.Validate(), "requested quantity must be positive")
+
+	order = newTestTransferOrder()
+	order.Items = nil
+	assert.ErrorContains(t, order.Validate(), "at least one item")
+}
+
+func TestTransferOrderShip(t *testing.T) {
+	order := newTestTransferOrder()
+	shippedBy := uuid.New()
+
+	require.NoError(t, order.Ship(shippedBy))
+	assert.Equal(t, TransferOrderStatusInTransit, order.Status)
+	assert.NotNil(t, order.ShippedAt)
+	assert.Equal(t, shippedBy, *order.ShippedBy)
+	assert.Equal(t, 14, order.InTransitQuantity())
+	assert.True(t, order.IsOpen())
+
+	// A shipped transfer cannot be shipped again or cancelled
+	assert.Error(t, order.Ship(shippedBy))
+	assert.Error(t, order.ChangeStatus(TransferOrderStatusCancelled))
+}
+
+func TestTransferOrderApplyReceipt(t *testing.T) {
+	order := newTestTransferOrder()
+
+	receipt := &TransferReceipt{ID: uuid.New(), Items: []TransferReceiptItem{
+		{TransferOrderItemID: order.Items[0].ID, QuantityReceived: 5},
+	}}
+	assert.ErrorContains(t, order.ApplyReceipt(receipt, false, ""), "cannot receive stock against a DRAFT")
+
+	require.NoError(t, order.Ship(uuid.New()))
+
+	// Damaged and short quantities need a reason
+	receipt.Items[0].QuantityDamaged = 1
+	assert.ErrorContains(t, order.ApplyReceipt(receipt, false, ""), "reason is required")
+
+	reason := "crushed pallet"
+	receipt.Items[0].DiscrepancyReason = &reason
+	require.NoError(t, order.ApplyReceipt(receipt, false, ""))
+	assert.Equal(t, TransferOrderStatusPartiallyReceived, order.Status)
+	assert.Equal(t, 5, order.Items[0].QuantityReceived)
+	assert.Equal(t, 1, order.Items[0].QuantityDamaged)
+	assert.Equal(t, 4, order.Items[0].InTransitQuantity())
+	assert.True(t, order.HasDiscrepancies())
+
+	// Cannot account for more than is still in transit
+	over := &TransferReceipt{ID: uuid.New(), Items: []TransferReceiptItem{
+		{TransferOrderItemID: order.Items[0].ID, QuantityReceived: 5},
+	}}
+	assert.ErrorContains(t, order.ApplyReceipt(over, false, ""), "only 4 in transit")
+	assert.Equal(t, 5, order.Items[0].QuantityReceived)
+
+	// Closing short writes off whatever is left in transit
+	final := &TransferReceipt{ID: uuid.New(), Items: []TransferReceiptItem{
+		{TransferOrderItemID: order.Items[1].ID, QuantityReceived: 4},
+	}}
+	assert.ErrorContains(t, order.ApplyReceipt(final, true, ""), "reason is required to close")
+
+	require.NoError(t, order.ApplyReceipt(final, true, "lost in transit"))
+	assert.Equal(t, TransferOrderStatusReceived, order.Status)
+	assert.NotNil(t, order.ReceivedAt)
+	assert.Equal(t, 4, order.Items[0].QuantityShort)
+	assert.Equal(t, 0, order.InTransitQuantity())
+	assert.False(t, order.IsOpen())
+	require.Len(t, final.Items, 2)
+	assert.Equal(t, 4, final.Items[1].QuantityShort)
+	assert.Equal(t, "lost in transit", *final.Items[1].DiscrepancyReason)
+}
@@ -23,6 +23,9 @@ type InventoryRepository interface {
 	ReserveStock(ctx context.Context, productID, warehouseID uuid.UUID, quantity int) error
 	ReleaseStock(ctx context.Context, productID, warehouseID uuid.UUID, quantity int) error
 	GetAvailableStock(ctx context.Context, productID, warehouseID uuid.UUID) (int, error)
+	// ReceiveStock adds received stock at the given unit cost, creating the inventory
+	// record when missing and updating its weighted average cost
+	ReceiveStock(ctx context.Context, productID, warehouseID uuid.UUID, quantity int, unitCost float64, receivedBy uuid.UUID) (*entities.Inventory, error)
 
 	// Listing and filtering
 	List(ctx context.Context, filter *InventoryFilter) ([]*entities.Inventory, error)
@@ -101,6 +104,7 @@ type InventoryTransactionRepository interface {
 
 	// Transfer operations
 	GetTransferTransactions(ctx context.Context, fromWarehouseID, toWarehouseID uuid.UUID) ([]*entities.InventoryTransaction, error)
+	// GetPendingTransfers retrieves the shipments of transfer orders still in transit
 	GetPendingTransfers(ctx context.Context, warehouseID *uuid.UUID) ([]*entities.InventoryTransaction, error)
 
 	// Analytics and reporting
@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"context"
+
+	"erpgo/internal/domain/inventory/entities"
+	"github.com/google/uuid"
+)
+
+// TransferOrderRepository defines the interface for transfer order data operations
+type TransferOrderRepository interface {
+	// Create persists a transfer order with its lines. Transfer orders without
+	// a number are numbered from the TRANSFER document sequence.
+	Create(ctx context.Context, transfer *entities.TransferOrder) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.TransferOrder, error)
+	// Update persists the transfer order header and its line quantities
+	Update(ctx context.Context, transfer *entities.TransferOrder) error
+	// Lock locks the transfer order's row until the transaction of the
+	// context ends, so concurrent changes to the transfer wait for it
+	Lock(ctx context.Context, id uuid.UUID) error
+	List(ctx context.Context, filter TransferOrderFilter) ([]*entities.TransferOrder, error)
+	Count(ctx context.Context, filter TransferOrderFilter) (int, error)
+
+	CreateReceipt(ctx context.Context, receipt *entities.TransferReceipt) error
+	// GetReceipts retrieves every receipt of a transfer order, oldest first
+	GetReceipts(ctx context.Context, transferOrderID uuid.UUID) ([]*entities.TransferReceipt, error)
+
+	// GetInTransitStock returns the quantity shipped on open transfer orders
+	// and not yet accounted for at the destination, per product and route
+	GetInTransitStock(ctx context.Context, filter InTransitFilter) ([]*InTransitStock, error)
+}
+
+// TransferOrderFilter defines filter criteria for transfer order queries
+type TransferOrderFilter struct {
+	Search          string                         `json:"search,omitempty"`
+	Status          []entities.TransferOrderStatus `json:"status,omitempty"`
+	FromWarehouseID *uuid.UUID                     `json:"from_warehouse_id,omitempty"`
+	ToWarehouseID   *uuid.UUID                     `json:"to_warehouse_id,omitempty"`
+
+	Page   int `json:"page"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset,omitempty"`
+}
+
+// InTransitFilter defines filter criteria for in-transit stock queries
+type InTransitFilter struct {
+	ProductID       *uuid.UUID `json:"product_id,omitempty"`
+	FromWarehouseID *uuid.UUID `json:"from_warehouse_id,omitempty"`
+	ToWarehouseID   *uuid.UUID `json:"to_warehouse_id,omitempty"`
+}
+
+// InTransitStock represents stock of a product in transit between two warehouses
+type InTransitStock struct {
+	ProductID       uuid.UUID `json:"product_id"`
+	ProductSKU      string    `json:"product_sku"`
+	ProductName     string    `json:"product_name"`
+	FromWarehouseID uuid.UUID `json:"from_warehouse_id"`
+	ToWarehouseID   uuid.UUID `json:"to_warehouse_id"`
+	Quantity        int       `json:"quantity"`
+	TotalValue      float64   `json:"total_value"`
+}
@@ -0,0 +1,360 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ApprovalStatus is the state of one step of an order's approval chain
+type ApprovalStatus string
+
+const (
+	ApprovalStatusPending  ApprovalStatus = "PENDING"
+	ApprovalStatusApproved ApprovalStatus = "APPROVED"
+	ApprovalStatusRejected ApprovalStatus = "REJECTED"
+	// ApprovalStatusSkipped marks steps left undecided when the chain was
+	// rejected or replaced
+	ApprovalStatusSkipped ApprovalStatus = "SKIPPED"
+)
+
+// ApprovalPolicy requires orders meeting its conditions to be approved by
+// users holding its approver roles, one role after the other, before they are
+// confirmed
+type ApprovalPolicy struct {
+	ID          uuid.UUID `json:"id" db:"id"`
+	Name        string    `json:"name" db:"name"`
+	Description *string   `json:"description,omitempty" db:"description"`
+
+	// Conditions. A policy applies to orders meeting every condition it sets;
+	// amounts are order totals in the base currency and percentages are of the
+	// order subtotal.
+	MinAmount          *decimal.Decimal `json:"min_amount,omitempty" db:"min_amount"`
+	CustomerTypes      []string         `json:"customer_types,omitempty" db:"customer_types"`
+	MinDiscountPercent *decimal.Decimal `json:"min_discount_percent,omitempty" db:"min_discount_percent"`
+	MaxMarginPercent   *decimal.Decimal `json:"max_margin_percent,omitempty" db:"max_margin_percent"`
+
+	// ApproverRoles are the roles that approve, in sequence
+	ApproverRoles []string `json:"approver_roles" db:"approver_roles"`
+	// Policies add their approvers to an order's chain in descending priority
+	Priority int  `json:"priority" db:"priority"`
+	IsActive bool `json:"is_active" db:"is_active"`
+
+	CreatedBy uuid.UUID `json:"created_by" db:"created_by"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Validate validates the approval policy
+func (p *ApprovalPolicy) Validate() error {
+	var errs []error
+
+	if p.ID == uuid.Nil {
+		errs = append(errs, errors.New("approval policy ID cannot be empty"))
+	}
+	if strings.TrimSpace(p.Name) == "" {
+		errs = append(errs, errors.New("approval policy name is required"))
+	}
+	if p.MinAmount == nil && len(p.CustomerTypes) == 0 && p.MinDiscountPercent == nil && p.MaxMarginPercent == nil {
+		errs = append(errs, errors.New("approval policy must set at least one condition"))
+	}
+	if p.MinAmount != nil && p.MinAmount.IsNegative() {
+		errs = append(errs, errors.New("minimum amount cannot be negative"))
+	}
+	for _, segment := range p.CustomerTypes {
+		if !validCustomerSegments[segment] {
+			errs = append(errs, fmt.Errorf("invalid customer type: %s", segment))
+		}
+	}
+	if p.MinDiscountPercent != nil && (p.MinDiscountPercent.IsNegative() || p.MinDiscountPercent.GreaterThan(oneHundredPercent)) {
+		errs = append(errs, errors.New("minimum discount percentage must be between 0 and 100"))
+	}
+	if p.MaxMarginPercent != nil && p.MaxMarginPercent.GreaterThan(oneHundredPercent) {
+		errs = append(errs, errors.New("maximum margin percentage cannot exceed 100"))
+	}
+	if len(p.ApproverRoles) == 0 {
+		errs = append(errs, errors.New("approval policy requires at least one approver role"))
+	}
+	seen := make(map[string]bool, len(p.ApproverRoles))
+	for _, role := range p.ApproverRoles {
+		if strings.TrimSpace(role) == "" {
+			errs = append(errs, errors.New("approver role cannot be empty"))
+		} else if seen[role] {
+			errs = append(errs, fmt.Errorf("approver role %s is listed more than once", role))
+		}
+		seen[role] = true
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("validation failed: %v", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// NeedsMargin reports whether matching the policy needs the margin of an order
+func (p *ApprovalPolicy) NeedsMargin() bool {
+	return p.MaxMarginPercent != nil
+}
+
+// Matches reports whether the policy is active and an order with the metrics
+// meets all of its conditions. Orders whose margin is unknown do not meet a
+// margin condition.
+func (p *ApprovalPolicy) Matches(metrics ApprovalMetrics) bool {
+	if !p.IsActive {
+		return false
+	}
+	if p.MinAmount != nil && metrics.Amount.LessThan(*p.MinAmount) {
+		return false
+	}
+	if len(p.CustomerTypes) > 0 {
+		matched := false
+		for _, segment := range p.CustomerTypes {
+			if strings.EqualFold(segment, metrics.CustomerType) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if p.MinDiscountPercent != nil && metrics.DiscountPercent.LessThan(*p.MinDiscountPercent) {
+		return false
+	}
+	if p.MaxMarginPercent != nil && (metrics.MarginPercent == nil || metrics.MarginPercent.GreaterThan(*p.MaxMarginPercent)) {
+		return false
+	}
+	return true
+}
+
+// ApprovalMetrics are the figures of an order that approval policies are matched against
+type ApprovalMetrics struct {
+	// Amount is the order total in the base currency
+	Amount       decimal.Decimal `json:"amount"`
+	CustomerType string          `json:"customer_type"`
+	// DiscountPercent is the item, order and coupon discounts as a percentage of the subtotal
+	DiscountPercent decimal.Decimal `json:"discount_percent"`
+	// MarginPercent is the discounted subtotal less the cost of the lines as a
+	// percentage of the discounted subtotal, nil when a line's cost is unknown
+	MarginPercent *decimal.Decimal `json:"margin_percent,omitempty"`
+}
+
+// NewApprovalMetrics works out the approval metrics of an order. Costs maps
+// the products of the order's lines to their unit cost; without the cost of
+// every line the margin is unknown.
+func NewApprovalMetrics(order *Order, costs map[uuid.UUID]decimal.Decimal) ApprovalMetrics {
+	metrics := ApprovalMetrics{
+		Amount:          order.TotalAmount,
+		DiscountPercent: decimal.Zero,
+	}
+	if order.ExchangeRate.IsPositive() {
+		metrics.Amount = order.TotalAmount.Mul(order.ExchangeRate).Round(2)
+	}
+	if order.Customer != nil {
+		metrics.CustomerType = order.Customer.Type
+	}
+	if !order.Subtotal.IsPositive() {
+		return metrics
+	}
+
+	metrics.DiscountPercent = order.DiscountAmount.Div(order.Subtotal).Mul(oneHundredPercent).Round(2)
+
+	cost := decimal.Zero
+	for _, item := range order.Items {
+		unitCost, ok := costs[item.ProductID]
+		if !ok {
+			return metrics
+		}
+		cost = cost.Add(unitCost.Mul(decimal.NewFromInt(int64(item.Quantity))))
+	}
+
+	netSales := order.Subtotal.Sub(order.DiscountAmount)
+	if netSales.IsPositive() {
+		margin := netSales.Sub(cost).Div(netSales).Mul(oneHundredPercent).Round(2)
+		metrics.MarginPercent = &margin
+	}
+
+	return metrics
+}
+
+// ApprovalStep is one approval an order requires
+type ApprovalStep struct {
+	PolicyID   uuid.UUID `json:"policy_id"`
+	PolicyName string    `json:"policy_name"`
+	Role       string    `json:"role"`
+}
+
+// BuildApprovalChain returns the approvals an order with the metrics
+// requires: the approver roles of every matching policy, highest priority
+// first. A role required by several policies approves once, at its first step.
+func BuildApprovalChain(policies []*ApprovalPolicy, metrics ApprovalMetrics) []ApprovalStep {
+	matching := make([]*ApprovalPolicy, 0, len(policies))
+	for _, policy := range policies {
+		if policy.Matches(metrics) {
+			matching = append(matching, policy)
+		}
+	}
+	sort.SliceStable(matching, func(i, j int) bool {
+		return matching[i].Priority > matching[j].Priority
+	})
+
+	var steps []ApprovalStep
+	seen := make(map[string]bool)
+	for _, policy := range matching {
+		for _, role := range policy.ApproverRoles {
+			if seen[role] {
+				continue
+			}
+			seen[role] = true
+			steps = append(steps, ApprovalStep{PolicyID: policy.ID, PolicyName: policy.Name, Role: role})
+		}
+	}
+
+	return steps
+}
+
+// OrderApproval is one step of an order's approval chain and, once decided,
+// the record of who approved or rejected it. Steps are replaced rather than
+// deleted when the order changes, so earlier decisions stay on record.
+type OrderApproval struct {
+	ID         uuid.UUID      `json:"id" db:"id"`
+	OrderID    uuid.UUID      `json:"order_id" db:"order_id"`
+	PolicyID   uuid.UUID      `json:"policy_id" db:"policy_id"`
+	PolicyName string         `json:"policy_name" db:"policy_name"`
+	Sequence   int            `json:"sequence" db:"sequence"`
+	Role       string         `json:"role" db:"role"`
+	Status     ApprovalStatus `json:"status" db:"status"`
+	// Amount is the order total in the base currency when approval was requested
+	Amount    decimal.Decimal `json:"amount" db:"amount"`
+	DecidedBy *uuid.UUID      `json:"decided_by,omitempty" db:"decided_by"`
+	DecidedAt *time.Time      `json:"decided_at,omitempty" db:"decided_at"`
+	Comment   *string         `json:"comment,omitempty" db:"comment"`
+	// SupersededAt is set when a change to the order replaced the chain
+	SupersededAt *time.Time `json:"superseded_at,omitempty" db:"superseded_at"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+
+	Order *Order `json:"order,omitempty" db:"-"`
+}
+
+// IsSuperseded reports whether the step belongs to a replaced chain
+func (a *OrderApproval) IsSuperseded() bool {
+	return a.SupersededAt != nil
+}
+
+// Decide records an approver's approval or rejection of a pending step
+func (a *OrderApproval) Decide(status ApprovalStatus, decidedBy uuid.UUID, comment string, at time.Time) error {
+	if a.Status != ApprovalStatusPending || a.IsSuperseded() {
+		return fmt.Errorf("approval step %d is already %s", a.Sequence, strings.ToLower(string(a.Status)))
+	}
+	if status != ApprovalStatusApproved && status != ApprovalStatusRejected {
+		return fmt.Errorf("invalid approval decision: %s", status)
+	}
+
+	a.Status = status
+	a.DecidedBy = &decidedBy
+	a.DecidedAt = &at
+	if comment = strings.TrimSpace(comment); comment != "" {
+		a.Comment = &comment
+	}
+	return nil
+}
+
+// ApprovalChain is the current chain of an order's approval steps in sequence
+type ApprovalChain []*OrderApproval
+
+// CurrentApprovalChain returns the steps of an order that were not superseded, in sequence
+func CurrentApprovalChain(approvals []*OrderApproval) ApprovalChain {
+	var chain ApprovalChain
+	for _, approval := range approvals {
+		if !approval.IsSuperseded() {
+			chain = append(chain, approval)
+		}
+	}
+	sort.SliceStable(chain, func(i, j int) bool {
+		return chain[i].Sequence < chain[j].Sequence
+	})
+	return chain
+}
+
+// Next returns the step awaiting a decision, or nil when none is
+func (c ApprovalChain) Next() *OrderApproval {
+	for _, approval := range c {
+		switch approval.Status {
+		case ApprovalStatusPending:
+			return approval
+		case ApprovalStatusRejected, ApprovalStatusSkipped:
+			return nil
+		}
+	}
+	return nil
+}
+
+// ApprovedBy returns the step of the chain the user approved, or nil when the
+// user approved none
+func (c ApprovalChain) ApprovedBy(userID uuid.UUID) *OrderApproval {
+	for _, approval := range c {
+		if approval.Status == ApprovalStatusApproved && approval.DecidedBy != nil && *approval.DecidedBy == userID {
+			return approval
+		}
+	}
+	return nil
+}
+
+// IsApproved reports whether every step of the chain was approved
+func (c ApprovalChain) IsApproved() bool {
+	for _, approval := range c {
+		if approval.Status != ApprovalStatusApproved {
+			return false
+		}
+	}
+	return true
+}
+
+// Covers reports whether the chain was requested for the steps at the amount,
+// so the order has not changed in a way that requires different approvals
+func (c ApprovalChain) Covers(steps []ApprovalStep, amount decimal.Decimal) bool {
+	if len(c) != len(steps) {
+		return false
+	}
+	for i, step := range steps {
+		if c[i].PolicyID != step.PolicyID || c[i].Role != step.Role || !c[i].Amount.Equal(amount) {
+			return false
+		}
+	}
+	return true
+}
+
+// ApprovesFor reports whether every step's role approved the order at an
+// amount of at least the one given, so a confirmed order changed to the
+// amount needs no further approval
+func (c ApprovalChain) ApprovesFor(steps []ApprovalStep, amount decimal.Decimal) bool {
+	approved := make(map[string]bool, len(c))
+	for _, approval := range c {
+		if approval.Status == ApprovalStatusApproved && approval.Amount.GreaterThanOrEqual(amount) {
+			approved[approval.Role] = true
+		}
+	}
+	for _, step := range steps {
+		if !approved[step.Role] {
+			return false
+		}
+	}
+	return true
+}
+
+// Roles lists the roles of the steps still awaiting a decision, in sequence
+func (c ApprovalChain) Roles() []string {
+	var roles []string
+	for _, approval := range c {
+		if approval.Status == ApprovalStatusPending {
+			roles = append(roles, approval.Role)
+		}
+	}
+	return roles
+}
@@ -0,0 +1,224 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestApprovalPolicy(name string, priority int, roles ...string) *ApprovalPolicy {
+	minAmount := decimal.NewFromInt(10000)
+	return &ApprovalPolicy{
+		ID:            uuid.New(),
+		Name:          name,
+		MinAmount:     &minAmount,
+		ApproverRoles: roles,
+		Priority:      priority,
+		IsActive:      true,
+	}
+}
+
+func newTestApprovalStep(policyID uuid.UUID, sequence int, role string, status ApprovalStatus) *OrderApproval {
+	return &OrderApproval{
+		ID:       uuid.New(),
+		PolicyID: policyID,
+		Sequence: sequence,
+		Role:     role,
+		Status:   status,
+		Amount:   decimal.NewFromInt(12000),
+	}
+}
+
+func TestApprovalPolicy_Validate(t *testing.T) {
+	t.Run("valid policy", func(t *testing.T) {
+		assert.NoError(t, newTestApprovalPolicy("Large orders", 0, "manager", "finance").Validate())
+	})
+
+	t.Run("requires a condition", func(t *testing.T) {
+		policy := newTestApprovalPolicy("Everything", 0, "manager")
+		policy.MinAmount = nil
+		err := policy.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "at least one condition")
+	})
+
+	t.Run("rejects unknown customer types", func(t *testing.T) {
+		policy := newTestApprovalPolicy("Segments", 0, "manager")
+		policy.CustomerTypes = []string{"RESELLER"}
+		err := policy.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid customer type")
+	})
+
+	t.Run("rejects discount percentage over 100", func(t *testing.T) {
+		policy := newTestApprovalPolicy("Discounts", 0, "manager")
+		discount := decimal.NewFromInt(120)
+		policy.MinDiscountPercent = &discount
+		assert.Error(t, policy.Validate())
+	})
+
+	t.Run("requires unique approver roles", func(t *testing.T) {
+		assert.Error(t, newTestApprovalPolicy("No approvers", 0).Validate())
+
+		err := newTestApprovalPolicy("Twice", 0, "manager", "manager").Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "more than once")
+	})
+}
+
+func TestApprovalPolicy_Matches(t *testing.T) {
+	policy := newTestApprovalPolicy("Large business orders", 0, "manager")
+	policy.CustomerTypes = []string{"BUSINESS"}
+	maxMargin := decimal.NewFromInt(15)
+	policy.MaxMarginPercent = &maxMargin
+
+	lowMargin := decimal.NewFromInt(10)
+	highMargin := decimal.NewFromInt(30)
+	metrics := ApprovalMetrics{
+		Amount:        decimal.NewFromInt(15000),
+		CustomerType:  "BUSINESS",
+		MarginPercent: &lowMargin,
+	}
+	assert.True(t, policy.Matches(metrics))
+	assert.True(t, policy.NeedsMargin())
+
+	below := metrics
+	below.Amount = decimal.NewFromInt(9999)
+	assert.False(t, policy.Matches(below), "every condition must hold")
+
+	individual := metrics
+	individual.CustomerType = "INDIVIDUAL"
+	assert.False(t, policy.Matches(individual))
+
+	profitable := metrics
+	profitable.MarginPercent = &highMargin
+	assert.False(t, policy.Matches(profitable))
+
+	unknownMargin := metrics
+	unknownMargin.MarginPercent = nil
+	assert.False(t, policy.Matches(unknownMargin), "an unknown margin does not meet a margin condition")
+
+	policy.IsActive = false
+	assert.False(t, policy.Matches(metrics))
+}
+
+func TestNewApprovalMetrics(t *testing.T) {
+	productA, productB := uuid.New(), uuid.New()
+	order := &Order{
+		Customer:       &Customer{Type: "BUSINESS"},
+		Subtotal:       decimal.NewFromInt(1000),
+		DiscountAmount: decimal.NewFromInt(200),
+		TotalAmount:    decimal.NewFromInt(880),
+		ExchangeRate:   decimal.RequireFromString("1.25"),
+		Items: []OrderItem{
+			{ProductID: productA, Quantity: 2},
+			{ProductID: productB, Quantity: 4},
+		},
+	}
+
+	metrics := NewApprovalMetrics(order, map[uuid.UUID]decimal.Decimal{
+		productA: decimal.NewFromInt(200),
+		productB: decimal.NewFromInt(50),
+	})
+
+	assert.True(t, metrics.Amount.Equal(decimal.NewFromInt(1100)), "amount is in the base currency")
+	assert.Equal(t, "BUSINESS", metrics.CustomerType)
+	assert.True(t, metrics.DiscountPercent.Equal(decimal.NewFromInt(20)))
+	require.NotNil(t, metrics.MarginPercent)
+	// (800 - 600) / 800
+	assert.True(t, metrics.MarginPercent.Equal(decimal.NewFromInt(25)))
+
+	missingCost := NewApprovalMetrics(order, map[uuid.UUID]decimal.Decimal{productA: decimal.NewFromInt(200)})
+	assert.Nil(t, missingCost.MarginPercent)
+}
+
+func TestBuildApprovalChain(t *testing.T) {
+	large := newTestApprovalPolicy("Large orders", 10, "manager", "finance")
+	discounted := newTestApprovalPolicy("Discounted orders", 20, "sales_director", "manager")
+	minDiscount := decimal.NewFromInt(15)
+	discounted.MinDiscountPercent = &minDiscount
+	inactive := newTestApprovalPolicy("Retired", 30, "ceo")
+	inactive.IsActive = false
+
+	policies := []*ApprovalPolicy{large, discounted, inactive}
+
+	t.Run("roles of matching policies by priority", func(t *testing.T) {
+		steps := BuildApprovalChain(policies, ApprovalMetrics{
+			Amount:          decimal.NewFromInt(20000),
+			DiscountPercent: decimal.NewFromInt(20),
+		})
+
+		require.Len(t, steps, 3)
+		assert.Equal(t, "sales_director", steps[0].Role)
+		assert.Equal(t, "manager", steps[1].Role)
+		assert.Equal(t, discounted.ID, steps[1].PolicyID, "a shared role approves once, at its first step")
+		assert.Equal(t, "finance", steps[2].Role)
+		assert.Equal(t, large.ID, steps[2].PolicyID)
+	})
+
+	t.Run("no matching policies", func(t *testing.T) {
+		assert.Empty(t, BuildApprovalChain(policies, ApprovalMetrics{Amount: decimal.NewFromInt(500)}))
+	})
+}
+
+func TestOrderApproval_Decide(t *testing.T) {
+	step := newTestApprovalStep(uuid.New(), 1, "manager", ApprovalStatusPending)
+	approver := uuid.New()
+
+	require.NoError(t, step.Decide(ApprovalStatusApproved, approver, "  fine  ", time.Now()))
+	assert.Equal(t, ApprovalStatusApproved, step.Status)
+	assert.Equal(t, approver, *step.DecidedBy)
+	require.NotNil(t, step.Comment)
+	assert.Equal(t, "fine", *step.Comment)
+
+	assert.Error(t, step.Decide(ApprovalStatusRejected, approver, "", time.Now()), "decided steps cannot be decided again")
+
+	superseded := newTestApprovalStep(uuid.New(), 1, "manager", ApprovalStatusPending)
+	now := time.Now()
+	superseded.SupersededAt = &now
+	assert.Error(t, superseded.Decide(ApprovalStatusApproved, approver, "", now))
+
+	assert.Error(t, newTestApprovalStep(uuid.New(), 1, "manager", ApprovalStatusPending).Decide(ApprovalStatusSkipped, approver, "", now))
+}
+
+func TestApprovalChain(t *testing.T) {
+	policyID := uuid.New()
+	now := time.Now()
+	old := newTestApprovalStep(policyID, 1, "manager", ApprovalStatusSkipped)
+	old.SupersededAt = &now
+	second := newTestApprovalStep(policyID, 2, "finance", ApprovalStatusPending)
+	first := newTestApprovalStep(policyID, 1, "manager", ApprovalStatusApproved)
+
+	chain := CurrentApprovalChain([]*OrderApproval{old, second, first})
+	require.Len(t, chain, 2)
+	assert.Equal(t, first, chain[0])
+	assert.Equal(t, second, chain.Next())
+	assert.False(t, chain.IsApproved())
+	assert.Equal(t, []string{"finance"}, chain.Roles())
+
+	steps := []ApprovalStep{{PolicyID: policyID, Role: "manager"}, {PolicyID: policyID, Role: "finance"}}
+	assert.True(t, chain.Covers(steps, decimal.NewFromInt(12000)))
+	assert.False(t, chain.Covers(steps, decimal.NewFromInt(13000)), "a changed total requires new approvals")
+	assert.False(t, chain.Covers(steps[:1], decimal.NewFromInt(12000)))
+
+	assert.False(t, chain.ApprovesFor(steps, decimal.NewFromInt(12000)), "finance has not approved yet")
+
+	manager := uuid.New()
+	first.DecidedBy = &manager
+	assert.Equal(t, first, chain.ApprovedBy(manager))
+	assert.Nil(t, chain.ApprovedBy(uuid.New()))
+
+	second.Status = ApprovalStatusApproved
+	assert.True(t, chain.IsApproved())
+	assert.Nil(t, chain.Next())
+	assert.True(t, chain.ApprovesFor(steps, decimal.NewFromInt(11000)))
+	assert.False(t, chain.ApprovesFor(steps, decimal.NewFromInt(13000)), "a larger amount needs approving again")
+
+	second.Status = ApprovalStatusRejected
+	assert.Nil(t, chain.Next())
+	assert.False(t, chain.IsApproved())
+}
@@ -0,0 +1,177 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BackorderStatus represents the allocation status of a backorder
+type BackorderStatus string
+
+const (
+	BackorderStatusOpen               BackorderStatus = "OPEN"
+	BackorderStatusPartiallyAllocated BackorderStatus = "PARTIALLY_ALLOCATED"
+	BackorderStatusAllocated          BackorderStatus = "ALLOCATED"
+	BackorderStatusCancelled          BackorderStatus = "CANCELLED"
+)
+
+// Backorder represents the quantity of an order line that could not be
+// allocated from stock when the order was approved. Backorders of a product
+// form a queue that is allocated as stock arrives, highest order priority
+// first and oldest order first within a priority.
+type Backorder struct {
+	ID                uuid.UUID       `json:"id" db:"id"`
+	OrderID           uuid.UUID       `json:"order_id" db:"order_id"`
+	OrderItemID       uuid.UUID       `json:"order_item_id" db:"order_item_id"`
+	ProductID         uuid.UUID       `json:"product_id" db:"product_id"`
+	ProductSKU        string          `json:"product_sku" db:"product_sku"`
+	Quantity          int             `json:"quantity" db:"quantity"`
+	QuantityAllocated int             `json:"quantity_allocated" db:"quantity_allocated"`
+	Status            BackorderStatus `json:"status" db:"status"`
+
+	// Queue position, read from the order so priority changes reorder the queue
+	OrderNumber string        `json:"order_number" db:"-"`
+	Priority    OrderPriority `json:"priority" db:"-"`
+	OrderDate   time.Time     `json:"order_date" db:"-"`
+
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+	AllocatedAt *time.Time `json:"allocated_at,omitempty" db:"allocated_at"`
+	CancelledAt *time.Time `json:"cancelled_at,omitempty" db:"cancelled_at"`
+}
+
+// NewBackorder creates an open backorder for the unallocated quantity of an order line
+func NewBackorder(order *Order, item *OrderItem, quantity int) (*Backorder, error) {
+	if quantity <= 0 {
+		return nil, errors.New("backorder quantity must be positive")
+	}
+
+	now := time.Now().UTC()
+	return &Backorder{
+		ID:          uuid.New(),
+		OrderID:     order.ID,
+		OrderItemID: item.ID,
+		ProductID:   item.ProductID,
+		ProductSKU:  item.ProductSKU,
+		Quantity:    quantity,
+		Status:      BackorderStatusOpen,
+		OrderNumber: order.OrderNumber,
+		Priority:    order.Priority,
+		OrderDate:   order.OrderDate,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// Remaining returns the quantity still waiting for stock
+func (b *Backorder) Remaining() int {
+	return b.Quantity - b.QuantityAllocated
+}
+
+// IsOpen reports whether the backorder is still waiting for stock
+func (b *Backorder) IsOpen() bool {
+	return b.Status == BackorderStatusOpen || b.Status == BackorderStatusPartiallyAllocated
+}
+
+// Allocate assigns newly available stock to the backorder
+func (b *Backorder) Allocate(quantity int) error {
+	if !b.IsOpen() {
+		return fmt.Errorf("backorder is %s", b.Status)
+	}
+	if quantity <= 0 {
+		return errors.New("allocated quantity must be positive")
+	}
+	if quantity > b.Remaining() {
+		return fmt.Errorf("cannot allocate %d units, only %d outstanding", quantity, b.Remaining())
+	}
+
+	now := time.Now().UTC()
+	b.QuantityAllocated += quantity
+	b.UpdatedAt = now
+	if b.Remaining() == 0 {
+		b.Status = BackorderStatusAllocated
+		b.AllocatedAt = &now
+	} else {
+		b.Status = BackorderStatusPartiallyAllocated
+	}
+	return nil
+}
+
+// Cancel withdraws the outstanding quantity from the queue
+func (b *Backorder) Cancel() error {
+	if !b.IsOpen() {
+		return fmt.Errorf("backorder is %s", b.Status)
+	}
+
+	now := time.Now().UTC()
+	b.Status = BackorderStatusCancelled
+	b.CancelledAt = &now
+	b.UpdatedAt = now
+	return nil
+}
+
+// Reduce takes quantity off the outstanding backorder when its order line
+// is reduced. A backorder left with nothing outstanding is allocated when
+// stock was already allocated to it and cancelled otherwise.
+func (b *Backorder) Reduce(quantity int) error {
+	if !b.IsOpen() {
+		return fmt.Errorf("backorder is %s", b.Status)
+	}
+	if quantity <= 0 {
+		return errors.New("reduced quantity must be positive")
+	}
+	if quantity > b.Remaining() {
+		return fmt.Errorf("cannot reduce by %d units, only %d outstanding", quantity, b.Remaining())
+	}
+
+	now := time.Now().UTC()
+	b.Quantity -= quantity
+	b.UpdatedAt = now
+	if b.Remaining() > 0 {
+		return nil
+	}
+	if b.QuantityAllocated > 0 {
+		b.Status = BackorderStatusAllocated
+		b.AllocatedAt = &now
+	} else {
+		b.Status = BackorderStatusCancelled
+		b.CancelledAt = &now
+	}
+	return nil
+}
+
+var priorityRanks = map[OrderPriority]int{
+	OrderPriorityCritical: 0,
+	OrderPriorityUrgent:   1,
+	OrderPriorityHigh:     2,
+	OrderPriorityNormal:   3,
+	OrderPriorityLow:      4,
+}
+
+// PriorityRank orders priorities from most to least urgent; unknown
+// priorities rank with NORMAL
+func PriorityRank(priority OrderPriority) int {
+	if rank, ok := priorityRanks[priority]; ok {
+		return rank
+	}
+	return priorityRanks[OrderPriorityNormal]
+}
+
+// SortBackorderQueue orders backorders for allocation: highest order priority
+// first, then oldest order date, then oldest backorder
+func SortBackorderQueue(queue []*Backorder) {
+	sort.SliceStable(queue, func(i, j int) bool {
+		a, b := queue[i], queue[j]
+		if ra, rb := PriorityRank(a.Priority), PriorityRank(b.Priority); ra != rb {
+			return ra < rb
+		}
+		if !a.OrderDate.Equal(b.OrderDate) {
+			return a.OrderDate.Before(b.OrderDate)
+		}
+		return a.CreatedAt.Before(b.CreatedAt)
+	})
+}
@@ -0,0 +1,101 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackorderAllocate(t *testing.T) {
+	order := generateTestOrder(t)
+	item := generateTestOrderItem(t, order.ID)
+
+	_, err := NewBackorder(order, item, 0)
+	require.Error(t, err)
+
+	backorder, err := NewBackorder(order, item, 5)
+	require.NoError(t, err)
+	assert.Equal(t, BackorderStatusOpen, backorder.Status)
+	assert.Equal(t, item.ProductSKU, backorder.ProductSKU)
+
+	require.NoError(t, backorder.Allocate(2))
+	assert.Equal(t, BackorderStatusPartiallyAllocated, backorder.Status)
+	assert.Equal(t, 3, backorder.Remaining())
+	assert.Nil(t, backorder.AllocatedAt)
+
+	err = backorder.Allocate(4)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only 3 outstanding")
+
+	require.NoError(t, backorder.Allocate(3))
+	assert.Equal(t, BackorderStatusAllocated, backorder.Status)
+	assert.NotNil(t, backorder.AllocatedAt)
+	assert.False(t, backorder.IsOpen())
+
+	require.Error(t, backorder.Allocate(1))
+	require.Error(t, backorder.Cancel())
+}
+
+func TestBackorderCancel(t *testing.T) {
+	order := generateTestOrder(t)
+	backorder, err := NewBackorder(order, generateTestOrderItem(t, order.ID), 2)
+	require.NoError(t, err)
+
+	require.NoError(t, backorder.Cancel())
+	assert.Equal(t, BackorderStatusCancelled, backorder.Status)
+	assert.NotNil(t, backorder.CancelledAt)
+	require.Error(t, backorder.Allocate(1))
+}
+
+func TestBackorderReduce(t *testing.T) {
+	order := generateTestOrder(t)
+	item := generateTestOrderItem(t, order.ID)
+
+	backorder, err := NewBackorder(order, item, 5)
+	require.NoError(t, err)
+	require.NoError(t, backorder.Allocate(2))
+
+	require.Error(t, backorder.Reduce(4))
+	require.NoError(t, backorder.Reduce(1))
+	assert.Equal(t, 4, backorder.Quantity)
+	assert.Equal(t, BackorderStatusPartiallyAllocated, backorder.Status)
+
+	require.NoError(t, backorder.Reduce(2))
+	assert.Equal(t, BackorderStatusAllocated, backorder.Status, "stock already allocated is kept")
+	assert.NotNil(t, backorder.AllocatedAt)
+
+	untouched, err := NewBackorder(order, item, 3)
+	require.NoError(t, err)
+	require.NoError(t, untouched.Reduce(3))
+	assert.Equal(t, BackorderStatusCancelled, untouched.Status)
+	require.Error(t, untouched.Reduce(1))
+}
+
+func TestSortBackorderQueue(t *testing.T) {
+	base := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	newest := &Backorder{ProductSKU: "normal-new", Priority: OrderPriorityNormal, OrderDate: base.Add(48 * time.Hour)}
+	oldest := &Backorder{ProductSKU: "normal-old", Priority: OrderPriorityNormal, OrderDate: base}
+	urgent := &Backorder{ProductSKU: "urgent", Priority: OrderPriorityUrgent, OrderDate: base.Add(72 * time.Hour)}
+	low := &Backorder{ProductSKU: "low", Priority: OrderPriorityLow, OrderDate: base.Add(-24 * time.Hour)}
+	critical := &Backorder{ProductSKU: "critical", Priority: OrderPriorityCritical, OrderDate: base.Add(96 * time.Hour)}
+
+	queue := []*Backorder{newest, low, oldest, urgent, critical}
+	SortBackorderQueue(queue)
+
+	order := make([]string, len(queue))
+	for i, backorder := range queue {
+		order[i] = backorder.ProductSKU
+	}
+	assert.Equal(t, []string{"critical", "urgent", "normal-old", "normal-new", "low"}, order)
+}
+
+func TestOrderItemShippableQuantity(t *testing.T) {
+	item := generateTestOrderItem(t, generateTestOrder(t).ID)
+	item.Quantity = 10
+	item.QuantityShipped = 3
+	item.QuantityBackordered = 4
+
+	assert.Equal(t, 3, item.ShippableQuantity())
+}
@@ -0,0 +1,182 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DocumentType identifies a numbered business document
+type DocumentType string
+
+const (
+	DocumentTypeSalesOrder    DocumentType = "SALES_ORDER"
+	DocumentTypePurchaseOrder DocumentType = "PURCHASE_ORDER"
+	DocumentTypeReturn        DocumentType = "RETURN"
+	DocumentTypeExchange      DocumentType = "EXCHANGE"
+	DocumentTypeTransfer      DocumentType = "TRANSFER"
+	DocumentTypeAdjustment    DocumentType = "ADJUSTMENT"
+	DocumentTypeGoodsReceipt  DocumentType = "GOODS_RECEIPT"
+)
+
+// SequenceResetPeriod defines when a document sequence restarts at 1
+type SequenceResetPeriod string
+
+const (
+	SequenceResetNever   SequenceResetPeriod = "NEVER"
+	SequenceResetYearly  SequenceResetPeriod = "YEARLY"
+	SequenceResetMonthly SequenceResetPeriod = "MONTHLY"
+	SequenceResetDaily   SequenceResetPeriod = "DAILY"
+)
+
+// DefaultDocumentPattern is used when a sequence has no explicit pattern
+const DefaultDocumentPattern = "{PREFIX}-{YYYY}-{SEQ}"
+
+// Supported pattern tokens
+const (
+	tokenPrefix   = "{PREFIX}"
+	tokenYear     = "{YYYY}"
+	tokenShortYr  = "{YY}"
+	tokenMonth    = "{MM}"
+	tokenDay      = "{DD}"
+	tokenSequence = "{SEQ}"
+)
+
+var documentTypeRegex = regexp.MustCompile(`^[A-Z][A-Z0-9_]{0,49}$`)
+
+// DocumentSequence configures gapless numbering for a document type
+type DocumentSequence struct {
+	ID           uuid.UUID           `json:"id"`
+	DocumentType DocumentType        `json:"document_type"`
+	Prefix       string              `json:"prefix"`
+	Pattern      string              `json:"pattern"`
+	Padding      int                 `json:"padding"`
+	ResetPeriod  SequenceResetPeriod `json:"reset_period"`
+	IsActive     bool                `json:"is_active"`
+	CreatedAt    time.Time           `json:"created_at"`
+	UpdatedAt    time.Time           `json:"updated_at"`
+}
+
+// Validate validates the document sequence configuration
+func (s *DocumentSequence) Validate() error {
+	var errs []error
+
+	if !documentTypeRegex.MatchString(string(s.DocumentType)) {
+		errs = append(errs, errors.New("document type must be uppercase letters, digits or underscores"))
+	}
+
+	prefix := strings.TrimSpace(s.Prefix)
+	if prefix == "" {
+		errs = append(errs, errors.New("prefix cannot be empty"))
+	} else if len(prefix) > 20 {
+		errs = append(errs, errors.New("prefix cannot exceed 20 characters"))
+	}
+
+	if s.Padding < 1 || s.Padding > 18 {
+		errs = append(errs, errors.New("padding must be between 1 and 18"))
+	}
+
+	pattern := s.pattern()
+	if len(pattern) > 100 {
+		errs = append(errs, errors.New("pattern cannot exceed 100 characters"))
+	}
+	if !strings.Contains(pattern, tokenSequence) {
+		errs = append(errs, errors.New("pattern must contain {SEQ}"))
+	}
+
+	// A sequence that resets per period must encode the period in the number,
+	// otherwise numbers would repeat across periods.
+	hasYear := strings.Contains(pattern, tokenYear) || strings.Contains(pattern, tokenShortYr)
+	switch s.ResetPeriod {
+	case SequenceResetNever:
+	case SequenceResetYearly:
+		if !hasYear {
+			errs = append(errs, errors.New("yearly sequences must include {YYYY} or {YY} in the pattern"))
+		}
+	case SequenceResetMonthly:
+		if !hasYear || !strings.Contains(pattern, tokenMonth) {
+			errs = append(errs, errors.New("monthly sequences must include the year and {MM} in the pattern"))
+		}
+	case SequenceResetDaily:
+		if !hasYear || !strings.Contains(pattern, tokenMonth) || !strings.Contains(pattern, tokenDay) {
+			errs = append(errs, errors.New("daily sequences must include the year, {MM} and {DD} in the pattern"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("invalid reset period: %s", s.ResetPeriod))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("validation failed: %v", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// PeriodKey returns the counter bucket for the given time under the reset period
+func (s *DocumentSequence) PeriodKey(at time.Time) string {
+	at = at.UTC()
+	switch s.ResetPeriod {
+	case SequenceResetYearly:
+		return at.Format("2006")
+	case SequenceResetMonthly:
+		return at.Format("200601")
+	case SequenceResetDaily:
+		return at.Format("20060102")
+	default:
+		return "ALL"
+	}
+}
+
+// Format renders a document number for the given sequence value
+func (s *DocumentSequence) Format(value int64, at time.Time) string {
+	at = at.UTC()
+	padding := s.Padding
+	if padding < 1 {
+		padding = 1
+	}
+
+	seq := strconv.FormatInt(value, 10)
+	if len(seq) < padding {
+		seq = strings.Repeat("0", padding-len(seq)) + seq
+	}
+
+	replacer := strings.NewReplacer(
+		tokenPrefix, strings.TrimSpace(s.Prefix),
+		tokenYear, at.Format("2006"),
+		tokenShortYr, at.Format("06"),
+		tokenMonth, at.Format("01"),
+		tokenDay, at.Format("02"),
+		tokenSequence, seq,
+	)
+	return replacer.Replace(s.pattern())
+}
+
+func (s *DocumentSequence) pattern() string {
+	if strings.TrimSpace(s.Pattern) == "" {
+		return DefaultDocumentPattern
+	}
+	return s.Pattern
+}
+
+// DocumentTypeForOrder maps an order type to the sequence that numbers it
+func DocumentTypeForOrder(orderType OrderType) DocumentType {
+	switch orderType {
+	case OrderTypePurchase:
+		return DocumentTypePurchaseOrder
+	case OrderTypeReturn:
+		return DocumentTypeReturn
+	case OrderTypeExchange:
+		return DocumentTypeExchange
+	case OrderTypeTransfer:
+		return DocumentTypeTransfer
+	case OrderTypeAdjustment:
+		return DocumentTypeAdjustment
+	default:
+		return DocumentTypeSalesOrder
+	}
+}
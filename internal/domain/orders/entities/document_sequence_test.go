@@ -0,0 +1,123 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentSequence_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		sequence    DocumentSequence
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name: "valid yearly sequence",
+			sequence: DocumentSequence{
+				DocumentType: DocumentTypeSalesOrder,
+				Prefix:       "SO",
+				Pattern:      DefaultDocumentPattern,
+				Padding:      6,
+				ResetPeriod:  SequenceResetYearly,
+			},
+		},
+		{
+			name: "valid never resetting sequence without year",
+			sequence: DocumentSequence{
+				DocumentType: DocumentTypeTransfer,
+				Prefix:       "TO",
+				Pattern:      "{PREFIX}{SEQ}",
+				Padding:      8,
+				ResetPeriod:  SequenceResetNever,
+			},
+		},
+		{
+			name: "pattern without sequence token",
+			sequence: DocumentSequence{
+				DocumentType: DocumentTypeSalesOrder,
+				Prefix:       "SO",
+				Pattern:      "{PREFIX}-{YYYY}",
+				Padding:      6,
+				ResetPeriod:  SequenceResetYearly,
+			},
+			expectError: true,
+			errorMsg:    "pattern must contain {SEQ}",
+		},
+		{
+			name: "monthly reset without month token",
+			sequence: DocumentSequence{
+				DocumentType: DocumentTypePurchaseOrder,
+				Prefix:       "PO",
+				Pattern:      DefaultDocumentPattern,
+				Padding:      6,
+				ResetPeriod:  SequenceResetMonthly,
+			},
+			expectError: true,
+			errorMsg:    "monthly sequences must include the year and {MM} in the pattern",
+		},
+		{
+			name: "invalid padding and prefix",
+			sequence: DocumentSequence{
+				DocumentType: DocumentTypeReturn,
+				Padding:      0,
+				ResetPeriod:  SequenceResetYearly,
+			},
+			expectError: true,
+			errorMsg:    "padding must be between 1 and 18",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.sequence.Validate()
+			if tt.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDocumentSequence_Format(t *testing.T) {
+	at := time.Date(2026, time.March, 7, 10, 0, 0, 0, time.UTC)
+
+	sequence := &DocumentSequence{Prefix: "SO", Padding: 6, ResetPeriod: SequenceResetYearly}
+	assert.Equal(t, "SO-2026-000123", sequence.Format(123, at))
+	assert.Equal(t, "SO-2026-1234567", sequence.Format(1234567, at))
+
+	sequence = &DocumentSequence{Prefix: "PO", Pattern: "{PREFIX}/{YY}/{MM}/{DD}/{SEQ}", Padding: 4, ResetPeriod: SequenceResetDaily}
+	assert.Equal(t, "PO/26/03/07/0045", sequence.Format(45, at))
+}
+
+func TestDocumentSequence_PeriodKey(t *testing.T) {
+	at := time.Date(2026, time.December, 31, 23, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, "ALL", (&DocumentSequence{ResetPeriod: SequenceResetNever}).PeriodKey(at))
+	assert.Equal(t, "2026", (&DocumentSequence{ResetPeriod: SequenceResetYearly}).PeriodKey(at))
+	assert.Equal(t, "202612", (&DocumentSequence{ResetPeriod: SequenceResetMonthly}).PeriodKey(at))
+	assert.Equal(t, "20261231", (&DocumentSequence{ResetPeriod: SequenceResetDaily}).PeriodKey(at))
+}
+
+func TestDocumentTypeForOrder(t *testing.T) {
+	assert.Equal(t, DocumentTypeSalesOrder, DocumentTypeForOrder(OrderTypeSales))
+	assert.Equal(t, DocumentTypePurchaseOrder, DocumentTypeForOrder(OrderTypePurchase))
+	assert.Equal(t, DocumentTypeReturn, DocumentTypeForOrder(OrderTypeReturn))
+	assert.Equal(t, DocumentTypeSalesOrder, DocumentTypeForOrder(""))
+}
+
+func TestOrder_ValidateUnnumbered(t *testing.T) {
+	order := generateTestOrder(t)
+	order.OrderNumber = ""
+
+	assert.NoError(t, order.ValidateUnnumbered())
+	assert.Error(t, order.Validate())
+
+	order.OrderNumber = "SO-2026-000001"
+	assert.NoError(t, order.Validate())
+}
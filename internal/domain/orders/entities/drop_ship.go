@@ -0,0 +1,272 @@
+package entities
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DropShipConfirmationField is a column of a supplier's drop-ship shipment
+// confirmation file
+type DropShipConfirmationField string
+
+const (
+	DropShipConfirmationFieldPONumber       DropShipConfirmationField = "po_number"
+	DropShipConfirmationFieldSKU            DropShipConfirmationField = "sku"
+	DropShipConfirmationFieldQuantity       DropShipConfirmationField = "quantity"
+	DropShipConfirmationFieldTrackingNumber DropShipConfirmationField = "tracking_number"
+	DropShipConfirmationFieldCarrier        DropShipConfirmationField = "carrier"
+	DropShipConfirmationFieldShippedAt      DropShipConfirmationField = "shipped_at"
+)
+
+// dropShipConfirmationRequired lists the columns a confirmation file must have
+var dropShipConfirmationRequired = []DropShipConfirmationField{
+	DropShipConfirmationFieldPONumber,
+	DropShipConfirmationFieldSKU,
+	DropShipConfirmationFieldQuantity,
+}
+
+// DropShipConfirmationRow is one shipped line of a supplier's shipment
+// confirmation file
+type DropShipConfirmationRow struct {
+	// Number is the line of a CSV file, counting the header as line 1, or
+	// the position of the record in a JSON file
+	Number         int        `json:"number"`
+	PONumber       string     `json:"po_number"`
+	SKU            string     `json:"sku"`
+	Quantity       int        `json:"quantity"`
+	TrackingNumber string     `json:"tracking_number,omitempty"`
+	Carrier        string     `json:"carrier,omitempty"`
+	ShippedAt      *time.Time `json:"shipped_at,omitempty"`
+}
+
+// DropShipConfirmationError is a problem with a row of a confirmation file
+type DropShipConfirmationError struct {
+	Row      int                       `json:"row"`
+	PONumber string                    `json:"po_number,omitempty"`
+	Field    DropShipConfirmationField `json:"field,omitempty"`
+	Message  string                    `json:"message"`
+}
+
+// DropShipConfirmationGroup is the rows of one supplier shipment: the rows
+// of a purchase order sharing a tracking number, in file order
+type DropShipConfirmationGroup struct {
+	PONumber       string                    `json:"po_number"`
+	TrackingNumber string                    `json:"tracking_number,omitempty"`
+	Carrier        string                    `json:"carrier,omitempty"`
+	ShippedAt      *time.Time                `json:"shipped_at,omitempty"`
+	Rows           []DropShipConfirmationRow `json:"rows"`
+}
+
+// Error returns a confirmation error for a field of the row
+func (r DropShipConfirmationRow) Error(field DropShipConfirmationField, format string, args ...interface{}) DropShipConfirmationError {
+	return DropShipConfirmationError{
+		Row:      r.Number,
+		PONumber: r.PONumber,
+		Field:    field,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+// ParseDropShipConfirmations reads the rows of a supplier's shipment
+// confirmation file: a CSV file with a header row or a JSON array of
+// records, one per shipped line, keyed by the DropShipConfirmationField
+// names. Malformed files fail as a whole; rows with missing or invalid
+// values are returned as errors.
+func ParseDropShipConfirmations(r io.Reader, format OrderImportFormat) ([]DropShipConfirmationRow, []DropShipConfirmationError, error) {
+	var records []dropShipRecord
+	var err error
+	switch format {
+	case OrderImportFormatCSV:
+		records, err = readDropShipCSV(r)
+	case OrderImportFormatJSON:
+		records, err = readDropShipJSON(r)
+	default:
+		return nil, nil, fmt.Errorf("invalid format: %s", format)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var rows []DropShipConfirmationRow
+	var errs []DropShipConfirmationError
+	for _, record := range records {
+		row, rowErrs := record.row()
+		if len(rowErrs) > 0 {
+			errs = append(errs, rowErrs...)
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, errs, nil
+}
+
+// GroupDropShipConfirmations groups confirmation rows into supplier
+// shipments by PO number and tracking number, in order of first appearance.
+// A shipment takes its carrier and shipping date from its first row
+// carrying them.
+func GroupDropShipConfirmations(rows []DropShipConfirmationRow) []DropShipConfirmationGroup {
+	var groups []DropShipConfirmationGroup
+	positions := make(map[[2]string]int)
+
+	for _, row := range rows {
+		key := [2]string{row.PONumber, row.TrackingNumber}
+		i, exists := positions[key]
+		if !exists {
+			i = len(groups)
+			positions[key] = i
+			groups = append(groups, DropShipConfirmationGroup{PONumber: row.PONumber, TrackingNumber: row.TrackingNumber})
+		}
+
+		group := &groups[i]
+		if group.Carrier == "" {
+			group.Carrier = row.Carrier
+		}
+		if group.ShippedAt == nil {
+			group.ShippedAt = row.ShippedAt
+		}
+		group.Rows = append(group.Rows, row)
+	}
+
+	return groups
+}
+
+// dropShipRecord is the raw values of a confirmation row
+type dropShipRecord struct {
+	number int
+	values map[DropShipConfirmationField]string
+}
+
+func (r dropShipRecord) row() (DropShipConfirmationRow, []DropShipConfirmationError) {
+	row := DropShipConfirmationRow{
+		Number:         r.number,
+		PONumber:       r.values[DropShipConfirmationFieldPONumber],
+		SKU:            r.values[DropShipConfirmationFieldSKU],
+		TrackingNumber: r.values[DropShipConfirmationFieldTrackingNumber],
+		Carrier:        r.values[DropShipConfirmationFieldCarrier],
+	}
+
+	var errs []DropShipConfirmationError
+	if row.PONumber == "" {
+		errs = append(errs, row.Error(DropShipConfirmationFieldPONumber, "PO number is required"))
+	}
+	if row.SKU == "" {
+		errs = append(errs, row.Error(DropShipConfirmationFieldSKU, "SKU is required"))
+	}
+
+	quantity, err := strconv.Atoi(r.values[DropShipConfirmationFieldQuantity])
+	if err != nil || quantity <= 0 {
+		errs = append(errs, row.Error(DropShipConfirmationFieldQuantity, "quantity must be a positive whole number"))
+	}
+	row.Quantity = quantity
+
+	if value := r.values[DropShipConfirmationFieldShippedAt]; value != "" {
+		shippedAt, err := parseConfirmationDate(value)
+		if err != nil {
+			errs = append(errs, row.Error(DropShipConfirmationFieldShippedAt, "shipped at must be a date or an RFC 3339 timestamp"))
+		} else {
+			row.ShippedAt = &shippedAt
+		}
+	}
+
+	return row, errs
+}
+
+func readDropShipCSV(r io.Reader) ([]dropShipRecord, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	// Columns are matched ignoring case and surrounding space
+	positions := make(map[DropShipConfirmationField]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		field := DropShipConfirmationField(strings.ToLower(strings.TrimSpace(name)))
+		if _, exists := positions[field]; !exists {
+			positions[field] = i
+		}
+	}
+	for _, field := range dropShipConfirmationRequired {
+		if _, exists := positions[field]; !exists {
+			return nil, fmt.Errorf("column %q is missing from the header", field)
+		}
+	}
+
+	var records []dropShipRecord
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read row: %w", err)
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+		values := make(map[DropShipConfirmationField]string, len(positions))
+		for field, i := range positions {
+			if i < len(record) {
+				values[field] = strings.TrimSpace(record[i])
+			}
+		}
+		records = append(records, dropShipRecord{number: line, values: values})
+	}
+
+	return records, nil
+}
+
+func readDropShipJSON(r io.Reader) ([]dropShipRecord, error) {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+
+	var objects []map[string]interface{}
+	if err := decoder.Decode(&objects); err != nil {
+		if err == io.EOF {
+			return nil, errors.New("file is empty")
+		}
+		return nil, fmt.Errorf("file must be a JSON array of objects: %w", err)
+	}
+
+	records := make([]dropShipRecord, 0, len(objects))
+	for i, object := range objects {
+		raw, err := jsonValues(object)
+		if err != nil {
+			return nil, fmt.Errorf("record %d: %w", i+1, err)
+		}
+
+		values := make(map[DropShipConfirmationField]string, len(raw))
+		for key, value := range raw {
+			values[DropShipConfirmationField(strings.ToLower(key))] = strings.TrimSpace(value)
+		}
+		records = append(records, dropShipRecord{number: i + 1, values: values})
+	}
+
+	return records, nil
+}
+
+// parseConfirmationDate parses a date or an RFC 3339 timestamp
+func parseConfirmationDate(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
@@ -0,0 +1,100 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDropShipConfirmations_CSV(t *testing.T) {
+	file := "\ufeffPO_Number, SKU ,Quantity,Tracking_Number,Carrier,Shipped_At\n" +
+		"PO-2026-000001,SKU-1,2,1Z999,UPS,2026-10-14\n" +
+		",,,,,\n" +
+		"PO-2026-000001,SKU-2,1,1Z999,,2026-10-14T09:30:00Z\n" +
+		"PO-2026-000002,,0,,,yesterday\n"
+
+	rows, errs, err := ParseDropShipConfirmations(strings.NewReader(file), OrderImportFormatCSV)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	assert.Equal(t, 2, rows[0].Number)
+	assert.Equal(t, "PO-2026-000001", rows[0].PONumber)
+	assert.Equal(t, "SKU-1", rows[0].SKU)
+	assert.Equal(t, 2, rows[0].Quantity)
+	assert.Equal(t, "1Z999", rows[0].TrackingNumber)
+	assert.Equal(t, "UPS", rows[0].Carrier)
+	require.NotNil(t, rows[0].ShippedAt)
+	assert.Equal(t, time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC), *rows[0].ShippedAt)
+
+	// Blank lines are skipped but still counted
+	assert.Equal(t, 4, rows[1].Number)
+	assert.Empty(t, rows[1].Carrier)
+
+	require.Len(t, errs, 3)
+	for _, e := range errs {
+		assert.Equal(t, 5, e.Row)
+		assert.Equal(t, "PO-2026-000002", e.PONumber)
+	}
+	assert.Equal(t, DropShipConfirmationFieldSKU, errs[0].Field)
+	assert.Equal(t, DropShipConfirmationFieldQuantity, errs[1].Field)
+	assert.Equal(t, DropShipConfirmationFieldShippedAt, errs[2].Field)
+
+	_, _, err = ParseDropShipConfirmations(strings.NewReader("po_number,sku\nPO-1,SKU-1\n"), OrderImportFormatCSV)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `column "quantity" is missing from the header`)
+
+	_, _, err = ParseDropShipConfirmations(strings.NewReader(""), OrderImportFormatCSV)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "file is empty")
+}
+
+func TestParseDropShipConfirmations_JSON(t *testing.T) {
+	file := `[
+		{"po_number": "PO-2026-000001", "sku": "SKU-1", "quantity": 3, "tracking_number": "1Z999"},
+		{"po_number": "PO-2026-000001", "sku": "SKU-2", "quantity": "two"}
+	]`
+
+	rows, errs, err := ParseDropShipConfirmations(strings.NewReader(file), OrderImportFormatJSON)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, 1, rows[0].Number)
+	assert.Equal(t, 3, rows[0].Quantity)
+	assert.Equal(t, "1Z999", rows[0].TrackingNumber)
+	assert.Nil(t, rows[0].ShippedAt)
+
+	require.Len(t, errs, 1)
+	assert.Equal(t, 2, errs[0].Row)
+	assert.Equal(t, DropShipConfirmationFieldQuantity, errs[0].Field)
+
+	_, _, err = ParseDropShipConfirmations(strings.NewReader(`{"po_number": "PO-1"}`), OrderImportFormatJSON)
+	assert.ErrorContains(t, err, "file must be a JSON array of objects")
+
+	_, _, err = ParseDropShipConfirmations(strings.NewReader(file), OrderImportFormat("XML"))
+	assert.ErrorContains(t, err, "invalid format")
+}
+
+func TestGroupDropShipConfirmations(t *testing.T) {
+	shippedAt := time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+	rows := []DropShipConfirmationRow{
+		{Number: 2, PONumber: "PO-1", SKU: "SKU-1", Quantity: 1, TrackingNumber: "T1"},
+		{Number: 3, PONumber: "PO-2", SKU: "SKU-3", Quantity: 1},
+		{Number: 4, PONumber: "PO-1", SKU: "SKU-2", Quantity: 2, TrackingNumber: "T1", Carrier: "UPS", ShippedAt: &shippedAt},
+		{Number: 5, PONumber: "PO-1", SKU: "SKU-2", Quantity: 1, TrackingNumber: "T2"},
+	}
+
+	groups := GroupDropShipConfirmations(rows)
+	require.Len(t, groups, 3)
+
+	assert.Equal(t, "PO-1", groups[0].PONumber)
+	assert.Equal(t, "T1", groups[0].TrackingNumber)
+	assert.Equal(t, "UPS", groups[0].Carrier, "carrier comes from the first row carrying one")
+	assert.Equal(t, &shippedAt, groups[0].ShippedAt)
+	require.Len(t, groups[0].Rows, 2)
+	assert.Equal(t, 4, groups[0].Rows[1].Number)
+
+	assert.Equal(t, "PO-2", groups[1].PONumber)
+	assert.Equal(t, "T2", groups[2].TrackingNumber)
+}
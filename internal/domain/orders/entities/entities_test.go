@@ -67,7 +67,7 @@ func generateTestOrderItem(t *testing.T, orderID uuid.UUID) *OrderItem {
 		ProductName:      "Test Product",
 		Quantity:         2,
 		UnitPrice:        decimal.NewFromFloat(29.99),
-		DiscountAmount:   decimal.NewFromFloat(5.00),
+		DiscountAmount:   decimal.NewFromFloat(2.50),
 		TaxRate:          decimal.NewFromFloat(8.25),
 		TaxAmount:        decimal.NewFromFloat(4.12),
 		TotalPrice:       decimal.NewFromFloat(59.10),
@@ -224,6 +224,16 @@ func TestIsTerminalStatus(t *testing.T) {
 	}
 }
 
+func TestRequiresApproval(t *testing.T) {
+	for _, status := range []OrderStatus{OrderStatusConfirmed, OrderStatusProcessing, OrderStatusPartiallyShipped, OrderStatusShipped, OrderStatusDelivered, OrderStatusReturned} {
+		assert.True(t, RequiresApproval(status), status)
+	}
+	// Unapproved orders can be held, cancelled and have their prepayments refunded
+	for _, status := range []OrderStatus{OrderStatusDraft, OrderStatusPending, OrderStatusOnHold, OrderStatusCancelled, OrderStatusRefunded} {
+		assert.False(t, RequiresApproval(status), status)
+	}
+}
+
 func TestGetTerminalStatuses(t *testing.T) {
 	terminalStatuses := GetTerminalStatuses()
 	expected := []OrderStatus{OrderStatusDelivered, OrderStatusCancelled, OrderStatusRefunded}
@@ -281,7 +291,7 @@ func TestOrder_Validate(t *testing.T) {
 				UpdatedAt:         time.Now(),
 			},
 			expectError: true,
-			errorMsg:    "order number must be in format YYYY-NNNNNN",
+			errorMsg:    "order number must be uppercase alphanumeric segments separated by '-' or '/' and contain a sequence number",
 		},
 		{
 			name: "missing customer ID",
@@ -492,6 +502,18 @@ func TestOrder_BusinessLogic(t *testing.T) {
 		assert.True(t, decimal.NewFromFloat(70.00).Equal(balance), "Outstanding balance mismatch")
 	})
 
+	t.Run("credit exposure", func(t *testing.T) {
+		order := generateTestOrder(t)
+		order.TotalAmount = decimal.NewFromFloat(100.00)
+		order.PaidAmount = decimal.NewFromFloat(30.00)
+		order.ExchangeRate = decimal.RequireFromString("1.25")
+
+		assert.True(t, decimal.NewFromFloat(87.50).Equal(order.CreditExposure()), "Credit exposure should be in the base currency")
+
+		order.PaidAmount = order.TotalAmount
+		assert.True(t, order.CreditExposure().IsZero(), "Paid orders take no credit")
+	})
+
 	t.Run("update tracking", func(t *testing.T) {
 		order := generateTestOrder(t)
 		err := order.UpdateTracking("1Z999AA10123456784", "FedEx")
@@ -635,6 +657,21 @@ func TestOrderItem_ShippingAndReturns(t *testing.T) {
 	})
 }
 
+func TestOrderItem_RefundItem(t *testing.T) {
+	item := generateTestOrderItem(t, uuid.New())
+	item.Quantity = 5
+
+	require.NoError(t, item.RefundItem(3))
+	assert.Equal(t, 3, item.QuantityRefunded)
+
+	err := item.RefundItem(3)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot refund 3 items, only 2 not refunded yet")
+	assert.Equal(t, 3, item.QuantityRefunded)
+
+	require.Error(t, item.RefundItem(0))
+}
+
 func TestOrderItem_CalculateTotals(t *testing.T) {
 	item := generateTestOrderItem(t, uuid.New())
 	item.Quantity = 3
@@ -653,6 +690,26 @@ func TestOrderItem_CalculateTotals(t *testing.T) {
 	assert.True(t, expectedTotal.Equal(item.TotalPrice), "TotalPrice mismatch: expected %s, got %s", expectedTotal, item.TotalPrice)
 }
 
+func TestOrderItem_DiscountPerUnit(t *testing.T) {
+	item := generateTestOrderItem(t, uuid.New())
+	item.Quantity = 2
+	item.UnitPrice = decimal.NewFromFloat(10.00)
+	item.DiscountAmount = decimal.NewFromFloat(1.00)
+	item.TaxRate = decimal.Zero
+
+	item.CalculateTotals()
+
+	assert.True(t, decimal.NewFromFloat(2.00).Equal(item.LineDiscount()), "LineDiscount mismatch: got %s", item.LineDiscount())
+	assert.True(t, decimal.NewFromFloat(18.00).Equal(item.LineAmount()), "LineAmount mismatch: got %s", item.LineAmount())
+	assert.True(t, item.LineAmount().Equal(item.TotalPrice), "TotalPrice mismatch: got %s", item.TotalPrice)
+	assert.NoError(t, item.Validate())
+
+	calculation, err := CalculateOrderTotals(&Order{Items: []OrderItem{*item}}, decimal.Zero, decimal.Zero)
+	require.NoError(t, err)
+	assert.True(t, decimal.NewFromFloat(2.00).Equal(calculation.DiscountAmount), "DiscountAmount mismatch: got %s", calculation.DiscountAmount)
+	assert.True(t, item.TotalPrice.Equal(calculation.TotalAmount), "TotalAmount mismatch: got %s", calculation.TotalAmount)
+}
+
 // ==================== CUSTOMER ENTITY TESTS ====================
 
 func TestCustomer_Validate(t *testing.T) {
@@ -1054,7 +1111,7 @@ func TestCalculateOrderTotals(t *testing.T) {
 		{
 			Quantity:       2,
 			UnitPrice:      decimal.NewFromFloat(25.00),
-			DiscountAmount: decimal.NewFromFloat(5.00), // Total discount for this line item
+			DiscountAmount: decimal.NewFromFloat(2.50), // Discount on each unit, 5.00 for the line
 			TaxRate:        decimal.NewFromFloat(8.00),
 			ProductName:    "Test Product 1",
 		},
@@ -1102,6 +1159,16 @@ func TestValidateOrder(t *testing.T) {
 		assert.Empty(t, validation.Errors)
 	})
 
+	t.Run("order not yet numbered", func(t *testing.T) {
+		order := generateTestOrder(t)
+		order.OrderNumber = ""
+		order.Items = []OrderItem{*generateTestOrderItem(t, order.ID)}
+
+		validation := ValidateOrder(order)
+		assert.True(t, validation.IsValid)
+		assert.Empty(t, validation.Errors)
+	})
+
 	t.Run("order with no items", func(t *testing.T) {
 		order := generateTestOrder(t)
 		order.Items = []OrderItem{}
@@ -1125,7 +1192,7 @@ func TestValidateOrder(t *testing.T) {
 
 	t.Run("order with warnings", func(t *testing.T) {
 		order := generateTestOrder(t)
-		// Create an item with high value to trigger the $10k warning
+		// Create a high value item
 		item := generateTestOrderItem(t, order.ID)
 		item.Quantity = 100
 		item.UnitPrice = decimal.NewFromFloat(150.00)
@@ -1149,18 +1216,14 @@ func TestValidateOrder(t *testing.T) {
 		}
 		assert.True(t, validation.IsValid)                    // Warnings don't make order invalid
 		assert.GreaterOrEqual(t, len(validation.Warnings), 1) // At least one warning
-		// Check for the warnings we expect
+		// Check for the warning we expect; required approvals come from the approval policies
 		hasRequiredDateWarning := false
-		hasTotalWarning := false
 		for _, w := range validation.Warnings {
 			if strings.Contains(w, "required date has passed") {
 				hasRequiredDateWarning = true
 			}
-			if strings.Contains(w, "exceeds $10,000") {
-				hasTotalWarning = true
-			}
 		}
-		assert.True(t, hasRequiredDateWarning || hasTotalWarning, "Expected at least one of the warnings")
+		assert.True(t, hasRequiredDateWarning, "Expected the required date warning")
 	})
 }
 
@@ -0,0 +1,270 @@
+package entities
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ExchangeRateSource records how an exchange rate was entered
+type ExchangeRateSource string
+
+const (
+	ExchangeRateSourceManual ExchangeRateSource = "MANUAL"
+	ExchangeRateSourceCSV    ExchangeRateSource = "CSV"
+	ExchangeRateSourceECB    ExchangeRateSource = "ECB"
+)
+
+// ExchangeRatePrecision is the number of decimal places rates are stored with
+const ExchangeRatePrecision = 8
+
+// ECBBaseCurrency is the currency the European Central Bank quotes its reference rates against
+const ECBBaseCurrency = "EUR"
+
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// ExchangeRate is the value of one unit of the from currency in the to
+// currency, effective from its date until a later rate of the same pair
+type ExchangeRate struct {
+	ID            uuid.UUID          `json:"id" db:"id"`
+	FromCurrency  string             `json:"from_currency" db:"from_currency"`
+	ToCurrency    string             `json:"to_currency" db:"to_currency"`
+	Rate          decimal.Decimal    `json:"rate" db:"rate"`
+	EffectiveDate time.Time          `json:"effective_date" db:"effective_date"`
+	Source        ExchangeRateSource `json:"source" db:"source"`
+	CreatedBy     *uuid.UUID         `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt     time.Time          `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at" db:"updated_at"`
+}
+
+// NormalizeCurrency upper-cases a currency code such as "eur" and checks it is
+// a 3-letter ISO 4217 code
+func NormalizeCurrency(currency string) (string, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(currency))
+	if !currencyPattern.MatchString(normalized) {
+		return "", fmt.Errorf("invalid currency: %s", currency)
+	}
+	return normalized, nil
+}
+
+// Validate validates the exchange rate
+func (r *ExchangeRate) Validate() error {
+	if r.ID == uuid.Nil {
+		return errors.New("exchange rate ID cannot be empty")
+	}
+	if !currencyPattern.MatchString(r.FromCurrency) || !currencyPattern.MatchString(r.ToCurrency) {
+		return errors.New("currencies must be valid 3-letter ISO 4217 codes")
+	}
+	if r.FromCurrency == r.ToCurrency {
+		return errors.New("from and to currency must differ")
+	}
+	if !r.Rate.IsPositive() {
+		return errors.New("exchange rate must be positive")
+	}
+	if r.EffectiveDate.IsZero() {
+		return errors.New("effective date is required")
+	}
+	switch r.Source {
+	case ExchangeRateSourceManual, ExchangeRateSourceCSV, ExchangeRateSourceECB:
+	default:
+		return fmt.Errorf("invalid exchange rate source: %s", r.Source)
+	}
+	return nil
+}
+
+// ResolveExchangeRate finds the rate converting one unit of from into to among
+// the effective rates. A rate may be used directly, inverted, or crossed
+// through a third currency, so rates quoted against the euro convert between
+// any two of the quoted currencies.
+func ResolveExchangeRate(from, to string, rates []*ExchangeRate) (decimal.Decimal, bool) {
+	if from == to {
+		return decimal.NewFromInt(1), true
+	}
+
+	// pairs maps each currency to the rate of one unit of it in each other
+	// currency, preferring the latest rate when a pair is given both ways
+	pairs := make(map[string]map[string]*ExchangeRate)
+	add := func(from, to string, rate *ExchangeRate) {
+		if pairs[from] == nil {
+			pairs[from] = make(map[string]*ExchangeRate)
+		}
+		if existing, ok := pairs[from][to]; ok && existing.EffectiveDate.After(rate.EffectiveDate) {
+			return
+		}
+		pairs[from][to] = rate
+	}
+	convert := func(from, to string) (decimal.Decimal, bool) {
+		rate, ok := pairs[from][to]
+		if !ok {
+			return decimal.Zero, false
+		}
+		if rate.FromCurrency == from {
+			return rate.Rate, true
+		}
+		return decimal.NewFromInt(1).DivRound(rate.Rate, 2*ExchangeRatePrecision), true
+	}
+
+	for _, rate := range rates {
+		if !rate.Rate.IsPositive() {
+			continue
+		}
+		add(rate.FromCurrency, rate.ToCurrency, rate)
+		add(rate.ToCurrency, rate.FromCurrency, rate)
+	}
+
+	if rate, ok := convert(from, to); ok {
+		return rate.Round(ExchangeRatePrecision), true
+	}
+
+	// Cross through the intermediate currencies in a fixed order so the
+	// result does not depend on map iteration
+	intermediates := make([]string, 0, len(pairs[from]))
+	for currency := range pairs[from] {
+		intermediates = append(intermediates, currency)
+	}
+	sort.Strings(intermediates)
+	for _, currency := range intermediates {
+		first, _ := convert(from, currency)
+		second, ok := convert(currency, to)
+		if ok {
+			return first.Mul(second).Round(ExchangeRatePrecision), true
+		}
+	}
+
+	return decimal.Zero, false
+}
+
+// ParseExchangeRatesCSV reads exchange rates from CSV with a header row naming
+// the from_currency, to_currency, rate and effective_date columns, in any
+// order. Dates are formatted YYYY-MM-DD.
+func ParseExchangeRatesCSV(r io.Reader) ([]*ExchangeRate, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("exchange rate file is empty")
+		}
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"from_currency", "to_currency", "rate", "effective_date"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing column: %s", name)
+		}
+	}
+
+	var rates []*ExchangeRate
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		rate, err := newImportedExchangeRate(
+			record[columns["from_currency"]],
+			record[columns["to_currency"]],
+			record[columns["rate"]],
+			record[columns["effective_date"]],
+			ExchangeRateSourceCSV,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		rates = append(rates, rate)
+	}
+
+	if len(rates) == 0 {
+		return nil, errors.New("exchange rate file has no rates")
+	}
+	return rates, nil
+}
+
+// ecbEnvelope is the eurofxref document of the European Central Bank, which
+// nests the rates of each day in Cube elements
+type ecbEnvelope struct {
+	Days []struct {
+		Time  string `xml:"time,attr"`
+		Rates []struct {
+			Currency string `xml:"currency,attr"`
+			Rate     string `xml:"rate,attr"`
+		} `xml:"Cube"`
+	} `xml:"Cube>Cube"`
+}
+
+// ParseECBExchangeRates reads the euro reference rates of a European Central
+// Bank eurofxref XML file, daily or historical
+func ParseECBExchangeRates(r io.Reader) ([]*ExchangeRate, error) {
+	var envelope ecbEnvelope
+	if err := xml.NewDecoder(r).Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("invalid ECB exchange rate file: %w", err)
+	}
+
+	var rates []*ExchangeRate
+	for _, day := range envelope.Days {
+		for _, quote := range day.Rates {
+			rate, err := newImportedExchangeRate(ECBBaseCurrency, quote.Currency, quote.Rate, day.Time, ExchangeRateSourceECB)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", day.Time, quote.Currency, err)
+			}
+			rates = append(rates, rate)
+		}
+	}
+
+	if len(rates) == 0 {
+		return nil, errors.New("exchange rate file has no rates")
+	}
+	return rates, nil
+}
+
+func newImportedExchangeRate(from, to, rate, effectiveDate string, source ExchangeRateSource) (*ExchangeRate, error) {
+	fromCurrency, err := NormalizeCurrency(from)
+	if err != nil {
+		return nil, err
+	}
+	toCurrency, err := NormalizeCurrency(to)
+	if err != nil {
+		return nil, err
+	}
+	value, err := decimal.NewFromString(strings.TrimSpace(rate))
+	if err != nil {
+		return nil, fmt.Errorf("invalid rate: %s", rate)
+	}
+	date, err := time.Parse("2006-01-02", strings.TrimSpace(effectiveDate))
+	if err != nil {
+		return nil, fmt.Errorf("invalid effective date: %s", effectiveDate)
+	}
+
+	now := time.Now().UTC()
+	exchangeRate := &ExchangeRate{
+		ID:            uuid.New(),
+		FromCurrency:  fromCurrency,
+		ToCurrency:    toCurrency,
+		Rate:          value.Round(ExchangeRatePrecision),
+		EffectiveDate: date,
+		Source:        source,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := exchangeRate.Validate(); err != nil {
+		return nil, err
+	}
+	return exchangeRate, nil
+}
@@ -0,0 +1,127 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestExchangeRate(from, to, rate string) *ExchangeRate {
+	return &ExchangeRate{
+		ID:            uuid.New(),
+		FromCurrency:  from,
+		ToCurrency:    to,
+		Rate:          decimal.RequireFromString(rate),
+		EffectiveDate: time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC),
+		Source:        ExchangeRateSourceManual,
+	}
+}
+
+func TestExchangeRateValidate(t *testing.T) {
+	require.NoError(t, newTestExchangeRate("EUR", "USD", "1.0956").Validate())
+
+	tests := []struct {
+		name   string
+		modify func(r *ExchangeRate)
+	}{
+		{"lower case currency", func(r *ExchangeRate) { r.FromCurrency = "eur" }},
+		{"same currency", func(r *ExchangeRate) { r.ToCurrency = "EUR" }},
+		{"zero rate", func(r *ExchangeRate) { r.Rate = decimal.Zero }},
+		{"missing date", func(r *ExchangeRate) { r.EffectiveDate = time.Time{} }},
+		{"unknown source", func(r *ExchangeRate) { r.Source = "FEED" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate := newTestExchangeRate("EUR", "USD", "1.0956")
+			tt.modify(rate)
+			assert.Error(t, rate.Validate())
+		})
+	}
+}
+
+func TestResolveExchangeRate(t *testing.T) {
+	rates := []*ExchangeRate{
+		newTestExchangeRate("EUR", "USD", "1.25"),
+		newTestExchangeRate("EUR", "GBP", "0.8"),
+	}
+
+	tests := []struct {
+		from, to string
+		expected string
+	}{
+		{"USD", "USD", "1"},
+		{"EUR", "USD", "1.25"},
+		{"USD", "EUR", "0.8"},
+		// Crossed through the euro: 1 GBP is 1.25 EUR is 1.5625 USD
+		{"GBP", "USD", "1.5625"},
+		{"USD", "GBP", "0.64"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from+"/"+tt.to, func(t *testing.T) {
+			rate, ok := ResolveExchangeRate(tt.from, tt.to, rates)
+			require.True(t, ok)
+			assert.True(t, rate.Equal(decimal.RequireFromString(tt.expected)), rate.String())
+		})
+	}
+
+	_, ok := ResolveExchangeRate("JPY", "USD", rates)
+	assert.False(t, ok)
+}
+
+func TestParseExchangeRatesCSV(t *testing.T) {
+	rates, err := ParseExchangeRatesCSV(strings.NewReader(
+		"effective_date,from_currency,to_currency,rate\n" +
+			"2026-10-15,gbp,USD,1.3021\n" +
+			"2026-10-16, EUR, USD, 1.0956\n"))
+	require.NoError(t, err)
+	require.Len(t, rates, 2)
+	assert.Equal(t, "GBP", rates[0].FromCurrency)
+	assert.Equal(t, ExchangeRateSourceCSV, rates[0].Source)
+	assert.Equal(t, time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), rates[1].EffectiveDate)
+	assert.True(t, rates[1].Rate.Equal(decimal.RequireFromString("1.0956")))
+
+	_, err = ParseExchangeRatesCSV(strings.NewReader("from_currency,to_currency,rate\nEUR,USD,1.1\n"))
+	assert.ErrorContains(t, err, "missing column: effective_date")
+
+	_, err = ParseExchangeRatesCSV(strings.NewReader("from_currency,to_currency,rate,effective_date\nEUR,USD,-1,2026-10-16\n"))
+	assert.ErrorContains(t, err, "line 2")
+
+	_, err = ParseExchangeRatesCSV(strings.NewReader(""))
+	assert.Error(t, err)
+}
+
+func TestParseECBExchangeRates(t *testing.T) {
+	document := `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<gesmes:Sender><gesmes:name>European Central Bank</gesmes:name></gesmes:Sender>
+	<Cube>
+		<Cube time="2026-10-16">
+			<Cube currency="USD" rate="1.0956"/>
+			<Cube currency="GBP" rate="0.8412"/>
+		</Cube>
+		<Cube time="2026-10-15">
+			<Cube currency="USD" rate="1.0921"/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+
+	rates, err := ParseECBExchangeRates(strings.NewReader(document))
+	require.NoError(t, err)
+	require.Len(t, rates, 3)
+	for _, rate := range rates {
+		assert.Equal(t, ECBBaseCurrency, rate.FromCurrency)
+		assert.Equal(t, ExchangeRateSourceECB, rate.Source)
+	}
+	assert.Equal(t, "GBP", rates[1].ToCurrency)
+	assert.True(t, rates[1].Rate.Equal(decimal.RequireFromString("0.8412")))
+	assert.Equal(t, time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC), rates[2].EffectiveDate)
+
+	_, err = ParseECBExchangeRates(strings.NewReader("<gesmes:Envelope></gesmes:Envelope>"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,373 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// InvoiceType distinguishes invoices from the credit notes that reverse them
+type InvoiceType string
+
+const (
+	InvoiceTypeInvoice    InvoiceType = "INVOICE"
+	InvoiceTypeCreditNote InvoiceType = "CREDIT_NOTE"
+)
+
+// InvoiceStatus represents the status of an invoice or credit note
+type InvoiceStatus string
+
+const (
+	InvoiceStatusDraft     InvoiceStatus = "DRAFT"
+	InvoiceStatusIssued    InvoiceStatus = "ISSUED"
+	InvoiceStatusCancelled InvoiceStatus = "CANCELLED"
+)
+
+// Document types numbering invoices and credit notes
+const (
+	DocumentTypeInvoice    DocumentType = "INVOICE"
+	DocumentTypeCreditNote DocumentType = "CREDIT_NOTE"
+)
+
+var paymentTermsRegex = regexp.MustCompile(`^NET(\d+)$`)
+
+// ParsePaymentTerms returns the number of days a customer has to pay under
+// terms such as NET30
+func ParsePaymentTerms(terms string) (int, error) {
+	match := paymentTermsRegex.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(terms)))
+	if match == nil {
+		return 0, fmt.Errorf("invalid payment terms: %s", terms)
+	}
+	days, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, fmt.Errorf("invalid payment terms: %s", terms)
+	}
+	return days, nil
+}
+
+// Invoice represents a bill for all or part of a sales order, or a credit note
+// reversing part of an issued invoice. Drafts carry no number; the number is
+// allocated when the document is issued and the document is locked from then on.
+type Invoice struct {
+	ID                uuid.UUID     `json:"id" db:"id"`
+	InvoiceNumber     string        `json:"invoice_number,omitempty" db:"invoice_number"`
+	Type              InvoiceType   `json:"type" db:"type"`
+	Status            InvoiceStatus `json:"status" db:"status"`
+	OrderID           uuid.UUID     `json:"order_id" db:"order_id"`
+	ShipmentID        *uuid.UUID    `json:"shipment_id,omitempty" db:"shipment_id"`
+	CreditedInvoiceID *uuid.UUID    `json:"credited_invoice_id,omitempty" db:"credited_invoice_id"`
+	CustomerID        uuid.UUID     `json:"customer_id" db:"customer_id"`
+	BillingAddressID  uuid.UUID     `json:"billing_address_id" db:"billing_address_id"`
+	Currency          string        `json:"currency" db:"currency"`
+	PaymentTerms      string        `json:"payment_terms" db:"payment_terms"`
+	IssueDate         *time.Time    `json:"issue_date,omitempty" db:"issue_date"`
+	DueDate           *time.Time    `json:"due_date,omitempty" db:"due_date"`
+
+	Subtotal       decimal.Decimal `json:"subtotal" db:"subtotal"`
+	TaxAmount      decimal.Decimal `json:"tax_amount" db:"tax_amount"`
+	ShippingAmount decimal.Decimal `json:"shipping_amount" db:"shipping_amount"`
+	DiscountAmount decimal.Decimal `json:"discount_amount" db:"discount_amount"`
+	TotalAmount    decimal.Decimal `json:"total_amount" db:"total_amount"`
+	TaxBreakdown   []TaxBreakdown  `json:"tax_breakdown" db:"tax_breakdown"`
+
+	Notes              *string `json:"notes,omitempty" db:"notes"`
+	Reason             *string `json:"reason,omitempty" db:"reason"`
+	CancellationReason *string `json:"cancellation_reason,omitempty" db:"cancellation_reason"`
+
+	Lines []InvoiceLine `json:"lines,omitempty" db:"-"`
+
+	CreatedBy   uuid.UUID  `json:"created_by" db:"created_by"`
+	IssuedBy    *uuid.UUID `json:"issued_by,omitempty" db:"issued_by"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+	CancelledAt *time.Time `json:"cancelled_at,omitempty" db:"cancelled_at"`
+}
+
+// InvoiceLine represents a billed quantity of an order line. DiscountAmount is
+// the discount on each unit, as on order items. Credit note lines without
+// an order item credit an amount rather than goods.
+type InvoiceLine struct {
+	ID             uuid.UUID       `json:"id" db:"id"`
+	InvoiceID      uuid.UUID       `json:"invoice_id" db:"invoice_id"`
+	OrderItemID    *uuid.UUID      `json:"order_item_id,omitempty" db:"order_item_id"`
+	ProductID      *uuid.UUID      `json:"product_id,omitempty" db:"product_id"`
+	ProductSKU     string          `json:"product_sku,omitempty" db:"product_sku"`
+	Description    string          `json:"description" db:"description"`
+	Quantity       int             `json:"quantity" db:"quantity"`
+	UnitPrice      decimal.Decimal `json:"unit_price" db:"unit_price"`
+	DiscountAmount decimal.Decimal `json:"discount_amount" db:"discount_amount"`
+	TaxRate        decimal.Decimal `json:"tax_rate" db:"tax_rate"`
+	TaxAmount      decimal.Decimal `json:"tax_amount" db:"tax_amount"`
+	TotalPrice     decimal.Decimal `json:"total_price" db:"total_price"`
+	// PriceIncludesTax marks UnitPrice as gross, as on the order line
+	PriceIncludesTax bool `json:"price_includes_tax" db:"price_includes_tax"`
+}
+
+// NewInvoiceLine bills a quantity of an order line at its unit discount
+func NewInvoiceLine(item *OrderItem, quantity int) InvoiceLine {
+	itemID, productID := item.ID, item.ProductID
+	line := InvoiceLine{
+		ID:             uuid.New(),
+		OrderItemID:    &itemID,
+		ProductID:      &productID,
+		ProductSKU:     item.ProductSKU,
+		Description:    item.ProductName,
+		Quantity:       quantity,
+		UnitPrice:      item.UnitPrice,
+		DiscountAmount: item.DiscountAmount,
+		TaxRate:        item.TaxRate,
+
+		PriceIncludesTax: item.PriceIncludesTax,
+	}
+	line.CalculateTotals()
+	return line
+}
+
+// NewCreditLine credits a gross amount, tax included, at the given tax rate
+func NewCreditLine(description string, quantity int, gross, taxRate decimal.Decimal) InvoiceLine {
+	if quantity < 1 {
+		quantity = 1
+	}
+	net := gross
+	if taxRate.IsPositive() {
+		net = gross.Div(decimal.NewFromInt(1).Add(taxRate.Div(decimal.NewFromInt(100)))).Round(2)
+	}
+
+	return InvoiceLine{
+		ID:          uuid.New(),
+		Description: description,
+		Quantity:    quantity,
+		UnitPrice:   net.Div(decimal.NewFromInt(int64(quantity))),
+		TaxRate:     taxRate,
+		TaxAmount:   gross.Sub(net),
+		TotalPrice:  gross,
+	}
+}
+
+// CalculateTotals prices the line, backing the tax out of tax-inclusive prices
+func (l *InvoiceLine) CalculateTotals() {
+	amount := l.UnitPrice.Sub(l.DiscountAmount).Mul(decimal.NewFromInt(int64(l.Quantity)))
+	if l.PriceIncludesTax {
+		net := amount.Div(decimal.NewFromInt(1).Add(l.TaxRate.Div(decimal.NewFromInt(100))))
+		l.TaxAmount = amount.Sub(net).Round(2)
+		l.TotalPrice = amount.Round(2)
+		return
+	}
+	l.TaxAmount = amount.Mul(l.TaxRate).Div(decimal.NewFromInt(100)).Round(2)
+	l.TotalPrice = amount.Add(l.TaxAmount).Round(2)
+}
+
+// Validate validates the invoice and its lines. Drafts have no number yet.
+func (i *Invoice) Validate() error {
+	var errs []error
+
+	if i.ID == uuid.Nil {
+		errs = append(errs, errors.New("invoice ID cannot be empty"))
+	}
+	if i.Type != InvoiceTypeInvoice && i.Type != InvoiceTypeCreditNote {
+		errs = append(errs, fmt.Errorf("invalid invoice type: %s", i.Type))
+	}
+	switch i.Status {
+	case InvoiceStatusDraft, InvoiceStatusCancelled:
+	case InvoiceStatusIssued:
+		if i.IssueDate == nil || i.DueDate == nil {
+			errs = append(errs, errors.New("issued invoices must have issue and due dates"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("invalid invoice status: %s", i.Status))
+	}
+	if i.OrderID == uuid.Nil || i.CustomerID == uuid.Nil {
+		errs = append(errs, errors.New("order and customer are required"))
+	}
+	if i.Type == InvoiceTypeCreditNote && i.CreditedInvoiceID == nil {
+		errs = append(errs, errors.New("credit notes must reference the credited invoice"))
+	}
+	if len(strings.TrimSpace(i.Currency)) != 3 {
+		errs = append(errs, errors.New("currency must be a valid 3-letter ISO 4217 code"))
+	}
+
+	if len(i.Lines) == 0 {
+		errs = append(errs, errors.New("invoice must have at least one line"))
+	}
+	for n, line := range i.Lines {
+		if line.Quantity <= 0 {
+			errs = append(errs, fmt.Errorf("line %d: quantity must be positive", n+1))
+		}
+		if line.UnitPrice.IsNegative() || line.TaxRate.IsNegative() {
+			errs = append(errs, fmt.Errorf("line %d: price and tax rate cannot be negative", n+1))
+		}
+	}
+
+	if i.TotalAmount.IsNegative() {
+		errs = append(errs, errors.New("total amount cannot be negative"))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("validation failed: %v", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// IsLocked reports whether the invoice can no longer change
+func (i *Invoice) IsLocked() bool {
+	return i.Status != InvoiceStatusDraft
+}
+
+// IsCreditNote reports whether the document is a credit note
+func (i *Invoice) IsCreditNote() bool {
+	return i.Type == InvoiceTypeCreditNote
+}
+
+// CalculateTotals prices the lines with the order calculation rules and
+// groups the tax breakdown by rate. As on orders, DiscountAmount ends up
+// holding the line discounts plus the given invoice-level discount.
+func (i *Invoice) CalculateTotals(invoiceDiscount decimal.Decimal) error {
+	items := make([]OrderItem, len(i.Lines))
+	for n := range i.Lines {
+		line := &i.Lines[n]
+		if !i.IsCreditNote() {
+			line.CalculateTotals()
+		}
+		items[n] = OrderItem{
+			ProductName:    line.Description,
+			Quantity:       line.Quantity,
+			UnitPrice:      line.UnitPrice,
+			DiscountAmount: line.DiscountAmount,
+			TaxRate:        line.TaxRate,
+
+			PriceIncludesTax: line.PriceIncludesTax,
+		}
+	}
+
+	calculation, err := CalculateOrderTotals(&Order{Items: items, DiscountAmount: invoiceDiscount}, decimal.Zero, i.ShippingAmount)
+	if err != nil {
+		return err
+	}
+
+	// Line taxes are rounded per line, and credit lines carry the tax backed
+	// out of their gross amount, so the invoice tax is the sum of the lines
+	tax := decimal.Zero
+	for _, line := range i.Lines {
+		tax = tax.Add(line.TaxAmount)
+	}
+
+	i.Subtotal = calculation.Subtotal.Round(2)
+	i.TaxAmount = tax.Round(2)
+	i.ShippingAmount = calculation.ShippingAmount.Round(2)
+	i.DiscountAmount = calculation.DiscountAmount.Round(2)
+	i.TotalAmount = i.Subtotal.Add(i.TaxAmount).Add(i.ShippingAmount).Sub(i.DiscountAmount)
+	i.TaxBreakdown = GroupTaxBreakdown(calculation.TaxBreakdown)
+	i.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// ApplyTaxBreakdown replaces the per-rate breakdown with the named tax
+// components worked out for the invoiced part of the order, which include
+// the tax on shipping
+func (i *Invoice) ApplyTaxBreakdown(breakdown []TaxBreakdown) {
+	i.TaxBreakdown = breakdown
+	i.TaxAmount = decimal.Zero
+	for _, component := range breakdown {
+		i.TaxAmount = i.TaxAmount.Add(component.TaxAmount)
+	}
+	i.TotalAmount = i.Subtotal.Add(i.TaxAmount).Add(i.ShippingAmount).Sub(i.DiscountAmount)
+	i.UpdatedAt = time.Now().UTC()
+}
+
+// Issue locks the invoice: it stamps the issue date and derives the due date
+// from the payment terms. The number is allocated when the issue is stored.
+func (i *Invoice) Issue(issuedBy uuid.UUID, issueDate time.Time) error {
+	if i.Status != InvoiceStatusDraft {
+		return fmt.Errorf("invoice is %s and cannot be issued", strings.ToLower(string(i.Status)))
+	}
+
+	days := 0
+	if i.Type == InvoiceTypeInvoice {
+		var err error
+		if days, err = ParsePaymentTerms(i.PaymentTerms); err != nil {
+			return err
+		}
+	}
+
+	issueDate = issueDate.UTC()
+	dueDate := issueDate.AddDate(0, 0, days)
+	i.Status = InvoiceStatusIssued
+	i.IssueDate = &issueDate
+	i.DueDate = &dueDate
+	i.IssuedBy = &issuedBy
+	i.UpdatedAt = time.Now().UTC()
+	return i.Validate()
+}
+
+// Cancel discards a draft. Issued invoices are corrected with credit notes.
+func (i *Invoice) Cancel(reason string) error {
+	if i.Status != InvoiceStatusDraft {
+		return errors.New("only draft invoices can be cancelled; issue a credit note instead")
+	}
+
+	now := time.Now().UTC()
+	reason = strings.TrimSpace(reason)
+	i.Status = InvoiceStatusCancelled
+	i.CancellationReason = &reason
+	i.CancelledAt = &now
+	i.UpdatedAt = now
+	return nil
+}
+
+// InvoicedQuantities sums the quantity of each order line billed by the
+// invoices that are not cancelled
+func InvoicedQuantities(invoices []*Invoice) map[uuid.UUID]int {
+	quantities := make(map[uuid.UUID]int)
+	for _, invoice := range invoices {
+		if invoice.Type != InvoiceTypeInvoice || invoice.Status == InvoiceStatusCancelled {
+			continue
+		}
+		for _, line := range invoice.Lines {
+			if line.OrderItemID != nil {
+				quantities[*line.OrderItemID] += line.Quantity
+			}
+		}
+	}
+	return quantities
+}
+
+// GroupTaxBreakdown merges tax breakdown entries of the same rate, lowest rate first
+func GroupTaxBreakdown(entries []TaxBreakdown) []TaxBreakdown {
+	byRate := make(map[string]*TaxBreakdown)
+	var rates []decimal.Decimal
+	for _, entry := range entries {
+		key := entry.TaxRate.String()
+		group, ok := byRate[key]
+		if !ok {
+			group = &TaxBreakdown{
+				TaxRate:       entry.TaxRate,
+				TaxAmount:     decimal.Zero,
+				TaxableAmount: decimal.Zero,
+				TaxName:       entry.TaxName,
+			}
+			byRate[key] = group
+			rates = append(rates, entry.TaxRate)
+		}
+		group.TaxAmount = group.TaxAmount.Add(entry.TaxAmount)
+		group.TaxableAmount = group.TaxableAmount.Add(entry.TaxableAmount)
+	}
+
+	sort.Slice(rates, func(a, b int) bool { return rates[a].LessThan(rates[b]) })
+
+	grouped := make([]TaxBreakdown, len(rates))
+	for n, rate := range rates {
+		group := byRate[rate.String()]
+		group.TaxAmount = group.TaxAmount.Round(2)
+		group.TaxableAmount = group.TaxableAmount.Round(2)
+		grouped[n] = *group
+	}
+	return grouped
+}
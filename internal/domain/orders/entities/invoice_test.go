@@ -0,0 +1,140 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestInvoice(lines ...InvoiceLine) *Invoice {
+	now := time.Now().UTC()
+	return &Invoice{
+		ID:               uuid.New(),
+		Type:             InvoiceTypeInvoice,
+		Status:           InvoiceStatusDraft,
+		OrderID:          uuid.New(),
+		CustomerID:       uuid.New(),
+		BillingAddressID: uuid.New(),
+		Currency:         "USD",
+		PaymentTerms:     "NET30",
+		Lines:            lines,
+		CreatedBy:        uuid.New(),
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+}
+
+func newTestInvoiceItem(unitPrice, discount, taxRate string, quantity int) *OrderItem {
+	return &OrderItem{
+		ID:             uuid.New(),
+		ProductID:      uuid.New(),
+		ProductSKU:     "SKU-1",
+		ProductName:    "Widget",
+		Quantity:       quantity,
+		UnitPrice:      decimal.RequireFromString(unitPrice),
+		DiscountAmount: decimal.RequireFromString(discount),
+		TaxRate:        decimal.RequireFromString(taxRate),
+	}
+}
+
+func TestParsePaymentTerms(t *testing.T) {
+	days, err := ParsePaymentTerms("NET30")
+	require.NoError(t, err)
+	assert.Equal(t, 30, days)
+
+	days, err = ParsePaymentTerms(" net60 ")
+	require.NoError(t, err)
+	assert.Equal(t, 60, days)
+
+	_, err = ParsePaymentTerms("EOM")
+	require.Error(t, err)
+}
+
+func TestNewInvoiceLineKeepsUnitDiscount(t *testing.T) {
+	item := newTestInvoiceItem("10", "1", "10", 4)
+
+	line := NewInvoiceLine(item, 1)
+	assert.True(t, line.DiscountAmount.Equal(decimal.NewFromInt(1)))
+	assert.True(t, line.TaxAmount.Equal(decimal.RequireFromString("0.9")))
+	assert.True(t, line.TotalPrice.Equal(decimal.RequireFromString("9.9")))
+	assert.Equal(t, item.ID, *line.OrderItemID)
+}
+
+func TestInvoiceCalculateTotalsGroupsTaxByRate(t *testing.T) {
+	invoice := newTestInvoice(
+		NewInvoiceLine(newTestInvoiceItem("100", "0", "10", 1), 1),
+		NewInvoiceLine(newTestInvoiceItem("50", "0", "10", 2), 2),
+		NewInvoiceLine(newTestInvoiceItem("20", "0", "5", 1), 1),
+	)
+	invoice.ShippingAmount = decimal.NewFromInt(15)
+
+	require.NoError(t, invoice.CalculateTotals(decimal.NewFromInt(5)))
+
+	assert.True(t, invoice.Subtotal.Equal(decimal.NewFromInt(220)))
+	assert.True(t, invoice.TaxAmount.Equal(decimal.NewFromInt(21)))
+	assert.True(t, invoice.DiscountAmount.Equal(decimal.NewFromInt(5)))
+	assert.True(t, invoice.TotalAmount.Equal(decimal.NewFromInt(251)))
+
+	require.Len(t, invoice.TaxBreakdown, 2)
+	assert.True(t, invoice.TaxBreakdown[0].TaxRate.Equal(decimal.NewFromInt(5)))
+	assert.True(t, invoice.TaxBreakdown[0].TaxAmount.Equal(decimal.NewFromInt(1)))
+	assert.True(t, invoice.TaxBreakdown[1].TaxableAmount.Equal(decimal.NewFromInt(200)))
+	assert.True(t, invoice.TaxBreakdown[1].TaxAmount.Equal(decimal.NewFromInt(20)))
+}
+
+func TestNewCreditLineBacksOutTax(t *testing.T) {
+	line := NewCreditLine("Refund", 1, decimal.NewFromInt(110), decimal.NewFromInt(10))
+
+	assert.True(t, line.UnitPrice.Equal(decimal.NewFromInt(100)))
+	assert.True(t, line.TaxAmount.Equal(decimal.NewFromInt(10)))
+	assert.True(t, line.TotalPrice.Equal(decimal.NewFromInt(110)))
+	assert.Nil(t, line.OrderItemID)
+}
+
+func TestInvoiceIssueLocksAndSetsDueDate(t *testing.T) {
+	invoice := newTestInvoice(NewInvoiceLine(newTestInvoiceItem("10", "0", "0", 1), 1))
+	require.NoError(t, invoice.CalculateTotals(decimal.Zero))
+
+	issueDate := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, invoice.Issue(uuid.New(), issueDate))
+
+	assert.True(t, invoice.IsLocked())
+	assert.Equal(t, issueDate.AddDate(0, 0, 30), *invoice.DueDate)
+	require.Error(t, invoice.Issue(uuid.New(), issueDate))
+	require.Error(t, invoice.Cancel("duplicate"))
+}
+
+func TestInvoiceIssueRejectsUnknownTerms(t *testing.T) {
+	invoice := newTestInvoice(NewInvoiceLine(newTestInvoiceItem("10", "0", "0", 1), 1))
+	invoice.PaymentTerms = "EOM"
+
+	require.Error(t, invoice.Issue(uuid.New(), time.Now()))
+	assert.Equal(t, InvoiceStatusDraft, invoice.Status)
+}
+
+func TestInvoiceCancelDraft(t *testing.T) {
+	invoice := newTestInvoice(NewInvoiceLine(newTestInvoiceItem("10", "0", "0", 1), 1))
+
+	require.NoError(t, invoice.Cancel("wrong quantities"))
+	assert.Equal(t, InvoiceStatusCancelled, invoice.Status)
+	assert.NotNil(t, invoice.CancelledAt)
+}
+
+func TestInvoicedQuantities(t *testing.T) {
+	item := newTestInvoiceItem("10", "0", "0", 5)
+
+	issued := newTestInvoice(NewInvoiceLine(item, 2))
+	issued.Status = InvoiceStatusIssued
+	draft := newTestInvoice(NewInvoiceLine(item, 1))
+	cancelled := newTestInvoice(NewInvoiceLine(item, 2))
+	cancelled.Status = InvoiceStatusCancelled
+	creditNote := newTestInvoice(NewInvoiceLine(item, 1))
+	creditNote.Type = InvoiceTypeCreditNote
+
+	quantities := InvoicedQuantities([]*Invoice{issued, draft, cancelled, creditNote})
+	assert.Equal(t, 3, quantities[item.ID])
+}
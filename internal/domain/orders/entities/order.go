@@ -74,6 +74,17 @@ func IsTerminalStatus(status OrderStatus) bool {
 	return false
 }
 
+// RequiresApproval checks if an order must have been approved to enter a
+// status: confirmation and the fulfillment statuses after it
+func RequiresApproval(status OrderStatus) bool {
+	switch status {
+	case OrderStatusConfirmed, OrderStatusProcessing, OrderStatusPartiallyShipped,
+		OrderStatusShipped, OrderStatusDelivered, OrderStatusReturned:
+		return true
+	}
+	return false
+}
+
 // OrderPriority represents the priority level of an order
 type OrderPriority string
 
@@ -133,16 +144,22 @@ type Order struct {
 	Type           OrderType      `json:"type" db:"type"`
 	PaymentStatus  PaymentStatus  `json:"payment_status" db:"payment_status"`
 	ShippingMethod ShippingMethod `json:"shipping_method" db:"shipping_method"`
+	// CreditHold marks an order held on approval for taking the customer over their credit limit
+	CreditHold bool `json:"credit_hold" db:"credit_hold"`
 
 	// Financial fields
 	Subtotal       decimal.Decimal `json:"subtotal" db:"subtotal"`
 	TaxAmount      decimal.Decimal `json:"tax_amount" db:"tax_amount"`
 	ShippingAmount decimal.Decimal `json:"shipping_amount" db:"shipping_amount"`
 	DiscountAmount decimal.Decimal `json:"discount_amount" db:"discount_amount"`
-	TotalAmount    decimal.Decimal `json:"total_amount" db:"total_amount"`
-	PaidAmount     decimal.Decimal `json:"paid_amount" db:"paid_amount"`
-	RefundedAmount decimal.Decimal `json:"refunded_amount" db:"refunded_amount"`
-	Currency       string          `json:"currency" db:"currency"`
+	// PromotionDiscountAmount is the part of DiscountAmount given by coupon codes
+	PromotionDiscountAmount decimal.Decimal `json:"promotion_discount_amount" db:"promotion_discount_amount"`
+	TotalAmount             decimal.Decimal `json:"total_amount" db:"total_amount"`
+	PaidAmount              decimal.Decimal `json:"paid_amount" db:"paid_amount"`
+	RefundedAmount          decimal.Decimal `json:"refunded_amount" db:"refunded_amount"`
+	Currency                string          `json:"currency" db:"currency"`
+	// ExchangeRate converts the order currency into the base currency at the rate booked with the order
+	ExchangeRate decimal.Decimal `json:"exchange_rate" db:"exchange_rate"`
 
 	// Date fields
 	OrderDate     time.Time  `json:"order_date" db:"order_date"`
@@ -172,12 +189,20 @@ type Order struct {
 	UpdatedAt  time.Time  `json:"updated_at" db:"updated_at"`
 	ApprovedAt *time.Time `json:"approved_at,omitempty" db:"approved_at"`
 	ShippedAt  *time.Time `json:"shipped_at,omitempty" db:"shipped_at"`
+	// ArchivedAt is set on orders read from the archive
+	ArchivedAt *time.Time `json:"archived_at,omitempty" db:"-"`
 
 	// Relationships
 	Items []OrderItem `json:"items,omitempty" db:"-"`
+	// Promotions are the coupon codes redeemed on the order
+	Promotions []PromotionRedemption `json:"promotions,omitempty" db:"-"`
+	// ShippingQuotes are the shipping methods available at the shipping
+	// address with their prices, worked out when the order is priced
+	ShippingQuotes []ShippingQuote `json:"shipping_quotes,omitempty" db:"-"`
 }
 
-// OrderItem represents an item in an order
+// OrderItem represents an item in an order. DiscountAmount is the discount on
+// each unit, not on the whole line.
 type OrderItem struct {
 	ID             uuid.UUID       `json:"id" db:"id"`
 	OrderID        uuid.UUID       `json:"order_id" db:"order_id"`
@@ -190,6 +215,13 @@ type OrderItem struct {
 	TaxRate        decimal.Decimal `json:"tax_rate" db:"tax_rate"`
 	TaxAmount      decimal.Decimal `json:"tax_amount" db:"tax_amount"`
 	TotalPrice     decimal.Decimal `json:"total_price" db:"total_price"`
+	// TaxClass is copied from the product and selects the tax rules of the line
+	TaxClass string `json:"tax_class" db:"tax_class"`
+	// PriceIncludesTax marks UnitPrice as gross; the tax is backed out of it
+	PriceIncludesTax bool `json:"price_includes_tax" db:"price_includes_tax"`
+	// IsDropShip marks a line the supplier ships straight to the customer
+	// against a drop-ship purchase order; it never touches warehouse stock
+	IsDropShip bool `json:"is_drop_ship" db:"is_drop_ship"`
 
 	// Additional fields
 	Weight     float64 `json:"weight" db:"weight"`
@@ -201,6 +233,10 @@ type OrderItem struct {
 	Status           string `json:"status" db:"status"` // ORDERED, SHIPPED, DELIVERED, CANCELLED, RETURNED
 	QuantityShipped  int    `json:"quantity_shipped" db:"quantity_shipped"`
 	QuantityReturned int    `json:"quantity_returned" db:"quantity_returned"`
+	// QuantityBackordered is the part of the line still waiting for stock
+	QuantityBackordered int `json:"quantity_backordered" db:"quantity_backordered"`
+	// QuantityRefunded is the part of the line refunded so far
+	QuantityRefunded int `json:"quantity_refunded" db:"quantity_refunded"`
 
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
@@ -333,6 +369,7 @@ type TaxBreakdown struct {
 	TaxAmount     decimal.Decimal `json:"tax_amount"`
 	TaxableAmount decimal.Decimal `json:"taxable_amount"`
 	TaxName       string          `json:"tax_name"`
+	Level         TaxLevel        `json:"level,omitempty"`
 }
 
 // DiscountBreakdown represents discount calculation details
@@ -355,6 +392,17 @@ type OrderStatistics struct {
 
 // Validate validates the order entity
 func (o *Order) Validate() error {
+	return o.validate(true)
+}
+
+// ValidateUnnumbered validates a new order whose number has not been assigned
+// yet. Order numbers are allocated from the document sequence when the order
+// is inserted, so an empty number is accepted here.
+func (o *Order) ValidateUnnumbered() error {
+	return o.validate(strings.TrimSpace(o.OrderNumber) != "")
+}
+
+func (o *Order) validate(requireNumber bool) error {
 	var errs []error
 
 	// Validate UUID
@@ -363,8 +411,10 @@ func (o *Order) Validate() error {
 	}
 
 	// Validate order number
-	if err := o.validateOrderNumber(); err != nil {
-		errs = append(errs, fmt.Errorf("invalid order number: %w", err))
+	if requireNumber {
+		if err := o.validateOrderNumber(); err != nil {
+			errs = append(errs, fmt.Errorf("invalid order number: %w", err))
+		}
 	}
 
 	// Validate customer ID
@@ -445,10 +495,11 @@ func (o *Order) validateOrderNumber() error {
 		return errors.New("order number cannot exceed 50 characters")
 	}
 
-	// Order number format: YEAR-SEQUENCE (e.g., 2024-001234)
-	orderNumberRegex := regexp.MustCompile(`^\d{4}-\d{6}$`)
-	if !orderNumberRegex.MatchString(orderNumber) {
-		return errors.New("order number must be in format YYYY-NNNNNN")
+	// Order numbers come from configurable document sequences
+	// (e.g., SO-2024-001234, 2024-001234, PO/24/05/0007)
+	orderNumberRegex := regexp.MustCompile(`^[A-Z0-9]+([-/][A-Z0-9]+)*$`)
+	if !orderNumberRegex.MatchString(orderNumber) || !strings.ContainsAny(orderNumber, "0123456789") {
+		return errors.New("order number must be uppercase alphanumeric segments separated by '-' or '/' and contain a sequence number")
 	}
 
 	return nil
@@ -775,6 +826,19 @@ func (o *Order) GetOutstandingBalance() decimal.Decimal {
 	return o.TotalAmount.Sub(o.PaidAmount)
 }
 
+// CreditExposure returns the outstanding balance in the base currency, the
+// customer credit the order takes while it is approved and open
+func (o *Order) CreditExposure() decimal.Decimal {
+	outstanding := o.GetOutstandingBalance()
+	if !outstanding.IsPositive() {
+		return decimal.Zero
+	}
+	if o.ExchangeRate.IsPositive() {
+		outstanding = outstanding.Mul(o.ExchangeRate)
+	}
+	return outstanding.Round(2)
+}
+
 // AddPayment adds a payment amount to the order
 func (o *Order) AddPayment(amount decimal.Decimal) error {
 	if amount.LessThanOrEqual(decimal.Zero) {
@@ -1129,8 +1193,11 @@ func (oi *OrderItem) validateTotalPrice() error {
 		return errors.New("total price cannot be negative")
 	}
 
-	// Calculate expected total price
-	expectedTotal := oi.UnitPrice.Mul(decimal.NewFromInt(int64(oi.Quantity))).Sub(oi.DiscountAmount).Add(oi.TaxAmount)
+	// Calculate expected total price; tax-inclusive prices already contain the tax
+	expectedTotal := oi.LineAmount()
+	if !oi.PriceIncludesTax {
+		expectedTotal = expectedTotal.Add(oi.TaxAmount)
+	}
 	if !oi.TotalPrice.Equal(expectedTotal) {
 		return fmt.Errorf("total price calculation mismatch: expected %s, got %s", expectedTotal, oi.TotalPrice)
 	}
@@ -1233,6 +1300,14 @@ func (oi *OrderItem) validateQuantities() error {
 		return fmt.Errorf("returned quantity (%d) cannot exceed shipped quantity (%d)", oi.QuantityReturned, oi.QuantityShipped)
 	}
 
+	if oi.QuantityRefunded < 0 {
+		return errors.New("refunded quantity cannot be negative")
+	}
+
+	if oi.QuantityRefunded > oi.Quantity {
+		return fmt.Errorf("refunded quantity (%d) cannot exceed ordered quantity (%d)", oi.QuantityRefunded, oi.Quantity)
+	}
+
 	return nil
 }
 
@@ -1242,18 +1317,30 @@ func (oi *OrderItem) CalculateTotals() {
 	subtotal := oi.UnitPrice.Mul(decimal.NewFromInt(int64(oi.Quantity)))
 
 	// Apply discount (DiscountAmount is per-item, so multiply by quantity)
-	totalDiscount := oi.DiscountAmount.Mul(decimal.NewFromInt(int64(oi.Quantity)))
-	afterDiscount := subtotal.Sub(totalDiscount)
-
-	// Calculate tax
-	oi.TaxAmount = afterDiscount.Mul(oi.TaxRate).Div(decimal.NewFromInt(100))
+	afterDiscount := subtotal.Sub(oi.LineDiscount())
 
-	// Calculate total
-	oi.TotalPrice = afterDiscount.Add(oi.TaxAmount)
+	// Calculate tax, backing it out of tax-inclusive prices
+	if oi.PriceIncludesTax {
+		oi.TaxAmount = afterDiscount.Sub(afterDiscount.Div(decimal.NewFromInt(1).Add(oi.TaxRate.Div(decimal.NewFromInt(100)))))
+		oi.TotalPrice = afterDiscount
+	} else {
+		oi.TaxAmount = afterDiscount.Mul(oi.TaxRate).Div(decimal.NewFromInt(100))
+		oi.TotalPrice = afterDiscount.Add(oi.TaxAmount)
+	}
 
 	oi.UpdatedAt = time.Now().UTC()
 }
 
+// LineDiscount returns the discount on the whole line
+func (oi *OrderItem) LineDiscount() decimal.Decimal {
+	return oi.DiscountAmount.Mul(decimal.NewFromInt(int64(oi.Quantity)))
+}
+
+// LineAmount returns the line price after the line discount
+func (oi *OrderItem) LineAmount() decimal.Decimal {
+	return oi.UnitPrice.Mul(decimal.NewFromInt(int64(oi.Quantity))).Sub(oi.LineDiscount())
+}
+
 // GetItemWeight returns the total weight for this item
 func (oi *OrderItem) GetItemWeight() float64 {
 	return oi.Weight * float64(oi.Quantity)
@@ -1264,6 +1351,11 @@ func (oi *OrderItem) CanBeShipped() bool {
 	return oi.Status == "ORDERED" && oi.QuantityShipped < oi.Quantity
 }
 
+// ShippableQuantity returns the allocated quantity that has not shipped yet
+func (oi *OrderItem) ShippableQuantity() int {
+	return oi.Quantity - oi.QuantityShipped - oi.QuantityBackordered
+}
+
 // ShipItem ships a quantity of the item
 func (oi *OrderItem) ShipItem(quantity int) error {
 	if quantity <= 0 {
@@ -1308,6 +1400,21 @@ func (oi *OrderItem) ReturnItem(quantity int) error {
 	return nil
 }
 
+// RefundItem records a refund of a quantity of the item
+func (oi *OrderItem) RefundItem(quantity int) error {
+	if quantity <= 0 {
+		return errors.New("refund quantity must be positive")
+	}
+
+	if oi.QuantityRefunded+quantity > oi.Quantity {
+		return fmt.Errorf("cannot refund %d items, only %d not refunded yet", quantity, oi.Quantity-oi.QuantityRefunded)
+	}
+
+	oi.QuantityRefunded += quantity
+	oi.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
 // ==================== CUSTOMER ENTITY METHODS ====================
 
 // Validate validates the customer entity
@@ -2004,19 +2111,26 @@ func CalculateOrderTotals(order *Order, taxRate decimal.Decimal, shippingCost de
 
 		// Calculate item discount
 		if item.DiscountAmount.GreaterThan(decimal.Zero) {
-			calculation.DiscountAmount = calculation.DiscountAmount.Add(item.DiscountAmount)
+			calculation.DiscountAmount = calculation.DiscountAmount.Add(item.LineDiscount())
 			calculation.DiscountBreakdown = append(calculation.DiscountBreakdown, DiscountBreakdown{
 				DiscountType: "ITEM_DISCOUNT",
-				Amount:       item.DiscountAmount,
+				Amount:       item.LineDiscount(),
 				Description:  fmt.Sprintf("Discount on %s", item.ProductName),
 			})
 		}
 
-		// Calculate tax for taxable items
+		// Calculate tax for taxable items. Tax included in the price is
+		// backed out and taken out of the subtotal, which is always net.
 		if item.TaxRate.GreaterThan(decimal.Zero) {
-			taxableAmount := itemSubtotal.Sub(item.DiscountAmount)
+			taxableAmount := item.LineAmount()
+			if item.PriceIncludesTax {
+				taxableAmount = taxableAmount.Div(decimal.NewFromInt(1).Add(item.TaxRate.Div(decimal.NewFromInt(100))))
+			}
 			itemTax := taxableAmount.Mul(item.TaxRate).Div(decimal.NewFromInt(100))
 			calculation.TaxAmount = calculation.TaxAmount.Add(itemTax)
+			if item.PriceIncludesTax {
+				calculation.Subtotal = calculation.Subtotal.Sub(itemTax)
+			}
 
 			calculation.TaxBreakdown = append(calculation.TaxBreakdown, TaxBreakdown{
 				TaxRate:       item.TaxRate,
@@ -2043,7 +2157,8 @@ func CalculateOrderTotals(order *Order, taxRate decimal.Decimal, shippingCost de
 	return calculation, nil
 }
 
-// ValidateOrder validates an entire order including all items and relationships
+// ValidateOrder validates an entire order including all items and
+// relationships. New orders are accepted before they are numbered.
 func ValidateOrder(order *Order) *OrderValidation {
 	validation := &OrderValidation{
 		IsValid:  true,
@@ -2052,7 +2167,7 @@ func ValidateOrder(order *Order) *OrderValidation {
 	}
 
 	// Validate the order itself
-	if err := order.Validate(); err != nil {
+	if err := order.ValidateUnnumbered(); err != nil {
 		validation.IsValid = false
 		validation.Errors = append(validation.Errors, fmt.Sprintf("Order validation failed: %s", err))
 	}
@@ -2089,10 +2204,6 @@ func ValidateOrder(order *Order) *OrderValidation {
 		validation.Warnings = append(validation.Warnings, "Order required date has passed")
 	}
 
-	if order.TotalAmount.GreaterThan(decimal.NewFromInt(10000)) {
-		validation.Warnings = append(validation.Warnings, "Order total exceeds $10,000 - additional approval may be required")
-	}
-
 	if order.IsDigitalOrder() && order.ShippingMethod != ShippingMethodDigital {
 		validation.Warnings = append(validation.Warnings, "Digital order with non-digital shipping method")
 	}
@@ -2100,7 +2211,10 @@ func ValidateOrder(order *Order) *OrderValidation {
 	return validation
 }
 
-// GenerateOrderNumber generates a unique order number
+// GenerateOrderNumber generates a timestamp-based order number.
+//
+// Deprecated: the numbers are neither gapless nor guaranteed unique. Orders are
+// numbered from the document sequence of their type when they are persisted.
 func GenerateOrderNumber() string {
 	now := time.Now().UTC()
 	year := now.Year()
@@ -0,0 +1,58 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// OrderArchivePolicy decides which closed orders move to the archive. An
+// order qualifies once it is closed, settled and unchanged for AfterMonths.
+type OrderArchivePolicy struct {
+	// AfterMonths is how long a closed order stays in the live tables after its last change
+	AfterMonths int `json:"after_months"`
+	// BatchSize caps the orders archived by one run
+	BatchSize int `json:"batch_size"`
+}
+
+// Validate validates the archive policy
+func (p OrderArchivePolicy) Validate() error {
+	if p.AfterMonths <= 0 {
+		return errors.New("archive age must be at least one month")
+	}
+	if p.BatchSize <= 0 {
+		return errors.New("archive batch size must be positive")
+	}
+	return nil
+}
+
+// Cutoff returns the time closed orders must have been unchanged since to be archived
+func (p OrderArchivePolicy) Cutoff(asOf time.Time) time.Time {
+	return asOf.AddDate(0, -p.AfterMonths, 0)
+}
+
+// CanBeArchived checks that the order is closed and settled: a delivered
+// order is paid in full and a cancelled or refunded order has returned
+// every payment
+func (o *Order) CanBeArchived() error {
+	if !IsTerminalStatus(o.Status) {
+		return fmt.Errorf("only closed orders can be archived, order is %s", o.Status)
+	}
+
+	if o.Status == OrderStatusDelivered {
+		if balance := o.GetOutstandingBalance(); balance.IsPositive() {
+			return fmt.Errorf("order has an outstanding balance of %s", balance.StringFixed(2))
+		}
+		return nil
+	}
+
+	if unrefunded := o.PaidAmount.Sub(o.RefundedAmount); unrefunded.IsPositive() {
+		return fmt.Errorf("order has %s paid and not refunded", unrefunded.StringFixed(2))
+	}
+	return nil
+}
+
+// IsArchived reports whether the order was read from the archive
+func (o *Order) IsArchived() bool {
+	return o.ArchivedAt != nil
+}
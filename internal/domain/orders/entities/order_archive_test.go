@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderArchivePolicy(t *testing.T) {
+	policy := OrderArchivePolicy{AfterMonths: 18, BatchSize: 100}
+	assert.NoError(t, policy.Validate())
+
+	asOf := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2025, 4, 16, 0, 0, 0, 0, time.UTC), policy.Cutoff(asOf))
+
+	assert.Error(t, OrderArchivePolicy{AfterMonths: 0, BatchSize: 100}.Validate())
+	assert.Error(t, OrderArchivePolicy{AfterMonths: 12, BatchSize: 0}.Validate())
+}
+
+func TestOrderCanBeArchived(t *testing.T) {
+	order := &Order{
+		Status:      OrderStatusShipped,
+		TotalAmount: decimal.RequireFromString("100.00"),
+		PaidAmount:  decimal.RequireFromString("100.00"),
+	}
+	assert.ErrorContains(t, order.CanBeArchived(), "only closed orders")
+
+	order.Status = OrderStatusDelivered
+	assert.NoError(t, order.CanBeArchived())
+
+	order.PaidAmount = decimal.RequireFromString("60.00")
+	assert.ErrorContains(t, order.CanBeArchived(), "outstanding balance of 40.00")
+
+	// Cancelled and refunded orders must have returned what was paid
+	order.Status = OrderStatusCancelled
+	assert.ErrorContains(t, order.CanBeArchived(), "60.00 paid and not refunded")
+
+	order.RefundedAmount = decimal.RequireFromString("60.00")
+	assert.NoError(t, order.CanBeArchived())
+
+	order.Status = OrderStatusRefunded
+	assert.NoError(t, order.CanBeArchived())
+
+	assert.False(t, order.IsArchived())
+	archivedAt := time.Now().UTC()
+	order.ArchivedAt = &archivedAt
+	assert.True(t, order.IsArchived())
+}
@@ -0,0 +1,427 @@
+package entities
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// OrderImportFormat is the file format of an order import
+type OrderImportFormat string
+
+const (
+	// OrderImportFormatCSV is a CSV file with a header row and one row per line item
+	OrderImportFormatCSV OrderImportFormat = "CSV"
+	// OrderImportFormatJSON is a JSON array of records, each a line item or an
+	// order whose line items are nested under the items field
+	OrderImportFormatJSON OrderImportFormat = "JSON"
+)
+
+// OrderImportField is an order field an import column is mapped to
+type OrderImportField string
+
+const (
+	OrderImportFieldExternalReference OrderImportField = "external_reference"
+	OrderImportFieldCustomerID        OrderImportField = "customer_id"
+	OrderImportFieldCustomerCode      OrderImportField = "customer_code"
+	OrderImportFieldCustomerEmail     OrderImportField = "customer_email"
+	OrderImportFieldShippingAddressID OrderImportField = "shipping_address_id"
+	OrderImportFieldBillingAddressID  OrderImportField = "billing_address_id"
+	OrderImportFieldShippingMethod    OrderImportField = "shipping_method"
+	OrderImportFieldShippingAmount    OrderImportField = "shipping_amount"
+	OrderImportFieldPriority          OrderImportField = "priority"
+	OrderImportFieldCurrency          OrderImportField = "currency"
+	OrderImportFieldRequiredDate      OrderImportField = "required_date"
+	OrderImportFieldNotes             OrderImportField = "notes"
+	OrderImportFieldCustomerNotes     OrderImportField = "customer_notes"
+	OrderImportFieldProductID         OrderImportField = "product_id"
+	OrderImportFieldSKU               OrderImportField = "sku"
+	OrderImportFieldQuantity          OrderImportField = "quantity"
+	OrderImportFieldUnitPrice         OrderImportField = "unit_price"
+	OrderImportFieldDiscountAmount    OrderImportField = "discount_amount"
+	OrderImportFieldTaxRate           OrderImportField = "tax_rate"
+	OrderImportFieldItemNotes         OrderImportField = "item_notes"
+
+	// OrderImportFieldItems is the key of the nested line items of a JSON
+	// order record. It cannot be given a default.
+	OrderImportFieldItems OrderImportField = "items"
+)
+
+// OrderImportFields lists the fields read from each row of an import file
+var OrderImportFields = []OrderImportField{
+	OrderImportFieldExternalReference,
+	OrderImportFieldCustomerID,
+	OrderImportFieldCustomerCode,
+	OrderImportFieldCustomerEmail,
+	OrderImportFieldShippingAddressID,
+	OrderImportFieldBillingAddressID,
+	OrderImportFieldShippingMethod,
+	OrderImportFieldShippingAmount,
+	OrderImportFieldPriority,
+	OrderImportFieldCurrency,
+	OrderImportFieldRequiredDate,
+	OrderImportFieldNotes,
+	OrderImportFieldCustomerNotes,
+	OrderImportFieldProductID,
+	OrderImportFieldSKU,
+	OrderImportFieldQuantity,
+	OrderImportFieldUnitPrice,
+	OrderImportFieldDiscountAmount,
+	OrderImportFieldTaxRate,
+	OrderImportFieldItemNotes,
+}
+
+// OrderImportProfile is a saved mapping of a partner's import files onto
+// order fields. Fields that are not mapped are read from a column named after
+// the field; defaults fill fields whose value is missing or empty.
+type OrderImportProfile struct {
+	ID          uuid.UUID `json:"id" db:"id"`
+	Name        string    `json:"name" db:"name"`
+	Description *string   `json:"description,omitempty" db:"description"`
+	// Source identifies the partner sending the files. External references
+	// are unique per source.
+	Source    string            `json:"source" db:"source"`
+	Format    OrderImportFormat `json:"format" db:"format"`
+	Delimiter string            `json:"delimiter" db:"delimiter"`
+	// Columns maps order fields to the column or key they are read from
+	Columns map[OrderImportField]string `json:"columns" db:"columns"`
+	// Defaults holds values for fields that are missing or empty in a row
+	Defaults  map[OrderImportField]string `json:"defaults" db:"defaults"`
+	IsActive  bool                        `json:"is_active" db:"is_active"`
+	CreatedBy uuid.UUID                   `json:"created_by" db:"created_by"`
+	CreatedAt time.Time                   `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time                   `json:"updated_at" db:"updated_at"`
+}
+
+// OrderImportRow is a line item of an import file with its values keyed by order field
+type OrderImportRow struct {
+	// Number is the line of a CSV file, counting the header as line 1, or
+	// the position of the record in a JSON file
+	Number int `json:"number"`
+	// Item is the position of a line nested in a JSON order record
+	Item   int                         `json:"item,omitempty"`
+	Values map[OrderImportField]string `json:"values"`
+}
+
+// OrderImportGroup is the rows of one external order, in file order
+type OrderImportGroup struct {
+	ExternalReference string           `json:"external_reference"`
+	Rows              []OrderImportRow `json:"rows"`
+}
+
+// OrderImportError is a problem with a row of an import file
+type OrderImportError struct {
+	Row               int              `json:"row"`
+	Item              int              `json:"item,omitempty"`
+	ExternalReference string           `json:"external_reference,omitempty"`
+	Field             OrderImportField `json:"field,omitempty"`
+	Message           string           `json:"message"`
+}
+
+// OrderExternalReference records the order imported for a partner's order reference
+type OrderExternalReference struct {
+	Source            string     `json:"source" db:"source"`
+	ExternalReference string     `json:"external_reference" db:"external_reference"`
+	OrderID           uuid.UUID  `json:"order_id" db:"order_id"`
+	ProfileID         *uuid.UUID `json:"profile_id,omitempty" db:"profile_id"`
+	CreatedBy         uuid.UUID  `json:"created_by" db:"created_by"`
+	CreatedAt         time.Time  `json:"created_at" db:"created_at"`
+
+	// OrderNumber is loaded with the reference
+	OrderNumber string `json:"order_number,omitempty" db:"-"`
+}
+
+// Validate validates the import profile
+func (p *OrderImportProfile) Validate() error {
+	var errs []error
+
+	if p.ID == uuid.Nil {
+		errs = append(errs, errors.New("import profile ID cannot be empty"))
+	}
+	if strings.TrimSpace(p.Name) == "" {
+		errs = append(errs, errors.New("import profile name is required"))
+	}
+	if strings.TrimSpace(p.Source) == "" {
+		errs = append(errs, errors.New("import profile source is required"))
+	}
+
+	switch p.Format {
+	case OrderImportFormatCSV:
+		if utf8.RuneCountInString(p.Delimiter) != 1 || p.Delimiter == "\"" || p.Delimiter == "\n" || p.Delimiter == "\r" {
+			errs = append(errs, errors.New("CSV delimiter must be a single character other than a quote or line break"))
+		}
+	case OrderImportFormatJSON:
+	default:
+		errs = append(errs, fmt.Errorf("invalid format: %s", p.Format))
+	}
+
+	for field, column := range p.Columns {
+		if !isOrderImportField(field) && field != OrderImportFieldItems {
+			errs = append(errs, fmt.Errorf("unknown field: %s", field))
+		}
+		if strings.TrimSpace(column) == "" {
+			errs = append(errs, fmt.Errorf("column of %s cannot be empty", field))
+		}
+	}
+	for field := range p.Defaults {
+		if !isOrderImportField(field) {
+			errs = append(errs, fmt.Errorf("unknown default field: %s", field))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("validation failed: %v", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// Column returns the column or key a field is read from
+func (p *OrderImportProfile) Column(field OrderImportField) string {
+	if column := strings.TrimSpace(p.Columns[field]); column != "" {
+		return column
+	}
+	return string(field)
+}
+
+// Parse reads the rows of an import file in the profile's format. Malformed
+// files fail as a whole; the values of the rows are checked when the orders
+// are built.
+func (p *OrderImportProfile) Parse(r io.Reader) ([]OrderImportRow, error) {
+	switch p.Format {
+	case OrderImportFormatCSV:
+		return p.parseCSV(r)
+	case OrderImportFormatJSON:
+		return p.parseJSON(r)
+	default:
+		return nil, fmt.Errorf("invalid format: %s", p.Format)
+	}
+}
+
+func (p *OrderImportProfile) parseCSV(r io.Reader) ([]OrderImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.Comma, _ = utf8.DecodeRuneInString(p.Delimiter)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	// Columns are matched ignoring case and surrounding space
+	positions := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		key := strings.ToLower(strings.TrimSpace(name))
+		if _, exists := positions[key]; !exists {
+			positions[key] = i
+		}
+	}
+	for field := range p.Columns {
+		if _, exists := positions[strings.ToLower(p.Column(field))]; !exists && field != OrderImportFieldItems {
+			return nil, fmt.Errorf("column %q mapped to %s is missing from the header", p.Column(field), field)
+		}
+	}
+
+	var rows []OrderImportRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read row: %w", err)
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, p.newRow(line, 0, func(column string) string {
+			if i, exists := positions[strings.ToLower(column)]; exists && i < len(record) {
+				return record[i]
+			}
+			return ""
+		}))
+	}
+
+	return rows, nil
+}
+
+func (p *OrderImportProfile) parseJSON(r io.Reader) ([]OrderImportRow, error) {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+
+	var records []map[string]interface{}
+	if err := decoder.Decode(&records); err != nil {
+		if err == io.EOF {
+			return nil, errors.New("file is empty")
+		}
+		return nil, fmt.Errorf("file must be a JSON array of objects: %w", err)
+	}
+
+	itemsKey := p.Column(OrderImportFieldItems)
+	var rows []OrderImportRow
+	for i, record := range records {
+		number := i + 1
+		order, err := jsonValues(record)
+		if err != nil {
+			return nil, fmt.Errorf("record %d: %w", number, err)
+		}
+
+		nested, hasItems := record[itemsKey]
+		if !hasItems {
+			rows = append(rows, p.newRow(number, 0, lookup(order)))
+			continue
+		}
+
+		items, ok := nested.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("record %d: %s must be an array of objects", number, itemsKey)
+		}
+		for j, nestedItem := range items {
+			item, ok := nestedItem.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("record %d: item %d must be an object", number, j+1)
+			}
+			line, err := jsonValues(item)
+			if err != nil {
+				return nil, fmt.Errorf("record %d: item %d: %w", number, j+1, err)
+			}
+
+			// Line values take precedence over the values of the order
+			rows = append(rows, p.newRow(number, j+1, func(key string) string {
+				if value, exists := line[key]; exists && strings.TrimSpace(value) != "" {
+					return value
+				}
+				return order[key]
+			}))
+		}
+	}
+
+	return rows, nil
+}
+
+// newRow maps the values of a record onto the order fields, applying defaults
+func (p *OrderImportProfile) newRow(number, item int, value func(column string) string) OrderImportRow {
+	row := OrderImportRow{
+		Number: number,
+		Item:   item,
+		Values: make(map[OrderImportField]string, len(OrderImportFields)),
+	}
+	for _, field := range OrderImportFields {
+		v := strings.TrimSpace(value(p.Column(field)))
+		if v == "" {
+			v = strings.TrimSpace(p.Defaults[field])
+		}
+		if v != "" {
+			row.Values[field] = v
+		}
+	}
+	return row
+}
+
+// Value returns the value of a field, empty when the row has none
+func (r OrderImportRow) Value(field OrderImportField) string {
+	return r.Values[field]
+}
+
+// Error returns an import error for a field of the row
+func (r OrderImportRow) Error(field OrderImportField, format string, args ...interface{}) OrderImportError {
+	return OrderImportError{
+		Row:               r.Number,
+		Item:              r.Item,
+		ExternalReference: r.Value(OrderImportFieldExternalReference),
+		Field:             field,
+		Message:           fmt.Sprintf(format, args...),
+	}
+}
+
+// GroupOrderImportRows groups the rows of an import file by external
+// reference, in order of first appearance. Rows without a reference are
+// reported as errors.
+func GroupOrderImportRows(rows []OrderImportRow) ([]OrderImportGroup, []OrderImportError) {
+	var groups []OrderImportGroup
+	var errs []OrderImportError
+	positions := make(map[string]int)
+
+	for _, row := range rows {
+		reference := row.Value(OrderImportFieldExternalReference)
+		if reference == "" {
+			errs = append(errs, row.Error(OrderImportFieldExternalReference, "external reference is required"))
+			continue
+		}
+
+		i, exists := positions[reference]
+		if !exists {
+			i = len(groups)
+			positions[reference] = i
+			groups = append(groups, OrderImportGroup{ExternalReference: reference})
+		}
+		groups[i].Rows = append(groups[i].Rows, row)
+	}
+
+	return groups, errs
+}
+
+// isOrderImportField reports whether a field is read from import rows
+func isOrderImportField(field OrderImportField) bool {
+	for _, f := range OrderImportFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// isBlankRecord reports whether every value of a CSV record is empty
+func isBlankRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// jsonValues converts the scalar values of a JSON object to text, leaving
+// out nested arrays and objects
+func jsonValues(record map[string]interface{}) (map[string]string, error) {
+	values := make(map[string]string, len(record))
+	for key, value := range record {
+		switch v := value.(type) {
+		case nil:
+		case string:
+			values[key] = v
+		case json.Number:
+			values[key] = v.String()
+		case bool:
+			values[key] = strconv.FormatBool(v)
+		case []interface{}, map[string]interface{}:
+		default:
+			return nil, fmt.Errorf("unsupported value of %s", key)
+		}
+	}
+	return values, nil
+}
+
+// lookup reads values of a JSON record by key
+func lookup(values map[string]string) func(string) string {
+	return func(key string) string {
+		return values[key]
+	}
+}
@@ -0,0 +1,167 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func generateTestOrderImportProfile(format OrderImportFormat) *OrderImportProfile {
+	now := time.Now().UTC()
+
+	return &OrderImportProfile{
+		ID:        uuid.New(),
+		Name:      "Marketplace orders",
+		Source:    "marketplace",
+		Format:    format,
+		Delimiter: ",",
+		IsActive:  true,
+		CreatedBy: uuid.New(),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func TestOrderImportProfile_Validate(t *testing.T) {
+	profile := generateTestOrderImportProfile(OrderImportFormatCSV)
+	profile.Columns = map[OrderImportField]string{OrderImportFieldSKU: "Item Code"}
+	profile.Defaults = map[OrderImportField]string{OrderImportFieldShippingMethod: "EXPRESS"}
+	assert.NoError(t, profile.Validate())
+
+	jsonProfile := generateTestOrderImportProfile(OrderImportFormatJSON)
+	jsonProfile.Delimiter = ""
+	jsonProfile.Columns = map[OrderImportField]string{OrderImportFieldItems: "lines"}
+	assert.NoError(t, jsonProfile.Validate())
+
+	profile.Delimiter = ";;"
+	profile.Columns = map[OrderImportField]string{"colour": "Colour", OrderImportFieldQuantity: " "}
+	profile.Defaults = map[OrderImportField]string{OrderImportFieldItems: "[]"}
+	err := profile.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "CSV delimiter must be a single character")
+	assert.Contains(t, err.Error(), "unknown field: colour")
+	assert.Contains(t, err.Error(), "column of quantity cannot be empty")
+	assert.Contains(t, err.Error(), "unknown default field: items")
+
+	profile = generateTestOrderImportProfile("XML")
+	err = profile.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid format: XML")
+}
+
+func TestOrderImportProfile_ParseCSV(t *testing.T) {
+	profile := generateTestOrderImportProfile(OrderImportFormatCSV)
+	profile.Delimiter = ";"
+	profile.Columns = map[OrderImportField]string{
+		OrderImportFieldExternalReference: "PO Number",
+		OrderImportFieldSKU:               "Item Code",
+		OrderImportFieldQuantity:          "Qty",
+	}
+	profile.Defaults = map[OrderImportField]string{
+		OrderImportFieldCustomerCode:   "CUST-001",
+		OrderImportFieldShippingMethod: "EXPRESS",
+	}
+
+	file := "\ufeffpo number;ITEM CODE;Qty;customer_code;notes\n" +
+		"PO-1;SKU-1;2;;first\n" +
+		";;;;\n" +
+		"PO-1;SKU-2; 3 ;CUST-002;\n"
+
+	rows, err := profile.Parse(strings.NewReader(file))
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	assert.Equal(t, 2, rows[0].Number)
+	assert.Equal(t, "PO-1", rows[0].Value(OrderImportFieldExternalReference))
+	assert.Equal(t, "SKU-1", rows[0].Value(OrderImportFieldSKU))
+	assert.Equal(t, "2", rows[0].Value(OrderImportFieldQuantity))
+	assert.Equal(t, "CUST-001", rows[0].Value(OrderImportFieldCustomerCode))
+	assert.Equal(t, "EXPRESS", rows[0].Value(OrderImportFieldShippingMethod))
+	assert.Equal(t, "first", rows[0].Value(OrderImportFieldNotes))
+
+	// Blank lines are skipped but still counted
+	assert.Equal(t, 4, rows[1].Number)
+	assert.Equal(t, "3", rows[1].Value(OrderImportFieldQuantity))
+	assert.Equal(t, "CUST-002", rows[1].Value(OrderImportFieldCustomerCode))
+	assert.Empty(t, rows[1].Value(OrderImportFieldNotes))
+
+	_, err = profile.Parse(strings.NewReader("po number;sku;qty\nPO-1;SKU-1;2\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `column "Item Code" mapped to sku is missing from the header`)
+
+	_, err = profile.Parse(strings.NewReader(""))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "file is empty")
+}
+
+func TestOrderImportProfile_ParseJSON(t *testing.T) {
+	profile := generateTestOrderImportProfile(OrderImportFormatJSON)
+	profile.Columns = map[OrderImportField]string{
+		OrderImportFieldExternalReference: "orderRef",
+		OrderImportFieldItems:             "lines",
+	}
+	profile.Defaults = map[OrderImportField]string{OrderImportFieldCurrency: "USD"}
+
+	file := `[
+		{"orderRef": "A-1", "customer_code": "CUST-001", "notes": "gift", "lines": [
+			{"sku": "SKU-1", "quantity": 2, "unit_price": 9.5},
+			{"sku": "SKU-2", "quantity": 1, "notes": "wrap"}
+		]},
+		{"orderRef": "A-2", "customer_code": "CUST-002", "sku": "SKU-3", "quantity": 4, "currency": "EUR"}
+	]`
+
+	rows, err := profile.Parse(strings.NewReader(file))
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	assert.Equal(t, 1, rows[0].Number)
+	assert.Equal(t, 1, rows[0].Item)
+	assert.Equal(t, "A-1", rows[0].Value(OrderImportFieldExternalReference))
+	assert.Equal(t, "CUST-001", rows[0].Value(OrderImportFieldCustomerCode))
+	assert.Equal(t, "9.5", rows[0].Value(OrderImportFieldUnitPrice))
+	assert.Equal(t, "gift", rows[0].Value(OrderImportFieldNotes))
+	assert.Equal(t, "USD", rows[0].Value(OrderImportFieldCurrency))
+
+	// Line values take precedence over the order's
+	assert.Equal(t, 2, rows[1].Item)
+	assert.Equal(t, "wrap", rows[1].Value(OrderImportFieldNotes))
+
+	assert.Equal(t, 2, rows[2].Number)
+	assert.Zero(t, rows[2].Item)
+	assert.Equal(t, "SKU-3", rows[2].Value(OrderImportFieldSKU))
+	assert.Equal(t, "EUR", rows[2].Value(OrderImportFieldCurrency))
+
+	_, err = profile.Parse(strings.NewReader(`{"orderRef": "A-1"}`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "file must be a JSON array of objects")
+
+	_, err = profile.Parse(strings.NewReader(`[{"orderRef": "A-1", "lines": {"sku": "SKU-1"}}]`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "record 1: lines must be an array of objects")
+}
+
+func TestGroupOrderImportRows(t *testing.T) {
+	rows := []OrderImportRow{
+		{Number: 2, Values: map[OrderImportField]string{OrderImportFieldExternalReference: "PO-2"}},
+		{Number: 3, Values: map[OrderImportField]string{OrderImportFieldExternalReference: "PO-1"}},
+		{Number: 4, Values: map[OrderImportField]string{OrderImportFieldSKU: "SKU-1"}},
+		{Number: 5, Values: map[OrderImportField]string{OrderImportFieldExternalReference: "PO-2"}},
+	}
+
+	groups, errs := GroupOrderImportRows(rows)
+	require.Len(t, groups, 2)
+	assert.Equal(t, "PO-2", groups[0].ExternalReference)
+	require.Len(t, groups[0].Rows, 2)
+	assert.Equal(t, 2, groups[0].Rows[0].Number)
+	assert.Equal(t, 5, groups[0].Rows[1].Number)
+	assert.Equal(t, "PO-1", groups[1].ExternalReference)
+
+	require.Len(t, errs, 1)
+	assert.Equal(t, 4, errs[0].Row)
+	assert.Equal(t, OrderImportFieldExternalReference, errs[0].Field)
+	assert.Equal(t, "external reference is required", errs[0].Message)
+}
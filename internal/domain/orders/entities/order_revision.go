@@ -0,0 +1,355 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// OrderRevisionStatus represents the status of an order revision
+type OrderRevisionStatus string
+
+const (
+	OrderRevisionStatusApplied         OrderRevisionStatus = "APPLIED"
+	OrderRevisionStatusPendingApproval OrderRevisionStatus = "PENDING_APPROVAL"
+	OrderRevisionStatusRejected        OrderRevisionStatus = "REJECTED"
+)
+
+// Fields of an order compared between revisions
+const (
+	RevisionFieldLineAdded       = "line_added"
+	RevisionFieldLineRemoved     = "line_removed"
+	RevisionFieldQuantity        = "quantity"
+	RevisionFieldUnitPrice       = "unit_price"
+	RevisionFieldDiscountAmount  = "discount_amount"
+	RevisionFieldShippingAddress = "shipping_address_id"
+	RevisionFieldBillingAddress  = "billing_address_id"
+	RevisionFieldTotalAmount     = "total_amount"
+)
+
+// OrderRevision is a numbered version of a confirmed order. Revision 1 is
+// the order as it stood before its first amendment; every amendment to its
+// lines, prices or addresses adds the next revision, with the changes from
+// the revision before. Amendments that require approval wait as
+// PENDING_APPROVAL revisions and change the order only once approved.
+type OrderRevision struct {
+	ID             uuid.UUID           `json:"id" db:"id"`
+	OrderID        uuid.UUID           `json:"order_id" db:"order_id"`
+	RevisionNumber int                 `json:"revision_number" db:"revision_number"`
+	Status         OrderRevisionStatus `json:"status" db:"status"`
+	Reason         *string             `json:"reason,omitempty" db:"reason"`
+
+	// Amendment is the change requested; the first revision has none
+	Amendment *OrderAmendment `json:"amendment,omitempty" db:"amendment"`
+	// Snapshot is the order as of the revision, or as it will be once a
+	// pending revision is approved
+	Snapshot OrderSnapshot         `json:"snapshot" db:"snapshot"`
+	Changes  []OrderRevisionChange `json:"changes" db:"changes"`
+
+	// ApproverRoles are the roles that must approve the revision, in sequence
+	ApproverRoles []string `json:"approver_roles,omitempty" db:"approver_roles"`
+	// ApprovalAmount is the order total in the base currency the approvers approve
+	ApprovalAmount decimal.Decimal         `json:"approval_amount" db:"approval_amount"`
+	Approvals      []OrderRevisionApproval `json:"approvals,omitempty" db:"approvals"`
+
+	CreatedBy       uuid.UUID  `json:"created_by" db:"created_by"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	AppliedAt       *time.Time `json:"applied_at,omitempty" db:"applied_at"`
+	RejectedBy      *uuid.UUID `json:"rejected_by,omitempty" db:"rejected_by"`
+	RejectedAt      *time.Time `json:"rejected_at,omitempty" db:"rejected_at"`
+	RejectionReason *string    `json:"rejection_reason,omitempty" db:"rejection_reason"`
+}
+
+// OrderRevisionApproval records an approver's approval of a revision
+type OrderRevisionApproval struct {
+	Role       string    `json:"role"`
+	ApprovedBy uuid.UUID `json:"approved_by"`
+	ApprovedAt time.Time `json:"approved_at"`
+}
+
+// OrderRevisionChange is one difference between a revision and the one before
+type OrderRevisionChange struct {
+	Field       string     `json:"field"`
+	OrderItemID *uuid.UUID `json:"order_item_id,omitempty"`
+	ProductSKU  string     `json:"product_sku,omitempty"`
+	From        string     `json:"from,omitempty"`
+	To          string     `json:"to,omitempty"`
+}
+
+// OrderAmendment is a change to the lines, prices or addresses of a confirmed order
+type OrderAmendment struct {
+	Lines             []OrderAmendmentLine `json:"lines,omitempty"`
+	ShippingAddressID *uuid.UUID           `json:"shipping_address_id,omitempty"`
+	BillingAddressID  *uuid.UUID           `json:"billing_address_id,omitempty"`
+}
+
+// OrderAmendmentLine changes one line of an order. Lines without an order
+// item ID are added to the order; only the fields set are changed.
+type OrderAmendmentLine struct {
+	OrderItemID    *uuid.UUID       `json:"order_item_id,omitempty"`
+	ProductID      *uuid.UUID       `json:"product_id,omitempty"`
+	Quantity       *int             `json:"quantity,omitempty"`
+	UnitPrice      *decimal.Decimal `json:"unit_price,omitempty"`
+	DiscountAmount *decimal.Decimal `json:"discount_amount,omitempty"`
+	Remove         bool             `json:"remove,omitempty"`
+}
+
+// OrderSnapshot is the state of an order's lines, addresses and totals
+type OrderSnapshot struct {
+	Lines             []OrderSnapshotLine `json:"lines"`
+	ShippingAddressID uuid.UUID           `json:"shipping_address_id"`
+	BillingAddressID  uuid.UUID           `json:"billing_address_id"`
+	Subtotal          decimal.Decimal     `json:"subtotal"`
+	TaxAmount         decimal.Decimal     `json:"tax_amount"`
+	ShippingAmount    decimal.Decimal     `json:"shipping_amount"`
+	DiscountAmount    decimal.Decimal     `json:"discount_amount"`
+	TotalAmount       decimal.Decimal     `json:"total_amount"`
+	Currency          string              `json:"currency"`
+}
+
+// OrderSnapshotLine is the state of an order line
+type OrderSnapshotLine struct {
+	OrderItemID     uuid.UUID       `json:"order_item_id"`
+	ProductID       uuid.UUID       `json:"product_id"`
+	ProductSKU      string          `json:"product_sku"`
+	ProductName     string          `json:"product_name"`
+	Quantity        int             `json:"quantity"`
+	QuantityShipped int             `json:"quantity_shipped"`
+	UnitPrice       decimal.Decimal `json:"unit_price"`
+	DiscountAmount  decimal.Decimal `json:"discount_amount"`
+	TaxAmount       decimal.Decimal `json:"tax_amount"`
+	TotalPrice      decimal.Decimal `json:"total_price"`
+}
+
+// NewOrderSnapshot captures the lines, addresses and totals of an order
+func NewOrderSnapshot(order *Order) OrderSnapshot {
+	snapshot := OrderSnapshot{
+		Lines:             make([]OrderSnapshotLine, len(order.Items)),
+		ShippingAddressID: order.ShippingAddressID,
+		BillingAddressID:  order.BillingAddressID,
+		Subtotal:          order.Subtotal,
+		TaxAmount:         order.TaxAmount,
+		ShippingAmount:    order.ShippingAmount,
+		DiscountAmount:    order.DiscountAmount,
+		TotalAmount:       order.TotalAmount,
+		Currency:          order.Currency,
+	}
+	for i, item := range order.Items {
+		snapshot.Lines[i] = OrderSnapshotLine{
+			OrderItemID:     item.ID,
+			ProductID:       item.ProductID,
+			ProductSKU:      item.ProductSKU,
+			ProductName:     item.ProductName,
+			Quantity:        item.Quantity,
+			QuantityShipped: item.QuantityShipped,
+			UnitPrice:       item.UnitPrice,
+			DiscountAmount:  item.DiscountAmount,
+			TaxAmount:       item.TaxAmount,
+			TotalPrice:      item.TotalPrice,
+		}
+	}
+	return snapshot
+}
+
+// DiffOrderSnapshots lists the changes from one snapshot of an order to the
+// next: removed lines, then changed and added lines in the order of the new
+// snapshot, then addresses and the total
+func DiffOrderSnapshots(before, after OrderSnapshot) []OrderRevisionChange {
+	var changes []OrderRevisionChange
+
+	previous := make(map[uuid.UUID]OrderSnapshotLine, len(before.Lines))
+	for _, line := range before.Lines {
+		previous[line.OrderItemID] = line
+	}
+	kept := make(map[uuid.UUID]bool, len(after.Lines))
+	for _, line := range after.Lines {
+		kept[line.OrderItemID] = true
+	}
+
+	for _, line := range before.Lines {
+		if !kept[line.OrderItemID] {
+			changes = append(changes, lineChange(RevisionFieldLineRemoved, line, describeLine(line), ""))
+		}
+	}
+
+	for _, line := range after.Lines {
+		old, ok := previous[line.OrderItemID]
+		if !ok {
+			changes = append(changes, lineChange(RevisionFieldLineAdded, line, "", describeLine(line)))
+			continue
+		}
+		if old.Quantity != line.Quantity {
+			changes = append(changes, lineChange(RevisionFieldQuantity, line, fmt.Sprint(old.Quantity), fmt.Sprint(line.Quantity)))
+		}
+		if !old.UnitPrice.Equal(line.UnitPrice) {
+			changes = append(changes, lineChange(RevisionFieldUnitPrice, line, old.UnitPrice.StringFixed(2), line.UnitPrice.StringFixed(2)))
+		}
+		if !old.DiscountAmount.Equal(line.DiscountAmount) {
+			changes = append(changes, lineChange(RevisionFieldDiscountAmount, line, old.DiscountAmount.StringFixed(2), line.DiscountAmount.StringFixed(2)))
+		}
+	}
+
+	if before.ShippingAddressID != after.ShippingAddressID {
+		changes = append(changes, OrderRevisionChange{Field: RevisionFieldShippingAddress, From: before.ShippingAddressID.String(), To: after.ShippingAddressID.String()})
+	}
+	if before.BillingAddressID != after.BillingAddressID {
+		changes = append(changes, OrderRevisionChange{Field: RevisionFieldBillingAddress, From: before.BillingAddressID.String(), To: after.BillingAddressID.String()})
+	}
+	if !before.TotalAmount.Equal(after.TotalAmount) {
+		changes = append(changes, OrderRevisionChange{Field: RevisionFieldTotalAmount, From: before.TotalAmount.StringFixed(2), To: after.TotalAmount.StringFixed(2)})
+	}
+
+	return changes
+}
+
+func lineChange(field string, line OrderSnapshotLine, from, to string) OrderRevisionChange {
+	itemID := line.OrderItemID
+	return OrderRevisionChange{Field: field, OrderItemID: &itemID, ProductSKU: line.ProductSKU, From: from, To: to}
+}
+
+func describeLine(line OrderSnapshotLine) string {
+	return fmt.Sprintf("%d x %s at %s", line.Quantity, line.ProductSKU, line.UnitPrice.StringFixed(2))
+}
+
+// Validate validates an amendment before it is applied to an order
+func (a *OrderAmendment) Validate() error {
+	if len(a.Lines) == 0 && a.ShippingAddressID == nil && a.BillingAddressID == nil {
+		return errors.New("amendment must change a line or an address")
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	for i, line := range a.Lines {
+		if line.OrderItemID == nil {
+			if line.Remove {
+				return fmt.Errorf("line %d: only existing lines can be removed", i+1)
+			}
+			if line.ProductID == nil || line.Quantity == nil {
+				return fmt.Errorf("line %d: added lines need a product and a quantity", i+1)
+			}
+		} else {
+			if seen[*line.OrderItemID] {
+				return fmt.Errorf("line %d: order item %s is amended more than once", i+1, line.OrderItemID)
+			}
+			seen[*line.OrderItemID] = true
+
+			if line.ProductID != nil {
+				return fmt.Errorf("line %d: the product of an existing line cannot be changed", i+1)
+			}
+			if line.Remove && (line.Quantity != nil || line.UnitPrice != nil || line.DiscountAmount != nil) {
+				return fmt.Errorf("line %d: a removed line cannot also be changed", i+1)
+			}
+			if !line.Remove && line.Quantity == nil && line.UnitPrice == nil && line.DiscountAmount == nil {
+				return fmt.Errorf("line %d: nothing to change", i+1)
+			}
+		}
+
+		if line.Quantity != nil && *line.Quantity <= 0 {
+			return fmt.Errorf("line %d: quantity must be positive", i+1)
+		}
+		if line.UnitPrice != nil && line.UnitPrice.IsNegative() {
+			return fmt.Errorf("line %d: unit price cannot be negative", i+1)
+		}
+		if line.DiscountAmount != nil && line.DiscountAmount.IsNegative() {
+			return fmt.Errorf("line %d: discount amount cannot be negative", i+1)
+		}
+	}
+
+	return nil
+}
+
+// Validate validates the order revision entity
+func (r *OrderRevision) Validate() error {
+	if r.OrderID == uuid.Nil {
+		return errors.New("order ID is required")
+	}
+	if r.RevisionNumber <= 0 {
+		return errors.New("revision number must be positive")
+	}
+	switch r.Status {
+	case OrderRevisionStatusApplied, OrderRevisionStatusPendingApproval, OrderRevisionStatusRejected:
+	default:
+		return fmt.Errorf("invalid revision status: %s", r.Status)
+	}
+	if r.RevisionNumber > 1 && r.Amendment == nil {
+		return errors.New("amendment is required")
+	}
+	if r.Status == OrderRevisionStatusPendingApproval && len(r.ApproverRoles) == 0 {
+		return errors.New("a pending revision needs approver roles")
+	}
+	if r.Reason != nil && len(*r.Reason) > 500 {
+		return errors.New("reason cannot exceed 500 characters")
+	}
+	if r.CreatedBy == uuid.Nil {
+		return errors.New("created by is required")
+	}
+	return nil
+}
+
+// IsPending reports whether the revision is waiting for approval
+func (r *OrderRevision) IsPending() bool {
+	return r.Status == OrderRevisionStatusPendingApproval
+}
+
+// NextApproverRole returns the role that approves the revision next, or an
+// empty string when no approval is awaited
+func (r *OrderRevision) NextApproverRole() string {
+	if !r.IsPending() || len(r.Approvals) >= len(r.ApproverRoles) {
+		return ""
+	}
+	return r.ApproverRoles[len(r.Approvals)]
+}
+
+// Approve records the approval of the next approver role and reports
+// whether every role has now approved
+func (r *OrderRevision) Approve(approvedBy uuid.UUID, at time.Time) (bool, error) {
+	role := r.NextApproverRole()
+	if role == "" {
+		return false, fmt.Errorf("revision %d is %s", r.RevisionNumber, strings.ToLower(string(r.Status)))
+	}
+
+	r.Approvals = append(r.Approvals, OrderRevisionApproval{Role: role, ApprovedBy: approvedBy, ApprovedAt: at})
+	return len(r.Approvals) == len(r.ApproverRoles), nil
+}
+
+// Reject rejects a pending revision, leaving the order unchanged
+func (r *OrderRevision) Reject(rejectedBy uuid.UUID, reason string, at time.Time) error {
+	if !r.IsPending() {
+		return fmt.Errorf("revision %d is %s", r.RevisionNumber, strings.ToLower(string(r.Status)))
+	}
+
+	r.Status = OrderRevisionStatusRejected
+	r.RejectedBy = &rejectedBy
+	r.RejectedAt = &at
+	if reason = strings.TrimSpace(reason); reason != "" {
+		r.RejectionReason = &reason
+	}
+	return nil
+}
+
+// MarkApplied records that the revision's changes were made to the order
+func (r *OrderRevision) MarkApplied(at time.Time) {
+	r.Status = OrderRevisionStatusApplied
+	r.AppliedAt = &at
+}
+
+// ApprovesFor reports whether the revision was approved by every role at an
+// amount of at least the one given
+func (r *OrderRevision) ApprovesFor(roles []string, amount decimal.Decimal) bool {
+	if r.Status != OrderRevisionStatusApplied || r.ApprovalAmount.LessThan(amount) {
+		return false
+	}
+	approved := make(map[string]bool, len(r.Approvals))
+	for _, approval := range r.Approvals {
+		approved[approval.Role] = true
+	}
+	for _, role := range roles {
+		if !approved[role] {
+			return false
+		}
+	}
+	return true
+}
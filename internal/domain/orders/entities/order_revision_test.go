@@ -0,0 +1,143 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderAmendment_Validate(t *testing.T) {
+	itemID := uuid.New()
+	productID := uuid.New()
+	quantity := 3
+	zero := 0
+	negative := decimal.NewFromInt(-1)
+
+	tests := []struct {
+		name      string
+		amendment OrderAmendment
+		wantErr   string
+	}{
+		{"empty", OrderAmendment{}, "must change a line or an address"},
+		{"address only", OrderAmendment{ShippingAddressID: &itemID}, ""},
+		{"quantity change", OrderAmendment{Lines: []OrderAmendmentLine{{OrderItemID: &itemID, Quantity: &quantity}}}, ""},
+		{"added line", OrderAmendment{Lines: []OrderAmendmentLine{{ProductID: &productID, Quantity: &quantity}}}, ""},
+		{"added line without quantity", OrderAmendment{Lines: []OrderAmendmentLine{{ProductID: &productID}}}, "need a product and a quantity"},
+		{"remove new line", OrderAmendment{Lines: []OrderAmendmentLine{{Remove: true}}}, "only existing lines"},
+		{"remove and change", OrderAmendment{Lines: []OrderAmendmentLine{{OrderItemID: &itemID, Remove: true, Quantity: &quantity}}}, "cannot also be changed"},
+		{"nothing to change", OrderAmendment{Lines: []OrderAmendmentLine{{OrderItemID: &itemID}}}, "nothing to change"},
+		{"change product", OrderAmendment{Lines: []OrderAmendmentLine{{OrderItemID: &itemID, ProductID: &productID, Quantity: &quantity}}}, "cannot be changed"},
+		{"duplicate line", OrderAmendment{Lines: []OrderAmendmentLine{{OrderItemID: &itemID, Quantity: &quantity}, {OrderItemID: &itemID, Remove: true}}}, "more than once"},
+		{"zero quantity", OrderAmendment{Lines: []OrderAmendmentLine{{OrderItemID: &itemID, Quantity: &zero}}}, "quantity must be positive"},
+		{"negative price", OrderAmendment{Lines: []OrderAmendmentLine{{OrderItemID: &itemID, UnitPrice: &negative}}}, "unit price cannot be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.amendment.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestDiffOrderSnapshots(t *testing.T) {
+	order := generateTestOrder(t)
+	kept := generateTestOrderItem(t, order.ID)
+	removed := generateTestOrderItem(t, order.ID)
+	removed.ProductSKU = "PROD002"
+	order.Items = []OrderItem{*kept, *removed}
+	before := NewOrderSnapshot(order)
+
+	added := generateTestOrderItem(t, order.ID)
+	added.ProductSKU = "PROD003"
+	kept.Quantity = 5
+	kept.UnitPrice = decimal.NewFromFloat(27.50)
+	order.Items = []OrderItem{*kept, *added}
+	order.ShippingAddressID = uuid.New()
+	order.TotalAmount = decimal.NewFromFloat(200)
+
+	changes := DiffOrderSnapshots(before, NewOrderSnapshot(order))
+	require.Len(t, changes, 6)
+
+	assert.Equal(t, RevisionFieldLineRemoved, changes[0].Field)
+	assert.Equal(t, removed.ID, *changes[0].OrderItemID)
+	assert.Equal(t, "2 x PROD002 at 29.99", changes[0].From)
+
+	assert.Equal(t, RevisionFieldQuantity, changes[1].Field)
+	assert.Equal(t, "2", changes[1].From)
+	assert.Equal(t, "5", changes[1].To)
+	assert.Equal(t, RevisionFieldUnitPrice, changes[2].Field)
+	assert.Equal(t, "27.50", changes[2].To)
+
+	assert.Equal(t, RevisionFieldLineAdded, changes[3].Field)
+	assert.Equal(t, "PROD003", changes[3].ProductSKU)
+	assert.Equal(t, RevisionFieldShippingAddress, changes[4].Field)
+	assert.Equal(t, RevisionFieldTotalAmount, changes[5].Field)
+	assert.Equal(t, "113.25", changes[5].From)
+
+	assert.Empty(t, DiffOrderSnapshots(before, before))
+}
+
+func TestOrderRevision_Approvals(t *testing.T) {
+	revision := &OrderRevision{
+		OrderID:        uuid.New(),
+		RevisionNumber: 2,
+		Status:         OrderRevisionStatusPendingApproval,
+		Amendment:      &OrderAmendment{},
+		ApproverRoles:  []string{"manager", "finance"},
+		ApprovalAmount: decimal.NewFromInt(15000),
+		CreatedBy:      uuid.New(),
+	}
+	require.NoError(t, revision.Validate())
+	assert.Equal(t, "manager", revision.NextApproverRole())
+
+	now := time.Now()
+	complete, err := revision.Approve(uuid.New(), now)
+	require.NoError(t, err)
+	assert.False(t, complete)
+	assert.Equal(t, "finance", revision.NextApproverRole())
+
+	complete, err = revision.Approve(uuid.New(), now)
+	require.NoError(t, err)
+	assert.True(t, complete)
+
+	assert.False(t, revision.ApprovesFor([]string{"manager"}, decimal.NewFromInt(100)), "only applied revisions approve later amendments")
+	revision.MarkApplied(now)
+	assert.Equal(t, "", revision.NextApproverRole())
+	assert.True(t, revision.ApprovesFor([]string{"manager", "finance"}, decimal.NewFromInt(15000)))
+	assert.False(t, revision.ApprovesFor([]string{"manager", "director"}, decimal.NewFromInt(100)))
+	assert.False(t, revision.ApprovesFor([]string{"manager"}, decimal.NewFromInt(16000)))
+
+	_, err = revision.Approve(uuid.New(), now)
+	require.Error(t, err)
+	require.Error(t, revision.Reject(uuid.New(), "too late", now))
+}
+
+func TestOrderRevision_Reject(t *testing.T) {
+	revision := &OrderRevision{
+		OrderID:        uuid.New(),
+		RevisionNumber: 3,
+		Status:         OrderRevisionStatusPendingApproval,
+		Amendment:      &OrderAmendment{},
+		ApproverRoles:  []string{"manager"},
+		CreatedBy:      uuid.New(),
+	}
+
+	require.NoError(t, revision.Reject(uuid.New(), "  price too low ", time.Now()))
+	assert.Equal(t, OrderRevisionStatusRejected, revision.Status)
+	assert.Equal(t, "price too low", *revision.RejectionReason)
+	assert.Equal(t, "", revision.NextApproverRole())
+
+	revision.Status = OrderRevisionStatusPendingApproval
+	revision.ApproverRoles = nil
+	assert.Error(t, revision.Validate())
+}
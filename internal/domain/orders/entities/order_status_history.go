@@ -0,0 +1,179 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// StatusChangeSource identifies what triggered a status change
+type StatusChangeSource string
+
+const (
+	StatusChangeSourceAPI    StatusChangeSource = "API"
+	StatusChangeSourceBulk   StatusChangeSource = "BULK"
+	StatusChangeSourceSystem StatusChangeSource = "SYSTEM"
+)
+
+// StatusHistoryType distinguishes order status changes from payment status changes
+type StatusHistoryType string
+
+const (
+	StatusHistoryTypeOrder   StatusHistoryType = "ORDER"
+	StatusHistoryTypePayment StatusHistoryType = "PAYMENT"
+)
+
+// OrderStatusHistory is an append-only record of a status change on an order
+type OrderStatusHistory struct {
+	ID         uuid.UUID          `json:"id" db:"id"`
+	OrderID    uuid.UUID          `json:"order_id" db:"order_id"`
+	Type       StatusHistoryType  `json:"type" db:"status_type"`
+	FromStatus *string            `json:"from_status,omitempty" db:"from_status"`
+	ToStatus   string             `json:"to_status" db:"to_status"`
+	Amount     *decimal.Decimal   `json:"amount,omitempty" db:"amount"`
+	ChangedBy  *uuid.UUID         `json:"changed_by,omitempty" db:"changed_by"`
+	Reason     string             `json:"reason,omitempty" db:"reason"`
+	Source     StatusChangeSource `json:"source" db:"source"`
+	CreatedAt  time.Time          `json:"created_at" db:"created_at"`
+}
+
+// Validate validates the status history entry
+func (h *OrderStatusHistory) Validate() error {
+	var errs []error
+
+	if h.OrderID == uuid.Nil {
+		errs = append(errs, errors.New("order ID cannot be empty"))
+	}
+
+	switch h.Type {
+	case StatusHistoryTypeOrder, StatusHistoryTypePayment:
+	default:
+		errs = append(errs, fmt.Errorf("invalid status history type: %s", h.Type))
+	}
+
+	if h.ToStatus == "" {
+		errs = append(errs, errors.New("to status cannot be empty"))
+	}
+
+	switch h.Source {
+	case StatusChangeSourceAPI, StatusChangeSourceBulk, StatusChangeSourceSystem:
+	default:
+		errs = append(errs, fmt.Errorf("invalid status change source: %s", h.Source))
+	}
+
+	if len(h.Reason) > 1000 {
+		errs = append(errs, errors.New("reason cannot exceed 1000 characters"))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("validation failed: %v", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// TimelineEventType categorizes events on an order timeline
+type TimelineEventType string
+
+const (
+	TimelineEventStatus   TimelineEventType = "STATUS"
+	TimelineEventPayment  TimelineEventType = "PAYMENT"
+	TimelineEventShipment TimelineEventType = "SHIPMENT"
+)
+
+// OrderTimelineEvent is a single entry on an order's timeline
+type OrderTimelineEvent struct {
+	Type           TimelineEventType  `json:"type"`
+	OccurredAt     time.Time          `json:"occurred_at"`
+	Description    string             `json:"description"`
+	FromStatus     *string            `json:"from_status,omitempty"`
+	ToStatus       string             `json:"to_status,omitempty"`
+	Amount         *decimal.Decimal   `json:"amount,omitempty"`
+	ActorID        *uuid.UUID         `json:"actor_id,omitempty"`
+	Source         StatusChangeSource `json:"source,omitempty"`
+	TrackingNumber *string            `json:"tracking_number,omitempty"`
+	Carrier        *string            `json:"carrier,omitempty"`
+}
+
+// BuildOrderTimeline merges an order's status history with its shipment
+// events into a single chronological timeline
+func BuildOrderTimeline(order *Order, history []*OrderStatusHistory, shipments []*Shipment) []OrderTimelineEvent {
+	events := make([]OrderTimelineEvent, 0, len(history)+2*len(shipments)+2)
+
+	for _, entry := range history {
+		event := OrderTimelineEvent{
+			OccurredAt: entry.CreatedAt,
+			FromStatus: entry.FromStatus,
+			ToStatus:   entry.ToStatus,
+			Amount:     entry.Amount,
+			ActorID:    entry.ChangedBy,
+			Source:     entry.Source,
+		}
+
+		if entry.Type == StatusHistoryTypePayment {
+			event.Type = TimelineEventPayment
+			event.Description = "Payment status " + entry.ToStatus
+		} else {
+			event.Type = TimelineEventStatus
+			event.Description = "Order status " + entry.ToStatus
+		}
+		if entry.Reason != "" {
+			event.Description += ": " + entry.Reason
+		}
+
+		events = append(events, event)
+	}
+
+	for _, shipment := range shipments {
+		events = append(events, OrderTimelineEvent{
+			Type:           TimelineEventShipment,
+			OccurredAt:     shipment.ShippedAt,
+			Description:    "Shipment " + shipment.ShipmentNumber + " dispatched",
+			ActorID:        &shipment.ShippedBy,
+			TrackingNumber: shipment.TrackingNumber,
+			Carrier:        shipment.Carrier,
+		})
+		if shipment.DeliveredAt != nil {
+			events = append(events, OrderTimelineEvent{
+				Type:           TimelineEventShipment,
+				OccurredAt:     *shipment.DeliveredAt,
+				Description:    "Shipment " + shipment.ShipmentNumber + " delivered",
+				ActorID:        shipment.DeliveredBy,
+				TrackingNumber: shipment.TrackingNumber,
+				Carrier:        shipment.Carrier,
+			})
+		}
+	}
+
+	// Orders shipped before shipments were recorded only carry the tracking
+	// of their last wave on the order itself
+	if len(shipments) == 0 && order.ShippedAt != nil {
+		events = append(events, OrderTimelineEvent{
+			Type:           TimelineEventShipment,
+			OccurredAt:     *order.ShippedAt,
+			Description:    "Shipment dispatched",
+			ActorID:        order.ShippedBy,
+			TrackingNumber: order.TrackingNumber,
+			Carrier:        order.Carrier,
+		})
+	}
+	if len(shipments) == 0 && order.DeliveryDate != nil {
+		events = append(events, OrderTimelineEvent{
+			Type:           TimelineEventShipment,
+			OccurredAt:     *order.DeliveryDate,
+			Description:    "Shipment delivered",
+			TrackingNumber: order.TrackingNumber,
+			Carrier:        order.Carrier,
+		})
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].OccurredAt.Before(events[j].OccurredAt)
+	})
+
+	return events
+}
@@ -0,0 +1,64 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderStatusHistory_Validate(t *testing.T) {
+	from := string(OrderStatusPending)
+	entry := &OrderStatusHistory{
+		OrderID:    uuid.New(),
+		Type:       StatusHistoryTypeOrder,
+		FromStatus: &from,
+		ToStatus:   string(OrderStatusConfirmed),
+		Source:     StatusChangeSourceAPI,
+	}
+	assert.NoError(t, entry.Validate())
+
+	entry.Source = "CRON"
+	err := entry.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid status change source")
+
+	entry.Source = StatusChangeSourceSystem
+	entry.ToStatus = ""
+	err = entry.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "to status cannot be empty")
+}
+
+func TestBuildOrderTimeline(t *testing.T) {
+	base := time.Date(2026, time.January, 10, 9, 0, 0, 0, time.UTC)
+	order := generateTestOrder(t)
+
+	shippedAt := base.Add(2 * time.Hour)
+	tracking := "1Z999"
+	order.ShippedAt = &shippedAt
+	order.TrackingNumber = &tracking
+
+	pending := string(OrderStatusPending)
+	paymentPending := string(PaymentStatusPending)
+	amount := decimal.NewFromInt(50)
+	history := []*OrderStatusHistory{
+		{Type: StatusHistoryTypeOrder, ToStatus: pending, Reason: "created", Source: StatusChangeSourceAPI, CreatedAt: base},
+		{Type: StatusHistoryTypeOrder, FromStatus: &pending, ToStatus: string(OrderStatusConfirmed), Source: StatusChangeSourceBulk, CreatedAt: base.Add(time.Hour)},
+		{Type: StatusHistoryTypePayment, FromStatus: &paymentPending, ToStatus: string(PaymentStatusPartiallyPaid), Amount: &amount, Source: StatusChangeSourceAPI, CreatedAt: base.Add(3 * time.Hour)},
+	}
+
+	events := BuildOrderTimeline(order, history, nil)
+	require.Len(t, events, 4)
+
+	assert.Equal(t, TimelineEventStatus, events[0].Type)
+	assert.Equal(t, "Order status PENDING: created", events[0].Description)
+	assert.Equal(t, StatusChangeSourceBulk, events[1].Source)
+	assert.Equal(t, TimelineEventShipment, events[2].Type)
+	assert.Equal(t, &tracking, events[2].TrackingNumber)
+	assert.Equal(t, TimelineEventPayment, events[3].Type)
+	assert.True(t, events[3].Amount.Equal(amount))
+}
@@ -0,0 +1,252 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// PaymentType distinguishes money received from money paid back
+type PaymentType string
+
+const (
+	PaymentTypePayment PaymentType = "PAYMENT"
+	PaymentTypeRefund  PaymentType = "REFUND"
+)
+
+// PaymentMethod represents how a payment was made
+type PaymentMethod string
+
+const (
+	PaymentMethodCash          PaymentMethod = "CASH"
+	PaymentMethodCheque        PaymentMethod = "CHEQUE"
+	PaymentMethodBankTransfer  PaymentMethod = "BANK_TRANSFER"
+	PaymentMethodCreditCard    PaymentMethod = "CREDIT_CARD"
+	PaymentMethodDebitCard     PaymentMethod = "DEBIT_CARD"
+	PaymentMethodDigitalWallet PaymentMethod = "DIGITAL_WALLET"
+	PaymentMethodOther         PaymentMethod = "OTHER"
+)
+
+// PaymentEntryStatus represents the status of a ledger entry
+type PaymentEntryStatus string
+
+const (
+	PaymentEntryStatusCompleted PaymentEntryStatus = "COMPLETED"
+	PaymentEntryStatusReversed  PaymentEntryStatus = "REVERSED"
+)
+
+// DocumentTypePayment numbers payments and refunds
+const DocumentTypePayment DocumentType = "PAYMENT"
+
+var validPaymentMethods = []PaymentMethod{
+	PaymentMethodCash, PaymentMethodCheque, PaymentMethodBankTransfer, PaymentMethodCreditCard,
+	PaymentMethodDebitCard, PaymentMethodDigitalWallet, PaymentMethodOther,
+}
+
+// ParsePaymentMethod normalizes a payment method such as "credit card" or
+// "bank-transfer" and reports whether it is known
+func ParsePaymentMethod(value string) (PaymentMethod, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(value))
+	normalized = strings.NewReplacer(" ", "_", "-", "_").Replace(normalized)
+	for _, method := range validPaymentMethods {
+		if PaymentMethod(normalized) == method {
+			return method, nil
+		}
+	}
+	return "", fmt.Errorf("invalid payment method: %s", value)
+}
+
+// Payment is an entry of the payment ledger: money received from a customer
+// or refunded to them. A payment may be allocated across several orders of the
+// customer; a refund is allocated to the order it refunds. Order paid and
+// refunded amounts are derived from the completed entries allocated to them.
+type Payment struct {
+	ID             uuid.UUID          `json:"id" db:"id"`
+	PaymentNumber  string             `json:"payment_number" db:"payment_number"`
+	Type           PaymentType        `json:"type" db:"type"`
+	CustomerID     uuid.UUID          `json:"customer_id" db:"customer_id"`
+	Method         PaymentMethod      `json:"method" db:"method"`
+	Reference      *string            `json:"reference,omitempty" db:"reference"`
+	Amount         decimal.Decimal    `json:"amount" db:"amount"`
+	Currency       string             `json:"currency" db:"currency"`
+	ReceivedAt     time.Time          `json:"received_at" db:"received_at"`
+	Status         PaymentEntryStatus `json:"status" db:"status"`
+	Notes          *string            `json:"notes,omitempty" db:"notes"`
+	ReversedAt     *time.Time         `json:"reversed_at,omitempty" db:"reversed_at"`
+	ReversedBy     *uuid.UUID         `json:"reversed_by,omitempty" db:"reversed_by"`
+	ReversalReason *string            `json:"reversal_reason,omitempty" db:"reversal_reason"`
+	CreatedBy      uuid.UUID          `json:"created_by" db:"created_by"`
+	CreatedAt      time.Time          `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at" db:"updated_at"`
+
+	Allocations []PaymentAllocation `json:"allocations,omitempty" db:"-"`
+}
+
+// PaymentAllocation assigns part of a payment to an order
+type PaymentAllocation struct {
+	ID        uuid.UUID       `json:"id" db:"id"`
+	PaymentID uuid.UUID       `json:"payment_id" db:"payment_id"`
+	OrderID   uuid.UUID       `json:"order_id" db:"order_id"`
+	Amount    decimal.Decimal `json:"amount" db:"amount"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+}
+
+// Validate validates the payment and its allocations
+func (p *Payment) Validate() error {
+	if p.Type != PaymentTypePayment && p.Type != PaymentTypeRefund {
+		return fmt.Errorf("invalid payment type: %s", p.Type)
+	}
+	if _, err := ParsePaymentMethod(string(p.Method)); err != nil {
+		return err
+	}
+	if p.CustomerID == uuid.Nil {
+		return errors.New("customer ID is required")
+	}
+	if !p.Amount.IsPositive() {
+		return errors.New("payment amount must be positive")
+	}
+	if len(p.Currency) != 3 {
+		return errors.New("currency must be a 3-letter code")
+	}
+	if p.ReceivedAt.IsZero() {
+		return errors.New("received date is required")
+	}
+	if p.Status != PaymentEntryStatusCompleted && p.Status != PaymentEntryStatusReversed {
+		return fmt.Errorf("invalid payment status: %s", p.Status)
+	}
+
+	for _, allocation := range p.Allocations {
+		if allocation.OrderID == uuid.Nil {
+			return errors.New("allocation order ID is required")
+		}
+		if !allocation.Amount.IsPositive() {
+			return errors.New("allocation amount must be positive")
+		}
+	}
+	if p.AllocatedAmount().GreaterThan(p.Amount) {
+		return fmt.Errorf("allocations of %s exceed payment amount %s", p.AllocatedAmount(), p.Amount)
+	}
+	if p.Type == PaymentTypeRefund && !p.UnallocatedAmount().IsZero() {
+		return errors.New("refunds must be fully allocated to orders")
+	}
+
+	return nil
+}
+
+// IsReversed reports whether the entry was reversed
+func (p *Payment) IsReversed() bool {
+	return p.Status == PaymentEntryStatusReversed
+}
+
+// AllocatedAmount returns the part of the payment allocated to orders
+func (p *Payment) AllocatedAmount() decimal.Decimal {
+	total := decimal.Zero
+	for _, allocation := range p.Allocations {
+		total = total.Add(allocation.Amount)
+	}
+	return total
+}
+
+// UnallocatedAmount returns the part of the payment not yet allocated to an order
+func (p *Payment) UnallocatedAmount() decimal.Decimal {
+	return p.Amount.Sub(p.AllocatedAmount())
+}
+
+// AllocatedTo returns the amount allocated to an order
+func (p *Payment) AllocatedTo(orderID uuid.UUID) decimal.Decimal {
+	total := decimal.Zero
+	for _, allocation := range p.Allocations {
+		if allocation.OrderID == orderID {
+			total = total.Add(allocation.Amount)
+		}
+	}
+	return total
+}
+
+// Allocate assigns part of the unallocated amount to an order
+func (p *Payment) Allocate(orderID uuid.UUID, amount decimal.Decimal) error {
+	if p.IsReversed() {
+		return errors.New("payment has been reversed")
+	}
+	if !amount.IsPositive() {
+		return errors.New("allocation amount must be positive")
+	}
+	if amount.GreaterThan(p.UnallocatedAmount()) {
+		return fmt.Errorf("allocation of %s exceeds unallocated amount %s", amount, p.UnallocatedAmount())
+	}
+
+	now := time.Now().UTC()
+	p.Allocations = append(p.Allocations, PaymentAllocation{
+		ID:        uuid.New(),
+		PaymentID: p.ID,
+		OrderID:   orderID,
+		Amount:    amount,
+		CreatedAt: now,
+	})
+	p.UpdatedAt = now
+	return nil
+}
+
+// Reverse voids the entry, e.g. for a bounced cheque or a charge-back. The
+// allocations are kept for the audit trail but no longer count towards orders.
+func (p *Payment) Reverse(reversedBy uuid.UUID, reason string) error {
+	if p.IsReversed() {
+		return errors.New("payment has already been reversed")
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return errors.New("reversal reason is required")
+	}
+
+	now := time.Now().UTC()
+	p.Status = PaymentEntryStatusReversed
+	p.ReversedAt = &now
+	p.ReversedBy = &reversedBy
+	p.ReversalReason = &reason
+	p.UpdatedAt = now
+	return nil
+}
+
+// PaymentTotals sums the completed payments and refunds allocated to an order
+func PaymentTotals(orderID uuid.UUID, payments []*Payment) (paid, refunded decimal.Decimal) {
+	paid, refunded = decimal.Zero, decimal.Zero
+	for _, payment := range payments {
+		if payment.IsReversed() {
+			continue
+		}
+		amount := payment.AllocatedTo(orderID)
+		if payment.Type == PaymentTypeRefund {
+			refunded = refunded.Add(amount)
+		} else {
+			paid = paid.Add(amount)
+		}
+	}
+	return paid, refunded
+}
+
+// ApplyPaymentLedger derives the paid and refunded amounts and the payment
+// status from the ledger entries allocated to the order
+func (o *Order) ApplyPaymentLedger(payments []*Payment) {
+	paid, refunded := PaymentTotals(o.ID, payments)
+
+	o.PaidAmount = paid
+	o.RefundedAmount = refunded
+	o.UpdatedAt = time.Now().UTC()
+
+	switch {
+	case paid.IsPositive() && refunded.GreaterThanOrEqual(paid):
+		o.PaymentStatus = PaymentStatusRefunded
+	case paid.IsPositive() && paid.GreaterThanOrEqual(o.TotalAmount):
+		o.PaymentStatus = PaymentStatusPaid
+	case paid.IsPositive():
+		o.PaymentStatus = PaymentStatusPartiallyPaid
+	case o.PaymentStatus == PaymentStatusOverdue:
+		// Still unpaid past its due date
+	default:
+		o.PaymentStatus = PaymentStatusPending
+	}
+}
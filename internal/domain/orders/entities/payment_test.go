@@ -0,0 +1,103 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPayment(paymentType PaymentType, amount string) *Payment {
+	now := time.Now().UTC()
+	return &Payment{
+		ID:         uuid.New(),
+		Type:       paymentType,
+		CustomerID: uuid.New(),
+		Method:     PaymentMethodCheque,
+		Amount:     decimal.RequireFromString(amount),
+		Currency:   "USD",
+		ReceivedAt: now,
+		Status:     PaymentEntryStatusCompleted,
+		CreatedBy:  uuid.New(),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+func TestParsePaymentMethod(t *testing.T) {
+	method, err := ParsePaymentMethod("bank-transfer")
+	require.NoError(t, err)
+	assert.Equal(t, PaymentMethodBankTransfer, method)
+
+	method, err = ParsePaymentMethod(" credit card ")
+	require.NoError(t, err)
+	assert.Equal(t, PaymentMethodCreditCard, method)
+
+	_, err = ParsePaymentMethod("barter")
+	require.Error(t, err)
+}
+
+func TestPaymentAllocate(t *testing.T) {
+	payment := newTestPayment(PaymentTypePayment, "100")
+	first, second := uuid.New(), uuid.New()
+
+	require.NoError(t, payment.Allocate(first, decimal.NewFromInt(60)))
+	require.NoError(t, payment.Allocate(second, decimal.NewFromInt(30)))
+	assert.True(t, payment.UnallocatedAmount().Equal(decimal.NewFromInt(10)))
+	assert.True(t, payment.AllocatedTo(first).Equal(decimal.NewFromInt(60)))
+
+	err := payment.Allocate(second, decimal.NewFromInt(11))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exceeds unallocated amount")
+	require.NoError(t, payment.Validate())
+
+	refund := newTestPayment(PaymentTypeRefund, "20")
+	require.Error(t, refund.Validate(), "refunds must be fully allocated")
+	require.NoError(t, refund.Allocate(first, decimal.NewFromInt(20)))
+	require.NoError(t, refund.Validate())
+}
+
+func TestPaymentReverse(t *testing.T) {
+	payment := newTestPayment(PaymentTypePayment, "50")
+	require.Error(t, payment.Reverse(uuid.New(), " "))
+
+	require.NoError(t, payment.Reverse(uuid.New(), "cheque bounced"))
+	assert.True(t, payment.IsReversed())
+	assert.NotNil(t, payment.ReversedAt)
+	require.Error(t, payment.Reverse(uuid.New(), "again"))
+	require.Error(t, payment.Allocate(uuid.New(), decimal.NewFromInt(1)))
+}
+
+func TestOrderApplyPaymentLedger(t *testing.T) {
+	order := generateTestOrder(t)
+	order.TotalAmount = decimal.NewFromInt(100)
+
+	cheque := newTestPayment(PaymentTypePayment, "150")
+	require.NoError(t, cheque.Allocate(order.ID, decimal.NewFromInt(70)))
+	require.NoError(t, cheque.Allocate(uuid.New(), decimal.NewFromInt(80)))
+	card := newTestPayment(PaymentTypePayment, "30")
+	require.NoError(t, card.Allocate(order.ID, decimal.NewFromInt(30)))
+	ledger := []*Payment{cheque, card}
+
+	order.ApplyPaymentLedger(ledger)
+	assert.True(t, order.PaidAmount.Equal(decimal.NewFromInt(100)))
+	assert.Equal(t, PaymentStatusPaid, order.PaymentStatus)
+
+	require.NoError(t, cheque.Reverse(uuid.New(), "cheque bounced"))
+	order.ApplyPaymentLedger(ledger)
+	assert.True(t, order.PaidAmount.Equal(decimal.NewFromInt(30)))
+	assert.Equal(t, PaymentStatusPartiallyPaid, order.PaymentStatus)
+
+	refund := newTestPayment(PaymentTypeRefund, "30")
+	require.NoError(t, refund.Allocate(order.ID, decimal.NewFromInt(30)))
+	order.ApplyPaymentLedger(append(ledger, refund))
+	assert.True(t, order.RefundedAmount.Equal(decimal.NewFromInt(30)))
+	assert.Equal(t, PaymentStatusRefunded, order.PaymentStatus)
+
+	order.ApplyPaymentLedger(nil)
+	assert.True(t, order.PaidAmount.IsZero())
+	assert.Equal(t, PaymentStatusPending, order.PaymentStatus)
+}
@@ -0,0 +1,523 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// DocumentTypePickWave numbers pick waves
+const DocumentTypePickWave DocumentType = "PICK_WAVE"
+
+// PickWaveStatus represents the status of a pick wave
+type PickWaveStatus string
+
+const (
+	// PickWaveStatusReleased is a wave handed to the warehouse with no pick confirmed yet
+	PickWaveStatusReleased  PickWaveStatus = "RELEASED"
+	PickWaveStatusPicking   PickWaveStatus = "PICKING"
+	PickWaveStatusCompleted PickWaveStatus = "COMPLETED"
+	PickWaveStatusCancelled PickWaveStatus = "CANCELLED"
+)
+
+// PickWaveOrderStatus represents the progress of an order through a pick wave
+type PickWaveOrderStatus string
+
+const (
+	PickWaveOrderStatusPicking PickWaveOrderStatus = "PICKING"
+	// PickWaveOrderStatusPicked orders have every line confirmed and are ready to pack
+	PickWaveOrderStatusPicked PickWaveOrderStatus = "PICKED"
+	PickWaveOrderStatusPacked PickWaveOrderStatus = "PACKED"
+	// PickWaveOrderStatusShort orders had every line short picked and have nothing to pack
+	PickWaveOrderStatusShort     PickWaveOrderStatus = "SHORT"
+	PickWaveOrderStatusCancelled PickWaveOrderStatus = "CANCELLED"
+)
+
+// PickLineStatus represents the status of a pick line
+type PickLineStatus string
+
+const (
+	PickLineStatusPending PickLineStatus = "PENDING"
+	PickLineStatusPicked  PickLineStatus = "PICKED"
+	// PickLineStatusShort lines were picked with less than the requested quantity
+	PickLineStatusShort     PickLineStatus = "SHORT"
+	PickLineStatusCancelled PickLineStatus = "CANCELLED"
+)
+
+// PickWave groups orders of one warehouse that are picked together. Its
+// lines are the order quantities to take from the shelves; the pick list walks
+// them in location order. Orders are packed and shipped one at a time once
+// their lines are confirmed.
+type PickWave struct {
+	ID          uuid.UUID      `json:"id" db:"id"`
+	WaveNumber  string         `json:"wave_number" db:"wave_number"`
+	WarehouseID uuid.UUID      `json:"warehouse_id" db:"warehouse_id"`
+	Status      PickWaveStatus `json:"status" db:"status"`
+
+	// The grouping the wave was released for. Orders of the wave ship with
+	// Carrier, and only orders placed by CutoffAt were included.
+	ShippingMethod *ShippingMethod `json:"shipping_method,omitempty" db:"shipping_method"`
+	MinPriority    *OrderPriority  `json:"min_priority,omitempty" db:"min_priority"`
+	Carrier        *string         `json:"carrier,omitempty" db:"carrier"`
+	CutoffAt       *time.Time      `json:"cutoff_at,omitempty" db:"cutoff_at"`
+	Notes          *string         `json:"notes,omitempty" db:"notes"`
+
+	Orders []PickWaveOrder `json:"orders,omitempty" db:"-"`
+	Lines  []PickLine      `json:"lines,omitempty" db:"-"`
+
+	CreatedBy   uuid.UUID  `json:"created_by" db:"created_by"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+	CancelledAt *time.Time `json:"cancelled_at,omitempty" db:"cancelled_at"`
+}
+
+// PickWaveOrder is an order picked in a wave. Sequence is the order's place
+// in the wave, most urgent first.
+type PickWaveOrder struct {
+	ID          uuid.UUID           `json:"id" db:"id"`
+	WaveID      uuid.UUID           `json:"wave_id" db:"wave_id"`
+	OrderID     uuid.UUID           `json:"order_id" db:"order_id"`
+	OrderNumber string              `json:"order_number" db:"order_number"`
+	Priority    OrderPriority       `json:"priority" db:"priority"`
+	Sequence    int                 `json:"sequence" db:"sequence"`
+	Status      PickWaveOrderStatus `json:"status" db:"status"`
+	ShipmentID  *uuid.UUID          `json:"shipment_id,omitempty" db:"shipment_id"`
+	PackedBy    *uuid.UUID          `json:"packed_by,omitempty" db:"packed_by"`
+	PackedAt    *time.Time          `json:"packed_at,omitempty" db:"packed_at"`
+}
+
+// PickLine is a quantity of an order line to pick from a warehouse location.
+// Units short picked are no longer at the location; the quantities reserved
+// again elsewhere or backordered for the order record how the shortfall was
+// covered.
+type PickLine struct {
+	ID          uuid.UUID `json:"id" db:"id"`
+	WaveID      uuid.UUID `json:"wave_id" db:"wave_id"`
+	OrderID     uuid.UUID `json:"order_id" db:"order_id"`
+	OrderItemID uuid.UUID `json:"order_item_id" db:"order_item_id"`
+	ProductID   uuid.UUID `json:"product_id" db:"product_id"`
+	ProductSKU  string    `json:"product_sku" db:"product_sku"`
+	ProductName string    `json:"product_name" db:"product_name"`
+	Location    *string   `json:"location,omitempty" db:"location"`
+
+	Quantity            int            `json:"quantity" db:"quantity"`
+	QuantityPicked      int            `json:"quantity_picked" db:"quantity_picked"`
+	QuantityShort       int            `json:"quantity_short" db:"quantity_short"`
+	QuantityReallocated int            `json:"quantity_reallocated" db:"quantity_reallocated"`
+	QuantityBackordered int            `json:"quantity_backordered" db:"quantity_backordered"`
+	Status              PickLineStatus `json:"status" db:"status"`
+
+	PickedBy  *uuid.UUID `json:"picked_by,omitempty" db:"picked_by"`
+	PickedAt  *time.Time `json:"picked_at,omitempty" db:"picked_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// PickLocation is the shelf location a product is picked from in a warehouse
+type PickLocation struct {
+	WarehouseID uuid.UUID `json:"warehouse_id" db:"warehouse_id"`
+	ProductID   uuid.UUID `json:"product_id" db:"product_id"`
+	Location    string    `json:"location" db:"location"`
+	UpdatedBy   uuid.UUID `json:"updated_by" db:"updated_by"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Validate validates the pick wave. A wave without a number is accepted,
+// since the number is allocated when it is first persisted.
+func (w *PickWave) Validate() error {
+	var errs []error
+
+	if w.ID == uuid.Nil {
+		errs = append(errs, errors.New("pick wave ID cannot be empty"))
+	}
+	if w.WarehouseID == uuid.Nil {
+		errs = append(errs, errors.New("warehouse ID cannot be empty"))
+	}
+
+	switch w.Status {
+	case PickWaveStatusReleased, PickWaveStatusPicking, PickWaveStatusCompleted, PickWaveStatusCancelled:
+	default:
+		errs = append(errs, fmt.Errorf("invalid status: %s", w.Status))
+	}
+
+	if w.ShippingMethod != nil {
+		if err := (&Order{ShippingMethod: *w.ShippingMethod}).validateShippingMethod(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if w.MinPriority != nil {
+		if err := (&Order{}).validatePriorityValue(*w.MinPriority); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if w.Carrier != nil && len(*w.Carrier) > 50 {
+		errs = append(errs, errors.New("carrier name cannot exceed 50 characters"))
+	}
+	if w.CreatedBy == uuid.Nil {
+		errs = append(errs, errors.New("created by user cannot be empty"))
+	}
+
+	if len(w.Orders) == 0 {
+		errs = append(errs, errors.New("pick wave must have at least one order"))
+	}
+	orders := make(map[uuid.UUID]bool, len(w.Orders))
+	for _, order := range w.Orders {
+		if orders[order.OrderID] {
+			errs = append(errs, fmt.Errorf("order %s is in the wave more than once", order.OrderNumber))
+		}
+		orders[order.OrderID] = true
+	}
+
+	lines := make(map[uuid.UUID]bool, len(w.Lines))
+	for i := range w.Lines {
+		line := &w.Lines[i]
+		if err := line.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("invalid line %d: %w", i+1, err))
+		}
+		if !orders[line.OrderID] {
+			errs = append(errs, fmt.Errorf("line %d: order %s is not in the wave", i+1, line.OrderID))
+		}
+		if lines[line.OrderItemID] {
+			errs = append(errs, fmt.Errorf("order item %s is picked more than once", line.OrderItemID))
+		}
+		lines[line.OrderItemID] = true
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("validation failed: %v", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// Validate validates a pick line
+func (l *PickLine) Validate() error {
+	var errs []error
+
+	if l.OrderItemID == uuid.Nil {
+		errs = append(errs, errors.New("order item ID cannot be empty"))
+	}
+	if l.ProductID == uuid.Nil {
+		errs = append(errs, errors.New("product ID cannot be empty"))
+	}
+	if l.Quantity <= 0 {
+		errs = append(errs, errors.New("quantity must be positive"))
+	}
+	if l.QuantityPicked < 0 || l.QuantityShort < 0 || l.QuantityPicked+l.QuantityShort > l.Quantity {
+		errs = append(errs, fmt.Errorf("picked and short quantities must add up to at most %d", l.Quantity))
+	}
+	if l.QuantityReallocated < 0 || l.QuantityBackordered < 0 || l.QuantityReallocated+l.QuantityBackordered > l.QuantityShort {
+		errs = append(errs, errors.New("reallocated and backordered quantities cannot exceed the short quantity"))
+	}
+	if l.Location != nil && len(*l.Location) > 50 {
+		errs = append(errs, errors.New("location cannot exceed 50 characters"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Validate validates the pick location
+func (l *PickLocation) Validate() error {
+	var errs []error
+
+	if l.WarehouseID == uuid.Nil {
+		errs = append(errs, errors.New("warehouse ID cannot be empty"))
+	}
+	if l.ProductID == uuid.Nil {
+		errs = append(errs, errors.New("product ID cannot be empty"))
+	}
+	if strings.TrimSpace(l.Location) == "" {
+		errs = append(errs, errors.New("location is required"))
+	} else if len(l.Location) > 50 {
+		errs = append(errs, errors.New("location cannot exceed 50 characters"))
+	}
+	if l.UpdatedBy == uuid.Nil {
+		errs = append(errs, errors.New("updated by user cannot be empty"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// IsClosed reports whether the wave can no longer change
+func (w *PickWave) IsClosed() bool {
+	return w.Status == PickWaveStatusCompleted || w.Status == PickWaveStatusCancelled
+}
+
+// IsClosed reports whether the order has left the wave, packed or not
+func (o *PickWaveOrder) IsClosed() bool {
+	switch o.Status {
+	case PickWaveOrderStatusPacked, PickWaveOrderStatusShort, PickWaveOrderStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// Outstanding returns the quantity of the line still held for the wave: the
+// units to pick, or the units picked and not yet packed
+func (l *PickLine) Outstanding() int {
+	if l.Status == PickLineStatusCancelled {
+		return 0
+	}
+	return l.Quantity - l.QuantityShort
+}
+
+// FindOrder returns the wave order of the given order
+func (w *PickWave) FindOrder(orderID uuid.UUID) *PickWaveOrder {
+	for i := range w.Orders {
+		if w.Orders[i].OrderID == orderID {
+			return &w.Orders[i]
+		}
+	}
+	return nil
+}
+
+// FindLine returns the pick line with the given ID
+func (w *PickWave) FindLine(lineID uuid.UUID) *PickLine {
+	for i := range w.Lines {
+		if w.Lines[i].ID == lineID {
+			return &w.Lines[i]
+		}
+	}
+	return nil
+}
+
+// OpenLines returns the lines of orders that are still in the wave, whose
+// quantities are held for it
+func (w *PickWave) OpenLines() []PickLine {
+	if w.IsClosed() {
+		return nil
+	}
+
+	var lines []PickLine
+	for _, line := range w.Lines {
+		if order := w.FindOrder(line.OrderID); order != nil && !order.IsClosed() && line.Outstanding() > 0 {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// PickList returns the lines still to pick, in the order a picker walks the
+// warehouse: by location, lines without a location last, then by SKU and by
+// the order's place in the wave
+func (w *PickWave) PickList() []PickLine {
+	sequence := make(map[uuid.UUID]int, len(w.Orders))
+	for _, order := range w.Orders {
+		sequence[order.OrderID] = order.Sequence
+	}
+
+	var lines []PickLine
+	for _, line := range w.Lines {
+		if line.Status == PickLineStatusPending {
+			lines = append(lines, line)
+		}
+	}
+
+	sort.SliceStable(lines, func(i, j int) bool {
+		a, b := lines[i], lines[j]
+		switch {
+		case a.Location == nil && b.Location != nil:
+			return false
+		case a.Location != nil && b.Location == nil:
+			return true
+		case a.Location != nil && b.Location != nil:
+			if c := CompareLocations(*a.Location, *b.Location); c != 0 {
+				return c < 0
+			}
+		}
+		if a.ProductSKU != b.ProductSKU {
+			return a.ProductSKU < b.ProductSKU
+		}
+		return sequence[a.OrderID] < sequence[b.OrderID]
+	})
+
+	return lines
+}
+
+// ConfirmPick records the quantity picked for a line. Anything less than the
+// line quantity is short. The order is ready to pack once all its lines are
+// confirmed.
+func (w *PickWave) ConfirmPick(lineID uuid.UUID, picked int, pickedBy uuid.UUID, at time.Time) (*PickLine, error) {
+	if w.IsClosed() {
+		return nil, fmt.Errorf("pick wave is %s", strings.ToLower(string(w.Status)))
+	}
+
+	line := w.FindLine(lineID)
+	if line == nil {
+		return nil, fmt.Errorf("pick line %s not found", lineID)
+	}
+	if line.Status != PickLineStatusPending {
+		return nil, fmt.Errorf("pick line for %s is already %s", line.ProductSKU, strings.ToLower(string(line.Status)))
+	}
+	if picked < 0 || picked > line.Quantity {
+		return nil, fmt.Errorf("picked quantity for %s must be between 0 and %d", line.ProductSKU, line.Quantity)
+	}
+
+	line.QuantityPicked = picked
+	line.QuantityShort = line.Quantity - picked
+	line.Status = PickLineStatusPicked
+	if line.QuantityShort > 0 {
+		line.Status = PickLineStatusShort
+	}
+	line.PickedBy = &pickedBy
+	line.PickedAt = &at
+	line.UpdatedAt = at
+
+	w.refreshOrder(line.OrderID)
+	if w.Status == PickWaveStatusReleased {
+		w.Status = PickWaveStatusPicking
+	}
+	w.refreshStatus(at)
+
+	return line, nil
+}
+
+// PickedQuantities returns the quantities picked for an order, keyed by order item
+func (w *PickWave) PickedQuantities(orderID uuid.UUID) map[uuid.UUID]int {
+	quantities := make(map[uuid.UUID]int)
+	for _, line := range w.Lines {
+		if line.OrderID == orderID && line.QuantityPicked > 0 {
+			quantities[line.OrderItemID] += line.QuantityPicked
+		}
+	}
+	return quantities
+}
+
+// Pack records that a picked order was packed into the given shipment
+func (w *PickWave) Pack(orderID, shipmentID, packedBy uuid.UUID, at time.Time) error {
+	order := w.FindOrder(orderID)
+	if order == nil {
+		return fmt.Errorf("order %s is not in the pick wave", orderID)
+	}
+	if order.Status != PickWaveOrderStatusPicked {
+		return fmt.Errorf("order %s must be picked to pack, status is %s", order.OrderNumber, order.Status)
+	}
+
+	order.Status = PickWaveOrderStatusPacked
+	order.ShipmentID = &shipmentID
+	order.PackedBy = &packedBy
+	order.PackedAt = &at
+
+	w.refreshStatus(at)
+	return nil
+}
+
+// Cancel withdraws the orders that have not been packed from the wave. Their
+// pending lines are cancelled; stock already picked goes back to its location.
+func (w *PickWave) Cancel(at time.Time) error {
+	if w.IsClosed() {
+		return fmt.Errorf("pick wave is already %s", strings.ToLower(string(w.Status)))
+	}
+
+	for i := range w.Orders {
+		order := &w.Orders[i]
+		if order.IsClosed() {
+			continue
+		}
+		order.Status = PickWaveOrderStatusCancelled
+		for j := range w.Lines {
+			line := &w.Lines[j]
+			if line.OrderID == order.OrderID && line.Status == PickLineStatusPending {
+				line.Status = PickLineStatusCancelled
+				line.UpdatedAt = at
+			}
+		}
+	}
+
+	w.Status = PickWaveStatusCancelled
+	w.CancelledAt = &at
+	w.UpdatedAt = at
+	return nil
+}
+
+// refreshOrder moves an order to PICKED, or SHORT when nothing was picked,
+// once none of its lines is pending
+func (w *PickWave) refreshOrder(orderID uuid.UUID) {
+	order := w.FindOrder(orderID)
+	if order == nil || order.Status != PickWaveOrderStatusPicking {
+		return
+	}
+
+	picked := 0
+	for _, line := range w.Lines {
+		if line.OrderID != orderID {
+			continue
+		}
+		if line.Status == PickLineStatusPending {
+			return
+		}
+		picked += line.QuantityPicked
+	}
+
+	if picked == 0 {
+		order.Status = PickWaveOrderStatusShort
+	} else {
+		order.Status = PickWaveOrderStatusPicked
+	}
+}
+
+// refreshStatus completes the wave once every order has left it
+func (w *PickWave) refreshStatus(at time.Time) {
+	w.UpdatedAt = at
+	for i := range w.Orders {
+		if !w.Orders[i].IsClosed() {
+			return
+		}
+	}
+	w.Status = PickWaveStatusCompleted
+	w.CompletedAt = &at
+}
+
+// CompareLocations orders warehouse locations the way they are walked:
+// segment by segment, comparing digit runs by value so that A-2 comes before
+// A-10, and ignoring case. It returns -1, 0 or 1.
+func CompareLocations(a, b string) int {
+	a, b = strings.ToUpper(strings.TrimSpace(a)), strings.ToUpper(strings.TrimSpace(b))
+	for a != "" && b != "" {
+		runA, restA := leadingRun(a)
+		runB, restB := leadingRun(b)
+
+		digitsA, digitsB := unicode.IsDigit(rune(runA[0])), unicode.IsDigit(rune(runB[0]))
+		switch {
+		case digitsA && digitsB:
+			numA, numB := strings.TrimLeft(runA, "0"), strings.TrimLeft(runB, "0")
+			if len(numA) != len(numB) {
+				return compareInts(len(numA), len(numB))
+			}
+			if numA != numB {
+				return strings.Compare(numA, numB)
+			}
+		case runA != runB:
+			return strings.Compare(runA, runB)
+		}
+
+		a, b = restA, restB
+	}
+	return compareInts(len(a), len(b))
+}
+
+// leadingRun splits off the leading run of digits or of other characters
+func leadingRun(s string) (string, string) {
+	digits := unicode.IsDigit(rune(s[0]))
+	i := 1
+	for i < len(s) && unicode.IsDigit(rune(s[i])) == digits {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
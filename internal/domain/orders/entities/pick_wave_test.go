@@ -0,0 +1,181 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestPickWave builds a released wave of two orders: the first with
+// lines of 4 and 2 units, the second with one line of 3 units
+func newTestPickWave(t *testing.T) *PickWave {
+	t.Helper()
+
+	now := time.Now().UTC()
+	wave := &PickWave{
+		ID:          uuid.New(),
+		WarehouseID: uuid.New(),
+		Status:      PickWaveStatusReleased,
+		CreatedBy:   uuid.New(),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	first, second := uuid.New(), uuid.New()
+	wave.Orders = []PickWaveOrder{
+		{ID: uuid.New(), WaveID: wave.ID, OrderID: first, OrderNumber: "SO-1", Priority: OrderPriorityUrgent, Sequence: 1, Status: PickWaveOrderStatusPicking},
+		{ID: uuid.New(), WaveID: wave.ID, OrderID: second, OrderNumber: "SO-2", Priority: OrderPriorityNormal, Sequence: 2, Status: PickWaveOrderStatusPicking},
+	}
+
+	line := func(orderID uuid.UUID, sku string, quantity int, location string) PickLine {
+		l := PickLine{
+			ID:          uuid.New(),
+			WaveID:      wave.ID,
+			OrderID:     orderID,
+			OrderItemID: uuid.New(),
+			ProductID:   uuid.New(),
+			ProductSKU:  sku,
+			Quantity:    quantity,
+			Status:      PickLineStatusPending,
+		}
+		if location != "" {
+			l.Location = &location
+		}
+		return l
+	}
+	wave.Lines = []PickLine{
+		line(first, "SKU-B", 4, "A-10-1"),
+		line(first, "SKU-A", 2, ""),
+		line(second, "SKU-C", 3, "a-2-3"),
+	}
+
+	require.NoError(t, wave.Validate())
+	return wave
+}
+
+func TestPickWaveValidate(t *testing.T) {
+	wave := newTestPickWave(t)
+
+	method := ShippingMethod("TELEPORT")
+	wave.ShippingMethod = &method
+	wave.Lines = append(wave.Lines, wave.Lines[0])
+	wave.Lines[1].QuantityPicked = 3
+
+	err := wave.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "shipping method")
+	assert.Contains(t, err.Error(), "picked more than once")
+	assert.Contains(t, err.Error(), "must add up to at most 2")
+
+	empty := &PickWave{ID: uuid.New(), WarehouseID: uuid.New(), Status: PickWaveStatusReleased, CreatedBy: uuid.New()}
+	err = empty.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "at least one order")
+}
+
+func TestPickWavePickList(t *testing.T) {
+	wave := newTestPickWave(t)
+
+	list := wave.PickList()
+	require.Len(t, list, 3)
+	assert.Equal(t, "SKU-C", list[0].ProductSKU, "A-2 comes before A-10")
+	assert.Equal(t, "SKU-B", list[1].ProductSKU)
+	assert.Equal(t, "SKU-A", list[2].ProductSKU, "lines without a location come last")
+
+	_, err := wave.ConfirmPick(list[0].ID, 3, uuid.New(), time.Now().UTC())
+	require.NoError(t, err)
+	assert.Len(t, wave.PickList(), 2, "confirmed lines leave the pick list")
+}
+
+func TestCompareLocations(t *testing.T) {
+	assert.Equal(t, -1, CompareLocations("A-2", "A-10"))
+	assert.Equal(t, 1, CompareLocations("B-1", "A-99"))
+	assert.Equal(t, 0, CompareLocations("a-02-1", " A-2-1"))
+	assert.Equal(t, -1, CompareLocations("A-2", "A-2-1"))
+	assert.Equal(t, 1, CompareLocations("A10", "A9"))
+}
+
+func TestPickWaveConfirmPick(t *testing.T) {
+	wave := newTestPickWave(t)
+	first := wave.Orders[0].OrderID
+	pickedBy := uuid.New()
+	now := time.Now().UTC()
+
+	line, err := wave.ConfirmPick(wave.Lines[0].ID, 3, pickedBy, now)
+	require.NoError(t, err)
+	assert.Equal(t, PickLineStatusShort, line.Status)
+	assert.Equal(t, 1, line.QuantityShort)
+	assert.Equal(t, 3, line.Outstanding())
+	assert.Equal(t, PickWaveStatusPicking, wave.Status)
+	assert.Equal(t, PickWaveOrderStatusPicking, wave.FindOrder(first).Status, "order still has a pending line")
+
+	_, err = wave.ConfirmPick(wave.Lines[0].ID, 4, pickedBy, now)
+	require.Error(t, err, "a line is confirmed once")
+	_, err = wave.ConfirmPick(wave.Lines[1].ID, 5, pickedBy, now)
+	require.Error(t, err, "cannot pick more than the line quantity")
+
+	_, err = wave.ConfirmPick(wave.Lines[1].ID, 2, pickedBy, now)
+	require.NoError(t, err)
+	assert.Equal(t, PickWaveOrderStatusPicked, wave.FindOrder(first).Status)
+
+	quantities := wave.PickedQuantities(first)
+	assert.Equal(t, 3, quantities[wave.Lines[0].OrderItemID])
+	assert.Equal(t, 2, quantities[wave.Lines[1].OrderItemID])
+
+	second := wave.Orders[1].OrderID
+	_, err = wave.ConfirmPick(wave.Lines[2].ID, 0, pickedBy, now)
+	require.NoError(t, err)
+	assert.Equal(t, PickWaveOrderStatusShort, wave.FindOrder(second).Status, "nothing picked leaves nothing to pack")
+	assert.Empty(t, wave.PickedQuantities(second))
+}
+
+func TestPickWavePack(t *testing.T) {
+	wave := newTestPickWave(t)
+	first, second := wave.Orders[0].OrderID, wave.Orders[1].OrderID
+	now := time.Now().UTC()
+
+	require.Error(t, wave.Pack(first, uuid.New(), uuid.New(), now), "order must be picked first")
+
+	for _, line := range wave.PickList() {
+		_, err := wave.ConfirmPick(line.ID, line.Quantity, uuid.New(), now)
+		require.NoError(t, err)
+	}
+
+	shipmentID := uuid.New()
+	require.NoError(t, wave.Pack(first, shipmentID, uuid.New(), now))
+	assert.Equal(t, PickWaveOrderStatusPacked, wave.FindOrder(first).Status)
+	assert.Equal(t, &shipmentID, wave.FindOrder(first).ShipmentID)
+	assert.Equal(t, PickWaveStatusPicking, wave.Status)
+	require.Error(t, wave.Pack(first, uuid.New(), uuid.New(), now), "order is packed once")
+
+	require.NoError(t, wave.Pack(second, uuid.New(), uuid.New(), now))
+	assert.Equal(t, PickWaveStatusCompleted, wave.Status)
+	assert.NotNil(t, wave.CompletedAt)
+	assert.Empty(t, wave.OpenLines())
+}
+
+func TestPickWaveCancel(t *testing.T) {
+	wave := newTestPickWave(t)
+	first, second := wave.Orders[0].OrderID, wave.Orders[1].OrderID
+	now := time.Now().UTC()
+
+	_, err := wave.ConfirmPick(wave.Lines[2].ID, 3, uuid.New(), now)
+	require.NoError(t, err)
+	require.NoError(t, wave.Pack(second, uuid.New(), uuid.New(), now))
+	assert.Len(t, wave.OpenLines(), 2)
+
+	require.NoError(t, wave.Cancel(now))
+	assert.Equal(t, PickWaveStatusCancelled, wave.Status)
+	assert.Equal(t, PickWaveOrderStatusCancelled, wave.FindOrder(first).Status)
+	assert.Equal(t, PickWaveOrderStatusPacked, wave.FindOrder(second).Status, "packed orders keep their shipment")
+	assert.Equal(t, PickLineStatusCancelled, wave.Lines[0].Status)
+	assert.Equal(t, 0, wave.Lines[0].Outstanding())
+	assert.Empty(t, wave.OpenLines())
+
+	require.Error(t, wave.Cancel(now))
+	_, err = wave.ConfirmPick(wave.Lines[1].ID, 1, uuid.New(), now)
+	require.Error(t, err)
+}
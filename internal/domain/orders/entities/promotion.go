@@ -0,0 +1,429 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// PromotionActionType is what a promotion does to an order
+type PromotionActionType string
+
+const (
+	// PromotionActionPercentage takes Value percent off the qualifying lines
+	PromotionActionPercentage PromotionActionType = "PERCENTAGE"
+	// PromotionActionFixedAmount takes Value off the qualifying lines
+	PromotionActionFixedAmount PromotionActionType = "FIXED_AMOUNT"
+	// PromotionActionBuyXGetY takes Value percent off GetQuantity units for
+	// every BuyQuantity units bought; the cheapest qualifying units are discounted
+	PromotionActionBuyXGetY PromotionActionType = "BUY_X_GET_Y"
+	// PromotionActionFreeShipping takes the shipping charge off the order
+	PromotionActionFreeShipping PromotionActionType = "FREE_SHIPPING"
+)
+
+// DiscountTypeCoupon is the discount breakdown type of promotion discounts
+const DiscountTypeCoupon = "COUPON"
+
+var (
+	promotionCodePattern  = regexp.MustCompile(`^[A-Z0-9][A-Z0-9_-]{2,49}$`)
+	validPromotionActions = []PromotionActionType{PromotionActionPercentage, PromotionActionFixedAmount, PromotionActionBuyXGetY, PromotionActionFreeShipping}
+	validCustomerSegments = map[string]bool{"INDIVIDUAL": true, "BUSINESS": true, "GOVERNMENT": true, "NON_PROFIT": true}
+)
+
+// NormalizePromotionCode upper-cases a coupon code as entered by a customer
+func NormalizePromotionCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Promotion is a discount redeemed with a coupon code. Its conditions decide
+// whether an order qualifies and which lines the discount applies to; its
+// action decides the discount.
+type Promotion struct {
+	ID          uuid.UUID           `json:"id" db:"id"`
+	Code        string              `json:"code" db:"code"`
+	Name        string              `json:"name" db:"name"`
+	Description *string             `json:"description,omitempty" db:"description"`
+	ActionType  PromotionActionType `json:"action_type" db:"action_type"`
+	// Value is the percentage or amount off; for buy-X-get-Y it is the
+	// percentage off the free units, 100 making them free
+	Value decimal.Decimal `json:"value" db:"value"`
+	// MaxDiscount caps the discount of percentage and buy-X-get-Y promotions
+	MaxDiscount *decimal.Decimal `json:"max_discount,omitempty" db:"max_discount"`
+	BuyQuantity int              `json:"buy_quantity,omitempty" db:"buy_quantity"`
+	GetQuantity int              `json:"get_quantity,omitempty" db:"get_quantity"`
+
+	// Conditions. Empty product and category scopes cover every line; a line
+	// qualifies when it matches either scope.
+	MinSubtotal   decimal.Decimal `json:"min_subtotal" db:"min_subtotal"`
+	ProductIDs    []uuid.UUID     `json:"product_ids,omitempty" db:"product_ids"`
+	CategoryIDs   []uuid.UUID     `json:"category_ids,omitempty" db:"category_ids"`
+	CustomerTypes []string        `json:"customer_types,omitempty" db:"customer_types"`
+	StartsAt      time.Time       `json:"starts_at" db:"starts_at"`
+	EndsAt        *time.Time      `json:"ends_at,omitempty" db:"ends_at"`
+
+	// Usage limits count redemptions that were not released
+	UsageLimit            *int `json:"usage_limit,omitempty" db:"usage_limit"`
+	UsageLimitPerCustomer *int `json:"usage_limit_per_customer,omitempty" db:"usage_limit_per_customer"`
+	TimesUsed             int  `json:"times_used" db:"-"`
+
+	// Stackable promotions combine with other stackable promotions on an
+	// order; a promotion that is not stackable is applied alone. Promotions
+	// apply in descending priority.
+	Stackable bool `json:"stackable" db:"stackable"`
+	Priority  int  `json:"priority" db:"priority"`
+	IsActive  bool `json:"is_active" db:"is_active"`
+
+	CreatedBy uuid.UUID `json:"created_by" db:"created_by"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// PromotionRedemption records a coupon code applied to an order. It counts
+// against the promotion's usage limits until it is released, which happens
+// when the code is removed or the order is cancelled.
+type PromotionRedemption struct {
+	ID            uuid.UUID  `json:"id" db:"id"`
+	PromotionID   uuid.UUID  `json:"promotion_id" db:"promotion_id"`
+	PromotionCode string     `json:"promotion_code" db:"promotion_code"`
+	OrderID       uuid.UUID  `json:"order_id" db:"order_id"`
+	CustomerID    uuid.UUID  `json:"customer_id" db:"customer_id"`
+	RedeemedBy    uuid.UUID  `json:"redeemed_by" db:"redeemed_by"`
+	RedeemedAt    time.Time  `json:"redeemed_at" db:"redeemed_at"`
+	ReleasedAt    *time.Time `json:"released_at,omitempty" db:"released_at"`
+	ReleaseReason *string    `json:"release_reason,omitempty" db:"release_reason"`
+
+	Promotion *Promotion `json:"promotion,omitempty" db:"-"`
+}
+
+// PromotionDiscount is the discount one promotion gives an order
+type PromotionDiscount struct {
+	PromotionID uuid.UUID           `json:"promotion_id"`
+	Code        string              `json:"code"`
+	Name        string              `json:"name"`
+	ActionType  PromotionActionType `json:"action_type"`
+	Amount      decimal.Decimal     `json:"amount"`
+	// Reason explains why an applied promotion gives no discount
+	Reason string `json:"reason,omitempty"`
+}
+
+// PromotionCalculation is the discount of the promotions applied to an order
+type PromotionCalculation struct {
+	Discounts      []PromotionDiscount `json:"discounts"`
+	DiscountAmount decimal.Decimal     `json:"discount_amount"`
+}
+
+// Validate validates the promotion
+func (p *Promotion) Validate() error {
+	var errs []error
+
+	if p.ID == uuid.Nil {
+		errs = append(errs, errors.New("promotion ID cannot be empty"))
+	}
+	if !promotionCodePattern.MatchString(p.Code) {
+		errs = append(errs, errors.New("promotion code must be 3 to 50 upper-case letters, digits, dashes or underscores"))
+	}
+	if strings.TrimSpace(p.Name) == "" {
+		errs = append(errs, errors.New("promotion name is required"))
+	}
+	if err := p.validateAction(); err != nil {
+		errs = append(errs, err)
+	}
+	if p.MaxDiscount != nil && !p.MaxDiscount.IsPositive() {
+		errs = append(errs, errors.New("maximum discount must be positive"))
+	}
+	if p.MinSubtotal.IsNegative() {
+		errs = append(errs, errors.New("minimum subtotal cannot be negative"))
+	}
+	for _, segment := range p.CustomerTypes {
+		if !validCustomerSegments[segment] {
+			errs = append(errs, fmt.Errorf("invalid customer type: %s", segment))
+		}
+	}
+	if p.StartsAt.IsZero() {
+		errs = append(errs, errors.New("promotion start date is required"))
+	}
+	if p.EndsAt != nil && !p.EndsAt.After(p.StartsAt) {
+		errs = append(errs, errors.New("promotion end date must be after its start date"))
+	}
+	if p.UsageLimit != nil && *p.UsageLimit < 1 {
+		errs = append(errs, errors.New("usage limit must be at least 1"))
+	}
+	if p.UsageLimitPerCustomer != nil && *p.UsageLimitPerCustomer < 1 {
+		errs = append(errs, errors.New("usage limit per customer must be at least 1"))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("validation failed: %v", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// validateAction checks the action type and the values it uses
+func (p *Promotion) validateAction() error {
+	switch p.ActionType {
+	case PromotionActionPercentage:
+		if !p.Value.IsPositive() || p.Value.GreaterThan(oneHundredPercent) {
+			return errors.New("percentage must be greater than 0 and at most 100")
+		}
+	case PromotionActionFixedAmount:
+		if !p.Value.IsPositive() {
+			return errors.New("fixed discount amount must be positive")
+		}
+	case PromotionActionBuyXGetY:
+		if p.BuyQuantity < 1 || p.GetQuantity < 1 {
+			return errors.New("buy and get quantities must be at least 1")
+		}
+		if !p.Value.IsPositive() || p.Value.GreaterThan(oneHundredPercent) {
+			return errors.New("percentage off the free units must be greater than 0 and at most 100")
+		}
+	case PromotionActionFreeShipping:
+		if !p.Value.IsZero() {
+			return errors.New("free shipping promotions take no value")
+		}
+	default:
+		return fmt.Errorf("invalid promotion action type: %s (valid: %v)", p.ActionType, validPromotionActions)
+	}
+	return nil
+}
+
+// IsRunning reports whether the promotion is active and within its date window
+func (p *Promotion) IsRunning(at time.Time) bool {
+	return p.checkWindow(at) == nil
+}
+
+// checkWindow checks that the promotion is active and within its date window
+func (p *Promotion) checkWindow(at time.Time) error {
+	if !p.IsActive {
+		return errors.New("promotion is not active")
+	}
+	if at.Before(p.StartsAt) {
+		return errors.New("promotion has not started yet")
+	}
+	if p.EndsAt != nil && !at.Before(*p.EndsAt) {
+		return errors.New("promotion has ended")
+	}
+	return nil
+}
+
+// CheckEligibility reports why an order does not qualify for the promotion
+// at the given time, or nil when it does. Categories maps the products of
+// the order's lines to their categories and is needed for category scopes.
+func (p *Promotion) CheckEligibility(order *Order, categories map[uuid.UUID]uuid.UUID, at time.Time) error {
+	if err := p.checkWindow(at); err != nil {
+		return err
+	}
+
+	if len(p.CustomerTypes) > 0 {
+		eligible := false
+		if order.Customer != nil {
+			for _, segment := range p.CustomerTypes {
+				if strings.EqualFold(segment, order.Customer.Type) {
+					eligible = true
+					break
+				}
+			}
+		}
+		if !eligible {
+			return errors.New("customer is not eligible for the promotion")
+		}
+	}
+
+	subtotal := decimal.Zero
+	qualifying := false
+	for i := range order.Items {
+		subtotal = subtotal.Add(order.Items[i].LineAmount())
+		qualifying = qualifying || p.Covers(&order.Items[i], categories)
+	}
+	if subtotal.LessThan(p.MinSubtotal) {
+		return fmt.Errorf("order subtotal is below the promotion minimum of %s", p.MinSubtotal.StringFixed(2))
+	}
+	if !qualifying && p.ActionType != PromotionActionFreeShipping {
+		return errors.New("no order items qualify for the promotion")
+	}
+
+	return nil
+}
+
+// Covers reports whether an order line is within the promotion's product and category scope
+func (p *Promotion) Covers(item *OrderItem, categories map[uuid.UUID]uuid.UUID) bool {
+	if len(p.ProductIDs) == 0 && len(p.CategoryIDs) == 0 {
+		return true
+	}
+	for _, productID := range p.ProductIDs {
+		if productID == item.ProductID {
+			return true
+		}
+	}
+	if categoryID, ok := categories[item.ProductID]; ok {
+		for _, id := range p.CategoryIDs {
+			if id == categoryID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CheckUsage reports whether another redemption would exceed the usage
+// limits, given the redemptions held overall and by the customer
+func (p *Promotion) CheckUsage(used, usedByCustomer int) error {
+	if p.UsageLimit != nil && used >= *p.UsageLimit {
+		return fmt.Errorf("promotion %s has reached its usage limit of %d", p.Code, *p.UsageLimit)
+	}
+	if p.UsageLimitPerCustomer != nil && usedByCustomer >= *p.UsageLimitPerCustomer {
+		return fmt.Errorf("promotion %s has reached its usage limit of %d per customer", p.Code, *p.UsageLimitPerCustomer)
+	}
+	return nil
+}
+
+// CanStackWith reports whether the promotion can be applied alongside the
+// promotions already applied to an order
+func (p *Promotion) CanStackWith(applied []*Promotion) error {
+	for _, other := range applied {
+		if other.ID == p.ID {
+			return fmt.Errorf("promotion %s is already applied", p.Code)
+		}
+		if !p.Stackable || !other.Stackable {
+			return fmt.Errorf("promotion %s cannot be combined with promotion %s", p.Code, other.Code)
+		}
+	}
+	return nil
+}
+
+// IsReleased reports whether the redemption no longer counts against usage limits
+func (r *PromotionRedemption) IsReleased() bool {
+	return r.ReleasedAt != nil
+}
+
+// Release gives the redemption back to the promotion's usage limits
+func (r *PromotionRedemption) Release(reason string) error {
+	if r.IsReleased() {
+		return errors.New("promotion redemption is already released")
+	}
+
+	now := time.Now().UTC()
+	reason = strings.TrimSpace(reason)
+	r.ReleasedAt = &now
+	r.ReleaseReason = &reason
+	return nil
+}
+
+// CalculatePromotions works out the discounts of the promotions redeemed on
+// an order, whose Promotion must be loaded. Eligibility is judged when each
+// code was redeemed so a promotion that has since ended keeps its discount;
+// an order that no longer meets the conditions gets no discount from it.
+// Promotions apply in descending priority and together never take more than
+// the lines net of the order's other discounts, plus the shipping charge.
+func CalculatePromotions(order *Order, categories map[uuid.UUID]uuid.UUID) *PromotionCalculation {
+	redemptions := make([]*PromotionRedemption, 0, len(order.Promotions))
+	for i := range order.Promotions {
+		if order.Promotions[i].Promotion != nil && !order.Promotions[i].IsReleased() {
+			redemptions = append(redemptions, &order.Promotions[i])
+		}
+	}
+	sort.SliceStable(redemptions, func(i, j int) bool {
+		if redemptions[i].Promotion.Priority != redemptions[j].Promotion.Priority {
+			return redemptions[i].Promotion.Priority > redemptions[j].Promotion.Priority
+		}
+		return redemptions[i].RedeemedAt.Before(redemptions[j].RedeemedAt)
+	})
+
+	subtotal := decimal.Zero
+	for i := range order.Items {
+		subtotal = subtotal.Add(order.Items[i].LineAmount())
+	}
+	remaining := decimal.Max(subtotal.Sub(order.DiscountAmount), decimal.Zero)
+	shippingRemaining := order.ShippingAmount
+
+	calculation := &PromotionCalculation{Discounts: []PromotionDiscount{}, DiscountAmount: decimal.Zero}
+	for _, redemption := range redemptions {
+		promotion := redemption.Promotion
+		discount := PromotionDiscount{
+			PromotionID: promotion.ID,
+			Code:        promotion.Code,
+			Name:        promotion.Name,
+			ActionType:  promotion.ActionType,
+			Amount:      decimal.Zero,
+		}
+
+		if err := promotion.CheckEligibility(order, categories, redemption.RedeemedAt); err != nil {
+			discount.Reason = err.Error()
+		} else if promotion.ActionType == PromotionActionFreeShipping {
+			discount.Amount = shippingRemaining
+			shippingRemaining = decimal.Zero
+		} else {
+			discount.Amount = decimal.Min(promotion.lineDiscount(order.Items, categories), remaining).Round(2)
+			remaining = remaining.Sub(discount.Amount)
+		}
+
+		calculation.Discounts = append(calculation.Discounts, discount)
+		calculation.DiscountAmount = calculation.DiscountAmount.Add(discount.Amount)
+	}
+
+	return calculation
+}
+
+// lineDiscount is the discount the promotion's action gives the qualifying lines
+func (p *Promotion) lineDiscount(items []OrderItem, categories map[uuid.UUID]uuid.UUID) decimal.Decimal {
+	base := decimal.Zero
+	var unitPrices []decimal.Decimal
+	for i := range items {
+		item := &items[i]
+		if !p.Covers(item, categories) || item.Quantity <= 0 {
+			continue
+		}
+		amount := item.LineAmount()
+		base = base.Add(amount)
+		unitPrice := amount.Div(decimal.NewFromInt(int64(item.Quantity)))
+		for n := 0; n < item.Quantity; n++ {
+			unitPrices = append(unitPrices, unitPrice)
+		}
+	}
+
+	var discount decimal.Decimal
+	switch p.ActionType {
+	case PromotionActionPercentage:
+		discount = base.Mul(p.Value).Div(oneHundredPercent)
+	case PromotionActionFixedAmount:
+		discount = decimal.Min(p.Value, base)
+	case PromotionActionBuyXGetY:
+		// The cheapest units of each group of buy+get units are discounted
+		sort.Slice(unitPrices, func(i, j int) bool { return unitPrices[i].LessThan(unitPrices[j]) })
+		free := len(unitPrices) / (p.BuyQuantity + p.GetQuantity) * p.GetQuantity
+		discount = decimal.Zero
+		for _, unitPrice := range unitPrices[:free] {
+			discount = discount.Add(unitPrice)
+		}
+		discount = discount.Mul(p.Value).Div(oneHundredPercent)
+	default:
+		return decimal.Zero
+	}
+
+	if p.MaxDiscount != nil && discount.GreaterThan(*p.MaxDiscount) {
+		discount = *p.MaxDiscount
+	}
+	return decimal.Max(discount, decimal.Zero)
+}
+
+// ApplyTo adds the promotion discounts to an order calculation as coupon lines
+func (c *PromotionCalculation) ApplyTo(calculation *OrderCalculation) {
+	for _, discount := range c.Discounts {
+		if !discount.Amount.IsPositive() {
+			continue
+		}
+		calculation.DiscountAmount = calculation.DiscountAmount.Add(discount.Amount)
+		calculation.DiscountBreakdown = append(calculation.DiscountBreakdown, DiscountBreakdown{
+			DiscountType: DiscountTypeCoupon,
+			Amount:       discount.Amount,
+			Description:  fmt.Sprintf("Coupon %s: %s", discount.Code, discount.Name),
+		})
+	}
+	calculation.TotalAmount = calculation.Subtotal.Add(calculation.TaxAmount).Add(calculation.ShippingAmount).Sub(calculation.DiscountAmount)
+}
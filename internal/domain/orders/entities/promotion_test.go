@@ -0,0 +1,228 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPromotion(code string, actionType PromotionActionType, value string) *Promotion {
+	return &Promotion{
+		ID:         uuid.New(),
+		Code:       code,
+		Name:       code,
+		ActionType: actionType,
+		Value:      decimal.RequireFromString(value),
+		StartsAt:   time.Now().Add(-24 * time.Hour),
+		IsActive:   true,
+		Stackable:  true,
+	}
+}
+
+func newTestPromotionOrder(shipping string, items ...OrderItem) *Order {
+	return &Order{
+		ID:             uuid.New(),
+		CustomerID:     uuid.New(),
+		Customer:       &Customer{Type: "INDIVIDUAL"},
+		ShippingAmount: decimal.RequireFromString(shipping),
+		Items:          items,
+	}
+}
+
+// redeem applies promotions to an order as if their codes were entered in turn
+func redeem(order *Order, promotions ...*Promotion) {
+	for i, promotion := range promotions {
+		order.Promotions = append(order.Promotions, PromotionRedemption{
+			ID:            uuid.New(),
+			PromotionID:   promotion.ID,
+			PromotionCode: promotion.Code,
+			OrderID:       order.ID,
+			CustomerID:    order.CustomerID,
+			RedeemedAt:    time.Now().Add(time.Duration(i) * time.Second),
+			Promotion:     promotion,
+		})
+	}
+}
+
+func intRef(value int) *int {
+	return &value
+}
+
+func TestPromotionValidate(t *testing.T) {
+	promotion := newTestPromotion("SPRING-10", PromotionActionPercentage, "10")
+	require.NoError(t, promotion.Validate())
+
+	tests := []struct {
+		name   string
+		modify func(p *Promotion)
+	}{
+		{"lower-case code", func(p *Promotion) { p.Code = "spring" }},
+		{"percentage over 100", func(p *Promotion) { p.Value = decimal.NewFromInt(101) }},
+		{"unknown action", func(p *Promotion) { p.ActionType = "GIFT" }},
+		{"buy-x-get-y without quantities", func(p *Promotion) { p.ActionType = PromotionActionBuyXGetY }},
+		{"free shipping with value", func(p *Promotion) { p.ActionType = PromotionActionFreeShipping }},
+		{"unknown customer type", func(p *Promotion) { p.CustomerTypes = []string{"VIP"} }},
+		{"ends before start", func(p *Promotion) { endsAt := p.StartsAt.Add(-time.Hour); p.EndsAt = &endsAt }},
+		{"zero usage limit", func(p *Promotion) { p.UsageLimit = intRef(0) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invalid := *promotion
+			tt.modify(&invalid)
+			assert.Error(t, invalid.Validate())
+		})
+	}
+}
+
+func TestPromotionCheckEligibility(t *testing.T) {
+	now := time.Now()
+	categoryID := uuid.New()
+	item := newTestTaxItem("40.00", 2, TaxClassStandard)
+	order := newTestPromotionOrder("10.00", item)
+
+	promotion := newTestPromotion("SAVE10", PromotionActionFixedAmount, "10")
+	require.NoError(t, promotion.CheckEligibility(order, nil, now))
+
+	promotion.MinSubtotal = decimal.NewFromInt(100)
+	assert.ErrorContains(t, promotion.CheckEligibility(order, nil, now), "below the promotion minimum of 100.00")
+	promotion.MinSubtotal = decimal.NewFromInt(80)
+	assert.NoError(t, promotion.CheckEligibility(order, nil, now))
+
+	promotion.CustomerTypes = []string{"BUSINESS"}
+	assert.ErrorContains(t, promotion.CheckEligibility(order, nil, now), "customer is not eligible")
+	promotion.CustomerTypes = []string{"BUSINESS", "INDIVIDUAL"}
+	assert.NoError(t, promotion.CheckEligibility(order, nil, now))
+
+	promotion.CategoryIDs = []uuid.UUID{categoryID}
+	assert.ErrorContains(t, promotion.CheckEligibility(order, nil, now), "no order items qualify")
+	categories := map[uuid.UUID]uuid.UUID{item.ProductID: categoryID}
+	assert.NoError(t, promotion.CheckEligibility(order, categories, now))
+
+	endsAt := now.Add(-time.Hour)
+	promotion.EndsAt = &endsAt
+	assert.ErrorContains(t, promotion.CheckEligibility(order, categories, now), "ended")
+	assert.NoError(t, promotion.CheckEligibility(order, categories, now.Add(-2*time.Hour)))
+
+	promotion.IsActive = false
+	assert.ErrorContains(t, promotion.CheckEligibility(order, categories, now.Add(-2*time.Hour)), "not active")
+}
+
+func TestPromotionCheckUsageAndStacking(t *testing.T) {
+	promotion := newTestPromotion("ONCE", PromotionActionPercentage, "5")
+	promotion.UsageLimit = intRef(100)
+	promotion.UsageLimitPerCustomer = intRef(1)
+
+	assert.NoError(t, promotion.CheckUsage(99, 0))
+	assert.ErrorContains(t, promotion.CheckUsage(100, 0), "usage limit of 100")
+	assert.ErrorContains(t, promotion.CheckUsage(10, 1), "usage limit of 1 per customer")
+
+	other := newTestPromotion("SHIPFREE", PromotionActionFreeShipping, "0")
+	assert.NoError(t, promotion.CanStackWith([]*Promotion{other}))
+	assert.ErrorContains(t, promotion.CanStackWith([]*Promotion{promotion}), "already applied")
+
+	other.Stackable = false
+	assert.ErrorContains(t, promotion.CanStackWith([]*Promotion{other}), "cannot be combined")
+}
+
+func TestCalculatePromotionsPercentageScopedAndCapped(t *testing.T) {
+	shoes := newTestTaxItem("100.00", 2, TaxClassStandard)
+	socks := newTestTaxItem("5.00", 4, TaxClassStandard)
+	order := newTestPromotionOrder("0", shoes, socks)
+
+	promotion := newTestPromotion("SHOES25", PromotionActionPercentage, "25")
+	promotion.ProductIDs = []uuid.UUID{shoes.ProductID}
+	redeem(order, promotion)
+
+	calculation := CalculatePromotions(order, nil)
+	require.Len(t, calculation.Discounts, 1)
+	assert.True(t, calculation.DiscountAmount.Equal(decimal.NewFromInt(50)))
+
+	maxDiscount := decimal.NewFromInt(30)
+	promotion.MaxDiscount = &maxDiscount
+	assert.True(t, CalculatePromotions(order, nil).DiscountAmount.Equal(decimal.NewFromInt(30)))
+}
+
+func TestCalculatePromotionsBuyXGetY(t *testing.T) {
+	// Buy 2 get 1 free over 7 units: two groups of three, so the two cheapest units are free
+	order := newTestPromotionOrder("0",
+		newTestTaxItem("10.00", 3, TaxClassStandard),
+		newTestTaxItem("4.00", 1, TaxClassStandard),
+		newTestTaxItem("7.00", 3, TaxClassStandard),
+	)
+
+	promotion := newTestPromotion("B2G1", PromotionActionBuyXGetY, "100")
+	promotion.BuyQuantity = 2
+	promotion.GetQuantity = 1
+	require.NoError(t, promotion.Validate())
+	redeem(order, promotion)
+
+	assert.True(t, CalculatePromotions(order, nil).DiscountAmount.Equal(decimal.NewFromInt(11)))
+
+	promotion.Value = decimal.NewFromInt(50)
+	assert.True(t, CalculatePromotions(order, nil).DiscountAmount.Equal(decimal.RequireFromString("5.5")))
+}
+
+func TestCalculatePromotionsStackInPriorityOrder(t *testing.T) {
+	order := newTestPromotionOrder("12.50", newTestTaxItem("30.00", 1, TaxClassStandard))
+	order.DiscountAmount = decimal.NewFromInt(5)
+
+	fixed := newTestPromotion("TWENTY", PromotionActionFixedAmount, "20")
+	percentage := newTestPromotion("HALF", PromotionActionPercentage, "50")
+	percentage.Priority = 10
+	shipping := newTestPromotion("SHIPFREE", PromotionActionFreeShipping, "0")
+	redeem(order, fixed, percentage, shipping)
+
+	// The percentage applies first; the fixed amount then only has what is left
+	// after the manual discount and the percentage
+	calculation := CalculatePromotions(order, nil)
+	require.Len(t, calculation.Discounts, 3)
+	assert.Equal(t, "HALF", calculation.Discounts[0].Code)
+	assert.True(t, calculation.Discounts[0].Amount.Equal(decimal.NewFromInt(15)))
+	assert.Equal(t, "TWENTY", calculation.Discounts[1].Code)
+	assert.True(t, calculation.Discounts[1].Amount.Equal(decimal.NewFromInt(10)))
+	assert.True(t, calculation.Discounts[2].Amount.Equal(decimal.RequireFromString("12.50")))
+	assert.True(t, calculation.DiscountAmount.Equal(decimal.RequireFromString("37.50")))
+}
+
+func TestCalculatePromotionsDropsPromotionOrderNoLongerQualifiesFor(t *testing.T) {
+	item := newTestTaxItem("60.00", 1, TaxClassStandard)
+	order := newTestPromotionOrder("0", item)
+
+	promotion := newTestPromotion("OVER50", PromotionActionFixedAmount, "10")
+	promotion.MinSubtotal = decimal.NewFromInt(50)
+	redeem(order, promotion)
+	assert.True(t, CalculatePromotions(order, nil).DiscountAmount.Equal(decimal.NewFromInt(10)))
+
+	// A promotion that ended after the code was applied keeps its discount
+	endsAt := time.Now().Add(-time.Minute)
+	promotion.EndsAt = &endsAt
+	order.Promotions[0].RedeemedAt = endsAt.Add(-time.Hour)
+	assert.True(t, CalculatePromotions(order, nil).DiscountAmount.Equal(decimal.NewFromInt(10)))
+
+	order.Items[0].UnitPrice = decimal.NewFromInt(40)
+	calculation := CalculatePromotions(order, nil)
+	assert.True(t, calculation.DiscountAmount.IsZero())
+	assert.Contains(t, calculation.Discounts[0].Reason, "below the promotion minimum")
+
+	require.NoError(t, order.Promotions[0].Release("removed"))
+	assert.Empty(t, CalculatePromotions(order, nil).Discounts)
+}
+
+func TestPromotionCalculationApplyTo(t *testing.T) {
+	order := newTestPromotionOrder("10.00", newTestTaxItem("50.00", 2, TaxClassStandard))
+	redeem(order, newTestPromotion("SAVE15", PromotionActionFixedAmount, "15"))
+
+	calculation, err := CalculateOrderTotals(order, decimal.Zero, order.ShippingAmount)
+	require.NoError(t, err)
+	CalculatePromotions(order, nil).ApplyTo(calculation)
+
+	require.Len(t, calculation.DiscountBreakdown, 1)
+	assert.Equal(t, DiscountTypeCoupon, calculation.DiscountBreakdown[0].DiscountType)
+	assert.Equal(t, "Coupon SAVE15: SAVE15", calculation.DiscountBreakdown[0].Description)
+	assert.True(t, calculation.DiscountAmount.Equal(decimal.NewFromInt(15)))
+	assert.True(t, calculation.TotalAmount.Equal(calculation.Subtotal.Add(calculation.TaxAmount).Add(decimal.NewFromInt(10)).Sub(decimal.NewFromInt(15))))
+}
@@ -0,0 +1,237 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// QuotationStatus represents the status of a sales quotation
+type QuotationStatus string
+
+const (
+	QuotationStatusDraft      QuotationStatus = "DRAFT"
+	QuotationStatusSent       QuotationStatus = "SENT"
+	QuotationStatusAccepted   QuotationStatus = "ACCEPTED"
+	QuotationStatusRejected   QuotationStatus = "REJECTED"
+	QuotationStatusExpired    QuotationStatus = "EXPIRED"
+	QuotationStatusConverted  QuotationStatus = "CONVERTED"
+	QuotationStatusSuperseded QuotationStatus = "SUPERSEDED"
+)
+
+// DocumentTypeQuotation numbers sales quotations
+const DocumentTypeQuotation DocumentType = "QUOTATION"
+
+// QuotationStatusTransitions defines valid quotation status transitions
+var QuotationStatusTransitions = map[QuotationStatus][]QuotationStatus{
+	QuotationStatusDraft:    {QuotationStatusSent, QuotationStatusAccepted, QuotationStatusRejected, QuotationStatusExpired, QuotationStatusSuperseded},
+	QuotationStatusSent:     {QuotationStatusAccepted, QuotationStatusRejected, QuotationStatusExpired, QuotationStatusSuperseded},
+	QuotationStatusAccepted: {QuotationStatusConverted},
+}
+
+// Quotation represents a priced offer to a customer that can become a sales order.
+// Quotation lines reuse OrderItem so that quotes and orders are priced identically;
+// the OrderID of a quotation line holds the quotation ID.
+type Quotation struct {
+	ID                 uuid.UUID       `json:"id" db:"id"`
+	QuotationNumber    string          `json:"quotation_number" db:"quotation_number"`
+	Revision           int             `json:"revision" db:"revision"`
+	PreviousRevisionID *uuid.UUID      `json:"previous_revision_id,omitempty" db:"previous_revision_id"`
+	CustomerID         uuid.UUID       `json:"customer_id" db:"customer_id"`
+	Status             QuotationStatus `json:"status" db:"status"`
+	ValidUntil         time.Time       `json:"valid_until" db:"valid_until"`
+
+	ShippingMethod    ShippingMethod `json:"shipping_method" db:"shipping_method"`
+	ShippingAddressID uuid.UUID      `json:"shipping_address_id" db:"shipping_address_id"`
+	BillingAddressID  uuid.UUID      `json:"billing_address_id" db:"billing_address_id"`
+
+	Subtotal       decimal.Decimal `json:"subtotal" db:"subtotal"`
+	TaxAmount      decimal.Decimal `json:"tax_amount" db:"tax_amount"`
+	ShippingAmount decimal.Decimal `json:"shipping_amount" db:"shipping_amount"`
+	DiscountAmount decimal.Decimal `json:"discount_amount" db:"discount_amount"`
+	TotalAmount    decimal.Decimal `json:"total_amount" db:"total_amount"`
+	Currency       string          `json:"currency" db:"currency"`
+
+	Notes           *string `json:"notes,omitempty" db:"notes"`
+	RejectionReason *string `json:"rejection_reason,omitempty" db:"rejection_reason"`
+
+	ConvertedOrderID *uuid.UUID `json:"converted_order_id,omitempty" db:"converted_order_id"`
+
+	Items []OrderItem `json:"items,omitempty" db:"-"`
+
+	CreatedBy   uuid.UUID  `json:"created_by" db:"created_by"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+	SentAt      *time.Time `json:"sent_at,omitempty" db:"sent_at"`
+	AcceptedAt  *time.Time `json:"accepted_at,omitempty" db:"accepted_at"`
+	RejectedAt  *time.Time `json:"rejected_at,omitempty" db:"rejected_at"`
+	ConvertedAt *time.Time `json:"converted_at,omitempty" db:"converted_at"`
+}
+
+// Validate validates the quotation entity. A quotation without a number is
+// accepted, since the number is allocated when it is first persisted.
+func (q *Quotation) Validate() error {
+	var errs []error
+
+	if q.ID == uuid.Nil {
+		errs = append(errs, errors.New("quotation ID cannot be empty"))
+	}
+
+	if q.CustomerID == uuid.Nil {
+		errs = append(errs, errors.New("customer ID cannot be empty"))
+	}
+
+	if q.Revision < 1 {
+		errs = append(errs, errors.New("revision must be at least 1"))
+	}
+
+	if _, ok := QuotationStatusTransitions[q.Status]; !ok && !q.IsClosed() {
+		errs = append(errs, fmt.Errorf("invalid status: %s", q.Status))
+	}
+
+	if q.ValidUntil.IsZero() {
+		errs = append(errs, errors.New("valid until date is required"))
+	}
+
+	if len(q.Items) == 0 {
+		errs = append(errs, errors.New("quotation must have at least one item"))
+	}
+	for i := range q.Items {
+		if err := q.Items[i].Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("invalid item %d: %w", i+1, err))
+		}
+	}
+
+	if q.ShippingAddressID == uuid.Nil || q.BillingAddressID == uuid.Nil {
+		errs = append(errs, errors.New("shipping and billing addresses are required"))
+	}
+
+	if len(strings.TrimSpace(q.Currency)) != 3 {
+		errs = append(errs, errors.New("currency must be a valid 3-letter ISO 4217 code"))
+	}
+
+	if q.TotalAmount.LessThan(decimal.Zero) {
+		errs = append(errs, errors.New("total amount cannot be negative"))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("validation failed: %v", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// IsClosed reports whether the quotation can no longer change status
+func (q *Quotation) IsClosed() bool {
+	switch q.Status {
+	case QuotationStatusRejected, QuotationStatusExpired, QuotationStatusConverted, QuotationStatusSuperseded:
+		return true
+	}
+	return false
+}
+
+// IsEditable reports whether lines and terms of the quotation may still change
+func (q *Quotation) IsEditable() bool {
+	return q.Status == QuotationStatusDraft
+}
+
+// IsExpiredAt reports whether an open quotation is past its validity date
+func (q *Quotation) IsExpiredAt(at time.Time) bool {
+	switch q.Status {
+	case QuotationStatusDraft, QuotationStatusSent:
+		return at.After(q.ValidUntil)
+	}
+	return false
+}
+
+// ChangeStatus moves the quotation to a new status, stamping the matching date
+func (q *Quotation) ChangeStatus(newStatus QuotationStatus) error {
+	valid := false
+	for _, status := range QuotationStatusTransitions[q.Status] {
+		if status == newStatus {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return fmt.Errorf("invalid quotation status transition from %s to %s", q.Status, newStatus)
+	}
+
+	now := time.Now().UTC()
+	switch newStatus {
+	case QuotationStatusSent:
+		q.SentAt = &now
+	case QuotationStatusAccepted:
+		if q.IsExpiredAt(now) {
+			return fmt.Errorf("quotation expired on %s", q.ValidUntil.Format("2006-01-02"))
+		}
+		q.AcceptedAt = &now
+	case QuotationStatusRejected:
+		q.RejectedAt = &now
+	case QuotationStatusConverted:
+		q.ConvertedAt = &now
+	}
+
+	q.Status = newStatus
+	q.UpdatedAt = now
+	return nil
+}
+
+// CalculateTotals prices the quotation lines with the order calculation rules
+func (q *Quotation) CalculateTotals() (*OrderCalculation, error) {
+	for i := range q.Items {
+		q.Items[i].CalculateTotals()
+	}
+
+	calculation, err := CalculateOrderTotals(&Order{Items: q.Items}, decimal.Zero, q.ShippingAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	q.Subtotal = calculation.Subtotal.Round(2)
+	q.TaxAmount = calculation.TaxAmount.Round(2)
+	q.ShippingAmount = calculation.ShippingAmount.Round(2)
+	q.DiscountAmount = calculation.DiscountAmount.Round(2)
+	q.TotalAmount = q.Subtotal.Add(q.TaxAmount).Add(q.ShippingAmount).Sub(q.DiscountAmount)
+	q.UpdatedAt = time.Now().UTC()
+
+	return calculation, nil
+}
+
+// NewRevision returns a draft copy of the quotation as its next revision.
+// The caller is responsible for superseding the current revision.
+func (q *Quotation) NewRevision(validUntil time.Time, createdBy uuid.UUID) *Quotation {
+	now := time.Now().UTC()
+	previousID := q.ID
+
+	revision := *q
+	revision.ID = uuid.New()
+	revision.Revision = q.Revision + 1
+	revision.PreviousRevisionID = &previousID
+	revision.Status = QuotationStatusDraft
+	revision.ValidUntil = validUntil
+	revision.RejectionReason = nil
+	revision.ConvertedOrderID = nil
+	revision.CreatedBy = createdBy
+	revision.CreatedAt = now
+	revision.UpdatedAt = now
+	revision.SentAt = nil
+	revision.AcceptedAt = nil
+	revision.RejectedAt = nil
+	revision.ConvertedAt = nil
+
+	revision.Items = make([]OrderItem, len(q.Items))
+	for i, item := range q.Items {
+		item.ID = uuid.New()
+		item.OrderID = revision.ID
+		item.CreatedAt = now
+		item.UpdatedAt = now
+		revision.Items[i] = item
+	}
+
+	return &revision
+}
@@ -0,0 +1,123 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func generateTestQuotation(t *testing.T) *Quotation {
+	id := uuid.New()
+	now := time.Now().UTC()
+
+	return &Quotation{
+		ID:                id,
+		QuotationNumber:   "QT-2026-000001",
+		Revision:          1,
+		CustomerID:        uuid.New(),
+		Status:            QuotationStatusDraft,
+		ValidUntil:        now.Add(30 * 24 * time.Hour),
+		ShippingMethod:    ShippingMethodStandard,
+		ShippingAddressID: uuid.New(),
+		BillingAddressID:  uuid.New(),
+		ShippingAmount:    decimal.NewFromFloat(10.00),
+		Currency:          "USD",
+		Items:             []OrderItem{*generateTestOrderItem(t, id)},
+		CreatedBy:         uuid.New(),
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+}
+
+func TestQuotation_Validate(t *testing.T) {
+	quotation := generateTestQuotation(t)
+	assert.NoError(t, quotation.Validate())
+
+	quotation.QuotationNumber = ""
+	assert.NoError(t, quotation.Validate(), "unnumbered quotations are numbered on insert")
+
+	quotation.Items = nil
+	quotation.Revision = 0
+	err := quotation.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "quotation must have at least one item")
+	assert.Contains(t, err.Error(), "revision must be at least 1")
+}
+
+func TestQuotation_CalculateTotals(t *testing.T) {
+	quotation := generateTestQuotation(t)
+
+	_, err := quotation.CalculateTotals()
+	require.NoError(t, err)
+
+	order := &Order{Items: []OrderItem{*generateTestOrderItem(t, uuid.New())}}
+	order.Items[0].CalculateTotals()
+	expected, err := CalculateOrderTotals(order, decimal.Zero, quotation.ShippingAmount)
+	require.NoError(t, err)
+
+	assert.True(t, quotation.Subtotal.Equal(expected.Subtotal.Round(2)))
+	assert.True(t, quotation.TaxAmount.Equal(expected.TaxAmount.Round(2)))
+	assert.True(t, quotation.DiscountAmount.Equal(expected.DiscountAmount.Round(2)))
+	assert.True(t, quotation.TotalAmount.Equal(
+		quotation.Subtotal.Add(quotation.TaxAmount).Add(quotation.ShippingAmount).Sub(quotation.DiscountAmount)))
+}
+
+func TestQuotation_ChangeStatus(t *testing.T) {
+	quotation := generateTestQuotation(t)
+
+	require.NoError(t, quotation.ChangeStatus(QuotationStatusSent))
+	assert.NotNil(t, quotation.SentAt)
+
+	require.NoError(t, quotation.ChangeStatus(QuotationStatusAccepted))
+	assert.NotNil(t, quotation.AcceptedAt)
+
+	assert.Error(t, quotation.ChangeStatus(QuotationStatusRejected))
+
+	require.NoError(t, quotation.ChangeStatus(QuotationStatusConverted))
+	assert.NotNil(t, quotation.ConvertedAt)
+	assert.True(t, quotation.IsClosed())
+	assert.Error(t, quotation.ChangeStatus(QuotationStatusSuperseded))
+}
+
+func TestQuotation_Expiry(t *testing.T) {
+	quotation := generateTestQuotation(t)
+	quotation.ValidUntil = time.Now().UTC().Add(-time.Hour)
+
+	assert.True(t, quotation.IsExpiredAt(time.Now().UTC()))
+
+	err := quotation.ChangeStatus(QuotationStatusAccepted)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "quotation expired")
+	assert.Equal(t, QuotationStatusDraft, quotation.Status)
+
+	require.NoError(t, quotation.ChangeStatus(QuotationStatusExpired))
+	assert.False(t, quotation.IsExpiredAt(time.Now().UTC()), "closed quotations are not swept again")
+}
+
+func TestQuotation_NewRevision(t *testing.T) {
+	quotation := generateTestQuotation(t)
+	require.NoError(t, quotation.ChangeStatus(QuotationStatusSent))
+
+	validUntil := time.Now().UTC().Add(60 * 24 * time.Hour)
+	revisedBy := uuid.New()
+	revision := quotation.NewRevision(validUntil, revisedBy)
+
+	assert.NotEqual(t, quotation.ID, revision.ID)
+	assert.Equal(t, quotation.QuotationNumber, revision.QuotationNumber)
+	assert.Equal(t, 2, revision.Revision)
+	require.NotNil(t, revision.PreviousRevisionID)
+	assert.Equal(t, quotation.ID, *revision.PreviousRevisionID)
+	assert.Equal(t, QuotationStatusDraft, revision.Status)
+	assert.Nil(t, revision.SentAt)
+	assert.Equal(t, revisedBy, revision.CreatedBy)
+	assert.Equal(t, validUntil, revision.ValidUntil)
+
+	require.Len(t, revision.Items, len(quotation.Items))
+	assert.NotEqual(t, quotation.Items[0].ID, revision.Items[0].ID)
+	assert.Equal(t, revision.ID, revision.Items[0].OrderID)
+	assert.Equal(t, quotation.ID, quotation.Items[0].OrderID, "original lines are left untouched")
+}
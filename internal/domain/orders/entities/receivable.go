@@ -0,0 +1,234 @@
+package entities
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// AgingBucket groups open receivables by how many days they are past due
+type AgingBucket string
+
+const (
+	AgingBucketCurrent AgingBucket = "CURRENT"
+	AgingBucket1To30   AgingBucket = "1-30"
+	AgingBucket31To60  AgingBucket = "31-60"
+	AgingBucket61To90  AgingBucket = "61-90"
+	AgingBucketOver90  AgingBucket = "90+"
+)
+
+// AgingBuckets lists the aging buckets from current to oldest
+var AgingBuckets = []AgingBucket{AgingBucketCurrent, AgingBucket1To30, AgingBucket31To60, AgingBucket61To90, AgingBucketOver90}
+
+// AgingBucketFor returns the bucket of a receivable the given number of days past due
+func AgingBucketFor(daysPastDue int) AgingBucket {
+	switch {
+	case daysPastDue <= 0:
+		return AgingBucketCurrent
+	case daysPastDue <= 30:
+		return AgingBucket1To30
+	case daysPastDue <= 60:
+		return AgingBucket31To60
+	case daysPastDue <= 90:
+		return AgingBucket61To90
+	default:
+		return AgingBucketOver90
+	}
+}
+
+// ReceivableDate returns the date an order became receivable, when it first
+// shipped, or nil while nothing has shipped
+func (o *Order) ReceivableDate() *time.Time {
+	if o.ShippedAt != nil {
+		return o.ShippedAt
+	}
+	return o.ShippingDate
+}
+
+// PaymentDueDate returns the date payment of a shipped order is due under
+// payment terms such as NET30. Orders without valid terms are due on shipment.
+func (o *Order) PaymentDueDate(terms string) *time.Time {
+	receivable := o.ReceivableDate()
+	if receivable == nil {
+		return nil
+	}
+
+	days, err := ParsePaymentTerms(terms)
+	if err != nil {
+		days = 0
+	}
+
+	due := truncateToDay(*receivable).AddDate(0, 0, days)
+	return &due
+}
+
+// DaysPastDue returns how many whole days payment of the order is past due on
+// the date, or zero when it is not yet due or has not shipped
+func (o *Order) DaysPastDue(terms string, asOf time.Time) int {
+	due := o.PaymentDueDate(terms)
+	if due == nil {
+		return 0
+	}
+
+	days := int(truncateToDay(asOf).Sub(*due).Hours() / 24)
+	return max(days, 0)
+}
+
+// IsPaymentOverdue reports whether the order has an unpaid balance past its
+// due date on the date
+func (o *Order) IsPaymentOverdue(terms string, asOf time.Time) bool {
+	return o.GetOutstandingBalance().IsPositive() && o.DaysPastDue(terms, asOf) > 0
+}
+
+// AgingAmounts are open receivables split by aging bucket
+type AgingAmounts struct {
+	Current    decimal.Decimal `json:"current"`
+	Days1To30  decimal.Decimal `json:"days_1_30"`
+	Days31To60 decimal.Decimal `json:"days_31_60"`
+	Days61To90 decimal.Decimal `json:"days_61_90"`
+	Over90     decimal.Decimal `json:"over_90"`
+	Total      decimal.Decimal `json:"total"`
+}
+
+// Add adds an amount to a bucket and the total
+func (a *AgingAmounts) Add(bucket AgingBucket, amount decimal.Decimal) {
+	switch bucket {
+	case AgingBucketCurrent:
+		a.Current = a.Current.Add(amount)
+	case AgingBucket1To30:
+		a.Days1To30 = a.Days1To30.Add(amount)
+	case AgingBucket31To60:
+		a.Days31To60 = a.Days31To60.Add(amount)
+	case AgingBucket61To90:
+		a.Days61To90 = a.Days61To90.Add(amount)
+	default:
+		a.Over90 = a.Over90.Add(amount)
+	}
+	a.Total = a.Total.Add(amount)
+}
+
+// Bucket returns the amount in a bucket
+func (a *AgingAmounts) Bucket(bucket AgingBucket) decimal.Decimal {
+	switch bucket {
+	case AgingBucketCurrent:
+		return a.Current
+	case AgingBucket1To30:
+		return a.Days1To30
+	case AgingBucket31To60:
+		return a.Days31To60
+	case AgingBucket61To90:
+		return a.Days61To90
+	default:
+		return a.Over90
+	}
+}
+
+// CustomerAging is the aged open receivables of one customer
+type CustomerAging struct {
+	CustomerID   uuid.UUID    `json:"customer_id"`
+	CustomerCode string       `json:"customer_code"`
+	CustomerName string       `json:"customer_name"`
+	Terms        string       `json:"terms"`
+	OpenOrders   int          `json:"open_orders"`
+	Amounts      AgingAmounts `json:"amounts"`
+}
+
+// ARAgingReport is the accounts receivable aging on a date, in the base currency
+type ARAgingReport struct {
+	AsOf      time.Time        `json:"as_of"`
+	Currency  string           `json:"currency"`
+	Customers []*CustomerAging `json:"customers"`
+	Totals    AgingAmounts     `json:"totals"`
+}
+
+// BuildARAgingReport ages the outstanding balances of shipped orders by days
+// past their due date. Orders carry their customer, whose payment terms set
+// the due date; balances are converted at each order's booked exchange rate.
+// Customers are listed with the largest balance first.
+func BuildARAgingReport(orders []*Order, asOf time.Time, currency string) *ARAgingReport {
+	report := &ARAgingReport{
+		AsOf:      truncateToDay(asOf),
+		Currency:  currency,
+		Customers: []*CustomerAging{},
+	}
+
+	byCustomer := make(map[uuid.UUID]*CustomerAging)
+	for _, order := range orders {
+		amount := order.CreditExposure()
+		if !amount.IsPositive() || order.ReceivableDate() == nil {
+			continue
+		}
+
+		aging, ok := byCustomer[order.CustomerID]
+		if !ok {
+			aging = &CustomerAging{CustomerID: order.CustomerID}
+			if order.Customer != nil {
+				aging.CustomerCode = order.Customer.CustomerCode
+				aging.CustomerName = order.Customer.GetDisplayName()
+				aging.Terms = order.Customer.Terms
+			}
+			byCustomer[order.CustomerID] = aging
+			report.Customers = append(report.Customers, aging)
+		}
+
+		bucket := AgingBucketFor(order.DaysPastDue(aging.Terms, asOf))
+		aging.OpenOrders++
+		aging.Amounts.Add(bucket, amount)
+		report.Totals.Add(bucket, amount)
+	}
+
+	sort.SliceStable(report.Customers, func(i, j int) bool {
+		if !report.Customers[i].Amounts.Total.Equal(report.Customers[j].Amounts.Total) {
+			return report.Customers[i].Amounts.Total.GreaterThan(report.Customers[j].Amounts.Total)
+		}
+		return report.Customers[i].CustomerName < report.Customers[j].CustomerName
+	})
+
+	return report
+}
+
+// WriteCSV writes the report with one row per customer followed by a total row
+func (r *ARAgingReport) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	header := []string{"customer_code", "customer_name", "terms", "open_orders"}
+	for _, bucket := range AgingBuckets {
+		header = append(header, string(bucket))
+	}
+	header = append(header, "total", "currency")
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	row := func(code, name, terms string, orders int, amounts AgingAmounts) []string {
+		record := []string{code, name, terms, fmt.Sprintf("%d", orders)}
+		for _, bucket := range AgingBuckets {
+			record = append(record, amounts.Bucket(bucket).StringFixed(2))
+		}
+		return append(record, amounts.Total.StringFixed(2), r.Currency)
+	}
+
+	openOrders := 0
+	for _, customer := range r.Customers {
+		openOrders += customer.OpenOrders
+		if err := writer.Write(row(customer.CustomerCode, customer.CustomerName, customer.Terms, customer.OpenOrders, customer.Amounts)); err != nil {
+			return fmt.Errorf("failed to write customer %s: %w", customer.CustomerCode, err)
+		}
+	}
+	if err := writer.Write(row("", "TOTAL", "", openOrders, r.Totals)); err != nil {
+		return fmt.Errorf("failed to write totals: %w", err)
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func truncateToDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
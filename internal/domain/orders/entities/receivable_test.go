@@ -0,0 +1,105 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestReceivable(customer *Customer, total, paid string, shippedAt time.Time) *Order {
+	return &Order{
+		ID:            uuid.New(),
+		CustomerID:    customer.ID,
+		Customer:      customer,
+		Status:        OrderStatusShipped,
+		PaymentStatus: PaymentStatusPending,
+		TotalAmount:   decimal.RequireFromString(total),
+		PaidAmount:    decimal.RequireFromString(paid),
+		ExchangeRate:  decimal.NewFromInt(1),
+		ShippedAt:     &shippedAt,
+	}
+}
+
+func TestAgingBucketFor(t *testing.T) {
+	assert.Equal(t, AgingBucketCurrent, AgingBucketFor(0))
+	assert.Equal(t, AgingBucket1To30, AgingBucketFor(1))
+	assert.Equal(t, AgingBucket1To30, AgingBucketFor(30))
+	assert.Equal(t, AgingBucket31To60, AgingBucketFor(31))
+	assert.Equal(t, AgingBucket61To90, AgingBucketFor(90))
+	assert.Equal(t, AgingBucketOver90, AgingBucketFor(91))
+}
+
+func TestOrderPaymentDueDate(t *testing.T) {
+	customer := &Customer{ID: uuid.New(), Terms: "NET30"}
+	shipped := time.Date(2026, 9, 1, 15, 30, 0, 0, time.UTC)
+	order := newTestReceivable(customer, "100.00", "0", shipped)
+
+	due := order.PaymentDueDate("NET30")
+	require.NotNil(t, due)
+	assert.Equal(t, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), *due)
+
+	assert.False(t, order.IsPaymentOverdue("NET30", time.Date(2026, 10, 1, 23, 0, 0, 0, time.UTC)))
+	assert.True(t, order.IsPaymentOverdue("NET30", time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 15, order.DaysPastDue("NET30", time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)))
+
+	// Unknown terms are due on shipment
+	assert.Equal(t, 1, order.DaysPastDue("PREPAID", time.Date(2026, 9, 2, 0, 0, 0, 0, time.UTC)))
+
+	order.PaidAmount = order.TotalAmount
+	assert.False(t, order.IsPaymentOverdue("NET30", time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)))
+
+	order.ShippedAt = nil
+	assert.Nil(t, order.PaymentDueDate("NET30"))
+}
+
+func TestBuildARAgingReport(t *testing.T) {
+	asOf := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	acme := &Customer{ID: uuid.New(), CustomerCode: "CUST-001", Type: "BUSINESS", CompanyName: stringPtr("Acme"), Terms: "NET30"}
+	jane := &Customer{ID: uuid.New(), CustomerCode: "CUST-002", Type: "INDIVIDUAL", FirstName: "Jane", LastName: "Doe", Terms: "NET15"}
+
+	euroOrder := newTestReceivable(acme, "100.00", "0", asOf.AddDate(0, 0, -75))
+	euroOrder.ExchangeRate = decimal.RequireFromString("1.10")
+	unshipped := newTestReceivable(acme, "500.00", "0", asOf)
+	unshipped.ShippedAt = nil
+
+	report := BuildARAgingReport([]*Order{
+		newTestReceivable(acme, "200.00", "50.00", asOf.AddDate(0, 0, -10)), // current
+		newTestReceivable(acme, "300.00", "0", asOf.AddDate(0, 0, -45)),     // 15 days past due
+		euroOrder, // 45 days past due
+		newTestReceivable(jane, "80.00", "0", asOf.AddDate(0, 0, -120)), // 105 days past due
+		newTestReceivable(jane, "40.00", "40.00", asOf.AddDate(0, 0, -120)),
+		unshipped,
+	}, asOf, "USD")
+
+	require.Len(t, report.Customers, 2)
+	assert.Equal(t, "USD", report.Currency)
+
+	first := report.Customers[0]
+	assert.Equal(t, "Acme", first.CustomerName)
+	assert.Equal(t, 3, first.OpenOrders)
+	assert.True(t, decimal.RequireFromString("150").Equal(first.Amounts.Current))
+	assert.True(t, decimal.RequireFromString("300").Equal(first.Amounts.Days1To30))
+	assert.True(t, decimal.RequireFromString("110").Equal(first.Amounts.Days31To60))
+	assert.True(t, decimal.RequireFromString("560").Equal(first.Amounts.Total))
+
+	second := report.Customers[1]
+	assert.Equal(t, "Jane Doe", second.CustomerName)
+	assert.Equal(t, 1, second.OpenOrders)
+	assert.True(t, decimal.RequireFromString("80").Equal(second.Amounts.Over90))
+
+	assert.True(t, decimal.RequireFromString("640").Equal(report.Totals.Total))
+	assert.True(t, decimal.RequireFromString("80").Equal(report.Totals.Over90))
+
+	var csv strings.Builder
+	require.NoError(t, report.WriteCSV(&csv))
+	lines := strings.Split(strings.TrimSpace(csv.String()), "\n")
+	require.Len(t, lines, 4)
+	assert.Equal(t, "customer_code,customer_name,terms,open_orders,CURRENT,1-30,31-60,61-90,90+,total,currency", lines[0])
+	assert.Equal(t, "CUST-001,Acme,NET30,3,150.00,300.00,110.00,0.00,0.00,560.00,USD", lines[1])
+	assert.Equal(t, ",TOTAL,,4,150.00,300.00,110.00,0.00,80.00,640.00,USD", lines[3])
+}
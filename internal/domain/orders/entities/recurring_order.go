@@ -0,0 +1,335 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecurringOrderFrequency is the unit of a recurring order's interval
+type RecurringOrderFrequency string
+
+const (
+	RecurringOrderFrequencyDaily   RecurringOrderFrequency = "DAILY"
+	RecurringOrderFrequencyWeekly  RecurringOrderFrequency = "WEEKLY"
+	RecurringOrderFrequencyMonthly RecurringOrderFrequency = "MONTHLY"
+)
+
+// RecurringOrderStatus represents the status of a recurring order schedule
+type RecurringOrderStatus string
+
+const (
+	RecurringOrderStatusActive    RecurringOrderStatus = "ACTIVE"
+	RecurringOrderStatusPaused    RecurringOrderStatus = "PAUSED"
+	RecurringOrderStatusCompleted RecurringOrderStatus = "COMPLETED"
+	RecurringOrderStatusCancelled RecurringOrderStatus = "CANCELLED"
+)
+
+// RecurringOrderRunStatus is the outcome of one occurrence of a recurring order
+type RecurringOrderRunStatus string
+
+const (
+	RecurringOrderRunGenerated RecurringOrderRunStatus = "GENERATED"
+	RecurringOrderRunSkipped   RecurringOrderRunStatus = "SKIPPED"
+	RecurringOrderRunFailed    RecurringOrderRunStatus = "FAILED"
+)
+
+// RecurringOrder is a schedule generating sales orders from a template of
+// lines. Occurrences fall every Interval days, weeks or months from the start
+// date, at its time of day; monthly schedules may fix the day of the month,
+// falling on the last day of shorter months. Lines carry no prices, so every
+// generated order is priced from the catalogue when it is generated.
+type RecurringOrder struct {
+	ID         uuid.UUID            `json:"id" db:"id"`
+	Name       string               `json:"name" db:"name"`
+	CustomerID uuid.UUID            `json:"customer_id" db:"customer_id"`
+	Status     RecurringOrderStatus `json:"status" db:"status"`
+
+	// Template of the generated orders
+	Priority          OrderPriority  `json:"priority" db:"priority"`
+	ShippingMethod    ShippingMethod `json:"shipping_method" db:"shipping_method"`
+	ShippingAddressID uuid.UUID      `json:"shipping_address_id" db:"shipping_address_id"`
+	BillingAddressID  uuid.UUID      `json:"billing_address_id" db:"billing_address_id"`
+	// Currency of the generated orders; empty uses the customer's preferred currency
+	Currency string               `json:"currency,omitempty" db:"currency"`
+	Notes    *string              `json:"notes,omitempty" db:"notes"`
+	Items    []RecurringOrderItem `json:"items,omitempty" db:"-"`
+
+	// Schedule
+	Frequency  RecurringOrderFrequency `json:"frequency" db:"frequency"`
+	Interval   int                     `json:"interval" db:"interval_count"`
+	DayOfMonth *int                    `json:"day_of_month,omitempty" db:"day_of_month"`
+	StartDate  time.Time               `json:"start_date" db:"start_date"`
+	// EndDate is the last time an occurrence may fall on
+	EndDate *time.Time `json:"end_date,omitempty" db:"end_date"`
+	// NextRunAt is the next occurrence, nil once the schedule is closed
+	NextRunAt *time.Time `json:"next_run_at,omitempty" db:"next_run_at"`
+	LastRunAt *time.Time `json:"last_run_at,omitempty" db:"last_run_at"`
+
+	CreatedBy uuid.UUID `json:"created_by" db:"created_by"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// RecurringOrderItem is a template line of a recurring order
+type RecurringOrderItem struct {
+	ID               uuid.UUID `json:"id" db:"id"`
+	RecurringOrderID uuid.UUID `json:"recurring_order_id" db:"recurring_order_id"`
+	ProductID        uuid.UUID `json:"product_id" db:"product_id"`
+	Quantity         int       `json:"quantity" db:"quantity"`
+	Notes            *string   `json:"notes,omitempty" db:"notes"`
+	CreatedAt        time.Time `json:"created_at" db:"created_at"`
+}
+
+// RecurringOrderRun records one occurrence of a recurring order: the order
+// generated for it, or why none was
+type RecurringOrderRun struct {
+	ID               uuid.UUID               `json:"id" db:"id"`
+	RecurringOrderID uuid.UUID               `json:"recurring_order_id" db:"recurring_order_id"`
+	ScheduledFor     time.Time               `json:"scheduled_for" db:"scheduled_for"`
+	Status           RecurringOrderRunStatus `json:"status" db:"status"`
+	OrderID          *uuid.UUID              `json:"order_id,omitempty" db:"order_id"`
+	OrderNumber      *string                 `json:"order_number,omitempty" db:"order_number"`
+	// Reason is why the occurrence was skipped or failed
+	Reason    *string    `json:"reason,omitempty" db:"reason"`
+	CreatedBy *uuid.UUID `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+// Validate validates the recurring order
+func (r *RecurringOrder) Validate() error {
+	var errs []error
+
+	if r.ID == uuid.Nil {
+		errs = append(errs, errors.New("recurring order ID cannot be empty"))
+	}
+	if strings.TrimSpace(r.Name) == "" {
+		errs = append(errs, errors.New("recurring order name is required"))
+	}
+	if r.CustomerID == uuid.Nil {
+		errs = append(errs, errors.New("customer ID cannot be empty"))
+	}
+	switch r.Status {
+	case RecurringOrderStatusActive, RecurringOrderStatusPaused, RecurringOrderStatusCompleted, RecurringOrderStatusCancelled:
+	default:
+		errs = append(errs, fmt.Errorf("invalid status: %s", r.Status))
+	}
+
+	if r.ShippingAddressID == uuid.Nil || r.BillingAddressID == uuid.Nil {
+		errs = append(errs, errors.New("shipping and billing addresses are required"))
+	}
+	if err := (&Order{ShippingMethod: r.ShippingMethod}).validateShippingMethod(); err != nil {
+		errs = append(errs, err)
+	}
+	if r.Currency != "" && len(strings.TrimSpace(r.Currency)) != 3 {
+		errs = append(errs, errors.New("currency must be a valid 3-letter ISO 4217 code"))
+	}
+
+	if len(r.Items) == 0 {
+		errs = append(errs, errors.New("recurring order must have at least one item"))
+	}
+	for i, item := range r.Items {
+		if item.ProductID == uuid.Nil {
+			errs = append(errs, fmt.Errorf("invalid item %d: product ID cannot be empty", i+1))
+		}
+		if item.Quantity <= 0 {
+			errs = append(errs, fmt.Errorf("invalid item %d: quantity must be positive", i+1))
+		}
+	}
+
+	switch r.Frequency {
+	case RecurringOrderFrequencyDaily, RecurringOrderFrequencyWeekly, RecurringOrderFrequencyMonthly:
+	default:
+		errs = append(errs, fmt.Errorf("invalid frequency: %s", r.Frequency))
+	}
+	if r.Interval < 1 {
+		errs = append(errs, errors.New("interval must be at least 1"))
+	}
+	if r.DayOfMonth != nil {
+		if r.Frequency != RecurringOrderFrequencyMonthly {
+			errs = append(errs, errors.New("day of month applies to monthly schedules only"))
+		} else if *r.DayOfMonth < 1 || *r.DayOfMonth > 31 {
+			errs = append(errs, errors.New("day of month must be between 1 and 31"))
+		}
+	}
+	if r.StartDate.IsZero() {
+		errs = append(errs, errors.New("start date is required"))
+	}
+	if r.EndDate != nil && r.EndDate.Before(r.StartDate) {
+		errs = append(errs, errors.New("end date cannot be before the start date"))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("validation failed: %v", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// IsClosed reports whether the schedule will generate no more orders
+func (r *RecurringOrder) IsClosed() bool {
+	return r.Status == RecurringOrderStatusCompleted || r.Status == RecurringOrderStatusCancelled
+}
+
+// IsDueAt reports whether an active schedule's next occurrence has come
+func (r *RecurringOrder) IsDueAt(at time.Time) bool {
+	return r.Status == RecurringOrderStatusActive && r.NextRunAt != nil && !r.NextRunAt.After(at)
+}
+
+// OccurrenceAfter returns the first occurrence of the schedule after the
+// given time. Occurrences past the end date are returned too; Schedule closes
+// the schedule on them.
+func (r *RecurringOrder) OccurrenceAfter(after time.Time) time.Time {
+	if after.Before(r.StartDate) {
+		if first := r.occurrence(0); !first.Before(r.StartDate) {
+			return first
+		}
+	}
+
+	// Start from an estimate a little before the answer and step forward
+	var k int
+	if r.Frequency == RecurringOrderFrequencyMonthly {
+		months := (after.Year()-r.StartDate.Year())*12 + int(after.Month()) - int(r.StartDate.Month())
+		k = months/r.every() - 1
+	} else {
+		days := int(after.Sub(r.StartDate).Hours() / 24)
+		k = days/r.stepDays() - 1
+	}
+	if k < 0 {
+		k = 0
+	}
+
+	for {
+		occurrence := r.occurrence(k)
+		if occurrence.After(after) && !occurrence.Before(r.StartDate) {
+			return occurrence
+		}
+		k++
+	}
+}
+
+// Schedule sets the next occurrence to the first one after the given time,
+// completing the schedule when it falls past the end date
+func (r *RecurringOrder) Schedule(after time.Time) {
+	next := r.OccurrenceAfter(after)
+	if r.EndDate != nil && next.After(*r.EndDate) {
+		r.Status = RecurringOrderStatusCompleted
+		r.NextRunAt = nil
+		return
+	}
+	r.NextRunAt = &next
+}
+
+// Reschedule sets the next occurrence to the first one on or after both the
+// start date and the given time, as when a schedule is created or changed
+func (r *RecurringOrder) Reschedule(at time.Time) {
+	from := at
+	if from.Before(r.StartDate) {
+		from = r.StartDate
+	}
+	r.Schedule(from.Add(-time.Nanosecond))
+}
+
+// Advance moves a schedule past its due occurrence. Occurrences missed while
+// no orders were generated are not caught up: the next occurrence is the
+// first one after both the due occurrence and the given time.
+func (r *RecurringOrder) Advance(at time.Time) {
+	after := at
+	if r.NextRunAt != nil {
+		ran := *r.NextRunAt
+		r.LastRunAt = &ran
+		if ran.After(after) {
+			after = ran
+		}
+	}
+	r.UpdatedAt = at
+	r.Schedule(after)
+}
+
+// Skip moves an active or paused schedule past its next occurrence without
+// generating an order and returns the skipped occurrence
+func (r *RecurringOrder) Skip(at time.Time) (time.Time, error) {
+	if r.IsClosed() || r.NextRunAt == nil {
+		return time.Time{}, fmt.Errorf("recurring order is %s", strings.ToLower(string(r.Status)))
+	}
+
+	skipped := *r.NextRunAt
+	r.UpdatedAt = at
+	r.Schedule(skipped)
+	return skipped, nil
+}
+
+// Pause stops an active schedule from generating orders until it is resumed
+func (r *RecurringOrder) Pause(at time.Time) error {
+	if r.Status != RecurringOrderStatusActive {
+		return fmt.Errorf("only active recurring orders can be paused, recurring order is %s", strings.ToLower(string(r.Status)))
+	}
+	r.Status = RecurringOrderStatusPaused
+	r.UpdatedAt = at
+	return nil
+}
+
+// Resume reactivates a paused schedule. Occurrences that fell while it was
+// paused are not generated.
+func (r *RecurringOrder) Resume(at time.Time) error {
+	if r.Status != RecurringOrderStatusPaused {
+		return fmt.Errorf("only paused recurring orders can be resumed, recurring order is %s", strings.ToLower(string(r.Status)))
+	}
+	r.Status = RecurringOrderStatusActive
+	r.UpdatedAt = at
+	if r.NextRunAt == nil || r.NextRunAt.Before(at) {
+		r.Reschedule(at)
+	}
+	return nil
+}
+
+// Cancel closes an active or paused schedule
+func (r *RecurringOrder) Cancel(at time.Time) error {
+	if r.IsClosed() {
+		return fmt.Errorf("recurring order is already %s", strings.ToLower(string(r.Status)))
+	}
+	r.Status = RecurringOrderStatusCancelled
+	r.NextRunAt = nil
+	r.UpdatedAt = at
+	return nil
+}
+
+// occurrence returns the k-th occurrence counted from the start date
+func (r *RecurringOrder) occurrence(k int) time.Time {
+	start := r.StartDate
+	if r.Frequency != RecurringOrderFrequencyMonthly {
+		return start.AddDate(0, 0, k*r.stepDays())
+	}
+
+	// Step whole months from the first of the start month so that no month
+	// is skipped by normalisation, then place the day within the month
+	month := time.Date(start.Year(), start.Month(), 1, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location()).
+		AddDate(0, k*r.every(), 0)
+	day := start.Day()
+	if r.DayOfMonth != nil {
+		day = *r.DayOfMonth
+	}
+	if last := month.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	return month.AddDate(0, 0, day-1)
+}
+
+// stepDays is the number of days between occurrences of daily and weekly schedules
+func (r *RecurringOrder) stepDays() int {
+	if r.Frequency == RecurringOrderFrequencyWeekly {
+		return 7 * r.every()
+	}
+	return r.every()
+}
+
+// every is the interval of the schedule, at least one
+func (r *RecurringOrder) every() int {
+	if r.Interval < 1 {
+		return 1
+	}
+	return r.Interval
+}
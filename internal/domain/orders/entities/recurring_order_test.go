@@ -0,0 +1,158 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func generateTestRecurringOrder(frequency RecurringOrderFrequency, start time.Time) *RecurringOrder {
+	id := uuid.New()
+
+	return &RecurringOrder{
+		ID:                id,
+		Name:              "Weekly office supplies",
+		CustomerID:        uuid.New(),
+		Status:            RecurringOrderStatusActive,
+		Priority:          OrderPriorityNormal,
+		ShippingMethod:    ShippingMethodStandard,
+		ShippingAddressID: uuid.New(),
+		BillingAddressID:  uuid.New(),
+		Items: []RecurringOrderItem{
+			{ID: uuid.New(), RecurringOrderID: id, ProductID: uuid.New(), Quantity: 2},
+		},
+		Frequency: frequency,
+		Interval:  1,
+		StartDate: start,
+		CreatedBy: uuid.New(),
+		CreatedAt: start,
+		UpdatedAt: start,
+	}
+}
+
+func runDate(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 9, 0, 0, 0, time.UTC)
+}
+
+func TestRecurringOrder_Validate(t *testing.T) {
+	recurringOrder := generateTestRecurringOrder(RecurringOrderFrequencyWeekly, runDate(2026, 1, 5))
+	assert.NoError(t, recurringOrder.Validate())
+
+	day := 15
+	recurringOrder.DayOfMonth = &day
+	recurringOrder.Interval = 0
+	endDate := runDate(2025, 12, 1)
+	recurringOrder.EndDate = &endDate
+	recurringOrder.Items = nil
+	err := recurringOrder.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "day of month applies to monthly schedules only")
+	assert.Contains(t, err.Error(), "interval must be at least 1")
+	assert.Contains(t, err.Error(), "end date cannot be before the start date")
+	assert.Contains(t, err.Error(), "recurring order must have at least one item")
+}
+
+func TestRecurringOrder_OccurrenceAfter(t *testing.T) {
+	daily := generateTestRecurringOrder(RecurringOrderFrequencyDaily, runDate(2026, 1, 5))
+	daily.Interval = 3
+	assert.Equal(t, runDate(2026, 1, 5), daily.OccurrenceAfter(runDate(2026, 1, 1)))
+	assert.Equal(t, runDate(2026, 1, 8), daily.OccurrenceAfter(runDate(2026, 1, 5)))
+	assert.Equal(t, runDate(2026, 2, 4), daily.OccurrenceAfter(runDate(2026, 2, 1)))
+
+	weekly := generateTestRecurringOrder(RecurringOrderFrequencyWeekly, runDate(2026, 1, 5))
+	weekly.Interval = 2
+	assert.Equal(t, runDate(2026, 1, 19), weekly.OccurrenceAfter(runDate(2026, 1, 5)))
+	assert.Equal(t, runDate(2026, 3, 2), weekly.OccurrenceAfter(runDate(2026, 2, 20)))
+
+	monthly := generateTestRecurringOrder(RecurringOrderFrequencyMonthly, runDate(2026, 1, 10))
+	day := 31
+	monthly.DayOfMonth = &day
+	assert.Equal(t, runDate(2026, 1, 31), monthly.OccurrenceAfter(runDate(2026, 1, 1)))
+	assert.Equal(t, runDate(2026, 2, 28), monthly.OccurrenceAfter(runDate(2026, 1, 31)), "day is clamped to the end of short months")
+	assert.Equal(t, runDate(2026, 3, 31), monthly.OccurrenceAfter(runDate(2026, 2, 28)), "clamping does not carry into later months")
+
+	quarterly := generateTestRecurringOrder(RecurringOrderFrequencyMonthly, runDate(2026, 1, 15))
+	quarterly.Interval = 3
+	assert.Equal(t, runDate(2026, 4, 15), quarterly.OccurrenceAfter(runDate(2026, 1, 15)))
+	assert.Equal(t, runDate(2027, 1, 15), quarterly.OccurrenceAfter(runDate(2026, 11, 1)))
+}
+
+func TestRecurringOrder_Reschedule(t *testing.T) {
+	recurringOrder := generateTestRecurringOrder(RecurringOrderFrequencyWeekly, runDate(2026, 1, 5))
+
+	recurringOrder.Reschedule(runDate(2025, 12, 1))
+	require.NotNil(t, recurringOrder.NextRunAt)
+	assert.Equal(t, runDate(2026, 1, 5), *recurringOrder.NextRunAt, "the start date is the first occurrence")
+
+	recurringOrder.Reschedule(runDate(2026, 1, 12))
+	assert.Equal(t, runDate(2026, 1, 12), *recurringOrder.NextRunAt)
+
+	recurringOrder.Reschedule(runDate(2026, 1, 13))
+	assert.Equal(t, runDate(2026, 1, 19), *recurringOrder.NextRunAt)
+}
+
+func TestRecurringOrder_Advance(t *testing.T) {
+	recurringOrder := generateTestRecurringOrder(RecurringOrderFrequencyWeekly, runDate(2026, 1, 5))
+	endDate := runDate(2026, 1, 26)
+	recurringOrder.EndDate = &endDate
+	recurringOrder.Reschedule(runDate(2026, 1, 1))
+
+	assert.False(t, recurringOrder.IsDueAt(runDate(2026, 1, 4)))
+	assert.True(t, recurringOrder.IsDueAt(runDate(2026, 1, 5)))
+
+	recurringOrder.Advance(runDate(2026, 1, 5).Add(time.Minute))
+	require.NotNil(t, recurringOrder.LastRunAt)
+	assert.Equal(t, runDate(2026, 1, 5), *recurringOrder.LastRunAt)
+	assert.Equal(t, runDate(2026, 1, 12), *recurringOrder.NextRunAt)
+
+	// Occurrences missed while no orders were generated are not caught up
+	recurringOrder.Advance(runDate(2026, 1, 20))
+	assert.Equal(t, runDate(2026, 1, 12), *recurringOrder.LastRunAt)
+	assert.Equal(t, runDate(2026, 1, 26), *recurringOrder.NextRunAt)
+
+	recurringOrder.Advance(runDate(2026, 1, 26))
+	assert.Equal(t, RecurringOrderStatusCompleted, recurringOrder.Status)
+	assert.Nil(t, recurringOrder.NextRunAt)
+	assert.True(t, recurringOrder.IsClosed())
+	assert.False(t, recurringOrder.IsDueAt(runDate(2026, 2, 2)))
+}
+
+func TestRecurringOrder_Skip(t *testing.T) {
+	recurringOrder := generateTestRecurringOrder(RecurringOrderFrequencyMonthly, runDate(2026, 1, 15))
+	recurringOrder.Reschedule(runDate(2026, 1, 1))
+
+	skipped, err := recurringOrder.Skip(runDate(2026, 1, 2))
+	require.NoError(t, err)
+	assert.Equal(t, runDate(2026, 1, 15), skipped)
+	assert.Equal(t, runDate(2026, 2, 15), *recurringOrder.NextRunAt)
+	assert.Nil(t, recurringOrder.LastRunAt, "skipped occurrences do not count as runs")
+
+	require.NoError(t, recurringOrder.Cancel(runDate(2026, 1, 3)))
+	_, err = recurringOrder.Skip(runDate(2026, 1, 4))
+	assert.Error(t, err)
+}
+
+func TestRecurringOrder_PauseResumeCancel(t *testing.T) {
+	recurringOrder := generateTestRecurringOrder(RecurringOrderFrequencyWeekly, runDate(2026, 1, 5))
+	recurringOrder.Reschedule(runDate(2026, 1, 1))
+
+	require.NoError(t, recurringOrder.Pause(runDate(2026, 1, 2)))
+	assert.Equal(t, RecurringOrderStatusPaused, recurringOrder.Status)
+	assert.False(t, recurringOrder.IsDueAt(runDate(2026, 1, 5)), "paused schedules are not due")
+	assert.Error(t, recurringOrder.Pause(runDate(2026, 1, 3)))
+
+	// Occurrences that fell while paused are not generated
+	require.NoError(t, recurringOrder.Resume(runDate(2026, 1, 20)))
+	assert.Equal(t, RecurringOrderStatusActive, recurringOrder.Status)
+	assert.Equal(t, runDate(2026, 1, 26), *recurringOrder.NextRunAt)
+	assert.Error(t, recurringOrder.Resume(runDate(2026, 1, 21)))
+
+	require.NoError(t, recurringOrder.Cancel(runDate(2026, 1, 22)))
+	assert.Equal(t, RecurringOrderStatusCancelled, recurringOrder.Status)
+	assert.Nil(t, recurringOrder.NextRunAt)
+	assert.Error(t, recurringOrder.Cancel(runDate(2026, 1, 23)))
+	assert.Error(t, recurringOrder.Pause(runDate(2026, 1, 23)))
+}
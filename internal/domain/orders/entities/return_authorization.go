@@ -0,0 +1,384 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ReturnStatus represents the status of a return merchandise authorization
+type ReturnStatus string
+
+const (
+	ReturnStatusRequested ReturnStatus = "REQUESTED"
+	ReturnStatusApproved  ReturnStatus = "APPROVED"
+	ReturnStatusRejected  ReturnStatus = "REJECTED"
+	ReturnStatusReceived  ReturnStatus = "RECEIVED"
+	ReturnStatusInspected ReturnStatus = "INSPECTED"
+	ReturnStatusCompleted ReturnStatus = "COMPLETED"
+	ReturnStatusCancelled ReturnStatus = "CANCELLED"
+)
+
+// ReturnStatusTransitions defines valid return authorization status transitions
+var ReturnStatusTransitions = map[ReturnStatus][]ReturnStatus{
+	ReturnStatusRequested: {ReturnStatusApproved, ReturnStatusRejected, ReturnStatusCancelled},
+	ReturnStatusApproved:  {ReturnStatusReceived, ReturnStatusCancelled},
+	ReturnStatusReceived:  {ReturnStatusInspected},
+	ReturnStatusInspected: {ReturnStatusCompleted},
+}
+
+// ReturnResolution represents how the customer is compensated for a return
+type ReturnResolution string
+
+const (
+	ReturnResolutionRefund      ReturnResolution = "REFUND"
+	ReturnResolutionReplacement ReturnResolution = "REPLACEMENT"
+)
+
+// ReturnReason represents why the customer returns an item
+type ReturnReason string
+
+const (
+	ReturnReasonDamaged        ReturnReason = "DAMAGED"
+	ReturnReasonDefective      ReturnReason = "DEFECTIVE"
+	ReturnReasonWrongItem      ReturnReason = "WRONG_ITEM"
+	ReturnReasonNotAsDescribed ReturnReason = "NOT_AS_DESCRIBED"
+	ReturnReasonNoLongerNeeded ReturnReason = "NO_LONGER_NEEDED"
+	ReturnReasonOther          ReturnReason = "OTHER"
+)
+
+// ReturnDisposition represents the inspection outcome of a returned line
+type ReturnDisposition string
+
+const (
+	// ReturnDispositionRestock puts the goods back into sellable stock
+	ReturnDispositionRestock ReturnDisposition = "RESTOCK"
+	// ReturnDispositionScrap writes the goods off as damaged
+	ReturnDispositionScrap ReturnDisposition = "SCRAP"
+	// ReturnDispositionReturnToVendor ships the goods back to the supplier
+	ReturnDispositionReturnToVendor ReturnDisposition = "RETURN_TO_VENDOR"
+)
+
+var validReturnReasons = map[ReturnReason]bool{
+	ReturnReasonDamaged:        true,
+	ReturnReasonDefective:      true,
+	ReturnReasonWrongItem:      true,
+	ReturnReasonNotAsDescribed: true,
+	ReturnReasonNoLongerNeeded: true,
+	ReturnReasonOther:          true,
+}
+
+// IsValid reports whether the return reason is known
+func (r ReturnReason) IsValid() bool {
+	return validReturnReasons[r]
+}
+
+// IsValid reports whether the return resolution is known
+func (r ReturnResolution) IsValid() bool {
+	return r == ReturnResolutionRefund || r == ReturnResolutionReplacement
+}
+
+// IsValid reports whether the return disposition is known
+func (d ReturnDisposition) IsValid() bool {
+	switch d {
+	case ReturnDispositionRestock, ReturnDispositionScrap, ReturnDispositionReturnToVendor:
+		return true
+	}
+	return false
+}
+
+// ReturnAuthorization represents a return merchandise authorization (RMA) for
+// shipped lines of an order. RMAs are numbered from the document sequence of
+// OrderTypeReturn.
+type ReturnAuthorization struct {
+	ID              uuid.UUID        `json:"id" db:"id"`
+	RMANumber       string           `json:"rma_number" db:"rma_number"`
+	OrderID         uuid.UUID        `json:"order_id" db:"order_id"`
+	CustomerID      uuid.UUID        `json:"customer_id" db:"customer_id"`
+	Status          ReturnStatus     `json:"status" db:"status"`
+	Resolution      ReturnResolution `json:"resolution" db:"resolution"`
+	Reason          ReturnReason     `json:"reason" db:"reason"`
+	CustomerNotes   *string          `json:"customer_notes,omitempty" db:"customer_notes"`
+	RejectionReason *string          `json:"rejection_reason,omitempty" db:"rejection_reason"`
+
+	// WarehouseID is the return warehouse the goods were received into
+	WarehouseID        *uuid.UUID      `json:"warehouse_id,omitempty" db:"warehouse_id"`
+	RefundAmount       decimal.Decimal `json:"refund_amount" db:"refund_amount"`
+	ReplacementOrderID *uuid.UUID      `json:"replacement_order_id,omitempty" db:"replacement_order_id"`
+
+	Items []ReturnAuthorizationItem `json:"items,omitempty" db:"-"`
+
+	RequestedBy uuid.UUID  `json:"requested_by" db:"requested_by"`
+	ApprovedBy  *uuid.UUID `json:"approved_by,omitempty" db:"approved_by"`
+	ReceivedBy  *uuid.UUID `json:"received_by,omitempty" db:"received_by"`
+	InspectedBy *uuid.UUID `json:"inspected_by,omitempty" db:"inspected_by"`
+
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+	ApprovedAt  *time.Time `json:"approved_at,omitempty" db:"approved_at"`
+	RejectedAt  *time.Time `json:"rejected_at,omitempty" db:"rejected_at"`
+	ReceivedAt  *time.Time `json:"received_at,omitempty" db:"received_at"`
+	InspectedAt *time.Time `json:"inspected_at,omitempty" db:"inspected_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+	CancelledAt *time.Time `json:"cancelled_at,omitempty" db:"cancelled_at"`
+}
+
+// ReturnAuthorizationItem represents an authorized return of an order line
+type ReturnAuthorizationItem struct {
+	ID                    uuid.UUID          `json:"id" db:"id"`
+	ReturnAuthorizationID uuid.UUID          `json:"return_authorization_id" db:"return_authorization_id"`
+	OrderItemID           uuid.UUID          `json:"order_item_id" db:"order_item_id"`
+	ProductID             uuid.UUID          `json:"product_id" db:"product_id"`
+	ProductSKU            string             `json:"product_sku" db:"product_sku"`
+	ProductName           string             `json:"product_name" db:"product_name"`
+	Quantity              int                `json:"quantity" db:"quantity"`
+	QuantityReceived      int                `json:"quantity_received" db:"quantity_received"`
+	UnitRefundAmount      decimal.Decimal    `json:"unit_refund_amount" db:"unit_refund_amount"`
+	Reason                ReturnReason       `json:"reason" db:"reason"`
+	Disposition           *ReturnDisposition `json:"disposition,omitempty" db:"disposition"`
+	InspectionNotes       *string            `json:"inspection_notes,omitempty" db:"inspection_notes"`
+	CreatedAt             time.Time          `json:"created_at" db:"created_at"`
+	UpdatedAt             time.Time          `json:"updated_at" db:"updated_at"`
+}
+
+// ReturnInspection is the inspection outcome recorded for a returned line
+type ReturnInspection struct {
+	Disposition ReturnDisposition
+	Notes       *string
+}
+
+// Validate validates the return authorization. An RMA without a number is
+// accepted, since the number is allocated when it is first persisted.
+func (r *ReturnAuthorization) Validate() error {
+	var errs []error
+
+	if r.ID == uuid.Nil {
+		errs = append(errs, errors.New("return authorization ID cannot be empty"))
+	}
+
+	if r.OrderID == uuid.Nil {
+		errs = append(errs, errors.New("order ID cannot be empty"))
+	}
+
+	if r.CustomerID == uuid.Nil {
+		errs = append(errs, errors.New("customer ID cannot be empty"))
+	}
+
+	if _, ok := ReturnStatusTransitions[r.Status]; !ok && !r.IsClosed() {
+		errs = append(errs, fmt.Errorf("invalid status: %s", r.Status))
+	}
+
+	if !r.Resolution.IsValid() {
+		errs = append(errs, fmt.Errorf("invalid resolution: %s", r.Resolution))
+	}
+
+	if !r.Reason.IsValid() {
+		errs = append(errs, fmt.Errorf("invalid reason: %s", r.Reason))
+	}
+
+	if r.RequestedBy == uuid.Nil {
+		errs = append(errs, errors.New("requested by user cannot be empty"))
+	}
+
+	if r.RefundAmount.LessThan(decimal.Zero) {
+		errs = append(errs, errors.New("refund amount cannot be negative"))
+	}
+
+	if len(r.Items) == 0 {
+		errs = append(errs, errors.New("return authorization must have at least one item"))
+	}
+	seen := make(map[uuid.UUID]bool, len(r.Items))
+	for i := range r.Items {
+		if err := r.Items[i].Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("invalid item %d: %w", i+1, err))
+		}
+		if seen[r.Items[i].OrderItemID] {
+			errs = append(errs, fmt.Errorf("order item %s is returned more than once", r.Items[i].OrderItemID))
+		}
+		seen[r.Items[i].OrderItemID] = true
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("validation failed: %v", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// Validate validates a return authorization line
+func (i *ReturnAuthorizationItem) Validate() error {
+	var errs []error
+
+	if i.OrderItemID == uuid.Nil {
+		errs = append(errs, errors.New("order item ID cannot be empty"))
+	}
+
+	if i.ProductID == uuid.Nil {
+		errs = append(errs, errors.New("product ID cannot be empty"))
+	}
+
+	if i.Quantity <= 0 {
+		errs = append(errs, errors.New("quantity must be positive"))
+	}
+
+	if i.QuantityReceived < 0 || i.QuantityReceived > i.Quantity {
+		errs = append(errs, fmt.Errorf("received quantity must be between 0 and %d", i.Quantity))
+	}
+
+	if i.UnitRefundAmount.LessThan(decimal.Zero) {
+		errs = append(errs, errors.New("unit refund amount cannot be negative"))
+	}
+
+	if !i.Reason.IsValid() {
+		errs = append(errs, fmt.Errorf("invalid reason: %s", i.Reason))
+	}
+
+	if i.Disposition != nil && !i.Disposition.IsValid() {
+		errs = append(errs, fmt.Errorf("invalid disposition: %s", *i.Disposition))
+	}
+
+	return errors.Join(errs...)
+}
+
+// IsClosed reports whether the return authorization can no longer change status
+func (r *ReturnAuthorization) IsClosed() bool {
+	switch r.Status {
+	case ReturnStatusRejected, ReturnStatusCompleted, ReturnStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// IsOpen reports whether the return still holds authorized quantities that
+// have not been received
+func (r *ReturnAuthorization) IsOpen() bool {
+	return r.Status == ReturnStatusRequested || r.Status == ReturnStatusApproved
+}
+
+// ChangeStatus moves the return authorization to a new status, stamping the matching date
+func (r *ReturnAuthorization) ChangeStatus(newStatus ReturnStatus) error {
+	valid := false
+	for _, status := range ReturnStatusTransitions[r.Status] {
+		if status == newStatus {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return fmt.Errorf("invalid return status transition from %s to %s", r.Status, newStatus)
+	}
+
+	now := time.Now().UTC()
+	switch newStatus {
+	case ReturnStatusApproved:
+		r.ApprovedAt = &now
+	case ReturnStatusRejected:
+		r.RejectedAt = &now
+	case ReturnStatusReceived:
+		r.ReceivedAt = &now
+	case ReturnStatusInspected:
+		r.InspectedAt = &now
+	case ReturnStatusCompleted:
+		r.CompletedAt = &now
+	case ReturnStatusCancelled:
+		r.CancelledAt = &now
+	}
+
+	r.Status = newStatus
+	r.UpdatedAt = now
+	return nil
+}
+
+// FindItem returns the return line with the given ID
+func (r *ReturnAuthorization) FindItem(itemID uuid.UUID) *ReturnAuthorizationItem {
+	for i := range r.Items {
+		if r.Items[i].ID == itemID {
+			return &r.Items[i]
+		}
+	}
+	return nil
+}
+
+// Receive records the quantities physically received per return line and moves
+// the authorization to RECEIVED. Lines missing from the map were not received.
+// All quantities are validated before any line is changed.
+func (r *ReturnAuthorization) Receive(quantities map[uuid.UUID]int) error {
+	if r.Status != ReturnStatusApproved {
+		return fmt.Errorf("return authorization must be approved before receipt, status is %s", r.Status)
+	}
+
+	total := 0
+	for itemID, quantity := range quantities {
+		item := r.FindItem(itemID)
+		if item == nil {
+			return fmt.Errorf("return line %s not found", itemID)
+		}
+		if quantity < 0 || quantity > item.Quantity {
+			return fmt.Errorf("received quantity for %s must be between 0 and %d", item.ProductSKU, item.Quantity)
+		}
+		total += quantity
+	}
+	if total == 0 {
+		return errors.New("at least one item must be received")
+	}
+
+	now := time.Now().UTC()
+	for i := range r.Items {
+		r.Items[i].QuantityReceived = quantities[r.Items[i].ID]
+		r.Items[i].UpdatedAt = now
+	}
+
+	return r.ChangeStatus(ReturnStatusReceived)
+}
+
+// Inspect records the disposition of every received line and moves the
+// authorization to INSPECTED. Every line with received goods needs an outcome.
+func (r *ReturnAuthorization) Inspect(outcomes map[uuid.UUID]ReturnInspection) error {
+	if r.Status != ReturnStatusReceived {
+		return fmt.Errorf("return authorization must be received before inspection, status is %s", r.Status)
+	}
+
+	for itemID, outcome := range outcomes {
+		item := r.FindItem(itemID)
+		if item == nil {
+			return fmt.Errorf("return line %s not found", itemID)
+		}
+		if item.QuantityReceived == 0 {
+			return fmt.Errorf("return line %s was not received", item.ProductSKU)
+		}
+		if !outcome.Disposition.IsValid() {
+			return fmt.Errorf("invalid disposition: %s", outcome.Disposition)
+		}
+	}
+	for _, item := range r.Items {
+		if _, ok := outcomes[item.ID]; item.QuantityReceived > 0 && !ok {
+			return fmt.Errorf("missing inspection outcome for %s", item.ProductSKU)
+		}
+	}
+
+	now := time.Now().UTC()
+	for i := range r.Items {
+		outcome, ok := outcomes[r.Items[i].ID]
+		if !ok {
+			continue
+		}
+		disposition := outcome.Disposition
+		r.Items[i].Disposition = &disposition
+		r.Items[i].InspectionNotes = outcome.Notes
+		r.Items[i].UpdatedAt = now
+	}
+
+	return r.ChangeStatus(ReturnStatusInspected)
+}
+
+// RefundTotal returns the refund owed for the received quantities
+func (r *ReturnAuthorization) RefundTotal() decimal.Decimal {
+	total := decimal.Zero
+	for _, item := range r.Items {
+		total = total.Add(item.UnitRefundAmount.Mul(decimal.NewFromInt(int64(item.QuantityReceived))))
+	}
+	return total.Round(2)
+}
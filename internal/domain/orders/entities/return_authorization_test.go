@@ -0,0 +1,118 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func generateTestReturnAuthorization(t *testing.T) *ReturnAuthorization {
+	id := uuid.New()
+	now := time.Now().UTC()
+
+	newItem := func(sku string, quantity int, unitRefund float64) ReturnAuthorizationItem {
+		return ReturnAuthorizationItem{
+			ID:                    uuid.New(),
+			ReturnAuthorizationID: id,
+			OrderItemID:           uuid.New(),
+			ProductID:             uuid.New(),
+			ProductSKU:            sku,
+			ProductName:           "Product " + sku,
+			Quantity:              quantity,
+			UnitRefundAmount:      decimal.NewFromFloat(unitRefund),
+			Reason:                ReturnReasonDefective,
+			CreatedAt:             now,
+			UpdatedAt:             now,
+		}
+	}
+
+	return &ReturnAuthorization{
+		ID:          id,
+		RMANumber:   "RMA-2026-000001",
+		OrderID:     uuid.New(),
+		CustomerID:  uuid.New(),
+		Status:      ReturnStatusRequested,
+		Resolution:  ReturnResolutionRefund,
+		Reason:      ReturnReasonDefective,
+		Items:       []ReturnAuthorizationItem{newItem("SKU-1", 2, 10.50), newItem("SKU-2", 1, 4.25)},
+		RequestedBy: uuid.New(),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+func TestReturnAuthorization_Validate(t *testing.T) {
+	rma := generateTestReturnAuthorization(t)
+	assert.NoError(t, rma.Validate())
+
+	rma.RMANumber = ""
+	assert.NoError(t, rma.Validate(), "unnumbered returns are numbered on insert")
+
+	rma.Resolution = "STORE_CREDIT"
+	rma.Items[1].OrderItemID = rma.Items[0].OrderItemID
+	rma.Items[0].QuantityReceived = 3
+	err := rma.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid resolution")
+	assert.Contains(t, err.Error(), "returned more than once")
+	assert.Contains(t, err.Error(), "received quantity must be between 0 and 2")
+}
+
+func TestReturnAuthorization_ChangeStatus(t *testing.T) {
+	rma := generateTestReturnAuthorization(t)
+
+	require.NoError(t, rma.ChangeStatus(ReturnStatusApproved))
+	assert.NotNil(t, rma.ApprovedAt)
+
+	assert.Error(t, rma.ChangeStatus(ReturnStatusRejected), "approved returns cannot be rejected")
+	assert.Error(t, rma.ChangeStatus(ReturnStatusCompleted), "returns must be received and inspected first")
+
+	require.NoError(t, rma.ChangeStatus(ReturnStatusCancelled))
+	assert.NotNil(t, rma.CancelledAt)
+	assert.True(t, rma.IsClosed())
+}
+
+func TestReturnAuthorization_ReceiveAndInspect(t *testing.T) {
+	rma := generateTestReturnAuthorization(t)
+	first, second := rma.Items[0].ID, rma.Items[1].ID
+
+	err := rma.Receive(map[uuid.UUID]int{first: 1})
+	require.Error(t, err, "requested returns cannot be received")
+
+	require.NoError(t, rma.ChangeStatus(ReturnStatusApproved))
+
+	err = rma.Receive(map[uuid.UUID]int{first: 3})
+	require.Error(t, err)
+	assert.Equal(t, 0, rma.Items[0].QuantityReceived, "invalid receipts leave lines untouched")
+
+	require.Error(t, rma.Receive(map[uuid.UUID]int{first: 0}), "nothing received")
+	require.Error(t, rma.Receive(map[uuid.UUID]int{uuid.New(): 1}), "unknown line")
+
+	require.NoError(t, rma.Receive(map[uuid.UUID]int{first: 2}))
+	assert.Equal(t, ReturnStatusReceived, rma.Status)
+	assert.NotNil(t, rma.ReceivedAt)
+	assert.True(t, decimal.NewFromFloat(21.00).Equal(rma.RefundTotal()))
+
+	err = rma.Inspect(map[uuid.UUID]ReturnInspection{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing inspection outcome for SKU-1")
+
+	err = rma.Inspect(map[uuid.UUID]ReturnInspection{
+		first:  {Disposition: ReturnDispositionRestock},
+		second: {Disposition: ReturnDispositionScrap},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "was not received")
+
+	require.Error(t, rma.Inspect(map[uuid.UUID]ReturnInspection{first: {Disposition: "RESELL"}}))
+
+	require.NoError(t, rma.Inspect(map[uuid.UUID]ReturnInspection{first: {Disposition: ReturnDispositionScrap}}))
+	assert.Equal(t, ReturnStatusInspected, rma.Status)
+	require.NotNil(t, rma.Items[0].Disposition)
+	assert.Equal(t, ReturnDispositionScrap, *rma.Items[0].Disposition)
+	assert.Nil(t, rma.Items[1].Disposition)
+}
@@ -0,0 +1,199 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ShipmentStatus represents the status of a shipment
+type ShipmentStatus string
+
+const (
+	ShipmentStatusShipped   ShipmentStatus = "SHIPPED"
+	ShipmentStatusDelivered ShipmentStatus = "DELIVERED"
+)
+
+// Shipment represents one wave of an order leaving the warehouse: the order
+// lines it carries, the packages they are packed in and their carrier tracking.
+// An order shipped in several waves has one shipment per wave.
+type Shipment struct {
+	ID             uuid.UUID      `json:"id" db:"id"`
+	OrderID        uuid.UUID      `json:"order_id" db:"order_id"`
+	ShipmentNumber string         `json:"shipment_number" db:"shipment_number"`
+	Status         ShipmentStatus `json:"status" db:"status"`
+	Carrier        *string        `json:"carrier,omitempty" db:"carrier"`
+	TrackingNumber *string        `json:"tracking_number,omitempty" db:"tracking_number"`
+	DeliveryProof  *string        `json:"delivery_proof,omitempty" db:"delivery_proof"`
+	Notes          *string        `json:"notes,omitempty" db:"notes"`
+
+	Items    []ShipmentItem    `json:"items,omitempty" db:"-"`
+	Packages []ShipmentPackage `json:"packages,omitempty" db:"-"`
+
+	ShippedBy   uuid.UUID  `json:"shipped_by" db:"shipped_by"`
+	DeliveredBy *uuid.UUID `json:"delivered_by,omitempty" db:"delivered_by"`
+	ShippedAt   time.Time  `json:"shipped_at" db:"shipped_at"`
+	DeliveredAt *time.Time `json:"delivered_at,omitempty" db:"delivered_at"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// ShipmentItem represents the quantity of an order line carried by a shipment
+type ShipmentItem struct {
+	ID          uuid.UUID `json:"id" db:"id"`
+	ShipmentID  uuid.UUID `json:"shipment_id" db:"shipment_id"`
+	OrderItemID uuid.UUID `json:"order_item_id" db:"order_item_id"`
+	ProductID   uuid.UUID `json:"product_id" db:"product_id"`
+	Quantity    int       `json:"quantity" db:"quantity"`
+}
+
+// ShipmentPackage represents a physical parcel of a shipment. Packages may
+// carry their own tracking number when the carrier tracks parcels separately.
+type ShipmentPackage struct {
+	ID             uuid.UUID       `json:"id" db:"id"`
+	ShipmentID     uuid.UUID       `json:"shipment_id" db:"shipment_id"`
+	TrackingNumber *string         `json:"tracking_number,omitempty" db:"tracking_number"`
+	Weight         decimal.Decimal `json:"weight" db:"weight"`
+	WeightUnit     string          `json:"weight_unit" db:"weight_unit"`
+	Length         decimal.Decimal `json:"length" db:"length"`
+	Width          decimal.Decimal `json:"width" db:"width"`
+	Height         decimal.Decimal `json:"height" db:"height"`
+	DimensionUnit  string          `json:"dimension_unit" db:"dimension_unit"`
+}
+
+// Validate validates the shipment and its lines and packages
+func (s *Shipment) Validate() error {
+	var errs []error
+
+	if s.OrderID == uuid.Nil {
+		errs = append(errs, errors.New("order ID cannot be empty"))
+	}
+	if s.Status != ShipmentStatusShipped && s.Status != ShipmentStatusDelivered {
+		errs = append(errs, fmt.Errorf("invalid shipment status: %s", s.Status))
+	}
+	if s.ShippedBy == uuid.Nil {
+		errs = append(errs, errors.New("shipped by cannot be empty"))
+	}
+	if s.Carrier != nil && len(*s.Carrier) > 50 {
+		errs = append(errs, errors.New("carrier name cannot exceed 50 characters"))
+	}
+	if s.TrackingNumber != nil && len(*s.TrackingNumber) > 100 {
+		errs = append(errs, errors.New("tracking number cannot exceed 100 characters"))
+	}
+	if len(s.Items) == 0 {
+		errs = append(errs, errors.New("shipment must have at least one item"))
+	}
+
+	seen := make(map[uuid.UUID]bool, len(s.Items))
+	for i, item := range s.Items {
+		if item.OrderItemID == uuid.Nil {
+			errs = append(errs, fmt.Errorf("item %d: order item ID cannot be empty", i+1))
+		}
+		if seen[item.OrderItemID] {
+			errs = append(errs, fmt.Errorf("item %d: order item %s is listed more than once", i+1, item.OrderItemID))
+		}
+		seen[item.OrderItemID] = true
+		if item.Quantity <= 0 {
+			errs = append(errs, fmt.Errorf("item %d: quantity must be positive", i+1))
+		}
+	}
+
+	for i, pkg := range s.Packages {
+		if err := pkg.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("package %d: %w", i+1, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("shipment validation failed: %v", errs)
+	}
+	return nil
+}
+
+// Validate validates the package weight and dimensions
+func (p *ShipmentPackage) Validate() error {
+	if p.Weight.LessThan(decimal.Zero) {
+		return errors.New("weight cannot be negative")
+	}
+	if p.Length.LessThan(decimal.Zero) || p.Width.LessThan(decimal.Zero) || p.Height.LessThan(decimal.Zero) {
+		return errors.New("dimensions cannot be negative")
+	}
+	if p.TrackingNumber != nil && len(*p.TrackingNumber) > 100 {
+		return errors.New("tracking number cannot exceed 100 characters")
+	}
+	return nil
+}
+
+// IsDelivered reports whether the shipment reached the customer
+func (s *Shipment) IsDelivered() bool {
+	return s.Status == ShipmentStatusDelivered
+}
+
+// Deliver marks the shipment as delivered at the given time
+func (s *Shipment) Deliver(deliveredAt time.Time, deliveredBy uuid.UUID, proof *string) error {
+	if s.IsDelivered() {
+		return fmt.Errorf("shipment %s is already delivered", s.ShipmentNumber)
+	}
+	if deliveredAt.Before(s.ShippedAt) {
+		return errors.New("delivery date cannot be before the shipping date")
+	}
+
+	deliveredAt = deliveredAt.UTC()
+	s.Status = ShipmentStatusDelivered
+	s.DeliveredAt = &deliveredAt
+	s.DeliveredBy = &deliveredBy
+	if proof != nil && strings.TrimSpace(*proof) != "" {
+		trimmed := strings.TrimSpace(*proof)
+		s.DeliveryProof = &trimmed
+	}
+	s.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// DeliveredQuantities sums the delivered quantity of every order line across shipments
+func DeliveredQuantities(shipments []*Shipment) map[uuid.UUID]int {
+	delivered := make(map[uuid.UUID]int)
+	for _, shipment := range shipments {
+		if !shipment.IsDelivered() {
+			continue
+		}
+		for _, item := range shipment.Items {
+			delivered[item.OrderItemID] += item.Quantity
+		}
+	}
+	return delivered
+}
+
+// FulfillmentStatus derives the order status from its shipped quantities and
+// the state of its shipments: PROCESSING before anything ships,
+// PARTIALLY_SHIPPED while lines remain, SHIPPED once everything is on its way
+// and DELIVERED once every shipment has arrived.
+func FulfillmentStatus(items []OrderItem, shipments []*Shipment) OrderStatus {
+	shippedAny, shippedAll := false, true
+	for _, item := range items {
+		if item.QuantityShipped > 0 {
+			shippedAny = true
+		}
+		if item.QuantityShipped < item.Quantity {
+			shippedAll = false
+		}
+	}
+
+	switch {
+	case !shippedAny:
+		return OrderStatusProcessing
+	case !shippedAll:
+		return OrderStatusPartiallyShipped
+	}
+
+	for _, shipment := range shipments {
+		if !shipment.IsDelivered() {
+			return OrderStatusShipped
+		}
+	}
+	return OrderStatusDelivered
+}
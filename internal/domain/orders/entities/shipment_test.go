@@ -0,0 +1,124 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func generateTestShipment(t *testing.T, orderID uuid.UUID, items ...ShipmentItem) *Shipment {
+	carrier := "UPS"
+	tracking := "1Z999AA10123456784"
+	now := time.Now().UTC()
+
+	return &Shipment{
+		ID:             uuid.New(),
+		OrderID:        orderID,
+		ShipmentNumber: "SO-000001-1",
+		Status:         ShipmentStatusShipped,
+		Carrier:        &carrier,
+		TrackingNumber: &tracking,
+		Items:          items,
+		Packages: []ShipmentPackage{
+			{Weight: decimal.NewFromFloat(2.5), WeightUnit: "kg", Length: decimal.NewFromInt(30), Width: decimal.NewFromInt(20), Height: decimal.NewFromInt(10), DimensionUnit: "cm"},
+		},
+		ShippedBy: uuid.New(),
+		ShippedAt: now,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func TestShipmentValidate(t *testing.T) {
+	orderItemID := uuid.New()
+	shipment := generateTestShipment(t, uuid.New(), ShipmentItem{OrderItemID: orderItemID, Quantity: 2})
+	require.NoError(t, shipment.Validate())
+
+	shipment.Items = append(shipment.Items, ShipmentItem{OrderItemID: orderItemID, Quantity: 1})
+	err := shipment.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "listed more than once")
+
+	shipment.Items = nil
+	err = shipment.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "at least one item")
+
+	shipment.Items = []ShipmentItem{{OrderItemID: orderItemID, Quantity: 1}}
+	shipment.Packages[0].Weight = decimal.NewFromInt(-1)
+	err = shipment.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "weight cannot be negative")
+}
+
+func TestShipmentDeliver(t *testing.T) {
+	shipment := generateTestShipment(t, uuid.New(), ShipmentItem{OrderItemID: uuid.New(), Quantity: 1})
+	deliveredBy := uuid.New()
+	proof := "  signed by J. Doe  "
+
+	err := shipment.Deliver(shipment.ShippedAt.Add(-time.Hour), deliveredBy, nil)
+	require.Error(t, err)
+
+	require.NoError(t, shipment.Deliver(shipment.ShippedAt.Add(24*time.Hour), deliveredBy, &proof))
+	assert.True(t, shipment.IsDelivered())
+	assert.Equal(t, &deliveredBy, shipment.DeliveredBy)
+	assert.Equal(t, "signed by J. Doe", *shipment.DeliveryProof)
+
+	err = shipment.Deliver(time.Now().UTC(), deliveredBy, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already delivered")
+}
+
+func TestFulfillmentStatus(t *testing.T) {
+	order := generateTestOrder(t)
+	first := generateTestOrderItem(t, order.ID)
+	second := generateTestOrderItem(t, order.ID)
+	items := []OrderItem{*first, *second}
+
+	assert.Equal(t, OrderStatusProcessing, FulfillmentStatus(items, nil))
+
+	items[0].QuantityShipped = items[0].Quantity
+	wave1 := generateTestShipment(t, order.ID, ShipmentItem{OrderItemID: items[0].ID, Quantity: items[0].Quantity})
+	shipments := []*Shipment{wave1}
+	assert.Equal(t, OrderStatusPartiallyShipped, FulfillmentStatus(items, shipments))
+
+	// Delivering the first wave does not deliver an order that is still being shipped
+	require.NoError(t, wave1.Deliver(time.Now().UTC(), uuid.New(), nil))
+	assert.Equal(t, OrderStatusPartiallyShipped, FulfillmentStatus(items, shipments))
+
+	items[1].QuantityShipped = items[1].Quantity
+	wave2 := generateTestShipment(t, order.ID, ShipmentItem{OrderItemID: items[1].ID, Quantity: items[1].Quantity})
+	shipments = append(shipments, wave2)
+	assert.Equal(t, OrderStatusShipped, FulfillmentStatus(items, shipments))
+
+	require.NoError(t, wave2.Deliver(time.Now().UTC(), uuid.New(), nil))
+	assert.Equal(t, OrderStatusDelivered, FulfillmentStatus(items, shipments))
+
+	delivered := DeliveredQuantities(shipments)
+	assert.Equal(t, items[0].Quantity, delivered[items[0].ID])
+	assert.Equal(t, items[1].Quantity, delivered[items[1].ID])
+}
+
+func TestBuildOrderTimelineWithShipments(t *testing.T) {
+	order := generateTestOrder(t)
+	item := generateTestOrderItem(t, order.ID)
+
+	wave1 := generateTestShipment(t, order.ID, ShipmentItem{OrderItemID: item.ID, Quantity: 1})
+	wave1.ShipmentNumber = "SO-000001-1"
+	wave1.ShippedAt = time.Date(2026, time.January, 10, 9, 0, 0, 0, time.UTC)
+	require.NoError(t, wave1.Deliver(wave1.ShippedAt.Add(48*time.Hour), uuid.New(), nil))
+
+	wave2 := generateTestShipment(t, order.ID, ShipmentItem{OrderItemID: item.ID, Quantity: 1})
+	wave2.ShipmentNumber = "SO-000001-2"
+	wave2.ShippedAt = wave1.ShippedAt.Add(24 * time.Hour)
+
+	events := BuildOrderTimeline(order, nil, []*Shipment{wave1, wave2})
+	require.Len(t, events, 3)
+	assert.Equal(t, "Shipment SO-000001-1 dispatched", events[0].Description)
+	assert.Equal(t, "Shipment SO-000001-2 dispatched", events[1].Description)
+	assert.Equal(t, "Shipment SO-000001-1 delivered", events[2].Description)
+}
@@ -0,0 +1,355 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var validShippingMethods = []ShippingMethod{
+	ShippingMethodStandard, ShippingMethodExpress, ShippingMethodOvernight,
+	ShippingMethodInternational, ShippingMethodPickup, ShippingMethodDigital,
+}
+
+// ParseShippingMethod normalizes a shipping method and reports whether it is known
+func ParseShippingMethod(value string) (ShippingMethod, error) {
+	normalized := ShippingMethod(strings.ToUpper(strings.TrimSpace(value)))
+	for _, method := range validShippingMethods {
+		if normalized == method {
+			return method, nil
+		}
+	}
+	return "", fmt.Errorf("invalid shipping method: %s", value)
+}
+
+// ShippingZone is a delivery area matched against shipping addresses by
+// country, state and postal code prefix. Unlike tax zones, shipping zones do
+// not stack: each shipping method is priced by the most specific matching
+// zone that has a rate for it, so a postal code zone for a city can override
+// the state zone around it for express deliveries only.
+type ShippingZone struct {
+	ID      uuid.UUID `json:"id" db:"id"`
+	Code    string    `json:"code" db:"code"`
+	Name    string    `json:"name" db:"name"`
+	Country string    `json:"country" db:"country"`
+	// State limits the zone to one state or region; nil covers the whole country
+	State *string `json:"state,omitempty" db:"state"`
+	// PostalCodePrefixes limits the zone to some postal codes; empty covers them all
+	PostalCodePrefixes []string  `json:"postal_code_prefixes,omitempty" db:"postal_code_prefixes"`
+	IsActive           bool      `json:"is_active" db:"is_active"`
+	CreatedAt          time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at" db:"updated_at"`
+
+	Rates []ShippingRate `json:"rates,omitempty" db:"-"`
+}
+
+// ShippingRate prices one shipping method within a zone for a weight bracket.
+// Weights are in kilograms and item dimensions in centimetres.
+type ShippingRate struct {
+	ID     uuid.UUID      `json:"id" db:"id"`
+	ZoneID uuid.UUID      `json:"zone_id" db:"zone_id"`
+	Method ShippingMethod `json:"method" db:"method"`
+	// Name is the service name shown in quotes, e.g. "Ground 0-5 kg"
+	Name string `json:"name" db:"name"`
+	// MinWeight and MaxWeight bound the chargeable weight the rate applies to;
+	// the bracket includes its minimum and excludes its maximum, and a nil
+	// maximum leaves it open-ended
+	MinWeight float64  `json:"min_weight" db:"min_weight"`
+	MaxWeight *float64 `json:"max_weight,omitempty" db:"max_weight"`
+	// BaseRate is charged at the bracket minimum; PerKgRate is added for every
+	// kilogram above it
+	BaseRate    decimal.Decimal `json:"base_rate" db:"base_rate"`
+	PerKgRate   decimal.Decimal `json:"per_kg_rate" db:"per_kg_rate"`
+	HandlingFee decimal.Decimal `json:"handling_fee" db:"handling_fee"`
+	// FreeShippingThreshold waives the charge for orders whose merchandise
+	// reaches it; nil never waives it
+	FreeShippingThreshold *decimal.Decimal `json:"free_shipping_threshold,omitempty" db:"free_shipping_threshold"`
+	// DimensionalDivisor converts item volume in cubic centimetres to a
+	// dimensional weight, e.g. 5000; zero charges the actual weight only
+	DimensionalDivisor float64   `json:"dimensional_divisor" db:"dimensional_divisor"`
+	MinDeliveryDays    int       `json:"min_delivery_days" db:"min_delivery_days"`
+	MaxDeliveryDays    int       `json:"max_delivery_days" db:"max_delivery_days"`
+	IsActive           bool      `json:"is_active" db:"is_active"`
+	CreatedAt          time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// ShippingQuote is the price and delivery estimate of one shipping method for an order
+type ShippingQuote struct {
+	Method   ShippingMethod `json:"method"`
+	RateID   uuid.UUID      `json:"rate_id"`
+	Name     string         `json:"name"`
+	ZoneCode string         `json:"zone_code"`
+	// ChargeableWeight is the greater of the actual and dimensional weights
+	ActualWeight      float64         `json:"actual_weight"`
+	DimensionalWeight float64         `json:"dimensional_weight"`
+	ChargeableWeight  float64         `json:"chargeable_weight"`
+	Amount            decimal.Decimal `json:"amount"`
+	// FreeShipping reports whether the order reached the free shipping threshold
+	FreeShipping          bool      `json:"free_shipping"`
+	MinDeliveryDays       int       `json:"min_delivery_days"`
+	MaxDeliveryDays       int       `json:"max_delivery_days"`
+	EstimatedDeliveryFrom time.Time `json:"estimated_delivery_from"`
+	EstimatedDeliveryTo   time.Time `json:"estimated_delivery_to"`
+}
+
+// Validate validates the zone and its rates. Active rates of the same method
+// cannot have overlapping weight brackets.
+func (z *ShippingZone) Validate() error {
+	var errs []error
+
+	if z.ID == uuid.Nil {
+		errs = append(errs, errors.New("shipping zone ID cannot be empty"))
+	}
+	if strings.TrimSpace(z.Code) == "" || len(z.Code) > 50 {
+		errs = append(errs, errors.New("shipping zone code is required and cannot exceed 50 characters"))
+	}
+	if strings.TrimSpace(z.Name) == "" {
+		errs = append(errs, errors.New("shipping zone name is required"))
+	}
+	if !countryPattern.MatchString(z.Country) {
+		errs = append(errs, errors.New("country must be a 2-letter ISO 3166 code"))
+	}
+	if z.State != nil && strings.TrimSpace(*z.State) == "" {
+		errs = append(errs, errors.New("state cannot be blank"))
+	}
+	for _, prefix := range z.PostalCodePrefixes {
+		if normalizePostalCode(prefix) == "" {
+			errs = append(errs, errors.New("postal code prefixes cannot be blank"))
+			break
+		}
+	}
+	for n := range z.Rates {
+		if err := z.Rates[n].Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("rate %d: %w", n+1, err))
+		}
+	}
+	for n := range z.Rates {
+		for m := n + 1; m < len(z.Rates); m++ {
+			if z.Rates[n].overlaps(&z.Rates[m]) {
+				errs = append(errs, fmt.Errorf("rates %d and %d have overlapping %s weight brackets", n+1, m+1, z.Rates[n].Method))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("validation failed: %v", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// Matches reports whether an address lies in the zone
+func (z *ShippingZone) Matches(address *OrderAddress) bool {
+	return z.IsActive && inJurisdiction(z.Country, z.State, z.PostalCodePrefixes, address)
+}
+
+// specificity ranks zones so postal code zones beat state zones, which beat
+// country-wide zones
+func (z *ShippingZone) specificity() int {
+	specificity := 0
+	if len(z.PostalCodePrefixes) > 0 {
+		specificity += 2
+	}
+	if z.State != nil {
+		specificity++
+	}
+	return specificity
+}
+
+// Validate validates the rate
+func (r *ShippingRate) Validate() error {
+	if _, err := ParseShippingMethod(string(r.Method)); err != nil {
+		return err
+	}
+	if strings.TrimSpace(r.Name) == "" {
+		return errors.New("shipping rate name is required")
+	}
+	if r.MinWeight < 0 {
+		return errors.New("minimum weight cannot be negative")
+	}
+	if r.MaxWeight != nil && *r.MaxWeight <= r.MinWeight {
+		return errors.New("maximum weight must be greater than minimum weight")
+	}
+	if r.BaseRate.IsNegative() || r.PerKgRate.IsNegative() || r.HandlingFee.IsNegative() {
+		return errors.New("shipping charges cannot be negative")
+	}
+	if r.FreeShippingThreshold != nil && r.FreeShippingThreshold.IsNegative() {
+		return errors.New("free shipping threshold cannot be negative")
+	}
+	if r.DimensionalDivisor < 0 {
+		return errors.New("dimensional divisor cannot be negative")
+	}
+	if r.MinDeliveryDays < 0 || r.MaxDeliveryDays < r.MinDeliveryDays {
+		return errors.New("delivery days must be non-negative with the maximum at least the minimum")
+	}
+	return nil
+}
+
+// Covers reports whether a chargeable weight falls in the rate's bracket
+func (r *ShippingRate) Covers(weight float64) bool {
+	return weight >= r.MinWeight && (r.MaxWeight == nil || weight < *r.MaxWeight)
+}
+
+// overlaps reports whether two active rates of the same method share part of
+// their weight brackets
+func (r *ShippingRate) overlaps(other *ShippingRate) bool {
+	if !r.IsActive || !other.IsActive || r.Method != other.Method {
+		return false
+	}
+	upper := func(rate *ShippingRate) float64 {
+		if rate.MaxWeight == nil {
+			return math.Inf(1)
+		}
+		return *rate.MaxWeight
+	}
+	return r.MinWeight < upper(other) && other.MinWeight < upper(r)
+}
+
+// Charge returns the shipping charge for a chargeable weight and merchandise
+// amount, and whether it was waived by the free shipping threshold
+func (r *ShippingRate) Charge(weight float64, merchandise decimal.Decimal) (decimal.Decimal, bool) {
+	if r.FreeShippingThreshold != nil && merchandise.GreaterThanOrEqual(*r.FreeShippingThreshold) {
+		return decimal.Zero, true
+	}
+
+	extra := decimal.NewFromFloat(math.Max(weight-r.MinWeight, 0))
+	return r.BaseRate.Add(r.PerKgRate.Mul(extra)).Add(r.HandlingFee).Round(2), false
+}
+
+// QuoteShipping prices every shipping method available at an order's shipping
+// address by the rates of the matching zones. Each method is priced by the
+// most specific zone that has an active rate for it covering the order's
+// chargeable weight. Quotes are sorted cheapest first and delivery estimates
+// count business days from the given time. Orders shipped outside configured
+// zones get no quotes.
+func QuoteShipping(order *Order, zones []*ShippingZone, at time.Time) []ShippingQuote {
+	var matched []*ShippingZone
+	for _, zone := range zones {
+		if zone.Matches(order.ShippingAddress) {
+			matched = append(matched, zone)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		return matched[i].specificity() > matched[j].specificity()
+	})
+
+	merchandise := decimal.Zero
+	for _, item := range order.Items {
+		merchandise = merchandise.Add(item.LineAmount())
+	}
+	actual := CalculateShippingWeight(order)
+
+	quoted := make(map[ShippingMethod]bool)
+	quotes := []ShippingQuote{}
+	for _, zone := range matched {
+		var zoneQuotes []ShippingQuote
+		for i := range zone.Rates {
+			rate := &zone.Rates[i]
+			if !rate.IsActive || quoted[rate.Method] {
+				continue
+			}
+			dimensional := CalculateDimensionalWeight(order, rate.DimensionalDivisor)
+			chargeable := math.Max(actual, dimensional)
+			if !rate.Covers(chargeable) {
+				continue
+			}
+
+			amount, free := rate.Charge(chargeable, merchandise)
+			zoneQuotes = append(zoneQuotes, ShippingQuote{
+				Method:                rate.Method,
+				RateID:                rate.ID,
+				Name:                  rate.Name,
+				ZoneCode:              zone.Code,
+				ActualWeight:          actual,
+				DimensionalWeight:     dimensional,
+				ChargeableWeight:      chargeable,
+				Amount:                amount,
+				FreeShipping:          free,
+				MinDeliveryDays:       rate.MinDeliveryDays,
+				MaxDeliveryDays:       rate.MaxDeliveryDays,
+				EstimatedDeliveryFrom: addBusinessDays(at, rate.MinDeliveryDays),
+				EstimatedDeliveryTo:   addBusinessDays(at, rate.MaxDeliveryDays),
+			})
+		}
+		for _, quote := range zoneQuotes {
+			quoted[quote.Method] = true
+		}
+		quotes = append(quotes, zoneQuotes...)
+	}
+
+	sort.SliceStable(quotes, func(i, j int) bool {
+		if !quotes[i].Amount.Equal(quotes[j].Amount) {
+			return quotes[i].Amount.LessThan(quotes[j].Amount)
+		}
+		return quotes[i].Method < quotes[j].Method
+	})
+	return quotes
+}
+
+// ShippingQuoteFor returns the cheapest quote of a shipping method
+func ShippingQuoteFor(quotes []ShippingQuote, method ShippingMethod) (*ShippingQuote, bool) {
+	var cheapest *ShippingQuote
+	for i := range quotes {
+		if quotes[i].Method != method {
+			continue
+		}
+		if cheapest == nil || quotes[i].Amount.LessThan(cheapest.Amount) {
+			cheapest = &quotes[i]
+		}
+	}
+	return cheapest, cheapest != nil
+}
+
+// CalculateDimensionalWeight calculates the dimensional weight of an order:
+// the volume of its items in cubic centimetres divided by the carrier's
+// divisor. Items without parseable dimensions add nothing.
+func CalculateDimensionalWeight(order *Order, divisor float64) float64 {
+	if divisor <= 0 {
+		return 0
+	}
+
+	volume := 0.0
+	for _, item := range order.Items {
+		if itemVolume, ok := parseVolume(item.Dimensions); ok {
+			volume += itemVolume * float64(item.Quantity)
+		}
+	}
+	return volume / divisor
+}
+
+// parseVolume parses dimensions in the "L x W x H" format into a volume
+func parseVolume(dimensions string) (float64, bool) {
+	parts := strings.Split(strings.ToLower(dimensions), "x")
+	if len(parts) != 3 {
+		return 0, false
+	}
+
+	volume := 1.0
+	for _, part := range parts {
+		value, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil || value < 0 {
+			return 0, false
+		}
+		volume *= value
+	}
+	return volume, true
+}
+
+// addBusinessDays moves a time forward by a number of working days, skipping weekends
+func addBusinessDays(t time.Time, days int) time.Time {
+	for days > 0 {
+		t = t.AddDate(0, 0, 1)
+		if t.Weekday() != time.Saturday && t.Weekday() != time.Sunday {
+			days--
+		}
+	}
+	return t
+}
@@ -0,0 +1,172 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestShippingZone(code, country string, state *string, rates ...ShippingRate) *ShippingZone {
+	zone := &ShippingZone{
+		ID:       uuid.New(),
+		Code:     code,
+		Name:     code,
+		Country:  country,
+		State:    state,
+		IsActive: true,
+	}
+	for _, rate := range rates {
+		rate.ID = uuid.New()
+		rate.ZoneID = zone.ID
+		rate.IsActive = true
+		if rate.Name == "" {
+			rate.Name = string(rate.Method)
+		}
+		zone.Rates = append(zone.Rates, rate)
+	}
+	return zone
+}
+
+func newTestShippingRate(method ShippingMethod, minWeight float64, maxWeight *float64, baseRate, perKgRate string) ShippingRate {
+	return ShippingRate{
+		Method:          method,
+		MinWeight:       minWeight,
+		MaxWeight:       maxWeight,
+		BaseRate:        decimal.RequireFromString(baseRate),
+		PerKgRate:       decimal.RequireFromString(perKgRate),
+		MinDeliveryDays: 3,
+		MaxDeliveryDays: 5,
+	}
+}
+
+func floatRef(value float64) *float64 {
+	return &value
+}
+
+func decimalRef(value decimal.Decimal) *decimal.Decimal {
+	return &value
+}
+
+func newTestShippingOrder(state string, unitPrice string) *Order {
+	item := newTestTaxItem(unitPrice, 2, TaxClassStandard)
+	item.Weight = 2
+	item.Dimensions = "50 x 40 x 30"
+	return &Order{
+		ID:              uuid.New(),
+		ShippingMethod:  ShippingMethodStandard,
+		ShippingAddress: &OrderAddress{Country: "US", State: state, PostalCode: "10001"},
+		Items:           []OrderItem{item},
+	}
+}
+
+func TestShippingZoneValidate(t *testing.T) {
+	zone := newTestShippingZone("US", "US", nil,
+		newTestShippingRate(ShippingMethodStandard, 0, floatRef(5), "5", "1"),
+		newTestShippingRate(ShippingMethodStandard, 5, nil, "10", "0.5"),
+		newTestShippingRate(ShippingMethodExpress, 0, nil, "15", "2"),
+	)
+	require.NoError(t, zone.Validate())
+
+	zone.Rates[1].MinWeight = 4
+	assert.ErrorContains(t, zone.Validate(), "overlapping STANDARD weight brackets")
+
+	// An inactive rate does not clash with its replacement
+	zone.Rates[1].IsActive = false
+	assert.NoError(t, zone.Validate())
+
+	tests := []struct {
+		name   string
+		modify func(r *ShippingRate)
+	}{
+		{"unknown method", func(r *ShippingRate) { r.Method = "SEA" }},
+		{"blank name", func(r *ShippingRate) { r.Name = " " }},
+		{"empty bracket", func(r *ShippingRate) { r.MaxWeight = floatRef(0) }},
+		{"negative charge", func(r *ShippingRate) { r.HandlingFee = decimal.NewFromInt(-1) }},
+		{"negative divisor", func(r *ShippingRate) { r.DimensionalDivisor = -5000 }},
+		{"delivery window reversed", func(r *ShippingRate) { r.MinDeliveryDays = 6 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate := zone.Rates[0]
+			tt.modify(&rate)
+			assert.Error(t, rate.Validate())
+		})
+	}
+}
+
+func TestCalculateDimensionalWeight(t *testing.T) {
+	order := newTestShippingOrder("NY", "30.00")
+	order.Items = append(order.Items, newTestTaxItem("5.00", 1, TaxClassStandard))
+
+	assert.Equal(t, 4.0, CalculateShippingWeight(order))
+	assert.InDelta(t, 24.0, CalculateDimensionalWeight(order, 5000), 0.0001)
+	assert.Zero(t, CalculateDimensionalWeight(order, 0))
+}
+
+func TestQuoteShippingMostSpecificZoneWins(t *testing.T) {
+	at := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC) // a Friday
+
+	express := newTestShippingRate(ShippingMethodExpress, 0, nil, "15", "2")
+	express.DimensionalDivisor = 5000
+	express.MinDeliveryDays = 1
+	express.MaxDeliveryDays = 2
+	standard := newTestShippingRate(ShippingMethodStandard, 0, floatRef(5), "5", "1")
+	standard.HandlingFee = decimal.NewFromInt(1)
+	country := newTestShippingZone("US", "US", nil,
+		standard,
+		newTestShippingRate(ShippingMethodStandard, 5, nil, "12", "0.5"),
+		express,
+	)
+
+	californiaStandard := newTestShippingRate(ShippingMethodStandard, 0, nil, "4", "0")
+	californiaStandard.FreeShippingThreshold = decimalRef(decimal.NewFromInt(100))
+	california := newTestShippingZone("US-CA", "US", stringRef("CA"), californiaStandard)
+	zones := []*ShippingZone{california, country}
+
+	// New York is only in the country zone: 4 kg standard is 5 + 1 x 4 + 1 handling
+	quotes := QuoteShipping(newTestShippingOrder("NY", "30.00"), zones, at)
+	require.Len(t, quotes, 2)
+	assert.Equal(t, ShippingMethodStandard, quotes[0].Method)
+	assert.Equal(t, "US", quotes[0].ZoneCode)
+	assert.True(t, quotes[0].Amount.Equal(decimal.NewFromInt(10)))
+
+	// Express is charged on the 24 kg dimensional weight
+	assert.Equal(t, ShippingMethodExpress, quotes[1].Method)
+	assert.InDelta(t, 24.0, quotes[1].ChargeableWeight, 0.0001)
+	assert.True(t, quotes[1].Amount.Equal(decimal.NewFromInt(63)))
+	assert.Equal(t, time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC), quotes[1].EstimatedDeliveryFrom)
+	assert.Equal(t, time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC), quotes[1].EstimatedDeliveryTo)
+
+	// California prices standard itself and falls back to the country for express
+	quotes = QuoteShipping(newTestShippingOrder("CA", "30.00"), zones, at)
+	quote, ok := ShippingQuoteFor(quotes, ShippingMethodStandard)
+	require.True(t, ok)
+	assert.Equal(t, "US-CA", quote.ZoneCode)
+	assert.True(t, quote.Amount.Equal(decimal.NewFromInt(4)))
+	quote, ok = ShippingQuoteFor(quotes, ShippingMethodExpress)
+	require.True(t, ok)
+	assert.Equal(t, "US", quote.ZoneCode)
+
+	// Reaching the threshold waives the charge
+	quotes = QuoteShipping(newTestShippingOrder("CA", "60.00"), zones, at)
+	quote, _ = ShippingQuoteFor(quotes, ShippingMethodStandard)
+	assert.True(t, quote.FreeShipping)
+	assert.True(t, quote.Amount.IsZero())
+
+	// Heavier orders move to the next bracket: 10 kg is 12 + 0.5 x 5
+	heavy := newTestShippingOrder("NY", "30.00")
+	heavy.Items[0].Weight = 5
+	quote, _ = ShippingQuoteFor(QuoteShipping(heavy, zones, at), ShippingMethodStandard)
+	assert.True(t, quote.Amount.Equal(decimal.RequireFromString("14.5")))
+
+	_, ok = ShippingQuoteFor(QuoteShipping(newTestShippingOrder("NY", "30.00"), zones, at), ShippingMethodOvernight)
+	assert.False(t, ok)
+
+	outside := newTestShippingOrder("NY", "30.00")
+	outside.ShippingAddress.Country = "CA"
+	assert.Empty(t, QuoteShipping(outside, zones, at))
+}
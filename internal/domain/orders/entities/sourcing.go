@@ -0,0 +1,429 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SourcingStrategy is a preference used to choose the warehouses fulfilling an order
+type SourcingStrategy string
+
+const (
+	// SourcingStrategyFewestShipments prefers warehouses that can ship more of
+	// the order's lines in full, so the order leaves in fewer shipments
+	SourcingStrategyFewestShipments SourcingStrategy = "FEWEST_SHIPMENTS"
+	// SourcingStrategyClosest prefers warehouses in the region of the shipping
+	// address: the same postal area, then state, then country
+	SourcingStrategyClosest SourcingStrategy = "CLOSEST"
+	// SourcingStrategyFulfillmentFirst prefers FULFILLMENT warehouses
+	SourcingStrategyFulfillmentFirst SourcingStrategy = "FULFILLMENT_FIRST"
+	// SourcingStrategyAvoidSplit keeps each line in one warehouse whenever a
+	// warehouse holds the whole quantity
+	SourcingStrategyAvoidSplit SourcingStrategy = "AVOID_SPLIT"
+)
+
+// DefaultSourcingStrategies apply to orders no sourcing rule matches
+var DefaultSourcingStrategies = []SourcingStrategy{
+	SourcingStrategyFewestShipments,
+	SourcingStrategyClosest,
+}
+
+var validSourcingStrategies = map[SourcingStrategy]bool{
+	SourcingStrategyFewestShipments:  true,
+	SourcingStrategyClosest:          true,
+	SourcingStrategyFulfillmentFirst: true,
+	SourcingStrategyAvoidSplit:       true,
+}
+
+// SourcingRule sets the strategies, in order of precedence, used to source
+// orders shipping to its countries with its shipping methods
+type SourcingRule struct {
+	ID          uuid.UUID `json:"id" db:"id"`
+	Name        string    `json:"name" db:"name"`
+	Description *string   `json:"description,omitempty" db:"description"`
+
+	// Conditions. A rule without conditions applies to every order.
+	Countries       []string         `json:"countries,omitempty" db:"countries"`
+	ShippingMethods []ShippingMethod `json:"shipping_methods,omitempty" db:"shipping_methods"`
+
+	Strategies []SourcingStrategy `json:"strategies" db:"strategies"`
+	// The matching rule with the highest priority is used
+	Priority int  `json:"priority" db:"priority"`
+	IsActive bool `json:"is_active" db:"is_active"`
+
+	CreatedBy uuid.UUID `json:"created_by" db:"created_by"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// OrderAllocation is the quantity of an order line reserved in a warehouse
+type OrderAllocation struct {
+	ID          uuid.UUID `json:"id" db:"id"`
+	OrderID     uuid.UUID `json:"order_id" db:"order_id"`
+	OrderItemID uuid.UUID `json:"order_item_id" db:"order_item_id"`
+	ProductID   uuid.UUID `json:"product_id" db:"product_id"`
+	WarehouseID uuid.UUID `json:"warehouse_id" db:"warehouse_id"`
+	Quantity    int       `json:"quantity" db:"quantity"`
+	// RuleID is the sourcing rule the allocation was planned with, if any
+	RuleID    *uuid.UUID `json:"rule_id,omitempty" db:"rule_id"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// Validate validates the sourcing rule
+func (r *SourcingRule) Validate() error {
+	var errs []error
+
+	if r.ID == uuid.Nil {
+		errs = append(errs, errors.New("sourcing rule ID cannot be empty"))
+	}
+	if strings.TrimSpace(r.Name) == "" {
+		errs = append(errs, errors.New("sourcing rule name is required"))
+	} else if len(r.Name) > 255 {
+		errs = append(errs, errors.New("sourcing rule name cannot exceed 255 characters"))
+	}
+	for _, country := range r.Countries {
+		if strings.TrimSpace(country) == "" {
+			errs = append(errs, errors.New("country cannot be empty"))
+		}
+	}
+	for _, method := range r.ShippingMethods {
+		if err := (&Order{ShippingMethod: method}).validateShippingMethod(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(r.Strategies) == 0 {
+		errs = append(errs, errors.New("sourcing rule requires at least one strategy"))
+	}
+	seen := make(map[SourcingStrategy]bool, len(r.Strategies))
+	for _, strategy := range r.Strategies {
+		if !validSourcingStrategies[strategy] {
+			errs = append(errs, fmt.Errorf("invalid sourcing strategy: %s", strategy))
+		} else if seen[strategy] {
+			errs = append(errs, fmt.Errorf("sourcing strategy %s is listed more than once", strategy))
+		}
+		seen[strategy] = true
+	}
+
+	if r.CreatedBy == uuid.Nil {
+		errs = append(errs, errors.New("created by user cannot be empty"))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("validation failed: %v", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// Matches reports whether the rule is active and applies to an order with
+// the shipping method shipping to the country
+func (r *SourcingRule) Matches(method ShippingMethod, country string) bool {
+	if !r.IsActive {
+		return false
+	}
+	if len(r.Countries) > 0 {
+		matched := false
+		for _, c := range r.Countries {
+			if strings.EqualFold(strings.TrimSpace(c), strings.TrimSpace(country)) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if len(r.ShippingMethods) > 0 {
+		matched := false
+		for _, m := range r.ShippingMethods {
+			if m == method {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// SelectSourcingRule returns the matching rule with the highest priority, or
+// nil when none matches
+func SelectSourcingRule(rules []*SourcingRule, method ShippingMethod, country string) *SourcingRule {
+	var selected *SourcingRule
+	for _, rule := range rules {
+		if !rule.Matches(method, country) {
+			continue
+		}
+		if selected == nil || rule.Priority > selected.Priority {
+			selected = rule
+		}
+	}
+	return selected
+}
+
+// SourcingLine is a quantity of an order line to source
+type SourcingLine struct {
+	OrderItemID uuid.UUID `json:"order_item_id"`
+	ProductID   uuid.UUID `json:"product_id"`
+	Quantity    int       `json:"quantity"`
+}
+
+// SourcingAddress is the region of a warehouse or of a shipping address
+type SourcingAddress struct {
+	Country    string `json:"country"`
+	State      string `json:"state"`
+	PostalCode string `json:"postal_code"`
+}
+
+// SourcingWarehouse is a warehouse that can fulfil the lines of a plan, with
+// the quantity of each product it can spare
+type SourcingWarehouse struct {
+	ID          uuid.UUID         `json:"id"`
+	Code        string            `json:"code"`
+	Fulfillment bool              `json:"fulfillment"`
+	Address     SourcingAddress   `json:"address"`
+	Available   map[uuid.UUID]int `json:"available"`
+}
+
+// SourcingPlan is the outcome of sourcing: the quantities of each line to
+// reserve per warehouse and those no warehouse can cover
+type SourcingPlan struct {
+	Allocations []OrderAllocation `json:"allocations"`
+	// Unallocated is the quantity left per order item
+	Unallocated map[uuid.UUID]int `json:"unallocated,omitempty"`
+}
+
+// Shipments returns the number of warehouses the plan ships from
+func (p *SourcingPlan) Shipments() int {
+	warehouses := make(map[uuid.UUID]bool)
+	for _, allocation := range p.Allocations {
+		warehouses[allocation.WarehouseID] = true
+	}
+	return len(warehouses)
+}
+
+// postalAreaLength is the length of the postal code prefix that makes two
+// addresses part of the same area
+const postalAreaLength = 3
+
+// RegionDistance rates how far apart two addresses are: 0 in the same postal
+// area, 1 in the same state, 2 in the same country and 3 otherwise or when
+// the destination is unknown
+func RegionDistance(from, to SourcingAddress) int {
+	sameCountry := to.Country != "" && strings.EqualFold(strings.TrimSpace(from.Country), strings.TrimSpace(to.Country))
+	if !sameCountry {
+		return 3
+	}
+	if to.State == "" || !strings.EqualFold(strings.TrimSpace(from.State), strings.TrimSpace(to.State)) {
+		return 2
+	}
+	fromArea, toArea := postalArea(from.PostalCode), postalArea(to.PostalCode)
+	if toArea != "" && fromArea == toArea {
+		return 0
+	}
+	return 1
+}
+
+func postalArea(postalCode string) string {
+	code := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(postalCode), " ", ""))
+	if len(code) < postalAreaLength {
+		return ""
+	}
+	return code[:postalAreaLength]
+}
+
+// PlanSourcing allocates lines to warehouses. Warehouses are taken one at a
+// time, best first by the strategies in order of precedence and then by the
+// quantity they can supply; each takes the lines it has stock for. Stock
+// passed over to keep a line whole is used when the line cannot be kept whole
+// after all, so a quantity is only unallocated when no warehouse has it.
+func PlanSourcing(lines []SourcingLine, warehouses []SourcingWarehouse, strategies []SourcingStrategy, destination SourcingAddress) *SourcingPlan {
+	plan := &SourcingPlan{Unallocated: make(map[uuid.UUID]int)}
+
+	remaining := make([]int, len(lines))
+	for i, line := range lines {
+		remaining[i] = max(line.Quantity, 0)
+	}
+	available := make([]map[uuid.UUID]int, len(warehouses))
+	for i, warehouse := range warehouses {
+		available[i] = make(map[uuid.UUID]int, len(warehouse.Available))
+		for productID, quantity := range warehouse.Available {
+			available[i][productID] = quantity
+		}
+	}
+
+	avoidSplit := false
+	for _, strategy := range strategies {
+		if strategy == SourcingStrategyAvoidSplit {
+			avoidSplit = true
+		}
+	}
+
+	// coverage returns the lines a warehouse can ship in full and the units it can supply
+	coverage := func(w int) (int, int) {
+		full, units := 0, 0
+		for i, line := range lines {
+			if remaining[i] == 0 {
+				continue
+			}
+			quantity := available[w][line.ProductID]
+			if quantity >= remaining[i] {
+				full++
+			}
+			units += min(max(quantity, 0), remaining[i])
+		}
+		return full, units
+	}
+	better := func(a, b int) bool {
+		wa, wb := warehouses[a], warehouses[b]
+		fullA, unitsA := coverage(a)
+		fullB, unitsB := coverage(b)
+		for _, strategy := range strategies {
+			switch strategy {
+			case SourcingStrategyFulfillmentFirst:
+				if wa.Fulfillment != wb.Fulfillment {
+					return wa.Fulfillment
+				}
+			case SourcingStrategyClosest:
+				if da, db := RegionDistance(wa.Address, destination), RegionDistance(wb.Address, destination); da != db {
+					return da < db
+				}
+			case SourcingStrategyFewestShipments:
+				if fullA != fullB {
+					return fullA > fullB
+				}
+			}
+		}
+		if unitsA != unitsB {
+			return unitsA > unitsB
+		}
+		if wa.Code != wb.Code {
+			return wa.Code < wb.Code
+		}
+		return wa.ID.String() < wb.ID.String()
+	}
+	// coveredElsewhere reports whether another unused warehouse holds the whole line
+	used := make([]bool, len(warehouses))
+	coveredElsewhere := func(w, i int) bool {
+		for other := range warehouses {
+			if other != w && !used[other] && available[other][lines[i].ProductID] >= remaining[i] {
+				return true
+			}
+		}
+		return false
+	}
+	allocate := func(w, i, quantity int) {
+		line := lines[i]
+		available[w][line.ProductID] -= quantity
+		remaining[i] -= quantity
+		for j := range plan.Allocations {
+			allocation := &plan.Allocations[j]
+			if allocation.OrderItemID == line.OrderItemID && allocation.WarehouseID == warehouses[w].ID {
+				allocation.Quantity += quantity
+				return
+			}
+		}
+		plan.Allocations = append(plan.Allocations, OrderAllocation{
+			OrderItemID: line.OrderItemID,
+			ProductID:   line.ProductID,
+			WarehouseID: warehouses[w].ID,
+			Quantity:    quantity,
+		})
+	}
+
+	for {
+		best := -1
+		for w := range warehouses {
+			if used[w] {
+				continue
+			}
+			if _, units := coverage(w); units == 0 {
+				continue
+			}
+			if best == -1 || better(w, best) {
+				best = w
+			}
+		}
+		if best == -1 {
+			break
+		}
+		used[best] = true
+
+		for i, line := range lines {
+			if remaining[i] == 0 {
+				continue
+			}
+			take := min(available[best][line.ProductID], remaining[i])
+			if take <= 0 {
+				continue
+			}
+			if take < remaining[i] && avoidSplit && coveredElsewhere(best, i) {
+				continue
+			}
+			allocate(best, i, take)
+		}
+	}
+
+	// Stock passed over to keep lines whole that ended up split anyway
+	ranked := make([]int, len(warehouses))
+	for w := range ranked {
+		ranked[w] = w
+	}
+	for i, line := range lines {
+		if remaining[i] == 0 {
+			continue
+		}
+		sort.SliceStable(ranked, func(a, b int) bool { return better(ranked[a], ranked[b]) })
+		for _, w := range ranked {
+			if take := min(available[w][line.ProductID], remaining[i]); take > 0 {
+				allocate(w, i, take)
+			}
+		}
+	}
+
+	for i, line := range lines {
+		if remaining[i] > 0 {
+			plan.Unallocated[line.OrderItemID] += remaining[i]
+		}
+	}
+
+	return plan
+}
+
+// ReleaseAllocations takes a quantity of an order item off its allocations,
+// from the warehouse given first and then from the others, and returns the
+// allocations left
+func ReleaseAllocations(allocations []*OrderAllocation, orderItemID, warehouseID uuid.UUID, quantity int) []*OrderAllocation {
+	take := func(match func(*OrderAllocation) bool) {
+		for _, allocation := range allocations {
+			if quantity == 0 {
+				return
+			}
+			if allocation.OrderItemID != orderItemID || !match(allocation) {
+				continue
+			}
+			n := min(allocation.Quantity, quantity)
+			allocation.Quantity -= n
+			quantity -= n
+		}
+	}
+	take(func(a *OrderAllocation) bool { return a.WarehouseID == warehouseID })
+	take(func(a *OrderAllocation) bool { return true })
+
+	kept := allocations[:0]
+	for _, allocation := range allocations {
+		if allocation.Quantity > 0 {
+			kept = append(kept, allocation)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,169 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSourcingRuleValidate(t *testing.T) {
+	rule := &SourcingRule{
+		ID:         uuid.New(),
+		Name:       "Domestic express",
+		Countries:  []string{"US"},
+		Strategies: []SourcingStrategy{SourcingStrategyClosest, SourcingStrategyAvoidSplit},
+		CreatedBy:  uuid.New(),
+	}
+	require.NoError(t, rule.Validate())
+
+	rule.Strategies = []SourcingStrategy{SourcingStrategyClosest, "CHEAPEST", SourcingStrategyClosest}
+	rule.ShippingMethods = []ShippingMethod{"TELEPORT"}
+	err := rule.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid sourcing strategy: CHEAPEST")
+	assert.Contains(t, err.Error(), "listed more than once")
+	assert.Contains(t, err.Error(), "shipping method")
+
+	rule.Strategies = nil
+	assert.ErrorContains(t, rule.Validate(), "at least one strategy")
+}
+
+func TestSelectSourcingRule(t *testing.T) {
+	general := &SourcingRule{Name: "general", IsActive: true, Priority: 0}
+	domestic := &SourcingRule{Name: "domestic", IsActive: true, Priority: 10, Countries: []string{"US"}}
+	express := &SourcingRule{Name: "express", IsActive: true, Priority: 20, Countries: []string{"us"}, ShippingMethods: []ShippingMethod{ShippingMethodExpress}}
+	inactive := &SourcingRule{Name: "inactive", IsActive: false, Priority: 99}
+	rules := []*SourcingRule{general, domestic, express, inactive}
+
+	assert.Equal(t, express, SelectSourcingRule(rules, ShippingMethodExpress, "US"))
+	assert.Equal(t, domestic, SelectSourcingRule(rules, ShippingMethodStandard, "US"))
+	assert.Equal(t, general, SelectSourcingRule(rules, ShippingMethodExpress, "CA"))
+	assert.Nil(t, SelectSourcingRule([]*SourcingRule{domestic, inactive}, ShippingMethodStandard, "CA"))
+}
+
+func TestRegionDistance(t *testing.T) {
+	destination := SourcingAddress{Country: "US", State: "CA", PostalCode: "94107"}
+
+	assert.Equal(t, 0, RegionDistance(SourcingAddress{Country: "US", State: "CA", PostalCode: "94110"}, destination))
+	assert.Equal(t, 1, RegionDistance(SourcingAddress{Country: "us", State: "ca", PostalCode: "90001"}, destination))
+	assert.Equal(t, 2, RegionDistance(SourcingAddress{Country: "US", State: "NV", PostalCode: "89101"}, destination))
+	assert.Equal(t, 3, RegionDistance(SourcingAddress{Country: "CA", State: "BC"}, destination))
+	assert.Equal(t, 3, RegionDistance(SourcingAddress{Country: "US"}, SourcingAddress{}), "unknown destination")
+}
+
+// sourcingFixture has two lines and three warehouses: a nearby distribution
+// center holding part of both products, a remote fulfillment center holding
+// all of the first and a remote store holding all of both
+type sourcingFixture struct {
+	lines                     []SourcingLine
+	nearby, fulfillment, both SourcingWarehouse
+	destination               SourcingAddress
+}
+
+func newSourcingFixture() sourcingFixture {
+	widget, gadget := uuid.New(), uuid.New()
+	return sourcingFixture{
+		lines: []SourcingLine{
+			{OrderItemID: uuid.New(), ProductID: widget, Quantity: 5},
+			{OrderItemID: uuid.New(), ProductID: gadget, Quantity: 2},
+		},
+		nearby: SourcingWarehouse{
+			ID: uuid.New(), Code: "NEAR",
+			Address:   SourcingAddress{Country: "US", State: "CA", PostalCode: "94110"},
+			Available: map[uuid.UUID]int{widget: 3, gadget: 2},
+		},
+		fulfillment: SourcingWarehouse{
+			ID: uuid.New(), Code: "FC", Fulfillment: true,
+			Address:   SourcingAddress{Country: "US", State: "TX", PostalCode: "75001"},
+			Available: map[uuid.UUID]int{widget: 10},
+		},
+		both: SourcingWarehouse{
+			ID: uuid.New(), Code: "STORE",
+			Address:   SourcingAddress{Country: "US", State: "NY", PostalCode: "10001"},
+			Available: map[uuid.UUID]int{widget: 5, gadget: 5},
+		},
+		destination: SourcingAddress{Country: "US", State: "CA", PostalCode: "94107"},
+	}
+}
+
+// allocated returns the quantity of each line allocated per warehouse code
+func (f sourcingFixture) allocated(plan *SourcingPlan) map[string][]int {
+	codes := map[uuid.UUID]string{f.nearby.ID: f.nearby.Code, f.fulfillment.ID: f.fulfillment.Code, f.both.ID: f.both.Code}
+	result := make(map[string][]int)
+	for _, allocation := range plan.Allocations {
+		code := codes[allocation.WarehouseID]
+		if result[code] == nil {
+			result[code] = make([]int, len(f.lines))
+		}
+		for i, line := range f.lines {
+			if line.OrderItemID == allocation.OrderItemID {
+				result[code][i] += allocation.Quantity
+			}
+		}
+	}
+	return result
+}
+
+func TestPlanSourcingStrategies(t *testing.T) {
+	f := newSourcingFixture()
+	warehouses := []SourcingWarehouse{f.nearby, f.fulfillment, f.both}
+
+	t.Run("fewest shipments ships from one warehouse", func(t *testing.T) {
+		plan := PlanSourcing(f.lines, warehouses, []SourcingStrategy{SourcingStrategyFewestShipments}, f.destination)
+		assert.Equal(t, map[string][]int{"STORE": {5, 2}}, f.allocated(plan))
+		assert.Equal(t, 1, plan.Shipments())
+		assert.Empty(t, plan.Unallocated)
+	})
+
+	t.Run("closest splits lines across warehouses", func(t *testing.T) {
+		plan := PlanSourcing(f.lines, warehouses, []SourcingStrategy{SourcingStrategyClosest}, f.destination)
+		assert.Equal(t, map[string][]int{"NEAR": {3, 2}, "FC": {2, 0}}, f.allocated(plan), "equally remote warehouses are taken by code")
+	})
+
+	t.Run("closest keeping lines whole", func(t *testing.T) {
+		plan := PlanSourcing(f.lines, warehouses, []SourcingStrategy{SourcingStrategyClosest, SourcingStrategyAvoidSplit}, f.destination)
+		assert.Equal(t, map[string][]int{"NEAR": {0, 2}, "FC": {5, 0}}, f.allocated(plan))
+	})
+
+	t.Run("fulfillment first", func(t *testing.T) {
+		plan := PlanSourcing(f.lines, warehouses, []SourcingStrategy{SourcingStrategyFulfillmentFirst, SourcingStrategyClosest}, f.destination)
+		assert.Equal(t, map[string][]int{"FC": {5, 0}, "NEAR": {0, 2}}, f.allocated(plan))
+	})
+}
+
+func TestPlanSourcingShortfall(t *testing.T) {
+	f := newSourcingFixture()
+	f.lines[1].Quantity = 9
+
+	plan := PlanSourcing(f.lines, []SourcingWarehouse{f.nearby, f.both}, []SourcingStrategy{SourcingStrategyAvoidSplit}, f.destination)
+	assert.Equal(t, map[string][]int{"NEAR": {0, 2}, "STORE": {5, 5}}, f.allocated(plan),
+		"lines that cannot be kept whole take stock from every warehouse")
+	assert.Equal(t, map[uuid.UUID]int{f.lines[1].OrderItemID: 2}, plan.Unallocated)
+	assert.Equal(t, 2, plan.Shipments())
+
+	// Warehouses are not mutated by planning
+	assert.Equal(t, 5, f.both.Available[f.lines[1].ProductID])
+}
+
+func TestReleaseAllocations(t *testing.T) {
+	itemID, otherItemID := uuid.New(), uuid.New()
+	east, west := uuid.New(), uuid.New()
+	allocations := []*OrderAllocation{
+		{OrderItemID: itemID, WarehouseID: east, Quantity: 2},
+		{OrderItemID: itemID, WarehouseID: west, Quantity: 3},
+		{OrderItemID: otherItemID, WarehouseID: west, Quantity: 4},
+	}
+
+	allocations = ReleaseAllocations(allocations, itemID, west, 4)
+	require.Len(t, allocations, 2)
+	assert.Equal(t, east, allocations[0].WarehouseID, "the named warehouse is drawn first")
+	assert.Equal(t, 1, allocations[0].Quantity)
+	assert.Equal(t, otherItemID, allocations[1].OrderItemID)
+	assert.Equal(t, 4, allocations[1].Quantity)
+
+	allocations = ReleaseAllocations(allocations, itemID, east, 10)
+	require.Len(t, allocations, 1)
+	assert.Equal(t, otherItemID, allocations[0].OrderItemID)
+}
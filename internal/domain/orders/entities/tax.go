@@ -0,0 +1,492 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// TaxLevel is the jurisdiction level a tax component is levied at
+type TaxLevel string
+
+const (
+	TaxLevelCountry TaxLevel = "COUNTRY"
+	TaxLevelState   TaxLevel = "STATE"
+	TaxLevelCounty  TaxLevel = "COUNTY"
+	TaxLevelCity    TaxLevel = "CITY"
+	TaxLevelVAT     TaxLevel = "VAT"
+)
+
+const (
+	// TaxClassStandard is the tax class of products without a specific one
+	TaxClassStandard = "STANDARD"
+	// TaxClassExempt is the tax class of non-taxable products; rules cannot target it
+	TaxClassExempt = "EXEMPT"
+	// TaxClassShipping is the tax class rules use to tax shipping charges
+	TaxClassShipping = "SHIPPING"
+)
+
+var (
+	taxClassPattern   = regexp.MustCompile(`^[A-Z][A-Z0-9_]{0,49}$`)
+	countryPattern    = regexp.MustCompile(`^[A-Z]{2}$`)
+	validTaxLevels    = []TaxLevel{TaxLevelCountry, TaxLevelState, TaxLevelCounty, TaxLevelCity, TaxLevelVAT}
+	oneHundredPercent = decimal.NewFromInt(100)
+)
+
+// NormalizeTaxClass upper-cases a tax class such as "reduced" and checks its
+// format. An empty class is the standard class.
+func NormalizeTaxClass(class string) (string, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(class))
+	if normalized == "" {
+		return TaxClassStandard, nil
+	}
+	if !taxClassPattern.MatchString(normalized) {
+		return "", fmt.Errorf("invalid tax class: %s", class)
+	}
+	return normalized, nil
+}
+
+// ParseTaxLevel normalizes a tax level and reports whether it is known
+func ParseTaxLevel(value string) (TaxLevel, error) {
+	normalized := TaxLevel(strings.ToUpper(strings.TrimSpace(value)))
+	for _, level := range validTaxLevels {
+		if normalized == level {
+			return level, nil
+		}
+	}
+	return "", fmt.Errorf("invalid tax level: %s", value)
+}
+
+// TaxZone is a tax jurisdiction matched against shipping addresses by
+// country, state and postal code prefix. Zones stack: an address in Los
+// Angeles matches both the California zone, which levies the state tax, and
+// the Los Angeles County zone, which levies the county tax.
+type TaxZone struct {
+	ID      uuid.UUID `json:"id" db:"id"`
+	Code    string    `json:"code" db:"code"`
+	Name    string    `json:"name" db:"name"`
+	Country string    `json:"country" db:"country"`
+	// State limits the zone to one state or region; nil covers the whole country
+	State *string `json:"state,omitempty" db:"state"`
+	// PostalCodePrefixes limits the zone to some postal codes; empty covers them all
+	PostalCodePrefixes []string `json:"postal_code_prefixes,omitempty" db:"postal_code_prefixes"`
+	// PricesIncludeTax marks item prices shipped into the zone as gross; its
+	// taxes are backed out of the price instead of added on top
+	PricesIncludeTax bool      `json:"prices_include_tax" db:"prices_include_tax"`
+	IsActive         bool      `json:"is_active" db:"is_active"`
+	CreatedAt        time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at" db:"updated_at"`
+
+	Rules []TaxRule `json:"rules,omitempty" db:"-"`
+}
+
+// TaxRule levies one named tax component on a tax class within a zone
+type TaxRule struct {
+	ID     uuid.UUID `json:"id" db:"id"`
+	ZoneID uuid.UUID `json:"zone_id" db:"zone_id"`
+	// Name is the component name shown in tax breakdowns, e.g. "California State Tax"
+	Name      string          `json:"name" db:"name"`
+	Level     TaxLevel        `json:"level" db:"level"`
+	TaxClass  string          `json:"tax_class" db:"tax_class"`
+	Rate      decimal.Decimal `json:"rate" db:"rate"`
+	IsActive  bool            `json:"is_active" db:"is_active"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// TaxExemption exempts a customer from the taxes of a jurisdiction on the
+// strength of an exemption certificate, such as a resale certificate
+type TaxExemption struct {
+	ID                uuid.UUID `json:"id" db:"id"`
+	CustomerID        uuid.UUID `json:"customer_id" db:"customer_id"`
+	CertificateNumber string    `json:"certificate_number" db:"certificate_number"`
+	Country           string    `json:"country" db:"country"`
+	// State limits the exemption to one state; nil covers every zone of the country
+	State        *string    `json:"state,omitempty" db:"state"`
+	ValidFrom    time.Time  `json:"valid_from" db:"valid_from"`
+	ValidUntil   *time.Time `json:"valid_until,omitempty" db:"valid_until"`
+	Reason       *string    `json:"reason,omitempty" db:"reason"`
+	CreatedBy    uuid.UUID  `json:"created_by" db:"created_by"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
+	RevokedAt    *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	RevokedBy    *uuid.UUID `json:"revoked_by,omitempty" db:"revoked_by"`
+	RevokeReason *string    `json:"revoke_reason,omitempty" db:"revoke_reason"`
+}
+
+// Validate validates the zone and its rules
+func (z *TaxZone) Validate() error {
+	var errs []error
+
+	if z.ID == uuid.Nil {
+		errs = append(errs, errors.New("tax zone ID cannot be empty"))
+	}
+	if strings.TrimSpace(z.Code) == "" || len(z.Code) > 50 {
+		errs = append(errs, errors.New("tax zone code is required and cannot exceed 50 characters"))
+	}
+	if strings.TrimSpace(z.Name) == "" {
+		errs = append(errs, errors.New("tax zone name is required"))
+	}
+	if !countryPattern.MatchString(z.Country) {
+		errs = append(errs, errors.New("country must be a 2-letter ISO 3166 code"))
+	}
+	if z.State != nil && strings.TrimSpace(*z.State) == "" {
+		errs = append(errs, errors.New("state cannot be blank"))
+	}
+	for _, prefix := range z.PostalCodePrefixes {
+		if normalizePostalCode(prefix) == "" {
+			errs = append(errs, errors.New("postal code prefixes cannot be blank"))
+			break
+		}
+	}
+	for n := range z.Rules {
+		if err := z.Rules[n].Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("rule %d: %w", n+1, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("validation failed: %v", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// Matches reports whether an address lies in the zone
+func (z *TaxZone) Matches(address *OrderAddress) bool {
+	return z.IsActive && inJurisdiction(z.Country, z.State, z.PostalCodePrefixes, address)
+}
+
+// inJurisdiction reports whether an address lies in a country, optionally
+// narrowed to a state and to postal code prefixes
+func inJurisdiction(country string, state *string, postalCodePrefixes []string, address *OrderAddress) bool {
+	if address == nil {
+		return false
+	}
+	if !strings.EqualFold(country, strings.TrimSpace(address.Country)) {
+		return false
+	}
+	if state != nil && !strings.EqualFold(*state, strings.TrimSpace(address.State)) {
+		return false
+	}
+	if len(postalCodePrefixes) == 0 {
+		return true
+	}
+
+	postalCode := normalizePostalCode(address.PostalCode)
+	for _, prefix := range postalCodePrefixes {
+		if strings.HasPrefix(postalCode, normalizePostalCode(prefix)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate validates the rule
+func (r *TaxRule) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return errors.New("tax rule name is required")
+	}
+	if _, err := ParseTaxLevel(string(r.Level)); err != nil {
+		return err
+	}
+	if !taxClassPattern.MatchString(r.TaxClass) {
+		return fmt.Errorf("invalid tax class: %s", r.TaxClass)
+	}
+	if r.TaxClass == TaxClassExempt {
+		return errors.New("the exempt tax class cannot be taxed")
+	}
+	if r.Rate.IsNegative() || r.Rate.GreaterThan(oneHundredPercent) {
+		return errors.New("tax rate must be between 0 and 100")
+	}
+	return nil
+}
+
+// Validate validates the exemption
+func (e *TaxExemption) Validate() error {
+	if e.CustomerID == uuid.Nil {
+		return errors.New("customer ID is required")
+	}
+	if strings.TrimSpace(e.CertificateNumber) == "" {
+		return errors.New("certificate number is required")
+	}
+	if !countryPattern.MatchString(e.Country) {
+		return errors.New("country must be a 2-letter ISO 3166 code")
+	}
+	if e.State != nil && strings.TrimSpace(*e.State) == "" {
+		return errors.New("state cannot be blank")
+	}
+	if e.ValidFrom.IsZero() {
+		return errors.New("valid from date is required")
+	}
+	if e.ValidUntil != nil && e.ValidUntil.Before(e.ValidFrom) {
+		return errors.New("valid until date cannot be before valid from date")
+	}
+	return nil
+}
+
+// IsRevoked reports whether the exemption was revoked
+func (e *TaxExemption) IsRevoked() bool {
+	return e.RevokedAt != nil
+}
+
+// Revoke withdraws the exemption
+func (e *TaxExemption) Revoke(revokedBy uuid.UUID, reason string) error {
+	if e.IsRevoked() {
+		return errors.New("tax exemption is already revoked")
+	}
+	if strings.TrimSpace(reason) == "" {
+		return errors.New("revoke reason is required")
+	}
+
+	now := time.Now().UTC()
+	reason = strings.TrimSpace(reason)
+	e.RevokedAt = &now
+	e.RevokedBy = &revokedBy
+	e.RevokeReason = &reason
+	e.UpdatedAt = now
+	return nil
+}
+
+// Covers reports whether the exemption applies at the given time to a
+// jurisdiction. A state exemption does not cover country-wide zones.
+func (e *TaxExemption) Covers(country string, state *string, at time.Time) bool {
+	if e.IsRevoked() || at.Before(e.ValidFrom) {
+		return false
+	}
+	if e.ValidUntil != nil && at.After(*e.ValidUntil) {
+		return false
+	}
+	if !strings.EqualFold(e.Country, country) {
+		return false
+	}
+	if e.State == nil {
+		return true
+	}
+	return state != nil && strings.EqualFold(*e.State, *state)
+}
+
+// ItemTax is the tax worked out for one order line or for shipping
+type ItemTax struct {
+	// TaxRate is the combined rate of the components levied
+	TaxRate decimal.Decimal `json:"tax_rate"`
+	// PriceIncludesTax reports whether the tax is backed out of the price
+	PriceIncludesTax bool            `json:"price_includes_tax"`
+	TaxableAmount    decimal.Decimal `json:"taxable_amount"`
+	TaxAmount        decimal.Decimal `json:"tax_amount"`
+	Components       []TaxBreakdown  `json:"components"`
+}
+
+// TaxCalculation is the tax of an order worked out by a tax calculator
+type TaxCalculation struct {
+	Items        map[uuid.UUID]ItemTax `json:"items"`
+	ShippingTax  ItemTax               `json:"shipping_tax"`
+	TaxAmount    decimal.Decimal       `json:"tax_amount"`
+	TaxBreakdown []TaxBreakdown        `json:"tax_breakdown"`
+	// ExemptionCertificates lists the certificates that exempted part of the order
+	ExemptionCertificates []string `json:"exemption_certificates,omitempty"`
+}
+
+// CalculateTax works out the tax of an order shipped to its shipping address
+// under the zones matching that address. Each line is taxed by the active
+// rules of its tax class; a line is priced tax included when any zone taxing
+// it has tax-inclusive prices. Shipping is taxed by the rules of the SHIPPING
+// class and is always net of tax. Rules of zones covered by one of the
+// customer's exemptions are skipped, as are VAT rules for VAT-exempt
+// customers. When no zone matches the address, lines keep their own tax
+// rates, so orders shipped outside configured jurisdictions are taxed as
+// before zones existed.
+func CalculateTax(order *Order, zones []*TaxZone, exemptions []*TaxExemption, at time.Time) *TaxCalculation {
+	calculation := &TaxCalculation{
+		Items:        make(map[uuid.UUID]ItemTax, len(order.Items)),
+		TaxAmount:    decimal.Zero,
+		TaxBreakdown: []TaxBreakdown{},
+	}
+
+	var matched []*TaxZone
+	for _, zone := range zones {
+		if zone.Matches(order.ShippingAddress) {
+			matched = append(matched, zone)
+		}
+	}
+
+	vatExempt := order.Customer != nil && order.Customer.IsVATExempt
+	certificates := make(map[string]bool)
+	exempt := func(country string, state *string) bool {
+		for _, exemption := range exemptions {
+			if exemption.Covers(country, state, at) {
+				certificates[exemption.CertificateNumber] = true
+				return true
+			}
+		}
+		return false
+	}
+
+	// rulesFor returns the rules levied on a tax class and whether any of
+	// their zones prices tax included
+	rulesFor := func(class string) ([]TaxRule, bool) {
+		var rules []TaxRule
+		included := false
+		for _, zone := range matched {
+			if exempt(zone.Country, zone.State) {
+				continue
+			}
+			for _, rule := range zone.Rules {
+				if !rule.IsActive || rule.TaxClass != class || (vatExempt && rule.Level == TaxLevelVAT) {
+					continue
+				}
+				rules = append(rules, rule)
+				included = included || zone.PricesIncludeTax
+			}
+		}
+		return rules, included
+	}
+
+	if len(matched) == 0 {
+		address := order.ShippingAddress
+		covered := address != nil && exempt(strings.TrimSpace(address.Country), &address.State)
+		for _, item := range order.Items {
+			var rules []TaxRule
+			if item.TaxRate.IsPositive() && !covered {
+				rules = []TaxRule{{Name: fmt.Sprintf("Tax @ %s%%", item.TaxRate.String()), Rate: item.TaxRate}}
+			}
+			calculation.Items[item.ID] = levyTax(item.LineAmount(), rules, item.PriceIncludesTax)
+		}
+		calculation.ShippingTax = levyTax(order.ShippingAmount, nil, false)
+	} else {
+		for _, item := range order.Items {
+			class := item.TaxClass
+			if class == "" {
+				class = TaxClassStandard
+			}
+			rules, included := rulesFor(class)
+			calculation.Items[item.ID] = levyTax(item.LineAmount(), rules, included)
+		}
+		rules, _ := rulesFor(TaxClassShipping)
+		calculation.ShippingTax = levyTax(order.ShippingAmount, rules, false)
+	}
+
+	var components []TaxBreakdown
+	for _, item := range order.Items {
+		components = append(components, calculation.Items[item.ID].Components...)
+	}
+	components = append(components, calculation.ShippingTax.Components...)
+
+	calculation.TaxBreakdown = MergeTaxBreakdown(components)
+	for _, component := range calculation.TaxBreakdown {
+		calculation.TaxAmount = calculation.TaxAmount.Add(component.TaxAmount)
+	}
+
+	for certificate := range certificates {
+		calculation.ExemptionCertificates = append(calculation.ExemptionCertificates, certificate)
+	}
+	sort.Strings(calculation.ExemptionCertificates)
+
+	return calculation
+}
+
+// levyTax levies rules on an amount. When the amount includes tax, the
+// taxable amount is backed out of it at the combined rate.
+func levyTax(amount decimal.Decimal, rules []TaxRule, included bool) ItemTax {
+	result := ItemTax{TaxRate: decimal.Zero, TaxableAmount: amount, TaxAmount: decimal.Zero}
+	for _, rule := range rules {
+		result.TaxRate = result.TaxRate.Add(rule.Rate)
+	}
+	if len(rules) == 0 {
+		return result
+	}
+
+	result.PriceIncludesTax = included
+	if included {
+		result.TaxableAmount = amount.Div(decimal.NewFromInt(1).Add(result.TaxRate.Div(oneHundredPercent)))
+	}
+	for _, rule := range rules {
+		tax := result.TaxableAmount.Mul(rule.Rate).Div(oneHundredPercent)
+		result.TaxAmount = result.TaxAmount.Add(tax)
+		result.Components = append(result.Components, TaxBreakdown{
+			TaxRate:       rule.Rate,
+			TaxAmount:     tax,
+			TaxableAmount: result.TaxableAmount,
+			TaxName:       rule.Name,
+			Level:         rule.Level,
+		})
+	}
+	return result
+}
+
+// ApplyToItems writes the line tax rates and pricing modes onto the order
+// items and recalculates their totals
+func (c *TaxCalculation) ApplyToItems(order *Order) {
+	for i := range order.Items {
+		item := &order.Items[i]
+		if itemTax, ok := c.Items[item.ID]; ok {
+			item.TaxRate = itemTax.TaxRate
+			item.PriceIncludesTax = itemTax.PriceIncludesTax
+			item.CalculateTotals()
+		}
+	}
+}
+
+// ApplyTo replaces the tax of an order calculation, which only knows the
+// line rates, with the named components including the tax on shipping
+func (c *TaxCalculation) ApplyTo(calculation *OrderCalculation) {
+	calculation.TaxAmount = c.TaxAmount
+	calculation.TaxBreakdown = c.TaxBreakdown
+	calculation.TotalAmount = calculation.Subtotal.Add(calculation.TaxAmount).Add(calculation.ShippingAmount).Sub(calculation.DiscountAmount)
+}
+
+// Portion returns the tax breakdown of part of the order: the given quantities
+// of its lines, plus the shipping charge when shipping is set
+func (c *TaxCalculation) Portion(items []OrderItem, quantities map[uuid.UUID]int, shipping bool) []TaxBreakdown {
+	var components []TaxBreakdown
+	for _, item := range items {
+		quantity := quantities[item.ID]
+		if quantity <= 0 || item.Quantity <= 0 {
+			continue
+		}
+		share := decimal.NewFromInt(int64(quantity)).Div(decimal.NewFromInt(int64(item.Quantity)))
+		for _, component := range c.Items[item.ID].Components {
+			component.TaxAmount = component.TaxAmount.Mul(share)
+			component.TaxableAmount = component.TaxableAmount.Mul(share)
+			components = append(components, component)
+		}
+	}
+	if shipping {
+		components = append(components, c.ShippingTax.Components...)
+	}
+	return MergeTaxBreakdown(components)
+}
+
+// MergeTaxBreakdown merges tax components of the same name and rate, keeping
+// the order they first appear in, and rounds the amounts to cents
+func MergeTaxBreakdown(components []TaxBreakdown) []TaxBreakdown {
+	merged := []TaxBreakdown{}
+	index := make(map[string]int)
+	for _, component := range components {
+		key := component.TaxName + "|" + component.TaxRate.String()
+		if n, ok := index[key]; ok {
+			merged[n].TaxAmount = merged[n].TaxAmount.Add(component.TaxAmount)
+			merged[n].TaxableAmount = merged[n].TaxableAmount.Add(component.TaxableAmount)
+			continue
+		}
+		index[key] = len(merged)
+		merged = append(merged, component)
+	}
+
+	for n := range merged {
+		merged[n].TaxAmount = merged[n].TaxAmount.Round(2)
+		merged[n].TaxableAmount = merged[n].TaxableAmount.Round(2)
+	}
+	return merged
+}
+
+// normalizePostalCode strips spaces and dashes so "SW1A 1AA" matches prefix "SW1A"
+func normalizePostalCode(postalCode string) string {
+	return strings.ToUpper(strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(postalCode)))
+}
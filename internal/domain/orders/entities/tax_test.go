@@ -0,0 +1,237 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTaxZone(code, country string, state *string, rules ...TaxRule) *TaxZone {
+	zone := &TaxZone{
+		ID:       uuid.New(),
+		Code:     code,
+		Name:     code,
+		Country:  country,
+		State:    state,
+		IsActive: true,
+	}
+	for _, rule := range rules {
+		rule.ID = uuid.New()
+		rule.ZoneID = zone.ID
+		rule.IsActive = true
+		if rule.TaxClass == "" {
+			rule.TaxClass = TaxClassStandard
+		}
+		zone.Rules = append(zone.Rules, rule)
+	}
+	return zone
+}
+
+func newTestTaxOrder(country, state, postalCode string, shipping string, items ...OrderItem) *Order {
+	return &Order{
+		ID:              uuid.New(),
+		CustomerID:      uuid.New(),
+		Customer:        &Customer{},
+		ShippingAddress: &OrderAddress{Country: country, State: state, PostalCode: postalCode},
+		ShippingAmount:  decimal.RequireFromString(shipping),
+		Items:           items,
+	}
+}
+
+func newTestTaxItem(unitPrice string, quantity int, taxClass string) OrderItem {
+	return OrderItem{
+		ID:          uuid.New(),
+		ProductID:   uuid.New(),
+		ProductName: "Widget",
+		Quantity:    quantity,
+		UnitPrice:   decimal.RequireFromString(unitPrice),
+		TaxClass:    taxClass,
+	}
+}
+
+func stringRef(value string) *string {
+	return &value
+}
+
+func TestTaxZoneMatches(t *testing.T) {
+	zone := newTestTaxZone("US-CA-LA", "US", stringRef("CA"))
+	zone.PostalCodePrefixes = []string{"900", "901"}
+
+	assert.True(t, zone.Matches(&OrderAddress{Country: "us", State: "ca", PostalCode: "90012"}))
+	assert.False(t, zone.Matches(&OrderAddress{Country: "US", State: "CA", PostalCode: "94105"}))
+	assert.False(t, zone.Matches(&OrderAddress{Country: "US", State: "NY", PostalCode: "90012"}))
+
+	uk := newTestTaxZone("GB-LONDON", "GB", nil)
+	uk.PostalCodePrefixes = []string{"SW1A"}
+	assert.True(t, uk.Matches(&OrderAddress{Country: "GB", PostalCode: "sw1a 1aa"}))
+
+	uk.IsActive = false
+	assert.False(t, uk.Matches(&OrderAddress{Country: "GB", PostalCode: "SW1A 1AA"}))
+}
+
+func TestTaxRuleValidate(t *testing.T) {
+	rule := TaxRule{Name: "State Tax", Level: TaxLevelState, TaxClass: TaxClassStandard, Rate: decimal.NewFromInt(6)}
+	require.NoError(t, rule.Validate())
+
+	rule.TaxClass = TaxClassExempt
+	assert.Error(t, rule.Validate())
+
+	rule.TaxClass = TaxClassStandard
+	rule.Rate = decimal.NewFromInt(101)
+	assert.Error(t, rule.Validate())
+}
+
+func TestCalculateTaxStacksStateAndCountyZones(t *testing.T) {
+	state := newTestTaxZone("US-CA", "US", stringRef("CA"),
+		TaxRule{Name: "California State Tax", Level: TaxLevelState, Rate: decimal.RequireFromString("6")})
+	county := newTestTaxZone("US-CA-LA", "US", stringRef("CA"),
+		TaxRule{Name: "Los Angeles County Tax", Level: TaxLevelCounty, Rate: decimal.RequireFromString("2.5")})
+	county.PostalCodePrefixes = []string{"900"}
+	other := newTestTaxZone("US-NY", "US", stringRef("NY"),
+		TaxRule{Name: "New York State Tax", Level: TaxLevelState, Rate: decimal.RequireFromString("4")})
+
+	order := newTestTaxOrder("US", "CA", "90012", "10", newTestTaxItem("100", 2, ""))
+
+	taxes := CalculateTax(order, []*TaxZone{state, county, other}, nil, time.Now())
+
+	itemTax := taxes.Items[order.Items[0].ID]
+	assert.True(t, itemTax.TaxRate.Equal(decimal.RequireFromString("8.5")))
+	assert.True(t, itemTax.TaxAmount.Equal(decimal.NewFromInt(17)))
+	assert.False(t, itemTax.PriceIncludesTax)
+
+	require.Len(t, taxes.TaxBreakdown, 2)
+	assert.Equal(t, "California State Tax", taxes.TaxBreakdown[0].TaxName)
+	assert.Equal(t, TaxLevelState, taxes.TaxBreakdown[0].Level)
+	assert.True(t, taxes.TaxBreakdown[0].TaxAmount.Equal(decimal.NewFromInt(12)))
+	assert.Equal(t, TaxLevelCounty, taxes.TaxBreakdown[1].Level)
+	assert.True(t, taxes.TaxBreakdown[1].TaxAmount.Equal(decimal.NewFromInt(5)))
+
+	// Shipping is not taxed without SHIPPING rules
+	assert.True(t, taxes.ShippingTax.TaxAmount.IsZero())
+	assert.True(t, taxes.TaxAmount.Equal(decimal.NewFromInt(17)))
+}
+
+func TestCalculateTaxByTaxClassAndShipping(t *testing.T) {
+	zone := newTestTaxZone("US-TX", "US", stringRef("TX"),
+		TaxRule{Name: "Texas State Tax", Level: TaxLevelState, Rate: decimal.RequireFromString("6.25")},
+		TaxRule{Name: "Texas Food Tax", Level: TaxLevelState, TaxClass: "FOOD", Rate: decimal.RequireFromString("2")},
+		TaxRule{Name: "Texas Shipping Tax", Level: TaxLevelState, TaxClass: TaxClassShipping, Rate: decimal.RequireFromString("6.25")})
+
+	order := newTestTaxOrder("US", "TX", "73301", "20",
+		newTestTaxItem("100", 1, TaxClassStandard),
+		newTestTaxItem("50", 1, "FOOD"),
+		newTestTaxItem("30", 1, TaxClassExempt))
+
+	taxes := CalculateTax(order, []*TaxZone{zone}, nil, time.Now())
+
+	assert.True(t, taxes.Items[order.Items[0].ID].TaxAmount.Equal(decimal.RequireFromString("6.25")))
+	assert.True(t, taxes.Items[order.Items[1].ID].TaxAmount.Equal(decimal.NewFromInt(1)))
+	assert.True(t, taxes.Items[order.Items[2].ID].TaxAmount.IsZero())
+	assert.True(t, taxes.ShippingTax.TaxAmount.Equal(decimal.RequireFromString("1.25")))
+	assert.True(t, taxes.TaxAmount.Equal(decimal.RequireFromString("8.5")))
+}
+
+func TestCalculateTaxInclusiveVAT(t *testing.T) {
+	zone := newTestTaxZone("DE", "DE", nil,
+		TaxRule{Name: "German VAT", Level: TaxLevelVAT, Rate: decimal.NewFromInt(19)})
+	zone.PricesIncludeTax = true
+
+	order := newTestTaxOrder("DE", "", "10115", "0", newTestTaxItem("119", 1, ""))
+
+	taxes := CalculateTax(order, []*TaxZone{zone}, nil, time.Now())
+	itemTax := taxes.Items[order.Items[0].ID]
+	assert.True(t, itemTax.PriceIncludesTax)
+	assert.True(t, itemTax.TaxableAmount.Equal(decimal.NewFromInt(100)))
+	assert.True(t, itemTax.TaxAmount.Equal(decimal.NewFromInt(19)))
+
+	taxes.ApplyToItems(order)
+	calculation, err := CalculateOrderTotals(order, decimal.Zero, order.ShippingAmount)
+	require.NoError(t, err)
+	taxes.ApplyTo(calculation)
+
+	assert.True(t, calculation.Subtotal.Equal(decimal.NewFromInt(100)))
+	assert.True(t, calculation.TaxAmount.Equal(decimal.NewFromInt(19)))
+	assert.True(t, calculation.TotalAmount.Equal(decimal.NewFromInt(119)))
+	assert.True(t, order.Items[0].TotalPrice.Equal(decimal.NewFromInt(119)))
+}
+
+func TestCalculateTaxVATExemptCustomer(t *testing.T) {
+	zone := newTestTaxZone("DE", "DE", nil,
+		TaxRule{Name: "German VAT", Level: TaxLevelVAT, Rate: decimal.NewFromInt(19)})
+
+	order := newTestTaxOrder("DE", "", "10115", "0", newTestTaxItem("100", 1, ""))
+	order.Customer.IsVATExempt = true
+
+	taxes := CalculateTax(order, []*TaxZone{zone}, nil, time.Now())
+	assert.True(t, taxes.TaxAmount.IsZero())
+	assert.Empty(t, taxes.TaxBreakdown)
+}
+
+func TestCalculateTaxExemptionCertificate(t *testing.T) {
+	state := newTestTaxZone("US-CA", "US", stringRef("CA"),
+		TaxRule{Name: "California State Tax", Level: TaxLevelState, Rate: decimal.NewFromInt(6)})
+	federal := newTestTaxZone("US", "US", nil,
+		TaxRule{Name: "Federal Excise", Level: TaxLevelCountry, Rate: decimal.NewFromInt(1)})
+
+	order := newTestTaxOrder("US", "CA", "90012", "0", newTestTaxItem("100", 1, ""))
+	now := time.Now().UTC()
+	exemption := &TaxExemption{
+		ID:                uuid.New(),
+		CustomerID:        order.CustomerID,
+		CertificateNumber: "CA-RESALE-1",
+		Country:           "US",
+		State:             stringRef("CA"),
+		ValidFrom:         now.AddDate(0, -1, 0),
+	}
+
+	// A state certificate exempts the state tax but not the country-wide zone
+	taxes := CalculateTax(order, []*TaxZone{federal, state}, []*TaxExemption{exemption}, now)
+	assert.True(t, taxes.TaxAmount.Equal(decimal.NewFromInt(1)))
+	assert.Equal(t, []string{"CA-RESALE-1"}, taxes.ExemptionCertificates)
+
+	// Expired and revoked certificates no longer exempt
+	expired := now.AddDate(0, 0, -1)
+	exemption.ValidUntil = &expired
+	taxes = CalculateTax(order, []*TaxZone{federal, state}, []*TaxExemption{exemption}, now)
+	assert.True(t, taxes.TaxAmount.Equal(decimal.NewFromInt(7)))
+	assert.Empty(t, taxes.ExemptionCertificates)
+
+	exemption.ValidUntil = nil
+	require.NoError(t, exemption.Revoke(uuid.New(), "certificate lapsed"))
+	taxes = CalculateTax(order, []*TaxZone{federal, state}, []*TaxExemption{exemption}, now)
+	assert.True(t, taxes.TaxAmount.Equal(decimal.NewFromInt(7)))
+}
+
+func TestCalculateTaxFallsBackToItemRates(t *testing.T) {
+	item := newTestTaxItem("100", 1, "")
+	item.TaxRate = decimal.NewFromInt(10)
+	order := newTestTaxOrder("FR", "", "75001", "5", item)
+
+	taxes := CalculateTax(order, nil, nil, time.Now())
+	assert.True(t, taxes.TaxAmount.Equal(decimal.NewFromInt(10)))
+	require.Len(t, taxes.TaxBreakdown, 1)
+	assert.Equal(t, "Tax @ 10%", taxes.TaxBreakdown[0].TaxName)
+	assert.True(t, taxes.ShippingTax.TaxAmount.IsZero())
+}
+
+func TestTaxCalculationPortion(t *testing.T) {
+	zone := newTestTaxZone("US-WA", "US", stringRef("WA"),
+		TaxRule{Name: "Washington State Tax", Level: TaxLevelState, Rate: decimal.NewFromInt(10)},
+		TaxRule{Name: "Washington Shipping Tax", Level: TaxLevelState, TaxClass: TaxClassShipping, Rate: decimal.NewFromInt(10)})
+
+	order := newTestTaxOrder("US", "WA", "98101", "20", newTestTaxItem("10", 4, ""))
+	taxes := CalculateTax(order, []*TaxZone{zone}, nil, time.Now())
+
+	portion := taxes.Portion(order.Items, map[uuid.UUID]int{order.Items[0].ID: 1}, false)
+	require.Len(t, portion, 1)
+	assert.True(t, portion[0].TaxAmount.Equal(decimal.NewFromInt(1)))
+
+	portion = taxes.Portion(order.Items, map[uuid.UUID]int{order.Items[0].ID: 2}, true)
+	require.Len(t, portion, 2)
+	assert.True(t, portion[0].TaxAmount.Equal(decimal.NewFromInt(2)))
+	assert.True(t, portion[1].TaxAmount.Equal(decimal.NewFromInt(2)))
+}
@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"context"
+
+	"erpgo/internal/domain/orders/entities"
+	"github.com/google/uuid"
+)
+
+// ApprovalRepository defines the interface for approval policy and order approval data operations
+type ApprovalRepository interface {
+	CreatePolicy(ctx context.Context, policy *entities.ApprovalPolicy) error
+	GetPolicyByID(ctx context.Context, id uuid.UUID) (*entities.ApprovalPolicy, error)
+	UpdatePolicy(ctx context.Context, policy *entities.ApprovalPolicy) error
+	// ListPolicies retrieves policies matching the filter, highest priority first
+	ListPolicies(ctx context.Context, filter ApprovalPolicyFilter) ([]*entities.ApprovalPolicy, error)
+	CountPolicies(ctx context.Context, filter ApprovalPolicyFilter) (int, error)
+
+	// Order approval operations
+
+	CreateApprovals(ctx context.Context, approvals []*entities.OrderApproval) error
+	// UpdateApproval persists the decision on a step or its replacement
+	UpdateApproval(ctx context.Context, approval *entities.OrderApproval) error
+	// GetApprovalsByOrderID retrieves every step of an order, including
+	// superseded ones, in the order they were requested
+	GetApprovalsByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.OrderApproval, error)
+	// ListPendingApprovals retrieves the steps awaiting one of the roles on
+	// pending orders whose earlier steps are all approved, oldest first
+	ListPendingApprovals(ctx context.Context, filter PendingApprovalFilter) ([]*entities.OrderApproval, error)
+	CountPendingApprovals(ctx context.Context, filter PendingApprovalFilter) (int, error)
+}
+
+// ApprovalPolicyFilter defines filter criteria for approval policy queries
+type ApprovalPolicyFilter struct {
+	Search   string `json:"search,omitempty"`
+	IsActive *bool  `json:"is_active,omitempty"`
+
+	Page   int `json:"page"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset,omitempty"`
+}
+
+// PendingApprovalFilter defines filter criteria for an approver's inbox
+type PendingApprovalFilter struct {
+	Roles []string `json:"roles"`
+
+	Page   int `json:"page"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset,omitempty"`
+}
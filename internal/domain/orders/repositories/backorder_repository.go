@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"context"
+
+	"erpgo/internal/domain/orders/entities"
+	"github.com/google/uuid"
+)
+
+// BackorderRepository defines the interface for backorder data operations.
+// Backorders are read with the number, priority and date of their order.
+type BackorderRepository interface {
+	Create(ctx context.Context, backorder *entities.Backorder) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Backorder, error)
+	GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.Backorder, error)
+	// GetOpenByProduct retrieves the backorders of a product still waiting for stock
+	GetOpenByProduct(ctx context.Context, productID uuid.UUID) ([]*entities.Backorder, error)
+	Update(ctx context.Context, backorder *entities.Backorder) error
+	List(ctx context.Context, filter BackorderFilter) ([]*entities.Backorder, error)
+	Count(ctx context.Context, filter BackorderFilter) (int, error)
+}
+
+// BackorderFilter defines filter criteria for backorder queries
+type BackorderFilter struct {
+	Status    []entities.BackorderStatus `json:"status,omitempty"`
+	ProductID *uuid.UUID                 `json:"product_id,omitempty"`
+	OrderID   *uuid.UUID                 `json:"order_id,omitempty"`
+
+	Page   int `json:"page"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset,omitempty"`
+}
@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+
+	"erpgo/internal/domain/orders/entities"
+)
+
+// DocumentSequenceRepository defines the interface for document number
+// sequences. Numbers are allocated by the repositories of the numbered
+// documents, inside the transaction inserting them, so a failed insert never
+// consumes a number.
+type DocumentSequenceRepository interface {
+	// Configuration
+	Create(ctx context.Context, sequence *entities.DocumentSequence) error
+	GetByDocumentType(ctx context.Context, documentType entities.DocumentType) (*entities.DocumentSequence, error)
+	Update(ctx context.Context, sequence *entities.DocumentSequence) error
+	List(ctx context.Context) ([]*entities.DocumentSequence, error)
+}
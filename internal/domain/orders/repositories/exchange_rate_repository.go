@@ -0,0 +1,36 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"erpgo/internal/domain/orders/entities"
+	"github.com/google/uuid"
+)
+
+// ExchangeRateRepository defines the interface for exchange rate data operations
+type ExchangeRateRepository interface {
+	Create(ctx context.Context, rate *entities.ExchangeRate) error
+	// Upsert persists imported rates in one transaction, replacing the rate of
+	// a pair already entered for the same effective date
+	Upsert(ctx context.Context, rates []*entities.ExchangeRate) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.ExchangeRate, error)
+	// GetEffective retrieves the latest rate of every pair effective on the date
+	GetEffective(ctx context.Context, at time.Time) ([]*entities.ExchangeRate, error)
+	Update(ctx context.Context, rate *entities.ExchangeRate) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	List(ctx context.Context, filter ExchangeRateFilter) ([]*entities.ExchangeRate, error)
+	Count(ctx context.Context, filter ExchangeRateFilter) (int, error)
+}
+
+// ExchangeRateFilter defines filter criteria for exchange rate queries
+type ExchangeRateFilter struct {
+	Currency  string                       `json:"currency,omitempty"`
+	Source    *entities.ExchangeRateSource `json:"source,omitempty"`
+	StartDate *time.Time                   `json:"start_date,omitempty"`
+	EndDate   *time.Time                   `json:"end_date,omitempty"`
+
+	Page   int `json:"page"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset,omitempty"`
+}
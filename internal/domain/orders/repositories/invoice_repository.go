@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"erpgo/internal/domain/orders/entities"
+	"github.com/google/uuid"
+)
+
+// InvoiceRepository defines the interface for invoice and credit note data operations
+type InvoiceRepository interface {
+	// Create persists an invoice with its lines. Invoices created as issued,
+	// such as credit notes, are numbered from the sequence of their type.
+	Create(ctx context.Context, invoice *entities.Invoice) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Invoice, error)
+	GetByNumber(ctx context.Context, invoiceNumber string) (*entities.Invoice, error)
+	// GetByOrderID retrieves the invoices and credit notes of an order, oldest first
+	GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.Invoice, error)
+	// Update persists a status change of a draft: issuing, which numbers the
+	// invoice, or cancelling. Issued invoices are locked and cannot be updated.
+	Update(ctx context.Context, invoice *entities.Invoice) error
+	List(ctx context.Context, filter InvoiceFilter) ([]*entities.Invoice, error)
+	Count(ctx context.Context, filter InvoiceFilter) (int, error)
+}
+
+// InvoiceFilter defines filter criteria for invoice queries
+type InvoiceFilter struct {
+	Search     string                   `json:"search,omitempty"`
+	Type       *entities.InvoiceType    `json:"type,omitempty"`
+	Status     []entities.InvoiceStatus `json:"status,omitempty"`
+	CustomerID *uuid.UUID               `json:"customer_id,omitempty"`
+	OrderID    *uuid.UUID               `json:"order_id,omitempty"`
+	IssuedFrom *time.Time               `json:"issued_from,omitempty"`
+	IssuedTo   *time.Time               `json:"issued_to,omitempty"`
+	DueBefore  *time.Time               `json:"due_before,omitempty"`
+
+	Page   int `json:"page"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset,omitempty"`
+}
@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"erpgo/internal/domain/orders/entities"
+	"github.com/google/uuid"
+)
+
+// OrderArchiveRepository defines the interface for the order archive. Orders
+// move between the live tables and the archive with their items, addresses
+// and status history.
+type OrderArchiveRepository interface {
+	// Archive moves an order from the live tables into the archive
+	Archive(ctx context.Context, orderID uuid.UUID, archivedAt time.Time) error
+	// Restore moves an archived order back into the live tables
+	Restore(ctx context.Context, orderID uuid.UUID) error
+
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Order, error)
+	GetByOrderNumber(ctx context.Context, orderNumber string) (*entities.Order, error)
+	GetItems(ctx context.Context, orderID uuid.UUID) ([]*entities.OrderItem, error)
+	// GetAddresses retrieves the addresses of an archived order as they stood when it was archived
+	GetAddresses(ctx context.Context, orderID uuid.UUID) ([]*entities.OrderAddress, error)
+	GetStatusHistory(ctx context.Context, orderID uuid.UUID) ([]*entities.OrderStatusHistory, error)
+	List(ctx context.Context, filter ArchivedOrderFilter) ([]*entities.Order, error)
+	Count(ctx context.Context, filter ArchivedOrderFilter) (int, error)
+
+	// FindArchivable returns the IDs of closed, settled live orders unchanged
+	// since before the cutoff and without open returns, oldest first
+	FindArchivable(ctx context.Context, cutoff time.Time, limit int) ([]uuid.UUID, error)
+	// HasOpenReturns reports whether a return authorization of the order is still open
+	HasOpenReturns(ctx context.Context, orderID uuid.UUID) (bool, error)
+}
+
+// ArchivedOrderFilter represents filters for listing archived orders
+type ArchivedOrderFilter struct {
+	Search     string     `json:"search,omitempty"`
+	CustomerID *uuid.UUID `json:"customer_id,omitempty"`
+	// Year is the year of the order date, selecting one archive partition
+	Year *int `json:"year,omitempty"`
+
+	Page   int `json:"page"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset,omitempty"`
+}
@@ -0,0 +1,40 @@
+package repositories
+
+import (
+	"context"
+
+	"erpgo/internal/domain/orders/entities"
+	"github.com/google/uuid"
+)
+
+// OrderImportRepository defines the interface for order import profile and
+// external reference data operations
+type OrderImportRepository interface {
+	CreateProfile(ctx context.Context, profile *entities.OrderImportProfile) error
+	GetProfileByID(ctx context.Context, id uuid.UUID) (*entities.OrderImportProfile, error)
+	UpdateProfile(ctx context.Context, profile *entities.OrderImportProfile) error
+	// ListProfiles retrieves profiles matching the filter, by name
+	ListProfiles(ctx context.Context, filter OrderImportProfileFilter) ([]*entities.OrderImportProfile, error)
+	CountProfiles(ctx context.Context, filter OrderImportProfileFilter) (int, error)
+
+	// External reference operations
+
+	// GetExternalReferences retrieves the references of a source already
+	// imported among the given ones, with their order numbers
+	GetExternalReferences(ctx context.Context, source string, references []string) ([]*entities.OrderExternalReference, error)
+	// ClaimExternalReferences records the references in one transaction before
+	// their orders are created. It fails without recording any when one of
+	// them has been recorded meanwhile.
+	ClaimExternalReferences(ctx context.Context, references []*entities.OrderExternalReference) error
+}
+
+// OrderImportProfileFilter defines filter criteria for order import profile queries
+type OrderImportProfileFilter struct {
+	Search   string `json:"search,omitempty"`
+	Source   string `json:"source,omitempty"`
+	IsActive *bool  `json:"is_active,omitempty"`
+
+	Page   int `json:"page"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset,omitempty"`
+}
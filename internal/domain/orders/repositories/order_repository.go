@@ -17,6 +17,9 @@ type OrderRepository interface {
 	GetByOrderNumber(ctx context.Context, orderNumber string) (*entities.Order, error)
 	Update(ctx context.Context, order *entities.Order) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	// Lock locks the order's row until the transaction of the context ends,
+	// so concurrent changes to the order wait for it
+	Lock(ctx context.Context, id uuid.UUID) error
 
 	// Query operations
 	List(ctx context.Context, filter OrderFilter) ([]*entities.Order, error)
@@ -38,8 +41,14 @@ type OrderRepository interface {
 
 	// Financial operations
 	GetUnpaidOrders(ctx context.Context) ([]*entities.Order, error)
+	// GetOpenReceivables returns shipped orders with an outstanding balance, with
+	// their customer, optionally of one customer
+	GetOpenReceivables(ctx context.Context, customerID *uuid.UUID) ([]*entities.Order, error)
 	GetOrdersByPaymentStatus(ctx context.Context, paymentStatus entities.PaymentStatus) ([]*entities.Order, error)
 	UpdatePaymentStatus(ctx context.Context, orderID uuid.UUID, paymentStatus entities.PaymentStatus, paidAmount decimal.Decimal) error
+	// GetCustomerCreditExposure returns the customer's open receivables plus
+	// unshipped approved orders in the base currency
+	GetCustomerCreditExposure(ctx context.Context, customerID uuid.UUID) (decimal.Decimal, error)
 
 	// Bulk operations
 	BulkUpdateStatus(ctx context.Context, orderIDs []uuid.UUID, newStatus entities.OrderStatus, updatedBy uuid.UUID) error
@@ -98,6 +107,9 @@ type CustomerRepository interface {
 	GetByCode(ctx context.Context, code string) (*entities.Customer, error)
 	Update(ctx context.Context, customer *entities.Customer) (*entities.Customer, error)
 	Delete(ctx context.Context, id uuid.UUID) error
+	// Lock locks the customer's row until the transaction of the context
+	// ends, so concurrent credit checks for the customer wait for it
+	Lock(ctx context.Context, id uuid.UUID) error
 
 	// Query operations
 	List(ctx context.Context, filter CustomerFilter) ([]*entities.Customer, error)
@@ -358,24 +370,26 @@ type CustomerStatsFilter struct {
 
 // Analytics result types
 
-// OrderStats represents order statistics
+// OrderStats represents order statistics with amounts in the base currency
 type OrderStats struct {
 	TotalOrders         int64            `json:"total_orders"`
 	TotalRevenue        decimal.Decimal  `json:"total_revenue"`
 	AverageOrderValue   decimal.Decimal  `json:"average_order_value"`
 	StatusCounts        map[string]int64 `json:"status_counts"`
 	PaymentStatusCounts map[string]int64 `json:"payment_status_counts"`
+	Currency            string           `json:"currency"`
 }
 
-// RevenueByPeriod represents revenue grouped by time period
+// RevenueByPeriod represents base currency revenue grouped by time period
 type RevenueByPeriod struct {
 	Period            string          `json:"period"`
 	Revenue           decimal.Decimal `json:"revenue"`
 	OrderCount        int64           `json:"order_count"`
 	AverageOrderValue decimal.Decimal `json:"average_order_value"`
+	Currency          string          `json:"currency"`
 }
 
-// CustomerOrderStats represents customer order statistics
+// CustomerOrderStats represents customer order statistics with amounts in the base currency
 type CustomerOrderStats struct {
 	CustomerID        uuid.UUID       `json:"customer_id"`
 	CustomerName      string          `json:"customer_name"`
@@ -384,6 +398,7 @@ type CustomerOrderStats struct {
 	TotalRevenue      decimal.Decimal `json:"total_revenue"`
 	AverageOrderValue decimal.Decimal `json:"average_order_value"`
 	LastOrderDate     *time.Time      `json:"last_order_date,omitempty"`
+	Currency          string          `json:"currency"`
 }
 
 // ProductSalesStats represents product sales statistics
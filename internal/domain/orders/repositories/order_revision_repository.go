@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+
+	"erpgo/internal/domain/orders/entities"
+	"github.com/google/uuid"
+)
+
+// OrderRevisionRepository defines the interface for order revision data operations
+type OrderRevisionRepository interface {
+	Create(ctx context.Context, revision *entities.OrderRevision) error
+	// Update records the approvals, rejection or application of a revision
+	Update(ctx context.Context, revision *entities.OrderRevision) error
+	GetByNumber(ctx context.Context, orderID uuid.UUID, revisionNumber int) (*entities.OrderRevision, error)
+	// GetByOrderID retrieves the revisions of an order, oldest first
+	GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.OrderRevision, error)
+}
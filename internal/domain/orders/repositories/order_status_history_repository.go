@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+
+	"erpgo/internal/domain/orders/entities"
+	"github.com/google/uuid"
+)
+
+// OrderStatusHistoryRepository defines the interface for the append-only order status history
+type OrderStatusHistoryRepository interface {
+	Create(ctx context.Context, entry *entities.OrderStatusHistory) error
+	GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.OrderStatusHistory, error)
+}
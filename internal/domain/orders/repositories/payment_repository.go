@@ -0,0 +1,45 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"erpgo/internal/domain/orders/entities"
+	"github.com/google/uuid"
+)
+
+// PaymentRepository defines the interface for payment ledger data operations
+type PaymentRepository interface {
+	// Create persists a ledger entry with its allocations. Entries without a
+	// number are numbered from the PAYMENT document sequence.
+	Create(ctx context.Context, payment *entities.Payment) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Payment, error)
+	// Lock locks the entry's row until the transaction of the context ends,
+	// so concurrent allocations and reversals of the entry wait for it
+	Lock(ctx context.Context, id uuid.UUID) error
+	// GetByOrderID retrieves every entry with an allocation to the order,
+	// reversed entries included
+	GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.Payment, error)
+	// Update persists the entry status and any allocations not stored yet
+	Update(ctx context.Context, payment *entities.Payment) error
+	List(ctx context.Context, filter PaymentFilter) ([]*entities.Payment, error)
+	Count(ctx context.Context, filter PaymentFilter) (int, error)
+}
+
+// PaymentFilter defines filter criteria for payment ledger queries
+type PaymentFilter struct {
+	Search       string                        `json:"search,omitempty"`
+	Type         *entities.PaymentType         `json:"type,omitempty"`
+	Method       []entities.PaymentMethod      `json:"method,omitempty"`
+	Status       []entities.PaymentEntryStatus `json:"status,omitempty"`
+	CustomerID   *uuid.UUID                    `json:"customer_id,omitempty"`
+	OrderID      *uuid.UUID                    `json:"order_id,omitempty"`
+	ReceivedFrom *time.Time                    `json:"received_from,omitempty"`
+	ReceivedTo   *time.Time                    `json:"received_to,omitempty"`
+	// Unallocated limits the results to payments with an unallocated balance
+	Unallocated bool `json:"unallocated,omitempty"`
+
+	Page   int `json:"page"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset,omitempty"`
+}
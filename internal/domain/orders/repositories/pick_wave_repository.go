@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"context"
+
+	"erpgo/internal/domain/orders/entities"
+	"github.com/google/uuid"
+)
+
+// PickWaveRepository defines the interface for pick wave and pick location data operations
+type PickWaveRepository interface {
+	// Create persists a pick wave with its orders and lines. Waves without a
+	// number are numbered from the PICK_WAVE document sequence.
+	Create(ctx context.Context, wave *entities.PickWave) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.PickWave, error)
+	// Update persists the wave header and the progress of its orders and lines
+	Update(ctx context.Context, wave *entities.PickWave) error
+	List(ctx context.Context, filter PickWaveFilter) ([]*entities.PickWave, error)
+	Count(ctx context.Context, filter PickWaveFilter) (int, error)
+	// GetOpen retrieves the waves being picked, with their orders and lines
+	GetOpen(ctx context.Context) ([]*entities.PickWave, error)
+
+	// SetPickLocation creates or replaces the location of a product in a warehouse
+	SetPickLocation(ctx context.Context, location *entities.PickLocation) error
+	// GetPickLocations retrieves the pick locations of a warehouse by location
+	GetPickLocations(ctx context.Context, warehouseID uuid.UUID) ([]*entities.PickLocation, error)
+}
+
+// PickWaveFilter defines filter criteria for pick wave queries
+type PickWaveFilter struct {
+	Search      string                    `json:"search,omitempty"`
+	Status      []entities.PickWaveStatus `json:"status,omitempty"`
+	WarehouseID *uuid.UUID                `json:"warehouse_id,omitempty"`
+	OrderID     *uuid.UUID                `json:"order_id,omitempty"`
+
+	Page   int `json:"page"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset,omitempty"`
+}
@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"erpgo/internal/domain/orders/entities"
+	"github.com/google/uuid"
+)
+
+// PromotionRepository defines the interface for promotion and coupon redemption data operations
+type PromotionRepository interface {
+	Create(ctx context.Context, promotion *entities.Promotion) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Promotion, error)
+	GetByCode(ctx context.Context, code string) (*entities.Promotion, error)
+	Update(ctx context.Context, promotion *entities.Promotion) error
+	List(ctx context.Context, filter PromotionFilter) ([]*entities.Promotion, error)
+	Count(ctx context.Context, filter PromotionFilter) (int, error)
+
+	// Redemption operations
+
+	// Redeem records a redemption after checking the promotion's usage limits
+	// with the promotion locked, so concurrent redemptions cannot exceed them
+	Redeem(ctx context.Context, redemption *entities.PromotionRedemption) error
+	// UpdateRedemption persists the release of a redemption
+	UpdateRedemption(ctx context.Context, redemption *entities.PromotionRedemption) error
+	// GetActiveRedemptionsByOrderID retrieves the redemptions of an order that
+	// were not released, with their promotions, oldest first
+	GetActiveRedemptionsByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.PromotionRedemption, error)
+}
+
+// PromotionFilter defines filter criteria for promotion queries
+type PromotionFilter struct {
+	Search     string                        `json:"search,omitempty"`
+	ActionType *entities.PromotionActionType `json:"action_type,omitempty"`
+	IsActive   *bool                         `json:"is_active,omitempty"`
+	// RunningAt limits the result to active promotions within their date window
+	RunningAt *time.Time `json:"running_at,omitempty"`
+
+	Page   int `json:"page"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset,omitempty"`
+}
@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"erpgo/internal/domain/orders/entities"
+	"github.com/google/uuid"
+)
+
+// QuotationRepository defines the interface for quotation data operations
+type QuotationRepository interface {
+	// Create persists a quotation with its lines. Quotations without a number
+	// are numbered from the QUOTATION document sequence.
+	Create(ctx context.Context, quotation *entities.Quotation) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Quotation, error)
+	GetByOrderID(ctx context.Context, orderID uuid.UUID) (*entities.Quotation, error)
+	// Update persists the quotation header and replaces its lines
+	Update(ctx context.Context, quotation *entities.Quotation) error
+	// Lock locks the quotation's row until the transaction of the context
+	// ends, so concurrent changes to the quotation wait for it
+	Lock(ctx context.Context, id uuid.UUID) error
+	List(ctx context.Context, filter QuotationFilter) ([]*entities.Quotation, error)
+	Count(ctx context.Context, filter QuotationFilter) (int, error)
+	GetRevisions(ctx context.Context, quotationNumber string) ([]*entities.Quotation, error)
+
+	// ExpireBefore closes open quotations whose validity ended before the given
+	// time and returns the number of quotations expired
+	ExpireBefore(ctx context.Context, before time.Time) (int, error)
+}
+
+// QuotationFilter defines filter criteria for quotation queries
+type QuotationFilter struct {
+	Search     string                     `json:"search,omitempty"`
+	Status     []entities.QuotationStatus `json:"status,omitempty"`
+	CustomerID *uuid.UUID                 `json:"customer_id,omitempty"`
+	// LatestOnly restricts results to the latest revision of each quotation
+	LatestOnly bool `json:"latest_only,omitempty"`
+
+	Page   int `json:"page"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset,omitempty"`
+}
@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"erpgo/internal/domain/orders/entities"
+	"github.com/google/uuid"
+)
+
+// RecurringOrderRepository defines the interface for recurring order data operations
+type RecurringOrderRepository interface {
+	// Create persists a recurring order with its template lines
+	Create(ctx context.Context, recurringOrder *entities.RecurringOrder) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.RecurringOrder, error)
+	// Update persists the recurring order and replaces its template lines
+	Update(ctx context.Context, recurringOrder *entities.RecurringOrder) error
+	List(ctx context.Context, filter RecurringOrderFilter) ([]*entities.RecurringOrder, error)
+	Count(ctx context.Context, filter RecurringOrderFilter) (int, error)
+
+	// ListDue retrieves active recurring orders whose next occurrence is at or
+	// before the given time, earliest first, with their template lines
+	ListDue(ctx context.Context, at time.Time, limit int) ([]*entities.RecurringOrder, error)
+	// ClaimOccurrence persists the schedule of a recurring order advanced past
+	// the occurrence it was due for, provided no one else claimed it first.
+	// It reports whether the occurrence was claimed.
+	ClaimOccurrence(ctx context.Context, recurringOrder *entities.RecurringOrder, dueAt time.Time) (bool, error)
+
+	CreateRun(ctx context.Context, run *entities.RecurringOrderRun) error
+	// GetRuns retrieves the runs of a recurring order, latest occurrence first
+	GetRuns(ctx context.Context, recurringOrderID uuid.UUID) ([]*entities.RecurringOrderRun, error)
+}
+
+// RecurringOrderFilter defines filter criteria for recurring order queries
+type RecurringOrderFilter struct {
+	Search     string                          `json:"search,omitempty"`
+	Status     []entities.RecurringOrderStatus `json:"status,omitempty"`
+	CustomerID *uuid.UUID                      `json:"customer_id,omitempty"`
+
+	Page   int `json:"page"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset,omitempty"`
+}
@@ -0,0 +1,37 @@
+package repositories
+
+import (
+	"context"
+
+	"erpgo/internal/domain/orders/entities"
+	"github.com/google/uuid"
+)
+
+// ReturnAuthorizationRepository defines the interface for return authorization data operations
+type ReturnAuthorizationRepository interface {
+	// Create persists a return authorization with its lines. Returns without a
+	// number are numbered from the document sequence of RETURN orders.
+	Create(ctx context.Context, rma *entities.ReturnAuthorization) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.ReturnAuthorization, error)
+	// GetByOrderID retrieves every return authorization raised against an order
+	GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.ReturnAuthorization, error)
+	// Update persists the return authorization header and its line progress
+	Update(ctx context.Context, rma *entities.ReturnAuthorization) error
+	// Lock locks the return authorization's row until the transaction of the
+	// context ends, so concurrent changes to the return wait for it
+	Lock(ctx context.Context, id uuid.UUID) error
+	List(ctx context.Context, filter ReturnAuthorizationFilter) ([]*entities.ReturnAuthorization, error)
+	Count(ctx context.Context, filter ReturnAuthorizationFilter) (int, error)
+}
+
+// ReturnAuthorizationFilter defines filter criteria for return authorization queries
+type ReturnAuthorizationFilter struct {
+	Search     string                  `json:"search,omitempty"`
+	Status     []entities.ReturnStatus `json:"status,omitempty"`
+	OrderID    *uuid.UUID              `json:"order_id,omitempty"`
+	CustomerID *uuid.UUID              `json:"customer_id,omitempty"`
+
+	Page   int `json:"page"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset,omitempty"`
+}
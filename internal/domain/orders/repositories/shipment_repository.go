@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+
+	"erpgo/internal/domain/orders/entities"
+	"github.com/google/uuid"
+)
+
+// ShipmentRepository defines the interface for shipment data operations
+type ShipmentRepository interface {
+	// Create persists a shipment with its lines and packages
+	Create(ctx context.Context, shipment *entities.Shipment) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Shipment, error)
+	// GetByOrderID retrieves every shipment of an order in shipping order
+	GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.Shipment, error)
+	// Update persists the shipment header; lines and packages are immutable
+	Update(ctx context.Context, shipment *entities.Shipment) error
+}
@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"context"
+
+	"erpgo/internal/domain/orders/entities"
+	"github.com/google/uuid"
+)
+
+// ShippingZoneRepository defines the interface for shipping zone and shipping rate data operations
+type ShippingZoneRepository interface {
+	// Create persists a zone with its rates
+	Create(ctx context.Context, zone *entities.ShippingZone) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.ShippingZone, error)
+	GetByCode(ctx context.Context, code string) (*entities.ShippingZone, error)
+	// GetActiveByCountry retrieves the active zones of a country with their active rates
+	GetActiveByCountry(ctx context.Context, country string) ([]*entities.ShippingZone, error)
+	// Update persists the zone itself; its rates are changed one at a time
+	Update(ctx context.Context, zone *entities.ShippingZone) error
+	List(ctx context.Context, filter ShippingZoneFilter) ([]*entities.ShippingZone, error)
+	Count(ctx context.Context, filter ShippingZoneFilter) (int, error)
+
+	// Rate operations
+	CreateRate(ctx context.Context, rate *entities.ShippingRate) error
+	UpdateRate(ctx context.Context, rate *entities.ShippingRate) error
+}
+
+// ShippingZoneFilter defines filter criteria for shipping zone queries
+type ShippingZoneFilter struct {
+	Search   string  `json:"search,omitempty"`
+	Country  string  `json:"country,omitempty"`
+	State    *string `json:"state,omitempty"`
+	IsActive *bool   `json:"is_active,omitempty"`
+
+	Page   int `json:"page"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset,omitempty"`
+}
@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"context"
+
+	"erpgo/internal/domain/orders/entities"
+	"github.com/google/uuid"
+)
+
+// SourcingRepository defines the interface for sourcing rule and order allocation data operations
+type SourcingRepository interface {
+	CreateRule(ctx context.Context, rule *entities.SourcingRule) error
+	GetRuleByID(ctx context.Context, id uuid.UUID) (*entities.SourcingRule, error)
+	UpdateRule(ctx context.Context, rule *entities.SourcingRule) error
+	// ListRules retrieves rules matching the filter, highest priority first
+	ListRules(ctx context.Context, filter SourcingRuleFilter) ([]*entities.SourcingRule, error)
+	CountRules(ctx context.Context, filter SourcingRuleFilter) (int, error)
+
+	// Order allocation operations
+
+	// GetAllocationsByOrderID retrieves the warehouse allocations of an order's lines
+	GetAllocationsByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.OrderAllocation, error)
+	// ReplaceAllocations replaces the allocations of an order in one transaction
+	ReplaceAllocations(ctx context.Context, orderID uuid.UUID, allocations []*entities.OrderAllocation) error
+	// AddAllocation adds the quantity of the allocation to the order line's
+	// allocation in its warehouse, creating it when missing
+	AddAllocation(ctx context.Context, allocation *entities.OrderAllocation) error
+}
+
+// SourcingRuleFilter defines filter criteria for sourcing rule queries
+type SourcingRuleFilter struct {
+	Search   string `json:"search,omitempty"`
+	IsActive *bool  `json:"is_active,omitempty"`
+
+	Page   int `json:"page"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset,omitempty"`
+}
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+
+	"erpgo/internal/domain/orders/entities"
+	"github.com/google/uuid"
+)
+
+// TaxExemptionRepository defines the interface for customer tax exemption data operations
+type TaxExemptionRepository interface {
+	Create(ctx context.Context, exemption *entities.TaxExemption) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.TaxExemption, error)
+	// GetByCustomerID retrieves the exemptions of a customer, revoked ones
+	// included, newest first
+	GetByCustomerID(ctx context.Context, customerID uuid.UUID) ([]*entities.TaxExemption, error)
+	Update(ctx context.Context, exemption *entities.TaxExemption) error
+}
@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"context"
+
+	"erpgo/internal/domain/orders/entities"
+	"github.com/google/uuid"
+)
+
+// TaxZoneRepository defines the interface for tax zone and tax rule data operations
+type TaxZoneRepository interface {
+	// Create persists a zone with its rules
+	Create(ctx context.Context, zone *entities.TaxZone) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.TaxZone, error)
+	GetByCode(ctx context.Context, code string) (*entities.TaxZone, error)
+	// GetActiveByCountry retrieves the active zones of a country with their rules,
+	// country-wide zones first
+	GetActiveByCountry(ctx context.Context, country string) ([]*entities.TaxZone, error)
+	// Update persists the zone itself; its rules are changed one at a time
+	Update(ctx context.Context, zone *entities.TaxZone) error
+	List(ctx context.Context, filter TaxZoneFilter) ([]*entities.TaxZone, error)
+	Count(ctx context.Context, filter TaxZoneFilter) (int, error)
+
+	// Rule operations
+	CreateRule(ctx context.Context, rule *entities.TaxRule) error
+	UpdateRule(ctx context.Context, rule *entities.TaxRule) error
+}
+
+// TaxZoneFilter defines filter criteria for tax zone queries
+type TaxZoneFilter struct {
+	Search   string  `json:"search,omitempty"`
+	Country  string  `json:"country,omitempty"`
+	State    *string `json:"state,omitempty"`
+	IsActive *bool   `json:"is_active,omitempty"`
+
+	Page   int `json:"page"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset,omitempty"`
+}
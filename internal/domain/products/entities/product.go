@@ -37,9 +37,12 @@ type Product struct {
 	RequiresShipping bool            `json:"requires_shipping" db:"requires_shipping"`
 	Taxable          bool            `json:"taxable" db:"taxable"`
 	TaxRate          decimal.Decimal `json:"tax_rate" db:"tax_rate"`
+	TaxClass         string          `json:"tax_class" db:"tax_class"` // STANDARD, REDUCED, etc.; selects tax zone rules
 	IsActive         bool            `json:"is_active" db:"is_active"`
 	IsFeatured       bool            `json:"is_featured" db:"is_featured"`
 	IsDigital        bool            `json:"is_digital" db:"is_digital"`
+	IsDropShip       bool            `json:"is_drop_ship" db:"is_drop_ship"`         // shipped by the supplier straight to the customer, never stocked
+	SupplierID       *uuid.UUID      `json:"supplier_id,omitempty" db:"supplier_id"` // supplier drop-ship lines are ordered from
 	DownloadURL      string          `json:"download_url" db:"download_url"`
 	MaxDownloads     int             `json:"max_downloads" db:"max_downloads"`
 	ExpiryDays       int             `json:"expiry_days" db:"expiry_days"`
@@ -106,6 +109,11 @@ func (p *Product) Validate() error {
 		errs = append(errs, fmt.Errorf("invalid digital settings: %w", err))
 	}
 
+	// Validate drop-ship settings
+	if err := p.validateDropShipSettings(); err != nil {
+		errs = append(errs, fmt.Errorf("invalid drop-ship settings: %w", err))
+	}
+
 	if len(errs) > 0 {
 		return fmt.Errorf("validation failed: %v", errors.Join(errs...))
 	}
@@ -300,6 +308,13 @@ func (p *Product) validateTaxSettings() error {
 		}
 	}
 
+	if p.TaxClass != "" {
+		taxClassRegex := regexp.MustCompile(`^[A-Z][A-Z0-9_]{0,49}$`)
+		if !taxClassRegex.MatchString(p.TaxClass) {
+			return errors.New("tax class must be upper case letters, digits and underscores")
+		}
+	}
+
 	return nil
 }
 
@@ -355,6 +370,29 @@ func (p *Product) validateDigitalSettings() error {
 	return nil
 }
 
+// validateDropShipSettings validates drop-ship product settings
+func (p *Product) validateDropShipSettings() error {
+	if !p.IsDropShip {
+		return nil
+	}
+
+	// Drop-ship products are ordered from their supplier for every sale
+	if p.SupplierID == nil || *p.SupplierID == uuid.Nil {
+		return errors.New("drop-ship products must have a supplier")
+	}
+
+	if p.IsDigital {
+		return errors.New("digital products cannot be drop-shipped")
+	}
+
+	// Stock never passes through a warehouse
+	if p.TrackInventory {
+		return errors.New("drop-ship products cannot track inventory")
+	}
+
+	return nil
+}
+
 // validateDownloadURL validates the download URL
 func (p *Product) validateDownloadURL() error {
 	url := strings.TrimSpace(p.DownloadURL)
@@ -572,9 +610,12 @@ func (p *Product) ToSafeProduct() *Product {
 		RequiresShipping: p.RequiresShipping,
 		Taxable:          p.Taxable,
 		TaxRate:          p.TaxRate,
+		TaxClass:         p.TaxClass,
 		IsActive:         p.IsActive,
 		IsFeatured:       p.IsFeatured,
 		IsDigital:        p.IsDigital,
+		IsDropShip:       p.IsDropShip,
+		SupplierID:       p.SupplierID,
 		CreatedAt:        p.CreatedAt,
 		UpdatedAt:        p.UpdatedAt,
 	}
@@ -947,6 +947,43 @@ func TestProduct_ValidationCoverage(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("validateDropShipSettings_AllCases", func(t *testing.T) {
+		supplierID := uuid.New()
+		product := &Product{
+			ID:         uuid.New(),
+			SKU:        "TEST-001",
+			Name:       "Test Product",
+			CategoryID: uuid.New(),
+			Price:      decimal.NewFromFloat(100.00),
+			Cost:       decimal.NewFromFloat(60.00),
+			IsDropShip: true,
+			SupplierID: &supplierID,
+			IsActive:   true,
+		}
+
+		// Valid drop-ship product
+		err := product.Validate()
+		assert.NoError(t, err)
+
+		// Drop-ship product tracking inventory
+		product.TrackInventory = true
+		err = product.Validate()
+		assert.ErrorContains(t, err, "cannot track inventory")
+		product.TrackInventory = false
+
+		// Drop-ship product without a supplier
+		product.SupplierID = nil
+		err = product.Validate()
+		assert.ErrorContains(t, err, "must have a supplier")
+		product.SupplierID = &supplierID
+
+		// Digital drop-ship product
+		product.IsDigital = true
+		product.DownloadURL = "https://example.com/download"
+		err = product.Validate()
+		assert.ErrorContains(t, err, "cannot be drop-shipped")
+	})
+
 	t.Run("validatePricing_AllCases", func(t *testing.T) {
 		product := &Product{
 			ID:         uuid.New(),
@@ -0,0 +1,428 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// PurchaseOrderStatus represents the status of a purchase order
+type PurchaseOrderStatus string
+
+const (
+	PurchaseOrderStatusDraft             PurchaseOrderStatus = "DRAFT"
+	PurchaseOrderStatusOrdered           PurchaseOrderStatus = "ORDERED"
+	PurchaseOrderStatusPartiallyReceived PurchaseOrderStatus = "PARTIALLY_RECEIVED"
+	PurchaseOrderStatusClosed            PurchaseOrderStatus = "CLOSED"
+	PurchaseOrderStatusCancelled         PurchaseOrderStatus = "CANCELLED"
+)
+
+// PurchaseOrderStatusTransitions defines valid purchase order status transitions
+var PurchaseOrderStatusTransitions = map[PurchaseOrderStatus][]PurchaseOrderStatus{
+	PurchaseOrderStatusDraft:             {PurchaseOrderStatusOrdered, PurchaseOrderStatusCancelled},
+	PurchaseOrderStatusOrdered:           {PurchaseOrderStatusPartiallyReceived, PurchaseOrderStatusClosed, PurchaseOrderStatusCancelled},
+	PurchaseOrderStatusPartiallyReceived: {PurchaseOrderStatusClosed},
+	PurchaseOrderStatusClosed:            {},
+	PurchaseOrderStatusCancelled:         {},
+}
+
+// PurchaseOrder represents an order for stock placed with a supplier and
+// received into a single warehouse. A drop-ship purchase order has no
+// warehouse: the supplier ships its lines straight to the customer of a
+// sales order.
+type PurchaseOrder struct {
+	ID          uuid.UUID           `json:"id" db:"id"`
+	PONumber    string              `json:"po_number" db:"po_number"`
+	SupplierID  uuid.UUID           `json:"supplier_id" db:"supplier_id"`
+	WarehouseID *uuid.UUID          `json:"warehouse_id,omitempty" db:"warehouse_id"`
+	Status      PurchaseOrderStatus `json:"status" db:"status"`
+
+	// SalesOrderID and ShipTo are set on drop-ship purchase orders
+	SalesOrderID *uuid.UUID       `json:"sales_order_id,omitempty" db:"sales_order_id"`
+	ShipTo       *DropShipAddress `json:"ship_to,omitempty" db:"ship_to"`
+
+	OrderDate    time.Time  `json:"order_date" db:"order_date"`
+	ExpectedDate *time.Time `json:"expected_date,omitempty" db:"expected_date"`
+	Currency     string     `json:"currency" db:"currency"`
+	PaymentTerms string     `json:"payment_terms" db:"payment_terms"`
+
+	Subtotal       decimal.Decimal `json:"subtotal" db:"subtotal"`
+	TaxAmount      decimal.Decimal `json:"tax_amount" db:"tax_amount"`
+	ShippingAmount decimal.Decimal `json:"shipping_amount" db:"shipping_amount"`
+	TotalAmount    decimal.Decimal `json:"total_amount" db:"total_amount"`
+
+	Notes              *string `json:"notes,omitempty" db:"notes"`
+	CancellationReason *string `json:"cancellation_reason,omitempty" db:"cancellation_reason"`
+
+	Items []PurchaseOrderItem `json:"items,omitempty" db:"-"`
+
+	CreatedBy   uuid.UUID  `json:"created_by" db:"created_by"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+	OrderedAt   *time.Time `json:"ordered_at,omitempty" db:"ordered_at"`
+	ClosedAt    *time.Time `json:"closed_at,omitempty" db:"closed_at"`
+	CancelledAt *time.Time `json:"cancelled_at,omitempty" db:"cancelled_at"`
+}
+
+// PurchaseOrderItem represents an ordered product line of a purchase order
+type PurchaseOrderItem struct {
+	ID               uuid.UUID       `json:"id" db:"id"`
+	PurchaseOrderID  uuid.UUID       `json:"purchase_order_id" db:"purchase_order_id"`
+	ProductID        uuid.UUID       `json:"product_id" db:"product_id"`
+	ProductSKU       string          `json:"product_sku" db:"product_sku"`
+	ProductName      string          `json:"product_name" db:"product_name"`
+	QuantityOrdered  int             `json:"quantity_ordered" db:"quantity_ordered"`
+	QuantityReceived int             `json:"quantity_received" db:"quantity_received"`
+	UnitCost         decimal.Decimal `json:"unit_cost" db:"unit_cost"`
+	TaxRate          decimal.Decimal `json:"tax_rate" db:"tax_rate"`
+	TaxAmount        decimal.Decimal `json:"tax_amount" db:"tax_amount"`
+	TotalCost        decimal.Decimal `json:"total_cost" db:"total_cost"`
+	ExpectedDate     *time.Time      `json:"expected_date,omitempty" db:"expected_date"`
+	Notes            *string         `json:"notes,omitempty" db:"notes"`
+	// SalesOrderItemID is the sales order line a drop-ship line fulfils
+	SalesOrderItemID *uuid.UUID `json:"sales_order_item_id,omitempty" db:"sales_order_item_id"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// DropShipAddress is the customer address a supplier ships a drop-ship
+// purchase order to, copied from the sales order's shipping address
+type DropShipAddress struct {
+	Name         string  `json:"name"`
+	Company      *string `json:"company,omitempty"`
+	AddressLine1 string  `json:"address_line1"`
+	AddressLine2 *string `json:"address_line2,omitempty"`
+	City         string  `json:"city"`
+	State        string  `json:"state"`
+	PostalCode   string  `json:"postal_code"`
+	Country      string  `json:"country"`
+	Phone        *string `json:"phone,omitempty"`
+}
+
+// GoodsReceipt records stock received against a purchase order
+type GoodsReceipt struct {
+	ID                uuid.UUID          `json:"id" db:"id"`
+	ReceiptNumber     string             `json:"receipt_number" db:"receipt_number"`
+	PurchaseOrderID   uuid.UUID          `json:"purchase_order_id" db:"purchase_order_id"`
+	WarehouseID       uuid.UUID          `json:"warehouse_id" db:"warehouse_id"`
+	SupplierReference *string            `json:"supplier_reference,omitempty" db:"supplier_reference"`
+	Notes             *string            `json:"notes,omitempty" db:"notes"`
+	Items             []GoodsReceiptItem `json:"items" db:"-"`
+	ReceivedBy        uuid.UUID          `json:"received_by" db:"received_by"`
+	ReceivedAt        time.Time          `json:"received_at" db:"received_at"`
+	CreatedAt         time.Time          `json:"created_at" db:"created_at"`
+}
+
+// GoodsReceiptItem records the quantity received for one purchase order line
+type GoodsReceiptItem struct {
+	ID                     uuid.UUID       `json:"id" db:"id"`
+	GoodsReceiptID         uuid.UUID       `json:"goods_receipt_id" db:"goods_receipt_id"`
+	PurchaseOrderItemID    uuid.UUID       `json:"purchase_order_item_id" db:"purchase_order_item_id"`
+	ProductID              uuid.UUID       `json:"product_id" db:"product_id"`
+	Quantity               int             `json:"quantity" db:"quantity"`
+	UnitCost               decimal.Decimal `json:"unit_cost" db:"unit_cost"`
+	BatchNumber            *string         `json:"batch_number,omitempty" db:"batch_number"`
+	ExpiryDate             *time.Time      `json:"expiry_date,omitempty" db:"expiry_date"`
+	InventoryTransactionID *uuid.UUID      `json:"inventory_transaction_id,omitempty" db:"inventory_transaction_id"`
+}
+
+// Validate validates the purchase order entity. A purchase order without a
+// number is accepted, since the number is allocated when it is first persisted.
+func (po *PurchaseOrder) Validate() error {
+	var errs []error
+
+	if po.ID == uuid.Nil {
+		errs = append(errs, errors.New("purchase order ID cannot be empty"))
+	}
+
+	if po.SupplierID == uuid.Nil {
+		errs = append(errs, errors.New("supplier ID cannot be empty"))
+	}
+
+	if po.IsDropShip() {
+		if po.WarehouseID != nil {
+			errs = append(errs, errors.New("drop-ship purchase orders cannot have a warehouse"))
+		}
+		if po.ShipTo == nil {
+			errs = append(errs, errors.New("drop-ship purchase orders must have a ship-to address"))
+		} else if err := po.ShipTo.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("invalid ship-to address: %w", err))
+		}
+		for i := range po.Items {
+			if po.Items[i].SalesOrderItemID == nil {
+				errs = append(errs, fmt.Errorf("item %d must reference a sales order line", i+1))
+			}
+		}
+	} else if po.WarehouseID == nil || *po.WarehouseID == uuid.Nil {
+		errs = append(errs, errors.New("warehouse ID cannot be empty"))
+	}
+
+	if _, ok := PurchaseOrderStatusTransitions[po.Status]; !ok {
+		errs = append(errs, fmt.Errorf("invalid status: %s", po.Status))
+	}
+
+	if len(po.Currency) != 3 || strings.ToUpper(po.Currency) != po.Currency {
+		errs = append(errs, errors.New("currency must be a valid 3-letter ISO 4217 code"))
+	}
+
+	if !validPaymentTerms[po.PaymentTerms] {
+		errs = append(errs, fmt.Errorf("invalid payment terms: %s", po.PaymentTerms))
+	}
+
+	if po.ExpectedDate != nil && po.ExpectedDate.Before(po.OrderDate.Truncate(24*time.Hour)) {
+		errs = append(errs, errors.New("expected date cannot be before the order date"))
+	}
+
+	if po.ShippingAmount.LessThan(decimal.Zero) {
+		errs = append(errs, errors.New("shipping amount cannot be negative"))
+	}
+
+	if len(po.Items) == 0 {
+		errs = append(errs, errors.New("purchase order must have at least one item"))
+	}
+	for i := range po.Items {
+		if err := po.Items[i].Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("invalid item %d: %w", i+1, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("validation failed: %v", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// Validate validates the purchase order item entity
+func (item *PurchaseOrderItem) Validate() error {
+	var errs []error
+
+	if item.ProductID == uuid.Nil {
+		errs = append(errs, errors.New("product ID cannot be empty"))
+	}
+
+	if item.QuantityOrdered <= 0 {
+		errs = append(errs, errors.New("ordered quantity must be positive"))
+	}
+
+	if item.QuantityReceived < 0 || item.QuantityReceived > item.QuantityOrdered {
+		errs = append(errs, errors.New("received quantity must be between 0 and the ordered quantity"))
+	}
+
+	if item.UnitCost.LessThan(decimal.Zero) {
+		errs = append(errs, errors.New("unit cost cannot be negative"))
+	}
+
+	if item.TaxRate.LessThan(decimal.Zero) || item.TaxRate.GreaterThan(decimal.NewFromInt(100)) {
+		errs = append(errs, errors.New("tax rate must be between 0 and 100"))
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	return nil
+}
+
+// Validate validates the drop-ship address
+func (a *DropShipAddress) Validate() error {
+	var errs []error
+
+	if strings.TrimSpace(a.Name) == "" {
+		errs = append(errs, errors.New("name cannot be empty"))
+	}
+	if strings.TrimSpace(a.AddressLine1) == "" {
+		errs = append(errs, errors.New("address line 1 cannot be empty"))
+	}
+	if strings.TrimSpace(a.City) == "" {
+		errs = append(errs, errors.New("city cannot be empty"))
+	}
+	if strings.TrimSpace(a.PostalCode) == "" {
+		errs = append(errs, errors.New("postal code cannot be empty"))
+	}
+	if strings.TrimSpace(a.Country) == "" {
+		errs = append(errs, errors.New("country cannot be empty"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// CalculateTotals calculates the tax and total cost of the line
+func (item *PurchaseOrderItem) CalculateTotals() {
+	subtotal := item.UnitCost.Mul(decimal.NewFromInt(int64(item.QuantityOrdered)))
+	item.TaxAmount = subtotal.Mul(item.TaxRate).Div(decimal.NewFromInt(100)).Round(2)
+	item.TotalCost = subtotal.Add(item.TaxAmount).Round(2)
+	item.UpdatedAt = time.Now().UTC()
+}
+
+// OutstandingQuantity returns the quantity still to be received
+func (item *PurchaseOrderItem) OutstandingQuantity() int {
+	return item.QuantityOrdered - item.QuantityReceived
+}
+
+// CalculateTotals calculates the purchase order totals from its lines
+func (po *PurchaseOrder) CalculateTotals() {
+	subtotal := decimal.Zero
+	tax := decimal.Zero
+	for i := range po.Items {
+		po.Items[i].CalculateTotals()
+		subtotal = subtotal.Add(po.Items[i].UnitCost.Mul(decimal.NewFromInt(int64(po.Items[i].QuantityOrdered))))
+		tax = tax.Add(po.Items[i].TaxAmount)
+	}
+
+	po.Subtotal = subtotal.Round(2)
+	po.TaxAmount = tax.Round(2)
+	po.TotalAmount = po.Subtotal.Add(po.TaxAmount).Add(po.ShippingAmount).Round(2)
+	po.UpdatedAt = time.Now().UTC()
+}
+
+// IsDropShip reports whether the supplier ships the purchase order straight
+// to the customer of a sales order
+func (po *PurchaseOrder) IsDropShip() bool {
+	return po.SalesOrderID != nil
+}
+
+// IsEditable reports whether lines and terms of the purchase order may still change
+func (po *PurchaseOrder) IsEditable() bool {
+	return po.Status == PurchaseOrderStatusDraft
+}
+
+// CanReceive reports whether goods may be received against the purchase order
+func (po *PurchaseOrder) CanReceive() bool {
+	return po.Status == PurchaseOrderStatusOrdered || po.Status == PurchaseOrderStatusPartiallyReceived
+}
+
+// IsFullyReceived reports whether every line has been received in full
+func (po *PurchaseOrder) IsFullyReceived() bool {
+	for i := range po.Items {
+		if po.Items[i].OutstandingQuantity() > 0 {
+			return false
+		}
+	}
+	return len(po.Items) > 0
+}
+
+// ChangeStatus moves the purchase order to a new status, stamping the matching date
+func (po *PurchaseOrder) ChangeStatus(newStatus PurchaseOrderStatus) error {
+	valid := false
+	for _, status := range PurchaseOrderStatusTransitions[po.Status] {
+		if status == newStatus {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return fmt.Errorf("invalid purchase order status transition from %s to %s", po.Status, newStatus)
+	}
+
+	now := time.Now().UTC()
+	switch newStatus {
+	case PurchaseOrderStatusOrdered:
+		po.OrderedAt = &now
+	case PurchaseOrderStatusClosed:
+		po.ClosedAt = &now
+	case PurchaseOrderStatusCancelled:
+		po.CancelledAt = &now
+	}
+
+	po.Status = newStatus
+	po.UpdatedAt = now
+	return nil
+}
+
+// ApplyReceipt books the receipt lines against the purchase order lines and
+// moves the purchase order to PARTIALLY_RECEIVED, or CLOSED once every line
+// has been received in full. Receiving more than is outstanding is rejected.
+func (po *PurchaseOrder) ApplyReceipt(receipt *GoodsReceipt) error {
+	if !po.CanReceive() {
+		return fmt.Errorf("cannot receive goods against a %s purchase order", po.Status)
+	}
+	if po.IsDropShip() {
+		return errors.New("drop-ship purchase orders are not received into a warehouse")
+	}
+	if len(receipt.Items) == 0 {
+		return errors.New("goods receipt must have at least one item")
+	}
+
+	received := make(map[uuid.UUID]int, len(receipt.Items))
+	for _, line := range receipt.Items {
+		if line.Quantity <= 0 {
+			return errors.New("received quantity must be positive")
+		}
+		if line.UnitCost.LessThan(decimal.Zero) {
+			return errors.New("unit cost cannot be negative")
+		}
+		received[line.PurchaseOrderItemID] += line.Quantity
+	}
+
+	return po.applyQuantities(received, "receive")
+}
+
+// ConfirmShipment books the quantities a supplier shipped to the customer
+// against the lines of a drop-ship purchase order, keyed by purchase order
+// line. Shipped quantities count as received, so the purchase order closes
+// once every line has shipped in full.
+func (po *PurchaseOrder) ConfirmShipment(quantities map[uuid.UUID]int) error {
+	if !po.IsDropShip() {
+		return errors.New("only drop-ship purchase orders are shipped by the supplier")
+	}
+	if !po.CanReceive() {
+		return fmt.Errorf("cannot confirm a shipment against a %s purchase order", po.Status)
+	}
+	if len(quantities) == 0 {
+		return errors.New("shipment must have at least one item")
+	}
+	for _, quantity := range quantities {
+		if quantity <= 0 {
+			return errors.New("shipped quantity must be positive")
+		}
+	}
+
+	return po.applyQuantities(quantities, "ship")
+}
+
+// applyQuantities adds the quantities to the received quantities of the
+// lines and derives the status. Nothing changes when any line would take
+// more than is outstanding.
+func (po *PurchaseOrder) applyQuantities(received map[uuid.UUID]int, verb string) error {
+	for itemID, quantity := range received {
+		item := po.FindItem(itemID)
+		if item == nil {
+			return fmt.Errorf("purchase order item %s not found", itemID)
+		}
+		if quantity > item.OutstandingQuantity() {
+			return fmt.Errorf("cannot %s %d of %s: only %d outstanding", verb, quantity, item.ProductSKU, item.OutstandingQuantity())
+		}
+	}
+
+	now := time.Now().UTC()
+	for itemID, quantity := range received {
+		item := po.FindItem(itemID)
+		item.QuantityReceived += quantity
+		item.UpdatedAt = now
+	}
+
+	target := PurchaseOrderStatusPartiallyReceived
+	if po.IsFullyReceived() {
+		target = PurchaseOrderStatusClosed
+	}
+	if po.Status == target {
+		po.UpdatedAt = now
+		return nil
+	}
+	return po.ChangeStatus(target)
+}
+
+// FindItem returns the purchase order line with the given ID
+func (po *PurchaseOrder) FindItem(itemID uuid.UUID) *PurchaseOrderItem {
+	for i := range po.Items {
+		if po.Items[i].ID == itemID {
+			return &po.Items[i]
+		}
+	}
+	return nil
+}
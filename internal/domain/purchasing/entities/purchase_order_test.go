@@ -0,0 +1,247 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func generateTestPurchaseOrder(t *testing.T) *PurchaseOrder {
+	id := uuid.New()
+	warehouseID := uuid.New()
+	now := time.Now().UTC()
+
+	return &PurchaseOrder{
+		ID:             id,
+		PONumber:       "PO-2026-000001",
+		SupplierID:     uuid.New(),
+		WarehouseID:    &warehouseID,
+		Status:         PurchaseOrderStatusDraft,
+		OrderDate:      now,
+		Currency:       "USD",
+		PaymentTerms:   "NET30",
+		ShippingAmount: decimal.NewFromFloat(15.00),
+		Items: []PurchaseOrderItem{
+			{
+				ID:              uuid.New(),
+				PurchaseOrderID: id,
+				ProductID:       uuid.New(),
+				ProductSKU:      "SKU-001",
+				ProductName:     "Widget",
+				QuantityOrdered: 10,
+				UnitCost:        decimal.NewFromFloat(4.50),
+				TaxRate:         decimal.NewFromInt(10),
+			},
+			{
+				ID:              uuid.New(),
+				PurchaseOrderID: id,
+				ProductID:       uuid.New(),
+				ProductSKU:      "SKU-002",
+				ProductName:     "Gadget",
+				QuantityOrdered: 5,
+				UnitCost:        decimal.NewFromFloat(20.00),
+			},
+		},
+		CreatedBy: uuid.New(),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func receiptFor(po *PurchaseOrder, quantities ...int) *GoodsReceipt {
+	receipt := &GoodsReceipt{ID: uuid.New(), PurchaseOrderID: po.ID, WarehouseID: *po.WarehouseID}
+	for i, quantity := range quantities {
+		if quantity == 0 {
+			continue
+		}
+		receipt.Items = append(receipt.Items, GoodsReceiptItem{
+			PurchaseOrderItemID: po.Items[i].ID,
+			ProductID:           po.Items[i].ProductID,
+			Quantity:            quantity,
+			UnitCost:            po.Items[i].UnitCost,
+		})
+	}
+	return receipt
+}
+
+func TestPurchaseOrder_Validate(t *testing.T) {
+	po := generateTestPurchaseOrder(t)
+	assert.NoError(t, po.Validate())
+
+	po.PONumber = ""
+	assert.NoError(t, po.Validate(), "unnumbered purchase orders are numbered on insert")
+
+	expected := po.OrderDate.AddDate(0, 0, -2)
+	po.ExpectedDate = &expected
+	po.PaymentTerms = "NET3"
+	po.Items[0].QuantityOrdered = 0
+	err := po.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expected date cannot be before the order date")
+	assert.Contains(t, err.Error(), "invalid payment terms")
+	assert.Contains(t, err.Error(), "ordered quantity must be positive")
+}
+
+func TestPurchaseOrder_CalculateTotals(t *testing.T) {
+	po := generateTestPurchaseOrder(t)
+	po.CalculateTotals()
+
+	assert.True(t, po.Items[0].TaxAmount.Equal(decimal.NewFromFloat(4.50)))
+	assert.True(t, po.Items[0].TotalCost.Equal(decimal.NewFromFloat(49.50)))
+	assert.True(t, po.Subtotal.Equal(decimal.NewFromFloat(145.00)))
+	assert.True(t, po.TaxAmount.Equal(decimal.NewFromFloat(4.50)))
+	assert.True(t, po.TotalAmount.Equal(decimal.NewFromFloat(164.50)))
+}
+
+func TestPurchaseOrder_ApplyReceipt(t *testing.T) {
+	po := generateTestPurchaseOrder(t)
+
+	err := po.ApplyReceipt(receiptFor(po, 1))
+	require.Error(t, err, "draft purchase orders cannot be received")
+
+	require.NoError(t, po.ChangeStatus(PurchaseOrderStatusOrdered))
+	assert.NotNil(t, po.OrderedAt)
+
+	require.NoError(t, po.ApplyReceipt(receiptFor(po, 4)))
+	assert.Equal(t, PurchaseOrderStatusPartiallyReceived, po.Status)
+	assert.Equal(t, 4, po.Items[0].QuantityReceived)
+	assert.Equal(t, 6, po.Items[0].OutstandingQuantity())
+
+	require.NoError(t, po.ApplyReceipt(receiptFor(po, 2, 1)))
+	assert.Equal(t, PurchaseOrderStatusPartiallyReceived, po.Status)
+
+	err = po.ApplyReceipt(receiptFor(po, 5))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only 4 outstanding")
+	assert.Equal(t, 6, po.Items[0].QuantityReceived, "rejected receipts leave lines untouched")
+
+	require.NoError(t, po.ApplyReceipt(receiptFor(po, 4, 4)))
+	assert.Equal(t, PurchaseOrderStatusClosed, po.Status)
+	assert.NotNil(t, po.ClosedAt)
+	assert.True(t, po.IsFullyReceived())
+
+	assert.Error(t, po.ApplyReceipt(receiptFor(po, 1)), "closed purchase orders cannot be received")
+}
+
+func TestPurchaseOrder_ApplyReceipt_UnknownLine(t *testing.T) {
+	po := generateTestPurchaseOrder(t)
+	require.NoError(t, po.ChangeStatus(PurchaseOrderStatusOrdered))
+
+	receipt := receiptFor(po, 1)
+	receipt.Items[0].PurchaseOrderItemID = uuid.New()
+
+	err := po.ApplyReceipt(receipt)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func generateTestDropShipOrder(t *testing.T) *PurchaseOrder {
+	po := generateTestPurchaseOrder(t)
+	salesOrderID := uuid.New()
+	po.WarehouseID = nil
+	po.SalesOrderID = &salesOrderID
+	po.ShipTo = &DropShipAddress{
+		Name:         "Jane Customer",
+		AddressLine1: "1 Main Street",
+		City:         "Springfield",
+		State:        "IL",
+		PostalCode:   "62701",
+		Country:      "US",
+	}
+	for i := range po.Items {
+		lineID := uuid.New()
+		po.Items[i].SalesOrderItemID = &lineID
+	}
+	return po
+}
+
+func TestPurchaseOrder_Validate_DropShip(t *testing.T) {
+	po := generateTestDropShipOrder(t)
+	assert.True(t, po.IsDropShip())
+	assert.NoError(t, po.Validate(), "drop-ship purchase orders have no warehouse")
+
+	po.ShipTo.PostalCode = ""
+	po.Items[1].SalesOrderItemID = nil
+	err := po.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "postal code cannot be empty")
+	assert.Contains(t, err.Error(), "item 2 must reference a sales order line")
+
+	po.ShipTo = nil
+	assert.ErrorContains(t, po.Validate(), "must have a ship-to address")
+
+	stocked := generateTestPurchaseOrder(t)
+	stocked.WarehouseID = nil
+	assert.ErrorContains(t, stocked.Validate(), "warehouse ID cannot be empty")
+}
+
+func TestPurchaseOrder_ConfirmShipment(t *testing.T) {
+	po := generateTestDropShipOrder(t)
+	first, second := po.Items[0].ID, po.Items[1].ID
+
+	assert.Error(t, po.ConfirmShipment(map[uuid.UUID]int{first: 1}), "draft purchase orders cannot ship")
+	require.NoError(t, po.ChangeStatus(PurchaseOrderStatusOrdered))
+
+	receipt := &GoodsReceipt{ID: uuid.New(), PurchaseOrderID: po.ID, WarehouseID: uuid.New(), Items: []GoodsReceiptItem{
+		{PurchaseOrderItemID: first, ProductID: po.Items[0].ProductID, Quantity: 1},
+	}}
+	assert.Error(t, po.ApplyReceipt(receipt), "drop-ship purchase orders are never received")
+
+	require.NoError(t, po.ConfirmShipment(map[uuid.UUID]int{first: 10}))
+	assert.Equal(t, PurchaseOrderStatusPartiallyReceived, po.Status)
+
+	err := po.ConfirmShipment(map[uuid.UUID]int{second: 6})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot ship 6 of SKU-002: only 5 outstanding")
+
+	require.NoError(t, po.ConfirmShipment(map[uuid.UUID]int{second: 5}))
+	assert.Equal(t, PurchaseOrderStatusClosed, po.Status)
+
+	stocked := generateTestPurchaseOrder(t)
+	require.NoError(t, stocked.ChangeStatus(PurchaseOrderStatusOrdered))
+	assert.Error(t, stocked.ConfirmShipment(map[uuid.UUID]int{stocked.Items[0].ID: 1}))
+}
+
+func TestPurchaseOrder_ChangeStatus(t *testing.T) {
+	po := generateTestPurchaseOrder(t)
+
+	assert.Error(t, po.ChangeStatus(PurchaseOrderStatusClosed))
+
+	require.NoError(t, po.ChangeStatus(PurchaseOrderStatusOrdered))
+	require.NoError(t, po.ChangeStatus(PurchaseOrderStatusPartiallyReceived))
+	assert.Error(t, po.ChangeStatus(PurchaseOrderStatusCancelled), "partially received purchase orders cannot be cancelled")
+}
+
+func TestSupplier_Validate(t *testing.T) {
+	email := "orders@acme.example"
+	supplier := &Supplier{
+		ID:           uuid.New(),
+		SupplierCode: "ACME-01",
+		Name:         "Acme Supplies",
+		Currency:     "EUR",
+		PaymentTerms: "NET45",
+		LeadTimeDays: 14,
+		IsActive:     true,
+		Contacts: []SupplierContact{
+			{ID: uuid.New(), Name: "Jo Buyer", Email: &email, IsPrimary: true},
+		},
+	}
+	assert.NoError(t, supplier.Validate())
+	assert.Equal(t, "Jo Buyer", supplier.PrimaryContact().Name)
+
+	orderedAt := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC), supplier.ExpectedDeliveryDate(orderedAt))
+
+	supplier.SupplierCode = "acme 01"
+	supplier.Currency = "eur"
+	supplier.Contacts = append(supplier.Contacts, SupplierContact{ID: uuid.New(), Name: "Sam", IsPrimary: true})
+	err := supplier.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "supplier code must be")
+	assert.Contains(t, err.Error(), "currency must be")
+	assert.Contains(t, err.Error(), "only one primary contact")
+}
@@ -0,0 +1,163 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Supported supplier payment terms
+var validPaymentTerms = map[string]bool{
+	"PREPAID": true,
+	"COD":     true,
+	"NET7":    true,
+	"NET15":   true,
+	"NET30":   true,
+	"NET45":   true,
+	"NET60":   true,
+	"NET90":   true,
+}
+
+var supplierCodeRegex = regexp.MustCompile(`^[A-Z0-9][A-Z0-9\-_]*$`)
+
+// Supplier represents a vendor that purchase orders are placed with
+type Supplier struct {
+	ID           uuid.UUID `json:"id" db:"id"`
+	SupplierCode string    `json:"supplier_code" db:"supplier_code"`
+	Name         string    `json:"name" db:"name"`
+	LegalName    *string   `json:"legal_name,omitempty" db:"legal_name"`
+	TaxID        *string   `json:"tax_id,omitempty" db:"tax_id"`
+	Email        string    `json:"email,omitempty" db:"email"`
+	Phone        string    `json:"phone,omitempty" db:"phone"`
+	Website      *string   `json:"website,omitempty" db:"website"`
+
+	AddressLine1 *string `json:"address_line1,omitempty" db:"address_line1"`
+	AddressLine2 *string `json:"address_line2,omitempty" db:"address_line2"`
+	City         *string `json:"city,omitempty" db:"city"`
+	State        *string `json:"state,omitempty" db:"state"`
+	PostalCode   *string `json:"postal_code,omitempty" db:"postal_code"`
+	Country      *string `json:"country,omitempty" db:"country"`
+
+	// Purchasing terms
+	Currency     string `json:"currency" db:"currency"`
+	PaymentTerms string `json:"payment_terms" db:"payment_terms"`
+	LeadTimeDays int    `json:"lead_time_days" db:"lead_time_days"`
+
+	IsActive  bool      `json:"is_active" db:"is_active"`
+	Notes     *string   `json:"notes,omitempty" db:"notes"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+
+	Contacts []SupplierContact `json:"contacts,omitempty" db:"-"`
+}
+
+// SupplierContact represents a contact person at a supplier
+type SupplierContact struct {
+	ID         uuid.UUID `json:"id" db:"id"`
+	SupplierID uuid.UUID `json:"supplier_id" db:"supplier_id"`
+	Name       string    `json:"name" db:"name"`
+	Role       *string   `json:"role,omitempty" db:"role"`
+	Email      *string   `json:"email,omitempty" db:"email"`
+	Phone      *string   `json:"phone,omitempty" db:"phone"`
+	IsPrimary  bool      `json:"is_primary" db:"is_primary"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Validate validates the supplier entity
+func (s *Supplier) Validate() error {
+	var errs []error
+
+	if s.ID == uuid.Nil {
+		errs = append(errs, errors.New("supplier ID cannot be empty"))
+	}
+
+	code := strings.TrimSpace(s.SupplierCode)
+	if code == "" {
+		errs = append(errs, errors.New("supplier code is required"))
+	} else if len(code) > 50 || !supplierCodeRegex.MatchString(code) {
+		errs = append(errs, errors.New("supplier code must be up to 50 uppercase letters, digits, hyphens or underscores"))
+	}
+
+	if strings.TrimSpace(s.Name) == "" {
+		errs = append(errs, errors.New("supplier name is required"))
+	} else if len(s.Name) > 200 {
+		errs = append(errs, errors.New("supplier name cannot exceed 200 characters"))
+	}
+
+	if s.Email != "" {
+		if _, err := mail.ParseAddress(s.Email); err != nil {
+			errs = append(errs, fmt.Errorf("invalid email: %s", s.Email))
+		}
+	}
+
+	if len(s.Currency) != 3 || strings.ToUpper(s.Currency) != s.Currency {
+		errs = append(errs, errors.New("currency must be a valid 3-letter ISO 4217 code"))
+	}
+
+	if !validPaymentTerms[s.PaymentTerms] {
+		errs = append(errs, fmt.Errorf("invalid payment terms: %s", s.PaymentTerms))
+	}
+
+	if s.LeadTimeDays < 0 || s.LeadTimeDays > 365 {
+		errs = append(errs, errors.New("lead time must be between 0 and 365 days"))
+	}
+
+	primaries := 0
+	for i := range s.Contacts {
+		if err := s.Contacts[i].Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("invalid contact %d: %w", i+1, err))
+		}
+		if s.Contacts[i].IsPrimary {
+			primaries++
+		}
+	}
+	if primaries > 1 {
+		errs = append(errs, errors.New("supplier can have only one primary contact"))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("validation failed: %v", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// Validate validates the supplier contact entity
+func (c *SupplierContact) Validate() error {
+	if strings.TrimSpace(c.Name) == "" {
+		return errors.New("contact name is required")
+	}
+	if len(c.Name) > 200 {
+		return errors.New("contact name cannot exceed 200 characters")
+	}
+	if c.Email != nil && *c.Email != "" {
+		if _, err := mail.ParseAddress(*c.Email); err != nil {
+			return fmt.Errorf("invalid contact email: %s", *c.Email)
+		}
+	}
+	return nil
+}
+
+// PrimaryContact returns the primary contact, or the first contact when none is marked primary
+func (s *Supplier) PrimaryContact() *SupplierContact {
+	for i := range s.Contacts {
+		if s.Contacts[i].IsPrimary {
+			return &s.Contacts[i]
+		}
+	}
+	if len(s.Contacts) > 0 {
+		return &s.Contacts[0]
+	}
+	return nil
+}
+
+// ExpectedDeliveryDate returns the delivery date implied by the supplier's lead time
+func (s *Supplier) ExpectedDeliveryDate(orderedAt time.Time) time.Time {
+	return orderedAt.AddDate(0, 0, s.LeadTimeDays)
+}
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+
+	"erpgo/internal/domain/purchasing/entities"
+	"github.com/google/uuid"
+)
+
+// GoodsReceiptRepository defines the interface for goods receipt data operations
+type GoodsReceiptRepository interface {
+	// Create persists a goods receipt with its lines. Receipts without a number
+	// are numbered from the GOODS_RECEIPT document sequence.
+	Create(ctx context.Context, receipt *entities.GoodsReceipt) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.GoodsReceipt, error)
+	GetByPurchaseOrderID(ctx context.Context, purchaseOrderID uuid.UUID) ([]*entities.GoodsReceipt, error)
+}
@@ -0,0 +1,45 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"erpgo/internal/domain/purchasing/entities"
+	"github.com/google/uuid"
+)
+
+// PurchaseOrderRepository defines the interface for purchase order data operations
+type PurchaseOrderRepository interface {
+	// Create persists a purchase order with its lines. Purchase orders without
+	// a number are numbered from the PURCHASE_ORDER document sequence.
+	Create(ctx context.Context, po *entities.PurchaseOrder) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.PurchaseOrder, error)
+	GetByNumber(ctx context.Context, poNumber string) (*entities.PurchaseOrder, error)
+	// GetBySalesOrderID retrieves the drop-ship purchase orders of a sales
+	// order with their lines, oldest first
+	GetBySalesOrderID(ctx context.Context, salesOrderID uuid.UUID) ([]*entities.PurchaseOrder, error)
+	// Update persists the purchase order header and replaces its lines
+	Update(ctx context.Context, po *entities.PurchaseOrder) error
+	// Lock locks the purchase order's row until the transaction of the
+	// context ends, so concurrent changes to the purchase order wait for it
+	Lock(ctx context.Context, id uuid.UUID) error
+	List(ctx context.Context, filter PurchaseOrderFilter) ([]*entities.PurchaseOrder, error)
+	Count(ctx context.Context, filter PurchaseOrderFilter) (int, error)
+	HasOpenOrdersForSupplier(ctx context.Context, supplierID uuid.UUID) (bool, error)
+}
+
+// PurchaseOrderFilter defines filter criteria for purchase order queries
+type PurchaseOrderFilter struct {
+	Search      string                         `json:"search,omitempty"`
+	Status      []entities.PurchaseOrderStatus `json:"status,omitempty"`
+	SupplierID  *uuid.UUID                     `json:"supplier_id,omitempty"`
+	WarehouseID *uuid.UUID                     `json:"warehouse_id,omitempty"`
+	// SalesOrderID restricts results to the drop-ship purchase orders of a sales order
+	SalesOrderID *uuid.UUID `json:"sales_order_id,omitempty"`
+	// ExpectedBefore restricts results to open purchase orders due before the given time
+	ExpectedBefore *time.Time `json:"expected_before,omitempty"`
+
+	Page   int `json:"page"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset,omitempty"`
+}
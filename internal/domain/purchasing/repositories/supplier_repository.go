@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"context"
+
+	"erpgo/internal/domain/purchasing/entities"
+	"github.com/google/uuid"
+)
+
+// SupplierRepository defines the interface for supplier data operations
+type SupplierRepository interface {
+	// Create persists a supplier with its contacts
+	Create(ctx context.Context, supplier *entities.Supplier) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Supplier, error)
+	GetByCode(ctx context.Context, code string) (*entities.Supplier, error)
+	// Update persists the supplier and replaces its contacts
+	Update(ctx context.Context, supplier *entities.Supplier) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	List(ctx context.Context, filter SupplierFilter) ([]*entities.Supplier, error)
+	Count(ctx context.Context, filter SupplierFilter) (int, error)
+	ExistsByCode(ctx context.Context, code string) (bool, error)
+}
+
+// SupplierFilter defines filter criteria for supplier queries
+type SupplierFilter struct {
+	Search   string `json:"search,omitempty"`
+	IsActive *bool  `json:"is_active,omitempty"`
+	Currency string `json:"currency,omitempty"`
+
+	Page   int `json:"page"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset,omitempty"`
+}
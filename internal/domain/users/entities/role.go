@@ -96,10 +96,12 @@ const (
 	PermissionProductDelete = "products.delete"
 
 	// Order permissions
-	PermissionOrderCreate = "orders.create"
-	PermissionOrderRead   = "orders.read"
-	PermissionOrderUpdate = "orders.update"
-	PermissionOrderDelete = "orders.delete"
+	PermissionOrderCreate           = "orders.create"
+	PermissionOrderRead             = "orders.read"
+	PermissionOrderUpdate           = "orders.update"
+	PermissionOrderDelete           = "orders.delete"
+	PermissionOrderCreditOverride   = "orders.credit_override"
+	PermissionOrderApprovalPolicies = "orders.approval_policies"
 
 	// Inventory permissions
 	PermissionInventoryCreate = "inventory.create"
@@ -107,6 +109,12 @@ const (
 	PermissionInventoryUpdate = "inventory.update"
 	PermissionInventoryDelete = "inventory.delete"
 
+	// Purchasing permissions
+	PermissionPurchaseCreate = "purchasing.create"
+	PermissionPurchaseRead   = "purchasing.read"
+	PermissionPurchaseUpdate = "purchasing.update"
+	PermissionPurchaseDelete = "purchasing.delete"
+
 	// System permissions
 	PermissionSystemAdmin = "system.admin"
 	PermissionSystemRead  = "system.read"
@@ -143,8 +151,9 @@ func DefaultRoles() []Role {
 				PermissionUserCreate, PermissionUserRead, PermissionUserUpdate, PermissionUserDelete,
 				PermissionRoleCreate, PermissionRoleRead, PermissionRoleUpdate, PermissionRoleDelete,
 				PermissionProductCreate, PermissionProductRead, PermissionProductUpdate, PermissionProductDelete,
-				PermissionOrderCreate, PermissionOrderRead, PermissionOrderUpdate, PermissionOrderDelete,
+				PermissionOrderCreate, PermissionOrderRead, PermissionOrderUpdate, PermissionOrderDelete, PermissionOrderCreditOverride, PermissionOrderApprovalPolicies,
 				PermissionInventoryCreate, PermissionInventoryRead, PermissionInventoryUpdate, PermissionInventoryDelete,
+				PermissionPurchaseCreate, PermissionPurchaseRead, PermissionPurchaseUpdate, PermissionPurchaseDelete,
 				PermissionSystemAdmin, PermissionSystemRead,
 				PermissionCourseCreate, PermissionCourseRead, PermissionCourseUpdate, PermissionCourseDelete,
 				PermissionAssignmentCreate, PermissionAssignmentRead, PermissionAssignmentUpdate, PermissionAssignmentDelete,
@@ -191,10 +200,19 @@ func DefaultRoles() []Role {
 				PermissionProductRead, PermissionProductUpdate,
 				PermissionOrderCreate, PermissionOrderRead, PermissionOrderUpdate,
 				PermissionInventoryRead, PermissionInventoryUpdate,
+				PermissionPurchaseCreate, PermissionPurchaseRead, PermissionPurchaseUpdate,
 				PermissionUserRead,
 				PermissionProfileRead, PermissionProfileUpdate,
 			},
 		},
+		{
+			Name:        "credit_manager",
+			Description: "Credit manager who releases orders held over customer credit limits",
+			Permissions: []string{
+				PermissionOrderRead, PermissionOrderUpdate, PermissionOrderCreditOverride,
+				PermissionProfileRead, PermissionProfileUpdate,
+			},
+		},
 		{
 			Name:        "employee",
 			Description: "Employee with basic operational access",
@@ -202,6 +220,7 @@ func DefaultRoles() []Role {
 				PermissionProductRead,
 				PermissionOrderRead,
 				PermissionInventoryRead,
+				PermissionPurchaseRead,
 				PermissionProfileRead, PermissionProfileUpdate,
 			},
 		},
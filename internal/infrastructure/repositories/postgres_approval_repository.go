@@ -0,0 +1,374 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
+	"erpgo/pkg/database"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// PostgresApprovalRepository implements ApprovalRepository for PostgreSQL
+type PostgresApprovalRepository struct {
+	db *database.Database
+}
+
+// NewPostgresApprovalRepository creates a new PostgreSQL approval repository
+func NewPostgresApprovalRepository(db *database.Database) *PostgresApprovalRepository {
+	return &PostgresApprovalRepository{
+		db: db,
+	}
+}
+
+const approvalPolicyColumns = `
+	id, name, description, min_amount, customer_types, min_discount_percent,
+	max_margin_percent, approver_roles, priority, is_active, created_by,
+	created_at, updated_at
+`
+
+const orderApprovalColumns = `
+	id, order_id, policy_id, policy_name, sequence, role, status, amount,
+	decided_by, decided_at, comment, superseded_at, created_at
+`
+
+// CreatePolicy creates a new approval policy
+func (r *PostgresApprovalRepository) CreatePolicy(ctx context.Context, policy *entities.ApprovalPolicy) error {
+	query := `INSERT INTO approval_policies (` + approvalPolicyColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+
+	_, err := r.db.Exec(ctx, query,
+		policy.ID,
+		policy.Name,
+		policy.Description,
+		policy.MinAmount,
+		stringArray(policy.CustomerTypes),
+		policy.MinDiscountPercent,
+		policy.MaxMarginPercent,
+		stringArray(policy.ApproverRoles),
+		policy.Priority,
+		policy.IsActive,
+		policy.CreatedBy,
+		policy.CreatedAt,
+		policy.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create approval policy: %w", err)
+	}
+
+	return nil
+}
+
+// GetPolicyByID retrieves an approval policy by ID
+func (r *PostgresApprovalRepository) GetPolicyByID(ctx context.Context, id uuid.UUID) (*entities.ApprovalPolicy, error) {
+	query := `SELECT ` + approvalPolicyColumns + ` FROM approval_policies WHERE id = $1`
+
+	policy, err := scanApprovalPolicy(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("approval policy with id %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get approval policy: %w", err)
+	}
+
+	return policy, nil
+}
+
+// UpdatePolicy updates an approval policy
+func (r *PostgresApprovalRepository) UpdatePolicy(ctx context.Context, policy *entities.ApprovalPolicy) error {
+	query := `
+		UPDATE approval_policies SET
+			name = $2, description = $3, min_amount = $4, customer_types = $5,
+			min_discount_percent = $6, max_margin_percent = $7, approver_roles = $8,
+			priority = $9, is_active = $10, updated_at = $11
+		WHERE id = $1
+	`
+
+	result, err := r.db.Exec(ctx, query,
+		policy.ID,
+		policy.Name,
+		policy.Description,
+		policy.MinAmount,
+		stringArray(policy.CustomerTypes),
+		policy.MinDiscountPercent,
+		policy.MaxMarginPercent,
+		stringArray(policy.ApproverRoles),
+		policy.Priority,
+		policy.IsActive,
+		policy.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update approval policy: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("approval policy with id %s not found", policy.ID)
+	}
+
+	return nil
+}
+
+// ListPolicies retrieves approval policies matching the filter, highest priority first
+func (r *PostgresApprovalRepository) ListPolicies(ctx context.Context, filter repositories.ApprovalPolicyFilter) ([]*entities.ApprovalPolicy, error) {
+	where, args := buildApprovalPolicyConditions(filter)
+	query := `SELECT ` + approvalPolicyColumns + ` FROM approval_policies` + where + ` ORDER BY priority DESC, name`
+
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", len(args)+1)
+		args = append(args, filter.Limit)
+
+		offset := filter.Offset
+		if offset == 0 && filter.Page > 1 {
+			offset = (filter.Page - 1) * filter.Limit
+		}
+		if offset > 0 {
+			query += fmt.Sprintf(" OFFSET $%d", len(args)+1)
+			args = append(args, offset)
+		}
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query approval policies: %w", err)
+	}
+	defer rows.Close()
+
+	var policies []*entities.ApprovalPolicy
+	for rows.Next() {
+		policy, err := scanApprovalPolicy(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan approval policy row: %w", err)
+		}
+		policies = append(policies, policy)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating approval policy rows: %w", err)
+	}
+
+	return policies, nil
+}
+
+// CountPolicies returns the number of approval policies matching the filter
+func (r *PostgresApprovalRepository) CountPolicies(ctx context.Context, filter repositories.ApprovalPolicyFilter) (int, error) {
+	where, args := buildApprovalPolicyConditions(filter)
+
+	var count int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM approval_policies`+where, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count approval policies: %w", err)
+	}
+
+	return count, nil
+}
+
+// CreateApprovals creates the steps of an approval chain in one transaction
+func (r *PostgresApprovalRepository) CreateApprovals(ctx context.Context, approvals []*entities.OrderApproval) error {
+	if len(approvals) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `INSERT INTO order_approvals (` + orderApprovalColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+	for _, approval := range approvals {
+		_, err := tx.Exec(ctx, query,
+			approval.ID,
+			approval.OrderID,
+			approval.PolicyID,
+			approval.PolicyName,
+			approval.Sequence,
+			approval.Role,
+			approval.Status,
+			approval.Amount,
+			approval.DecidedBy,
+			approval.DecidedAt,
+			approval.Comment,
+			approval.SupersededAt,
+			approval.CreatedAt,
+		)
+		if err != nil {
+			if strings.Contains(err.Error(), "uq_order_approvals_current") {
+				return fmt.Errorf("order %s already has approval step %d", approval.OrderID, approval.Sequence)
+			}
+			return fmt.Errorf("failed to create order approval: %w", err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateApproval updates the decision on an approval step or its replacement
+func (r *PostgresApprovalRepository) UpdateApproval(ctx context.Context, approval *entities.OrderApproval) error {
+	query := `
+		UPDATE order_approvals SET
+			status = $2, decided_by = $3, decided_at = $4, comment = $5, superseded_at = $6
+		WHERE id = $1
+	`
+
+	result, err := r.db.Exec(ctx, query,
+		approval.ID,
+		approval.Status,
+		approval.DecidedBy,
+		approval.DecidedAt,
+		approval.Comment,
+		approval.SupersededAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update order approval: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("order approval with id %s not found", approval.ID)
+	}
+
+	return nil
+}
+
+// GetApprovalsByOrderID retrieves every approval step of an order in the
+// order they were requested
+func (r *PostgresApprovalRepository) GetApprovalsByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.OrderApproval, error) {
+	query := `SELECT ` + orderApprovalColumns + ` FROM order_approvals WHERE order_id = $1 ORDER BY created_at, sequence`
+
+	return r.queryApprovals(ctx, query, orderID)
+}
+
+// ListPendingApprovals retrieves the steps awaiting one of the roles whose
+// earlier steps are all approved, on orders still pending, oldest first
+func (r *PostgresApprovalRepository) ListPendingApprovals(ctx context.Context, filter repositories.PendingApprovalFilter) ([]*entities.OrderApproval, error) {
+	query := `
+		SELECT oa.id, oa.order_id, oa.policy_id, oa.policy_name, oa.sequence, oa.role,
+			oa.status, oa.amount, oa.decided_by, oa.decided_at, oa.comment,
+			oa.superseded_at, oa.created_at
+	` + pendingApprovalsFrom + ` ORDER BY oa.created_at, oa.order_id`
+	args := []interface{}{stringArray(filter.Roles)}
+
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", len(args)+1)
+		args = append(args, filter.Limit)
+
+		offset := filter.Offset
+		if offset == 0 && filter.Page > 1 {
+			offset = (filter.Page - 1) * filter.Limit
+		}
+		if offset > 0 {
+			query += fmt.Sprintf(" OFFSET $%d", len(args)+1)
+			args = append(args, offset)
+		}
+	}
+
+	return r.queryApprovals(ctx, query, args...)
+}
+
+// CountPendingApprovals returns the number of steps awaiting one of the roles
+func (r *PostgresApprovalRepository) CountPendingApprovals(ctx context.Context, filter repositories.PendingApprovalFilter) (int, error) {
+	var count int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*)`+pendingApprovalsFrom, stringArray(filter.Roles)).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count pending approvals: %w", err)
+	}
+
+	return count, nil
+}
+
+// pendingApprovalsFrom selects the current pending steps for the roles in $1
+// that are next in their chain
+const pendingApprovalsFrom = `
+	FROM order_approvals oa
+	JOIN orders o ON o.id = oa.order_id
+	WHERE oa.status = 'PENDING' AND oa.superseded_at IS NULL
+	  AND oa.role = ANY($1) AND o.status = 'PENDING'
+	  AND NOT EXISTS (
+		SELECT 1 FROM order_approvals prev
+		WHERE prev.order_id = oa.order_id AND prev.superseded_at IS NULL
+		  AND prev.sequence < oa.sequence AND prev.status <> 'APPROVED'
+	  )
+`
+
+func (r *PostgresApprovalRepository) queryApprovals(ctx context.Context, query string, args ...interface{}) ([]*entities.OrderApproval, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query order approvals: %w", err)
+	}
+	defer rows.Close()
+
+	var approvals []*entities.OrderApproval
+	for rows.Next() {
+		approval := &entities.OrderApproval{}
+		err := rows.Scan(
+			&approval.ID,
+			&approval.OrderID,
+			&approval.PolicyID,
+			&approval.PolicyName,
+			&approval.Sequence,
+			&approval.Role,
+			&approval.Status,
+			&approval.Amount,
+			&approval.DecidedBy,
+			&approval.DecidedAt,
+			&approval.Comment,
+			&approval.SupersededAt,
+			&approval.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan order approval: %w", err)
+		}
+		approvals = append(approvals, approval)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating order approvals: %w", err)
+	}
+
+	return approvals, nil
+}
+
+func buildApprovalPolicyConditions(filter repositories.ApprovalPolicyFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Search != "" {
+		args = append(args, "%"+filter.Search+"%")
+		conditions = append(conditions, fmt.Sprintf("name ILIKE $%d", len(args)))
+	}
+
+	if filter.IsActive != nil {
+		args = append(args, *filter.IsActive)
+		conditions = append(conditions, fmt.Sprintf("is_active = $%d", len(args)))
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func scanApprovalPolicy(row pgx.Row) (*entities.ApprovalPolicy, error) {
+	policy := &entities.ApprovalPolicy{}
+	err := row.Scan(
+		&policy.ID,
+		&policy.Name,
+		&policy.Description,
+		&policy.MinAmount,
+		&policy.CustomerTypes,
+		&policy.MinDiscountPercent,
+		&policy.MaxMarginPercent,
+		&policy.ApproverRoles,
+		&policy.Priority,
+		&policy.IsActive,
+		&policy.CreatedBy,
+		&policy.CreatedAt,
+		&policy.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
@@ -0,0 +1,231 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
+	"erpgo/pkg/database"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// PostgresBackorderRepository implements BackorderRepository for PostgreSQL
+type PostgresBackorderRepository struct {
+	db *database.Database
+}
+
+// NewPostgresBackorderRepository creates a new PostgreSQL backorder repository
+func NewPostgresBackorderRepository(db *database.Database) *PostgresBackorderRepository {
+	return &PostgresBackorderRepository{
+		db: db,
+	}
+}
+
+const backorderColumns = `
+	b.id, b.order_id, b.order_item_id, b.product_id, b.product_sku, b.quantity,
+	b.quantity_allocated, b.status, o.order_number, o.priority, o.order_date,
+	b.created_at, b.updated_at, b.allocated_at, b.cancelled_at
+`
+
+const backorderFrom = ` FROM backorders b INNER JOIN orders o ON o.id = b.order_id`
+
+// Create creates a new backorder
+func (r *PostgresBackorderRepository) Create(ctx context.Context, backorder *entities.Backorder) error {
+	query := `
+		INSERT INTO backorders (
+			id, order_id, order_item_id, product_id, product_sku, quantity,
+			quantity_allocated, status, created_at, updated_at, allocated_at, cancelled_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+		)
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		backorder.ID,
+		backorder.OrderID,
+		backorder.OrderItemID,
+		backorder.ProductID,
+		backorder.ProductSKU,
+		backorder.Quantity,
+		backorder.QuantityAllocated,
+		backorder.Status,
+		backorder.CreatedAt,
+		backorder.UpdatedAt,
+		backorder.AllocatedAt,
+		backorder.CancelledAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create backorder: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a backorder by ID
+func (r *PostgresBackorderRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Backorder, error) {
+	query := `SELECT ` + backorderColumns + backorderFrom + ` WHERE b.id = $1`
+
+	backorder, err := scanBackorder(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("backorder with id %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get backorder: %w", err)
+	}
+
+	return backorder, nil
+}
+
+// GetByOrderID retrieves the backorders of an order, oldest first
+func (r *PostgresBackorderRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.Backorder, error) {
+	query := `SELECT ` + backorderColumns + backorderFrom + ` WHERE b.order_id = $1 ORDER BY b.created_at`
+	return r.query(ctx, query, orderID)
+}
+
+// GetOpenByProduct retrieves the open and partially allocated backorders of a product
+func (r *PostgresBackorderRepository) GetOpenByProduct(ctx context.Context, productID uuid.UUID) ([]*entities.Backorder, error) {
+	query := `SELECT ` + backorderColumns + backorderFrom + `
+		WHERE b.product_id = $1 AND b.status IN ('OPEN', 'PARTIALLY_ALLOCATED')
+		ORDER BY b.created_at`
+	return r.query(ctx, query, productID)
+}
+
+// Update updates the allocation state of a backorder
+func (r *PostgresBackorderRepository) Update(ctx context.Context, backorder *entities.Backorder) error {
+	query := `
+		UPDATE backorders SET
+			quantity_allocated = $2, status = $3, updated_at = $4, allocated_at = $5, cancelled_at = $6
+		WHERE id = $1
+	`
+
+	result, err := r.db.Exec(ctx, query,
+		backorder.ID,
+		backorder.QuantityAllocated,
+		backorder.Status,
+		backorder.UpdatedAt,
+		backorder.AllocatedAt,
+		backorder.CancelledAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update backorder: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("backorder with id %s not found", backorder.ID)
+	}
+
+	return nil
+}
+
+// List retrieves backorders matching the filter, newest first
+func (r *PostgresBackorderRepository) List(ctx context.Context, filter repositories.BackorderFilter) ([]*entities.Backorder, error) {
+	where, args := buildBackorderConditions(filter)
+	query := `SELECT ` + backorderColumns + backorderFrom + where + ` ORDER BY b.created_at DESC`
+
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", len(args)+1)
+		args = append(args, filter.Limit)
+
+		offset := filter.Offset
+		if offset == 0 && filter.Page > 1 {
+			offset = (filter.Page - 1) * filter.Limit
+		}
+		if offset > 0 {
+			query += fmt.Sprintf(" OFFSET $%d", len(args)+1)
+			args = append(args, offset)
+		}
+	}
+
+	return r.query(ctx, query, args...)
+}
+
+// Count returns the number of backorders matching the filter
+func (r *PostgresBackorderRepository) Count(ctx context.Context, filter repositories.BackorderFilter) (int, error) {
+	where, args := buildBackorderConditions(filter)
+
+	var count int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM backorders b`+where, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count backorders: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *PostgresBackorderRepository) query(ctx context.Context, query string, args ...interface{}) ([]*entities.Backorder, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query backorders: %w", err)
+	}
+	defer rows.Close()
+
+	var backorders []*entities.Backorder
+	for rows.Next() {
+		backorder, err := scanBackorder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan backorder row: %w", err)
+		}
+		backorders = append(backorders, backorder)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating backorder rows: %w", err)
+	}
+
+	return backorders, nil
+}
+
+func buildBackorderConditions(filter repositories.BackorderFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if len(filter.Status) > 0 {
+		placeholders := make([]string, len(filter.Status))
+		for i, status := range filter.Status {
+			args = append(args, status)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		conditions = append(conditions, "b.status IN ("+strings.Join(placeholders, ", ")+")")
+	}
+
+	if filter.ProductID != nil {
+		args = append(args, *filter.ProductID)
+		conditions = append(conditions, fmt.Sprintf("b.product_id = $%d", len(args)))
+	}
+
+	if filter.OrderID != nil {
+		args = append(args, *filter.OrderID)
+		conditions = append(conditions, fmt.Sprintf("b.order_id = $%d", len(args)))
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func scanBackorder(row pgx.Row) (*entities.Backorder, error) {
+	backorder := &entities.Backorder{}
+	err := row.Scan(
+		&backorder.ID,
+		&backorder.OrderID,
+		&backorder.OrderItemID,
+		&backorder.ProductID,
+		&backorder.ProductSKU,
+		&backorder.Quantity,
+		&backorder.QuantityAllocated,
+		&backorder.Status,
+		&backorder.OrderNumber,
+		&backorder.Priority,
+		&backorder.OrderDate,
+		&backorder.CreatedAt,
+		&backorder.UpdatedAt,
+		&backorder.AllocatedAt,
+		&backorder.CancelledAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return backorder, nil
+}
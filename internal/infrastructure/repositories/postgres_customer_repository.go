@@ -118,6 +118,19 @@ func (r *PostgresCustomerRepository) GetByID(ctx context.Context, id uuid.UUID)
 	return customer, nil
 }
 
+// Lock locks the customer's row until the transaction of the context ends
+func (r *PostgresCustomerRepository) Lock(ctx context.Context, id uuid.UUID) error {
+	var locked uuid.UUID
+	err := r.db.QueryRow(ctx, `SELECT id FROM customers WHERE id = $1 FOR UPDATE`, id).Scan(&locked)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("customer with id %s not found", id)
+		}
+		return fmt.Errorf("failed to lock customer: %w", err)
+	}
+	return nil
+}
+
 // GetByCustomerCode retrieves a customer by customer code
 func (r *PostgresCustomerRepository) GetByCustomerCode(ctx context.Context, customerCode string) (*entities.Customer, error) {
 	query := `
@@ -255,8 +268,8 @@ func (r *PostgresCustomerRepository) GetCustomerOrders(ctx context.Context, cust
 		SELECT
 			id, order_number, customer_id, status, previous_status, priority,
 			type, payment_status, shipping_method, subtotal, tax_amount,
-			shipping_amount, discount_amount, total_amount, paid_amount,
-			refunded_amount, currency, order_date, required_date,
+			shipping_amount, discount_amount, promotion_discount_amount, total_amount,
+			paid_amount, refunded_amount, currency, exchange_rate, credit_hold, order_date, required_date,
 			shipping_date, delivery_date, cancelled_date, shipping_address_id,
 			billing_address_id, notes, internal_notes, customer_notes,
 			tracking_number, carrier, created_by, approved_by, shipped_by,
@@ -294,10 +307,13 @@ func (r *PostgresCustomerRepository) GetCustomerOrders(ctx context.Context, cust
 			&order.TaxAmount,
 			&order.ShippingAmount,
 			&order.DiscountAmount,
+			&order.PromotionDiscountAmount,
 			&order.TotalAmount,
 			&order.PaidAmount,
 			&order.RefundedAmount,
 			&order.Currency,
+			&order.ExchangeRate,
+			&order.CreditHold,
 			&order.OrderDate,
 			&order.RequiredDate,
 			&order.ShippingDate,
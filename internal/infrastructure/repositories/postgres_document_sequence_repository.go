@@ -0,0 +1,185 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/pkg/database"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// rowQuerier is satisfied by both the connection pool and an open transaction
+type rowQuerier interface {
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// PostgresDocumentSequenceRepository implements DocumentSequenceRepository for PostgreSQL
+type PostgresDocumentSequenceRepository struct {
+	db *database.Database
+}
+
+// NewPostgresDocumentSequenceRepository creates a new PostgreSQL document sequence repository
+func NewPostgresDocumentSequenceRepository(db *database.Database) *PostgresDocumentSequenceRepository {
+	return &PostgresDocumentSequenceRepository{
+		db: db,
+	}
+}
+
+const documentSequenceColumns = `
+	id, document_type, prefix, pattern, padding, reset_period, is_active, created_at, updated_at
+`
+
+// Create creates a new document sequence
+func (r *PostgresDocumentSequenceRepository) Create(ctx context.Context, sequence *entities.DocumentSequence) error {
+	if sequence.ID == uuid.Nil {
+		sequence.ID = uuid.New()
+	}
+
+	query := `
+		INSERT INTO document_sequences (
+			id, document_type, prefix, pattern, padding, reset_period, is_active, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
+		RETURNING created_at, updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		sequence.ID,
+		sequence.DocumentType,
+		sequence.Prefix,
+		sequence.Pattern,
+		sequence.Padding,
+		sequence.ResetPeriod,
+		sequence.IsActive,
+	).Scan(&sequence.CreatedAt, &sequence.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create document sequence: %w", err)
+	}
+
+	return nil
+}
+
+// GetByDocumentType retrieves the sequence configured for a document type
+func (r *PostgresDocumentSequenceRepository) GetByDocumentType(ctx context.Context, documentType entities.DocumentType) (*entities.DocumentSequence, error) {
+	query := `SELECT ` + documentSequenceColumns + ` FROM document_sequences WHERE document_type = $1`
+
+	sequence, err := scanDocumentSequence(r.db.QueryRow(ctx, query, documentType))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("document sequence %s not found", documentType)
+		}
+		return nil, fmt.Errorf("failed to get document sequence: %w", err)
+	}
+
+	return sequence, nil
+}
+
+// Update updates a document sequence configuration. Counters are not touched,
+// so changing the pattern never reissues numbers already allocated.
+func (r *PostgresDocumentSequenceRepository) Update(ctx context.Context, sequence *entities.DocumentSequence) error {
+	query := `
+		UPDATE document_sequences SET
+			prefix = $2, pattern = $3, padding = $4, reset_period = $5, is_active = $6, updated_at = NOW()
+		WHERE document_type = $1
+		RETURNING updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		sequence.DocumentType,
+		sequence.Prefix,
+		sequence.Pattern,
+		sequence.Padding,
+		sequence.ResetPeriod,
+		sequence.IsActive,
+	).Scan(&sequence.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("document sequence %s not found", sequence.DocumentType)
+		}
+		return fmt.Errorf("failed to update document sequence: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves all document sequences
+func (r *PostgresDocumentSequenceRepository) List(ctx context.Context) ([]*entities.DocumentSequence, error) {
+	query := `SELECT ` + documentSequenceColumns + ` FROM document_sequences ORDER BY document_type`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list document sequences: %w", err)
+	}
+	defer rows.Close()
+
+	var sequences []*entities.DocumentSequence
+	for rows.Next() {
+		sequence, err := scanDocumentSequence(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan document sequence: %w", err)
+		}
+		sequences = append(sequences, sequence)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating document sequences: %w", err)
+	}
+
+	return sequences, nil
+}
+
+// allocateDocumentNumber increments the counter of a document sequence and
+// formats the result. It only runs in the transaction inserting the document
+// the number is for. The upsert holds a row lock on the counter until q's
+// transaction ends, so concurrent callers are serialized and a rolled back
+// allocation is returned to the sequence, keeping numbers gapless.
+func allocateDocumentNumber(ctx context.Context, q rowQuerier, documentType entities.DocumentType, at time.Time) (string, error) {
+	query := `SELECT ` + documentSequenceColumns + ` FROM document_sequences WHERE document_type = $1`
+
+	sequence, err := scanDocumentSequence(q.QueryRow(ctx, query, documentType))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return "", fmt.Errorf("document sequence %s not found", documentType)
+		}
+		return "", fmt.Errorf("failed to get document sequence: %w", err)
+	}
+	if !sequence.IsActive {
+		return "", fmt.Errorf("document sequence %s is inactive", documentType)
+	}
+
+	counterQuery := `
+		INSERT INTO document_sequence_counters (document_type, period_key, last_value, updated_at)
+		VALUES ($1, $2, 1, NOW())
+		ON CONFLICT (document_type, period_key)
+		DO UPDATE SET last_value = document_sequence_counters.last_value + 1, updated_at = NOW()
+		RETURNING last_value
+	`
+
+	var value int64
+	if err := q.QueryRow(ctx, counterQuery, documentType, sequence.PeriodKey(at)).Scan(&value); err != nil {
+		return "", fmt.Errorf("failed to allocate document number: %w", err)
+	}
+
+	return sequence.Format(value, at), nil
+}
+
+func scanDocumentSequence(row pgx.Row) (*entities.DocumentSequence, error) {
+	sequence := &entities.DocumentSequence{}
+	err := row.Scan(
+		&sequence.ID,
+		&sequence.DocumentType,
+		&sequence.Prefix,
+		&sequence.Pattern,
+		&sequence.Padding,
+		&sequence.ResetPeriod,
+		&sequence.IsActive,
+		&sequence.CreatedAt,
+		&sequence.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return sequence, nil
+}
@@ -0,0 +1,274 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
+	"erpgo/pkg/database"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// PostgresExchangeRateRepository implements ExchangeRateRepository for PostgreSQL
+type PostgresExchangeRateRepository struct {
+	db *database.Database
+}
+
+// NewPostgresExchangeRateRepository creates a new PostgreSQL exchange rate repository
+func NewPostgresExchangeRateRepository(db *database.Database) *PostgresExchangeRateRepository {
+	return &PostgresExchangeRateRepository{
+		db: db,
+	}
+}
+
+const exchangeRateColumns = `
+	id, from_currency, to_currency, rate, effective_date, source, created_by, created_at, updated_at
+`
+
+// Create creates a new exchange rate
+func (r *PostgresExchangeRateRepository) Create(ctx context.Context, rate *entities.ExchangeRate) error {
+	query := `INSERT INTO exchange_rates (` + exchangeRateColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := r.db.Exec(ctx, query,
+		rate.ID,
+		rate.FromCurrency,
+		rate.ToCurrency,
+		rate.Rate,
+		rate.EffectiveDate,
+		rate.Source,
+		rate.CreatedBy,
+		rate.CreatedAt,
+		rate.UpdatedAt,
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return fmt.Errorf("exchange rate %s/%s on %s already exists", rate.FromCurrency, rate.ToCurrency, rate.EffectiveDate.Format("2006-01-02"))
+		}
+		return fmt.Errorf("failed to create exchange rate: %w", err)
+	}
+
+	return nil
+}
+
+// Upsert creates or replaces exchange rates by pair and effective date
+func (r *PostgresExchangeRateRepository) Upsert(ctx context.Context, rates []*entities.ExchangeRate) error {
+	if len(rates) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO exchange_rates (` + exchangeRateColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (from_currency, to_currency, effective_date) DO UPDATE SET
+			rate = EXCLUDED.rate, source = EXCLUDED.source, created_by = EXCLUDED.created_by,
+			updated_at = EXCLUDED.updated_at
+		RETURNING id, created_at
+	`
+
+	for _, rate := range rates {
+		err := tx.QueryRow(ctx, query,
+			rate.ID,
+			rate.FromCurrency,
+			rate.ToCurrency,
+			rate.Rate,
+			rate.EffectiveDate,
+			rate.Source,
+			rate.CreatedBy,
+			rate.CreatedAt,
+			rate.UpdatedAt,
+		).Scan(&rate.ID, &rate.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to upsert exchange rate %s/%s: %w", rate.FromCurrency, rate.ToCurrency, err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an exchange rate by ID
+func (r *PostgresExchangeRateRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.ExchangeRate, error) {
+	query := `SELECT ` + exchangeRateColumns + ` FROM exchange_rates WHERE id = $1`
+
+	rate, err := scanExchangeRate(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("exchange rate with id %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get exchange rate: %w", err)
+	}
+
+	return rate, nil
+}
+
+// GetEffective retrieves the latest rate of every currency pair effective on the date
+func (r *PostgresExchangeRateRepository) GetEffective(ctx context.Context, at time.Time) ([]*entities.ExchangeRate, error) {
+	query := `
+		SELECT DISTINCT ON (from_currency, to_currency) ` + exchangeRateColumns + `
+		FROM exchange_rates
+		WHERE effective_date <= $1
+		ORDER BY from_currency, to_currency, effective_date DESC
+	`
+
+	return r.query(ctx, query, at)
+}
+
+// Update updates an exchange rate
+func (r *PostgresExchangeRateRepository) Update(ctx context.Context, rate *entities.ExchangeRate) error {
+	query := `
+		UPDATE exchange_rates SET
+			rate = $2, effective_date = $3, source = $4, updated_at = $5
+		WHERE id = $1
+	`
+
+	result, err := r.db.Exec(ctx, query,
+		rate.ID,
+		rate.Rate,
+		rate.EffectiveDate,
+		rate.Source,
+		rate.UpdatedAt,
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return fmt.Errorf("exchange rate %s/%s on %s already exists", rate.FromCurrency, rate.ToCurrency, rate.EffectiveDate.Format("2006-01-02"))
+		}
+		return fmt.Errorf("failed to update exchange rate: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("exchange rate with id %s not found", rate.ID)
+	}
+
+	return nil
+}
+
+// Delete deletes an exchange rate
+func (r *PostgresExchangeRateRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM exchange_rates WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete exchange rate: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("exchange rate with id %s not found", id)
+	}
+
+	return nil
+}
+
+// List retrieves exchange rates matching the filter, latest first
+func (r *PostgresExchangeRateRepository) List(ctx context.Context, filter repositories.ExchangeRateFilter) ([]*entities.ExchangeRate, error) {
+	where, args := buildExchangeRateConditions(filter)
+	query := `SELECT ` + exchangeRateColumns + ` FROM exchange_rates` + where +
+		` ORDER BY effective_date DESC, from_currency, to_currency`
+
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", len(args)+1)
+		args = append(args, filter.Limit)
+
+		offset := filter.Offset
+		if offset == 0 && filter.Page > 1 {
+			offset = (filter.Page - 1) * filter.Limit
+		}
+		if offset > 0 {
+			query += fmt.Sprintf(" OFFSET $%d", len(args)+1)
+			args = append(args, offset)
+		}
+	}
+
+	return r.query(ctx, query, args...)
+}
+
+// Count returns the number of exchange rates matching the filter
+func (r *PostgresExchangeRateRepository) Count(ctx context.Context, filter repositories.ExchangeRateFilter) (int, error) {
+	where, args := buildExchangeRateConditions(filter)
+
+	var count int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM exchange_rates`+where, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count exchange rates: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *PostgresExchangeRateRepository) query(ctx context.Context, query string, args ...interface{}) ([]*entities.ExchangeRate, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query exchange rates: %w", err)
+	}
+	defer rows.Close()
+
+	var rates []*entities.ExchangeRate
+	for rows.Next() {
+		rate, err := scanExchangeRate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan exchange rate row: %w", err)
+		}
+		rates = append(rates, rate)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating exchange rate rows: %w", err)
+	}
+
+	return rates, nil
+}
+
+func buildExchangeRateConditions(filter repositories.ExchangeRateFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Currency != "" {
+		args = append(args, strings.ToUpper(filter.Currency))
+		conditions = append(conditions, fmt.Sprintf("(from_currency = $%d OR to_currency = $%d)", len(args), len(args)))
+	}
+
+	if filter.Source != nil {
+		args = append(args, *filter.Source)
+		conditions = append(conditions, fmt.Sprintf("source = $%d", len(args)))
+	}
+
+	if filter.StartDate != nil {
+		args = append(args, *filter.StartDate)
+		conditions = append(conditions, fmt.Sprintf("effective_date >= $%d", len(args)))
+	}
+
+	if filter.EndDate != nil {
+		args = append(args, *filter.EndDate)
+		conditions = append(conditions, fmt.Sprintf("effective_date <= $%d", len(args)))
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func scanExchangeRate(row pgx.Row) (*entities.ExchangeRate, error) {
+	rate := &entities.ExchangeRate{}
+	err := row.Scan(
+		&rate.ID,
+		&rate.FromCurrency,
+		&rate.ToCurrency,
+		&rate.Rate,
+		&rate.EffectiveDate,
+		&rate.Source,
+		&rate.CreatedBy,
+		&rate.CreatedAt,
+		&rate.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return rate, nil
+}
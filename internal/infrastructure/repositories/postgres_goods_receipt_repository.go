@@ -0,0 +1,200 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	orderEntities "erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/purchasing/entities"
+	"erpgo/pkg/database"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// PostgresGoodsReceiptRepository implements GoodsReceiptRepository for PostgreSQL
+type PostgresGoodsReceiptRepository struct {
+	db *database.Database
+}
+
+// NewPostgresGoodsReceiptRepository creates a new PostgreSQL goods receipt repository
+func NewPostgresGoodsReceiptRepository(db *database.Database) *PostgresGoodsReceiptRepository {
+	return &PostgresGoodsReceiptRepository{
+		db: db,
+	}
+}
+
+const goodsReceiptColumns = `
+	id, receipt_number, purchase_order_id, warehouse_id, supplier_reference, notes,
+	received_by, received_at, created_at
+`
+
+const goodsReceiptItemColumns = `
+	id, goods_receipt_id, purchase_order_item_id, product_id, quantity, unit_cost,
+	batch_number, expiry_date, inventory_transaction_id
+`
+
+// Create creates a new goods receipt with its items
+func (r *PostgresGoodsReceiptRepository) Create(ctx context.Context, receipt *entities.GoodsReceipt) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	receiptNumber := receipt.ReceiptNumber
+	if strings.TrimSpace(receiptNumber) == "" {
+		receiptNumber, err = allocateDocumentNumber(ctx, tx, orderEntities.DocumentTypeGoodsReceipt, receipt.ReceivedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	query := `INSERT INTO goods_receipts (` + goodsReceiptColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err = tx.Exec(ctx, query,
+		receipt.ID,
+		receiptNumber,
+		receipt.PurchaseOrderID,
+		receipt.WarehouseID,
+		receipt.SupplierReference,
+		receipt.Notes,
+		receipt.ReceivedBy,
+		receipt.ReceivedAt,
+		receipt.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create goods receipt: %w", err)
+	}
+
+	itemQuery := `INSERT INTO goods_receipt_items (` + goodsReceiptItemColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	for _, item := range receipt.Items {
+		_, err := tx.Exec(ctx, itemQuery,
+			item.ID,
+			receipt.ID,
+			item.PurchaseOrderItemID,
+			item.ProductID,
+			item.Quantity,
+			item.UnitCost,
+			item.BatchNumber,
+			item.ExpiryDate,
+			item.InventoryTransactionID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create goods receipt item: %w", err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	receipt.ReceiptNumber = receiptNumber
+	return nil
+}
+
+// GetByID retrieves a goods receipt with its items
+func (r *PostgresGoodsReceiptRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.GoodsReceipt, error) {
+	query := `SELECT ` + goodsReceiptColumns + ` FROM goods_receipts WHERE id = $1`
+
+	receipt, err := scanGoodsReceipt(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("goods receipt with id %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get goods receipt: %w", err)
+	}
+
+	if err := r.loadItems(ctx, receipt); err != nil {
+		return nil, err
+	}
+
+	return receipt, nil
+}
+
+// GetByPurchaseOrderID retrieves every goods receipt of a purchase order, oldest first
+func (r *PostgresGoodsReceiptRepository) GetByPurchaseOrderID(ctx context.Context, purchaseOrderID uuid.UUID) ([]*entities.GoodsReceipt, error) {
+	query := `SELECT ` + goodsReceiptColumns + ` FROM goods_receipts WHERE purchase_order_id = $1 ORDER BY received_at, id`
+
+	rows, err := r.db.Query(ctx, query, purchaseOrderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get goods receipts: %w", err)
+	}
+	defer rows.Close()
+
+	var receipts []*entities.GoodsReceipt
+	for rows.Next() {
+		receipt, err := scanGoodsReceipt(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan goods receipt row: %w", err)
+		}
+		receipts = append(receipts, receipt)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating goods receipt rows: %w", err)
+	}
+
+	for _, receipt := range receipts {
+		if err := r.loadItems(ctx, receipt); err != nil {
+			return nil, err
+		}
+	}
+
+	return receipts, nil
+}
+
+func (r *PostgresGoodsReceiptRepository) loadItems(ctx context.Context, receipt *entities.GoodsReceipt) error {
+	query := `SELECT ` + goodsReceiptItemColumns + ` FROM goods_receipt_items WHERE goods_receipt_id = $1 ORDER BY id`
+
+	rows, err := r.db.Query(ctx, query, receipt.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get goods receipt items: %w", err)
+	}
+	defer rows.Close()
+
+	receipt.Items = nil
+	for rows.Next() {
+		var item entities.GoodsReceiptItem
+		err := rows.Scan(
+			&item.ID,
+			&item.GoodsReceiptID,
+			&item.PurchaseOrderItemID,
+			&item.ProductID,
+			&item.Quantity,
+			&item.UnitCost,
+			&item.BatchNumber,
+			&item.ExpiryDate,
+			&item.InventoryTransactionID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to scan goods receipt item: %w", err)
+		}
+		receipt.Items = append(receipt.Items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating goods receipt items: %w", err)
+	}
+
+	return nil
+}
+
+func scanGoodsReceipt(row pgx.Row) (*entities.GoodsReceipt, error) {
+	receipt := &entities.GoodsReceipt{}
+	err := row.Scan(
+		&receipt.ID,
+		&receipt.ReceiptNumber,
+		&receipt.PurchaseOrderID,
+		&receipt.WarehouseID,
+		&receipt.SupplierReference,
+		&receipt.Notes,
+		&receipt.ReceivedBy,
+		&receipt.ReceivedAt,
+		&receipt.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return receipt, nil
+}
@@ -303,6 +303,90 @@ func (r *PostgresInventoryRepository) GetAvailableStock(ctx context.Context, pro
 	return availableStock, nil
 }
 
+// ReceiveStock adds received stock at the given unit cost. The inventory row is
+// locked while the weighted average cost is recalculated so concurrent receipts
+// and stock movements are not lost.
+func (r *PostgresInventoryRepository) ReceiveStock(ctx context.Context, productID, warehouseID uuid.UUID, quantity int, unitCost float64, receivedBy uuid.UUID) (*entities.Inventory, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		SELECT id, product_id, warehouse_id, quantity_on_hand, quantity_reserved,
+		       reorder_level, max_stock, min_stock, average_cost, last_count_date,
+		       last_counted_by, updated_at, updated_by
+		FROM inventory
+		WHERE product_id = $1 AND warehouse_id = $2
+		FOR UPDATE
+	`
+
+	inventory := &entities.Inventory{}
+	exists := true
+	err = tx.QueryRow(ctx, query, productID, warehouseID).Scan(
+		&inventory.ID,
+		&inventory.ProductID,
+		&inventory.WarehouseID,
+		&inventory.QuantityOnHand,
+		&inventory.QuantityReserved,
+		&inventory.ReorderLevel,
+		&inventory.MaxStock,
+		&inventory.MinStock,
+		&inventory.AverageCost,
+		&inventory.LastCountDate,
+		&inventory.LastCountedBy,
+		&inventory.UpdatedAt,
+		&inventory.UpdatedBy,
+	)
+	if err == pgx.ErrNoRows {
+		exists = false
+		inventory = &entities.Inventory{
+			ID:          uuid.New(),
+			ProductID:   productID,
+			WarehouseID: warehouseID,
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get inventory: %w", err)
+	}
+
+	if err := inventory.ReceiveStock(quantity, unitCost); err != nil {
+		return nil, err
+	}
+	inventory.UpdatedBy = receivedBy
+
+	if exists {
+		_, err = tx.Exec(ctx, `
+			UPDATE inventory
+			SET quantity_on_hand = $2, average_cost = $3, updated_at = $4, updated_by = $5
+			WHERE id = $1
+		`, inventory.ID, inventory.QuantityOnHand, inventory.AverageCost, inventory.UpdatedAt, inventory.UpdatedBy)
+	} else {
+		// A concurrent first receipt may insert the row first; fall back to
+		// adding to it rather than failing the receipt
+		_, err = tx.Exec(ctx, `
+			INSERT INTO inventory (id, product_id, warehouse_id, quantity_on_hand, quantity_reserved,
+			                      reorder_level, average_cost, updated_at, updated_by)
+			VALUES ($1, $2, $3, $4, 0, 0, $5, $6, $7)
+			ON CONFLICT (product_id, warehouse_id) DO UPDATE SET
+				average_cost = (GREATEST(inventory.quantity_on_hand, 0) * inventory.average_cost + EXCLUDED.quantity_on_hand * EXCLUDED.average_cost)
+					/ (GREATEST(inventory.quantity_on_hand, 0) + EXCLUDED.quantity_on_hand),
+				quantity_on_hand = inventory.quantity_on_hand + EXCLUDED.quantity_on_hand,
+				updated_at = EXCLUDED.updated_at,
+				updated_by = EXCLUDED.updated_by
+		`, inventory.ID, productID, warehouseID, inventory.QuantityOnHand, inventory.AverageCost, inventory.UpdatedAt, inventory.UpdatedBy)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to receive stock: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return inventory, nil
+}
+
 // List retrieves inventory records with filtering
 func (r *PostgresInventoryRepository) List(ctx context.Context, filter *repositories.InventoryFilter) ([]*entities.Inventory, error) {
 	query := `
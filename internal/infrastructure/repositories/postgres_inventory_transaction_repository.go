@@ -1022,28 +1022,31 @@ func (r *PostgresInventoryTransactionRepository) GetTransferTransactions(ctx con
 	return transactions, nil
 }
 
-// GetPendingTransfers retrieves pending transfer transactions
+// GetPendingTransfers retrieves the TRANSFER_OUT transactions of transfer
+// orders whose stock is still in transit, optionally for transfers from or to
+// a warehouse
 func (r *PostgresInventoryTransactionRepository) GetPendingTransfers(ctx context.Context, warehouseID *uuid.UUID) ([]*entities.InventoryTransaction, error) {
 	query := `
-		SELECT id, product_id, warehouse_id, transaction_type, quantity,
-		       reference_type, reference_id, reason, unit_cost, total_cost,
-		       batch_number, expiry_date, serial_number, from_warehouse_id,
-		       to_warehouse_id, created_at, created_by, approved_at, approved_by
-		FROM inventory_transactions
-		WHERE transaction_type IN ('TRANSFER_OUT', 'TRANSFER_IN')
-		  AND approved_at IS NULL
+		SELECT it.id, it.product_id, it.warehouse_id, it.transaction_type, it.quantity,
+		       it.reference_type, it.reference_id, it.reason, it.unit_cost, it.total_cost,
+		       it.batch_number, it.expiry_date, it.serial_number, it.from_warehouse_id,
+		       it.to_warehouse_id, it.created_at, it.created_by, it.approved_at, it.approved_by
+		FROM inventory_transactions it
+		JOIN transfer_orders t ON t.id = it.reference_id AND it.reference_type = 'TRANSFER_ORDER'
+		WHERE it.transaction_type = 'TRANSFER_OUT'
+		  AND t.status IN ('IN_TRANSIT', 'PARTIALLY_RECEIVED')
 	`
 
 	args := []interface{}{}
 	argIndex := 1
 
 	if warehouseID != nil {
-		query += fmt.Sprintf(" AND (warehouse_id = $%d OR from_warehouse_id = $%d OR to_warehouse_id = $%d)", argIndex, argIndex, argIndex)
+		query += fmt.Sprintf(" AND (t.from_warehouse_id = $%d OR t.to_warehouse_id = $%d)", argIndex, argIndex)
 		args = append(args, *warehouseID)
 		argIndex++
 	}
 
-	query += " ORDER BY created_at ASC"
+	query += " ORDER BY it.created_at ASC"
 
 	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
@@ -0,0 +1,447 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
+	"erpgo/pkg/database"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// PostgresInvoiceRepository implements InvoiceRepository for PostgreSQL
+type PostgresInvoiceRepository struct {
+	db *database.Database
+}
+
+// NewPostgresInvoiceRepository creates a new PostgreSQL invoice repository
+func NewPostgresInvoiceRepository(db *database.Database) *PostgresInvoiceRepository {
+	return &PostgresInvoiceRepository{
+		db: db,
+	}
+}
+
+const invoiceColumns = `
+	id, invoice_number, type, status, order_id, shipment_id, credited_invoice_id,
+	customer_id, billing_address_id, currency, payment_terms, issue_date, due_date,
+	subtotal, tax_amount, shipping_amount, discount_amount, total_amount, tax_breakdown,
+	notes, reason, cancellation_reason, created_by, issued_by, created_at, updated_at,
+	cancelled_at
+`
+
+const invoiceLineColumns = `
+	id, invoice_id, order_item_id, product_id, product_sku, description, quantity,
+	unit_price, discount_amount, tax_rate, tax_amount, total_price, price_includes_tax
+`
+
+// Create creates a new invoice with its lines
+func (r *PostgresInvoiceRepository) Create(ctx context.Context, invoice *entities.Invoice) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	invoiceNumber, err := r.invoiceNumber(ctx, tx, invoice)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO invoices (` + invoiceColumns + `) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17,
+			$18, $19, $20, $21, $22, $23, $24, $25, $26, $27
+		)
+	`
+
+	_, err = tx.Exec(ctx, query,
+		invoice.ID,
+		nullableNumber(invoiceNumber),
+		invoice.Type,
+		invoice.Status,
+		invoice.OrderID,
+		invoice.ShipmentID,
+		invoice.CreditedInvoiceID,
+		invoice.CustomerID,
+		invoice.BillingAddressID,
+		invoice.Currency,
+		invoice.PaymentTerms,
+		invoice.IssueDate,
+		invoice.DueDate,
+		invoice.Subtotal,
+		invoice.TaxAmount,
+		invoice.ShippingAmount,
+		invoice.DiscountAmount,
+		invoice.TotalAmount,
+		invoice.TaxBreakdown,
+		invoice.Notes,
+		invoice.Reason,
+		invoice.CancellationReason,
+		invoice.CreatedBy,
+		invoice.IssuedBy,
+		invoice.CreatedAt,
+		invoice.UpdatedAt,
+		invoice.CancelledAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create invoice: %w", err)
+	}
+
+	lineQuery := `INSERT INTO invoice_lines (` + invoiceLineColumns + `, line_number) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
+	for i, line := range invoice.Lines {
+		_, err := tx.Exec(ctx, lineQuery,
+			line.ID,
+			invoice.ID,
+			line.OrderItemID,
+			line.ProductID,
+			line.ProductSKU,
+			line.Description,
+			line.Quantity,
+			line.UnitPrice,
+			line.DiscountAmount,
+			line.TaxRate,
+			line.TaxAmount,
+			line.TotalPrice,
+			line.PriceIncludesTax,
+			i+1,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create invoice line: %w", err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	invoice.InvoiceNumber = invoiceNumber
+	return nil
+}
+
+// GetByID retrieves an invoice with its lines
+func (r *PostgresInvoiceRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Invoice, error) {
+	query := `SELECT ` + invoiceColumns + ` FROM invoices WHERE id = $1`
+
+	invoice, err := scanInvoice(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("invoice with id %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get invoice: %w", err)
+	}
+
+	if err := r.loadLines(ctx, []*entities.Invoice{invoice}); err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+// GetByNumber retrieves an issued invoice or credit note by its number
+func (r *PostgresInvoiceRepository) GetByNumber(ctx context.Context, invoiceNumber string) (*entities.Invoice, error) {
+	query := `SELECT ` + invoiceColumns + ` FROM invoices WHERE invoice_number = $1`
+
+	invoice, err := scanInvoice(r.db.QueryRow(ctx, query, invoiceNumber))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("invoice with number %s not found", invoiceNumber)
+		}
+		return nil, fmt.Errorf("failed to get invoice: %w", err)
+	}
+
+	if err := r.loadLines(ctx, []*entities.Invoice{invoice}); err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+// GetByOrderID retrieves the invoices and credit notes of an order, oldest first
+func (r *PostgresInvoiceRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.Invoice, error) {
+	query := `SELECT ` + invoiceColumns + ` FROM invoices WHERE order_id = $1 ORDER BY created_at, id`
+
+	return r.query(ctx, query, orderID)
+}
+
+// Update persists the status change of a draft. The status condition keeps
+// issued invoices locked even against concurrent writers.
+func (r *PostgresInvoiceRepository) Update(ctx context.Context, invoice *entities.Invoice) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	invoiceNumber, err := r.invoiceNumber(ctx, tx, invoice)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE invoices SET
+			invoice_number = $2, status = $3, issue_date = $4, due_date = $5, notes = $6,
+			cancellation_reason = $7, issued_by = $8, updated_at = $9, cancelled_at = $10
+		WHERE id = $1 AND status = 'DRAFT'
+	`
+
+	result, err := tx.Exec(ctx, query,
+		invoice.ID,
+		nullableNumber(invoiceNumber),
+		invoice.Status,
+		invoice.IssueDate,
+		invoice.DueDate,
+		invoice.Notes,
+		invoice.CancellationReason,
+		invoice.IssuedBy,
+		invoice.UpdatedAt,
+		invoice.CancelledAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update invoice: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("invoice with id %s not found or already locked", invoice.ID)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	invoice.InvoiceNumber = invoiceNumber
+	return nil
+}
+
+// List retrieves invoices matching the filter with their lines, newest first
+func (r *PostgresInvoiceRepository) List(ctx context.Context, filter repositories.InvoiceFilter) ([]*entities.Invoice, error) {
+	where, args := buildInvoiceConditions(filter)
+	query := `SELECT ` + invoiceColumns + ` FROM invoices` + where + ` ORDER BY created_at DESC, id`
+
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", len(args)+1)
+		args = append(args, filter.Limit)
+
+		offset := filter.Offset
+		if offset == 0 && filter.Page > 1 {
+			offset = (filter.Page - 1) * filter.Limit
+		}
+		if offset > 0 {
+			query += fmt.Sprintf(" OFFSET $%d", len(args)+1)
+			args = append(args, offset)
+		}
+	}
+
+	return r.query(ctx, query, args...)
+}
+
+// Count returns the number of invoices matching the filter
+func (r *PostgresInvoiceRepository) Count(ctx context.Context, filter repositories.InvoiceFilter) (int, error) {
+	where, args := buildInvoiceConditions(filter)
+
+	var count int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM invoices`+where, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count invoices: %w", err)
+	}
+
+	return count, nil
+}
+
+// invoiceNumber returns the number of the invoice, allocating one from the
+// sequence of its type when it is issued without a number
+func (r *PostgresInvoiceRepository) invoiceNumber(ctx context.Context, tx pgx.Tx, invoice *entities.Invoice) (string, error) {
+	if invoice.Status != entities.InvoiceStatusIssued || strings.TrimSpace(invoice.InvoiceNumber) != "" {
+		return invoice.InvoiceNumber, nil
+	}
+
+	documentType := entities.DocumentTypeInvoice
+	if invoice.IsCreditNote() {
+		documentType = entities.DocumentTypeCreditNote
+	}
+	return allocateDocumentNumber(ctx, tx, documentType, *invoice.IssueDate)
+}
+
+func (r *PostgresInvoiceRepository) query(ctx context.Context, query string, args ...interface{}) ([]*entities.Invoice, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query invoices: %w", err)
+	}
+	defer rows.Close()
+
+	var invoices []*entities.Invoice
+	for rows.Next() {
+		invoice, err := scanInvoice(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan invoice row: %w", err)
+		}
+		invoices = append(invoices, invoice)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating invoice rows: %w", err)
+	}
+
+	if err := r.loadLines(ctx, invoices); err != nil {
+		return nil, err
+	}
+
+	return invoices, nil
+}
+
+// loadLines loads the lines of several invoices in one query
+func (r *PostgresInvoiceRepository) loadLines(ctx context.Context, invoices []*entities.Invoice) error {
+	if len(invoices) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(invoices))
+	byID := make(map[uuid.UUID]*entities.Invoice, len(invoices))
+	for i, invoice := range invoices {
+		ids[i] = invoice.ID
+		byID[invoice.ID] = invoice
+		invoice.Lines = nil
+	}
+
+	query := `SELECT ` + invoiceLineColumns + ` FROM invoice_lines WHERE invoice_id = ANY($1) ORDER BY invoice_id, line_number`
+
+	rows, err := r.db.Query(ctx, query, ids)
+	if err != nil {
+		return fmt.Errorf("failed to get invoice lines: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var line entities.InvoiceLine
+		err := rows.Scan(
+			&line.ID,
+			&line.InvoiceID,
+			&line.OrderItemID,
+			&line.ProductID,
+			&line.ProductSKU,
+			&line.Description,
+			&line.Quantity,
+			&line.UnitPrice,
+			&line.DiscountAmount,
+			&line.TaxRate,
+			&line.TaxAmount,
+			&line.TotalPrice,
+			&line.PriceIncludesTax,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to scan invoice line: %w", err)
+		}
+		invoice := byID[line.InvoiceID]
+		invoice.Lines = append(invoice.Lines, line)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating invoice lines: %w", err)
+	}
+
+	return nil
+}
+
+func buildInvoiceConditions(filter repositories.InvoiceFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Search != "" {
+		args = append(args, "%"+filter.Search+"%")
+		conditions = append(conditions, fmt.Sprintf("invoice_number ILIKE $%d", len(args)))
+	}
+
+	if filter.Type != nil {
+		args = append(args, *filter.Type)
+		conditions = append(conditions, fmt.Sprintf("type = $%d", len(args)))
+	}
+
+	if len(filter.Status) > 0 {
+		placeholders := make([]string, len(filter.Status))
+		for i, status := range filter.Status {
+			args = append(args, status)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		conditions = append(conditions, "status IN ("+strings.Join(placeholders, ", ")+")")
+	}
+
+	if filter.CustomerID != nil {
+		args = append(args, *filter.CustomerID)
+		conditions = append(conditions, fmt.Sprintf("customer_id = $%d", len(args)))
+	}
+
+	if filter.OrderID != nil {
+		args = append(args, *filter.OrderID)
+		conditions = append(conditions, fmt.Sprintf("order_id = $%d", len(args)))
+	}
+
+	if filter.IssuedFrom != nil {
+		args = append(args, *filter.IssuedFrom)
+		conditions = append(conditions, fmt.Sprintf("issue_date >= $%d", len(args)))
+	}
+
+	if filter.IssuedTo != nil {
+		args = append(args, *filter.IssuedTo)
+		conditions = append(conditions, fmt.Sprintf("issue_date <= $%d", len(args)))
+	}
+
+	if filter.DueBefore != nil {
+		args = append(args, *filter.DueBefore)
+		conditions = append(conditions, fmt.Sprintf("due_date < $%d", len(args)))
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func scanInvoice(row pgx.Row) (*entities.Invoice, error) {
+	invoice := &entities.Invoice{}
+	var invoiceNumber *string
+	err := row.Scan(
+		&invoice.ID,
+		&invoiceNumber,
+		&invoice.Type,
+		&invoice.Status,
+		&invoice.OrderID,
+		&invoice.ShipmentID,
+		&invoice.CreditedInvoiceID,
+		&invoice.CustomerID,
+		&invoice.BillingAddressID,
+		&invoice.Currency,
+		&invoice.PaymentTerms,
+		&invoice.IssueDate,
+		&invoice.DueDate,
+		&invoice.Subtotal,
+		&invoice.TaxAmount,
+		&invoice.ShippingAmount,
+		&invoice.DiscountAmount,
+		&invoice.TotalAmount,
+		&invoice.TaxBreakdown,
+		&invoice.Notes,
+		&invoice.Reason,
+		&invoice.CancellationReason,
+		&invoice.CreatedBy,
+		&invoice.IssuedBy,
+		&invoice.CreatedAt,
+		&invoice.UpdatedAt,
+		&invoice.CancelledAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if invoiceNumber != nil {
+		invoice.InvoiceNumber = *invoiceNumber
+	}
+	return invoice, nil
+}
+
+// nullableNumber stores drafts without a number as NULL so the unique
+// constraint only applies to issued numbers
+func nullableNumber(number string) *string {
+	if strings.TrimSpace(number) == "" {
+		return nil
+	}
+	return &number
+}
@@ -60,7 +60,9 @@ type SeasonalStats struct {
 	GrowthRate    decimal.Decimal `json:"growth_rate"`
 }
 
-// PostgresOrderAnalyticsRepository implements advanced analytics queries for orders
+// PostgresOrderAnalyticsRepository implements advanced analytics queries for
+// orders. Amounts are reported in the base currency, converted at the exchange
+// rate each order was booked at.
 type PostgresOrderAnalyticsRepository struct {
 	db *database.Database
 }
@@ -93,9 +95,9 @@ func (r *PostgresOrderAnalyticsRepository) GetRevenueByPeriod(ctx context.Contex
 	query := fmt.Sprintf(`
 		SELECT
 			TO_CHAR(order_date, '%s') as period,
-			COALESCE(SUM(total_amount), 0) as revenue,
+			ROUND(COALESCE(SUM(total_amount * exchange_rate), 0), 2) as revenue,
 			COUNT(*) as order_count,
-			COALESCE(AVG(total_amount), 0) as average_order_value
+			ROUND(COALESCE(AVG(total_amount * exchange_rate), 0), 2) as average_order_value
 		FROM orders
 		WHERE order_date >= $1 AND order_date <= $2
 		AND status NOT IN ('CANCELLED', 'REFUNDED')
@@ -139,8 +141,8 @@ func (r *PostgresOrderAnalyticsRepository) GetTopCustomers(ctx context.Context,
 			COALESCE(c.first_name, '') || ' ' || COALESCE(c.last_name, '') as customer_name,
 			COALESCE(c.email, '') as customer_email,
 			COUNT(o.id) as order_count,
-			COALESCE(SUM(o.total_amount), 0) as total_revenue,
-			COALESCE(AVG(o.total_amount), 0) as average_order_value,
+			ROUND(COALESCE(SUM(o.total_amount * o.exchange_rate), 0), 2) as total_revenue,
+			ROUND(COALESCE(AVG(o.total_amount * o.exchange_rate), 0), 2) as average_order_value,
 			MAX(o.order_date) as last_order_date
 		FROM customers c
 		INNER JOIN orders o ON c.id = o.customer_id
@@ -190,7 +192,7 @@ func (r *PostgresOrderAnalyticsRepository) GetSalesByProduct(ctx context.Context
 			p.sku,
 			p.name,
 			COALESCE(SUM(oi.quantity), 0) as quantity_sold,
-			COALESCE(SUM(oi.total_price), 0) as total_revenue,
+			ROUND(COALESCE(SUM(oi.total_price * o.exchange_rate), 0), 2) as total_revenue,
 			COUNT(DISTINCT oi.order_id) as order_count
 		FROM products p
 		INNER JOIN order_items oi ON p.id = oi.product_id
@@ -259,8 +261,8 @@ func (r *PostgresOrderAnalyticsRepository) GetOrderTrends(ctx context.Context, s
 				COUNT(CASE WHEN status = 'SHIPPED' THEN 1 END) as shipped_orders,
 				COUNT(CASE WHEN status = 'DELIVERED' THEN 1 END) as delivered_orders,
 				COUNT(CASE WHEN status = 'CANCELLED' THEN 1 END) as cancelled_orders,
-				COALESCE(SUM(total_amount), 0) as total_revenue,
-				COALESCE(AVG(total_amount), 0) as average_order_value
+				ROUND(COALESCE(SUM(total_amount * exchange_rate), 0), 2) as total_revenue,
+				ROUND(COALESCE(AVG(total_amount * exchange_rate), 0), 2) as average_order_value
 			FROM orders
 			WHERE order_date >= $1 AND order_date <= $2
 			GROUP BY TO_CHAR(order_date, '%s')
@@ -311,8 +313,8 @@ func (r *PostgresOrderAnalyticsRepository) GetSalesByRegion(ctx context.Context,
 			COALESCE(shipping.state, '') as state,
 			COALESCE(shipping.city, '') as city,
 			COUNT(DISTINCT o.id) as order_count,
-			COALESCE(SUM(o.total_amount), 0) as total_revenue,
-			COALESCE(AVG(o.total_amount), 0) as avg_order_value
+			ROUND(COALESCE(SUM(o.total_amount * o.exchange_rate), 0), 2) as total_revenue,
+			ROUND(COALESCE(AVG(o.total_amount * o.exchange_rate), 0), 2) as avg_order_value
 		FROM orders o
 		LEFT JOIN order_addresses shipping ON o.shipping_address_id = shipping.id
 		WHERE o.order_date >= $1 AND o.order_date <= $2
@@ -364,7 +366,7 @@ func (r *PostgresOrderAnalyticsRepository) GetCustomerRetentionAnalysis(ctx cont
 					ELSE 'returning'
 				END as customer_type,
 				COUNT(o.id) as order_count,
-				COALESCE(SUM(o.total_amount), 0) as total_spent
+				ROUND(COALESCE(SUM(o.total_amount * o.exchange_rate), 0), 2) as total_spent
 			FROM customers c
 			LEFT JOIN orders o ON c.id = o.customer_id
 			AND o.order_date >= $1 AND o.order_date <= $2
@@ -492,7 +494,7 @@ func (r *PostgresOrderAnalyticsRepository) GetPaymentAnalysis(ctx context.Contex
 			COUNT(CASE WHEN payment_status = 'PENDING' THEN 1 END) as pending_orders,
 			COUNT(CASE WHEN payment_status = 'OVERDUE' THEN 1 END) as overdue_orders,
 			COUNT(CASE WHEN payment_status = 'FAILED' THEN 1 END) as failed_orders,
-			COALESCE(SUM(CASE WHEN payment_status = 'OVERDUE' THEN total_amount - paid_amount ELSE 0 END), 0) as overdue_amount
+			ROUND(COALESCE(SUM(CASE WHEN payment_status = 'OVERDUE' THEN (total_amount - paid_amount) * exchange_rate ELSE 0 END), 0), 2) as overdue_amount
 		FROM orders
 		WHERE order_date >= $1 AND order_date <= $2
 	`
@@ -548,8 +550,8 @@ func (r *PostgresOrderAnalyticsRepository) GetSeasonalAnalysis(ctx context.Conte
 					WHEN EXTRACT(MONTH FROM order_date) IN (9, 10, 11) THEN 'Fall'
 				END as season,
 				COUNT(*) as order_count,
-				COALESCE(SUM(total_amount), 0) as total_revenue,
-				COALESCE(AVG(total_amount), 0) as avg_order_value
+				ROUND(COALESCE(SUM(total_amount * exchange_rate), 0), 2) as total_revenue,
+				ROUND(COALESCE(AVG(total_amount * exchange_rate), 0), 2) as avg_order_value
 			FROM orders
 			WHERE order_date >= CURRENT_DATE - INTERVAL '%d years'
 			AND status NOT IN ('CANCELLED', 'REFUNDED')
@@ -0,0 +1,596 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
+	"erpgo/pkg/database"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// PostgresOrderArchiveRepository implements OrderArchiveRepository for
+// PostgreSQL. Archive tables are partitioned by the year of the order date;
+// rows are copied between them and the live tables by column name.
+type PostgresOrderArchiveRepository struct {
+	db *database.Database
+}
+
+// NewPostgresOrderArchiveRepository creates a new PostgreSQL order archive repository
+func NewPostgresOrderArchiveRepository(db *database.Database) *PostgresOrderArchiveRepository {
+	return &PostgresOrderArchiveRepository{
+		db: db,
+	}
+}
+
+const archivedOrderColumns = `
+	id, order_number, customer_id, status, previous_status, priority, type,
+	payment_status, shipping_method, subtotal, tax_amount, shipping_amount,
+	discount_amount, promotion_discount_amount, total_amount, paid_amount,
+	refunded_amount, currency, exchange_rate, credit_hold,
+	order_date, required_date, shipping_date, delivery_date, cancelled_date,
+	shipping_address_id, billing_address_id, notes, internal_notes,
+	customer_notes, tracking_number, carrier, created_by, approved_by,
+	shipped_by, created_at, updated_at, approved_at, shipped_at, archived_at
+`
+
+// archiveStatement is one step of moving an order between the live tables and the archive
+type archiveStatement struct {
+	query string
+	what  string
+}
+
+// Archive copies the order with its items, addresses and status history into
+// the archive partition of its order year and removes it from the live
+// tables. Items, the addresses the order owns and its allocations go with
+// the order; addresses in the customer's address book stay live, as do its
+// documents, which reference the order through order_keys.
+func (r *PostgresOrderArchiveRepository) Archive(ctx context.Context, orderID uuid.UUID, archivedAt time.Time) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var year int
+	err = tx.QueryRow(ctx, `
+		SELECT EXTRACT(YEAR FROM order_date AT TIME ZONE 'UTC')::INTEGER
+		FROM orders
+		WHERE id = $1
+		FOR UPDATE
+	`, orderID).Scan(&year)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("order with id %s not found", orderID)
+		}
+		return fmt.Errorf("failed to lock order: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `SELECT create_order_archive_partitions($1)`, year); err != nil {
+		return fmt.Errorf("failed to create order archive partitions: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO orders_archive
+		SELECT r.*
+		FROM orders o,
+			jsonb_populate_record(NULL::orders_archive,
+				to_jsonb(o) || jsonb_build_object('order_year', $2::INTEGER, 'archived_at', $3::TIMESTAMPTZ)) r
+		WHERE o.id = $1
+	`, orderID, year, archivedAt)
+	if err != nil {
+		return fmt.Errorf("failed to archive order: %w", err)
+	}
+
+	copies := []archiveStatement{
+		{`
+			INSERT INTO order_items_archive
+			SELECT r.*
+			FROM order_items i,
+				jsonb_populate_record(NULL::order_items_archive, to_jsonb(i) || jsonb_build_object('order_year', $2::INTEGER)) r
+			WHERE i.order_id = $1
+		`, "order items"},
+		{`
+			INSERT INTO order_addresses_archive
+			SELECT r.*
+			FROM orders o
+			JOIN order_addresses a ON a.id IN (o.shipping_address_id, o.billing_address_id) OR a.order_id = o.id,
+				jsonb_populate_record(NULL::order_addresses_archive,
+					to_jsonb(a) || jsonb_build_object('archived_order_id', o.id, 'order_year', $2::INTEGER)) r
+			WHERE o.id = $1
+		`, "order addresses"},
+		{`
+			INSERT INTO order_status_history_archive
+			SELECT r.*
+			FROM order_status_history h,
+				jsonb_populate_record(NULL::order_status_history_archive, to_jsonb(h) || jsonb_build_object('order_year', $2::INTEGER)) r
+			WHERE h.order_id = $1
+		`, "order status history"},
+	}
+	for _, statement := range copies {
+		if _, err := tx.Exec(ctx, statement.query, orderID, year); err != nil {
+			return fmt.Errorf("failed to archive %s: %w", statement.what, err)
+		}
+	}
+
+	removals := []archiveStatement{
+		// Only history already in the archive can be deleted
+		{`DELETE FROM order_status_history WHERE order_id = $1`, "order status history"},
+		// Keep the customer's address book entries the order delete would cascade to
+		{`UPDATE order_addresses SET order_id = NULL WHERE order_id = $1 AND customer_id IS NOT NULL`, "order addresses"},
+		{`DELETE FROM orders WHERE id = $1`, "order"},
+	}
+	for _, statement := range removals {
+		if _, err := tx.Exec(ctx, statement.query, orderID); err != nil {
+			return fmt.Errorf("failed to remove archived %s: %w", statement.what, err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Restore moves an archived order with its items, addresses and status
+// history back into the live tables. Addresses still in the customer's
+// address book are linked to the order again rather than copied.
+func (r *PostgresOrderArchiveRepository) Restore(ctx context.Context, orderID uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var year int
+	err = tx.QueryRow(ctx, `SELECT order_year FROM orders_archive WHERE id = $1 FOR UPDATE`, orderID).Scan(&year)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("archived order with id %s not found", orderID)
+		}
+		return fmt.Errorf("failed to lock archived order: %w", err)
+	}
+
+	// Restored items carry their order's totals already, and the order and
+	// the addresses it owns reference each other
+	if _, err := tx.Exec(ctx, `SELECT set_config('erpgo.restoring_orders', 'on', true)`); err != nil {
+		return fmt.Errorf("failed to prepare order restore: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `SET CONSTRAINTS fk_order_addresses_order_id DEFERRED`); err != nil {
+		return fmt.Errorf("failed to prepare order restore: %w", err)
+	}
+
+	statements := []archiveStatement{
+		{`
+			INSERT INTO order_addresses
+			SELECT r.*
+			FROM order_addresses_archive a,
+				jsonb_populate_record(NULL::order_addresses, to_jsonb(a)) r
+			WHERE a.archived_order_id = $1 AND a.order_year = $2
+			ON CONFLICT (id) DO UPDATE SET order_id = EXCLUDED.order_id
+			WHERE order_addresses.order_id IS NULL
+		`, "order addresses"},
+		{`
+			INSERT INTO orders
+			SELECT r.*
+			FROM orders_archive a,
+				jsonb_populate_record(NULL::orders, to_jsonb(a)) r
+			WHERE a.id = $1 AND a.order_year = $2
+		`, "order"},
+		{`
+			INSERT INTO order_items
+			SELECT r.*
+			FROM order_items_archive a,
+				jsonb_populate_record(NULL::order_items, to_jsonb(a)) r
+			WHERE a.order_id = $1 AND a.order_year = $2
+		`, "order items"},
+		{`
+			INSERT INTO order_status_history
+			SELECT r.*
+			FROM order_status_history_archive a,
+				jsonb_populate_record(NULL::order_status_history, to_jsonb(a)) r
+			WHERE a.order_id = $1 AND a.order_year = $2
+		`, "order status history"},
+		{`DELETE FROM order_status_history_archive WHERE order_id = $1 AND order_year = $2`, "archived order status history"},
+		{`DELETE FROM order_addresses_archive WHERE archived_order_id = $1 AND order_year = $2`, "archived order addresses"},
+		{`DELETE FROM order_items_archive WHERE order_id = $1 AND order_year = $2`, "archived order items"},
+		{`DELETE FROM orders_archive WHERE id = $1 AND order_year = $2`, "archived order"},
+	}
+	for _, statement := range statements {
+		if _, err := tx.Exec(ctx, statement.query, orderID, year); err != nil {
+			return fmt.Errorf("failed to restore %s: %w", statement.what, err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an archived order by ID
+func (r *PostgresOrderArchiveRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Order, error) {
+	query := `SELECT ` + archivedOrderColumns + ` FROM orders_archive WHERE id = $1`
+
+	order, err := scanArchivedOrder(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("archived order with id %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get archived order by id: %w", err)
+	}
+
+	return order, nil
+}
+
+// GetByOrderNumber retrieves an archived order by order number
+func (r *PostgresOrderArchiveRepository) GetByOrderNumber(ctx context.Context, orderNumber string) (*entities.Order, error) {
+	query := `SELECT ` + archivedOrderColumns + ` FROM orders_archive WHERE order_number = $1`
+
+	order, err := scanArchivedOrder(r.db.QueryRow(ctx, query, orderNumber))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("archived order with number %s not found", orderNumber)
+		}
+		return nil, fmt.Errorf("failed to get archived order by number: %w", err)
+	}
+
+	return order, nil
+}
+
+// GetItems retrieves the items of an archived order
+func (r *PostgresOrderArchiveRepository) GetItems(ctx context.Context, orderID uuid.UUID) ([]*entities.OrderItem, error) {
+	query := `
+		SELECT
+			id, order_id, product_id, product_sku, product_name, quantity,
+			unit_price, discount_amount, tax_rate, tax_amount, total_price,
+			weight, dimensions, barcode, notes, status, quantity_shipped,
+			quantity_returned, quantity_backordered, tax_class, price_includes_tax, is_drop_ship, quantity_refunded, created_at, updated_at
+		FROM order_items_archive
+		WHERE order_id = $1
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get archived order items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*entities.OrderItem
+	for rows.Next() {
+		item := &entities.OrderItem{}
+		err := rows.Scan(
+			&item.ID,
+			&item.OrderID,
+			&item.ProductID,
+			&item.ProductSKU,
+			&item.ProductName,
+			&item.Quantity,
+			&item.UnitPrice,
+			&item.DiscountAmount,
+			&item.TaxRate,
+			&item.TaxAmount,
+			&item.TotalPrice,
+			&item.Weight,
+			&item.Dimensions,
+			&item.Barcode,
+			&item.Notes,
+			&item.Status,
+			&item.QuantityShipped,
+			&item.QuantityReturned,
+			&item.QuantityBackordered,
+			&item.TaxClass,
+			&item.PriceIncludesTax,
+			&item.IsDropShip,
+			&item.QuantityRefunded,
+			&item.CreatedAt,
+			&item.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan archived order item row: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating archived order item rows: %w", err)
+	}
+
+	return items, nil
+}
+
+// GetAddresses retrieves the addresses of an archived order as they stood when it was archived
+func (r *PostgresOrderArchiveRepository) GetAddresses(ctx context.Context, orderID uuid.UUID) ([]*entities.OrderAddress, error) {
+	query := `
+		SELECT
+			id, customer_id, order_id, type, first_name, last_name, company,
+			address_line_1, address_line_2, city, state, postal_code, country,
+			phone, email, instructions, is_default, created_at, updated_at
+		FROM order_addresses_archive
+		WHERE archived_order_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.Query(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get archived order addresses: %w", err)
+	}
+	defer rows.Close()
+
+	var addresses []*entities.OrderAddress
+	for rows.Next() {
+		address := &entities.OrderAddress{}
+		err := rows.Scan(
+			&address.ID,
+			&address.CustomerID,
+			&address.OrderID,
+			&address.Type,
+			&address.FirstName,
+			&address.LastName,
+			&address.Company,
+			&address.AddressLine1,
+			&address.AddressLine2,
+			&address.City,
+			&address.State,
+			&address.PostalCode,
+			&address.Country,
+			&address.Phone,
+			&address.Email,
+			&address.Instructions,
+			&address.IsDefault,
+			&address.CreatedAt,
+			&address.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan archived order address row: %w", err)
+		}
+		addresses = append(addresses, address)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating archived order address rows: %w", err)
+	}
+
+	return addresses, nil
+}
+
+// GetStatusHistory retrieves the status history of an archived order in chronological order
+func (r *PostgresOrderArchiveRepository) GetStatusHistory(ctx context.Context, orderID uuid.UUID) ([]*entities.OrderStatusHistory, error) {
+	query := `
+		SELECT id, order_id, status_type, from_status, to_status, amount,
+			changed_by, COALESCE(reason, ''), source, created_at
+		FROM order_status_history_archive
+		WHERE order_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.Query(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get archived order status history: %w", err)
+	}
+	defer rows.Close()
+
+	var history []*entities.OrderStatusHistory
+	for rows.Next() {
+		entry := &entities.OrderStatusHistory{}
+		err := rows.Scan(
+			&entry.ID,
+			&entry.OrderID,
+			&entry.Type,
+			&entry.FromStatus,
+			&entry.ToStatus,
+			&entry.Amount,
+			&entry.ChangedBy,
+			&entry.Reason,
+			&entry.Source,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan archived order status history: %w", err)
+		}
+		history = append(history, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating archived order status history: %w", err)
+	}
+
+	return history, nil
+}
+
+// List retrieves archived orders, most recent order date first
+func (r *PostgresOrderArchiveRepository) List(ctx context.Context, filter repositories.ArchivedOrderFilter) ([]*entities.Order, error) {
+	where, args := buildArchivedOrderConditions(filter)
+	query := `SELECT ` + archivedOrderColumns + ` FROM orders_archive` + where + ` ORDER BY order_date DESC`
+
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", len(args)+1)
+		args = append(args, filter.Limit)
+
+		offset := filter.Offset
+		if offset == 0 && filter.Page > 1 {
+			offset = (filter.Page - 1) * filter.Limit
+		}
+		if offset > 0 {
+			query += fmt.Sprintf(" OFFSET $%d", len(args)+1)
+			args = append(args, offset)
+		}
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list archived orders: %w", err)
+	}
+	defer rows.Close()
+
+	var orders []*entities.Order
+	for rows.Next() {
+		order, err := scanArchivedOrder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan archived order row: %w", err)
+		}
+		orders = append(orders, order)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating archived order rows: %w", err)
+	}
+
+	return orders, nil
+}
+
+// Count returns the number of archived orders matching the filter
+func (r *PostgresOrderArchiveRepository) Count(ctx context.Context, filter repositories.ArchivedOrderFilter) (int, error) {
+	where, args := buildArchivedOrderConditions(filter)
+
+	var count int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM orders_archive`+where, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count archived orders: %w", err)
+	}
+
+	return count, nil
+}
+
+// FindArchivable returns the IDs of closed, settled live orders unchanged
+// since before the cutoff and without open returns, oldest first
+func (r *PostgresOrderArchiveRepository) FindArchivable(ctx context.Context, cutoff time.Time, limit int) ([]uuid.UUID, error) {
+	query := `
+		SELECT o.id
+		FROM orders o
+		WHERE o.updated_at < $1
+			AND (
+				(o.status = 'DELIVERED' AND o.paid_amount >= o.total_amount)
+				OR (o.status IN ('CANCELLED', 'REFUNDED') AND o.refunded_amount >= o.paid_amount)
+			)
+			AND NOT EXISTS (
+				SELECT 1 FROM return_authorizations ra
+				WHERE ra.order_id = o.id AND ra.status NOT IN ('REJECTED', 'COMPLETED', 'CANCELLED')
+			)
+		ORDER BY o.updated_at
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, cutoff, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find archivable orders: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan archivable order: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating archivable orders: %w", err)
+	}
+
+	return ids, nil
+}
+
+// HasOpenReturns reports whether a return authorization of the order is still open
+func (r *PostgresOrderArchiveRepository) HasOpenReturns(ctx context.Context, orderID uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM return_authorizations
+			WHERE order_id = $1 AND status NOT IN ('REJECTED', 'COMPLETED', 'CANCELLED')
+		)
+	`
+
+	var open bool
+	if err := r.db.QueryRow(ctx, query, orderID).Scan(&open); err != nil {
+		return false, fmt.Errorf("failed to check open returns: %w", err)
+	}
+
+	return open, nil
+}
+
+// buildArchivedOrderConditions builds the WHERE clause for archived order
+// listings. A year filter keeps the query to one partition.
+func buildArchivedOrderConditions(filter repositories.ArchivedOrderFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Year != nil {
+		args = append(args, *filter.Year)
+		conditions = append(conditions, fmt.Sprintf("order_year = $%d", len(args)))
+	}
+
+	if filter.CustomerID != nil {
+		args = append(args, *filter.CustomerID)
+		conditions = append(conditions, fmt.Sprintf("customer_id = $%d", len(args)))
+	}
+
+	if filter.Search != "" {
+		args = append(args, "%"+filter.Search+"%")
+		conditions = append(conditions, fmt.Sprintf(
+			"(order_number ILIKE $%[1]d OR EXISTS (SELECT 1 FROM order_items_archive oi WHERE oi.order_id = orders_archive.id AND oi.order_year = orders_archive.order_year AND (oi.product_sku ILIKE $%[1]d OR oi.product_name ILIKE $%[1]d)))",
+			len(args)))
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func scanArchivedOrder(row pgx.Row) (*entities.Order, error) {
+	order := &entities.Order{}
+	err := row.Scan(
+		&order.ID,
+		&order.OrderNumber,
+		&order.CustomerID,
+		&order.Status,
+		&order.PreviousStatus,
+		&order.Priority,
+		&order.Type,
+		&order.PaymentStatus,
+		&order.ShippingMethod,
+		&order.Subtotal,
+		&order.TaxAmount,
+		&order.ShippingAmount,
+		&order.DiscountAmount,
+		&order.PromotionDiscountAmount,
+		&order.TotalAmount,
+		&order.PaidAmount,
+		&order.RefundedAmount,
+		&order.Currency,
+		&order.ExchangeRate,
+		&order.CreditHold,
+		&order.OrderDate,
+		&order.RequiredDate,
+		&order.ShippingDate,
+		&order.DeliveryDate,
+		&order.CancelledDate,
+		&order.ShippingAddressID,
+		&order.BillingAddressID,
+		&order.Notes,
+		&order.InternalNotes,
+		&order.CustomerNotes,
+		&order.TrackingNumber,
+		&order.Carrier,
+		&order.CreatedBy,
+		&order.ApprovedBy,
+		&order.ShippedBy,
+		&order.CreatedAt,
+		&order.UpdatedAt,
+		&order.ApprovedAt,
+		&order.ShippedAt,
+		&order.ArchivedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
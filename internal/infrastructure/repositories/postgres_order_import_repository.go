@@ -0,0 +1,298 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
+	"erpgo/pkg/database"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// PostgresOrderImportRepository implements OrderImportRepository for PostgreSQL
+type PostgresOrderImportRepository struct {
+	db *database.Database
+}
+
+// NewPostgresOrderImportRepository creates a new PostgreSQL order import repository
+func NewPostgresOrderImportRepository(db *database.Database) *PostgresOrderImportRepository {
+	return &PostgresOrderImportRepository{
+		db: db,
+	}
+}
+
+const orderImportProfileColumns = `
+	id, name, description, source, format, delimiter, columns, defaults,
+	is_active, created_by, created_at, updated_at
+`
+
+// CreateProfile creates a new order import profile
+func (r *PostgresOrderImportRepository) CreateProfile(ctx context.Context, profile *entities.OrderImportProfile) error {
+	query := `INSERT INTO order_import_profiles (` + orderImportProfileColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+
+	_, err := r.db.Exec(ctx, query,
+		profile.ID,
+		profile.Name,
+		profile.Description,
+		profile.Source,
+		profile.Format,
+		profile.Delimiter,
+		importFieldMap(profile.Columns),
+		importFieldMap(profile.Defaults),
+		profile.IsActive,
+		profile.CreatedBy,
+		profile.CreatedAt,
+		profile.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create order import profile: %w", err)
+	}
+
+	return nil
+}
+
+// GetProfileByID retrieves an order import profile by ID
+func (r *PostgresOrderImportRepository) GetProfileByID(ctx context.Context, id uuid.UUID) (*entities.OrderImportProfile, error) {
+	query := `SELECT ` + orderImportProfileColumns + ` FROM order_import_profiles WHERE id = $1`
+
+	profile, err := scanOrderImportProfile(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("order import profile with id %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get order import profile: %w", err)
+	}
+
+	return profile, nil
+}
+
+// UpdateProfile updates an order import profile
+func (r *PostgresOrderImportRepository) UpdateProfile(ctx context.Context, profile *entities.OrderImportProfile) error {
+	query := `
+		UPDATE order_import_profiles SET
+			name = $2, description = $3, source = $4, format = $5, delimiter = $6,
+			columns = $7, defaults = $8, is_active = $9, updated_at = $10
+		WHERE id = $1
+	`
+
+	result, err := r.db.Exec(ctx, query,
+		profile.ID,
+		profile.Name,
+		profile.Description,
+		profile.Source,
+		profile.Format,
+		profile.Delimiter,
+		importFieldMap(profile.Columns),
+		importFieldMap(profile.Defaults),
+		profile.IsActive,
+		profile.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update order import profile: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("order import profile with id %s not found", profile.ID)
+	}
+
+	return nil
+}
+
+// ListProfiles retrieves order import profiles matching the filter, by name
+func (r *PostgresOrderImportRepository) ListProfiles(ctx context.Context, filter repositories.OrderImportProfileFilter) ([]*entities.OrderImportProfile, error) {
+	where, args := buildOrderImportProfileConditions(filter)
+	query := `SELECT ` + orderImportProfileColumns + ` FROM order_import_profiles` + where + ` ORDER BY name`
+
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", len(args)+1)
+		args = append(args, filter.Limit)
+
+		offset := filter.Offset
+		if offset == 0 && filter.Page > 1 {
+			offset = (filter.Page - 1) * filter.Limit
+		}
+		if offset > 0 {
+			query += fmt.Sprintf(" OFFSET $%d", len(args)+1)
+			args = append(args, offset)
+		}
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query order import profiles: %w", err)
+	}
+	defer rows.Close()
+
+	var profiles []*entities.OrderImportProfile
+	for rows.Next() {
+		profile, err := scanOrderImportProfile(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan order import profile row: %w", err)
+		}
+		profiles = append(profiles, profile)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating order import profile rows: %w", err)
+	}
+
+	return profiles, nil
+}
+
+// CountProfiles returns the number of order import profiles matching the filter
+func (r *PostgresOrderImportRepository) CountProfiles(ctx context.Context, filter repositories.OrderImportProfileFilter) (int, error) {
+	where, args := buildOrderImportProfileConditions(filter)
+
+	var count int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM order_import_profiles`+where, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count order import profiles: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetExternalReferences retrieves the references of a source already imported among the given ones
+func (r *PostgresOrderImportRepository) GetExternalReferences(ctx context.Context, source string, references []string) ([]*entities.OrderExternalReference, error) {
+	if len(references) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT
+			x.source, x.external_reference, x.order_id, x.profile_id, x.created_by,
+			x.created_at, COALESCE(o.order_number, '')
+		FROM order_external_references x
+		LEFT JOIN orders o ON o.id = x.order_id
+		WHERE x.source = $1 AND x.external_reference = ANY($2)
+	`
+
+	rows, err := r.db.Query(ctx, query, source, references)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query order external references: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*entities.OrderExternalReference
+	for rows.Next() {
+		reference := &entities.OrderExternalReference{}
+		err := rows.Scan(
+			&reference.Source,
+			&reference.ExternalReference,
+			&reference.OrderID,
+			&reference.ProfileID,
+			&reference.CreatedBy,
+			&reference.CreatedAt,
+			&reference.OrderNumber,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan order external reference row: %w", err)
+		}
+		result = append(result, reference)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating order external reference rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// ClaimExternalReferences records the references in one transaction
+func (r *PostgresOrderImportRepository) ClaimExternalReferences(ctx context.Context, references []*entities.OrderExternalReference) error {
+	if len(references) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO order_external_references (
+			source, external_reference, order_id, profile_id, created_by, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	for _, reference := range references {
+		_, err := tx.Exec(ctx, query,
+			reference.Source,
+			reference.ExternalReference,
+			reference.OrderID,
+			reference.ProfileID,
+			reference.CreatedBy,
+			reference.CreatedAt,
+		)
+		if err != nil {
+			if strings.Contains(err.Error(), "duplicate key") {
+				return fmt.Errorf("external reference %s of %s already exists", reference.ExternalReference, reference.Source)
+			}
+			return fmt.Errorf("failed to create order external reference: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func buildOrderImportProfileConditions(filter repositories.OrderImportProfileFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Search != "" {
+		args = append(args, "%"+filter.Search+"%")
+		conditions = append(conditions, fmt.Sprintf("name ILIKE $%d", len(args)))
+	}
+
+	if filter.Source != "" {
+		args = append(args, filter.Source)
+		conditions = append(conditions, fmt.Sprintf("source = $%d", len(args)))
+	}
+
+	if filter.IsActive != nil {
+		args = append(args, *filter.IsActive)
+		conditions = append(conditions, fmt.Sprintf("is_active = $%d", len(args)))
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func scanOrderImportProfile(row pgx.Row) (*entities.OrderImportProfile, error) {
+	profile := &entities.OrderImportProfile{}
+	err := row.Scan(
+		&profile.ID,
+		&profile.Name,
+		&profile.Description,
+		&profile.Source,
+		&profile.Format,
+		&profile.Delimiter,
+		&profile.Columns,
+		&profile.Defaults,
+		&profile.IsActive,
+		&profile.CreatedBy,
+		&profile.CreatedAt,
+		&profile.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// importFieldMap stores a missing mapping as an empty JSON object
+func importFieldMap(values map[entities.OrderImportField]string) map[entities.OrderImportField]string {
+	if values == nil {
+		return map[entities.OrderImportField]string{}
+	}
+	return values
+}
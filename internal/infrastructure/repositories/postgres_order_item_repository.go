@@ -30,10 +30,11 @@ func (r *PostgresOrderItemRepository) Create(ctx context.Context, item *entities
 			id, order_id, product_id, product_sku, product_name, quantity,
 			unit_price, discount_amount, tax_rate, tax_amount, total_price,
 			weight, dimensions, barcode, notes, status, quantity_shipped,
-			quantity_returned
+			quantity_returned, quantity_backordered, tax_class, price_includes_tax, is_drop_ship,
+			quantity_refunded
 		) VALUES (
 			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15,
-			$16, $17, $18
+			$16, $17, $18, $19, $20, $21, $22, $23
 		)
 	`
 
@@ -56,6 +57,11 @@ func (r *PostgresOrderItemRepository) Create(ctx context.Context, item *entities
 		item.Status,
 		item.QuantityShipped,
 		item.QuantityReturned,
+		item.QuantityBackordered,
+		item.TaxClass,
+		item.PriceIncludesTax,
+		item.IsDropShip,
+		item.QuantityRefunded,
 	)
 
 	if err != nil {
@@ -72,7 +78,7 @@ func (r *PostgresOrderItemRepository) GetByID(ctx context.Context, id uuid.UUID)
 			id, order_id, product_id, product_sku, product_name, quantity,
 			unit_price, discount_amount, tax_rate, tax_amount, total_price,
 			weight, dimensions, barcode, notes, status, quantity_shipped,
-			quantity_returned, created_at, updated_at
+			quantity_returned, quantity_backordered, tax_class, price_includes_tax, is_drop_ship, quantity_refunded, created_at, updated_at
 		FROM order_items
 		WHERE id = $1
 	`
@@ -97,6 +103,11 @@ func (r *PostgresOrderItemRepository) GetByID(ctx context.Context, id uuid.UUID)
 		&item.Status,
 		&item.QuantityShipped,
 		&item.QuantityReturned,
+		&item.QuantityBackordered,
+		&item.TaxClass,
+		&item.PriceIncludesTax,
+		&item.IsDropShip,
+		&item.QuantityRefunded,
 		&item.CreatedAt,
 		&item.UpdatedAt,
 	)
@@ -119,7 +130,8 @@ func (r *PostgresOrderItemRepository) Update(ctx context.Context, item *entities
 			unit_price = $6, discount_amount = $7, tax_rate = $8, tax_amount = $9,
 			total_price = $10, weight = $11, dimensions = $12, barcode = $13,
 			notes = $14, status = $15, quantity_shipped = $16,
-			quantity_returned = $17, updated_at = CURRENT_TIMESTAMP
+			quantity_returned = $17, quantity_backordered = $18,
+			tax_class = $19, price_includes_tax = $20, is_drop_ship = $21, quantity_refunded = $22, updated_at = CURRENT_TIMESTAMP
 		WHERE id = $1
 	`
 
@@ -141,6 +153,11 @@ func (r *PostgresOrderItemRepository) Update(ctx context.Context, item *entities
 		item.Status,
 		item.QuantityShipped,
 		item.QuantityReturned,
+		item.QuantityBackordered,
+		item.TaxClass,
+		item.PriceIncludesTax,
+		item.IsDropShip,
+		item.QuantityRefunded,
 	)
 
 	if err != nil {
@@ -169,7 +186,7 @@ func (r *PostgresOrderItemRepository) GetByOrderID(ctx context.Context, orderID
 			id, order_id, product_id, product_sku, product_name, quantity,
 			unit_price, discount_amount, tax_rate, tax_amount, total_price,
 			weight, dimensions, barcode, notes, status, quantity_shipped,
-			quantity_returned, created_at, updated_at
+			quantity_returned, quantity_backordered, tax_class, price_includes_tax, is_drop_ship, quantity_refunded, created_at, updated_at
 		FROM order_items
 		WHERE order_id = $1
 		ORDER BY created_at ASC
@@ -203,6 +220,11 @@ func (r *PostgresOrderItemRepository) GetByOrderID(ctx context.Context, orderID
 			&item.Status,
 			&item.QuantityShipped,
 			&item.QuantityReturned,
+			&item.QuantityBackordered,
+			&item.TaxClass,
+			&item.PriceIncludesTax,
+			&item.IsDropShip,
+			&item.QuantityRefunded,
 			&item.CreatedAt,
 			&item.UpdatedAt,
 		)
@@ -226,7 +248,7 @@ func (r *PostgresOrderItemRepository) GetByProductID(ctx context.Context, produc
 			id, order_id, product_id, product_sku, product_name, quantity,
 			unit_price, discount_amount, tax_rate, tax_amount, total_price,
 			weight, dimensions, barcode, notes, status, quantity_shipped,
-			quantity_returned, created_at, updated_at
+			quantity_returned, quantity_backordered, tax_class, price_includes_tax, is_drop_ship, quantity_refunded, created_at, updated_at
 		FROM order_items
 		WHERE product_id = $1
 		ORDER BY created_at DESC
@@ -260,6 +282,11 @@ func (r *PostgresOrderItemRepository) GetByProductID(ctx context.Context, produc
 			&item.Status,
 			&item.QuantityShipped,
 			&item.QuantityReturned,
+			&item.QuantityBackordered,
+			&item.TaxClass,
+			&item.PriceIncludesTax,
+			&item.IsDropShip,
+			&item.QuantityRefunded,
 			&item.CreatedAt,
 			&item.UpdatedAt,
 		)
@@ -283,7 +310,7 @@ func (r *PostgresOrderItemRepository) GetByOrderAndProduct(ctx context.Context,
 			id, order_id, product_id, product_sku, product_name, quantity,
 			unit_price, discount_amount, tax_rate, tax_amount, total_price,
 			weight, dimensions, barcode, notes, status, quantity_shipped,
-			quantity_returned, created_at, updated_at
+			quantity_returned, quantity_backordered, tax_class, price_includes_tax, is_drop_ship, quantity_refunded, created_at, updated_at
 		FROM order_items
 		WHERE order_id = $1 AND product_id = $2
 		LIMIT 1
@@ -309,6 +336,11 @@ func (r *PostgresOrderItemRepository) GetByOrderAndProduct(ctx context.Context,
 		&item.Status,
 		&item.QuantityShipped,
 		&item.QuantityReturned,
+		&item.QuantityBackordered,
+		&item.TaxClass,
+		&item.PriceIncludesTax,
+		&item.IsDropShip,
+		&item.QuantityRefunded,
 		&item.CreatedAt,
 		&item.UpdatedAt,
 	)
@@ -389,10 +421,11 @@ func (r *PostgresOrderItemRepository) BulkCreate(ctx context.Context, items []*e
 			id, order_id, product_id, product_sku, product_name, quantity,
 			unit_price, discount_amount, tax_rate, tax_amount, total_price,
 			weight, dimensions, barcode, notes, status, quantity_shipped,
-			quantity_returned
+			quantity_returned, quantity_backordered, tax_class, price_includes_tax, is_drop_ship,
+			quantity_refunded
 		) VALUES (
 			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15,
-			$16, $17, $18
+			$16, $17, $18, $19, $20, $21, $22, $23
 		)
 	`
 
@@ -416,6 +449,11 @@ func (r *PostgresOrderItemRepository) BulkCreate(ctx context.Context, items []*e
 			item.Status,
 			item.QuantityShipped,
 			item.QuantityReturned,
+			item.QuantityBackordered,
+			item.TaxClass,
+			item.PriceIncludesTax,
+			item.IsDropShip,
+			item.QuantityRefunded,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to bulk create order item: %w", err)
@@ -448,7 +486,8 @@ func (r *PostgresOrderItemRepository) BulkUpdate(ctx context.Context, items []*e
 			unit_price = $6, discount_amount = $7, tax_rate = $8, tax_amount = $9,
 			total_price = $10, weight = $11, dimensions = $12, barcode = $13,
 			notes = $14, status = $15, quantity_shipped = $16,
-			quantity_returned = $17, updated_at = CURRENT_TIMESTAMP
+			quantity_returned = $17, quantity_backordered = $18,
+			tax_class = $19, price_includes_tax = $20, is_drop_ship = $21, quantity_refunded = $22, updated_at = CURRENT_TIMESTAMP
 		WHERE id = $1
 	`
 
@@ -471,6 +510,11 @@ func (r *PostgresOrderItemRepository) BulkUpdate(ctx context.Context, items []*e
 			item.Status,
 			item.QuantityShipped,
 			item.QuantityReturned,
+			item.QuantityBackordered,
+			item.TaxClass,
+			item.PriceIncludesTax,
+			item.IsDropShip,
+			item.QuantityRefunded,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to bulk update order item: %w", err)
@@ -503,7 +547,8 @@ func (r *PostgresOrderItemRepository) GetProductOrderHistory(ctx context.Context
 			oi.id, oi.order_id, oi.product_id, oi.product_sku, oi.product_name,
 			oi.quantity, oi.unit_price, oi.discount_amount, oi.tax_rate,
 			oi.tax_amount, oi.total_price, oi.weight, oi.dimensions, oi.barcode,
-			oi.notes, oi.status, oi.quantity_shipped, oi.quantity_returned,
+			oi.notes, oi.status, oi.quantity_shipped, oi.quantity_returned, oi.quantity_backordered,
+			oi.tax_class, oi.price_includes_tax, oi.is_drop_ship, oi.quantity_refunded,
 			oi.created_at, oi.updated_at
 		FROM order_items oi
 		INNER JOIN orders o ON oi.order_id = o.id
@@ -540,6 +585,11 @@ func (r *PostgresOrderItemRepository) GetProductOrderHistory(ctx context.Context
 			&item.Status,
 			&item.QuantityShipped,
 			&item.QuantityReturned,
+			&item.QuantityBackordered,
+			&item.TaxClass,
+			&item.PriceIncludesTax,
+			&item.IsDropShip,
+			&item.QuantityRefunded,
 			&item.CreatedAt,
 			&item.UpdatedAt,
 		)
@@ -563,7 +613,7 @@ func (r *PostgresOrderItemRepository) GetItemsByStatus(ctx context.Context, stat
 			id, order_id, product_id, product_sku, product_name, quantity,
 			unit_price, discount_amount, tax_rate, tax_amount, total_price,
 			weight, dimensions, barcode, notes, status, quantity_shipped,
-			quantity_returned, created_at, updated_at
+			quantity_returned, quantity_backordered, tax_class, price_includes_tax, is_drop_ship, quantity_refunded, created_at, updated_at
 		FROM order_items
 		WHERE status = $1
 		ORDER BY created_at DESC
@@ -598,6 +648,11 @@ func (r *PostgresOrderItemRepository) GetItemsByStatus(ctx context.Context, stat
 			&item.Status,
 			&item.QuantityShipped,
 			&item.QuantityReturned,
+			&item.QuantityBackordered,
+			&item.TaxClass,
+			&item.PriceIncludesTax,
+			&item.IsDropShip,
+			&item.QuantityRefunded,
 			&item.CreatedAt,
 			&item.UpdatedAt,
 		)
@@ -26,8 +26,28 @@ func NewPostgresOrderRepository(db *database.Database) *PostgresOrderRepository
 	}
 }
 
-// Create creates a new order
+// Create creates a new order. Orders without a number are numbered from the
+// document sequence of their type in the same transaction as the insert, so a
+// failed insert does not leave a gap in the sequence.
 func (r *PostgresOrderRepository) Create(ctx context.Context, order *entities.Order) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	orderNumber := order.OrderNumber
+	if strings.TrimSpace(orderNumber) == "" {
+		at := order.OrderDate
+		if at.IsZero() {
+			at = time.Now().UTC()
+		}
+		orderNumber, err = allocateDocumentNumber(ctx, tx, entities.DocumentTypeForOrder(order.Type), at)
+		if err != nil {
+			return err
+		}
+	}
+
 	query := `
 		INSERT INTO orders (
 			id, order_number, customer_id, status, previous_status, priority, type,
@@ -36,17 +56,17 @@ func (r *PostgresOrderRepository) Create(ctx context.Context, order *entities.Or
 			order_date, required_date, shipping_date, delivery_date, cancelled_date,
 			shipping_address_id, billing_address_id, notes, internal_notes,
 			customer_notes, tracking_number, carrier, created_by, approved_by,
-			shipped_by, approved_at, shipped_at
+			shipped_by, approved_at, shipped_at, promotion_discount_amount, exchange_rate
 		) VALUES (
 			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15,
 			$16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28,
-			$29, $30, $31, $32, $33, $34
+			$29, $30, $31, $32, $33, $34, $35, $36
 		)
 	`
 
-	_, err := r.db.Exec(ctx, query,
+	_, err = tx.Exec(ctx, query,
 		order.ID,
-		order.OrderNumber,
+		orderNumber,
 		order.CustomerID,
 		order.Status,
 		order.PreviousStatus,
@@ -79,12 +99,19 @@ func (r *PostgresOrderRepository) Create(ctx context.Context, order *entities.Or
 		order.ShippedBy,
 		order.ApprovedAt,
 		order.ShippedAt,
+		order.PromotionDiscountAmount,
+		order.ExchangeRate,
 	)
 
 	if err != nil {
 		return fmt.Errorf("failed to create order: %w", err)
 	}
 
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	order.OrderNumber = orderNumber
 	return nil
 }
 
@@ -94,7 +121,8 @@ func (r *PostgresOrderRepository) GetByID(ctx context.Context, id uuid.UUID) (*e
 		SELECT
 			id, order_number, customer_id, status, previous_status, priority, type,
 			payment_status, shipping_method, subtotal, tax_amount, shipping_amount,
-			discount_amount, total_amount, paid_amount, refunded_amount, currency,
+			discount_amount, promotion_discount_amount, total_amount, paid_amount,
+			refunded_amount, currency, exchange_rate, credit_hold,
 			order_date, required_date, shipping_date, delivery_date, cancelled_date,
 			shipping_address_id, billing_address_id, notes, internal_notes,
 			customer_notes, tracking_number, carrier, created_by, approved_by,
@@ -118,10 +146,13 @@ func (r *PostgresOrderRepository) GetByID(ctx context.Context, id uuid.UUID) (*e
 		&order.TaxAmount,
 		&order.ShippingAmount,
 		&order.DiscountAmount,
+		&order.PromotionDiscountAmount,
 		&order.TotalAmount,
 		&order.PaidAmount,
 		&order.RefundedAmount,
 		&order.Currency,
+		&order.ExchangeRate,
+		&order.CreditHold,
 		&order.OrderDate,
 		&order.RequiredDate,
 		&order.ShippingDate,
@@ -159,7 +190,8 @@ func (r *PostgresOrderRepository) GetByOrderNumber(ctx context.Context, orderNum
 		SELECT
 			id, order_number, customer_id, status, previous_status, priority, type,
 			payment_status, shipping_method, subtotal, tax_amount, shipping_amount,
-			discount_amount, total_amount, paid_amount, refunded_amount, currency,
+			discount_amount, promotion_discount_amount, total_amount, paid_amount,
+			refunded_amount, currency, exchange_rate, credit_hold,
 			order_date, required_date, shipping_date, delivery_date, cancelled_date,
 			shipping_address_id, billing_address_id, notes, internal_notes,
 			customer_notes, tracking_number, carrier, created_by, approved_by,
@@ -183,10 +215,13 @@ func (r *PostgresOrderRepository) GetByOrderNumber(ctx context.Context, orderNum
 		&order.TaxAmount,
 		&order.ShippingAmount,
 		&order.DiscountAmount,
+		&order.PromotionDiscountAmount,
 		&order.TotalAmount,
 		&order.PaidAmount,
 		&order.RefundedAmount,
 		&order.Currency,
+		&order.ExchangeRate,
+		&order.CreditHold,
 		&order.OrderDate,
 		&order.RequiredDate,
 		&order.ShippingDate,
@@ -230,7 +265,9 @@ func (r *PostgresOrderRepository) Update(ctx context.Context, order *entities.Or
 			shipping_address_id = $21, billing_address_id = $22, notes = $23,
 			internal_notes = $24, customer_notes = $25, tracking_number = $26,
 			carrier = $27, approved_by = $28, shipped_by = $29, approved_at = $30,
-			shipped_at = $31, updated_at = CURRENT_TIMESTAMP
+			shipped_at = $31, promotion_discount_amount = $32, exchange_rate = $33,
+			credit_hold = $34,
+			updated_at = CURRENT_TIMESTAMP
 		WHERE id = $1
 	`
 
@@ -266,6 +303,9 @@ func (r *PostgresOrderRepository) Update(ctx context.Context, order *entities.Or
 		order.ShippedBy,
 		order.ApprovedAt,
 		order.ShippedAt,
+		order.PromotionDiscountAmount,
+		order.ExchangeRate,
+		order.CreditHold,
 	)
 
 	if err != nil {
@@ -287,6 +327,19 @@ func (r *PostgresOrderRepository) Delete(ctx context.Context, id uuid.UUID) erro
 	return nil
 }
 
+// Lock locks an order's row until the transaction of the context ends
+func (r *PostgresOrderRepository) Lock(ctx context.Context, id uuid.UUID) error {
+	var locked uuid.UUID
+	err := r.db.QueryRow(ctx, `SELECT id FROM orders WHERE id = $1 FOR UPDATE`, id).Scan(&locked)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("order with id %s not found", id)
+		}
+		return fmt.Errorf("failed to lock order: %w", err)
+	}
+	return nil
+}
+
 // List retrieves a list of orders with filtering
 func (r *PostgresOrderRepository) List(ctx context.Context, filter repositories.OrderFilter) ([]*entities.Order, error) {
 	baseQuery, args, err := r.buildOrderQuery(filter, false)
@@ -317,10 +370,13 @@ func (r *PostgresOrderRepository) List(ctx context.Context, filter repositories.
 			&order.TaxAmount,
 			&order.ShippingAmount,
 			&order.DiscountAmount,
+			&order.PromotionDiscountAmount,
 			&order.TotalAmount,
 			&order.PaidAmount,
 			&order.RefundedAmount,
 			&order.Currency,
+			&order.ExchangeRate,
+			&order.CreditHold,
 			&order.OrderDate,
 			&order.RequiredDate,
 			&order.ShippingDate,
@@ -380,7 +436,8 @@ func (r *PostgresOrderRepository) buildOrderQuery(filter repositories.OrderFilte
 			SELECT
 				id, order_number, customer_id, status, previous_status, priority, type,
 				payment_status, shipping_method, subtotal, tax_amount, shipping_amount,
-				discount_amount, total_amount, paid_amount, refunded_amount, currency,
+				discount_amount, promotion_discount_amount, total_amount, paid_amount,
+				refunded_amount, currency, exchange_rate, credit_hold,
 				order_date, required_date, shipping_date, delivery_date, cancelled_date,
 				shipping_address_id, billing_address_id, notes, internal_notes,
 				customer_notes, tracking_number, carrier, created_by, approved_by,
@@ -607,7 +664,8 @@ func (r *PostgresOrderRepository) GetOverdueOrders(ctx context.Context) ([]*enti
 		SELECT
 			id, order_number, customer_id, status, previous_status, priority, type,
 			payment_status, shipping_method, subtotal, tax_amount, shipping_amount,
-			discount_amount, total_amount, paid_amount, refunded_amount, currency,
+			discount_amount, promotion_discount_amount, total_amount, paid_amount,
+			refunded_amount, currency, exchange_rate, credit_hold,
 			order_date, required_date, shipping_date, delivery_date, cancelled_date,
 			shipping_address_id, billing_address_id, notes, internal_notes,
 			customer_notes, tracking_number, carrier, created_by, approved_by,
@@ -642,10 +700,13 @@ func (r *PostgresOrderRepository) GetOverdueOrders(ctx context.Context) ([]*enti
 			&order.TaxAmount,
 			&order.ShippingAmount,
 			&order.DiscountAmount,
+			&order.PromotionDiscountAmount,
 			&order.TotalAmount,
 			&order.PaidAmount,
 			&order.RefundedAmount,
 			&order.Currency,
+			&order.ExchangeRate,
+			&order.CreditHold,
 			&order.OrderDate,
 			&order.RequiredDate,
 			&order.ShippingDate,
@@ -694,7 +755,8 @@ func (r *PostgresOrderRepository) GetUnpaidOrders(ctx context.Context) ([]*entit
 		SELECT
 			id, order_number, customer_id, status, previous_status, priority, type,
 			payment_status, shipping_method, subtotal, tax_amount, shipping_amount,
-			discount_amount, total_amount, paid_amount, refunded_amount, currency,
+			discount_amount, promotion_discount_amount, total_amount, paid_amount,
+			refunded_amount, currency, exchange_rate, credit_hold,
 			order_date, required_date, shipping_date, delivery_date, cancelled_date,
 			shipping_address_id, billing_address_id, notes, internal_notes,
 			customer_notes, tracking_number, carrier, created_by, approved_by,
@@ -728,10 +790,13 @@ func (r *PostgresOrderRepository) GetUnpaidOrders(ctx context.Context) ([]*entit
 			&order.TaxAmount,
 			&order.ShippingAmount,
 			&order.DiscountAmount,
+			&order.PromotionDiscountAmount,
 			&order.TotalAmount,
 			&order.PaidAmount,
 			&order.RefundedAmount,
 			&order.Currency,
+			&order.ExchangeRate,
+			&order.CreditHold,
 			&order.OrderDate,
 			&order.RequiredDate,
 			&order.ShippingDate,
@@ -765,6 +830,101 @@ func (r *PostgresOrderRepository) GetUnpaidOrders(ctx context.Context) ([]*entit
 	return orders, nil
 }
 
+// GetOpenReceivables retrieves shipped orders with an outstanding balance,
+// oldest first, with the customer whose payment terms they fall due under
+func (r *PostgresOrderRepository) GetOpenReceivables(ctx context.Context, customerID *uuid.UUID) ([]*entities.Order, error) {
+	query := `
+		SELECT
+			o.id, o.order_number, o.customer_id, o.status, o.previous_status, o.priority, o.type,
+			o.payment_status, o.shipping_method, o.subtotal, o.tax_amount, o.shipping_amount,
+			o.discount_amount, o.promotion_discount_amount, o.total_amount, o.paid_amount,
+			o.refunded_amount, o.currency, o.exchange_rate, o.credit_hold,
+			o.order_date, o.required_date, o.shipping_date, o.delivery_date, o.cancelled_date,
+			o.shipping_address_id, o.billing_address_id, o.notes, o.internal_notes,
+			o.customer_notes, o.tracking_number, o.carrier, o.created_by, o.approved_by,
+			o.shipped_by, o.created_at, o.updated_at, o.approved_at, o.shipped_at,
+			c.customer_code, c.type, c.first_name, c.last_name, c.company_name, c.terms
+		FROM orders o
+		INNER JOIN customers c ON c.id = o.customer_id
+		WHERE (o.shipped_at IS NOT NULL OR o.shipping_date IS NOT NULL)
+		AND o.total_amount > o.paid_amount
+		AND o.status NOT IN ('CANCELLED', 'REFUNDED', 'RETURNED')
+		AND ($1::uuid IS NULL OR o.customer_id = $1)
+		ORDER BY COALESCE(o.shipped_at, o.shipping_date) ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, customerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open receivables: %w", err)
+	}
+	defer rows.Close()
+
+	var orders []*entities.Order
+	for rows.Next() {
+		order := &entities.Order{}
+		customer := &entities.Customer{}
+		err := rows.Scan(
+			&order.ID,
+			&order.OrderNumber,
+			&order.CustomerID,
+			&order.Status,
+			&order.PreviousStatus,
+			&order.Priority,
+			&order.Type,
+			&order.PaymentStatus,
+			&order.ShippingMethod,
+			&order.Subtotal,
+			&order.TaxAmount,
+			&order.ShippingAmount,
+			&order.DiscountAmount,
+			&order.PromotionDiscountAmount,
+			&order.TotalAmount,
+			&order.PaidAmount,
+			&order.RefundedAmount,
+			&order.Currency,
+			&order.ExchangeRate,
+			&order.CreditHold,
+			&order.OrderDate,
+			&order.RequiredDate,
+			&order.ShippingDate,
+			&order.DeliveryDate,
+			&order.CancelledDate,
+			&order.ShippingAddressID,
+			&order.BillingAddressID,
+			&order.Notes,
+			&order.InternalNotes,
+			&order.CustomerNotes,
+			&order.TrackingNumber,
+			&order.Carrier,
+			&order.CreatedBy,
+			&order.ApprovedBy,
+			&order.ShippedBy,
+			&order.CreatedAt,
+			&order.UpdatedAt,
+			&order.ApprovedAt,
+			&order.ShippedAt,
+			&customer.CustomerCode,
+			&customer.Type,
+			&customer.FirstName,
+			&customer.LastName,
+			&customer.CompanyName,
+			&customer.Terms,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan open receivable row: %w", err)
+		}
+		customer.ID = order.CustomerID
+		order.Customer = customer
+		orders = append(orders, order)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating open receivable rows: %w", err)
+	}
+
+	return orders, nil
+}
+
 // GetOrdersByPaymentStatus retrieves orders by payment status
 func (r *PostgresOrderRepository) GetOrdersByPaymentStatus(ctx context.Context, paymentStatus entities.PaymentStatus) ([]*entities.Order, error) {
 	filter := repositories.OrderFilter{
@@ -790,6 +950,26 @@ func (r *PostgresOrderRepository) UpdatePaymentStatus(ctx context.Context, order
 	return nil
 }
 
+// GetCustomerCreditExposure returns the customer's open receivables and
+// unshipped approved orders in the base currency
+func (r *PostgresOrderRepository) GetCustomerCreditExposure(ctx context.Context, customerID uuid.UUID) (decimal.Decimal, error) {
+	query := `
+		SELECT ROUND(COALESCE(SUM((total_amount - paid_amount) * exchange_rate), 0), 2)
+		FROM orders
+		WHERE customer_id = $1
+		AND approved_at IS NOT NULL
+		AND status NOT IN ('CANCELLED', 'REFUNDED', 'RETURNED')
+		AND total_amount > paid_amount
+	`
+
+	var exposure decimal.Decimal
+	if err := r.db.QueryRow(ctx, query, customerID).Scan(&exposure); err != nil {
+		return decimal.Zero, fmt.Errorf("failed to get customer credit exposure: %w", err)
+	}
+
+	return exposure, nil
+}
+
 // BulkUpdateStatus updates status for multiple orders
 func (r *PostgresOrderRepository) BulkUpdateStatus(ctx context.Context, orderIDs []uuid.UUID, newStatus entities.OrderStatus, updatedBy uuid.UUID) error {
 	if len(orderIDs) == 0 {
@@ -820,7 +1000,8 @@ func (r *PostgresOrderRepository) BulkUpdateStatus(ctx context.Context, orderIDs
 	return nil
 }
 
-// BulkCreate creates multiple orders
+// BulkCreate creates multiple orders in one transaction. Orders without a
+// number are numbered from the document sequence of their type.
 func (r *PostgresOrderRepository) BulkCreate(ctx context.Context, orders []*entities.Order) error {
 	if len(orders) == 0 {
 		return nil
@@ -841,18 +1022,31 @@ func (r *PostgresOrderRepository) BulkCreate(ctx context.Context, orders []*enti
 			order_date, required_date, shipping_date, delivery_date, cancelled_date,
 			shipping_address_id, billing_address_id, notes, internal_notes,
 			customer_notes, tracking_number, carrier, created_by, approved_by,
-			shipped_by, approved_at, shipped_at
+			shipped_by, approved_at, shipped_at, promotion_discount_amount, exchange_rate
 		) VALUES (
 			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15,
 			$16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28,
-			$29, $30, $31, $32, $33, $34
+			$29, $30, $31, $32, $33, $34, $35, $36
 		)
 	`
 
-	for _, order := range orders {
+	numbers := make([]string, len(orders))
+	for i, order := range orders {
+		numbers[i] = order.OrderNumber
+		if strings.TrimSpace(numbers[i]) == "" {
+			at := order.OrderDate
+			if at.IsZero() {
+				at = time.Now().UTC()
+			}
+			numbers[i], err = allocateDocumentNumber(ctx, tx, entities.DocumentTypeForOrder(order.Type), at)
+			if err != nil {
+				return err
+			}
+		}
+
 		_, err := tx.Exec(ctx, query,
 			order.ID,
-			order.OrderNumber,
+			numbers[i],
 			order.CustomerID,
 			order.Status,
 			order.PreviousStatus,
@@ -885,6 +1079,8 @@ func (r *PostgresOrderRepository) BulkCreate(ctx context.Context, orders []*enti
 			order.ShippedBy,
 			order.ApprovedAt,
 			order.ShippedAt,
+			order.PromotionDiscountAmount,
+			order.ExchangeRate,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to bulk create order: %w", err)
@@ -895,6 +1091,9 @@ func (r *PostgresOrderRepository) BulkCreate(ctx context.Context, orders []*enti
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
+	for i, order := range orders {
+		order.OrderNumber = numbers[i]
+	}
 	return nil
 }
 
@@ -903,8 +1102,8 @@ func (r *PostgresOrderRepository) GetOrderStats(ctx context.Context, filter repo
 	query := `
 		SELECT
 			COUNT(*) as total_orders,
-			COALESCE(SUM(total_amount), 0) as total_revenue,
-			COALESCE(AVG(total_amount), 0) as average_order_value
+			ROUND(COALESCE(SUM(total_amount * exchange_rate), 0), 2) as total_revenue,
+			ROUND(COALESCE(AVG(total_amount * exchange_rate), 0), 2) as average_order_value
 		FROM orders
 		WHERE order_date >= $1 AND order_date <= $2
 	`
@@ -1071,7 +1270,8 @@ func (r *PostgresOrderRepository) GetOrdersWithItems(ctx context.Context, orderI
 		SELECT
 			id, order_number, customer_id, status, previous_status, priority, type,
 			payment_status, shipping_method, subtotal, tax_amount, shipping_amount,
-			discount_amount, total_amount, paid_amount, refunded_amount, currency,
+			discount_amount, promotion_discount_amount, total_amount, paid_amount,
+			refunded_amount, currency, exchange_rate, credit_hold,
 			order_date, required_date, shipping_date, delivery_date, cancelled_date,
 			shipping_address_id, billing_address_id, notes, internal_notes,
 			customer_notes, tracking_number, carrier, created_by, approved_by,
@@ -1104,10 +1304,13 @@ func (r *PostgresOrderRepository) GetOrdersWithItems(ctx context.Context, orderI
 			&order.TaxAmount,
 			&order.ShippingAmount,
 			&order.DiscountAmount,
+			&order.PromotionDiscountAmount,
 			&order.TotalAmount,
 			&order.PaidAmount,
 			&order.RefundedAmount,
 			&order.Currency,
+			&order.ExchangeRate,
+			&order.CreditHold,
 			&order.OrderDate,
 			&order.RequiredDate,
 			&order.ShippingDate,
@@ -1154,27 +1357,26 @@ func (r *PostgresOrderRepository) ExistsByOrderNumber(ctx context.Context, order
 	return exists, nil
 }
 
-// GenerateUniqueOrderNumber generates a unique order number
+// GenerateUniqueOrderNumber allocates the next sales order number.
+//
+// The number is committed as soon as it is allocated, so it becomes a gap if
+// the caller never persists an order with it. Create numbers orders itself and
+// should be preferred.
 func (r *PostgresOrderRepository) GenerateUniqueOrderNumber(ctx context.Context) (string, error) {
-	// Generate order number with format: ORD-YYYYMMDD-XXXXX
-	dateStr := time.Now().Format("20060102")
-
-	for i := 0; i < 10; i++ { // Try 10 times to generate unique number
-		randomSuffix := fmt.Sprintf("%05d", time.Now().UnixNano()%100000)
-		orderNumber := fmt.Sprintf("ORD-%s-%s", dateStr, randomSuffix)
-
-		exists, err := r.ExistsByOrderNumber(ctx, orderNumber)
-		if err != nil {
-			return "", fmt.Errorf("failed to check order number uniqueness: %w", err)
-		}
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
 
-		if !exists {
-			return orderNumber, nil
-		}
+	orderNumber, err := allocateDocumentNumber(ctx, tx, entities.DocumentTypeSalesOrder, time.Now().UTC())
+	if err != nil {
+		return "", err
+	}
 
-		// Wait a bit before trying again
-		time.Sleep(time.Millisecond * 10)
+	if err = tx.Commit(ctx); err != nil {
+		return "", fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	return "", fmt.Errorf("failed to generate unique order number after 10 attempts")
+	return orderNumber, nil
 }
@@ -0,0 +1,174 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/pkg/database"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// PostgresOrderRevisionRepository implements OrderRevisionRepository for PostgreSQL
+type PostgresOrderRevisionRepository struct {
+	db *database.Database
+}
+
+// NewPostgresOrderRevisionRepository creates a new PostgreSQL order revision repository
+func NewPostgresOrderRevisionRepository(db *database.Database) *PostgresOrderRevisionRepository {
+	return &PostgresOrderRevisionRepository{
+		db: db,
+	}
+}
+
+const orderRevisionColumns = `
+	id, order_id, revision_number, status, reason, amendment, snapshot, changes,
+	approver_roles, approval_amount, approvals, created_by, created_at,
+	applied_at, rejected_by, rejected_at, rejection_reason
+`
+
+// Create creates a new order revision
+func (r *PostgresOrderRevisionRepository) Create(ctx context.Context, revision *entities.OrderRevision) error {
+	query := `INSERT INTO order_revisions (` + orderRevisionColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)`
+
+	_, err := r.db.Exec(ctx, query,
+		revision.ID,
+		revision.OrderID,
+		revision.RevisionNumber,
+		revision.Status,
+		revision.Reason,
+		revision.Amendment,
+		revision.Snapshot,
+		revisionChanges(revision.Changes),
+		stringArray(revision.ApproverRoles),
+		revision.ApprovalAmount,
+		revisionApprovals(revision.Approvals),
+		revision.CreatedBy,
+		revision.CreatedAt,
+		revision.AppliedAt,
+		revision.RejectedBy,
+		revision.RejectedAt,
+		revision.RejectionReason,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create order revision: %w", err)
+	}
+
+	return nil
+}
+
+// Update records the approvals, rejection or application of a revision
+func (r *PostgresOrderRevisionRepository) Update(ctx context.Context, revision *entities.OrderRevision) error {
+	query := `
+		UPDATE order_revisions SET
+			status = $2, snapshot = $3, changes = $4, approvals = $5, applied_at = $6,
+			rejected_by = $7, rejected_at = $8, rejection_reason = $9
+		WHERE id = $1
+	`
+
+	result, err := r.db.Exec(ctx, query,
+		revision.ID,
+		revision.Status,
+		revision.Snapshot,
+		revisionChanges(revision.Changes),
+		revisionApprovals(revision.Approvals),
+		revision.AppliedAt,
+		revision.RejectedBy,
+		revision.RejectedAt,
+		revision.RejectionReason,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update order revision: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("order revision with id %s not found", revision.ID)
+	}
+
+	return nil
+}
+
+// GetByNumber retrieves a revision of an order by its number
+func (r *PostgresOrderRevisionRepository) GetByNumber(ctx context.Context, orderID uuid.UUID, revisionNumber int) (*entities.OrderRevision, error) {
+	query := `SELECT ` + orderRevisionColumns + ` FROM order_revisions WHERE order_id = $1 AND revision_number = $2`
+
+	revision, err := scanOrderRevision(r.db.QueryRow(ctx, query, orderID, revisionNumber))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("order revision %d of order %s not found", revisionNumber, orderID)
+		}
+		return nil, fmt.Errorf("failed to get order revision: %w", err)
+	}
+
+	return revision, nil
+}
+
+// GetByOrderID retrieves the revisions of an order, oldest first
+func (r *PostgresOrderRevisionRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.OrderRevision, error) {
+	query := `SELECT ` + orderRevisionColumns + ` FROM order_revisions WHERE order_id = $1 ORDER BY revision_number`
+
+	rows, err := r.db.Query(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query order revisions: %w", err)
+	}
+	defer rows.Close()
+
+	var revisions []*entities.OrderRevision
+	for rows.Next() {
+		revision, err := scanOrderRevision(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan order revision row: %w", err)
+		}
+		revisions = append(revisions, revision)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating order revision rows: %w", err)
+	}
+
+	return revisions, nil
+}
+
+func scanOrderRevision(row pgx.Row) (*entities.OrderRevision, error) {
+	revision := &entities.OrderRevision{}
+	err := row.Scan(
+		&revision.ID,
+		&revision.OrderID,
+		&revision.RevisionNumber,
+		&revision.Status,
+		&revision.Reason,
+		&revision.Amendment,
+		&revision.Snapshot,
+		&revision.Changes,
+		&revision.ApproverRoles,
+		&revision.ApprovalAmount,
+		&revision.Approvals,
+		&revision.CreatedBy,
+		&revision.CreatedAt,
+		&revision.AppliedAt,
+		&revision.RejectedBy,
+		&revision.RejectedAt,
+		&revision.RejectionReason,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return revision, nil
+}
+
+// revisionChanges stores a revision without changes as an empty JSON array
+func revisionChanges(changes []entities.OrderRevisionChange) []entities.OrderRevisionChange {
+	if changes == nil {
+		return []entities.OrderRevisionChange{}
+	}
+	return changes
+}
+
+// revisionApprovals stores a revision without approvals as an empty JSON array
+func revisionApprovals(approvals []entities.OrderRevisionApproval) []entities.OrderRevisionApproval {
+	if approvals == nil {
+		return []entities.OrderRevisionApproval{}
+	}
+	return approvals
+}
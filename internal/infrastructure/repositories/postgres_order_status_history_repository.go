@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/pkg/database"
+	"github.com/google/uuid"
+)
+
+// PostgresOrderStatusHistoryRepository implements OrderStatusHistoryRepository for PostgreSQL
+type PostgresOrderStatusHistoryRepository struct {
+	db *database.Database
+}
+
+// NewPostgresOrderStatusHistoryRepository creates a new PostgreSQL order status history repository
+func NewPostgresOrderStatusHistoryRepository(db *database.Database) *PostgresOrderStatusHistoryRepository {
+	return &PostgresOrderStatusHistoryRepository{
+		db: db,
+	}
+}
+
+// Create appends a status history entry
+func (r *PostgresOrderStatusHistoryRepository) Create(ctx context.Context, entry *entities.OrderStatusHistory) error {
+	if entry.ID == uuid.Nil {
+		entry.ID = uuid.New()
+	}
+
+	// clock_timestamp keeps entries written in one transaction in order
+	query := `
+		INSERT INTO order_status_history (
+			id, order_id, status_type, from_status, to_status, amount,
+			changed_by, reason, source, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), $9, clock_timestamp())
+		RETURNING created_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		entry.ID,
+		entry.OrderID,
+		entry.Type,
+		entry.FromStatus,
+		entry.ToStatus,
+		entry.Amount,
+		entry.ChangedBy,
+		entry.Reason,
+		entry.Source,
+	).Scan(&entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create order status history: %w", err)
+	}
+
+	return nil
+}
+
+// GetByOrderID retrieves the status history of an order in chronological order
+func (r *PostgresOrderStatusHistoryRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.OrderStatusHistory, error) {
+	query := `
+		SELECT id, order_id, status_type, from_status, to_status, amount,
+			changed_by, COALESCE(reason, ''), source, created_at
+		FROM order_status_history
+		WHERE order_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.Query(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order status history: %w", err)
+	}
+	defer rows.Close()
+
+	var history []*entities.OrderStatusHistory
+	for rows.Next() {
+		entry := &entities.OrderStatusHistory{}
+		err := rows.Scan(
+			&entry.ID,
+			&entry.OrderID,
+			&entry.Type,
+			&entry.FromStatus,
+			&entry.ToStatus,
+			&entry.Amount,
+			&entry.ChangedBy,
+			&entry.Reason,
+			&entry.Source,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan order status history: %w", err)
+		}
+		history = append(history, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating order status history: %w", err)
+	}
+
+	return history, nil
+}
@@ -0,0 +1,390 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
+	"erpgo/pkg/database"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// PostgresPaymentRepository implements PaymentRepository for PostgreSQL
+type PostgresPaymentRepository struct {
+	db *database.Database
+}
+
+// NewPostgresPaymentRepository creates a new PostgreSQL payment repository
+func NewPostgresPaymentRepository(db *database.Database) *PostgresPaymentRepository {
+	return &PostgresPaymentRepository{
+		db: db,
+	}
+}
+
+const paymentColumns = `
+	id, payment_number, type, customer_id, method, reference, amount, currency,
+	received_at, status, notes, reversed_at, reversed_by, reversal_reason,
+	created_by, created_at, updated_at
+`
+
+const paymentAllocationColumns = `id, payment_id, order_id, amount, created_at`
+
+// Create creates a new ledger entry with its allocations
+func (r *PostgresPaymentRepository) Create(ctx context.Context, payment *entities.Payment) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	paymentNumber := payment.PaymentNumber
+	if strings.TrimSpace(paymentNumber) == "" {
+		paymentNumber, err = allocateDocumentNumber(ctx, tx, entities.DocumentTypePayment, payment.CreatedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	query := `
+		INSERT INTO payments (` + paymentColumns + `) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17
+		)
+	`
+
+	_, err = tx.Exec(ctx, query,
+		payment.ID,
+		paymentNumber,
+		payment.Type,
+		payment.CustomerID,
+		payment.Method,
+		payment.Reference,
+		payment.Amount,
+		payment.Currency,
+		payment.ReceivedAt,
+		payment.Status,
+		payment.Notes,
+		payment.ReversedAt,
+		payment.ReversedBy,
+		payment.ReversalReason,
+		payment.CreatedBy,
+		payment.CreatedAt,
+		payment.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create payment: %w", err)
+	}
+
+	if err := insertPaymentAllocations(ctx, tx, payment); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	payment.PaymentNumber = paymentNumber
+	return nil
+}
+
+// Lock locks a ledger entry's row until the transaction of the context ends
+func (r *PostgresPaymentRepository) Lock(ctx context.Context, id uuid.UUID) error {
+	var locked uuid.UUID
+	err := r.db.QueryRow(ctx, `SELECT id FROM payments WHERE id = $1 FOR UPDATE`, id).Scan(&locked)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("payment with id %s not found", id)
+		}
+		return fmt.Errorf("failed to lock payment: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a ledger entry with its allocations
+func (r *PostgresPaymentRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Payment, error) {
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE id = $1`
+
+	payment, err := scanPayment(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("payment with id %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	if err := r.loadAllocations(ctx, []*entities.Payment{payment}); err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// GetByOrderID retrieves the ledger entries allocated to an order, oldest first
+func (r *PostgresPaymentRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.Payment, error) {
+	query := `SELECT ` + paymentColumns + ` FROM payments
+		WHERE id IN (SELECT payment_id FROM payment_allocations WHERE order_id = $1)
+		ORDER BY received_at, created_at`
+
+	return r.query(ctx, query, orderID)
+}
+
+// Update updates the status of a ledger entry and stores its new allocations.
+// Amounts and existing allocations never change; corrections are reversals.
+func (r *PostgresPaymentRepository) Update(ctx context.Context, payment *entities.Payment) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE payments SET
+			status = $2, notes = $3, reversed_at = $4, reversed_by = $5,
+			reversal_reason = $6, updated_at = $7
+		WHERE id = $1
+	`
+
+	result, err := tx.Exec(ctx, query,
+		payment.ID,
+		payment.Status,
+		payment.Notes,
+		payment.ReversedAt,
+		payment.ReversedBy,
+		payment.ReversalReason,
+		payment.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update payment: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("payment with id %s not found", payment.ID)
+	}
+
+	if err := insertPaymentAllocations(ctx, tx, payment); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves ledger entries matching the filter with their allocations, newest first
+func (r *PostgresPaymentRepository) List(ctx context.Context, filter repositories.PaymentFilter) ([]*entities.Payment, error) {
+	where, args := buildPaymentConditions(filter)
+	query := `SELECT ` + paymentColumns + ` FROM payments` + where + ` ORDER BY received_at DESC, created_at DESC`
+
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", len(args)+1)
+		args = append(args, filter.Limit)
+
+		offset := filter.Offset
+		if offset == 0 && filter.Page > 1 {
+			offset = (filter.Page - 1) * filter.Limit
+		}
+		if offset > 0 {
+			query += fmt.Sprintf(" OFFSET $%d", len(args)+1)
+			args = append(args, offset)
+		}
+	}
+
+	return r.query(ctx, query, args...)
+}
+
+// Count returns the number of ledger entries matching the filter
+func (r *PostgresPaymentRepository) Count(ctx context.Context, filter repositories.PaymentFilter) (int, error) {
+	where, args := buildPaymentConditions(filter)
+
+	var count int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM payments`+where, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count payments: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *PostgresPaymentRepository) query(ctx context.Context, query string, args ...interface{}) ([]*entities.Payment, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query payments: %w", err)
+	}
+	defer rows.Close()
+
+	var payments []*entities.Payment
+	for rows.Next() {
+		payment, err := scanPayment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan payment row: %w", err)
+		}
+		payments = append(payments, payment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating payment rows: %w", err)
+	}
+
+	if err := r.loadAllocations(ctx, payments); err != nil {
+		return nil, err
+	}
+
+	return payments, nil
+}
+
+// loadAllocations loads the allocations of several entries in one query
+func (r *PostgresPaymentRepository) loadAllocations(ctx context.Context, payments []*entities.Payment) error {
+	if len(payments) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(payments))
+	byID := make(map[uuid.UUID]*entities.Payment, len(payments))
+	for i, payment := range payments {
+		ids[i] = payment.ID
+		byID[payment.ID] = payment
+		payment.Allocations = nil
+	}
+
+	query := `SELECT ` + paymentAllocationColumns + ` FROM payment_allocations WHERE payment_id = ANY($1) ORDER BY created_at, id`
+
+	rows, err := r.db.Query(ctx, query, ids)
+	if err != nil {
+		return fmt.Errorf("failed to get payment allocations: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var allocation entities.PaymentAllocation
+		err := rows.Scan(
+			&allocation.ID,
+			&allocation.PaymentID,
+			&allocation.OrderID,
+			&allocation.Amount,
+			&allocation.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to scan payment allocation: %w", err)
+		}
+		payment := byID[allocation.PaymentID]
+		payment.Allocations = append(payment.Allocations, allocation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating payment allocations: %w", err)
+	}
+
+	return nil
+}
+
+// insertPaymentAllocations stores the allocations of an entry that are not stored yet
+func insertPaymentAllocations(ctx context.Context, tx pgx.Tx, payment *entities.Payment) error {
+	query := `
+		INSERT INTO payment_allocations (` + paymentAllocationColumns + `) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (id) DO NOTHING
+	`
+	for _, allocation := range payment.Allocations {
+		_, err := tx.Exec(ctx, query,
+			allocation.ID,
+			payment.ID,
+			allocation.OrderID,
+			allocation.Amount,
+			allocation.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create payment allocation: %w", err)
+		}
+	}
+	return nil
+}
+
+func buildPaymentConditions(filter repositories.PaymentFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Search != "" {
+		args = append(args, "%"+filter.Search+"%")
+		conditions = append(conditions, fmt.Sprintf("(payment_number ILIKE $%d OR reference ILIKE $%d)", len(args), len(args)))
+	}
+
+	if filter.Type != nil {
+		args = append(args, *filter.Type)
+		conditions = append(conditions, fmt.Sprintf("type = $%d", len(args)))
+	}
+
+	if len(filter.Method) > 0 {
+		placeholders := make([]string, len(filter.Method))
+		for i, method := range filter.Method {
+			args = append(args, method)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		conditions = append(conditions, "method IN ("+strings.Join(placeholders, ", ")+")")
+	}
+
+	if len(filter.Status) > 0 {
+		placeholders := make([]string, len(filter.Status))
+		for i, status := range filter.Status {
+			args = append(args, status)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		conditions = append(conditions, "status IN ("+strings.Join(placeholders, ", ")+")")
+	}
+
+	if filter.CustomerID != nil {
+		args = append(args, *filter.CustomerID)
+		conditions = append(conditions, fmt.Sprintf("customer_id = $%d", len(args)))
+	}
+
+	if filter.OrderID != nil {
+		args = append(args, *filter.OrderID)
+		conditions = append(conditions, fmt.Sprintf("id IN (SELECT payment_id FROM payment_allocations WHERE order_id = $%d)", len(args)))
+	}
+
+	if filter.ReceivedFrom != nil {
+		args = append(args, *filter.ReceivedFrom)
+		conditions = append(conditions, fmt.Sprintf("received_at >= $%d", len(args)))
+	}
+
+	if filter.ReceivedTo != nil {
+		args = append(args, *filter.ReceivedTo)
+		conditions = append(conditions, fmt.Sprintf("received_at <= $%d", len(args)))
+	}
+
+	if filter.Unallocated {
+		conditions = append(conditions, "status = 'COMPLETED' AND amount > COALESCE((SELECT SUM(pa.amount) FROM payment_allocations pa WHERE pa.payment_id = payments.id), 0)")
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func scanPayment(row pgx.Row) (*entities.Payment, error) {
+	payment := &entities.Payment{}
+	err := row.Scan(
+		&payment.ID,
+		&payment.PaymentNumber,
+		&payment.Type,
+		&payment.CustomerID,
+		&payment.Method,
+		&payment.Reference,
+		&payment.Amount,
+		&payment.Currency,
+		&payment.ReceivedAt,
+		&payment.Status,
+		&payment.Notes,
+		&payment.ReversedAt,
+		&payment.ReversedBy,
+		&payment.ReversalReason,
+		&payment.CreatedBy,
+		&payment.CreatedAt,
+		&payment.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
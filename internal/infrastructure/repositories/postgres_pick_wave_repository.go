@@ -0,0 +1,514 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
+	"erpgo/pkg/database"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// PostgresPickWaveRepository implements PickWaveRepository for PostgreSQL
+type PostgresPickWaveRepository struct {
+	db *database.Database
+}
+
+// NewPostgresPickWaveRepository creates a new PostgreSQL pick wave repository
+func NewPostgresPickWaveRepository(db *database.Database) *PostgresPickWaveRepository {
+	return &PostgresPickWaveRepository{
+		db: db,
+	}
+}
+
+const pickWaveColumns = `
+	id, wave_number, warehouse_id, status, shipping_method, min_priority, carrier,
+	cutoff_at, notes, created_by, created_at, updated_at, completed_at, cancelled_at
+`
+
+const pickWaveOrderColumns = `
+	id, wave_id, order_id, order_number, priority, sequence, status, shipment_id,
+	packed_by, packed_at
+`
+
+const pickLineColumns = `
+	id, wave_id, order_id, order_item_id, product_id, product_sku, product_name,
+	location, quantity, quantity_picked, quantity_short, quantity_reallocated,
+	quantity_backordered, status, picked_by, picked_at, created_at, updated_at
+`
+
+// Create creates a new pick wave with its orders and lines
+func (r *PostgresPickWaveRepository) Create(ctx context.Context, wave *entities.PickWave) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	waveNumber := wave.WaveNumber
+	if strings.TrimSpace(waveNumber) == "" {
+		waveNumber, err = allocateDocumentNumber(ctx, tx, entities.DocumentTypePickWave, wave.CreatedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	query := `
+		INSERT INTO pick_waves (` + pickWaveColumns + `) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
+		)
+	`
+
+	_, err = tx.Exec(ctx, query,
+		wave.ID,
+		waveNumber,
+		wave.WarehouseID,
+		wave.Status,
+		wave.ShippingMethod,
+		wave.MinPriority,
+		wave.Carrier,
+		wave.CutoffAt,
+		wave.Notes,
+		wave.CreatedBy,
+		wave.CreatedAt,
+		wave.UpdatedAt,
+		wave.CompletedAt,
+		wave.CancelledAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create pick wave: %w", err)
+	}
+
+	orderQuery := `
+		INSERT INTO pick_wave_orders (` + pickWaveOrderColumns + `) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+		)
+	`
+	for _, order := range wave.Orders {
+		_, err := tx.Exec(ctx, orderQuery,
+			order.ID,
+			wave.ID,
+			order.OrderID,
+			order.OrderNumber,
+			order.Priority,
+			order.Sequence,
+			order.Status,
+			order.ShipmentID,
+			order.PackedBy,
+			order.PackedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create pick wave order: %w", err)
+		}
+	}
+
+	lineQuery := `
+		INSERT INTO pick_lines (` + pickLineColumns + `) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18
+		)
+	`
+	for _, line := range wave.Lines {
+		_, err := tx.Exec(ctx, lineQuery,
+			line.ID,
+			wave.ID,
+			line.OrderID,
+			line.OrderItemID,
+			line.ProductID,
+			line.ProductSKU,
+			line.ProductName,
+			line.Location,
+			line.Quantity,
+			line.QuantityPicked,
+			line.QuantityShort,
+			line.QuantityReallocated,
+			line.QuantityBackordered,
+			line.Status,
+			line.PickedBy,
+			line.PickedAt,
+			line.CreatedAt,
+			line.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create pick line: %w", err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	wave.WaveNumber = waveNumber
+	return nil
+}
+
+// GetByID retrieves a pick wave with its orders and lines
+func (r *PostgresPickWaveRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.PickWave, error) {
+	query := `SELECT ` + pickWaveColumns + ` FROM pick_waves WHERE id = $1`
+
+	wave, err := scanPickWave(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("pick wave with id %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get pick wave: %w", err)
+	}
+
+	if err := r.loadDetails(ctx, wave); err != nil {
+		return nil, err
+	}
+
+	return wave, nil
+}
+
+// Update updates a pick wave and the progress of its orders and lines. The
+// orders and quantities to pick never change.
+func (r *PostgresPickWaveRepository) Update(ctx context.Context, wave *entities.PickWave) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE pick_waves SET
+			status = $2, notes = $3, updated_at = $4, completed_at = $5, cancelled_at = $6
+		WHERE id = $1
+	`
+
+	result, err := tx.Exec(ctx, query,
+		wave.ID,
+		wave.Status,
+		wave.Notes,
+		wave.UpdatedAt,
+		wave.CompletedAt,
+		wave.CancelledAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update pick wave: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("pick wave with id %s not found", wave.ID)
+	}
+
+	orderQuery := `
+		UPDATE pick_wave_orders SET
+			status = $3, shipment_id = $4, packed_by = $5, packed_at = $6
+		WHERE id = $1 AND wave_id = $2
+	`
+	for _, order := range wave.Orders {
+		_, err := tx.Exec(ctx, orderQuery,
+			order.ID,
+			wave.ID,
+			order.Status,
+			order.ShipmentID,
+			order.PackedBy,
+			order.PackedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update pick wave order: %w", err)
+		}
+	}
+
+	lineQuery := `
+		UPDATE pick_lines SET
+			quantity_picked = $3, quantity_short = $4, quantity_reallocated = $5,
+			quantity_backordered = $6, status = $7, picked_by = $8, picked_at = $9, updated_at = $10
+		WHERE id = $1 AND wave_id = $2
+	`
+	for _, line := range wave.Lines {
+		_, err := tx.Exec(ctx, lineQuery,
+			line.ID,
+			wave.ID,
+			line.QuantityPicked,
+			line.QuantityShort,
+			line.QuantityReallocated,
+			line.QuantityBackordered,
+			line.Status,
+			line.PickedBy,
+			line.PickedAt,
+			line.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update pick line: %w", err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves pick waves matching the filter, without their orders and lines
+func (r *PostgresPickWaveRepository) List(ctx context.Context, filter repositories.PickWaveFilter) ([]*entities.PickWave, error) {
+	where, args := buildPickWaveConditions(filter)
+	query := `SELECT ` + pickWaveColumns + ` FROM pick_waves` + where + ` ORDER BY created_at DESC`
+
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", len(args)+1)
+		args = append(args, filter.Limit)
+
+		offset := filter.Offset
+		if offset == 0 && filter.Page > 1 {
+			offset = (filter.Page - 1) * filter.Limit
+		}
+		if offset > 0 {
+			query += fmt.Sprintf(" OFFSET $%d", len(args)+1)
+			args = append(args, offset)
+		}
+	}
+
+	return r.queryWaves(ctx, query, args...)
+}
+
+// Count returns the number of pick waves matching the filter
+func (r *PostgresPickWaveRepository) Count(ctx context.Context, filter repositories.PickWaveFilter) (int, error) {
+	where, args := buildPickWaveConditions(filter)
+
+	var count int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM pick_waves`+where, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count pick waves: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetOpen retrieves the released and picking waves with their orders and lines, oldest first
+func (r *PostgresPickWaveRepository) GetOpen(ctx context.Context) ([]*entities.PickWave, error) {
+	query := `SELECT ` + pickWaveColumns + ` FROM pick_waves WHERE status IN ('RELEASED', 'PICKING') ORDER BY created_at`
+
+	waves, err := r.queryWaves(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, wave := range waves {
+		if err := r.loadDetails(ctx, wave); err != nil {
+			return nil, err
+		}
+	}
+
+	return waves, nil
+}
+
+// SetPickLocation creates or replaces the pick location of a product in a warehouse
+func (r *PostgresPickWaveRepository) SetPickLocation(ctx context.Context, location *entities.PickLocation) error {
+	query := `
+		INSERT INTO pick_locations (warehouse_id, product_id, location, updated_by, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (warehouse_id, product_id)
+		DO UPDATE SET location = EXCLUDED.location, updated_by = EXCLUDED.updated_by, updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		location.WarehouseID,
+		location.ProductID,
+		location.Location,
+		location.UpdatedBy,
+		location.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to set pick location: %w", err)
+	}
+
+	return nil
+}
+
+// GetPickLocations retrieves the pick locations of a warehouse
+func (r *PostgresPickWaveRepository) GetPickLocations(ctx context.Context, warehouseID uuid.UUID) ([]*entities.PickLocation, error) {
+	query := `
+		SELECT warehouse_id, product_id, location, updated_by, updated_at
+		FROM pick_locations WHERE warehouse_id = $1 ORDER BY location, product_id
+	`
+
+	rows, err := r.db.Query(ctx, query, warehouseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pick locations: %w", err)
+	}
+	defer rows.Close()
+
+	var locations []*entities.PickLocation
+	for rows.Next() {
+		location := &entities.PickLocation{}
+		err := rows.Scan(
+			&location.WarehouseID,
+			&location.ProductID,
+			&location.Location,
+			&location.UpdatedBy,
+			&location.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan pick location: %w", err)
+		}
+		locations = append(locations, location)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pick locations: %w", err)
+	}
+
+	return locations, nil
+}
+
+func (r *PostgresPickWaveRepository) queryWaves(ctx context.Context, query string, args ...interface{}) ([]*entities.PickWave, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pick waves: %w", err)
+	}
+	defer rows.Close()
+
+	var waves []*entities.PickWave
+	for rows.Next() {
+		wave, err := scanPickWave(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan pick wave row: %w", err)
+		}
+		waves = append(waves, wave)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pick wave rows: %w", err)
+	}
+
+	return waves, nil
+}
+
+func (r *PostgresPickWaveRepository) loadDetails(ctx context.Context, wave *entities.PickWave) error {
+	orderQuery := `SELECT ` + pickWaveOrderColumns + ` FROM pick_wave_orders WHERE wave_id = $1 ORDER BY sequence`
+
+	rows, err := r.db.Query(ctx, orderQuery, wave.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get pick wave orders: %w", err)
+	}
+	defer rows.Close()
+
+	wave.Orders = nil
+	for rows.Next() {
+		var order entities.PickWaveOrder
+		err := rows.Scan(
+			&order.ID,
+			&order.WaveID,
+			&order.OrderID,
+			&order.OrderNumber,
+			&order.Priority,
+			&order.Sequence,
+			&order.Status,
+			&order.ShipmentID,
+			&order.PackedBy,
+			&order.PackedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to scan pick wave order: %w", err)
+		}
+		wave.Orders = append(wave.Orders, order)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating pick wave orders: %w", err)
+	}
+	rows.Close()
+
+	lineQuery := `SELECT ` + pickLineColumns + ` FROM pick_lines WHERE wave_id = $1 ORDER BY created_at, id`
+
+	lineRows, err := r.db.Query(ctx, lineQuery, wave.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get pick lines: %w", err)
+	}
+	defer lineRows.Close()
+
+	wave.Lines = nil
+	for lineRows.Next() {
+		var line entities.PickLine
+		err := lineRows.Scan(
+			&line.ID,
+			&line.WaveID,
+			&line.OrderID,
+			&line.OrderItemID,
+			&line.ProductID,
+			&line.ProductSKU,
+			&line.ProductName,
+			&line.Location,
+			&line.Quantity,
+			&line.QuantityPicked,
+			&line.QuantityShort,
+			&line.QuantityReallocated,
+			&line.QuantityBackordered,
+			&line.Status,
+			&line.PickedBy,
+			&line.PickedAt,
+			&line.CreatedAt,
+			&line.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to scan pick line: %w", err)
+		}
+		wave.Lines = append(wave.Lines, line)
+	}
+
+	if err := lineRows.Err(); err != nil {
+		return fmt.Errorf("error iterating pick lines: %w", err)
+	}
+
+	return nil
+}
+
+func buildPickWaveConditions(filter repositories.PickWaveFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Search != "" {
+		args = append(args, "%"+filter.Search+"%")
+		conditions = append(conditions, fmt.Sprintf("wave_number ILIKE $%d", len(args)))
+	}
+
+	if len(filter.Status) > 0 {
+		placeholders := make([]string, len(filter.Status))
+		for i, status := range filter.Status {
+			args = append(args, status)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		conditions = append(conditions, "status IN ("+strings.Join(placeholders, ", ")+")")
+	}
+
+	if filter.WarehouseID != nil {
+		args = append(args, *filter.WarehouseID)
+		conditions = append(conditions, fmt.Sprintf("warehouse_id = $%d", len(args)))
+	}
+
+	if filter.OrderID != nil {
+		args = append(args, *filter.OrderID)
+		conditions = append(conditions, fmt.Sprintf("id IN (SELECT wave_id FROM pick_wave_orders WHERE order_id = $%d)", len(args)))
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func scanPickWave(row pgx.Row) (*entities.PickWave, error) {
+	wave := &entities.PickWave{}
+	err := row.Scan(
+		&wave.ID,
+		&wave.WaveNumber,
+		&wave.WarehouseID,
+		&wave.Status,
+		&wave.ShippingMethod,
+		&wave.MinPriority,
+		&wave.Carrier,
+		&wave.CutoffAt,
+		&wave.Notes,
+		&wave.CreatedBy,
+		&wave.CreatedAt,
+		&wave.UpdatedAt,
+		&wave.CompletedAt,
+		&wave.CancelledAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return wave, nil
+}
@@ -62,11 +62,12 @@ func (r *PostgresProductRepository) Create(ctx context.Context, product *entitie
 			id, sku, name, description, short_description, category_id, price, cost,
 			weight, dimensions, length, width, height, volume, barcode, track_inventory,
 			stock_quantity, min_stock_level, max_stock_level, allow_backorder,
-			requires_shipping, taxable, tax_rate, is_active, is_featured, is_digital,
-			download_url, max_downloads, expiry_days, created_at, updated_at
+			requires_shipping, taxable, tax_rate, tax_class, is_active, is_featured, is_digital,
+			is_drop_ship, supplier_id, download_url, max_downloads, expiry_days, created_at, updated_at
 		) VALUES (
 			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16,
-			$17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31
+			$17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31,
+			$32, $33, $34
 		)
 	`
 
@@ -94,9 +95,12 @@ func (r *PostgresProductRepository) Create(ctx context.Context, product *entitie
 		product.RequiresShipping,
 		product.Taxable,
 		product.TaxRate,
+		product.TaxClass,
 		product.IsActive,
 		product.IsFeatured,
 		product.IsDigital,
+		product.IsDropShip,
+		product.SupplierID,
 		product.DownloadURL,
 		product.MaxDownloads,
 		product.ExpiryDays,
@@ -117,8 +121,8 @@ func (r *PostgresProductRepository) GetByID(ctx context.Context, id uuid.UUID) (
 		SELECT id, sku, name, description, short_description, category_id, price, cost,
 		       weight, dimensions, length, width, height, volume, barcode, track_inventory,
 		       stock_quantity, min_stock_level, max_stock_level, allow_backorder,
-		       requires_shipping, taxable, tax_rate, is_active, is_featured, is_digital,
-		       download_url, max_downloads, expiry_days, created_at, updated_at
+		       requires_shipping, taxable, tax_rate, tax_class, is_active, is_featured, is_digital,
+		       is_drop_ship, supplier_id, download_url, max_downloads, expiry_days, created_at, updated_at
 		FROM products
 		WHERE id = $1
 	`
@@ -148,9 +152,12 @@ func (r *PostgresProductRepository) GetByID(ctx context.Context, id uuid.UUID) (
 		&product.RequiresShipping,
 		&product.Taxable,
 		&product.TaxRate,
+		&product.TaxClass,
 		&product.IsActive,
 		&product.IsFeatured,
 		&product.IsDigital,
+		&product.IsDropShip,
+		&product.SupplierID,
 		&product.DownloadURL,
 		&product.MaxDownloads,
 		&product.ExpiryDays,
@@ -174,8 +181,8 @@ func (r *PostgresProductRepository) GetBySKU(ctx context.Context, sku string) (*
 		SELECT id, sku, name, description, short_description, category_id, price, cost,
 		       weight, dimensions, length, width, height, volume, barcode, track_inventory,
 		       stock_quantity, min_stock_level, max_stock_level, allow_backorder,
-		       requires_shipping, taxable, tax_rate, is_active, is_featured, is_digital,
-		       download_url, max_downloads, expiry_days, created_at, updated_at
+		       requires_shipping, taxable, tax_rate, tax_class, is_active, is_featured, is_digital,
+		       is_drop_ship, supplier_id, download_url, max_downloads, expiry_days, created_at, updated_at
 		FROM products
 		WHERE sku = $1
 	`
@@ -205,9 +212,12 @@ func (r *PostgresProductRepository) GetBySKU(ctx context.Context, sku string) (*
 		&product.RequiresShipping,
 		&product.Taxable,
 		&product.TaxRate,
+		&product.TaxClass,
 		&product.IsActive,
 		&product.IsFeatured,
 		&product.IsDigital,
+		&product.IsDropShip,
+		&product.SupplierID,
 		&product.DownloadURL,
 		&product.MaxDownloads,
 		&product.ExpiryDays,
@@ -235,7 +245,8 @@ func (r *PostgresProductRepository) Update(ctx context.Context, product *entitie
 		    stock_quantity = $16, min_stock_level = $17, max_stock_level = $18,
 		    allow_backorder = $19, requires_shipping = $20, taxable = $21,
 		    tax_rate = $22, is_active = $23, is_featured = $24, is_digital = $25,
-		    download_url = $26, max_downloads = $27, expiry_days = $28, updated_at = $29
+		    download_url = $26, max_downloads = $27, expiry_days = $28, updated_at = $29,
+		    tax_class = $30, is_drop_ship = $31, supplier_id = $32
 		WHERE id = $1
 	`
 
@@ -269,6 +280,9 @@ func (r *PostgresProductRepository) Update(ctx context.Context, product *entitie
 		product.MaxDownloads,
 		product.ExpiryDays,
 		product.UpdatedAt,
+		product.TaxClass,
+		product.IsDropShip,
+		product.SupplierID,
 	)
 
 	if err != nil {
@@ -297,8 +311,8 @@ func (r *PostgresProductRepository) List(ctx context.Context, filter repositorie
 		SELECT id, sku, name, description, short_description, category_id, price, cost,
 		       weight, dimensions, length, width, height, volume, barcode, track_inventory,
 		       stock_quantity, min_stock_level, max_stock_level, allow_backorder,
-		       requires_shipping, taxable, tax_rate, is_active, is_featured, is_digital,
-		       download_url, max_downloads, expiry_days, created_at, updated_at
+		       requires_shipping, taxable, tax_rate, tax_class, is_active, is_featured, is_digital,
+		       is_drop_ship, supplier_id, download_url, max_downloads, expiry_days, created_at, updated_at
 		FROM products
 		WHERE 1=1
 	`
@@ -464,9 +478,12 @@ func (r *PostgresProductRepository) List(ctx context.Context, filter repositorie
 			&product.RequiresShipping,
 			&product.Taxable,
 			&product.TaxRate,
+			&product.TaxClass,
 			&product.IsActive,
 			&product.IsFeatured,
 			&product.IsDigital,
+			&product.IsDropShip,
+			&product.SupplierID,
 			&product.DownloadURL,
 			&product.MaxDownloads,
 			&product.ExpiryDays,
@@ -492,8 +509,8 @@ func (r *PostgresProductRepository) Search(ctx context.Context, query string, li
 		SELECT id, sku, name, description, short_description, category_id, price, cost,
 		       weight, dimensions, length, width, height, volume, barcode, track_inventory,
 		       stock_quantity, min_stock_level, max_stock_level, allow_backorder,
-		       requires_shipping, taxable, tax_rate, is_active, is_featured, is_digital,
-		       download_url, max_downloads, expiry_days, created_at, updated_at
+		       requires_shipping, taxable, tax_rate, tax_class, is_active, is_featured, is_digital,
+		       is_drop_ship, supplier_id, download_url, max_downloads, expiry_days, created_at, updated_at
 		FROM products
 		WHERE (
 			name ILIKE $1 OR
@@ -543,9 +560,12 @@ func (r *PostgresProductRepository) Search(ctx context.Context, query string, li
 			&product.RequiresShipping,
 			&product.Taxable,
 			&product.TaxRate,
+			&product.TaxClass,
 			&product.IsActive,
 			&product.IsFeatured,
 			&product.IsDigital,
+			&product.IsDropShip,
+			&product.SupplierID,
 			&product.DownloadURL,
 			&product.MaxDownloads,
 			&product.ExpiryDays,
@@ -683,8 +703,8 @@ func (r *PostgresProductRepository) GetByCategory(ctx context.Context, categoryI
 		SELECT id, sku, name, description, short_description, category_id, price, cost,
 		       weight, dimensions, length, width, height, volume, barcode, track_inventory,
 		       stock_quantity, min_stock_level, max_stock_level, allow_backorder,
-		       requires_shipping, taxable, tax_rate, is_active, is_featured, is_digital,
-		       download_url, max_downloads, expiry_days, created_at, updated_at
+		       requires_shipping, taxable, tax_rate, tax_class, is_active, is_featured, is_digital,
+		       is_drop_ship, supplier_id, download_url, max_downloads, expiry_days, created_at, updated_at
 		FROM products
 		WHERE category_id = $1 AND is_active = true
 		ORDER BY name
@@ -723,9 +743,12 @@ func (r *PostgresProductRepository) GetByCategory(ctx context.Context, categoryI
 			&product.RequiresShipping,
 			&product.Taxable,
 			&product.TaxRate,
+			&product.TaxClass,
 			&product.IsActive,
 			&product.IsFeatured,
 			&product.IsDigital,
+			&product.IsDropShip,
+			&product.SupplierID,
 			&product.DownloadURL,
 			&product.MaxDownloads,
 			&product.ExpiryDays,
@@ -762,8 +785,8 @@ func (r *PostgresProductRepository) GetByCategories(ctx context.Context, categor
 		SELECT id, sku, name, description, short_description, category_id, price, cost,
 		       weight, dimensions, length, width, height, volume, barcode, track_inventory,
 		       stock_quantity, min_stock_level, max_stock_level, allow_backorder,
-		       requires_shipping, taxable, tax_rate, is_active, is_featured, is_digital,
-		       download_url, max_downloads, expiry_days, created_at, updated_at
+		       requires_shipping, taxable, tax_rate, tax_class, is_active, is_featured, is_digital,
+		       is_drop_ship, supplier_id, download_url, max_downloads, expiry_days, created_at, updated_at
 		FROM products
 		WHERE category_id IN (%s) AND is_active = true
 		ORDER BY name
@@ -802,9 +825,12 @@ func (r *PostgresProductRepository) GetByCategories(ctx context.Context, categor
 			&product.RequiresShipping,
 			&product.Taxable,
 			&product.TaxRate,
+			&product.TaxClass,
 			&product.IsActive,
 			&product.IsFeatured,
 			&product.IsDigital,
+			&product.IsDropShip,
+			&product.SupplierID,
 			&product.DownloadURL,
 			&product.MaxDownloads,
 			&product.ExpiryDays,
@@ -830,8 +856,8 @@ func (r *PostgresProductRepository) GetFeatured(ctx context.Context, limit int)
 		SELECT id, sku, name, description, short_description, category_id, price, cost,
 		       weight, dimensions, length, width, height, volume, barcode, track_inventory,
 		       stock_quantity, min_stock_level, max_stock_level, allow_backorder,
-		       requires_shipping, taxable, tax_rate, is_active, is_featured, is_digital,
-		       download_url, max_downloads, expiry_days, created_at, updated_at
+		       requires_shipping, taxable, tax_rate, tax_class, is_active, is_featured, is_digital,
+		       is_drop_ship, supplier_id, download_url, max_downloads, expiry_days, created_at, updated_at
 		FROM products
 		WHERE is_featured = true AND is_active = true
 		ORDER BY name
@@ -871,9 +897,12 @@ func (r *PostgresProductRepository) GetFeatured(ctx context.Context, limit int)
 			&product.RequiresShipping,
 			&product.Taxable,
 			&product.TaxRate,
+			&product.TaxClass,
 			&product.IsActive,
 			&product.IsFeatured,
 			&product.IsDigital,
+			&product.IsDropShip,
+			&product.SupplierID,
 			&product.DownloadURL,
 			&product.MaxDownloads,
 			&product.ExpiryDays,
@@ -899,8 +928,8 @@ func (r *PostgresProductRepository) GetActive(ctx context.Context, limit int) ([
 		SELECT id, sku, name, description, short_description, category_id, price, cost,
 		       weight, dimensions, length, width, height, volume, barcode, track_inventory,
 		       stock_quantity, min_stock_level, max_stock_level, allow_backorder,
-		       requires_shipping, taxable, tax_rate, is_active, is_featured, is_digital,
-		       download_url, max_downloads, expiry_days, created_at, updated_at
+		       requires_shipping, taxable, tax_rate, tax_class, is_active, is_featured, is_digital,
+		       is_drop_ship, supplier_id, download_url, max_downloads, expiry_days, created_at, updated_at
 		FROM products
 		WHERE is_active = true
 		ORDER BY created_at DESC
@@ -940,9 +969,12 @@ func (r *PostgresProductRepository) GetActive(ctx context.Context, limit int) ([
 			&product.RequiresShipping,
 			&product.Taxable,
 			&product.TaxRate,
+			&product.TaxClass,
 			&product.IsActive,
 			&product.IsFeatured,
 			&product.IsDigital,
+			&product.IsDropShip,
+			&product.SupplierID,
 			&product.DownloadURL,
 			&product.MaxDownloads,
 			&product.ExpiryDays,
@@ -968,8 +1000,8 @@ func (r *PostgresProductRepository) GetLowStock(ctx context.Context, threshold i
 		SELECT id, sku, name, description, short_description, category_id, price, cost,
 		       weight, dimensions, length, width, height, volume, barcode, track_inventory,
 		       stock_quantity, min_stock_level, max_stock_level, allow_backorder,
-		       requires_shipping, taxable, tax_rate, is_active, is_featured, is_digital,
-		       download_url, max_downloads, expiry_days, created_at, updated_at
+		       requires_shipping, taxable, tax_rate, tax_class, is_active, is_featured, is_digital,
+		       is_drop_ship, supplier_id, download_url, max_downloads, expiry_days, created_at, updated_at
 		FROM products
 		WHERE track_inventory = true
 		  AND stock_quantity <= COALESCE(min_stock_level, $1)
@@ -1011,9 +1043,12 @@ func (r *PostgresProductRepository) GetLowStock(ctx context.Context, threshold i
 			&product.RequiresShipping,
 			&product.Taxable,
 			&product.TaxRate,
+			&product.TaxClass,
 			&product.IsActive,
 			&product.IsFeatured,
 			&product.IsDigital,
+			&product.IsDropShip,
+			&product.SupplierID,
 			&product.DownloadURL,
 			&product.MaxDownloads,
 			&product.ExpiryDays,
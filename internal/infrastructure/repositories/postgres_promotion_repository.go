@@ -0,0 +1,425 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
+	"erpgo/pkg/database"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// PostgresPromotionRepository implements PromotionRepository for PostgreSQL
+type PostgresPromotionRepository struct {
+	db *database.Database
+}
+
+// NewPostgresPromotionRepository creates a new PostgreSQL promotion repository
+func NewPostgresPromotionRepository(db *database.Database) *PostgresPromotionRepository {
+	return &PostgresPromotionRepository{
+		db: db,
+	}
+}
+
+const promotionColumns = `
+	id, code, name, description, action_type, value, max_discount, buy_quantity,
+	get_quantity, min_subtotal, product_ids, category_ids, customer_types, starts_at,
+	ends_at, usage_limit, usage_limit_per_customer, stackable, priority, is_active,
+	created_by, created_at, updated_at
+`
+
+// promotionSelect selects the promotion columns with the number of redemptions
+// that were not released
+const promotionSelect = `SELECT ` + promotionColumns + `,
+	(SELECT COUNT(*) FROM promotion_redemptions pr WHERE pr.promotion_id = promotions.id AND pr.released_at IS NULL) AS times_used
+	FROM promotions`
+
+const promotionRedemptionColumns = `
+	id, promotion_id, promotion_code, order_id, customer_id, redeemed_by, redeemed_at,
+	released_at, release_reason
+`
+
+// Create creates a new promotion
+func (r *PostgresPromotionRepository) Create(ctx context.Context, promotion *entities.Promotion) error {
+	query := `INSERT INTO promotions (` + promotionColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)`
+
+	_, err := r.db.Exec(ctx, query,
+		promotion.ID,
+		promotion.Code,
+		promotion.Name,
+		promotion.Description,
+		promotion.ActionType,
+		promotion.Value,
+		promotion.MaxDiscount,
+		promotion.BuyQuantity,
+		promotion.GetQuantity,
+		promotion.MinSubtotal,
+		uuidArray(promotion.ProductIDs),
+		uuidArray(promotion.CategoryIDs),
+		stringArray(promotion.CustomerTypes),
+		promotion.StartsAt,
+		promotion.EndsAt,
+		promotion.UsageLimit,
+		promotion.UsageLimitPerCustomer,
+		promotion.Stackable,
+		promotion.Priority,
+		promotion.IsActive,
+		promotion.CreatedBy,
+		promotion.CreatedAt,
+		promotion.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create promotion: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a promotion by ID
+func (r *PostgresPromotionRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Promotion, error) {
+	promotion, err := scanPromotion(r.db.QueryRow(ctx, promotionSelect+` WHERE id = $1`, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("promotion with id %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get promotion: %w", err)
+	}
+
+	return promotion, nil
+}
+
+// GetByCode retrieves a promotion by its coupon code
+func (r *PostgresPromotionRepository) GetByCode(ctx context.Context, code string) (*entities.Promotion, error) {
+	promotion, err := scanPromotion(r.db.QueryRow(ctx, promotionSelect+` WHERE code = $1`, code))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("promotion with code %s not found", code)
+		}
+		return nil, fmt.Errorf("failed to get promotion: %w", err)
+	}
+
+	return promotion, nil
+}
+
+// Update updates a promotion
+func (r *PostgresPromotionRepository) Update(ctx context.Context, promotion *entities.Promotion) error {
+	query := `
+		UPDATE promotions SET
+			name = $2, description = $3, action_type = $4, value = $5, max_discount = $6,
+			buy_quantity = $7, get_quantity = $8, min_subtotal = $9, product_ids = $10,
+			category_ids = $11, customer_types = $12, starts_at = $13, ends_at = $14,
+			usage_limit = $15, usage_limit_per_customer = $16, stackable = $17,
+			priority = $18, is_active = $19, updated_at = $20
+		WHERE id = $1
+	`
+
+	result, err := r.db.Exec(ctx, query,
+		promotion.ID,
+		promotion.Name,
+		promotion.Description,
+		promotion.ActionType,
+		promotion.Value,
+		promotion.MaxDiscount,
+		promotion.BuyQuantity,
+		promotion.GetQuantity,
+		promotion.MinSubtotal,
+		uuidArray(promotion.ProductIDs),
+		uuidArray(promotion.CategoryIDs),
+		stringArray(promotion.CustomerTypes),
+		promotion.StartsAt,
+		promotion.EndsAt,
+		promotion.UsageLimit,
+		promotion.UsageLimitPerCustomer,
+		promotion.Stackable,
+		promotion.Priority,
+		promotion.IsActive,
+		promotion.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update promotion: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("promotion with id %s not found", promotion.ID)
+	}
+
+	return nil
+}
+
+// List retrieves promotions matching the filter
+func (r *PostgresPromotionRepository) List(ctx context.Context, filter repositories.PromotionFilter) ([]*entities.Promotion, error) {
+	where, args := buildPromotionConditions(filter)
+	query := promotionSelect + where + ` ORDER BY starts_at DESC, code`
+
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", len(args)+1)
+		args = append(args, filter.Limit)
+
+		offset := filter.Offset
+		if offset == 0 && filter.Page > 1 {
+			offset = (filter.Page - 1) * filter.Limit
+		}
+		if offset > 0 {
+			query += fmt.Sprintf(" OFFSET $%d", len(args)+1)
+			args = append(args, offset)
+		}
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query promotions: %w", err)
+	}
+	defer rows.Close()
+
+	var promotions []*entities.Promotion
+	for rows.Next() {
+		promotion, err := scanPromotion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan promotion row: %w", err)
+		}
+		promotions = append(promotions, promotion)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating promotion rows: %w", err)
+	}
+
+	return promotions, nil
+}
+
+// Count returns the number of promotions matching the filter
+func (r *PostgresPromotionRepository) Count(ctx context.Context, filter repositories.PromotionFilter) (int, error) {
+	where, args := buildPromotionConditions(filter)
+
+	var count int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM promotions`+where, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count promotions: %w", err)
+	}
+
+	return count, nil
+}
+
+// Redeem records a redemption. The promotion row is locked while its
+// redemptions are counted so concurrent redemptions of the same code are
+// checked against the usage limits one at a time.
+func (r *PostgresPromotionRepository) Redeem(ctx context.Context, redemption *entities.PromotionRedemption) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	promotion, err := scanPromotion(tx.QueryRow(ctx, promotionSelect+` WHERE id = $1 FOR UPDATE`, redemption.PromotionID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("promotion with id %s not found", redemption.PromotionID)
+		}
+		return fmt.Errorf("failed to lock promotion: %w", err)
+	}
+
+	var usedByCustomer int
+	query := `SELECT COUNT(*) FROM promotion_redemptions WHERE promotion_id = $1 AND customer_id = $2 AND released_at IS NULL`
+	if err := tx.QueryRow(ctx, query, redemption.PromotionID, redemption.CustomerID).Scan(&usedByCustomer); err != nil {
+		return fmt.Errorf("failed to count promotion redemptions: %w", err)
+	}
+
+	if err := promotion.CheckUsage(promotion.TimesUsed, usedByCustomer); err != nil {
+		return err
+	}
+
+	query = `INSERT INTO promotion_redemptions (` + promotionRedemptionColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	_, err = tx.Exec(ctx, query,
+		redemption.ID,
+		redemption.PromotionID,
+		redemption.PromotionCode,
+		redemption.OrderID,
+		redemption.CustomerID,
+		redemption.RedeemedBy,
+		redemption.RedeemedAt,
+		redemption.ReleasedAt,
+		redemption.ReleaseReason,
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "uq_promotion_redemptions_active") {
+			return fmt.Errorf("promotion %s is already applied to order %s", redemption.PromotionCode, redemption.OrderID)
+		}
+		return fmt.Errorf("failed to create promotion redemption: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	redemption.Promotion = promotion
+	return nil
+}
+
+// UpdateRedemption updates the release of a redemption
+func (r *PostgresPromotionRepository) UpdateRedemption(ctx context.Context, redemption *entities.PromotionRedemption) error {
+	query := `UPDATE promotion_redemptions SET released_at = $2, release_reason = $3 WHERE id = $1`
+
+	result, err := r.db.Exec(ctx, query, redemption.ID, redemption.ReleasedAt, redemption.ReleaseReason)
+	if err != nil {
+		return fmt.Errorf("failed to update promotion redemption: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("promotion redemption with id %s not found", redemption.ID)
+	}
+
+	return nil
+}
+
+// GetActiveRedemptionsByOrderID retrieves the unreleased redemptions of an
+// order with their promotions, oldest first
+func (r *PostgresPromotionRepository) GetActiveRedemptionsByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.PromotionRedemption, error) {
+	query := `
+		SELECT ` + promotionRedemptionColumns + ` FROM promotion_redemptions
+		WHERE order_id = $1 AND released_at IS NULL
+		ORDER BY redeemed_at, id
+	`
+
+	rows, err := r.db.Query(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query promotion redemptions: %w", err)
+	}
+	defer rows.Close()
+
+	var redemptions []*entities.PromotionRedemption
+	var promotionIDs []uuid.UUID
+	for rows.Next() {
+		redemption := &entities.PromotionRedemption{}
+		err := rows.Scan(
+			&redemption.ID,
+			&redemption.PromotionID,
+			&redemption.PromotionCode,
+			&redemption.OrderID,
+			&redemption.CustomerID,
+			&redemption.RedeemedBy,
+			&redemption.RedeemedAt,
+			&redemption.ReleasedAt,
+			&redemption.ReleaseReason,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan promotion redemption: %w", err)
+		}
+		redemptions = append(redemptions, redemption)
+		promotionIDs = append(promotionIDs, redemption.PromotionID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating promotion redemptions: %w", err)
+	}
+	rows.Close()
+
+	if len(redemptions) == 0 {
+		return redemptions, nil
+	}
+
+	promotionRows, err := r.db.Query(ctx, promotionSelect+` WHERE id = ANY($1)`, promotionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query promotions: %w", err)
+	}
+	defer promotionRows.Close()
+
+	promotions := make(map[uuid.UUID]*entities.Promotion, len(promotionIDs))
+	for promotionRows.Next() {
+		promotion, err := scanPromotion(promotionRows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan promotion row: %w", err)
+		}
+		promotions[promotion.ID] = promotion
+	}
+
+	if err := promotionRows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating promotion rows: %w", err)
+	}
+
+	for _, redemption := range redemptions {
+		redemption.Promotion = promotions[redemption.PromotionID]
+	}
+
+	return redemptions, nil
+}
+
+func buildPromotionConditions(filter repositories.PromotionFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Search != "" {
+		args = append(args, "%"+filter.Search+"%")
+		conditions = append(conditions, fmt.Sprintf("(code ILIKE $%d OR name ILIKE $%d)", len(args), len(args)))
+	}
+
+	if filter.ActionType != nil {
+		args = append(args, *filter.ActionType)
+		conditions = append(conditions, fmt.Sprintf("action_type = $%d", len(args)))
+	}
+
+	if filter.IsActive != nil {
+		args = append(args, *filter.IsActive)
+		conditions = append(conditions, fmt.Sprintf("is_active = $%d", len(args)))
+	}
+
+	if filter.RunningAt != nil {
+		args = append(args, *filter.RunningAt)
+		conditions = append(conditions, fmt.Sprintf("is_active AND starts_at <= $%d AND (ends_at IS NULL OR ends_at > $%d)", len(args), len(args)))
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func scanPromotion(row pgx.Row) (*entities.Promotion, error) {
+	promotion := &entities.Promotion{}
+	err := row.Scan(
+		&promotion.ID,
+		&promotion.Code,
+		&promotion.Name,
+		&promotion.Description,
+		&promotion.ActionType,
+		&promotion.Value,
+		&promotion.MaxDiscount,
+		&promotion.BuyQuantity,
+		&promotion.GetQuantity,
+		&promotion.MinSubtotal,
+		&promotion.ProductIDs,
+		&promotion.CategoryIDs,
+		&promotion.CustomerTypes,
+		&promotion.StartsAt,
+		&promotion.EndsAt,
+		&promotion.UsageLimit,
+		&promotion.UsageLimitPerCustomer,
+		&promotion.Stackable,
+		&promotion.Priority,
+		&promotion.IsActive,
+		&promotion.CreatedBy,
+		&promotion.CreatedAt,
+		&promotion.UpdatedAt,
+		&promotion.TimesUsed,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+// uuidArray stores a missing ID list as an empty array
+func uuidArray(ids []uuid.UUID) []uuid.UUID {
+	if ids == nil {
+		return []uuid.UUID{}
+	}
+	return ids
+}
+
+// stringArray stores a missing string list as an empty array
+func stringArray(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
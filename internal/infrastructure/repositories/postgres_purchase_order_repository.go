@@ -0,0 +1,485 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	orderEntities "erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/purchasing/entities"
+	"erpgo/internal/domain/purchasing/repositories"
+	"erpgo/pkg/database"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// PostgresPurchaseOrderRepository implements PurchaseOrderRepository for PostgreSQL
+type PostgresPurchaseOrderRepository struct {
+	db *database.Database
+}
+
+// NewPostgresPurchaseOrderRepository creates a new PostgreSQL purchase order repository
+func NewPostgresPurchaseOrderRepository(db *database.Database) *PostgresPurchaseOrderRepository {
+	return &PostgresPurchaseOrderRepository{
+		db: db,
+	}
+}
+
+const purchaseOrderColumns = `
+	id, po_number, supplier_id, warehouse_id, status, order_date, expected_date, currency,
+	payment_terms, subtotal, tax_amount, shipping_amount, total_amount, notes,
+	cancellation_reason, created_by, created_at, updated_at, ordered_at, closed_at, cancelled_at,
+	sales_order_id, ship_to
+`
+
+const purchaseOrderItemColumns = `
+	id, purchase_order_id, product_id, product_sku, product_name, quantity_ordered,
+	quantity_received, unit_cost, tax_rate, tax_amount, total_cost, expected_date, notes,
+	created_at, updated_at, sales_order_item_id
+`
+
+// Create creates a new purchase order with its items
+func (r *PostgresPurchaseOrderRepository) Create(ctx context.Context, po *entities.PurchaseOrder) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	poNumber := po.PONumber
+	if strings.TrimSpace(poNumber) == "" {
+		poNumber, err = allocateDocumentNumber(ctx, tx, orderEntities.DocumentTypePurchaseOrder, po.CreatedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	query := `
+		INSERT INTO purchase_orders (` + purchaseOrderColumns + `) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11,
+			$12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23
+		)
+	`
+
+	_, err = tx.Exec(ctx, query,
+		po.ID,
+		poNumber,
+		po.SupplierID,
+		po.WarehouseID,
+		po.Status,
+		po.OrderDate,
+		po.ExpectedDate,
+		po.Currency,
+		po.PaymentTerms,
+		po.Subtotal,
+		po.TaxAmount,
+		po.ShippingAmount,
+		po.TotalAmount,
+		po.Notes,
+		po.CancellationReason,
+		po.CreatedBy,
+		po.CreatedAt,
+		po.UpdatedAt,
+		po.OrderedAt,
+		po.ClosedAt,
+		po.CancelledAt,
+		po.SalesOrderID,
+		po.ShipTo,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create purchase order: %w", err)
+	}
+
+	if err := insertPurchaseOrderItems(ctx, tx, po.ID, po.Items); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	po.PONumber = poNumber
+	return nil
+}
+
+// GetByID retrieves a purchase order with its items
+func (r *PostgresPurchaseOrderRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.PurchaseOrder, error) {
+	query := `SELECT ` + purchaseOrderColumns + ` FROM purchase_orders WHERE id = $1`
+
+	po, err := scanPurchaseOrder(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("purchase order with id %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get purchase order: %w", err)
+	}
+
+	if err := r.loadItems(ctx, po); err != nil {
+		return nil, err
+	}
+
+	return po, nil
+}
+
+// GetByNumber retrieves a purchase order with its items by PO number
+func (r *PostgresPurchaseOrderRepository) GetByNumber(ctx context.Context, poNumber string) (*entities.PurchaseOrder, error) {
+	query := `SELECT ` + purchaseOrderColumns + ` FROM purchase_orders WHERE po_number = $1`
+
+	po, err := scanPurchaseOrder(r.db.QueryRow(ctx, query, poNumber))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("purchase order with number %s not found", poNumber)
+		}
+		return nil, fmt.Errorf("failed to get purchase order: %w", err)
+	}
+
+	if err := r.loadItems(ctx, po); err != nil {
+		return nil, err
+	}
+
+	return po, nil
+}
+
+// GetBySalesOrderID retrieves the drop-ship purchase orders of a sales order with their items
+func (r *PostgresPurchaseOrderRepository) GetBySalesOrderID(ctx context.Context, salesOrderID uuid.UUID) ([]*entities.PurchaseOrder, error) {
+	query := `SELECT ` + purchaseOrderColumns + ` FROM purchase_orders WHERE sales_order_id = $1 ORDER BY created_at, id`
+
+	rows, err := r.db.Query(ctx, query, salesOrderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get drop-ship purchase orders: %w", err)
+	}
+	defer rows.Close()
+
+	var orders []*entities.PurchaseOrder
+	for rows.Next() {
+		po, err := scanPurchaseOrder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan purchase order row: %w", err)
+		}
+		orders = append(orders, po)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating purchase order rows: %w", err)
+	}
+
+	for _, po := range orders {
+		if err := r.loadItems(ctx, po); err != nil {
+			return nil, err
+		}
+	}
+
+	return orders, nil
+}
+
+// Update updates a purchase order and its items. Lines no longer on the
+// purchase order are removed, so received lines must be kept by the caller.
+func (r *PostgresPurchaseOrderRepository) Update(ctx context.Context, po *entities.PurchaseOrder) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE purchase_orders SET
+			supplier_id = $2, warehouse_id = $3, status = $4, order_date = $5, expected_date = $6,
+			currency = $7, payment_terms = $8, subtotal = $9, tax_amount = $10,
+			shipping_amount = $11, total_amount = $12, notes = $13, cancellation_reason = $14,
+			updated_at = $15, ordered_at = $16, closed_at = $17, cancelled_at = $18,
+			sales_order_id = $19, ship_to = $20
+		WHERE id = $1
+	`
+
+	result, err := tx.Exec(ctx, query,
+		po.ID,
+		po.SupplierID,
+		po.WarehouseID,
+		po.Status,
+		po.OrderDate,
+		po.ExpectedDate,
+		po.Currency,
+		po.PaymentTerms,
+		po.Subtotal,
+		po.TaxAmount,
+		po.ShippingAmount,
+		po.TotalAmount,
+		po.Notes,
+		po.CancellationReason,
+		po.UpdatedAt,
+		po.OrderedAt,
+		po.ClosedAt,
+		po.CancelledAt,
+		po.SalesOrderID,
+		po.ShipTo,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update purchase order: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("purchase order with id %s not found", po.ID)
+	}
+
+	// Lines are upserted rather than replaced because goods receipt lines reference them
+	keep := make([]uuid.UUID, 0, len(po.Items))
+	for _, item := range po.Items {
+		keep = append(keep, item.ID)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM purchase_order_items WHERE purchase_order_id = $1 AND NOT (id = ANY($2))`, po.ID, keep); err != nil {
+		return fmt.Errorf("failed to remove purchase order items: %w", err)
+	}
+	if err := insertPurchaseOrderItems(ctx, tx, po.ID, po.Items); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Lock locks a purchase order's row for the rest of the context's transaction
+func (r *PostgresPurchaseOrderRepository) Lock(ctx context.Context, id uuid.UUID) error {
+	var locked uuid.UUID
+	err := r.db.QueryRow(ctx, `SELECT id FROM purchase_orders WHERE id = $1 FOR UPDATE`, id).Scan(&locked)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("purchase order with id %s not found", id)
+		}
+		return fmt.Errorf("failed to lock purchase order: %w", err)
+	}
+	return nil
+}
+
+// List retrieves purchase orders matching the filter, without their items
+func (r *PostgresPurchaseOrderRepository) List(ctx context.Context, filter repositories.PurchaseOrderFilter) ([]*entities.PurchaseOrder, error) {
+	where, args := buildPurchaseOrderConditions(filter)
+	query := `SELECT ` + purchaseOrderColumns + ` FROM purchase_orders` + where + ` ORDER BY created_at DESC`
+
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", len(args)+1)
+		args = append(args, filter.Limit)
+
+		offset := filter.Offset
+		if offset == 0 && filter.Page > 1 {
+			offset = (filter.Page - 1) * filter.Limit
+		}
+		if offset > 0 {
+			query += fmt.Sprintf(" OFFSET $%d", len(args)+1)
+			args = append(args, offset)
+		}
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list purchase orders: %w", err)
+	}
+	defer rows.Close()
+
+	var orders []*entities.PurchaseOrder
+	for rows.Next() {
+		po, err := scanPurchaseOrder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan purchase order row: %w", err)
+		}
+		orders = append(orders, po)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating purchase order rows: %w", err)
+	}
+
+	return orders, nil
+}
+
+// Count returns the number of purchase orders matching the filter
+func (r *PostgresPurchaseOrderRepository) Count(ctx context.Context, filter repositories.PurchaseOrderFilter) (int, error) {
+	where, args := buildPurchaseOrderConditions(filter)
+
+	var count int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM purchase_orders`+where, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count purchase orders: %w", err)
+	}
+
+	return count, nil
+}
+
+// HasOpenOrdersForSupplier checks whether the supplier has purchase orders that are not closed or cancelled
+func (r *PostgresPurchaseOrderRepository) HasOpenOrdersForSupplier(ctx context.Context, supplierID uuid.UUID) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM purchase_orders WHERE supplier_id = $1 AND status NOT IN ('CLOSED', 'CANCELLED'))`
+
+	var exists bool
+	if err := r.db.QueryRow(ctx, query, supplierID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check open purchase orders: %w", err)
+	}
+
+	return exists, nil
+}
+
+func (r *PostgresPurchaseOrderRepository) loadItems(ctx context.Context, po *entities.PurchaseOrder) error {
+	query := `SELECT ` + purchaseOrderItemColumns + ` FROM purchase_order_items WHERE purchase_order_id = $1 ORDER BY created_at, id`
+
+	rows, err := r.db.Query(ctx, query, po.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get purchase order items: %w", err)
+	}
+	defer rows.Close()
+
+	po.Items = nil
+	for rows.Next() {
+		var item entities.PurchaseOrderItem
+		err := rows.Scan(
+			&item.ID,
+			&item.PurchaseOrderID,
+			&item.ProductID,
+			&item.ProductSKU,
+			&item.ProductName,
+			&item.QuantityOrdered,
+			&item.QuantityReceived,
+			&item.UnitCost,
+			&item.TaxRate,
+			&item.TaxAmount,
+			&item.TotalCost,
+			&item.ExpectedDate,
+			&item.Notes,
+			&item.CreatedAt,
+			&item.UpdatedAt,
+			&item.SalesOrderItemID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to scan purchase order item: %w", err)
+		}
+		po.Items = append(po.Items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating purchase order items: %w", err)
+	}
+
+	return nil
+}
+
+func insertPurchaseOrderItems(ctx context.Context, tx pgx.Tx, purchaseOrderID uuid.UUID, items []entities.PurchaseOrderItem) error {
+	query := `
+		INSERT INTO purchase_order_items (` + purchaseOrderItemColumns + `) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16
+		)
+		ON CONFLICT (id) DO UPDATE SET
+			product_id = EXCLUDED.product_id,
+			product_sku = EXCLUDED.product_sku,
+			product_name = EXCLUDED.product_name,
+			quantity_ordered = EXCLUDED.quantity_ordered,
+			quantity_received = EXCLUDED.quantity_received,
+			unit_cost = EXCLUDED.unit_cost,
+			tax_rate = EXCLUDED.tax_rate,
+			tax_amount = EXCLUDED.tax_amount,
+			total_cost = EXCLUDED.total_cost,
+			expected_date = EXCLUDED.expected_date,
+			notes = EXCLUDED.notes,
+			sales_order_item_id = EXCLUDED.sales_order_item_id,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	for _, item := range items {
+		_, err := tx.Exec(ctx, query,
+			item.ID,
+			purchaseOrderID,
+			item.ProductID,
+			item.ProductSKU,
+			item.ProductName,
+			item.QuantityOrdered,
+			item.QuantityReceived,
+			item.UnitCost,
+			item.TaxRate,
+			item.TaxAmount,
+			item.TotalCost,
+			item.ExpectedDate,
+			item.Notes,
+			item.CreatedAt,
+			item.UpdatedAt,
+			item.SalesOrderItemID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save purchase order item: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func buildPurchaseOrderConditions(filter repositories.PurchaseOrderFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Search != "" {
+		args = append(args, "%"+filter.Search+"%")
+		conditions = append(conditions, fmt.Sprintf("po_number ILIKE $%d", len(args)))
+	}
+
+	if len(filter.Status) > 0 {
+		placeholders := make([]string, len(filter.Status))
+		for i, status := range filter.Status {
+			args = append(args, status)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		conditions = append(conditions, "status IN ("+strings.Join(placeholders, ", ")+")")
+	}
+
+	if filter.SupplierID != nil {
+		args = append(args, *filter.SupplierID)
+		conditions = append(conditions, fmt.Sprintf("supplier_id = $%d", len(args)))
+	}
+
+	if filter.WarehouseID != nil {
+		args = append(args, *filter.WarehouseID)
+		conditions = append(conditions, fmt.Sprintf("warehouse_id = $%d", len(args)))
+	}
+
+	if filter.SalesOrderID != nil {
+		args = append(args, *filter.SalesOrderID)
+		conditions = append(conditions, fmt.Sprintf("sales_order_id = $%d", len(args)))
+	}
+
+	if filter.ExpectedBefore != nil {
+		args = append(args, *filter.ExpectedBefore)
+		conditions = append(conditions, fmt.Sprintf("status IN ('ORDERED', 'PARTIALLY_RECEIVED') AND expected_date < $%d", len(args)))
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func scanPurchaseOrder(row pgx.Row) (*entities.PurchaseOrder, error) {
+	po := &entities.PurchaseOrder{}
+	err := row.Scan(
+		&po.ID,
+		&po.PONumber,
+		&po.SupplierID,
+		&po.WarehouseID,
+		&po.Status,
+		&po.OrderDate,
+		&po.ExpectedDate,
+		&po.Currency,
+		&po.PaymentTerms,
+		&po.Subtotal,
+		&po.TaxAmount,
+		&po.ShippingAmount,
+		&po.TotalAmount,
+		&po.Notes,
+		&po.CancellationReason,
+		&po.CreatedBy,
+		&po.CreatedAt,
+		&po.UpdatedAt,
+		&po.OrderedAt,
+		&po.ClosedAt,
+		&po.CancelledAt,
+		&po.SalesOrderID,
+		&po.ShipTo,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return po, nil
+}
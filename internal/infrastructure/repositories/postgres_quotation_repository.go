@@ -0,0 +1,467 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
+	"erpgo/pkg/database"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// PostgresQuotationRepository implements QuotationRepository for PostgreSQL
+type PostgresQuotationRepository struct {
+	db *database.Database
+}
+
+// NewPostgresQuotationRepository creates a new PostgreSQL quotation repository
+func NewPostgresQuotationRepository(db *database.Database) *PostgresQuotationRepository {
+	return &PostgresQuotationRepository{
+		db: db,
+	}
+}
+
+const quotationColumns = `
+	id, quotation_number, revision, previous_revision_id, customer_id, status, valid_until,
+	shipping_method, shipping_address_id, billing_address_id, subtotal, tax_amount,
+	shipping_amount, discount_amount, total_amount, currency, notes, rejection_reason,
+	converted_order_id, created_by, created_at, updated_at, sent_at, accepted_at,
+	rejected_at, converted_at
+`
+
+const quotationItemColumns = `
+	id, quotation_id, product_id, product_sku, product_name, quantity, unit_price,
+	discount_amount, tax_rate, tax_amount, total_price, weight, dimensions, barcode,
+	notes, created_at, updated_at
+`
+
+// Create creates a new quotation with its items
+func (r *PostgresQuotationRepository) Create(ctx context.Context, quotation *entities.Quotation) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	quotationNumber := quotation.QuotationNumber
+	if strings.TrimSpace(quotationNumber) == "" {
+		quotationNumber, err = allocateDocumentNumber(ctx, tx, entities.DocumentTypeQuotation, quotation.CreatedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	query := `
+		INSERT INTO quotations (` + quotationColumns + `) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13,
+			$14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26
+		)
+	`
+
+	_, err = tx.Exec(ctx, query,
+		quotation.ID,
+		quotationNumber,
+		quotation.Revision,
+		quotation.PreviousRevisionID,
+		quotation.CustomerID,
+		quotation.Status,
+		quotation.ValidUntil,
+		quotation.ShippingMethod,
+		quotation.ShippingAddressID,
+		quotation.BillingAddressID,
+		quotation.Subtotal,
+		quotation.TaxAmount,
+		quotation.ShippingAmount,
+		quotation.DiscountAmount,
+		quotation.TotalAmount,
+		quotation.Currency,
+		quotation.Notes,
+		quotation.RejectionReason,
+		quotation.ConvertedOrderID,
+		quotation.CreatedBy,
+		quotation.CreatedAt,
+		quotation.UpdatedAt,
+		quotation.SentAt,
+		quotation.AcceptedAt,
+		quotation.RejectedAt,
+		quotation.ConvertedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create quotation: %w", err)
+	}
+
+	if err := insertQuotationItems(ctx, tx, quotation.ID, quotation.Items); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	quotation.QuotationNumber = quotationNumber
+	return nil
+}
+
+// GetByID retrieves a quotation with its items
+func (r *PostgresQuotationRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Quotation, error) {
+	query := `SELECT ` + quotationColumns + ` FROM quotations WHERE id = $1`
+
+	quotation, err := scanQuotation(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("quotation with id %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get quotation: %w", err)
+	}
+
+	if err := r.loadItems(ctx, quotation); err != nil {
+		return nil, err
+	}
+
+	return quotation, nil
+}
+
+// GetByOrderID retrieves the quotation an order was converted from
+func (r *PostgresQuotationRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) (*entities.Quotation, error) {
+	query := `SELECT ` + quotationColumns + ` FROM quotations WHERE converted_order_id = $1`
+
+	quotation, err := scanQuotation(r.db.QueryRow(ctx, query, orderID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("quotation for order %s not found", orderID)
+		}
+		return nil, fmt.Errorf("failed to get quotation by order: %w", err)
+	}
+
+	if err := r.loadItems(ctx, quotation); err != nil {
+		return nil, err
+	}
+
+	return quotation, nil
+}
+
+// Update updates a quotation and replaces its items
+func (r *PostgresQuotationRepository) Update(ctx context.Context, quotation *entities.Quotation) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE quotations SET
+			status = $2, valid_until = $3, shipping_method = $4, shipping_address_id = $5,
+			billing_address_id = $6, subtotal = $7, tax_amount = $8, shipping_amount = $9,
+			discount_amount = $10, total_amount = $11, currency = $12, notes = $13,
+			rejection_reason = $14, converted_order_id = $15, updated_at = $16,
+			sent_at = $17, accepted_at = $18, rejected_at = $19, converted_at = $20
+		WHERE id = $1
+	`
+
+	result, err := tx.Exec(ctx, query,
+		quotation.ID,
+		quotation.Status,
+		quotation.ValidUntil,
+		quotation.ShippingMethod,
+		quotation.ShippingAddressID,
+		quotation.BillingAddressID,
+		quotation.Subtotal,
+		quotation.TaxAmount,
+		quotation.ShippingAmount,
+		quotation.DiscountAmount,
+		quotation.TotalAmount,
+		quotation.Currency,
+		quotation.Notes,
+		quotation.RejectionReason,
+		quotation.ConvertedOrderID,
+		quotation.UpdatedAt,
+		quotation.SentAt,
+		quotation.AcceptedAt,
+		quotation.RejectedAt,
+		quotation.ConvertedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update quotation: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("quotation with id %s not found", quotation.ID)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM quotation_items WHERE quotation_id = $1`, quotation.ID); err != nil {
+		return fmt.Errorf("failed to replace quotation items: %w", err)
+	}
+	if err := insertQuotationItems(ctx, tx, quotation.ID, quotation.Items); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Lock locks the quotation's row until the transaction of the context ends
+func (r *PostgresQuotationRepository) Lock(ctx context.Context, id uuid.UUID) error {
+	var locked uuid.UUID
+	err := r.db.QueryRow(ctx, `SELECT id FROM quotations WHERE id = $1 FOR UPDATE`, id).Scan(&locked)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("quotation with id %s not found", id)
+		}
+		return fmt.Errorf("failed to lock quotation: %w", err)
+	}
+	return nil
+}
+
+// List retrieves quotations matching the filter, without their items
+func (r *PostgresQuotationRepository) List(ctx context.Context, filter repositories.QuotationFilter) ([]*entities.Quotation, error) {
+	where, args := buildQuotationConditions(filter)
+	query := `SELECT ` + quotationColumns + ` FROM quotations q` + where + ` ORDER BY created_at DESC`
+
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", len(args)+1)
+		args = append(args, filter.Limit)
+
+		offset := filter.Offset
+		if offset == 0 && filter.Page > 1 {
+			offset = (filter.Page - 1) * filter.Limit
+		}
+		if offset > 0 {
+			query += fmt.Sprintf(" OFFSET $%d", len(args)+1)
+			args = append(args, offset)
+		}
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list quotations: %w", err)
+	}
+	defer rows.Close()
+
+	var quotations []*entities.Quotation
+	for rows.Next() {
+		quotation, err := scanQuotation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan quotation row: %w", err)
+		}
+		quotations = append(quotations, quotation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating quotation rows: %w", err)
+	}
+
+	return quotations, nil
+}
+
+// Count returns the number of quotations matching the filter
+func (r *PostgresQuotationRepository) Count(ctx context.Context, filter repositories.QuotationFilter) (int, error) {
+	where, args := buildQuotationConditions(filter)
+
+	var count int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM quotations q`+where, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count quotations: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetRevisions retrieves every revision of a quotation, oldest first
+func (r *PostgresQuotationRepository) GetRevisions(ctx context.Context, quotationNumber string) ([]*entities.Quotation, error) {
+	query := `SELECT ` + quotationColumns + ` FROM quotations WHERE quotation_number = $1 ORDER BY revision`
+
+	rows, err := r.db.Query(ctx, query, quotationNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quotation revisions: %w", err)
+	}
+	defer rows.Close()
+
+	var quotations []*entities.Quotation
+	for rows.Next() {
+		quotation, err := scanQuotation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan quotation row: %w", err)
+		}
+		quotations = append(quotations, quotation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating quotation rows: %w", err)
+	}
+
+	return quotations, nil
+}
+
+// ExpireBefore marks open quotations past their validity date as expired
+func (r *PostgresQuotationRepository) ExpireBefore(ctx context.Context, before time.Time) (int, error) {
+	query := `
+		UPDATE quotations
+		SET status = 'EXPIRED', updated_at = NOW()
+		WHERE status IN ('DRAFT', 'SENT') AND valid_until < $1
+	`
+
+	result, err := r.db.Exec(ctx, query, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire quotations: %w", err)
+	}
+
+	return int(result.RowsAffected()), nil
+}
+
+func (r *PostgresQuotationRepository) loadItems(ctx context.Context, quotation *entities.Quotation) error {
+	query := `SELECT ` + quotationItemColumns + ` FROM quotation_items WHERE quotation_id = $1 ORDER BY created_at, id`
+
+	rows, err := r.db.Query(ctx, query, quotation.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get quotation items: %w", err)
+	}
+	defer rows.Close()
+
+	quotation.Items = nil
+	for rows.Next() {
+		var item entities.OrderItem
+		var dimensions *string
+		err := rows.Scan(
+			&item.ID,
+			&item.OrderID,
+			&item.ProductID,
+			&item.ProductSKU,
+			&item.ProductName,
+			&item.Quantity,
+			&item.UnitPrice,
+			&item.DiscountAmount,
+			&item.TaxRate,
+			&item.TaxAmount,
+			&item.TotalPrice,
+			&item.Weight,
+			&dimensions,
+			&item.Barcode,
+			&item.Notes,
+			&item.CreatedAt,
+			&item.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to scan quotation item: %w", err)
+		}
+		if dimensions != nil {
+			item.Dimensions = *dimensions
+		}
+		item.Status = "ORDERED"
+		quotation.Items = append(quotation.Items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating quotation items: %w", err)
+	}
+
+	return nil
+}
+
+func insertQuotationItems(ctx context.Context, tx pgx.Tx, quotationID uuid.UUID, items []entities.OrderItem) error {
+	query := `
+		INSERT INTO quotation_items (` + quotationItemColumns + `) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, NULLIF($13, ''), $14, $15, $16, $17
+		)
+	`
+
+	for _, item := range items {
+		_, err := tx.Exec(ctx, query,
+			item.ID,
+			quotationID,
+			item.ProductID,
+			item.ProductSKU,
+			item.ProductName,
+			item.Quantity,
+			item.UnitPrice,
+			item.DiscountAmount,
+			item.TaxRate,
+			item.TaxAmount,
+			item.TotalPrice,
+			item.Weight,
+			item.Dimensions,
+			item.Barcode,
+			item.Notes,
+			item.CreatedAt,
+			item.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create quotation item: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func buildQuotationConditions(filter repositories.QuotationFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Search != "" {
+		args = append(args, "%"+filter.Search+"%")
+		conditions = append(conditions, fmt.Sprintf("q.quotation_number ILIKE $%d", len(args)))
+	}
+
+	if len(filter.Status) > 0 {
+		placeholders := make([]string, len(filter.Status))
+		for i, status := range filter.Status {
+			args = append(args, status)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		conditions = append(conditions, "q.status IN ("+strings.Join(placeholders, ", ")+")")
+	}
+
+	if filter.CustomerID != nil {
+		args = append(args, *filter.CustomerID)
+		conditions = append(conditions, fmt.Sprintf("q.customer_id = $%d", len(args)))
+	}
+
+	if filter.LatestOnly {
+		conditions = append(conditions, "NOT EXISTS (SELECT 1 FROM quotations newer WHERE newer.quotation_number = q.quotation_number AND newer.revision > q.revision)")
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func scanQuotation(row pgx.Row) (*entities.Quotation, error) {
+	quotation := &entities.Quotation{}
+	err := row.Scan(
+		&quotation.ID,
+		&quotation.QuotationNumber,
+		&quotation.Revision,
+		&quotation.PreviousRevisionID,
+		&quotation.CustomerID,
+		&quotation.Status,
+		&quotation.ValidUntil,
+		&quotation.ShippingMethod,
+		&quotation.ShippingAddressID,
+		&quotation.BillingAddressID,
+		&quotation.Subtotal,
+		&quotation.TaxAmount,
+		&quotation.ShippingAmount,
+		&quotation.DiscountAmount,
+		&quotation.TotalAmount,
+		&quotation.Currency,
+		&quotation.Notes,
+		&quotation.RejectionReason,
+		&quotation.ConvertedOrderID,
+		&quotation.CreatedBy,
+		&quotation.CreatedAt,
+		&quotation.UpdatedAt,
+		&quotation.SentAt,
+		&quotation.AcceptedAt,
+		&quotation.RejectedAt,
+		&quotation.ConvertedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return quotation, nil
+}
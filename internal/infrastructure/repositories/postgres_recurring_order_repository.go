@@ -0,0 +1,456 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
+	"erpgo/pkg/database"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// PostgresRecurringOrderRepository implements RecurringOrderRepository for PostgreSQL
+type PostgresRecurringOrderRepository struct {
+	db *database.Database
+}
+
+// NewPostgresRecurringOrderRepository creates a new PostgreSQL recurring order repository
+func NewPostgresRecurringOrderRepository(db *database.Database) *PostgresRecurringOrderRepository {
+	return &PostgresRecurringOrderRepository{
+		db: db,
+	}
+}
+
+const recurringOrderColumns = `
+	id, name, customer_id, status, priority, shipping_method, shipping_address_id,
+	billing_address_id, currency, notes, frequency, interval_count, day_of_month,
+	start_date, end_date, next_run_at, last_run_at, created_by, created_at, updated_at
+`
+
+const recurringOrderItemColumns = `
+	id, recurring_order_id, product_id, quantity, notes, created_at
+`
+
+const recurringOrderRunColumns = `
+	id, recurring_order_id, scheduled_for, status, order_id, order_number, reason,
+	created_by, created_at
+`
+
+// Create creates a new recurring order with its items
+func (r *PostgresRecurringOrderRepository) Create(ctx context.Context, recurringOrder *entities.RecurringOrder) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO recurring_orders (` + recurringOrderColumns + `) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''), $10, $11, $12, $13, $14,
+			$15, $16, $17, $18, $19, $20
+		)
+	`
+
+	_, err = tx.Exec(ctx, query,
+		recurringOrder.ID,
+		recurringOrder.Name,
+		recurringOrder.CustomerID,
+		recurringOrder.Status,
+		recurringOrder.Priority,
+		recurringOrder.ShippingMethod,
+		recurringOrder.ShippingAddressID,
+		recurringOrder.BillingAddressID,
+		recurringOrder.Currency,
+		recurringOrder.Notes,
+		recurringOrder.Frequency,
+		recurringOrder.Interval,
+		recurringOrder.DayOfMonth,
+		recurringOrder.StartDate,
+		recurringOrder.EndDate,
+		recurringOrder.NextRunAt,
+		recurringOrder.LastRunAt,
+		recurringOrder.CreatedBy,
+		recurringOrder.CreatedAt,
+		recurringOrder.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create recurring order: %w", err)
+	}
+
+	if err := insertRecurringOrderItems(ctx, tx, recurringOrder.ID, recurringOrder.Items); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a recurring order with its items
+func (r *PostgresRecurringOrderRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.RecurringOrder, error) {
+	query := `SELECT ` + recurringOrderColumns + ` FROM recurring_orders WHERE id = $1`
+
+	recurringOrder, err := scanRecurringOrder(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("recurring order with id %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get recurring order: %w", err)
+	}
+
+	if err := r.loadItems(ctx, recurringOrder); err != nil {
+		return nil, err
+	}
+
+	return recurringOrder, nil
+}
+
+// Update updates a recurring order and replaces its items
+func (r *PostgresRecurringOrderRepository) Update(ctx context.Context, recurringOrder *entities.RecurringOrder) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE recurring_orders SET
+			name = $2, status = $3, priority = $4, shipping_method = $5,
+			shipping_address_id = $6, billing_address_id = $7, currency = NULLIF($8, ''),
+			notes = $9, frequency = $10, interval_count = $11, day_of_month = $12,
+			start_date = $13, end_date = $14, next_run_at = $15, last_run_at = $16,
+			updated_at = $17
+		WHERE id = $1
+	`
+
+	result, err := tx.Exec(ctx, query,
+		recurringOrder.ID,
+		recurringOrder.Name,
+		recurringOrder.Status,
+		recurringOrder.Priority,
+		recurringOrder.ShippingMethod,
+		recurringOrder.ShippingAddressID,
+		recurringOrder.BillingAddressID,
+		recurringOrder.Currency,
+		recurringOrder.Notes,
+		recurringOrder.Frequency,
+		recurringOrder.Interval,
+		recurringOrder.DayOfMonth,
+		recurringOrder.StartDate,
+		recurringOrder.EndDate,
+		recurringOrder.NextRunAt,
+		recurringOrder.LastRunAt,
+		recurringOrder.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update recurring order: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("recurring order with id %s not found", recurringOrder.ID)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM recurring_order_items WHERE recurring_order_id = $1`, recurringOrder.ID); err != nil {
+		return fmt.Errorf("failed to replace recurring order items: %w", err)
+	}
+	if err := insertRecurringOrderItems(ctx, tx, recurringOrder.ID, recurringOrder.Items); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves recurring orders matching the filter, without their items
+func (r *PostgresRecurringOrderRepository) List(ctx context.Context, filter repositories.RecurringOrderFilter) ([]*entities.RecurringOrder, error) {
+	where, args := buildRecurringOrderConditions(filter)
+	query := `SELECT ` + recurringOrderColumns + ` FROM recurring_orders ro` + where + ` ORDER BY next_run_at NULLS LAST, created_at DESC`
+
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", len(args)+1)
+		args = append(args, filter.Limit)
+
+		offset := filter.Offset
+		if offset == 0 && filter.Page > 1 {
+			offset = (filter.Page - 1) * filter.Limit
+		}
+		if offset > 0 {
+			query += fmt.Sprintf(" OFFSET $%d", len(args)+1)
+			args = append(args, offset)
+		}
+	}
+
+	return r.queryRecurringOrders(ctx, query, args...)
+}
+
+// Count returns the number of recurring orders matching the filter
+func (r *PostgresRecurringOrderRepository) Count(ctx context.Context, filter repositories.RecurringOrderFilter) (int, error) {
+	where, args := buildRecurringOrderConditions(filter)
+
+	var count int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM recurring_orders ro`+where, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count recurring orders: %w", err)
+	}
+
+	return count, nil
+}
+
+// ListDue retrieves active recurring orders due at the given time with their items
+func (r *PostgresRecurringOrderRepository) ListDue(ctx context.Context, at time.Time, limit int) ([]*entities.RecurringOrder, error) {
+	query := `
+		SELECT ` + recurringOrderColumns + ` FROM recurring_orders
+		WHERE status = 'ACTIVE' AND next_run_at <= $1
+		ORDER BY next_run_at
+		LIMIT $2
+	`
+
+	recurringOrders, err := r.queryRecurringOrders(ctx, query, at, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, recurringOrder := range recurringOrders {
+		if err := r.loadItems(ctx, recurringOrder); err != nil {
+			return nil, err
+		}
+	}
+
+	return recurringOrders, nil
+}
+
+// ClaimOccurrence advances the schedule of a recurring order still due at dueAt
+func (r *PostgresRecurringOrderRepository) ClaimOccurrence(ctx context.Context, recurringOrder *entities.RecurringOrder, dueAt time.Time) (bool, error) {
+	query := `
+		UPDATE recurring_orders SET
+			status = $3, next_run_at = $4, last_run_at = $5, updated_at = $6
+		WHERE id = $1 AND status = 'ACTIVE' AND next_run_at = $2
+	`
+
+	result, err := r.db.Exec(ctx, query,
+		recurringOrder.ID,
+		dueAt,
+		recurringOrder.Status,
+		recurringOrder.NextRunAt,
+		recurringOrder.LastRunAt,
+		recurringOrder.UpdatedAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim recurring order occurrence: %w", err)
+	}
+
+	return result.RowsAffected() == 1, nil
+}
+
+// CreateRun records an occurrence of a recurring order
+func (r *PostgresRecurringOrderRepository) CreateRun(ctx context.Context, run *entities.RecurringOrderRun) error {
+	query := `
+		INSERT INTO recurring_order_runs (` + recurringOrderRunColumns + `) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9
+		)
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		run.ID,
+		run.RecurringOrderID,
+		run.ScheduledFor,
+		run.Status,
+		run.OrderID,
+		run.OrderNumber,
+		run.Reason,
+		run.CreatedBy,
+		run.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create recurring order run: %w", err)
+	}
+
+	return nil
+}
+
+// GetRuns retrieves the runs of a recurring order, latest occurrence first
+func (r *PostgresRecurringOrderRepository) GetRuns(ctx context.Context, recurringOrderID uuid.UUID) ([]*entities.RecurringOrderRun, error) {
+	query := `
+		SELECT ` + recurringOrderRunColumns + ` FROM recurring_order_runs
+		WHERE recurring_order_id = $1
+		ORDER BY scheduled_for DESC, created_at DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, recurringOrderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recurring order runs: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []*entities.RecurringOrderRun
+	for rows.Next() {
+		run := &entities.RecurringOrderRun{}
+		err := rows.Scan(
+			&run.ID,
+			&run.RecurringOrderID,
+			&run.ScheduledFor,
+			&run.Status,
+			&run.OrderID,
+			&run.OrderNumber,
+			&run.Reason,
+			&run.CreatedBy,
+			&run.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan recurring order run: %w", err)
+		}
+		runs = append(runs, run)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating recurring order runs: %w", err)
+	}
+
+	return runs, nil
+}
+
+func (r *PostgresRecurringOrderRepository) queryRecurringOrders(ctx context.Context, query string, args ...interface{}) ([]*entities.RecurringOrder, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recurring orders: %w", err)
+	}
+	defer rows.Close()
+
+	var recurringOrders []*entities.RecurringOrder
+	for rows.Next() {
+		recurringOrder, err := scanRecurringOrder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan recurring order row: %w", err)
+		}
+		recurringOrders = append(recurringOrders, recurringOrder)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating recurring order rows: %w", err)
+	}
+
+	return recurringOrders, nil
+}
+
+func (r *PostgresRecurringOrderRepository) loadItems(ctx context.Context, recurringOrder *entities.RecurringOrder) error {
+	query := `SELECT ` + recurringOrderItemColumns + ` FROM recurring_order_items WHERE recurring_order_id = $1 ORDER BY created_at, id`
+
+	rows, err := r.db.Query(ctx, query, recurringOrder.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get recurring order items: %w", err)
+	}
+	defer rows.Close()
+
+	recurringOrder.Items = nil
+	for rows.Next() {
+		var item entities.RecurringOrderItem
+		err := rows.Scan(
+			&item.ID,
+			&item.RecurringOrderID,
+			&item.ProductID,
+			&item.Quantity,
+			&item.Notes,
+			&item.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to scan recurring order item: %w", err)
+		}
+		recurringOrder.Items = append(recurringOrder.Items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating recurring order items: %w", err)
+	}
+
+	return nil
+}
+
+func insertRecurringOrderItems(ctx context.Context, tx pgx.Tx, recurringOrderID uuid.UUID, items []entities.RecurringOrderItem) error {
+	query := `INSERT INTO recurring_order_items (` + recurringOrderItemColumns + `) VALUES ($1, $2, $3, $4, $5, $6)`
+
+	for _, item := range items {
+		_, err := tx.Exec(ctx, query,
+			item.ID,
+			recurringOrderID,
+			item.ProductID,
+			item.Quantity,
+			item.Notes,
+			item.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create recurring order item: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func buildRecurringOrderConditions(filter repositories.RecurringOrderFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Search != "" {
+		args = append(args, "%"+filter.Search+"%")
+		conditions = append(conditions, fmt.Sprintf("ro.name ILIKE $%d", len(args)))
+	}
+
+	if len(filter.Status) > 0 {
+		placeholders := make([]string, len(filter.Status))
+		for i, status := range filter.Status {
+			args = append(args, status)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		conditions = append(conditions, "ro.status IN ("+strings.Join(placeholders, ", ")+")")
+	}
+
+	if filter.CustomerID != nil {
+		args = append(args, *filter.CustomerID)
+		conditions = append(conditions, fmt.Sprintf("ro.customer_id = $%d", len(args)))
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func scanRecurringOrder(row pgx.Row) (*entities.RecurringOrder, error) {
+	recurringOrder := &entities.RecurringOrder{}
+	var currency *string
+	err := row.Scan(
+		&recurringOrder.ID,
+		&recurringOrder.Name,
+		&recurringOrder.CustomerID,
+		&recurringOrder.Status,
+		&recurringOrder.Priority,
+		&recurringOrder.ShippingMethod,
+		&recurringOrder.ShippingAddressID,
+		&recurringOrder.BillingAddressID,
+		&currency,
+		&recurringOrder.Notes,
+		&recurringOrder.Frequency,
+		&recurringOrder.Interval,
+		&recurringOrder.DayOfMonth,
+		&recurringOrder.StartDate,
+		&recurringOrder.EndDate,
+		&recurringOrder.NextRunAt,
+		&recurringOrder.LastRunAt,
+		&recurringOrder.CreatedBy,
+		&recurringOrder.CreatedAt,
+		&recurringOrder.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if currency != nil {
+		recurringOrder.Currency = *currency
+	}
+	return recurringOrder, nil
+}
@@ -0,0 +1,423 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
+	"erpgo/pkg/database"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// PostgresReturnAuthorizationRepository implements ReturnAuthorizationRepository for PostgreSQL
+type PostgresReturnAuthorizationRepository struct {
+	db *database.Database
+}
+
+// NewPostgresReturnAuthorizationRepository creates a new PostgreSQL return authorization repository
+func NewPostgresReturnAuthorizationRepository(db *database.Database) *PostgresReturnAuthorizationRepository {
+	return &PostgresReturnAuthorizationRepository{
+		db: db,
+	}
+}
+
+const returnAuthorizationColumns = `
+	id, rma_number, order_id, customer_id, status, resolution, reason, customer_notes,
+	rejection_reason, warehouse_id, refund_amount, replacement_order_id, requested_by,
+	approved_by, received_by, inspected_by, created_at, updated_at, approved_at,
+	rejected_at, received_at, inspected_at, completed_at, cancelled_at
+`
+
+const returnAuthorizationItemColumns = `
+	id, return_authorization_id, order_item_id, product_id, product_sku, product_name,
+	quantity, quantity_received, unit_refund_amount, reason, disposition,
+	inspection_notes, created_at, updated_at
+`
+
+// Create creates a new return authorization with its items
+func (r *PostgresReturnAuthorizationRepository) Create(ctx context.Context, rma *entities.ReturnAuthorization) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rmaNumber := rma.RMANumber
+	if strings.TrimSpace(rmaNumber) == "" {
+		rmaNumber, err = allocateDocumentNumber(ctx, tx, entities.DocumentTypeForOrder(entities.OrderTypeReturn), rma.CreatedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	query := `
+		INSERT INTO return_authorizations (` + returnAuthorizationColumns + `) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12,
+			$13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24
+		)
+	`
+
+	_, err = tx.Exec(ctx, query,
+		rma.ID,
+		rmaNumber,
+		rma.OrderID,
+		rma.CustomerID,
+		rma.Status,
+		rma.Resolution,
+		rma.Reason,
+		rma.CustomerNotes,
+		rma.RejectionReason,
+		rma.WarehouseID,
+		rma.RefundAmount,
+		rma.ReplacementOrderID,
+		rma.RequestedBy,
+		rma.ApprovedBy,
+		rma.ReceivedBy,
+		rma.InspectedBy,
+		rma.CreatedAt,
+		rma.UpdatedAt,
+		rma.ApprovedAt,
+		rma.RejectedAt,
+		rma.ReceivedAt,
+		rma.InspectedAt,
+		rma.CompletedAt,
+		rma.CancelledAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create return authorization: %w", err)
+	}
+
+	itemQuery := `
+		INSERT INTO return_authorization_items (` + returnAuthorizationItemColumns + `) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
+		)
+	`
+	for _, item := range rma.Items {
+		_, err := tx.Exec(ctx, itemQuery,
+			item.ID,
+			rma.ID,
+			item.OrderItemID,
+			item.ProductID,
+			item.ProductSKU,
+			item.ProductName,
+			item.Quantity,
+			item.QuantityReceived,
+			item.UnitRefundAmount,
+			item.Reason,
+			item.Disposition,
+			item.InspectionNotes,
+			item.CreatedAt,
+			item.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create return authorization item: %w", err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	rma.RMANumber = rmaNumber
+	return nil
+}
+
+// GetByID retrieves a return authorization with its items
+func (r *PostgresReturnAuthorizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.ReturnAuthorization, error) {
+	query := `SELECT ` + returnAuthorizationColumns + ` FROM return_authorizations WHERE id = $1`
+
+	rma, err := scanReturnAuthorization(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("return authorization with id %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get return authorization: %w", err)
+	}
+
+	if err := r.loadItems(ctx, rma); err != nil {
+		return nil, err
+	}
+
+	return rma, nil
+}
+
+// GetByOrderID retrieves the return authorizations of an order with their items, oldest first
+func (r *PostgresReturnAuthorizationRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.ReturnAuthorization, error) {
+	query := `SELECT ` + returnAuthorizationColumns + ` FROM return_authorizations WHERE order_id = $1 ORDER BY created_at`
+
+	rows, err := r.db.Query(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get return authorizations by order: %w", err)
+	}
+	defer rows.Close()
+
+	var rmas []*entities.ReturnAuthorization
+	for rows.Next() {
+		rma, err := scanReturnAuthorization(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan return authorization row: %w", err)
+		}
+		rmas = append(rmas, rma)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating return authorization rows: %w", err)
+	}
+
+	for _, rma := range rmas {
+		if err := r.loadItems(ctx, rma); err != nil {
+			return nil, err
+		}
+	}
+
+	return rmas, nil
+}
+
+// Update updates a return authorization and the receipt and inspection
+// progress of its items. The authorized lines themselves never change.
+func (r *PostgresReturnAuthorizationRepository) Update(ctx context.Context, rma *entities.ReturnAuthorization) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE return_authorizations SET
+			status = $2, resolution = $3, rejection_reason = $4, warehouse_id = $5,
+			refund_amount = $6, replacement_order_id = $7, approved_by = $8,
+			received_by = $9, inspected_by = $10, updated_at = $11, approved_at = $12,
+			rejected_at = $13, received_at = $14, inspected_at = $15, completed_at = $16,
+			cancelled_at = $17
+		WHERE id = $1
+	`
+
+	result, err := tx.Exec(ctx, query,
+		rma.ID,
+		rma.Status,
+		rma.Resolution,
+		rma.RejectionReason,
+		rma.WarehouseID,
+		rma.RefundAmount,
+		rma.ReplacementOrderID,
+		rma.ApprovedBy,
+		rma.ReceivedBy,
+		rma.InspectedBy,
+		rma.UpdatedAt,
+		rma.ApprovedAt,
+		rma.RejectedAt,
+		rma.ReceivedAt,
+		rma.InspectedAt,
+		rma.CompletedAt,
+		rma.CancelledAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update return authorization: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("return authorization with id %s not found", rma.ID)
+	}
+
+	itemQuery := `
+		UPDATE return_authorization_items SET
+			quantity_received = $3, disposition = $4, inspection_notes = $5, updated_at = $6
+		WHERE id = $1 AND return_authorization_id = $2
+	`
+	for _, item := range rma.Items {
+		_, err := tx.Exec(ctx, itemQuery,
+			item.ID,
+			rma.ID,
+			item.QuantityReceived,
+			item.Disposition,
+			item.InspectionNotes,
+			item.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update return authorization item: %w", err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Lock locks the return authorization's row until the transaction of the context ends
+func (r *PostgresReturnAuthorizationRepository) Lock(ctx context.Context, id uuid.UUID) error {
+	var locked uuid.UUID
+	err := r.db.QueryRow(ctx, `SELECT id FROM return_authorizations WHERE id = $1 FOR UPDATE`, id).Scan(&locked)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("return authorization with id %s not found", id)
+		}
+		return fmt.Errorf("failed to lock return authorization: %w", err)
+	}
+	return nil
+}
+
+// List retrieves return authorizations matching the filter, without their items
+func (r *PostgresReturnAuthorizationRepository) List(ctx context.Context, filter repositories.ReturnAuthorizationFilter) ([]*entities.ReturnAuthorization, error) {
+	where, args := buildReturnAuthorizationConditions(filter)
+	query := `SELECT ` + returnAuthorizationColumns + ` FROM return_authorizations` + where + ` ORDER BY created_at DESC`
+
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", len(args)+1)
+		args = append(args, filter.Limit)
+
+		offset := filter.Offset
+		if offset == 0 && filter.Page > 1 {
+			offset = (filter.Page - 1) * filter.Limit
+		}
+		if offset > 0 {
+			query += fmt.Sprintf(" OFFSET $%d", len(args)+1)
+			args = append(args, offset)
+		}
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list return authorizations: %w", err)
+	}
+	defer rows.Close()
+
+	var rmas []*entities.ReturnAuthorization
+	for rows.Next() {
+		rma, err := scanReturnAuthorization(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan return authorization row: %w", err)
+		}
+		rmas = append(rmas, rma)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating return authorization rows: %w", err)
+	}
+
+	return rmas, nil
+}
+
+// Count returns the number of return authorizations matching the filter
+func (r *PostgresReturnAuthorizationRepository) Count(ctx context.Context, filter repositories.ReturnAuthorizationFilter) (int, error) {
+	where, args := buildReturnAuthorizationConditions(filter)
+
+	var count int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM return_authorizations`+where, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count return authorizations: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *PostgresReturnAuthorizationRepository) loadItems(ctx context.Context, rma *entities.ReturnAuthorization) error {
+	query := `SELECT ` + returnAuthorizationItemColumns + ` FROM return_authorization_items WHERE return_authorization_id = $1 ORDER BY created_at, id`
+
+	rows, err := r.db.Query(ctx, query, rma.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get return authorization items: %w", err)
+	}
+	defer rows.Close()
+
+	rma.Items = nil
+	for rows.Next() {
+		var item entities.ReturnAuthorizationItem
+		err := rows.Scan(
+			&item.ID,
+			&item.ReturnAuthorizationID,
+			&item.OrderItemID,
+			&item.ProductID,
+			&item.ProductSKU,
+			&item.ProductName,
+			&item.Quantity,
+			&item.QuantityReceived,
+			&item.UnitRefundAmount,
+			&item.Reason,
+			&item.Disposition,
+			&item.InspectionNotes,
+			&item.CreatedAt,
+			&item.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to scan return authorization item: %w", err)
+		}
+		rma.Items = append(rma.Items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating return authorization items: %w", err)
+	}
+
+	return nil
+}
+
+func buildReturnAuthorizationConditions(filter repositories.ReturnAuthorizationFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Search != "" {
+		args = append(args, "%"+filter.Search+"%")
+		conditions = append(conditions, fmt.Sprintf("rma_number ILIKE $%d", len(args)))
+	}
+
+	if len(filter.Status) > 0 {
+		placeholders := make([]string, len(filter.Status))
+		for i, status := range filter.Status {
+			args = append(args, status)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		conditions = append(conditions, "status IN ("+strings.Join(placeholders, ", ")+")")
+	}
+
+	if filter.OrderID != nil {
+		args = append(args, *filter.OrderID)
+		conditions = append(conditions, fmt.Sprintf("order_id = $%d", len(args)))
+	}
+
+	if filter.CustomerID != nil {
+		args = append(args, *filter.CustomerID)
+		conditions = append(conditions, fmt.Sprintf("customer_id = $%d", len(args)))
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func scanReturnAuthorization(row pgx.Row) (*entities.ReturnAuthorization, error) {
+	rma := &entities.ReturnAuthorization{}
+	err := row.Scan(
+		&rma.ID,
+		&rma.RMANumber,
+		&rma.OrderID,
+		&rma.CustomerID,
+		&rma.Status,
+		&rma.Resolution,
+		&rma.Reason,
+		&rma.CustomerNotes,
+		&rma.RejectionReason,
+		&rma.WarehouseID,
+		&rma.RefundAmount,
+		&rma.ReplacementOrderID,
+		&rma.RequestedBy,
+		&rma.ApprovedBy,
+		&rma.ReceivedBy,
+		&rma.InspectedBy,
+		&rma.CreatedAt,
+		&rma.UpdatedAt,
+		&rma.ApprovedAt,
+		&rma.RejectedAt,
+		&rma.ReceivedAt,
+		&rma.InspectedAt,
+		&rma.CompletedAt,
+		&rma.CancelledAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return rma, nil
+}
@@ -0,0 +1,267 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/pkg/database"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// PostgresShipmentRepository implements ShipmentRepository for PostgreSQL
+type PostgresShipmentRepository struct {
+	db *database.Database
+}
+
+// NewPostgresShipmentRepository creates a new PostgreSQL shipment repository
+func NewPostgresShipmentRepository(db *database.Database) *PostgresShipmentRepository {
+	return &PostgresShipmentRepository{
+		db: db,
+	}
+}
+
+const shipmentColumns = `
+	id, order_id, shipment_number, status, carrier, tracking_number, delivery_proof,
+	notes, shipped_by, delivered_by, shipped_at, delivered_at, created_at, updated_at
+`
+
+const shipmentItemColumns = `id, shipment_id, order_item_id, product_id, quantity`
+
+const shipmentPackageColumns = `
+	id, shipment_id, tracking_number, weight, weight_unit, length, width, height, dimension_unit
+`
+
+// Create creates a new shipment with its items and packages
+func (r *PostgresShipmentRepository) Create(ctx context.Context, shipment *entities.Shipment) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO shipments (` + shipmentColumns + `) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
+		)
+	`
+
+	_, err = tx.Exec(ctx, query,
+		shipment.ID,
+		shipment.OrderID,
+		shipment.ShipmentNumber,
+		shipment.Status,
+		shipment.Carrier,
+		shipment.TrackingNumber,
+		shipment.DeliveryProof,
+		shipment.Notes,
+		shipment.ShippedBy,
+		shipment.DeliveredBy,
+		shipment.ShippedAt,
+		shipment.DeliveredAt,
+		shipment.CreatedAt,
+		shipment.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create shipment: %w", err)
+	}
+
+	itemQuery := `INSERT INTO shipment_items (` + shipmentItemColumns + `) VALUES ($1, $2, $3, $4, $5)`
+	for _, item := range shipment.Items {
+		_, err := tx.Exec(ctx, itemQuery,
+			item.ID,
+			shipment.ID,
+			item.OrderItemID,
+			item.ProductID,
+			item.Quantity,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create shipment item: %w", err)
+		}
+	}
+
+	packageQuery := `
+		INSERT INTO shipment_packages (` + shipmentPackageColumns + `) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9
+		)
+	`
+	for _, pkg := range shipment.Packages {
+		_, err := tx.Exec(ctx, packageQuery,
+			pkg.ID,
+			shipment.ID,
+			pkg.TrackingNumber,
+			pkg.Weight,
+			pkg.WeightUnit,
+			pkg.Length,
+			pkg.Width,
+			pkg.Height,
+			pkg.DimensionUnit,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create shipment package: %w", err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a shipment with its items and packages
+func (r *PostgresShipmentRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Shipment, error) {
+	query := `SELECT ` + shipmentColumns + ` FROM shipments WHERE id = $1`
+
+	shipment, err := scanShipment(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("shipment with id %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get shipment: %w", err)
+	}
+
+	if err := r.loadDetails(ctx, shipment); err != nil {
+		return nil, err
+	}
+
+	return shipment, nil
+}
+
+// GetByOrderID retrieves the shipments of an order with their items and packages, oldest first
+func (r *PostgresShipmentRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.Shipment, error) {
+	query := `SELECT ` + shipmentColumns + ` FROM shipments WHERE order_id = $1 ORDER BY shipped_at, created_at`
+
+	rows, err := r.db.Query(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shipments by order: %w", err)
+	}
+	defer rows.Close()
+
+	var shipments []*entities.Shipment
+	for rows.Next() {
+		shipment, err := scanShipment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan shipment row: %w", err)
+		}
+		shipments = append(shipments, shipment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating shipment rows: %w", err)
+	}
+
+	for _, shipment := range shipments {
+		if err := r.loadDetails(ctx, shipment); err != nil {
+			return nil, err
+		}
+	}
+
+	return shipments, nil
+}
+
+// Update updates the delivery state and notes of a shipment
+func (r *PostgresShipmentRepository) Update(ctx context.Context, shipment *entities.Shipment) error {
+	query := `
+		UPDATE shipments SET
+			status = $2, carrier = $3, tracking_number = $4, delivery_proof = $5, notes = $6,
+			delivered_by = $7, delivered_at = $8, updated_at = $9
+		WHERE id = $1
+	`
+
+	result, err := r.db.Exec(ctx, query,
+		shipment.ID,
+		shipment.Status,
+		shipment.Carrier,
+		shipment.TrackingNumber,
+		shipment.DeliveryProof,
+		shipment.Notes,
+		shipment.DeliveredBy,
+		shipment.DeliveredAt,
+		shipment.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update shipment: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("shipment with id %s not found", shipment.ID)
+	}
+
+	return nil
+}
+
+func (r *PostgresShipmentRepository) loadDetails(ctx context.Context, shipment *entities.Shipment) error {
+	rows, err := r.db.Query(ctx, `SELECT `+shipmentItemColumns+` FROM shipment_items WHERE shipment_id = $1 ORDER BY id`, shipment.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get shipment items: %w", err)
+	}
+	defer rows.Close()
+
+	shipment.Items = nil
+	for rows.Next() {
+		var item entities.ShipmentItem
+		if err := rows.Scan(&item.ID, &item.ShipmentID, &item.OrderItemID, &item.ProductID, &item.Quantity); err != nil {
+			return fmt.Errorf("failed to scan shipment item: %w", err)
+		}
+		shipment.Items = append(shipment.Items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating shipment items: %w", err)
+	}
+
+	packageRows, err := r.db.Query(ctx, `SELECT `+shipmentPackageColumns+` FROM shipment_packages WHERE shipment_id = $1 ORDER BY id`, shipment.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get shipment packages: %w", err)
+	}
+	defer packageRows.Close()
+
+	shipment.Packages = nil
+	for packageRows.Next() {
+		var pkg entities.ShipmentPackage
+		err := packageRows.Scan(
+			&pkg.ID,
+			&pkg.ShipmentID,
+			&pkg.TrackingNumber,
+			&pkg.Weight,
+			&pkg.WeightUnit,
+			&pkg.Length,
+			&pkg.Width,
+			&pkg.Height,
+			&pkg.DimensionUnit,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to scan shipment package: %w", err)
+		}
+		shipment.Packages = append(shipment.Packages, pkg)
+	}
+	if err := packageRows.Err(); err != nil {
+		return fmt.Errorf("error iterating shipment packages: %w", err)
+	}
+
+	return nil
+}
+
+func scanShipment(row pgx.Row) (*entities.Shipment, error) {
+	shipment := &entities.Shipment{}
+	err := row.Scan(
+		&shipment.ID,
+		&shipment.OrderID,
+		&shipment.ShipmentNumber,
+		&shipment.Status,
+		&shipment.Carrier,
+		&shipment.TrackingNumber,
+		&shipment.DeliveryProof,
+		&shipment.Notes,
+		&shipment.ShippedBy,
+		&shipment.DeliveredBy,
+		&shipment.ShippedAt,
+		&shipment.DeliveredAt,
+		&shipment.CreatedAt,
+		&shipment.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return shipment, nil
+}
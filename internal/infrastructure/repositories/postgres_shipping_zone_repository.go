@@ -0,0 +1,405 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
+	"erpgo/pkg/database"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// PostgresShippingZoneRepository implements ShippingZoneRepository for PostgreSQL
+type PostgresShippingZoneRepository struct {
+	db *database.Database
+}
+
+// NewPostgresShippingZoneRepository creates a new PostgreSQL shipping zone repository
+func NewPostgresShippingZoneRepository(db *database.Database) *PostgresShippingZoneRepository {
+	return &PostgresShippingZoneRepository{
+		db: db,
+	}
+}
+
+const shippingZoneColumns = `
+	id, code, name, country, state, postal_code_prefixes, is_active, created_at, updated_at
+`
+
+const shippingRateColumns = `
+	id, zone_id, method, name, min_weight, max_weight, base_rate, per_kg_rate,
+	handling_fee, free_shipping_threshold, dimensional_divisor, min_delivery_days,
+	max_delivery_days, is_active, created_at, updated_at
+`
+
+// Create creates a new shipping zone with its rates
+func (r *PostgresShippingZoneRepository) Create(ctx context.Context, zone *entities.ShippingZone) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `INSERT INTO shipping_zones (` + shippingZoneColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err = tx.Exec(ctx, query,
+		zone.ID,
+		zone.Code,
+		zone.Name,
+		zone.Country,
+		zone.State,
+		postalCodePrefixes(zone.PostalCodePrefixes),
+		zone.IsActive,
+		zone.CreatedAt,
+		zone.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create shipping zone: %w", err)
+	}
+
+	for i := range zone.Rates {
+		if err := insertShippingRate(ctx, tx, &zone.Rates[i]); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a shipping zone with its rates
+func (r *PostgresShippingZoneRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.ShippingZone, error) {
+	query := `SELECT ` + shippingZoneColumns + ` FROM shipping_zones WHERE id = $1`
+
+	zone, err := scanShippingZone(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("shipping zone with id %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get shipping zone: %w", err)
+	}
+
+	if err := r.loadRates(ctx, []*entities.ShippingZone{zone}, false); err != nil {
+		return nil, err
+	}
+
+	return zone, nil
+}
+
+// GetByCode retrieves a shipping zone by its code
+func (r *PostgresShippingZoneRepository) GetByCode(ctx context.Context, code string) (*entities.ShippingZone, error) {
+	query := `SELECT ` + shippingZoneColumns + ` FROM shipping_zones WHERE code = $1`
+
+	zone, err := scanShippingZone(r.db.QueryRow(ctx, query, code))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("shipping zone with code %s not found", code)
+		}
+		return nil, fmt.Errorf("failed to get shipping zone: %w", err)
+	}
+
+	if err := r.loadRates(ctx, []*entities.ShippingZone{zone}, false); err != nil {
+		return nil, err
+	}
+
+	return zone, nil
+}
+
+// GetActiveByCountry retrieves the active zones of a country with their active rates
+func (r *PostgresShippingZoneRepository) GetActiveByCountry(ctx context.Context, country string) ([]*entities.ShippingZone, error) {
+	query := `
+		SELECT ` + shippingZoneColumns + ` FROM shipping_zones
+		WHERE country = $1 AND is_active
+		ORDER BY state IS NOT NULL, cardinality(postal_code_prefixes) > 0, code
+	`
+
+	return r.query(ctx, query, true, strings.ToUpper(strings.TrimSpace(country)))
+}
+
+// Update updates a shipping zone
+func (r *PostgresShippingZoneRepository) Update(ctx context.Context, zone *entities.ShippingZone) error {
+	query := `
+		UPDATE shipping_zones SET
+			name = $2, state = $3, postal_code_prefixes = $4, is_active = $5, updated_at = $6
+		WHERE id = $1
+	`
+
+	result, err := r.db.Exec(ctx, query,
+		zone.ID,
+		zone.Name,
+		zone.State,
+		postalCodePrefixes(zone.PostalCodePrefixes),
+		zone.IsActive,
+		zone.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update shipping zone: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("shipping zone with id %s not found", zone.ID)
+	}
+
+	return nil
+}
+
+// List retrieves shipping zones matching the filter with their rates
+func (r *PostgresShippingZoneRepository) List(ctx context.Context, filter repositories.ShippingZoneFilter) ([]*entities.ShippingZone, error) {
+	where, args := buildShippingZoneConditions(filter)
+	query := `SELECT ` + shippingZoneColumns + ` FROM shipping_zones` + where + ` ORDER BY country, state NULLS FIRST, code`
+
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", len(args)+1)
+		args = append(args, filter.Limit)
+
+		offset := filter.Offset
+		if offset == 0 && filter.Page > 1 {
+			offset = (filter.Page - 1) * filter.Limit
+		}
+		if offset > 0 {
+			query += fmt.Sprintf(" OFFSET $%d", len(args)+1)
+			args = append(args, offset)
+		}
+	}
+
+	return r.query(ctx, query, false, args...)
+}
+
+// Count returns the number of shipping zones matching the filter
+func (r *PostgresShippingZoneRepository) Count(ctx context.Context, filter repositories.ShippingZoneFilter) (int, error) {
+	where, args := buildShippingZoneConditions(filter)
+
+	var count int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM shipping_zones`+where, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count shipping zones: %w", err)
+	}
+
+	return count, nil
+}
+
+// CreateRate adds a rate to a shipping zone
+func (r *PostgresShippingZoneRepository) CreateRate(ctx context.Context, rate *entities.ShippingRate) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := insertShippingRate(ctx, tx, rate); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateRate updates a shipping rate
+func (r *PostgresShippingZoneRepository) UpdateRate(ctx context.Context, rate *entities.ShippingRate) error {
+	query := `
+		UPDATE shipping_rates SET
+			method = $3, name = $4, min_weight = $5, max_weight = $6, base_rate = $7,
+			per_kg_rate = $8, handling_fee = $9, free_shipping_threshold = $10,
+			dimensional_divisor = $11, min_delivery_days = $12, max_delivery_days = $13,
+			is_active = $14, updated_at = $15
+		WHERE id = $1 AND zone_id = $2
+	`
+
+	result, err := r.db.Exec(ctx, query,
+		rate.ID,
+		rate.ZoneID,
+		rate.Method,
+		rate.Name,
+		rate.MinWeight,
+		rate.MaxWeight,
+		rate.BaseRate,
+		rate.PerKgRate,
+		rate.HandlingFee,
+		rate.FreeShippingThreshold,
+		rate.DimensionalDivisor,
+		rate.MinDeliveryDays,
+		rate.MaxDeliveryDays,
+		rate.IsActive,
+		rate.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update shipping rate: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("shipping rate with id %s not found", rate.ID)
+	}
+
+	return nil
+}
+
+func (r *PostgresShippingZoneRepository) query(ctx context.Context, query string, activeRatesOnly bool, args ...interface{}) ([]*entities.ShippingZone, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query shipping zones: %w", err)
+	}
+	defer rows.Close()
+
+	var zones []*entities.ShippingZone
+	for rows.Next() {
+		zone, err := scanShippingZone(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan shipping zone row: %w", err)
+		}
+		zones = append(zones, zone)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating shipping zone rows: %w", err)
+	}
+
+	if err := r.loadRates(ctx, zones, activeRatesOnly); err != nil {
+		return nil, err
+	}
+
+	return zones, nil
+}
+
+// loadRates loads the rates of several zones in one query
+func (r *PostgresShippingZoneRepository) loadRates(ctx context.Context, zones []*entities.ShippingZone, activeOnly bool) error {
+	if len(zones) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(zones))
+	byID := make(map[uuid.UUID]*entities.ShippingZone, len(zones))
+	for i, zone := range zones {
+		ids[i] = zone.ID
+		byID[zone.ID] = zone
+		zone.Rates = nil
+	}
+
+	query := `SELECT ` + shippingRateColumns + ` FROM shipping_rates WHERE zone_id = ANY($1)`
+	if activeOnly {
+		query += ` AND is_active`
+	}
+	query += ` ORDER BY zone_id, method, min_weight, id`
+
+	rows, err := r.db.Query(ctx, query, ids)
+	if err != nil {
+		return fmt.Errorf("failed to get shipping rates: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var rate entities.ShippingRate
+		err := rows.Scan(
+			&rate.ID,
+			&rate.ZoneID,
+			&rate.Method,
+			&rate.Name,
+			&rate.MinWeight,
+			&rate.MaxWeight,
+			&rate.BaseRate,
+			&rate.PerKgRate,
+			&rate.HandlingFee,
+			&rate.FreeShippingThreshold,
+			&rate.DimensionalDivisor,
+			&rate.MinDeliveryDays,
+			&rate.MaxDeliveryDays,
+			&rate.IsActive,
+			&rate.CreatedAt,
+			&rate.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to scan shipping rate: %w", err)
+		}
+		zone := byID[rate.ZoneID]
+		zone.Rates = append(zone.Rates, rate)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating shipping rates: %w", err)
+	}
+
+	return nil
+}
+
+func insertShippingRate(ctx context.Context, tx pgx.Tx, rate *entities.ShippingRate) error {
+	query := `INSERT INTO shipping_rates (` + shippingRateColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)`
+
+	_, err := tx.Exec(ctx, query,
+		rate.ID,
+		rate.ZoneID,
+		rate.Method,
+		rate.Name,
+		rate.MinWeight,
+		rate.MaxWeight,
+		rate.BaseRate,
+		rate.PerKgRate,
+		rate.HandlingFee,
+		rate.FreeShippingThreshold,
+		rate.DimensionalDivisor,
+		rate.MinDeliveryDays,
+		rate.MaxDeliveryDays,
+		rate.IsActive,
+		rate.CreatedAt,
+		rate.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create shipping rate: %w", err)
+	}
+
+	return nil
+}
+
+func buildShippingZoneConditions(filter repositories.ShippingZoneFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Search != "" {
+		args = append(args, "%"+filter.Search+"%")
+		conditions = append(conditions, fmt.Sprintf("(code ILIKE $%d OR name ILIKE $%d)", len(args), len(args)))
+	}
+
+	if filter.Country != "" {
+		args = append(args, strings.ToUpper(filter.Country))
+		conditions = append(conditions, fmt.Sprintf("country = $%d", len(args)))
+	}
+
+	if filter.State != nil {
+		args = append(args, *filter.State)
+		conditions = append(conditions, fmt.Sprintf("state ILIKE $%d", len(args)))
+	}
+
+	if filter.IsActive != nil {
+		args = append(args, *filter.IsActive)
+		conditions = append(conditions, fmt.Sprintf("is_active = $%d", len(args)))
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func scanShippingZone(row pgx.Row) (*entities.ShippingZone, error) {
+	zone := &entities.ShippingZone{}
+	err := row.Scan(
+		&zone.ID,
+		&zone.Code,
+		&zone.Name,
+		&zone.Country,
+		&zone.State,
+		&zone.PostalCodePrefixes,
+		&zone.IsActive,
+		&zone.CreatedAt,
+		&zone.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return zone, nil
+}
@@ -0,0 +1,329 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
+	"erpgo/pkg/database"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// PostgresSourcingRepository implements SourcingRepository for PostgreSQL
+type PostgresSourcingRepository struct {
+	db *database.Database
+}
+
+// NewPostgresSourcingRepository creates a new PostgreSQL sourcing repository
+func NewPostgresSourcingRepository(db *database.Database) *PostgresSourcingRepository {
+	return &PostgresSourcingRepository{
+		db: db,
+	}
+}
+
+const sourcingRuleColumns = `
+	id, name, description, countries, shipping_methods, strategies, priority,
+	is_active, created_by, created_at, updated_at
+`
+
+const orderAllocationColumns = `
+	id, order_id, order_item_id, product_id, warehouse_id, quantity, rule_id,
+	created_at, updated_at
+`
+
+// CreateRule creates a new sourcing rule
+func (r *PostgresSourcingRepository) CreateRule(ctx context.Context, rule *entities.SourcingRule) error {
+	query := `INSERT INTO sourcing_rules (` + sourcingRuleColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+
+	_, err := r.db.Exec(ctx, query,
+		rule.ID,
+		rule.Name,
+		rule.Description,
+		stringArray(rule.Countries),
+		shippingMethodArray(rule.ShippingMethods),
+		sourcingStrategyArray(rule.Strategies),
+		rule.Priority,
+		rule.IsActive,
+		rule.CreatedBy,
+		rule.CreatedAt,
+		rule.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create sourcing rule: %w", err)
+	}
+
+	return nil
+}
+
+// GetRuleByID retrieves a sourcing rule by ID
+func (r *PostgresSourcingRepository) GetRuleByID(ctx context.Context, id uuid.UUID) (*entities.SourcingRule, error) {
+	query := `SELECT ` + sourcingRuleColumns + ` FROM sourcing_rules WHERE id = $1`
+
+	rule, err := scanSourcingRule(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("sourcing rule with id %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get sourcing rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+// UpdateRule updates a sourcing rule
+func (r *PostgresSourcingRepository) UpdateRule(ctx context.Context, rule *entities.SourcingRule) error {
+	query := `
+		UPDATE sourcing_rules SET
+			name = $2, description = $3, countries = $4, shipping_methods = $5,
+			strategies = $6, priority = $7, is_active = $8, updated_at = $9
+		WHERE id = $1
+	`
+
+	result, err := r.db.Exec(ctx, query,
+		rule.ID,
+		rule.Name,
+		rule.Description,
+		stringArray(rule.Countries),
+		shippingMethodArray(rule.ShippingMethods),
+		sourcingStrategyArray(rule.Strategies),
+		rule.Priority,
+		rule.IsActive,
+		rule.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update sourcing rule: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("sourcing rule with id %s not found", rule.ID)
+	}
+
+	return nil
+}
+
+// ListRules retrieves sourcing rules matching the filter, highest priority first
+func (r *PostgresSourcingRepository) ListRules(ctx context.Context, filter repositories.SourcingRuleFilter) ([]*entities.SourcingRule, error) {
+	where, args := buildSourcingRuleConditions(filter)
+	query := `SELECT ` + sourcingRuleColumns + ` FROM sourcing_rules` + where + ` ORDER BY priority DESC, name`
+
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", len(args)+1)
+		args = append(args, filter.Limit)
+
+		offset := filter.Offset
+		if offset == 0 && filter.Page > 1 {
+			offset = (filter.Page - 1) * filter.Limit
+		}
+		if offset > 0 {
+			query += fmt.Sprintf(" OFFSET $%d", len(args)+1)
+			args = append(args, offset)
+		}
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sourcing rules: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []*entities.SourcingRule
+	for rows.Next() {
+		rule, err := scanSourcingRule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan sourcing rule row: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating sourcing rule rows: %w", err)
+	}
+
+	return rules, nil
+}
+
+// CountRules returns the number of sourcing rules matching the filter
+func (r *PostgresSourcingRepository) CountRules(ctx context.Context, filter repositories.SourcingRuleFilter) (int, error) {
+	where, args := buildSourcingRuleConditions(filter)
+
+	var count int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM sourcing_rules`+where, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count sourcing rules: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetAllocationsByOrderID retrieves the warehouse allocations of an order's lines
+func (r *PostgresSourcingRepository) GetAllocationsByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.OrderAllocation, error) {
+	query := `SELECT ` + orderAllocationColumns + ` FROM order_allocations WHERE order_id = $1 ORDER BY order_item_id, created_at`
+
+	rows, err := r.db.Query(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query order allocations: %w", err)
+	}
+	defer rows.Close()
+
+	var allocations []*entities.OrderAllocation
+	for rows.Next() {
+		allocation := &entities.OrderAllocation{}
+		err := rows.Scan(
+			&allocation.ID,
+			&allocation.OrderID,
+			&allocation.OrderItemID,
+			&allocation.ProductID,
+			&allocation.WarehouseID,
+			&allocation.Quantity,
+			&allocation.RuleID,
+			&allocation.CreatedAt,
+			&allocation.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan order allocation row: %w", err)
+		}
+		allocations = append(allocations, allocation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating order allocation rows: %w", err)
+	}
+
+	return allocations, nil
+}
+
+// ReplaceAllocations replaces the allocations of an order in one transaction
+func (r *PostgresSourcingRepository) ReplaceAllocations(ctx context.Context, orderID uuid.UUID, allocations []*entities.OrderAllocation) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM order_allocations WHERE order_id = $1`, orderID); err != nil {
+		return fmt.Errorf("failed to delete order allocations: %w", err)
+	}
+
+	query := `INSERT INTO order_allocations (` + orderAllocationColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	for _, allocation := range allocations {
+		if allocation.Quantity <= 0 {
+			continue
+		}
+		_, err := tx.Exec(ctx, query,
+			allocation.ID,
+			orderID,
+			allocation.OrderItemID,
+			allocation.ProductID,
+			allocation.WarehouseID,
+			allocation.Quantity,
+			allocation.RuleID,
+			allocation.CreatedAt,
+			allocation.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create order allocation: %w", err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// AddAllocation adds the quantity of the allocation to the order line's
+// allocation in its warehouse, creating it when missing
+func (r *PostgresSourcingRepository) AddAllocation(ctx context.Context, allocation *entities.OrderAllocation) error {
+	query := `
+		INSERT INTO order_allocations (` + orderAllocationColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (order_item_id, warehouse_id) DO UPDATE SET
+			quantity = order_allocations.quantity + EXCLUDED.quantity,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		allocation.ID,
+		allocation.OrderID,
+		allocation.OrderItemID,
+		allocation.ProductID,
+		allocation.WarehouseID,
+		allocation.Quantity,
+		allocation.RuleID,
+		allocation.CreatedAt,
+		allocation.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add order allocation: %w", err)
+	}
+
+	return nil
+}
+
+func buildSourcingRuleConditions(filter repositories.SourcingRuleFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Search != "" {
+		args = append(args, "%"+filter.Search+"%")
+		conditions = append(conditions, fmt.Sprintf("name ILIKE $%d", len(args)))
+	}
+
+	if filter.IsActive != nil {
+		args = append(args, *filter.IsActive)
+		conditions = append(conditions, fmt.Sprintf("is_active = $%d", len(args)))
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func scanSourcingRule(row pgx.Row) (*entities.SourcingRule, error) {
+	rule := &entities.SourcingRule{}
+	var methods, strategies []string
+	err := row.Scan(
+		&rule.ID,
+		&rule.Name,
+		&rule.Description,
+		&rule.Countries,
+		&methods,
+		&strategies,
+		&rule.Priority,
+		&rule.IsActive,
+		&rule.CreatedBy,
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, method := range methods {
+		rule.ShippingMethods = append(rule.ShippingMethods, entities.ShippingMethod(method))
+	}
+	for _, strategy := range strategies {
+		rule.Strategies = append(rule.Strategies, entities.SourcingStrategy(strategy))
+	}
+
+	return rule, nil
+}
+
+func shippingMethodArray(methods []entities.ShippingMethod) []string {
+	values := make([]string, len(methods))
+	for i, method := range methods {
+		values[i] = string(method)
+	}
+	return values
+}
+
+func sourcingStrategyArray(strategies []entities.SourcingStrategy) []string {
+	values := make([]string, len(strategies))
+	for i, strategy := range strategies {
+		values[i] = string(strategy)
+	}
+	return values
+}
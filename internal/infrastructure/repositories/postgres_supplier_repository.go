@@ -0,0 +1,391 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"erpgo/internal/domain/purchasing/entities"
+	"erpgo/internal/domain/purchasing/repositories"
+	"erpgo/pkg/database"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// PostgresSupplierRepository implements SupplierRepository for PostgreSQL
+type PostgresSupplierRepository struct {
+	db *database.Database
+}
+
+// NewPostgresSupplierRepository creates a new PostgreSQL supplier repository
+func NewPostgresSupplierRepository(db *database.Database) *PostgresSupplierRepository {
+	return &PostgresSupplierRepository{
+		db: db,
+	}
+}
+
+const supplierColumns = `
+	id, supplier_code, name, legal_name, tax_id, COALESCE(email, ''), COALESCE(phone, ''),
+	website, address_line1, address_line2, city, state, postal_code, country, currency,
+	payment_terms, lead_time_days, is_active, notes, created_at, updated_at
+`
+
+const supplierContactColumns = `
+	id, supplier_id, name, role, email, phone, is_primary, created_at, updated_at
+`
+
+// Create creates a new supplier with its contacts
+func (r *PostgresSupplierRepository) Create(ctx context.Context, supplier *entities.Supplier) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO suppliers (
+			id, supplier_code, name, legal_name, tax_id, email, phone, website,
+			address_line1, address_line2, city, state, postal_code, country, currency,
+			payment_terms, lead_time_days, is_active, notes, created_at, updated_at
+		) VALUES (
+			$1, $2, $3, $4, $5, NULLIF($6, ''), NULLIF($7, ''), $8, $9, $10, $11,
+			$12, $13, $14, $15, $16, $17, $18, $19, $20, $21
+		)
+	`
+
+	_, err = tx.Exec(ctx, query,
+		supplier.ID,
+		supplier.SupplierCode,
+		supplier.Name,
+		supplier.LegalName,
+		supplier.TaxID,
+		supplier.Email,
+		supplier.Phone,
+		supplier.Website,
+		supplier.AddressLine1,
+		supplier.AddressLine2,
+		supplier.City,
+		supplier.State,
+		supplier.PostalCode,
+		supplier.Country,
+		supplier.Currency,
+		supplier.PaymentTerms,
+		supplier.LeadTimeDays,
+		supplier.IsActive,
+		supplier.Notes,
+		supplier.CreatedAt,
+		supplier.UpdatedAt,
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return fmt.Errorf("supplier with code %s already exists", supplier.SupplierCode)
+		}
+		return fmt.Errorf("failed to create supplier: %w", err)
+	}
+
+	if err := insertSupplierContacts(ctx, tx, supplier.ID, supplier.Contacts); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a supplier with its contacts
+func (r *PostgresSupplierRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Supplier, error) {
+	query := `SELECT ` + supplierColumns + ` FROM suppliers WHERE id = $1`
+
+	supplier, err := scanSupplier(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("supplier with id %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get supplier: %w", err)
+	}
+
+	if err := r.loadContacts(ctx, supplier); err != nil {
+		return nil, err
+	}
+
+	return supplier, nil
+}
+
+// GetByCode retrieves a supplier by its code
+func (r *PostgresSupplierRepository) GetByCode(ctx context.Context, code string) (*entities.Supplier, error) {
+	query := `SELECT ` + supplierColumns + ` FROM suppliers WHERE supplier_code = $1`
+
+	supplier, err := scanSupplier(r.db.QueryRow(ctx, query, code))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("supplier with code %s not found", code)
+		}
+		return nil, fmt.Errorf("failed to get supplier by code: %w", err)
+	}
+
+	if err := r.loadContacts(ctx, supplier); err != nil {
+		return nil, err
+	}
+
+	return supplier, nil
+}
+
+// Update updates a supplier and replaces its contacts
+func (r *PostgresSupplierRepository) Update(ctx context.Context, supplier *entities.Supplier) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE suppliers SET
+			supplier_code = $2, name = $3, legal_name = $4, tax_id = $5, email = NULLIF($6, ''),
+			phone = NULLIF($7, ''), website = $8, address_line1 = $9, address_line2 = $10,
+			city = $11, state = $12, postal_code = $13, country = $14, currency = $15,
+			payment_terms = $16, lead_time_days = $17, is_active = $18, notes = $19, updated_at = $20
+		WHERE id = $1
+	`
+
+	result, err := tx.Exec(ctx, query,
+		supplier.ID,
+		supplier.SupplierCode,
+		supplier.Name,
+		supplier.LegalName,
+		supplier.TaxID,
+		supplier.Email,
+		supplier.Phone,
+		supplier.Website,
+		supplier.AddressLine1,
+		supplier.AddressLine2,
+		supplier.City,
+		supplier.State,
+		supplier.PostalCode,
+		supplier.Country,
+		supplier.Currency,
+		supplier.PaymentTerms,
+		supplier.LeadTimeDays,
+		supplier.IsActive,
+		supplier.Notes,
+		supplier.UpdatedAt,
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return fmt.Errorf("supplier with code %s already exists", supplier.SupplierCode)
+		}
+		return fmt.Errorf("failed to update supplier: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("supplier with id %s not found", supplier.ID)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM supplier_contacts WHERE supplier_id = $1`, supplier.ID); err != nil {
+		return fmt.Errorf("failed to replace supplier contacts: %w", err)
+	}
+	if err := insertSupplierContacts(ctx, tx, supplier.ID, supplier.Contacts); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Delete deletes a supplier and its contacts
+func (r *PostgresSupplierRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM suppliers WHERE id = $1`, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "foreign key") {
+			return fmt.Errorf("supplier %s is referenced by purchase orders", id)
+		}
+		return fmt.Errorf("failed to delete supplier: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("supplier with id %s not found", id)
+	}
+
+	return nil
+}
+
+// List retrieves suppliers matching the filter, without their contacts
+func (r *PostgresSupplierRepository) List(ctx context.Context, filter repositories.SupplierFilter) ([]*entities.Supplier, error) {
+	where, args := buildSupplierConditions(filter)
+	query := `SELECT ` + supplierColumns + ` FROM suppliers` + where + ` ORDER BY name, supplier_code`
+
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", len(args)+1)
+		args = append(args, filter.Limit)
+
+		offset := filter.Offset
+		if offset == 0 && filter.Page > 1 {
+			offset = (filter.Page - 1) * filter.Limit
+		}
+		if offset > 0 {
+			query += fmt.Sprintf(" OFFSET $%d", len(args)+1)
+			args = append(args, offset)
+		}
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list suppliers: %w", err)
+	}
+	defer rows.Close()
+
+	var suppliers []*entities.Supplier
+	for rows.Next() {
+		supplier, err := scanSupplier(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan supplier row: %w", err)
+		}
+		suppliers = append(suppliers, supplier)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating supplier rows: %w", err)
+	}
+
+	return suppliers, nil
+}
+
+// Count returns the number of suppliers matching the filter
+func (r *PostgresSupplierRepository) Count(ctx context.Context, filter repositories.SupplierFilter) (int, error) {
+	where, args := buildSupplierConditions(filter)
+
+	var count int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM suppliers`+where, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count suppliers: %w", err)
+	}
+
+	return count, nil
+}
+
+// ExistsByCode checks whether a supplier with the given code exists
+func (r *PostgresSupplierRepository) ExistsByCode(ctx context.Context, code string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM suppliers WHERE supplier_code = $1)`, code).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check supplier code: %w", err)
+	}
+
+	return exists, nil
+}
+
+func (r *PostgresSupplierRepository) loadContacts(ctx context.Context, supplier *entities.Supplier) error {
+	query := `SELECT ` + supplierContactColumns + ` FROM supplier_contacts WHERE supplier_id = $1 ORDER BY is_primary DESC, name`
+
+	rows, err := r.db.Query(ctx, query, supplier.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get supplier contacts: %w", err)
+	}
+	defer rows.Close()
+
+	supplier.Contacts = nil
+	for rows.Next() {
+		var contact entities.SupplierContact
+		err := rows.Scan(
+			&contact.ID,
+			&contact.SupplierID,
+			&contact.Name,
+			&contact.Role,
+			&contact.Email,
+			&contact.Phone,
+			&contact.IsPrimary,
+			&contact.CreatedAt,
+			&contact.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to scan supplier contact: %w", err)
+		}
+		supplier.Contacts = append(supplier.Contacts, contact)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating supplier contacts: %w", err)
+	}
+
+	return nil
+}
+
+func insertSupplierContacts(ctx context.Context, tx pgx.Tx, supplierID uuid.UUID, contacts []entities.SupplierContact) error {
+	query := `INSERT INTO supplier_contacts (` + supplierContactColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	for _, contact := range contacts {
+		_, err := tx.Exec(ctx, query,
+			contact.ID,
+			supplierID,
+			contact.Name,
+			contact.Role,
+			contact.Email,
+			contact.Phone,
+			contact.IsPrimary,
+			contact.CreatedAt,
+			contact.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create supplier contact: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func buildSupplierConditions(filter repositories.SupplierFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Search != "" {
+		args = append(args, "%"+filter.Search+"%")
+		conditions = append(conditions, fmt.Sprintf("(supplier_code ILIKE $%d OR name ILIKE $%d OR email ILIKE $%d)", len(args), len(args), len(args)))
+	}
+
+	if filter.IsActive != nil {
+		args = append(args, *filter.IsActive)
+		conditions = append(conditions, fmt.Sprintf("is_active = $%d", len(args)))
+	}
+
+	if filter.Currency != "" {
+		args = append(args, filter.Currency)
+		conditions = append(conditions, fmt.Sprintf("currency = $%d", len(args)))
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func scanSupplier(row pgx.Row) (*entities.Supplier, error) {
+	supplier := &entities.Supplier{}
+	err := row.Scan(
+		&supplier.ID,
+		&supplier.SupplierCode,
+		&supplier.Name,
+		&supplier.LegalName,
+		&supplier.TaxID,
+		&supplier.Email,
+		&supplier.Phone,
+		&supplier.Website,
+		&supplier.AddressLine1,
+		&supplier.AddressLine2,
+		&supplier.City,
+		&supplier.State,
+		&supplier.PostalCode,
+		&supplier.Country,
+		&supplier.Currency,
+		&supplier.PaymentTerms,
+		&supplier.LeadTimeDays,
+		&supplier.IsActive,
+		&supplier.Notes,
+		&supplier.CreatedAt,
+		&supplier.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return supplier, nil
+}
@@ -0,0 +1,149 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/pkg/database"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// PostgresTaxExemptionRepository implements TaxExemptionRepository for PostgreSQL
+type PostgresTaxExemptionRepository struct {
+	db *database.Database
+}
+
+// NewPostgresTaxExemptionRepository creates a new PostgreSQL tax exemption repository
+func NewPostgresTaxExemptionRepository(db *database.Database) *PostgresTaxExemptionRepository {
+	return &PostgresTaxExemptionRepository{
+		db: db,
+	}
+}
+
+const taxExemptionColumns = `
+	id, customer_id, certificate_number, country, state, valid_from, valid_until,
+	reason, created_by, created_at, updated_at, revoked_at, revoked_by, revoke_reason
+`
+
+// Create creates a new tax exemption
+func (r *PostgresTaxExemptionRepository) Create(ctx context.Context, exemption *entities.TaxExemption) error {
+	query := `INSERT INTO tax_exemptions (` + taxExemptionColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
+
+	_, err := r.db.Exec(ctx, query,
+		exemption.ID,
+		exemption.CustomerID,
+		exemption.CertificateNumber,
+		exemption.Country,
+		exemption.State,
+		exemption.ValidFrom,
+		exemption.ValidUntil,
+		exemption.Reason,
+		exemption.CreatedBy,
+		exemption.CreatedAt,
+		exemption.UpdatedAt,
+		exemption.RevokedAt,
+		exemption.RevokedBy,
+		exemption.RevokeReason,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create tax exemption: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a tax exemption by ID
+func (r *PostgresTaxExemptionRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.TaxExemption, error) {
+	query := `SELECT ` + taxExemptionColumns + ` FROM tax_exemptions WHERE id = $1`
+
+	exemption, err := scanTaxExemption(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("tax exemption with id %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get tax exemption: %w", err)
+	}
+
+	return exemption, nil
+}
+
+// GetByCustomerID retrieves the exemptions of a customer, newest first
+func (r *PostgresTaxExemptionRepository) GetByCustomerID(ctx context.Context, customerID uuid.UUID) ([]*entities.TaxExemption, error) {
+	query := `SELECT ` + taxExemptionColumns + ` FROM tax_exemptions WHERE customer_id = $1 ORDER BY valid_from DESC, id`
+
+	rows, err := r.db.Query(ctx, query, customerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tax exemptions: %w", err)
+	}
+	defer rows.Close()
+
+	var exemptions []*entities.TaxExemption
+	for rows.Next() {
+		exemption, err := scanTaxExemption(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan tax exemption row: %w", err)
+		}
+		exemptions = append(exemptions, exemption)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tax exemption rows: %w", err)
+	}
+
+	return exemptions, nil
+}
+
+// Update updates a tax exemption
+func (r *PostgresTaxExemptionRepository) Update(ctx context.Context, exemption *entities.TaxExemption) error {
+	query := `
+		UPDATE tax_exemptions SET
+			valid_until = $2, reason = $3, updated_at = $4, revoked_at = $5,
+			revoked_by = $6, revoke_reason = $7
+		WHERE id = $1
+	`
+
+	result, err := r.db.Exec(ctx, query,
+		exemption.ID,
+		exemption.ValidUntil,
+		exemption.Reason,
+		exemption.UpdatedAt,
+		exemption.RevokedAt,
+		exemption.RevokedBy,
+		exemption.RevokeReason,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update tax exemption: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("tax exemption with id %s not found", exemption.ID)
+	}
+
+	return nil
+}
+
+func scanTaxExemption(row pgx.Row) (*entities.TaxExemption, error) {
+	exemption := &entities.TaxExemption{}
+	err := row.Scan(
+		&exemption.ID,
+		&exemption.CustomerID,
+		&exemption.CertificateNumber,
+		&exemption.Country,
+		&exemption.State,
+		&exemption.ValidFrom,
+		&exemption.ValidUntil,
+		&exemption.Reason,
+		&exemption.CreatedBy,
+		&exemption.CreatedAt,
+		&exemption.UpdatedAt,
+		&exemption.RevokedAt,
+		&exemption.RevokedBy,
+		&exemption.RevokeReason,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return exemption, nil
+}
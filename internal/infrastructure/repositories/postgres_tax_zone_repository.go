@@ -0,0 +1,392 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
+	"erpgo/pkg/database"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// PostgresTaxZoneRepository implements TaxZoneRepository for PostgreSQL
+type PostgresTaxZoneRepository struct {
+	db *database.Database
+}
+
+// NewPostgresTaxZoneRepository creates a new PostgreSQL tax zone repository
+func NewPostgresTaxZoneRepository(db *database.Database) *PostgresTaxZoneRepository {
+	return &PostgresTaxZoneRepository{
+		db: db,
+	}
+}
+
+const taxZoneColumns = `
+	id, code, name, country, state, postal_code_prefixes, prices_include_tax,
+	is_active, created_at, updated_at
+`
+
+const taxRuleColumns = `
+	id, zone_id, name, level, tax_class, rate, is_active, created_at, updated_at
+`
+
+// Create creates a new tax zone with its rules
+func (r *PostgresTaxZoneRepository) Create(ctx context.Context, zone *entities.TaxZone) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `INSERT INTO tax_zones (` + taxZoneColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+	_, err = tx.Exec(ctx, query,
+		zone.ID,
+		zone.Code,
+		zone.Name,
+		zone.Country,
+		zone.State,
+		postalCodePrefixes(zone.PostalCodePrefixes),
+		zone.PricesIncludeTax,
+		zone.IsActive,
+		zone.CreatedAt,
+		zone.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create tax zone: %w", err)
+	}
+
+	for i := range zone.Rules {
+		if err := insertTaxRule(ctx, tx, &zone.Rules[i]); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a tax zone with its rules
+func (r *PostgresTaxZoneRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.TaxZone, error) {
+	query := `SELECT ` + taxZoneColumns + ` FROM tax_zones WHERE id = $1`
+
+	zone, err := scanTaxZone(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("tax zone with id %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get tax zone: %w", err)
+	}
+
+	if err := r.loadRules(ctx, []*entities.TaxZone{zone}, false); err != nil {
+		return nil, err
+	}
+
+	return zone, nil
+}
+
+// GetByCode retrieves a tax zone by its code
+func (r *PostgresTaxZoneRepository) GetByCode(ctx context.Context, code string) (*entities.TaxZone, error) {
+	query := `SELECT ` + taxZoneColumns + ` FROM tax_zones WHERE code = $1`
+
+	zone, err := scanTaxZone(r.db.QueryRow(ctx, query, code))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("tax zone with code %s not found", code)
+		}
+		return nil, fmt.Errorf("failed to get tax zone: %w", err)
+	}
+
+	if err := r.loadRules(ctx, []*entities.TaxZone{zone}, false); err != nil {
+		return nil, err
+	}
+
+	return zone, nil
+}
+
+// GetActiveByCountry retrieves the active zones of a country with their active
+// rules. Country-wide zones come first, then state zones, then postal code zones.
+func (r *PostgresTaxZoneRepository) GetActiveByCountry(ctx context.Context, country string) ([]*entities.TaxZone, error) {
+	query := `
+		SELECT ` + taxZoneColumns + ` FROM tax_zones
+		WHERE country = $1 AND is_active
+		ORDER BY state IS NOT NULL, cardinality(postal_code_prefixes) > 0, code
+	`
+
+	return r.query(ctx, query, true, strings.ToUpper(strings.TrimSpace(country)))
+}
+
+// Update updates a tax zone
+func (r *PostgresTaxZoneRepository) Update(ctx context.Context, zone *entities.TaxZone) error {
+	query := `
+		UPDATE tax_zones SET
+			name = $2, state = $3, postal_code_prefixes = $4, prices_include_tax = $5,
+			is_active = $6, updated_at = $7
+		WHERE id = $1
+	`
+
+	result, err := r.db.Exec(ctx, query,
+		zone.ID,
+		zone.Name,
+		zone.State,
+		postalCodePrefixes(zone.PostalCodePrefixes),
+		zone.PricesIncludeTax,
+		zone.IsActive,
+		zone.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update tax zone: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("tax zone with id %s not found", zone.ID)
+	}
+
+	return nil
+}
+
+// List retrieves tax zones matching the filter with their rules
+func (r *PostgresTaxZoneRepository) List(ctx context.Context, filter repositories.TaxZoneFilter) ([]*entities.TaxZone, error) {
+	where, args := buildTaxZoneConditions(filter)
+	query := `SELECT ` + taxZoneColumns + ` FROM tax_zones` + where + ` ORDER BY country, state NULLS FIRST, code`
+
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", len(args)+1)
+		args = append(args, filter.Limit)
+
+		offset := filter.Offset
+		if offset == 0 && filter.Page > 1 {
+			offset = (filter.Page - 1) * filter.Limit
+		}
+		if offset > 0 {
+			query += fmt.Sprintf(" OFFSET $%d", len(args)+1)
+			args = append(args, offset)
+		}
+	}
+
+	return r.query(ctx, query, false, args...)
+}
+
+// Count returns the number of tax zones matching the filter
+func (r *PostgresTaxZoneRepository) Count(ctx context.Context, filter repositories.TaxZoneFilter) (int, error) {
+	where, args := buildTaxZoneConditions(filter)
+
+	var count int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM tax_zones`+where, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count tax zones: %w", err)
+	}
+
+	return count, nil
+}
+
+// CreateRule adds a rule to a tax zone
+func (r *PostgresTaxZoneRepository) CreateRule(ctx context.Context, rule *entities.TaxRule) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := insertTaxRule(ctx, tx, rule); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateRule updates a tax rule
+func (r *PostgresTaxZoneRepository) UpdateRule(ctx context.Context, rule *entities.TaxRule) error {
+	query := `
+		UPDATE tax_rules SET
+			name = $3, level = $4, tax_class = $5, rate = $6, is_active = $7, updated_at = $8
+		WHERE id = $1 AND zone_id = $2
+	`
+
+	result, err := r.db.Exec(ctx, query,
+		rule.ID,
+		rule.ZoneID,
+		rule.Name,
+		rule.Level,
+		rule.TaxClass,
+		rule.Rate,
+		rule.IsActive,
+		rule.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update tax rule: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("tax rule with id %s not found", rule.ID)
+	}
+
+	return nil
+}
+
+func (r *PostgresTaxZoneRepository) query(ctx context.Context, query string, activeRulesOnly bool, args ...interface{}) ([]*entities.TaxZone, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tax zones: %w", err)
+	}
+	defer rows.Close()
+
+	var zones []*entities.TaxZone
+	for rows.Next() {
+		zone, err := scanTaxZone(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan tax zone row: %w", err)
+		}
+		zones = append(zones, zone)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tax zone rows: %w", err)
+	}
+
+	if err := r.loadRules(ctx, zones, activeRulesOnly); err != nil {
+		return nil, err
+	}
+
+	return zones, nil
+}
+
+// loadRules loads the rules of several zones in one query
+func (r *PostgresTaxZoneRepository) loadRules(ctx context.Context, zones []*entities.TaxZone, activeOnly bool) error {
+	if len(zones) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(zones))
+	byID := make(map[uuid.UUID]*entities.TaxZone, len(zones))
+	for i, zone := range zones {
+		ids[i] = zone.ID
+		byID[zone.ID] = zone
+		zone.Rules = nil
+	}
+
+	query := `SELECT ` + taxRuleColumns + ` FROM tax_rules WHERE zone_id = ANY($1)`
+	if activeOnly {
+		query += ` AND is_active`
+	}
+	query += ` ORDER BY zone_id, created_at, id`
+
+	rows, err := r.db.Query(ctx, query, ids)
+	if err != nil {
+		return fmt.Errorf("failed to get tax rules: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var rule entities.TaxRule
+		err := rows.Scan(
+			&rule.ID,
+			&rule.ZoneID,
+			&rule.Name,
+			&rule.Level,
+			&rule.TaxClass,
+			&rule.Rate,
+			&rule.IsActive,
+			&rule.CreatedAt,
+			&rule.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to scan tax rule: %w", err)
+		}
+		zone := byID[rule.ZoneID]
+		zone.Rules = append(zone.Rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating tax rules: %w", err)
+	}
+
+	return nil
+}
+
+func insertTaxRule(ctx context.Context, tx pgx.Tx, rule *entities.TaxRule) error {
+	query := `INSERT INTO tax_rules (` + taxRuleColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := tx.Exec(ctx, query,
+		rule.ID,
+		rule.ZoneID,
+		rule.Name,
+		rule.Level,
+		rule.TaxClass,
+		rule.Rate,
+		rule.IsActive,
+		rule.CreatedAt,
+		rule.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create tax rule: %w", err)
+	}
+
+	return nil
+}
+
+func buildTaxZoneConditions(filter repositories.TaxZoneFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Search != "" {
+		args = append(args, "%"+filter.Search+"%")
+		conditions = append(conditions, fmt.Sprintf("(code ILIKE $%d OR name ILIKE $%d)", len(args), len(args)))
+	}
+
+	if filter.Country != "" {
+		args = append(args, strings.ToUpper(filter.Country))
+		conditions = append(conditions, fmt.Sprintf("country = $%d", len(args)))
+	}
+
+	if filter.State != nil {
+		args = append(args, *filter.State)
+		conditions = append(conditions, fmt.Sprintf("state ILIKE $%d", len(args)))
+	}
+
+	if filter.IsActive != nil {
+		args = append(args, *filter.IsActive)
+		conditions = append(conditions, fmt.Sprintf("is_active = $%d", len(args)))
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func scanTaxZone(row pgx.Row) (*entities.TaxZone, error) {
+	zone := &entities.TaxZone{}
+	err := row.Scan(
+		&zone.ID,
+		&zone.Code,
+		&zone.Name,
+		&zone.Country,
+		&zone.State,
+		&zone.PostalCodePrefixes,
+		&zone.PricesIncludeTax,
+		&zone.IsActive,
+		&zone.CreatedAt,
+		&zone.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return zone, nil
+}
+
+// postalCodePrefixes stores a missing prefix list as an empty array
+func postalCodePrefixes(prefixes []string) []string {
+	if prefixes == nil {
+		return []string{}
+	}
+	return prefixes
+}
@@ -0,0 +1,562 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"erpgo/internal/domain/inventory/entities"
+	"erpgo/internal/domain/inventory/repositories"
+	orderEntities "erpgo/internal/domain/orders/entities"
+	"erpgo/pkg/database"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// PostgresTransferOrderRepository implements TransferOrderRepository for PostgreSQL
+type PostgresTransferOrderRepository struct {
+	db *database.Database
+}
+
+// NewPostgresTransferOrderRepository creates a new PostgreSQL transfer order repository
+func NewPostgresTransferOrderRepository(db *database.Database) *PostgresTransferOrderRepository {
+	return &PostgresTransferOrderRepository{
+		db: db,
+	}
+}
+
+const transferOrderColumns = `
+	id, transfer_number, from_warehouse_id, to_warehouse_id, status, expected_date, carrier,
+	tracking_number, notes, cancellation_reason, created_by, created_at, updated_at,
+	shipped_by, shipped_at, received_at, cancelled_at
+`
+
+const transferOrderItemColumns = `
+	id, transfer_order_id, product_id, product_sku, product_name, quantity_requested,
+	quantity_shipped, quantity_received, quantity_damaged, quantity_short, unit_cost, notes,
+	created_at, updated_at
+`
+
+const transferReceiptColumns = `
+	id, transfer_order_id, warehouse_id, notes, received_by, received_at, created_at
+`
+
+const transferReceiptItemColumns = `
+	id, transfer_receipt_id, transfer_order_item_id, product_id, quantity_received,
+	quantity_damaged, quantity_short, discrepancy_reason, inventory_transaction_id
+`
+
+// Create creates a new transfer order with its items
+func (r *PostgresTransferOrderRepository) Create(ctx context.Context, transfer *entities.TransferOrder) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	transferNumber := transfer.TransferNumber
+	if strings.TrimSpace(transferNumber) == "" {
+		transferNumber, err = allocateDocumentNumber(ctx, tx, orderEntities.DocumentTypeTransfer, transfer.CreatedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	query := `
+		INSERT INTO transfer_orders (` + transferOrderColumns + `) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17
+		)
+	`
+
+	_, err = tx.Exec(ctx, query,
+		transfer.ID,
+		transferNumber,
+		transfer.FromWarehouseID,
+		transfer.ToWarehouseID,
+		transfer.Status,
+		transfer.ExpectedDate,
+		transfer.Carrier,
+		transfer.TrackingNumber,
+		transfer.Notes,
+		transfer.CancellationReason,
+		transfer.CreatedBy,
+		transfer.CreatedAt,
+		transfer.UpdatedAt,
+		transfer.ShippedBy,
+		transfer.ShippedAt,
+		transfer.ReceivedAt,
+		transfer.CancelledAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create transfer order: %w", err)
+	}
+
+	if err := saveTransferOrderItems(ctx, tx, transfer.ID, transfer.Items); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	transfer.TransferNumber = transferNumber
+	return nil
+}
+
+// GetByID retrieves a transfer order with its items
+func (r *PostgresTransferOrderRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.TransferOrder, error) {
+	query := `SELECT ` + transferOrderColumns + ` FROM transfer_orders WHERE id = $1`
+
+	transfer, err := scanTransferOrder(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("transfer order with id %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get transfer order: %w", err)
+	}
+
+	if err := r.loadItems(ctx, transfer); err != nil {
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+// Update updates a transfer order and the quantities of its items
+func (r *PostgresTransferOrderRepository) Update(ctx context.Context, transfer *entities.TransferOrder) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE transfer_orders SET
+			status = $2, expected_date = $3, carrier = $4, tracking_number = $5, notes = $6,
+			cancellation_reason = $7, updated_at = $8, shipped_by = $9, shipped_at = $10,
+			received_at = $11, cancelled_at = $12
+		WHERE id = $1
+	`
+
+	result, err := tx.Exec(ctx, query,
+		transfer.ID,
+		transfer.Status,
+		transfer.ExpectedDate,
+		transfer.Carrier,
+		transfer.TrackingNumber,
+		transfer.Notes,
+		transfer.CancellationReason,
+		transfer.UpdatedAt,
+		transfer.ShippedBy,
+		transfer.ShippedAt,
+		transfer.ReceivedAt,
+		transfer.CancelledAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update transfer order: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("transfer order with id %s not found", transfer.ID)
+	}
+
+	if err := saveTransferOrderItems(ctx, tx, transfer.ID, transfer.Items); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Lock locks a transfer order's row for the rest of the context's transaction
+func (r *PostgresTransferOrderRepository) Lock(ctx context.Context, id uuid.UUID) error {
+	var locked uuid.UUID
+	err := r.db.QueryRow(ctx, `SELECT id FROM transfer_orders WHERE id = $1 FOR UPDATE`, id).Scan(&locked)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("transfer order with id %s not found", id)
+		}
+		return fmt.Errorf("failed to lock transfer order: %w", err)
+	}
+	return nil
+}
+
+// List retrieves transfer orders matching the filter, without their items
+func (r *PostgresTransferOrderRepository) List(ctx context.Context, filter repositories.TransferOrderFilter) ([]*entities.TransferOrder, error) {
+	where, args := buildTransferOrderConditions(filter)
+	query := `SELECT ` + transferOrderColumns + ` FROM transfer_orders` + where + ` ORDER BY created_at DESC`
+
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", len(args)+1)
+		args = append(args, filter.Limit)
+
+		offset := filter.Offset
+		if offset == 0 && filter.Page > 1 {
+			offset = (filter.Page - 1) * filter.Limit
+		}
+		if offset > 0 {
+			query += fmt.Sprintf(" OFFSET $%d", len(args)+1)
+			args = append(args, offset)
+		}
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transfer orders: %w", err)
+	}
+	defer rows.Close()
+
+	var transfers []*entities.TransferOrder
+	for rows.Next() {
+		transfer, err := scanTransferOrder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan transfer order row: %w", err)
+		}
+		transfers = append(transfers, transfer)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating transfer order rows: %w", err)
+	}
+
+	return transfers, nil
+}
+
+// Count returns the number of transfer orders matching the filter
+func (r *PostgresTransferOrderRepository) Count(ctx context.Context, filter repositories.TransferOrderFilter) (int, error) {
+	where, args := buildTransferOrderConditions(filter)
+
+	var count int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM transfer_orders`+where, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count transfer orders: %w", err)
+	}
+
+	return count, nil
+}
+
+// CreateReceipt creates a new transfer receipt with its items
+func (r *PostgresTransferOrderRepository) CreateReceipt(ctx context.Context, receipt *entities.TransferReceipt) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `INSERT INTO transfer_receipts (` + transferReceiptColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err = tx.Exec(ctx, query,
+		receipt.ID,
+		receipt.TransferOrderID,
+		receipt.WarehouseID,
+		receipt.Notes,
+		receipt.ReceivedBy,
+		receipt.ReceivedAt,
+		receipt.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create transfer receipt: %w", err)
+	}
+
+	itemQuery := `INSERT INTO transfer_receipt_items (` + transferReceiptItemColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	for _, item := range receipt.Items {
+		_, err := tx.Exec(ctx, itemQuery,
+			item.ID,
+			receipt.ID,
+			item.TransferOrderItemID,
+			item.ProductID,
+			item.QuantityReceived,
+			item.QuantityDamaged,
+			item.QuantityShort,
+			item.DiscrepancyReason,
+			item.InventoryTransactionID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create transfer receipt item: %w", err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetReceipts retrieves every receipt of a transfer order, oldest first
+func (r *PostgresTransferOrderRepository) GetReceipts(ctx context.Context, transferOrderID uuid.UUID) ([]*entities.TransferReceipt, error) {
+	query := `SELECT ` + transferReceiptColumns + ` FROM transfer_receipts WHERE transfer_order_id = $1 ORDER BY received_at, id`
+
+	rows, err := r.db.Query(ctx, query, transferOrderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transfer receipts: %w", err)
+	}
+	defer rows.Close()
+
+	var receipts []*entities.TransferReceipt
+	for rows.Next() {
+		receipt := &entities.TransferReceipt{}
+		err := rows.Scan(
+			&receipt.ID,
+			&receipt.TransferOrderID,
+			&receipt.WarehouseID,
+			&receipt.Notes,
+			&receipt.ReceivedBy,
+			&receipt.ReceivedAt,
+			&receipt.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan transfer receipt row: %w", err)
+		}
+		receipts = append(receipts, receipt)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating transfer receipt rows: %w", err)
+	}
+
+	for _, receipt := range receipts {
+		if err := r.loadReceiptItems(ctx, receipt); err != nil {
+			return nil, err
+		}
+	}
+
+	return receipts, nil
+}
+
+// GetInTransitStock returns the quantity in transit per product and route
+func (r *PostgresTransferOrderRepository) GetInTransitStock(ctx context.Context, filter repositories.InTransitFilter) ([]*repositories.InTransitStock, error) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.ProductID != nil {
+		args = append(args, *filter.ProductID)
+		conditions = append(conditions, fmt.Sprintf("product_id = $%d", len(args)))
+	}
+	if filter.FromWarehouseID != nil {
+		args = append(args, *filter.FromWarehouseID)
+		conditions = append(conditions, fmt.Sprintf("from_warehouse_id = $%d", len(args)))
+	}
+	if filter.ToWarehouseID != nil {
+		args = append(args, *filter.ToWarehouseID)
+		conditions = append(conditions, fmt.Sprintf("to_warehouse_id = $%d", len(args)))
+	}
+
+	query := `
+		SELECT product_id, product_sku, product_name, from_warehouse_id, to_warehouse_id, quantity, total_value
+		FROM inventory_in_transit
+	`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY product_sku, from_warehouse_id, to_warehouse_id"
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get in-transit stock: %w", err)
+	}
+	defer rows.Close()
+
+	var stock []*repositories.InTransitStock
+	for rows.Next() {
+		line := &repositories.InTransitStock{}
+		err := rows.Scan(
+			&line.ProductID,
+			&line.ProductSKU,
+			&line.ProductName,
+			&line.FromWarehouseID,
+			&line.ToWarehouseID,
+			&line.Quantity,
+			&line.TotalValue,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan in-transit stock row: %w", err)
+		}
+		stock = append(stock, line)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating in-transit stock rows: %w", err)
+	}
+
+	return stock, nil
+}
+
+func (r *PostgresTransferOrderRepository) loadItems(ctx context.Context, transfer *entities.TransferOrder) error {
+	query := `SELECT ` + transferOrderItemColumns + ` FROM transfer_order_items WHERE transfer_order_id = $1 ORDER BY created_at, id`
+
+	rows, err := r.db.Query(ctx, query, transfer.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get transfer order items: %w", err)
+	}
+	defer rows.Close()
+
+	transfer.Items = nil
+	for rows.Next() {
+		var item entities.TransferOrderItem
+		err := rows.Scan(
+			&item.ID,
+			&item.TransferOrderID,
+			&item.ProductID,
+			&item.ProductSKU,
+			&item.ProductName,
+			&item.QuantityRequested,
+			&item.QuantityShipped,
+			&item.QuantityReceived,
+			&item.QuantityDamaged,
+			&item.QuantityShort,
+			&item.UnitCost,
+			&item.Notes,
+			&item.CreatedAt,
+			&item.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to scan transfer order item: %w", err)
+		}
+		transfer.Items = append(transfer.Items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating transfer order items: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresTransferOrderRepository) loadReceiptItems(ctx context.Context, receipt *entities.TransferReceipt) error {
+	query := `SELECT ` + transferReceiptItemColumns + ` FROM transfer_receipt_items WHERE transfer_receipt_id = $1 ORDER BY id`
+
+	rows, err := r.db.Query(ctx, query, receipt.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get transfer receipt items: %w", err)
+	}
+	defer rows.Close()
+
+	receipt.Items = nil
+	for rows.Next() {
+		var item entities.TransferReceiptItem
+		err := rows.Scan(
+			&item.ID,
+			&item.TransferReceiptID,
+			&item.TransferOrderItemID,
+			&item.ProductID,
+			&item.QuantityReceived,
+			&item.QuantityDamaged,
+			&item.QuantityShort,
+			&item.DiscrepancyReason,
+			&item.InventoryTransactionID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to scan transfer receipt item: %w", err)
+		}
+		receipt.Items = append(receipt.Items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating transfer receipt items: %w", err)
+	}
+
+	return nil
+}
+
+func saveTransferOrderItems(ctx context.Context, tx pgx.Tx, transferOrderID uuid.UUID, items []entities.TransferOrderItem) error {
+	query := `
+		INSERT INTO transfer_order_items (` + transferOrderItemColumns + `) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
+		)
+		ON CONFLICT (id) DO UPDATE SET
+			quantity_shipped = EXCLUDED.quantity_shipped,
+			quantity_received = EXCLUDED.quantity_received,
+			quantity_damaged = EXCLUDED.quantity_damaged,
+			quantity_short = EXCLUDED.quantity_short,
+			unit_cost = EXCLUDED.unit_cost,
+			notes = EXCLUDED.notes,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	for _, item := range items {
+		_, err := tx.Exec(ctx, query,
+			item.ID,
+			transferOrderID,
+			item.ProductID,
+			item.ProductSKU,
+			item.ProductName,
+			item.QuantityRequested,
+			item.QuantityShipped,
+			item.QuantityReceived,
+			item.QuantityDamaged,
+			item.QuantityShort,
+			item.UnitCost,
+			item.Notes,
+			item.CreatedAt,
+			item.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save transfer order item: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func buildTransferOrderConditions(filter repositories.TransferOrderFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Search != "" {
+		args = append(args, "%"+filter.Search+"%")
+		conditions = append(conditions, fmt.Sprintf("(transfer_number ILIKE $%d OR tracking_number ILIKE $%d)", len(args), len(args)))
+	}
+
+	if len(filter.Status) > 0 {
+		placeholders := make([]string, len(filter.Status))
+		for i, status := range filter.Status {
+			args = append(args, status)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		conditions = append(conditions, "status IN ("+strings.Join(placeholders, ", ")+")")
+	}
+
+	if filter.FromWarehouseID != nil {
+		args = append(args, *filter.FromWarehouseID)
+		conditions = append(conditions, fmt.Sprintf("from_warehouse_id = $%d", len(args)))
+	}
+
+	if filter.ToWarehouseID != nil {
+		args = append(args, *filter.ToWarehouseID)
+		conditions = append(conditions, fmt.Sprintf("to_warehouse_id = $%d", len(args)))
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func scanTransferOrder(row pgx.Row) (*entities.TransferOrder, error) {
+	transfer := &entities.TransferOrder{}
+	err := row.Scan(
+		&transfer.ID,
+		&transfer.TransferNumber,
+		&transfer.FromWarehouseID,
+		&transfer.ToWarehouseID,
+		&transfer.Status,
+		&transfer.ExpectedDate,
+		&transfer.Carrier,
+		&transfer.TrackingNumber,
+		&transfer.Notes,
+		&transfer.CancellationReason,
+		&transfer.CreatedBy,
+		&transfer.CreatedAt,
+		&transfer.UpdatedAt,
+		&transfer.ShippedBy,
+		&transfer.ShippedAt,
+		&transfer.ReceivedAt,
+		&transfer.CancelledAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
@@ -884,38 +884,151 @@ func (r *PostgresWarehouseRepository) GetCapacityUtilization(ctx context.Context
 	return utilization, nil
 }
 
-// Extended warehouse operations (for WarehouseExtended entities)
-// These would require additional tables and can be implemented later
+// Extended warehouse operations (for WarehouseExtended entities).
+// Warehouses without a warehouses_extended row are reported with the table
+// defaults, so every warehouse has a type.
+
+const warehouseExtendedColumns = `
+	w.id, w.name, w.code, w.address, w.city, w.state, w.country, w.postal_code,
+	w.phone, w.email, w.manager_id, w.is_active, w.created_at, w.updated_at,
+	COALESCE(we.type::text, 'RETAIL'), we.capacity, we.square_footage, we.dock_count,
+	COALESCE(we.temperature_controlled, false), COALESCE(we.security_level, 0),
+	COALESCE(we.description, '')
+`
+
+// CreateExtended creates a warehouse together with its extended metadata
 func (r *PostgresWarehouseRepository) CreateExtended(ctx context.Context, warehouse *entities.WarehouseExtended) error {
-	// First create the base warehouse
 	baseWarehouse := &warehouse.Warehouse
 	if err := r.Create(ctx, baseWarehouse); err != nil {
 		return fmt.Errorf("failed to create base warehouse: %w", err)
 	}
 
-	// Then create the extended record (would need warehouse_extended table)
-	// TODO: Implement when warehouse_extended table is created
-	return nil
+	return r.upsertExtended(ctx, warehouse)
 }
 
+// GetExtendedByID retrieves a warehouse with its extended metadata
 func (r *PostgresWarehouseRepository) GetExtendedByID(ctx context.Context, id uuid.UUID) (*entities.WarehouseExtended, error) {
-	// TODO: Implement when warehouse_extended table is created
-	return nil, fmt.Errorf("extended warehouse operations not yet implemented")
+	query := `
+		SELECT ` + warehouseExtendedColumns + `
+		FROM warehouses w
+		LEFT JOIN warehouses_extended we ON we.warehouse_id = w.id
+		WHERE w.id = $1
+	`
+
+	warehouse, err := scanWarehouseExtended(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("warehouse with ID %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get extended warehouse: %w", err)
+	}
+
+	return warehouse, nil
 }
 
+// UpdateExtended updates a warehouse together with its extended metadata
 func (r *PostgresWarehouseRepository) UpdateExtended(ctx context.Context, warehouse *entities.WarehouseExtended) error {
-	// First update the base warehouse
 	baseWarehouse := &warehouse.Warehouse
 	if err := r.Update(ctx, baseWarehouse); err != nil {
 		return fmt.Errorf("failed to update base warehouse: %w", err)
 	}
 
-	// Then update the extended record (would need warehouse_extended table)
-	// TODO: Implement when warehouse_extended table is created
-	return nil
+	return r.upsertExtended(ctx, warehouse)
 }
 
+// GetByType retrieves the warehouses of a given type
 func (r *PostgresWarehouseRepository) GetByType(ctx context.Context, warehouseType entities.WarehouseType) ([]*entities.WarehouseExtended, error) {
-	// TODO: Implement when warehouse_extended table is created
-	return nil, fmt.Errorf("extended warehouse operations not yet implemented")
+	query := `
+		SELECT ` + warehouseExtendedColumns + `
+		FROM warehouses w
+		LEFT JOIN warehouses_extended we ON we.warehouse_id = w.id
+		WHERE COALESCE(we.type::text, 'RETAIL') = $1
+		ORDER BY w.name ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, string(warehouseType))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query warehouses by type: %w", err)
+	}
+	defer rows.Close()
+
+	var warehouses []*entities.WarehouseExtended
+	for rows.Next() {
+		warehouse, err := scanWarehouseExtended(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan warehouse row: %w", err)
+		}
+		warehouses = append(warehouses, warehouse)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating warehouse rows: %w", err)
+	}
+
+	return warehouses, nil
+}
+
+func (r *PostgresWarehouseRepository) upsertExtended(ctx context.Context, warehouse *entities.WarehouseExtended) error {
+	query := `
+		INSERT INTO warehouses_extended (warehouse_id, type, capacity, square_footage, dock_count,
+		                                 temperature_controlled, security_level, description)
+		VALUES ($1, $2::warehouse_type, $3, $4, $5, $6, $7, NULLIF($8, ''))
+		ON CONFLICT (warehouse_id) DO UPDATE SET
+			type = EXCLUDED.type,
+			capacity = EXCLUDED.capacity,
+			square_footage = EXCLUDED.square_footage,
+			dock_count = EXCLUDED.dock_count,
+			temperature_controlled = EXCLUDED.temperature_controlled,
+			security_level = EXCLUDED.security_level,
+			description = EXCLUDED.description
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		warehouse.ID,
+		string(warehouse.Type),
+		warehouse.Capacity,
+		warehouse.SquareFootage,
+		warehouse.DockCount,
+		warehouse.TemperatureControlled,
+		warehouse.SecurityLevel,
+		warehouse.Description,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save extended warehouse: %w", err)
+	}
+
+	return nil
+}
+
+func scanWarehouseExtended(row pgx.Row) (*entities.WarehouseExtended, error) {
+	warehouse := &entities.WarehouseExtended{}
+	var warehouseType string
+	err := row.Scan(
+		&warehouse.ID,
+		&warehouse.Name,
+		&warehouse.Code,
+		&warehouse.Address,
+		&warehouse.City,
+		&warehouse.State,
+		&warehouse.Country,
+		&warehouse.PostalCode,
+		&warehouse.Phone,
+		&warehouse.Email,
+		&warehouse.ManagerID,
+		&warehouse.IsActive,
+		&warehouse.CreatedAt,
+		&warehouse.UpdatedAt,
+		&warehouseType,
+		&warehouse.Capacity,
+		&warehouse.SquareFootage,
+		&warehouse.DockCount,
+		&warehouse.TemperatureControlled,
+		&warehouse.SecurityLevel,
+		&warehouse.Description,
+	)
+	if err != nil {
+		return nil, err
+	}
+	warehouse.Type = entities.WarehouseType(warehouseType)
+	return warehouse, nil
 }
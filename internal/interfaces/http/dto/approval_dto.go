@@ -0,0 +1,111 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Approval DTOs
+
+// CreateApprovalPolicyRequest represents a request to create an approval policy
+type CreateApprovalPolicyRequest struct {
+	Name               string           `json:"name" binding:"required,max=255"`
+	Description        *string          `json:"description,omitempty"`
+	MinAmount          *decimal.Decimal `json:"min_amount,omitempty"`
+	CustomerTypes      []string         `json:"customer_types,omitempty" binding:"omitempty,dive,oneof=INDIVIDUAL BUSINESS GOVERNMENT NON_PROFIT"`
+	MinDiscountPercent *decimal.Decimal `json:"min_discount_percent,omitempty"`
+	MaxMarginPercent   *decimal.Decimal `json:"max_margin_percent,omitempty"`
+	ApproverRoles      []string         `json:"approver_roles" binding:"required,min=1,dive,required,max=100"`
+	Priority           int              `json:"priority"`
+}
+
+// UpdateApprovalPolicyRequest represents a request to update an approval policy
+type UpdateApprovalPolicyRequest struct {
+	Name               *string          `json:"name,omitempty" binding:"omitempty,max=255"`
+	Description        *string          `json:"description,omitempty"`
+	MinAmount          *decimal.Decimal `json:"min_amount,omitempty"`
+	CustomerTypes      []string         `json:"customer_types,omitempty" binding:"omitempty,dive,oneof=INDIVIDUAL BUSINESS GOVERNMENT NON_PROFIT"`
+	MinDiscountPercent *decimal.Decimal `json:"min_discount_percent,omitempty"`
+	MaxMarginPercent   *decimal.Decimal `json:"max_margin_percent,omitempty"`
+	ApproverRoles      []string         `json:"approver_roles,omitempty" binding:"omitempty,min=1,dive,required,max=100"`
+	Priority           *int             `json:"priority,omitempty"`
+	IsActive           *bool            `json:"is_active,omitempty"`
+}
+
+// ListApprovalPoliciesRequest represents a request to list approval policies
+type ListApprovalPoliciesRequest struct {
+	Search   string `json:"search,omitempty" form:"search"`
+	IsActive *bool  `json:"is_active,omitempty" form:"is_active"`
+	Page     int    `json:"page" form:"page" binding:"omitempty,min=1"`
+	Limit    int    `json:"limit" form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// ApprovalPolicyResponse represents an approval policy in responses
+type ApprovalPolicyResponse struct {
+	ID                 uuid.UUID        `json:"id"`
+	Name               string           `json:"name"`
+	Description        *string          `json:"description,omitempty"`
+	MinAmount          *decimal.Decimal `json:"min_amount,omitempty"`
+	CustomerTypes      []string         `json:"customer_types"`
+	MinDiscountPercent *decimal.Decimal `json:"min_discount_percent,omitempty"`
+	MaxMarginPercent   *decimal.Decimal `json:"max_margin_percent,omitempty"`
+	ApproverRoles      []string         `json:"approver_roles"`
+	Priority           int              `json:"priority"`
+	IsActive           bool             `json:"is_active"`
+	CreatedBy          uuid.UUID        `json:"created_by"`
+	CreatedAt          time.Time        `json:"created_at"`
+	UpdatedAt          time.Time        `json:"updated_at"`
+}
+
+// ListApprovalPoliciesResponse represents a paginated list of approval policies
+type ListApprovalPoliciesResponse struct {
+	Policies   []*ApprovalPolicyResponse `json:"policies"`
+	Pagination *Pagination               `json:"pagination"`
+}
+
+// RejectOrderRequest represents an approver's request to reject a pending order
+type RejectOrderRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// PendingApprovalsRequest represents a request for the current user's approval inbox
+type PendingApprovalsRequest struct {
+	Page  int `json:"page" form:"page" binding:"omitempty,min=1"`
+	Limit int `json:"limit" form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// OrderApprovalResponse represents a step of an order's approval chain
+type OrderApprovalResponse struct {
+	ID           uuid.UUID       `json:"id"`
+	OrderID      uuid.UUID       `json:"order_id"`
+	PolicyID     uuid.UUID       `json:"policy_id"`
+	PolicyName   string          `json:"policy_name"`
+	Sequence     int             `json:"sequence"`
+	Role         string          `json:"role"`
+	Status       string          `json:"status"`
+	Amount       decimal.Decimal `json:"amount"`
+	DecidedBy    *uuid.UUID      `json:"decided_by,omitempty"`
+	DecidedAt    *time.Time      `json:"decided_at,omitempty"`
+	Comment      *string         `json:"comment,omitempty"`
+	SupersededAt *time.Time      `json:"superseded_at,omitempty"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+// PendingApprovalResponse represents an approval step awaiting the current user, with its order
+type PendingApprovalResponse struct {
+	OrderApprovalResponse
+	OrderNumber  string          `json:"order_number"`
+	CustomerID   uuid.UUID       `json:"customer_id"`
+	OrderDate    time.Time       `json:"order_date"`
+	TotalAmount  decimal.Decimal `json:"total_amount"`
+	Currency     string          `json:"currency"`
+	BaseCurrency string          `json:"base_currency"`
+}
+
+// PendingApprovalsResponse represents the current user's approval inbox
+type PendingApprovalsResponse struct {
+	Approvals  []*PendingApprovalResponse `json:"approvals"`
+	Pagination *Pagination                `json:"pagination"`
+}
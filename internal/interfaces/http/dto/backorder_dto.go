@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Backorder DTOs
+
+// AllocateBackordersRequest represents a request to allocate warehouse stock to a product's backorders
+type AllocateBackordersRequest struct {
+	ProductID   uuid.UUID `json:"product_id" binding:"required"`
+	WarehouseID uuid.UUID `json:"warehouse_id" binding:"required"`
+}
+
+// ListBackordersRequest represents a request to list backorders
+type ListBackordersRequest struct {
+	ProductID *string `json:"product_id,omitempty" form:"product_id" binding:"omitempty,uuid"`
+	OrderID   *string `json:"order_id,omitempty" form:"order_id" binding:"omitempty,uuid"`
+	Status    *string `json:"status,omitempty" form:"status" binding:"omitempty,oneof=OPEN PARTIALLY_ALLOCATED ALLOCATED CANCELLED"`
+	Page      int     `json:"page" form:"page" binding:"omitempty,min=1"`
+	Limit     int     `json:"limit" form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// BackorderResponse represents a backorder in responses
+type BackorderResponse struct {
+	ID                uuid.UUID  `json:"id"`
+	OrderID           uuid.UUID  `json:"order_id"`
+	OrderNumber       string     `json:"order_number"`
+	OrderItemID       uuid.UUID  `json:"order_item_id"`
+	ProductID         uuid.UUID  `json:"product_id"`
+	ProductSKU        string     `json:"product_sku"`
+	Quantity          int        `json:"quantity"`
+	QuantityAllocated int        `json:"quantity_allocated"`
+	QuantityRemaining int        `json:"quantity_remaining"`
+	Status            string     `json:"status"`
+	Priority          string     `json:"priority"`
+	OrderDate         time.Time  `json:"order_date"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+	AllocatedAt       *time.Time `json:"allocated_at,omitempty"`
+	CancelledAt       *time.Time `json:"cancelled_at,omitempty"`
+}
+
+// ListBackordersResponse represents a paginated list of backorders
+type ListBackordersResponse struct {
+	Backorders []*BackorderResponse `json:"backorders"`
+	Pagination *Pagination          `json:"pagination"`
+}
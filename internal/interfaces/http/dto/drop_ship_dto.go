@@ -0,0 +1,61 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Drop-ship DTOs
+
+// ConfirmDropShipmentRequest represents a supplier's confirmation that it
+// shipped lines of a drop-ship purchase order. Without items every
+// outstanding line has shipped.
+type ConfirmDropShipmentRequest struct {
+	Items          []DropShipmentItemRequest `json:"items,omitempty" binding:"omitempty,dive"`
+	TrackingNumber string                    `json:"tracking_number,omitempty" binding:"omitempty,max=100"`
+	Carrier        string                    `json:"carrier,omitempty" binding:"omitempty,max=100"`
+	ShippingDate   *time.Time                `json:"shipping_date,omitempty"`
+}
+
+// DropShipmentItemRequest represents the quantity shipped of a drop-ship purchase order line
+type DropShipmentItemRequest struct {
+	PurchaseOrderItemID uuid.UUID `json:"purchase_order_item_id" binding:"required"`
+	Quantity            int       `json:"quantity" binding:"required,min=1"`
+}
+
+// DropShipmentResponse represents a supplier shipment booked against a
+// drop-ship purchase order and the sales order it ships
+type DropShipmentResponse struct {
+	PurchaseOrder *PurchaseOrderResponse `json:"purchase_order"`
+	OrderID       uuid.UUID              `json:"order_id"`
+	OrderNumber   string                 `json:"order_number"`
+	OrderStatus   string                 `json:"order_status"`
+	Shipment      ShipmentResponse       `json:"shipment"`
+}
+
+// ImportDropShipConfirmationsResponse reports the outcome of a supplier
+// shipment confirmation import
+type ImportDropShipConfirmationsResponse struct {
+	Committed bool                                `json:"committed"`
+	TotalRows int                                 `json:"total_rows"`
+	Shipments []ImportedDropShipmentResponse      `json:"shipments"`
+	Errors    []DropShipConfirmationErrorResponse `json:"errors"`
+}
+
+// ImportedDropShipmentResponse represents a supplier shipment of a confirmation file
+type ImportedDropShipmentResponse struct {
+	PONumber       string `json:"po_number"`
+	OrderNumber    string `json:"order_number"`
+	TrackingNumber string `json:"tracking_number,omitempty"`
+	ShipmentNumber string `json:"shipment_number"`
+	Rows           []int  `json:"rows"`
+}
+
+// DropShipConfirmationErrorResponse represents a problem with a row of a confirmation file
+type DropShipConfirmationErrorResponse struct {
+	Row      int    `json:"row"`
+	PONumber string `json:"po_number,omitempty"`
+	Field    string `json:"field,omitempty"`
+	Message  string `json:"message"`
+}
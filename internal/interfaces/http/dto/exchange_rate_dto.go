@@ -0,0 +1,78 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Exchange rate DTOs
+
+// CreateExchangeRateRequest represents a request to enter an exchange rate
+type CreateExchangeRateRequest struct {
+	FromCurrency  string          `json:"from_currency" binding:"required,len=3"`
+	ToCurrency    string          `json:"to_currency" binding:"required,len=3"`
+	Rate          decimal.Decimal `json:"rate"`
+	EffectiveDate time.Time       `json:"effective_date" binding:"required"`
+}
+
+// UpdateExchangeRateRequest represents a request to correct an exchange rate
+type UpdateExchangeRateRequest struct {
+	Rate          *decimal.Decimal `json:"rate,omitempty"`
+	EffectiveDate *time.Time       `json:"effective_date,omitempty"`
+}
+
+// ListExchangeRatesRequest represents a request to list exchange rates
+type ListExchangeRatesRequest struct {
+	Currency  string     `json:"currency,omitempty" form:"currency" binding:"omitempty,len=3"`
+	Source    string     `json:"source,omitempty" form:"source" binding:"omitempty,oneof=MANUAL CSV ECB"`
+	StartDate *time.Time `json:"start_date,omitempty" form:"start_date" time_format:"2006-01-02"`
+	EndDate   *time.Time `json:"end_date,omitempty" form:"end_date" time_format:"2006-01-02"`
+	Page      int        `json:"page" form:"page" binding:"omitempty,min=1"`
+	Limit     int        `json:"limit" form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// ConvertCurrencyRequest represents a request to convert an amount
+type ConvertCurrencyRequest struct {
+	Amount       decimal.Decimal `json:"amount" form:"amount"`
+	FromCurrency string          `json:"from_currency" form:"from" binding:"required,len=3"`
+	ToCurrency   string          `json:"to_currency,omitempty" form:"to" binding:"omitempty,len=3"`
+	Date         *time.Time      `json:"date,omitempty" form:"date" time_format:"2006-01-02"`
+}
+
+// ExchangeRateResponse represents an exchange rate in responses
+type ExchangeRateResponse struct {
+	ID            uuid.UUID       `json:"id"`
+	FromCurrency  string          `json:"from_currency"`
+	ToCurrency    string          `json:"to_currency"`
+	Rate          decimal.Decimal `json:"rate"`
+	EffectiveDate time.Time       `json:"effective_date"`
+	Source        string          `json:"source"`
+	CreatedBy     *uuid.UUID      `json:"created_by,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+// ListExchangeRatesResponse represents a paginated list of exchange rates
+type ListExchangeRatesResponse struct {
+	Rates      []*ExchangeRateResponse `json:"rates"`
+	Pagination *Pagination             `json:"pagination"`
+}
+
+// ImportExchangeRatesResponse summarizes an exchange rate import
+type ImportExchangeRatesResponse struct {
+	Imported   int                     `json:"imported"`
+	Currencies []string                `json:"currencies"`
+	Rates      []*ExchangeRateResponse `json:"rates"`
+}
+
+// CurrencyConversionResponse represents an amount converted at an exchange rate
+type CurrencyConversionResponse struct {
+	FromCurrency    string          `json:"from_currency"`
+	ToCurrency      string          `json:"to_currency"`
+	Rate            decimal.Decimal `json:"rate"`
+	Date            time.Time       `json:"date"`
+	Amount          decimal.Decimal `json:"amount"`
+	ConvertedAmount decimal.Decimal `json:"converted_amount"`
+}
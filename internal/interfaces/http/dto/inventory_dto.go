@@ -187,6 +187,7 @@ type InventoryResponse struct {
 	Quantity          int       `json:"quantity"`
 	ReservedQuantity  int       `json:"reserved_quantity"`
 	AvailableQuantity int       `json:"available_quantity"`
+	InTransitQuantity int       `json:"in_transit_quantity"`
 	MinStockLevel     int       `json:"min_stock_level"`
 	MaxStockLevel     *int      `json:"max_stock_level,omitempty"`
 	IsLowStock        bool      `json:"is_low_stock"`
@@ -204,15 +205,17 @@ type InventoryListResponse struct {
 
 // InventoryStatsResponse represents inventory statistics
 type InventoryStatsResponse struct {
-	TotalProducts        int                  `json:"total_products"`
-	TotalWarehouses      int                  `json:"total_warehouses"`
-	TotalInventoryValue  decimal.Decimal      `json:"total_inventory_value"`
-	TotalStockQuantity   int                  `json:"total_stock_quantity"`
-	LowStockItems        int                  `json:"low_stock_items"`
-	OutOfStockItems      int                  `json:"out_of_stock_items"`
-	TotalReservations    int                  `json:"total_reservations"`
-	TopWarehousesByStock []WarehouseStockInfo `json:"top_warehouses_by_stock"`
-	TopProductsByValue   []ProductValueInfo   `json:"top_products_by_value"`
+	TotalProducts          int                  `json:"total_products"`
+	TotalWarehouses        int                  `json:"total_warehouses"`
+	TotalInventoryValue    decimal.Decimal      `json:"total_inventory_value"`
+	TotalStockQuantity     int                  `json:"total_stock_quantity"`
+	LowStockItems          int                  `json:"low_stock_items"`
+	OutOfStockItems        int                  `json:"out_of_stock_items"`
+	TotalReservations      int                  `json:"total_reservations"`
+	TotalInTransitQuantity int                  `json:"total_in_transit_quantity"`
+	TotalInTransitValue    decimal.Decimal      `json:"total_in_transit_value"`
+	TopWarehousesByStock   []WarehouseStockInfo `json:"top_warehouses_by_stock"`
+	TopProductsByValue     []ProductValueInfo   `json:"top_products_by_value"`
 }
 
 // WarehouseStockInfo represents warehouse stock information for statistics
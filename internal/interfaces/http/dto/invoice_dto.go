@@ -0,0 +1,106 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Invoice DTOs
+
+// GenerateInvoiceRequest represents a request to invoice an order
+type GenerateInvoiceRequest struct {
+	OrderID    uuid.UUID  `json:"order_id" binding:"required"`
+	ShipmentID *uuid.UUID `json:"shipment_id,omitempty"`
+	Notes      *string    `json:"notes,omitempty"`
+	Issue      bool       `json:"issue"`
+}
+
+// IssueInvoiceRequest represents a request to issue a draft invoice
+type IssueInvoiceRequest struct {
+	IssueDate *time.Time `json:"issue_date,omitempty"`
+}
+
+// CancelInvoiceRequest represents a request to cancel a draft invoice
+type CancelInvoiceRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// ListInvoicesRequest represents a request to list invoices
+type ListInvoicesRequest struct {
+	Search     string     `json:"search,omitempty" form:"search"`
+	Type       *string    `json:"type,omitempty" form:"type" binding:"omitempty,oneof=INVOICE CREDIT_NOTE"`
+	Status     *string    `json:"status,omitempty" form:"status" binding:"omitempty,oneof=DRAFT ISSUED CANCELLED"`
+	CustomerID *string    `json:"customer_id,omitempty" form:"customer_id" binding:"omitempty,uuid"`
+	OrderID    *string    `json:"order_id,omitempty" form:"order_id" binding:"omitempty,uuid"`
+	IssuedFrom *time.Time `json:"issued_from,omitempty" form:"issued_from" time_format:"2006-01-02"`
+	IssuedTo   *time.Time `json:"issued_to,omitempty" form:"issued_to" time_format:"2006-01-02"`
+	Page       int        `json:"page" form:"page" binding:"omitempty,min=1"`
+	Limit      int        `json:"limit" form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// InvoiceLineResponse represents an invoice line in responses
+type InvoiceLineResponse struct {
+	ID             uuid.UUID       `json:"id"`
+	OrderItemID    *uuid.UUID      `json:"order_item_id,omitempty"`
+	ProductID      *uuid.UUID      `json:"product_id,omitempty"`
+	ProductSKU     string          `json:"product_sku,omitempty"`
+	Description    string          `json:"description"`
+	Quantity       int             `json:"quantity"`
+	UnitPrice      decimal.Decimal `json:"unit_price"`
+	DiscountAmount decimal.Decimal `json:"discount_amount"`
+	TaxRate        decimal.Decimal `json:"tax_rate"`
+	TaxAmount      decimal.Decimal `json:"tax_amount"`
+	TotalPrice     decimal.Decimal `json:"total_price"`
+	// PriceIncludesTax marks a gross unit price the tax was backed out of
+	PriceIncludesTax bool `json:"price_includes_tax"`
+}
+
+// TaxBreakdownResponse represents one named tax component in responses
+type TaxBreakdownResponse struct {
+	TaxName       string          `json:"tax_name"`
+	Level         string          `json:"level,omitempty"`
+	TaxRate       decimal.Decimal `json:"tax_rate"`
+	TaxableAmount decimal.Decimal `json:"taxable_amount"`
+	TaxAmount     decimal.Decimal `json:"tax_amount"`
+}
+
+// InvoiceResponse represents an invoice or credit note in responses
+type InvoiceResponse struct {
+	ID                 uuid.UUID              `json:"id"`
+	InvoiceNumber      string                 `json:"invoice_number,omitempty"`
+	Type               string                 `json:"type"`
+	Status             string                 `json:"status"`
+	Locked             bool                   `json:"locked"`
+	OrderID            uuid.UUID              `json:"order_id"`
+	ShipmentID         *uuid.UUID             `json:"shipment_id,omitempty"`
+	CreditedInvoiceID  *uuid.UUID             `json:"credited_invoice_id,omitempty"`
+	CustomerID         uuid.UUID              `json:"customer_id"`
+	BillingAddressID   uuid.UUID              `json:"billing_address_id"`
+	Currency           string                 `json:"currency"`
+	PaymentTerms       string                 `json:"payment_terms"`
+	IssueDate          *time.Time             `json:"issue_date,omitempty"`
+	DueDate            *time.Time             `json:"due_date,omitempty"`
+	Subtotal           decimal.Decimal        `json:"subtotal"`
+	TaxAmount          decimal.Decimal        `json:"tax_amount"`
+	ShippingAmount     decimal.Decimal        `json:"shipping_amount"`
+	DiscountAmount     decimal.Decimal        `json:"discount_amount"`
+	TotalAmount        decimal.Decimal        `json:"total_amount"`
+	TaxBreakdown       []TaxBreakdownResponse `json:"tax_breakdown"`
+	Lines              []InvoiceLineResponse  `json:"lines"`
+	Notes              *string                `json:"notes,omitempty"`
+	Reason             *string                `json:"reason,omitempty"`
+	CancellationReason *string                `json:"cancellation_reason,omitempty"`
+	CreatedBy          uuid.UUID              `json:"created_by"`
+	IssuedBy           *uuid.UUID             `json:"issued_by,omitempty"`
+	CreatedAt          time.Time              `json:"created_at"`
+	UpdatedAt          time.Time              `json:"updated_at"`
+	CancelledAt        *time.Time             `json:"cancelled_at,omitempty"`
+}
+
+// ListInvoicesResponse represents a paginated list of invoices
+type ListInvoicesResponse struct {
+	Invoices   []*InvoiceResponse `json:"invoices"`
+	Pagination *Pagination        `json:"pagination"`
+}
@@ -11,63 +11,76 @@ import (
 
 // OrderResponse represents order information returned in responses
 type OrderResponse struct {
-	ID                 uuid.UUID           `json:"id"`
-	OrderNumber        string              `json:"order_number"`
-	CustomerID         uuid.UUID           `json:"customer_id"`
-	CustomerName       string              `json:"customer_name"`
-	Type               string              `json:"type"`
-	Status             string              `json:"status"`
-	Priority           string              `json:"priority"`
-	PaymentStatus      string              `json:"payment_status"`
-	FulfillmentStatus  string              `json:"fulfillment_status"`
-	Currency           string              `json:"currency"`
-	Subtotal           decimal.Decimal     `json:"subtotal"`
-	TaxAmount          decimal.Decimal     `json:"tax_amount"`
-	ShippingAmount     decimal.Decimal     `json:"shipping_amount"`
-	DiscountAmount     decimal.Decimal     `json:"discount_amount"`
-	TotalAmount        decimal.Decimal     `json:"total_amount"`
-	PaidAmount         decimal.Decimal     `json:"paid_amount"`
-	RefundedAmount     decimal.Decimal     `json:"refunded_amount"`
-	Weight             decimal.Decimal     `json:"weight"`
-	ShippingMethod     string              `json:"shipping_method"`
-	TrackingNumber     string              `json:"tracking_number,omitempty"`
-	ShippingAddress    *AddressResponse    `json:"shipping_address"`
-	BillingAddress     *AddressResponse    `json:"billing_address"`
-	Items              []OrderItemResponse `json:"items"`
-	Notes              *string             `json:"notes,omitempty"`
-	CustomerNotes      *string             `json:"customer_notes,omitempty"`
-	InternalNotes      *string             `json:"internal_notes,omitempty"`
-	RequiredDate       *time.Time          `json:"required_date,omitempty"`
-	ShippedDate        *time.Time          `json:"shipped_date,omitempty"`
-	DeliveredDate      *time.Time          `json:"delivered_date,omitempty"`
-	CreatedAt          time.Time           `json:"created_at"`
-	UpdatedAt          time.Time           `json:"updated_at"`
-	ApprovedAt         *time.Time          `json:"approved_at,omitempty"`
-	ApprovedBy         *string             `json:"approved_by,omitempty"`
-	CancelledAt        *time.Time          `json:"cancelled_at,omitempty"`
-	CancelledBy        *string             `json:"cancelled_by,omitempty"`
-	CancellationReason *string             `json:"cancellation_reason,omitempty"`
+	ID                uuid.UUID       `json:"id"`
+	OrderNumber       string          `json:"order_number"`
+	CustomerID        uuid.UUID       `json:"customer_id"`
+	CustomerName      string          `json:"customer_name"`
+	Type              string          `json:"type"`
+	Status            string          `json:"status"`
+	CreditHold        bool            `json:"credit_hold"`
+	Priority          string          `json:"priority"`
+	PaymentStatus     string          `json:"payment_status"`
+	FulfillmentStatus string          `json:"fulfillment_status"`
+	Currency          string          `json:"currency"`
+	ExchangeRate      decimal.Decimal `json:"exchange_rate"`
+	Subtotal          decimal.Decimal `json:"subtotal"`
+	TaxAmount         decimal.Decimal `json:"tax_amount"`
+	ShippingAmount    decimal.Decimal `json:"shipping_amount"`
+	DiscountAmount    decimal.Decimal `json:"discount_amount"`
+	// PromotionDiscountAmount is the part of DiscountAmount given by coupon codes
+	PromotionDiscountAmount decimal.Decimal            `json:"promotion_discount_amount"`
+	Promotions              []AppliedPromotionResponse `json:"promotions"`
+	TotalAmount             decimal.Decimal            `json:"total_amount"`
+	PaidAmount              decimal.Decimal            `json:"paid_amount"`
+	RefundedAmount          decimal.Decimal            `json:"refunded_amount"`
+	Weight                  decimal.Decimal            `json:"weight"`
+	ShippingMethod          string                     `json:"shipping_method"`
+	ShippingQuotes          []ShippingQuoteResponse    `json:"shipping_quotes,omitempty"`
+	TrackingNumber          string                     `json:"tracking_number,omitempty"`
+	ShippingAddress         *AddressResponse           `json:"shipping_address"`
+	BillingAddress          *AddressResponse           `json:"billing_address"`
+	Items                   []OrderItemResponse        `json:"items"`
+	Notes                   *string                    `json:"notes,omitempty"`
+	CustomerNotes           *string                    `json:"customer_notes,omitempty"`
+	InternalNotes           *string                    `json:"internal_notes,omitempty"`
+	RequiredDate            *time.Time                 `json:"required_date,omitempty"`
+	ShippedDate             *time.Time                 `json:"shipped_date,omitempty"`
+	DeliveredDate           *time.Time                 `json:"delivered_date,omitempty"`
+	CreatedAt               time.Time                  `json:"created_at"`
+	UpdatedAt               time.Time                  `json:"updated_at"`
+	ApprovedAt              *time.Time                 `json:"approved_at,omitempty"`
+	ApprovedBy              *string                    `json:"approved_by,omitempty"`
+	CancelledAt             *time.Time                 `json:"cancelled_at,omitempty"`
+	CancelledBy             *string                    `json:"cancelled_by,omitempty"`
+	CancellationReason      *string                    `json:"cancellation_reason,omitempty"`
+	ArchivedAt              *time.Time                 `json:"archived_at,omitempty"`
 }
 
 // OrderItemResponse represents order item information returned in responses
 type OrderItemResponse struct {
-	ID               uuid.UUID       `json:"id"`
-	ProductID        uuid.UUID       `json:"product_id"`
-	ProductSKU       string          `json:"product_sku"`
-	ProductName      string          `json:"product_name"`
-	VariantID        *uuid.UUID      `json:"variant_id,omitempty"`
-	VariantName      *string         `json:"variant_name,omitempty"`
-	Quantity         int32           `json:"quantity"`
-	UnitPrice        decimal.Decimal `json:"unit_price"`
-	TotalPrice       decimal.Decimal `json:"total_price"`
-	TaxAmount        decimal.Decimal `json:"tax_amount"`
-	DiscountAmount   decimal.Decimal `json:"discount_amount"`
-	FinalPrice       decimal.Decimal `json:"final_price"`
-	Weight           decimal.Decimal `json:"weight"`
-	Status           string          `json:"status"`
-	ShippedQuantity  int32           `json:"shipped_quantity"`
-	ReturnedQuantity int32           `json:"returned_quantity"`
-	Notes            *string         `json:"notes,omitempty"`
+	ID                  uuid.UUID       `json:"id"`
+	ProductID           uuid.UUID       `json:"product_id"`
+	ProductSKU          string          `json:"product_sku"`
+	ProductName         string          `json:"product_name"`
+	VariantID           *uuid.UUID      `json:"variant_id,omitempty"`
+	VariantName         *string         `json:"variant_name,omitempty"`
+	Quantity            int32           `json:"quantity"`
+	UnitPrice           decimal.Decimal `json:"unit_price"`
+	TotalPrice          decimal.Decimal `json:"total_price"`
+	TaxRate             decimal.Decimal `json:"tax_rate"`
+	TaxClass            string          `json:"tax_class,omitempty"`
+	PriceIncludesTax    bool            `json:"price_includes_tax"`
+	IsDropShip          bool            `json:"is_drop_ship"`
+	TaxAmount           decimal.Decimal `json:"tax_amount"`
+	DiscountAmount      decimal.Decimal `json:"discount_amount"`
+	FinalPrice          decimal.Decimal `json:"final_price"`
+	Weight              decimal.Decimal `json:"weight"`
+	Status              string          `json:"status"`
+	ShippedQuantity     int32           `json:"shipped_quantity"`
+	ReturnedQuantity    int32           `json:"returned_quantity"`
+	BackorderedQuantity int32           `json:"backordered_quantity"`
+	RefundedQuantity    int32           `json:"refunded_quantity"`
+	Notes               *string         `json:"notes,omitempty"`
 }
 
 // AddressResponse represents address information returned in responses
@@ -112,6 +125,8 @@ type OrderItemRequest struct {
 	Quantity  int32           `json:"quantity" binding:"required,min=1"`
 	UnitPrice decimal.Decimal `json:"unit_price" binding:"required,gt=0"`
 	Notes     *string         `json:"notes,omitempty"`
+	// DropShip overrides whether the product's supplier ships the line
+	DropShip *bool `json:"drop_ship,omitempty"`
 }
 
 // UpdateOrderRequest represents a request to update an order
@@ -150,16 +165,30 @@ type ProcessOrderRequest struct {
 
 // ShipOrderRequest represents a request to ship an order
 type ShipOrderRequest struct {
-	TrackingNumber string     `json:"tracking_number" binding:"required"`
-	Carrier        string     `json:"carrier" binding:"required"`
-	ShippingDate   *time.Time `json:"shipping_date,omitempty"`
-	NotifyCustomer bool       `json:"notify_customer"`
-	TrackingURL    *string    `json:"tracking_url,omitempty"`
-	Notes          *string    `json:"notes,omitempty"`
+	TrackingNumber string                   `json:"tracking_number" binding:"required"`
+	Carrier        string                   `json:"carrier" binding:"required"`
+	ShippingDate   *time.Time               `json:"shipping_date,omitempty"`
+	NotifyCustomer bool                     `json:"notify_customer"`
+	TrackingURL    *string                  `json:"tracking_url,omitempty"`
+	Notes          *string                  `json:"notes,omitempty"`
+	Packages       []ShipmentPackageRequest `json:"packages,omitempty" binding:"omitempty,dive"`
+}
+
+// ShipmentPackageRequest represents a parcel of a shipment
+type ShipmentPackageRequest struct {
+	TrackingNumber string          `json:"tracking_number,omitempty" binding:"omitempty,max=100"`
+	Weight         decimal.Decimal `json:"weight"`
+	WeightUnit     string          `json:"weight_unit,omitempty" binding:"omitempty,oneof=kg g lb oz"`
+	Length         decimal.Decimal `json:"length"`
+	Width          decimal.Decimal `json:"width"`
+	Height         decimal.Decimal `json:"height"`
+	DimensionUnit  string          `json:"dimension_unit,omitempty" binding:"omitempty,oneof=cm mm m in"`
 }
 
-// DeliverOrderRequest represents a request to mark an order as delivered
+// DeliverOrderRequest represents a request to mark an order as delivered.
+// When ShipmentID is set only that shipment is marked as delivered.
 type DeliverOrderRequest struct {
+	ShipmentID     *uuid.UUID `json:"shipment_id,omitempty"`
 	DeliveryDate   *time.Time `json:"delivery_date,omitempty"`
 	Signature      *string    `json:"signature,omitempty"`
 	PhotoProofURL  *string    `json:"photo_proof_url,omitempty"`
@@ -176,6 +205,7 @@ type PartialShipOrderRequest struct {
 	NotifyCustomer bool                     `json:"notify_customer"`
 	TrackingURL    *string                  `json:"tracking_url,omitempty"`
 	Notes          *string                  `json:"notes,omitempty"`
+	Packages       []ShipmentPackageRequest `json:"packages,omitempty" binding:"omitempty,dive"`
 }
 
 // PartialShipItemRequest represents an item to be partially shipped
@@ -201,6 +231,16 @@ type ReturnItemRequest struct {
 	Reason      *string   `json:"reason,omitempty"`
 }
 
+// HoldOrderRequest represents a request to place an order on hold
+type HoldOrderRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// OverrideCreditHoldRequest represents a credit manager's request to confirm an order on credit hold
+type OverrideCreditHoldRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
 // ProcessPaymentRequest represents a request to process payment
 type ProcessPaymentRequest struct {
 	PaymentMethod string          `json:"payment_method" binding:"required"`
@@ -243,6 +283,8 @@ type AddOrderItemRequest struct {
 	Quantity  int32           `json:"quantity" binding:"required,min=1"`
 	UnitPrice decimal.Decimal `json:"unit_price" binding:"required,gt=0"`
 	Notes     *string         `json:"notes,omitempty"`
+	// DropShip overrides whether the product's supplier ships the line
+	DropShip *bool `json:"drop_ship,omitempty"`
 }
 
 // UpdateOrderItemRequest represents a request to update an order item
@@ -254,50 +296,59 @@ type UpdateOrderItemRequest struct {
 
 // ListOrdersRequest represents a request to list orders
 type ListOrdersRequest struct {
-	CustomerID        *uuid.UUID `json:"customer_id,omitempty"`
-	Status            *string    `json:"status,omitempty"`
-	Type              *string    `json:"type,omitempty"`
-	Priority          *string    `json:"priority,omitempty"`
-	PaymentStatus     *string    `json:"payment_status,omitempty"`
-	FulfillmentStatus *string    `json:"fulfillment_status,omitempty"`
-	Currency          *string    `json:"currency,omitempty"`
-	CreatedAfter      *time.Time `json:"created_after,omitempty"`
-	CreatedBefore     *time.Time `json:"created_before,omitempty"`
-	RequiredAfter     *time.Time `json:"required_after,omitempty"`
-	RequiredBefore    *time.Time `json:"required_before,omitempty"`
-	Search            *string    `json:"search,omitempty"`
-	SortBy            *string    `json:"sort_by,omitempty"`
-	SortOrder         *string    `json:"sort_order,omitempty"`
-	Page              int        `json:"page" binding:"omitempty,min=1"`
-	Limit             int        `json:"limit" binding:"omitempty,min=1,max=100"`
+	CustomerID        *string    `json:"customer_id,omitempty" form:"customer_id" binding:"omitempty,uuid"`
+	Status            *string    `json:"status,omitempty" form:"status"`
+	Type              *string    `json:"type,omitempty" form:"type"`
+	Priority          *string    `json:"priority,omitempty" form:"priority"`
+	PaymentStatus     *string    `json:"payment_status,omitempty" form:"payment_status"`
+	FulfillmentStatus *string    `json:"fulfillment_status,omitempty" form:"fulfillment_status"`
+	Currency          *string    `json:"currency,omitempty" form:"currency"`
+	CreatedAfter      *time.Time `json:"created_after,omitempty" form:"created_after"`
+	CreatedBefore     *time.Time `json:"created_before,omitempty" form:"created_before"`
+	RequiredAfter     *time.Time `json:"required_after,omitempty" form:"required_after"`
+	RequiredBefore    *time.Time `json:"required_before,omitempty" form:"required_before"`
+	Search            *string    `json:"search,omitempty" form:"search"`
+	SortBy            *string    `json:"sort_by,omitempty" form:"sort_by"`
+	SortOrder         *string    `json:"sort_order,omitempty" form:"sort_order"`
+	Page              int        `json:"page" form:"page" binding:"omitempty,min=1"`
+	Limit             int        `json:"limit" form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// ListArchivedOrdersRequest represents a request to list archived orders
+type ListArchivedOrdersRequest struct {
+	Search     *string `json:"search,omitempty" form:"search"`
+	CustomerID *string `json:"customer_id,omitempty" form:"customer_id" binding:"omitempty,uuid"`
+	Year       *int    `json:"year,omitempty" form:"year" binding:"omitempty,min=1900"`
+	Page       int     `json:"page" form:"page" binding:"omitempty,min=1"`
+	Limit      int     `json:"limit" form:"limit" binding:"omitempty,min=1,max=100"`
 }
 
 // SearchOrdersRequest represents a request to search orders
 type SearchOrdersRequest struct {
-	Query         string     `json:"query" binding:"required"`
-	CustomerID    *uuid.UUID `json:"customer_id,omitempty"`
-	Status        *string    `json:"status,omitempty"`
-	Type          *string    `json:"type,omitempty"`
-	CreatedAfter  *time.Time `json:"created_after,omitempty"`
-	CreatedBefore *time.Time `json:"created_before,omitempty"`
-	SearchFields  []string   `json:"search_fields,omitempty"`
-	SortBy        *string    `json:"sort_by,omitempty"`
-	SortOrder     *string    `json:"sort_order,omitempty"`
-	Page          int        `json:"page" binding:"omitempty,min=1"`
-	Limit         int        `json:"limit" binding:"omitempty,min=1,max=100"`
+	Query         string     `json:"query" form:"query" binding:"required"`
+	CustomerID    *string    `json:"customer_id,omitempty" form:"customer_id" binding:"omitempty,uuid"`
+	Status        *string    `json:"status,omitempty" form:"status"`
+	Type          *string    `json:"type,omitempty" form:"type"`
+	CreatedAfter  *time.Time `json:"created_after,omitempty" form:"created_after"`
+	CreatedBefore *time.Time `json:"created_before,omitempty" form:"created_before"`
+	SearchFields  []string   `json:"search_fields,omitempty" form:"search_fields"`
+	SortBy        *string    `json:"sort_by,omitempty" form:"sort_by"`
+	SortOrder     *string    `json:"sort_order,omitempty" form:"sort_order"`
+	Page          int        `json:"page" form:"page" binding:"omitempty,min=1"`
+	Limit         int        `json:"limit" form:"limit" binding:"omitempty,min=1,max=100"`
 }
 
 // GetCustomerOrdersRequest represents a request to get customer orders
 type GetCustomerOrdersRequest struct {
-	CustomerID    uuid.UUID  `json:"customer_id" binding:"required"`
-	Status        *string    `json:"status,omitempty"`
-	Type          *string    `json:"type,omitempty"`
-	CreatedAfter  *time.Time `json:"created_after,omitempty"`
-	CreatedBefore *time.Time `json:"created_before,omitempty"`
-	SortBy        *string    `json:"sort_by,omitempty"`
-	SortOrder     *string    `json:"sort_order,omitempty"`
-	Page          int        `json:"page" binding:"omitempty,min=1"`
-	Limit         int        `json:"limit" binding:"omitempty,min=1,max=100"`
+	CustomerID    uuid.UUID  `json:"customer_id" form:"-"`
+	Status        *string    `json:"status,omitempty" form:"status"`
+	Type          *string    `json:"type,omitempty" form:"type"`
+	CreatedAfter  *time.Time `json:"created_after,omitempty" form:"created_after"`
+	CreatedBefore *time.Time `json:"created_before,omitempty" form:"created_before"`
+	SortBy        *string    `json:"sort_by,omitempty" form:"sort_by"`
+	SortOrder     *string    `json:"sort_order,omitempty" form:"sort_order"`
+	Page          int        `json:"page" form:"page" binding:"omitempty,min=1"`
+	Limit         int        `json:"limit" form:"limit" binding:"omitempty,min=1,max=100"`
 }
 
 // OrderValidationRequest represents a request to validate an order
@@ -410,6 +461,7 @@ type CustomerInfo struct {
 type OrderStatsResponse struct {
 	TotalOrders       int64            `json:"total_orders"`
 	TotalRevenue      decimal.Decimal  `json:"total_revenue"`
+	Currency          string           `json:"currency"`
 	OrdersByStatus    map[string]int64 `json:"orders_by_status"`
 	OrdersByType      map[string]int64 `json:"orders_by_type"`
 	TopProducts       []ProductStats   `json:"top_products"`
@@ -433,11 +485,13 @@ type CustomerStats struct {
 	Email        string          `json:"email"`
 	TotalOrders  int64           `json:"total_orders"`
 	TotalRevenue decimal.Decimal `json:"total_revenue"`
+	Currency     string          `json:"currency,omitempty"`
 }
 
 // RevenueByPeriodResponse represents revenue by period response
 type RevenueByPeriodResponse struct {
 	Period      string             `json:"period"`
+	Currency    string             `json:"currency"`
 	RevenueData []RevenueDataPoint `json:"revenue_data"`
 }
 
@@ -482,6 +536,50 @@ type PaymentMetrics struct {
 	TotalRefundedAmount decimal.Decimal  `json:"total_refunded_amount"`
 }
 
+// OrderAnalyticsRequest represents query parameters for order analytics
+type OrderAnalyticsRequest struct {
+	StartDate  *time.Time `json:"start_date,omitempty" form:"start_date" time_format:"2006-01-02"`
+	EndDate    *time.Time `json:"end_date,omitempty" form:"end_date" time_format:"2006-01-02"`
+	GroupBy    string     `json:"group_by,omitempty" form:"group_by" binding:"omitempty,oneof=day week month year"`
+	Status     *string    `json:"status,omitempty" form:"status"`
+	CustomerID *string    `json:"customer_id,omitempty" form:"customer_id" binding:"omitempty,uuid"`
+	Limit      int        `json:"limit,omitempty" form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// CheckInventoryRequest represents a request to check stock for order lines
+type CheckInventoryRequest struct {
+	Items []CheckInventoryItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+// CheckInventoryItemRequest represents a single line in an inventory check
+type CheckInventoryItemRequest struct {
+	ProductID   uuid.UUID  `json:"product_id" binding:"required"`
+	WarehouseID *uuid.UUID `json:"warehouse_id,omitempty"`
+	Quantity    int32      `json:"quantity" binding:"required,min=1"`
+}
+
+// CheckInventoryResponse represents the result of an inventory check
+type CheckInventoryResponse struct {
+	Available  bool                  `json:"available"`
+	Items      []InventoryItemResult `json:"items"`
+	TotalValue decimal.Decimal       `json:"total_value"`
+}
+
+// InventoryItemResult represents stock availability for a single line
+type InventoryItemResult struct {
+	ProductID        string          `json:"product_id"`
+	ProductName      string          `json:"product_name"`
+	RequestedQty     int             `json:"requested_qty"`
+	AvailableQty     int             `json:"available_qty"`
+	AllocatedQty     int             `json:"allocated_qty"`
+	BackorderQty     int             `json:"backorder_qty"`
+	CanFulfill       bool            `json:"can_fulfill"`
+	BackorderAllowed bool            `json:"backorder_allowed"`
+	UnitPrice        decimal.Decimal `json:"unit_price"`
+	TotalValue       decimal.Decimal `json:"total_value"`
+	Reason           string          `json:"reason,omitempty"`
+}
+
 // BulkUpdateStatusRequest represents a request to bulk update order status
 type BulkUpdateStatusRequest struct {
 	OrderIDs       []string `json:"order_ids" binding:"required,min=1"`
@@ -567,7 +665,80 @@ type OrderDiscount struct {
 type OrderTax struct {
 	ID     uuid.UUID       `json:"id"`
 	Name   string          `json:"name"`
+	Level  string          `json:"level,omitempty"`
 	Rate   decimal.Decimal `json:"rate"`
 	Amount decimal.Decimal `json:"amount"`
 	Type   string          `json:"type"`
 }
+
+// OrderStatusHistoryResponse represents a recorded status change of an order
+type OrderStatusHistoryResponse struct {
+	ID         uuid.UUID        `json:"id"`
+	Type       string           `json:"type"`
+	FromStatus *string          `json:"from_status,omitempty"`
+	ToStatus   string           `json:"to_status"`
+	Amount     *decimal.Decimal `json:"amount,omitempty"`
+	ChangedBy  *uuid.UUID       `json:"changed_by,omitempty"`
+	Reason     string           `json:"reason,omitempty"`
+	Source     string           `json:"source"`
+	CreatedAt  time.Time        `json:"created_at"`
+}
+
+// OrderTimelineEventResponse represents a single event on an order timeline
+type OrderTimelineEventResponse struct {
+	Type           string           `json:"type"`
+	OccurredAt     time.Time        `json:"occurred_at"`
+	Description    string           `json:"description"`
+	FromStatus     *string          `json:"from_status,omitempty"`
+	ToStatus       string           `json:"to_status,omitempty"`
+	Amount         *decimal.Decimal `json:"amount,omitempty"`
+	ActorID        *uuid.UUID       `json:"actor_id,omitempty"`
+	Source         string           `json:"source,omitempty"`
+	TrackingNumber *string          `json:"tracking_number,omitempty"`
+	Carrier        *string          `json:"carrier,omitempty"`
+}
+
+// ShipmentItemResponse represents an order line carried by a shipment
+type ShipmentItemResponse struct {
+	OrderItemID uuid.UUID `json:"order_item_id"`
+	ProductID   uuid.UUID `json:"product_id"`
+	Quantity    int       `json:"quantity"`
+}
+
+// ShipmentPackageResponse represents a parcel of a shipment in responses
+type ShipmentPackageResponse struct {
+	ID             uuid.UUID       `json:"id"`
+	TrackingNumber *string         `json:"tracking_number,omitempty"`
+	Weight         decimal.Decimal `json:"weight"`
+	WeightUnit     string          `json:"weight_unit"`
+	Length         decimal.Decimal `json:"length"`
+	Width          decimal.Decimal `json:"width"`
+	Height         decimal.Decimal `json:"height"`
+	DimensionUnit  string          `json:"dimension_unit"`
+}
+
+// ShipmentResponse represents a shipment of an order in responses
+type ShipmentResponse struct {
+	ID             uuid.UUID                 `json:"id"`
+	ShipmentNumber string                    `json:"shipment_number"`
+	Status         string                    `json:"status"`
+	Carrier        *string                   `json:"carrier,omitempty"`
+	TrackingNumber *string                   `json:"tracking_number,omitempty"`
+	DeliveryProof  *string                   `json:"delivery_proof,omitempty"`
+	Items          []ShipmentItemResponse    `json:"items"`
+	Packages       []ShipmentPackageResponse `json:"packages"`
+	ShippedBy      uuid.UUID                 `json:"shipped_by"`
+	DeliveredBy    *uuid.UUID                `json:"delivered_by,omitempty"`
+	ShippedAt      time.Time                 `json:"shipped_at"`
+	DeliveredAt    *time.Time                `json:"delivered_at,omitempty"`
+}
+
+// OrderShipmentsResponse represents the shipments of an order
+type OrderShipmentsResponse struct {
+	Shipments []ShipmentResponse `json:"shipments"`
+}
+
+// OrderTimelineResponse represents the merged event timeline of an order
+type OrderTimelineResponse struct {
+	Events []OrderTimelineEventResponse `json:"events"`
+}
@@ -0,0 +1,99 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Order import DTOs
+
+// CreateOrderImportProfileRequest represents a request to create an order
+// import profile. Columns and defaults are keyed by order field, such as
+// external_reference, customer_code, sku or quantity.
+type CreateOrderImportProfileRequest struct {
+	Name        string            `json:"name" binding:"required,max=255"`
+	Description *string           `json:"description,omitempty"`
+	Source      string            `json:"source" binding:"required,max=100"`
+	Format      string            `json:"format" binding:"required,oneof=CSV JSON"`
+	Delimiter   string            `json:"delimiter,omitempty" binding:"omitempty,max=1"`
+	Columns     map[string]string `json:"columns,omitempty"`
+	Defaults    map[string]string `json:"defaults,omitempty"`
+}
+
+// UpdateOrderImportProfileRequest represents a request to update an order import profile
+type UpdateOrderImportProfileRequest struct {
+	Name        *string           `json:"name,omitempty" binding:"omitempty,max=255"`
+	Description *string           `json:"description,omitempty"`
+	Source      *string           `json:"source,omitempty" binding:"omitempty,max=100"`
+	Format      *string           `json:"format,omitempty" binding:"omitempty,oneof=CSV JSON"`
+	Delimiter   *string           `json:"delimiter,omitempty" binding:"omitempty,max=1"`
+	Columns     map[string]string `json:"columns,omitempty"`
+	Defaults    map[string]string `json:"defaults,omitempty"`
+	IsActive    *bool             `json:"is_active,omitempty"`
+}
+
+// ListOrderImportProfilesRequest represents a request to list order import profiles
+type ListOrderImportProfilesRequest struct {
+	Search   string `json:"search,omitempty" form:"search"`
+	Source   string `json:"source,omitempty" form:"source"`
+	IsActive *bool  `json:"is_active,omitempty" form:"is_active"`
+	Page     int    `json:"page" form:"page" binding:"omitempty,min=1"`
+	Limit    int    `json:"limit" form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// OrderImportProfileResponse represents an order import profile in responses
+type OrderImportProfileResponse struct {
+	ID          uuid.UUID         `json:"id"`
+	Name        string            `json:"name"`
+	Description *string           `json:"description,omitempty"`
+	Source      string            `json:"source"`
+	Format      string            `json:"format"`
+	Delimiter   string            `json:"delimiter"`
+	Columns     map[string]string `json:"columns"`
+	Defaults    map[string]string `json:"defaults"`
+	IsActive    bool              `json:"is_active"`
+	CreatedBy   uuid.UUID         `json:"created_by"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+// ListOrderImportProfilesResponse represents a paginated list of order import profiles
+type ListOrderImportProfilesResponse struct {
+	Profiles   []*OrderImportProfileResponse `json:"profiles"`
+	Pagination *Pagination                   `json:"pagination"`
+}
+
+// ImportedOrderResponse represents the order of an external reference in an import report
+type ImportedOrderResponse struct {
+	ExternalReference string           `json:"external_reference"`
+	OrderID           *uuid.UUID       `json:"order_id,omitempty"`
+	OrderNumber       string           `json:"order_number,omitempty"`
+	Rows              []int            `json:"rows"`
+	TotalAmount       *decimal.Decimal `json:"total_amount,omitempty"`
+	Currency          string           `json:"currency,omitempty"`
+}
+
+// OrderImportErrorResponse represents a problem with a row of an import file
+type OrderImportErrorResponse struct {
+	Row               int    `json:"row"`
+	Item              int    `json:"item,omitempty"`
+	ExternalReference string `json:"external_reference,omitempty"`
+	Field             string `json:"field,omitempty"`
+	Message           string `json:"message"`
+}
+
+// ImportOrdersResponse reports an order import. Orders are created only when
+// no row has errors; references imported before are listed as skipped.
+type ImportOrdersResponse struct {
+	ProfileID   uuid.UUID                  `json:"profile_id"`
+	Source      string                     `json:"source"`
+	DryRun      bool                       `json:"dry_run"`
+	Committed   bool                       `json:"committed"`
+	TotalRows   int                        `json:"total_rows"`
+	TotalOrders int                        `json:"total_orders"`
+	Created     []ImportedOrderResponse    `json:"created"`
+	Skipped     []ImportedOrderResponse    `json:"skipped"`
+	Errors      []OrderImportErrorResponse `json:"errors"`
+}
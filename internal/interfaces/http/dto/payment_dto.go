@@ -0,0 +1,126 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Payment ledger DTOs
+
+// RecordPaymentRequest represents a payment received from a customer
+type RecordPaymentRequest struct {
+	CustomerID  uuid.UUID                  `json:"customer_id" binding:"required"`
+	Method      string                     `json:"method" binding:"required"`
+	Reference   *string                    `json:"reference,omitempty"`
+	Amount      decimal.Decimal            `json:"amount" binding:"required"`
+	Currency    string                     `json:"currency" binding:"required,len=3"`
+	ReceivedAt  *time.Time                 `json:"received_at,omitempty"`
+	Notes       *string                    `json:"notes,omitempty"`
+	Allocations []PaymentAllocationRequest `json:"allocations,omitempty" binding:"omitempty,dive"`
+}
+
+// PaymentAllocationRequest represents the part of a payment applied to an order
+type PaymentAllocationRequest struct {
+	OrderID uuid.UUID       `json:"order_id" binding:"required"`
+	Amount  decimal.Decimal `json:"amount" binding:"required"`
+}
+
+// AllocatePaymentRequest represents a request to apply the unallocated part of a payment to orders
+type AllocatePaymentRequest struct {
+	Allocations []PaymentAllocationRequest `json:"allocations" binding:"required,min=1,dive"`
+}
+
+// ReversePaymentRequest represents a request to reverse a payment or refund
+type ReversePaymentRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// ListPaymentsRequest represents a request to list ledger entries
+type ListPaymentsRequest struct {
+	Search       string     `json:"search,omitempty" form:"search"`
+	Type         *string    `json:"type,omitempty" form:"type" binding:"omitempty,oneof=PAYMENT REFUND"`
+	Method       *string    `json:"method,omitempty" form:"method"`
+	Status       *string    `json:"status,omitempty" form:"status" binding:"omitempty,oneof=COMPLETED REVERSED"`
+	CustomerID   *string    `json:"customer_id,omitempty" form:"customer_id" binding:"omitempty,uuid"`
+	OrderID      *string    `json:"order_id,omitempty" form:"order_id" binding:"omitempty,uuid"`
+	ReceivedFrom *time.Time `json:"received_from,omitempty" form:"received_from" time_format:"2006-01-02"`
+	ReceivedTo   *time.Time `json:"received_to,omitempty" form:"received_to" time_format:"2006-01-02"`
+	Unallocated  bool       `json:"unallocated,omitempty" form:"unallocated"`
+	Page         int        `json:"page" form:"page" binding:"omitempty,min=1"`
+	Limit        int        `json:"limit" form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// PaymentAllocationResponse represents the part of a payment applied to an order
+type PaymentAllocationResponse struct {
+	ID        uuid.UUID       `json:"id"`
+	OrderID   uuid.UUID       `json:"order_id"`
+	Amount    decimal.Decimal `json:"amount"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// PaymentResponse represents a ledger entry in responses
+type PaymentResponse struct {
+	ID                uuid.UUID                   `json:"id"`
+	PaymentNumber     string                      `json:"payment_number"`
+	Type              string                      `json:"type"`
+	CustomerID        uuid.UUID                   `json:"customer_id"`
+	Method            string                      `json:"method"`
+	Reference         *string                     `json:"reference,omitempty"`
+	Amount            decimal.Decimal             `json:"amount"`
+	AllocatedAmount   decimal.Decimal             `json:"allocated_amount"`
+	UnallocatedAmount decimal.Decimal             `json:"unallocated_amount"`
+	Currency          string                      `json:"currency"`
+	ReceivedAt        time.Time                   `json:"received_at"`
+	Status            string                      `json:"status"`
+	Notes             *string                     `json:"notes,omitempty"`
+	ReversedAt        *time.Time                  `json:"reversed_at,omitempty"`
+	ReversedBy        *uuid.UUID                  `json:"reversed_by,omitempty"`
+	ReversalReason    *string                     `json:"reversal_reason,omitempty"`
+	Allocations       []PaymentAllocationResponse `json:"allocations"`
+	CreatedBy         uuid.UUID                   `json:"created_by"`
+	CreatedAt         time.Time                   `json:"created_at"`
+	UpdatedAt         time.Time                   `json:"updated_at"`
+}
+
+// ListPaymentsResponse represents a paginated list of ledger entries
+type ListPaymentsResponse struct {
+	Payments   []*PaymentResponse `json:"payments"`
+	Pagination *Pagination        `json:"pagination"`
+}
+
+// ARAgingReportRequest represents a request for the accounts receivable aging
+type ARAgingReportRequest struct {
+	AsOf       *time.Time `json:"as_of,omitempty" form:"as_of" time_format:"2006-01-02"`
+	CustomerID *string    `json:"customer_id,omitempty" form:"customer_id" binding:"omitempty,uuid"`
+	Format     string     `json:"format,omitempty" form:"format" binding:"omitempty,oneof=json csv"`
+}
+
+// AgingAmountsResponse represents open receivables split by days past due
+type AgingAmountsResponse struct {
+	Current    decimal.Decimal `json:"current"`
+	Days1To30  decimal.Decimal `json:"days_1_30"`
+	Days31To60 decimal.Decimal `json:"days_31_60"`
+	Days61To90 decimal.Decimal `json:"days_61_90"`
+	Over90     decimal.Decimal `json:"over_90"`
+	Total      decimal.Decimal `json:"total"`
+}
+
+// CustomerAgingResponse represents the aged open receivables of a customer
+type CustomerAgingResponse struct {
+	CustomerID   uuid.UUID            `json:"customer_id"`
+	CustomerCode string               `json:"customer_code"`
+	CustomerName string               `json:"customer_name"`
+	Terms        string               `json:"terms"`
+	OpenOrders   int                  `json:"open_orders"`
+	Amounts      AgingAmountsResponse `json:"amounts"`
+}
+
+// ARAgingReportResponse represents the accounts receivable aging in the base currency
+type ARAgingReportResponse struct {
+	AsOf      time.Time                `json:"as_of"`
+	Currency  string                   `json:"currency"`
+	Customers []*CustomerAgingResponse `json:"customers"`
+	Totals    AgingAmountsResponse     `json:"totals"`
+}
@@ -0,0 +1,122 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Pick wave DTOs
+
+// CreatePickWaveRequest represents a request to release a pick wave for a warehouse
+type CreatePickWaveRequest struct {
+	WarehouseID    uuid.UUID  `json:"warehouse_id" binding:"required"`
+	ShippingMethod *string    `json:"shipping_method,omitempty" binding:"omitempty,oneof=STANDARD EXPRESS OVERNIGHT INTERNATIONAL PICKUP DIGITAL"`
+	MinPriority    *string    `json:"min_priority,omitempty" binding:"omitempty,oneof=LOW NORMAL HIGH URGENT CRITICAL"`
+	Carrier        *string    `json:"carrier,omitempty" binding:"omitempty,max=50"`
+	CutoffAt       *time.Time `json:"cutoff_at,omitempty"`
+	MaxOrders      int        `json:"max_orders,omitempty" binding:"omitempty,min=1,max=500"`
+	Notes          *string    `json:"notes,omitempty" binding:"omitempty,max=1000"`
+}
+
+// ConfirmPickRequest represents the quantity picked for a pick line
+type ConfirmPickRequest struct {
+	LineID   uuid.UUID `json:"line_id" binding:"required"`
+	Quantity int       `json:"quantity" binding:"min=0"`
+}
+
+// ConfirmPicksRequest represents the picked quantities of pick lines
+type ConfirmPicksRequest struct {
+	Picks []ConfirmPickRequest `json:"picks" binding:"required,min=1,dive"`
+}
+
+// PackOrderRequest represents the packing of a picked order into a shipment
+type PackOrderRequest struct {
+	TrackingNumber string                   `json:"tracking_number" binding:"required"`
+	Carrier        string                   `json:"carrier,omitempty" binding:"omitempty,max=50"`
+	NotifyCustomer bool                     `json:"notify_customer"`
+	Packages       []ShipmentPackageRequest `json:"packages,omitempty" binding:"omitempty,dive"`
+}
+
+// SetPickLocationRequest represents the shelf location of a product in a warehouse
+type SetPickLocationRequest struct {
+	WarehouseID uuid.UUID `json:"warehouse_id" binding:"required"`
+	ProductID   uuid.UUID `json:"product_id" binding:"required"`
+	Location    string    `json:"location" binding:"required,max=50"`
+}
+
+// ListPickWavesRequest represents a request to list pick waves
+type ListPickWavesRequest struct {
+	WarehouseID *string `json:"warehouse_id,omitempty" form:"warehouse_id" binding:"omitempty,uuid"`
+	OrderID     *string `json:"order_id,omitempty" form:"order_id" binding:"omitempty,uuid"`
+	Status      *string `json:"status,omitempty" form:"status"`
+	Search      *string `json:"search,omitempty" form:"search"`
+	Page        int     `json:"page" form:"page" binding:"omitempty,min=1"`
+	Limit       int     `json:"limit" form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// PickWaveOrderResponse represents an order of a pick wave in responses
+type PickWaveOrderResponse struct {
+	OrderID     uuid.UUID  `json:"order_id"`
+	OrderNumber string     `json:"order_number"`
+	Priority    string     `json:"priority"`
+	Sequence    int        `json:"sequence"`
+	Status      string     `json:"status"`
+	ShipmentID  *uuid.UUID `json:"shipment_id,omitempty"`
+	PackedBy    *uuid.UUID `json:"packed_by,omitempty"`
+	PackedAt    *time.Time `json:"packed_at,omitempty"`
+}
+
+// PickLineResponse represents a pick line in responses
+type PickLineResponse struct {
+	ID                  uuid.UUID  `json:"id"`
+	OrderID             uuid.UUID  `json:"order_id"`
+	OrderItemID         uuid.UUID  `json:"order_item_id"`
+	ProductID           uuid.UUID  `json:"product_id"`
+	ProductSKU          string     `json:"product_sku"`
+	ProductName         string     `json:"product_name"`
+	Location            *string    `json:"location,omitempty"`
+	Quantity            int        `json:"quantity"`
+	QuantityPicked      int        `json:"quantity_picked"`
+	QuantityShort       int        `json:"quantity_short"`
+	QuantityReallocated int        `json:"quantity_reallocated"`
+	QuantityBackordered int        `json:"quantity_backordered"`
+	Status              string     `json:"status"`
+	PickedBy            *uuid.UUID `json:"picked_by,omitempty"`
+	PickedAt            *time.Time `json:"picked_at,omitempty"`
+}
+
+// PickWaveResponse represents pick wave information returned in responses
+type PickWaveResponse struct {
+	ID             uuid.UUID               `json:"id"`
+	WaveNumber     string                  `json:"wave_number"`
+	WarehouseID    uuid.UUID               `json:"warehouse_id"`
+	Status         string                  `json:"status"`
+	ShippingMethod *string                 `json:"shipping_method,omitempty"`
+	MinPriority    *string                 `json:"min_priority,omitempty"`
+	Carrier        *string                 `json:"carrier,omitempty"`
+	CutoffAt       *time.Time              `json:"cutoff_at,omitempty"`
+	Notes          *string                 `json:"notes,omitempty"`
+	Orders         []PickWaveOrderResponse `json:"orders"`
+	Lines          []PickLineResponse      `json:"lines"`
+	CreatedBy      uuid.UUID               `json:"created_by"`
+	CreatedAt      time.Time               `json:"created_at"`
+	UpdatedAt      time.Time               `json:"updated_at"`
+	CompletedAt    *time.Time              `json:"completed_at,omitempty"`
+	CancelledAt    *time.Time              `json:"cancelled_at,omitempty"`
+}
+
+// ListPickWavesResponse represents a paginated list of pick waves
+type ListPickWavesResponse struct {
+	Waves      []*PickWaveResponse `json:"waves"`
+	Pagination *Pagination         `json:"pagination"`
+}
+
+// PickLocationResponse represents the pick location of a product in responses
+type PickLocationResponse struct {
+	WarehouseID uuid.UUID `json:"warehouse_id"`
+	ProductID   uuid.UUID `json:"product_id"`
+	Location    string    `json:"location"`
+	UpdatedBy   uuid.UUID `json:"updated_by"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
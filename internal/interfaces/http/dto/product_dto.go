@@ -35,9 +35,12 @@ type ProductResponse struct {
 	RequiresShipping bool             `json:"requires_shipping"`
 	Taxable          bool             `json:"taxable"`
 	TaxRate          decimal.Decimal  `json:"tax_rate,omitempty"`
+	TaxClass         string           `json:"tax_class,omitempty"`
 	IsActive         bool             `json:"is_active"`
 	IsFeatured       bool             `json:"is_featured"`
 	IsDigital        bool             `json:"is_digital"`
+	IsDropShip       bool             `json:"is_drop_ship"`
+	SupplierID       *uuid.UUID       `json:"supplier_id,omitempty"`
 	DownloadURL      string           `json:"download_url,omitempty"`
 	MaxDownloads     int              `json:"max_downloads,omitempty"`
 	ExpiryDays       int              `json:"expiry_days,omitempty"`
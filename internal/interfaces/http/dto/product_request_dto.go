@@ -28,8 +28,11 @@ type CreateProductRequest struct {
 	RequiresShipping bool            `json:"requires_shipping"`
 	Taxable          bool            `json:"taxable"`
 	TaxRate          decimal.Decimal `json:"tax_rate,omitempty" binding:"omitempty,gte=0,lte=100"`
+	TaxClass         string          `json:"tax_class,omitempty" binding:"omitempty,max=50"`
 	IsFeatured       bool            `json:"is_featured"`
 	IsDigital        bool            `json:"is_digital"`
+	IsDropShip       bool            `json:"is_drop_ship"`
+	SupplierID       string          `json:"supplier_id,omitempty" binding:"omitempty,uuid"`
 	DownloadURL      string          `json:"download_url,omitempty" binding:"omitempty,url,max=1000"`
 	MaxDownloads     int             `json:"max_downloads,omitempty" binding:"omitempty,gte=0,max=9999"`
 	ExpiryDays       int             `json:"expiry_days,omitempty" binding:"omitempty,gte=0,max=3650"`
@@ -55,8 +58,11 @@ type UpdateProductRequest struct {
 	RequiresShipping *bool            `json:"requires_shipping,omitempty"`
 	Taxable          *bool            `json:"taxable,omitempty"`
 	TaxRate          *decimal.Decimal `json:"tax_rate,omitempty" binding:"omitempty,gte=0,lte=100"`
+	TaxClass         *string          `json:"tax_class,omitempty" binding:"omitempty,max=50"`
 	IsFeatured       *bool            `json:"is_featured,omitempty"`
 	IsDigital        *bool            `json:"is_digital,omitempty"`
+	IsDropShip       *bool            `json:"is_drop_ship,omitempty"`
+	SupplierID       *string          `json:"supplier_id,omitempty" binding:"omitempty,uuid"`
 	DownloadURL      *string          `json:"download_url,omitempty" binding:"omitempty,url,max=1000"`
 	MaxDownloads     *int             `json:"max_downloads,omitempty" binding:"omitempty,gte=0,max=9999"`
 	ExpiryDays       *int             `json:"expiry_days,omitempty" binding:"omitempty,gte=0,max=3650"`
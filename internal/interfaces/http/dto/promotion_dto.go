@@ -0,0 +1,111 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Promotion DTOs
+
+// CreatePromotionRequest represents a request to create a promotion
+type CreatePromotionRequest struct {
+	Code                  string           `json:"code" binding:"required,min=3,max=50"`
+	Name                  string           `json:"name" binding:"required,max=255"`
+	Description           *string          `json:"description,omitempty"`
+	ActionType            string           `json:"action_type" binding:"required,oneof=PERCENTAGE FIXED_AMOUNT BUY_X_GET_Y FREE_SHIPPING"`
+	Value                 decimal.Decimal  `json:"value"`
+	MaxDiscount           *decimal.Decimal `json:"max_discount,omitempty"`
+	BuyQuantity           int              `json:"buy_quantity,omitempty" binding:"omitempty,min=1"`
+	GetQuantity           int              `json:"get_quantity,omitempty" binding:"omitempty,min=1"`
+	MinSubtotal           decimal.Decimal  `json:"min_subtotal"`
+	ProductIDs            []string         `json:"product_ids,omitempty" binding:"omitempty,dive,uuid"`
+	CategoryIDs           []string         `json:"category_ids,omitempty" binding:"omitempty,dive,uuid"`
+	CustomerTypes         []string         `json:"customer_types,omitempty" binding:"omitempty,dive,oneof=INDIVIDUAL BUSINESS GOVERNMENT NON_PROFIT"`
+	StartsAt              *time.Time       `json:"starts_at,omitempty"`
+	EndsAt                *time.Time       `json:"ends_at,omitempty"`
+	UsageLimit            *int             `json:"usage_limit,omitempty" binding:"omitempty,min=1"`
+	UsageLimitPerCustomer *int             `json:"usage_limit_per_customer,omitempty" binding:"omitempty,min=1"`
+	Stackable             bool             `json:"stackable"`
+	Priority              int              `json:"priority"`
+}
+
+// UpdatePromotionRequest represents a request to update a promotion
+type UpdatePromotionRequest struct {
+	Name                  *string          `json:"name,omitempty" binding:"omitempty,max=255"`
+	Description           *string          `json:"description,omitempty"`
+	Value                 *decimal.Decimal `json:"value,omitempty"`
+	MaxDiscount           *decimal.Decimal `json:"max_discount,omitempty"`
+	BuyQuantity           *int             `json:"buy_quantity,omitempty" binding:"omitempty,min=1"`
+	GetQuantity           *int             `json:"get_quantity,omitempty" binding:"omitempty,min=1"`
+	MinSubtotal           *decimal.Decimal `json:"min_subtotal,omitempty"`
+	ProductIDs            []string         `json:"product_ids,omitempty" binding:"omitempty,dive,uuid"`
+	CategoryIDs           []string         `json:"category_ids,omitempty" binding:"omitempty,dive,uuid"`
+	CustomerTypes         []string         `json:"customer_types,omitempty" binding:"omitempty,dive,oneof=INDIVIDUAL BUSINESS GOVERNMENT NON_PROFIT"`
+	StartsAt              *time.Time       `json:"starts_at,omitempty"`
+	EndsAt                *time.Time       `json:"ends_at,omitempty"`
+	UsageLimit            *int             `json:"usage_limit,omitempty" binding:"omitempty,min=1"`
+	UsageLimitPerCustomer *int             `json:"usage_limit_per_customer,omitempty" binding:"omitempty,min=1"`
+	Stackable             *bool            `json:"stackable,omitempty"`
+	Priority              *int             `json:"priority,omitempty"`
+	IsActive              *bool            `json:"is_active,omitempty"`
+}
+
+// ListPromotionsRequest represents a request to list promotions
+type ListPromotionsRequest struct {
+	Search     string  `json:"search,omitempty" form:"search"`
+	ActionType *string `json:"action_type,omitempty" form:"action_type" binding:"omitempty,oneof=PERCENTAGE FIXED_AMOUNT BUY_X_GET_Y FREE_SHIPPING"`
+	IsActive   *bool   `json:"is_active,omitempty" form:"is_active"`
+	Running    bool    `json:"running,omitempty" form:"running"`
+	Page       int     `json:"page" form:"page" binding:"omitempty,min=1"`
+	Limit      int     `json:"limit" form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// PromotionResponse represents a promotion in responses
+type PromotionResponse struct {
+	ID                    uuid.UUID        `json:"id"`
+	Code                  string           `json:"code"`
+	Name                  string           `json:"name"`
+	Description           *string          `json:"description,omitempty"`
+	ActionType            string           `json:"action_type"`
+	Value                 decimal.Decimal  `json:"value"`
+	MaxDiscount           *decimal.Decimal `json:"max_discount,omitempty"`
+	BuyQuantity           int              `json:"buy_quantity,omitempty"`
+	GetQuantity           int              `json:"get_quantity,omitempty"`
+	MinSubtotal           decimal.Decimal  `json:"min_subtotal"`
+	ProductIDs            []uuid.UUID      `json:"product_ids"`
+	CategoryIDs           []uuid.UUID      `json:"category_ids"`
+	CustomerTypes         []string         `json:"customer_types"`
+	StartsAt              time.Time        `json:"starts_at"`
+	EndsAt                *time.Time       `json:"ends_at,omitempty"`
+	UsageLimit            *int             `json:"usage_limit,omitempty"`
+	UsageLimitPerCustomer *int             `json:"usage_limit_per_customer,omitempty"`
+	TimesUsed             int              `json:"times_used"`
+	Stackable             bool             `json:"stackable"`
+	Priority              int              `json:"priority"`
+	IsActive              bool             `json:"is_active"`
+	CreatedBy             uuid.UUID        `json:"created_by"`
+	CreatedAt             time.Time        `json:"created_at"`
+	UpdatedAt             time.Time        `json:"updated_at"`
+}
+
+// ListPromotionsResponse represents a paginated list of promotions
+type ListPromotionsResponse struct {
+	Promotions []*PromotionResponse `json:"promotions"`
+	Pagination *Pagination          `json:"pagination"`
+}
+
+// ApplyCouponRequest represents a coupon code entered on an order
+type ApplyCouponRequest struct {
+	Code string `json:"code" binding:"required,max=50"`
+}
+
+// AppliedPromotionResponse represents a coupon code applied to an order
+type AppliedPromotionResponse struct {
+	PromotionID uuid.UUID `json:"promotion_id"`
+	Code        string    `json:"code"`
+	Name        string    `json:"name,omitempty"`
+	ActionType  string    `json:"action_type,omitempty"`
+	RedeemedAt  time.Time `json:"redeemed_at"`
+}
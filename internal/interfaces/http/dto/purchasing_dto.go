@@ -0,0 +1,261 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Purchasing-related DTOs
+
+// SupplierContactRequest represents a supplier contact in requests
+type SupplierContactRequest struct {
+	Name      string  `json:"name" binding:"required,max=200"`
+	Role      *string `json:"role,omitempty" binding:"omitempty,max=100"`
+	Email     *string `json:"email,omitempty" binding:"omitempty,email"`
+	Phone     *string `json:"phone,omitempty" binding:"omitempty,max=50"`
+	IsPrimary bool    `json:"is_primary"`
+}
+
+// SupplierRequest represents a request to create or replace a supplier.
+// Currency defaults to USD and payment terms to NET30.
+type SupplierRequest struct {
+	SupplierCode string                   `json:"supplier_code" binding:"required,max=50"`
+	Name         string                   `json:"name" binding:"required,max=200"`
+	LegalName    *string                  `json:"legal_name,omitempty" binding:"omitempty,max=200"`
+	TaxID        *string                  `json:"tax_id,omitempty" binding:"omitempty,max=50"`
+	Email        string                   `json:"email,omitempty" binding:"omitempty,email"`
+	Phone        string                   `json:"phone,omitempty" binding:"omitempty,max=50"`
+	Website      *string                  `json:"website,omitempty" binding:"omitempty,url"`
+	AddressLine1 *string                  `json:"address_line1,omitempty" binding:"omitempty,max=200"`
+	AddressLine2 *string                  `json:"address_line2,omitempty" binding:"omitempty,max=200"`
+	City         *string                  `json:"city,omitempty" binding:"omitempty,max=100"`
+	State        *string                  `json:"state,omitempty" binding:"omitempty,max=100"`
+	PostalCode   *string                  `json:"postal_code,omitempty" binding:"omitempty,max=20"`
+	Country      *string                  `json:"country,omitempty" binding:"omitempty,max=100"`
+	Currency     string                   `json:"currency,omitempty" binding:"omitempty,len=3"`
+	PaymentTerms string                   `json:"payment_terms,omitempty" binding:"omitempty,oneof=PREPAID COD NET7 NET15 NET30 NET45 NET60 NET90"`
+	LeadTimeDays int                      `json:"lead_time_days" binding:"min=0,max=365"`
+	IsActive     *bool                    `json:"is_active,omitempty"`
+	Notes        *string                  `json:"notes,omitempty"`
+	Contacts     []SupplierContactRequest `json:"contacts,omitempty" binding:"omitempty,dive"`
+}
+
+// ListSuppliersRequest represents a request to list suppliers
+type ListSuppliersRequest struct {
+	Search   *string `json:"search,omitempty" form:"search"`
+	IsActive *bool   `json:"is_active,omitempty" form:"is_active"`
+	Currency *string `json:"currency,omitempty" form:"currency" binding:"omitempty,len=3"`
+	Page     int     `json:"page" form:"page" binding:"omitempty,min=1"`
+	Limit    int     `json:"limit" form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// SupplierContactResponse represents a supplier contact in responses
+type SupplierContactResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Role      *string   `json:"role,omitempty"`
+	Email     *string   `json:"email,omitempty"`
+	Phone     *string   `json:"phone,omitempty"`
+	IsPrimary bool      `json:"is_primary"`
+}
+
+// SupplierResponse represents supplier information returned in responses
+type SupplierResponse struct {
+	ID           uuid.UUID                 `json:"id"`
+	SupplierCode string                    `json:"supplier_code"`
+	Name         string                    `json:"name"`
+	LegalName    *string                   `json:"legal_name,omitempty"`
+	TaxID        *string                   `json:"tax_id,omitempty"`
+	Email        string                    `json:"email,omitempty"`
+	Phone        string                    `json:"phone,omitempty"`
+	Website      *string                   `json:"website,omitempty"`
+	AddressLine1 *string                   `json:"address_line1,omitempty"`
+	AddressLine2 *string                   `json:"address_line2,omitempty"`
+	City         *string                   `json:"city,omitempty"`
+	State        *string                   `json:"state,omitempty"`
+	PostalCode   *string                   `json:"postal_code,omitempty"`
+	Country      *string                   `json:"country,omitempty"`
+	Currency     string                    `json:"currency"`
+	PaymentTerms string                    `json:"payment_terms"`
+	LeadTimeDays int                       `json:"lead_time_days"`
+	IsActive     bool                      `json:"is_active"`
+	Notes        *string                   `json:"notes,omitempty"`
+	Contacts     []SupplierContactResponse `json:"contacts,omitempty"`
+	CreatedAt    time.Time                 `json:"created_at"`
+	UpdatedAt    time.Time                 `json:"updated_at"`
+}
+
+// ListSuppliersResponse represents a paginated list of suppliers
+type ListSuppliersResponse struct {
+	Suppliers  []*SupplierResponse `json:"suppliers"`
+	Pagination *Pagination         `json:"pagination"`
+}
+
+// PurchaseOrderItemRequest represents a purchase order line in requests.
+// Unit cost defaults to the product's cost when omitted.
+type PurchaseOrderItemRequest struct {
+	ProductID    uuid.UUID       `json:"product_id" binding:"required"`
+	Quantity     int             `json:"quantity" binding:"required,min=1"`
+	UnitCost     decimal.Decimal `json:"unit_cost,omitempty"`
+	TaxRate      decimal.Decimal `json:"tax_rate,omitempty"`
+	ExpectedDate *time.Time      `json:"expected_date,omitempty"`
+	Notes        *string         `json:"notes,omitempty"`
+}
+
+// PurchaseOrderRequest represents a request to create a purchase order.
+// Currency and payment terms default to the supplier's, and the expected date
+// to the supplier's lead time.
+type PurchaseOrderRequest struct {
+	SupplierID     uuid.UUID                  `json:"supplier_id" binding:"required"`
+	WarehouseID    uuid.UUID                  `json:"warehouse_id" binding:"required"`
+	ExpectedDate   *time.Time                 `json:"expected_date,omitempty"`
+	Currency       string                     `json:"currency,omitempty" binding:"omitempty,len=3"`
+	PaymentTerms   string                     `json:"payment_terms,omitempty"`
+	ShippingAmount decimal.Decimal            `json:"shipping_amount,omitempty"`
+	Notes          *string                    `json:"notes,omitempty"`
+	Items          []PurchaseOrderItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+// UpdatePurchaseOrderRequest represents a request to update a draft purchase order
+type UpdatePurchaseOrderRequest struct {
+	WarehouseID    *uuid.UUID                 `json:"warehouse_id,omitempty"`
+	ExpectedDate   *time.Time                 `json:"expected_date,omitempty"`
+	PaymentTerms   *string                    `json:"payment_terms,omitempty"`
+	ShippingAmount *decimal.Decimal           `json:"shipping_amount,omitempty"`
+	Notes          *string                    `json:"notes,omitempty"`
+	Items          []PurchaseOrderItemRequest `json:"items,omitempty" binding:"omitempty,min=1,dive"`
+}
+
+// CancelPurchaseOrderRequest represents a request to cancel a purchase order
+type CancelPurchaseOrderRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+// ListPurchaseOrdersRequest represents a request to list purchase orders
+type ListPurchaseOrdersRequest struct {
+	SupplierID  *string `json:"supplier_id,omitempty" form:"supplier_id" binding:"omitempty,uuid"`
+	WarehouseID *string `json:"warehouse_id,omitempty" form:"warehouse_id" binding:"omitempty,uuid"`
+	// SalesOrderID lists the drop-ship purchase orders of a sales order
+	SalesOrderID *string `json:"sales_order_id,omitempty" form:"sales_order_id" binding:"omitempty,uuid"`
+	Status       *string `json:"status,omitempty" form:"status"`
+	Search       *string `json:"search,omitempty" form:"search"`
+	Overdue      bool    `json:"overdue,omitempty" form:"overdue"`
+	Page         int     `json:"page" form:"page" binding:"omitempty,min=1"`
+	Limit        int     `json:"limit" form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// PurchaseOrderItemResponse represents a purchase order line in responses
+type PurchaseOrderItemResponse struct {
+	ID                  uuid.UUID       `json:"id"`
+	ProductID           uuid.UUID       `json:"product_id"`
+	ProductSKU          string          `json:"product_sku"`
+	ProductName         string          `json:"product_name"`
+	QuantityOrdered     int             `json:"quantity_ordered"`
+	QuantityReceived    int             `json:"quantity_received"`
+	QuantityOutstanding int             `json:"quantity_outstanding"`
+	UnitCost            decimal.Decimal `json:"unit_cost"`
+	TaxRate             decimal.Decimal `json:"tax_rate"`
+	TaxAmount           decimal.Decimal `json:"tax_amount"`
+	TotalCost           decimal.Decimal `json:"total_cost"`
+	ExpectedDate        *time.Time      `json:"expected_date,omitempty"`
+	Notes               *string         `json:"notes,omitempty"`
+	SalesOrderItemID    *uuid.UUID      `json:"sales_order_item_id,omitempty"`
+}
+
+// DropShipAddressResponse represents the customer address a drop-ship
+// purchase order is shipped to
+type DropShipAddressResponse struct {
+	Name         string  `json:"name"`
+	Company      *string `json:"company,omitempty"`
+	AddressLine1 string  `json:"address_line1"`
+	AddressLine2 *string `json:"address_line2,omitempty"`
+	City         string  `json:"city"`
+	State        string  `json:"state"`
+	PostalCode   string  `json:"postal_code"`
+	Country      string  `json:"country"`
+	Phone        *string `json:"phone,omitempty"`
+}
+
+// PurchaseOrderResponse represents purchase order information returned in responses
+type PurchaseOrderResponse struct {
+	ID                 uuid.UUID                   `json:"id"`
+	PONumber           string                      `json:"po_number"`
+	SupplierID         uuid.UUID                   `json:"supplier_id"`
+	WarehouseID        *uuid.UUID                  `json:"warehouse_id,omitempty"`
+	SalesOrderID       *uuid.UUID                  `json:"sales_order_id,omitempty"`
+	ShipTo             *DropShipAddressResponse    `json:"ship_to,omitempty"`
+	Status             string                      `json:"status"`
+	OrderDate          time.Time                   `json:"order_date"`
+	ExpectedDate       *time.Time                  `json:"expected_date,omitempty"`
+	Currency           string                      `json:"currency"`
+	PaymentTerms       string                      `json:"payment_terms"`
+	Subtotal           decimal.Decimal             `json:"subtotal"`
+	TaxAmount          decimal.Decimal             `json:"tax_amount"`
+	ShippingAmount     decimal.Decimal             `json:"shipping_amount"`
+	TotalAmount        decimal.Decimal             `json:"total_amount"`
+	Items              []PurchaseOrderItemResponse `json:"items,omitempty"`
+	Notes              *string                     `json:"notes,omitempty"`
+	CancellationReason *string                     `json:"cancellation_reason,omitempty"`
+	CreatedBy          uuid.UUID                   `json:"created_by"`
+	CreatedAt          time.Time                   `json:"created_at"`
+	UpdatedAt          time.Time                   `json:"updated_at"`
+	OrderedAt          *time.Time                  `json:"ordered_at,omitempty"`
+	ClosedAt           *time.Time                  `json:"closed_at,omitempty"`
+	CancelledAt        *time.Time                  `json:"cancelled_at,omitempty"`
+}
+
+// ListPurchaseOrdersResponse represents a paginated list of purchase orders
+type ListPurchaseOrdersResponse struct {
+	PurchaseOrders []*PurchaseOrderResponse `json:"purchase_orders"`
+	Pagination     *Pagination              `json:"pagination"`
+}
+
+// GoodsReceiptItemRequest represents the quantity received for one purchase
+// order line. Unit cost defaults to the purchase order line's unit cost.
+type GoodsReceiptItemRequest struct {
+	PurchaseOrderItemID uuid.UUID        `json:"purchase_order_item_id" binding:"required"`
+	Quantity            int              `json:"quantity" binding:"required,min=1"`
+	UnitCost            *decimal.Decimal `json:"unit_cost,omitempty"`
+	BatchNumber         *string          `json:"batch_number,omitempty" binding:"omitempty,max=100"`
+	ExpiryDate          *time.Time       `json:"expiry_date,omitempty"`
+}
+
+// GoodsReceiptRequest represents a request to receive goods against a purchase order
+type GoodsReceiptRequest struct {
+	SupplierReference *string                   `json:"supplier_reference,omitempty" binding:"omitempty,max=100"`
+	Notes             *string                   `json:"notes,omitempty"`
+	Items             []GoodsReceiptItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+// GoodsReceiptItemResponse represents a received line in responses
+type GoodsReceiptItemResponse struct {
+	ID                     uuid.UUID       `json:"id"`
+	PurchaseOrderItemID    uuid.UUID       `json:"purchase_order_item_id"`
+	ProductID              uuid.UUID       `json:"product_id"`
+	Quantity               int             `json:"quantity"`
+	UnitCost               decimal.Decimal `json:"unit_cost"`
+	BatchNumber            *string         `json:"batch_number,omitempty"`
+	ExpiryDate             *time.Time      `json:"expiry_date,omitempty"`
+	InventoryTransactionID *uuid.UUID      `json:"inventory_transaction_id,omitempty"`
+}
+
+// GoodsReceiptResponse represents goods receipt information returned in responses
+type GoodsReceiptResponse struct {
+	ID                uuid.UUID                  `json:"id"`
+	ReceiptNumber     string                     `json:"receipt_number"`
+	PurchaseOrderID   uuid.UUID                  `json:"purchase_order_id"`
+	WarehouseID       uuid.UUID                  `json:"warehouse_id"`
+	SupplierReference *string                    `json:"supplier_reference,omitempty"`
+	Notes             *string                    `json:"notes,omitempty"`
+	Items             []GoodsReceiptItemResponse `json:"items"`
+	ReceivedBy        uuid.UUID                  `json:"received_by"`
+	ReceivedAt        time.Time                  `json:"received_at"`
+}
+
+// ReceiveGoodsResponse represents a recorded goods receipt with the updated purchase order
+type ReceiveGoodsResponse struct {
+	Receipt       *GoodsReceiptResponse  `json:"receipt"`
+	PurchaseOrder *PurchaseOrderResponse `json:"purchase_order"`
+}
@@ -0,0 +1,120 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Quotation-related DTOs
+
+// QuotationItemRequest represents a quotation line in requests. Unit price and
+// tax rate default to the product's price and tax rate when omitted.
+type QuotationItemRequest struct {
+	ProductID      uuid.UUID       `json:"product_id" binding:"required"`
+	Quantity       int32           `json:"quantity" binding:"required,min=1"`
+	UnitPrice      decimal.Decimal `json:"unit_price,omitempty"`
+	DiscountAmount decimal.Decimal `json:"discount_amount,omitempty"`
+	TaxRate        decimal.Decimal `json:"tax_rate,omitempty"`
+	Notes          *string         `json:"notes,omitempty"`
+}
+
+// QuotationRequest represents a request to create a quotation
+type QuotationRequest struct {
+	CustomerID        uuid.UUID              `json:"customer_id" binding:"required"`
+	ShippingMethod    string                 `json:"shipping_method" binding:"required"`
+	ShippingAddressID uuid.UUID              `json:"shipping_address_id" binding:"required"`
+	BillingAddressID  uuid.UUID              `json:"billing_address_id" binding:"required"`
+	Currency          string                 `json:"currency" binding:"omitempty,len=3"`
+	ValidUntil        time.Time              `json:"valid_until" binding:"required"`
+	ShippingAmount    decimal.Decimal        `json:"shipping_amount,omitempty"`
+	Notes             *string                `json:"notes,omitempty"`
+	Items             []QuotationItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+// UpdateQuotationRequest represents a request to update a draft quotation
+type UpdateQuotationRequest struct {
+	ShippingMethod    *string                `json:"shipping_method,omitempty"`
+	ShippingAddressID *uuid.UUID             `json:"shipping_address_id,omitempty"`
+	BillingAddressID  *uuid.UUID             `json:"billing_address_id,omitempty"`
+	ValidUntil        *time.Time             `json:"valid_until,omitempty"`
+	ShippingAmount    *decimal.Decimal       `json:"shipping_amount,omitempty"`
+	Notes             *string                `json:"notes,omitempty"`
+	Items             []QuotationItemRequest `json:"items,omitempty" binding:"omitempty,min=1,dive"`
+}
+
+// RejectQuotationRequest represents a request to reject a quotation
+type RejectQuotationRequest struct {
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+// ReviseQuotationRequest represents a request to create a new quotation revision
+type ReviseQuotationRequest struct {
+	ValidUntil *time.Time `json:"valid_until,omitempty"`
+}
+
+// ConvertQuotationRequest represents a request to convert a quotation into an order
+type ConvertQuotationRequest struct {
+	Priority      string     `json:"priority,omitempty"`
+	RequiredDate  *time.Time `json:"required_date,omitempty"`
+	CustomerNotes *string    `json:"customer_notes,omitempty"`
+	PaymentMethod *string    `json:"payment_method,omitempty"`
+}
+
+// ConvertQuotationResponse represents the order created from a quotation
+type ConvertQuotationResponse struct {
+	QuotationID string          `json:"quotation_id"`
+	OrderID     uuid.UUID       `json:"order_id"`
+	OrderNumber string          `json:"order_number"`
+	OrderStatus string          `json:"order_status"`
+	TotalAmount decimal.Decimal `json:"total_amount"`
+	Currency    string          `json:"currency"`
+}
+
+// ListQuotationsRequest represents a request to list quotations
+type ListQuotationsRequest struct {
+	CustomerID   *string `json:"customer_id,omitempty" form:"customer_id" binding:"omitempty,uuid"`
+	Status       *string `json:"status,omitempty" form:"status"`
+	Search       *string `json:"search,omitempty" form:"search"`
+	AllRevisions bool    `json:"all_revisions,omitempty" form:"all_revisions"`
+	Page         int     `json:"page" form:"page" binding:"omitempty,min=1"`
+	Limit        int     `json:"limit" form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// QuotationResponse represents quotation information returned in responses
+type QuotationResponse struct {
+	ID                 uuid.UUID           `json:"id"`
+	QuotationNumber    string              `json:"quotation_number"`
+	Revision           int                 `json:"revision"`
+	PreviousRevisionID *uuid.UUID          `json:"previous_revision_id,omitempty"`
+	CustomerID         uuid.UUID           `json:"customer_id"`
+	Status             string              `json:"status"`
+	ValidUntil         time.Time           `json:"valid_until"`
+	ShippingMethod     string              `json:"shipping_method"`
+	ShippingAddressID  uuid.UUID           `json:"shipping_address_id"`
+	BillingAddressID   uuid.UUID           `json:"billing_address_id"`
+	Currency           string              `json:"currency"`
+	Subtotal           decimal.Decimal     `json:"subtotal"`
+	TaxAmount          decimal.Decimal     `json:"tax_amount"`
+	ShippingAmount     decimal.Decimal     `json:"shipping_amount"`
+	DiscountAmount     decimal.Decimal     `json:"discount_amount"`
+	TotalAmount        decimal.Decimal     `json:"total_amount"`
+	Items              []OrderItemResponse `json:"items"`
+	Notes              *string             `json:"notes,omitempty"`
+	RejectionReason    *string             `json:"rejection_reason,omitempty"`
+	ConvertedOrderID   *uuid.UUID          `json:"converted_order_id,omitempty"`
+	CreatedBy          uuid.UUID           `json:"created_by"`
+	CreatedAt          time.Time           `json:"created_at"`
+	UpdatedAt          time.Time           `json:"updated_at"`
+	SentAt             *time.Time          `json:"sent_at,omitempty"`
+	AcceptedAt         *time.Time          `json:"accepted_at,omitempty"`
+	RejectedAt         *time.Time          `json:"rejected_at,omitempty"`
+	ConvertedAt        *time.Time          `json:"converted_at,omitempty"`
+}
+
+// ListQuotationsResponse represents a paginated list of quotations
+type ListQuotationsResponse struct {
+	Quotations []*QuotationResponse `json:"quotations"`
+	Pagination *Pagination          `json:"pagination"`
+}
@@ -0,0 +1,120 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Recurring order DTOs
+
+// RecurringOrderItemRequest represents a template line of a recurring order.
+// Lines carry no price; generated orders are priced from the catalogue.
+type RecurringOrderItemRequest struct {
+	ProductID uuid.UUID `json:"product_id" binding:"required"`
+	Quantity  int       `json:"quantity" binding:"required,min=1,max=9999"`
+	Notes     *string   `json:"notes,omitempty"`
+}
+
+// CreateRecurringOrderRequest represents a request to create a recurring
+// order. With a source order, the customer, addresses, shipping method,
+// currency and lines not given are copied from that order.
+type CreateRecurringOrderRequest struct {
+	Name              string                      `json:"name" binding:"required,max=255"`
+	SourceOrderID     *uuid.UUID                  `json:"source_order_id,omitempty"`
+	CustomerID        *uuid.UUID                  `json:"customer_id,omitempty"`
+	Priority          string                      `json:"priority,omitempty" binding:"omitempty,oneof=LOW NORMAL HIGH URGENT CRITICAL"`
+	ShippingMethod    string                      `json:"shipping_method,omitempty"`
+	ShippingAddressID *uuid.UUID                  `json:"shipping_address_id,omitempty"`
+	BillingAddressID  *uuid.UUID                  `json:"billing_address_id,omitempty"`
+	Currency          string                      `json:"currency,omitempty" binding:"omitempty,len=3"`
+	Notes             *string                     `json:"notes,omitempty"`
+	Items             []RecurringOrderItemRequest `json:"items,omitempty" binding:"omitempty,dive"`
+	Frequency         string                      `json:"frequency" binding:"required,oneof=DAILY WEEKLY MONTHLY"`
+	Interval          int                         `json:"interval,omitempty" binding:"omitempty,min=1"`
+	DayOfMonth        *int                        `json:"day_of_month,omitempty" binding:"omitempty,min=1,max=31"`
+	StartDate         time.Time                   `json:"start_date" binding:"required"`
+	EndDate           *time.Time                  `json:"end_date,omitempty"`
+}
+
+// UpdateRecurringOrderRequest represents a request to update an active or paused recurring order
+type UpdateRecurringOrderRequest struct {
+	Name              *string                     `json:"name,omitempty" binding:"omitempty,max=255"`
+	Priority          *string                     `json:"priority,omitempty" binding:"omitempty,oneof=LOW NORMAL HIGH URGENT CRITICAL"`
+	ShippingMethod    *string                     `json:"shipping_method,omitempty"`
+	ShippingAddressID *uuid.UUID                  `json:"shipping_address_id,omitempty"`
+	BillingAddressID  *uuid.UUID                  `json:"billing_address_id,omitempty"`
+	Currency          *string                     `json:"currency,omitempty" binding:"omitempty,len=3"`
+	Notes             *string                     `json:"notes,omitempty"`
+	Items             []RecurringOrderItemRequest `json:"items,omitempty" binding:"omitempty,min=1,dive"`
+	Frequency         *string                     `json:"frequency,omitempty" binding:"omitempty,oneof=DAILY WEEKLY MONTHLY"`
+	Interval          *int                        `json:"interval,omitempty" binding:"omitempty,min=1"`
+	DayOfMonth        *int                        `json:"day_of_month,omitempty" binding:"omitempty,min=1,max=31"`
+	StartDate         *time.Time                  `json:"start_date,omitempty"`
+	EndDate           *time.Time                  `json:"end_date,omitempty"`
+}
+
+// SkipRecurringOrderRequest represents a request to skip the next occurrence of a recurring order
+type SkipRecurringOrderRequest struct {
+	Reason *string `json:"reason,omitempty" binding:"omitempty,max=500"`
+}
+
+// ListRecurringOrdersRequest represents a request to list recurring orders
+type ListRecurringOrdersRequest struct {
+	CustomerID *string `json:"customer_id,omitempty" form:"customer_id" binding:"omitempty,uuid"`
+	Status     *string `json:"status,omitempty" form:"status"`
+	Search     *string `json:"search,omitempty" form:"search"`
+	Page       int     `json:"page" form:"page" binding:"omitempty,min=1"`
+	Limit      int     `json:"limit" form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// RecurringOrderItemResponse represents a template line of a recurring order in responses
+type RecurringOrderItemResponse struct {
+	ID        uuid.UUID `json:"id"`
+	ProductID uuid.UUID `json:"product_id"`
+	Quantity  int       `json:"quantity"`
+	Notes     *string   `json:"notes,omitempty"`
+}
+
+// RecurringOrderResponse represents a recurring order in responses
+type RecurringOrderResponse struct {
+	ID                uuid.UUID                    `json:"id"`
+	Name              string                       `json:"name"`
+	CustomerID        uuid.UUID                    `json:"customer_id"`
+	Status            string                       `json:"status"`
+	Priority          string                       `json:"priority"`
+	ShippingMethod    string                       `json:"shipping_method"`
+	ShippingAddressID uuid.UUID                    `json:"shipping_address_id"`
+	BillingAddressID  uuid.UUID                    `json:"billing_address_id"`
+	Currency          string                       `json:"currency,omitempty"`
+	Notes             *string                      `json:"notes,omitempty"`
+	Items             []RecurringOrderItemResponse `json:"items"`
+	Frequency         string                       `json:"frequency"`
+	Interval          int                          `json:"interval"`
+	DayOfMonth        *int                         `json:"day_of_month,omitempty"`
+	StartDate         time.Time                    `json:"start_date"`
+	EndDate           *time.Time                   `json:"end_date,omitempty"`
+	NextRunAt         *time.Time                   `json:"next_run_at,omitempty"`
+	LastRunAt         *time.Time                   `json:"last_run_at,omitempty"`
+	CreatedBy         uuid.UUID                    `json:"created_by"`
+	CreatedAt         time.Time                    `json:"created_at"`
+	UpdatedAt         time.Time                    `json:"updated_at"`
+}
+
+// ListRecurringOrdersResponse represents a paginated list of recurring orders
+type ListRecurringOrdersResponse struct {
+	RecurringOrders []*RecurringOrderResponse `json:"recurring_orders"`
+	Pagination      *Pagination               `json:"pagination"`
+}
+
+// RecurringOrderRunResponse represents an occurrence of a recurring order in responses
+type RecurringOrderRunResponse struct {
+	ID           uuid.UUID  `json:"id"`
+	ScheduledFor time.Time  `json:"scheduled_for"`
+	Status       string     `json:"status"`
+	OrderID      *uuid.UUID `json:"order_id,omitempty"`
+	OrderNumber  *string    `json:"order_number,omitempty"`
+	Reason       *string    `json:"reason,omitempty"`
+	CreatedBy    *uuid.UUID `json:"created_by,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
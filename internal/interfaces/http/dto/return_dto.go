@@ -0,0 +1,116 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Return authorization (RMA) DTOs
+
+// ReturnAuthorizationItemRequest represents an order line to be returned
+type ReturnAuthorizationItemRequest struct {
+	OrderItemID uuid.UUID `json:"order_item_id" binding:"required"`
+	Quantity    int       `json:"quantity" binding:"required,min=1"`
+	Reason      string    `json:"reason,omitempty" binding:"omitempty,oneof=DAMAGED DEFECTIVE WRONG_ITEM NOT_AS_DESCRIBED NO_LONGER_NEEDED OTHER"`
+}
+
+// ReturnAuthorizationRequest represents a request to authorize a return
+type ReturnAuthorizationRequest struct {
+	OrderID       uuid.UUID                        `json:"order_id" binding:"required"`
+	Resolution    string                           `json:"resolution" binding:"required,oneof=REFUND REPLACEMENT"`
+	Reason        string                           `json:"reason" binding:"required,oneof=DAMAGED DEFECTIVE WRONG_ITEM NOT_AS_DESCRIBED NO_LONGER_NEEDED OTHER"`
+	CustomerNotes *string                          `json:"customer_notes,omitempty"`
+	Items         []ReturnAuthorizationItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+// RejectReturnRequest represents a request to reject a return
+type RejectReturnRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+// ReceiveReturnItemRequest represents the received quantity of a return line
+type ReceiveReturnItemRequest struct {
+	ItemID   uuid.UUID `json:"item_id" binding:"required"`
+	Quantity int       `json:"quantity" binding:"min=0"`
+}
+
+// ReceiveReturnRequest represents the receipt of returned goods into a RETURN warehouse
+type ReceiveReturnRequest struct {
+	WarehouseID uuid.UUID                  `json:"warehouse_id" binding:"required"`
+	Items       []ReceiveReturnItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+// InspectReturnItemRequest represents the inspection outcome of a return line
+type InspectReturnItemRequest struct {
+	ItemID      uuid.UUID `json:"item_id" binding:"required"`
+	Disposition string    `json:"disposition" binding:"required,oneof=RESTOCK SCRAP RETURN_TO_VENDOR"`
+	Notes       *string   `json:"notes,omitempty"`
+}
+
+// InspectReturnRequest represents the inspection of a received return
+type InspectReturnRequest struct {
+	Items              []InspectReturnItemRequest `json:"items" binding:"required,min=1,dive"`
+	RestockWarehouseID *uuid.UUID                 `json:"restock_warehouse_id,omitempty"`
+}
+
+// ListReturnsRequest represents a request to list return authorizations
+type ListReturnsRequest struct {
+	OrderID    *string `json:"order_id,omitempty" form:"order_id" binding:"omitempty,uuid"`
+	CustomerID *string `json:"customer_id,omitempty" form:"customer_id" binding:"omitempty,uuid"`
+	Status     *string `json:"status,omitempty" form:"status"`
+	Search     *string `json:"search,omitempty" form:"search"`
+	Page       int     `json:"page" form:"page" binding:"omitempty,min=1"`
+	Limit      int     `json:"limit" form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// ReturnAuthorizationItemResponse represents a return line in responses
+type ReturnAuthorizationItemResponse struct {
+	ID               uuid.UUID       `json:"id"`
+	OrderItemID      uuid.UUID       `json:"order_item_id"`
+	ProductID        uuid.UUID       `json:"product_id"`
+	ProductSKU       string          `json:"product_sku"`
+	ProductName      string          `json:"product_name"`
+	Quantity         int             `json:"quantity"`
+	QuantityReceived int             `json:"quantity_received"`
+	UnitRefundAmount decimal.Decimal `json:"unit_refund_amount"`
+	Reason           string          `json:"reason"`
+	Disposition      *string         `json:"disposition,omitempty"`
+	InspectionNotes  *string         `json:"inspection_notes,omitempty"`
+}
+
+// ReturnAuthorizationResponse represents return authorization information returned in responses
+type ReturnAuthorizationResponse struct {
+	ID                 uuid.UUID                         `json:"id"`
+	RMANumber          string                            `json:"rma_number"`
+	OrderID            uuid.UUID                         `json:"order_id"`
+	CustomerID         uuid.UUID                         `json:"customer_id"`
+	Status             string                            `json:"status"`
+	Resolution         string                            `json:"resolution"`
+	Reason             string                            `json:"reason"`
+	CustomerNotes      *string                           `json:"customer_notes,omitempty"`
+	RejectionReason    *string                           `json:"rejection_reason,omitempty"`
+	WarehouseID        *uuid.UUID                        `json:"warehouse_id,omitempty"`
+	RefundAmount       decimal.Decimal                   `json:"refund_amount"`
+	ReplacementOrderID *uuid.UUID                        `json:"replacement_order_id,omitempty"`
+	Items              []ReturnAuthorizationItemResponse `json:"items"`
+	RequestedBy        uuid.UUID                         `json:"requested_by"`
+	ApprovedBy         *uuid.UUID                        `json:"approved_by,omitempty"`
+	ReceivedBy         *uuid.UUID                        `json:"received_by,omitempty"`
+	InspectedBy        *uuid.UUID                        `json:"inspected_by,omitempty"`
+	CreatedAt          time.Time                         `json:"created_at"`
+	UpdatedAt          time.Time                         `json:"updated_at"`
+	ApprovedAt         *time.Time                        `json:"approved_at,omitempty"`
+	RejectedAt         *time.Time                        `json:"rejected_at,omitempty"`
+	ReceivedAt         *time.Time                        `json:"received_at,omitempty"`
+	InspectedAt        *time.Time                        `json:"inspected_at,omitempty"`
+	CompletedAt        *time.Time                        `json:"completed_at,omitempty"`
+	CancelledAt        *time.Time                        `json:"cancelled_at,omitempty"`
+}
+
+// ListReturnsResponse represents a paginated list of return authorizations
+type ListReturnsResponse struct {
+	Returns    []*ReturnAuthorizationResponse `json:"returns"`
+	Pagination *Pagination                    `json:"pagination"`
+}
@@ -0,0 +1,97 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Order revision DTOs
+
+// AmendOrderRequest represents a change to the lines, prices or addresses of a confirmed order
+type AmendOrderRequest struct {
+	Lines             []AmendOrderLineRequest `json:"lines,omitempty" binding:"omitempty,dive"`
+	ShippingAddressID *string                 `json:"shipping_address_id,omitempty" binding:"omitempty,uuid"`
+	BillingAddressID  *string                 `json:"billing_address_id,omitempty" binding:"omitempty,uuid"`
+	Reason            *string                 `json:"reason,omitempty" binding:"omitempty,max=500"`
+}
+
+// AmendOrderLineRequest changes, removes or, without an order item ID, adds a line
+type AmendOrderLineRequest struct {
+	OrderItemID    *string          `json:"order_item_id,omitempty" binding:"omitempty,uuid"`
+	ProductID      *string          `json:"product_id,omitempty" binding:"omitempty,uuid"`
+	Quantity       *int             `json:"quantity,omitempty" binding:"omitempty,min=1"`
+	UnitPrice      *decimal.Decimal `json:"unit_price,omitempty"`
+	DiscountAmount *decimal.Decimal `json:"discount_amount,omitempty"`
+	Remove         bool             `json:"remove,omitempty"`
+}
+
+// RejectOrderRevisionRequest represents an approver's request to reject a pending revision
+type RejectOrderRevisionRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// OrderRevisionResponse represents a numbered revision of an order
+type OrderRevisionResponse struct {
+	ID              uuid.UUID                       `json:"id"`
+	OrderID         uuid.UUID                       `json:"order_id"`
+	RevisionNumber  int                             `json:"revision_number"`
+	Status          string                          `json:"status"`
+	Reason          *string                         `json:"reason,omitempty"`
+	Changes         []OrderRevisionChangeResponse   `json:"changes"`
+	Snapshot        OrderSnapshotResponse           `json:"snapshot"`
+	ApproverRoles   []string                        `json:"approver_roles,omitempty"`
+	NextApprover    string                          `json:"next_approver,omitempty"`
+	ApprovalAmount  decimal.Decimal                 `json:"approval_amount"`
+	Approvals       []OrderRevisionApprovalResponse `json:"approvals,omitempty"`
+	CreatedBy       uuid.UUID                       `json:"created_by"`
+	CreatedAt       time.Time                       `json:"created_at"`
+	AppliedAt       *time.Time                      `json:"applied_at,omitempty"`
+	RejectedBy      *uuid.UUID                      `json:"rejected_by,omitempty"`
+	RejectedAt      *time.Time                      `json:"rejected_at,omitempty"`
+	RejectionReason *string                         `json:"rejection_reason,omitempty"`
+}
+
+// OrderRevisionChangeResponse represents one difference from the previous revision
+type OrderRevisionChangeResponse struct {
+	Field       string     `json:"field"`
+	OrderItemID *uuid.UUID `json:"order_item_id,omitempty"`
+	ProductSKU  string     `json:"product_sku,omitempty"`
+	From        string     `json:"from,omitempty"`
+	To          string     `json:"to,omitempty"`
+}
+
+// OrderRevisionApprovalResponse represents an approver's approval of a revision
+type OrderRevisionApprovalResponse struct {
+	Role       string    `json:"role"`
+	ApprovedBy uuid.UUID `json:"approved_by"`
+	ApprovedAt time.Time `json:"approved_at"`
+}
+
+// OrderSnapshotResponse represents the lines, addresses and totals of an order as of a revision
+type OrderSnapshotResponse struct {
+	Lines             []OrderSnapshotLineResponse `json:"lines"`
+	ShippingAddressID uuid.UUID                   `json:"shipping_address_id"`
+	BillingAddressID  uuid.UUID                   `json:"billing_address_id"`
+	Subtotal          decimal.Decimal             `json:"subtotal"`
+	TaxAmount         decimal.Decimal             `json:"tax_amount"`
+	ShippingAmount    decimal.Decimal             `json:"shipping_amount"`
+	DiscountAmount    decimal.Decimal             `json:"discount_amount"`
+	TotalAmount       decimal.Decimal             `json:"total_amount"`
+	Currency          string                      `json:"currency"`
+}
+
+// OrderSnapshotLineResponse represents an order line as of a revision
+type OrderSnapshotLineResponse struct {
+	OrderItemID     uuid.UUID       `json:"order_item_id"`
+	ProductID       uuid.UUID       `json:"product_id"`
+	ProductSKU      string          `json:"product_sku"`
+	ProductName     string          `json:"product_name"`
+	Quantity        int             `json:"quantity"`
+	QuantityShipped int             `json:"quantity_shipped"`
+	UnitPrice       decimal.Decimal `json:"unit_price"`
+	DiscountAmount  decimal.Decimal `json:"discount_amount"`
+	TaxAmount       decimal.Decimal `json:"tax_amount"`
+	TotalPrice      decimal.Decimal `json:"total_price"`
+}
@@ -0,0 +1,126 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Shipping DTOs
+
+// ShippingRateRequest represents a shipping rate of a zone
+type ShippingRateRequest struct {
+	Method                string           `json:"method" binding:"required,oneof=STANDARD EXPRESS OVERNIGHT INTERNATIONAL PICKUP DIGITAL"`
+	Name                  string           `json:"name" binding:"required,max=255"`
+	MinWeight             float64          `json:"min_weight" binding:"min=0"`
+	MaxWeight             *float64         `json:"max_weight,omitempty"`
+	BaseRate              decimal.Decimal  `json:"base_rate"`
+	PerKgRate             decimal.Decimal  `json:"per_kg_rate"`
+	HandlingFee           decimal.Decimal  `json:"handling_fee"`
+	FreeShippingThreshold *decimal.Decimal `json:"free_shipping_threshold,omitempty"`
+	DimensionalDivisor    float64          `json:"dimensional_divisor" binding:"min=0"`
+	MinDeliveryDays       int              `json:"min_delivery_days" binding:"min=0"`
+	MaxDeliveryDays       int              `json:"max_delivery_days" binding:"min=0"`
+}
+
+// CreateShippingZoneRequest represents a request to create a shipping zone
+type CreateShippingZoneRequest struct {
+	Code               string                `json:"code" binding:"required,max=50"`
+	Name               string                `json:"name" binding:"required,max=255"`
+	Country            string                `json:"country" binding:"required,len=2"`
+	State              *string               `json:"state,omitempty" binding:"omitempty,max=100"`
+	PostalCodePrefixes []string              `json:"postal_code_prefixes,omitempty"`
+	Rates              []ShippingRateRequest `json:"rates,omitempty" binding:"omitempty,dive"`
+}
+
+// UpdateShippingZoneRequest represents a request to update a shipping zone
+type UpdateShippingZoneRequest struct {
+	Name               *string  `json:"name,omitempty" binding:"omitempty,max=255"`
+	State              *string  `json:"state,omitempty" binding:"omitempty,max=100"`
+	PostalCodePrefixes []string `json:"postal_code_prefixes,omitempty"`
+	IsActive           *bool    `json:"is_active,omitempty"`
+}
+
+// UpdateShippingRateRequest represents a request to update a shipping rate
+type UpdateShippingRateRequest struct {
+	Name                       *string          `json:"name,omitempty" binding:"omitempty,max=255"`
+	MinWeight                  *float64         `json:"min_weight,omitempty" binding:"omitempty,min=0"`
+	MaxWeight                  *float64         `json:"max_weight,omitempty"`
+	ClearMaxWeight             bool             `json:"clear_max_weight,omitempty"`
+	BaseRate                   *decimal.Decimal `json:"base_rate,omitempty"`
+	PerKgRate                  *decimal.Decimal `json:"per_kg_rate,omitempty"`
+	HandlingFee                *decimal.Decimal `json:"handling_fee,omitempty"`
+	FreeShippingThreshold      *decimal.Decimal `json:"free_shipping_threshold,omitempty"`
+	ClearFreeShippingThreshold bool             `json:"clear_free_shipping_threshold,omitempty"`
+	DimensionalDivisor         *float64         `json:"dimensional_divisor,omitempty" binding:"omitempty,min=0"`
+	MinDeliveryDays            *int             `json:"min_delivery_days,omitempty" binding:"omitempty,min=0"`
+	MaxDeliveryDays            *int             `json:"max_delivery_days,omitempty" binding:"omitempty,min=0"`
+	IsActive                   *bool            `json:"is_active,omitempty"`
+}
+
+// ListShippingZonesRequest represents a request to list shipping zones
+type ListShippingZonesRequest struct {
+	Search   string  `json:"search,omitempty" form:"search"`
+	Country  string  `json:"country,omitempty" form:"country" binding:"omitempty,len=2"`
+	State    *string `json:"state,omitempty" form:"state"`
+	IsActive *bool   `json:"is_active,omitempty" form:"is_active"`
+	Page     int     `json:"page" form:"page" binding:"omitempty,min=1"`
+	Limit    int     `json:"limit" form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// ShippingRateResponse represents a shipping rate in responses
+type ShippingRateResponse struct {
+	ID                    uuid.UUID        `json:"id"`
+	Method                string           `json:"method"`
+	Name                  string           `json:"name"`
+	MinWeight             float64          `json:"min_weight"`
+	MaxWeight             *float64         `json:"max_weight,omitempty"`
+	BaseRate              decimal.Decimal  `json:"base_rate"`
+	PerKgRate             decimal.Decimal  `json:"per_kg_rate"`
+	HandlingFee           decimal.Decimal  `json:"handling_fee"`
+	FreeShippingThreshold *decimal.Decimal `json:"free_shipping_threshold,omitempty"`
+	DimensionalDivisor    float64          `json:"dimensional_divisor"`
+	MinDeliveryDays       int              `json:"min_delivery_days"`
+	MaxDeliveryDays       int              `json:"max_delivery_days"`
+	IsActive              bool             `json:"is_active"`
+	CreatedAt             time.Time        `json:"created_at"`
+	UpdatedAt             time.Time        `json:"updated_at"`
+}
+
+// ShippingZoneResponse represents a shipping zone with its rates in responses
+type ShippingZoneResponse struct {
+	ID                 uuid.UUID              `json:"id"`
+	Code               string                 `json:"code"`
+	Name               string                 `json:"name"`
+	Country            string                 `json:"country"`
+	State              *string                `json:"state,omitempty"`
+	PostalCodePrefixes []string               `json:"postal_code_prefixes"`
+	IsActive           bool                   `json:"is_active"`
+	Rates              []ShippingRateResponse `json:"rates"`
+	CreatedAt          time.Time              `json:"created_at"`
+	UpdatedAt          time.Time              `json:"updated_at"`
+}
+
+// ListShippingZonesResponse represents a paginated list of shipping zones
+type ListShippingZonesResponse struct {
+	Zones      []*ShippingZoneResponse `json:"zones"`
+	Pagination *Pagination             `json:"pagination"`
+}
+
+// ShippingQuoteResponse represents the price and delivery estimate of a shipping method
+type ShippingQuoteResponse struct {
+	Method                string          `json:"method"`
+	RateID                uuid.UUID       `json:"rate_id"`
+	Name                  string          `json:"name"`
+	ZoneCode              string          `json:"zone_code"`
+	ActualWeight          float64         `json:"actual_weight"`
+	DimensionalWeight     float64         `json:"dimensional_weight"`
+	ChargeableWeight      float64         `json:"chargeable_weight"`
+	Amount                decimal.Decimal `json:"amount"`
+	FreeShipping          bool            `json:"free_shipping"`
+	MinDeliveryDays       int             `json:"min_delivery_days"`
+	MaxDeliveryDays       int             `json:"max_delivery_days"`
+	EstimatedDeliveryFrom time.Time       `json:"estimated_delivery_from"`
+	EstimatedDeliveryTo   time.Time       `json:"estimated_delivery_to"`
+}
@@ -0,0 +1,77 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Sourcing DTOs
+
+// CreateSourcingRuleRequest represents a request to create a sourcing rule
+type CreateSourcingRuleRequest struct {
+	Name            string   `json:"name" binding:"required,max=255"`
+	Description     *string  `json:"description,omitempty"`
+	Countries       []string `json:"countries,omitempty" binding:"omitempty,dive,required,max=100"`
+	ShippingMethods []string `json:"shipping_methods,omitempty" binding:"omitempty,dive,oneof=STANDARD EXPRESS OVERNIGHT INTERNATIONAL PICKUP DIGITAL"`
+	Strategies      []string `json:"strategies" binding:"required,min=1,dive,oneof=FEWEST_SHIPMENTS CLOSEST FULFILLMENT_FIRST AVOID_SPLIT"`
+	Priority        int      `json:"priority"`
+}
+
+// UpdateSourcingRuleRequest represents a request to update a sourcing rule
+type UpdateSourcingRuleRequest struct {
+	Name            *string  `json:"name,omitempty" binding:"omitempty,max=255"`
+	Description     *string  `json:"description,omitempty"`
+	Countries       []string `json:"countries,omitempty" binding:"omitempty,dive,required,max=100"`
+	ShippingMethods []string `json:"shipping_methods,omitempty" binding:"omitempty,dive,oneof=STANDARD EXPRESS OVERNIGHT INTERNATIONAL PICKUP DIGITAL"`
+	Strategies      []string `json:"strategies,omitempty" binding:"omitempty,min=1,dive,oneof=FEWEST_SHIPMENTS CLOSEST FULFILLMENT_FIRST AVOID_SPLIT"`
+	Priority        *int     `json:"priority,omitempty"`
+	IsActive        *bool    `json:"is_active,omitempty"`
+}
+
+// ListSourcingRulesRequest represents a request to list sourcing rules
+type ListSourcingRulesRequest struct {
+	Search   string `json:"search,omitempty" form:"search"`
+	IsActive *bool  `json:"is_active,omitempty" form:"is_active"`
+	Page     int    `json:"page" form:"page" binding:"omitempty,min=1"`
+	Limit    int    `json:"limit" form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// SourcingRuleResponse represents a sourcing rule in responses
+type SourcingRuleResponse struct {
+	ID              uuid.UUID `json:"id"`
+	Name            string    `json:"name"`
+	Description     *string   `json:"description,omitempty"`
+	Countries       []string  `json:"countries"`
+	ShippingMethods []string  `json:"shipping_methods"`
+	Strategies      []string  `json:"strategies"`
+	Priority        int       `json:"priority"`
+	IsActive        bool      `json:"is_active"`
+	CreatedBy       uuid.UUID `json:"created_by"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// ListSourcingRulesResponse represents a paginated list of sourcing rules
+type ListSourcingRulesResponse struct {
+	Rules      []*SourcingRuleResponse `json:"rules"`
+	Pagination *Pagination             `json:"pagination"`
+}
+
+// OrderAllocationResponse represents the quantity of an order line reserved in a warehouse
+type OrderAllocationResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	OrderItemID uuid.UUID  `json:"order_item_id"`
+	ProductID   uuid.UUID  `json:"product_id"`
+	WarehouseID uuid.UUID  `json:"warehouse_id"`
+	Quantity    int        `json:"quantity"`
+	RuleID      *uuid.UUID `json:"rule_id,omitempty"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// OrderAllocationsResponse represents the warehouse allocations of an order
+type OrderAllocationsResponse struct {
+	Allocations []OrderAllocationResponse `json:"allocations"`
+	// Shipments is the number of warehouses the order ships from
+	Shipments int `json:"shipments"`
+}
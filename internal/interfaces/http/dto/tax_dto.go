@@ -0,0 +1,125 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Tax DTOs
+
+// TaxRuleRequest represents a tax rule of a zone
+type TaxRuleRequest struct {
+	Name     string          `json:"name" binding:"required,max=100"`
+	Level    string          `json:"level" binding:"required,oneof=COUNTRY STATE COUNTY CITY VAT"`
+	TaxClass string          `json:"tax_class,omitempty" binding:"omitempty,max=50"`
+	Rate     decimal.Decimal `json:"rate" binding:"required"`
+}
+
+// CreateTaxZoneRequest represents a request to create a tax zone
+type CreateTaxZoneRequest struct {
+	Code               string           `json:"code" binding:"required,max=50"`
+	Name               string           `json:"name" binding:"required,max=255"`
+	Country            string           `json:"country" binding:"required,len=2"`
+	State              *string          `json:"state,omitempty" binding:"omitempty,max=100"`
+	PostalCodePrefixes []string         `json:"postal_code_prefixes,omitempty"`
+	PricesIncludeTax   bool             `json:"prices_include_tax"`
+	Rules              []TaxRuleRequest `json:"rules,omitempty" binding:"omitempty,dive"`
+}
+
+// UpdateTaxZoneRequest represents a request to update a tax zone
+type UpdateTaxZoneRequest struct {
+	Name               *string  `json:"name,omitempty" binding:"omitempty,max=255"`
+	State              *string  `json:"state,omitempty" binding:"omitempty,max=100"`
+	PostalCodePrefixes []string `json:"postal_code_prefixes,omitempty"`
+	PricesIncludeTax   *bool    `json:"prices_include_tax,omitempty"`
+	IsActive           *bool    `json:"is_active,omitempty"`
+}
+
+// UpdateTaxRuleRequest represents a request to update a tax rule
+type UpdateTaxRuleRequest struct {
+	Name     *string          `json:"name,omitempty" binding:"omitempty,max=100"`
+	Level    *string          `json:"level,omitempty" binding:"omitempty,oneof=COUNTRY STATE COUNTY CITY VAT"`
+	TaxClass *string          `json:"tax_class,omitempty" binding:"omitempty,max=50"`
+	Rate     *decimal.Decimal `json:"rate,omitempty"`
+	IsActive *bool            `json:"is_active,omitempty"`
+}
+
+// ListTaxZonesRequest represents a request to list tax zones
+type ListTaxZonesRequest struct {
+	Search   string  `json:"search,omitempty" form:"search"`
+	Country  string  `json:"country,omitempty" form:"country" binding:"omitempty,len=2"`
+	State    *string `json:"state,omitempty" form:"state"`
+	IsActive *bool   `json:"is_active,omitempty" form:"is_active"`
+	Page     int     `json:"page" form:"page" binding:"omitempty,min=1"`
+	Limit    int     `json:"limit" form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// TaxRuleResponse represents a tax rule in responses
+type TaxRuleResponse struct {
+	ID        uuid.UUID       `json:"id"`
+	Name      string          `json:"name"`
+	Level     string          `json:"level"`
+	TaxClass  string          `json:"tax_class"`
+	Rate      decimal.Decimal `json:"rate"`
+	IsActive  bool            `json:"is_active"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// TaxZoneResponse represents a tax zone with its rules in responses
+type TaxZoneResponse struct {
+	ID                 uuid.UUID         `json:"id"`
+	Code               string            `json:"code"`
+	Name               string            `json:"name"`
+	Country            string            `json:"country"`
+	State              *string           `json:"state,omitempty"`
+	PostalCodePrefixes []string          `json:"postal_code_prefixes"`
+	PricesIncludeTax   bool              `json:"prices_include_tax"`
+	IsActive           bool              `json:"is_active"`
+	Rules              []TaxRuleResponse `json:"rules"`
+	CreatedAt          time.Time         `json:"created_at"`
+	UpdatedAt          time.Time         `json:"updated_at"`
+}
+
+// ListTaxZonesResponse represents a paginated list of tax zones
+type ListTaxZonesResponse struct {
+	Zones      []*TaxZoneResponse `json:"zones"`
+	Pagination *Pagination        `json:"pagination"`
+}
+
+// CreateTaxExemptionRequest represents a request to record a customer's exemption certificate
+type CreateTaxExemptionRequest struct {
+	CustomerID        uuid.UUID  `json:"customer_id" binding:"required"`
+	CertificateNumber string     `json:"certificate_number" binding:"required,max=100"`
+	Country           string     `json:"country" binding:"required,len=2"`
+	State             *string    `json:"state,omitempty" binding:"omitempty,max=100"`
+	ValidFrom         *time.Time `json:"valid_from,omitempty"`
+	ValidUntil        *time.Time `json:"valid_until,omitempty"`
+	Reason            *string    `json:"reason,omitempty" binding:"omitempty,max=500"`
+}
+
+// RevokeTaxExemptionRequest represents a request to revoke an exemption certificate
+type RevokeTaxExemptionRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+// TaxExemptionResponse represents a tax exemption certificate in responses
+type TaxExemptionResponse struct {
+	ID                uuid.UUID  `json:"id"`
+	CustomerID        uuid.UUID  `json:"customer_id"`
+	CertificateNumber string     `json:"certificate_number"`
+	Country           string     `json:"country"`
+	State             *string    `json:"state,omitempty"`
+	ValidFrom         time.Time  `json:"valid_from"`
+	ValidUntil        *time.Time `json:"valid_until,omitempty"`
+	Reason            *string    `json:"reason,omitempty"`
+	Revoked           bool       `json:"revoked"`
+	RevokedAt         *time.Time `json:"revoked_at,omitempty"`
+	RevokedBy         *uuid.UUID `json:"revoked_by,omitempty"`
+	RevokeReason      *string    `json:"revoke_reason,omitempty"`
+	CreatedBy         uuid.UUID  `json:"created_by"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
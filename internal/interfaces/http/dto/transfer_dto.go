@@ -0,0 +1,159 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Transfer order DTOs
+
+// TransferOrderItemRequest represents a transfer order line in requests
+type TransferOrderItemRequest struct {
+	ProductID uuid.UUID `json:"product_id" binding:"required"`
+	Quantity  int       `json:"quantity" binding:"required,min=1"`
+	Notes     *string   `json:"notes,omitempty"`
+}
+
+// TransferOrderRequest represents a request to create a transfer order
+type TransferOrderRequest struct {
+	FromWarehouseID uuid.UUID                  `json:"from_warehouse_id" binding:"required"`
+	ToWarehouseID   uuid.UUID                  `json:"to_warehouse_id" binding:"required"`
+	ExpectedDate    *time.Time                 `json:"expected_date,omitempty"`
+	Notes           *string                    `json:"notes,omitempty"`
+	Items           []TransferOrderItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+// ShipTransferOrderRequest represents a request to ship a transfer order
+type ShipTransferOrderRequest struct {
+	Carrier        *string    `json:"carrier,omitempty" binding:"omitempty,max=100"`
+	TrackingNumber *string    `json:"tracking_number,omitempty" binding:"omitempty,max=100"`
+	ExpectedDate   *time.Time `json:"expected_date,omitempty"`
+}
+
+// CancelTransferOrderRequest represents a request to cancel a transfer order
+type CancelTransferOrderRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+// ListTransferOrdersRequest represents a request to list transfer orders
+type ListTransferOrdersRequest struct {
+	FromWarehouseID *string `json:"from_warehouse_id,omitempty" form:"from_warehouse_id" binding:"omitempty,uuid"`
+	ToWarehouseID   *string `json:"to_warehouse_id,omitempty" form:"to_warehouse_id" binding:"omitempty,uuid"`
+	Status          *string `json:"status,omitempty" form:"status"`
+	Search          *string `json:"search,omitempty" form:"search"`
+	Page            int     `json:"page" form:"page" binding:"omitempty,min=1"`
+	Limit           int     `json:"limit" form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// TransferOrderItemResponse represents a transfer order line in responses
+type TransferOrderItemResponse struct {
+	ID                uuid.UUID `json:"id"`
+	ProductID         uuid.UUID `json:"product_id"`
+	ProductSKU        string    `json:"product_sku"`
+	ProductName       string    `json:"product_name"`
+	QuantityRequested int       `json:"quantity_requested"`
+	QuantityShipped   int       `json:"quantity_shipped"`
+	QuantityReceived  int       `json:"quantity_received"`
+	QuantityDamaged   int       `json:"quantity_damaged"`
+	QuantityShort     int       `json:"quantity_short"`
+	QuantityInTransit int       `json:"quantity_in_transit"`
+	UnitCost          float64   `json:"unit_cost"`
+	Notes             *string   `json:"notes,omitempty"`
+}
+
+// TransferOrderResponse represents a transfer order in responses
+type TransferOrderResponse struct {
+	ID                 uuid.UUID                   `json:"id"`
+	TransferNumber     string                      `json:"transfer_number"`
+	FromWarehouseID    uuid.UUID                   `json:"from_warehouse_id"`
+	ToWarehouseID      uuid.UUID                   `json:"to_warehouse_id"`
+	Status             string                      `json:"status"`
+	ExpectedDate       *time.Time                  `json:"expected_date,omitempty"`
+	Carrier            *string                     `json:"carrier,omitempty"`
+	TrackingNumber     *string                     `json:"tracking_number,omitempty"`
+	HasDiscrepancies   bool                        `json:"has_discrepancies"`
+	Items              []TransferOrderItemResponse `json:"items"`
+	Notes              *string                     `json:"notes,omitempty"`
+	CancellationReason *string                     `json:"cancellation_reason,omitempty"`
+	CreatedBy          uuid.UUID                   `json:"created_by"`
+	CreatedAt          time.Time                   `json:"created_at"`
+	UpdatedAt          time.Time                   `json:"updated_at"`
+	ShippedBy          *uuid.UUID                  `json:"shipped_by,omitempty"`
+	ShippedAt          *time.Time                  `json:"shipped_at,omitempty"`
+	ReceivedAt         *time.Time                  `json:"received_at,omitempty"`
+	CancelledAt        *time.Time                  `json:"cancelled_at,omitempty"`
+}
+
+// ListTransferOrdersResponse represents a paginated list of transfer orders
+type ListTransferOrdersResponse struct {
+	TransferOrders []*TransferOrderResponse `json:"transfer_orders"`
+	Pagination     *Pagination              `json:"pagination"`
+}
+
+// TransferReceiptItemRequest represents what arrived for one transfer order
+// line. Damaged and short quantities require a discrepancy reason.
+type TransferReceiptItemRequest struct {
+	TransferOrderItemID uuid.UUID `json:"transfer_order_item_id" binding:"required"`
+	QuantityReceived    int       `json:"quantity_received" binding:"min=0"`
+	QuantityDamaged     int       `json:"quantity_damaged" binding:"min=0"`
+	QuantityShort       int       `json:"quantity_short" binding:"min=0"`
+	DiscrepancyReason   *string   `json:"discrepancy_reason,omitempty" binding:"omitempty,max=500"`
+}
+
+// TransferReceiptRequest represents a request to receive stock against a
+// transfer order. With close_short, whatever is still in transit after the
+// receipt is recorded as short with short_reason.
+type TransferReceiptRequest struct {
+	Notes       *string                      `json:"notes,omitempty"`
+	Items       []TransferReceiptItemRequest `json:"items,omitempty" binding:"omitempty,dive"`
+	CloseShort  bool                         `json:"close_short"`
+	ShortReason string                       `json:"short_reason,omitempty" binding:"omitempty,max=500"`
+}
+
+// TransferReceiptItemResponse represents a received transfer order line in responses
+type TransferReceiptItemResponse struct {
+	ID                     uuid.UUID  `json:"id"`
+	TransferOrderItemID    uuid.UUID  `json:"transfer_order_item_id"`
+	ProductID              uuid.UUID  `json:"product_id"`
+	QuantityReceived       int        `json:"quantity_received"`
+	QuantityDamaged        int        `json:"quantity_damaged"`
+	QuantityShort          int        `json:"quantity_short"`
+	DiscrepancyReason      *string    `json:"discrepancy_reason,omitempty"`
+	InventoryTransactionID *uuid.UUID `json:"inventory_transaction_id,omitempty"`
+}
+
+// TransferReceiptResponse represents a transfer receipt in responses
+type TransferReceiptResponse struct {
+	ID              uuid.UUID                     `json:"id"`
+	TransferOrderID uuid.UUID                     `json:"transfer_order_id"`
+	WarehouseID     uuid.UUID                     `json:"warehouse_id"`
+	Notes           *string                       `json:"notes,omitempty"`
+	Items           []TransferReceiptItemResponse `json:"items"`
+	ReceivedBy      uuid.UUID                     `json:"received_by"`
+	ReceivedAt      time.Time                     `json:"received_at"`
+}
+
+// ReceiveTransferOrderResponse represents a recorded transfer receipt with the updated transfer order
+type ReceiveTransferOrderResponse struct {
+	Receipt       *TransferReceiptResponse `json:"receipt"`
+	TransferOrder *TransferOrderResponse   `json:"transfer_order"`
+}
+
+// InTransitStockRequest represents a request for the in-transit stock report
+type InTransitStockRequest struct {
+	ProductID       *string `json:"product_id,omitempty" form:"product_id" binding:"omitempty,uuid"`
+	FromWarehouseID *string `json:"from_warehouse_id,omitempty" form:"from_warehouse_id" binding:"omitempty,uuid"`
+	ToWarehouseID   *string `json:"to_warehouse_id,omitempty" form:"to_warehouse_id" binding:"omitempty,uuid"`
+}
+
+// InTransitStockResponse represents stock of a product in transit between two warehouses
+type InTransitStockResponse struct {
+	ProductID       uuid.UUID `json:"product_id"`
+	ProductSKU      string    `json:"product_sku"`
+	ProductName     string    `json:"product_name"`
+	FromWarehouseID uuid.UUID `json:"from_warehouse_id"`
+	ToWarehouseID   uuid.UUID `json:"to_warehouse_id"`
+	Quantity        int       `json:"quantity"`
+	TotalValue      float64   `json:"total_value"`
+}
@@ -0,0 +1,243 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"erpgo/internal/application/services/order"
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/interfaces/http/dto"
+	"erpgo/pkg/auth"
+)
+
+// ApprovalPolicyHandler handles approval policy HTTP requests. Orders are
+// approved and rejected through the order endpoints.
+type ApprovalPolicyHandler struct {
+	policyService order.ApprovalPolicyService
+	logger        zerolog.Logger
+}
+
+// NewApprovalPolicyHandler creates a new approval policy handler
+func NewApprovalPolicyHandler(policyService order.ApprovalPolicyService, logger zerolog.Logger) *ApprovalPolicyHandler {
+	return &ApprovalPolicyHandler{
+		policyService: policyService,
+		logger:        logger,
+	}
+}
+
+// CreateApprovalPolicy creates an approval policy
+// @Summary Create approval policy
+// @Description Create a policy requiring orders that meet its amount, customer type, discount or margin conditions to be approved by its roles in sequence
+// @Tags approval-policies
+// @Accept json
+// @Produce json
+// @Param policy body dto.CreateApprovalPolicyRequest true "Approval policy"
+// @Success 201 {object} dto.ApprovalPolicyResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/approval-policies [post]
+func (h *ApprovalPolicyHandler) CreateApprovalPolicy(c *gin.Context) {
+	var req dto.CreateApprovalPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid approval policy request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := auth.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "User not authenticated",
+			Details: "Authentication required",
+		})
+		return
+	}
+
+	policy, err := h.policyService.CreateApprovalPolicy(c, &order.CreateApprovalPolicyRequest{
+		Name:               req.Name,
+		Description:        req.Description,
+		MinAmount:          req.MinAmount,
+		CustomerTypes:      req.CustomerTypes,
+		MinDiscountPercent: req.MinDiscountPercent,
+		MaxMarginPercent:   req.MaxMarginPercent,
+		ApproverRoles:      req.ApproverRoles,
+		Priority:           req.Priority,
+		CreatedBy:          userID.String(),
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("name", req.Name).Msg("Failed to create approval policy")
+		handleOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, approvalPolicyToResponse(policy))
+}
+
+// GetApprovalPolicy retrieves an approval policy by ID
+// @Summary Get approval policy
+// @Description Get an approval policy with its conditions and approver roles
+// @Tags approval-policies
+// @Produce json
+// @Param id path string true "Approval policy ID"
+// @Success 200 {object} dto.ApprovalPolicyResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/approval-policies/{id} [get]
+func (h *ApprovalPolicyHandler) GetApprovalPolicy(c *gin.Context) {
+	id := c.Param("id")
+
+	policy, err := h.policyService.GetApprovalPolicy(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("policy_id", id).Msg("Failed to get approval policy")
+		handleOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, approvalPolicyToResponse(policy))
+}
+
+// UpdateApprovalPolicy updates an approval policy
+// @Summary Update approval policy
+// @Description Update the conditions, approver roles, priority or status of an approval policy. Pending orders pick up the change when they are next edited or approved.
+// @Tags approval-policies
+// @Accept json
+// @Produce json
+// @Param id path string true "Approval policy ID"
+// @Param policy body dto.UpdateApprovalPolicyRequest true "Approval policy changes"
+// @Success 200 {object} dto.ApprovalPolicyResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/approval-policies/{id} [put]
+func (h *ApprovalPolicyHandler) UpdateApprovalPolicy(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.UpdateApprovalPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid approval policy update request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	policy, err := h.policyService.UpdateApprovalPolicy(c, id, &order.UpdateApprovalPolicyRequest{
+		Name:               req.Name,
+		Description:        req.Description,
+		MinAmount:          req.MinAmount,
+		CustomerTypes:      req.CustomerTypes,
+		MinDiscountPercent: req.MinDiscountPercent,
+		MaxMarginPercent:   req.MaxMarginPercent,
+		ApproverRoles:      req.ApproverRoles,
+		Priority:           req.Priority,
+		IsActive:           req.IsActive,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("policy_id", id).Msg("Failed to update approval policy")
+		handleOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, approvalPolicyToResponse(policy))
+}
+
+// ListApprovalPolicies lists approval policies
+// @Summary List approval policies
+// @Description List approval policies, highest priority first
+// @Tags approval-policies
+// @Produce json
+// @Param search query string false "Name"
+// @Param is_active query bool false "Active policies only"
+// @Param page query int false "Page number"
+// @Param limit query int false "Page size"
+// @Success 200 {object} dto.ListApprovalPoliciesResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/approval-policies [get]
+func (h *ApprovalPolicyHandler) ListApprovalPolicies(c *gin.Context) {
+	var req dto.ListApprovalPoliciesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid approval policy list request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid query parameters",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	result, err := h.policyService.ListApprovalPolicies(c, &order.ListApprovalPoliciesRequest{
+		Search:   req.Search,
+		IsActive: req.IsActive,
+		Page:     req.Page,
+		Limit:    req.Limit,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to list approval policies")
+		handleOrderError(c, err)
+		return
+	}
+
+	policies := make([]*dto.ApprovalPolicyResponse, len(result.Policies))
+	for i, policy := range result.Policies {
+		policies[i] = approvalPolicyToResponse(policy)
+	}
+
+	c.JSON(http.StatusOK, &dto.ListApprovalPoliciesResponse{
+		Policies: policies,
+		Pagination: &dto.Pagination{
+			Page:       result.Pagination.Page,
+			Limit:      result.Pagination.Limit,
+			Total:      result.Pagination.Total,
+			TotalPages: result.Pagination.TotalPages,
+			HasNext:    result.Pagination.HasNext,
+			HasPrev:    result.Pagination.HasPrev,
+		},
+	})
+}
+
+// approvalPolicyToResponse converts an approval policy entity to a response DTO
+func approvalPolicyToResponse(policy *entities.ApprovalPolicy) *dto.ApprovalPolicyResponse {
+	return &dto.ApprovalPolicyResponse{
+		ID:                 policy.ID,
+		Name:               policy.Name,
+		Description:        policy.Description,
+		MinAmount:          policy.MinAmount,
+		CustomerTypes:      policy.CustomerTypes,
+		MinDiscountPercent: policy.MinDiscountPercent,
+		MaxMarginPercent:   policy.MaxMarginPercent,
+		ApproverRoles:      policy.ApproverRoles,
+		Priority:           policy.Priority,
+		IsActive:           policy.IsActive,
+		CreatedBy:          policy.CreatedBy,
+		CreatedAt:          policy.CreatedAt,
+		UpdatedAt:          policy.UpdatedAt,
+	}
+}
+
+// orderApprovalToResponse converts an order approval step to a response DTO
+func orderApprovalToResponse(approval *entities.OrderApproval) dto.OrderApprovalResponse {
+	return dto.OrderApprovalResponse{
+		ID:           approval.ID,
+		OrderID:      approval.OrderID,
+		PolicyID:     approval.PolicyID,
+		PolicyName:   approval.PolicyName,
+		Sequence:     approval.Sequence,
+		Role:         approval.Role,
+		Status:       string(approval.Status),
+		Amount:       approval.Amount,
+		DecidedBy:    approval.DecidedBy,
+		DecidedAt:    approval.DecidedAt,
+		Comment:      approval.Comment,
+		SupersededAt: approval.SupersededAt,
+		CreatedAt:    approval.CreatedAt,
+	}
+}
@@ -0,0 +1,233 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"erpgo/internal/application/services/order"
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/interfaces/http/dto"
+)
+
+// BackorderHandler handles backorder HTTP requests
+type BackorderHandler struct {
+	backorderService order.BackorderService
+	logger           zerolog.Logger
+}
+
+// NewBackorderHandler creates a new backorder handler
+func NewBackorderHandler(backorderService order.BackorderService, logger zerolog.Logger) *BackorderHandler {
+	return &BackorderHandler{
+		backorderService: backorderService,
+		logger:           logger,
+	}
+}
+
+// GetBackorder retrieves a backorder by ID
+// @Summary Get backorder
+// @Description Get a backorder by ID
+// @Tags backorders
+// @Produce json
+// @Param id path string true "Backorder ID"
+// @Success 200 {object} dto.BackorderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/backorders/{id} [get]
+func (h *BackorderHandler) GetBackorder(c *gin.Context) {
+	id := c.Param("id")
+
+	backorder, err := h.backorderService.GetBackorder(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("backorder_id", id).Msg("Failed to get backorder")
+		handleBackorderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, backorderToResponse(backorder))
+}
+
+// GetOrderBackorders retrieves the backorders of an order
+// @Summary Get backorders of order
+// @Description Get every backorder raised for the lines of an order, oldest first
+// @Tags backorders
+// @Produce json
+// @Param order_id path string true "Order ID"
+// @Success 200 {array} dto.BackorderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/backorders/order/{order_id} [get]
+func (h *BackorderHandler) GetOrderBackorders(c *gin.Context) {
+	orderID := c.Param("order_id")
+
+	backorders, err := h.backorderService.GetOrderBackorders(c, orderID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("order_id", orderID).Msg("Failed to get backorders of order")
+		handleBackorderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, backordersToResponse(backorders))
+}
+
+// GetBackorderQueue retrieves the backorder queue of a product
+// @Summary Get backorder queue
+// @Description Get the open backorders of a product in allocation order: highest order priority first, then oldest order
+// @Tags backorders
+// @Produce json
+// @Param product_id path string true "Product ID"
+// @Success 200 {array} dto.BackorderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/backorders/queue/{product_id} [get]
+func (h *BackorderHandler) GetBackorderQueue(c *gin.Context) {
+	productID := c.Param("product_id")
+
+	backorders, err := h.backorderService.GetBackorderQueue(c, productID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("product_id", productID).Msg("Failed to get backorder queue")
+		handleBackorderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, backordersToResponse(backorders))
+}
+
+// ListBackorders lists backorders
+// @Summary List backorders
+// @Description List backorders with filtering and pagination
+// @Tags backorders
+// @Produce json
+// @Param product_id query string false "Product ID"
+// @Param order_id query string false "Order ID"
+// @Param status query string false "Backorder status"
+// @Param page query int false "Page number"
+// @Param limit query int false "Page size"
+// @Success 200 {object} dto.ListBackordersResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/backorders [get]
+func (h *BackorderHandler) ListBackorders(c *gin.Context) {
+	var req dto.ListBackordersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid backorder list request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid query parameters",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	serviceReq := &order.ListBackordersRequest{
+		ProductID: req.ProductID,
+		OrderID:   req.OrderID,
+		Page:      req.Page,
+		Limit:     req.Limit,
+	}
+	if req.Status != nil {
+		serviceReq.Status = []entities.BackorderStatus{entities.BackorderStatus(*req.Status)}
+	}
+
+	result, err := h.backorderService.ListBackorders(c, serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to list backorders")
+		handleBackorderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, &dto.ListBackordersResponse{
+		Backorders: backordersToResponse(result.Backorders),
+		Pagination: &dto.Pagination{
+			Page:       result.Pagination.Page,
+			Limit:      result.Pagination.Limit,
+			Total:      result.Pagination.Total,
+			TotalPages: result.Pagination.TotalPages,
+			HasNext:    result.Pagination.HasNext,
+			HasPrev:    result.Pagination.HasPrev,
+		},
+	})
+}
+
+// AllocateBackorders allocates available warehouse stock to a product's backorders
+// @Summary Allocate backorders
+// @Description Reserve the available stock of a warehouse for the product's backorder queue. Stock added by goods receipts and positive adjustments is allocated automatically.
+// @Tags backorders
+// @Accept json
+// @Produce json
+// @Param allocation body dto.AllocateBackordersRequest true "Product and warehouse"
+// @Success 200 {array} dto.BackorderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/backorders/allocate [post]
+func (h *BackorderHandler) AllocateBackorders(c *gin.Context) {
+	var req dto.AllocateBackordersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid backorder allocation request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	backorders, err := h.backorderService.AllocateStock(c, &order.AllocateBackordersRequest{
+		ProductID:   req.ProductID.String(),
+		WarehouseID: req.WarehouseID.String(),
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("product_id", req.ProductID.String()).Msg("Failed to allocate backorders")
+		handleBackorderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, backordersToResponse(backorders))
+}
+
+// handleBackorderError maps backorder service errors to HTTP responses
+func handleBackorderError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, order.ErrBackorderNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:   "Resource not found",
+			Details: err.Error(),
+		})
+	default:
+		handleOrderError(c, err)
+	}
+}
+
+// backordersToResponse converts backorder entities to response DTOs
+func backordersToResponse(backorders []*entities.Backorder) []*dto.BackorderResponse {
+	response := make([]*dto.BackorderResponse, len(backorders))
+	for i, backorder := range backorders {
+		response[i] = backorderToResponse(backorder)
+	}
+	return response
+}
+
+// backorderToResponse converts a backorder entity to a response DTO
+func backorderToResponse(b *entities.Backorder) *dto.BackorderResponse {
+	return &dto.BackorderResponse{
+		ID:                b.ID,
+		OrderID:           b.OrderID,
+		OrderNumber:       b.OrderNumber,
+		OrderItemID:       b.OrderItemID,
+		ProductID:         b.ProductID,
+		ProductSKU:        b.ProductSKU,
+		Quantity:          b.Quantity,
+		QuantityAllocated: b.QuantityAllocated,
+		QuantityRemaining: b.Remaining(),
+		Status:            string(b.Status),
+		Priority:          string(b.Priority),
+		OrderDate:         b.OrderDate,
+		CreatedAt:         b.CreatedAt,
+		UpdatedAt:         b.UpdatedAt,
+		AllocatedAt:       b.AllocatedAt,
+		CancelledAt:       b.CancelledAt,
+	}
+}
@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"erpgo/internal/application/services/order"
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/interfaces/http/dto"
+	"erpgo/pkg/auth"
+)
+
+// DropShipHandler handles supplier shipment confirmations of drop-ship purchase orders
+type DropShipHandler struct {
+	orderService order.Service
+	logger       zerolog.Logger
+}
+
+// NewDropShipHandler creates a new drop-ship handler
+func NewDropShipHandler(orderService order.Service, logger zerolog.Logger) *DropShipHandler {
+	return &DropShipHandler{
+		orderService: orderService,
+		logger:       logger,
+	}
+}
+
+// ConfirmDropShipment books a supplier's shipment of a drop-ship purchase order
+// @Summary Confirm drop-ship shipment
+// @Description Record that the supplier shipped lines of a drop-ship purchase order to the customer. The sales order lines the purchase order was placed for ship in one shipment without moving warehouse stock. Without items every outstanding line has shipped.
+// @Tags drop-ship
+// @Accept json
+// @Produce json
+// @Param id path string true "Purchase order ID"
+// @Param shipment body dto.ConfirmDropShipmentRequest true "Shipped lines"
+// @Success 201 {object} dto.DropShipmentResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/drop-ship/purchase-orders/{id}/shipments [post]
+func (h *DropShipHandler) ConfirmDropShipment(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.ConfirmDropShipmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid drop-ship shipment request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	items := make([]order.DropShipmentItemRequest, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = order.DropShipmentItemRequest{
+			PurchaseOrderItemID: item.PurchaseOrderItemID.String(),
+			Quantity:            item.Quantity,
+		}
+	}
+
+	shipment, err := h.orderService.ConfirmDropShipment(c, id, &order.ConfirmDropShipmentRequest{
+		Items:          items,
+		TrackingNumber: req.TrackingNumber,
+		Carrier:        req.Carrier,
+		ShippingDate:   req.ShippingDate,
+		ConfirmedBy:    userID,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("purchase_order_id", id).Msg("Failed to confirm drop-ship shipment")
+		handleOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, &dto.DropShipmentResponse{
+		PurchaseOrder: purchaseOrderToResponse(shipment.PurchaseOrder),
+		OrderID:       shipment.Order.ID,
+		OrderNumber:   shipment.Order.OrderNumber,
+		OrderStatus:   string(shipment.Order.Status),
+		Shipment:      shipmentToResponse(shipment.Shipment),
+	})
+}
+
+// ImportDropShipConfirmations imports a supplier's shipment confirmation file
+// @Summary Import drop-ship confirmations
+// @Description Import a CSV file with a header row, or a JSON array of records, with one row per shipped line: po_number, sku and quantity, with optional tracking_number, carrier and shipped_at. Rows of a purchase order sharing a tracking number ship as one shipment. The file is validated before anything is booked; when any row has errors no shipment is booked and the errors are reported per row.
+// @Tags drop-ship
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "Confirmation file"
+// @Param format formData string false "CSV or JSON; defaults from the file extension"
+// @Success 200 {object} dto.ImportDropShipConfirmationsResponse "Rows with errors"
+// @Success 201 {object} dto.ImportDropShipConfirmationsResponse "Shipments booked"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/drop-ship/confirmations [post]
+func (h *DropShipHandler) ImportDropShipConfirmations(c *gin.Context) {
+	file, err := c.FormFile("file")
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to get drop-ship confirmation file")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "No file uploaded",
+			Details: "Please select a confirmation file to import",
+		})
+		return
+	}
+	if file.Size > maxOrderImportFileSize {
+		c.JSON(http.StatusRequestEntityTooLarge, dto.ErrorResponse{
+			Error:   "File too large",
+			Details: "Confirmation files are limited to 20MB",
+		})
+		return
+	}
+
+	format := entities.OrderImportFormat(strings.ToUpper(strings.TrimSpace(c.PostForm("format"))))
+	if format == "" {
+		format = entities.OrderImportFormatCSV
+		if strings.EqualFold(filepath.Ext(file.Filename), ".json") {
+			format = entities.OrderImportFormatJSON
+		}
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	content, err := file.Open()
+	if err != nil {
+		h.logger.Error().Err(err).Str("filename", file.Filename).Msg("Failed to open drop-ship confirmation file")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Failed to read file",
+			Details: err.Error(),
+		})
+		return
+	}
+	defer content.Close()
+
+	result, err := h.orderService.ImportDropShipConfirmations(c, &order.ImportDropShipConfirmationsRequest{
+		File:       content,
+		Format:     format,
+		ImportedBy: userID,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("filename", file.Filename).Msg("Failed to import drop-ship confirmations")
+		handleOrderImportError(c, err)
+		return
+	}
+
+	status := http.StatusOK
+	if result.Committed {
+		status = http.StatusCreated
+	}
+	c.JSON(status, dropShipImportToResponse(result))
+}
+
+// currentUser returns the authenticated user's ID, responding 401 when absent
+func (h *DropShipHandler) currentUser(c *gin.Context) (string, bool) {
+	userID, exists := auth.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "User not authenticated",
+			Details: "Authentication required",
+		})
+		return "", false
+	}
+	return userID.String(), true
+}
+
+// dropShipImportToResponse converts a confirmation import report to a response DTO
+func dropShipImportToResponse(result *order.ImportDropShipConfirmationsResponse) *dto.ImportDropShipConfirmationsResponse {
+	response := &dto.ImportDropShipConfirmationsResponse{
+		Committed: result.Committed,
+		TotalRows: result.TotalRows,
+		Shipments: make([]dto.ImportedDropShipmentResponse, len(result.Shipments)),
+		Errors:    make([]dto.DropShipConfirmationErrorResponse, len(result.Errors)),
+	}
+	for i, shipment := range result.Shipments {
+		response.Shipments[i] = dto.ImportedDropShipmentResponse{
+			PONumber:       shipment.PONumber,
+			OrderNumber:    shipment.OrderNumber,
+			TrackingNumber: shipment.TrackingNumber,
+			ShipmentNumber: shipment.ShipmentNumber,
+			Rows:           shipment.Rows,
+		}
+	}
+	for i, importErr := range result.Errors {
+		response.Errors[i] = dto.DropShipConfirmationErrorResponse{
+			Row:      importErr.Row,
+			PONumber: importErr.PONumber,
+			Field:    string(importErr.Field),
+			Message:  importErr.Message,
+		}
+	}
+	return response
+}
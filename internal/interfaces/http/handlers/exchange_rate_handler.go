@@ -0,0 +1,403 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"erpgo/internal/application/services/order"
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/interfaces/http/dto"
+	"erpgo/pkg/auth"
+)
+
+// maxExchangeRateFileSize bounds uploaded exchange rate files; the full ECB
+// history is well below it
+const maxExchangeRateFileSize = 10 << 20
+
+// ExchangeRateHandler handles exchange rate HTTP requests
+type ExchangeRateHandler struct {
+	exchangeRateService order.ExchangeRateService
+	logger              zerolog.Logger
+}
+
+// NewExchangeRateHandler creates a new exchange rate handler
+func NewExchangeRateHandler(exchangeRateService order.ExchangeRateService, logger zerolog.Logger) *ExchangeRateHandler {
+	return &ExchangeRateHandler{
+		exchangeRateService: exchangeRateService,
+		logger:              logger,
+	}
+}
+
+// CreateExchangeRate enters an exchange rate
+// @Summary Create exchange rate
+// @Description Enter the value of one unit of a currency in another from an effective date
+// @Tags exchange-rates
+// @Accept json
+// @Produce json
+// @Param rate body dto.CreateExchangeRateRequest true "Exchange rate"
+// @Success 201 {object} dto.ExchangeRateResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/exchange-rates [post]
+func (h *ExchangeRateHandler) CreateExchangeRate(c *gin.Context) {
+	var req dto.CreateExchangeRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid exchange rate request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	rate, err := h.exchangeRateService.CreateExchangeRate(c, &order.CreateExchangeRateRequest{
+		FromCurrency:  req.FromCurrency,
+		ToCurrency:    req.ToCurrency,
+		Rate:          req.Rate,
+		EffectiveDate: req.EffectiveDate,
+		CreatedBy:     userID,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("pair", req.FromCurrency+"/"+req.ToCurrency).Msg("Failed to create exchange rate")
+		handleExchangeRateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, exchangeRateToResponse(rate))
+}
+
+// GetExchangeRate retrieves an exchange rate by ID
+// @Summary Get exchange rate
+// @Description Get an exchange rate
+// @Tags exchange-rates
+// @Produce json
+// @Param id path string true "Exchange rate ID"
+// @Success 200 {object} dto.ExchangeRateResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/exchange-rates/{id} [get]
+func (h *ExchangeRateHandler) GetExchangeRate(c *gin.Context) {
+	id := c.Param("id")
+
+	rate, err := h.exchangeRateService.GetExchangeRate(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("exchange_rate_id", id).Msg("Failed to get exchange rate")
+		handleExchangeRateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, exchangeRateToResponse(rate))
+}
+
+// UpdateExchangeRate corrects an exchange rate
+// @Summary Update exchange rate
+// @Description Correct the rate or effective date of an exchange rate. Orders keep the rate they were booked at.
+// @Tags exchange-rates
+// @Accept json
+// @Produce json
+// @Param id path string true "Exchange rate ID"
+// @Param rate body dto.UpdateExchangeRateRequest true "Exchange rate changes"
+// @Success 200 {object} dto.ExchangeRateResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/exchange-rates/{id} [put]
+func (h *ExchangeRateHandler) UpdateExchangeRate(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.UpdateExchangeRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid exchange rate update request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	rate, err := h.exchangeRateService.UpdateExchangeRate(c, id, &order.UpdateExchangeRateRequest{
+		Rate:          req.Rate,
+		EffectiveDate: req.EffectiveDate,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("exchange_rate_id", id).Msg("Failed to update exchange rate")
+		handleExchangeRateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, exchangeRateToResponse(rate))
+}
+
+// DeleteExchangeRate deletes an exchange rate
+// @Summary Delete exchange rate
+// @Description Delete an exchange rate. Orders keep the rate they were booked at.
+// @Tags exchange-rates
+// @Produce json
+// @Param id path string true "Exchange rate ID"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/exchange-rates/{id} [delete]
+func (h *ExchangeRateHandler) DeleteExchangeRate(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := h.exchangeRateService.DeleteExchangeRate(c, id); err != nil {
+		h.logger.Error().Err(err).Str("exchange_rate_id", id).Msg("Failed to delete exchange rate")
+		handleExchangeRateError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListExchangeRates lists exchange rates
+// @Summary List exchange rates
+// @Description List exchange rates, latest first, with filtering and pagination
+// @Tags exchange-rates
+// @Produce json
+// @Param currency query string false "Currency on either side of the pair"
+// @Param source query string false "Source" Enums(MANUAL,CSV,ECB)
+// @Param start_date query string false "Effective from (YYYY-MM-DD)"
+// @Param end_date query string false "Effective until (YYYY-MM-DD)"
+// @Param page query int false "Page number"
+// @Param limit query int false "Page size"
+// @Success 200 {object} dto.ListExchangeRatesResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/exchange-rates [get]
+func (h *ExchangeRateHandler) ListExchangeRates(c *gin.Context) {
+	var req dto.ListExchangeRatesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid exchange rate list request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid query parameters",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	result, err := h.exchangeRateService.ListExchangeRates(c, &order.ListExchangeRatesRequest{
+		Currency:  req.Currency,
+		Source:    req.Source,
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+		Page:      req.Page,
+		Limit:     req.Limit,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to list exchange rates")
+		handleExchangeRateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, &dto.ListExchangeRatesResponse{
+		Rates: exchangeRatesToResponse(result.Rates),
+		Pagination: &dto.Pagination{
+			Page:       result.Pagination.Page,
+			Limit:      result.Pagination.Limit,
+			Total:      result.Pagination.Total,
+			TotalPages: result.Pagination.TotalPages,
+			HasNext:    result.Pagination.HasNext,
+			HasPrev:    result.Pagination.HasPrev,
+		},
+	})
+}
+
+// ImportExchangeRates imports an exchange rate file
+// @Summary Import exchange rates
+// @Description Import a CSV file with from_currency, to_currency, rate and effective_date columns, or a European Central Bank eurofxref XML file. Rates already entered for a pair and date are replaced.
+// @Tags exchange-rates
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "Exchange rate file"
+// @Param format formData string false "File format, by default from the file extension" Enums(CSV,ECB)
+// @Success 201 {object} dto.ImportExchangeRatesResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/exchange-rates/import [post]
+func (h *ExchangeRateHandler) ImportExchangeRates(c *gin.Context) {
+	file, err := c.FormFile("file")
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to get exchange rate file")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "No file uploaded",
+			Details: "Please select an exchange rate file to import",
+		})
+		return
+	}
+	if file.Size > maxExchangeRateFileSize {
+		c.JSON(http.StatusRequestEntityTooLarge, dto.ErrorResponse{
+			Error:   "File too large",
+			Details: "Exchange rate files are limited to 10MB",
+		})
+		return
+	}
+
+	format := strings.ToUpper(strings.TrimSpace(c.PostForm("format")))
+	if format == "" {
+		format = order.ExchangeRateFormatCSV
+		if strings.EqualFold(filepath.Ext(file.Filename), ".xml") {
+			format = order.ExchangeRateFormatECB
+		}
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	content, err := file.Open()
+	if err != nil {
+		h.logger.Error().Err(err).Str("filename", file.Filename).Msg("Failed to open exchange rate file")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Failed to read file",
+			Details: err.Error(),
+		})
+		return
+	}
+	defer content.Close()
+
+	result, err := h.exchangeRateService.ImportExchangeRates(c, &order.ImportExchangeRatesRequest{
+		Format:     format,
+		File:       content,
+		ImportedBy: userID,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("filename", file.Filename).Msg("Failed to import exchange rates")
+		handleExchangeRateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, &dto.ImportExchangeRatesResponse{
+		Imported:   result.Imported,
+		Currencies: result.Currencies,
+		Rates:      exchangeRatesToResponse(result.Rates),
+	})
+}
+
+// ConvertCurrency converts an amount between currencies
+// @Summary Convert currency
+// @Description Convert an amount at the exchange rate effective on a date, into the base currency unless another currency is given
+// @Tags exchange-rates
+// @Produce json
+// @Param amount query number false "Amount"
+// @Param from query string true "Currency of the amount"
+// @Param to query string false "Currency to convert into"
+// @Param date query string false "Rate date (YYYY-MM-DD), today by default"
+// @Success 200 {object} dto.CurrencyConversionResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 422 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/exchange-rates/convert [get]
+func (h *ExchangeRateHandler) ConvertCurrency(c *gin.Context) {
+	var req dto.ConvertCurrencyRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid currency conversion request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid query parameters",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	conversion, err := h.exchangeRateService.ConvertCurrency(c, &order.ConvertCurrencyRequest{
+		Amount:       req.Amount,
+		FromCurrency: req.FromCurrency,
+		ToCurrency:   req.ToCurrency,
+		Date:         req.Date,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("from", req.FromCurrency).Msg("Failed to convert currency")
+		handleExchangeRateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, &dto.CurrencyConversionResponse{
+		FromCurrency:    conversion.FromCurrency,
+		ToCurrency:      conversion.ToCurrency,
+		Rate:            conversion.Rate,
+		Date:            conversion.Date,
+		Amount:          conversion.Amount,
+		ConvertedAmount: conversion.ConvertedAmount,
+	})
+}
+
+// currentUser returns the authenticated user's ID, responding 401 when absent
+func (h *ExchangeRateHandler) currentUser(c *gin.Context) (string, bool) {
+	userID, exists := auth.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "User not authenticated",
+			Details: "Authentication required",
+		})
+		return "", false
+	}
+	return userID.String(), true
+}
+
+// handleExchangeRateError maps exchange rate service errors to HTTP responses
+func handleExchangeRateError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, order.ErrExchangeRateNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:   "Exchange rate not found",
+			Details: err.Error(),
+		})
+	case errors.Is(err, order.ErrExchangeRateExists):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error:   "Exchange rate conflict",
+			Details: err.Error(),
+		})
+	case errors.Is(err, order.ErrInvalidExchangeRateFile), errors.Is(err, order.ErrUnsupportedRateFormat):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid exchange rate file",
+			Details: err.Error(),
+		})
+	case errors.Is(err, order.ErrExchangeRateUnconfigured):
+		c.JSON(http.StatusServiceUnavailable, dto.ErrorResponse{
+			Error:   "Currency conversion unavailable",
+			Details: err.Error(),
+		})
+	default:
+		handleOrderError(c, err)
+	}
+}
+
+// exchangeRateToResponse converts an exchange rate entity to a response DTO
+func exchangeRateToResponse(rate *entities.ExchangeRate) *dto.ExchangeRateResponse {
+	return &dto.ExchangeRateResponse{
+		ID:            rate.ID,
+		FromCurrency:  rate.FromCurrency,
+		ToCurrency:    rate.ToCurrency,
+		Rate:          rate.Rate,
+		EffectiveDate: rate.EffectiveDate,
+		Source:        string(rate.Source),
+		CreatedBy:     rate.CreatedBy,
+		CreatedAt:     rate.CreatedAt,
+		UpdatedAt:     rate.UpdatedAt,
+	}
+}
+
+// exchangeRatesToResponse converts exchange rate entities to response DTOs
+func exchangeRatesToResponse(rates []*entities.ExchangeRate) []*dto.ExchangeRateResponse {
+	response := make([]*dto.ExchangeRateResponse, len(rates))
+	for i, rate := range rates {
+		response[i] = exchangeRateToResponse(rate)
+	}
+	return response
+}
@@ -0,0 +1,421 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"erpgo/internal/application/services/order"
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/interfaces/http/dto"
+	"erpgo/pkg/auth"
+)
+
+// InvoiceHandler handles invoice and credit note HTTP requests
+type InvoiceHandler struct {
+	invoiceService order.InvoiceService
+	logger         zerolog.Logger
+}
+
+// NewInvoiceHandler creates a new invoice handler
+func NewInvoiceHandler(invoiceService order.InvoiceService, logger zerolog.Logger) *InvoiceHandler {
+	return &InvoiceHandler{
+		invoiceService: invoiceService,
+		logger:         logger,
+	}
+}
+
+// GenerateInvoice generates an invoice from an order
+// @Summary Generate invoice
+// @Description Invoice the part of a confirmed or shipped order not invoiced yet, or the lines of one shipment. The invoice is a draft unless issue is set.
+// @Tags invoices
+// @Accept json
+// @Produce json
+// @Param invoice body dto.GenerateInvoiceRequest true "Order and optional shipment"
+// @Success 201 {object} dto.InvoiceResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/invoices [post]
+func (h *InvoiceHandler) GenerateInvoice(c *gin.Context) {
+	var req dto.GenerateInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid invoice request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	serviceReq := &order.GenerateInvoiceRequest{
+		OrderID:   req.OrderID.String(),
+		Notes:     req.Notes,
+		Issue:     req.Issue,
+		CreatedBy: userID,
+	}
+	if req.ShipmentID != nil {
+		shipmentID := req.ShipmentID.String()
+		serviceReq.ShipmentID = &shipmentID
+	}
+
+	invoice, err := h.invoiceService.GenerateInvoice(c, serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Str("order_id", req.OrderID.String()).Msg("Failed to generate invoice")
+		handleInvoiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, invoiceToResponse(invoice))
+}
+
+// GetInvoice retrieves an invoice by ID
+// @Summary Get invoice
+// @Description Get an invoice or credit note with its lines and tax breakdown
+// @Tags invoices
+// @Produce json
+// @Param id path string true "Invoice ID"
+// @Success 200 {object} dto.InvoiceResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/invoices/{id} [get]
+func (h *InvoiceHandler) GetInvoice(c *gin.Context) {
+	id := c.Param("id")
+
+	invoice, err := h.invoiceService.GetInvoice(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("invoice_id", id).Msg("Failed to get invoice")
+		handleInvoiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invoiceToResponse(invoice))
+}
+
+// GetInvoiceByNumber retrieves an invoice by its number
+// @Summary Get invoice by number
+// @Description Get an issued invoice or credit note by its number
+// @Tags invoices
+// @Produce json
+// @Param number path string true "Invoice number"
+// @Success 200 {object} dto.InvoiceResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/invoices/number/{number} [get]
+func (h *InvoiceHandler) GetInvoiceByNumber(c *gin.Context) {
+	number := c.Param("number")
+
+	invoice, err := h.invoiceService.GetInvoiceByNumber(c, number)
+	if err != nil {
+		h.logger.Error().Err(err).Str("invoice_number", number).Msg("Failed to get invoice")
+		handleInvoiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invoiceToResponse(invoice))
+}
+
+// GetOrderInvoices retrieves the invoices of an order
+// @Summary Get invoices of order
+// @Description Get the invoices and credit notes of an order, oldest first
+// @Tags invoices
+// @Produce json
+// @Param order_id path string true "Order ID"
+// @Success 200 {array} dto.InvoiceResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/invoices/order/{order_id} [get]
+func (h *InvoiceHandler) GetOrderInvoices(c *gin.Context) {
+	orderID := c.Param("order_id")
+
+	invoices, err := h.invoiceService.GetOrderInvoices(c, orderID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("order_id", orderID).Msg("Failed to get invoices of order")
+		handleInvoiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invoicesToResponse(invoices))
+}
+
+// ListInvoices lists invoices
+// @Summary List invoices
+// @Description List invoices and credit notes with filtering and pagination
+// @Tags invoices
+// @Produce json
+// @Param search query string false "Invoice number"
+// @Param type query string false "INVOICE or CREDIT_NOTE"
+// @Param status query string false "DRAFT, ISSUED or CANCELLED"
+// @Param customer_id query string false "Customer ID"
+// @Param order_id query string false "Order ID"
+// @Param issued_from query string false "Issued on or after (YYYY-MM-DD)"
+// @Param issued_to query string false "Issued on or before (YYYY-MM-DD)"
+// @Param page query int false "Page number"
+// @Param limit query int false "Page size"
+// @Success 200 {object} dto.ListInvoicesResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/invoices [get]
+func (h *InvoiceHandler) ListInvoices(c *gin.Context) {
+	var req dto.ListInvoicesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid invoice list request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid query parameters",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	serviceReq := &order.ListInvoicesRequest{
+		Search:     req.Search,
+		CustomerID: req.CustomerID,
+		OrderID:    req.OrderID,
+		IssuedFrom: req.IssuedFrom,
+		Page:       req.Page,
+		Limit:      req.Limit,
+	}
+	if req.IssuedTo != nil {
+		// Include the whole final day
+		issuedTo := req.IssuedTo.Add(24*time.Hour - time.Nanosecond)
+		serviceReq.IssuedTo = &issuedTo
+	}
+	if req.Type != nil {
+		invoiceType := entities.InvoiceType(*req.Type)
+		serviceReq.Type = &invoiceType
+	}
+	if req.Status != nil {
+		serviceReq.Status = []entities.InvoiceStatus{entities.InvoiceStatus(*req.Status)}
+	}
+
+	result, err := h.invoiceService.ListInvoices(c, serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to list invoices")
+		handleInvoiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, &dto.ListInvoicesResponse{
+		Invoices: invoicesToResponse(result.Invoices),
+		Pagination: &dto.Pagination{
+			Page:       result.Pagination.Page,
+			Limit:      result.Pagination.Limit,
+			Total:      result.Pagination.Total,
+			TotalPages: result.Pagination.TotalPages,
+			HasNext:    result.Pagination.HasNext,
+			HasPrev:    result.Pagination.HasPrev,
+		},
+	})
+}
+
+// IssueInvoice issues a draft invoice
+// @Summary Issue invoice
+// @Description Number and lock a draft invoice. The due date follows from the customer's payment terms.
+// @Tags invoices
+// @Accept json
+// @Produce json
+// @Param id path string true "Invoice ID"
+// @Param issue body dto.IssueInvoiceRequest false "Issue date"
+// @Success 200 {object} dto.InvoiceResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/invoices/{id}/issue [post]
+func (h *InvoiceHandler) IssueInvoice(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.IssueInvoiceRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.logger.Error().Err(err).Msg("Invalid invoice issue request")
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Invalid request body",
+				Details: err.Error(),
+			})
+			return
+		}
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	invoice, err := h.invoiceService.IssueInvoice(c, id, &order.IssueInvoiceRequest{
+		IssueDate: req.IssueDate,
+		IssuedBy:  userID,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("invoice_id", id).Msg("Failed to issue invoice")
+		handleInvoiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invoiceToResponse(invoice))
+}
+
+// CancelInvoice cancels a draft invoice
+// @Summary Cancel invoice
+// @Description Discard a draft invoice. Issued invoices are locked and are corrected with credit notes.
+// @Tags invoices
+// @Accept json
+// @Produce json
+// @Param id path string true "Invoice ID"
+// @Param cancellation body dto.CancelInvoiceRequest true "Cancellation reason"
+// @Success 200 {object} dto.InvoiceResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/invoices/{id}/cancel [post]
+func (h *InvoiceHandler) CancelInvoice(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.CancelInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid invoice cancellation request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	invoice, err := h.invoiceService.CancelInvoice(c, id, &order.CancelInvoiceRequest{
+		Reason:      req.Reason,
+		CancelledBy: userID,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("invoice_id", id).Msg("Failed to cancel invoice")
+		handleInvoiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invoiceToResponse(invoice))
+}
+
+// currentUser returns the authenticated user's ID, responding 401 when absent
+func (h *InvoiceHandler) currentUser(c *gin.Context) (string, bool) {
+	userID, exists := auth.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "User not authenticated",
+			Details: "Authentication required",
+		})
+		return "", false
+	}
+	return userID.String(), true
+}
+
+// handleInvoiceError maps invoice service errors to HTTP responses
+func handleInvoiceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, order.ErrInvoiceNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:   "Resource not found",
+			Details: err.Error(),
+		})
+	case errors.Is(err, order.ErrInvoiceLocked), errors.Is(err, order.ErrNothingToInvoice):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error:   "Invoice state conflict",
+			Details: err.Error(),
+		})
+	default:
+		handleOrderError(c, err)
+	}
+}
+
+// invoicesToResponse converts invoice entities to response DTOs
+func invoicesToResponse(invoices []*entities.Invoice) []*dto.InvoiceResponse {
+	response := make([]*dto.InvoiceResponse, len(invoices))
+	for i, invoice := range invoices {
+		response[i] = invoiceToResponse(invoice)
+	}
+	return response
+}
+
+// invoiceToResponse converts an invoice entity to a response DTO
+func invoiceToResponse(inv *entities.Invoice) *dto.InvoiceResponse {
+	lines := make([]dto.InvoiceLineResponse, len(inv.Lines))
+	for i, line := range inv.Lines {
+		lines[i] = dto.InvoiceLineResponse{
+			ID:             line.ID,
+			OrderItemID:    line.OrderItemID,
+			ProductID:      line.ProductID,
+			ProductSKU:     line.ProductSKU,
+			Description:    line.Description,
+			Quantity:       line.Quantity,
+			UnitPrice:      line.UnitPrice,
+			DiscountAmount: line.DiscountAmount,
+			TaxRate:        line.TaxRate,
+			TaxAmount:      line.TaxAmount,
+			TotalPrice:     line.TotalPrice,
+
+			PriceIncludesTax: line.PriceIncludesTax,
+		}
+	}
+
+	taxes := make([]dto.TaxBreakdownResponse, len(inv.TaxBreakdown))
+	for i, tax := range inv.TaxBreakdown {
+		taxes[i] = dto.TaxBreakdownResponse{
+			TaxName:       tax.TaxName,
+			Level:         string(tax.Level),
+			TaxRate:       tax.TaxRate,
+			TaxableAmount: tax.TaxableAmount,
+			TaxAmount:     tax.TaxAmount,
+		}
+	}
+
+	return &dto.InvoiceResponse{
+		ID:                 inv.ID,
+		InvoiceNumber:      inv.InvoiceNumber,
+		Type:               string(inv.Type),
+		Status:             string(inv.Status),
+		Locked:             inv.IsLocked(),
+		OrderID:            inv.OrderID,
+		ShipmentID:         inv.ShipmentID,
+		CreditedInvoiceID:  inv.CreditedInvoiceID,
+		CustomerID:         inv.CustomerID,
+		BillingAddressID:   inv.BillingAddressID,
+		Currency:           inv.Currency,
+		PaymentTerms:       inv.PaymentTerms,
+		IssueDate:          inv.IssueDate,
+		DueDate:            inv.DueDate,
+		Subtotal:           inv.Subtotal,
+		TaxAmount:          inv.TaxAmount,
+		ShippingAmount:     inv.ShippingAmount,
+		DiscountAmount:     inv.DiscountAmount,
+		TotalAmount:        inv.TotalAmount,
+		TaxBreakdown:       taxes,
+		Lines:              lines,
+		Notes:              inv.Notes,
+		Reason:             inv.Reason,
+		CancellationReason: inv.CancellationReason,
+		CreatedBy:          inv.CreatedBy,
+		IssuedBy:           inv.IssuedBy,
+		CreatedAt:          inv.CreatedAt,
+		UpdatedAt:          inv.UpdatedAt,
+		CancelledAt:        inv.CancelledAt,
+	}
+}
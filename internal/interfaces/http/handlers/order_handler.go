@@ -1,7 +1,12 @@
 package handlers
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -10,7 +15,9 @@ import (
 
 	"erpgo/internal/application/services/order"
 	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/domain/orders/repositories"
 	"erpgo/internal/interfaces/http/dto"
+	"erpgo/pkg/auth"
 )
 
 // OrderHandler handles order HTTP requests
@@ -54,6 +61,11 @@ func (h *OrderHandler) CreateOrder(c *gin.Context) {
 		return
 	}
 
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
 	// Convert to service request
 	serviceReq := &order.CreateOrderRequest{
 		CustomerID:        req.CustomerID.String(),
@@ -68,6 +80,7 @@ func (h *OrderHandler) CreateOrder(c *gin.Context) {
 		CustomerNotes:     req.CustomerNotes,
 		DiscountCode:      req.DiscountCode,
 		PaymentMethod:     req.PaymentMethod,
+		CreatedBy:         userID,
 	}
 
 	// Convert items
@@ -78,10 +91,11 @@ func (h *OrderHandler) CreateOrder(c *gin.Context) {
 			Quantity:  int(item.Quantity),
 			UnitPrice: item.UnitPrice,
 			Notes:     item.Notes,
+			DropShip:  item.DropShip,
 		}
 	}
 
-	createdOrder, err := h.orderService.CreateOrder(c, serviceReq)
+	createdOrder, err := h.orderService.CreateOrder(h.statusContext(c), serviceReq)
 	if err != nil {
 		h.logger.Error().Err(err).Msg("Failed to create order")
 		handleOrderError(c, err)
@@ -158,7 +172,7 @@ func (h *OrderHandler) GetOrderByNumber(c *gin.Context) {
 
 // UpdateOrder updates an order
 // @Summary Update order
-// @Description Update an existing order
+// @Description Update a draft or pending order. Confirmed orders are changed through amendments.
 // @Tags orders
 // @Accept json
 // @Produce json
@@ -215,7 +229,7 @@ func (h *OrderHandler) UpdateOrder(c *gin.Context) {
 
 // DeleteOrder deletes an order
 // @Summary Delete order
-// @Description Delete an order
+// @Description Withdraw a draft or pending order. Orders keep their number and history, so the order is cancelled rather than removed.
 // @Tags orders
 // @Accept json
 // @Produce json
@@ -236,7 +250,7 @@ func (h *OrderHandler) DeleteOrder(c *gin.Context) {
 		return
 	}
 
-	err := h.orderService.DeleteOrder(c, id)
+	err := h.orderService.DeleteOrder(h.statusContext(c), id)
 	if err != nil {
 		h.logger.Error().Err(err).Str("order_id", id).Msg("Failed to delete order")
 		handleOrderError(c, err)
@@ -294,13 +308,28 @@ func (h *OrderHandler) ListOrders(c *gin.Context) {
 
 	// Convert to service request
 	serviceReq := &order.ListOrdersRequest{
-		CustomerID: uuidPtrToPtrString(req.CustomerID),
+		CustomerID: req.CustomerID,
 		Search:     ptrStringToString(req.Search),
+		Currency:   ptrStringToString(req.Currency),
+		StartDate:  req.CreatedAfter,
+		EndDate:    req.CreatedBefore,
 		Page:       req.Page,
 		Limit:      req.Limit,
 		SortBy:     ptrStringToString(req.SortBy),
 		SortOrder:  ptrStringToString(req.SortOrder),
 	}
+	if req.Status != nil {
+		serviceReq.Status = []entities.OrderStatus{entities.OrderStatus(*req.Status)}
+	}
+	if req.Type != nil {
+		serviceReq.Type = []entities.OrderType{entities.OrderType(*req.Type)}
+	}
+	if req.Priority != nil {
+		serviceReq.Priority = []entities.OrderPriority{entities.OrderPriority(*req.Priority)}
+	}
+	if req.PaymentStatus != nil {
+		serviceReq.PaymentStatus = []entities.PaymentStatus{entities.PaymentStatus(*req.PaymentStatus)}
+	}
 
 	result, err := h.orderService.ListOrders(c, serviceReq)
 	if err != nil {
@@ -332,6 +361,136 @@ func (h *OrderHandler) ListOrders(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// ListArchivedOrders retrieves a paginated list of archived orders
+// @Summary List archived orders
+// @Description Get a paginated list of archived orders, most recent order date first
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param search query string false "Order number, item SKU or item name"
+// @Param customer_id query string false "Customer ID"
+// @Param year query int false "Year of the order date"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} dto.ListOrdersResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/archived [get]
+func (h *OrderHandler) ListArchivedOrders(c *gin.Context) {
+	var req dto.ListArchivedOrdersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid archived order list request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid query parameters",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	result, err := h.orderService.ListArchivedOrders(c, &order.ListArchivedOrdersRequest{
+		Search:     ptrStringToString(req.Search),
+		CustomerID: req.CustomerID,
+		Year:       req.Year,
+		Page:       req.Page,
+		Limit:      req.Limit,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to list archived orders")
+		handleOrderError(c, err)
+		return
+	}
+
+	orders := make([]*dto.OrderResponse, len(result.Orders))
+	for i, o := range result.Orders {
+		orders[i] = h.orderToResponse(o)
+	}
+
+	c.JSON(http.StatusOK, &dto.ListOrdersResponse{
+		Orders: orders,
+		Pagination: &dto.Pagination{
+			Page:       result.Pagination.Page,
+			Limit:      result.Pagination.Limit,
+			Total:      result.Pagination.Total,
+			TotalPages: result.Pagination.TotalPages,
+			HasNext:    result.Pagination.HasNext,
+			HasPrev:    result.Pagination.HasPrev,
+		},
+	})
+}
+
+// ArchiveOrder moves a closed order into the archive
+// @Summary Archive order
+// @Description Move a delivered, cancelled or refunded order that is settled and has no open returns into the archive with its items, addresses and history
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/{id}/archive [post]
+func (h *OrderHandler) ArchiveOrder(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Order ID is required",
+		})
+		return
+	}
+
+	if err := h.orderService.ArchiveOrder(c, id); err != nil {
+		h.logger.Error().Err(err).Str("order_id", id).Msg("Failed to archive order")
+		handleOrderError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RestoreOrder moves an archived order back into the live orders
+// @Summary Restore archived order
+// @Description Move an archived order with its items, addresses and history back into the live orders
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Success 200 {object} dto.OrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/{id}/restore [post]
+func (h *OrderHandler) RestoreOrder(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Order ID is required",
+		})
+		return
+	}
+
+	if err := h.orderService.RestoreOrder(c, id); err != nil {
+		h.logger.Error().Err(err).Str("order_id", id).Msg("Failed to restore order")
+		handleOrderError(c, err)
+		return
+	}
+
+	restoredOrder, err := h.orderService.GetOrder(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("order_id", id).Msg("Failed to get restored order")
+		handleOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.orderToResponse(restoredOrder))
+}
+
 // SearchOrders searches orders by query
 // @Summary Search orders
 // @Description Search orders by query string
@@ -440,14 +599,19 @@ func (h *OrderHandler) UpdateOrderStatus(c *gin.Context) {
 		return
 	}
 
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
 	serviceReq := &order.UpdateOrderStatusRequest{
 		Status:    entities.OrderStatus(req.Status),
 		Reason:    ptrStringToString(req.Notes),
 		Notify:    req.NotifyCustomer,
-		UpdatedBy: "", // TODO: Get from authenticated user context
+		UpdatedBy: userID,
 	}
 
-	updatedOrder, err := h.orderService.UpdateOrderStatus(c, id, serviceReq)
+	updatedOrder, err := h.orderService.UpdateOrderStatus(h.statusContext(c), id, serviceReq)
 	if err != nil {
 		h.logger.Error().Err(err).Str("order_id", id).Msg("Failed to update order status")
 		handleOrderError(c, err)
@@ -492,14 +656,19 @@ func (h *OrderHandler) CancelOrder(c *gin.Context) {
 		return
 	}
 
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
 	serviceReq := &order.CancelOrderRequest{
 		Reason:      req.Reason,
 		Refund:      req.RefundPayment,
 		Notify:      req.NotifyCustomer,
-		CancelledBy: "", // TODO: Get from authenticated user context
+		CancelledBy: userID,
 	}
 
-	canceledOrder, err := h.orderService.CancelOrder(c, id, serviceReq)
+	canceledOrder, err := h.orderService.CancelOrder(h.statusContext(c), id, serviceReq)
 	if err != nil {
 		h.logger.Error().Err(err).Str("order_id", id).Msg("Failed to cancel order")
 		handleOrderError(c, err)
@@ -544,7 +713,7 @@ func (h *OrderHandler) ProcessOrder(c *gin.Context) {
 		return
 	}
 
-	processedOrder, err := h.orderService.ProcessOrder(c, id)
+	processedOrder, err := h.orderService.ProcessOrder(h.statusContext(c), id)
 	if err != nil {
 		h.logger.Error().Err(err).Str("order_id", id).Msg("Failed to process order")
 		handleOrderError(c, err)
@@ -589,15 +758,21 @@ func (h *OrderHandler) ShipOrder(c *gin.Context) {
 		return
 	}
 
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
 	serviceReq := &order.ShipOrderRequest{
 		TrackingNumber: req.TrackingNumber,
 		Carrier:        req.Carrier,
 		ShippingDate:   req.ShippingDate,
 		Notify:         req.NotifyCustomer,
-		ShippedBy:      "", // TODO: Get from authenticated user context
+		ShippedBy:      userID,
+		Packages:       shipmentPackages(req.Packages),
 	}
 
-	shippedOrder, err := h.orderService.ShipOrder(c, id, serviceReq)
+	shippedOrder, err := h.orderService.ShipOrder(h.statusContext(c), id, serviceReq)
 	if err != nil {
 		h.logger.Error().Err(err).Str("order_id", id).Msg("Failed to ship order")
 		handleOrderError(c, err)
@@ -610,7 +785,7 @@ func (h *OrderHandler) ShipOrder(c *gin.Context) {
 
 // DeliverOrder marks an order as delivered
 // @Summary Deliver order
-// @Description Mark an order as delivered
+// @Description Mark the order's shipments, or a single shipment, as delivered. The order becomes delivered once all of its lines have shipped and arrived.
 // @Tags orders
 // @Accept json
 // @Produce json
@@ -642,15 +817,21 @@ func (h *OrderHandler) DeliverOrder(c *gin.Context) {
 		return
 	}
 
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
 	serviceReq := &order.DeliverOrderRequest{
+		ShipmentID:   uuidPtrToPtrString(req.ShipmentID),
 		DeliveryDate: req.DeliveryDate,
 		Proof:        req.PhotoProofURL,
 		Notes:        req.Notes,
 		Notify:       req.NotifyCustomer,
-		DeliveredBy:  "", // TODO: Get from authenticated user context
+		DeliveredBy:  userID,
 	}
 
-	deliveredOrder, err := h.orderService.DeliverOrder(c, id, serviceReq)
+	deliveredOrder, err := h.orderService.DeliverOrder(h.statusContext(c), id, serviceReq)
 	if err != nil {
 		h.logger.Error().Err(err).Str("order_id", id).Msg("Failed to deliver order")
 		handleOrderError(c, err)
@@ -661,24 +842,63 @@ func (h *OrderHandler) DeliverOrder(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
-// Payment Processing
+// ApproveOrder approves a pending order
+// @Summary Approve order
+// @Description Approve the next step of a pending order's approval chain. The approver must hold the role the step awaits; the order stays pending until every step is approved, then it is confirmed and inventory reserved. Orders taking the customer over their credit limit are put on credit hold instead.
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Success 200 {object} dto.OrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 422 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/{id}/approve [post]
+func (h *OrderHandler) ApproveOrder(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Order ID is required",
+		})
+		return
+	}
 
-// ProcessPayment processes a payment for an order
-// @Summary Process payment
-// @Description Process a payment for an order
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	approvedOrder, err := h.orderService.ApproveOrder(h.statusContext(c), id, userID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("order_id", id).Msg("Failed to approve order")
+		handleOrderError(c, err)
+		return
+	}
+
+	response := h.orderToResponse(approvedOrder)
+	c.JSON(http.StatusOK, response)
+}
+
+// HoldOrder places an order on hold
+// @Summary Hold order
+// @Description Place an order on hold with a reason
 // @Tags orders
 // @Accept json
 // @Produce json
 // @Param id path string true "Order ID"
-// @Param payment body dto.ProcessPaymentRequest true "Payment data"
+// @Param hold body dto.HoldOrderRequest true "Hold data"
 // @Success 200 {object} dto.OrderResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
 // @Failure 403 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
-// @Router /api/v1/orders/{id}/payment [post]
-func (h *OrderHandler) ProcessPayment(c *gin.Context) {
+// @Router /api/v1/orders/{id}/hold [post]
+func (h *OrderHandler) HoldOrder(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
 		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
@@ -687,9 +907,9 @@ func (h *OrderHandler) ProcessPayment(c *gin.Context) {
 		return
 	}
 
-	var req dto.ProcessPaymentRequest
+	var req dto.HoldOrderRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		h.logger.Error().Err(err).Msg("Invalid payment request")
+		h.logger.Error().Err(err).Msg("Invalid hold order request")
 		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
 			Error:   "Invalid request body",
 			Details: err.Error(),
@@ -697,162 +917,2334 @@ func (h *OrderHandler) ProcessPayment(c *gin.Context) {
 		return
 	}
 
-	serviceReq := &order.ProcessPaymentRequest{
-		PaymentMethod: req.PaymentMethod,
-		Amount:        req.Amount,
-		TransactionID: ptrStringToString(req.TransactionID),
-		Notes:         req.Notes,
-		PaymentBy:     "", // TODO: Get from authenticated user context
-	}
-
-	order, err := h.orderService.ProcessPayment(c, id, serviceReq)
+	heldOrder, err := h.orderService.HoldOrder(h.statusContext(c), id, req.Reason)
 	if err != nil {
-		h.logger.Error().Err(err).Str("order_id", id).Msg("Failed to process payment")
+		h.logger.Error().Err(err).Str("order_id", id).Msg("Failed to hold order")
 		handleOrderError(c, err)
 		return
 	}
 
-	response := h.orderToResponse(order)
+	response := h.orderToResponse(heldOrder)
 	c.JSON(http.StatusOK, response)
 }
 
-// Helper Methods
-
-// orderToResponse converts an order entity to a response DTO
-func (h *OrderHandler) orderToResponse(o *entities.Order) *dto.OrderResponse {
-	// Convert order items
-	items := make([]dto.OrderItemResponse, len(o.Items))
-	for i, item := range o.Items {
-		// Validate quantities are within int32 range
-		quantity := item.Quantity
-		if quantity > 0x7FFFFFFF || quantity < -0x80000000 {
-			quantity = 0 // Fallback to 0 if out of range
-		}
-		shippedQty := item.QuantityShipped
-		if shippedQty > 0x7FFFFFFF || shippedQty < -0x80000000 {
-			shippedQty = 0
-		}
-		returnedQty := item.QuantityReturned
-		if returnedQty > 0x7FFFFFFF || returnedQty < -0x80000000 {
-			returnedQty = 0
-		}
+// UnholdOrder releases an order from hold
+// @Summary Release order hold
+// @Description Release an order from hold back to its previous status
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Success 200 {object} dto.OrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/{id}/unhold [post]
+func (h *OrderHandler) UnholdOrder(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Order ID is required",
+		})
+		return
+	}
 
-		items[i] = dto.OrderItemResponse{
-			ID:               item.ID,
-			ProductID:        item.ProductID,
-			ProductSKU:       item.ProductSKU,
-			ProductName:      item.ProductName,
-			Quantity:         int32(quantity), // #nosec G115 - Validated above
-			UnitPrice:        item.UnitPrice,
-			TotalPrice:       item.TotalPrice,
-			TaxAmount:        item.TaxAmount,
-			DiscountAmount:   item.DiscountAmount,
-			Weight:           decimal.NewFromFloat(item.Weight),
-			Status:           item.Status,
-			ShippedQuantity:  int32(shippedQty),  // #nosec G115 - Validated above
-			ReturnedQuantity: int32(returnedQty), // #nosec G115 - Validated above
-			Notes:            item.Notes,
-		}
+	releasedOrder, err := h.orderService.UnholdOrder(h.statusContext(c), id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("order_id", id).Msg("Failed to release order hold")
+		handleOrderError(c, err)
+		return
 	}
 
-	// Get customer name from the relationship
-	customerName := ""
-	if o.Customer != nil {
-		customerName = o.Customer.FirstName + " " + o.Customer.LastName
+	response := h.orderToResponse(releasedOrder)
+	c.JSON(http.StatusOK, response)
+}
+
+// OverrideCreditHold confirms an order held for exceeding the customer's credit limit
+// @Summary Override credit hold
+// @Description Confirm an order on credit hold despite the customer's credit limit. The override is recorded in the order history.
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param override body dto.OverrideCreditHoldRequest true "Override data"
+// @Success 200 {object} dto.OrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/{id}/credit-override [post]
+func (h *OrderHandler) OverrideCreditHold(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Order ID is required",
+		})
+		return
 	}
 
-	return &dto.OrderResponse{
-		ID:                 o.ID,
-		OrderNumber:        o.OrderNumber,
-		CustomerID:         o.CustomerID,
-		CustomerName:       customerName,
-		Type:               string(o.Type),
-		Status:             string(o.Status),
-		Priority:           string(o.Priority),
-		PaymentStatus:      string(o.PaymentStatus),
-		FulfillmentStatus:  string(o.Status), // Using Status as FulfillmentStatus
-		Currency:           o.Currency,
-		Subtotal:           o.Subtotal,
-		TaxAmount:          o.TaxAmount,
-		ShippingAmount:     o.ShippingAmount,
-		DiscountAmount:     o.DiscountAmount,
-		TotalAmount:        o.TotalAmount,
-		PaidAmount:         o.PaidAmount,
-		RefundedAmount:     o.RefundedAmount,
-		Weight:             decimal.NewFromFloat(0), // TODO: Calculate from items
-		ShippingMethod:     string(o.ShippingMethod),
-		TrackingNumber:     ptrStringToString(o.TrackingNumber),
-		ShippingAddress:    h.addressToResponse(o.ShippingAddress),
-		BillingAddress:     h.addressToResponse(o.BillingAddress),
-		Items:              items,
-		Notes:              o.Notes,
-		CustomerNotes:      o.CustomerNotes,
-		InternalNotes:      o.InternalNotes,
-		RequiredDate:       o.RequiredDate,
-		ShippedDate:        o.ShippingDate,
-		DeliveredDate:      o.DeliveryDate,
-		CreatedAt:          o.CreatedAt,
-		UpdatedAt:          o.UpdatedAt,
-		ApprovedAt:         o.ApprovedAt,
-		ApprovedBy:         uuidPtrToPtrString(o.ApprovedBy),
-		CancelledAt:        o.CancelledDate,
-		CancelledBy:        nil, // No field in entity
-		CancellationReason: nil, // No field in entity
+	var req dto.OverrideCreditHoldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid credit hold override request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
 	}
-}
 
-// addressToResponse converts an address entity to a response DTO
-func (h *OrderHandler) addressToResponse(addr *entities.OrderAddress) *dto.AddressResponse {
-	if addr == nil {
-		return nil
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
 	}
 
-	return &dto.AddressResponse{
-		ID:         addr.ID,
-		Type:       addr.Type,
-		FirstName:  addr.FirstName,
-		LastName:   addr.LastName,
-		Company:    addr.Company,
-		Address1:   addr.AddressLine1,
-		Address2:   addr.AddressLine2,
-		City:       addr.City,
-		State:      addr.State,
-		PostalCode: addr.PostalCode,
-		Country:    addr.Country,
-		Phone:      addr.Phone,
-		Email:      addr.Email,
-		IsDefault:  addr.IsDefault,
+	confirmedOrder, err := h.orderService.OverrideCreditHold(h.statusContext(c), id, &order.OverrideCreditHoldRequest{
+		Reason:       req.Reason,
+		OverriddenBy: userID,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("order_id", id).Msg("Failed to override credit hold")
+		handleOrderError(c, err)
+		return
 	}
+
+	response := h.orderToResponse(confirmedOrder)
+	c.JSON(http.StatusOK, response)
 }
 
-// handleOrderError handles order service errors
-func handleOrderError(c *gin.Context, err error) {
+// RejectOrder rejects a pending order awaiting the approver
+// @Summary Reject order
+// @Description Reject the next step of a pending order's approval chain, cancelling the order. The approver must hold the role the step awaits.
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param reject body dto.RejectOrderRequest true "Rejection reason"
+// @Success 200 {object} dto.OrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/{id}/reject [post]
+func (h *OrderHandler) RejectOrder(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Order ID is required",
+		})
+		return
+	}
+
+	var req dto.RejectOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid reject order request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	rejectedOrder, err := h.orderService.RejectOrder(h.statusContext(c), id, &order.RejectOrderRequest{
+		Reason:     req.Reason,
+		RejectedBy: userID,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("order_id", id).Msg("Failed to reject order")
+		handleOrderError(c, err)
+		return
+	}
+
+	response := h.orderToResponse(rejectedOrder)
+	c.JSON(http.StatusOK, response)
+}
+
+// GetOrderApprovals returns the approval chain of an order
+// @Summary Get order approvals
+// @Description Get the approval steps of an order in sequence with the decisions recorded against them, including steps superseded by changes to the order
+// @Tags orders
+// @Produce json
+// @Param id path string true "Order ID"
+// @Success 200 {array} dto.OrderApprovalResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/{id}/approvals [get]
+func (h *OrderHandler) GetOrderApprovals(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Order ID is required",
+		})
+		return
+	}
+
+	approvals, err := h.orderService.GetOrderApprovals(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("order_id", id).Msg("Failed to get order approvals")
+		handleOrderError(c, err)
+		return
+	}
+
+	response := make([]dto.OrderApprovalResponse, len(approvals))
+	for i, approval := range approvals {
+		response[i] = orderApprovalToResponse(approval)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetPendingApprovals returns the orders awaiting the current user's approval
+// @Summary Get pending approvals
+// @Description Get the approval steps awaiting a role of the current user, oldest first, with their orders. Amounts are in the base currency.
+// @Tags orders
+// @Produce json
+// @Param page query int false "Page number"
+// @Param limit query int false "Page size"
+// @Success 200 {object} dto.PendingApprovalsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/approvals/pending [get]
+func (h *OrderHandler) GetPendingApprovals(c *gin.Context) {
+	var req dto.PendingApprovalsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid pending approvals request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid query parameters",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.orderService.GetPendingApprovals(c, &order.GetPendingApprovalsRequest{
+		ApproverID: userID,
+		Page:       req.Page,
+		Limit:      req.Limit,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", userID).Msg("Failed to get pending approvals")
+		handleOrderError(c, err)
+		return
+	}
+
+	approvals := make([]*dto.PendingApprovalResponse, 0, len(result.Approvals))
+	for _, approval := range result.Approvals {
+		pending := &dto.PendingApprovalResponse{
+			OrderApprovalResponse: orderApprovalToResponse(approval),
+			BaseCurrency:          h.orderService.BaseCurrency(),
+		}
+		if approval.Order != nil {
+			pending.OrderNumber = approval.Order.OrderNumber
+			pending.CustomerID = approval.Order.CustomerID
+			pending.OrderDate = approval.Order.OrderDate
+			pending.TotalAmount = approval.Order.TotalAmount
+			pending.Currency = approval.Order.Currency
+		}
+		approvals = append(approvals, pending)
+	}
+
+	c.JSON(http.StatusOK, &dto.PendingApprovalsResponse{
+		Approvals: approvals,
+		Pagination: &dto.Pagination{
+			Page:       result.Pagination.Page,
+			Limit:      result.Pagination.Limit,
+			Total:      result.Pagination.Total,
+			TotalPages: result.Pagination.TotalPages,
+			HasNext:    result.Pagination.HasNext,
+			HasPrev:    result.Pagination.HasPrev,
+		},
+	})
+}
+
+// GetOrderStatusHistory returns the status history of an order
+// @Summary Get order status history
+// @Description Get every recorded order and payment status change of an order
+// @Tags orders
+// @Produce json
+// @Param id path string true "Order ID"
+// @Success 200 {array} dto.OrderStatusHistoryResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/{id}/history [get]
+func (h *OrderHandler) GetOrderStatusHistory(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Order ID is required",
+		})
+		return
+	}
+
+	history, err := h.orderService.GetOrderStatusHistory(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("order_id", id).Msg("Failed to get order status history")
+		handleOrderError(c, err)
+		return
+	}
+
+	response := make([]dto.OrderStatusHistoryResponse, len(history))
+	for i, entry := range history {
+		response[i] = dto.OrderStatusHistoryResponse{
+			ID:         entry.ID,
+			Type:       string(entry.Type),
+			FromStatus: entry.FromStatus,
+			ToStatus:   entry.ToStatus,
+			Amount:     entry.Amount,
+			ChangedBy:  entry.ChangedBy,
+			Reason:     entry.Reason,
+			Source:     string(entry.Source),
+			CreatedAt:  entry.CreatedAt,
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetOrderTimeline returns the event timeline of an order
+// @Summary Get order timeline
+// @Description Get status, payment and shipment events of an order in chronological order
+// @Tags orders
+// @Produce json
+// @Param id path string true "Order ID"
+// @Success 200 {object} dto.OrderTimelineResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/{id}/timeline [get]
+func (h *OrderHandler) GetOrderTimeline(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Order ID is required",
+		})
+		return
+	}
+
+	events, err := h.orderService.GetOrderTimeline(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("order_id", id).Msg("Failed to get order timeline")
+		handleOrderError(c, err)
+		return
+	}
+
+	response := dto.OrderTimelineResponse{
+		Events: make([]dto.OrderTimelineEventResponse, len(events)),
+	}
+	for i, event := range events {
+		response.Events[i] = dto.OrderTimelineEventResponse{
+			Type:           string(event.Type),
+			OccurredAt:     event.OccurredAt,
+			Description:    event.Description,
+			FromStatus:     event.FromStatus,
+			ToStatus:       event.ToStatus,
+			Amount:         event.Amount,
+			ActorID:        event.ActorID,
+			Source:         string(event.Source),
+			TrackingNumber: event.TrackingNumber,
+			Carrier:        event.Carrier,
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetOrderShipments returns the shipments of an order
+// @Summary Get order shipments
+// @Description Get every shipment of an order with its lines, packages and tracking
+// @Tags orders
+// @Produce json
+// @Param id path string true "Order ID"
+// @Success 200 {object} dto.OrderShipmentsResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/{id}/shipments [get]
+func (h *OrderHandler) GetOrderShipments(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Order ID is required",
+		})
+		return
+	}
+
+	shipments, err := h.orderService.GetOrderShipments(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("order_id", id).Msg("Failed to get order shipments")
+		handleOrderError(c, err)
+		return
+	}
+
+	response := dto.OrderShipmentsResponse{
+		Shipments: make([]dto.ShipmentResponse, len(shipments)),
+	}
+	for i, shipment := range shipments {
+		response.Shipments[i] = shipmentToResponse(shipment)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetOrderAllocations returns the warehouse allocations of an order
+// @Summary Get order allocations
+// @Description Get the warehouses each line of an order is reserved from, as planned by the sourcing rules
+// @Tags orders
+// @Produce json
+// @Param id path string true "Order ID"
+// @Success 200 {object} dto.OrderAllocationsResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/{id}/allocations [get]
+func (h *OrderHandler) GetOrderAllocations(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Order ID is required",
+		})
+		return
+	}
+
+	allocations, err := h.orderService.GetOrderAllocations(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("order_id", id).Msg("Failed to get order allocations")
+		handleOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, orderAllocationsToResponse(allocations))
+}
+
+// SourceOrder sources a reserved order again
+// @Summary Source order
+// @Description Plan the warehouses of a reserved order again with the current stock and sourcing rules, and move its reservations to match. Only quantities allocated to warehouses move; stock reserved before the order's lines were allocated stays where it is. Added quantities are reserved atomically before the others are released; nothing changes when the stock cannot cover the order.
+// @Tags orders
+// @Produce json
+// @Param id path string true "Order ID"
+// @Success 200 {object} dto.OrderAllocationsResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 422 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/{id}/source [post]
+func (h *OrderHandler) SourceOrder(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Order ID is required",
+		})
+		return
+	}
+
+	allocations, err := h.orderService.SourceOrder(h.statusContext(c), id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("order_id", id).Msg("Failed to source order")
+		handleOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, orderAllocationsToResponse(allocations))
+}
+
+// AmendOrder amends a confirmed order
+// @Summary Amend order
+// @Description Change the lines, prices or addresses of a confirmed order as its next revision. Lines without an order item ID are added. Reservations move with the quantities, shortfalls are backordered and the customer's credit limit is checked against the added amount. When the approval policies require approval of the amended order that it has not had, the revision waits as PENDING_APPROVAL and the order is unchanged until it is approved.
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param amendment body dto.AmendOrderRequest true "Amendment"
+// @Success 201 {object} dto.OrderRevisionResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 422 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/{id}/amendments [post]
+func (h *OrderHandler) AmendOrder(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Order ID is required",
+		})
+		return
+	}
+
+	var req dto.AmendOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid amend order request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	lines := make([]order.AmendOrderLineRequest, len(req.Lines))
+	for i, line := range req.Lines {
+		lines[i] = order.AmendOrderLineRequest{
+			OrderItemID:    line.OrderItemID,
+			ProductID:      line.ProductID,
+			Quantity:       line.Quantity,
+			UnitPrice:      line.UnitPrice,
+			DiscountAmount: line.DiscountAmount,
+			Remove:         line.Remove,
+		}
+	}
+
+	revision, err := h.orderService.AmendOrder(h.statusContext(c), id, &order.AmendOrderRequest{
+		Lines:             lines,
+		ShippingAddressID: req.ShippingAddressID,
+		BillingAddressID:  req.BillingAddressID,
+		Reason:            req.Reason,
+		AmendedBy:         userID,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("order_id", id).Msg("Failed to amend order")
+		handleOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, orderRevisionToResponse(revision))
+}
+
+// GetOrderRevisions returns the revisions of an order
+// @Summary Get order revisions
+// @Description Get the numbered revisions of an order, oldest first, with the changes each made. Revision 1 is the order as it stood before its first amendment.
+// @Tags orders
+// @Produce json
+// @Param id path string true "Order ID"
+// @Success 200 {array} dto.OrderRevisionResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/{id}/revisions [get]
+func (h *OrderHandler) GetOrderRevisions(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Order ID is required",
+		})
+		return
+	}
+
+	revisions, err := h.orderService.GetOrderRevisions(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("order_id", id).Msg("Failed to get order revisions")
+		handleOrderError(c, err)
+		return
+	}
+
+	response := make([]dto.OrderRevisionResponse, len(revisions))
+	for i, revision := range revisions {
+		response[i] = orderRevisionToResponse(revision)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetOrderRevision returns a revision of an order
+// @Summary Get order revision
+// @Description Get a revision of an order by its number, with the order's lines, addresses and totals as of the revision
+// @Tags orders
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param number path int true "Revision number"
+// @Success 200 {object} dto.OrderRevisionResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/{id}/revisions/{number} [get]
+func (h *OrderHandler) GetOrderRevision(c *gin.Context) {
+	id := c.Param("id")
+	number, ok := revisionNumber(c)
+	if !ok {
+		return
+	}
+
+	revision, err := h.orderService.GetOrderRevision(c, id, number)
+	if err != nil {
+		h.logger.Error().Err(err).Str("order_id", id).Int("revision", number).Msg("Failed to get order revision")
+		handleOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, orderRevisionToResponse(revision))
+}
+
+// ApproveOrderRevision approves a pending order revision
+// @Summary Approve order revision
+// @Description Approve a pending revision as the next of its approver roles. The amendment is applied to the order once every role has approved.
+// @Tags orders
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param number path int true "Revision number"
+// @Success 200 {object} dto.OrderRevisionResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 422 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/{id}/revisions/{number}/approve [post]
+func (h *OrderHandler) ApproveOrderRevision(c *gin.Context) {
+	id := c.Param("id")
+	number, ok := revisionNumber(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	revision, err := h.orderService.ApproveOrderRevision(h.statusContext(c), id, number, &order.ApproveOrderRevisionRequest{
+		ApprovedBy: userID,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("order_id", id).Int("revision", number).Msg("Failed to approve order revision")
+		handleOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, orderRevisionToResponse(revision))
+}
+
+// RejectOrderRevision rejects a pending order revision
+// @Summary Reject order revision
+// @Description Reject a pending revision as the next of its approver roles, leaving the order unchanged
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param number path int true "Revision number"
+// @Param rejection body dto.RejectOrderRevisionRequest true "Rejection reason"
+// @Success 200 {object} dto.OrderRevisionResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/{id}/revisions/{number}/reject [post]
+func (h *OrderHandler) RejectOrderRevision(c *gin.Context) {
+	id := c.Param("id")
+	number, ok := revisionNumber(c)
+	if !ok {
+		return
+	}
+
+	var req dto.RejectOrderRevisionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid reject order revision request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	revision, err := h.orderService.RejectOrderRevision(h.statusContext(c), id, number, &order.RejectOrderRevisionRequest{
+		Reason:     req.Reason,
+		RejectedBy: userID,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("order_id", id).Int("revision", number).Msg("Failed to reject order revision")
+		handleOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, orderRevisionToResponse(revision))
+}
+
+// PartialShipOrder ships part of an order
+// @Summary Partially ship order
+// @Description Ship selected quantities of order items
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param ship body dto.PartialShipOrderRequest true "Partial shipping data"
+// @Success 200 {object} dto.OrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/{id}/partial-ship [post]
+func (h *OrderHandler) PartialShipOrder(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Order ID is required",
+		})
+		return
+	}
+
+	var req dto.PartialShipOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid partial ship order request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	serviceReq := &order.PartialShipOrderRequest{
+		TrackingNumber: req.TrackingNumber,
+		Carrier:        req.Carrier,
+		ShippingDate:   req.ShippingDate,
+		Notify:         req.NotifyCustomer,
+		ShippedBy:      userID,
+		Packages:       shipmentPackages(req.Packages),
+	}
+	serviceReq.Items = make([]order.ShipItemRequest, len(req.Items))
+	for i, item := range req.Items {
+		serviceReq.Items[i] = order.ShipItemRequest{
+			ItemID:   item.OrderItemID.String(),
+			Quantity: int(item.Quantity),
+		}
+	}
+
+	shippedOrder, err := h.orderService.PartialShipOrder(h.statusContext(c), id, serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Str("order_id", id).Msg("Failed to partially ship order")
+		handleOrderError(c, err)
+		return
+	}
+
+	response := h.orderToResponse(shippedOrder)
+	c.JSON(http.StatusOK, response)
+}
+
+// ReturnOrderItems records returned order items
+// @Summary Return order items
+// @Description Record returned quantities for shipped order items
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param return body dto.ReturnItemsRequest true "Return data"
+// @Success 200 {object} dto.OrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/{id}/return [post]
+func (h *OrderHandler) ReturnOrderItems(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Order ID is required",
+		})
+		return
+	}
+
+	var req dto.ReturnItemsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid return items request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	serviceReq := &order.ReturnItemsRequest{
+		Reason:     req.Reason,
+		Refund:     req.RefundAmount,
+		Notes:      req.Notes,
+		ReturnedBy: userID,
+	}
+	serviceReq.Items = make([]order.ReturnItemRequest, len(req.Items))
+	for i, item := range req.Items {
+		serviceReq.Items[i] = order.ReturnItemRequest{
+			ItemID:   item.OrderItemID.String(),
+			Quantity: int(item.Quantity),
+			Reason:   ptrStringToString(item.Reason),
+		}
+	}
+
+	returnedOrder, err := h.orderService.ReturnOrderItems(h.statusContext(c), id, serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Str("order_id", id).Msg("Failed to return order items")
+		handleOrderError(c, err)
+		return
+	}
+
+	response := h.orderToResponse(returnedOrder)
+	c.JSON(http.StatusOK, response)
+}
+
+// Payment Processing
+
+// ProcessPayment processes a payment for an order
+// @Summary Process payment
+// @Description Process a payment for an order
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param payment body dto.ProcessPaymentRequest true "Payment data"
+// @Success 200 {object} dto.OrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/{id}/payment [post]
+func (h *OrderHandler) ProcessPayment(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Order ID is required",
+		})
+		return
+	}
+
+	var req dto.ProcessPaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid payment request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	serviceReq := &order.ProcessPaymentRequest{
+		PaymentMethod: req.PaymentMethod,
+		Amount:        req.Amount,
+		TransactionID: ptrStringToString(req.TransactionID),
+		PaymentDate:   req.PaymentDate,
+		Notes:         req.Notes,
+		PaymentBy:     userID,
+	}
+
+	order, err := h.orderService.ProcessPayment(h.statusContext(c), id, serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Str("order_id", id).Msg("Failed to process payment")
+		handleOrderError(c, err)
+		return
+	}
+
+	response := h.orderToResponse(order)
+	c.JSON(http.StatusOK, response)
+}
+
+// RefundOrder refunds an order
+// @Summary Refund order
+// @Description Refund an amount paid on an order
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param refund body dto.RefundOrderRequest true "Refund data"
+// @Success 200 {object} dto.OrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/{id}/refund [post]
+func (h *OrderHandler) RefundOrder(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Order ID is required",
+		})
+		return
+	}
+
+	var req dto.RefundOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid refund request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	serviceReq := &order.RefundOrderRequest{
+		Amount:     req.Amount,
+		Reason:     req.Reason,
+		Notes:      req.Notes,
+		RefundedBy: userID,
+	}
+
+	refundedOrder, err := h.orderService.RefundOrder(h.statusContext(c), id, serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Str("order_id", id).Msg("Failed to refund order")
+		handleOrderError(c, err)
+		return
+	}
+
+	response := h.orderToResponse(refundedOrder)
+	c.JSON(http.StatusOK, response)
+}
+
+// PartialRefundOrder refunds individual order items
+// @Summary Partially refund order
+// @Description Refund selected order items
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param refund body dto.PartialRefundOrderRequest true "Partial refund data"
+// @Success 200 {object} dto.OrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/{id}/partial-refund [post]
+func (h *OrderHandler) PartialRefundOrder(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Order ID is required",
+		})
+		return
+	}
+
+	var req dto.PartialRefundOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid partial refund request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	serviceReq := &order.PartialRefundOrderRequest{
+		Reason:     req.Reason,
+		Notes:      req.Notes,
+		RefundedBy: userID,
+	}
+	serviceReq.Items = make([]order.RefundItemRequest, len(req.Items))
+	for i, item := range req.Items {
+		serviceReq.Items[i] = order.RefundItemRequest{
+			ItemID:       item.OrderItemID.String(),
+			Quantity:     int(item.Quantity),
+			RefundAmount: item.Amount,
+			Reason:       ptrStringToString(item.Reason),
+		}
+	}
+
+	refundedOrder, err := h.orderService.PartialRefundOrder(h.statusContext(c), id, serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Str("order_id", id).Msg("Failed to partially refund order")
+		handleOrderError(c, err)
+		return
+	}
+
+	response := h.orderToResponse(refundedOrder)
+	c.JSON(http.StatusOK, response)
+}
+
+// Order Item Management
+
+// AddOrderItem adds an item to an order
+// @Summary Add order item
+// @Description Add an item to a draft or pending order
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param item body dto.AddOrderItemRequest true "Item data"
+// @Success 200 {object} dto.OrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/{id}/items [post]
+func (h *OrderHandler) AddOrderItem(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Order ID is required",
+		})
+		return
+	}
+
+	var req dto.AddOrderItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid add order item request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	serviceReq := &order.AddOrderItemRequest{
+		ProductID: req.ProductID.String(),
+		Quantity:  int(req.Quantity),
+		UnitPrice: req.UnitPrice,
+		Notes:     req.Notes,
+		DropShip:  req.DropShip,
+	}
+
+	updatedOrder, err := h.orderService.AddOrderItem(c, id, serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Str("order_id", id).Msg("Failed to add order item")
+		handleOrderError(c, err)
+		return
+	}
+
+	response := h.orderToResponse(updatedOrder)
+	c.JSON(http.StatusOK, response)
+}
+
+// UpdateOrderItem updates an order item
+// @Summary Update order item
+// @Description Update quantity, price or notes of an item on a draft or pending order
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param item_id path string true "Order item ID"
+// @Param item body dto.UpdateOrderItemRequest true "Item data"
+// @Success 200 {object} dto.OrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/{id}/items/{item_id} [put]
+func (h *OrderHandler) UpdateOrderItem(c *gin.Context) {
+	id := c.Param("id")
+	itemID := c.Param("item_id")
+	if id == "" || itemID == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Order ID and item ID are required",
+		})
+		return
+	}
+
+	var req dto.UpdateOrderItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid update order item request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	serviceReq := &order.UpdateOrderItemRequest{
+		UnitPrice: req.UnitPrice,
+		Notes:     req.Notes,
+	}
+	if req.Quantity != nil {
+		quantity := int(*req.Quantity)
+		serviceReq.Quantity = &quantity
+	}
+
+	updatedOrder, err := h.orderService.UpdateOrderItem(c, id, itemID, serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Str("order_id", id).Str("item_id", itemID).Msg("Failed to update order item")
+		handleOrderError(c, err)
+		return
+	}
+
+	response := h.orderToResponse(updatedOrder)
+	c.JSON(http.StatusOK, response)
+}
+
+// RemoveOrderItem removes an item from an order
+// @Summary Remove order item
+// @Description Remove an item from a draft or pending order
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param item_id path string true "Order item ID"
+// @Success 200 {object} dto.OrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/{id}/items/{item_id} [delete]
+func (h *OrderHandler) RemoveOrderItem(c *gin.Context) {
+	id := c.Param("id")
+	itemID := c.Param("item_id")
+	if id == "" || itemID == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Order ID and item ID are required",
+		})
+		return
+	}
+
+	updatedOrder, err := h.orderService.RemoveOrderItem(c, id, itemID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("order_id", id).Str("item_id", itemID).Msg("Failed to remove order item")
+		handleOrderError(c, err)
+		return
+	}
+
+	response := h.orderToResponse(updatedOrder)
+	c.JSON(http.StatusOK, response)
+}
+
+// ApplyCoupon applies a coupon code to an order
+// @Summary Apply coupon
+// @Description Redeem a coupon code on a draft or pending order. The order must qualify for the promotion and the promotion must combine with the codes already applied; its discount shows as a COUPON line of the order calculation.
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param coupon body dto.ApplyCouponRequest true "Coupon code"
+// @Success 200 {object} dto.OrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 422 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/{id}/coupons [post]
+func (h *OrderHandler) ApplyCoupon(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Order ID is required",
+		})
+		return
+	}
+
+	var req dto.ApplyCouponRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid apply coupon request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	updatedOrder, err := h.orderService.ApplyCoupon(c, id, &order.ApplyCouponRequest{
+		Code:      req.Code,
+		AppliedBy: userID,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("order_id", id).Str("code", req.Code).Msg("Failed to apply coupon")
+		handleOrderError(c, err)
+		return
+	}
+
+	response := h.orderToResponse(updatedOrder)
+	c.JSON(http.StatusOK, response)
+}
+
+// RemoveCoupon removes a coupon code from an order
+// @Summary Remove coupon
+// @Description Remove a coupon code from a draft or pending order, giving the redemption back to the promotion's usage limits
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param code path string true "Coupon code"
+// @Success 200 {object} dto.OrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/{id}/coupons/{code} [delete]
+func (h *OrderHandler) RemoveCoupon(c *gin.Context) {
+	id := c.Param("id")
+	code := c.Param("code")
+	if id == "" || code == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Order ID and coupon code are required",
+		})
+		return
+	}
+
+	updatedOrder, err := h.orderService.RemoveCoupon(c, id, code)
+	if err != nil {
+		h.logger.Error().Err(err).Str("order_id", id).Str("code", code).Msg("Failed to remove coupon")
+		handleOrderError(c, err)
+		return
+	}
+
+	response := h.orderToResponse(updatedOrder)
+	c.JSON(http.StatusOK, response)
+}
+
+// Order Validation and Calculation
+
+// ValidateOrder validates an order
+// @Summary Validate order
+// @Description Validate an order's data, customer, addresses and stock
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Success 200 {object} dto.OrderValidationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/{id}/validate [get]
+func (h *OrderHandler) ValidateOrder(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Order ID is required",
+		})
+		return
+	}
+
+	validation, err := h.orderService.ValidateOrder(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("order_id", id).Msg("Failed to validate order")
+		handleOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.OrderValidationResponse{
+		IsValid:  validation.IsValid,
+		Errors:   validation.Errors,
+		Warnings: validation.Warnings,
+	})
+}
+
+// CalculateOrderTotals calculates order totals without saving them
+// @Summary Calculate order totals
+// @Description Calculate an order's totals with tax and discount breakdowns
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Success 200 {object} dto.OrderCalculationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/{id}/calculate [get]
+func (h *OrderHandler) CalculateOrderTotals(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Order ID is required",
+		})
+		return
+	}
+
+	calculation, err := h.orderService.CalculateOrderTotals(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("order_id", id).Msg("Failed to calculate order totals")
+		handleOrderError(c, err)
+		return
+	}
+
+	response := dto.OrderCalculationResponse{
+		Subtotal:       calculation.Subtotal,
+		TaxAmount:      calculation.TaxAmount,
+		ShippingAmount: calculation.ShippingAmount,
+		DiscountAmount: calculation.DiscountAmount,
+		TotalAmount:    calculation.TotalAmount,
+		Discounts:      make([]dto.OrderDiscount, len(calculation.DiscountBreakdown)),
+		Taxes:          make([]dto.OrderTax, len(calculation.TaxBreakdown)),
+	}
+	for i, discount := range calculation.DiscountBreakdown {
+		response.Discounts[i] = dto.OrderDiscount{
+			ID:     uuid.New(),
+			Type:   discount.DiscountType,
+			Name:   discount.Description,
+			Amount: discount.Amount,
+		}
+	}
+	for i, tax := range calculation.TaxBreakdown {
+		response.Taxes[i] = dto.OrderTax{
+			ID:     uuid.New(),
+			Name:   tax.TaxName,
+			Level:  string(tax.Level),
+			Rate:   tax.TaxRate,
+			Amount: tax.TaxAmount,
+			Type:   "PERCENTAGE",
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// QuoteShipping quotes the shipping methods available for an order
+// @Summary Quote order shipping
+// @Description Price the shipping methods available at an order's shipping address by weight, dimensional weight and zone, cheapest first, with delivery estimates
+// @Tags orders
+// @Produce json
+// @Param id path string true "Order ID"
+// @Success 200 {array} dto.ShippingQuoteResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/{id}/shipping-quotes [get]
+func (h *OrderHandler) QuoteShipping(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Order ID is required",
+		})
+		return
+	}
+
+	quotes, err := h.orderService.QuoteShipping(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("order_id", id).Msg("Failed to quote order shipping")
+		handleOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, shippingQuotesToResponse(quotes))
+}
+
+// RecalculateOrder recalculates and saves order totals
+// @Summary Recalculate order
+// @Description Recalculate and save an order's totals
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Success 200 {object} dto.OrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/{id}/recalculate [post]
+func (h *OrderHandler) RecalculateOrder(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Order ID is required",
+		})
+		return
+	}
+
+	recalculatedOrder, err := h.orderService.RecalculateOrder(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("order_id", id).Msg("Failed to recalculate order")
+		handleOrderError(c, err)
+		return
+	}
+
+	response := h.orderToResponse(recalculatedOrder)
+	c.JSON(http.StatusOK, response)
+}
+
+// CloneOrder clones an order
+// @Summary Clone order
+// @Description Create a new pending order from an existing order
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param clone body dto.CloneOrderRequest true "Clone options"
+// @Success 201 {object} dto.OrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/{id}/clone [post]
+func (h *OrderHandler) CloneOrder(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Order ID is required",
+		})
+		return
+	}
+
+	var req dto.CloneOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid clone order request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	serviceReq := &order.CloneOrderRequest{
+		ClonedBy:      userID,
+		CopyItems:     req.IncludeItems,
+		CopyAddresses: req.IncludeAddresses,
+		CopyNotes:     req.NewNotes == nil,
+		CopyDiscounts: true,
+		Notes:         req.NewNotes,
+	}
+
+	clonedOrder, err := h.orderService.CloneOrder(h.statusContext(c), id, serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Str("order_id", id).Msg("Failed to clone order")
+		handleOrderError(c, err)
+		return
+	}
+
+	response := h.orderToResponse(clonedOrder)
+	c.JSON(http.StatusCreated, response)
+}
+
+// Customer Orders
+
+// GetCustomerOrders retrieves orders for a customer
+// @Summary Get customer orders
+// @Description Get a paginated list of a customer's orders
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param customer_id path string true "Customer ID"
+// @Param status query string false "Order status"
+// @Param created_after query string false "Created after (ISO 8601)"
+// @Param created_before query string false "Created before (ISO 8601)"
+// @Param sort_by query string false "Sort field" default("created_at")
+// @Param sort_order query string false "Sort order" Enums(asc,desc) default("desc")
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} dto.GetCustomerOrdersResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/customer/{customer_id} [get]
+func (h *OrderHandler) GetCustomerOrders(c *gin.Context) {
+	customerID := c.Param("customer_id")
+	if customerID == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Customer ID is required",
+		})
+		return
+	}
+
+	var req dto.GetCustomerOrdersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid customer orders request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid query parameters",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	serviceReq := &order.GetCustomerOrdersRequest{
+		StartDate: req.CreatedAfter,
+		EndDate:   req.CreatedBefore,
+		Page:      req.Page,
+		Limit:     req.Limit,
+		SortBy:    ptrStringToString(req.SortBy),
+		SortOrder: ptrStringToString(req.SortOrder),
+	}
+	if req.Status != nil {
+		serviceReq.Status = []entities.OrderStatus{entities.OrderStatus(*req.Status)}
+	}
+
+	result, err := h.orderService.GetCustomerOrders(c, customerID, serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Str("customer_id", customerID).Msg("Failed to get customer orders")
+		handleOrderError(c, err)
+		return
+	}
+
+	orders := make([]*dto.OrderResponse, len(result.Orders))
+	for i, o := range result.Orders {
+		orders[i] = h.orderToResponse(o)
+	}
+
+	response := &dto.GetCustomerOrdersResponse{
+		Orders: orders,
+		Pagination: &dto.Pagination{
+			Page:       result.Pagination.Page,
+			Limit:      result.Pagination.Limit,
+			Total:      result.Pagination.Total,
+			TotalPages: result.Pagination.TotalPages,
+			HasNext:    result.Pagination.HasNext,
+			HasPrev:    result.Pagination.HasPrev,
+		},
+	}
+	if len(result.Orders) > 0 && result.Orders[0].Customer != nil {
+		customer := result.Orders[0].Customer
+		response.Customer = &dto.CustomerInfo{
+			ID:          customer.ID,
+			Name:        customer.FirstName + " " + customer.LastName,
+			Email:       customer.Email,
+			Phone:       customer.Phone,
+			CreditLimit: customer.CreditLimit,
+			Balance:     customer.CreditUsed,
+			IsActive:    customer.IsActive,
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Analytics
+
+// GetOrderStats retrieves order statistics
+// @Summary Get order statistics
+// @Description Get aggregate order statistics for a date range
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Param status query string false "Order status"
+// @Param customer_id query string false "Customer ID"
+// @Success 200 {object} dto.OrderStatsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/stats [get]
+func (h *OrderHandler) GetOrderStats(c *gin.Context) {
+	var req dto.OrderAnalyticsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid order stats request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid query parameters",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	serviceReq := &order.GetOrderStatsRequest{
+		StartDate:  req.StartDate,
+		EndDate:    req.EndDate,
+		CustomerID: req.CustomerID,
+	}
+	if req.Status != nil {
+		serviceReq.Status = []entities.OrderStatus{entities.OrderStatus(*req.Status)}
+	}
+
+	stats, err := h.orderService.GetOrderStats(c, serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to get order stats")
+		handleOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, orderStatsToResponse(stats))
+}
+
+// GetRevenueByPeriod retrieves revenue grouped by period
+// @Summary Get revenue by period
+// @Description Get order revenue grouped by day, week, month or year
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Param group_by query string false "Grouping" Enums(day,week,month,year) default("day")
+// @Success 200 {object} dto.RevenueByPeriodResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/analytics/revenue [get]
+func (h *OrderHandler) GetRevenueByPeriod(c *gin.Context) {
+	var req dto.OrderAnalyticsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid revenue request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid query parameters",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	startDate, endDate := analyticsRange(&req)
+	groupBy := req.GroupBy
+	if groupBy == "" {
+		groupBy = "day"
+	}
+
+	revenue, err := h.orderService.GetRevenueByPeriod(c, &order.GetRevenueByPeriodRequest{
+		StartDate: startDate,
+		EndDate:   endDate,
+		GroupBy:   groupBy,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to get revenue by period")
+		handleOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.RevenueByPeriodResponse{
+		Period:      groupBy,
+		Currency:    h.orderService.BaseCurrency(),
+		RevenueData: revenueToResponse(revenue),
+	})
+}
+
+// GetTopCustomers retrieves the highest revenue customers
+// @Summary Get top customers
+// @Description Get the customers with the highest order revenue
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Param limit query int false "Number of customers" default(10)
+// @Success 200 {array} dto.CustomerStats
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/analytics/top-customers [get]
+func (h *OrderHandler) GetTopCustomers(c *gin.Context) {
+	var req dto.OrderAnalyticsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid top customers request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid query parameters",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	startDate, endDate := analyticsRange(&req)
+	customers, err := h.orderService.GetTopCustomers(c, &order.GetTopCustomersRequest{
+		StartDate: startDate,
+		EndDate:   endDate,
+		Limit:     req.Limit,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to get top customers")
+		handleOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, topCustomersToResponse(customers))
+}
+
+// GetSalesByProduct retrieves sales aggregated per product
+// @Summary Get sales by product
+// @Description Get the best selling products by revenue
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Param limit query int false "Number of products" default(10)
+// @Success 200 {array} dto.ProductStats
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/analytics/top-products [get]
+func (h *OrderHandler) GetSalesByProduct(c *gin.Context) {
+	var req dto.OrderAnalyticsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid sales by product request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid query parameters",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	startDate, endDate := analyticsRange(&req)
+	products, err := h.orderService.GetSalesByProduct(c, &order.GetSalesByProductRequest{
+		StartDate: startDate,
+		EndDate:   endDate,
+		Limit:     req.Limit,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to get sales by product")
+		handleOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, topProductsToResponse(products))
+}
+
+// GetOrderAnalytics retrieves combined order analytics
+// @Summary Get order analytics
+// @Description Get order statistics, revenue, top customers and top products in one call
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Param group_by query string false "Grouping" Enums(day,week,month,year) default("day")
+// @Param customer_id query string false "Customer ID"
+// @Success 200 {object} dto.OrderAnalyticsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/analytics [get]
+func (h *OrderHandler) GetOrderAnalytics(c *gin.Context) {
+	var req dto.OrderAnalyticsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid order analytics request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid query parameters",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	startDate, endDate := analyticsRange(&req)
+	groupBy := req.GroupBy
+	if groupBy == "" {
+		groupBy = "day"
+	}
+
+	analytics, err := h.orderService.GetOrderAnalytics(c, &order.GetOrderAnalyticsRequest{
+		StartDate:  startDate,
+		EndDate:    endDate,
+		GroupBy:    groupBy,
+		CustomerID: req.CustomerID,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to get order analytics")
+		handleOrderError(c, err)
+		return
+	}
+
+	summary := orderStatsToResponse(analytics.OrderStats)
+	summary.TopCustomers = topCustomersToResponse(analytics.TopCustomers)
+	summary.TopProducts = topProductsToResponse(analytics.TopProducts)
+
+	c.JSON(http.StatusOK, dto.OrderAnalyticsResponse{
+		Period:          groupBy,
+		Summary:         *summary,
+		RevenueByPeriod: revenueToResponse(analytics.RevenueByPeriod),
+		OrdersByStatus:  summary.OrdersByStatus,
+		TopProducts:     summary.TopProducts,
+		TopCustomers:    summary.TopCustomers,
+	})
+}
+
+// Inventory
+
+// CheckInventoryAvailability checks stock for a set of order lines
+// @Summary Check inventory availability
+// @Description Check whether stock is available for the given products and quantities
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param check body dto.CheckInventoryRequest true "Items to check"
+// @Success 200 {object} dto.CheckInventoryResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/inventory/check [post]
+func (h *OrderHandler) CheckInventoryAvailability(c *gin.Context) {
+	var req dto.CheckInventoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid inventory check request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	serviceReq := &order.CheckInventoryRequest{
+		Items: make([]order.CheckInventoryItemRequest, len(req.Items)),
+	}
+	for i, item := range req.Items {
+		serviceReq.Items[i] = order.CheckInventoryItemRequest{
+			ProductID:   item.ProductID.String(),
+			Quantity:    int(item.Quantity),
+			WarehouseID: uuidPtrToPtrString(item.WarehouseID),
+		}
+	}
+
+	result, err := h.orderService.CheckInventoryAvailability(c, serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to check inventory availability")
+		handleOrderError(c, err)
+		return
+	}
+
+	response := dto.CheckInventoryResponse{
+		Available:  result.Available,
+		Items:      make([]dto.InventoryItemResult, len(result.Items)),
+		TotalValue: result.TotalValue,
+	}
+	for i, item := range result.Items {
+		response.Items[i] = dto.InventoryItemResult{
+			ProductID:        item.ProductID,
+			ProductName:      item.ProductName,
+			RequestedQty:     item.RequestedQty,
+			AvailableQty:     item.AvailableQty,
+			AllocatedQty:     item.AllocatedQty,
+			BackorderQty:     item.BackorderQty,
+			CanFulfill:       item.CanFulfill,
+			BackorderAllowed: item.BackorderAllowed,
+			UnitPrice:        item.UnitPrice,
+			TotalValue:       item.TotalValue,
+			Reason:           item.Reason,
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Bulk Operations
+
+// BulkUpdateStatus updates the status of multiple orders
+// @Summary Bulk update order status
+// @Description Update the status of multiple orders, reporting per-order results
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param bulk body dto.BulkUpdateStatusRequest true "Bulk status data"
+// @Success 200 {object} dto.BulkUpdateStatusResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/bulk/status [post]
+func (h *OrderHandler) BulkUpdateStatus(c *gin.Context) {
+	var req dto.BulkUpdateStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid bulk status update request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	serviceReq := &order.BulkUpdateStatusRequest{
+		OrderIDs:  req.OrderIDs,
+		Status:    entities.OrderStatus(req.Status),
+		Reason:    ptrStringToString(req.Notes),
+		Notify:    req.NotifyCustomer,
+		UpdatedBy: userID,
+	}
+
+	result, err := h.orderService.BulkUpdateStatus(h.statusContext(c), serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to bulk update order status")
+		handleOrderError(c, err)
+		return
+	}
+
+	response := dto.BulkUpdateStatusResponse{
+		UpdatedCount:  result.UpdatedCount,
+		FailedCount:   result.FailedCount,
+		UpdatedOrders: []string{},
+		FailedOrders:  []dto.FailedOperation{},
+	}
+	for _, r := range result.Results {
+		if r.Success {
+			response.UpdatedOrders = append(response.UpdatedOrders, r.OrderID)
+		} else {
+			response.FailedOrders = append(response.FailedOrders, dto.FailedOperation{OrderID: r.OrderID, Error: r.Error})
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// BulkCancelOrders cancels multiple orders
+// @Summary Bulk cancel orders
+// @Description Cancel multiple orders, reporting per-order results
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param bulk body dto.BulkCancelOrdersRequest true "Bulk cancellation data"
+// @Success 200 {object} dto.BulkCancelOrdersResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/orders/bulk/cancel [post]
+func (h *OrderHandler) BulkCancelOrders(c *gin.Context) {
+	var req dto.BulkCancelOrdersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid bulk cancel request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	serviceReq := &order.BulkCancelOrdersRequest{
+		OrderIDs:    req.OrderIDs,
+		Reason:      req.Reason,
+		Refund:      req.RefundPayment,
+		Notify:      req.NotifyCustomer,
+		CancelledBy: userID,
+	}
+
+	result, err := h.orderService.BulkCancelOrders(h.statusContext(c), serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to bulk cancel orders")
+		handleOrderError(c, err)
+		return
+	}
+
+	response := dto.BulkCancelOrdersResponse{
+		CancelledCount:  result.CancelledCount,
+		FailedCount:     result.FailedCount,
+		CancelledOrders: []string{},
+		FailedOrders:    []dto.FailedOperation{},
+	}
+	for _, r := range result.Results {
+		if r.Success {
+			response.CancelledOrders = append(response.CancelledOrders, r.OrderID)
+		} else {
+			response.FailedOrders = append(response.FailedOrders, dto.FailedOperation{OrderID: r.OrderID, Error: r.Error})
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Helper Methods
+
+// orderToResponse converts an order entity to a response DTO
+func (h *OrderHandler) orderToResponse(o *entities.Order) *dto.OrderResponse {
+	// Convert order items
+	items := make([]dto.OrderItemResponse, len(o.Items))
+	for i, item := range o.Items {
+		items[i] = orderItemToResponse(item)
+	}
+
+	// Get customer name from the relationship
+	customerName := ""
+	if o.Customer != nil {
+		customerName = o.Customer.FirstName + " " + o.Customer.LastName
+	}
+
+	promotions := make([]dto.AppliedPromotionResponse, len(o.Promotions))
+	for i, redemption := range o.Promotions {
+		promotions[i] = dto.AppliedPromotionResponse{
+			PromotionID: redemption.PromotionID,
+			Code:        redemption.PromotionCode,
+			RedeemedAt:  redemption.RedeemedAt,
+		}
+		if redemption.Promotion != nil {
+			promotions[i].Name = redemption.Promotion.Name
+			promotions[i].ActionType = string(redemption.Promotion.ActionType)
+		}
+	}
+
+	return &dto.OrderResponse{
+		ID:                      o.ID,
+		OrderNumber:             o.OrderNumber,
+		CustomerID:              o.CustomerID,
+		CustomerName:            customerName,
+		Type:                    string(o.Type),
+		Status:                  string(o.Status),
+		CreditHold:              o.CreditHold,
+		Priority:                string(o.Priority),
+		PaymentStatus:           string(o.PaymentStatus),
+		FulfillmentStatus:       string(o.Status), // Using Status as FulfillmentStatus
+		Currency:                o.Currency,
+		ExchangeRate:            o.ExchangeRate,
+		Subtotal:                o.Subtotal,
+		TaxAmount:               o.TaxAmount,
+		ShippingAmount:          o.ShippingAmount,
+		DiscountAmount:          o.DiscountAmount,
+		PromotionDiscountAmount: o.PromotionDiscountAmount,
+		Promotions:              promotions,
+		TotalAmount:             o.TotalAmount,
+		PaidAmount:              o.PaidAmount,
+		RefundedAmount:          o.RefundedAmount,
+		Weight:                  decimal.NewFromFloat(entities.CalculateShippingWeight(o)),
+		ShippingMethod:          string(o.ShippingMethod),
+		ShippingQuotes:          shippingQuotesToResponse(o.ShippingQuotes),
+		TrackingNumber:          ptrStringToString(o.TrackingNumber),
+		ShippingAddress:         h.addressToResponse(o.ShippingAddress),
+		BillingAddress:          h.addressToResponse(o.BillingAddress),
+		Items:                   items,
+		Notes:                   o.Notes,
+		CustomerNotes:           o.CustomerNotes,
+		InternalNotes:           o.InternalNotes,
+		RequiredDate:            o.RequiredDate,
+		ShippedDate:             o.ShippingDate,
+		DeliveredDate:           o.DeliveryDate,
+		CreatedAt:               o.CreatedAt,
+		UpdatedAt:               o.UpdatedAt,
+		ApprovedAt:              o.ApprovedAt,
+		ApprovedBy:              uuidPtrToPtrString(o.ApprovedBy),
+		CancelledAt:             o.CancelledDate,
+		CancelledBy:             nil, // No field in entity
+		CancellationReason:      nil, // No field in entity
+		ArchivedAt:              o.ArchivedAt,
+	}
+}
+
+// orderItemToResponse converts an order item entity to a response DTO
+func orderItemToResponse(item entities.OrderItem) dto.OrderItemResponse {
+	// Validate quantities are within int32 range
+	quantity := item.Quantity
+	if quantity > 0x7FFFFFFF || quantity < -0x80000000 {
+		quantity = 0 // Fallback to 0 if out of range
+	}
+	shippedQty := item.QuantityShipped
+	if shippedQty > 0x7FFFFFFF || shippedQty < -0x80000000 {
+		shippedQty = 0
+	}
+	returnedQty := item.QuantityReturned
+	if returnedQty > 0x7FFFFFFF || returnedQty < -0x80000000 {
+		returnedQty = 0
+	}
+	backorderedQty := item.QuantityBackordered
+	if backorderedQty > 0x7FFFFFFF || backorderedQty < -0x80000000 {
+		backorderedQty = 0
+	}
+	refundedQty := item.QuantityRefunded
+	if refundedQty > 0x7FFFFFFF || refundedQty < -0x80000000 {
+		refundedQty = 0
+	}
+
+	return dto.OrderItemResponse{
+		ID:                  item.ID,
+		ProductID:           item.ProductID,
+		ProductSKU:          item.ProductSKU,
+		ProductName:         item.ProductName,
+		Quantity:            int32(quantity), // #nosec G115 - Validated above
+		UnitPrice:           item.UnitPrice,
+		TotalPrice:          item.TotalPrice,
+		TaxRate:             item.TaxRate,
+		TaxClass:            item.TaxClass,
+		PriceIncludesTax:    item.PriceIncludesTax,
+		IsDropShip:          item.IsDropShip,
+		TaxAmount:           item.TaxAmount,
+		DiscountAmount:      item.DiscountAmount,
+		Weight:              decimal.NewFromFloat(item.Weight),
+		Status:              item.Status,
+		ShippedQuantity:     int32(shippedQty),     // #nosec G115 - Validated above
+		ReturnedQuantity:    int32(returnedQty),    // #nosec G115 - Validated above
+		BackorderedQuantity: int32(backorderedQty), // #nosec G115 - Validated above
+		RefundedQuantity:    int32(refundedQty),    // #nosec G115 - Validated above
+		Notes:               item.Notes,
+	}
+}
+
+// shipmentToResponse converts a shipment entity to a response DTO
+func shipmentToResponse(shipment *entities.Shipment) dto.ShipmentResponse {
+	response := dto.ShipmentResponse{
+		ID:             shipment.ID,
+		ShipmentNumber: shipment.ShipmentNumber,
+		Status:         string(shipment.Status),
+		Carrier:        shipment.Carrier,
+		TrackingNumber: shipment.TrackingNumber,
+		DeliveryProof:  shipment.DeliveryProof,
+		Items:          make([]dto.ShipmentItemResponse, len(shipment.Items)),
+		Packages:       make([]dto.ShipmentPackageResponse, len(shipment.Packages)),
+		ShippedBy:      shipment.ShippedBy,
+		DeliveredBy:    shipment.DeliveredBy,
+		ShippedAt:      shipment.ShippedAt,
+		DeliveredAt:    shipment.DeliveredAt,
+	}
+	for i, item := range shipment.Items {
+		response.Items[i] = dto.ShipmentItemResponse{
+			OrderItemID: item.OrderItemID,
+			ProductID:   item.ProductID,
+			Quantity:    item.Quantity,
+		}
+	}
+	for i, pkg := range shipment.Packages {
+		response.Packages[i] = dto.ShipmentPackageResponse{
+			ID:             pkg.ID,
+			TrackingNumber: pkg.TrackingNumber,
+			Weight:         pkg.Weight,
+			WeightUnit:     pkg.WeightUnit,
+			Length:         pkg.Length,
+			Width:          pkg.Width,
+			Height:         pkg.Height,
+			DimensionUnit:  pkg.DimensionUnit,
+		}
+	}
+	return response
+}
+
+// shipmentPackages converts package requests into service requests
+func shipmentPackages(packages []dto.ShipmentPackageRequest) []order.ShipmentPackageRequest {
+	if len(packages) == 0 {
+		return nil
+	}
+	result := make([]order.ShipmentPackageRequest, len(packages))
+	for i, pkg := range packages {
+		result[i] = order.ShipmentPackageRequest{
+			TrackingNumber: pkg.TrackingNumber,
+			Weight:         pkg.Weight,
+			WeightUnit:     pkg.WeightUnit,
+			Length:         pkg.Length,
+			Width:          pkg.Width,
+			Height:         pkg.Height,
+			DimensionUnit:  pkg.DimensionUnit,
+		}
+	}
+	return result
+}
+
+// addressToResponse converts an address entity to a response DTO
+func (h *OrderHandler) addressToResponse(addr *entities.OrderAddress) *dto.AddressResponse {
+	if addr == nil {
+		return nil
+	}
+
+	return &dto.AddressResponse{
+		ID:         addr.ID,
+		Type:       addr.Type,
+		FirstName:  addr.FirstName,
+		LastName:   addr.LastName,
+		Company:    addr.Company,
+		Address1:   addr.AddressLine1,
+		Address2:   addr.AddressLine2,
+		City:       addr.City,
+		State:      addr.State,
+		PostalCode: addr.PostalCode,
+		Country:    addr.Country,
+		Phone:      addr.Phone,
+		Email:      addr.Email,
+		IsDefault:  addr.IsDefault,
+	}
+}
+
+// orderRevisionToResponse converts an order revision to a response DTO
+func orderRevisionToResponse(revision *entities.OrderRevision) dto.OrderRevisionResponse {
+	response := dto.OrderRevisionResponse{
+		ID:              revision.ID,
+		OrderID:         revision.OrderID,
+		RevisionNumber:  revision.RevisionNumber,
+		Status:          string(revision.Status),
+		Reason:          revision.Reason,
+		Changes:         make([]dto.OrderRevisionChangeResponse, len(revision.Changes)),
+		ApproverRoles:   revision.ApproverRoles,
+		NextApprover:    revision.NextApproverRole(),
+		ApprovalAmount:  revision.ApprovalAmount,
+		CreatedBy:       revision.CreatedBy,
+		CreatedAt:       revision.CreatedAt,
+		AppliedAt:       revision.AppliedAt,
+		RejectedBy:      revision.RejectedBy,
+		RejectedAt:      revision.RejectedAt,
+		RejectionReason: revision.RejectionReason,
+	}
+	for i, change := range revision.Changes {
+		response.Changes[i] = dto.OrderRevisionChangeResponse{
+			Field:       change.Field,
+			OrderItemID: change.OrderItemID,
+			ProductSKU:  change.ProductSKU,
+			From:        change.From,
+			To:          change.To,
+		}
+	}
+	for _, approval := range revision.Approvals {
+		response.Approvals = append(response.Approvals, dto.OrderRevisionApprovalResponse{
+			Role:       approval.Role,
+			ApprovedBy: approval.ApprovedBy,
+			ApprovedAt: approval.ApprovedAt,
+		})
+	}
+
+	snapshot := revision.Snapshot
+	response.Snapshot = dto.OrderSnapshotResponse{
+		Lines:             make([]dto.OrderSnapshotLineResponse, len(snapshot.Lines)),
+		ShippingAddressID: snapshot.ShippingAddressID,
+		BillingAddressID:  snapshot.BillingAddressID,
+		Subtotal:          snapshot.Subtotal,
+		TaxAmount:         snapshot.TaxAmount,
+		ShippingAmount:    snapshot.ShippingAmount,
+		DiscountAmount:    snapshot.DiscountAmount,
+		TotalAmount:       snapshot.TotalAmount,
+		Currency:          snapshot.Currency,
+	}
+	for i, line := range snapshot.Lines {
+		response.Snapshot.Lines[i] = dto.OrderSnapshotLineResponse{
+			OrderItemID:     line.OrderItemID,
+			ProductID:       line.ProductID,
+			ProductSKU:      line.ProductSKU,
+			ProductName:     line.ProductName,
+			Quantity:        line.Quantity,
+			QuantityShipped: line.QuantityShipped,
+			UnitPrice:       line.UnitPrice,
+			DiscountAmount:  line.DiscountAmount,
+			TaxAmount:       line.TaxAmount,
+			TotalPrice:      line.TotalPrice,
+		}
+	}
+
+	return response
+}
+
+// revisionNumber parses the revision number path parameter, responding 400 when invalid
+func revisionNumber(c *gin.Context) (int, bool) {
+	number, err := strconv.Atoi(c.Param("number"))
+	if err != nil || number <= 0 {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid revision number",
+			Details: "Revision number must be a positive integer",
+		})
+		return 0, false
+	}
+	return number, true
+}
+
+// handleOrderError handles order service errors
+func handleOrderError(c *gin.Context, err error) {
 	switch {
-	case err.Error() == "order not found":
+	case errors.Is(err, order.ErrOrderNotFound):
 		c.JSON(http.StatusNotFound, dto.ErrorResponse{
 			Error:   "Order not found",
 			Details: err.Error(),
 		})
-	case err.Error() == "order already exists":
+	case errors.Is(err, order.ErrOrderItemNotFound), errors.Is(err, order.ErrCustomerNotFound),
+		errors.Is(err, order.ErrProductNotFound), errors.Is(err, order.ErrShipmentNotFound),
+		errors.Is(err, order.ErrPaymentNotFound), errors.Is(err, order.ErrPromotionNotFound),
+		errors.Is(err, order.ErrPromotionNotApplied), errors.Is(err, order.ErrApprovalPolicyNotFound),
+		errors.Is(err, order.ErrSourcingRuleNotFound), errors.Is(err, order.ErrOrderRevisionNotFound),
+		errors.Is(err, order.ErrDropShipOrderNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:   "Resource not found",
+			Details: err.Error(),
+		})
+	case errors.Is(err, order.ErrOrderAlreadyExists), errors.Is(err, order.ErrOrderAlreadyPaid),
+		errors.Is(err, order.ErrInvalidStatusTransition), errors.Is(err, order.ErrOrderCannotBeModified),
+		errors.Is(err, order.ErrOrderCannotBeCancelled), errors.Is(err, order.ErrOrderCannotBeShipped),
+		errors.Is(err, order.ErrOrderCannotBeReturned), errors.Is(err, order.ErrInvalidOrderStatus),
+		errors.Is(err, order.ErrOrderCannotBeDelivered), errors.Is(err, order.ErrPaymentAlreadyReversed),
+		errors.Is(err, order.ErrPaymentCannotBeReversed), errors.Is(err, order.ErrOrderNotOnCreditHold),
+		errors.Is(err, order.ErrOrderNotAwaitingApproval), errors.Is(err, order.ErrOrderNotSourceable),
+		errors.Is(err, order.ErrOrderNotAmendable), errors.Is(err, order.ErrRevisionPending),
+		errors.Is(err, order.ErrRevisionNotPending), errors.Is(err, order.ErrOrderNotArchivable),
+		errors.Is(err, order.ErrOrderAlreadyArchived), errors.Is(err, order.ErrOrderNotArchived):
 		c.JSON(http.StatusConflict, dto.ErrorResponse{
-			Error:   "Order already exists",
+			Error:   "Order state conflict",
 			Details: err.Error(),
 		})
-	case err.Error() == "invalid order status":
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:   "Invalid order status",
+	case errors.Is(err, order.ErrApproverNotAuthorized):
+		c.JSON(http.StatusForbidden, dto.ErrorResponse{
+			Error:   "Approval not permitted",
 			Details: err.Error(),
 		})
-	case err.Error() == "cannot cancel processed order":
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:   "Cannot cancel processed order",
+	case errors.Is(err, order.ErrPromotionExists), errors.Is(err, order.ErrPromotionNotStackable),
+		errors.Is(err, order.ErrPromotionUsageLimitReached):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error:   "Promotion conflict",
 			Details: err.Error(),
 		})
-	case err.Error() == "insufficient inventory":
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+	case errors.Is(err, order.ErrPromotionNotApplicable):
+		c.JSON(http.StatusUnprocessableEntity, dto.ErrorResponse{
+			Error:   "Promotion not applicable",
+			Details: err.Error(),
+		})
+	case errors.Is(err, order.ErrCreditLimitExceeded):
+		c.JSON(http.StatusUnprocessableEntity, dto.ErrorResponse{
+			Error:   "Credit limit exceeded",
+			Details: err.Error(),
+		})
+	case errors.Is(err, order.ErrExchangeRateUnavailable):
+		c.JSON(http.StatusUnprocessableEntity, dto.ErrorResponse{
+			Error:   "Exchange rate unavailable",
+			Details: err.Error(),
+		})
+	case errors.Is(err, order.ErrInsufficientInventory), errors.Is(err, order.ErrInventoryReservationFailed):
+		c.JSON(http.StatusUnprocessableEntity, dto.ErrorResponse{
 			Error:   "Insufficient inventory",
 			Details: err.Error(),
 		})
+	case errors.Is(err, order.ErrInvalidQuantity), errors.Is(err, order.ErrInvalidAddress),
+		errors.Is(err, order.ErrInvalidPaymentAmount), errors.Is(err, order.ErrInvalidDiscount),
+		errors.Is(err, order.ErrInvalidTaxRate), errors.Is(err, order.ErrOrderNotPaid),
+		errors.Is(err, order.ErrRefundFailed), errors.Is(err, order.ErrInvalidOrderNumber),
+		errors.Is(err, order.ErrInvalidPayment), errors.Is(err, order.ErrInvalidCurrency),
+		errors.Is(err, order.ErrInvalidAmendment), errors.Is(err, order.ErrInvalidDropShip),
+		errors.Is(err, order.ErrInvalidDropShipment), strings.HasPrefix(err.Error(), "invalid "):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request",
+			Details: err.Error(),
+		})
 	default:
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error:   "Internal server error",
@@ -861,6 +3253,111 @@ func handleOrderError(c *gin.Context, err error) {
 	}
 }
 
+// currentUser returns the authenticated user's ID, responding 401 when absent
+func (h *OrderHandler) currentUser(c *gin.Context) (string, bool) {
+	userID, exists := auth.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "User not authenticated",
+			Details: "Authentication required",
+		})
+		return "", false
+	}
+	return userID.String(), true
+}
+
+// statusContext attributes status changes made while serving the request to
+// the authenticated user
+func (h *OrderHandler) statusContext(c *gin.Context) context.Context {
+	userID := ""
+	if id, exists := auth.GetCurrentUserID(c); exists {
+		userID = id.String()
+	}
+	return order.WithStatusChangeActor(c, userID, entities.StatusChangeSourceAPI)
+}
+
+// analyticsRange resolves the analytics date range, defaulting to the last 30 days
+func analyticsRange(req *dto.OrderAnalyticsRequest) (time.Time, time.Time) {
+	endDate := time.Now().UTC()
+	if req.EndDate != nil {
+		endDate = *req.EndDate
+	}
+	startDate := endDate.AddDate(0, 0, -30)
+	if req.StartDate != nil {
+		startDate = *req.StartDate
+	}
+	return startDate, endDate
+}
+
+// orderStatsToResponse converts repository order stats to a response DTO
+func orderStatsToResponse(stats *repositories.OrderStats) *dto.OrderStatsResponse {
+	return &dto.OrderStatsResponse{
+		TotalOrders:       stats.TotalOrders,
+		TotalRevenue:      stats.TotalRevenue,
+		Currency:          stats.Currency,
+		OrdersByStatus:    stats.StatusCounts,
+		OrdersByType:      map[string]int64{},
+		TopProducts:       []dto.ProductStats{},
+		TopCustomers:      []dto.CustomerStats{},
+		AverageOrderValue: stats.AverageOrderValue,
+	}
+}
+
+// revenueToResponse converts revenue periods to response data points
+func revenueToResponse(revenue []*repositories.RevenueByPeriod) []dto.RevenueDataPoint {
+	points := make([]dto.RevenueDataPoint, len(revenue))
+	for i, r := range revenue {
+		points[i] = dto.RevenueDataPoint{
+			Period:            parsePeriod(r.Period),
+			Revenue:           r.Revenue,
+			Orders:            r.OrderCount,
+			AverageOrderValue: r.AverageOrderValue,
+		}
+	}
+	return points
+}
+
+// topCustomersToResponse converts customer order stats to response DTOs
+func topCustomersToResponse(customers []*repositories.CustomerOrderStats) []dto.CustomerStats {
+	stats := make([]dto.CustomerStats, len(customers))
+	for i, customer := range customers {
+		stats[i] = dto.CustomerStats{
+			CustomerID:   customer.CustomerID,
+			CustomerName: customer.CustomerName,
+			Email:        customer.CustomerEmail,
+			TotalOrders:  customer.OrderCount,
+			TotalRevenue: customer.TotalRevenue,
+			Currency:     customer.Currency,
+		}
+	}
+	return stats
+}
+
+// topProductsToResponse converts product sales stats to response DTOs
+func topProductsToResponse(products []*repositories.ProductSalesStats) []dto.ProductStats {
+	stats := make([]dto.ProductStats, len(products))
+	for i, product := range products {
+		stats[i] = dto.ProductStats{
+			ProductID:    product.ProductID,
+			ProductName:  product.ProductName,
+			SKU:          product.ProductSKU,
+			TotalSales:   product.QuantitySold,
+			TotalRevenue: product.TotalRevenue,
+		}
+	}
+	return stats
+}
+
+// parsePeriod parses a revenue period label into the start of the period
+func parsePeriod(period string) time.Time {
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02", "2006-01", "2006"} {
+		if t, err := time.Parse(layout, period); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
 // uuidPtrToString converts a UUID pointer to string
 func uuidPtrToString(ptr *string) string {
 	if ptr == nil {
@@ -0,0 +1,408 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"erpgo/internal/application/services/order"
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/interfaces/http/dto"
+	"erpgo/pkg/auth"
+)
+
+// maxOrderImportFileSize bounds uploaded order import files
+const maxOrderImportFileSize = 20 << 20
+
+// OrderImportHandler handles order import and import profile HTTP requests
+type OrderImportHandler struct {
+	orderService   order.Service
+	profileService order.OrderImportProfileService
+	logger         zerolog.Logger
+}
+
+// NewOrderImportHandler creates a new order import handler
+func NewOrderImportHandler(orderService order.Service, profileService order.OrderImportProfileService, logger zerolog.Logger) *OrderImportHandler {
+	return &OrderImportHandler{
+		orderService:   orderService,
+		profileService: profileService,
+		logger:         logger,
+	}
+}
+
+// ImportOrders imports orders from a partner file
+// @Summary Import orders
+// @Description Import a CSV file with one row per line item, or a JSON array of line items or of orders with nested items, through an import profile. Rows are grouped into orders by external reference and validated before anything is created; when any row has errors no order is created and the errors are reported per row. References already imported from the profile's source are skipped.
+// @Tags order-imports
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "Order file"
+// @Param profile_id formData string true "Import profile ID"
+// @Param dry_run formData bool false "Validate the file without creating orders"
+// @Success 200 {object} dto.ImportOrdersResponse "Dry run, nothing to create, or rows with errors"
+// @Success 201 {object} dto.ImportOrdersResponse "Orders created"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/order-imports [post]
+func (h *OrderImportHandler) ImportOrders(c *gin.Context) {
+	file, err := c.FormFile("file")
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to get order import file")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "No file uploaded",
+			Details: "Please select an order file to import",
+		})
+		return
+	}
+	if file.Size > maxOrderImportFileSize {
+		c.JSON(http.StatusRequestEntityTooLarge, dto.ErrorResponse{
+			Error:   "File too large",
+			Details: "Order import files are limited to 20MB",
+		})
+		return
+	}
+
+	dryRun := false
+	if value := strings.TrimSpace(c.PostForm("dry_run")); value != "" {
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Invalid request",
+				Details: "dry_run must be true or false",
+			})
+			return
+		}
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	content, err := file.Open()
+	if err != nil {
+		h.logger.Error().Err(err).Str("filename", file.Filename).Msg("Failed to open order import file")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Failed to read file",
+			Details: err.Error(),
+		})
+		return
+	}
+	defer content.Close()
+
+	result, err := h.orderService.ImportOrders(c, &order.ImportOrdersRequest{
+		ProfileID:  c.PostForm("profile_id"),
+		File:       content,
+		DryRun:     dryRun,
+		ImportedBy: userID,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("filename", file.Filename).Msg("Failed to import orders")
+		handleOrderImportError(c, err)
+		return
+	}
+
+	status := http.StatusOK
+	if result.Committed {
+		status = http.StatusCreated
+	}
+	c.JSON(status, importOrdersToResponse(result))
+}
+
+// CreateOrderImportProfile creates an order import profile
+// @Summary Create order import profile
+// @Description Create a mapping of a partner's CSV or JSON order files onto order fields. Fields not mapped are read from a column named after the field; defaults fill fields the file leaves empty.
+// @Tags order-imports
+// @Accept json
+// @Produce json
+// @Param profile body dto.CreateOrderImportProfileRequest true "Import profile"
+// @Success 201 {object} dto.OrderImportProfileResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/order-imports/profiles [post]
+func (h *OrderImportHandler) CreateOrderImportProfile(c *gin.Context) {
+	var req dto.CreateOrderImportProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid order import profile request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	profile, err := h.profileService.CreateOrderImportProfile(c, &order.CreateOrderImportProfileRequest{
+		Name:        req.Name,
+		Description: req.Description,
+		Source:      req.Source,
+		Format:      entities.OrderImportFormat(req.Format),
+		Delimiter:   req.Delimiter,
+		Columns:     importFieldsFromRequest(req.Columns),
+		Defaults:    importFieldsFromRequest(req.Defaults),
+		CreatedBy:   userID,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("name", req.Name).Msg("Failed to create order import profile")
+		handleOrderImportError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, orderImportProfileToResponse(profile))
+}
+
+// GetOrderImportProfile retrieves an order import profile by ID
+// @Summary Get order import profile
+// @Description Get an order import profile with its column mapping and defaults
+// @Tags order-imports
+// @Produce json
+// @Param id path string true "Import profile ID"
+// @Success 200 {object} dto.OrderImportProfileResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/order-imports/profiles/{id} [get]
+func (h *OrderImportHandler) GetOrderImportProfile(c *gin.Context) {
+	id := c.Param("id")
+
+	profile, err := h.profileService.GetOrderImportProfile(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("profile_id", id).Msg("Failed to get order import profile")
+		handleOrderImportError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, orderImportProfileToResponse(profile))
+}
+
+// UpdateOrderImportProfile updates an order import profile
+// @Summary Update order import profile
+// @Description Update the mapping or status of an order import profile. Columns and defaults are replaced when given.
+// @Tags order-imports
+// @Accept json
+// @Produce json
+// @Param id path string true "Import profile ID"
+// @Param profile body dto.UpdateOrderImportProfileRequest true "Import profile update"
+// @Success 200 {object} dto.OrderImportProfileResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/order-imports/profiles/{id} [put]
+func (h *OrderImportHandler) UpdateOrderImportProfile(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.UpdateOrderImportProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid order import profile update request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	serviceReq := &order.UpdateOrderImportProfileRequest{
+		Name:        req.Name,
+		Description: req.Description,
+		Source:      req.Source,
+		Delimiter:   req.Delimiter,
+		Columns:     importFieldsFromRequest(req.Columns),
+		Defaults:    importFieldsFromRequest(req.Defaults),
+		IsActive:    req.IsActive,
+	}
+	if req.Format != nil {
+		format := entities.OrderImportFormat(*req.Format)
+		serviceReq.Format = &format
+	}
+
+	profile, err := h.profileService.UpdateOrderImportProfile(c, id, serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Str("profile_id", id).Msg("Failed to update order import profile")
+		handleOrderImportError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, orderImportProfileToResponse(profile))
+}
+
+// ListOrderImportProfiles lists order import profiles
+// @Summary List order import profiles
+// @Description List order import profiles by name
+// @Tags order-imports
+// @Produce json
+// @Param search query string false "Name"
+// @Param source query string false "Source"
+// @Param is_active query bool false "Active profiles only"
+// @Param page query int false "Page number"
+// @Param limit query int false "Page size"
+// @Success 200 {object} dto.ListOrderImportProfilesResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/order-imports/profiles [get]
+func (h *OrderImportHandler) ListOrderImportProfiles(c *gin.Context) {
+	var req dto.ListOrderImportProfilesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid order import profile list request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid query parameters",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	result, err := h.profileService.ListOrderImportProfiles(c, &order.ListOrderImportProfilesRequest{
+		Search:   req.Search,
+		Source:   req.Source,
+		IsActive: req.IsActive,
+		Page:     req.Page,
+		Limit:    req.Limit,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to list order import profiles")
+		handleOrderImportError(c, err)
+		return
+	}
+
+	profiles := make([]*dto.OrderImportProfileResponse, len(result.Profiles))
+	for i, profile := range result.Profiles {
+		profiles[i] = orderImportProfileToResponse(profile)
+	}
+
+	c.JSON(http.StatusOK, &dto.ListOrderImportProfilesResponse{
+		Profiles: profiles,
+		Pagination: &dto.Pagination{
+			Page:       result.Pagination.Page,
+			Limit:      result.Pagination.Limit,
+			Total:      result.Pagination.Total,
+			TotalPages: result.Pagination.TotalPages,
+			HasNext:    result.Pagination.HasNext,
+			HasPrev:    result.Pagination.HasPrev,
+		},
+	})
+}
+
+func (h *OrderImportHandler) currentUser(c *gin.Context) (string, bool) {
+	userID, exists := auth.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "User not authenticated",
+			Details: "Authentication required",
+		})
+		return "", false
+	}
+	return userID.String(), true
+}
+
+// importFieldsFromRequest keys a column mapping or defaults by order field
+func importFieldsFromRequest(values map[string]string) map[entities.OrderImportField]string {
+	if values == nil {
+		return nil
+	}
+	fields := make(map[entities.OrderImportField]string, len(values))
+	for field, value := range values {
+		fields[entities.OrderImportField(strings.ToLower(strings.TrimSpace(field)))] = value
+	}
+	return fields
+}
+
+// importFieldsToResponse keys a column mapping or defaults by field name
+func importFieldsToResponse(values map[entities.OrderImportField]string) map[string]string {
+	fields := make(map[string]string, len(values))
+	for field, value := range values {
+		fields[string(field)] = value
+	}
+	return fields
+}
+
+// orderImportProfileToResponse converts an order import profile entity to a response DTO
+func orderImportProfileToResponse(p *entities.OrderImportProfile) *dto.OrderImportProfileResponse {
+	return &dto.OrderImportProfileResponse{
+		ID:          p.ID,
+		Name:        p.Name,
+		Description: p.Description,
+		Source:      p.Source,
+		Format:      string(p.Format),
+		Delimiter:   p.Delimiter,
+		Columns:     importFieldsToResponse(p.Columns),
+		Defaults:    importFieldsToResponse(p.Defaults),
+		IsActive:    p.IsActive,
+		CreatedBy:   p.CreatedBy,
+		CreatedAt:   p.CreatedAt,
+		UpdatedAt:   p.UpdatedAt,
+	}
+}
+
+// importOrdersToResponse converts an import report to a response DTO
+func importOrdersToResponse(result *order.ImportOrdersResponse) *dto.ImportOrdersResponse {
+	response := &dto.ImportOrdersResponse{
+		ProfileID:   result.ProfileID,
+		Source:      result.Source,
+		DryRun:      result.DryRun,
+		Committed:   result.Committed,
+		TotalRows:   result.TotalRows,
+		TotalOrders: result.TotalOrders,
+		Created:     importedOrdersToResponse(result.Created),
+		Skipped:     importedOrdersToResponse(result.Skipped),
+		Errors:      make([]dto.OrderImportErrorResponse, len(result.Errors)),
+	}
+	for i, importErr := range result.Errors {
+		response.Errors[i] = dto.OrderImportErrorResponse{
+			Row:               importErr.Row,
+			Item:              importErr.Item,
+			ExternalReference: importErr.ExternalReference,
+			Field:             string(importErr.Field),
+			Message:           importErr.Message,
+		}
+	}
+	return response
+}
+
+func importedOrdersToResponse(orders []order.ImportedOrder) []dto.ImportedOrderResponse {
+	response := make([]dto.ImportedOrderResponse, len(orders))
+	for i, imported := range orders {
+		response[i] = dto.ImportedOrderResponse{
+			ExternalReference: imported.ExternalReference,
+			OrderID:           imported.OrderID,
+			OrderNumber:       imported.OrderNumber,
+			Rows:              imported.Rows,
+			TotalAmount:       imported.TotalAmount,
+			Currency:          imported.Currency,
+		}
+	}
+	return response
+}
+
+// handleOrderImportError maps order import service errors to HTTP responses
+func handleOrderImportError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, order.ErrOrderImportProfileNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:   "Order import profile not found",
+			Details: err.Error(),
+		})
+	case errors.Is(err, order.ErrOrderImportProfileInactive), errors.Is(err, order.ErrOrderImportConflict):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error:   "Order import conflict",
+			Details: err.Error(),
+		})
+	case errors.Is(err, order.ErrInvalidImportFile):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid import file",
+			Details: err.Error(),
+		})
+	default:
+		handleOrderError(c, err)
+	}
+}
@@ -0,0 +1,460 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"erpgo/internal/application/services/order"
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/interfaces/http/dto"
+	"erpgo/pkg/auth"
+)
+
+// PaymentHandler handles payment ledger HTTP requests
+type PaymentHandler struct {
+	orderService order.Service
+	logger       zerolog.Logger
+}
+
+// NewPaymentHandler creates a new payment handler
+func NewPaymentHandler(orderService order.Service, logger zerolog.Logger) *PaymentHandler {
+	return &PaymentHandler{
+		orderService: orderService,
+		logger:       logger,
+	}
+}
+
+// RecordPayment records a payment received from a customer
+// @Summary Record payment
+// @Description Record money received from a customer, optionally allocating it across several of the customer's orders. The rest can be allocated later.
+// @Tags payments
+// @Accept json
+// @Produce json
+// @Param payment body dto.RecordPaymentRequest true "Payment data"
+// @Success 201 {object} dto.PaymentResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/payments [post]
+func (h *PaymentHandler) RecordPayment(c *gin.Context) {
+	var req dto.RecordPaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid payment request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	ctx := order.WithStatusChangeActor(c, userID, entities.StatusChangeSourceAPI)
+	payment, err := h.orderService.RecordPayment(ctx, &order.RecordPaymentRequest{
+		CustomerID:  req.CustomerID.String(),
+		Method:      req.Method,
+		Reference:   ptrStringToString(req.Reference),
+		Amount:      req.Amount,
+		Currency:    req.Currency,
+		ReceivedAt:  req.ReceivedAt,
+		Notes:       req.Notes,
+		Allocations: allocationsFromRequest(req.Allocations),
+		ReceivedBy:  userID,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("customer_id", req.CustomerID.String()).Msg("Failed to record payment")
+		handleOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, paymentToResponse(payment))
+}
+
+// GetPayment retrieves a ledger entry by ID
+// @Summary Get payment
+// @Description Get a payment or refund with its allocations
+// @Tags payments
+// @Produce json
+// @Param id path string true "Payment ID"
+// @Success 200 {object} dto.PaymentResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/payments/{id} [get]
+func (h *PaymentHandler) GetPayment(c *gin.Context) {
+	id := c.Param("id")
+
+	payment, err := h.orderService.GetPayment(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("payment_id", id).Msg("Failed to get payment")
+		handleOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, paymentToResponse(payment))
+}
+
+// GetOrderPayments retrieves the ledger entries of an order
+// @Summary Get payments of order
+// @Description Get the payments and refunds allocated to an order, oldest first, including reversed entries
+// @Tags payments
+// @Produce json
+// @Param order_id path string true "Order ID"
+// @Success 200 {array} dto.PaymentResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/payments/order/{order_id} [get]
+func (h *PaymentHandler) GetOrderPayments(c *gin.Context) {
+	orderID := c.Param("order_id")
+
+	payments, err := h.orderService.GetOrderPayments(c, orderID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("order_id", orderID).Msg("Failed to get payments of order")
+		handleOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, paymentsToResponse(payments))
+}
+
+// ListPayments lists ledger entries
+// @Summary List payments
+// @Description List payments and refunds with filtering and pagination. Filter by reference and received date range to reconcile a bank statement.
+// @Tags payments
+// @Produce json
+// @Param search query string false "Payment number or reference"
+// @Param type query string false "PAYMENT or REFUND"
+// @Param method query string false "Payment method"
+// @Param status query string false "COMPLETED or REVERSED"
+// @Param customer_id query string false "Customer ID"
+// @Param order_id query string false "Order ID"
+// @Param received_from query string false "Received on or after (YYYY-MM-DD)"
+// @Param received_to query string false "Received on or before (YYYY-MM-DD)"
+// @Param unallocated query bool false "Only payments with an unallocated amount"
+// @Param page query int false "Page number"
+// @Param limit query int false "Page size"
+// @Success 200 {object} dto.ListPaymentsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/payments [get]
+func (h *PaymentHandler) ListPayments(c *gin.Context) {
+	var req dto.ListPaymentsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid payment list request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid query parameters",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	serviceReq := &order.ListPaymentsRequest{
+		Search:       req.Search,
+		CustomerID:   req.CustomerID,
+		OrderID:      req.OrderID,
+		ReceivedFrom: req.ReceivedFrom,
+		Unallocated:  req.Unallocated,
+		Page:         req.Page,
+		Limit:        req.Limit,
+	}
+	if req.ReceivedTo != nil {
+		// Include the whole final day
+		receivedTo := req.ReceivedTo.Add(24*time.Hour - time.Nanosecond)
+		serviceReq.ReceivedTo = &receivedTo
+	}
+	if req.Type != nil {
+		paymentType := entities.PaymentType(*req.Type)
+		serviceReq.Type = &paymentType
+	}
+	if req.Method != nil {
+		method, err := entities.ParsePaymentMethod(*req.Method)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Invalid query parameters",
+				Details: err.Error(),
+			})
+			return
+		}
+		serviceReq.Method = []entities.PaymentMethod{method}
+	}
+	if req.Status != nil {
+		serviceReq.Status = []entities.PaymentEntryStatus{entities.PaymentEntryStatus(*req.Status)}
+	}
+
+	result, err := h.orderService.ListPayments(c, serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to list payments")
+		handleOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, &dto.ListPaymentsResponse{
+		Payments: paymentsToResponse(result.Payments),
+		Pagination: &dto.Pagination{
+			Page:       result.Pagination.Page,
+			Limit:      result.Pagination.Limit,
+			Total:      result.Pagination.Total,
+			TotalPages: result.Pagination.TotalPages,
+			HasNext:    result.Pagination.HasNext,
+			HasPrev:    result.Pagination.HasPrev,
+		},
+	})
+}
+
+// AllocatePayment applies the unallocated part of a payment to orders
+// @Summary Allocate payment
+// @Description Apply the unallocated part of a payment to orders of the same customer and currency
+// @Tags payments
+// @Accept json
+// @Produce json
+// @Param id path string true "Payment ID"
+// @Param allocation body dto.AllocatePaymentRequest true "Allocations"
+// @Success 200 {object} dto.PaymentResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/payments/{id}/allocate [post]
+func (h *PaymentHandler) AllocatePayment(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.AllocatePaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid payment allocation request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	ctx := order.WithStatusChangeActor(c, userID, entities.StatusChangeSourceAPI)
+	payment, err := h.orderService.AllocatePayment(ctx, id, &order.AllocatePaymentRequest{
+		Allocations: allocationsFromRequest(req.Allocations),
+		AllocatedBy: userID,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("payment_id", id).Msg("Failed to allocate payment")
+		handleOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, paymentToResponse(payment))
+}
+
+// ReversePayment reverses a payment or refund
+// @Summary Reverse payment
+// @Description Reverse a payment or refund, e.g. for a bounced cheque or a charge-back. The payment status of every order it was allocated to is derived again.
+// @Tags payments
+// @Accept json
+// @Produce json
+// @Param id path string true "Payment ID"
+// @Param reversal body dto.ReversePaymentRequest true "Reversal reason"
+// @Success 200 {object} dto.PaymentResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/payments/{id}/reverse [post]
+func (h *PaymentHandler) ReversePayment(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.ReversePaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid payment reversal request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	ctx := order.WithStatusChangeActor(c, userID, entities.StatusChangeSourceAPI)
+	payment, err := h.orderService.ReversePayment(ctx, id, &order.ReversePaymentRequest{
+		Reason:     req.Reason,
+		ReversedBy: userID,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("payment_id", id).Msg("Failed to reverse payment")
+		handleOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, paymentToResponse(payment))
+}
+
+// GetARAgingReport returns the accounts receivable aging
+// @Summary Get AR aging report
+// @Description Age the outstanding balances of shipped orders by days past the due date of the customer's payment terms (current, 1-30, 31-60, 61-90, 90+), per customer and in total, in the base currency. Use format=csv to download the report.
+// @Tags payments
+// @Produce json
+// @Produce text/csv
+// @Param as_of query string false "Aging date (YYYY-MM-DD), defaults to today"
+// @Param customer_id query string false "Customer ID"
+// @Param format query string false "json or csv" default(json)
+// @Success 200 {object} dto.ARAgingReportResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/payments/aging [get]
+func (h *PaymentHandler) GetARAgingReport(c *gin.Context) {
+	var req dto.ARAgingReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid AR aging request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid query parameters",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	report, err := h.orderService.GetARAgingReport(c, &order.GetARAgingReportRequest{
+		AsOf:       req.AsOf,
+		CustomerID: req.CustomerID,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to get AR aging report")
+		handleOrderError(c, err)
+		return
+	}
+
+	if req.Format == "csv" {
+		var buf bytes.Buffer
+		if err := report.WriteCSV(&buf); err != nil {
+			h.logger.Error().Err(err).Msg("Failed to write AR aging report")
+			handleOrderError(c, err)
+			return
+		}
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"ar-aging-%s.csv\"", report.AsOf.Format("2006-01-02")))
+		c.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+		return
+	}
+
+	c.JSON(http.StatusOK, arAgingReportToResponse(report))
+}
+
+// currentUser returns the authenticated user's ID, responding 401 when absent
+func (h *PaymentHandler) currentUser(c *gin.Context) (string, bool) {
+	userID, exists := auth.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "User not authenticated",
+			Details: "Authentication required",
+		})
+		return "", false
+	}
+	return userID.String(), true
+}
+
+// allocationsFromRequest converts allocation DTOs to service requests
+func allocationsFromRequest(allocations []dto.PaymentAllocationRequest) []order.PaymentAllocationRequest {
+	result := make([]order.PaymentAllocationRequest, len(allocations))
+	for i, allocation := range allocations {
+		result[i] = order.PaymentAllocationRequest{
+			OrderID: allocation.OrderID.String(),
+			Amount:  allocation.Amount,
+		}
+	}
+	return result
+}
+
+// paymentsToResponse converts ledger entries to response DTOs
+func paymentsToResponse(payments []*entities.Payment) []*dto.PaymentResponse {
+	response := make([]*dto.PaymentResponse, len(payments))
+	for i, payment := range payments {
+		response[i] = paymentToResponse(payment)
+	}
+	return response
+}
+
+// paymentToResponse converts a ledger entry to a response DTO
+func paymentToResponse(p *entities.Payment) *dto.PaymentResponse {
+	allocations := make([]dto.PaymentAllocationResponse, len(p.Allocations))
+	for i, allocation := range p.Allocations {
+		allocations[i] = dto.PaymentAllocationResponse{
+			ID:        allocation.ID,
+			OrderID:   allocation.OrderID,
+			Amount:    allocation.Amount,
+			CreatedAt: allocation.CreatedAt,
+		}
+	}
+
+	return &dto.PaymentResponse{
+		ID:                p.ID,
+		PaymentNumber:     p.PaymentNumber,
+		Type:              string(p.Type),
+		CustomerID:        p.CustomerID,
+		Method:            string(p.Method),
+		Reference:         p.Reference,
+		Amount:            p.Amount,
+		AllocatedAmount:   p.AllocatedAmount(),
+		UnallocatedAmount: p.UnallocatedAmount(),
+		Currency:          p.Currency,
+		ReceivedAt:        p.ReceivedAt,
+		Status:            string(p.Status),
+		Notes:             p.Notes,
+		ReversedAt:        p.ReversedAt,
+		ReversedBy:        p.ReversedBy,
+		ReversalReason:    p.ReversalReason,
+		Allocations:       allocations,
+		CreatedBy:         p.CreatedBy,
+		CreatedAt:         p.CreatedAt,
+		UpdatedAt:         p.UpdatedAt,
+	}
+}
+
+// arAgingReportToResponse converts an AR aging report to a response DTO
+func arAgingReportToResponse(r *entities.ARAgingReport) *dto.ARAgingReportResponse {
+	customers := make([]*dto.CustomerAgingResponse, len(r.Customers))
+	for i, customer := range r.Customers {
+		customers[i] = &dto.CustomerAgingResponse{
+			CustomerID:   customer.CustomerID,
+			CustomerCode: customer.CustomerCode,
+			CustomerName: customer.CustomerName,
+			Terms:        customer.Terms,
+			OpenOrders:   customer.OpenOrders,
+			Amounts:      agingAmountsToResponse(customer.Amounts),
+		}
+	}
+
+	return &dto.ARAgingReportResponse{
+		AsOf:      r.AsOf,
+		Currency:  r.Currency,
+		Customers: customers,
+		Totals:    agingAmountsToResponse(r.Totals),
+	}
+}
+
+func agingAmountsToResponse(a entities.AgingAmounts) dto.AgingAmountsResponse {
+	return dto.AgingAmountsResponse{
+		Current:    a.Current,
+		Days1To30:  a.Days1To30,
+		Days31To60: a.Days31To60,
+		Days61To90: a.Days61To90,
+		Over90:     a.Over90,
+		Total:      a.Total,
+	}
+}
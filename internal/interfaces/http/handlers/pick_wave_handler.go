@@ -0,0 +1,524 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"erpgo/internal/application/services/order"
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/interfaces/http/dto"
+	"erpgo/pkg/auth"
+)
+
+// PickWaveHandler handles pick wave and pick location HTTP requests
+type PickWaveHandler struct {
+	pickWaveService order.PickWaveService
+	logger          zerolog.Logger
+}
+
+// NewPickWaveHandler creates a new pick wave handler
+func NewPickWaveHandler(pickWaveService order.PickWaveService, logger zerolog.Logger) *PickWaveHandler {
+	return &PickWaveHandler{
+		pickWaveService: pickWaveService,
+		logger:          logger,
+	}
+}
+
+// CreatePickWave releases a pick wave
+// @Summary Create pick wave
+// @Description Release a wave of the warehouse's most urgent orders ready to ship, optionally limited to a shipping method, a minimum priority and an order cutoff time
+// @Tags pick-waves
+// @Accept json
+// @Produce json
+// @Param wave body dto.CreatePickWaveRequest true "Wave grouping"
+// @Success 201 {object} dto.PickWaveResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/pick-waves [post]
+func (h *PickWaveHandler) CreatePickWave(c *gin.Context) {
+	var req dto.CreatePickWaveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid pick wave creation request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	serviceReq := &order.CreatePickWaveRequest{
+		WarehouseID: req.WarehouseID.String(),
+		Carrier:     req.Carrier,
+		CutoffAt:    req.CutoffAt,
+		MaxOrders:   req.MaxOrders,
+		Notes:       req.Notes,
+		CreatedBy:   userID,
+	}
+	if req.ShippingMethod != nil {
+		method := entities.ShippingMethod(*req.ShippingMethod)
+		serviceReq.ShippingMethod = &method
+	}
+	if req.MinPriority != nil {
+		priority := entities.OrderPriority(*req.MinPriority)
+		serviceReq.MinPriority = &priority
+	}
+
+	wave, err := h.pickWaveService.CreatePickWave(c, serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to create pick wave")
+		handlePickWaveError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, pickWaveToResponse(wave))
+}
+
+// GetPickWave retrieves a pick wave by ID
+// @Summary Get pick wave
+// @Description Get a pick wave with its orders and lines
+// @Tags pick-waves
+// @Produce json
+// @Param id path string true "Pick wave ID"
+// @Success 200 {object} dto.PickWaveResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/pick-waves/{id} [get]
+func (h *PickWaveHandler) GetPickWave(c *gin.Context) {
+	id := c.Param("id")
+
+	wave, err := h.pickWaveService.GetPickWave(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("pick_wave_id", id).Msg("Failed to get pick wave")
+		handlePickWaveError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pickWaveToResponse(wave))
+}
+
+// ListPickWaves lists pick waves
+// @Summary List pick waves
+// @Description List pick waves with filtering and pagination
+// @Tags pick-waves
+// @Produce json
+// @Param warehouse_id query string false "Warehouse ID"
+// @Param order_id query string false "Order ID"
+// @Param status query string false "Wave status"
+// @Param search query string false "Search by wave number"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} dto.ListPickWavesResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/pick-waves [get]
+func (h *PickWaveHandler) ListPickWaves(c *gin.Context) {
+	var req dto.ListPickWavesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid pick wave list request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid query parameters",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	serviceReq := &order.ListPickWavesRequest{
+		Search:      ptrStringToString(req.Search),
+		WarehouseID: req.WarehouseID,
+		OrderID:     req.OrderID,
+		Page:        req.Page,
+		Limit:       req.Limit,
+	}
+	if req.Status != nil {
+		serviceReq.Status = []entities.PickWaveStatus{entities.PickWaveStatus(*req.Status)}
+	}
+
+	result, err := h.pickWaveService.ListPickWaves(c, serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to list pick waves")
+		handlePickWaveError(c, err)
+		return
+	}
+
+	waves := make([]*dto.PickWaveResponse, len(result.Waves))
+	for i, wave := range result.Waves {
+		waves[i] = pickWaveToResponse(wave)
+	}
+
+	c.JSON(http.StatusOK, &dto.ListPickWavesResponse{
+		Waves: waves,
+		Pagination: &dto.Pagination{
+			Page:       result.Pagination.Page,
+			Limit:      result.Pagination.Limit,
+			Total:      result.Pagination.Total,
+			TotalPages: result.Pagination.TotalPages,
+			HasNext:    result.Pagination.HasNext,
+			HasPrev:    result.Pagination.HasPrev,
+		},
+	})
+}
+
+// GetPickList retrieves the lines of a wave still to pick
+// @Summary Get pick list
+// @Description Get the lines of a pick wave still to pick, sorted by warehouse location, then SKU and order sequence
+// @Tags pick-waves
+// @Produce json
+// @Param id path string true "Pick wave ID"
+// @Success 200 {array} dto.PickLineResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/pick-waves/{id}/pick-list [get]
+func (h *PickWaveHandler) GetPickList(c *gin.Context) {
+	id := c.Param("id")
+
+	lines, err := h.pickWaveService.GetPickList(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("pick_wave_id", id).Msg("Failed to get pick list")
+		handlePickWaveError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pickLinesToResponse(lines))
+}
+
+// ConfirmPicks records picked quantities
+// @Summary Confirm picks
+// @Description Record the quantities picked for wave lines. Short picked units are written off the warehouse, reserved in other warehouses when available and backordered when the product allows it.
+// @Tags pick-waves
+// @Accept json
+// @Produce json
+// @Param id path string true "Pick wave ID"
+// @Param picks body dto.ConfirmPicksRequest true "Picked quantities"
+// @Success 200 {object} dto.PickWaveResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/pick-waves/{id}/picks [post]
+func (h *PickWaveHandler) ConfirmPicks(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.ConfirmPicksRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid pick confirmation request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	serviceReq := &order.ConfirmPicksRequest{
+		Picks:    make([]order.ConfirmPickRequest, len(req.Picks)),
+		PickedBy: userID,
+	}
+	for i, pick := range req.Picks {
+		serviceReq.Picks[i] = order.ConfirmPickRequest{
+			LineID:   pick.LineID.String(),
+			Quantity: pick.Quantity,
+		}
+	}
+
+	wave, err := h.pickWaveService.ConfirmPicks(c, id, serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Str("pick_wave_id", id).Msg("Failed to confirm picks")
+		handlePickWaveError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pickWaveToResponse(wave))
+}
+
+// PackOrder packs a picked order and ships it
+// @Summary Pack order
+// @Description Pack the picked quantities of an order into a shipment leaving from the wave's warehouse. The carrier defaults to the carrier of the wave.
+// @Tags pick-waves
+// @Accept json
+// @Produce json
+// @Param id path string true "Pick wave ID"
+// @Param order_id path string true "Order ID"
+// @Param pack body dto.PackOrderRequest true "Shipment data"
+// @Success 200 {object} dto.PickWaveResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/pick-waves/{id}/orders/{order_id}/pack [post]
+func (h *PickWaveHandler) PackOrder(c *gin.Context) {
+	id := c.Param("id")
+	orderID := c.Param("order_id")
+
+	var req dto.PackOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid pack request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	serviceReq := &order.PackOrderRequest{
+		TrackingNumber: req.TrackingNumber,
+		Carrier:        req.Carrier,
+		Packages:       shipmentPackages(req.Packages),
+		Notify:         req.NotifyCustomer,
+		PackedBy:       userID,
+	}
+
+	ctx := order.WithStatusChangeActor(c, userID, entities.StatusChangeSourceAPI)
+	wave, err := h.pickWaveService.PackOrder(ctx, id, orderID, serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Str("pick_wave_id", id).Str("order_id", orderID).Msg("Failed to pack order")
+		handlePickWaveError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pickWaveToResponse(wave))
+}
+
+// CancelPickWave cancels a pick wave
+// @Summary Cancel pick wave
+// @Description Withdraw the orders not yet packed from a wave. Their stock stays reserved for a later wave.
+// @Tags pick-waves
+// @Produce json
+// @Param id path string true "Pick wave ID"
+// @Success 200 {object} dto.PickWaveResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/pick-waves/{id}/cancel [post]
+func (h *PickWaveHandler) CancelPickWave(c *gin.Context) {
+	id := c.Param("id")
+
+	wave, err := h.pickWaveService.CancelPickWave(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("pick_wave_id", id).Msg("Failed to cancel pick wave")
+		handlePickWaveError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pickWaveToResponse(wave))
+}
+
+// SetPickLocation sets the pick location of a product
+// @Summary Set pick location
+// @Description Set the shelf location a product is picked from in a warehouse. Waves released afterwards use the new location.
+// @Tags pick-waves
+// @Accept json
+// @Produce json
+// @Param location body dto.SetPickLocationRequest true "Pick location"
+// @Success 200 {object} dto.PickLocationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/pick-locations [put]
+func (h *PickWaveHandler) SetPickLocation(c *gin.Context) {
+	var req dto.SetPickLocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid pick location request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	location, err := h.pickWaveService.SetPickLocation(c, &order.SetPickLocationRequest{
+		WarehouseID: req.WarehouseID.String(),
+		ProductID:   req.ProductID.String(),
+		Location:    req.Location,
+		UpdatedBy:   userID,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to set pick location")
+		handlePickWaveError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pickLocationToResponse(location))
+}
+
+// GetPickLocations lists the pick locations of a warehouse
+// @Summary List pick locations
+// @Description List the pick locations of a warehouse in walking order
+// @Tags pick-waves
+// @Produce json
+// @Param warehouse_id query string true "Warehouse ID"
+// @Success 200 {array} dto.PickLocationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/pick-locations [get]
+func (h *PickWaveHandler) GetPickLocations(c *gin.Context) {
+	warehouseID := c.Query("warehouse_id")
+	if warehouseID == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid query parameters",
+			Details: "warehouse_id is required",
+		})
+		return
+	}
+
+	locations, err := h.pickWaveService.GetPickLocations(c, warehouseID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("warehouse_id", warehouseID).Msg("Failed to get pick locations")
+		handlePickWaveError(c, err)
+		return
+	}
+
+	response := make([]*dto.PickLocationResponse, len(locations))
+	for i, location := range locations {
+		response[i] = pickLocationToResponse(location)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// currentUser returns the authenticated user ID, writing a 401 response when missing
+func (h *PickWaveHandler) currentUser(c *gin.Context) (string, bool) {
+	userID, exists := auth.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "User not authenticated",
+			Details: "Authentication required",
+		})
+		return "", false
+	}
+	return userID.String(), true
+}
+
+// pickWaveToResponse converts a pick wave entity to a response DTO
+func pickWaveToResponse(w *entities.PickWave) *dto.PickWaveResponse {
+	orders := make([]dto.PickWaveOrderResponse, len(w.Orders))
+	for i, o := range w.Orders {
+		orders[i] = dto.PickWaveOrderResponse{
+			OrderID:     o.OrderID,
+			OrderNumber: o.OrderNumber,
+			Priority:    string(o.Priority),
+			Sequence:    o.Sequence,
+			Status:      string(o.Status),
+			ShipmentID:  o.ShipmentID,
+			PackedBy:    o.PackedBy,
+			PackedAt:    o.PackedAt,
+		}
+	}
+
+	response := &dto.PickWaveResponse{
+		ID:          w.ID,
+		WaveNumber:  w.WaveNumber,
+		WarehouseID: w.WarehouseID,
+		Status:      string(w.Status),
+		Carrier:     w.Carrier,
+		CutoffAt:    w.CutoffAt,
+		Notes:       w.Notes,
+		Orders:      orders,
+		Lines:       pickLinesToResponse(w.Lines),
+		CreatedBy:   w.CreatedBy,
+		CreatedAt:   w.CreatedAt,
+		UpdatedAt:   w.UpdatedAt,
+		CompletedAt: w.CompletedAt,
+		CancelledAt: w.CancelledAt,
+	}
+	if w.ShippingMethod != nil {
+		method := string(*w.ShippingMethod)
+		response.ShippingMethod = &method
+	}
+	if w.MinPriority != nil {
+		priority := string(*w.MinPriority)
+		response.MinPriority = &priority
+	}
+
+	return response
+}
+
+// pickLinesToResponse converts pick lines to response DTOs
+func pickLinesToResponse(lines []entities.PickLine) []dto.PickLineResponse {
+	response := make([]dto.PickLineResponse, len(lines))
+	for i, line := range lines {
+		response[i] = dto.PickLineResponse{
+			ID:                  line.ID,
+			OrderID:             line.OrderID,
+			OrderItemID:         line.OrderItemID,
+			ProductID:           line.ProductID,
+			ProductSKU:          line.ProductSKU,
+			ProductName:         line.ProductName,
+			Location:            line.Location,
+			Quantity:            line.Quantity,
+			QuantityPicked:      line.QuantityPicked,
+			QuantityShort:       line.QuantityShort,
+			QuantityReallocated: line.QuantityReallocated,
+			QuantityBackordered: line.QuantityBackordered,
+			Status:              string(line.Status),
+			PickedBy:            line.PickedBy,
+			PickedAt:            line.PickedAt,
+		}
+	}
+	return response
+}
+
+// pickLocationToResponse converts a pick location entity to a response DTO
+func pickLocationToResponse(l *entities.PickLocation) *dto.PickLocationResponse {
+	return &dto.PickLocationResponse{
+		WarehouseID: l.WarehouseID,
+		ProductID:   l.ProductID,
+		Location:    l.Location,
+		UpdatedBy:   l.UpdatedBy,
+		UpdatedAt:   l.UpdatedAt,
+	}
+}
+
+// handlePickWaveError handles pick wave service errors, deferring order errors
+// raised while shipping packed orders to handleOrderError
+func handlePickWaveError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, order.ErrPickWaveNotFound), errors.Is(err, order.ErrWarehouseNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:   "Resource not found",
+			Details: err.Error(),
+		})
+	case errors.Is(err, order.ErrInvalidPickWaveData):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request",
+			Details: err.Error(),
+		})
+	case errors.Is(err, order.ErrNoOrdersToPick):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error:   "Nothing to pick",
+			Details: err.Error(),
+		})
+	default:
+		handleOrderError(c, err)
+	}
+}
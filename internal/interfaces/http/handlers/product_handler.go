@@ -74,8 +74,11 @@ func (h *ProductHandler) CreateProduct(c *gin.Context) {
 		RequiresShipping: req.RequiresShipping,
 		Taxable:          req.Taxable,
 		TaxRate:          req.TaxRate,
+		TaxClass:         req.TaxClass,
 		IsFeatured:       req.IsFeatured,
 		IsDigital:        req.IsDigital,
+		IsDropShip:       req.IsDropShip,
+		SupplierID:       req.SupplierID,
 		DownloadURL:      req.DownloadURL,
 		MaxDownloads:     req.MaxDownloads,
 		ExpiryDays:       req.ExpiryDays,
@@ -210,8 +213,11 @@ func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 		RequiresShipping: req.RequiresShipping,
 		Taxable:          req.Taxable,
 		TaxRate:          req.TaxRate,
+		TaxClass:         req.TaxClass,
 		IsFeatured:       req.IsFeatured,
 		IsDigital:        req.IsDigital,
+		IsDropShip:       req.IsDropShip,
+		SupplierID:       req.SupplierID,
 		DownloadURL:      req.DownloadURL,
 		MaxDownloads:     req.MaxDownloads,
 		ExpiryDays:       req.ExpiryDays,
@@ -919,9 +925,12 @@ func (h *ProductHandler) productToResponse(p *entities.Product) *dto.ProductResp
 		RequiresShipping: p.RequiresShipping,
 		Taxable:          p.Taxable,
 		TaxRate:          p.TaxRate,
+		TaxClass:         p.TaxClass,
 		IsActive:         p.IsActive,
 		IsFeatured:       p.IsFeatured,
 		IsDigital:        p.IsDigital,
+		IsDropShip:       p.IsDropShip,
+		SupplierID:       p.SupplierID,
 		DownloadURL:      p.DownloadURL,
 		MaxDownloads:     p.MaxDownloads,
 		ExpiryDays:       p.ExpiryDays,
@@ -0,0 +1,294 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"erpgo/internal/application/services/order"
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/interfaces/http/dto"
+	"erpgo/pkg/auth"
+)
+
+// PromotionHandler handles promotion HTTP requests. Coupon codes are applied
+// to orders through the order endpoints.
+type PromotionHandler struct {
+	promotionService order.PromotionService
+	logger           zerolog.Logger
+}
+
+// NewPromotionHandler creates a new promotion handler
+func NewPromotionHandler(promotionService order.PromotionService, logger zerolog.Logger) *PromotionHandler {
+	return &PromotionHandler{
+		promotionService: promotionService,
+		logger:           logger,
+	}
+}
+
+// CreatePromotion creates a promotion
+// @Summary Create promotion
+// @Description Create a promotion redeemed with a coupon code, with its conditions, discount, usage limits and stacking rule
+// @Tags promotions
+// @Accept json
+// @Produce json
+// @Param promotion body dto.CreatePromotionRequest true "Promotion"
+// @Success 201 {object} dto.PromotionResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/promotions [post]
+func (h *PromotionHandler) CreatePromotion(c *gin.Context) {
+	var req dto.CreatePromotionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid promotion request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	promotion, err := h.promotionService.CreatePromotion(c, &order.CreatePromotionRequest{
+		Code:                  req.Code,
+		Name:                  req.Name,
+		Description:           req.Description,
+		ActionType:            entities.PromotionActionType(req.ActionType),
+		Value:                 req.Value,
+		MaxDiscount:           req.MaxDiscount,
+		BuyQuantity:           req.BuyQuantity,
+		GetQuantity:           req.GetQuantity,
+		MinSubtotal:           req.MinSubtotal,
+		ProductIDs:            req.ProductIDs,
+		CategoryIDs:           req.CategoryIDs,
+		CustomerTypes:         req.CustomerTypes,
+		StartsAt:              req.StartsAt,
+		EndsAt:                req.EndsAt,
+		UsageLimit:            req.UsageLimit,
+		UsageLimitPerCustomer: req.UsageLimitPerCustomer,
+		Stackable:             req.Stackable,
+		Priority:              req.Priority,
+		CreatedBy:             userID,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("code", req.Code).Msg("Failed to create promotion")
+		handleOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, promotionToResponse(promotion))
+}
+
+// GetPromotion retrieves a promotion by ID
+// @Summary Get promotion
+// @Description Get a promotion with the number of its redemptions
+// @Tags promotions
+// @Produce json
+// @Param id path string true "Promotion ID"
+// @Success 200 {object} dto.PromotionResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/promotions/{id} [get]
+func (h *PromotionHandler) GetPromotion(c *gin.Context) {
+	id := c.Param("id")
+
+	promotion, err := h.promotionService.GetPromotion(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("promotion_id", id).Msg("Failed to get promotion")
+		handleOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, promotionToResponse(promotion))
+}
+
+// GetPromotionByCode retrieves a promotion by its coupon code
+// @Summary Get promotion by code
+// @Description Get the promotion of a coupon code
+// @Tags promotions
+// @Produce json
+// @Param code path string true "Coupon code"
+// @Success 200 {object} dto.PromotionResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/promotions/code/{code} [get]
+func (h *PromotionHandler) GetPromotionByCode(c *gin.Context) {
+	code := c.Param("code")
+
+	promotion, err := h.promotionService.GetPromotionByCode(c, code)
+	if err != nil {
+		h.logger.Error().Err(err).Str("code", code).Msg("Failed to get promotion")
+		handleOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, promotionToResponse(promotion))
+}
+
+// UpdatePromotion updates a promotion
+// @Summary Update promotion
+// @Description Update the conditions, discount, limits or status of a promotion. Orders it was applied to pick up the change when recalculated.
+// @Tags promotions
+// @Accept json
+// @Produce json
+// @Param id path string true "Promotion ID"
+// @Param promotion body dto.UpdatePromotionRequest true "Promotion changes"
+// @Success 200 {object} dto.PromotionResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/promotions/{id} [put]
+func (h *PromotionHandler) UpdatePromotion(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.UpdatePromotionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid promotion update request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	promotion, err := h.promotionService.UpdatePromotion(c, id, &order.UpdatePromotionRequest{
+		Name:                  req.Name,
+		Description:           req.Description,
+		Value:                 req.Value,
+		MaxDiscount:           req.MaxDiscount,
+		BuyQuantity:           req.BuyQuantity,
+		GetQuantity:           req.GetQuantity,
+		MinSubtotal:           req.MinSubtotal,
+		ProductIDs:            req.ProductIDs,
+		CategoryIDs:           req.CategoryIDs,
+		CustomerTypes:         req.CustomerTypes,
+		StartsAt:              req.StartsAt,
+		EndsAt:                req.EndsAt,
+		UsageLimit:            req.UsageLimit,
+		UsageLimitPerCustomer: req.UsageLimitPerCustomer,
+		Stackable:             req.Stackable,
+		Priority:              req.Priority,
+		IsActive:              req.IsActive,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("promotion_id", id).Msg("Failed to update promotion")
+		handleOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, promotionToResponse(promotion))
+}
+
+// ListPromotions lists promotions
+// @Summary List promotions
+// @Description List promotions with filtering and pagination
+// @Tags promotions
+// @Produce json
+// @Param search query string false "Code or name"
+// @Param action_type query string false "Action type"
+// @Param is_active query bool false "Active promotions only"
+// @Param running query bool false "Promotions that can be redeemed now only"
+// @Param page query int false "Page number"
+// @Param limit query int false "Page size"
+// @Success 200 {object} dto.ListPromotionsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/promotions [get]
+func (h *PromotionHandler) ListPromotions(c *gin.Context) {
+	var req dto.ListPromotionsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid promotion list request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid query parameters",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	serviceReq := &order.ListPromotionsRequest{
+		Search:   req.Search,
+		IsActive: req.IsActive,
+		Running:  req.Running,
+		Page:     req.Page,
+		Limit:    req.Limit,
+	}
+	if req.ActionType != nil {
+		actionType := entities.PromotionActionType(*req.ActionType)
+		serviceReq.ActionType = &actionType
+	}
+
+	result, err := h.promotionService.ListPromotions(c, serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to list promotions")
+		handleOrderError(c, err)
+		return
+	}
+
+	promotions := make([]*dto.PromotionResponse, len(result.Promotions))
+	for i, promotion := range result.Promotions {
+		promotions[i] = promotionToResponse(promotion)
+	}
+
+	c.JSON(http.StatusOK, &dto.ListPromotionsResponse{
+		Promotions: promotions,
+		Pagination: &dto.Pagination{
+			Page:       result.Pagination.Page,
+			Limit:      result.Pagination.Limit,
+			Total:      result.Pagination.Total,
+			TotalPages: result.Pagination.TotalPages,
+			HasNext:    result.Pagination.HasNext,
+			HasPrev:    result.Pagination.HasPrev,
+		},
+	})
+}
+
+// currentUser returns the authenticated user's ID, responding 401 when absent
+func (h *PromotionHandler) currentUser(c *gin.Context) (string, bool) {
+	userID, exists := auth.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "User not authenticated",
+			Details: "Authentication required",
+		})
+		return "", false
+	}
+	return userID.String(), true
+}
+
+// promotionToResponse converts a promotion entity to a response DTO
+func promotionToResponse(promotion *entities.Promotion) *dto.PromotionResponse {
+	return &dto.PromotionResponse{
+		ID:                    promotion.ID,
+		Code:                  promotion.Code,
+		Name:                  promotion.Name,
+		Description:           promotion.Description,
+		ActionType:            string(promotion.ActionType),
+		Value:                 promotion.Value,
+		MaxDiscount:           promotion.MaxDiscount,
+		BuyQuantity:           promotion.BuyQuantity,
+		GetQuantity:           promotion.GetQuantity,
+		MinSubtotal:           promotion.MinSubtotal,
+		ProductIDs:            promotion.ProductIDs,
+		CategoryIDs:           promotion.CategoryIDs,
+		CustomerTypes:         promotion.CustomerTypes,
+		StartsAt:              promotion.StartsAt,
+		EndsAt:                promotion.EndsAt,
+		UsageLimit:            promotion.UsageLimit,
+		UsageLimitPerCustomer: promotion.UsageLimitPerCustomer,
+		TimesUsed:             promotion.TimesUsed,
+		Stackable:             promotion.Stackable,
+		Priority:              promotion.Priority,
+		IsActive:              promotion.IsActive,
+		CreatedBy:             promotion.CreatedBy,
+		CreatedAt:             promotion.CreatedAt,
+		UpdatedAt:             promotion.UpdatedAt,
+	}
+}
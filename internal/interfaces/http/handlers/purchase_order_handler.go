@@ -0,0 +1,487 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"erpgo/internal/application/services/purchasing"
+	"erpgo/internal/domain/purchasing/entities"
+	"erpgo/internal/interfaces/http/dto"
+	"erpgo/pkg/auth"
+)
+
+// PurchaseOrderHandler handles purchase order and goods receipt HTTP requests
+type PurchaseOrderHandler struct {
+	purchasingService purchasing.Service
+	logger            zerolog.Logger
+}
+
+// NewPurchaseOrderHandler creates a new purchase order handler
+func NewPurchaseOrderHandler(purchasingService purchasing.Service, logger zerolog.Logger) *PurchaseOrderHandler {
+	return &PurchaseOrderHandler{
+		purchasingService: purchasingService,
+		logger:            logger,
+	}
+}
+
+// CreatePurchaseOrder creates a new draft purchase order
+// @Summary Create purchase order
+// @Description Create a draft purchase order with a supplier for receipt into a warehouse
+// @Tags purchase-orders
+// @Accept json
+// @Produce json
+// @Param purchase_order body dto.PurchaseOrderRequest true "Purchase order data"
+// @Success 201 {object} dto.PurchaseOrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/purchase-orders [post]
+func (h *PurchaseOrderHandler) CreatePurchaseOrder(c *gin.Context) {
+	var req dto.PurchaseOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid purchase order creation request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	po, err := h.purchasingService.CreatePurchaseOrder(c, &purchasing.CreatePurchaseOrderRequest{
+		SupplierID:     req.SupplierID.String(),
+		WarehouseID:    req.WarehouseID.String(),
+		ExpectedDate:   req.ExpectedDate,
+		Currency:       req.Currency,
+		PaymentTerms:   req.PaymentTerms,
+		ShippingAmount: req.ShippingAmount,
+		Notes:          req.Notes,
+		Items:          purchaseOrderItemsToRequests(req.Items),
+		CreatedBy:      userID,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to create purchase order")
+		handlePurchasingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, purchaseOrderToResponse(po))
+}
+
+// GetPurchaseOrder retrieves a purchase order by ID
+// @Summary Get purchase order
+// @Description Get a purchase order with its lines and received quantities
+// @Tags purchase-orders
+// @Produce json
+// @Param id path string true "Purchase order ID"
+// @Success 200 {object} dto.PurchaseOrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/purchase-orders/{id} [get]
+func (h *PurchaseOrderHandler) GetPurchaseOrder(c *gin.Context) {
+	id := c.Param("id")
+
+	po, err := h.purchasingService.GetPurchaseOrder(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("purchase_order_id", id).Msg("Failed to get purchase order")
+		handlePurchasingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, purchaseOrderToResponse(po))
+}
+
+// UpdatePurchaseOrder updates a draft purchase order
+// @Summary Update purchase order
+// @Description Update the terms and lines of a draft purchase order
+// @Tags purchase-orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Purchase order ID"
+// @Param purchase_order body dto.UpdatePurchaseOrderRequest true "Purchase order update data"
+// @Success 200 {object} dto.PurchaseOrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/purchase-orders/{id} [put]
+func (h *PurchaseOrderHandler) UpdatePurchaseOrder(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.UpdatePurchaseOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid purchase order update request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	serviceReq := &purchasing.UpdatePurchaseOrderRequest{
+		ExpectedDate:   req.ExpectedDate,
+		PaymentTerms:   req.PaymentTerms,
+		ShippingAmount: req.ShippingAmount,
+		Notes:          req.Notes,
+	}
+	if req.WarehouseID != nil {
+		warehouseID := req.WarehouseID.String()
+		serviceReq.WarehouseID = &warehouseID
+	}
+	if req.Items != nil {
+		serviceReq.Items = purchaseOrderItemsToRequests(req.Items)
+	}
+
+	po, err := h.purchasingService.UpdatePurchaseOrder(c, id, serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Str("purchase_order_id", id).Msg("Failed to update purchase order")
+		handlePurchasingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, purchaseOrderToResponse(po))
+}
+
+// ListPurchaseOrders lists purchase orders
+// @Summary List purchase orders
+// @Description List purchase orders with filtering and pagination
+// @Tags purchase-orders
+// @Produce json
+// @Param supplier_id query string false "Supplier ID"
+// @Param warehouse_id query string false "Warehouse ID"
+// @Param sales_order_id query string false "Sales order ID of drop-ship purchase orders"
+// @Param status query string false "Purchase order status"
+// @Param search query string false "Search by PO number"
+// @Param overdue query bool false "Open purchase orders past their expected date only"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} dto.ListPurchaseOrdersResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/purchase-orders [get]
+func (h *PurchaseOrderHandler) ListPurchaseOrders(c *gin.Context) {
+	var req dto.ListPurchaseOrdersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid purchase order list request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid query parameters",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	serviceReq := &purchasing.ListPurchaseOrdersRequest{
+		Search:       ptrStringToString(req.Search),
+		SupplierID:   req.SupplierID,
+		WarehouseID:  req.WarehouseID,
+		SalesOrderID: req.SalesOrderID,
+		Overdue:      req.Overdue,
+		Page:         req.Page,
+		Limit:        req.Limit,
+	}
+	if req.Status != nil {
+		serviceReq.Status = []entities.PurchaseOrderStatus{entities.PurchaseOrderStatus(*req.Status)}
+	}
+
+	result, err := h.purchasingService.ListPurchaseOrders(c, serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to list purchase orders")
+		handlePurchasingError(c, err)
+		return
+	}
+
+	orders := make([]*dto.PurchaseOrderResponse, len(result.PurchaseOrders))
+	for i, po := range result.PurchaseOrders {
+		orders[i] = purchaseOrderToResponse(po)
+	}
+
+	c.JSON(http.StatusOK, &dto.ListPurchaseOrdersResponse{
+		PurchaseOrders: orders,
+		Pagination:     purchasingPagination(result.Pagination),
+	})
+}
+
+// SubmitPurchaseOrder places a draft purchase order with the supplier
+// @Summary Submit purchase order
+// @Description Place a draft purchase order with the supplier so goods can be received against it
+// @Tags purchase-orders
+// @Produce json
+// @Param id path string true "Purchase order ID"
+// @Success 200 {object} dto.PurchaseOrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/purchase-orders/{id}/submit [post]
+func (h *PurchaseOrderHandler) SubmitPurchaseOrder(c *gin.Context) {
+	id := c.Param("id")
+
+	po, err := h.purchasingService.SubmitPurchaseOrder(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("purchase_order_id", id).Msg("Failed to submit purchase order")
+		handlePurchasingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, purchaseOrderToResponse(po))
+}
+
+// CancelPurchaseOrder cancels a purchase order
+// @Summary Cancel purchase order
+// @Description Cancel a purchase order that has not received any goods
+// @Tags purchase-orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Purchase order ID"
+// @Param request body dto.CancelPurchaseOrderRequest true "Cancellation reason"
+// @Success 200 {object} dto.PurchaseOrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/purchase-orders/{id}/cancel [post]
+func (h *PurchaseOrderHandler) CancelPurchaseOrder(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.CancelPurchaseOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid purchase order cancellation request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	po, err := h.purchasingService.CancelPurchaseOrder(c, id, req.Reason)
+	if err != nil {
+		h.logger.Error().Err(err).Str("purchase_order_id", id).Msg("Failed to cancel purchase order")
+		handlePurchasingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, purchaseOrderToResponse(po))
+}
+
+// ReceiveGoods records a goods receipt against a purchase order
+// @Summary Receive goods
+// @Description Receive all or part of the outstanding quantities of a purchase order into its warehouse
+// @Tags purchase-orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Purchase order ID"
+// @Param receipt body dto.GoodsReceiptRequest true "Received quantities"
+// @Success 201 {object} dto.ReceiveGoodsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/purchase-orders/{id}/receipts [post]
+func (h *PurchaseOrderHandler) ReceiveGoods(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.GoodsReceiptRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid goods receipt request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	items := make([]purchasing.ReceiveGoodsItemRequest, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = purchasing.ReceiveGoodsItemRequest{
+			PurchaseOrderItemID: item.PurchaseOrderItemID.String(),
+			Quantity:            item.Quantity,
+			UnitCost:            item.UnitCost,
+			BatchNumber:         item.BatchNumber,
+			ExpiryDate:          item.ExpiryDate,
+		}
+	}
+
+	result, err := h.purchasingService.ReceiveGoods(c, id, &purchasing.ReceiveGoodsRequest{
+		SupplierReference: req.SupplierReference,
+		Notes:             req.Notes,
+		Items:             items,
+		ReceivedBy:        userID,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("purchase_order_id", id).Msg("Failed to receive goods")
+		handlePurchasingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, &dto.ReceiveGoodsResponse{
+		Receipt:       goodsReceiptToResponse(result.Receipt),
+		PurchaseOrder: purchaseOrderToResponse(result.PurchaseOrder),
+	})
+}
+
+// GetGoodsReceipts lists the goods receipts of a purchase order
+// @Summary Get goods receipts
+// @Description Get every goods receipt recorded against a purchase order, oldest first
+// @Tags purchase-orders
+// @Produce json
+// @Param id path string true "Purchase order ID"
+// @Success 200 {array} dto.GoodsReceiptResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/purchase-orders/{id}/receipts [get]
+func (h *PurchaseOrderHandler) GetGoodsReceipts(c *gin.Context) {
+	id := c.Param("id")
+
+	receipts, err := h.purchasingService.GetGoodsReceipts(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("purchase_order_id", id).Msg("Failed to get goods receipts")
+		handlePurchasingError(c, err)
+		return
+	}
+
+	response := make([]*dto.GoodsReceiptResponse, len(receipts))
+	for i, receipt := range receipts {
+		response[i] = goodsReceiptToResponse(receipt)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// currentUser returns the authenticated user's ID, responding 401 when absent
+func (h *PurchaseOrderHandler) currentUser(c *gin.Context) (string, bool) {
+	userID, exists := auth.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "User not authenticated",
+			Details: "Authentication required",
+		})
+		return "", false
+	}
+	return userID.String(), true
+}
+
+// purchaseOrderItemsToRequests converts purchase order line DTOs to service requests
+func purchaseOrderItemsToRequests(items []dto.PurchaseOrderItemRequest) []purchasing.PurchaseOrderItemRequest {
+	requests := make([]purchasing.PurchaseOrderItemRequest, len(items))
+	for i, item := range items {
+		requests[i] = purchasing.PurchaseOrderItemRequest{
+			ProductID:    item.ProductID.String(),
+			Quantity:     item.Quantity,
+			UnitCost:     item.UnitCost,
+			TaxRate:      item.TaxRate,
+			ExpectedDate: item.ExpectedDate,
+			Notes:        item.Notes,
+		}
+	}
+	return requests
+}
+
+// purchaseOrderToResponse converts a purchase order entity to a response DTO
+func purchaseOrderToResponse(po *entities.PurchaseOrder) *dto.PurchaseOrderResponse {
+	items := make([]dto.PurchaseOrderItemResponse, len(po.Items))
+	for i, item := range po.Items {
+		items[i] = dto.PurchaseOrderItemResponse{
+			ID:                  item.ID,
+			ProductID:           item.ProductID,
+			ProductSKU:          item.ProductSKU,
+			ProductName:         item.ProductName,
+			QuantityOrdered:     item.QuantityOrdered,
+			QuantityReceived:    item.QuantityReceived,
+			QuantityOutstanding: item.OutstandingQuantity(),
+			UnitCost:            item.UnitCost,
+			TaxRate:             item.TaxRate,
+			TaxAmount:           item.TaxAmount,
+			TotalCost:           item.TotalCost,
+			ExpectedDate:        item.ExpectedDate,
+			Notes:               item.Notes,
+			SalesOrderItemID:    item.SalesOrderItemID,
+		}
+	}
+
+	var shipTo *dto.DropShipAddressResponse
+	if po.ShipTo != nil {
+		shipTo = &dto.DropShipAddressResponse{
+			Name:         po.ShipTo.Name,
+			Company:      po.ShipTo.Company,
+			AddressLine1: po.ShipTo.AddressLine1,
+			AddressLine2: po.ShipTo.AddressLine2,
+			City:         po.ShipTo.City,
+			State:        po.ShipTo.State,
+			PostalCode:   po.ShipTo.PostalCode,
+			Country:      po.ShipTo.Country,
+			Phone:        po.ShipTo.Phone,
+		}
+	}
+
+	return &dto.PurchaseOrderResponse{
+		ID:                 po.ID,
+		PONumber:           po.PONumber,
+		SupplierID:         po.SupplierID,
+		WarehouseID:        po.WarehouseID,
+		SalesOrderID:       po.SalesOrderID,
+		ShipTo:             shipTo,
+		Status:             string(po.Status),
+		OrderDate:          po.OrderDate,
+		ExpectedDate:       po.ExpectedDate,
+		Currency:           po.Currency,
+		PaymentTerms:       po.PaymentTerms,
+		Subtotal:           po.Subtotal,
+		TaxAmount:          po.TaxAmount,
+		ShippingAmount:     po.ShippingAmount,
+		TotalAmount:        po.TotalAmount,
+		Items:              items,
+		Notes:              po.Notes,
+		CancellationReason: po.CancellationReason,
+		CreatedBy:          po.CreatedBy,
+		CreatedAt:          po.CreatedAt,
+		UpdatedAt:          po.UpdatedAt,
+		OrderedAt:          po.OrderedAt,
+		ClosedAt:           po.ClosedAt,
+		CancelledAt:        po.CancelledAt,
+	}
+}
+
+// goodsReceiptToResponse converts a goods receipt entity to a response DTO
+func goodsReceiptToResponse(receipt *entities.GoodsReceipt) *dto.GoodsReceiptResponse {
+	items := make([]dto.GoodsReceiptItemResponse, len(receipt.Items))
+	for i, item := range receipt.Items {
+		items[i] = dto.GoodsReceiptItemResponse{
+			ID:                     item.ID,
+			PurchaseOrderItemID:    item.PurchaseOrderItemID,
+			ProductID:              item.ProductID,
+			Quantity:               item.Quantity,
+			UnitCost:               item.UnitCost,
+			BatchNumber:            item.BatchNumber,
+			ExpiryDate:             item.ExpiryDate,
+			InventoryTransactionID: item.InventoryTransactionID,
+		}
+	}
+
+	return &dto.GoodsReceiptResponse{
+		ID:                receipt.ID,
+		ReceiptNumber:     receipt.ReceiptNumber,
+		PurchaseOrderID:   receipt.PurchaseOrderID,
+		WarehouseID:       receipt.WarehouseID,
+		SupplierReference: receipt.SupplierReference,
+		Notes:             receipt.Notes,
+		Items:             items,
+		ReceivedBy:        receipt.ReceivedBy,
+		ReceivedAt:        receipt.ReceivedAt,
+	}
+}
@@ -0,0 +1,563 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"erpgo/internal/application/services/order"
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/interfaces/http/dto"
+	"erpgo/pkg/auth"
+)
+
+// QuotationHandler handles quotation HTTP requests
+type QuotationHandler struct {
+	quotationService order.QuotationService
+	logger           zerolog.Logger
+}
+
+// NewQuotationHandler creates a new quotation handler
+func NewQuotationHandler(quotationService order.QuotationService, logger zerolog.Logger) *QuotationHandler {
+	return &QuotationHandler{
+		quotationService: quotationService,
+		logger:           logger,
+	}
+}
+
+// CreateQuotation creates a new draft quotation
+// @Summary Create quotation
+// @Description Create a draft quotation priced from the product catalogue
+// @Tags quotations
+// @Accept json
+// @Produce json
+// @Param quotation body dto.QuotationRequest true "Quotation data"
+// @Success 201 {object} dto.QuotationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/quotations [post]
+func (h *QuotationHandler) CreateQuotation(c *gin.Context) {
+	var req dto.QuotationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid quotation creation request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	serviceReq := &order.CreateQuotationRequest{
+		CustomerID:        req.CustomerID.String(),
+		ShippingMethod:    entities.ShippingMethod(req.ShippingMethod),
+		ShippingAddressID: req.ShippingAddressID.String(),
+		BillingAddressID:  req.BillingAddressID.String(),
+		Currency:          req.Currency,
+		ValidUntil:        req.ValidUntil,
+		ShippingAmount:    req.ShippingAmount,
+		Notes:             req.Notes,
+		Items:             quotationItemsToRequests(req.Items),
+		CreatedBy:         userID,
+	}
+
+	quotation, err := h.quotationService.CreateQuotation(c, serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to create quotation")
+		handleQuotationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, quotationToResponse(quotation))
+}
+
+// GetQuotation retrieves a quotation by ID
+// @Summary Get quotation
+// @Description Get a quotation by its ID
+// @Tags quotations
+// @Produce json
+// @Param id path string true "Quotation ID"
+// @Success 200 {object} dto.QuotationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/quotations/{id} [get]
+func (h *QuotationHandler) GetQuotation(c *gin.Context) {
+	id := c.Param("id")
+
+	quotation, err := h.quotationService.GetQuotation(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("quotation_id", id).Msg("Failed to get quotation")
+		handleQuotationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, quotationToResponse(quotation))
+}
+
+// GetQuotationByOrder retrieves the quotation an order was converted from
+// @Summary Get quotation of order
+// @Description Get the quotation an order was converted from
+// @Tags quotations
+// @Produce json
+// @Param order_id path string true "Order ID"
+// @Success 200 {object} dto.QuotationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/quotations/order/{order_id} [get]
+func (h *QuotationHandler) GetQuotationByOrder(c *gin.Context) {
+	orderID := c.Param("order_id")
+
+	quotation, err := h.quotationService.GetQuotationByOrder(c, orderID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("order_id", orderID).Msg("Failed to get quotation of order")
+		handleQuotationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, quotationToResponse(quotation))
+}
+
+// UpdateQuotation updates a draft quotation
+// @Summary Update quotation
+// @Description Update the terms and lines of a draft quotation
+// @Tags quotations
+// @Accept json
+// @Produce json
+// @Param id path string true "Quotation ID"
+// @Param quotation body dto.UpdateQuotationRequest true "Quotation update data"
+// @Success 200 {object} dto.QuotationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/quotations/{id} [put]
+func (h *QuotationHandler) UpdateQuotation(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.UpdateQuotationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid quotation update request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	serviceReq := &order.UpdateQuotationRequest{
+		ValidUntil:     req.ValidUntil,
+		ShippingAmount: req.ShippingAmount,
+		Notes:          req.Notes,
+	}
+	if req.ShippingMethod != nil {
+		method := entities.ShippingMethod(*req.ShippingMethod)
+		serviceReq.ShippingMethod = &method
+	}
+	if req.ShippingAddressID != nil {
+		addressID := req.ShippingAddressID.String()
+		serviceReq.ShippingAddressID = &addressID
+	}
+	if req.BillingAddressID != nil {
+		addressID := req.BillingAddressID.String()
+		serviceReq.BillingAddressID = &addressID
+	}
+	if req.Items != nil {
+		serviceReq.Items = quotationItemsToRequests(req.Items)
+	}
+
+	quotation, err := h.quotationService.UpdateQuotation(c, id, serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Str("quotation_id", id).Msg("Failed to update quotation")
+		handleQuotationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, quotationToResponse(quotation))
+}
+
+// ListQuotations lists quotations
+// @Summary List quotations
+// @Description List quotations with filtering and pagination; only the latest revision of each quotation is returned unless all_revisions is set
+// @Tags quotations
+// @Produce json
+// @Param customer_id query string false "Customer ID"
+// @Param status query string false "Quotation status"
+// @Param search query string false "Search term"
+// @Param all_revisions query bool false "Include superseded revisions"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} dto.ListQuotationsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/quotations [get]
+func (h *QuotationHandler) ListQuotations(c *gin.Context) {
+	var req dto.ListQuotationsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid quotation list request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid query parameters",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	serviceReq := &order.ListQuotationsRequest{
+		Search:       ptrStringToString(req.Search),
+		CustomerID:   req.CustomerID,
+		AllRevisions: req.AllRevisions,
+		Page:         req.Page,
+		Limit:        req.Limit,
+	}
+	if req.Status != nil {
+		serviceReq.Status = []entities.QuotationStatus{entities.QuotationStatus(*req.Status)}
+	}
+
+	result, err := h.quotationService.ListQuotations(c, serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to list quotations")
+		handleQuotationError(c, err)
+		return
+	}
+
+	quotations := make([]*dto.QuotationResponse, len(result.Quotations))
+	for i, quotation := range result.Quotations {
+		quotations[i] = quotationToResponse(quotation)
+	}
+
+	c.JSON(http.StatusOK, &dto.ListQuotationsResponse{
+		Quotations: quotations,
+		Pagination: &dto.Pagination{
+			Page:       result.Pagination.Page,
+			Limit:      result.Pagination.Limit,
+			Total:      result.Pagination.Total,
+			TotalPages: result.Pagination.TotalPages,
+			HasNext:    result.Pagination.HasNext,
+			HasPrev:    result.Pagination.HasPrev,
+		},
+	})
+}
+
+// GetQuotationRevisions lists all revisions of a quotation
+// @Summary Get quotation revisions
+// @Description Get all revisions of a quotation, oldest first
+// @Tags quotations
+// @Produce json
+// @Param id path string true "Quotation ID"
+// @Success 200 {array} dto.QuotationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/quotations/{id}/revisions [get]
+func (h *QuotationHandler) GetQuotationRevisions(c *gin.Context) {
+	id := c.Param("id")
+
+	revisions, err := h.quotationService.GetQuotationRevisions(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("quotation_id", id).Msg("Failed to get quotation revisions")
+		handleQuotationError(c, err)
+		return
+	}
+
+	response := make([]*dto.QuotationResponse, len(revisions))
+	for i, revision := range revisions {
+		response[i] = quotationToResponse(revision)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// SendQuotation marks a quotation as sent to the customer
+// @Summary Send quotation
+// @Description Mark a draft quotation as sent to the customer
+// @Tags quotations
+// @Produce json
+// @Param id path string true "Quotation ID"
+// @Success 200 {object} dto.QuotationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/quotations/{id}/send [post]
+func (h *QuotationHandler) SendQuotation(c *gin.Context) {
+	id := c.Param("id")
+
+	quotation, err := h.quotationService.SendQuotation(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("quotation_id", id).Msg("Failed to send quotation")
+		handleQuotationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, quotationToResponse(quotation))
+}
+
+// AcceptQuotation records the customer's acceptance of a quotation
+// @Summary Accept quotation
+// @Description Record the customer's acceptance of a quotation that has not expired
+// @Tags quotations
+// @Produce json
+// @Param id path string true "Quotation ID"
+// @Success 200 {object} dto.QuotationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/quotations/{id}/accept [post]
+func (h *QuotationHandler) AcceptQuotation(c *gin.Context) {
+	id := c.Param("id")
+
+	quotation, err := h.quotationService.AcceptQuotation(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("quotation_id", id).Msg("Failed to accept quotation")
+		handleQuotationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, quotationToResponse(quotation))
+}
+
+// RejectQuotation records the customer's rejection of a quotation
+// @Summary Reject quotation
+// @Description Record the customer's rejection of a quotation
+// @Tags quotations
+// @Accept json
+// @Produce json
+// @Param id path string true "Quotation ID"
+// @Param reject body dto.RejectQuotationRequest false "Rejection data"
+// @Success 200 {object} dto.QuotationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/quotations/{id}/reject [post]
+func (h *QuotationHandler) RejectQuotation(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.RejectQuotationRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.logger.Error().Err(err).Msg("Invalid quotation rejection request")
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Invalid request body",
+				Details: err.Error(),
+			})
+			return
+		}
+	}
+
+	quotation, err := h.quotationService.RejectQuotation(c, id, req.Reason)
+	if err != nil {
+		h.logger.Error().Err(err).Str("quotation_id", id).Msg("Failed to reject quotation")
+		handleQuotationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, quotationToResponse(quotation))
+}
+
+// ReviseQuotation creates a new revision of a quotation
+// @Summary Revise quotation
+// @Description Create a new draft revision of a quotation, superseding it when it is still open
+// @Tags quotations
+// @Accept json
+// @Produce json
+// @Param id path string true "Quotation ID"
+// @Param revise body dto.ReviseQuotationRequest false "Revision data"
+// @Success 201 {object} dto.QuotationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/quotations/{id}/revise [post]
+func (h *QuotationHandler) ReviseQuotation(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.ReviseQuotationRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.logger.Error().Err(err).Msg("Invalid quotation revision request")
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Invalid request body",
+				Details: err.Error(),
+			})
+			return
+		}
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	revision, err := h.quotationService.ReviseQuotation(c, id, &order.ReviseQuotationRequest{
+		ValidUntil: req.ValidUntil,
+		RevisedBy:  userID,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("quotation_id", id).Msg("Failed to revise quotation")
+		handleQuotationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, quotationToResponse(revision))
+}
+
+// ConvertQuotation converts an accepted quotation into a sales order
+// @Summary Convert quotation to order
+// @Description Create a PENDING sales order from an accepted quotation at the quoted prices
+// @Tags quotations
+// @Accept json
+// @Produce json
+// @Param id path string true "Quotation ID"
+// @Param convert body dto.ConvertQuotationRequest false "Conversion data"
+// @Success 201 {object} dto.ConvertQuotationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/quotations/{id}/convert [post]
+func (h *QuotationHandler) ConvertQuotation(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.ConvertQuotationRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.logger.Error().Err(err).Msg("Invalid quotation conversion request")
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Invalid request body",
+				Details: err.Error(),
+			})
+			return
+		}
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	ctx := order.WithStatusChangeActor(c, userID, entities.StatusChangeSourceAPI)
+	createdOrder, err := h.quotationService.ConvertToOrder(ctx, id, &order.ConvertQuotationRequest{
+		Priority:      entities.OrderPriority(req.Priority),
+		RequiredDate:  req.RequiredDate,
+		CustomerNotes: req.CustomerNotes,
+		PaymentMethod: req.PaymentMethod,
+		ConvertedBy:   userID,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("quotation_id", id).Msg("Failed to convert quotation")
+		handleQuotationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, &dto.ConvertQuotationResponse{
+		QuotationID: id,
+		OrderID:     createdOrder.ID,
+		OrderNumber: createdOrder.OrderNumber,
+		OrderStatus: string(createdOrder.Status),
+		TotalAmount: createdOrder.TotalAmount,
+		Currency:    createdOrder.Currency,
+	})
+}
+
+// currentUser returns the authenticated user's ID, responding 401 when absent
+func (h *QuotationHandler) currentUser(c *gin.Context) (string, bool) {
+	userID, exists := auth.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "User not authenticated",
+			Details: "Authentication required",
+		})
+		return "", false
+	}
+	return userID.String(), true
+}
+
+// quotationItemsToRequests converts quotation line DTOs to service requests
+func quotationItemsToRequests(items []dto.QuotationItemRequest) []order.CreateOrderItemRequest {
+	requests := make([]order.CreateOrderItemRequest, len(items))
+	for i, item := range items {
+		requests[i] = order.CreateOrderItemRequest{
+			ProductID:      item.ProductID.String(),
+			Quantity:       int(item.Quantity),
+			UnitPrice:      item.UnitPrice,
+			DiscountAmount: item.DiscountAmount,
+			TaxRate:        item.TaxRate,
+			Notes:          item.Notes,
+		}
+	}
+	return requests
+}
+
+// quotationToResponse converts a quotation entity to a response DTO
+func quotationToResponse(q *entities.Quotation) *dto.QuotationResponse {
+	items := make([]dto.OrderItemResponse, len(q.Items))
+	for i, item := range q.Items {
+		items[i] = orderItemToResponse(item)
+	}
+
+	return &dto.QuotationResponse{
+		ID:                 q.ID,
+		QuotationNumber:    q.QuotationNumber,
+		Revision:           q.Revision,
+		PreviousRevisionID: q.PreviousRevisionID,
+		CustomerID:         q.CustomerID,
+		Status:             string(q.Status),
+		ValidUntil:         q.ValidUntil,
+		ShippingMethod:     string(q.ShippingMethod),
+		ShippingAddressID:  q.ShippingAddressID,
+		BillingAddressID:   q.BillingAddressID,
+		Currency:           q.Currency,
+		Subtotal:           q.Subtotal,
+		TaxAmount:          q.TaxAmount,
+		ShippingAmount:     q.ShippingAmount,
+		DiscountAmount:     q.DiscountAmount,
+		TotalAmount:        q.TotalAmount,
+		Items:              items,
+		Notes:              q.Notes,
+		RejectionReason:    q.RejectionReason,
+		ConvertedOrderID:   q.ConvertedOrderID,
+		CreatedBy:          q.CreatedBy,
+		CreatedAt:          q.CreatedAt,
+		UpdatedAt:          q.UpdatedAt,
+		SentAt:             q.SentAt,
+		AcceptedAt:         q.AcceptedAt,
+		RejectedAt:         q.RejectedAt,
+		ConvertedAt:        q.ConvertedAt,
+	}
+}
+
+// handleQuotationError handles quotation service errors, deferring order
+// errors raised while converting a quotation to handleOrderError
+func handleQuotationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, order.ErrQuotationNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:   "Quotation not found",
+			Details: err.Error(),
+		})
+	case errors.Is(err, order.ErrQuotationNotEditable), errors.Is(err, order.ErrQuotationNotAccepted),
+		errors.Is(err, order.ErrQuotationExpired), errors.Is(err, order.ErrQuotationNotLatest):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error:   "Quotation state conflict",
+			Details: err.Error(),
+		})
+	case errors.Is(err, order.ErrInvalidQuotationValidity):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request",
+			Details: err.Error(),
+		})
+	default:
+		handleOrderError(c, err)
+	}
+}
@@ -0,0 +1,472 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"erpgo/internal/application/services/order"
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/interfaces/http/dto"
+	"erpgo/pkg/auth"
+)
+
+// RecurringOrderHandler handles recurring order HTTP requests
+type RecurringOrderHandler struct {
+	recurringOrderService order.RecurringOrderService
+	logger                zerolog.Logger
+}
+
+// NewRecurringOrderHandler creates a new recurring order handler
+func NewRecurringOrderHandler(recurringOrderService order.RecurringOrderService, logger zerolog.Logger) *RecurringOrderHandler {
+	return &RecurringOrderHandler{
+		recurringOrderService: recurringOrderService,
+		logger:                logger,
+	}
+}
+
+// CreateRecurringOrder creates a recurring order
+// @Summary Create recurring order
+// @Description Create a schedule generating sales orders from a template of lines every interval days, weeks or months. Lines not given are copied from the source order. Generated orders are priced from the catalogue when they are generated.
+// @Tags recurring-orders
+// @Accept json
+// @Produce json
+// @Param recurring_order body dto.CreateRecurringOrderRequest true "Recurring order data"
+// @Success 201 {object} dto.RecurringOrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/recurring-orders [post]
+func (h *RecurringOrderHandler) CreateRecurringOrder(c *gin.Context) {
+	var req dto.CreateRecurringOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid recurring order creation request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	serviceReq := &order.CreateRecurringOrderRequest{
+		Name:              req.Name,
+		SourceOrderID:     uuidPtrToPtrString(req.SourceOrderID),
+		CustomerID:        ptrStringToString(uuidPtrToPtrString(req.CustomerID)),
+		Priority:          entities.OrderPriority(req.Priority),
+		ShippingMethod:    entities.ShippingMethod(req.ShippingMethod),
+		ShippingAddressID: ptrStringToString(uuidPtrToPtrString(req.ShippingAddressID)),
+		BillingAddressID:  ptrStringToString(uuidPtrToPtrString(req.BillingAddressID)),
+		Currency:          req.Currency,
+		Notes:             req.Notes,
+		Items:             recurringOrderItemsToRequests(req.Items),
+		Frequency:         entities.RecurringOrderFrequency(req.Frequency),
+		Interval:          req.Interval,
+		DayOfMonth:        req.DayOfMonth,
+		StartDate:         req.StartDate,
+		EndDate:           req.EndDate,
+		CreatedBy:         userID,
+	}
+
+	recurringOrder, err := h.recurringOrderService.CreateRecurringOrder(c, serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to create recurring order")
+		handleRecurringOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, recurringOrderToResponse(recurringOrder))
+}
+
+// GetRecurringOrder retrieves a recurring order by ID
+// @Summary Get recurring order
+// @Description Get a recurring order with its template lines and next occurrence
+// @Tags recurring-orders
+// @Produce json
+// @Param id path string true "Recurring order ID"
+// @Success 200 {object} dto.RecurringOrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/recurring-orders/{id} [get]
+func (h *RecurringOrderHandler) GetRecurringOrder(c *gin.Context) {
+	id := c.Param("id")
+
+	recurringOrder, err := h.recurringOrderService.GetRecurringOrder(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("recurring_order_id", id).Msg("Failed to get recurring order")
+		handleRecurringOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, recurringOrderToResponse(recurringOrder))
+}
+
+// UpdateRecurringOrder updates a recurring order
+// @Summary Update recurring order
+// @Description Update the template or schedule of an active or paused recurring order. A changed schedule is recalculated from now.
+// @Tags recurring-orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Recurring order ID"
+// @Param recurring_order body dto.UpdateRecurringOrderRequest true "Recurring order update data"
+// @Success 200 {object} dto.RecurringOrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/recurring-orders/{id} [put]
+func (h *RecurringOrderHandler) UpdateRecurringOrder(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.UpdateRecurringOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid recurring order update request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	serviceReq := &order.UpdateRecurringOrderRequest{
+		Name:              req.Name,
+		ShippingAddressID: uuidPtrToPtrString(req.ShippingAddressID),
+		BillingAddressID:  uuidPtrToPtrString(req.BillingAddressID),
+		Currency:          req.Currency,
+		Notes:             req.Notes,
+		Interval:          req.Interval,
+		DayOfMonth:        req.DayOfMonth,
+		StartDate:         req.StartDate,
+		EndDate:           req.EndDate,
+	}
+	if req.Priority != nil {
+		priority := entities.OrderPriority(*req.Priority)
+		serviceReq.Priority = &priority
+	}
+	if req.ShippingMethod != nil {
+		method := entities.ShippingMethod(*req.ShippingMethod)
+		serviceReq.ShippingMethod = &method
+	}
+	if req.Frequency != nil {
+		frequency := entities.RecurringOrderFrequency(*req.Frequency)
+		serviceReq.Frequency = &frequency
+	}
+	if req.Items != nil {
+		serviceReq.Items = recurringOrderItemsToRequests(req.Items)
+	}
+
+	recurringOrder, err := h.recurringOrderService.UpdateRecurringOrder(c, id, serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Str("recurring_order_id", id).Msg("Failed to update recurring order")
+		handleRecurringOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, recurringOrderToResponse(recurringOrder))
+}
+
+// ListRecurringOrders lists recurring orders
+// @Summary List recurring orders
+// @Description List recurring orders, next occurrence first
+// @Tags recurring-orders
+// @Produce json
+// @Param customer_id query string false "Customer ID"
+// @Param status query string false "Status"
+// @Param search query string false "Name"
+// @Param page query int false "Page number"
+// @Param limit query int false "Page size"
+// @Success 200 {object} dto.ListRecurringOrdersResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/recurring-orders [get]
+func (h *RecurringOrderHandler) ListRecurringOrders(c *gin.Context) {
+	var req dto.ListRecurringOrdersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid recurring order list request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid query parameters",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	serviceReq := &order.ListRecurringOrdersRequest{
+		Search:     ptrStringToString(req.Search),
+		CustomerID: req.CustomerID,
+		Page:       req.Page,
+		Limit:      req.Limit,
+	}
+	if req.Status != nil {
+		serviceReq.Status = []entities.RecurringOrderStatus{entities.RecurringOrderStatus(*req.Status)}
+	}
+
+	result, err := h.recurringOrderService.ListRecurringOrders(c, serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to list recurring orders")
+		handleRecurringOrderError(c, err)
+		return
+	}
+
+	recurringOrders := make([]*dto.RecurringOrderResponse, len(result.RecurringOrders))
+	for i, recurringOrder := range result.RecurringOrders {
+		recurringOrders[i] = recurringOrderToResponse(recurringOrder)
+	}
+
+	c.JSON(http.StatusOK, &dto.ListRecurringOrdersResponse{
+		RecurringOrders: recurringOrders,
+		Pagination: &dto.Pagination{
+			Page:       result.Pagination.Page,
+			Limit:      result.Pagination.Limit,
+			Total:      result.Pagination.Total,
+			TotalPages: result.Pagination.TotalPages,
+			HasNext:    result.Pagination.HasNext,
+			HasPrev:    result.Pagination.HasPrev,
+		},
+	})
+}
+
+// GetRecurringOrderRuns lists the occurrences of a recurring order
+// @Summary Get recurring order runs
+// @Description Get the occurrences of a recurring order with the order generated for each, or why it was skipped or failed, latest first
+// @Tags recurring-orders
+// @Produce json
+// @Param id path string true "Recurring order ID"
+// @Success 200 {array} dto.RecurringOrderRunResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/recurring-orders/{id}/runs [get]
+func (h *RecurringOrderHandler) GetRecurringOrderRuns(c *gin.Context) {
+	id := c.Param("id")
+
+	runs, err := h.recurringOrderService.GetRecurringOrderRuns(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("recurring_order_id", id).Msg("Failed to get recurring order runs")
+		handleRecurringOrderError(c, err)
+		return
+	}
+
+	response := make([]dto.RecurringOrderRunResponse, len(runs))
+	for i, run := range runs {
+		response[i] = dto.RecurringOrderRunResponse{
+			ID:           run.ID,
+			ScheduledFor: run.ScheduledFor,
+			Status:       string(run.Status),
+			OrderID:      run.OrderID,
+			OrderNumber:  run.OrderNumber,
+			Reason:       run.Reason,
+			CreatedBy:    run.CreatedBy,
+			CreatedAt:    run.CreatedAt,
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// PauseRecurringOrder pauses a recurring order
+// @Summary Pause recurring order
+// @Description Stop an active recurring order from generating orders until it is resumed
+// @Tags recurring-orders
+// @Produce json
+// @Param id path string true "Recurring order ID"
+// @Success 200 {object} dto.RecurringOrderResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/recurring-orders/{id}/pause [post]
+func (h *RecurringOrderHandler) PauseRecurringOrder(c *gin.Context) {
+	id := c.Param("id")
+
+	recurringOrder, err := h.recurringOrderService.PauseRecurringOrder(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("recurring_order_id", id).Msg("Failed to pause recurring order")
+		handleRecurringOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, recurringOrderToResponse(recurringOrder))
+}
+
+// ResumeRecurringOrder resumes a paused recurring order
+// @Summary Resume recurring order
+// @Description Reactivate a paused recurring order. Occurrences that fell while it was paused are not generated.
+// @Tags recurring-orders
+// @Produce json
+// @Param id path string true "Recurring order ID"
+// @Success 200 {object} dto.RecurringOrderResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/recurring-orders/{id}/resume [post]
+func (h *RecurringOrderHandler) ResumeRecurringOrder(c *gin.Context) {
+	id := c.Param("id")
+
+	recurringOrder, err := h.recurringOrderService.ResumeRecurringOrder(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("recurring_order_id", id).Msg("Failed to resume recurring order")
+		handleRecurringOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, recurringOrderToResponse(recurringOrder))
+}
+
+// SkipNextOccurrence skips the next occurrence of a recurring order
+// @Summary Skip next occurrence
+// @Description Move a recurring order past its next occurrence without generating an order
+// @Tags recurring-orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Recurring order ID"
+// @Param skip body dto.SkipRecurringOrderRequest false "Skip reason"
+// @Success 200 {object} dto.RecurringOrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/recurring-orders/{id}/skip [post]
+func (h *RecurringOrderHandler) SkipNextOccurrence(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.SkipRecurringOrderRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.logger.Error().Err(err).Msg("Invalid recurring order skip request")
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Invalid request body",
+				Details: err.Error(),
+			})
+			return
+		}
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	recurringOrder, err := h.recurringOrderService.SkipNextOccurrence(c, id, &order.SkipRecurringOrderRequest{
+		Reason:    req.Reason,
+		SkippedBy: userID,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("recurring_order_id", id).Msg("Failed to skip recurring order occurrence")
+		handleRecurringOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, recurringOrderToResponse(recurringOrder))
+}
+
+// CancelRecurringOrder cancels a recurring order
+// @Summary Cancel recurring order
+// @Description Close a recurring order. Orders already generated are kept.
+// @Tags recurring-orders
+// @Produce json
+// @Param id path string true "Recurring order ID"
+// @Success 200 {object} dto.RecurringOrderResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/recurring-orders/{id}/cancel [post]
+func (h *RecurringOrderHandler) CancelRecurringOrder(c *gin.Context) {
+	id := c.Param("id")
+
+	recurringOrder, err := h.recurringOrderService.CancelRecurringOrder(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("recurring_order_id", id).Msg("Failed to cancel recurring order")
+		handleRecurringOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, recurringOrderToResponse(recurringOrder))
+}
+
+func (h *RecurringOrderHandler) currentUser(c *gin.Context) (string, bool) {
+	userID, exists := auth.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "User not authenticated",
+			Details: "Authentication required",
+		})
+		return "", false
+	}
+	return userID.String(), true
+}
+
+// recurringOrderItemsToRequests converts recurring order line DTOs to service requests
+func recurringOrderItemsToRequests(items []dto.RecurringOrderItemRequest) []order.RecurringOrderItemRequest {
+	requests := make([]order.RecurringOrderItemRequest, len(items))
+	for i, item := range items {
+		requests[i] = order.RecurringOrderItemRequest{
+			ProductID: item.ProductID.String(),
+			Quantity:  item.Quantity,
+			Notes:     item.Notes,
+		}
+	}
+	return requests
+}
+
+// recurringOrderToResponse converts a recurring order entity to a response DTO
+func recurringOrderToResponse(r *entities.RecurringOrder) *dto.RecurringOrderResponse {
+	items := make([]dto.RecurringOrderItemResponse, len(r.Items))
+	for i, item := range r.Items {
+		items[i] = dto.RecurringOrderItemResponse{
+			ID:        item.ID,
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+			Notes:     item.Notes,
+		}
+	}
+
+	return &dto.RecurringOrderResponse{
+		ID:                r.ID,
+		Name:              r.Name,
+		CustomerID:        r.CustomerID,
+		Status:            string(r.Status),
+		Priority:          string(r.Priority),
+		ShippingMethod:    string(r.ShippingMethod),
+		ShippingAddressID: r.ShippingAddressID,
+		BillingAddressID:  r.BillingAddressID,
+		Currency:          r.Currency,
+		Notes:             r.Notes,
+		Items:             items,
+		Frequency:         string(r.Frequency),
+		Interval:          r.Interval,
+		DayOfMonth:        r.DayOfMonth,
+		StartDate:         r.StartDate,
+		EndDate:           r.EndDate,
+		NextRunAt:         r.NextRunAt,
+		LastRunAt:         r.LastRunAt,
+		CreatedBy:         r.CreatedBy,
+		CreatedAt:         r.CreatedAt,
+		UpdatedAt:         r.UpdatedAt,
+	}
+}
+
+// handleRecurringOrderError maps recurring order service errors to HTTP responses
+func handleRecurringOrderError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, order.ErrRecurringOrderNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:   "Recurring order not found",
+			Details: err.Error(),
+		})
+	case errors.Is(err, order.ErrRecurringOrderClosed):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error:   "Recurring order state conflict",
+			Details: err.Error(),
+		})
+	default:
+		handleOrderError(c, err)
+	}
+}
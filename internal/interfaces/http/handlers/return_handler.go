@@ -0,0 +1,522 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"erpgo/internal/application/services/order"
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/interfaces/http/dto"
+	"erpgo/pkg/auth"
+)
+
+// ReturnHandler handles return merchandise authorization (RMA) HTTP requests
+type ReturnHandler struct {
+	returnService order.ReturnService
+	logger        zerolog.Logger
+}
+
+// NewReturnHandler creates a new return handler
+func NewReturnHandler(returnService order.ReturnService, logger zerolog.Logger) *ReturnHandler {
+	return &ReturnHandler{
+		returnService: returnService,
+		logger:        logger,
+	}
+}
+
+// CreateReturn requests a return authorization
+// @Summary Create return authorization
+// @Description Request the return of shipped order lines, refunded or replaced once inspected
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Param return body dto.ReturnAuthorizationRequest true "Return data"
+// @Success 201 {object} dto.ReturnAuthorizationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/returns [post]
+func (h *ReturnHandler) CreateReturn(c *gin.Context) {
+	var req dto.ReturnAuthorizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid return creation request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	serviceReq := &order.CreateReturnRequest{
+		OrderID:       req.OrderID.String(),
+		Resolution:    entities.ReturnResolution(req.Resolution),
+		Reason:        entities.ReturnReason(req.Reason),
+		CustomerNotes: req.CustomerNotes,
+		Items:         make([]order.CreateReturnItemRequest, len(req.Items)),
+		RequestedBy:   userID,
+	}
+	for i, item := range req.Items {
+		serviceReq.Items[i] = order.CreateReturnItemRequest{
+			OrderItemID: item.OrderItemID.String(),
+			Quantity:    item.Quantity,
+			Reason:      entities.ReturnReason(item.Reason),
+		}
+	}
+
+	rma, err := h.returnService.CreateReturn(c, serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to create return")
+		handleReturnError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, returnToResponse(rma))
+}
+
+// GetReturn retrieves a return authorization by ID
+// @Summary Get return authorization
+// @Description Get a return authorization by its ID
+// @Tags returns
+// @Produce json
+// @Param id path string true "Return authorization ID"
+// @Success 200 {object} dto.ReturnAuthorizationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/returns/{id} [get]
+func (h *ReturnHandler) GetReturn(c *gin.Context) {
+	id := c.Param("id")
+
+	rma, err := h.returnService.GetReturn(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("return_id", id).Msg("Failed to get return")
+		handleReturnError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, returnToResponse(rma))
+}
+
+// GetReturnsByOrder retrieves the return authorizations of an order
+// @Summary Get returns of order
+// @Description Get every return authorization raised against an order, oldest first
+// @Tags returns
+// @Produce json
+// @Param order_id path string true "Order ID"
+// @Success 200 {array} dto.ReturnAuthorizationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/returns/order/{order_id} [get]
+func (h *ReturnHandler) GetReturnsByOrder(c *gin.Context) {
+	orderID := c.Param("order_id")
+
+	rmas, err := h.returnService.GetReturnsByOrder(c, orderID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("order_id", orderID).Msg("Failed to get returns of order")
+		handleReturnError(c, err)
+		return
+	}
+
+	response := make([]*dto.ReturnAuthorizationResponse, len(rmas))
+	for i, rma := range rmas {
+		response[i] = returnToResponse(rma)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ListReturns lists return authorizations
+// @Summary List return authorizations
+// @Description List return authorizations with filtering and pagination
+// @Tags returns
+// @Produce json
+// @Param order_id query string false "Order ID"
+// @Param customer_id query string false "Customer ID"
+// @Param status query string false "Return status"
+// @Param search query string false "Search by RMA number"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} dto.ListReturnsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/returns [get]
+func (h *ReturnHandler) ListReturns(c *gin.Context) {
+	var req dto.ListReturnsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid return list request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid query parameters",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	serviceReq := &order.ListReturnsRequest{
+		Search:     ptrStringToString(req.Search),
+		OrderID:    req.OrderID,
+		CustomerID: req.CustomerID,
+		Page:       req.Page,
+		Limit:      req.Limit,
+	}
+	if req.Status != nil {
+		serviceReq.Status = []entities.ReturnStatus{entities.ReturnStatus(*req.Status)}
+	}
+
+	result, err := h.returnService.ListReturns(c, serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to list returns")
+		handleReturnError(c, err)
+		return
+	}
+
+	rmas := make([]*dto.ReturnAuthorizationResponse, len(result.Returns))
+	for i, rma := range result.Returns {
+		rmas[i] = returnToResponse(rma)
+	}
+
+	c.JSON(http.StatusOK, &dto.ListReturnsResponse{
+		Returns: rmas,
+		Pagination: &dto.Pagination{
+			Page:       result.Pagination.Page,
+			Limit:      result.Pagination.Limit,
+			Total:      result.Pagination.Total,
+			TotalPages: result.Pagination.TotalPages,
+			HasNext:    result.Pagination.HasNext,
+			HasPrev:    result.Pagination.HasPrev,
+		},
+	})
+}
+
+// ApproveReturn approves a requested return
+// @Summary Approve return
+// @Description Authorize the customer to send back the goods of a requested return
+// @Tags returns
+// @Produce json
+// @Param id path string true "Return authorization ID"
+// @Success 200 {object} dto.ReturnAuthorizationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/returns/{id}/approve [post]
+func (h *ReturnHandler) ApproveReturn(c *gin.Context) {
+	id := c.Param("id")
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	rma, err := h.returnService.ApproveReturn(c, id, userID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("return_id", id).Msg("Failed to approve return")
+		handleReturnError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, returnToResponse(rma))
+}
+
+// RejectReturn rejects a requested return
+// @Summary Reject return
+// @Description Decline a requested return with a reason
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Param id path string true "Return authorization ID"
+// @Param reject body dto.RejectReturnRequest true "Rejection data"
+// @Success 200 {object} dto.ReturnAuthorizationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/returns/{id}/reject [post]
+func (h *ReturnHandler) RejectReturn(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.RejectReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid return rejection request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	rma, err := h.returnService.RejectReturn(c, id, req.Reason)
+	if err != nil {
+		h.logger.Error().Err(err).Str("return_id", id).Msg("Failed to reject return")
+		handleReturnError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, returnToResponse(rma))
+}
+
+// CancelReturn cancels a return before its goods are received
+// @Summary Cancel return
+// @Description Withdraw a requested or approved return
+// @Tags returns
+// @Produce json
+// @Param id path string true "Return authorization ID"
+// @Success 200 {object} dto.ReturnAuthorizationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/returns/{id}/cancel [post]
+func (h *ReturnHandler) CancelReturn(c *gin.Context) {
+	id := c.Param("id")
+
+	rma, err := h.returnService.CancelReturn(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("return_id", id).Msg("Failed to cancel return")
+		handleReturnError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, returnToResponse(rma))
+}
+
+// ReceiveReturn records the receipt of returned goods
+// @Summary Receive return
+// @Description Receive the goods of an approved return into a RETURN warehouse and mark the order lines as returned
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Param id path string true "Return authorization ID"
+// @Param receipt body dto.ReceiveReturnRequest true "Receipt data"
+// @Success 200 {object} dto.ReturnAuthorizationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/returns/{id}/receive [post]
+func (h *ReturnHandler) ReceiveReturn(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.ReceiveReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid return receipt request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	serviceReq := &order.ReceiveReturnRequest{
+		WarehouseID: req.WarehouseID.String(),
+		Items:       make([]order.ReceiveReturnItemRequest, len(req.Items)),
+		ReceivedBy:  userID,
+	}
+	for i, item := range req.Items {
+		serviceReq.Items[i] = order.ReceiveReturnItemRequest{
+			ItemID:   item.ItemID.String(),
+			Quantity: item.Quantity,
+		}
+	}
+
+	ctx := order.WithStatusChangeActor(c, userID, entities.StatusChangeSourceAPI)
+	rma, err := h.returnService.ReceiveReturn(ctx, id, serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Str("return_id", id).Msg("Failed to receive return")
+		handleReturnError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, returnToResponse(rma))
+}
+
+// InspectReturn records the inspection outcome of a received return
+// @Summary Inspect return
+// @Description Record the disposition of every received line and refund or replace the returned goods
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Param id path string true "Return authorization ID"
+// @Param inspection body dto.InspectReturnRequest true "Inspection data"
+// @Success 200 {object} dto.ReturnAuthorizationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/returns/{id}/inspect [post]
+func (h *ReturnHandler) InspectReturn(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.InspectReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid return inspection request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	serviceReq := &order.InspectReturnRequest{
+		Items:       make([]order.InspectReturnItemRequest, len(req.Items)),
+		InspectedBy: userID,
+	}
+	if req.RestockWarehouseID != nil {
+		warehouseID := req.RestockWarehouseID.String()
+		serviceReq.RestockWarehouseID = &warehouseID
+	}
+	for i, item := range req.Items {
+		serviceReq.Items[i] = order.InspectReturnItemRequest{
+			ItemID:      item.ItemID.String(),
+			Disposition: entities.ReturnDisposition(item.Disposition),
+			Notes:       item.Notes,
+		}
+	}
+
+	ctx := order.WithStatusChangeActor(c, userID, entities.StatusChangeSourceAPI)
+	rma, err := h.returnService.InspectReturn(ctx, id, serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Str("return_id", id).Msg("Failed to inspect return")
+		handleReturnError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, returnToResponse(rma))
+}
+
+// ResolveReturn retries the resolution of an inspected return
+// @Summary Resolve return
+// @Description Refund or replace the goods of an inspected return whose automatic resolution failed
+// @Tags returns
+// @Produce json
+// @Param id path string true "Return authorization ID"
+// @Success 200 {object} dto.ReturnAuthorizationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/returns/{id}/resolve [post]
+func (h *ReturnHandler) ResolveReturn(c *gin.Context) {
+	id := c.Param("id")
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	ctx := order.WithStatusChangeActor(c, userID, entities.StatusChangeSourceAPI)
+	rma, err := h.returnService.ResolveReturn(ctx, id, userID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("return_id", id).Msg("Failed to resolve return")
+		handleReturnError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, returnToResponse(rma))
+}
+
+// currentUser returns the authenticated user's ID, responding 401 when absent
+func (h *ReturnHandler) currentUser(c *gin.Context) (string, bool) {
+	userID, exists := auth.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "User not authenticated",
+			Details: "Authentication required",
+		})
+		return "", false
+	}
+	return userID.String(), true
+}
+
+// returnToResponse converts a return authorization entity to a response DTO
+func returnToResponse(r *entities.ReturnAuthorization) *dto.ReturnAuthorizationResponse {
+	items := make([]dto.ReturnAuthorizationItemResponse, len(r.Items))
+	for i, item := range r.Items {
+		items[i] = dto.ReturnAuthorizationItemResponse{
+			ID:               item.ID,
+			OrderItemID:      item.OrderItemID,
+			ProductID:        item.ProductID,
+			ProductSKU:       item.ProductSKU,
+			ProductName:      item.ProductName,
+			Quantity:         item.Quantity,
+			QuantityReceived: item.QuantityReceived,
+			UnitRefundAmount: item.UnitRefundAmount,
+			Reason:           string(item.Reason),
+			InspectionNotes:  item.InspectionNotes,
+		}
+		if item.Disposition != nil {
+			disposition := string(*item.Disposition)
+			items[i].Disposition = &disposition
+		}
+	}
+
+	return &dto.ReturnAuthorizationResponse{
+		ID:                 r.ID,
+		RMANumber:          r.RMANumber,
+		OrderID:            r.OrderID,
+		CustomerID:         r.CustomerID,
+		Status:             string(r.Status),
+		Resolution:         string(r.Resolution),
+		Reason:             string(r.Reason),
+		CustomerNotes:      r.CustomerNotes,
+		RejectionReason:    r.RejectionReason,
+		WarehouseID:        r.WarehouseID,
+		RefundAmount:       r.RefundAmount,
+		ReplacementOrderID: r.ReplacementOrderID,
+		Items:              items,
+		RequestedBy:        r.RequestedBy,
+		ApprovedBy:         r.ApprovedBy,
+		ReceivedBy:         r.ReceivedBy,
+		InspectedBy:        r.InspectedBy,
+		CreatedAt:          r.CreatedAt,
+		UpdatedAt:          r.UpdatedAt,
+		ApprovedAt:         r.ApprovedAt,
+		RejectedAt:         r.RejectedAt,
+		ReceivedAt:         r.ReceivedAt,
+		InspectedAt:        r.InspectedAt,
+		CompletedAt:        r.CompletedAt,
+		CancelledAt:        r.CancelledAt,
+	}
+}
+
+// handleReturnError handles return service errors, deferring order errors
+// raised while returning, refunding or replacing order lines to handleOrderError
+func handleReturnError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, order.ErrReturnNotFound), errors.Is(err, order.ErrWarehouseNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:   "Resource not found",
+			Details: err.Error(),
+		})
+	case errors.Is(err, order.ErrInvalidReturnData), errors.Is(err, order.ErrInvalidReturnWarehouse):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request",
+			Details: err.Error(),
+		})
+	default:
+		handleOrderError(c, err)
+	}
+}
@@ -0,0 +1,385 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"erpgo/internal/application/services/order"
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/interfaces/http/dto"
+)
+
+// ShippingHandler handles shipping zone and shipping rate HTTP requests.
+// Orders are quoted through the order endpoints.
+type ShippingHandler struct {
+	shippingService order.ShippingService
+	logger          zerolog.Logger
+}
+
+// NewShippingHandler creates a new shipping handler
+func NewShippingHandler(shippingService order.ShippingService, logger zerolog.Logger) *ShippingHandler {
+	return &ShippingHandler{
+		shippingService: shippingService,
+		logger:          logger,
+	}
+}
+
+// CreateShippingZone creates a shipping zone
+// @Summary Create shipping zone
+// @Description Create a shipping zone matched on the shipping country, state and postal code prefixes, with its rates
+// @Tags shipping
+// @Accept json
+// @Produce json
+// @Param zone body dto.CreateShippingZoneRequest true "Shipping zone"
+// @Success 201 {object} dto.ShippingZoneResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/shipping/zones [post]
+func (h *ShippingHandler) CreateShippingZone(c *gin.Context) {
+	var req dto.CreateShippingZoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid shipping zone request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	serviceReq := &order.CreateShippingZoneRequest{
+		Code:               req.Code,
+		Name:               req.Name,
+		Country:            req.Country,
+		State:              req.State,
+		PostalCodePrefixes: req.PostalCodePrefixes,
+		Rates:              make([]order.ShippingRateRequest, len(req.Rates)),
+	}
+	for i, rate := range req.Rates {
+		serviceReq.Rates[i] = shippingRateRequest(rate)
+	}
+
+	zone, err := h.shippingService.CreateShippingZone(c, serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Str("code", req.Code).Msg("Failed to create shipping zone")
+		handleShippingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, shippingZoneToResponse(zone))
+}
+
+// GetShippingZone retrieves a shipping zone by ID
+// @Summary Get shipping zone
+// @Description Get a shipping zone with its rates
+// @Tags shipping
+// @Produce json
+// @Param id path string true "Shipping zone ID"
+// @Success 200 {object} dto.ShippingZoneResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/shipping/zones/{id} [get]
+func (h *ShippingHandler) GetShippingZone(c *gin.Context) {
+	id := c.Param("id")
+
+	zone, err := h.shippingService.GetShippingZone(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("shipping_zone_id", id).Msg("Failed to get shipping zone")
+		handleShippingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, shippingZoneToResponse(zone))
+}
+
+// UpdateShippingZone updates a shipping zone
+// @Summary Update shipping zone
+// @Description Update the area or status of a shipping zone. Editable orders are requoted when next recalculated.
+// @Tags shipping
+// @Accept json
+// @Produce json
+// @Param id path string true "Shipping zone ID"
+// @Param zone body dto.UpdateShippingZoneRequest true "Shipping zone changes"
+// @Success 200 {object} dto.ShippingZoneResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/shipping/zones/{id} [put]
+func (h *ShippingHandler) UpdateShippingZone(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.UpdateShippingZoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid shipping zone update request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	zone, err := h.shippingService.UpdateShippingZone(c, id, &order.UpdateShippingZoneRequest{
+		Name:               req.Name,
+		State:              req.State,
+		PostalCodePrefixes: req.PostalCodePrefixes,
+		IsActive:           req.IsActive,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("shipping_zone_id", id).Msg("Failed to update shipping zone")
+		handleShippingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, shippingZoneToResponse(zone))
+}
+
+// ListShippingZones lists shipping zones
+// @Summary List shipping zones
+// @Description List shipping zones with their rates, with filtering and pagination
+// @Tags shipping
+// @Produce json
+// @Param search query string false "Zone code or name"
+// @Param country query string false "Country code"
+// @Param state query string false "State"
+// @Param is_active query bool false "Active zones only"
+// @Param page query int false "Page number"
+// @Param limit query int false "Page size"
+// @Success 200 {object} dto.ListShippingZonesResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/shipping/zones [get]
+func (h *ShippingHandler) ListShippingZones(c *gin.Context) {
+	var req dto.ListShippingZonesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid shipping zone list request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid query parameters",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	result, err := h.shippingService.ListShippingZones(c, &order.ListShippingZonesRequest{
+		Search:   req.Search,
+		Country:  req.Country,
+		State:    req.State,
+		IsActive: req.IsActive,
+		Page:     req.Page,
+		Limit:    req.Limit,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to list shipping zones")
+		handleShippingError(c, err)
+		return
+	}
+
+	zones := make([]*dto.ShippingZoneResponse, len(result.Zones))
+	for i, zone := range result.Zones {
+		zones[i] = shippingZoneToResponse(zone)
+	}
+
+	c.JSON(http.StatusOK, &dto.ListShippingZonesResponse{
+		Zones: zones,
+		Pagination: &dto.Pagination{
+			Page:       result.Pagination.Page,
+			Limit:      result.Pagination.Limit,
+			Total:      result.Pagination.Total,
+			TotalPages: result.Pagination.TotalPages,
+			HasNext:    result.Pagination.HasNext,
+			HasPrev:    result.Pagination.HasPrev,
+		},
+	})
+}
+
+// AddShippingRate adds a rate to a shipping zone
+// @Summary Add shipping rate
+// @Description Price a shipping method within a zone for a weight bracket. Brackets of the same method cannot overlap.
+// @Tags shipping
+// @Accept json
+// @Produce json
+// @Param id path string true "Shipping zone ID"
+// @Param rate body dto.ShippingRateRequest true "Shipping rate"
+// @Success 201 {object} dto.ShippingZoneResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/shipping/zones/{id}/rates [post]
+func (h *ShippingHandler) AddShippingRate(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.ShippingRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid shipping rate request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	rate := shippingRateRequest(req)
+	zone, err := h.shippingService.AddShippingRate(c, id, &rate)
+	if err != nil {
+		h.logger.Error().Err(err).Str("shipping_zone_id", id).Msg("Failed to add shipping rate")
+		handleShippingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, shippingZoneToResponse(zone))
+}
+
+// UpdateShippingRate updates a shipping rate
+// @Summary Update shipping rate
+// @Description Change the weight bracket, charges, free shipping threshold or delivery window of a rate, or deactivate it
+// @Tags shipping
+// @Accept json
+// @Produce json
+// @Param id path string true "Shipping zone ID"
+// @Param rate_id path string true "Shipping rate ID"
+// @Param rate body dto.UpdateShippingRateRequest true "Shipping rate changes"
+// @Success 200 {object} dto.ShippingZoneResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/shipping/zones/{id}/rates/{rate_id} [put]
+func (h *ShippingHandler) UpdateShippingRate(c *gin.Context) {
+	id := c.Param("id")
+	rateID := c.Param("rate_id")
+
+	var req dto.UpdateShippingRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid shipping rate update request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	zone, err := h.shippingService.UpdateShippingRate(c, id, rateID, &order.UpdateShippingRateRequest{
+		Name:                       req.Name,
+		MinWeight:                  req.MinWeight,
+		MaxWeight:                  req.MaxWeight,
+		ClearMaxWeight:             req.ClearMaxWeight,
+		BaseRate:                   req.BaseRate,
+		PerKgRate:                  req.PerKgRate,
+		HandlingFee:                req.HandlingFee,
+		FreeShippingThreshold:      req.FreeShippingThreshold,
+		ClearFreeShippingThreshold: req.ClearFreeShippingThreshold,
+		DimensionalDivisor:         req.DimensionalDivisor,
+		MinDeliveryDays:            req.MinDeliveryDays,
+		MaxDeliveryDays:            req.MaxDeliveryDays,
+		IsActive:                   req.IsActive,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("shipping_rate_id", rateID).Msg("Failed to update shipping rate")
+		handleShippingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, shippingZoneToResponse(zone))
+}
+
+// handleShippingError maps shipping service errors to HTTP responses
+func handleShippingError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, order.ErrShippingZoneNotFound), errors.Is(err, order.ErrShippingRateNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:   "Resource not found",
+			Details: err.Error(),
+		})
+	case errors.Is(err, order.ErrShippingZoneExists):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error:   "Shipping state conflict",
+			Details: err.Error(),
+		})
+	default:
+		handleOrderError(c, err)
+	}
+}
+
+// shippingRateRequest converts a shipping rate DTO to a service request
+func shippingRateRequest(req dto.ShippingRateRequest) order.ShippingRateRequest {
+	return order.ShippingRateRequest{
+		Method:                req.Method,
+		Name:                  req.Name,
+		MinWeight:             req.MinWeight,
+		MaxWeight:             req.MaxWeight,
+		BaseRate:              req.BaseRate,
+		PerKgRate:             req.PerKgRate,
+		HandlingFee:           req.HandlingFee,
+		FreeShippingThreshold: req.FreeShippingThreshold,
+		DimensionalDivisor:    req.DimensionalDivisor,
+		MinDeliveryDays:       req.MinDeliveryDays,
+		MaxDeliveryDays:       req.MaxDeliveryDays,
+	}
+}
+
+// shippingZoneToResponse converts a shipping zone entity to a response DTO
+func shippingZoneToResponse(zone *entities.ShippingZone) *dto.ShippingZoneResponse {
+	rates := make([]dto.ShippingRateResponse, len(zone.Rates))
+	for i, rate := range zone.Rates {
+		rates[i] = dto.ShippingRateResponse{
+			ID:                    rate.ID,
+			Method:                string(rate.Method),
+			Name:                  rate.Name,
+			MinWeight:             rate.MinWeight,
+			MaxWeight:             rate.MaxWeight,
+			BaseRate:              rate.BaseRate,
+			PerKgRate:             rate.PerKgRate,
+			HandlingFee:           rate.HandlingFee,
+			FreeShippingThreshold: rate.FreeShippingThreshold,
+			DimensionalDivisor:    rate.DimensionalDivisor,
+			MinDeliveryDays:       rate.MinDeliveryDays,
+			MaxDeliveryDays:       rate.MaxDeliveryDays,
+			IsActive:              rate.IsActive,
+			CreatedAt:             rate.CreatedAt,
+			UpdatedAt:             rate.UpdatedAt,
+		}
+	}
+
+	prefixes := zone.PostalCodePrefixes
+	if prefixes == nil {
+		prefixes = []string{}
+	}
+
+	return &dto.ShippingZoneResponse{
+		ID:                 zone.ID,
+		Code:               zone.Code,
+		Name:               zone.Name,
+		Country:            zone.Country,
+		State:              zone.State,
+		PostalCodePrefixes: prefixes,
+		IsActive:           zone.IsActive,
+		Rates:              rates,
+		CreatedAt:          zone.CreatedAt,
+		UpdatedAt:          zone.UpdatedAt,
+	}
+}
+
+// shippingQuotesToResponse converts shipping quotes to response DTOs
+func shippingQuotesToResponse(quotes []entities.ShippingQuote) []dto.ShippingQuoteResponse {
+	response := make([]dto.ShippingQuoteResponse, len(quotes))
+	for i, quote := range quotes {
+		response[i] = dto.ShippingQuoteResponse{
+			Method:                string(quote.Method),
+			RateID:                quote.RateID,
+			Name:                  quote.Name,
+			ZoneCode:              quote.ZoneCode,
+			ActualWeight:          quote.ActualWeight,
+			DimensionalWeight:     quote.DimensionalWeight,
+			ChargeableWeight:      quote.ChargeableWeight,
+			Amount:                quote.Amount,
+			FreeShipping:          quote.FreeShipping,
+			MinDeliveryDays:       quote.MinDeliveryDays,
+			MaxDeliveryDays:       quote.MaxDeliveryDays,
+			EstimatedDeliveryFrom: quote.EstimatedDeliveryFrom,
+			EstimatedDeliveryTo:   quote.EstimatedDeliveryTo,
+		}
+	}
+	return response
+}
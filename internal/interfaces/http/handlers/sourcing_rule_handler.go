@@ -0,0 +1,250 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"erpgo/internal/application/services/order"
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/interfaces/http/dto"
+	"erpgo/pkg/auth"
+)
+
+// SourcingRuleHandler handles sourcing rule HTTP requests. Orders are
+// sourced through the order endpoints.
+type SourcingRuleHandler struct {
+	ruleService order.SourcingRuleService
+	logger      zerolog.Logger
+}
+
+// NewSourcingRuleHandler creates a new sourcing rule handler
+func NewSourcingRuleHandler(ruleService order.SourcingRuleService, logger zerolog.Logger) *SourcingRuleHandler {
+	return &SourcingRuleHandler{
+		ruleService: ruleService,
+		logger:      logger,
+	}
+}
+
+// CreateSourcingRule creates a sourcing rule
+// @Summary Create sourcing rule
+// @Description Create a rule choosing the warehouses that fulfil orders shipping to its countries with its shipping methods. Strategies apply in the order given: FEWEST_SHIPMENTS, CLOSEST (by region of the shipping address), FULFILLMENT_FIRST and AVOID_SPLIT.
+// @Tags sourcing-rules
+// @Accept json
+// @Produce json
+// @Param rule body dto.CreateSourcingRuleRequest true "Sourcing rule"
+// @Success 201 {object} dto.SourcingRuleResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/sourcing-rules [post]
+func (h *SourcingRuleHandler) CreateSourcingRule(c *gin.Context) {
+	var req dto.CreateSourcingRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid sourcing rule request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := auth.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "User not authenticated",
+			Details: "Authentication required",
+		})
+		return
+	}
+
+	rule, err := h.ruleService.CreateSourcingRule(c, &order.CreateSourcingRuleRequest{
+		Name:            req.Name,
+		Description:     req.Description,
+		Countries:       req.Countries,
+		ShippingMethods: req.ShippingMethods,
+		Strategies:      req.Strategies,
+		Priority:        req.Priority,
+		CreatedBy:       userID.String(),
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("name", req.Name).Msg("Failed to create sourcing rule")
+		handleOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, sourcingRuleToResponse(rule))
+}
+
+// GetSourcingRule retrieves a sourcing rule by ID
+// @Summary Get sourcing rule
+// @Description Get a sourcing rule with its conditions and strategies
+// @Tags sourcing-rules
+// @Produce json
+// @Param id path string true "Sourcing rule ID"
+// @Success 200 {object} dto.SourcingRuleResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/sourcing-rules/{id} [get]
+func (h *SourcingRuleHandler) GetSourcingRule(c *gin.Context) {
+	id := c.Param("id")
+
+	rule, err := h.ruleService.GetSourcingRule(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("rule_id", id).Msg("Failed to get sourcing rule")
+		handleOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, sourcingRuleToResponse(rule))
+}
+
+// UpdateSourcingRule updates a sourcing rule
+// @Summary Update sourcing rule
+// @Description Update the conditions, strategies, priority or status of a sourcing rule. Reserved orders keep their warehouses until they are sourced again.
+// @Tags sourcing-rules
+// @Accept json
+// @Produce json
+// @Param id path string true "Sourcing rule ID"
+// @Param rule body dto.UpdateSourcingRuleRequest true "Sourcing rule changes"
+// @Success 200 {object} dto.SourcingRuleResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/sourcing-rules/{id} [put]
+func (h *SourcingRuleHandler) UpdateSourcingRule(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.UpdateSourcingRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid sourcing rule update request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	rule, err := h.ruleService.UpdateSourcingRule(c, id, &order.UpdateSourcingRuleRequest{
+		Name:            req.Name,
+		Description:     req.Description,
+		Countries:       req.Countries,
+		ShippingMethods: req.ShippingMethods,
+		Strategies:      req.Strategies,
+		Priority:        req.Priority,
+		IsActive:        req.IsActive,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("rule_id", id).Msg("Failed to update sourcing rule")
+		handleOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, sourcingRuleToResponse(rule))
+}
+
+// ListSourcingRules lists sourcing rules
+// @Summary List sourcing rules
+// @Description List sourcing rules, highest priority first
+// @Tags sourcing-rules
+// @Produce json
+// @Param search query string false "Name"
+// @Param is_active query bool false "Active rules only"
+// @Param page query int false "Page number"
+// @Param limit query int false "Page size"
+// @Success 200 {object} dto.ListSourcingRulesResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/sourcing-rules [get]
+func (h *SourcingRuleHandler) ListSourcingRules(c *gin.Context) {
+	var req dto.ListSourcingRulesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid sourcing rule list request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid query parameters",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	result, err := h.ruleService.ListSourcingRules(c, &order.ListSourcingRulesRequest{
+		Search:   req.Search,
+		IsActive: req.IsActive,
+		Page:     req.Page,
+		Limit:    req.Limit,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to list sourcing rules")
+		handleOrderError(c, err)
+		return
+	}
+
+	rules := make([]*dto.SourcingRuleResponse, len(result.Rules))
+	for i, rule := range result.Rules {
+		rules[i] = sourcingRuleToResponse(rule)
+	}
+
+	c.JSON(http.StatusOK, &dto.ListSourcingRulesResponse{
+		Rules: rules,
+		Pagination: &dto.Pagination{
+			Page:       result.Pagination.Page,
+			Limit:      result.Pagination.Limit,
+			Total:      result.Pagination.Total,
+			TotalPages: result.Pagination.TotalPages,
+			HasNext:    result.Pagination.HasNext,
+			HasPrev:    result.Pagination.HasPrev,
+		},
+	})
+}
+
+// sourcingRuleToResponse converts a sourcing rule entity to a response DTO
+func sourcingRuleToResponse(rule *entities.SourcingRule) *dto.SourcingRuleResponse {
+	response := &dto.SourcingRuleResponse{
+		ID:              rule.ID,
+		Name:            rule.Name,
+		Description:     rule.Description,
+		Countries:       rule.Countries,
+		ShippingMethods: make([]string, len(rule.ShippingMethods)),
+		Strategies:      make([]string, len(rule.Strategies)),
+		Priority:        rule.Priority,
+		IsActive:        rule.IsActive,
+		CreatedBy:       rule.CreatedBy,
+		CreatedAt:       rule.CreatedAt,
+		UpdatedAt:       rule.UpdatedAt,
+	}
+	if response.Countries == nil {
+		response.Countries = []string{}
+	}
+	for i, method := range rule.ShippingMethods {
+		response.ShippingMethods[i] = string(method)
+	}
+	for i, strategy := range rule.Strategies {
+		response.Strategies[i] = string(strategy)
+	}
+	return response
+}
+
+// orderAllocationsToResponse converts the allocations of an order to a response DTO
+func orderAllocationsToResponse(allocations []*entities.OrderAllocation) dto.OrderAllocationsResponse {
+	response := dto.OrderAllocationsResponse{
+		Allocations: make([]dto.OrderAllocationResponse, len(allocations)),
+	}
+	warehouses := make(map[string]bool)
+	for i, allocation := range allocations {
+		response.Allocations[i] = dto.OrderAllocationResponse{
+			ID:          allocation.ID,
+			OrderItemID: allocation.OrderItemID,
+			ProductID:   allocation.ProductID,
+			WarehouseID: allocation.WarehouseID,
+			Quantity:    allocation.Quantity,
+			RuleID:      allocation.RuleID,
+			UpdatedAt:   allocation.UpdatedAt,
+		}
+		warehouses[allocation.WarehouseID.String()] = true
+	}
+	response.Shipments = len(warehouses)
+	return response
+}
@@ -0,0 +1,313 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"erpgo/internal/application/services/purchasing"
+	"erpgo/internal/domain/purchasing/entities"
+	"erpgo/internal/interfaces/http/dto"
+)
+
+// SupplierHandler handles supplier HTTP requests
+type SupplierHandler struct {
+	purchasingService purchasing.Service
+	logger            zerolog.Logger
+}
+
+// NewSupplierHandler creates a new supplier handler
+func NewSupplierHandler(purchasingService purchasing.Service, logger zerolog.Logger) *SupplierHandler {
+	return &SupplierHandler{
+		purchasingService: purchasingService,
+		logger:            logger,
+	}
+}
+
+// CreateSupplier creates a new supplier
+// @Summary Create supplier
+// @Description Create a supplier with its contacts and purchasing terms
+// @Tags suppliers
+// @Accept json
+// @Produce json
+// @Param supplier body dto.SupplierRequest true "Supplier data"
+// @Success 201 {object} dto.SupplierResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/suppliers [post]
+func (h *SupplierHandler) CreateSupplier(c *gin.Context) {
+	var req dto.SupplierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid supplier creation request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	supplier, err := h.purchasingService.CreateSupplier(c, supplierRequestToService(&req))
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to create supplier")
+		handlePurchasingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, supplierToResponse(supplier))
+}
+
+// GetSupplier retrieves a supplier by ID
+// @Summary Get supplier
+// @Description Get a supplier with its contacts
+// @Tags suppliers
+// @Produce json
+// @Param id path string true "Supplier ID"
+// @Success 200 {object} dto.SupplierResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/suppliers/{id} [get]
+func (h *SupplierHandler) GetSupplier(c *gin.Context) {
+	id := c.Param("id")
+
+	supplier, err := h.purchasingService.GetSupplier(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("supplier_id", id).Msg("Failed to get supplier")
+		handlePurchasingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, supplierToResponse(supplier))
+}
+
+// UpdateSupplier replaces a supplier's master data and contacts
+// @Summary Update supplier
+// @Description Replace a supplier's master data, purchasing terms and contacts
+// @Tags suppliers
+// @Accept json
+// @Produce json
+// @Param id path string true "Supplier ID"
+// @Param supplier body dto.SupplierRequest true "Supplier data"
+// @Success 200 {object} dto.SupplierResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/suppliers/{id} [put]
+func (h *SupplierHandler) UpdateSupplier(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.SupplierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid supplier update request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	supplier, err := h.purchasingService.UpdateSupplier(c, id, supplierRequestToService(&req))
+	if err != nil {
+		h.logger.Error().Err(err).Str("supplier_id", id).Msg("Failed to update supplier")
+		handlePurchasingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, supplierToResponse(supplier))
+}
+
+// DeleteSupplier deletes a supplier
+// @Summary Delete supplier
+// @Description Delete a supplier that has no open purchase orders
+// @Tags suppliers
+// @Param id path string true "Supplier ID"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/suppliers/{id} [delete]
+func (h *SupplierHandler) DeleteSupplier(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := h.purchasingService.DeleteSupplier(c, id); err != nil {
+		h.logger.Error().Err(err).Str("supplier_id", id).Msg("Failed to delete supplier")
+		handlePurchasingError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListSuppliers lists suppliers
+// @Summary List suppliers
+// @Description List suppliers with filtering and pagination
+// @Tags suppliers
+// @Produce json
+// @Param search query string false "Search by code, name or email"
+// @Param is_active query bool false "Active suppliers only"
+// @Param currency query string false "Supplier currency"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} dto.ListSuppliersResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/suppliers [get]
+func (h *SupplierHandler) ListSuppliers(c *gin.Context) {
+	var req dto.ListSuppliersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid supplier list request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid query parameters",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	result, err := h.purchasingService.ListSuppliers(c, &purchasing.ListSuppliersRequest{
+		Search:   ptrStringToString(req.Search),
+		IsActive: req.IsActive,
+		Currency: ptrStringToString(req.Currency),
+		Page:     req.Page,
+		Limit:    req.Limit,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to list suppliers")
+		handlePurchasingError(c, err)
+		return
+	}
+
+	suppliers := make([]*dto.SupplierResponse, len(result.Suppliers))
+	for i, supplier := range result.Suppliers {
+		suppliers[i] = supplierToResponse(supplier)
+	}
+
+	c.JSON(http.StatusOK, &dto.ListSuppliersResponse{
+		Suppliers:  suppliers,
+		Pagination: purchasingPagination(result.Pagination),
+	})
+}
+
+// supplierRequestToService converts a supplier DTO to a service request
+func supplierRequestToService(req *dto.SupplierRequest) *purchasing.SupplierRequest {
+	contacts := make([]purchasing.SupplierContactRequest, len(req.Contacts))
+	for i, contact := range req.Contacts {
+		contacts[i] = purchasing.SupplierContactRequest{
+			Name:      contact.Name,
+			Role:      contact.Role,
+			Email:     contact.Email,
+			Phone:     contact.Phone,
+			IsPrimary: contact.IsPrimary,
+		}
+	}
+
+	return &purchasing.SupplierRequest{
+		SupplierCode: req.SupplierCode,
+		Name:         req.Name,
+		LegalName:    req.LegalName,
+		TaxID:        req.TaxID,
+		Email:        req.Email,
+		Phone:        req.Phone,
+		Website:      req.Website,
+		AddressLine1: req.AddressLine1,
+		AddressLine2: req.AddressLine2,
+		City:         req.City,
+		State:        req.State,
+		PostalCode:   req.PostalCode,
+		Country:      req.Country,
+		Currency:     req.Currency,
+		PaymentTerms: req.PaymentTerms,
+		LeadTimeDays: req.LeadTimeDays,
+		IsActive:     req.IsActive,
+		Notes:        req.Notes,
+		Contacts:     contacts,
+	}
+}
+
+// supplierToResponse converts a supplier entity to a response DTO
+func supplierToResponse(s *entities.Supplier) *dto.SupplierResponse {
+	contacts := make([]dto.SupplierContactResponse, len(s.Contacts))
+	for i, contact := range s.Contacts {
+		contacts[i] = dto.SupplierContactResponse{
+			ID:        contact.ID,
+			Name:      contact.Name,
+			Role:      contact.Role,
+			Email:     contact.Email,
+			Phone:     contact.Phone,
+			IsPrimary: contact.IsPrimary,
+		}
+	}
+
+	return &dto.SupplierResponse{
+		ID:           s.ID,
+		SupplierCode: s.SupplierCode,
+		Name:         s.Name,
+		LegalName:    s.LegalName,
+		TaxID:        s.TaxID,
+		Email:        s.Email,
+		Phone:        s.Phone,
+		Website:      s.Website,
+		AddressLine1: s.AddressLine1,
+		AddressLine2: s.AddressLine2,
+		City:         s.City,
+		State:        s.State,
+		PostalCode:   s.PostalCode,
+		Country:      s.Country,
+		Currency:     s.Currency,
+		PaymentTerms: s.PaymentTerms,
+		LeadTimeDays: s.LeadTimeDays,
+		IsActive:     s.IsActive,
+		Notes:        s.Notes,
+		Contacts:     contacts,
+		CreatedAt:    s.CreatedAt,
+		UpdatedAt:    s.UpdatedAt,
+	}
+}
+
+// purchasingPagination converts purchasing pagination metadata to a response DTO
+func purchasingPagination(p *purchasing.Pagination) *dto.Pagination {
+	return &dto.Pagination{
+		Page:       p.Page,
+		Limit:      p.Limit,
+		Total:      p.Total,
+		TotalPages: p.TotalPages,
+		HasNext:    p.HasNext,
+		HasPrev:    p.HasPrev,
+	}
+}
+
+// handlePurchasingError handles supplier, purchase order and goods receipt service errors
+func handlePurchasingError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, purchasing.ErrSupplierNotFound), errors.Is(err, purchasing.ErrPurchaseOrderNotFound),
+		errors.Is(err, purchasing.ErrProductNotFound), errors.Is(err, purchasing.ErrWarehouseNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:   "Resource not found",
+			Details: err.Error(),
+		})
+	case errors.Is(err, purchasing.ErrSupplierAlreadyExists), errors.Is(err, purchasing.ErrSupplierInactive),
+		errors.Is(err, purchasing.ErrSupplierInUse), errors.Is(err, purchasing.ErrPurchaseOrderNotEditable),
+		errors.Is(err, purchasing.ErrInvalidStatusTransition):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error:   "Purchasing state conflict",
+			Details: err.Error(),
+		})
+	case errors.Is(err, purchasing.ErrInvalidReceipt), errors.Is(err, purchasing.ErrInvalidPurchaseOrderData),
+		errors.Is(err, purchasing.ErrInvalidSupplierData), errors.Is(err, purchasing.ErrCancellationReasonMissing),
+		strings.HasPrefix(err.Error(), "invalid "):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request",
+			Details: err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "Internal server error",
+			Details: err.Error(),
+		})
+	}
+}
@@ -0,0 +1,503 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"erpgo/internal/application/services/order"
+	"erpgo/internal/domain/orders/entities"
+	"erpgo/internal/interfaces/http/dto"
+	"erpgo/pkg/auth"
+)
+
+// TaxHandler handles tax zone, tax rule and tax exemption HTTP requests
+type TaxHandler struct {
+	taxService order.TaxService
+	logger     zerolog.Logger
+}
+
+// NewTaxHandler creates a new tax handler
+func NewTaxHandler(taxService order.TaxService, logger zerolog.Logger) *TaxHandler {
+	return &TaxHandler{
+		taxService: taxService,
+		logger:     logger,
+	}
+}
+
+// CreateTaxZone creates a tax zone
+// @Summary Create tax zone
+// @Description Create a tax zone matched on the shipping country, state and postal code prefixes, with its rules
+// @Tags tax
+// @Accept json
+// @Produce json
+// @Param zone body dto.CreateTaxZoneRequest true "Tax zone"
+// @Success 201 {object} dto.TaxZoneResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/tax/zones [post]
+func (h *TaxHandler) CreateTaxZone(c *gin.Context) {
+	var req dto.CreateTaxZoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid tax zone request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	serviceReq := &order.CreateTaxZoneRequest{
+		Code:               req.Code,
+		Name:               req.Name,
+		Country:            req.Country,
+		State:              req.State,
+		PostalCodePrefixes: req.PostalCodePrefixes,
+		PricesIncludeTax:   req.PricesIncludeTax,
+		Rules:              make([]order.TaxRuleRequest, len(req.Rules)),
+	}
+	for i, rule := range req.Rules {
+		serviceReq.Rules[i] = taxRuleRequest(rule)
+	}
+
+	zone, err := h.taxService.CreateTaxZone(c, serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Str("code", req.Code).Msg("Failed to create tax zone")
+		handleTaxError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, taxZoneToResponse(zone))
+}
+
+// GetTaxZone retrieves a tax zone by ID
+// @Summary Get tax zone
+// @Description Get a tax zone with its rules
+// @Tags tax
+// @Produce json
+// @Param id path string true "Tax zone ID"
+// @Success 200 {object} dto.TaxZoneResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/tax/zones/{id} [get]
+func (h *TaxHandler) GetTaxZone(c *gin.Context) {
+	id := c.Param("id")
+
+	zone, err := h.taxService.GetTaxZone(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("tax_zone_id", id).Msg("Failed to get tax zone")
+		handleTaxError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, taxZoneToResponse(zone))
+}
+
+// UpdateTaxZone updates a tax zone
+// @Summary Update tax zone
+// @Description Update the jurisdiction, pricing mode or status of a tax zone. Orders are repriced when next recalculated.
+// @Tags tax
+// @Accept json
+// @Produce json
+// @Param id path string true "Tax zone ID"
+// @Param zone body dto.UpdateTaxZoneRequest true "Tax zone changes"
+// @Success 200 {object} dto.TaxZoneResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/tax/zones/{id} [put]
+func (h *TaxHandler) UpdateTaxZone(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.UpdateTaxZoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid tax zone update request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	zone, err := h.taxService.UpdateTaxZone(c, id, &order.UpdateTaxZoneRequest{
+		Name:               req.Name,
+		State:              req.State,
+		PostalCodePrefixes: req.PostalCodePrefixes,
+		PricesIncludeTax:   req.PricesIncludeTax,
+		IsActive:           req.IsActive,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("tax_zone_id", id).Msg("Failed to update tax zone")
+		handleTaxError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, taxZoneToResponse(zone))
+}
+
+// ListTaxZones lists tax zones
+// @Summary List tax zones
+// @Description List tax zones with their rules, with filtering and pagination
+// @Tags tax
+// @Produce json
+// @Param search query string false "Zone code or name"
+// @Param country query string false "Country code"
+// @Param state query string false "State"
+// @Param is_active query bool false "Active zones only"
+// @Param page query int false "Page number"
+// @Param limit query int false "Page size"
+// @Success 200 {object} dto.ListTaxZonesResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/tax/zones [get]
+func (h *TaxHandler) ListTaxZones(c *gin.Context) {
+	var req dto.ListTaxZonesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid tax zone list request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid query parameters",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	result, err := h.taxService.ListTaxZones(c, &order.ListTaxZonesRequest{
+		Search:   req.Search,
+		Country:  req.Country,
+		State:    req.State,
+		IsActive: req.IsActive,
+		Page:     req.Page,
+		Limit:    req.Limit,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to list tax zones")
+		handleTaxError(c, err)
+		return
+	}
+
+	zones := make([]*dto.TaxZoneResponse, len(result.Zones))
+	for i, zone := range result.Zones {
+		zones[i] = taxZoneToResponse(zone)
+	}
+
+	c.JSON(http.StatusOK, &dto.ListTaxZonesResponse{
+		Zones: zones,
+		Pagination: &dto.Pagination{
+			Page:       result.Pagination.Page,
+			Limit:      result.Pagination.Limit,
+			Total:      result.Pagination.Total,
+			TotalPages: result.Pagination.TotalPages,
+			HasNext:    result.Pagination.HasNext,
+			HasPrev:    result.Pagination.HasPrev,
+		},
+	})
+}
+
+// AddTaxRule adds a rule to a tax zone
+// @Summary Add tax rule
+// @Description Add a named tax component levied on a tax class within a zone. Use tax class SHIPPING to tax shipping charges.
+// @Tags tax
+// @Accept json
+// @Produce json
+// @Param id path string true "Tax zone ID"
+// @Param rule body dto.TaxRuleRequest true "Tax rule"
+// @Success 201 {object} dto.TaxZoneResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/tax/zones/{id}/rules [post]
+func (h *TaxHandler) AddTaxRule(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.TaxRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid tax rule request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	rule := taxRuleRequest(req)
+	zone, err := h.taxService.AddTaxRule(c, id, &rule)
+	if err != nil {
+		h.logger.Error().Err(err).Str("tax_zone_id", id).Msg("Failed to add tax rule")
+		handleTaxError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, taxZoneToResponse(zone))
+}
+
+// UpdateTaxRule updates a tax rule
+// @Summary Update tax rule
+// @Description Change the rate, level, class or name of a tax rule, or deactivate it
+// @Tags tax
+// @Accept json
+// @Produce json
+// @Param id path string true "Tax zone ID"
+// @Param rule_id path string true "Tax rule ID"
+// @Param rule body dto.UpdateTaxRuleRequest true "Tax rule changes"
+// @Success 200 {object} dto.TaxZoneResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/tax/zones/{id}/rules/{rule_id} [put]
+func (h *TaxHandler) UpdateTaxRule(c *gin.Context) {
+	id := c.Param("id")
+	ruleID := c.Param("rule_id")
+
+	var req dto.UpdateTaxRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid tax rule update request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	zone, err := h.taxService.UpdateTaxRule(c, id, ruleID, &order.UpdateTaxRuleRequest{
+		Name:     req.Name,
+		Level:    req.Level,
+		TaxClass: req.TaxClass,
+		Rate:     req.Rate,
+		IsActive: req.IsActive,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("tax_rule_id", ruleID).Msg("Failed to update tax rule")
+		handleTaxError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, taxZoneToResponse(zone))
+}
+
+// CreateTaxExemption records a customer's exemption certificate
+// @Summary Create tax exemption
+// @Description Record an exemption certificate exempting a customer from the taxes of a country or state
+// @Tags tax
+// @Accept json
+// @Produce json
+// @Param exemption body dto.CreateTaxExemptionRequest true "Exemption certificate"
+// @Success 201 {object} dto.TaxExemptionResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/tax/exemptions [post]
+func (h *TaxHandler) CreateTaxExemption(c *gin.Context) {
+	var req dto.CreateTaxExemptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid tax exemption request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	exemption, err := h.taxService.CreateTaxExemption(c, &order.CreateTaxExemptionRequest{
+		CustomerID:        req.CustomerID.String(),
+		CertificateNumber: req.CertificateNumber,
+		Country:           req.Country,
+		State:             req.State,
+		ValidFrom:         req.ValidFrom,
+		ValidUntil:        req.ValidUntil,
+		Reason:            req.Reason,
+		CreatedBy:         userID,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("customer_id", req.CustomerID.String()).Msg("Failed to create tax exemption")
+		handleTaxError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, taxExemptionToResponse(exemption))
+}
+
+// GetCustomerTaxExemptions lists the exemption certificates of a customer
+// @Summary Get tax exemptions of customer
+// @Description Get the exemption certificates of a customer, revoked ones included
+// @Tags tax
+// @Produce json
+// @Param customer_id path string true "Customer ID"
+// @Success 200 {array} dto.TaxExemptionResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/tax/exemptions/customer/{customer_id} [get]
+func (h *TaxHandler) GetCustomerTaxExemptions(c *gin.Context) {
+	customerID := c.Param("customer_id")
+
+	exemptions, err := h.taxService.GetCustomerTaxExemptions(c, customerID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("customer_id", customerID).Msg("Failed to get tax exemptions")
+		handleTaxError(c, err)
+		return
+	}
+
+	response := make([]*dto.TaxExemptionResponse, len(exemptions))
+	for i, exemption := range exemptions {
+		response[i] = taxExemptionToResponse(exemption)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// RevokeTaxExemption revokes an exemption certificate
+// @Summary Revoke tax exemption
+// @Description Withdraw an exemption certificate. Orders are taxed again when next recalculated.
+// @Tags tax
+// @Accept json
+// @Produce json
+// @Param id path string true "Tax exemption ID"
+// @Param revocation body dto.RevokeTaxExemptionRequest true "Revoke reason"
+// @Success 200 {object} dto.TaxExemptionResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/tax/exemptions/{id}/revoke [post]
+func (h *TaxHandler) RevokeTaxExemption(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.RevokeTaxExemptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid tax exemption revocation request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	exemption, err := h.taxService.RevokeTaxExemption(c, id, &order.RevokeTaxExemptionRequest{
+		Reason:    req.Reason,
+		RevokedBy: userID,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("tax_exemption_id", id).Msg("Failed to revoke tax exemption")
+		handleTaxError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, taxExemptionToResponse(exemption))
+}
+
+// currentUser returns the authenticated user's ID, responding 401 when absent
+func (h *TaxHandler) currentUser(c *gin.Context) (string, bool) {
+	userID, exists := auth.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "User not authenticated",
+			Details: "Authentication required",
+		})
+		return "", false
+	}
+	return userID.String(), true
+}
+
+// handleTaxError maps tax service errors to HTTP responses
+func handleTaxError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, order.ErrTaxZoneNotFound), errors.Is(err, order.ErrTaxRuleNotFound),
+		errors.Is(err, order.ErrTaxExemptionNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:   "Resource not found",
+			Details: err.Error(),
+		})
+	case errors.Is(err, order.ErrTaxZoneExists), errors.Is(err, order.ErrTaxExemptionExists),
+		errors.Is(err, order.ErrTaxExemptionRevoked):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error:   "Tax state conflict",
+			Details: err.Error(),
+		})
+	default:
+		handleOrderError(c, err)
+	}
+}
+
+// taxRuleRequest converts a tax rule DTO to a service request
+func taxRuleRequest(req dto.TaxRuleRequest) order.TaxRuleRequest {
+	return order.TaxRuleRequest{
+		Name:     req.Name,
+		Level:    req.Level,
+		TaxClass: req.TaxClass,
+		Rate:     req.Rate,
+	}
+}
+
+// taxZoneToResponse converts a tax zone entity to a response DTO
+func taxZoneToResponse(zone *entities.TaxZone) *dto.TaxZoneResponse {
+	rules := make([]dto.TaxRuleResponse, len(zone.Rules))
+	for i, rule := range zone.Rules {
+		rules[i] = dto.TaxRuleResponse{
+			ID:        rule.ID,
+			Name:      rule.Name,
+			Level:     string(rule.Level),
+			TaxClass:  rule.TaxClass,
+			Rate:      rule.Rate,
+			IsActive:  rule.IsActive,
+			CreatedAt: rule.CreatedAt,
+			UpdatedAt: rule.UpdatedAt,
+		}
+	}
+
+	prefixes := zone.PostalCodePrefixes
+	if prefixes == nil {
+		prefixes = []string{}
+	}
+
+	return &dto.TaxZoneResponse{
+		ID:                 zone.ID,
+		Code:               zone.Code,
+		Name:               zone.Name,
+		Country:            zone.Country,
+		State:              zone.State,
+		PostalCodePrefixes: prefixes,
+		PricesIncludeTax:   zone.PricesIncludeTax,
+		IsActive:           zone.IsActive,
+		Rules:              rules,
+		CreatedAt:          zone.CreatedAt,
+		UpdatedAt:          zone.UpdatedAt,
+	}
+}
+
+// taxExemptionToResponse converts a tax exemption entity to a response DTO
+func taxExemptionToResponse(exemption *entities.TaxExemption) *dto.TaxExemptionResponse {
+	return &dto.TaxExemptionResponse{
+		ID:                exemption.ID,
+		CustomerID:        exemption.CustomerID,
+		CertificateNumber: exemption.CertificateNumber,
+		Country:           exemption.Country,
+		State:             exemption.State,
+		ValidFrom:         exemption.ValidFrom,
+		ValidUntil:        exemption.ValidUntil,
+		Reason:            exemption.Reason,
+		Revoked:           exemption.IsRevoked(),
+		RevokedAt:         exemption.RevokedAt,
+		RevokedBy:         exemption.RevokedBy,
+		RevokeReason:      exemption.RevokeReason,
+		CreatedBy:         exemption.CreatedBy,
+		CreatedAt:         exemption.CreatedAt,
+		UpdatedAt:         exemption.UpdatedAt,
+	}
+}
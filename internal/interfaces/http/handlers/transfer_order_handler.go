@@ -0,0 +1,513 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"erpgo/internal/application/services/transfer"
+	"erpgo/internal/domain/inventory/entities"
+	"erpgo/internal/interfaces/http/dto"
+	"erpgo/pkg/auth"
+)
+
+// TransferOrderHandler handles inter-warehouse transfer order HTTP requests
+type TransferOrderHandler struct {
+	transferService transfer.Service
+	logger          zerolog.Logger
+}
+
+// NewTransferOrderHandler creates a new transfer order handler
+func NewTransferOrderHandler(transferService transfer.Service, logger zerolog.Logger) *TransferOrderHandler {
+	return &TransferOrderHandler{
+		transferService: transferService,
+		logger:          logger,
+	}
+}
+
+// CreateTransferOrder creates a new draft transfer order
+// @Summary Create transfer order
+// @Description Create a draft transfer order between two warehouses, reserving the stock in the source warehouse
+// @Tags transfer-orders
+// @Accept json
+// @Produce json
+// @Param transfer_order body dto.TransferOrderRequest true "Transfer order data"
+// @Success 201 {object} dto.TransferOrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/transfer-orders [post]
+func (h *TransferOrderHandler) CreateTransferOrder(c *gin.Context) {
+	var req dto.TransferOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid transfer order creation request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	items := make([]transfer.TransferOrderItemRequest, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = transfer.TransferOrderItemRequest{
+			ProductID: item.ProductID.String(),
+			Quantity:  item.Quantity,
+			Notes:     item.Notes,
+		}
+	}
+
+	order, err := h.transferService.CreateTransferOrder(c, &transfer.CreateTransferOrderRequest{
+		FromWarehouseID: req.FromWarehouseID.String(),
+		ToWarehouseID:   req.ToWarehouseID.String(),
+		ExpectedDate:    req.ExpectedDate,
+		Notes:           req.Notes,
+		Items:           items,
+		CreatedBy:       userID,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to create transfer order")
+		handleTransferError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, transferOrderToResponse(order))
+}
+
+// GetTransferOrder retrieves a transfer order by ID
+// @Summary Get transfer order
+// @Description Get a transfer order with its shipped, received, damaged, short and in-transit quantities
+// @Tags transfer-orders
+// @Produce json
+// @Param id path string true "Transfer order ID"
+// @Success 200 {object} dto.TransferOrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/transfer-orders/{id} [get]
+func (h *TransferOrderHandler) GetTransferOrder(c *gin.Context) {
+	id := c.Param("id")
+
+	order, err := h.transferService.GetTransferOrder(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("transfer_order_id", id).Msg("Failed to get transfer order")
+		handleTransferError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, transferOrderToResponse(order))
+}
+
+// ListTransferOrders lists transfer orders
+// @Summary List transfer orders
+// @Description List transfer orders with filtering and pagination
+// @Tags transfer-orders
+// @Produce json
+// @Param from_warehouse_id query string false "Source warehouse ID"
+// @Param to_warehouse_id query string false "Destination warehouse ID"
+// @Param status query string false "Transfer order status"
+// @Param search query string false "Search by transfer number or tracking number"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} dto.ListTransferOrdersResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/transfer-orders [get]
+func (h *TransferOrderHandler) ListTransferOrders(c *gin.Context) {
+	var req dto.ListTransferOrdersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid transfer order list request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid query parameters",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	serviceReq := &transfer.ListTransferOrdersRequest{
+		Search:          ptrStringToString(req.Search),
+		FromWarehouseID: req.FromWarehouseID,
+		ToWarehouseID:   req.ToWarehouseID,
+		Page:            req.Page,
+		Limit:           req.Limit,
+	}
+	if req.Status != nil {
+		serviceReq.Status = []entities.TransferOrderStatus{entities.TransferOrderStatus(*req.Status)}
+	}
+
+	result, err := h.transferService.ListTransferOrders(c, serviceReq)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to list transfer orders")
+		handleTransferError(c, err)
+		return
+	}
+
+	orders := make([]*dto.TransferOrderResponse, len(result.TransferOrders))
+	for i, order := range result.TransferOrders {
+		orders[i] = transferOrderToResponse(order)
+	}
+
+	c.JSON(http.StatusOK, &dto.ListTransferOrdersResponse{
+		TransferOrders: orders,
+		Pagination: &dto.Pagination{
+			Page:       result.Pagination.Page,
+			Limit:      result.Pagination.Limit,
+			Total:      result.Pagination.Total,
+			TotalPages: result.Pagination.TotalPages,
+			HasNext:    result.Pagination.HasNext,
+			HasPrev:    result.Pagination.HasPrev,
+		},
+	})
+}
+
+// ShipTransferOrder ships a draft transfer order
+// @Summary Ship transfer order
+// @Description Take the reserved stock out of the source warehouse and put it in transit to the destination warehouse
+// @Tags transfer-orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Transfer order ID"
+// @Param shipment body dto.ShipTransferOrderRequest false "Carrier and tracking details"
+// @Success 200 {object} dto.TransferOrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/transfer-orders/{id}/ship [post]
+func (h *TransferOrderHandler) ShipTransferOrder(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.ShipTransferOrderRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.logger.Error().Err(err).Msg("Invalid transfer order shipment request")
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Invalid request body",
+				Details: err.Error(),
+			})
+			return
+		}
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	order, err := h.transferService.ShipTransferOrder(c, id, &transfer.ShipTransferOrderRequest{
+		Carrier:        req.Carrier,
+		TrackingNumber: req.TrackingNumber,
+		ExpectedDate:   req.ExpectedDate,
+		ShippedBy:      userID,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("transfer_order_id", id).Msg("Failed to ship transfer order")
+		handleTransferError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, transferOrderToResponse(order))
+}
+
+// ReceiveTransferOrder records a receipt against a transfer order
+// @Summary Receive transfer order
+// @Description Receive all or part of the stock in transit into the destination warehouse, recording damaged and short quantities with a reason
+// @Tags transfer-orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Transfer order ID"
+// @Param receipt body dto.TransferReceiptRequest true "Received, damaged and short quantities"
+// @Success 201 {object} dto.ReceiveTransferOrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/transfer-orders/{id}/receipts [post]
+func (h *TransferOrderHandler) ReceiveTransferOrder(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.TransferReceiptRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid transfer receipt request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	items := make([]transfer.ReceiveTransferItemRequest, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = transfer.ReceiveTransferItemRequest{
+			TransferOrderItemID: item.TransferOrderItemID.String(),
+			QuantityReceived:    item.QuantityReceived,
+			QuantityDamaged:     item.QuantityDamaged,
+			QuantityShort:       item.QuantityShort,
+			DiscrepancyReason:   item.DiscrepancyReason,
+		}
+	}
+
+	result, err := h.transferService.ReceiveTransferOrder(c, id, &transfer.ReceiveTransferOrderRequest{
+		Notes:       req.Notes,
+		Items:       items,
+		CloseShort:  req.CloseShort,
+		ShortReason: req.ShortReason,
+		ReceivedBy:  userID,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("transfer_order_id", id).Msg("Failed to receive transfer order")
+		handleTransferError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, &dto.ReceiveTransferOrderResponse{
+		Receipt:       transferReceiptToResponse(result.Receipt),
+		TransferOrder: transferOrderToResponse(result.TransferOrder),
+	})
+}
+
+// GetTransferReceipts lists the receipts of a transfer order
+// @Summary Get transfer receipts
+// @Description Get every receipt recorded against a transfer order, oldest first
+// @Tags transfer-orders
+// @Produce json
+// @Param id path string true "Transfer order ID"
+// @Success 200 {array} dto.TransferReceiptResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/transfer-orders/{id}/receipts [get]
+func (h *TransferOrderHandler) GetTransferReceipts(c *gin.Context) {
+	id := c.Param("id")
+
+	receipts, err := h.transferService.GetTransferReceipts(c, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("transfer_order_id", id).Msg("Failed to get transfer receipts")
+		handleTransferError(c, err)
+		return
+	}
+
+	response := make([]*dto.TransferReceiptResponse, len(receipts))
+	for i, receipt := range receipts {
+		response[i] = transferReceiptToResponse(receipt)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// CancelTransferOrder cancels a draft transfer order
+// @Summary Cancel transfer order
+// @Description Cancel a transfer order that has not shipped, releasing the stock reserved in the source warehouse
+// @Tags transfer-orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Transfer order ID"
+// @Param request body dto.CancelTransferOrderRequest true "Cancellation reason"
+// @Success 200 {object} dto.TransferOrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/transfer-orders/{id}/cancel [post]
+func (h *TransferOrderHandler) CancelTransferOrder(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.CancelTransferOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid transfer order cancellation request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	order, err := h.transferService.CancelTransferOrder(c, id, req.Reason)
+	if err != nil {
+		h.logger.Error().Err(err).Str("transfer_order_id", id).Msg("Failed to cancel transfer order")
+		handleTransferError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, transferOrderToResponse(order))
+}
+
+// GetInTransitStock reports stock in transit between warehouses
+// @Summary Get in-transit stock
+// @Description Get the quantity and value shipped on open transfer orders and not yet received, per product and route
+// @Tags transfer-orders
+// @Produce json
+// @Param product_id query string false "Product ID"
+// @Param from_warehouse_id query string false "Source warehouse ID"
+// @Param to_warehouse_id query string false "Destination warehouse ID"
+// @Success 200 {array} dto.InTransitStockResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/transfer-orders/in-transit [get]
+func (h *TransferOrderHandler) GetInTransitStock(c *gin.Context) {
+	var req dto.InTransitStockRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Invalid in-transit stock request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid query parameters",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	stock, err := h.transferService.GetInTransitStock(c, &transfer.InTransitStockRequest{
+		ProductID:       req.ProductID,
+		FromWarehouseID: req.FromWarehouseID,
+		ToWarehouseID:   req.ToWarehouseID,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to get in-transit stock")
+		handleTransferError(c, err)
+		return
+	}
+
+	response := make([]*dto.InTransitStockResponse, len(stock))
+	for i, line := range stock {
+		response[i] = &dto.InTransitStockResponse{
+			ProductID:       line.ProductID,
+			ProductSKU:      line.ProductSKU,
+			ProductName:     line.ProductName,
+			FromWarehouseID: line.FromWarehouseID,
+			ToWarehouseID:   line.ToWarehouseID,
+			Quantity:        line.Quantity,
+			TotalValue:      line.TotalValue,
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// currentUser returns the authenticated user's ID, responding 401 when absent
+func (h *TransferOrderHandler) currentUser(c *gin.Context) (string, bool) {
+	userID, exists := auth.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "User not authenticated",
+			Details: "Authentication required",
+		})
+		return "", false
+	}
+	return userID.String(), true
+}
+
+// transferOrderToResponse converts a transfer order entity to a response DTO
+func transferOrderToResponse(order *entities.TransferOrder) *dto.TransferOrderResponse {
+	items := make([]dto.TransferOrderItemResponse, len(order.Items))
+	for i, item := range order.Items {
+		items[i] = dto.TransferOrderItemResponse{
+			ID:                item.ID,
+			ProductID:         item.ProductID,
+			ProductSKU:        item.ProductSKU,
+			ProductName:       item.ProductName,
+			QuantityRequested: item.QuantityRequested,
+			QuantityShipped:   item.QuantityShipped,
+			QuantityReceived:  item.QuantityReceived,
+			QuantityDamaged:   item.QuantityDamaged,
+			QuantityShort:     item.QuantityShort,
+			QuantityInTransit: item.InTransitQuantity(),
+			UnitCost:          item.UnitCost,
+			Notes:             item.Notes,
+		}
+	}
+
+	return &dto.TransferOrderResponse{
+		ID:                 order.ID,
+		TransferNumber:     order.TransferNumber,
+		FromWarehouseID:    order.FromWarehouseID,
+		ToWarehouseID:      order.ToWarehouseID,
+		Status:             string(order.Status),
+		ExpectedDate:       order.ExpectedDate,
+		Carrier:            order.Carrier,
+		TrackingNumber:     order.TrackingNumber,
+		HasDiscrepancies:   order.HasDiscrepancies(),
+		Items:              items,
+		Notes:              order.Notes,
+		CancellationReason: order.CancellationReason,
+		CreatedBy:          order.CreatedBy,
+		CreatedAt:          order.CreatedAt,
+		UpdatedAt:          order.UpdatedAt,
+		ShippedBy:          order.ShippedBy,
+		ShippedAt:          order.ShippedAt,
+		ReceivedAt:         order.ReceivedAt,
+		CancelledAt:        order.CancelledAt,
+	}
+}
+
+// transferReceiptToResponse converts a transfer receipt entity to a response DTO
+func transferReceiptToResponse(receipt *entities.TransferReceipt) *dto.TransferReceiptResponse {
+	items := make([]dto.TransferReceiptItemResponse, len(receipt.Items))
+	for i, item := range receipt.Items {
+		items[i] = dto.TransferReceiptItemResponse{
+			ID:                     item.ID,
+			TransferOrderItemID:    item.TransferOrderItemID,
+			ProductID:              item.ProductID,
+			QuantityReceived:       item.QuantityReceived,
+			QuantityDamaged:        item.QuantityDamaged,
+			QuantityShort:          item.QuantityShort,
+			DiscrepancyReason:      item.DiscrepancyReason,
+			InventoryTransactionID: item.InventoryTransactionID,
+		}
+	}
+
+	return &dto.TransferReceiptResponse{
+		ID:              receipt.ID,
+		TransferOrderID: receipt.TransferOrderID,
+		WarehouseID:     receipt.WarehouseID,
+		Notes:           receipt.Notes,
+		Items:           items,
+		ReceivedBy:      receipt.ReceivedBy,
+		ReceivedAt:      receipt.ReceivedAt,
+	}
+}
+
+// handleTransferError handles transfer order service errors
+func handleTransferError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, transfer.ErrTransferOrderNotFound), errors.Is(err, transfer.ErrProductNotFound),
+		errors.Is(err, transfer.ErrWarehouseNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:   "Resource not found",
+			Details: err.Error(),
+		})
+	case errors.Is(err, transfer.ErrInvalidStatusTransition), errors.Is(err, transfer.ErrInsufficientStock),
+		errors.Is(err, transfer.ErrWarehouseInactive):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error:   "Transfer order state conflict",
+			Details: err.Error(),
+		})
+	case errors.Is(err, transfer.ErrInvalidReceipt), errors.Is(err, transfer.ErrInvalidTransferOrderData),
+		errors.Is(err, transfer.ErrProductNotStocked), errors.Is(err, transfer.ErrCancellationReasonMissing),
+		strings.HasPrefix(err.Error(), "invalid "):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request",
+			Details: err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "Internal server error",
+			Details: err.Error(),
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	userEntities "erpgo/internal/domain/users/entities"
+	"erpgo/internal/domain/users/repositories"
+	"erpgo/internal/interfaces/http/handlers"
+	"erpgo/internal/interfaces/http/middleware"
+	"erpgo/pkg/auth"
+)
+
+// SetupApprovalPolicyRoutes configures approval policy routes. Orders are
+// approved and rejected through the order routes.
+func SetupApprovalPolicyRoutes(
+	router *gin.RouterGroup,
+	approvalPolicyHandler *handlers.ApprovalPolicyHandler,
+	roleRepo repositories.RoleRepository,
+	authMiddleware gin.HandlerFunc,
+	logger zerolog.Logger,
+) {
+	canRead := auth.RequirePermission(roleRepo, userEntities.PermissionOrderRead)
+	canManage := auth.RequirePermission(roleRepo, userEntities.PermissionOrderApprovalPolicies)
+
+	// Approval policy routes (require authentication)
+	policyGroup := router.Group("/approval-policies")
+	policyGroup.Use(authMiddleware)
+	policyGroup.Use(middleware.Logger(logger))
+	{
+		policyGroup.POST("", canManage, approvalPolicyHandler.CreateApprovalPolicy)
+		policyGroup.GET("", canRead, approvalPolicyHandler.ListApprovalPolicies)
+		policyGroup.GET("/:id", canRead, approvalPolicyHandler.GetApprovalPolicy)
+		policyGroup.PUT("/:id", canManage, approvalPolicyHandler.UpdateApprovalPolicy)
+	}
+}
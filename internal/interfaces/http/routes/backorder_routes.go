@@ -0,0 +1,39 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	userEntities "erpgo/internal/domain/users/entities"
+	"erpgo/internal/domain/users/repositories"
+	"erpgo/internal/interfaces/http/handlers"
+	"erpgo/internal/interfaces/http/middleware"
+	"erpgo/pkg/auth"
+)
+
+// SetupBackorderRoutes configures backorder routes. Backorders are parts of
+// sales order lines and share the order permissions.
+func SetupBackorderRoutes(
+	router *gin.RouterGroup,
+	backorderHandler *handlers.BackorderHandler,
+	roleRepo repositories.RoleRepository,
+	authMiddleware gin.HandlerFunc,
+	logger zerolog.Logger,
+) {
+	canRead := auth.RequirePermission(roleRepo, userEntities.PermissionOrderRead)
+	canUpdate := auth.RequirePermission(roleRepo, userEntities.PermissionOrderUpdate)
+
+	// Backorder routes (require authentication)
+	backorderGroup := router.Group("/backorders")
+	backorderGroup.Use(authMiddleware)
+	backorderGroup.Use(middleware.Logger(logger))
+	{
+		backorderGroup.GET("", canRead, backorderHandler.ListBackorders)
+		backorderGroup.GET("/order/:order_id", canRead, backorderHandler.GetOrderBackorders)
+		backorderGroup.GET("/queue/:product_id", canRead, backorderHandler.GetBackorderQueue)
+		backorderGroup.GET("/:id", canRead, backorderHandler.GetBackorder)
+
+		// Allocation
+		backorderGroup.POST("/allocate", canUpdate, backorderHandler.AllocateBackorders)
+	}
+}
@@ -0,0 +1,33 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	userEntities "erpgo/internal/domain/users/entities"
+	"erpgo/internal/domain/users/repositories"
+	"erpgo/internal/interfaces/http/handlers"
+	"erpgo/internal/interfaces/http/middleware"
+	"erpgo/pkg/auth"
+)
+
+// SetupDropShipRoutes configures drop-ship routes. Supplier shipment
+// confirmations ship sales orders and need the order update permission.
+func SetupDropShipRoutes(
+	router *gin.RouterGroup,
+	dropShipHandler *handlers.DropShipHandler,
+	roleRepo repositories.RoleRepository,
+	authMiddleware gin.HandlerFunc,
+	logger zerolog.Logger,
+) {
+	canUpdate := auth.RequirePermission(roleRepo, userEntities.PermissionOrderUpdate)
+
+	// Drop-ship routes (require authentication)
+	dropShipGroup := router.Group("/drop-ship")
+	dropShipGroup.Use(authMiddleware)
+	dropShipGroup.Use(middleware.Logger(logger))
+	{
+		dropShipGroup.POST("/purchase-orders/:id/shipments", canUpdate, dropShipHandler.ConfirmDropShipment)
+		dropShipGroup.POST("/confirmations", canUpdate, dropShipHandler.ImportDropShipConfirmations)
+	}
+}
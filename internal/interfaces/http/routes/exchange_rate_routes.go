@@ -0,0 +1,39 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	userEntities "erpgo/internal/domain/users/entities"
+	"erpgo/internal/domain/users/repositories"
+	"erpgo/internal/interfaces/http/handlers"
+	"erpgo/internal/interfaces/http/middleware"
+	"erpgo/pkg/auth"
+)
+
+// SetupExchangeRateRoutes configures exchange rate routes. Exchange rates
+// book sales orders into the base currency and share the order permissions.
+func SetupExchangeRateRoutes(
+	router *gin.RouterGroup,
+	exchangeRateHandler *handlers.ExchangeRateHandler,
+	roleRepo repositories.RoleRepository,
+	authMiddleware gin.HandlerFunc,
+	logger zerolog.Logger,
+) {
+	canRead := auth.RequirePermission(roleRepo, userEntities.PermissionOrderRead)
+	canUpdate := auth.RequirePermission(roleRepo, userEntities.PermissionOrderUpdate)
+
+	// Exchange rate routes (require authentication)
+	rateGroup := router.Group("/exchange-rates")
+	rateGroup.Use(authMiddleware)
+	rateGroup.Use(middleware.Logger(logger))
+	{
+		rateGroup.POST("", canUpdate, exchangeRateHandler.CreateExchangeRate)
+		rateGroup.GET("", canRead, exchangeRateHandler.ListExchangeRates)
+		rateGroup.POST("/import", canUpdate, exchangeRateHandler.ImportExchangeRates)
+		rateGroup.GET("/convert", canRead, exchangeRateHandler.ConvertCurrency)
+		rateGroup.GET("/:id", canRead, exchangeRateHandler.GetExchangeRate)
+		rateGroup.PUT("/:id", canUpdate, exchangeRateHandler.UpdateExchangeRate)
+		rateGroup.DELETE("/:id", canUpdate, exchangeRateHandler.DeleteExchangeRate)
+	}
+}
@@ -0,0 +1,41 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	userEntities "erpgo/internal/domain/users/entities"
+	"erpgo/internal/domain/users/repositories"
+	"erpgo/internal/interfaces/http/handlers"
+	"erpgo/internal/interfaces/http/middleware"
+	"erpgo/pkg/auth"
+)
+
+// SetupInvoiceRoutes configures invoice and credit note routes. Invoices bill
+// sales orders and share the order permissions.
+func SetupInvoiceRoutes(
+	router *gin.RouterGroup,
+	invoiceHandler *handlers.InvoiceHandler,
+	roleRepo repositories.RoleRepository,
+	authMiddleware gin.HandlerFunc,
+	logger zerolog.Logger,
+) {
+	canRead := auth.RequirePermission(roleRepo, userEntities.PermissionOrderRead)
+	canUpdate := auth.RequirePermission(roleRepo, userEntities.PermissionOrderUpdate)
+
+	// Invoice routes (require authentication)
+	invoiceGroup := router.Group("/invoices")
+	invoiceGroup.Use(authMiddleware)
+	invoiceGroup.Use(middleware.Logger(logger))
+	{
+		invoiceGroup.POST("", canUpdate, invoiceHandler.GenerateInvoice)
+		invoiceGroup.GET("", canRead, invoiceHandler.ListInvoices)
+		invoiceGroup.GET("/order/:order_id", canRead, invoiceHandler.GetOrderInvoices)
+		invoiceGroup.GET("/number/:number", canRead, invoiceHandler.GetInvoiceByNumber)
+		invoiceGroup.GET("/:id", canRead, invoiceHandler.GetInvoice)
+
+		// Issuing locks the invoice; only drafts can be cancelled
+		invoiceGroup.POST("/:id/issue", canUpdate, invoiceHandler.IssueInvoice)
+		invoiceGroup.POST("/:id/cancel", canUpdate, invoiceHandler.CancelInvoice)
+	}
+}
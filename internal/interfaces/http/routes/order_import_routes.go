@@ -0,0 +1,41 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	userEntities "erpgo/internal/domain/users/entities"
+	"erpgo/internal/domain/users/repositories"
+	"erpgo/internal/interfaces/http/handlers"
+	"erpgo/internal/interfaces/http/middleware"
+	"erpgo/pkg/auth"
+)
+
+// SetupOrderImportRoutes configures order import routes. Importing creates
+// orders; import profiles change how partner files become orders and need
+// the order update permission.
+func SetupOrderImportRoutes(
+	router *gin.RouterGroup,
+	orderImportHandler *handlers.OrderImportHandler,
+	roleRepo repositories.RoleRepository,
+	authMiddleware gin.HandlerFunc,
+	logger zerolog.Logger,
+) {
+	canCreate := auth.RequirePermission(roleRepo, userEntities.PermissionOrderCreate)
+	canRead := auth.RequirePermission(roleRepo, userEntities.PermissionOrderRead)
+	canUpdate := auth.RequirePermission(roleRepo, userEntities.PermissionOrderUpdate)
+
+	// Order import routes (require authentication)
+	orderImportGroup := router.Group("/order-imports")
+	orderImportGroup.Use(authMiddleware)
+	orderImportGroup.Use(middleware.Logger(logger))
+	{
+		orderImportGroup.POST("", canCreate, orderImportHandler.ImportOrders)
+
+		// Import profile operations
+		orderImportGroup.POST("/profiles", canUpdate, orderImportHandler.CreateOrderImportProfile)
+		orderImportGroup.GET("/profiles", canRead, orderImportHandler.ListOrderImportProfiles)
+		orderImportGroup.GET("/profiles/:id", canRead, orderImportHandler.GetOrderImportProfile)
+		orderImportGroup.PUT("/profiles/:id", canUpdate, orderImportHandler.UpdateOrderImportProfile)
+	}
+}
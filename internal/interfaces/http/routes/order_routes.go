@@ -0,0 +1,113 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	userEntities "erpgo/internal/domain/users/entities"
+	"erpgo/internal/domain/users/repositories"
+	"erpgo/internal/interfaces/http/handlers"
+	"erpgo/internal/interfaces/http/middleware"
+	"erpgo/pkg/auth"
+)
+
+// SetupOrderRoutes configures all order-related routes
+func SetupOrderRoutes(
+	router *gin.RouterGroup,
+	orderHandler *handlers.OrderHandler,
+	roleRepo repositories.RoleRepository,
+	authMiddleware gin.HandlerFunc,
+	logger zerolog.Logger,
+) {
+	canCreate := auth.RequirePermission(roleRepo, userEntities.PermissionOrderCreate)
+	canRead := auth.RequirePermission(roleRepo, userEntities.PermissionOrderRead)
+	canUpdate := auth.RequirePermission(roleRepo, userEntities.PermissionOrderUpdate)
+	canDelete := auth.RequirePermission(roleRepo, userEntities.PermissionOrderDelete)
+	canOverrideCredit := auth.RequirePermission(roleRepo, userEntities.PermissionOrderCreditOverride)
+
+	// Order routes (require authentication)
+	orderGroup := router.Group("/orders")
+	orderGroup.Use(authMiddleware)
+	orderGroup.Use(middleware.Logger(logger))
+	{
+		// Order CRUD operations
+		orderGroup.POST("", canCreate, orderHandler.CreateOrder)
+		orderGroup.GET("", canRead, orderHandler.ListOrders)
+		orderGroup.GET("/search", canRead, orderHandler.SearchOrders)
+		orderGroup.GET("/number/:number", canRead, orderHandler.GetOrderByNumber)
+		orderGroup.GET("/archived", canRead, orderHandler.ListArchivedOrders)
+		orderGroup.GET("/:id", canRead, orderHandler.GetOrder)
+		orderGroup.PUT("/:id", canUpdate, orderHandler.UpdateOrder)
+		orderGroup.DELETE("/:id", canDelete, orderHandler.DeleteOrder)
+		orderGroup.POST("/:id/clone", canCreate, orderHandler.CloneOrder)
+		orderGroup.POST("/:id/archive", canDelete, orderHandler.ArchiveOrder)
+		orderGroup.POST("/:id/restore", canUpdate, orderHandler.RestoreOrder)
+
+		// Order status transitions
+		orderGroup.PUT("/:id/status", canUpdate, orderHandler.UpdateOrderStatus)
+		orderGroup.POST("/:id/approve", canUpdate, orderHandler.ApproveOrder)
+		orderGroup.POST("/:id/reject", canUpdate, orderHandler.RejectOrder)
+		orderGroup.GET("/:id/approvals", canRead, orderHandler.GetOrderApprovals)
+		orderGroup.GET("/approvals/pending", canRead, orderHandler.GetPendingApprovals)
+		orderGroup.POST("/:id/cancel", canUpdate, orderHandler.CancelOrder)
+		orderGroup.POST("/:id/hold", canUpdate, orderHandler.HoldOrder)
+		orderGroup.POST("/:id/unhold", canUpdate, orderHandler.UnholdOrder)
+		orderGroup.POST("/:id/credit-override", canOverrideCredit, orderHandler.OverrideCreditHold)
+		orderGroup.GET("/:id/history", canRead, orderHandler.GetOrderStatusHistory)
+		orderGroup.GET("/:id/timeline", canRead, orderHandler.GetOrderTimeline)
+
+		// Order fulfillment
+		orderGroup.POST("/:id/process", canUpdate, orderHandler.ProcessOrder)
+		orderGroup.POST("/:id/ship", canUpdate, orderHandler.ShipOrder)
+		orderGroup.POST("/:id/partial-ship", canUpdate, orderHandler.PartialShipOrder)
+		orderGroup.POST("/:id/deliver", canUpdate, orderHandler.DeliverOrder)
+		orderGroup.GET("/:id/shipments", canRead, orderHandler.GetOrderShipments)
+		orderGroup.GET("/:id/allocations", canRead, orderHandler.GetOrderAllocations)
+		orderGroup.POST("/:id/source", canUpdate, orderHandler.SourceOrder)
+		orderGroup.POST("/:id/return", canUpdate, orderHandler.ReturnOrderItems)
+
+		// Order payments
+		orderGroup.POST("/:id/payment", canUpdate, orderHandler.ProcessPayment)
+		orderGroup.POST("/:id/refund", canUpdate, orderHandler.RefundOrder)
+		orderGroup.POST("/:id/partial-refund", canUpdate, orderHandler.PartialRefundOrder)
+
+		// Order item edits
+		orderGroup.POST("/:id/items", canUpdate, orderHandler.AddOrderItem)
+		orderGroup.PUT("/:id/items/:item_id", canUpdate, orderHandler.UpdateOrderItem)
+		orderGroup.DELETE("/:id/items/:item_id", canUpdate, orderHandler.RemoveOrderItem)
+
+		// Amendments of confirmed orders and their revisions
+		orderGroup.POST("/:id/amendments", canUpdate, orderHandler.AmendOrder)
+		orderGroup.GET("/:id/revisions", canRead, orderHandler.GetOrderRevisions)
+		orderGroup.GET("/:id/revisions/:number", canRead, orderHandler.GetOrderRevision)
+		orderGroup.POST("/:id/revisions/:number/approve", canUpdate, orderHandler.ApproveOrderRevision)
+		orderGroup.POST("/:id/revisions/:number/reject", canUpdate, orderHandler.RejectOrderRevision)
+
+		// Order coupons
+		orderGroup.POST("/:id/coupons", canUpdate, orderHandler.ApplyCoupon)
+		orderGroup.DELETE("/:id/coupons/:code", canUpdate, orderHandler.RemoveCoupon)
+
+		// Order validation and calculation
+		orderGroup.GET("/:id/validate", canRead, orderHandler.ValidateOrder)
+		orderGroup.GET("/:id/calculate", canRead, orderHandler.CalculateOrderTotals)
+		orderGroup.POST("/:id/recalculate", canUpdate, orderHandler.RecalculateOrder)
+		orderGroup.GET("/:id/shipping-quotes", canRead, orderHandler.QuoteShipping)
+
+		// Customer orders
+		orderGroup.GET("/customer/:customer_id", canRead, orderHandler.GetCustomerOrders)
+
+		// Inventory checks
+		orderGroup.POST("/inventory/check", canRead, orderHandler.CheckInventoryAvailability)
+
+		// Bulk operations
+		orderGroup.POST("/bulk/status", canUpdate, orderHandler.BulkUpdateStatus)
+		orderGroup.POST("/bulk/cancel", canUpdate, orderHandler.BulkCancelOrders)
+
+		// Order analytics
+		orderGroup.GET("/stats", canRead, orderHandler.GetOrderStats)
+		orderGroup.GET("/analytics", canRead, orderHandler.GetOrderAnalytics)
+		orderGroup.GET("/analytics/revenue", canRead, orderHandler.GetRevenueByPeriod)
+		orderGroup.GET("/analytics/top-customers", canRead, orderHandler.GetTopCustomers)
+		orderGroup.GET("/analytics/top-products", canRead, orderHandler.GetSalesByProduct)
+	}
+}
@@ -0,0 +1,41 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	userEntities "erpgo/internal/domain/users/entities"
+	"erpgo/internal/domain/users/repositories"
+	"erpgo/internal/interfaces/http/handlers"
+	"erpgo/internal/interfaces/http/middleware"
+	"erpgo/pkg/auth"
+)
+
+// SetupPaymentRoutes configures payment ledger routes. Payments settle sales
+// orders and share the order permissions.
+func SetupPaymentRoutes(
+	router *gin.RouterGroup,
+	paymentHandler *handlers.PaymentHandler,
+	roleRepo repositories.RoleRepository,
+	authMiddleware gin.HandlerFunc,
+	logger zerolog.Logger,
+) {
+	canRead := auth.RequirePermission(roleRepo, userEntities.PermissionOrderRead)
+	canUpdate := auth.RequirePermission(roleRepo, userEntities.PermissionOrderUpdate)
+
+	// Payment routes (require authentication)
+	paymentGroup := router.Group("/payments")
+	paymentGroup.Use(authMiddleware)
+	paymentGroup.Use(middleware.Logger(logger))
+	{
+		paymentGroup.POST("", canUpdate, paymentHandler.RecordPayment)
+		paymentGroup.GET("", canRead, paymentHandler.ListPayments)
+		paymentGroup.GET("/order/:order_id", canRead, paymentHandler.GetOrderPayments)
+		paymentGroup.GET("/aging", canRead, paymentHandler.GetARAgingReport)
+		paymentGroup.GET("/:id", canRead, paymentHandler.GetPayment)
+
+		// Allocation and reversal
+		paymentGroup.POST("/:id/allocate", canUpdate, paymentHandler.AllocatePayment)
+		paymentGroup.POST("/:id/reverse", canUpdate, paymentHandler.ReversePayment)
+	}
+}
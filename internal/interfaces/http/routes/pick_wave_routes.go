@@ -0,0 +1,50 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	userEntities "erpgo/internal/domain/users/entities"
+	"erpgo/internal/domain/users/repositories"
+	"erpgo/internal/interfaces/http/handlers"
+	"erpgo/internal/interfaces/http/middleware"
+	"erpgo/pkg/auth"
+)
+
+// SetupPickWaveRoutes configures pick wave and pick location routes. Picking
+// and packing is warehouse work and uses the inventory permissions.
+func SetupPickWaveRoutes(
+	router *gin.RouterGroup,
+	pickWaveHandler *handlers.PickWaveHandler,
+	roleRepo repositories.RoleRepository,
+	authMiddleware gin.HandlerFunc,
+	logger zerolog.Logger,
+) {
+	canRead := auth.RequirePermission(roleRepo, userEntities.PermissionInventoryRead)
+	canUpdate := auth.RequirePermission(roleRepo, userEntities.PermissionInventoryUpdate)
+
+	// Pick wave routes (require authentication)
+	waveGroup := router.Group("/pick-waves")
+	waveGroup.Use(authMiddleware)
+	waveGroup.Use(middleware.Logger(logger))
+	{
+		waveGroup.POST("", canUpdate, pickWaveHandler.CreatePickWave)
+		waveGroup.GET("", canRead, pickWaveHandler.ListPickWaves)
+		waveGroup.GET("/:id", canRead, pickWaveHandler.GetPickWave)
+		waveGroup.GET("/:id/pick-list", canRead, pickWaveHandler.GetPickList)
+
+		// Picking and packing
+		waveGroup.POST("/:id/picks", canUpdate, pickWaveHandler.ConfirmPicks)
+		waveGroup.POST("/:id/orders/:order_id/pack", canUpdate, pickWaveHandler.PackOrder)
+		waveGroup.POST("/:id/cancel", canUpdate, pickWaveHandler.CancelPickWave)
+	}
+
+	// Pick location routes (require authentication)
+	locationGroup := router.Group("/pick-locations")
+	locationGroup.Use(authMiddleware)
+	locationGroup.Use(middleware.Logger(logger))
+	{
+		locationGroup.GET("", canRead, pickWaveHandler.GetPickLocations)
+		locationGroup.PUT("", canUpdate, pickWaveHandler.SetPickLocation)
+	}
+}
@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	userEntities "erpgo/internal/domain/users/entities"
+	"erpgo/internal/domain/users/repositories"
+	"erpgo/internal/interfaces/http/handlers"
+	"erpgo/internal/interfaces/http/middleware"
+	"erpgo/pkg/auth"
+)
+
+// SetupPromotionRoutes configures promotion routes. Promotions price sales
+// orders and share the order permissions.
+func SetupPromotionRoutes(
+	router *gin.RouterGroup,
+	promotionHandler *handlers.PromotionHandler,
+	roleRepo repositories.RoleRepository,
+	authMiddleware gin.HandlerFunc,
+	logger zerolog.Logger,
+) {
+	canRead := auth.RequirePermission(roleRepo, userEntities.PermissionOrderRead)
+	canUpdate := auth.RequirePermission(roleRepo, userEntities.PermissionOrderUpdate)
+
+	// Promotion routes (require authentication)
+	promotionGroup := router.Group("/promotions")
+	promotionGroup.Use(authMiddleware)
+	promotionGroup.Use(middleware.Logger(logger))
+	{
+		promotionGroup.POST("", canUpdate, promotionHandler.CreatePromotion)
+		promotionGroup.GET("", canRead, promotionHandler.ListPromotions)
+		promotionGroup.GET("/code/:code", canRead, promotionHandler.GetPromotionByCode)
+		promotionGroup.GET("/:id", canRead, promotionHandler.GetPromotion)
+		promotionGroup.PUT("/:id", canUpdate, promotionHandler.UpdatePromotion)
+	}
+}
@@ -0,0 +1,59 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	userEntities "erpgo/internal/domain/users/entities"
+	"erpgo/internal/domain/users/repositories"
+	"erpgo/internal/interfaces/http/handlers"
+	"erpgo/internal/interfaces/http/middleware"
+	"erpgo/pkg/auth"
+)
+
+// SetupPurchasingRoutes configures supplier and purchase order routes
+func SetupPurchasingRoutes(
+	router *gin.RouterGroup,
+	supplierHandler *handlers.SupplierHandler,
+	purchaseOrderHandler *handlers.PurchaseOrderHandler,
+	roleRepo repositories.RoleRepository,
+	authMiddleware gin.HandlerFunc,
+	logger zerolog.Logger,
+) {
+	canCreate := auth.RequirePermission(roleRepo, userEntities.PermissionPurchaseCreate)
+	canRead := auth.RequirePermission(roleRepo, userEntities.PermissionPurchaseRead)
+	canUpdate := auth.RequirePermission(roleRepo, userEntities.PermissionPurchaseUpdate)
+	canDelete := auth.RequirePermission(roleRepo, userEntities.PermissionPurchaseDelete)
+
+	// Supplier routes (require authentication)
+	supplierGroup := router.Group("/suppliers")
+	supplierGroup.Use(authMiddleware)
+	supplierGroup.Use(middleware.Logger(logger))
+	{
+		supplierGroup.POST("", canCreate, supplierHandler.CreateSupplier)
+		supplierGroup.GET("", canRead, supplierHandler.ListSuppliers)
+		supplierGroup.GET("/:id", canRead, supplierHandler.GetSupplier)
+		supplierGroup.PUT("/:id", canUpdate, supplierHandler.UpdateSupplier)
+		supplierGroup.DELETE("/:id", canDelete, supplierHandler.DeleteSupplier)
+	}
+
+	// Purchase order routes (require authentication)
+	poGroup := router.Group("/purchase-orders")
+	poGroup.Use(authMiddleware)
+	poGroup.Use(middleware.Logger(logger))
+	{
+		// Purchase order CRUD operations
+		poGroup.POST("", canCreate, purchaseOrderHandler.CreatePurchaseOrder)
+		poGroup.GET("", canRead, purchaseOrderHandler.ListPurchaseOrders)
+		poGroup.GET("/:id", canRead, purchaseOrderHandler.GetPurchaseOrder)
+		poGroup.PUT("/:id", canUpdate, purchaseOrderHandler.UpdatePurchaseOrder)
+
+		// Purchase order lifecycle
+		poGroup.POST("/:id/submit", canUpdate, purchaseOrderHandler.SubmitPurchaseOrder)
+		poGroup.POST("/:id/cancel", canUpdate, purchaseOrderHandler.CancelPurchaseOrder)
+
+		// Goods receipts
+		poGroup.POST("/:id/receipts", canUpdate, purchaseOrderHandler.ReceiveGoods)
+		poGroup.GET("/:id/receipts", canRead, purchaseOrderHandler.GetGoodsReceipts)
+	}
+}
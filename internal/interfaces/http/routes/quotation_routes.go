@@ -0,0 +1,47 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	userEntities "erpgo/internal/domain/users/entities"
+	"erpgo/internal/domain/users/repositories"
+	"erpgo/internal/interfaces/http/handlers"
+	"erpgo/internal/interfaces/http/middleware"
+	"erpgo/pkg/auth"
+)
+
+// SetupQuotationRoutes configures all quotation-related routes. Quotations are
+// sales documents and share the order permissions.
+func SetupQuotationRoutes(
+	router *gin.RouterGroup,
+	quotationHandler *handlers.QuotationHandler,
+	roleRepo repositories.RoleRepository,
+	authMiddleware gin.HandlerFunc,
+	logger zerolog.Logger,
+) {
+	canCreate := auth.RequirePermission(roleRepo, userEntities.PermissionOrderCreate)
+	canRead := auth.RequirePermission(roleRepo, userEntities.PermissionOrderRead)
+	canUpdate := auth.RequirePermission(roleRepo, userEntities.PermissionOrderUpdate)
+
+	// Quotation routes (require authentication)
+	quotationGroup := router.Group("/quotations")
+	quotationGroup.Use(authMiddleware)
+	quotationGroup.Use(middleware.Logger(logger))
+	{
+		// Quotation CRUD operations
+		quotationGroup.POST("", canCreate, quotationHandler.CreateQuotation)
+		quotationGroup.GET("", canRead, quotationHandler.ListQuotations)
+		quotationGroup.GET("/order/:order_id", canRead, quotationHandler.GetQuotationByOrder)
+		quotationGroup.GET("/:id", canRead, quotationHandler.GetQuotation)
+		quotationGroup.PUT("/:id", canUpdate, quotationHandler.UpdateQuotation)
+		quotationGroup.GET("/:id/revisions", canRead, quotationHandler.GetQuotationRevisions)
+
+		// Quotation lifecycle
+		quotationGroup.POST("/:id/send", canUpdate, quotationHandler.SendQuotation)
+		quotationGroup.POST("/:id/accept", canUpdate, quotationHandler.AcceptQuotation)
+		quotationGroup.POST("/:id/reject", canUpdate, quotationHandler.RejectQuotation)
+		quotationGroup.POST("/:id/revise", canCreate, quotationHandler.ReviseQuotation)
+		quotationGroup.POST("/:id/convert", canCreate, quotationHandler.ConvertQuotation)
+	}
+}
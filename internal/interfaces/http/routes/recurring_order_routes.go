@@ -0,0 +1,45 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	userEntities "erpgo/internal/domain/users/entities"
+	"erpgo/internal/domain/users/repositories"
+	"erpgo/internal/interfaces/http/handlers"
+	"erpgo/internal/interfaces/http/middleware"
+	"erpgo/pkg/auth"
+)
+
+// SetupRecurringOrderRoutes configures all recurring order routes. Recurring
+// orders generate sales orders and share the order permissions.
+func SetupRecurringOrderRoutes(
+	router *gin.RouterGroup,
+	recurringOrderHandler *handlers.RecurringOrderHandler,
+	roleRepo repositories.RoleRepository,
+	authMiddleware gin.HandlerFunc,
+	logger zerolog.Logger,
+) {
+	canCreate := auth.RequirePermission(roleRepo, userEntities.PermissionOrderCreate)
+	canRead := auth.RequirePermission(roleRepo, userEntities.PermissionOrderRead)
+	canUpdate := auth.RequirePermission(roleRepo, userEntities.PermissionOrderUpdate)
+
+	// Recurring order routes (require authentication)
+	recurringOrderGroup := router.Group("/recurring-orders")
+	recurringOrderGroup.Use(authMiddleware)
+	recurringOrderGroup.Use(middleware.Logger(logger))
+	{
+		// Recurring order CRUD operations
+		recurringOrderGroup.POST("", canCreate, recurringOrderHandler.CreateRecurringOrder)
+		recurringOrderGroup.GET("", canRead, recurringOrderHandler.ListRecurringOrders)
+		recurringOrderGroup.GET("/:id", canRead, recurringOrderHandler.GetRecurringOrder)
+		recurringOrderGroup.PUT("/:id", canUpdate, recurringOrderHandler.UpdateRecurringOrder)
+		recurringOrderGroup.GET("/:id/runs", canRead, recurringOrderHandler.GetRecurringOrderRuns)
+
+		// Recurring order schedule
+		recurringOrderGroup.POST("/:id/pause", canUpdate, recurringOrderHandler.PauseRecurringOrder)
+		recurringOrderGroup.POST("/:id/resume", canUpdate, recurringOrderHandler.ResumeRecurringOrder)
+		recurringOrderGroup.POST("/:id/skip", canUpdate, recurringOrderHandler.SkipNextOccurrence)
+		recurringOrderGroup.POST("/:id/cancel", canUpdate, recurringOrderHandler.CancelRecurringOrder)
+	}
+}
@@ -0,0 +1,45 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	userEntities "erpgo/internal/domain/users/entities"
+	"erpgo/internal/domain/users/repositories"
+	"erpgo/internal/interfaces/http/handlers"
+	"erpgo/internal/interfaces/http/middleware"
+	"erpgo/pkg/auth"
+)
+
+// SetupReturnRoutes configures return merchandise authorization (RMA) routes.
+// Returns act on sales orders and share the order permissions.
+func SetupReturnRoutes(
+	router *gin.RouterGroup,
+	returnHandler *handlers.ReturnHandler,
+	roleRepo repositories.RoleRepository,
+	authMiddleware gin.HandlerFunc,
+	logger zerolog.Logger,
+) {
+	canCreate := auth.RequirePermission(roleRepo, userEntities.PermissionOrderCreate)
+	canRead := auth.RequirePermission(roleRepo, userEntities.PermissionOrderRead)
+	canUpdate := auth.RequirePermission(roleRepo, userEntities.PermissionOrderUpdate)
+
+	// Return routes (require authentication)
+	returnGroup := router.Group("/returns")
+	returnGroup.Use(authMiddleware)
+	returnGroup.Use(middleware.Logger(logger))
+	{
+		returnGroup.POST("", canCreate, returnHandler.CreateReturn)
+		returnGroup.GET("", canRead, returnHandler.ListReturns)
+		returnGroup.GET("/order/:order_id", canRead, returnHandler.GetReturnsByOrder)
+		returnGroup.GET("/:id", canRead, returnHandler.GetReturn)
+
+		// Return lifecycle
+		returnGroup.POST("/:id/approve", canUpdate, returnHandler.ApproveReturn)
+		returnGroup.POST("/:id/reject", canUpdate, returnHandler.RejectReturn)
+		returnGroup.POST("/:id/cancel", canUpdate, returnHandler.CancelReturn)
+		returnGroup.POST("/:id/receive", canUpdate, returnHandler.ReceiveReturn)
+		returnGroup.POST("/:id/inspect", canUpdate, returnHandler.InspectReturn)
+		returnGroup.POST("/:id/resolve", canUpdate, returnHandler.ResolveReturn)
+	}
+}
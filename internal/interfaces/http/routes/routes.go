@@ -4,8 +4,10 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog"
 
+	"erpgo/internal/domain/users/repositories"
 	"erpgo/internal/interfaces/http/handlers"
 	"erpgo/internal/interfaces/http/middleware"
+	"erpgo/pkg/auth"
 	"erpgo/pkg/config"
 )
 
@@ -17,11 +19,32 @@ func SetupRoutes(
 	inventoryHandler *handlers.InventoryHandler,
 	warehouseHandler *handlers.WarehouseHandler,
 	transactionHandler *handlers.InventoryTransactionHandler,
+	orderHandler *handlers.OrderHandler,
+	quotationHandler *handlers.QuotationHandler,
+	recurringOrderHandler *handlers.RecurringOrderHandler,
+	orderImportHandler *handlers.OrderImportHandler,
+	returnHandler *handlers.ReturnHandler,
+	pickWaveHandler *handlers.PickWaveHandler,
+	sourcingRuleHandler *handlers.SourcingRuleHandler,
+	backorderHandler *handlers.BackorderHandler,
+	paymentHandler *handlers.PaymentHandler,
+	invoiceHandler *handlers.InvoiceHandler,
+	taxHandler *handlers.TaxHandler,
+	promotionHandler *handlers.PromotionHandler,
+	approvalPolicyHandler *handlers.ApprovalPolicyHandler,
+	shippingHandler *handlers.ShippingHandler,
+	exchangeRateHandler *handlers.ExchangeRateHandler,
+	supplierHandler *handlers.SupplierHandler,
+	purchaseOrderHandler *handlers.PurchaseOrderHandler,
+	transferOrderHandler *handlers.TransferOrderHandler,
+	dropShipHandler *handlers.DropShipHandler,
+	roleRepo repositories.RoleRepository,
+	jwtService *auth.JWTService,
 	cfg *config.Config,
 	logger zerolog.Logger,
 ) {
 	// Setup middlewares
-	authMiddleware := middleware.Auth(nil)               // TODO: Pass actual JWT service
+	authMiddleware := middleware.Auth(jwtService)
 	validationMiddleware := middleware.SecurityHeaders() // Use available middleware
 	// API v1 group
 	v1 := router.Group("/api/v1")
@@ -29,9 +52,25 @@ func SetupRoutes(
 	// Setup individual route groups
 	SetupUserRoutes(v1, authHandler)
 	SetupProductRoutes(v1, productHandler)
-	// TODO: Implement OrderHandler
-	// SetupOrderRoutes(v1, orderHandler)
+	SetupOrderRoutes(v1, orderHandler, roleRepo, authMiddleware, logger)
+	SetupQuotationRoutes(v1, quotationHandler, roleRepo, authMiddleware, logger)
+	SetupRecurringOrderRoutes(v1, recurringOrderHandler, roleRepo, authMiddleware, logger)
+	SetupOrderImportRoutes(v1, orderImportHandler, roleRepo, authMiddleware, logger)
+	SetupReturnRoutes(v1, returnHandler, roleRepo, authMiddleware, logger)
+	SetupPickWaveRoutes(v1, pickWaveHandler, roleRepo, authMiddleware, logger)
+	SetupSourcingRuleRoutes(v1, sourcingRuleHandler, roleRepo, authMiddleware, logger)
+	SetupBackorderRoutes(v1, backorderHandler, roleRepo, authMiddleware, logger)
+	SetupPaymentRoutes(v1, paymentHandler, roleRepo, authMiddleware, logger)
+	SetupInvoiceRoutes(v1, invoiceHandler, roleRepo, authMiddleware, logger)
+	SetupTaxRoutes(v1, taxHandler, roleRepo, authMiddleware, logger)
+	SetupPromotionRoutes(v1, promotionHandler, roleRepo, authMiddleware, logger)
+	SetupApprovalPolicyRoutes(v1, approvalPolicyHandler, roleRepo, authMiddleware, logger)
+	SetupShippingRoutes(v1, shippingHandler, roleRepo, authMiddleware, logger)
+	SetupExchangeRateRoutes(v1, exchangeRateHandler, roleRepo, authMiddleware, logger)
+	SetupPurchasingRoutes(v1, supplierHandler, purchaseOrderHandler, roleRepo, authMiddleware, logger)
 	SetupInventoryRoutes(v1, warehouseHandler, inventoryHandler, transactionHandler, authMiddleware, authMiddleware, validationMiddleware, logger)
+	SetupTransferRoutes(v1, transferOrderHandler, roleRepo, authMiddleware, logger)
+	SetupDropShipRoutes(v1, dropShipHandler, roleRepo, authMiddleware, logger)
 
 	// Root endpoint
 	router.GET("/", func(c *gin.Context) {
@@ -0,0 +1,38 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	userEntities "erpgo/internal/domain/users/entities"
+	"erpgo/internal/domain/users/repositories"
+	"erpgo/internal/interfaces/http/handlers"
+	"erpgo/internal/interfaces/http/middleware"
+	"erpgo/pkg/auth"
+)
+
+// SetupShippingRoutes configures shipping zone and shipping rate routes.
+// Shipping rates price sales orders and share the order permissions.
+func SetupShippingRoutes(
+	router *gin.RouterGroup,
+	shippingHandler *handlers.ShippingHandler,
+	roleRepo repositories.RoleRepository,
+	authMiddleware gin.HandlerFunc,
+	logger zerolog.Logger,
+) {
+	canRead := auth.RequirePermission(roleRepo, userEntities.PermissionOrderRead)
+	canUpdate := auth.RequirePermission(roleRepo, userEntities.PermissionOrderUpdate)
+
+	// Shipping routes (require authentication)
+	shippingGroup := router.Group("/shipping")
+	shippingGroup.Use(authMiddleware)
+	shippingGroup.Use(middleware.Logger(logger))
+	{
+		shippingGroup.POST("/zones", canUpdate, shippingHandler.CreateShippingZone)
+		shippingGroup.GET("/zones", canRead, shippingHandler.ListShippingZones)
+		shippingGroup.GET("/zones/:id", canRead, shippingHandler.GetShippingZone)
+		shippingGroup.PUT("/zones/:id", canUpdate, shippingHandler.UpdateShippingZone)
+		shippingGroup.POST("/zones/:id/rates", canUpdate, shippingHandler.AddShippingRate)
+		shippingGroup.PUT("/zones/:id/rates/:rate_id", canUpdate, shippingHandler.UpdateShippingRate)
+	}
+}
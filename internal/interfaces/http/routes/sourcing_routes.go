@@ -0,0 +1,36 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	userEntities "erpgo/internal/domain/users/entities"
+	"erpgo/internal/domain/users/repositories"
+	"erpgo/internal/interfaces/http/handlers"
+	"erpgo/internal/interfaces/http/middleware"
+	"erpgo/pkg/auth"
+)
+
+// SetupSourcingRuleRoutes configures sourcing rule routes. Orders are
+// sourced through the order routes.
+func SetupSourcingRuleRoutes(
+	router *gin.RouterGroup,
+	sourcingRuleHandler *handlers.SourcingRuleHandler,
+	roleRepo repositories.RoleRepository,
+	authMiddleware gin.HandlerFunc,
+	logger zerolog.Logger,
+) {
+	canRead := auth.RequirePermission(roleRepo, userEntities.PermissionInventoryRead)
+	canManage := auth.RequirePermission(roleRepo, userEntities.PermissionInventoryUpdate)
+
+	// Sourcing rule routes (require authentication)
+	ruleGroup := router.Group("/sourcing-rules")
+	ruleGroup.Use(authMiddleware)
+	ruleGroup.Use(middleware.Logger(logger))
+	{
+		ruleGroup.POST("", canManage, sourcingRuleHandler.CreateSourcingRule)
+		ruleGroup.GET("", canRead, sourcingRuleHandler.ListSourcingRules)
+		ruleGroup.GET("/:id", canRead, sourcingRuleHandler.GetSourcingRule)
+		ruleGroup.PUT("/:id", canManage, sourcingRuleHandler.UpdateSourcingRule)
+	}
+}
@@ -0,0 +1,42 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	userEntities "erpgo/internal/domain/users/entities"
+	"erpgo/internal/domain/users/repositories"
+	"erpgo/internal/interfaces/http/handlers"
+	"erpgo/internal/interfaces/http/middleware"
+	"erpgo/pkg/auth"
+)
+
+// SetupTaxRoutes configures tax zone, tax rule and tax exemption routes. Tax
+// settings price sales orders and share the order permissions.
+func SetupTaxRoutes(
+	router *gin.RouterGroup,
+	taxHandler *handlers.TaxHandler,
+	roleRepo repositories.RoleRepository,
+	authMiddleware gin.HandlerFunc,
+	logger zerolog.Logger,
+) {
+	canRead := auth.RequirePermission(roleRepo, userEntities.PermissionOrderRead)
+	canUpdate := auth.RequirePermission(roleRepo, userEntities.PermissionOrderUpdate)
+
+	// Tax routes (require authentication)
+	taxGroup := router.Group("/tax")
+	taxGroup.Use(authMiddleware)
+	taxGroup.Use(middleware.Logger(logger))
+	{
+		taxGroup.POST("/zones", canUpdate, taxHandler.CreateTaxZone)
+		taxGroup.GET("/zones", canRead, taxHandler.ListTaxZones)
+		taxGroup.GET("/zones/:id", canRead, taxHandler.GetTaxZone)
+		taxGroup.PUT("/zones/:id", canUpdate, taxHandler.UpdateTaxZone)
+		taxGroup.POST("/zones/:id/rules", canUpdate, taxHandler.AddTaxRule)
+		taxGroup.PUT("/zones/:id/rules/:rule_id", canUpdate, taxHandler.UpdateTaxRule)
+
+		taxGroup.POST("/exemptions", canUpdate, taxHandler.CreateTaxExemption)
+		taxGroup.GET("/exemptions/customer/:customer_id", canRead, taxHandler.GetCustomerTaxExemptions)
+		taxGroup.POST("/exemptions/:id/revoke", canUpdate, taxHandler.RevokeTaxExemption)
+	}
+}
@@ -0,0 +1,44 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	userEntities "erpgo/internal/domain/users/entities"
+	"erpgo/internal/domain/users/repositories"
+	"erpgo/internal/interfaces/http/handlers"
+	"erpgo/internal/interfaces/http/middleware"
+	"erpgo/pkg/auth"
+)
+
+// SetupTransferRoutes configures inter-warehouse transfer order routes
+func SetupTransferRoutes(
+	router *gin.RouterGroup,
+	transferOrderHandler *handlers.TransferOrderHandler,
+	roleRepo repositories.RoleRepository,
+	authMiddleware gin.HandlerFunc,
+	logger zerolog.Logger,
+) {
+	canCreate := auth.RequirePermission(roleRepo, userEntities.PermissionInventoryCreate)
+	canRead := auth.RequirePermission(roleRepo, userEntities.PermissionInventoryRead)
+	canUpdate := auth.RequirePermission(roleRepo, userEntities.PermissionInventoryUpdate)
+
+	// Transfer order routes (require authentication)
+	transferGroup := router.Group("/transfer-orders")
+	transferGroup.Use(authMiddleware)
+	transferGroup.Use(middleware.Logger(logger))
+	{
+		transferGroup.POST("", canCreate, transferOrderHandler.CreateTransferOrder)
+		transferGroup.GET("", canRead, transferOrderHandler.ListTransferOrders)
+		transferGroup.GET("/in-transit", canRead, transferOrderHandler.GetInTransitStock)
+		transferGroup.GET("/:id", canRead, transferOrderHandler.GetTransferOrder)
+
+		// Transfer order lifecycle
+		transferGroup.POST("/:id/ship", canUpdate, transferOrderHandler.ShipTransferOrder)
+		transferGroup.POST("/:id/cancel", canUpdate, transferOrderHandler.CancelTransferOrder)
+
+		// Transfer receipts
+		transferGroup.POST("/:id/receipts", canUpdate, transferOrderHandler.ReceiveTransferOrder)
+		transferGroup.GET("/:id/receipts", canRead, transferOrderHandler.GetTransferReceipts)
+	}
+}
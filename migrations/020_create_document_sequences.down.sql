@@ -0,0 +1,5 @@
+-- Drop document sequence tables
+
+DROP INDEX IF EXISTS idx_document_sequences_is_active;
+DROP TABLE IF EXISTS document_sequence_counters;
+DROP TABLE IF EXISTS document_sequences;
@@ -0,0 +1,42 @@
+-- Create document sequence tables
+-- Document numbers (sales orders, purchase orders, RMAs, ...) must be gapless
+-- and strictly increasing per document type and reset period for auditing.
+
+CREATE TABLE IF NOT EXISTS document_sequences (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    document_type VARCHAR(50) NOT NULL UNIQUE,
+    prefix VARCHAR(20) NOT NULL,
+    pattern VARCHAR(100) NOT NULL DEFAULT '{PREFIX}-{YYYY}-{SEQ}',
+    padding INTEGER NOT NULL DEFAULT 6 CHECK (padding BETWEEN 1 AND 18),
+    reset_period VARCHAR(10) NOT NULL DEFAULT 'YEARLY' CHECK (reset_period IN ('NEVER', 'YEARLY', 'MONTHLY', 'DAILY')),
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT check_document_sequence_pattern CHECK (pattern LIKE '%{SEQ}%')
+);
+
+-- One counter row per document type and period. Numbers are allocated with an
+-- upsert that row-locks the counter until the surrounding transaction ends, so
+-- concurrent allocations serialize and a rollback returns the number.
+CREATE TABLE IF NOT EXISTS document_sequence_counters (
+    document_type VARCHAR(50) NOT NULL REFERENCES document_sequences(document_type) ON DELETE CASCADE,
+    period_key VARCHAR(10) NOT NULL,
+    last_value BIGINT NOT NULL DEFAULT 0 CHECK (last_value >= 0),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (document_type, period_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_document_sequences_is_active ON document_sequences(is_active);
+
+-- Default sequences for order types
+INSERT INTO document_sequences (document_type, prefix, pattern, padding, reset_period) VALUES
+    ('SALES_ORDER', 'SO', '{PREFIX}-{YYYY}-{SEQ}', 6, 'YEARLY'),
+    ('PURCHASE_ORDER', 'PO', '{PREFIX}-{YYYY}-{SEQ}', 6, 'YEARLY'),
+    ('RETURN', 'RMA', '{PREFIX}-{YYYY}-{SEQ}', 6, 'YEARLY'),
+    ('EXCHANGE', 'EX', '{PREFIX}-{YYYY}-{SEQ}', 6, 'YEARLY'),
+    ('TRANSFER', 'TO', '{PREFIX}-{YYYY}-{SEQ}', 6, 'YEARLY'),
+    ('ADJUSTMENT', 'ADJ', '{PREFIX}-{YYYY}-{SEQ}', 6, 'YEARLY')
+ON CONFLICT (document_type) DO NOTHING;
+
+COMMENT ON TABLE document_sequences IS 'Configuration of gapless document number sequences per document type.';
+COMMENT ON TABLE document_sequence_counters IS 'Last allocated value per document type and reset period.';
@@ -0,0 +1,9 @@
+-- Drop order_status_history table and related rules
+
+DROP RULE IF EXISTS order_status_history_no_delete ON order_status_history;
+DROP RULE IF EXISTS order_status_history_no_update ON order_status_history;
+
+DROP INDEX IF EXISTS idx_order_status_history_changed_by;
+DROP INDEX IF EXISTS idx_order_status_history_order_id_created_at;
+
+DROP TABLE IF EXISTS order_status_history;
@@ -0,0 +1,33 @@
+-- Create order_status_history table
+-- Append-only log of order and payment status changes with actor, reason and source
+
+CREATE TABLE IF NOT EXISTS order_status_history (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    -- No foreign key: history is retained for audit even if the order is removed
+    order_id UUID NOT NULL,
+    status_type VARCHAR(10) NOT NULL DEFAULT 'ORDER' CHECK (status_type IN ('ORDER', 'PAYMENT')),
+    from_status VARCHAR(20),
+    to_status VARCHAR(20) NOT NULL,
+    amount DECIMAL(12,2),
+    changed_by UUID,
+    reason TEXT,
+    source VARCHAR(10) NOT NULL DEFAULT 'API' CHECK (source IN ('API', 'BULK', 'SYSTEM')),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_status_history_order_id_created_at
+    ON order_status_history(order_id, created_at);
+
+CREATE INDEX IF NOT EXISTS idx_order_status_history_changed_by
+    ON order_status_history(changed_by) WHERE changed_by IS NOT NULL;
+
+-- Make the table append-only
+CREATE RULE order_status_history_no_update AS
+    ON UPDATE TO order_status_history
+    DO INSTEAD NOTHING;
+
+CREATE RULE order_status_history_no_delete AS
+    ON DELETE TO order_status_history
+    DO INSTEAD NOTHING;
+
+COMMENT ON TABLE order_status_history IS 'Append-only history of order and payment status changes. No updates or deletes allowed.';
@@ -0,0 +1,14 @@
+-- Drop quotations and quotation_items tables
+
+DELETE FROM document_sequences WHERE document_type = 'QUOTATION';
+
+DROP INDEX IF EXISTS idx_quotation_items_product_id;
+DROP INDEX IF EXISTS idx_quotation_items_quotation_id;
+DROP INDEX IF EXISTS idx_quotations_created_at;
+DROP INDEX IF EXISTS idx_quotations_converted_order_id;
+DROP INDEX IF EXISTS idx_quotations_open_valid_until;
+DROP INDEX IF EXISTS idx_quotations_status;
+DROP INDEX IF EXISTS idx_quotations_customer_id;
+
+DROP TABLE IF EXISTS quotation_items;
+DROP TABLE IF EXISTS quotations;
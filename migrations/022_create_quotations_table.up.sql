@@ -0,0 +1,75 @@
+-- Create quotations and quotation_items tables
+-- Quotations are priced offers to customers that convert into sales orders
+
+CREATE TABLE IF NOT EXISTS quotations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    quotation_number VARCHAR(50) NOT NULL,
+    revision INTEGER NOT NULL DEFAULT 1 CHECK (revision >= 1),
+    previous_revision_id UUID REFERENCES quotations(id) ON DELETE SET NULL,
+    customer_id UUID NOT NULL REFERENCES customers(id) ON DELETE RESTRICT,
+    status VARCHAR(20) NOT NULL DEFAULT 'DRAFT' CHECK (status IN ('DRAFT', 'SENT', 'ACCEPTED', 'REJECTED', 'EXPIRED', 'CONVERTED', 'SUPERSEDED')),
+    valid_until TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    shipping_method VARCHAR(20) NOT NULL DEFAULT 'STANDARD' CHECK (shipping_method IN ('STANDARD', 'EXPRESS', 'OVERNIGHT', 'INTERNATIONAL', 'PICKUP', 'DIGITAL')),
+    shipping_address_id UUID NOT NULL REFERENCES order_addresses(id) ON DELETE RESTRICT,
+    billing_address_id UUID NOT NULL REFERENCES order_addresses(id) ON DELETE RESTRICT,
+
+    subtotal DECIMAL(12,2) NOT NULL DEFAULT 0 CHECK (subtotal >= 0),
+    tax_amount DECIMAL(12,2) NOT NULL DEFAULT 0 CHECK (tax_amount >= 0),
+    shipping_amount DECIMAL(12,2) NOT NULL DEFAULT 0 CHECK (shipping_amount >= 0),
+    discount_amount DECIMAL(12,2) NOT NULL DEFAULT 0 CHECK (discount_amount >= 0),
+    total_amount DECIMAL(12,2) NOT NULL DEFAULT 0 CHECK (total_amount >= 0),
+    currency VARCHAR(3) NOT NULL DEFAULT 'USD' CHECK (currency ~ '^[A-Z]{3}$'),
+
+    notes TEXT,
+    rejection_reason TEXT,
+
+    -- Link to the sales order created from an accepted quotation
+    converted_order_id UUID REFERENCES orders(id) ON DELETE SET NULL,
+
+    created_by UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    sent_at TIMESTAMP WITH TIME ZONE,
+    accepted_at TIMESTAMP WITH TIME ZONE,
+    rejected_at TIMESTAMP WITH TIME ZONE,
+    converted_at TIMESTAMP WITH TIME ZONE,
+
+    CONSTRAINT uq_quotations_number_revision UNIQUE (quotation_number, revision)
+);
+
+CREATE TABLE IF NOT EXISTS quotation_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    quotation_id UUID NOT NULL REFERENCES quotations(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL,
+    product_sku VARCHAR(100) NOT NULL,
+    product_name VARCHAR(300) NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0 AND quantity <= 9999),
+    unit_price DECIMAL(12,2) NOT NULL CHECK (unit_price >= 0),
+    discount_amount DECIMAL(12,2) NOT NULL DEFAULT 0 CHECK (discount_amount >= 0),
+    tax_rate DECIMAL(5,2) NOT NULL DEFAULT 0 CHECK (tax_rate >= 0 AND tax_rate <= 100),
+    tax_amount DECIMAL(12,2) NOT NULL DEFAULT 0 CHECK (tax_amount >= 0),
+    total_price DECIMAL(12,2) NOT NULL CHECK (total_price >= 0),
+    weight DECIMAL(10,3) NOT NULL DEFAULT 0 CHECK (weight >= 0),
+    dimensions VARCHAR(100),
+    barcode VARCHAR(50),
+    notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_quotations_customer_id ON quotations(customer_id);
+CREATE INDEX IF NOT EXISTS idx_quotations_status ON quotations(status);
+CREATE INDEX IF NOT EXISTS idx_quotations_open_valid_until ON quotations(valid_until) WHERE status IN ('DRAFT', 'SENT');
+CREATE INDEX IF NOT EXISTS idx_quotations_converted_order_id ON quotations(converted_order_id) WHERE converted_order_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_quotations_created_at ON quotations(created_at);
+CREATE INDEX IF NOT EXISTS idx_quotation_items_quotation_id ON quotation_items(quotation_id);
+CREATE INDEX IF NOT EXISTS idx_quotation_items_product_id ON quotation_items(product_id);
+
+-- Number quotations from their own document sequence
+INSERT INTO document_sequences (document_type, prefix, pattern, padding, reset_period) VALUES
+    ('QUOTATION', 'QT', '{PREFIX}-{YYYY}-{SEQ}', 6, 'YEARLY')
+ON CONFLICT (document_type) DO NOTHING;
+
+COMMENT ON TABLE quotations IS 'Sales quotations with revisions; accepted quotations convert into sales orders.';
+COMMENT ON TABLE quotation_items IS 'Priced lines of a sales quotation.';
@@ -0,0 +1,24 @@
+-- Drop supplier, purchase order and goods receipt tables
+
+DELETE FROM document_sequences WHERE document_type = 'GOODS_RECEIPT';
+
+DROP INDEX IF EXISTS idx_goods_receipt_items_goods_receipt_id;
+DROP INDEX IF EXISTS idx_goods_receipts_purchase_order_id;
+DROP INDEX IF EXISTS idx_purchase_order_items_product_id;
+DROP INDEX IF EXISTS idx_purchase_order_items_purchase_order_id;
+DROP INDEX IF EXISTS idx_purchase_orders_created_at;
+DROP INDEX IF EXISTS idx_purchase_orders_expected_date;
+DROP INDEX IF EXISTS idx_purchase_orders_status;
+DROP INDEX IF EXISTS idx_purchase_orders_warehouse_id;
+DROP INDEX IF EXISTS idx_purchase_orders_supplier_id;
+DROP INDEX IF EXISTS idx_supplier_contacts_primary;
+DROP INDEX IF EXISTS idx_supplier_contacts_supplier_id;
+DROP INDEX IF EXISTS idx_suppliers_is_active;
+DROP INDEX IF EXISTS idx_suppliers_name;
+
+DROP TABLE IF EXISTS goods_receipt_items;
+DROP TABLE IF EXISTS goods_receipts;
+DROP TABLE IF EXISTS purchase_order_items;
+DROP TABLE IF EXISTS purchase_orders;
+DROP TABLE IF EXISTS supplier_contacts;
+DROP TABLE IF EXISTS suppliers;
@@ -0,0 +1,138 @@
+-- Create supplier, purchase order and goods receipt tables
+-- Purchase orders are placed with suppliers and received into a warehouse,
+-- possibly across several partial goods receipts
+
+CREATE TABLE IF NOT EXISTS suppliers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    supplier_code VARCHAR(50) NOT NULL UNIQUE,
+    name VARCHAR(200) NOT NULL,
+    legal_name VARCHAR(200),
+    tax_id VARCHAR(50),
+    email VARCHAR(255),
+    phone VARCHAR(50),
+    website VARCHAR(500),
+
+    address_line1 VARCHAR(200),
+    address_line2 VARCHAR(200),
+    city VARCHAR(100),
+    state VARCHAR(100),
+    postal_code VARCHAR(20),
+    country VARCHAR(100),
+
+    currency VARCHAR(3) NOT NULL DEFAULT 'USD' CHECK (currency ~ '^[A-Z]{3}$'),
+    payment_terms VARCHAR(20) NOT NULL DEFAULT 'NET30',
+    lead_time_days INTEGER NOT NULL DEFAULT 0 CHECK (lead_time_days >= 0 AND lead_time_days <= 365),
+
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS supplier_contacts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    supplier_id UUID NOT NULL REFERENCES suppliers(id) ON DELETE CASCADE,
+    name VARCHAR(200) NOT NULL,
+    role VARCHAR(100),
+    email VARCHAR(255),
+    phone VARCHAR(50),
+    is_primary BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS purchase_orders (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    po_number VARCHAR(50) NOT NULL UNIQUE,
+    supplier_id UUID NOT NULL REFERENCES suppliers(id) ON DELETE RESTRICT,
+    warehouse_id UUID NOT NULL REFERENCES warehouses(id) ON DELETE RESTRICT,
+    status VARCHAR(20) NOT NULL DEFAULT 'DRAFT' CHECK (status IN ('DRAFT', 'ORDERED', 'PARTIALLY_RECEIVED', 'CLOSED', 'CANCELLED')),
+
+    order_date TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expected_date TIMESTAMP WITH TIME ZONE,
+    currency VARCHAR(3) NOT NULL DEFAULT 'USD' CHECK (currency ~ '^[A-Z]{3}$'),
+    payment_terms VARCHAR(20) NOT NULL DEFAULT 'NET30',
+
+    subtotal DECIMAL(12,2) NOT NULL DEFAULT 0 CHECK (subtotal >= 0),
+    tax_amount DECIMAL(12,2) NOT NULL DEFAULT 0 CHECK (tax_amount >= 0),
+    shipping_amount DECIMAL(12,2) NOT NULL DEFAULT 0 CHECK (shipping_amount >= 0),
+    total_amount DECIMAL(12,2) NOT NULL DEFAULT 0 CHECK (total_amount >= 0),
+
+    notes TEXT,
+    cancellation_reason TEXT,
+
+    created_by UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    ordered_at TIMESTAMP WITH TIME ZONE,
+    closed_at TIMESTAMP WITH TIME ZONE,
+    cancelled_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE IF NOT EXISTS purchase_order_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    purchase_order_id UUID NOT NULL REFERENCES purchase_orders(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE RESTRICT,
+    product_sku VARCHAR(100) NOT NULL,
+    product_name VARCHAR(300) NOT NULL,
+    quantity_ordered INTEGER NOT NULL CHECK (quantity_ordered > 0),
+    quantity_received INTEGER NOT NULL DEFAULT 0 CHECK (quantity_received >= 0 AND quantity_received <= quantity_ordered),
+    unit_cost DECIMAL(12,2) NOT NULL CHECK (unit_cost >= 0),
+    tax_rate DECIMAL(5,2) NOT NULL DEFAULT 0 CHECK (tax_rate >= 0 AND tax_rate <= 100),
+    tax_amount DECIMAL(12,2) NOT NULL DEFAULT 0 CHECK (tax_amount >= 0),
+    total_cost DECIMAL(12,2) NOT NULL CHECK (total_cost >= 0),
+    expected_date TIMESTAMP WITH TIME ZONE,
+    notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS goods_receipts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    receipt_number VARCHAR(50) NOT NULL UNIQUE,
+    purchase_order_id UUID NOT NULL REFERENCES purchase_orders(id) ON DELETE RESTRICT,
+    warehouse_id UUID NOT NULL REFERENCES warehouses(id) ON DELETE RESTRICT,
+    supplier_reference VARCHAR(100),
+    notes TEXT,
+    received_by UUID NOT NULL,
+    received_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS goods_receipt_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    goods_receipt_id UUID NOT NULL REFERENCES goods_receipts(id) ON DELETE CASCADE,
+    purchase_order_item_id UUID NOT NULL REFERENCES purchase_order_items(id) ON DELETE RESTRICT,
+    product_id UUID NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    unit_cost DECIMAL(12,2) NOT NULL CHECK (unit_cost >= 0),
+    batch_number VARCHAR(100),
+    expiry_date TIMESTAMP WITH TIME ZONE,
+    inventory_transaction_id UUID
+);
+
+CREATE INDEX IF NOT EXISTS idx_suppliers_name ON suppliers(name);
+CREATE INDEX IF NOT EXISTS idx_suppliers_is_active ON suppliers(is_active);
+CREATE INDEX IF NOT EXISTS idx_supplier_contacts_supplier_id ON supplier_contacts(supplier_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_supplier_contacts_primary ON supplier_contacts(supplier_id) WHERE is_primary;
+CREATE INDEX IF NOT EXISTS idx_purchase_orders_supplier_id ON purchase_orders(supplier_id);
+CREATE INDEX IF NOT EXISTS idx_purchase_orders_warehouse_id ON purchase_orders(warehouse_id);
+CREATE INDEX IF NOT EXISTS idx_purchase_orders_status ON purchase_orders(status);
+CREATE INDEX IF NOT EXISTS idx_purchase_orders_expected_date ON purchase_orders(expected_date) WHERE status IN ('ORDERED', 'PARTIALLY_RECEIVED');
+CREATE INDEX IF NOT EXISTS idx_purchase_orders_created_at ON purchase_orders(created_at);
+CREATE INDEX IF NOT EXISTS idx_purchase_order_items_purchase_order_id ON purchase_order_items(purchase_order_id);
+CREATE INDEX IF NOT EXISTS idx_purchase_order_items_product_id ON purchase_order_items(product_id);
+CREATE INDEX IF NOT EXISTS idx_goods_receipts_purchase_order_id ON goods_receipts(purchase_order_id);
+CREATE INDEX IF NOT EXISTS idx_goods_receipt_items_goods_receipt_id ON goods_receipt_items(goods_receipt_id);
+
+-- Number goods receipts from their own document sequence
+INSERT INTO document_sequences (document_type, prefix, pattern, padding, reset_period) VALUES
+    ('GOODS_RECEIPT', 'GR', '{PREFIX}-{YYYY}-{SEQ}', 6, 'YEARLY')
+ON CONFLICT (document_type) DO NOTHING;
+
+COMMENT ON TABLE suppliers IS 'Supplier master data used for purchasing.';
+COMMENT ON TABLE supplier_contacts IS 'Contact people of a supplier; at most one primary contact per supplier.';
+COMMENT ON TABLE purchase_orders IS 'Purchase orders placed with suppliers for receipt into a warehouse.';
+COMMENT ON TABLE purchase_order_items IS 'Ordered lines of a purchase order with their received quantities.';
+COMMENT ON TABLE goods_receipts IS 'Receipts of purchased stock against a purchase order.';
+COMMENT ON TABLE goods_receipt_items IS 'Received quantities per purchase order line, linked to the PURCHASE inventory transaction.';
@@ -0,0 +1,11 @@
+-- Drop return merchandise authorization (RMA) tables
+
+DROP INDEX IF EXISTS idx_return_authorization_items_order_item_id;
+DROP INDEX IF EXISTS idx_return_authorization_items_rma_id;
+DROP INDEX IF EXISTS idx_return_authorizations_created_at;
+DROP INDEX IF EXISTS idx_return_authorizations_status;
+DROP INDEX IF EXISTS idx_return_authorizations_customer_id;
+DROP INDEX IF EXISTS idx_return_authorizations_order_id;
+
+DROP TABLE IF EXISTS return_authorization_items;
+DROP TABLE IF EXISTS return_authorizations;
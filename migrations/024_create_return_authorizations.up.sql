@@ -0,0 +1,61 @@
+-- Create return merchandise authorization (RMA) tables
+-- An RMA authorizes the return of shipped order lines, records their receipt
+-- into a return warehouse and the inspection outcome of every line, and is
+-- resolved by a refund on the original order or a replacement order
+
+CREATE TABLE IF NOT EXISTS return_authorizations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    rma_number VARCHAR(50) NOT NULL UNIQUE,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE RESTRICT,
+    customer_id UUID NOT NULL REFERENCES customers(id) ON DELETE RESTRICT,
+    status VARCHAR(20) NOT NULL DEFAULT 'REQUESTED' CHECK (status IN ('REQUESTED', 'APPROVED', 'REJECTED', 'RECEIVED', 'INSPECTED', 'COMPLETED', 'CANCELLED')),
+    resolution VARCHAR(20) NOT NULL CHECK (resolution IN ('REFUND', 'REPLACEMENT')),
+    reason VARCHAR(30) NOT NULL,
+    customer_notes TEXT,
+    rejection_reason TEXT,
+
+    warehouse_id UUID REFERENCES warehouses(id) ON DELETE RESTRICT,
+    refund_amount DECIMAL(12,2) NOT NULL DEFAULT 0 CHECK (refund_amount >= 0),
+    replacement_order_id UUID REFERENCES orders(id) ON DELETE SET NULL,
+
+    requested_by UUID NOT NULL,
+    approved_by UUID,
+    received_by UUID,
+    inspected_by UUID,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    approved_at TIMESTAMP WITH TIME ZONE,
+    rejected_at TIMESTAMP WITH TIME ZONE,
+    received_at TIMESTAMP WITH TIME ZONE,
+    inspected_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    cancelled_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE IF NOT EXISTS return_authorization_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    return_authorization_id UUID NOT NULL REFERENCES return_authorizations(id) ON DELETE CASCADE,
+    order_item_id UUID NOT NULL REFERENCES order_items(id) ON DELETE RESTRICT,
+    product_id UUID NOT NULL,
+    product_sku VARCHAR(100) NOT NULL,
+    product_name VARCHAR(300) NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    quantity_received INTEGER NOT NULL DEFAULT 0 CHECK (quantity_received >= 0 AND quantity_received <= quantity),
+    unit_refund_amount DECIMAL(12,2) NOT NULL DEFAULT 0 CHECK (unit_refund_amount >= 0),
+    reason VARCHAR(30) NOT NULL,
+    disposition VARCHAR(20) CHECK (disposition IN ('RESTOCK', 'SCRAP', 'RETURN_TO_VENDOR')),
+    inspection_notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_return_authorizations_order_id ON return_authorizations(order_id);
+CREATE INDEX IF NOT EXISTS idx_return_authorizations_customer_id ON return_authorizations(customer_id);
+CREATE INDEX IF NOT EXISTS idx_return_authorizations_status ON return_authorizations(status);
+CREATE INDEX IF NOT EXISTS idx_return_authorizations_created_at ON return_authorizations(created_at);
+CREATE INDEX IF NOT EXISTS idx_return_authorization_items_rma_id ON return_authorization_items(return_authorization_id);
+CREATE INDEX IF NOT EXISTS idx_return_authorization_items_order_item_id ON return_authorization_items(order_item_id);
+
+COMMENT ON TABLE return_authorizations IS 'Return merchandise authorizations (RMA) for shipped order lines, numbered from the RETURN document sequence.';
+COMMENT ON TABLE return_authorization_items IS 'Authorized, received and inspected quantities per returned order line.';
+COMMENT ON COLUMN return_authorization_items.disposition IS 'Inspection outcome: RESTOCK back to stock, SCRAP as damaged, or RETURN_TO_VENDOR.';
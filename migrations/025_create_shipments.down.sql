@@ -0,0 +1,12 @@
+-- Drop shipment tables
+
+DROP INDEX IF EXISTS idx_shipment_packages_shipment_id;
+DROP INDEX IF EXISTS idx_shipment_items_order_item_id;
+DROP INDEX IF EXISTS idx_shipment_items_shipment_id;
+DROP INDEX IF EXISTS idx_shipments_status;
+DROP INDEX IF EXISTS idx_shipments_tracking_number;
+DROP INDEX IF EXISTS idx_shipments_order_id;
+
+DROP TABLE IF EXISTS shipment_packages;
+DROP TABLE IF EXISTS shipment_items;
+DROP TABLE IF EXISTS shipments;
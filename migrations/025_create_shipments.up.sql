@@ -0,0 +1,57 @@
+-- Create shipment tables
+-- A shipment is one wave of an order leaving the warehouse. It records the
+-- order lines it carries, the packages they are packed in and the carrier
+-- tracking, so orders shipped in several waves keep every tracking number
+
+CREATE TABLE IF NOT EXISTS shipments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    shipment_number VARCHAR(60) NOT NULL UNIQUE,
+    status VARCHAR(20) NOT NULL DEFAULT 'SHIPPED' CHECK (status IN ('SHIPPED', 'DELIVERED')),
+    carrier VARCHAR(50),
+    tracking_number VARCHAR(100),
+    delivery_proof TEXT,
+    notes TEXT,
+
+    shipped_by UUID NOT NULL,
+    delivered_by UUID,
+    shipped_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_shipments_delivered_at CHECK (delivered_at IS NULL OR delivered_at >= shipped_at)
+);
+
+CREATE TABLE IF NOT EXISTS shipment_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    shipment_id UUID NOT NULL REFERENCES shipments(id) ON DELETE CASCADE,
+    order_item_id UUID NOT NULL REFERENCES order_items(id) ON DELETE RESTRICT,
+    product_id UUID NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+
+    CONSTRAINT uq_shipment_items_order_item UNIQUE (shipment_id, order_item_id)
+);
+
+CREATE TABLE IF NOT EXISTS shipment_packages (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    shipment_id UUID NOT NULL REFERENCES shipments(id) ON DELETE CASCADE,
+    tracking_number VARCHAR(100),
+    weight DECIMAL(10,3) NOT NULL DEFAULT 0 CHECK (weight >= 0),
+    weight_unit VARCHAR(10) NOT NULL DEFAULT 'kg',
+    length DECIMAL(10,2) NOT NULL DEFAULT 0 CHECK (length >= 0),
+    width DECIMAL(10,2) NOT NULL DEFAULT 0 CHECK (width >= 0),
+    height DECIMAL(10,2) NOT NULL DEFAULT 0 CHECK (height >= 0),
+    dimension_unit VARCHAR(10) NOT NULL DEFAULT 'cm'
+);
+
+CREATE INDEX IF NOT EXISTS idx_shipments_order_id ON shipments(order_id);
+CREATE INDEX IF NOT EXISTS idx_shipments_tracking_number ON shipments(tracking_number);
+CREATE INDEX IF NOT EXISTS idx_shipments_status ON shipments(status);
+CREATE INDEX IF NOT EXISTS idx_shipment_items_shipment_id ON shipment_items(shipment_id);
+CREATE INDEX IF NOT EXISTS idx_shipment_items_order_item_id ON shipment_items(order_item_id);
+CREATE INDEX IF NOT EXISTS idx_shipment_packages_shipment_id ON shipment_packages(shipment_id);
+
+COMMENT ON TABLE shipments IS 'Shipment waves of an order with carrier tracking; order fulfillment status is derived from them.';
+COMMENT ON TABLE shipment_items IS 'Quantities of order lines carried by a shipment.';
+COMMENT ON TABLE shipment_packages IS 'Physical parcels of a shipment with weight, dimensions and optional per-parcel tracking.';
@@ -0,0 +1,11 @@
+-- Drop backorders
+
+DROP INDEX IF EXISTS idx_backorders_created_at;
+DROP INDEX IF EXISTS idx_backorders_product_status;
+DROP INDEX IF EXISTS idx_backorders_order_item_id;
+DROP INDEX IF EXISTS idx_backorders_order_id;
+
+DROP TABLE IF EXISTS backorders;
+
+ALTER TABLE order_items DROP CONSTRAINT IF EXISTS chk_order_items_quantity_backordered;
+ALTER TABLE order_items DROP COLUMN IF EXISTS quantity_backordered;
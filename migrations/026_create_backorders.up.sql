@@ -0,0 +1,31 @@
+-- Create backorders
+-- When an order is approved with less stock than ordered, lines of products
+-- that allow backorders are split into an allocated and a backordered
+-- quantity. The backordered quantity waits in a per-product queue and is
+-- allocated as purchase receipts and stock adjustments add stock
+
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS quantity_backordered INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE order_items ADD CONSTRAINT chk_order_items_quantity_backordered CHECK (quantity_backordered >= 0 AND quantity_backordered <= quantity);
+
+CREATE TABLE IF NOT EXISTS backorders (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    order_item_id UUID NOT NULL REFERENCES order_items(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL,
+    product_sku VARCHAR(100) NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    quantity_allocated INTEGER NOT NULL DEFAULT 0 CHECK (quantity_allocated >= 0 AND quantity_allocated <= quantity),
+    status VARCHAR(20) NOT NULL DEFAULT 'OPEN' CHECK (status IN ('OPEN', 'PARTIALLY_ALLOCATED', 'ALLOCATED', 'CANCELLED')),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    allocated_at TIMESTAMP WITH TIME ZONE,
+    cancelled_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_backorders_order_id ON backorders(order_id);
+CREATE INDEX IF NOT EXISTS idx_backorders_order_item_id ON backorders(order_item_id);
+CREATE INDEX IF NOT EXISTS idx_backorders_product_status ON backorders(product_id, status);
+CREATE INDEX IF NOT EXISTS idx_backorders_created_at ON backorders(created_at);
+
+COMMENT ON TABLE backorders IS 'Order line quantities waiting for stock, allocated per product by order priority and order date.';
+COMMENT ON COLUMN order_items.quantity_backordered IS 'Part of the line quantity still waiting for stock; the rest is allocated.';
@@ -0,0 +1,13 @@
+-- Drop the payment ledger; order paid and refunded amounts keep their last derived value
+
+DELETE FROM document_sequences WHERE document_type = 'PAYMENT';
+
+DROP INDEX IF EXISTS idx_payment_allocations_order_id;
+DROP INDEX IF EXISTS idx_payment_allocations_payment_id;
+DROP INDEX IF EXISTS idx_payments_status;
+DROP INDEX IF EXISTS idx_payments_reference;
+DROP INDEX IF EXISTS idx_payments_received_at;
+DROP INDEX IF EXISTS idx_payments_customer_id;
+
+DROP TABLE IF EXISTS payment_allocations;
+DROP TABLE IF EXISTS payments;
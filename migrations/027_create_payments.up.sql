@@ -0,0 +1,67 @@
+-- Create the payment ledger
+-- Payments record how money was received (method, reference, received date)
+-- and may be allocated across several orders of a customer. Refunds are
+-- ledger entries allocated to the order they refund. Reversed entries (e.g.
+-- bounced cheques) no longer count; order paid and refunded amounts are
+-- derived from the completed entries allocated to the order.
+
+CREATE TABLE IF NOT EXISTS payments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    payment_number VARCHAR(50) NOT NULL UNIQUE,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('PAYMENT', 'REFUND')),
+    customer_id UUID NOT NULL REFERENCES customers(id) ON DELETE RESTRICT,
+    method VARCHAR(30) NOT NULL CHECK (method IN ('CASH', 'CHEQUE', 'BANK_TRANSFER', 'CREDIT_CARD', 'DEBIT_CARD', 'DIGITAL_WALLET', 'OTHER')),
+    reference VARCHAR(255),
+    amount DECIMAL(15,2) NOT NULL CHECK (amount > 0),
+    currency VARCHAR(3) NOT NULL CHECK (currency ~ '^[A-Z]{3}$'),
+    received_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'COMPLETED' CHECK (status IN ('COMPLETED', 'REVERSED')),
+    notes TEXT,
+    reversed_at TIMESTAMP WITH TIME ZONE,
+    reversed_by UUID,
+    reversal_reason TEXT,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_payments_reversal CHECK (status <> 'REVERSED' OR reversed_at IS NOT NULL)
+);
+
+CREATE TABLE IF NOT EXISTS payment_allocations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    payment_id UUID NOT NULL REFERENCES payments(id) ON DELETE CASCADE,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE RESTRICT,
+    amount DECIMAL(15,2) NOT NULL CHECK (amount > 0),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_payments_customer_id ON payments(customer_id);
+CREATE INDEX IF NOT EXISTS idx_payments_received_at ON payments(received_at);
+CREATE INDEX IF NOT EXISTS idx_payments_reference ON payments(reference);
+CREATE INDEX IF NOT EXISTS idx_payments_status ON payments(status);
+CREATE INDEX IF NOT EXISTS idx_payment_allocations_payment_id ON payment_allocations(payment_id);
+CREATE INDEX IF NOT EXISTS idx_payment_allocations_order_id ON payment_allocations(order_id);
+
+-- Number payments and refunds from their own document sequence
+INSERT INTO document_sequences (document_type, prefix, pattern, padding, reset_period) VALUES
+    ('PAYMENT', 'PAY', '{PREFIX}-{YYYY}-{SEQ}', 6, 'YEARLY')
+ON CONFLICT (document_type) DO NOTHING;
+
+-- Carry the amounts already recorded on orders into the ledger so that
+-- deriving them from the ledger keeps their value
+INSERT INTO payments (payment_number, type, customer_id, method, amount, currency, received_at, status, notes, created_by, created_at, updated_at)
+SELECT 'MIG-PAY-' || order_number, 'PAYMENT', customer_id, 'OTHER', paid_amount, currency, updated_at, 'COMPLETED',
+       'Paid amount recorded before the payment ledger', created_by, NOW(), NOW()
+FROM orders WHERE paid_amount > 0;
+
+INSERT INTO payments (payment_number, type, customer_id, method, amount, currency, received_at, status, notes, created_by, created_at, updated_at)
+SELECT 'MIG-REF-' || order_number, 'REFUND', customer_id, 'OTHER', refunded_amount, currency, updated_at, 'COMPLETED',
+       'Refunded amount recorded before the payment ledger', created_by, NOW(), NOW()
+FROM orders WHERE refunded_amount > 0;
+
+INSERT INTO payment_allocations (payment_id, order_id, amount, created_at)
+SELECT p.id, o.id, p.amount, NOW()
+FROM payments p
+INNER JOIN orders o ON p.payment_number IN ('MIG-PAY-' || o.order_number, 'MIG-REF-' || o.order_number);
+
+COMMENT ON TABLE payments IS 'Payment ledger: payments received and refunds paid, numbered from the PAYMENT document sequence.';
+COMMENT ON TABLE payment_allocations IS 'Parts of a payment or refund assigned to orders; order paid and refunded amounts are derived from them.';
@@ -0,0 +1,16 @@
+-- Drop invoices and credit notes
+
+DELETE FROM document_sequences WHERE document_type IN ('INVOICE', 'CREDIT_NOTE');
+
+DROP TRIGGER IF EXISTS trg_invoices_locked ON invoices;
+DROP FUNCTION IF EXISTS prevent_issued_invoice_changes();
+
+DROP INDEX IF EXISTS idx_invoice_lines_order_item_id;
+DROP INDEX IF EXISTS idx_invoices_credited_invoice_id;
+DROP INDEX IF EXISTS idx_invoices_due_date;
+DROP INDEX IF EXISTS idx_invoices_status;
+DROP INDEX IF EXISTS idx_invoices_customer_id;
+DROP INDEX IF EXISTS idx_invoices_order_id;
+
+DROP TABLE IF EXISTS invoice_lines;
+DROP TABLE IF EXISTS invoices;
@@ -0,0 +1,88 @@
+-- Create invoices and credit notes
+-- Invoices bill all or part (one shipment) of a sales order. Drafts have no
+-- number; issuing allocates the number from the INVOICE or CREDIT_NOTE
+-- document sequence, stamps the due date from the customer's payment terms and
+-- locks the document. Credit notes reference the invoice they credit.
+
+CREATE TABLE IF NOT EXISTS invoices (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    invoice_number VARCHAR(50) UNIQUE,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('INVOICE', 'CREDIT_NOTE')),
+    status VARCHAR(20) NOT NULL DEFAULT 'DRAFT' CHECK (status IN ('DRAFT', 'ISSUED', 'CANCELLED')),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE RESTRICT,
+    shipment_id UUID REFERENCES shipments(id) ON DELETE RESTRICT,
+    credited_invoice_id UUID REFERENCES invoices(id) ON DELETE RESTRICT,
+    customer_id UUID NOT NULL REFERENCES customers(id) ON DELETE RESTRICT,
+    billing_address_id UUID NOT NULL,
+    currency VARCHAR(3) NOT NULL CHECK (currency ~ '^[A-Z]{3}$'),
+    payment_terms VARCHAR(20) NOT NULL,
+    issue_date TIMESTAMP WITH TIME ZONE,
+    due_date TIMESTAMP WITH TIME ZONE,
+    subtotal DECIMAL(15,2) NOT NULL DEFAULT 0,
+    tax_amount DECIMAL(15,2) NOT NULL DEFAULT 0,
+    shipping_amount DECIMAL(15,2) NOT NULL DEFAULT 0,
+    discount_amount DECIMAL(15,2) NOT NULL DEFAULT 0,
+    total_amount DECIMAL(15,2) NOT NULL DEFAULT 0 CHECK (total_amount >= 0),
+    tax_breakdown JSONB NOT NULL DEFAULT '[]',
+    notes TEXT,
+    reason TEXT,
+    cancellation_reason TEXT,
+    created_by UUID NOT NULL,
+    issued_by UUID,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    cancelled_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT chk_invoices_issued CHECK (
+        status <> 'ISSUED' OR (invoice_number IS NOT NULL AND issue_date IS NOT NULL AND due_date IS NOT NULL)
+    ),
+    CONSTRAINT chk_invoices_credit_note CHECK (type <> 'CREDIT_NOTE' OR credited_invoice_id IS NOT NULL)
+);
+
+CREATE TABLE IF NOT EXISTS invoice_lines (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    invoice_id UUID NOT NULL REFERENCES invoices(id) ON DELETE CASCADE,
+    line_number INTEGER NOT NULL,
+    order_item_id UUID REFERENCES order_items(id) ON DELETE RESTRICT,
+    product_id UUID,
+    product_sku VARCHAR(100) NOT NULL DEFAULT '',
+    description VARCHAR(500) NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    unit_price DECIMAL(15,4) NOT NULL CHECK (unit_price >= 0),
+    discount_amount DECIMAL(15,2) NOT NULL DEFAULT 0,
+    tax_rate DECIMAL(5,2) NOT NULL DEFAULT 0,
+    tax_amount DECIMAL(15,2) NOT NULL DEFAULT 0,
+    total_price DECIMAL(15,2) NOT NULL,
+    UNIQUE (invoice_id, line_number)
+);
+
+CREATE INDEX IF NOT EXISTS idx_invoices_order_id ON invoices(order_id);
+CREATE INDEX IF NOT EXISTS idx_invoices_customer_id ON invoices(customer_id);
+CREATE INDEX IF NOT EXISTS idx_invoices_status ON invoices(status);
+CREATE INDEX IF NOT EXISTS idx_invoices_due_date ON invoices(due_date) WHERE status = 'ISSUED';
+CREATE INDEX IF NOT EXISTS idx_invoices_credited_invoice_id ON invoices(credited_invoice_id);
+CREATE INDEX IF NOT EXISTS idx_invoice_lines_order_item_id ON invoice_lines(order_item_id);
+
+-- Issued documents are locked: reject any change to them at the database level
+CREATE OR REPLACE FUNCTION prevent_issued_invoice_changes()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF OLD.status <> 'DRAFT' THEN
+        RAISE EXCEPTION 'invoice % is % and locked', COALESCE(OLD.invoice_number, OLD.id::text), OLD.status;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_invoices_locked
+    BEFORE UPDATE OR DELETE ON invoices
+    FOR EACH ROW EXECUTE FUNCTION prevent_issued_invoice_changes();
+
+-- Number invoices and credit notes from their own document sequences
+INSERT INTO document_sequences (document_type, prefix, pattern, padding, reset_period) VALUES
+    ('INVOICE', 'INV', '{PREFIX}-{YYYY}-{SEQ}', 6, 'YEARLY'),
+    ('CREDIT_NOTE', 'CN', '{PREFIX}-{YYYY}-{SEQ}', 6, 'YEARLY')
+ON CONFLICT (document_type) DO NOTHING;
+
+COMMENT ON TABLE invoices IS 'Invoices and credit notes generated from sales orders; locked once issued.';
+COMMENT ON COLUMN invoices.tax_breakdown IS 'Tax amounts grouped by rate, from the order calculation of the invoice lines.';
+COMMENT ON TABLE invoice_lines IS 'Billed quantities of order lines, or credited amounts on credit notes.';
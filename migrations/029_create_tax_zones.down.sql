@@ -0,0 +1,14 @@
+-- Drop the tax engine tables
+
+ALTER TABLE invoice_lines DROP COLUMN IF EXISTS price_includes_tax;
+ALTER TABLE order_items DROP COLUMN IF EXISTS price_includes_tax;
+ALTER TABLE order_items DROP COLUMN IF EXISTS tax_class;
+ALTER TABLE products DROP COLUMN IF EXISTS tax_class;
+
+DROP INDEX IF EXISTS idx_tax_exemptions_customer_id;
+DROP INDEX IF EXISTS idx_tax_rules_zone_id;
+DROP INDEX IF EXISTS idx_tax_zones_country;
+
+DROP TABLE IF EXISTS tax_exemptions;
+DROP TABLE IF EXISTS tax_rules;
+DROP TABLE IF EXISTS tax_zones;
@@ -0,0 +1,72 @@
+-- Create the tax engine tables
+-- Tax zones are jurisdictions matched against the shipping address by
+-- country, state and postal code prefix; zones stack, so an address can be
+-- taxed by a state zone and a county zone at once. Each zone levies named tax
+-- components (state tax, county tax, VAT) on product tax classes through its
+-- rules; rules of the SHIPPING class tax shipping charges. Exemptions record
+-- the certificates that exempt a customer from the taxes of a jurisdiction.
+
+CREATE TABLE IF NOT EXISTS tax_zones (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    code VARCHAR(50) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    country VARCHAR(2) NOT NULL CHECK (country ~ '^[A-Z]{2}$'),
+    state VARCHAR(100),
+    postal_code_prefixes TEXT[] NOT NULL DEFAULT '{}',
+    prices_include_tax BOOLEAN NOT NULL DEFAULT false,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS tax_rules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    zone_id UUID NOT NULL REFERENCES tax_zones(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    level VARCHAR(20) NOT NULL CHECK (level IN ('COUNTRY', 'STATE', 'COUNTY', 'CITY', 'VAT')),
+    tax_class VARCHAR(50) NOT NULL CHECK (tax_class <> 'EXEMPT'),
+    rate DECIMAL(7,4) NOT NULL CHECK (rate >= 0 AND rate <= 100),
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS tax_exemptions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    customer_id UUID NOT NULL REFERENCES customers(id) ON DELETE CASCADE,
+    certificate_number VARCHAR(100) NOT NULL,
+    country VARCHAR(2) NOT NULL CHECK (country ~ '^[A-Z]{2}$'),
+    state VARCHAR(100),
+    valid_from TIMESTAMP WITH TIME ZONE NOT NULL,
+    valid_until TIMESTAMP WITH TIME ZONE,
+    reason TEXT,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    revoked_by UUID,
+    revoke_reason TEXT,
+    CONSTRAINT chk_tax_exemptions_validity CHECK (valid_until IS NULL OR valid_until >= valid_from),
+    CONSTRAINT uq_tax_exemptions_certificate UNIQUE (customer_id, certificate_number)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tax_zones_country ON tax_zones(country, state) WHERE is_active;
+CREATE INDEX IF NOT EXISTS idx_tax_rules_zone_id ON tax_rules(zone_id);
+CREATE INDEX IF NOT EXISTS idx_tax_exemptions_customer_id ON tax_exemptions(customer_id);
+
+-- Products and order lines carry a tax class; order lines remember whether
+-- their price includes tax so recalculations back it out the same way
+ALTER TABLE products ADD COLUMN IF NOT EXISTS tax_class VARCHAR(50) NOT NULL DEFAULT 'STANDARD';
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS tax_class VARCHAR(50) NOT NULL DEFAULT 'STANDARD';
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS price_includes_tax BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE invoice_lines ADD COLUMN IF NOT EXISTS price_includes_tax BOOLEAN NOT NULL DEFAULT false;
+
+UPDATE products SET tax_class = 'EXEMPT' WHERE NOT taxable;
+UPDATE order_items oi SET tax_class = 'EXEMPT' FROM products p WHERE p.id = oi.product_id AND NOT p.taxable;
+
+-- Add comments for documentation
+COMMENT ON TABLE tax_zones IS 'Tax jurisdictions matched against shipping addresses; matching zones stack.';
+COMMENT ON COLUMN tax_zones.prices_include_tax IS 'Item prices shipped into the zone are gross; tax is backed out of them.';
+COMMENT ON TABLE tax_rules IS 'Named tax components levied by a zone on a product tax class; the SHIPPING class taxes shipping.';
+COMMENT ON TABLE tax_exemptions IS 'Exemption certificates exempting a customer from the taxes of a country or state.';
+COMMENT ON COLUMN products.tax_class IS 'Selects the tax zone rules applying to the product.';
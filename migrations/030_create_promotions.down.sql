@@ -0,0 +1,11 @@
+-- Drop the promotion tables
+
+ALTER TABLE orders DROP COLUMN IF EXISTS promotion_discount_amount;
+
+DROP INDEX IF EXISTS uq_promotion_redemptions_active;
+DROP INDEX IF EXISTS idx_promotion_redemptions_order_id;
+DROP INDEX IF EXISTS idx_promotion_redemptions_promotion;
+DROP INDEX IF EXISTS idx_promotions_window;
+
+DROP TABLE IF EXISTS promotion_redemptions;
+DROP TABLE IF EXISTS promotions;
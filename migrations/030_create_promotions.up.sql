@@ -0,0 +1,63 @@
+-- Create the promotion tables
+-- Promotions are discounts redeemed with a coupon code. Their conditions
+-- (minimum subtotal, product and category scope, customer types, date window)
+-- decide whether an order qualifies; their action decides the discount.
+-- Redemptions record the codes applied to orders and count against the usage
+-- limits until they are released.
+
+CREATE TABLE IF NOT EXISTS promotions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    code VARCHAR(50) NOT NULL UNIQUE CHECK (code ~ '^[A-Z0-9][A-Z0-9_-]{2,49}$'),
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    action_type VARCHAR(20) NOT NULL CHECK (action_type IN ('PERCENTAGE', 'FIXED_AMOUNT', 'BUY_X_GET_Y', 'FREE_SHIPPING')),
+    value DECIMAL(12,2) NOT NULL DEFAULT 0 CHECK (value >= 0),
+    max_discount DECIMAL(12,2) CHECK (max_discount > 0),
+    buy_quantity INTEGER NOT NULL DEFAULT 0 CHECK (buy_quantity >= 0),
+    get_quantity INTEGER NOT NULL DEFAULT 0 CHECK (get_quantity >= 0),
+    min_subtotal DECIMAL(12,2) NOT NULL DEFAULT 0 CHECK (min_subtotal >= 0),
+    product_ids UUID[] NOT NULL DEFAULT '{}',
+    category_ids UUID[] NOT NULL DEFAULT '{}',
+    customer_types TEXT[] NOT NULL DEFAULT '{}',
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE,
+    usage_limit INTEGER CHECK (usage_limit > 0),
+    usage_limit_per_customer INTEGER CHECK (usage_limit_per_customer > 0),
+    stackable BOOLEAN NOT NULL DEFAULT false,
+    priority INTEGER NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_promotions_window CHECK (ends_at IS NULL OR ends_at > starts_at)
+);
+
+CREATE TABLE IF NOT EXISTS promotion_redemptions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    promotion_id UUID NOT NULL REFERENCES promotions(id) ON DELETE RESTRICT,
+    promotion_code VARCHAR(50) NOT NULL,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    customer_id UUID NOT NULL REFERENCES customers(id) ON DELETE CASCADE,
+    redeemed_by UUID NOT NULL,
+    redeemed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    released_at TIMESTAMP WITH TIME ZONE,
+    release_reason TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_promotions_window ON promotions(starts_at, ends_at) WHERE is_active;
+CREATE INDEX IF NOT EXISTS idx_promotion_redemptions_promotion ON promotion_redemptions(promotion_id, customer_id) WHERE released_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_promotion_redemptions_order_id ON promotion_redemptions(order_id);
+-- A code can be applied to an order once at a time
+CREATE UNIQUE INDEX IF NOT EXISTS uq_promotion_redemptions_active ON promotion_redemptions(promotion_id, order_id) WHERE released_at IS NULL;
+
+-- Orders remember how much of their discount came from coupon codes so
+-- recalculations keep manual discounts apart from promotion discounts
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS promotion_discount_amount DECIMAL(12,2) NOT NULL DEFAULT 0
+    CHECK (promotion_discount_amount >= 0 AND promotion_discount_amount <= discount_amount);
+
+-- Add comments for documentation
+COMMENT ON TABLE promotions IS 'Discounts redeemed with a coupon code, with eligibility conditions and usage limits.';
+COMMENT ON COLUMN promotions.value IS 'Percentage or amount off; for BUY_X_GET_Y the percentage off the free units.';
+COMMENT ON COLUMN promotions.stackable IS 'Whether the promotion combines with other stackable promotions on an order.';
+COMMENT ON TABLE promotion_redemptions IS 'Coupon codes applied to orders; unreleased redemptions count against usage limits.';
+COMMENT ON COLUMN orders.promotion_discount_amount IS 'Part of the order discount given by coupon codes.';
@@ -0,0 +1,7 @@
+-- Drop the shipping rate engine tables
+
+DROP INDEX IF EXISTS idx_shipping_rates_zone_id;
+DROP INDEX IF EXISTS idx_shipping_zones_country;
+
+DROP TABLE IF EXISTS shipping_rates;
+DROP TABLE IF EXISTS shipping_zones;
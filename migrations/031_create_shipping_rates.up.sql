@@ -0,0 +1,49 @@
+-- Create the shipping rate engine tables
+-- Shipping zones are delivery areas matched against the shipping address by
+-- country, state and postal code prefix. Each zone prices shipping methods
+-- through rates for weight brackets; a method is priced by the most specific
+-- matching zone with a rate for it. Rates charge the greater of the actual
+-- and dimensional weight, add a handling fee, and can be waived above a
+-- free shipping threshold.
+
+CREATE TABLE IF NOT EXISTS shipping_zones (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    code VARCHAR(50) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    country VARCHAR(2) NOT NULL CHECK (country ~ '^[A-Z]{2}$'),
+    state VARCHAR(100),
+    postal_code_prefixes TEXT[] NOT NULL DEFAULT '{}',
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS shipping_rates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    zone_id UUID NOT NULL REFERENCES shipping_zones(id) ON DELETE CASCADE,
+    method VARCHAR(20) NOT NULL CHECK (method IN ('STANDARD', 'EXPRESS', 'OVERNIGHT', 'INTERNATIONAL', 'PICKUP', 'DIGITAL')),
+    name VARCHAR(255) NOT NULL,
+    min_weight DECIMAL(10,3) NOT NULL DEFAULT 0 CHECK (min_weight >= 0),
+    max_weight DECIMAL(10,3),
+    base_rate DECIMAL(15,2) NOT NULL DEFAULT 0 CHECK (base_rate >= 0),
+    per_kg_rate DECIMAL(15,4) NOT NULL DEFAULT 0 CHECK (per_kg_rate >= 0),
+    handling_fee DECIMAL(15,2) NOT NULL DEFAULT 0 CHECK (handling_fee >= 0),
+    free_shipping_threshold DECIMAL(15,2) CHECK (free_shipping_threshold >= 0),
+    dimensional_divisor DECIMAL(10,2) NOT NULL DEFAULT 0 CHECK (dimensional_divisor >= 0),
+    min_delivery_days INTEGER NOT NULL DEFAULT 0 CHECK (min_delivery_days >= 0),
+    max_delivery_days INTEGER NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_shipping_rates_weight CHECK (max_weight IS NULL OR max_weight > min_weight),
+    CONSTRAINT chk_shipping_rates_delivery CHECK (max_delivery_days >= min_delivery_days)
+);
+
+CREATE INDEX IF NOT EXISTS idx_shipping_zones_country ON shipping_zones(country, state) WHERE is_active;
+CREATE INDEX IF NOT EXISTS idx_shipping_rates_zone_id ON shipping_rates(zone_id, method);
+
+-- Add comments for documentation
+COMMENT ON TABLE shipping_zones IS 'Delivery areas matched against shipping addresses; the most specific zone pricing a method wins.';
+COMMENT ON TABLE shipping_rates IS 'Price of a shipping method in a zone for a chargeable weight bracket [min_weight, max_weight).';
+COMMENT ON COLUMN shipping_rates.dimensional_divisor IS 'Cubic centimetres per kilogram of dimensional weight; 0 charges actual weight only.';
+COMMENT ON COLUMN shipping_rates.free_shipping_threshold IS 'Merchandise amount from which the shipping charge is waived.';
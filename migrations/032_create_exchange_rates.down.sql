@@ -0,0 +1,7 @@
+-- Drop the exchange rate table
+
+ALTER TABLE orders DROP COLUMN IF EXISTS exchange_rate;
+
+DROP INDEX IF EXISTS idx_exchange_rates_effective_date;
+
+DROP TABLE IF EXISTS exchange_rates;
@@ -0,0 +1,32 @@
+-- Create the exchange rate table
+-- An exchange rate is the value of one unit of from_currency in to_currency,
+-- effective from its date until a later rate of the same pair. Rates are
+-- entered by hand or imported from CSV and European Central Bank files; pairs
+-- without a direct rate are crossed through a common currency. Orders book the
+-- rate into the company base currency when they are created so revenue
+-- reports sum amounts of different currencies in the base currency.
+
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    from_currency VARCHAR(3) NOT NULL CHECK (from_currency ~ '^[A-Z]{3}$'),
+    to_currency VARCHAR(3) NOT NULL CHECK (to_currency ~ '^[A-Z]{3}$'),
+    rate DECIMAL(18,8) NOT NULL CHECK (rate > 0),
+    effective_date DATE NOT NULL,
+    source VARCHAR(20) NOT NULL DEFAULT 'MANUAL' CHECK (source IN ('MANUAL', 'CSV', 'ECB')),
+    created_by UUID,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_exchange_rates_pair CHECK (from_currency <> to_currency),
+    CONSTRAINT uq_exchange_rates_pair_date UNIQUE (from_currency, to_currency, effective_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_exchange_rates_effective_date ON exchange_rates(effective_date DESC);
+
+-- Orders placed before multi-currency support are booked at par
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS exchange_rate DECIMAL(18,8) NOT NULL DEFAULT 1
+    CHECK (exchange_rate > 0);
+
+-- Add comments for documentation
+COMMENT ON TABLE exchange_rates IS 'Currency exchange rates by effective date; the latest rate on or before a date applies.';
+COMMENT ON COLUMN exchange_rates.rate IS 'Units of to_currency per unit of from_currency.';
+COMMENT ON COLUMN orders.exchange_rate IS 'Rate converting the order currency into the base currency, booked when the order was created.';
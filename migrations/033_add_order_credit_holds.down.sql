@@ -0,0 +1,16 @@
+-- Drop order credit holds
+
+DELETE FROM roles WHERE name = 'credit_manager';
+
+UPDATE roles SET permissions = array_remove(permissions, 'orders.credit_override');
+
+UPDATE customers SET credit_used = credit_limit WHERE credit_used > credit_limit;
+
+ALTER TABLE customers ADD CONSTRAINT check_credit_limit_used
+    CHECK (credit_used <= credit_limit);
+
+COMMENT ON COLUMN customers.credit_used IS 'Currently used credit';
+
+DROP INDEX IF EXISTS idx_orders_credit_hold;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS credit_hold;
@@ -0,0 +1,25 @@
+-- Add credit holds to orders
+-- Approving an order checks the customer's open receivables plus unshipped
+-- orders, in the base currency, against their credit limit. Orders that would
+-- exceed it are put on hold with a credit-hold reason until the customer pays
+-- or a credit manager overrides the hold. customers.credit_used tracks the
+-- exposure and is released by payments, cancellations and returns; overrides
+-- deliberately take it above the limit.
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS credit_hold BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_orders_credit_hold ON orders(customer_id) WHERE credit_hold;
+
+ALTER TABLE customers DROP CONSTRAINT IF EXISTS check_credit_limit_used;
+
+-- Credit managers confirm orders on credit hold
+UPDATE roles SET permissions = array_append(permissions, 'orders.credit_override')
+WHERE name = 'admin' AND NOT ('orders.credit_override' = ANY(permissions));
+
+INSERT INTO roles (name, description, permissions) VALUES
+('credit_manager', 'Credit manager who releases orders held over customer credit limits', ARRAY['orders.read', 'orders.update', 'orders.credit_override', 'profile.read', 'profile.update'])
+ON CONFLICT (name) DO NOTHING;
+
+-- Add comments for documentation
+COMMENT ON COLUMN orders.credit_hold IS 'Whether the order is held for taking the customer over their credit limit.';
+COMMENT ON COLUMN customers.credit_used IS 'Open receivables plus unshipped approved orders in the base currency.';
@@ -0,0 +1,6 @@
+-- Drop the order approval workflow tables
+
+UPDATE roles SET permissions = array_remove(permissions, 'orders.approval_policies');
+
+DROP TABLE IF EXISTS order_approvals;
+DROP TABLE IF EXISTS approval_policies;
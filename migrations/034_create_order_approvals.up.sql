@@ -0,0 +1,56 @@
+-- Create the order approval workflow tables
+-- Approval policies require orders meeting their conditions (an amount in the
+-- base currency, customer types, a discount percentage or a maximum margin)
+-- to be approved by users holding their approver roles, in sequence, before
+-- the order is confirmed. The chain of an order is the approver roles of its
+-- matching policies by descending priority; each step is a row of
+-- order_approvals recording who approved or rejected it. When a pending order
+-- changes, its chain is superseded by a new one and the old rows are kept.
+
+CREATE TABLE IF NOT EXISTS approval_policies (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    min_amount DECIMAL(15,2) CHECK (min_amount >= 0),
+    customer_types TEXT[] NOT NULL DEFAULT '{}',
+    min_discount_percent DECIMAL(5,2) CHECK (min_discount_percent BETWEEN 0 AND 100),
+    max_margin_percent DECIMAL(7,2) CHECK (max_margin_percent <= 100),
+    approver_roles TEXT[] NOT NULL CHECK (cardinality(approver_roles) > 0),
+    priority INTEGER NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS order_approvals (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    policy_id UUID NOT NULL REFERENCES approval_policies(id),
+    policy_name VARCHAR(255) NOT NULL,
+    sequence INTEGER NOT NULL CHECK (sequence > 0),
+    role VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'APPROVED', 'REJECTED', 'SKIPPED')),
+    amount DECIMAL(15,2) NOT NULL,
+    decided_by UUID,
+    decided_at TIMESTAMP WITH TIME ZONE,
+    comment TEXT,
+    superseded_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_order_approvals_decision CHECK ((status IN ('APPROVED', 'REJECTED')) = (decided_by IS NOT NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_approval_policies_active ON approval_policies(priority DESC) WHERE is_active;
+CREATE INDEX IF NOT EXISTS idx_order_approvals_order_id ON order_approvals(order_id, sequence);
+CREATE INDEX IF NOT EXISTS idx_order_approvals_pending ON order_approvals(role, created_at) WHERE status = 'PENDING' AND superseded_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_order_approvals_current ON order_approvals(order_id, sequence) WHERE superseded_at IS NULL;
+
+-- Finance configures the approval policies
+UPDATE roles SET permissions = array_append(permissions, 'orders.approval_policies')
+WHERE name = 'admin' AND NOT ('orders.approval_policies' = ANY(permissions));
+
+-- Add comments for documentation
+COMMENT ON TABLE approval_policies IS 'Conditions under which orders need approval and the roles approving them in sequence.';
+COMMENT ON TABLE order_approvals IS 'Steps of order approval chains with the approvals and rejections recorded against them.';
+COMMENT ON COLUMN order_approvals.amount IS 'Order total in the base currency when approval was requested.';
+COMMENT ON COLUMN order_approvals.superseded_at IS 'When a change to the order replaced the chain this step belonged to.';
@@ -0,0 +1,5 @@
+-- Drop recurring order tables
+
+DROP TABLE IF EXISTS recurring_order_runs;
+DROP TABLE IF EXISTS recurring_order_items;
+DROP TABLE IF EXISTS recurring_orders;
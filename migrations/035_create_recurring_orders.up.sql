@@ -0,0 +1,71 @@
+-- Create recurring order tables
+-- Recurring orders generate sales orders from a template of lines every
+-- interval_count days, weeks or months from their start date. Template lines
+-- carry no prices; each generated order is priced from the catalogue. Every
+-- occurrence is recorded in recurring_order_runs with the order generated for
+-- it, or why it was skipped or failed.
+
+CREATE TABLE IF NOT EXISTS recurring_orders (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    customer_id UUID NOT NULL REFERENCES customers(id) ON DELETE RESTRICT,
+    status VARCHAR(20) NOT NULL DEFAULT 'ACTIVE' CHECK (status IN ('ACTIVE', 'PAUSED', 'COMPLETED', 'CANCELLED')),
+
+    priority VARCHAR(20) NOT NULL DEFAULT 'NORMAL' CHECK (priority IN ('LOW', 'NORMAL', 'HIGH', 'URGENT', 'CRITICAL')),
+    shipping_method VARCHAR(20) NOT NULL DEFAULT 'STANDARD' CHECK (shipping_method IN ('STANDARD', 'EXPRESS', 'OVERNIGHT', 'INTERNATIONAL', 'PICKUP', 'DIGITAL')),
+    shipping_address_id UUID NOT NULL REFERENCES order_addresses(id) ON DELETE RESTRICT,
+    billing_address_id UUID NOT NULL REFERENCES order_addresses(id) ON DELETE RESTRICT,
+    currency VARCHAR(3) CHECK (currency ~ '^[A-Z]{3}$'),
+    notes TEXT,
+
+    frequency VARCHAR(20) NOT NULL CHECK (frequency IN ('DAILY', 'WEEKLY', 'MONTHLY')),
+    interval_count INTEGER NOT NULL DEFAULT 1 CHECK (interval_count >= 1),
+    day_of_month INTEGER CHECK (day_of_month BETWEEN 1 AND 31),
+    start_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_date TIMESTAMP WITH TIME ZONE,
+    next_run_at TIMESTAMP WITH TIME ZONE,
+    last_run_at TIMESTAMP WITH TIME ZONE,
+
+    created_by UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_recurring_orders_day_of_month CHECK (day_of_month IS NULL OR frequency = 'MONTHLY'),
+    CONSTRAINT chk_recurring_orders_end_date CHECK (end_date IS NULL OR end_date >= start_date)
+);
+
+CREATE TABLE IF NOT EXISTS recurring_order_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    recurring_order_id UUID NOT NULL REFERENCES recurring_orders(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE RESTRICT,
+    quantity INTEGER NOT NULL CHECK (quantity > 0 AND quantity <= 9999),
+    notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS recurring_order_runs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    recurring_order_id UUID NOT NULL REFERENCES recurring_orders(id) ON DELETE CASCADE,
+    scheduled_for TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('GENERATED', 'SKIPPED', 'FAILED')),
+    order_id UUID REFERENCES orders(id) ON DELETE SET NULL,
+    order_number VARCHAR(50),
+    reason TEXT,
+    created_by UUID,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_recurring_orders_customer_id ON recurring_orders(customer_id);
+CREATE INDEX IF NOT EXISTS idx_recurring_orders_status ON recurring_orders(status);
+CREATE INDEX IF NOT EXISTS idx_recurring_orders_due ON recurring_orders(next_run_at) WHERE status = 'ACTIVE';
+CREATE INDEX IF NOT EXISTS idx_recurring_order_items_recurring_order_id ON recurring_order_items(recurring_order_id);
+CREATE INDEX IF NOT EXISTS idx_recurring_order_runs_recurring_order_id ON recurring_order_runs(recurring_order_id, scheduled_for DESC);
+CREATE INDEX IF NOT EXISTS idx_recurring_order_runs_order_id ON recurring_order_runs(order_id) WHERE order_id IS NOT NULL;
+
+-- Add comments for documentation
+COMMENT ON TABLE recurring_orders IS 'Schedules generating sales orders from a template of lines.';
+COMMENT ON TABLE recurring_order_items IS 'Template lines of recurring orders, priced when each order is generated.';
+COMMENT ON TABLE recurring_order_runs IS 'Occurrences of recurring orders with the order generated for each.';
+COMMENT ON COLUMN recurring_orders.day_of_month IS 'Day of the month of monthly occurrences, the last day of shorter months.';
+COMMENT ON COLUMN recurring_orders.end_date IS 'Last time an occurrence may fall on; the schedule completes after it.';
+COMMENT ON COLUMN recurring_orders.currency IS 'Currency of generated orders; NULL uses the customer''s preferred currency.';
@@ -0,0 +1,4 @@
+-- Drop order import tables
+
+DROP TABLE IF EXISTS order_external_references;
+DROP TABLE IF EXISTS order_import_profiles;
@@ -0,0 +1,41 @@
+-- Create order import tables
+-- Import profiles map the columns of a partner's CSV or JSON files onto order
+-- fields. Every imported order records the partner's order reference in
+-- order_external_references so that a file imported twice creates its orders
+-- once. References are claimed before their orders are inserted, so order_id
+-- carries no foreign key.
+
+CREATE TABLE IF NOT EXISTS order_import_profiles (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    source VARCHAR(100) NOT NULL,
+    format VARCHAR(10) NOT NULL CHECK (format IN ('CSV', 'JSON')),
+    delimiter VARCHAR(1) NOT NULL DEFAULT ',',
+    columns JSONB NOT NULL DEFAULT '{}',
+    defaults JSONB NOT NULL DEFAULT '{}',
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS order_external_references (
+    source VARCHAR(100) NOT NULL,
+    external_reference VARCHAR(255) NOT NULL,
+    order_id UUID NOT NULL,
+    profile_id UUID REFERENCES order_import_profiles(id) ON DELETE SET NULL,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (source, external_reference)
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_import_profiles_source ON order_import_profiles(source);
+CREATE INDEX IF NOT EXISTS idx_order_external_references_order_id ON order_external_references(order_id);
+
+COMMENT ON TABLE order_import_profiles IS 'Saved column mappings of partner order import files';
+COMMENT ON COLUMN order_import_profiles.source IS 'Partner sending the files; external references are unique per source';
+COMMENT ON COLUMN order_import_profiles.columns IS 'Order field to file column or key; unmapped fields are read from a column named after the field';
+COMMENT ON COLUMN order_import_profiles.defaults IS 'Order field to value used when the file gives none';
+COMMENT ON TABLE order_external_references IS 'Partner order references of imported orders, making imports idempotent';
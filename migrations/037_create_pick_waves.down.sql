@@ -0,0 +1,8 @@
+-- Drop pick wave tables
+
+DELETE FROM document_sequences WHERE document_type = 'PICK_WAVE';
+
+DROP TABLE IF EXISTS pick_locations;
+DROP TABLE IF EXISTS pick_lines;
+DROP TABLE IF EXISTS pick_wave_orders;
+DROP TABLE IF EXISTS pick_waves;
@@ -0,0 +1,88 @@
+-- Create pick wave tables
+-- A pick wave groups PROCESSING orders of one warehouse that are picked
+-- together, optionally for one shipping method, a minimum priority and a
+-- carrier cutoff. Its lines are the order quantities to take from stock
+-- reserved in the warehouse, located through pick_locations. Short picked
+-- units are written off and reserved again elsewhere or backordered; picked
+-- orders are packed into shipments one at a time.
+
+CREATE TABLE IF NOT EXISTS pick_waves (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    wave_number VARCHAR(50) NOT NULL UNIQUE,
+    warehouse_id UUID NOT NULL REFERENCES warehouses(id) ON DELETE RESTRICT,
+    status VARCHAR(20) NOT NULL DEFAULT 'RELEASED' CHECK (status IN ('RELEASED', 'PICKING', 'COMPLETED', 'CANCELLED')),
+    shipping_method VARCHAR(20) CHECK (shipping_method IN ('STANDARD', 'EXPRESS', 'OVERNIGHT', 'INTERNATIONAL', 'PICKUP', 'DIGITAL')),
+    min_priority VARCHAR(20) CHECK (min_priority IN ('LOW', 'NORMAL', 'HIGH', 'URGENT', 'CRITICAL')),
+    carrier VARCHAR(50),
+    cutoff_at TIMESTAMP WITH TIME ZONE,
+    notes TEXT,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP WITH TIME ZONE,
+    cancelled_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE IF NOT EXISTS pick_wave_orders (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    wave_id UUID NOT NULL REFERENCES pick_waves(id) ON DELETE CASCADE,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE RESTRICT,
+    order_number VARCHAR(50) NOT NULL,
+    priority VARCHAR(20) NOT NULL,
+    sequence INTEGER NOT NULL CHECK (sequence > 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'PICKING' CHECK (status IN ('PICKING', 'PICKED', 'PACKED', 'SHORT', 'CANCELLED')),
+    shipment_id UUID REFERENCES shipments(id) ON DELETE SET NULL,
+    packed_by UUID,
+    packed_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT uq_pick_wave_orders_order UNIQUE (wave_id, order_id)
+);
+
+CREATE TABLE IF NOT EXISTS pick_lines (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    wave_id UUID NOT NULL REFERENCES pick_waves(id) ON DELETE CASCADE,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE RESTRICT,
+    order_item_id UUID NOT NULL REFERENCES order_items(id) ON DELETE RESTRICT,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE RESTRICT,
+    product_sku VARCHAR(100) NOT NULL,
+    product_name VARCHAR(300) NOT NULL,
+    location VARCHAR(50),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    quantity_picked INTEGER NOT NULL DEFAULT 0 CHECK (quantity_picked >= 0),
+    quantity_short INTEGER NOT NULL DEFAULT 0 CHECK (quantity_short >= 0),
+    quantity_reallocated INTEGER NOT NULL DEFAULT 0 CHECK (quantity_reallocated >= 0),
+    quantity_backordered INTEGER NOT NULL DEFAULT 0 CHECK (quantity_backordered >= 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'PICKED', 'SHORT', 'CANCELLED')),
+    picked_by UUID,
+    picked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_pick_lines_picked CHECK (quantity_picked + quantity_short <= quantity),
+    CONSTRAINT chk_pick_lines_short CHECK (quantity_reallocated + quantity_backordered <= quantity_short),
+    CONSTRAINT uq_pick_lines_order_item UNIQUE (wave_id, order_item_id)
+);
+
+CREATE TABLE IF NOT EXISTS pick_locations (
+    warehouse_id UUID NOT NULL REFERENCES warehouses(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    location VARCHAR(50) NOT NULL,
+    updated_by UUID NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (warehouse_id, product_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_pick_waves_warehouse_id ON pick_waves(warehouse_id);
+CREATE INDEX IF NOT EXISTS idx_pick_waves_open ON pick_waves(created_at) WHERE status IN ('RELEASED', 'PICKING');
+CREATE INDEX IF NOT EXISTS idx_pick_wave_orders_order_id ON pick_wave_orders(order_id);
+CREATE INDEX IF NOT EXISTS idx_pick_lines_wave_id ON pick_lines(wave_id);
+CREATE INDEX IF NOT EXISTS idx_pick_lines_order_item_id ON pick_lines(order_item_id);
+CREATE INDEX IF NOT EXISTS idx_pick_locations_location ON pick_locations(warehouse_id, location);
+
+-- Number pick waves from their own document sequence
+INSERT INTO document_sequences (document_type, prefix, pattern, padding, reset_period) VALUES
+    ('PICK_WAVE', 'WAVE', '{PREFIX}-{YYYY}{MM}{DD}-{SEQ}', 4, 'DAILY')
+ON CONFLICT (document_type) DO NOTHING;
+
+COMMENT ON TABLE pick_waves IS 'Groups of PROCESSING orders of one warehouse picked together, numbered from the PICK_WAVE document sequence.';
+COMMENT ON TABLE pick_wave_orders IS 'Orders of a pick wave and their progress from picking to the shipment they were packed into.';
+COMMENT ON TABLE pick_lines IS 'Order quantities to pick in a wave, with the picked and short quantities and how shortfalls were covered.';
+COMMENT ON TABLE pick_locations IS 'Shelf location each product is picked from in a warehouse; pick lists are walked in location order.';
@@ -0,0 +1,4 @@
+-- Drop sourcing tables
+
+DROP TABLE IF EXISTS order_allocations;
+DROP TABLE IF EXISTS sourcing_rules;
@@ -0,0 +1,41 @@
+-- Create sourcing tables
+-- Sourcing rules choose how the warehouses fulfilling an order are picked
+-- when several hold stock: the active rule of highest priority matching the
+-- order's shipping method and destination country lists the strategies to
+-- apply in order. Order allocations record the warehouse and quantity each
+-- order line is reserved from; they are rewritten when the order is sourced
+-- again and drawn down as the order ships.
+
+CREATE TABLE IF NOT EXISTS sourcing_rules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    countries TEXT[] NOT NULL DEFAULT '{}',
+    shipping_methods TEXT[] NOT NULL DEFAULT '{}',
+    strategies TEXT[] NOT NULL CHECK (cardinality(strategies) > 0),
+    priority INTEGER NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS order_allocations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    order_item_id UUID NOT NULL REFERENCES order_items(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE RESTRICT,
+    warehouse_id UUID NOT NULL REFERENCES warehouses(id) ON DELETE RESTRICT,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    rule_id UUID REFERENCES sourcing_rules(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_order_allocations_item_warehouse UNIQUE (order_item_id, warehouse_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_sourcing_rules_active ON sourcing_rules(priority DESC) WHERE is_active;
+CREATE INDEX IF NOT EXISTS idx_order_allocations_order_id ON order_allocations(order_id);
+CREATE INDEX IF NOT EXISTS idx_order_allocations_warehouse_id ON order_allocations(warehouse_id, product_id);
+
+COMMENT ON TABLE sourcing_rules IS 'Strategies choosing fulfilling warehouses for orders matching a shipping method and destination country; highest priority wins.';
+COMMENT ON TABLE order_allocations IS 'Warehouse and quantity each order line is reserved from, as planned by the sourcing engine.';
@@ -0,0 +1,3 @@
+-- Drop order revisions table
+
+DROP TABLE IF EXISTS order_revisions;
@@ -0,0 +1,33 @@
+-- Create order revisions table
+-- Confirmed orders are changed through amendments rather than edited in
+-- place. Each amendment adds the order's next numbered revision holding the
+-- requested change, a snapshot of the order's lines, addresses and totals
+-- after it, and the differences from the revision before. Revision 1 is the
+-- order as it stood before its first amendment. Amendments needing approval
+-- wait as PENDING_APPROVAL revisions until every approver role has approved.
+
+CREATE TABLE IF NOT EXISTS order_revisions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    revision_number INTEGER NOT NULL CHECK (revision_number > 0),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('APPLIED', 'PENDING_APPROVAL', 'REJECTED')),
+    reason TEXT,
+    amendment JSONB,
+    snapshot JSONB NOT NULL,
+    changes JSONB NOT NULL DEFAULT '[]',
+    approver_roles TEXT[] NOT NULL DEFAULT '{}',
+    approval_amount DECIMAL(15,2) NOT NULL DEFAULT 0,
+    approvals JSONB NOT NULL DEFAULT '[]',
+    created_by UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    applied_at TIMESTAMP WITH TIME ZONE,
+    rejected_by UUID,
+    rejected_at TIMESTAMP WITH TIME ZONE,
+    rejection_reason TEXT,
+    CONSTRAINT uq_order_revisions_number UNIQUE (order_id, revision_number)
+);
+
+-- At most one amendment of an order waits for approval
+CREATE UNIQUE INDEX IF NOT EXISTS idx_order_revisions_pending ON order_revisions(order_id) WHERE status = 'PENDING_APPROVAL';
+
+COMMENT ON TABLE order_revisions IS 'Numbered versions of confirmed orders with the amendment, resulting snapshot and changes of each.';
@@ -0,0 +1,66 @@
+-- Drop order archive tables
+-- Archived orders must be restored first; their documents would otherwise
+-- lose the orders their foreign keys point to.
+
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM orders_archive) THEN
+        RAISE EXCEPTION 'restore archived orders before dropping the order archive';
+    END IF;
+END;
+$$;
+
+CREATE OR REPLACE FUNCTION update_order_totals_on_item_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    -- Update order subtotal, tax, and total amounts based on items
+    UPDATE orders
+    SET
+        subtotal = (
+            SELECT COALESCE(SUM(unit_price * quantity - discount_amount), 0)
+            FROM order_items
+            WHERE order_id = NEW.order_id
+        ),
+        tax_amount = (
+            SELECT COALESCE(SUM(tax_amount), 0)
+            FROM order_items
+            WHERE order_id = NEW.order_id
+        ),
+        updated_at = NOW()
+    WHERE id = NEW.order_id;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP RULE IF EXISTS order_status_history_no_delete ON order_status_history;
+CREATE RULE order_status_history_no_delete AS
+    ON DELETE TO order_status_history
+    DO INSTEAD NOTHING;
+
+ALTER TABLE order_addresses ALTER CONSTRAINT fk_order_addresses_order_id NOT DEFERRABLE;
+
+ALTER TABLE quotations ADD CONSTRAINT quotations_converted_order_id_fkey FOREIGN KEY (converted_order_id) REFERENCES orders(id) ON DELETE SET NULL;
+ALTER TABLE return_authorizations ADD CONSTRAINT return_authorizations_order_id_fkey FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE RESTRICT;
+ALTER TABLE return_authorizations ADD CONSTRAINT return_authorizations_replacement_order_id_fkey FOREIGN KEY (replacement_order_id) REFERENCES orders(id) ON DELETE SET NULL;
+ALTER TABLE return_authorization_items ADD CONSTRAINT return_authorization_items_order_item_id_fkey FOREIGN KEY (order_item_id) REFERENCES order_items(id) ON DELETE RESTRICT;
+ALTER TABLE shipments ADD CONSTRAINT shipments_order_id_fkey FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE;
+ALTER TABLE shipment_items ADD CONSTRAINT shipment_items_order_item_id_fkey FOREIGN KEY (order_item_id) REFERENCES order_items(id) ON DELETE RESTRICT;
+ALTER TABLE backorders ADD CONSTRAINT backorders_order_id_fkey FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE;
+ALTER TABLE backorders ADD CONSTRAINT backorders_order_item_id_fkey FOREIGN KEY (order_item_id) REFERENCES order_items(id) ON DELETE CASCADE;
+ALTER TABLE payment_allocations ADD CONSTRAINT payment_allocations_order_id_fkey FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE RESTRICT;
+ALTER TABLE invoices ADD CONSTRAINT invoices_order_id_fkey FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE RESTRICT;
+ALTER TABLE invoice_lines ADD CONSTRAINT invoice_lines_order_item_id_fkey FOREIGN KEY (order_item_id) REFERENCES order_items(id) ON DELETE RESTRICT;
+ALTER TABLE promotion_redemptions ADD CONSTRAINT promotion_redemptions_order_id_fkey FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE;
+ALTER TABLE order_approvals ADD CONSTRAINT order_approvals_order_id_fkey FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE;
+ALTER TABLE recurring_order_runs ADD CONSTRAINT recurring_order_runs_order_id_fkey FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE SET NULL;
+ALTER TABLE pick_wave_orders ADD CONSTRAINT pick_wave_orders_order_id_fkey FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE RESTRICT;
+ALTER TABLE pick_lines ADD CONSTRAINT pick_lines_order_id_fkey FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE RESTRICT;
+ALTER TABLE pick_lines ADD CONSTRAINT pick_lines_order_item_id_fkey FOREIGN KEY (order_item_id) REFERENCES order_items(id) ON DELETE RESTRICT;
+ALTER TABLE order_revisions ADD CONSTRAINT order_revisions_order_id_fkey FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE;
+
+DROP FUNCTION IF EXISTS create_order_archive_partitions(INTEGER);
+DROP TABLE IF EXISTS order_status_history_archive;
+DROP TABLE IF EXISTS order_addresses_archive;
+DROP TABLE IF EXISTS order_items_archive;
+DROP TABLE IF EXISTS orders_archive;
@@ -0,0 +1,124 @@
+-- Create order archive tables
+-- Closed, settled orders move out of the live order tables once they are old
+-- enough: the order, its items, its addresses and its status history are
+-- copied into archive tables partitioned by the year of the order date and
+-- removed from the live tables in one transaction. Archived orders are still
+-- read by ID or number and can be restored. Partitions are created on demand
+-- by create_order_archive_partitions.
+--
+-- Rows are copied between the live and archive tables by column name, so a
+-- column added to orders, order_items, order_addresses or
+-- order_status_history must be added to its archive table as well.
+
+CREATE TABLE IF NOT EXISTS orders_archive (
+    LIKE orders INCLUDING DEFAULTS,
+    order_year INTEGER NOT NULL,
+    archived_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (id, order_year)
+) PARTITION BY LIST (order_year);
+
+CREATE TABLE IF NOT EXISTS order_items_archive (
+    LIKE order_items INCLUDING DEFAULTS,
+    order_year INTEGER NOT NULL,
+    PRIMARY KEY (id, order_year)
+) PARTITION BY LIST (order_year);
+
+-- Addresses are kept per archived order: the shipping and billing addresses
+-- may be shared with the customer's address book, which keeps its own copy
+CREATE TABLE IF NOT EXISTS order_addresses_archive (
+    LIKE order_addresses INCLUDING DEFAULTS,
+    archived_order_id UUID NOT NULL,
+    order_year INTEGER NOT NULL,
+    PRIMARY KEY (archived_order_id, id, order_year)
+) PARTITION BY LIST (order_year);
+
+CREATE TABLE IF NOT EXISTS order_status_history_archive (
+    LIKE order_status_history INCLUDING DEFAULTS,
+    order_year INTEGER NOT NULL,
+    PRIMARY KEY (id, order_year)
+) PARTITION BY LIST (order_year);
+
+CREATE INDEX IF NOT EXISTS idx_orders_archive_order_number ON orders_archive(order_number);
+CREATE INDEX IF NOT EXISTS idx_orders_archive_customer_id ON orders_archive(customer_id, order_date);
+CREATE INDEX IF NOT EXISTS idx_order_items_archive_order_id ON order_items_archive(order_id);
+CREATE INDEX IF NOT EXISTS idx_order_status_history_archive_order_id ON order_status_history_archive(order_id, created_at);
+
+-- create_order_archive_partitions creates the partitions of every archive
+-- table for a year when they are missing
+CREATE OR REPLACE FUNCTION create_order_archive_partitions(p_year INTEGER)
+RETURNS VOID AS $$
+DECLARE
+    archive_table TEXT;
+BEGIN
+    FOREACH archive_table IN ARRAY ARRAY['orders_archive', 'order_items_archive', 'order_addresses_archive', 'order_status_history_archive'] LOOP
+        EXECUTE format('CREATE TABLE IF NOT EXISTS %I PARTITION OF %I FOR VALUES IN (%s)',
+            archive_table || '_' || p_year, archive_table, p_year);
+    END LOOP;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Documents of an order outlive it in the live tables once it is archived,
+-- so they keep its ID without a foreign key, like order_status_history
+ALTER TABLE quotations DROP CONSTRAINT IF EXISTS quotations_converted_order_id_fkey;
+ALTER TABLE return_authorizations DROP CONSTRAINT IF EXISTS return_authorizations_order_id_fkey;
+ALTER TABLE return_authorizations DROP CONSTRAINT IF EXISTS return_authorizations_replacement_order_id_fkey;
+ALTER TABLE return_authorization_items DROP CONSTRAINT IF EXISTS return_authorization_items_order_item_id_fkey;
+ALTER TABLE shipments DROP CONSTRAINT IF EXISTS shipments_order_id_fkey;
+ALTER TABLE shipment_items DROP CONSTRAINT IF EXISTS shipment_items_order_item_id_fkey;
+ALTER TABLE backorders DROP CONSTRAINT IF EXISTS backorders_order_id_fkey;
+ALTER TABLE backorders DROP CONSTRAINT IF EXISTS backorders_order_item_id_fkey;
+ALTER TABLE payment_allocations DROP CONSTRAINT IF EXISTS payment_allocations_order_id_fkey;
+ALTER TABLE invoices DROP CONSTRAINT IF EXISTS invoices_order_id_fkey;
+ALTER TABLE invoice_lines DROP CONSTRAINT IF EXISTS invoice_lines_order_item_id_fkey;
+ALTER TABLE promotion_redemptions DROP CONSTRAINT IF EXISTS promotion_redemptions_order_id_fkey;
+ALTER TABLE order_approvals DROP CONSTRAINT IF EXISTS order_approvals_order_id_fkey;
+ALTER TABLE recurring_order_runs DROP CONSTRAINT IF EXISTS recurring_order_runs_order_id_fkey;
+ALTER TABLE pick_wave_orders DROP CONSTRAINT IF EXISTS pick_wave_orders_order_id_fkey;
+ALTER TABLE pick_lines DROP CONSTRAINT IF EXISTS pick_lines_order_id_fkey;
+ALTER TABLE pick_lines DROP CONSTRAINT IF EXISTS pick_lines_order_item_id_fkey;
+ALTER TABLE order_revisions DROP CONSTRAINT IF EXISTS order_revisions_order_id_fkey;
+
+-- An order and the addresses it owns reference each other; restoring an
+-- archived order defers the check until both are back
+ALTER TABLE order_addresses ALTER CONSTRAINT fk_order_addresses_order_id DEFERRABLE INITIALLY IMMEDIATE;
+
+-- Status history stays append-only, except that entries already copied to
+-- the archive can be removed from the live table
+DROP RULE IF EXISTS order_status_history_no_delete ON order_status_history;
+CREATE RULE order_status_history_no_delete AS
+    ON DELETE TO order_status_history
+    WHERE NOT EXISTS (SELECT 1 FROM order_status_history_archive a WHERE a.id = OLD.id)
+    DO INSTEAD NOTHING;
+
+-- Restored items already carry the totals of their order
+CREATE OR REPLACE FUNCTION update_order_totals_on_item_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF current_setting('erpgo.restoring_orders', true) = 'on' THEN
+        RETURN NEW;
+    END IF;
+
+    -- Update order subtotal, tax, and total amounts based on items
+    UPDATE orders
+    SET
+        subtotal = (
+            SELECT COALESCE(SUM(unit_price * quantity - discount_amount), 0)
+            FROM order_items
+            WHERE order_id = NEW.order_id
+        ),
+        tax_amount = (
+            SELECT COALESCE(SUM(tax_amount), 0)
+            FROM order_items
+            WHERE order_id = NEW.order_id
+        ),
+        updated_at = NOW()
+    WHERE id = NEW.order_id;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+COMMENT ON TABLE orders_archive IS 'Closed orders moved out of orders, partitioned by the year of the order date.';
+COMMENT ON TABLE order_items_archive IS 'Items of archived orders, partitioned like orders_archive.';
+COMMENT ON TABLE order_addresses_archive IS 'Addresses of archived orders as they stood when archived, partitioned like orders_archive.';
+COMMENT ON TABLE order_status_history_archive IS 'Status history of archived orders, partitioned like orders_archive.';
@@ -0,0 +1,17 @@
+-- Drop transfer order and transfer receipt tables
+
+DROP VIEW IF EXISTS inventory_in_transit;
+
+DROP INDEX IF EXISTS idx_transfer_receipt_items_transfer_receipt_id;
+DROP INDEX IF EXISTS idx_transfer_receipts_transfer_order_id;
+DROP INDEX IF EXISTS idx_transfer_order_items_product_id;
+DROP INDEX IF EXISTS idx_transfer_order_items_transfer_order_id;
+DROP INDEX IF EXISTS idx_transfer_orders_created_at;
+DROP INDEX IF EXISTS idx_transfer_orders_status;
+DROP INDEX IF EXISTS idx_transfer_orders_to_warehouse_id;
+DROP INDEX IF EXISTS idx_transfer_orders_from_warehouse_id;
+
+DROP TABLE IF EXISTS transfer_receipt_items;
+DROP TABLE IF EXISTS transfer_receipts;
+DROP TABLE IF EXISTS transfer_order_items;
+DROP TABLE IF EXISTS transfer_orders;
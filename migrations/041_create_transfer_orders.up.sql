@@ -0,0 +1,105 @@
+-- Create transfer order and transfer receipt tables
+-- Transfer orders move stock between warehouses in two steps: shipping takes
+-- the stock out of the source warehouse and receiving puts it into the
+-- destination warehouse. In between, the shipped quantity is in transit.
+
+CREATE TABLE IF NOT EXISTS transfer_orders (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    transfer_number VARCHAR(50) NOT NULL UNIQUE,
+    from_warehouse_id UUID NOT NULL REFERENCES warehouses(id) ON DELETE RESTRICT,
+    to_warehouse_id UUID NOT NULL REFERENCES warehouses(id) ON DELETE RESTRICT,
+    status VARCHAR(20) NOT NULL DEFAULT 'DRAFT' CHECK (status IN ('DRAFT', 'IN_TRANSIT', 'PARTIALLY_RECEIVED', 'RECEIVED', 'CANCELLED')),
+
+    expected_date TIMESTAMP WITH TIME ZONE,
+    carrier VARCHAR(100),
+    tracking_number VARCHAR(100),
+
+    notes TEXT,
+    cancellation_reason TEXT,
+
+    created_by UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    shipped_by UUID,
+    shipped_at TIMESTAMP WITH TIME ZONE,
+    received_at TIMESTAMP WITH TIME ZONE,
+    cancelled_at TIMESTAMP WITH TIME ZONE,
+
+    CONSTRAINT chk_transfer_orders_warehouses CHECK (from_warehouse_id <> to_warehouse_id)
+);
+
+CREATE TABLE IF NOT EXISTS transfer_order_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    transfer_order_id UUID NOT NULL REFERENCES transfer_orders(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE RESTRICT,
+    product_sku VARCHAR(100) NOT NULL,
+    product_name VARCHAR(300) NOT NULL,
+    quantity_requested INTEGER NOT NULL CHECK (quantity_requested > 0),
+    quantity_shipped INTEGER NOT NULL DEFAULT 0 CHECK (quantity_shipped >= 0 AND quantity_shipped <= quantity_requested),
+    quantity_received INTEGER NOT NULL DEFAULT 0 CHECK (quantity_received >= 0),
+    quantity_damaged INTEGER NOT NULL DEFAULT 0 CHECK (quantity_damaged >= 0),
+    quantity_short INTEGER NOT NULL DEFAULT 0 CHECK (quantity_short >= 0),
+    unit_cost DECIMAL(12,2) NOT NULL DEFAULT 0 CHECK (unit_cost >= 0),
+    notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_transfer_order_items_product UNIQUE (transfer_order_id, product_id),
+    CONSTRAINT chk_transfer_order_items_accounted CHECK (quantity_received + quantity_damaged + quantity_short <= quantity_shipped)
+);
+
+CREATE TABLE IF NOT EXISTS transfer_receipts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    transfer_order_id UUID NOT NULL REFERENCES transfer_orders(id) ON DELETE RESTRICT,
+    warehouse_id UUID NOT NULL REFERENCES warehouses(id) ON DELETE RESTRICT,
+    notes TEXT,
+    received_by UUID NOT NULL,
+    received_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS transfer_receipt_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    transfer_receipt_id UUID NOT NULL REFERENCES transfer_receipts(id) ON DELETE CASCADE,
+    transfer_order_item_id UUID NOT NULL REFERENCES transfer_order_items(id) ON DELETE RESTRICT,
+    product_id UUID NOT NULL,
+    quantity_received INTEGER NOT NULL DEFAULT 0 CHECK (quantity_received >= 0),
+    quantity_damaged INTEGER NOT NULL DEFAULT 0 CHECK (quantity_damaged >= 0),
+    quantity_short INTEGER NOT NULL DEFAULT 0 CHECK (quantity_short >= 0),
+    discrepancy_reason TEXT,
+    inventory_transaction_id UUID,
+
+    CONSTRAINT chk_transfer_receipt_items_quantity CHECK (quantity_received + quantity_damaged + quantity_short > 0),
+    CONSTRAINT chk_transfer_receipt_items_reason CHECK (quantity_damaged + quantity_short = 0 OR discrepancy_reason IS NOT NULL)
+);
+
+CREATE INDEX IF NOT EXISTS idx_transfer_orders_from_warehouse_id ON transfer_orders(from_warehouse_id);
+CREATE INDEX IF NOT EXISTS idx_transfer_orders_to_warehouse_id ON transfer_orders(to_warehouse_id);
+CREATE INDEX IF NOT EXISTS idx_transfer_orders_status ON transfer_orders(status);
+CREATE INDEX IF NOT EXISTS idx_transfer_orders_created_at ON transfer_orders(created_at);
+CREATE INDEX IF NOT EXISTS idx_transfer_order_items_transfer_order_id ON transfer_order_items(transfer_order_id);
+CREATE INDEX IF NOT EXISTS idx_transfer_order_items_product_id ON transfer_order_items(product_id);
+CREATE INDEX IF NOT EXISTS idx_transfer_receipts_transfer_order_id ON transfer_receipts(transfer_order_id);
+CREATE INDEX IF NOT EXISTS idx_transfer_receipt_items_transfer_receipt_id ON transfer_receipt_items(transfer_receipt_id);
+
+-- Stock shipped on open transfer orders and not yet accounted for at the destination
+CREATE OR REPLACE VIEW inventory_in_transit AS
+SELECT
+    toi.product_id,
+    toi.product_sku,
+    toi.product_name,
+    t.from_warehouse_id,
+    t.to_warehouse_id,
+    SUM(toi.quantity_shipped - toi.quantity_received - toi.quantity_damaged - toi.quantity_short) as quantity,
+    SUM((toi.quantity_shipped - toi.quantity_received - toi.quantity_damaged - toi.quantity_short) * toi.unit_cost) as total_value
+FROM transfer_order_items toi
+JOIN transfer_orders t ON t.id = toi.transfer_order_id
+WHERE t.status IN ('IN_TRANSIT', 'PARTIALLY_RECEIVED')
+  AND toi.quantity_shipped > toi.quantity_received + toi.quantity_damaged + toi.quantity_short
+GROUP BY toi.product_id, toi.product_sku, toi.product_name, t.from_warehouse_id, t.to_warehouse_id;
+
+COMMENT ON TABLE transfer_orders IS 'Transfers of stock from one warehouse to another, shipped and received as separate steps.';
+COMMENT ON TABLE transfer_order_items IS 'Product lines of a transfer order with shipped, received, damaged and short quantities.';
+COMMENT ON TABLE transfer_receipts IS 'Receipts of transferred stock at the destination warehouse.';
+COMMENT ON TABLE transfer_receipt_items IS 'Received, damaged and short quantities per transfer order line, linked to the TRANSFER_IN inventory transaction.';
+COMMENT ON VIEW inventory_in_transit IS 'Quantity and value in transit per product and warehouse pair.';
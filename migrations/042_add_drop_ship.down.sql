@@ -0,0 +1,27 @@
+-- Remove drop-ship fulfilment
+-- Drop-ship purchase orders have no warehouse to fall back to, so they must
+-- be removed before warehouse_id can be required again.
+
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM purchase_orders WHERE sales_order_id IS NOT NULL) THEN
+        RAISE EXCEPTION 'remove drop-ship purchase orders before removing drop-ship fulfilment';
+    END IF;
+END;
+$$;
+
+ALTER TABLE purchase_order_items DROP COLUMN IF EXISTS sales_order_item_id;
+
+DROP INDEX IF EXISTS idx_purchase_orders_sales_order_id;
+ALTER TABLE purchase_orders DROP CONSTRAINT IF EXISTS chk_purchase_orders_destination;
+ALTER TABLE purchase_orders DROP COLUMN IF EXISTS ship_to;
+ALTER TABLE purchase_orders DROP COLUMN IF EXISTS sales_order_id;
+ALTER TABLE purchase_orders ALTER COLUMN warehouse_id SET NOT NULL;
+
+ALTER TABLE order_items_archive DROP COLUMN IF EXISTS is_drop_ship;
+ALTER TABLE order_items DROP COLUMN IF EXISTS is_drop_ship;
+
+DROP INDEX IF EXISTS idx_products_supplier_id;
+ALTER TABLE products DROP CONSTRAINT IF EXISTS chk_products_drop_ship;
+ALTER TABLE products DROP COLUMN IF EXISTS supplier_id;
+ALTER TABLE products DROP COLUMN IF EXISTS is_drop_ship;
@@ -0,0 +1,35 @@
+-- Add drop-ship fulfilment
+-- Drop-ship products are never stocked: confirming a sales order places a
+-- purchase order with the product's supplier, who ships the lines straight
+-- to the customer's shipping address. Supplier shipment confirmations mark
+-- the sales order lines shipped without moving warehouse stock.
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS is_drop_ship BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS supplier_id UUID REFERENCES suppliers(id) ON DELETE SET NULL;
+ALTER TABLE products ADD CONSTRAINT chk_products_drop_ship
+    CHECK (NOT is_drop_ship OR (supplier_id IS NOT NULL AND NOT track_inventory AND NOT is_digital));
+
+CREATE INDEX IF NOT EXISTS idx_products_supplier_id ON products(supplier_id) WHERE supplier_id IS NOT NULL;
+
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS is_drop_ship BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE order_items_archive ADD COLUMN IF NOT EXISTS is_drop_ship BOOLEAN NOT NULL DEFAULT false;
+
+-- Drop-ship purchase orders have no warehouse. Like the other documents of
+-- an order they keep the sales order's ID without a foreign key, so the
+-- order can be archived.
+ALTER TABLE purchase_orders ALTER COLUMN warehouse_id DROP NOT NULL;
+ALTER TABLE purchase_orders ADD COLUMN IF NOT EXISTS sales_order_id UUID;
+ALTER TABLE purchase_orders ADD COLUMN IF NOT EXISTS ship_to JSONB;
+ALTER TABLE purchase_orders ADD CONSTRAINT chk_purchase_orders_destination
+    CHECK ((warehouse_id IS NOT NULL AND sales_order_id IS NULL) OR (warehouse_id IS NULL AND sales_order_id IS NOT NULL AND ship_to IS NOT NULL));
+
+CREATE INDEX IF NOT EXISTS idx_purchase_orders_sales_order_id ON purchase_orders(sales_order_id) WHERE sales_order_id IS NOT NULL;
+
+ALTER TABLE purchase_order_items ADD COLUMN IF NOT EXISTS sales_order_item_id UUID;
+
+COMMENT ON COLUMN products.is_drop_ship IS 'Shipped by the supplier straight to the customer and never stocked.';
+COMMENT ON COLUMN products.supplier_id IS 'Supplier drop-ship lines of the product are ordered from.';
+COMMENT ON COLUMN order_items.is_drop_ship IS 'Line fulfilled by a drop-ship purchase order rather than from a warehouse.';
+COMMENT ON COLUMN purchase_orders.sales_order_id IS 'Sales order a drop-ship purchase order is shipped for.';
+COMMENT ON COLUMN purchase_orders.ship_to IS 'Customer address a drop-ship purchase order is shipped to.';
+COMMENT ON COLUMN purchase_order_items.sales_order_item_id IS 'Sales order line a drop-ship purchase order line fulfils.';
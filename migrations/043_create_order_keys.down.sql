@@ -0,0 +1,38 @@
+-- Drop order keys
+-- The documents of an order keep its ID without a foreign key again, as
+-- after migration 040.
+
+ALTER TABLE purchase_order_items DROP CONSTRAINT IF EXISTS purchase_order_items_sales_order_item_id_fkey;
+ALTER TABLE purchase_orders DROP CONSTRAINT IF EXISTS purchase_orders_sales_order_id_fkey;
+
+ALTER TABLE quotations DROP CONSTRAINT IF EXISTS quotations_converted_order_id_fkey;
+ALTER TABLE return_authorizations DROP CONSTRAINT IF EXISTS return_authorizations_order_id_fkey;
+ALTER TABLE return_authorizations DROP CONSTRAINT IF EXISTS return_authorizations_replacement_order_id_fkey;
+ALTER TABLE return_authorization_items DROP CONSTRAINT IF EXISTS return_authorization_items_order_item_id_fkey;
+ALTER TABLE shipments DROP CONSTRAINT IF EXISTS shipments_order_id_fkey;
+ALTER TABLE shipment_items DROP CONSTRAINT IF EXISTS shipment_items_order_item_id_fkey;
+ALTER TABLE backorders DROP CONSTRAINT IF EXISTS backorders_order_id_fkey;
+ALTER TABLE backorders DROP CONSTRAINT IF EXISTS backorders_order_item_id_fkey;
+ALTER TABLE payment_allocations DROP CONSTRAINT IF EXISTS payment_allocations_order_id_fkey;
+ALTER TABLE invoices DROP CONSTRAINT IF EXISTS invoices_order_id_fkey;
+ALTER TABLE invoice_lines DROP CONSTRAINT IF EXISTS invoice_lines_order_item_id_fkey;
+ALTER TABLE promotion_redemptions DROP CONSTRAINT IF EXISTS promotion_redemptions_order_id_fkey;
+ALTER TABLE order_approvals DROP CONSTRAINT IF EXISTS order_approvals_order_id_fkey;
+ALTER TABLE recurring_order_runs DROP CONSTRAINT IF EXISTS recurring_order_runs_order_id_fkey;
+ALTER TABLE pick_wave_orders DROP CONSTRAINT IF EXISTS pick_wave_orders_order_id_fkey;
+ALTER TABLE pick_lines DROP CONSTRAINT IF EXISTS pick_lines_order_id_fkey;
+ALTER TABLE pick_lines DROP CONSTRAINT IF EXISTS pick_lines_order_item_id_fkey;
+ALTER TABLE order_revisions DROP CONSTRAINT IF EXISTS order_revisions_order_id_fkey;
+
+DROP TRIGGER IF EXISTS trigger_order_items_archive_remove_key ON order_items_archive;
+DROP TRIGGER IF EXISTS trigger_order_items_remove_key ON order_items;
+DROP TRIGGER IF EXISTS trigger_order_items_add_key ON order_items;
+DROP TRIGGER IF EXISTS trigger_orders_archive_remove_key ON orders_archive;
+DROP TRIGGER IF EXISTS trigger_orders_remove_key ON orders;
+DROP TRIGGER IF EXISTS trigger_orders_add_key ON orders;
+
+DROP FUNCTION IF EXISTS remove_order_key();
+DROP FUNCTION IF EXISTS add_order_key();
+
+DROP TABLE IF EXISTS order_item_keys;
+DROP TABLE IF EXISTS order_keys;
@@ -0,0 +1,106 @@
+-- Create order keys
+-- Archiving moves an order out of the orders table while its invoices,
+-- payments, shipments and other documents stay live, so those documents
+-- cannot reference orders directly. They reference order_keys instead: it
+-- holds the ID of every order, live or archived, and order_item_keys the ID
+-- of every order item. A key is added when its row is first inserted and
+-- removed only when the row leaves both the live and the archive table, so
+-- archiving and restoring keep it while deleting an order applies the
+-- documents' ON DELETE rules as their foreign keys to orders did: orders with
+-- payments, invoices, returns or pick lines cannot be deleted.
+
+CREATE TABLE IF NOT EXISTS order_keys (
+    id UUID PRIMARY KEY
+);
+
+CREATE TABLE IF NOT EXISTS order_item_keys (
+    id UUID PRIMARY KEY
+);
+
+INSERT INTO order_keys (id)
+SELECT id FROM orders
+UNION
+SELECT id FROM orders_archive
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO order_item_keys (id)
+SELECT id FROM order_items
+UNION
+SELECT id FROM order_items_archive
+ON CONFLICT (id) DO NOTHING;
+
+-- add_order_key records the key of a row inserted into a live or archive
+-- table; the key table is the trigger's argument
+CREATE OR REPLACE FUNCTION add_order_key()
+RETURNS TRIGGER AS $$
+BEGIN
+    EXECUTE format('INSERT INTO %I (id) VALUES ($1) ON CONFLICT (id) DO NOTHING', TG_ARGV[0])
+    USING NEW.id;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- remove_order_key removes the key of a deleted row unless the row lives on
+-- in its other table; the arguments are the key table and the other table
+CREATE OR REPLACE FUNCTION remove_order_key()
+RETURNS TRIGGER AS $$
+DECLARE
+    kept BOOLEAN;
+BEGIN
+    EXECUTE format('SELECT EXISTS (SELECT 1 FROM %I WHERE id = $1)', TG_ARGV[1])
+    INTO kept
+    USING OLD.id;
+
+    IF NOT kept THEN
+        EXECUTE format('DELETE FROM %I WHERE id = $1', TG_ARGV[0]) USING OLD.id;
+    END IF;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_orders_add_key
+    BEFORE INSERT ON orders
+    FOR EACH ROW EXECUTE FUNCTION add_order_key('order_keys');
+CREATE TRIGGER trigger_orders_remove_key
+    AFTER DELETE ON orders
+    FOR EACH ROW EXECUTE FUNCTION remove_order_key('order_keys', 'orders_archive');
+CREATE TRIGGER trigger_orders_archive_remove_key
+    AFTER DELETE ON orders_archive
+    FOR EACH ROW EXECUTE FUNCTION remove_order_key('order_keys', 'orders');
+
+CREATE TRIGGER trigger_order_items_add_key
+    BEFORE INSERT ON order_items
+    FOR EACH ROW EXECUTE FUNCTION add_order_key('order_item_keys');
+CREATE TRIGGER trigger_order_items_remove_key
+    AFTER DELETE ON order_items
+    FOR EACH ROW EXECUTE FUNCTION remove_order_key('order_item_keys', 'order_items_archive');
+CREATE TRIGGER trigger_order_items_archive_remove_key
+    AFTER DELETE ON order_items_archive
+    FOR EACH ROW EXECUTE FUNCTION remove_order_key('order_item_keys', 'order_items');
+
+-- The foreign keys migration 040 dropped, with their original delete rules
+ALTER TABLE quotations ADD CONSTRAINT quotations_converted_order_id_fkey FOREIGN KEY (converted_order_id) REFERENCES order_keys(id) ON DELETE SET NULL;
+ALTER TABLE return_authorizations ADD CONSTRAINT return_authorizations_order_id_fkey FOREIGN KEY (order_id) REFERENCES order_keys(id) ON DELETE RESTRICT;
+ALTER TABLE return_authorizations ADD CONSTRAINT return_authorizations_replacement_order_id_fkey FOREIGN KEY (replacement_order_id) REFERENCES order_keys(id) ON DELETE SET NULL;
+ALTER TABLE return_authorization_items ADD CONSTRAINT return_authorization_items_order_item_id_fkey FOREIGN KEY (order_item_id) REFERENCES order_item_keys(id) ON DELETE RESTRICT;
+ALTER TABLE shipments ADD CONSTRAINT shipments_order_id_fkey FOREIGN KEY (order_id) REFERENCES order_keys(id) ON DELETE CASCADE;
+ALTER TABLE shipment_items ADD CONSTRAINT shipment_items_order_item_id_fkey FOREIGN KEY (order_item_id) REFERENCES order_item_keys(id) ON DELETE RESTRICT;
+ALTER TABLE backorders ADD CONSTRAINT backorders_order_id_fkey FOREIGN KEY (order_id) REFERENCES order_keys(id) ON DELETE CASCADE;
+ALTER TABLE backorders ADD CONSTRAINT backorders_order_item_id_fkey FOREIGN KEY (order_item_id) REFERENCES order_item_keys(id) ON DELETE CASCADE;
+ALTER TABLE payment_allocations ADD CONSTRAINT payment_allocations_order_id_fkey FOREIGN KEY (order_id) REFERENCES order_keys(id) ON DELETE RESTRICT;
+ALTER TABLE invoices ADD CONSTRAINT invoices_order_id_fkey FOREIGN KEY (order_id) REFERENCES order_keys(id) ON DELETE RESTRICT;
+ALTER TABLE invoice_lines ADD CONSTRAINT invoice_lines_order_item_id_fkey FOREIGN KEY (order_item_id) REFERENCES order_item_keys(id) ON DELETE RESTRICT;
+ALTER TABLE promotion_redemptions ADD CONSTRAINT promotion_redemptions_order_id_fkey FOREIGN KEY (order_id) REFERENCES order_keys(id) ON DELETE CASCADE;
+ALTER TABLE order_approvals ADD CONSTRAINT order_approvals_order_id_fkey FOREIGN KEY (order_id) REFERENCES order_keys(id) ON DELETE CASCADE;
+ALTER TABLE recurring_order_runs ADD CONSTRAINT recurring_order_runs_order_id_fkey FOREIGN KEY (order_id) REFERENCES order_keys(id) ON DELETE SET NULL;
+ALTER TABLE pick_wave_orders ADD CONSTRAINT pick_wave_orders_order_id_fkey FOREIGN KEY (order_id) REFERENCES order_keys(id) ON DELETE RESTRICT;
+ALTER TABLE pick_lines ADD CONSTRAINT pick_lines_order_id_fkey FOREIGN KEY (order_id) REFERENCES order_keys(id) ON DELETE RESTRICT;
+ALTER TABLE pick_lines ADD CONSTRAINT pick_lines_order_item_id_fkey FOREIGN KEY (order_item_id) REFERENCES order_item_keys(id) ON DELETE RESTRICT;
+ALTER TABLE order_revisions ADD CONSTRAINT order_revisions_order_id_fkey FOREIGN KEY (order_id) REFERENCES order_keys(id) ON DELETE CASCADE;
+
+-- Drop-ship purchase orders and their lines name the sales order they ship
+ALTER TABLE purchase_orders ADD CONSTRAINT purchase_orders_sales_order_id_fkey FOREIGN KEY (sales_order_id) REFERENCES order_keys(id) ON DELETE RESTRICT;
+ALTER TABLE purchase_order_items ADD CONSTRAINT purchase_order_items_sales_order_item_id_fkey FOREIGN KEY (sales_order_item_id) REFERENCES order_item_keys(id) ON DELETE RESTRICT;
+
+COMMENT ON TABLE order_keys IS 'IDs of live and archived orders, referenced by the documents of an order.';
+COMMENT ON TABLE order_item_keys IS 'IDs of live and archived order items, referenced by the documents of an order.';
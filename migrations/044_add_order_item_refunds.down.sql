@@ -0,0 +1,5 @@
+-- Remove refunded quantities from order lines
+
+ALTER TABLE order_items_archive DROP COLUMN IF EXISTS quantity_refunded;
+ALTER TABLE order_items DROP CONSTRAINT IF EXISTS chk_order_items_quantity_refunded;
+ALTER TABLE order_items DROP COLUMN IF EXISTS quantity_refunded;
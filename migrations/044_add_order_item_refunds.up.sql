@@ -0,0 +1,9 @@
+-- Add refunded quantities to order lines
+-- Line refunds are limited to the units of a line not refunded yet, so the
+-- quantity refunded so far is kept on the line.
+
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS quantity_refunded INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE order_items ADD CONSTRAINT chk_order_items_quantity_refunded CHECK (quantity_refunded >= 0 AND quantity_refunded <= quantity);
+ALTER TABLE order_items_archive ADD COLUMN IF NOT EXISTS quantity_refunded INTEGER NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN order_items.quantity_refunded IS 'Units of the line refunded so far.';
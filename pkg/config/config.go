@@ -106,11 +106,18 @@ type Config struct {
 	CacheProductTTL   time.Duration `env:"CACHE_PRODUCT_TTL" envDefault:"15m"`
 	CacheInventoryTTL time.Duration `env:"CACHE_INVENTORY_TTL" envDefault:"1m"`
 
+	// Accounting
+	BaseCurrency string `env:"BASE_CURRENCY" envDefault:"USD"` // ISO 4217 code reports are converted into
+
 	// Background jobs
 	WorkerEnabled    bool `env:"WORKER_ENABLED" envDefault:"true"`
 	WorkerCount      int  `env:"WORKER_COUNT" envDefault:"5"`
 	JobRetryAttempts int  `env:"JOB_RETRY_ATTEMPTS" envDefault:"3"`
 
+	// Order archiving
+	OrderArchiveAfterMonths int `env:"ORDER_ARCHIVE_AFTER_MONTHS" envDefault:"24"` // closed orders unchanged this long are archived; 0 disables archiving
+	OrderArchiveBatchSize   int `env:"ORDER_ARCHIVE_BATCH_SIZE" envDefault:"500"`  // orders archived per daily run
+
 	// Structured configuration objects (computed from env vars)
 	// These are not loaded from env directly but computed in Load()
 	RateLimit *RateLimitConfig `json:"-"`
@@ -294,6 +301,17 @@ func (c *Config) validate() error {
 		return fmt.Errorf("SERVER_PORT must be between 1 and 65535")
 	}
 
+	if len(c.BaseCurrency) != 3 || strings.ToUpper(c.BaseCurrency) != c.BaseCurrency {
+		return fmt.Errorf("BASE_CURRENCY must be a 3-letter ISO 4217 code")
+	}
+
+	if c.OrderArchiveAfterMonths < 0 {
+		return fmt.Errorf("ORDER_ARCHIVE_AFTER_MONTHS cannot be negative")
+	}
+	if c.OrderArchiveAfterMonths > 0 && c.OrderArchiveBatchSize <= 0 {
+		return fmt.Errorf("ORDER_ARCHIVE_BATCH_SIZE must be positive")
+	}
+
 	if c.StorageType == "s3" {
 		if c.S3Bucket == "" || c.S3Region == "" || c.S3AccessKey == "" || c.S3SecretKey == "" {
 			return fmt.Errorf("S3 configuration is incomplete when STORAGE_TYPE is 's3'")
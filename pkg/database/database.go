@@ -229,7 +229,7 @@ func (db *Database) Exec(ctx context.Context, query string, args ...interface{})
 	defer span.End()
 
 	start := time.Now()
-	result, err := db.pool.Exec(ctx, query, args...)
+	result, err := db.conn(ctx).Exec(ctx, query, args...)
 	duration := time.Since(start)
 
 	// Enhanced logging with tracing and performance monitoring
@@ -249,7 +249,7 @@ func (db *Database) Query(ctx context.Context, query string, args ...interface{}
 	defer span.End()
 
 	start := time.Now()
-	rows, err := db.pool.Query(ctx, query, args...)
+	rows, err := db.conn(ctx).Query(ctx, query, args...)
 	duration := time.Since(start)
 
 	// Enhanced logging with tracing and performance monitoring
@@ -269,7 +269,7 @@ func (db *Database) QueryRow(ctx context.Context, query string, args ...interfac
 	defer span.End()
 
 	start := time.Now()
-	row := db.pool.QueryRow(ctx, query, args...)
+	row := db.conn(ctx).QueryRow(ctx, query, args...)
 	duration := time.Since(start)
 
 	// Enhanced logging with tracing and performance monitoring
@@ -278,16 +278,39 @@ func (db *Database) QueryRow(ctx context.Context, query string, args ...interfac
 	return row
 }
 
-// Begin begins a transaction
+// Begin begins a transaction, or a savepoint of the transaction the
+// context carries
 func (db *Database) Begin(ctx context.Context) (pgx.Tx, error) {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx.Begin(ctx)
+	}
 	return db.pool.Begin(ctx)
 }
 
-// BeginTx begins a transaction with the given options
+// BeginTx begins a transaction with the given options, or a savepoint of
+// the transaction the context carries
 func (db *Database) BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error) {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx.Begin(ctx)
+	}
 	return db.pool.BeginTx(ctx, txOptions)
 }
 
+// querier is the connection a query runs on: the transaction the context
+// carries, or the pool
+type querier interface {
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+func (db *Database) conn(ctx context.Context) querier {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx
+	}
+	return db.pool
+}
+
 // validate validates the database configuration
 func (c *Config) validate() error {
 	if c.URL == "" {
@@ -0,0 +1,43 @@
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// txContextKey keys the transaction carried by a context
+type txContextKey struct{}
+
+// ContextWithTx returns a context carrying the transaction. Queries made
+// through a Database with the context run in the transaction, and
+// transactions begun with it are nested in it as savepoints.
+func ContextWithTx(ctx context.Context, tx pgx.Tx) context.Context {
+	if tx == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, txContextKey{}, tx)
+}
+
+// TxFromContext returns the transaction carried by the context, if any
+func TxFromContext(ctx context.Context) (pgx.Tx, bool) {
+	tx, ok := ctx.Value(txContextKey{}).(pgx.Tx)
+	return tx, ok && tx != nil
+}
+
+// InTransaction runs fn in a transaction of the manager and passes it a
+// context carrying the transaction, so every repository call made with that
+// context commits or rolls back with it. A context already carrying a
+// transaction joins it instead of starting another. Failed transactions are
+// not retried, as fn usually changes the entities it saves.
+func InTransaction(ctx context.Context, tm TransactionManagerInterface, fn func(ctx context.Context) error) error {
+	if _, ok := TxFromContext(ctx); ok {
+		return fn(ctx)
+	}
+
+	config := DefaultTransactionConfig()
+	config.MaxRetries = 0
+	return tm.WithTransactionOptions(ctx, config, func(tx pgx.Tx) error {
+		return fn(ContextWithTx(ctx, tx))
+	})
+}
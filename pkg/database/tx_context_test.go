@@ -0,0 +1,78 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTx is a transaction the tests only compare by identity
+type fakeTx struct {
+	pgx.Tx
+}
+
+// fakeTxManager runs functions in a fake transaction, recording the config
+type fakeTxManager struct {
+	tx      pgx.Tx
+	calls   int
+	configs []TransactionConfig
+}
+
+func (m *fakeTxManager) WithTransaction(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	return m.WithTransactionOptions(ctx, DefaultTransactionConfig(), fn)
+}
+
+func (m *fakeTxManager) WithRetryTransaction(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	return m.WithTransactionOptions(ctx, DefaultTransactionConfig(), fn)
+}
+
+func (m *fakeTxManager) WithTransactionOptions(ctx context.Context, opts TransactionConfig, fn func(tx pgx.Tx) error) error {
+	m.calls++
+	m.configs = append(m.configs, opts)
+	return fn(m.tx)
+}
+
+func TestContextWithTx(t *testing.T) {
+	ctx := context.Background()
+
+	_, ok := TxFromContext(ctx)
+	assert.False(t, ok)
+	assert.Equal(t, ctx, ContextWithTx(ctx, nil), "a nil transaction is not carried")
+
+	tx := &fakeTx{}
+	carried, ok := TxFromContext(ContextWithTx(ctx, tx))
+	require.True(t, ok)
+	assert.Same(t, tx, carried)
+}
+
+func TestInTransaction(t *testing.T) {
+	tx := &fakeTx{}
+	manager := &fakeTxManager{tx: tx}
+
+	err := InTransaction(context.Background(), manager, func(ctx context.Context) error {
+		carried, ok := TxFromContext(ctx)
+		require.True(t, ok)
+		assert.Same(t, tx, carried)
+
+		// A nested call joins the transaction
+		return InTransaction(ctx, manager, func(nested context.Context) error {
+			joined, ok := TxFromContext(nested)
+			require.True(t, ok)
+			assert.Same(t, tx, joined)
+			return nil
+		})
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, manager.calls)
+	assert.Zero(t, manager.configs[0].MaxRetries, "transactions are not retried")
+
+	failure := errors.New("boom")
+	err = InTransaction(context.Background(), manager, func(ctx context.Context) error {
+		return failure
+	})
+	assert.ErrorIs(t, err, failure)
+}